@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -25,8 +26,8 @@ type NetworkMode int32
 
 const (
 	NetworkMode_NETWORK_MODE_UNSPECIFIED NetworkMode = 0 // Defaults to HOST
-	NetworkMode_NETWORK_MODE_HOST        NetworkMode = 1
-	NetworkMode_NETWORK_MODE_BRIDGE      NetworkMode = 2
+	NetworkMode_NETWORK_MODE_HOST        NetworkMode = 1 // The task shares the network of the client
+	NetworkMode_NETWORK_MODE_BRIDGE      NetworkMode = 2 // The task gets a network namespace, ports are mapped. Requires CNI
 )
 
 // Enum value maps for NetworkMode.
@@ -70,6 +71,315 @@ func (NetworkMode) EnumDescriptor() ([]byte, []int) {
 	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{0}
 }
 
+type DeploymentType int32
+
+const (
+	DeploymentType_DEPLOYMENT_TYPE_UNSPECIFIED DeploymentType = 0 // Defaults to SERVICE
+	DeploymentType_DEPLOYMENT_TYPE_SERVICE     DeploymentType = 1 // Long running instances
+	DeploymentType_DEPLOYMENT_TYPE_FUNCTION    DeploymentType = 2 // Dispatched on every invocation, see function
+	DeploymentType_DEPLOYMENT_TYPE_CRON        DeploymentType = 3 // Run on the schedule of cron
+)
+
+// Enum value maps for DeploymentType.
+var (
+	DeploymentType_name = map[int32]string{
+		0: "DEPLOYMENT_TYPE_UNSPECIFIED",
+		1: "DEPLOYMENT_TYPE_SERVICE",
+		2: "DEPLOYMENT_TYPE_FUNCTION",
+		3: "DEPLOYMENT_TYPE_CRON",
+	}
+	DeploymentType_value = map[string]int32{
+		"DEPLOYMENT_TYPE_UNSPECIFIED": 0,
+		"DEPLOYMENT_TYPE_SERVICE":     1,
+		"DEPLOYMENT_TYPE_FUNCTION":    2,
+		"DEPLOYMENT_TYPE_CRON":        3,
+	}
+)
+
+func (x DeploymentType) Enum() *DeploymentType {
+	p := new(DeploymentType)
+	*p = x
+	return p
+}
+
+func (x DeploymentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeploymentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_controlplane_proto_enumTypes[1].Descriptor()
+}
+
+func (DeploymentType) Type() protoreflect.EnumType {
+	return &file_api_proto_controlplane_proto_enumTypes[1]
+}
+
+func (x DeploymentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeploymentType.Descriptor instead.
+func (DeploymentType) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{1}
+}
+
+type UpdatePolicy int32
+
+const (
+	UpdatePolicy_UPDATE_POLICY_UNSPECIFIED UpdatePolicy = 0 // Defaults to PROPOSE
+	UpdatePolicy_UPDATE_POLICY_PROPOSE     UpdatePolicy = 1 // Record the update, applied through ApplyBlueprintUpdate
+	UpdatePolicy_UPDATE_POLICY_AUTO        UpdatePolicy = 2 // Deploy the update as soon as it is published
+)
+
+// Enum value maps for UpdatePolicy.
+var (
+	UpdatePolicy_name = map[int32]string{
+		0: "UPDATE_POLICY_UNSPECIFIED",
+		1: "UPDATE_POLICY_PROPOSE",
+		2: "UPDATE_POLICY_AUTO",
+	}
+	UpdatePolicy_value = map[string]int32{
+		"UPDATE_POLICY_UNSPECIFIED": 0,
+		"UPDATE_POLICY_PROPOSE":     1,
+		"UPDATE_POLICY_AUTO":        2,
+	}
+)
+
+func (x UpdatePolicy) Enum() *UpdatePolicy {
+	p := new(UpdatePolicy)
+	*p = x
+	return p
+}
+
+func (x UpdatePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UpdatePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_controlplane_proto_enumTypes[2].Descriptor()
+}
+
+func (UpdatePolicy) Type() protoreflect.EnumType {
+	return &file_api_proto_controlplane_proto_enumTypes[2]
+}
+
+func (x UpdatePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UpdatePolicy.Descriptor instead.
+func (UpdatePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{2}
+}
+
+// Certificate Traefik requests for the SSL router
+type CertStrategy int32
+
+const (
+	CertStrategy_CERT_STRATEGY_UNSPECIFIED CertStrategy = 0 // The controller's policy decides, wildcard below its wildcard domains
+	CertStrategy_CERT_STRATEGY_HOST        CertStrategy = 1 // A certificate per host, with cert_sans
+	CertStrategy_CERT_STRATEGY_WILDCARD    CertStrategy = 2 // The wildcard certificate of a wildcard domain of the controller
+)
+
+// Enum value maps for CertStrategy.
+var (
+	CertStrategy_name = map[int32]string{
+		0: "CERT_STRATEGY_UNSPECIFIED",
+		1: "CERT_STRATEGY_HOST",
+		2: "CERT_STRATEGY_WILDCARD",
+	}
+	CertStrategy_value = map[string]int32{
+		"CERT_STRATEGY_UNSPECIFIED": 0,
+		"CERT_STRATEGY_HOST":        1,
+		"CERT_STRATEGY_WILDCARD":    2,
+	}
+)
+
+func (x CertStrategy) Enum() *CertStrategy {
+	p := new(CertStrategy)
+	*p = x
+	return p
+}
+
+func (x CertStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CertStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_controlplane_proto_enumTypes[3].Descriptor()
+}
+
+func (CertStrategy) Type() protoreflect.EnumType {
+	return &file_api_proto_controlplane_proto_enumTypes[3]
+}
+
+func (x CertStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CertStrategy.Descriptor instead.
+func (CertStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{3}
+}
+
+// How a new version of a service replaces the running one
+type DeployStrategy int32
+
+const (
+	DeployStrategy_DEPLOY_STRATEGY_UNSPECIFIED    DeployStrategy = 0 // Defaults to ROLLING
+	DeployStrategy_DEPLOY_STRATEGY_ROLLING        DeployStrategy = 1 // Nomad replaces the allocations of the job, see update
+	DeployStrategy_DEPLOY_STRATEGY_BLUE_GREEN     DeployStrategy = 2 // A green job of the full count next to the running one takes over its traffic
+	DeployStrategy_DEPLOY_STRATEGY_CANARY_TRAFFIC DeployStrategy = 3 // A green job of update.canary instances, 1 when unset, takes a growing share of the traffic
+)
+
+// Enum value maps for DeployStrategy.
+var (
+	DeployStrategy_name = map[int32]string{
+		0: "DEPLOY_STRATEGY_UNSPECIFIED",
+		1: "DEPLOY_STRATEGY_ROLLING",
+		2: "DEPLOY_STRATEGY_BLUE_GREEN",
+		3: "DEPLOY_STRATEGY_CANARY_TRAFFIC",
+	}
+	DeployStrategy_value = map[string]int32{
+		"DEPLOY_STRATEGY_UNSPECIFIED":    0,
+		"DEPLOY_STRATEGY_ROLLING":        1,
+		"DEPLOY_STRATEGY_BLUE_GREEN":     2,
+		"DEPLOY_STRATEGY_CANARY_TRAFFIC": 3,
+	}
+)
+
+func (x DeployStrategy) Enum() *DeployStrategy {
+	p := new(DeployStrategy)
+	*p = x
+	return p
+}
+
+func (x DeployStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeployStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_controlplane_proto_enumTypes[4].Descriptor()
+}
+
+func (DeployStrategy) Type() protoreflect.EnumType {
+	return &file_api_proto_controlplane_proto_enumTypes[4]
+}
+
+func (x DeployStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeployStrategy.Descriptor instead.
+func (DeployStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{4}
+}
+
+// Normalized health for GitOps tools, following the states of Argo CD and Flux
+type ApplicationHealthStatus int32
+
+const (
+	ApplicationHealthStatus_APPLICATION_HEALTH_UNKNOWN     ApplicationHealthStatus = 0
+	ApplicationHealthStatus_APPLICATION_HEALTH_HEALTHY     ApplicationHealthStatus = 1
+	ApplicationHealthStatus_APPLICATION_HEALTH_PROGRESSING ApplicationHealthStatus = 2
+	ApplicationHealthStatus_APPLICATION_HEALTH_DEGRADED    ApplicationHealthStatus = 3
+	ApplicationHealthStatus_APPLICATION_HEALTH_SUSPENDED   ApplicationHealthStatus = 4
+)
+
+// Enum value maps for ApplicationHealthStatus.
+var (
+	ApplicationHealthStatus_name = map[int32]string{
+		0: "APPLICATION_HEALTH_UNKNOWN",
+		1: "APPLICATION_HEALTH_HEALTHY",
+		2: "APPLICATION_HEALTH_PROGRESSING",
+		3: "APPLICATION_HEALTH_DEGRADED",
+		4: "APPLICATION_HEALTH_SUSPENDED",
+	}
+	ApplicationHealthStatus_value = map[string]int32{
+		"APPLICATION_HEALTH_UNKNOWN":     0,
+		"APPLICATION_HEALTH_HEALTHY":     1,
+		"APPLICATION_HEALTH_PROGRESSING": 2,
+		"APPLICATION_HEALTH_DEGRADED":    3,
+		"APPLICATION_HEALTH_SUSPENDED":   4,
+	}
+)
+
+func (x ApplicationHealthStatus) Enum() *ApplicationHealthStatus {
+	p := new(ApplicationHealthStatus)
+	*p = x
+	return p
+}
+
+func (x ApplicationHealthStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ApplicationHealthStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_controlplane_proto_enumTypes[5].Descriptor()
+}
+
+func (ApplicationHealthStatus) Type() protoreflect.EnumType {
+	return &file_api_proto_controlplane_proto_enumTypes[5]
+}
+
+func (x ApplicationHealthStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ApplicationHealthStatus.Descriptor instead.
+func (ApplicationHealthStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{5}
+}
+
+type ArtifactKind int32
+
+const (
+	ArtifactKind_ARTIFACT_KIND_UNSPECIFIED ArtifactKind = 0
+	ArtifactKind_ARTIFACT_KIND_SBOM        ArtifactKind = 1
+	ArtifactKind_ARTIFACT_KIND_PROVENANCE  ArtifactKind = 2
+)
+
+// Enum value maps for ArtifactKind.
+var (
+	ArtifactKind_name = map[int32]string{
+		0: "ARTIFACT_KIND_UNSPECIFIED",
+		1: "ARTIFACT_KIND_SBOM",
+		2: "ARTIFACT_KIND_PROVENANCE",
+	}
+	ArtifactKind_value = map[string]int32{
+		"ARTIFACT_KIND_UNSPECIFIED": 0,
+		"ARTIFACT_KIND_SBOM":        1,
+		"ARTIFACT_KIND_PROVENANCE":  2,
+	}
+)
+
+func (x ArtifactKind) Enum() *ArtifactKind {
+	p := new(ArtifactKind)
+	*p = x
+	return p
+}
+
+func (x ArtifactKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArtifactKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_controlplane_proto_enumTypes[6].Descriptor()
+}
+
+func (ArtifactKind) Type() protoreflect.EnumType {
+	return &file_api_proto_controlplane_proto_enumTypes[6]
+}
+
+func (x ArtifactKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ArtifactKind.Descriptor instead.
+func (ArtifactKind) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{6}
+}
+
 type HealthStatus int32
 
 const (
@@ -106,11 +416,11 @@ func (x HealthStatus) String() string {
 }
 
 func (HealthStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_controlplane_proto_enumTypes[1].Descriptor()
+	return file_api_proto_controlplane_proto_enumTypes[7].Descriptor()
 }
 
 func (HealthStatus) Type() protoreflect.EnumType {
-	return &file_api_proto_controlplane_proto_enumTypes[1]
+	return &file_api_proto_controlplane_proto_enumTypes[7]
 }
 
 func (x HealthStatus) Number() protoreflect.EnumNumber {
@@ -119,29 +429,102 @@ func (x HealthStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use HealthStatus.Descriptor instead.
 func (HealthStatus) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{7}
+}
+
+// A port of the task. The first http port is the application's service Traefik routes to
+// and health checks reach, the others are registered as services of their own.
+type PortSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`                                       // e.g. http or metrics, the task reads its port from NOMAD_PORT_<label>
+	ContainerPort int32                  `protobuf:"varint,2,opt,name=container_port,json=containerPort,proto3" json:"container_port,omitempty"` // The port the container listens on in bridge mode, 0 is the allocated port
+	HostPort      int32                  `protobuf:"varint,3,opt,name=host_port,json=hostPort,proto3" json:"host_port,omitempty"`                // Static port of the node, 0 allocates a dynamic one
+	Protocol      string                 `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`                                 // http (default), tcp or udp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortSpec) Reset() {
+	*x = PortSpec{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortSpec) ProtoMessage() {}
+
+func (x *PortSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortSpec.ProtoReflect.Descriptor instead.
+func (*PortSpec) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PortSpec) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *PortSpec) GetContainerPort() int32 {
+	if x != nil {
+		return x.ContainerPort
+	}
+	return 0
+}
+
+func (x *PortSpec) GetHostPort() int32 {
+	if x != nil {
+		return x.HostPort
+	}
+	return 0
+}
+
+func (x *PortSpec) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
 }
 
+// Routes the traffic of a host to the application through Traefik
 type TraefikConfig struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
-	Enable              bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
-	Host                string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
-	Entrypoint          string                 `protobuf:"bytes,3,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`
-	EnableSsl           bool                   `protobuf:"varint,4,opt,name=enable_ssl,json=enableSsl,proto3" json:"enable_ssl,omitempty"`
-	SslHost             string                 `protobuf:"bytes,5,opt,name=ssl_host,json=sslHost,proto3" json:"ssl_host,omitempty"`
-	CertResolver        string                 `protobuf:"bytes,6,opt,name=cert_resolver,json=certResolver,proto3" json:"cert_resolver,omitempty"`
-	HealthCheckPath     string                 `protobuf:"bytes,7,opt,name=health_check_path,json=healthCheckPath,proto3" json:"health_check_path,omitempty"`
-	HealthCheckInterval string                 `protobuf:"bytes,8,opt,name=health_check_interval,json=healthCheckInterval,proto3" json:"health_check_interval,omitempty"`
-	PathPrefix          string                 `protobuf:"bytes,9,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"`
-	Middlewares         []string               `protobuf:"bytes,10,rep,name=middlewares,proto3" json:"middlewares,omitempty"`
-	CustomLabels        map[string]string      `protobuf:"bytes,11,rep,name=custom_labels,json=customLabels,proto3" json:"custom_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Enable              bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                                                                                                           // Register the application with Traefik
+	Host                string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`                                                                                                                // Hostname routed to the application
+	Entrypoint          string                 `protobuf:"bytes,3,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`                                                                                                    // Entrypoint of the HTTP router, defaults to web
+	EnableSsl           bool                   `protobuf:"varint,4,opt,name=enable_ssl,json=enableSsl,proto3" json:"enable_ssl,omitempty"`                                                                                    // Route HTTPS on the websecure entrypoint too, requires host
+	SslHost             string                 `protobuf:"bytes,5,opt,name=ssl_host,json=sslHost,proto3" json:"ssl_host,omitempty"`                                                                                           // Hostname of the HTTPS router, defaults to host
+	CertResolver        string                 `protobuf:"bytes,6,opt,name=cert_resolver,json=certResolver,proto3" json:"cert_resolver,omitempty"`                                                                            // Traefik certificate resolver, e.g. letsencrypt
+	HealthCheckPath     string                 `protobuf:"bytes,7,opt,name=health_check_path,json=healthCheckPath,proto3" json:"health_check_path,omitempty"`                                                                 // Path Traefik checks the instances on
+	HealthCheckInterval string                 `protobuf:"bytes,8,opt,name=health_check_interval,json=healthCheckInterval,proto3" json:"health_check_interval,omitempty"`                                                     // Duration, e.g. 10s. Defaults to 30s
+	PathPrefix          string                 `protobuf:"bytes,9,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"`                                                                                  // Only route the requests below the prefix
+	Middlewares         []string               `protobuf:"bytes,10,rep,name=middlewares,proto3" json:"middlewares,omitempty"`                                                                                                 // Traefik middlewares of the routers, e.g. auth@file
+	CustomLabels        map[string]string      `protobuf:"bytes,11,rep,name=custom_labels,json=customLabels,proto3" json:"custom_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Further Traefik tags, e.g. traefik.http.routers.web.priority, not router rules or TLS domains
+	CertStrategy        CertStrategy           `protobuf:"varint,12,opt,name=cert_strategy,json=certStrategy,proto3,enum=controlplane.CertStrategy" json:"cert_strategy,omitempty"`                                           // Certificate of the HTTPS router
+	CertSans            []string               `protobuf:"bytes,13,rep,name=cert_sans,json=certSans,proto3" json:"cert_sans,omitempty"`                                                                                       // Extra hosts of a per-host certificate
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
 
 func (x *TraefikConfig) Reset() {
 	*x = TraefikConfig{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[0]
+	mi := &file_api_proto_controlplane_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -153,7 +536,7 @@ func (x *TraefikConfig) String() string {
 func (*TraefikConfig) ProtoMessage() {}
 
 func (x *TraefikConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[0]
+	mi := &file_api_proto_controlplane_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -166,7 +549,7 @@ func (x *TraefikConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TraefikConfig.ProtoReflect.Descriptor instead.
 func (*TraefikConfig) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{0}
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *TraefikConfig) GetEnable() bool {
@@ -246,36 +629,45 @@ func (x *TraefikConfig) GetCustomLabels() map[string]string {
 	return nil
 }
 
-type DeployRequest struct {
+func (x *TraefikConfig) GetCertStrategy() CertStrategy {
+	if x != nil {
+		return x.CertStrategy
+	}
+	return CertStrategy_CERT_STRATEGY_UNSPECIFIED
+}
+
+func (x *TraefikConfig) GetCertSans() []string {
+	if x != nil {
+		return x.CertSans
+	}
+	return nil
+}
+
+// Restricts the clients the application is placed on
+type Constraint struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Image         string                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Replicas      int32                  `protobuf:"varint,3,opt,name=replicas,proto3" json:"replicas,omitempty"`
-	Cpu           float64                `protobuf:"fixed64,4,opt,name=cpu,proto3" json:"cpu,omitempty"`
-	Memory        int64                  `protobuf:"varint,5,opt,name=memory,proto3" json:"memory,omitempty"`
-	Region        string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
-	Labels        map[string]string      `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Traefik       *TraefikConfig         `protobuf:"bytes,8,opt,name=traefik,proto3" json:"traefik,omitempty"`
-	NetworkMode   NetworkMode            `protobuf:"varint,9,opt,name=network_mode,json=networkMode,proto3,enum=controlplane.NetworkMode" json:"network_mode,omitempty"`
+	Attribute     string                 `protobuf:"bytes,1,opt,name=attribute,proto3" json:"attribute,omitempty"` // e.g. ${attr.kernel.name}, ${meta.storage} or the shorthand meta.storage
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`   // Defaults to "="
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`         // Compared with the attribute by the operator
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeployRequest) Reset() {
-	*x = DeployRequest{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[1]
+func (x *Constraint) Reset() {
+	*x = Constraint{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeployRequest) String() string {
+func (x *Constraint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeployRequest) ProtoMessage() {}
+func (*Constraint) ProtoMessage() {}
 
-func (x *DeployRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[1]
+func (x *Constraint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -286,98 +678,14041 @@ func (x *DeployRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeployRequest.ProtoReflect.Descriptor instead.
-func (*DeployRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use Constraint.ProtoReflect.Descriptor instead.
+func (*Constraint) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *DeployRequest) GetName() string {
+func (x *Constraint) GetAttribute() string {
 	if x != nil {
-		return x.Name
+		return x.Attribute
 	}
 	return ""
 }
 
-func (x *DeployRequest) GetImage() string {
+func (x *Constraint) GetOperator() string {
 	if x != nil {
-		return x.Image
+		return x.Operator
 	}
 	return ""
 }
 
-func (x *DeployRequest) GetReplicas() int32 {
+func (x *Constraint) GetValue() string {
 	if x != nil {
-		return x.Replicas
+		return x.Value
 	}
-	return 0
+	return ""
 }
 
-func (x *DeployRequest) GetCpu() float64 {
-	if x != nil {
-		return x.Cpu
-	}
-	return 0
+// Scratch space of the task, kept across reschedules when sticky
+type EphemeralDisk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SizeMb        int32                  `protobuf:"varint,1,opt,name=size_mb,json=sizeMb,proto3" json:"size_mb,omitempty"` // Defaults to Nomad's 300MB when unset
+	Migrate       bool                   `protobuf:"varint,2,opt,name=migrate,proto3" json:"migrate,omitempty"`             // Requires sticky
+	Sticky        bool                   `protobuf:"varint,3,opt,name=sticky,proto3" json:"sticky,omitempty"`               // Place a rescheduled instance on the same client, keeping its data
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EphemeralDisk) Reset() {
+	*x = EphemeralDisk{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EphemeralDisk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EphemeralDisk) ProtoMessage() {}
+
+func (x *EphemeralDisk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EphemeralDisk.ProtoReflect.Descriptor instead.
+func (*EphemeralDisk) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EphemeralDisk) GetSizeMb() int32 {
+	if x != nil {
+		return x.SizeMb
+	}
+	return 0
+}
+
+func (x *EphemeralDisk) GetMigrate() bool {
+	if x != nil {
+		return x.Migrate
+	}
+	return false
+}
+
+func (x *EphemeralDisk) GetSticky() bool {
+	if x != nil {
+		return x.Sticky
+	}
+	return false
+}
+
+// A CSI volume claimed by the application and mounted into its task
+type VolumeMount struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VolumeId       string                 `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`                   // Registered with CreateVolume
+	Destination    string                 `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`                             // Absolute path in the task
+	ReadOnly       bool                   `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`                  // Mount the volume read only
+	AccessMode     string                 `protobuf:"bytes,4,opt,name=access_mode,json=accessMode,proto3" json:"access_mode,omitempty"`             // Defaults to single-node-writer, or single-node-reader-only when read_only
+	AttachmentMode string                 `protobuf:"bytes,5,opt,name=attachment_mode,json=attachmentMode,proto3" json:"attachment_mode,omitempty"` // file-system (default) or block-device
+	PerAlloc       bool                   `protobuf:"varint,6,opt,name=per_alloc,json=perAlloc,proto3" json:"per_alloc,omitempty"`                  // Instance N claims the volume "<volume_id>[N]"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VolumeMount) Reset() {
+	*x = VolumeMount{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeMount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeMount) ProtoMessage() {}
+
+func (x *VolumeMount) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeMount.ProtoReflect.Descriptor instead.
+func (*VolumeMount) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VolumeMount) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *VolumeMount) GetAccessMode() string {
+	if x != nil {
+		return x.AccessMode
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetAttachmentMode() string {
+	if x != nil {
+		return x.AttachmentMode
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetPerAlloc() bool {
+	if x != nil {
+		return x.PerAlloc
+	}
+	return false
+}
+
+// Allows outbound traffic to a Consul service or an address range
+type EgressRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`     // Consul service, reached on its registered port
+	Cidr          string                 `protobuf:"bytes,2,opt,name=cidr,proto3" json:"cidr,omitempty"`           // e.g. 10.0.0.0/8 or 203.0.113.7/32
+	Ports         []int32                `protobuf:"varint,3,rep,packed,name=ports,proto3" json:"ports,omitempty"` // Every port when empty
+	Protocol      string                 `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`   // tcp (default) or udp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EgressRule) Reset() {
+	*x = EgressRule{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EgressRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EgressRule) ProtoMessage() {}
+
+func (x *EgressRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EgressRule.ProtoReflect.Descriptor instead.
+func (*EgressRule) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EgressRule) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *EgressRule) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *EgressRule) GetPorts() []int32 {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *EgressRule) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+// Restricts what the processes of the task may do
+type SecurityContext struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NoNewPrivileges  bool                   `protobuf:"varint,1,opt,name=no_new_privileges,json=noNewPrivileges,proto3" json:"no_new_privileges,omitempty"` // Processes cannot gain privileges, e.g. through setuid
+	SeccompProfile   string                 `protobuf:"bytes,2,opt,name=seccomp_profile,json=seccompProfile,proto3" json:"seccomp_profile,omitempty"`       // default, unconfined or a profile in the clients' /etc/nomad/seccomp
+	ApparmorProfile  string                 `protobuf:"bytes,3,opt,name=apparmor_profile,json=apparmorProfile,proto3" json:"apparmor_profile,omitempty"`    // Must be loaded on the clients
+	DropCapabilities []string               `protobuf:"bytes,4,rep,name=drop_capabilities,json=dropCapabilities,proto3" json:"drop_capabilities,omitempty"` // e.g. NET_RAW or ALL
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SecurityContext) Reset() {
+	*x = SecurityContext{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecurityContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityContext) ProtoMessage() {}
+
+func (x *SecurityContext) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityContext.ProtoReflect.Descriptor instead.
+func (*SecurityContext) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SecurityContext) GetNoNewPrivileges() bool {
+	if x != nil {
+		return x.NoNewPrivileges
+	}
+	return false
+}
+
+func (x *SecurityContext) GetSeccompProfile() string {
+	if x != nil {
+		return x.SeccompProfile
+	}
+	return ""
+}
+
+func (x *SecurityContext) GetApparmorProfile() string {
+	if x != nil {
+		return x.ApparmorProfile
+	}
+	return ""
+}
+
+func (x *SecurityContext) GetDropCapabilities() []string {
+	if x != nil {
+		return x.DropCapabilities
+	}
+	return nil
+}
+
+// Where the placement engine may run an application whose request names no region
+type Placement struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RegionSelector map[string]string      `protobuf:"bytes,1,rep,name=region_selector,json=regionSelector,proto3" json:"region_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Labels the region must have, e.g. provider=aws
+	LatencyHint    string                 `protobuf:"bytes,2,opt,name=latency_hint,json=latencyHint,proto3" json:"latency_hint,omitempty"`                                                                                    // Where the users are, e.g. eu, scored by the latency of the regions to it
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Placement) Reset() {
+	*x = Placement{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Placement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Placement) ProtoMessage() {}
+
+func (x *Placement) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Placement.ProtoReflect.Descriptor instead.
+func (*Placement) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Placement) GetRegionSelector() map[string]string {
+	if x != nil {
+		return x.RegionSelector
+	}
+	return nil
+}
+
+func (x *Placement) GetLatencyHint() string {
+	if x != nil {
+		return x.LatencyHint
+	}
+	return ""
+}
+
+// Deploys the application to every target region, the first one serves status, logs and
+// scaling, and manages the DNS records routing users to the regions
+type GeoRouting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hostname      string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`  // Name of the records, e.g. shop.example.com
+	Targets       []*GeoTarget           `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`    // Regions deployed to, the first is the primary
+	Failover      bool                   `protobuf:"varint,3,opt,name=failover,proto3" json:"failover,omitempty"` // Withdraw the records of a region while the application is unhealthy there
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoRouting) Reset() {
+	*x = GeoRouting{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoRouting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoRouting) ProtoMessage() {}
+
+func (x *GeoRouting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoRouting.ProtoReflect.Descriptor instead.
+func (*GeoRouting) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GeoRouting) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *GeoRouting) GetTargets() []*GeoTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *GeoRouting) GetFailover() bool {
+	if x != nil {
+		return x.Failover
+	}
+	return false
+}
+
+// A region of geo routing, routing is geographic when the targets have locations and
+// weighted otherwise
+type GeoTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Region        string                 `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`       // Nomad region deployed to
+	Weight        int64                  `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`      // Share of the traffic of weighted routing (default: 1)
+	Locations     []string               `protobuf:"bytes,3,rep,name=locations,proto3" json:"locations,omitempty"` // Continent codes, e.g. EU, or country codes, e.g. DE
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoTarget) Reset() {
+	*x = GeoTarget{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoTarget) ProtoMessage() {}
+
+func (x *GeoTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoTarget.ProtoReflect.Descriptor instead.
+func (*GeoTarget) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GeoTarget) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GeoTarget) GetWeight() int64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *GeoTarget) GetLocations() []string {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+// The update block of the job, how Nomad replaces the allocations of a new version
+type UpdateStrategy struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	MaxParallel            int32                  `protobuf:"varint,1,opt,name=max_parallel,json=maxParallel,proto3" json:"max_parallel,omitempty"`                                    // Allocations replaced at a time, 0 keeps Nomad's default of 1
+	Canary                 int32                  `protobuf:"varint,2,opt,name=canary,proto3" json:"canary,omitempty"`                                                                 // Allocations of the new version placed next to the old ones first
+	AutoRevert             bool                   `protobuf:"varint,3,opt,name=auto_revert,json=autoRevert,proto3" json:"auto_revert,omitempty"`                                       // Revert to the last stable version when the rollout fails
+	AutoPromote            bool                   `protobuf:"varint,4,opt,name=auto_promote,json=autoPromote,proto3" json:"auto_promote,omitempty"`                                    // Promote the canaries once all of them are healthy, requires canaries
+	MinHealthyTimeSeconds  int32                  `protobuf:"varint,5,opt,name=min_healthy_time_seconds,json=minHealthyTimeSeconds,proto3" json:"min_healthy_time_seconds,omitempty"`  // An allocation must be healthy this long to count as healthy, 0 keeps Nomad's default of 10
+	HealthyDeadlineSeconds int32                  `protobuf:"varint,6,opt,name=healthy_deadline_seconds,json=healthyDeadlineSeconds,proto3" json:"healthy_deadline_seconds,omitempty"` // An allocation not healthy after this long is unhealthy, 0 keeps Nomad's default of 300
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UpdateStrategy) Reset() {
+	*x = UpdateStrategy{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStrategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStrategy) ProtoMessage() {}
+
+func (x *UpdateStrategy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStrategy.ProtoReflect.Descriptor instead.
+func (*UpdateStrategy) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateStrategy) GetMaxParallel() int32 {
+	if x != nil {
+		return x.MaxParallel
+	}
+	return 0
+}
+
+func (x *UpdateStrategy) GetCanary() int32 {
+	if x != nil {
+		return x.Canary
+	}
+	return 0
+}
+
+func (x *UpdateStrategy) GetAutoRevert() bool {
+	if x != nil {
+		return x.AutoRevert
+	}
+	return false
+}
+
+func (x *UpdateStrategy) GetAutoPromote() bool {
+	if x != nil {
+		return x.AutoPromote
+	}
+	return false
+}
+
+func (x *UpdateStrategy) GetMinHealthyTimeSeconds() int32 {
+	if x != nil {
+		return x.MinHealthyTimeSeconds
+	}
+	return 0
+}
+
+func (x *UpdateStrategy) GetHealthyDeadlineSeconds() int32 {
+	if x != nil {
+		return x.HealthyDeadlineSeconds
+	}
+	return 0
+}
+
+// Shifts the traffic of the application's host from the running job to the green job in
+// steps, once the green job is healthy. The green job is promoted after the last step.
+type TrafficShift struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Weights             []int32                `protobuf:"varint,1,rep,packed,name=weights,proto3" json:"weights,omitempty"`                                               // Percent of the traffic routed to the green job per step, increasing; 100 for blue-green and 10, 25, 50 for canary-traffic when empty
+	StepIntervalSeconds int32                  `protobuf:"varint,2,opt,name=step_interval_seconds,json=stepIntervalSeconds,proto3" json:"step_interval_seconds,omitempty"` // How long a step holds before the next one, 60 when 0
+	ManualPromotion     bool                   `protobuf:"varint,3,opt,name=manual_promotion,json=manualPromotion,proto3" json:"manual_promotion,omitempty"`               // Wait for PromoteDeployment after the last step instead of promoting
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TrafficShift) Reset() {
+	*x = TrafficShift{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficShift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficShift) ProtoMessage() {}
+
+func (x *TrafficShift) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficShift.ProtoReflect.Descriptor instead.
+func (*TrafficShift) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TrafficShift) GetWeights() []int32 {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *TrafficShift) GetStepIntervalSeconds() int32 {
+	if x != nil {
+		return x.StepIntervalSeconds
+	}
+	return 0
+}
+
+func (x *TrafficShift) GetManualPromotion() bool {
+	if x != nil {
+		return x.ManualPromotion
+	}
+	return false
+}
+
+// Scales a service to the count its metrics need, the highest count any metric asks for wins
+type Autoscaling struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MinReplicas     int32                  `protobuf:"varint,1,opt,name=min_replicas,json=minReplicas,proto3" json:"min_replicas,omitempty"`             // At least 1
+	MaxReplicas     int32                  `protobuf:"varint,2,opt,name=max_replicas,json=maxReplicas,proto3" json:"max_replicas,omitempty"`             // At least min_replicas
+	Metrics         []*ScalingMetric       `protobuf:"bytes,3,rep,name=metrics,proto3" json:"metrics,omitempty"`                                         // Metrics scaled on, at least one
+	CooldownSeconds int32                  `protobuf:"varint,4,opt,name=cooldown_seconds,json=cooldownSeconds,proto3" json:"cooldown_seconds,omitempty"` // Between two scalings of the application, 0 keeps the default of 300
+	// Between a scaling and scaling in, so the lull between two bursts keeps the instances.
+	// 0 is the cooldown_seconds.
+	ScaleDownCooldownSeconds int32              `protobuf:"varint,5,opt,name=scale_down_cooldown_seconds,json=scaleDownCooldownSeconds,proto3" json:"scale_down_cooldown_seconds,omitempty"`
+	Prediction               *ScalingPrediction `protobuf:"bytes,6,opt,name=prediction,proto3" json:"prediction,omitempty"` // Scales ahead of the seasonal peaks of the metrics
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Autoscaling) Reset() {
+	*x = Autoscaling{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Autoscaling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Autoscaling) ProtoMessage() {}
+
+func (x *Autoscaling) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Autoscaling.ProtoReflect.Descriptor instead.
+func (*Autoscaling) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Autoscaling) GetMinReplicas() int32 {
+	if x != nil {
+		return x.MinReplicas
+	}
+	return 0
+}
+
+func (x *Autoscaling) GetMaxReplicas() int32 {
+	if x != nil {
+		return x.MaxReplicas
+	}
+	return 0
+}
+
+func (x *Autoscaling) GetMetrics() []*ScalingMetric {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *Autoscaling) GetCooldownSeconds() int32 {
+	if x != nil {
+		return x.CooldownSeconds
+	}
+	return 0
+}
+
+func (x *Autoscaling) GetScaleDownCooldownSeconds() int32 {
+	if x != nil {
+		return x.ScaleDownCooldownSeconds
+	}
+	return 0
+}
+
+func (x *Autoscaling) GetPrediction() *ScalingPrediction {
+	if x != nil {
+		return x.Prediction
+	}
+	return nil
+}
+
+// Pre-scales the service ahead of the recurring peaks of its sampled CPU usage, requires
+// usage sampling and a cpu metric whose target sizes the instances
+type ScalingPrediction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Season        string                 `protobuf:"bytes,1,opt,name=season,proto3" json:"season,omitempty"`                                      // daily or weekly, the period the usage repeats with
+	MinConfidence float64                `protobuf:"fixed64,2,opt,name=min_confidence,json=minConfidence,proto3" json:"min_confidence,omitempty"` // Less certain predictions are only logged, 0 keeps the default of 0.8
+	LeadSeconds   int32                  `protobuf:"varint,3,opt,name=lead_seconds,json=leadSeconds,proto3" json:"lead_seconds,omitempty"`        // How far ahead of a peak to scale out, 0 keeps the default of 900
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScalingPrediction) Reset() {
+	*x = ScalingPrediction{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScalingPrediction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScalingPrediction) ProtoMessage() {}
+
+func (x *ScalingPrediction) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScalingPrediction.ProtoReflect.Descriptor instead.
+func (*ScalingPrediction) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ScalingPrediction) GetSeason() string {
+	if x != nil {
+		return x.Season
+	}
+	return ""
+}
+
+func (x *ScalingPrediction) GetMinConfidence() float64 {
+	if x != nil {
+		return x.MinConfidence
+	}
+	return 0
+}
+
+func (x *ScalingPrediction) GetLeadSeconds() int32 {
+	if x != nil {
+		return x.LeadSeconds
+	}
+	return 0
+}
+
+// A metric and the value of it one instance handles
+type ScalingMetric struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// cpu or memory from Nomad, rps from Traefik, or the name of a -metric-providers provider
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Query    string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"` // The provider's query, {app} is replaced by the job ID
+	// Percent of the reserved cpu or memory an instance should use on average, or the value of
+	// an external metric one instance handles, e.g. 50 requests per second or 100 queued messages
+	Target float64 `protobuf:"fixed64,3,opt,name=target,proto3" json:"target,omitempty"`
+	// Averages the metric over about this long, a burst shorter than it scales out only partly
+	SmoothingSeconds int32 `protobuf:"varint,4,opt,name=smoothing_seconds,json=smoothingSeconds,proto3" json:"smoothing_seconds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ScalingMetric) Reset() {
+	*x = ScalingMetric{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScalingMetric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScalingMetric) ProtoMessage() {}
+
+func (x *ScalingMetric) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScalingMetric.ProtoReflect.Descriptor instead.
+func (*ScalingMetric) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ScalingMetric) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ScalingMetric) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ScalingMetric) GetTarget() float64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *ScalingMetric) GetSmoothingSeconds() int32 {
+	if x != nil {
+		return x.SmoothingSeconds
+	}
+	return 0
+}
+
+// Restricts the outbound traffic of the application to its rules
+type EgressConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*EgressRule          `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"` // Traffic not allowed by a rule is dropped
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EgressConfig) Reset() {
+	*x = EgressConfig{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EgressConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EgressConfig) ProtoMessage() {}
+
+func (x *EgressConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EgressConfig.ProtoReflect.Descriptor instead.
+func (*EgressConfig) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EgressConfig) GetRules() []*EgressRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// Backs up the volumes of the application to S3 compatible storage
+type BackupConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Destination   string                 `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`                                                           // s3://bucket/prefix
+	Schedule      string                 `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`                                                                 // Cron expression, backups only run on request when empty
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`                                                 // Of the schedule, defaults to UTC
+	Image         string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`                                                                       // Needs a shell, tar and rclone. Defaults to rclone/rclone
+	Env           map[string]string      `protobuf:"bytes,5,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // e.g. RCLONE_S3_ENDPOINT for S3 compatible storage
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupConfig) Reset() {
+	*x = BackupConfig{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupConfig) ProtoMessage() {}
+
+func (x *BackupConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupConfig.ProtoReflect.Descriptor instead.
+func (*BackupConfig) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BackupConfig) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *BackupConfig) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *BackupConfig) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *BackupConfig) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *BackupConfig) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+// A managed dependency deployed alongside the application, its connection details reach the
+// application as <NAME>_HOST, <NAME>_PORT, <NAME>_PASSWORD and <NAME>_URL
+type AddOn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                         // e.g. "db", runs as the job <application>-<name>
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                         // postgres or redis
+	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`                   // Image tag, defaults to the latest supported major version
+	Memory        int64                  `protobuf:"varint,4,opt,name=memory,proto3" json:"memory,omitempty"`                    // MB, defaults to 256 for postgres and 128 for redis
+	VolumeId      string                 `protobuf:"bytes,5,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"` // Persists the data, without a volume it is lost when the add-on is rescheduled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddOn) Reset() {
+	*x = AddOn{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddOn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddOn) ProtoMessage() {}
+
+func (x *AddOn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddOn.ProtoReflect.Descriptor instead.
+func (*AddOn) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AddOn) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddOn) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AddOn) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AddOn) GetMemory() int64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *AddOn) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+// Settings of FUNCTION deployments, dispatched as a Nomad job per invocation
+type FunctionConfig struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MaxConcurrency int32                  `protobuf:"varint,1,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"` // Concurrent invocations, further ones queue. Defaults to 10
+	TimeoutSeconds int32                  `protobuf:"varint,2,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"` // Invocations running longer are stopped. Defaults to 60
+	Artifact       string                 `protobuf:"bytes,3,opt,name=artifact,proto3" json:"artifact,omitempty"`                                    // Optional code artifact (go-getter source) unpacked into local/
+	MetaKeys       []string               `protobuf:"bytes,4,rep,name=meta_keys,json=metaKeys,proto3" json:"meta_keys,omitempty"`                    // Meta keys invocations may pass
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FunctionConfig) Reset() {
+	*x = FunctionConfig{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionConfig) ProtoMessage() {}
+
+func (x *FunctionConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionConfig.ProtoReflect.Descriptor instead.
+func (*FunctionConfig) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FunctionConfig) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *FunctionConfig) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *FunctionConfig) GetArtifact() string {
+	if x != nil {
+		return x.Artifact
+	}
+	return ""
+}
+
+func (x *FunctionConfig) GetMetaKeys() []string {
+	if x != nil {
+		return x.MetaKeys
+	}
+	return nil
+}
+
+// Settings of CRON deployments, run as a periodic Nomad job
+type CronConfig struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Schedule        string                 `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`                                       // Cron expression, e.g. "0 3 * * *"
+	TimeZone        string                 `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`                       // Defaults to UTC
+	ProhibitOverlap bool                   `protobuf:"varint,3,opt,name=prohibit_overlap,json=prohibitOverlap,proto3" json:"prohibit_overlap,omitempty"` // Skip a run while the previous one is still running
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CronConfig) Reset() {
+	*x = CronConfig{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronConfig) ProtoMessage() {}
+
+func (x *CronConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronConfig.ProtoReflect.Descriptor instead.
+func (*CronConfig) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CronConfig) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *CronConfig) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *CronConfig) GetProhibitOverlap() bool {
+	if x != nil {
+		return x.ProhibitOverlap
+	}
+	return false
+}
+
+// The spec of an application, as deployed by DeployApplication and kept in its revisions
+type DeployRequest struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Name                   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                                          // Unique application name of letters, digits, '-' and '_', required
+	Image                  string                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`                                                                                        // Docker image, e.g. nginx:latest, required
+	Replicas               int32                  `protobuf:"varint,3,opt,name=replicas,proto3" json:"replicas,omitempty"`                                                                                 // Number of instances
+	Cpu                    float64                `protobuf:"fixed64,4,opt,name=cpu,proto3" json:"cpu,omitempty"`                                                                                          // CPU cores, at least 0.1
+	Memory                 int64                  `protobuf:"varint,5,opt,name=memory,proto3" json:"memory,omitempty"`                                                                                     // Memory in MB, at least 10
+	Region                 string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`                                                                                      // Target region, chosen by the placement engine when empty
+	Labels                 map[string]string      `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`            // Metadata of the application kept in the job meta, e.g. team, not its environment
+	Traefik                *TraefikConfig         `protobuf:"bytes,8,opt,name=traefik,proto3" json:"traefik,omitempty"`                                                                                    // Reverse proxy configuration
+	NetworkMode            NetworkMode            `protobuf:"varint,9,opt,name=network_mode,json=networkMode,proto3,enum=controlplane.NetworkMode" json:"network_mode,omitempty"`                          // Networking of the task
+	Constraints            []*Constraint          `protobuf:"bytes,10,rep,name=constraints,proto3" json:"constraints,omitempty"`                                                                           // Clients the instances may run on, all must hold
+	EphemeralDisk          *EphemeralDisk         `protobuf:"bytes,11,opt,name=ephemeral_disk,json=ephemeralDisk,proto3" json:"ephemeral_disk,omitempty"`                                                  // Scratch space of the task
+	IdleTimeoutMinutes     int32                  `protobuf:"varint,12,opt,name=idle_timeout_minutes,json=idleTimeoutMinutes,proto3" json:"idle_timeout_minutes,omitempty"`                                // Scale to zero after this many minutes without traffic, 0 disables
+	Type                   DeploymentType         `protobuf:"varint,13,opt,name=type,proto3,enum=controlplane.DeploymentType" json:"type,omitempty"`                                                       // How the application runs
+	Function               *FunctionConfig        `protobuf:"bytes,14,opt,name=function,proto3" json:"function,omitempty"`                                                                                 // Only used by FUNCTION deployments
+	Cron                   *CronConfig            `protobuf:"bytes,15,opt,name=cron,proto3" json:"cron,omitempty"`                                                                                         // Only used by CRON deployments
+	DependsOn              []string               `protobuf:"bytes,16,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`                                                              // Applications this one consumes, recorded for GetImpact
+	Volumes                []*VolumeMount         `protobuf:"bytes,17,rep,name=volumes,proto3" json:"volumes,omitempty"`                                                                                   // Registered with CreateVolume
+	Backup                 *BackupConfig          `protobuf:"bytes,18,opt,name=backup,proto3" json:"backup,omitempty"`                                                                                     // Requires volumes
+	Addons                 []*AddOn               `protobuf:"bytes,19,rep,name=addons,proto3" json:"addons,omitempty"`                                                                                     // Deployed before and deleted with the application
+	Tenant                 string                 `protobuf:"bytes,20,opt,name=tenant,proto3" json:"tenant,omitempty"`                                                                                     // Owner, Traefik hosts outside its domain template need a verified domain
+	Egress                 *EgressConfig          `protobuf:"bytes,21,opt,name=egress,proto3" json:"egress,omitempty"`                                                                                     // Outbound traffic allowed, enforced as far as the cluster supports
+	Security               *SecurityContext       `protobuf:"bytes,22,opt,name=security,proto3" json:"security,omitempty"`                                                                                 // Unset settings take the defaults of the tenant
+	PinOnDrift             bool                   `protobuf:"varint,23,opt,name=pin_on_drift,json=pinOnDrift,proto3" json:"pin_on_drift,omitempty"`                                                        // Redeploy pinned to the deployed digest when the image's tag moves
+	MemoryMax              int64                  `protobuf:"varint,24,opt,name=memory_max,json=memoryMax,proto3" json:"memory_max,omitempty"`                                                             // Memory in MB the application may burst to, requires memory oversubscription
+	Annotations            map[string]string      `protobuf:"bytes,25,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // e.g. description, runbook or repo, shown in the Nomad UI
+	ConcurrencyGroup       string                 `protobuf:"bytes,26,opt,name=concurrency_group,json=concurrencyGroup,proto3" json:"concurrency_group,omitempty"`                                         // Rollouts of the group run one at a time across applications, e.g. db-migrations
+	RolloutDeadlineSeconds int32                  `protobuf:"varint,27,opt,name=rollout_deadline_seconds,json=rolloutDeadlineSeconds,proto3" json:"rollout_deadline_seconds,omitempty"`                    // Fail a rollout still running after this many seconds, 0 waits forever
+	RevertOnDeadline       bool                   `protobuf:"varint,28,opt,name=revert_on_deadline,json=revertOnDeadline,proto3" json:"revert_on_deadline,omitempty"`                                      // Revert to the last stable version when the rollout deadline passes
+	Update                 *UpdateStrategy        `protobuf:"bytes,29,opt,name=update,proto3" json:"update,omitempty"`                                                                                     // How Nomad rolls out new versions of services
+	Placement              *Placement             `protobuf:"bytes,30,opt,name=placement,proto3" json:"placement,omitempty"`                                                                               // Chooses the region when none is named
+	Geo                    *GeoRouting            `protobuf:"bytes,31,opt,name=geo,proto3" json:"geo,omitempty"`                                                                                           // Deploys to several regions and routes users to them through DNS
+	Actions                []*Action              `protobuf:"bytes,32,rep,name=actions,proto3" json:"actions,omitempty"`                                                                                   // Commands operators run in the application with RunAction, requires Nomad 1.7
+	ConsulKv               *ConsulKV              `protobuf:"bytes,33,opt,name=consul_kv,json=consulKv,proto3" json:"consul_kv,omitempty"`                                                                 // Configuration kept under a Consul KV prefix of the application
+	AllowFrom              []string               `protobuf:"bytes,34,rep,name=allow_from,json=allowFrom,proto3" json:"allow_from,omitempty"`                                                              // Applications allowed to call this one through the Connect mesh, besides its dependents
+	CommitTime             int64                  `protobuf:"varint,35,opt,name=commit_time,json=commitTime,proto3" json:"commit_time,omitempty"`                                                          // Unix seconds of the commit the image was built from, for the lead time of changes
+	Autoscaling            *Autoscaling           `protobuf:"bytes,36,opt,name=autoscaling,proto3" json:"autoscaling,omitempty"`                                                                           // Scales the service between min and max replicas on its metrics, replicas is the initial count
+	Env                    map[string]string      `protobuf:"bytes,37,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                 // Environment variables of the task, may hold environment expressions
+	Ports                  []*PortSpec            `protobuf:"bytes,38,rep,name=ports,proto3" json:"ports,omitempty"`                                                                                       // Ports of the task, http on container port 80 when empty
+	DryRun                 bool                   `protobuf:"varint,39,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                                                                      // Plan the job with the Nomad scheduler and return what would change instead of deploying it
+	Namespace              string                 `protobuf:"bytes,40,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                                               // Namespace the application runs in, default when empty
+	Strategy               DeployStrategy         `protobuf:"varint,41,opt,name=strategy,proto3,enum=controlplane.DeployStrategy" json:"strategy,omitempty"`                                               // Blue-green and canary-traffic need a Traefik host and a running version
+	TrafficShift           *TrafficShift          `protobuf:"bytes,42,opt,name=traffic_shift,json=trafficShift,proto3" json:"traffic_shift,omitempty"`                                                     // Steps of the blue-green and canary-traffic strategies
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DeployRequest) Reset() {
+	*x = DeployRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployRequest) ProtoMessage() {}
+
+func (x *DeployRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeployRequest.ProtoReflect.Descriptor instead.
+func (*DeployRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeployRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeployRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *DeployRequest) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *DeployRequest) GetCpu() float64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
 }
 
 func (x *DeployRequest) GetMemory() int64 {
 	if x != nil {
-		return x.Memory
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *DeployRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *DeployRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetTraefik() *TraefikConfig {
+	if x != nil {
+		return x.Traefik
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetNetworkMode() NetworkMode {
+	if x != nil {
+		return x.NetworkMode
+	}
+	return NetworkMode_NETWORK_MODE_UNSPECIFIED
+}
+
+func (x *DeployRequest) GetConstraints() []*Constraint {
+	if x != nil {
+		return x.Constraints
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetEphemeralDisk() *EphemeralDisk {
+	if x != nil {
+		return x.EphemeralDisk
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetIdleTimeoutMinutes() int32 {
+	if x != nil {
+		return x.IdleTimeoutMinutes
+	}
+	return 0
+}
+
+func (x *DeployRequest) GetType() DeploymentType {
+	if x != nil {
+		return x.Type
+	}
+	return DeploymentType_DEPLOYMENT_TYPE_UNSPECIFIED
+}
+
+func (x *DeployRequest) GetFunction() *FunctionConfig {
+	if x != nil {
+		return x.Function
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetCron() *CronConfig {
+	if x != nil {
+		return x.Cron
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetVolumes() []*VolumeMount {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetBackup() *BackupConfig {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetAddons() []*AddOn {
+	if x != nil {
+		return x.Addons
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *DeployRequest) GetEgress() *EgressConfig {
+	if x != nil {
+		return x.Egress
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetSecurity() *SecurityContext {
+	if x != nil {
+		return x.Security
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetPinOnDrift() bool {
+	if x != nil {
+		return x.PinOnDrift
+	}
+	return false
+}
+
+func (x *DeployRequest) GetMemoryMax() int64 {
+	if x != nil {
+		return x.MemoryMax
+	}
+	return 0
+}
+
+func (x *DeployRequest) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetConcurrencyGroup() string {
+	if x != nil {
+		return x.ConcurrencyGroup
+	}
+	return ""
+}
+
+func (x *DeployRequest) GetRolloutDeadlineSeconds() int32 {
+	if x != nil {
+		return x.RolloutDeadlineSeconds
+	}
+	return 0
+}
+
+func (x *DeployRequest) GetRevertOnDeadline() bool {
+	if x != nil {
+		return x.RevertOnDeadline
+	}
+	return false
+}
+
+func (x *DeployRequest) GetUpdate() *UpdateStrategy {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetPlacement() *Placement {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetGeo() *GeoRouting {
+	if x != nil {
+		return x.Geo
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetActions() []*Action {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetConsulKv() *ConsulKV {
+	if x != nil {
+		return x.ConsulKv
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetAllowFrom() []string {
+	if x != nil {
+		return x.AllowFrom
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetCommitTime() int64 {
+	if x != nil {
+		return x.CommitTime
+	}
+	return 0
+}
+
+func (x *DeployRequest) GetAutoscaling() *Autoscaling {
+	if x != nil {
+		return x.Autoscaling
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetPorts() []*PortSpec {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *DeployRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DeployRequest) GetStrategy() DeployStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return DeployStrategy_DEPLOY_STRATEGY_UNSPECIFIED
+}
+
+func (x *DeployRequest) GetTrafficShift() *TrafficShift {
+	if x != nil {
+		return x.TrafficShift
+	}
+	return nil
+}
+
+// The keys of the application's Consul KV prefix are rendered into the task as KEY=VALUE
+// lines and re-rendered when they change, through the spec or SetApplicationConfig
+type ConsulKV struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        map[string]string      `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Written on every deployment
+	Prune         bool                   `protobuf:"varint,2,opt,name=prune,proto3" json:"prune,omitempty"`                                                                            // Delete the keys not in values on every deployment
+	Destination   string                 `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`                                                                 // File in local/ or secrets/, the keys become environment variables when empty
+	ChangeMode    string                 `protobuf:"bytes,4,opt,name=change_mode,json=changeMode,proto3" json:"change_mode,omitempty"`                                                 // restart (default), signal or noop
+	ChangeSignal  string                 `protobuf:"bytes,5,opt,name=change_signal,json=changeSignal,proto3" json:"change_signal,omitempty"`                                           // Sent with the signal change mode, e.g. SIGHUP
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsulKV) Reset() {
+	*x = ConsulKV{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsulKV) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsulKV) ProtoMessage() {}
+
+func (x *ConsulKV) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsulKV.ProtoReflect.Descriptor instead.
+func (*ConsulKV) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ConsulKV) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ConsulKV) GetPrune() bool {
+	if x != nil {
+		return x.Prune
+	}
+	return false
+}
+
+func (x *ConsulKV) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *ConsulKV) GetChangeMode() string {
+	if x != nil {
+		return x.ChangeMode
+	}
+	return ""
+}
+
+func (x *ConsulKV) GetChangeSignal() string {
+	if x != nil {
+		return x.ChangeSignal
+	}
+	return ""
+}
+
+// A named command of the application, e.g. a cache flush, run without a shell
+type Action struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // Passed to RunAction
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"` // Executable in the task
+	Args          []string               `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`       // Arguments of the command
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Action) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Action) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *Action) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+// Chunks of a serialized DeployRequest too large for a single message
+type SpecChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	TotalSize     int64                  `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"` // Set on the first chunk so oversized specs are rejected early
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpecChunk) Reset() {
+	*x = SpecChunk{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpecChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpecChunk) ProtoMessage() {}
+
+func (x *SpecChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpecChunk.ProtoReflect.Descriptor instead.
+func (*SpecChunk) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SpecChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SpecChunk) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+// Deploys a Nomad job the spec cannot express, it passes the same admission as specs
+type DeployRawJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           string                 `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`             // Nomad job as JSON, bare or wrapped in {"Job": ...}, or HCL
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`       // json or hcl, detected when empty
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`           // Application name, defaults to the job ID
+	Tenant        string                 `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`       // Owner, subject to its quota and image policy
+	Namespace     string                 `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace the job runs in, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeployRawJobRequest) Reset() {
+	*x = DeployRawJobRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployRawJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployRawJobRequest) ProtoMessage() {}
+
+func (x *DeployRawJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeployRawJobRequest.ProtoReflect.Descriptor instead.
+func (*DeployRawJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeployRawJobRequest) GetJob() string {
+	if x != nil {
+		return x.Job
+	}
+	return ""
+}
+
+func (x *DeployRawJobRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *DeployRawJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeployRawJobRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *DeployRawJobRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type DeployResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	JobId         string                 `protobuf:"bytes,4,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // Nomad job of the application, named by the controller's naming strategy
+	Warnings      []*LintWarning         `protobuf:"bytes,5,rep,name=warnings,proto3" json:"warnings,omitempty"`        // Best practices the spec does not follow, they never fail a deployment
+	Plan          *JobPlan               `protobuf:"bytes,6,opt,name=plan,proto3" json:"plan,omitempty"`                // What the deployment would change, set for dry runs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeployResponse) Reset() {
+	*x = DeployResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployResponse) ProtoMessage() {}
+
+func (x *DeployResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeployResponse.ProtoReflect.Descriptor instead.
+func (*DeployResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeployResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *DeployResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeployResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeployResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *DeployResponse) GetWarnings() []*LintWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *DeployResponse) GetPlan() *JobPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+// The scheduler's plan of a job, nothing of it is applied
+type JobPlan struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DiffType         string                 `protobuf:"bytes,1,opt,name=diff_type,json=diffType,proto3" json:"diff_type,omitempty"`                         // Added, Edited, Deleted or None
+	Changes          []*PlanChange          `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`                                           // Fields of the job, its task groups and tasks that would change
+	Groups           []*PlanGroupUpdate     `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`                                             // Allocations the scheduler would place, update or stop
+	FailedPlacements []string               `protobuf:"bytes,4,rep,name=failed_placements,json=failedPlacements,proto3" json:"failed_placements,omitempty"` // Task groups the cluster could not place, with why
+	Warnings         string                 `protobuf:"bytes,5,opt,name=warnings,proto3" json:"warnings,omitempty"`
+	JobModifyIndex   uint64                 `protobuf:"varint,6,opt,name=job_modify_index,json=jobModifyIndex,proto3" json:"job_modify_index,omitempty"` // Index of the job the plan was made against, 0 for a new job
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *JobPlan) Reset() {
+	*x = JobPlan{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobPlan) ProtoMessage() {}
+
+func (x *JobPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobPlan.ProtoReflect.Descriptor instead.
+func (*JobPlan) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *JobPlan) GetDiffType() string {
+	if x != nil {
+		return x.DiffType
+	}
+	return ""
+}
+
+func (x *JobPlan) GetChanges() []*PlanChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *JobPlan) GetGroups() []*PlanGroupUpdate {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *JobPlan) GetFailedPlacements() []string {
+	if x != nil {
+		return x.FailedPlacements
+	}
+	return nil
+}
+
+func (x *JobPlan) GetWarnings() string {
+	if x != nil {
+		return x.Warnings
+	}
+	return ""
+}
+
+func (x *JobPlan) GetJobModifyIndex() uint64 {
+	if x != nil {
+		return x.JobModifyIndex
+	}
+	return 0
+}
+
+type PlanChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // Added, Deleted or Edited
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"` // e.g. Task Group shop-group > Task shop > Config > image
+	Old           string                 `protobuf:"bytes,3,opt,name=old,proto3" json:"old,omitempty"`
+	New           string                 `protobuf:"bytes,4,opt,name=new,proto3" json:"new,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanChange) Reset() {
+	*x = PlanChange{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanChange) ProtoMessage() {}
+
+func (x *PlanChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanChange.ProtoReflect.Descriptor instead.
+func (*PlanChange) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PlanChange) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PlanChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PlanChange) GetOld() string {
+	if x != nil {
+		return x.Old
+	}
+	return ""
+}
+
+func (x *PlanChange) GetNew() string {
+	if x != nil {
+		return x.New
+	}
+	return ""
+}
+
+type PlanGroupUpdate struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TaskGroup         string                 `protobuf:"bytes,1,opt,name=task_group,json=taskGroup,proto3" json:"task_group,omitempty"`
+	Place             uint64                 `protobuf:"varint,2,opt,name=place,proto3" json:"place,omitempty"`
+	Stop              uint64                 `protobuf:"varint,3,opt,name=stop,proto3" json:"stop,omitempty"`
+	InPlaceUpdate     uint64                 `protobuf:"varint,4,opt,name=in_place_update,json=inPlaceUpdate,proto3" json:"in_place_update,omitempty"`
+	DestructiveUpdate uint64                 `protobuf:"varint,5,opt,name=destructive_update,json=destructiveUpdate,proto3" json:"destructive_update,omitempty"`
+	Migrate           uint64                 `protobuf:"varint,6,opt,name=migrate,proto3" json:"migrate,omitempty"`
+	Canary            uint64                 `protobuf:"varint,7,opt,name=canary,proto3" json:"canary,omitempty"`
+	Ignore            uint64                 `protobuf:"varint,8,opt,name=ignore,proto3" json:"ignore,omitempty"`
+	Preemptions       uint64                 `protobuf:"varint,9,opt,name=preemptions,proto3" json:"preemptions,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PlanGroupUpdate) Reset() {
+	*x = PlanGroupUpdate{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanGroupUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanGroupUpdate) ProtoMessage() {}
+
+func (x *PlanGroupUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanGroupUpdate.ProtoReflect.Descriptor instead.
+func (*PlanGroupUpdate) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PlanGroupUpdate) GetTaskGroup() string {
+	if x != nil {
+		return x.TaskGroup
+	}
+	return ""
+}
+
+func (x *PlanGroupUpdate) GetPlace() uint64 {
+	if x != nil {
+		return x.Place
+	}
+	return 0
+}
+
+func (x *PlanGroupUpdate) GetStop() uint64 {
+	if x != nil {
+		return x.Stop
+	}
+	return 0
+}
+
+func (x *PlanGroupUpdate) GetInPlaceUpdate() uint64 {
+	if x != nil {
+		return x.InPlaceUpdate
+	}
+	return 0
+}
+
+func (x *PlanGroupUpdate) GetDestructiveUpdate() uint64 {
+	if x != nil {
+		return x.DestructiveUpdate
+	}
+	return 0
+}
+
+func (x *PlanGroupUpdate) GetMigrate() uint64 {
+	if x != nil {
+		return x.Migrate
+	}
+	return 0
+}
+
+func (x *PlanGroupUpdate) GetCanary() uint64 {
+	if x != nil {
+		return x.Canary
+	}
+	return 0
+}
+
+func (x *PlanGroupUpdate) GetIgnore() uint64 {
+	if x != nil {
+		return x.Ignore
+	}
+	return 0
+}
+
+func (x *PlanGroupUpdate) GetPreemptions() uint64 {
+	if x != nil {
+		return x.Preemptions
+	}
+	return 0
+}
+
+type LintWarning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`   // e.g. latest-tag
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"` // Field of the spec, e.g. image
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LintWarning) Reset() {
+	*x = LintWarning{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintWarning) ProtoMessage() {}
+
+func (x *LintWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintWarning.ProtoReflect.Descriptor instead.
+func (*LintWarning) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LintWarning) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *LintWarning) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *LintWarning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StackApplication struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spec          *DeployRequest         `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	DependsOn     []string               `protobuf:"bytes,2,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"` // Applications of the stack deployed and healthy before this one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StackApplication) Reset() {
+	*x = StackApplication{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StackApplication) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StackApplication) ProtoMessage() {}
+
+func (x *StackApplication) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StackApplication.ProtoReflect.Descriptor instead.
+func (*StackApplication) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StackApplication) GetSpec() *DeployRequest {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *StackApplication) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+type DeployStackRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Applications         []*StackApplication    `protobuf:"bytes,2,rep,name=applications,proto3" json:"applications,omitempty"`
+	ContinueOnError      bool                   `protobuf:"varint,3,opt,name=continue_on_error,json=continueOnError,proto3" json:"continue_on_error,omitempty"`                // Keep deploying later stages when an application fails
+	HealthTimeoutSeconds int32                  `protobuf:"varint,4,opt,name=health_timeout_seconds,json=healthTimeoutSeconds,proto3" json:"health_timeout_seconds,omitempty"` // Per stage, defaults to 300
+	Namespace            string                 `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                      // Namespace of every application of the stack, default when empty
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *DeployStackRequest) Reset() {
+	*x = DeployStackRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployStackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployStackRequest) ProtoMessage() {}
+
+func (x *DeployStackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeployStackRequest.ProtoReflect.Descriptor instead.
+func (*DeployStackRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeployStackRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeployStackRequest) GetApplications() []*StackApplication {
+	if x != nil {
+		return x.Applications
+	}
+	return nil
+}
+
+func (x *DeployStackRequest) GetContinueOnError() bool {
+	if x != nil {
+		return x.ContinueOnError
+	}
+	return false
+}
+
+func (x *DeployStackRequest) GetHealthTimeoutSeconds() int32 {
+	if x != nil {
+		return x.HealthTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *DeployStackRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type StackApplicationResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Stage         int32                  `protobuf:"varint,2,opt,name=stage,proto3" json:"stage,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // HEALTHY, SUBMITTED, FAILED or SKIPPED
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StackApplicationResult) Reset() {
+	*x = StackApplicationResult{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StackApplicationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StackApplicationResult) ProtoMessage() {}
+
+func (x *StackApplicationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StackApplicationResult.ProtoReflect.Descriptor instead.
+func (*StackApplicationResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *StackApplicationResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StackApplicationResult) GetStage() int32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+func (x *StackApplicationResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StackApplicationResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DeployStackResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Name          string                    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                    `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // SUCCEEDED, PARTIAL or FAILED
+	Applications  []*StackApplicationResult `protobuf:"bytes,3,rep,name=applications,proto3" json:"applications,omitempty"`
+	Message       string                    `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeployStackResponse) Reset() {
+	*x = DeployStackResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployStackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployStackResponse) ProtoMessage() {}
+
+func (x *DeployStackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeployStackResponse.ProtoReflect.Descriptor instead.
+func (*DeployStackResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeployStackResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeployStackResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeployStackResponse) GetApplications() []*StackApplicationResult {
+	if x != nil {
+		return x.Applications
+	}
+	return nil
+}
+
+func (x *DeployStackResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PublishBlueprintRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blueprint     string                 `protobuf:"bytes,1,opt,name=blueprint,proto3" json:"blueprint,omitempty"`
+	Channel       string                 `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"` // e.g. stable or beta
+	Spec          *DeployRequest         `protobuf:"bytes,3,opt,name=spec,proto3" json:"spec,omitempty"`       // Base spec, the name comes from the subscribed applications
+	Tenant        string                 `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`   // Owner, the spec is encrypted with its key. Empty for platform blueprints
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishBlueprintRequest) Reset() {
+	*x = PublishBlueprintRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishBlueprintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishBlueprintRequest) ProtoMessage() {}
+
+func (x *PublishBlueprintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishBlueprintRequest.ProtoReflect.Descriptor instead.
+func (*PublishBlueprintRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PublishBlueprintRequest) GetBlueprint() string {
+	if x != nil {
+		return x.Blueprint
+	}
+	return ""
+}
+
+func (x *PublishBlueprintRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *PublishBlueprintRequest) GetSpec() *DeployRequest {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *PublishBlueprintRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type PublishBlueprintResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Success              bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message              string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Version              int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedApplications  []string               `protobuf:"bytes,4,rep,name=updated_applications,json=updatedApplications,proto3" json:"updated_applications,omitempty"`    // Subscribers with the AUTO policy
+	ProposedApplications []string               `protobuf:"bytes,5,rep,name=proposed_applications,json=proposedApplications,proto3" json:"proposed_applications,omitempty"` // Subscribers with the PROPOSE policy
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *PublishBlueprintResponse) Reset() {
+	*x = PublishBlueprintResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishBlueprintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishBlueprintResponse) ProtoMessage() {}
+
+func (x *PublishBlueprintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishBlueprintResponse.ProtoReflect.Descriptor instead.
+func (*PublishBlueprintResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PublishBlueprintResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PublishBlueprintResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PublishBlueprintResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PublishBlueprintResponse) GetUpdatedApplications() []string {
+	if x != nil {
+		return x.UpdatedApplications
+	}
+	return nil
+}
+
+func (x *PublishBlueprintResponse) GetProposedApplications() []string {
+	if x != nil {
+		return x.ProposedApplications
+	}
+	return nil
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Application   string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Blueprint     string                 `protobuf:"bytes,2,opt,name=blueprint,proto3" json:"blueprint,omitempty"`
+	Channel       string                 `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"`
+	Policy        UpdatePolicy           `protobuf:"varint,4,opt,name=policy,proto3,enum=controlplane.UpdatePolicy" json:"policy,omitempty"`
+	Overrides     *DeployRequest         `protobuf:"bytes,5,opt,name=overrides,proto3" json:"overrides,omitempty"`                               // Merged over the blueprint spec
+	PinnedVersion int32                  `protobuf:"varint,6,opt,name=pinned_version,json=pinnedVersion,proto3" json:"pinned_version,omitempty"` // Stay on this version, 0 follows the channel
+	Tenant        string                 `protobuf:"bytes,7,opt,name=tenant,proto3" json:"tenant,omitempty"`                                     // Owner of the application, the overrides are encrypted with its key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SubscribeRequest) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetBlueprint() string {
+	if x != nil {
+		return x.Blueprint
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetPolicy() UpdatePolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return UpdatePolicy_UPDATE_POLICY_UNSPECIFIED
+}
+
+func (x *SubscribeRequest) GetOverrides() *DeployRequest {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetPinnedVersion() int32 {
+	if x != nil {
+		return x.PinnedVersion
+	}
+	return 0
+}
+
+func (x *SubscribeRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type SubscribeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	DeploymentId  string                 `protobuf:"bytes,4,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeResponse) Reset() {
+	*x = SubscribeResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeResponse) ProtoMessage() {}
+
+func (x *SubscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SubscribeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SubscribeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SubscribeResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SubscribeResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type Subscription struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Application     string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Blueprint       string                 `protobuf:"bytes,2,opt,name=blueprint,proto3" json:"blueprint,omitempty"`
+	Channel         string                 `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"`
+	Policy          UpdatePolicy           `protobuf:"varint,4,opt,name=policy,proto3,enum=controlplane.UpdatePolicy" json:"policy,omitempty"`
+	PinnedVersion   int32                  `protobuf:"varint,5,opt,name=pinned_version,json=pinnedVersion,proto3" json:"pinned_version,omitempty"`
+	DeployedVersion int32                  `protobuf:"varint,6,opt,name=deployed_version,json=deployedVersion,proto3" json:"deployed_version,omitempty"`
+	LatestVersion   int32                  `protobuf:"varint,7,opt,name=latest_version,json=latestVersion,proto3" json:"latest_version,omitempty"`
+	Behind          bool                   `protobuf:"varint,8,opt,name=behind,proto3" json:"behind,omitempty"`
+	Tenant          string                 `protobuf:"bytes,9,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Subscription) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *Subscription) GetBlueprint() string {
+	if x != nil {
+		return x.Blueprint
+	}
+	return ""
+}
+
+func (x *Subscription) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Subscription) GetPolicy() UpdatePolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return UpdatePolicy_UPDATE_POLICY_UNSPECIFIED
+}
+
+func (x *Subscription) GetPinnedVersion() int32 {
+	if x != nil {
+		return x.PinnedVersion
+	}
+	return 0
+}
+
+func (x *Subscription) GetDeployedVersion() int32 {
+	if x != nil {
+		return x.DeployedVersion
+	}
+	return 0
+}
+
+func (x *Subscription) GetLatestVersion() int32 {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return 0
+}
+
+func (x *Subscription) GetBehind() bool {
+	if x != nil {
+		return x.Behind
+	}
+	return false
+}
+
+func (x *Subscription) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type ListSubscriptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blueprint     string                 `protobuf:"bytes,1,opt,name=blueprint,proto3" json:"blueprint,omitempty"` // All blueprints when empty
+	BehindOnly    bool                   `protobuf:"varint,2,opt,name=behind_only,json=behindOnly,proto3" json:"behind_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionsRequest) Reset() {
+	*x = ListSubscriptionsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsRequest) ProtoMessage() {}
+
+func (x *ListSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListSubscriptionsRequest) GetBlueprint() string {
+	if x != nil {
+		return x.Blueprint
+	}
+	return ""
+}
+
+func (x *ListSubscriptionsRequest) GetBehindOnly() bool {
+	if x != nil {
+		return x.BehindOnly
+	}
+	return false
+}
+
+type ListSubscriptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*Subscription        `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionsResponse) Reset() {
+	*x = ListSubscriptionsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsResponse) ProtoMessage() {}
+
+func (x *ListSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListSubscriptionsResponse) GetSubscriptions() []*Subscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *ListSubscriptionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ApplyBlueprintUpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Application   string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"` // Defaults to the pinned version or the head of the channel
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyBlueprintUpdateRequest) Reset() {
+	*x = ApplyBlueprintUpdateRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyBlueprintUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyBlueprintUpdateRequest) ProtoMessage() {}
+
+func (x *ApplyBlueprintUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyBlueprintUpdateRequest.ProtoReflect.Descriptor instead.
+func (*ApplyBlueprintUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ApplyBlueprintUpdateRequest) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *ApplyBlueprintUpdateRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ApplyBlueprintUpdateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	DeploymentId  string                 `protobuf:"bytes,4,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyBlueprintUpdateResponse) Reset() {
+	*x = ApplyBlueprintUpdateResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyBlueprintUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyBlueprintUpdateResponse) ProtoMessage() {}
+
+func (x *ApplyBlueprintUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyBlueprintUpdateResponse.ProtoReflect.Descriptor instead.
+func (*ApplyBlueprintUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ApplyBlueprintUpdateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ApplyBlueprintUpdateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ApplyBlueprintUpdateResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ApplyBlueprintUpdateResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type ImpactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpactRequest) Reset() {
+	*x = ImpactRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpactRequest) ProtoMessage() {}
+
+func (x *ImpactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpactRequest.ProtoReflect.Descriptor instead.
+func (*ImpactRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ImpactRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImpactRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ImpactedApplication struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Depth         int32                  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"` // 1 for direct consumers
+	Via           []string               `protobuf:"bytes,3,rep,name=via,proto3" json:"via,omitempty"`      // Dependency path from the consumer to the application
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpactedApplication) Reset() {
+	*x = ImpactedApplication{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpactedApplication) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpactedApplication) ProtoMessage() {}
+
+func (x *ImpactedApplication) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpactedApplication.ProtoReflect.Descriptor instead.
+func (*ImpactedApplication) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ImpactedApplication) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImpactedApplication) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ImpactedApplication) GetVia() []string {
+	if x != nil {
+		return x.Via
+	}
+	return nil
+}
+
+type ImpactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Dependencies  []string               `protobuf:"bytes,2,rep,name=dependencies,proto3" json:"dependencies,omitempty"` // Applications the application consumes
+	Consumers     []*ImpactedApplication `protobuf:"bytes,3,rep,name=consumers,proto3" json:"consumers,omitempty"`       // Direct and transitive consumers
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpactResponse) Reset() {
+	*x = ImpactResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpactResponse) ProtoMessage() {}
+
+func (x *ImpactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpactResponse.ProtoReflect.Descriptor instead.
+func (*ImpactResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ImpactResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImpactResponse) GetDependencies() []string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *ImpactResponse) GetConsumers() []*ImpactedApplication {
+	if x != nil {
+		return x.Consumers
+	}
+	return nil
+}
+
+func (x *ImpactResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DependencyGraphRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependencyGraphRequest) Reset() {
+	*x = DependencyGraphRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependencyGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyGraphRequest) ProtoMessage() {}
+
+func (x *DependencyGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyGraphRequest.ProtoReflect.Descriptor instead.
+func (*DependencyGraphRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{46}
+}
+
+type DependencyEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Application   string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	DependsOn     string                 `protobuf:"bytes,2,opt,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependencyEdge) Reset() {
+	*x = DependencyEdge{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependencyEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyEdge) ProtoMessage() {}
+
+func (x *DependencyEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyEdge.ProtoReflect.Descriptor instead.
+func (*DependencyEdge) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DependencyEdge) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *DependencyEdge) GetDependsOn() string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return ""
+}
+
+type DependencyGraphResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edges         []*DependencyEdge      `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependencyGraphResponse) Reset() {
+	*x = DependencyGraphResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependencyGraphResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyGraphResponse) ProtoMessage() {}
+
+func (x *DependencyGraphResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyGraphResponse.ProtoReflect.Descriptor instead.
+func (*DependencyGraphResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DependencyGraphResponse) GetEdges() []*DependencyEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *DependencyGraphResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DeleteRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DeleteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusRequest) Reset() {
+	*x = StatusRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusRequest) ProtoMessage() {}
+
+func (x *StatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *StatusRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *StatusRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type AllocationStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AllocationId  string                 `protobuf:"bytes,1,opt,name=allocation_id,json=allocationId,proto3" json:"allocation_id,omitempty"`
+	NodeId        string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	NodeName      string                 `protobuf:"bytes,3,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	DesiredStatus string                 `protobuf:"bytes,5,opt,name=desired_status,json=desiredStatus,proto3" json:"desired_status,omitempty"`
+	CreateTime    int64                  `protobuf:"varint,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	ModifyTime    int64                  `protobuf:"varint,7,opt,name=modify_time,json=modifyTime,proto3" json:"modify_time,omitempty"`
+	TaskStates    map[string]string      `protobuf:"bytes,8,rep,name=task_states,json=taskStates,proto3" json:"task_states,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TaskGroup     string                 `protobuf:"bytes,9,opt,name=task_group,json=taskGroup,proto3" json:"task_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocationStatus) Reset() {
+	*x = AllocationStatus{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationStatus) ProtoMessage() {}
+
+func (x *AllocationStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationStatus.ProtoReflect.Descriptor instead.
+func (*AllocationStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *AllocationStatus) GetAllocationId() string {
+	if x != nil {
+		return x.AllocationId
+	}
+	return ""
+}
+
+func (x *AllocationStatus) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *AllocationStatus) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *AllocationStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AllocationStatus) GetDesiredStatus() string {
+	if x != nil {
+		return x.DesiredStatus
+	}
+	return ""
+}
+
+func (x *AllocationStatus) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+func (x *AllocationStatus) GetModifyTime() int64 {
+	if x != nil {
+		return x.ModifyTime
+	}
+	return 0
+}
+
+func (x *AllocationStatus) GetTaskStates() map[string]string {
+	if x != nil {
+		return x.TaskStates
+	}
+	return nil
+}
+
+func (x *AllocationStatus) GetTaskGroup() string {
+	if x != nil {
+		return x.TaskGroup
+	}
+	return ""
+}
+
+type TaskGroupStatus struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DesiredInstances int32                  `protobuf:"varint,2,opt,name=desired_instances,json=desiredInstances,proto3" json:"desired_instances,omitempty"`
+	RunningInstances int32                  `protobuf:"varint,3,opt,name=running_instances,json=runningInstances,proto3" json:"running_instances,omitempty"`
+	HealthyInstances int32                  `protobuf:"varint,4,opt,name=healthy_instances,json=healthyInstances,proto3" json:"healthy_instances,omitempty"`
+	FailedInstances  int32                  `protobuf:"varint,5,opt,name=failed_instances,json=failedInstances,proto3" json:"failed_instances,omitempty"` // Failed or lost allocations which were not replaced yet
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TaskGroupStatus) Reset() {
+	*x = TaskGroupStatus{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskGroupStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskGroupStatus) ProtoMessage() {}
+
+func (x *TaskGroupStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskGroupStatus.ProtoReflect.Descriptor instead.
+func (*TaskGroupStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *TaskGroupStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaskGroupStatus) GetDesiredInstances() int32 {
+	if x != nil {
+		return x.DesiredInstances
+	}
+	return 0
+}
+
+func (x *TaskGroupStatus) GetRunningInstances() int32 {
+	if x != nil {
+		return x.RunningInstances
+	}
+	return 0
+}
+
+func (x *TaskGroupStatus) GetHealthyInstances() int32 {
+	if x != nil {
+		return x.HealthyInstances
+	}
+	return 0
+}
+
+func (x *TaskGroupStatus) GetFailedInstances() int32 {
+	if x != nil {
+		return x.FailedInstances
+	}
+	return 0
+}
+
+type RolloutProgress struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId      string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // Nomad deployment ID
+	Status            string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	DesiredInstances  int32                  `protobuf:"varint,3,opt,name=desired_instances,json=desiredInstances,proto3" json:"desired_instances,omitempty"`
+	HealthyInstances  int32                  `protobuf:"varint,4,opt,name=healthy_instances,json=healthyInstances,proto3" json:"healthy_instances,omitempty"`
+	Percent           float64                `protobuf:"fixed64,5,opt,name=percent,proto3" json:"percent,omitempty"`
+	EtaSeconds        int64                  `protobuf:"varint,6,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"` // -1 when no estimate is available
+	StartedAt         int64                  `protobuf:"varint,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Reason            string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`      // why the rollout failed, e.g. its deadline passed
+	Reverted          bool                   `protobuf:"varint,9,opt,name=reverted,proto3" json:"reverted,omitempty"` // the version never took, the job runs reverted_to_version again
+	RevertedToVersion uint64                 `protobuf:"varint,10,opt,name=reverted_to_version,json=revertedToVersion,proto3" json:"reverted_to_version,omitempty"`
+	JobVersion        uint64                 `protobuf:"varint,11,opt,name=job_version,json=jobVersion,proto3" json:"job_version,omitempty"`
+	FinishedAt        int64                  `protobuf:"varint,12,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"` // 0 while the rollout runs
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RolloutProgress) Reset() {
+	*x = RolloutProgress{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RolloutProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolloutProgress) ProtoMessage() {}
+
+func (x *RolloutProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RolloutProgress.ProtoReflect.Descriptor instead.
+func (*RolloutProgress) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RolloutProgress) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *RolloutProgress) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RolloutProgress) GetDesiredInstances() int32 {
+	if x != nil {
+		return x.DesiredInstances
+	}
+	return 0
+}
+
+func (x *RolloutProgress) GetHealthyInstances() int32 {
+	if x != nil {
+		return x.HealthyInstances
+	}
+	return 0
+}
+
+func (x *RolloutProgress) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *RolloutProgress) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *RolloutProgress) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *RolloutProgress) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RolloutProgress) GetReverted() bool {
+	if x != nil {
+		return x.Reverted
+	}
+	return false
+}
+
+func (x *RolloutProgress) GetRevertedToVersion() uint64 {
+	if x != nil {
+		return x.RevertedToVersion
+	}
+	return 0
+}
+
+func (x *RolloutProgress) GetJobVersion() uint64 {
+	if x != nil {
+		return x.JobVersion
+	}
+	return 0
+}
+
+func (x *RolloutProgress) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+type WatchDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                     // Application or job ID
+	EvaluationId  string                 `protobuf:"bytes,2,opt,name=evaluation_id,json=evaluationId,proto3" json:"evaluation_id,omitempty"` // The deployment_id a deploy, scale or rollback returned; the latest rollout when empty
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`                           // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchDeploymentRequest) Reset() {
+	*x = WatchDeploymentRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDeploymentRequest) ProtoMessage() {}
+
+func (x *WatchDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*WatchDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *WatchDeploymentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WatchDeploymentRequest) GetEvaluationId() string {
+	if x != nil {
+		return x.EvaluationId
+	}
+	return ""
+}
+
+func (x *WatchDeploymentRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// Promotes the canaries of a rollout, the remaining allocations are replaced after them
+type PromoteDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                     // Application or job ID
+	DeploymentId  string                 `protobuf:"bytes,2,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // Rollout to promote, the traffic split or the latest rollout when empty
+	Groups        []string               `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`                                 // Task groups whose canaries are promoted, every group when empty
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`                           // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteDeploymentRequest) Reset() {
+	*x = PromoteDeploymentRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteDeploymentRequest) ProtoMessage() {}
+
+func (x *PromoteDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*PromoteDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *PromoteDeploymentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentRequest) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *PromoteDeploymentRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type PromoteDeploymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	DeploymentId  string                 `protobuf:"bytes,3,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	EvalId        string                 `protobuf:"bytes,4,opt,name=eval_id,json=evalId,proto3" json:"eval_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteDeploymentResponse) Reset() {
+	*x = PromoteDeploymentResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteDeploymentResponse) ProtoMessage() {}
+
+func (x *PromoteDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*PromoteDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *PromoteDeploymentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PromoteDeploymentResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentResponse) GetEvalId() string {
+	if x != nil {
+		return x.EvalId
+	}
+	return ""
+}
+
+// Fails a running rollout, Nomad reverts the job when its update strategy has auto_revert
+type FailDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                     // Application or job ID
+	DeploymentId  string                 `protobuf:"bytes,2,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // Rollout to fail, the traffic split or the latest rollout when empty
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`                           // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailDeploymentRequest) Reset() {
+	*x = FailDeploymentRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailDeploymentRequest) ProtoMessage() {}
+
+func (x *FailDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*FailDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *FailDeploymentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FailDeploymentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *FailDeploymentRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type FailDeploymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	DeploymentId  string                 `protobuf:"bytes,3,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	EvalId        string                 `protobuf:"bytes,4,opt,name=eval_id,json=evalId,proto3" json:"eval_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailDeploymentResponse) Reset() {
+	*x = FailDeploymentResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailDeploymentResponse) ProtoMessage() {}
+
+func (x *FailDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*FailDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *FailDeploymentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *FailDeploymentResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *FailDeploymentResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *FailDeploymentResponse) GetEvalId() string {
+	if x != nil {
+		return x.EvalId
+	}
+	return ""
+}
+
+// A step of a rollout, the stream ends with the done event
+type DeploymentEvent struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Type               string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // evaluation, placement_failed, allocation, deployment or done
+	Message            string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Time               int64                  `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	EvaluationId       string                 `protobuf:"bytes,4,opt,name=evaluation_id,json=evaluationId,proto3" json:"evaluation_id,omitempty"`
+	DeploymentId       string                 `protobuf:"bytes,5,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	AllocationId       string                 `protobuf:"bytes,6,opt,name=allocation_id,json=allocationId,proto3" json:"allocation_id,omitempty"`
+	TaskGroup          string                 `protobuf:"bytes,7,opt,name=task_group,json=taskGroup,proto3" json:"task_group,omitempty"`
+	Status             string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // Of the evaluation, allocation or deployment
+	DesiredInstances   int32                  `protobuf:"varint,9,opt,name=desired_instances,json=desiredInstances,proto3" json:"desired_instances,omitempty"`
+	PlacedInstances    int32                  `protobuf:"varint,10,opt,name=placed_instances,json=placedInstances,proto3" json:"placed_instances,omitempty"`
+	HealthyInstances   int32                  `protobuf:"varint,11,opt,name=healthy_instances,json=healthyInstances,proto3" json:"healthy_instances,omitempty"`
+	UnhealthyInstances int32                  `protobuf:"varint,12,opt,name=unhealthy_instances,json=unhealthyInstances,proto3" json:"unhealthy_instances,omitempty"`
+	Success            bool                   `protobuf:"varint,13,opt,name=success,proto3" json:"success,omitempty"`                         // Whether the rollout succeeded, set on the done event
+	Percent            float64                `protobuf:"fixed64,14,opt,name=percent,proto3" json:"percent,omitempty"`                        // Healthy instances of the desired ones, set on the deployment events
+	EtaSeconds         int64                  `protobuf:"varint,15,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"` // Time the rollout has left, set on the deployment events while it runs, -1 when no estimate is available
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DeploymentEvent) Reset() {
+	*x = DeploymentEvent{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentEvent) ProtoMessage() {}
+
+func (x *DeploymentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentEvent.ProtoReflect.Descriptor instead.
+func (*DeploymentEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DeploymentEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DeploymentEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeploymentEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *DeploymentEvent) GetEvaluationId() string {
+	if x != nil {
+		return x.EvaluationId
+	}
+	return ""
+}
+
+func (x *DeploymentEvent) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *DeploymentEvent) GetAllocationId() string {
+	if x != nil {
+		return x.AllocationId
+	}
+	return ""
+}
+
+func (x *DeploymentEvent) GetTaskGroup() string {
+	if x != nil {
+		return x.TaskGroup
+	}
+	return ""
+}
+
+func (x *DeploymentEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeploymentEvent) GetDesiredInstances() int32 {
+	if x != nil {
+		return x.DesiredInstances
+	}
+	return 0
+}
+
+func (x *DeploymentEvent) GetPlacedInstances() int32 {
+	if x != nil {
+		return x.PlacedInstances
+	}
+	return 0
+}
+
+func (x *DeploymentEvent) GetHealthyInstances() int32 {
+	if x != nil {
+		return x.HealthyInstances
+	}
+	return 0
+}
+
+func (x *DeploymentEvent) GetUnhealthyInstances() int32 {
+	if x != nil {
+		return x.UnhealthyInstances
+	}
+	return 0
+}
+
+func (x *DeploymentEvent) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeploymentEvent) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *DeploymentEvent) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+type StatusResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId     string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	JobStatus        string                 `protobuf:"bytes,2,opt,name=job_status,json=jobStatus,proto3" json:"job_status,omitempty"`
+	JobType          string                 `protobuf:"bytes,3,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	DesiredInstances int32                  `protobuf:"varint,4,opt,name=desired_instances,json=desiredInstances,proto3" json:"desired_instances,omitempty"`
+	RunningInstances int32                  `protobuf:"varint,5,opt,name=running_instances,json=runningInstances,proto3" json:"running_instances,omitempty"`
+	Allocations      []*AllocationStatus    `protobuf:"bytes,6,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	Message          string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	TaskGroups       []*TaskGroupStatus     `protobuf:"bytes,8,rep,name=task_groups,json=taskGroups,proto3" json:"task_groups,omitempty"`
+	HealthyInstances int32                  `protobuf:"varint,9,opt,name=healthy_instances,json=healthyInstances,proto3" json:"healthy_instances,omitempty"`
+	FailedInstances  int32                  `protobuf:"varint,10,opt,name=failed_instances,json=failedInstances,proto3" json:"failed_instances,omitempty"`
+	Rollout          *RolloutProgress       `protobuf:"bytes,11,opt,name=rollout,proto3" json:"rollout,omitempty"`
+	JobId            string                 `protobuf:"bytes,12,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // The deployment_id of the request may be the application's name
+	Application      string                 `protobuf:"bytes,13,opt,name=application,proto3" json:"application,omitempty"`
+	Tenant           string                 `protobuf:"bytes,14,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	History          []*RolloutProgress     `protobuf:"bytes,15,rep,name=history,proto3" json:"history,omitempty"`                    // Finished rollouts, most recent first
+	Geo              []*GeoRegion           `protobuf:"bytes,16,rep,name=geo,proto3" json:"geo,omitempty"`                            // Health of the regions of geo routing
+	CachedAt         int64                  `protobuf:"varint,17,opt,name=cached_at,json=cachedAt,proto3" json:"cached_at,omitempty"` // Set when served from cache while Nomad is under pressure
+	Traffic          *TrafficSplit          `protobuf:"bytes,18,opt,name=traffic,proto3" json:"traffic,omitempty"`                    // The green job of a blue-green or canary-traffic rollout while it runs
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *StatusResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetJobStatus() string {
+	if x != nil {
+		return x.JobStatus
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetDesiredInstances() int32 {
+	if x != nil {
+		return x.DesiredInstances
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetRunningInstances() int32 {
+	if x != nil {
+		return x.RunningInstances
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetAllocations() []*AllocationStatus {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetTaskGroups() []*TaskGroupStatus {
+	if x != nil {
+		return x.TaskGroups
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetHealthyInstances() int32 {
+	if x != nil {
+		return x.HealthyInstances
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetFailedInstances() int32 {
+	if x != nil {
+		return x.FailedInstances
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetRollout() *RolloutProgress {
+	if x != nil {
+		return x.Rollout
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetHistory() []*RolloutProgress {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetGeo() []*GeoRegion {
+	if x != nil {
+		return x.Geo
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetCachedAt() int64 {
+	if x != nil {
+		return x.CachedAt
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetTraffic() *TrafficSplit {
+	if x != nil {
+		return x.Traffic
+	}
+	return nil
+}
+
+// Where a blue-green or canary-traffic rollout stands
+type TrafficSplit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Strategy      string                 `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"` // blue-green or canary-traffic
+	GreenJobId    string                 `protobuf:"bytes,2,opt,name=green_job_id,json=greenJobId,proto3" json:"green_job_id,omitempty"`
+	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`                                // Image of the green job
+	Weight        int32                  `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`                             // Percent of the traffic routed to the green job
+	Weights       []int32                `protobuf:"varint,5,rep,packed,name=weights,proto3" json:"weights,omitempty"`                    // The steps of the shift
+	Phase         string                 `protobuf:"bytes,6,opt,name=phase,proto3" json:"phase,omitempty"`                                // starting, shifting, awaiting_promotion or promoting
+	NextStepAt    int64                  `protobuf:"varint,7,opt,name=next_step_at,json=nextStepAt,proto3" json:"next_step_at,omitempty"` // Unix seconds, 0 when no step is scheduled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrafficSplit) Reset() {
+	*x = TrafficSplit{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficSplit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficSplit) ProtoMessage() {}
+
+func (x *TrafficSplit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficSplit.ProtoReflect.Descriptor instead.
+func (*TrafficSplit) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *TrafficSplit) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *TrafficSplit) GetGreenJobId() string {
+	if x != nil {
+		return x.GreenJobId
+	}
+	return ""
+}
+
+func (x *TrafficSplit) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *TrafficSplit) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *TrafficSplit) GetWeights() []int32 {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *TrafficSplit) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *TrafficSplit) GetNextStepAt() int64 {
+	if x != nil {
+		return x.NextStepAt
+	}
+	return 0
+}
+
+type GeoRegion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Region        string                 `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Healthy       bool                   `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	ChangedAt     int64                  `protobuf:"varint,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	Routed        bool                   `protobuf:"varint,5,opt,name=routed,proto3" json:"routed,omitempty"` // Its records are published
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoRegion) Reset() {
+	*x = GeoRegion{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoRegion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoRegion) ProtoMessage() {}
+
+func (x *GeoRegion) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoRegion.ProtoReflect.Descriptor instead.
+func (*GeoRegion) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GeoRegion) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GeoRegion) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *GeoRegion) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GeoRegion) GetChangedAt() int64 {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return 0
+}
+
+func (x *GeoRegion) GetRouted() bool {
+	if x != nil {
+		return x.Routed
+	}
+	return false
+}
+
+type ApplicationHealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Empty for every application
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplicationHealthRequest) Reset() {
+	*x = ApplicationHealthRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplicationHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplicationHealthRequest) ProtoMessage() {}
+
+func (x *ApplicationHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplicationHealthRequest.ProtoReflect.Descriptor instead.
+func (*ApplicationHealthRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ApplicationHealthRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ApplicationHealth struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Name          string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status        ApplicationHealthStatus `protobuf:"varint,2,opt,name=status,proto3,enum=controlplane.ApplicationHealthStatus" json:"status,omitempty"`
+	Reason        string                  `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // The rule the status was derived from
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplicationHealth) Reset() {
+	*x = ApplicationHealth{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplicationHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplicationHealth) ProtoMessage() {}
+
+func (x *ApplicationHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplicationHealth.ProtoReflect.Descriptor instead.
+func (*ApplicationHealth) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ApplicationHealth) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApplicationHealth) GetStatus() ApplicationHealthStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ApplicationHealthStatus_APPLICATION_HEALTH_UNKNOWN
+}
+
+func (x *ApplicationHealth) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ApplicationHealthResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applications  []*ApplicationHealth   `protobuf:"bytes,1,rep,name=applications,proto3" json:"applications,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplicationHealthResponse) Reset() {
+	*x = ApplicationHealthResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplicationHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplicationHealthResponse) ProtoMessage() {}
+
+func (x *ApplicationHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplicationHealthResponse.ProtoReflect.Descriptor instead.
+func (*ApplicationHealthResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ApplicationHealthResponse) GetApplications() []*ApplicationHealth {
+	if x != nil {
+		return x.Applications
+	}
+	return nil
+}
+
+func (x *ApplicationHealthResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ListApplicationsRequest pages through the applications ordered by name, the filters apply
+// before paging
+type ListApplicationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Only applications with all of these labels
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`                                                                           // Only applications running in this region
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                                                      // Defaults to every application on one page
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                                                    // The next_page_token of the previous page
+	Project       string                 `protobuf:"bytes,5,opt,name=project,proto3" json:"project,omitempty"`                                                                         // Only applications of this project
+	Namespace     string                 `protobuf:"bytes,6,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                                     // Only applications of this namespace, default when empty, * for every namespace
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApplicationsRequest) Reset() {
+	*x = ListApplicationsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApplicationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApplicationsRequest) ProtoMessage() {}
+
+func (x *ListApplicationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApplicationsRequest.ProtoReflect.Descriptor instead.
+func (*ListApplicationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListApplicationsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListApplicationsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ListApplicationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListApplicationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListApplicationsRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *ListApplicationsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ApplicationSummary is the one-line status of an application
+type ApplicationSummary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	JobId            string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Tenant           string                 `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Type             string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Status           string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // Nomad job status
+	Image            string                 `protobuf:"bytes,6,opt,name=image,proto3" json:"image,omitempty"`
+	DesiredInstances int32                  `protobuf:"varint,7,opt,name=desired_instances,json=desiredInstances,proto3" json:"desired_instances,omitempty"`
+	RunningInstances int32                  `protobuf:"varint,8,opt,name=running_instances,json=runningInstances,proto3" json:"running_instances,omitempty"`
+	HealthyInstances int32                  `protobuf:"varint,9,opt,name=healthy_instances,json=healthyInstances,proto3" json:"healthy_instances,omitempty"`
+	FailedInstances  int32                  `protobuf:"varint,10,opt,name=failed_instances,json=failedInstances,proto3" json:"failed_instances,omitempty"`
+	Region           string                 `protobuf:"bytes,11,opt,name=region,proto3" json:"region,omitempty"`
+	Url              string                 `protobuf:"bytes,12,opt,name=url,proto3" json:"url,omitempty"`                                     // Empty when the application is not routed by Traefik
+	SubmittedAt      int64                  `protobuf:"varint,13,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"` // Unix seconds of the current job version
+	Owner            string                 `protobuf:"bytes,14,opt,name=owner,proto3" json:"owner,omitempty"`                                 // The owner label, or else the tenant
+	Revision         int32                  `protobuf:"varint,15,opt,name=revision,proto3" json:"revision,omitempty"`                          // Latest revision in the registry, 0 when none was recorded
+	CreatedAt        int64                  `protobuf:"varint,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`       // Unix seconds of the first deployment
+	Project          string                 `protobuf:"bytes,17,opt,name=project,proto3" json:"project,omitempty"`                             // The project label
+	Namespace        string                 `protobuf:"bytes,18,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ApplicationSummary) Reset() {
+	*x = ApplicationSummary{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplicationSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplicationSummary) ProtoMessage() {}
+
+func (x *ApplicationSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplicationSummary.ProtoReflect.Descriptor instead.
+func (*ApplicationSummary) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ApplicationSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApplicationSummary) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ApplicationSummary) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *ApplicationSummary) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ApplicationSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ApplicationSummary) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ApplicationSummary) GetDesiredInstances() int32 {
+	if x != nil {
+		return x.DesiredInstances
+	}
+	return 0
+}
+
+func (x *ApplicationSummary) GetRunningInstances() int32 {
+	if x != nil {
+		return x.RunningInstances
+	}
+	return 0
+}
+
+func (x *ApplicationSummary) GetHealthyInstances() int32 {
+	if x != nil {
+		return x.HealthyInstances
+	}
+	return 0
+}
+
+func (x *ApplicationSummary) GetFailedInstances() int32 {
+	if x != nil {
+		return x.FailedInstances
+	}
+	return 0
+}
+
+func (x *ApplicationSummary) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ApplicationSummary) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ApplicationSummary) GetSubmittedAt() int64 {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return 0
+}
+
+func (x *ApplicationSummary) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ApplicationSummary) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *ApplicationSummary) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ApplicationSummary) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *ApplicationSummary) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ListApplicationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applications  []*ApplicationSummary  `protobuf:"bytes,1,rep,name=applications,proto3" json:"applications,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApplicationsResponse) Reset() {
+	*x = ListApplicationsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApplicationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApplicationsResponse) ProtoMessage() {}
+
+func (x *ListApplicationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApplicationsResponse.ProtoReflect.Descriptor instead.
+func (*ListApplicationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListApplicationsResponse) GetApplications() []*ApplicationSummary {
+	if x != nil {
+		return x.Applications
+	}
+	return nil
+}
+
+func (x *ListApplicationsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListApplicationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ScaleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	TaskGroup     string                 `protobuf:"bytes,3,opt,name=task_group,json=taskGroup,proto3" json:"task_group,omitempty"` // Required when the job has more than one task group
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`                  // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScaleRequest) Reset() {
+	*x = ScaleRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScaleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScaleRequest) ProtoMessage() {}
+
+func (x *ScaleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScaleRequest.ProtoReflect.Descriptor instead.
+func (*ScaleRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ScaleRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *ScaleRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ScaleRequest) GetTaskGroup() string {
+	if x != nil {
+		return x.TaskGroup
+	}
+	return ""
+}
+
+func (x *ScaleRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ScaleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	TaskGroup     string                 `protobuf:"bytes,3,opt,name=task_group,json=taskGroup,proto3" json:"task_group,omitempty"`
+	PreviousCount int32                  `protobuf:"varint,4,opt,name=previous_count,json=previousCount,proto3" json:"previous_count,omitempty"`
+	DesiredCount  int32                  `protobuf:"varint,5,opt,name=desired_count,json=desiredCount,proto3" json:"desired_count,omitempty"`
+	RunningCount  int32                  `protobuf:"varint,6,opt,name=running_count,json=runningCount,proto3" json:"running_count,omitempty"` // Instances running when the count changed, Nomad places or stops the difference
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScaleResponse) Reset() {
+	*x = ScaleResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScaleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScaleResponse) ProtoMessage() {}
+
+func (x *ScaleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScaleResponse.ProtoReflect.Descriptor instead.
+func (*ScaleResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ScaleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ScaleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ScaleResponse) GetTaskGroup() string {
+	if x != nil {
+		return x.TaskGroup
+	}
+	return ""
+}
+
+func (x *ScaleResponse) GetPreviousCount() int32 {
+	if x != nil {
+		return x.PreviousCount
+	}
+	return 0
+}
+
+func (x *ScaleResponse) GetDesiredCount() int32 {
+	if x != nil {
+		return x.DesiredCount
+	}
+	return 0
+}
+
+func (x *ScaleResponse) GetRunningCount() int32 {
+	if x != nil {
+		return x.RunningCount
+	}
+	return 0
+}
+
+type RollbackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Version       uint64                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`    // Job version to revert to, defaults to the last stable version before the current one
+	Revision      int32                  `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`  // Registry revision whose spec is deployed again instead, see ListRevisions
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackRequest) Reset() {
+	*x = RollbackRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackRequest) ProtoMessage() {}
+
+func (x *RollbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackRequest.ProtoReflect.Descriptor instead.
+func (*RollbackRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *RollbackRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *RollbackRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RollbackRequest) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RollbackRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// Deploys the latest revision of an application with the fields of the mask replaced
+type UpdateApplicationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                      // Application to update
+	Spec          *DeployRequest         `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`                                      // New values of the fields of update_mask, the other fields are ignored
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`        // Fields of DeployRequest to replace, e.g. image, cpu or traefik.host. Fields unset in spec are cleared
+	BaseRevision  int32                  `protobuf:"varint,4,opt,name=base_revision,json=baseRevision,proto3" json:"base_revision,omitempty"` // Revision the update was made against, rejected when a later one was recorded since. 0 skips the check
+	Namespace     string                 `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`                            // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateApplicationRequest) Reset() {
+	*x = UpdateApplicationRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateApplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateApplicationRequest) ProtoMessage() {}
+
+func (x *UpdateApplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateApplicationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateApplicationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *UpdateApplicationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateApplicationRequest) GetSpec() *DeployRequest {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *UpdateApplicationRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateApplicationRequest) GetBaseRevision() int32 {
+	if x != nil {
+		return x.BaseRevision
+	}
+	return 0
+}
+
+func (x *UpdateApplicationRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type RollbackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Version       uint64                 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"` // Job version reverted to
+	EvalId        string                 `protobuf:"bytes,4,opt,name=eval_id,json=evalId,proto3" json:"eval_id,omitempty"`
+	Revision      int32                  `protobuf:"varint,5,opt,name=revision,proto3" json:"revision,omitempty"` // Revision the spec was recorded as when rolling back to a revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackResponse) Reset() {
+	*x = RollbackResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackResponse) ProtoMessage() {}
+
+func (x *RollbackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackResponse.ProtoReflect.Descriptor instead.
+func (*RollbackResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RollbackResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RollbackResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RollbackResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RollbackResponse) GetEvalId() string {
+	if x != nil {
+		return x.EvalId
+	}
+	return ""
+}
+
+func (x *RollbackResponse) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type InvokeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"` // Written to local/payload in the task
+	Meta          map[string]string      `protobuf:"bytes,3,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvokeRequest) Reset() {
+	*x = InvokeRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvokeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeRequest) ProtoMessage() {}
+
+func (x *InvokeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeRequest.ProtoReflect.Descriptor instead.
+func (*InvokeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *InvokeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InvokeRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *InvokeRequest) GetMeta() map[string]string {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *InvokeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type Invocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvocationId  string                 `protobuf:"bytes,1,opt,name=invocation_id,json=invocationId,proto3" json:"invocation_id,omitempty"` // Dispatched Nomad job ID
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	QueuedMs      int64                  `protobuf:"varint,5,opt,name=queued_ms,json=queuedMs,proto3" json:"queued_ms,omitempty"` // Time spent waiting for a free concurrency slot
+	StartedAt     int64                  `protobuf:"varint,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invocation) Reset() {
+	*x = Invocation{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invocation) ProtoMessage() {}
+
+func (x *Invocation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invocation.ProtoReflect.Descriptor instead.
+func (*Invocation) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *Invocation) GetInvocationId() string {
+	if x != nil {
+		return x.InvocationId
+	}
+	return ""
+}
+
+func (x *Invocation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Invocation) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *Invocation) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *Invocation) GetQueuedMs() int64 {
+	if x != nil {
+		return x.QueuedMs
+	}
+	return 0
+}
+
+func (x *Invocation) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+type InvokeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Invocation    *Invocation            `protobuf:"bytes,3,opt,name=invocation,proto3" json:"invocation,omitempty"`
+	Output        string                 `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"` // Tail of the task's stdout
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvokeResponse) Reset() {
+	*x = InvokeResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvokeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeResponse) ProtoMessage() {}
+
+func (x *InvokeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeResponse.ProtoReflect.Descriptor instead.
+func (*InvokeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *InvokeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *InvokeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InvokeResponse) GetInvocation() *Invocation {
+	if x != nil {
+		return x.Invocation
+	}
+	return nil
+}
+
+func (x *InvokeResponse) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+type FunctionMetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionMetricsRequest) Reset() {
+	*x = FunctionMetricsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionMetricsRequest) ProtoMessage() {}
+
+func (x *FunctionMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionMetricsRequest.ProtoReflect.Descriptor instead.
+func (*FunctionMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *FunctionMetricsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FunctionMetricsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type FunctionMetricsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Invocations   int64                  `protobuf:"varint,2,opt,name=invocations,proto3" json:"invocations,omitempty"`
+	Failures      int64                  `protobuf:"varint,3,opt,name=failures,proto3" json:"failures,omitempty"`
+	P50DurationMs int64                  `protobuf:"varint,4,opt,name=p50_duration_ms,json=p50DurationMs,proto3" json:"p50_duration_ms,omitempty"`
+	P95DurationMs int64                  `protobuf:"varint,5,opt,name=p95_duration_ms,json=p95DurationMs,proto3" json:"p95_duration_ms,omitempty"`
+	InFlight      int32                  `protobuf:"varint,6,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`
+	Recent        []*Invocation          `protobuf:"bytes,7,rep,name=recent,proto3" json:"recent,omitempty"`
+	Message       string                 `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionMetricsResponse) Reset() {
+	*x = FunctionMetricsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionMetricsResponse) ProtoMessage() {}
+
+func (x *FunctionMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionMetricsResponse.ProtoReflect.Descriptor instead.
+func (*FunctionMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *FunctionMetricsResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FunctionMetricsResponse) GetInvocations() int64 {
+	if x != nil {
+		return x.Invocations
+	}
+	return 0
+}
+
+func (x *FunctionMetricsResponse) GetFailures() int64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *FunctionMetricsResponse) GetP50DurationMs() int64 {
+	if x != nil {
+		return x.P50DurationMs
+	}
+	return 0
+}
+
+func (x *FunctionMetricsResponse) GetP95DurationMs() int64 {
+	if x != nil {
+		return x.P95DurationMs
+	}
+	return 0
+}
+
+func (x *FunctionMetricsResponse) GetInFlight() int32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *FunctionMetricsResponse) GetRecent() []*Invocation {
+	if x != nil {
+		return x.Recent
+	}
+	return nil
+}
+
+func (x *FunctionMetricsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DispatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // Parameterized job to dispatch
+	Payload       []byte                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Meta          map[string]string      `protobuf:"bytes,3,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DispatchRequest) Reset() {
+	*x = DispatchRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DispatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DispatchRequest) ProtoMessage() {}
+
+func (x *DispatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DispatchRequest.ProtoReflect.Descriptor instead.
+func (*DispatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *DispatchRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *DispatchRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DispatchRequest) GetMeta() map[string]string {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *DispatchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type DispatchResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	DispatchedJobId string                 `protobuf:"bytes,3,opt,name=dispatched_job_id,json=dispatchedJobId,proto3" json:"dispatched_job_id,omitempty"` // Usable as deployment_id for status and logs
+	EvalId          string                 `protobuf:"bytes,4,opt,name=eval_id,json=evalId,proto3" json:"eval_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DispatchResponse) Reset() {
+	*x = DispatchResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DispatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DispatchResponse) ProtoMessage() {}
+
+func (x *DispatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DispatchResponse.ProtoReflect.Descriptor instead.
+func (*DispatchResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *DispatchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DispatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DispatchResponse) GetDispatchedJobId() string {
+	if x != nil {
+		return x.DispatchedJobId
+	}
+	return ""
+}
+
+func (x *DispatchResponse) GetEvalId() string {
+	if x != nil {
+		return x.EvalId
+	}
+	return ""
+}
+
+type CronRunsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`        // Defaults to 10
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CronRunsRequest) Reset() {
+	*x = CronRunsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronRunsRequest) ProtoMessage() {}
+
+func (x *CronRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronRunsRequest.ProtoReflect.Descriptor instead.
+func (*CronRunsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *CronRunsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CronRunsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *CronRunsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type CronRun struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // Periodic child job ID, usable as deployment_id for status and logs
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`            // pending, running, complete or failed
+	StartedAt     int64                  `protobuf:"varint,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    int64                  `protobuf:"varint,4,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CronRun) Reset() {
+	*x = CronRun{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronRun) ProtoMessage() {}
+
+func (x *CronRun) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronRun.ProtoReflect.Descriptor instead.
+func (*CronRun) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *CronRun) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *CronRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CronRun) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *CronRun) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *CronRun) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type CronRunsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Schedule      string                 `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Paused        bool                   `protobuf:"varint,4,opt,name=paused,proto3" json:"paused,omitempty"`
+	NextRun       int64                  `protobuf:"varint,5,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	Runs          []*CronRun             `protobuf:"bytes,6,rep,name=runs,proto3" json:"runs,omitempty"`
+	Message       string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CronRunsResponse) Reset() {
+	*x = CronRunsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronRunsResponse) ProtoMessage() {}
+
+func (x *CronRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronRunsResponse.ProtoReflect.Descriptor instead.
+func (*CronRunsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *CronRunsResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CronRunsResponse) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *CronRunsResponse) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *CronRunsResponse) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *CronRunsResponse) GetNextRun() int64 {
+	if x != nil {
+		return x.NextRun
+	}
+	return 0
+}
+
+func (x *CronRunsResponse) GetRuns() []*CronRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+func (x *CronRunsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CronTriggerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CronTriggerRequest) Reset() {
+	*x = CronTriggerRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronTriggerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronTriggerRequest) ProtoMessage() {}
+
+func (x *CronTriggerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronTriggerRequest.ProtoReflect.Descriptor instead.
+func (*CronTriggerRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *CronTriggerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CronTriggerRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type CronTriggerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	EvalId        string                 `protobuf:"bytes,3,opt,name=eval_id,json=evalId,proto3" json:"eval_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CronTriggerResponse) Reset() {
+	*x = CronTriggerResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronTriggerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronTriggerResponse) ProtoMessage() {}
+
+func (x *CronTriggerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronTriggerResponse.ProtoReflect.Descriptor instead.
+func (*CronTriggerResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *CronTriggerResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CronTriggerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CronTriggerResponse) GetEvalId() string {
+	if x != nil {
+		return x.EvalId
+	}
+	return ""
+}
+
+type CronPauseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Paused        bool                   `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`      // false resumes the schedule
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CronPauseRequest) Reset() {
+	*x = CronPauseRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronPauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronPauseRequest) ProtoMessage() {}
+
+func (x *CronPauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronPauseRequest.ProtoReflect.Descriptor instead.
+func (*CronPauseRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *CronPauseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CronPauseRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *CronPauseRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type CronPauseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CronPauseResponse) Reset() {
+	*x = CronPauseResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronPauseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronPauseResponse) ProtoMessage() {}
+
+func (x *CronPauseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronPauseResponse.ProtoReflect.Descriptor instead.
+func (*CronPauseResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *CronPauseResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CronPauseResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type LogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	AllocationId  string                 `protobuf:"bytes,2,opt,name=allocation_id,json=allocationId,proto3" json:"allocation_id,omitempty"` // Defaults to the most recent allocation
+	TaskName      string                 `protobuf:"bytes,3,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`             // Defaults to the allocation's only task
+	Follow        bool                   `protobuf:"varint,4,opt,name=follow,proto3" json:"follow,omitempty"`                                // Keeps streaming new lines, only supported by GetLogs
+	TailLines     int32                  `protobuf:"varint,5,opt,name=tail_lines,json=tailLines,proto3" json:"tail_lines,omitempty"`         // Defaults to 100
+	LogType       string                 `protobuf:"bytes,6,opt,name=log_type,json=logType,proto3" json:"log_type,omitempty"`                // "stdout" (default) or "stderr"
+	Namespace     string                 `protobuf:"bytes,7,opt,name=namespace,proto3" json:"namespace,omitempty"`                           // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogsRequest) Reset() {
+	*x = LogsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogsRequest) ProtoMessage() {}
+
+func (x *LogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogsRequest.ProtoReflect.Descriptor instead.
+func (*LogsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *LogsRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *LogsRequest) GetAllocationId() string {
+	if x != nil {
+		return x.AllocationId
+	}
+	return ""
+}
+
+func (x *LogsRequest) GetTaskName() string {
+	if x != nil {
+		return x.TaskName
+	}
+	return ""
+}
+
+func (x *LogsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+func (x *LogsRequest) GetTailLines() int32 {
+	if x != nil {
+		return x.TailLines
+	}
+	return 0
+}
+
+func (x *LogsRequest) GetLogType() string {
+	if x != nil {
+		return x.LogType
+	}
+	return ""
+}
+
+func (x *LogsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type LogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LogLines      []string               `protobuf:"bytes,1,rep,name=log_lines,json=logLines,proto3" json:"log_lines,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogsResponse) Reset() {
+	*x = LogsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogsResponse) ProtoMessage() {}
+
+func (x *LogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogsResponse.ProtoReflect.Descriptor instead.
+func (*LogsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *LogsResponse) GetLogLines() []string {
+	if x != nil {
+		return x.LogLines
+	}
+	return nil
+}
+
+func (x *LogsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LogsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Lines GetLogs streams as the task writes them
+type LogChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         []string               `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	AllocationId  string                 `protobuf:"bytes,2,opt,name=allocation_id,json=allocationId,proto3" json:"allocation_id,omitempty"`
+	TaskName      string                 `protobuf:"bytes,3,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // Set on the last chunk when the log could not be read
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogChunk) Reset() {
+	*x = LogChunk{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogChunk) ProtoMessage() {}
+
+func (x *LogChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogChunk.ProtoReflect.Descriptor instead.
+func (*LogChunk) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *LogChunk) GetLines() []string {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *LogChunk) GetAllocationId() string {
+	if x != nil {
+		return x.AllocationId
+	}
+	return ""
+}
+
+func (x *LogChunk) GetTaskName() string {
+	if x != nil {
+		return x.TaskName
+	}
+	return ""
+}
+
+func (x *LogChunk) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetApplicationConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApplicationConfigRequest) Reset() {
+	*x = GetApplicationConfigRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApplicationConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApplicationConfigRequest) ProtoMessage() {}
+
+func (x *GetApplicationConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApplicationConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetApplicationConfigRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GetApplicationConfigRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *GetApplicationConfigRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type SetApplicationConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Values        map[string]string      `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DeleteKeys    []string               `protobuf:"bytes,3,rep,name=delete_keys,json=deleteKeys,proto3" json:"delete_keys,omitempty"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetApplicationConfigRequest) Reset() {
+	*x = SetApplicationConfigRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetApplicationConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetApplicationConfigRequest) ProtoMessage() {}
+
+func (x *SetApplicationConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetApplicationConfigRequest.ProtoReflect.Descriptor instead.
+func (*SetApplicationConfigRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *SetApplicationConfigRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *SetApplicationConfigRequest) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *SetApplicationConfigRequest) GetDeleteKeys() []string {
+	if x != nil {
+		return x.DeleteKeys
+	}
+	return nil
+}
+
+func (x *SetApplicationConfigRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ApplicationConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Prefix        string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`                                                                           // Consul KV prefix of the application
+	Values        map[string]string      `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Every key of the prefix after the change
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplicationConfigResponse) Reset() {
+	*x = ApplicationConfigResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplicationConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplicationConfigResponse) ProtoMessage() {}
+
+func (x *ApplicationConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplicationConfigResponse.ProtoReflect.Descriptor instead.
+func (*ApplicationConfigResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *ApplicationConfigResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ApplicationConfigResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ApplicationConfigResponse) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ApplicationConfigResponse) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type RunActionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId   string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Action         string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	AllocationId   string                 `protobuf:"bytes,3,opt,name=allocation_id,json=allocationId,proto3" json:"allocation_id,omitempty"`        // Defaults to the most recent running allocation
+	TaskName       string                 `protobuf:"bytes,4,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`                    // Defaults to the allocation's only task
+	TimeoutSeconds int32                  `protobuf:"varint,5,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"` // Defaults to 300
+	Namespace      string                 `protobuf:"bytes,6,opt,name=namespace,proto3" json:"namespace,omitempty"`                                  // Namespace of the application, default when empty
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RunActionRequest) Reset() {
+	*x = RunActionRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunActionRequest) ProtoMessage() {}
+
+func (x *RunActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunActionRequest.ProtoReflect.Descriptor instead.
+func (*RunActionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *RunActionRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *RunActionRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *RunActionRequest) GetAllocationId() string {
+	if x != nil {
+		return x.AllocationId
+	}
+	return ""
+}
+
+func (x *RunActionRequest) GetTaskName() string {
+	if x != nil {
+		return x.TaskName
+	}
+	return ""
+}
+
+func (x *RunActionRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *RunActionRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type RunActionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // The action ran and exited with 0
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Stdout        string                 `protobuf:"bytes,4,opt,name=stdout,proto3" json:"stdout,omitempty"` // The last 64KB
+	Stderr        string                 `protobuf:"bytes,5,opt,name=stderr,proto3" json:"stderr,omitempty"` // The last 64KB
+	AllocationId  string                 `protobuf:"bytes,6,opt,name=allocation_id,json=allocationId,proto3" json:"allocation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunActionResponse) Reset() {
+	*x = RunActionResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunActionResponse) ProtoMessage() {}
+
+func (x *RunActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunActionResponse.ProtoReflect.Descriptor instead.
+func (*RunActionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *RunActionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RunActionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RunActionResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *RunActionResponse) GetStdout() string {
+	if x != nil {
+		return x.Stdout
+	}
+	return ""
+}
+
+func (x *RunActionResponse) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+func (x *RunActionResponse) GetAllocationId() string {
+	if x != nil {
+		return x.AllocationId
+	}
+	return ""
+}
+
+// Creates the volume through the CSI controller plugin, or registers an existing volume
+// of the storage provider when external_id is set
+type CreateVolumeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Defaults to the id
+	PluginId       string                 `protobuf:"bytes,3,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"`
+	ExternalId     string                 `protobuf:"bytes,4,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	CapacityMinMb  int64                  `protobuf:"varint,5,opt,name=capacity_min_mb,json=capacityMinMb,proto3" json:"capacity_min_mb,omitempty"`
+	CapacityMaxMb  int64                  `protobuf:"varint,6,opt,name=capacity_max_mb,json=capacityMaxMb,proto3" json:"capacity_max_mb,omitempty"`
+	AccessMode     string                 `protobuf:"bytes,7,opt,name=access_mode,json=accessMode,proto3" json:"access_mode,omitempty"`             // Defaults to single-node-writer
+	AttachmentMode string                 `protobuf:"bytes,8,opt,name=attachment_mode,json=attachmentMode,proto3" json:"attachment_mode,omitempty"` // Defaults to file-system
+	FsType         string                 `protobuf:"bytes,9,opt,name=fs_type,json=fsType,proto3" json:"fs_type,omitempty"`
+	Parameters     map[string]string      `protobuf:"bytes,10,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Passed to the plugin, e.g. the storage class
+	Secrets        map[string]string      `protobuf:"bytes,11,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Namespace      string                 `protobuf:"bytes,12,opt,name=namespace,proto3" json:"namespace,omitempty"` // Nomad namespace of the volume, default when empty
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateVolumeRequest) Reset() {
+	*x = CreateVolumeRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVolumeRequest) ProtoMessage() {}
+
+func (x *CreateVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVolumeRequest.ProtoReflect.Descriptor instead.
+func (*CreateVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *CreateVolumeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateVolumeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateVolumeRequest) GetPluginId() string {
+	if x != nil {
+		return x.PluginId
+	}
+	return ""
+}
+
+func (x *CreateVolumeRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *CreateVolumeRequest) GetCapacityMinMb() int64 {
+	if x != nil {
+		return x.CapacityMinMb
+	}
+	return 0
+}
+
+func (x *CreateVolumeRequest) GetCapacityMaxMb() int64 {
+	if x != nil {
+		return x.CapacityMaxMb
+	}
+	return 0
+}
+
+func (x *CreateVolumeRequest) GetAccessMode() string {
+	if x != nil {
+		return x.AccessMode
+	}
+	return ""
+}
+
+func (x *CreateVolumeRequest) GetAttachmentMode() string {
+	if x != nil {
+		return x.AttachmentMode
+	}
+	return ""
+}
+
+func (x *CreateVolumeRequest) GetFsType() string {
+	if x != nil {
+		return x.FsType
+	}
+	return ""
+}
+
+func (x *CreateVolumeRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *CreateVolumeRequest) GetSecrets() map[string]string {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+func (x *CreateVolumeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type CreateVolumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	VolumeId      string                 `protobuf:"bytes,3,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	ExternalId    string                 `protobuf:"bytes,4,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	CapacityMb    int64                  `protobuf:"varint,5,opt,name=capacity_mb,json=capacityMb,proto3" json:"capacity_mb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVolumeResponse) Reset() {
+	*x = CreateVolumeResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVolumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVolumeResponse) ProtoMessage() {}
+
+func (x *CreateVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVolumeResponse.ProtoReflect.Descriptor instead.
+func (*CreateVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *CreateVolumeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateVolumeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateVolumeResponse) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *CreateVolumeResponse) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *CreateVolumeResponse) GetCapacityMb() int64 {
+	if x != nil {
+		return x.CapacityMb
+	}
+	return 0
+}
+
+type ListVolumesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PluginId      string                 `protobuf:"bytes,1,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"` // Lists the volumes of every plugin when empty
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`               // Nomad namespace of the volumes, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVolumesRequest) Reset() {
+	*x = ListVolumesRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVolumesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVolumesRequest) ProtoMessage() {}
+
+func (x *ListVolumesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVolumesRequest.ProtoReflect.Descriptor instead.
+func (*ListVolumesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *ListVolumesRequest) GetPluginId() string {
+	if x != nil {
+		return x.PluginId
+	}
+	return ""
+}
+
+func (x *ListVolumesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type Volume struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PluginId       string                 `protobuf:"bytes,3,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"`
+	ExternalId     string                 `protobuf:"bytes,4,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	AccessMode     string                 `protobuf:"bytes,5,opt,name=access_mode,json=accessMode,proto3" json:"access_mode,omitempty"`
+	AttachmentMode string                 `protobuf:"bytes,6,opt,name=attachment_mode,json=attachmentMode,proto3" json:"attachment_mode,omitempty"`
+	Schedulable    bool                   `protobuf:"varint,7,opt,name=schedulable,proto3" json:"schedulable,omitempty"`
+	Readers        int32                  `protobuf:"varint,8,opt,name=readers,proto3" json:"readers,omitempty"` // Allocations claiming the volume
+	Writers        int32                  `protobuf:"varint,9,opt,name=writers,proto3" json:"writers,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Volume) Reset() {
+	*x = Volume{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Volume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Volume) ProtoMessage() {}
+
+func (x *Volume) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Volume.ProtoReflect.Descriptor instead.
+func (*Volume) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *Volume) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Volume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Volume) GetPluginId() string {
+	if x != nil {
+		return x.PluginId
+	}
+	return ""
+}
+
+func (x *Volume) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *Volume) GetAccessMode() string {
+	if x != nil {
+		return x.AccessMode
+	}
+	return ""
+}
+
+func (x *Volume) GetAttachmentMode() string {
+	if x != nil {
+		return x.AttachmentMode
+	}
+	return ""
+}
+
+func (x *Volume) GetSchedulable() bool {
+	if x != nil {
+		return x.Schedulable
+	}
+	return false
+}
+
+func (x *Volume) GetReaders() int32 {
+	if x != nil {
+		return x.Readers
+	}
+	return 0
+}
+
+func (x *Volume) GetWriters() int32 {
+	if x != nil {
+		return x.Writers
+	}
+	return 0
+}
+
+type ListVolumesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Volumes       []*Volume              `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVolumesResponse) Reset() {
+	*x = ListVolumesResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVolumesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVolumesResponse) ProtoMessage() {}
+
+func (x *ListVolumesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVolumesResponse.ProtoReflect.Descriptor instead.
+func (*ListVolumesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *ListVolumesResponse) GetVolumes() []*Volume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *ListVolumesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DeleteVolumeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeregisterOnly bool                   `protobuf:"varint,2,opt,name=deregister_only,json=deregisterOnly,proto3" json:"deregister_only,omitempty"` // Keep the volume at the storage provider
+	Namespace      string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`                                  // Nomad namespace of the volume, default when empty
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteVolumeRequest) Reset() {
+	*x = DeleteVolumeRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVolumeRequest) ProtoMessage() {}
+
+func (x *DeleteVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVolumeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *DeleteVolumeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteVolumeRequest) GetDeregisterOnly() bool {
+	if x != nil {
+		return x.DeregisterOnly
+	}
+	return false
+}
+
+func (x *DeleteVolumeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type DeleteVolumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVolumeResponse) Reset() {
+	*x = DeleteVolumeResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVolumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVolumeResponse) ProtoMessage() {}
+
+func (x *DeleteVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVolumeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *DeleteVolumeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteVolumeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupRequest) Reset() {
+	*x = BackupRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupRequest) ProtoMessage() {}
+
+func (x *BackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupRequest.ProtoReflect.Descriptor instead.
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *BackupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BackupRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type Snapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Application   string                 `protobuf:"bytes,2,opt,name=application,proto3" json:"application,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`            // running, complete or failed
+	JobId         string                 `protobuf:"bytes,5,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // Dispatched backup job, its logs explain failures
+	StartedAt     int64                  `protobuf:"varint,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    int64                  `protobuf:"varint,7,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *Snapshot) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Snapshot) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *Snapshot) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Snapshot) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Snapshot) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *Snapshot) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *Snapshot) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+type BackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Snapshot      *Snapshot              `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"` // Still running, see ListSnapshots
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupResponse) Reset() {
+	*x = BackupResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupResponse) ProtoMessage() {}
+
+func (x *BackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupResponse.ProtoReflect.Descriptor instead.
+func (*BackupResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *BackupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BackupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BackupResponse) GetSnapshot() *Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type ListSnapshotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsRequest) Reset() {
+	*x = ListSnapshotsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsRequest) ProtoMessage() {}
+
+func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *ListSnapshotsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListSnapshotsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ListSnapshotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshots     []*Snapshot            `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsResponse) Reset() {
+	*x = ListSnapshotsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *ListSnapshotsResponse) GetSnapshots() []*Snapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+func (x *ListSnapshotsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Overwrites the volumes of a stopped application with a snapshot
+type RestoreVolumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"` // Defaults to the latest complete snapshot
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`                     // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreVolumeRequest) Reset() {
+	*x = RestoreVolumeRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreVolumeRequest) ProtoMessage() {}
+
+func (x *RestoreVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreVolumeRequest.ProtoReflect.Descriptor instead.
+func (*RestoreVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *RestoreVolumeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RestoreVolumeRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *RestoreVolumeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type RestoreVolumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	SnapshotId    string                 `protobuf:"bytes,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	JobId         string                 `protobuf:"bytes,4,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreVolumeResponse) Reset() {
+	*x = RestoreVolumeResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreVolumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreVolumeResponse) ProtoMessage() {}
+
+func (x *RestoreVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreVolumeResponse.ProtoReflect.Descriptor instead.
+func (*RestoreVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *RestoreVolumeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RestoreVolumeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RestoreVolumeResponse) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *RestoreVolumeResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// Claims a custom domain for a tenant, it is verified with a DNS TXT record
+type AddDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"` // Covers its subdomains too
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDomainRequest) Reset() {
+	*x = AddDomainRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDomainRequest) ProtoMessage() {}
+
+func (x *AddDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDomainRequest.ProtoReflect.Descriptor instead.
+func (*AddDomainRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *AddDomainRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *AddDomainRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+type Domain struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tenant        string                 `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Verified      bool                   `protobuf:"varint,3,opt,name=verified,proto3" json:"verified,omitempty"`
+	RecordName    string                 `protobuf:"bytes,4,opt,name=record_name,json=recordName,proto3" json:"record_name,omitempty"` // TXT record to create, e.g. _controlplane-challenge.example.com
+	RecordValue   string                 `protobuf:"bytes,5,opt,name=record_value,json=recordValue,proto3" json:"record_value,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	VerifiedAt    int64                  `protobuf:"varint,7,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"`
+	CheckedAt     int64                  `protobuf:"varint,8,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	LastError     string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"` // Why the last check failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Domain) Reset() {
+	*x = Domain{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Domain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Domain) ProtoMessage() {}
+
+func (x *Domain) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Domain.ProtoReflect.Descriptor instead.
+func (*Domain) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *Domain) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Domain) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *Domain) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *Domain) GetRecordName() string {
+	if x != nil {
+		return x.RecordName
+	}
+	return ""
+}
+
+func (x *Domain) GetRecordValue() string {
+	if x != nil {
+		return x.RecordValue
+	}
+	return ""
+}
+
+func (x *Domain) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Domain) GetVerifiedAt() int64 {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return 0
+}
+
+func (x *Domain) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
+}
+
+func (x *Domain) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type AddDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Domain        *Domain                `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDomainResponse) Reset() {
+	*x = AddDomainResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDomainResponse) ProtoMessage() {}
+
+func (x *AddDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDomainResponse.ProtoReflect.Descriptor instead.
+func (*AddDomainResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *AddDomainResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AddDomainResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AddDomainResponse) GetDomain() *Domain {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+// Checks the TXT record of a claimed domain now instead of waiting for the controller
+type VerifyDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDomainRequest) Reset() {
+	*x = VerifyDomainRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainRequest) ProtoMessage() {}
+
+func (x *VerifyDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainRequest.ProtoReflect.Descriptor instead.
+func (*VerifyDomainRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *VerifyDomainRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *VerifyDomainRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+type VerifyDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // The domain is verified
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Domain        *Domain                `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDomainResponse) Reset() {
+	*x = VerifyDomainResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainResponse) ProtoMessage() {}
+
+func (x *VerifyDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainResponse.ProtoReflect.Descriptor instead.
+func (*VerifyDomainResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *VerifyDomainResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *VerifyDomainResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *VerifyDomainResponse) GetDomain() *Domain {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+type ListDomainsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"` // All tenants when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDomainsRequest) Reset() {
+	*x = ListDomainsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDomainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainsRequest) ProtoMessage() {}
+
+func (x *ListDomainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainsRequest.ProtoReflect.Descriptor instead.
+func (*ListDomainsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ListDomainsRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type ListDomainsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domains       []*Domain              `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDomainsResponse) Reset() {
+	*x = ListDomainsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDomainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainsResponse) ProtoMessage() {}
+
+func (x *ListDomainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainsResponse.ProtoReflect.Descriptor instead.
+func (*ListDomainsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ListDomainsResponse) GetDomains() []*Domain {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+func (x *ListDomainsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImageDriftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                   // All applications when empty
+	DriftedOnly   bool                   `protobuf:"varint,2,opt,name=drifted_only,json=driftedOnly,proto3" json:"drifted_only,omitempty"` // Only applications whose tag moved
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageDriftRequest) Reset() {
+	*x = ImageDriftRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageDriftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageDriftRequest) ProtoMessage() {}
+
+func (x *ImageDriftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageDriftRequest.ProtoReflect.Descriptor instead.
+func (*ImageDriftRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *ImageDriftRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImageDriftRequest) GetDriftedOnly() bool {
+	if x != nil {
+		return x.DriftedOnly
+	}
+	return false
+}
+
+// The image of an application compared with the registry, its tag may have moved since the deploy
+type ImageDrift struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Application        string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Image              string                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	DeployedDigest     string                 `protobuf:"bytes,3,opt,name=deployed_digest,json=deployedDigest,proto3" json:"deployed_digest,omitempty"`
+	TagDigest          string                 `protobuf:"bytes,4,opt,name=tag_digest,json=tagDigest,proto3" json:"tag_digest,omitempty"`                            // Set while the tag resolves to another digest
+	DriftedAllocations []string               `protobuf:"bytes,5,rep,name=drifted_allocations,json=driftedAllocations,proto3" json:"drifted_allocations,omitempty"` // (Re)started since the tag moved, they may run tag_digest
+	PinOnDrift         bool                   `protobuf:"varint,6,opt,name=pin_on_drift,json=pinOnDrift,proto3" json:"pin_on_drift,omitempty"`
+	DeployedAt         int64                  `protobuf:"varint,7,opt,name=deployed_at,json=deployedAt,proto3" json:"deployed_at,omitempty"`
+	CheckedAt          int64                  `protobuf:"varint,8,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	MovedAt            int64                  `protobuf:"varint,9,opt,name=moved_at,json=movedAt,proto3" json:"moved_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ImageDrift) Reset() {
+	*x = ImageDrift{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageDrift) ProtoMessage() {}
+
+func (x *ImageDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageDrift.ProtoReflect.Descriptor instead.
+func (*ImageDrift) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *ImageDrift) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *ImageDrift) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ImageDrift) GetDeployedDigest() string {
+	if x != nil {
+		return x.DeployedDigest
+	}
+	return ""
+}
+
+func (x *ImageDrift) GetTagDigest() string {
+	if x != nil {
+		return x.TagDigest
+	}
+	return ""
+}
+
+func (x *ImageDrift) GetDriftedAllocations() []string {
+	if x != nil {
+		return x.DriftedAllocations
+	}
+	return nil
+}
+
+func (x *ImageDrift) GetPinOnDrift() bool {
+	if x != nil {
+		return x.PinOnDrift
+	}
+	return false
+}
+
+func (x *ImageDrift) GetDeployedAt() int64 {
+	if x != nil {
+		return x.DeployedAt
+	}
+	return 0
+}
+
+func (x *ImageDrift) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
+}
+
+func (x *ImageDrift) GetMovedAt() int64 {
+	if x != nil {
+		return x.MovedAt
+	}
+	return 0
+}
+
+type ImageDriftResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Images        []*ImageDrift          `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageDriftResponse) Reset() {
+	*x = ImageDriftResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageDriftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageDriftResponse) ProtoMessage() {}
+
+func (x *ImageDriftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageDriftResponse.ProtoReflect.Descriptor instead.
+func (*ImageDriftResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *ImageDriftResponse) GetImages() []*ImageDrift {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *ImageDriftResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RestartAnomaliesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // All applications when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestartAnomaliesRequest) Reset() {
+	*x = RestartAnomaliesRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestartAnomaliesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartAnomaliesRequest) ProtoMessage() {}
+
+func (x *RestartAnomaliesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartAnomaliesRequest.ProtoReflect.Descriptor instead.
+func (*RestartAnomaliesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *RestartAnomaliesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// An allocation which restarted during a restart spike
+type RestartedAllocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Restarts      int32                  `protobuf:"varint,2,opt,name=restarts,proto3" json:"restarts,omitempty"`                   // During the last check
+	LastEvent     string                 `protobuf:"bytes,3,opt,name=last_event,json=lastEvent,proto3" json:"last_event,omitempty"` // The last event of its tasks, e.g. why it restarted
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`                              // Its page in the Nomad UI
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestartedAllocation) Reset() {
+	*x = RestartedAllocation{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestartedAllocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartedAllocation) ProtoMessage() {}
+
+func (x *RestartedAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartedAllocation.ProtoReflect.Descriptor instead.
+func (*RestartedAllocation) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *RestartedAllocation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RestartedAllocation) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+func (x *RestartedAllocation) GetLastEvent() string {
+	if x != nil {
+		return x.LastEvent
+	}
+	return ""
+}
+
+func (x *RestartedAllocation) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// An application restarting far more often than its baseline, e.g. crash looping
+type RestartAnomaly struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Application   string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Restarts      int32                  `protobuf:"varint,2,opt,name=restarts,proto3" json:"restarts,omitempty"`  // During the last check
+	Baseline      float64                `protobuf:"fixed64,3,opt,name=baseline,proto3" json:"baseline,omitempty"` // Mean restarts per check before the spike
+	Since         int64                  `protobuf:"varint,4,opt,name=since,proto3" json:"since,omitempty"`
+	CheckedAt     int64                  `protobuf:"varint,5,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	Allocations   []*RestartedAllocation `protobuf:"bytes,6,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	Links         map[string]string      `protobuf:"bytes,7,rep,name=links,proto3" json:"links,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Label to URL, the links of the application's Nomad UI page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestartAnomaly) Reset() {
+	*x = RestartAnomaly{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestartAnomaly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartAnomaly) ProtoMessage() {}
+
+func (x *RestartAnomaly) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartAnomaly.ProtoReflect.Descriptor instead.
+func (*RestartAnomaly) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *RestartAnomaly) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *RestartAnomaly) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+func (x *RestartAnomaly) GetBaseline() float64 {
+	if x != nil {
+		return x.Baseline
+	}
+	return 0
+}
+
+func (x *RestartAnomaly) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *RestartAnomaly) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
+}
+
+func (x *RestartAnomaly) GetAllocations() []*RestartedAllocation {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+func (x *RestartAnomaly) GetLinks() map[string]string {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type RestartAnomaliesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Anomalies     []*RestartAnomaly      `protobuf:"bytes,1,rep,name=anomalies,proto3" json:"anomalies,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestartAnomaliesResponse) Reset() {
+	*x = RestartAnomaliesResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestartAnomaliesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartAnomaliesResponse) ProtoMessage() {}
+
+func (x *RestartAnomaliesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartAnomaliesResponse.ProtoReflect.Descriptor instead.
+func (*RestartAnomaliesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *RestartAnomaliesResponse) GetAnomalies() []*RestartAnomaly {
+	if x != nil {
+		return x.Anomalies
+	}
+	return nil
+}
+
+func (x *RestartAnomaliesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Asks for the history of an application, e.g. for a timeline view
+type TimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Since         int64                  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`                                  // Unix seconds, the last 7 days when 0
+	AllocationId  string                 `protobuf:"bytes,3,opt,name=allocation_id,json=allocationId,proto3" json:"allocation_id,omitempty"` // Only the events of this allocation, a prefix of its ID is enough
+	Types         []string               `protobuf:"bytes,4,rep,name=types,proto3" json:"types,omitempty"`                                   // Only events of these types, all when empty
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                                  // The most recent events returned, 200 when 0
+	Namespace     string                 `protobuf:"bytes,6,opt,name=namespace,proto3" json:"namespace,omitempty"`                           // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimelineRequest) Reset() {
+	*x = TimelineRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineRequest) ProtoMessage() {}
+
+func (x *TimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineRequest.ProtoReflect.Descriptor instead.
+func (*TimelineRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *TimelineRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TimelineRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *TimelineRequest) GetAllocationId() string {
+	if x != nil {
+		return x.AllocationId
+	}
+	return ""
+}
+
+func (x *TimelineRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *TimelineRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *TimelineRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// An event in the history of an application
+type TimelineEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          int64                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"` // Unix seconds
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`  // deploy, rollout, scaling, placement, health, restart, failure, reschedule or stop
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	AllocationId  string                 `protobuf:"bytes,4,opt,name=allocation_id,json=allocationId,proto3" json:"allocation_id,omitempty"`
+	Task          string                 `protobuf:"bytes,5,opt,name=task,proto3" json:"task,omitempty"`
+	Node          string                 `protobuf:"bytes,6,opt,name=node,proto3" json:"node,omitempty"`
+	JobVersion    uint64                 `protobuf:"varint,7,opt,name=job_version,json=jobVersion,proto3" json:"job_version,omitempty"`
+	DeploymentId  string                 `protobuf:"bytes,8,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimelineEvent) Reset() {
+	*x = TimelineEvent{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineEvent) ProtoMessage() {}
+
+func (x *TimelineEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineEvent.ProtoReflect.Descriptor instead.
+func (*TimelineEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *TimelineEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *TimelineEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetAllocationId() string {
+	if x != nil {
+		return x.AllocationId
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetJobVersion() uint64 {
+	if x != nil {
+		return x.JobVersion
+	}
+	return 0
+}
+
+func (x *TimelineEvent) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type TimelineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Events        []*TimelineEvent       `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimelineResponse) Reset() {
+	*x = TimelineResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineResponse) ProtoMessage() {}
+
+func (x *TimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineResponse.ProtoReflect.Descriptor instead.
+func (*TimelineResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *TimelineResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TimelineResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TimelineResponse) GetEvents() []*TimelineEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Terms joined by AND, each field=value, field!=value or field~substring over name, image,
+// owner, tenant, host, status or label.<key>, e.g. image~nginx AND status=degraded
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`         // Every application when empty
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`        // Defaults to 100
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"` // Only applications of this namespace, default when empty, * for every namespace
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Tenant        string                 `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Owner         string                 `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"` // The owner label, or else the tenant
+	Image         string                 `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Hosts         []string               `protobuf:"bytes,7,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // Health as of updated_at: healthy, progressing, degraded, suspended or unknown
+	UpdatedAt     int64                  `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *SearchResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchResult) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *SearchResult) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *SearchResult) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SearchResult) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *SearchResult) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *SearchResult) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *SearchResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SearchResult) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Results       []*SearchResult        `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"` // Ordered by name
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`    // Matches before the limit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *SearchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SearchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// Lists the deployment specs an application was submitted with, as recorded by the registry
+type ListRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // Application name or job ID
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                  // Defaults to every recorded revision
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`                           // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRevisionsRequest) Reset() {
+	*x = ListRevisionsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevisionsRequest) ProtoMessage() {}
+
+func (x *ListRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *ListRevisionsRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *ListRevisionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRevisionsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type Revision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      int32                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Owner         string                 `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	DeploymentId  string                 `protobuf:"bytes,5,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // Nomad evaluation of the deployment
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`         // Unix seconds
+	Spec          *DeployRequest         `protobuf:"bytes,7,opt,name=spec,proto3" json:"spec,omitempty"`                                     // Sensitive values of its env are redacted
+	EnvChanges    []*EnvChange           `protobuf:"bytes,8,rep,name=env_changes,json=envChanges,proto3" json:"env_changes,omitempty"`       // Changes of the env from the previous revision, by key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Revision) Reset() {
+	*x = Revision{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revision) ProtoMessage() {}
+
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revision.ProtoReflect.Descriptor instead.
+func (*Revision) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *Revision) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *Revision) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *Revision) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Revision) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Revision) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *Revision) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Revision) GetSpec() *DeployRequest {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *Revision) GetEnvChanges() []*EnvChange {
+	if x != nil {
+		return x.EnvChanges
+	}
+	return nil
+}
+
+// A change of an environment variable from one revision to the next. Values of keys matching
+// the controller's sensitive patterns, or containing a secret of the application, are redacted.
+type EnvChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Change        string                 `protobuf:"bytes,2,opt,name=change,proto3" json:"change,omitempty"` // added, changed or removed
+	OldValue      string                 `protobuf:"bytes,3,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      string                 `protobuf:"bytes,4,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	Redacted      bool                   `protobuf:"varint,5,opt,name=redacted,proto3" json:"redacted,omitempty"` // The values are replaced by <redacted>, the change is still shown
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnvChange) Reset() {
+	*x = EnvChange{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvChange) ProtoMessage() {}
+
+func (x *EnvChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvChange.ProtoReflect.Descriptor instead.
+func (*EnvChange) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *EnvChange) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *EnvChange) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+func (x *EnvChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *EnvChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+func (x *EnvChange) GetRedacted() bool {
+	if x != nil {
+		return x.Redacted
+	}
+	return false
+}
+
+type ListRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Revisions     []*Revision            `protobuf:"bytes,3,rep,name=revisions,proto3" json:"revisions,omitempty"` // Most recent first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRevisionsResponse) Reset() {
+	*x = ListRevisionsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevisionsResponse) ProtoMessage() {}
+
+func (x *ListRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *ListRevisionsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListRevisionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListRevisionsResponse) GetRevisions() []*Revision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// Creates or updates a project, applications join it with the label project=<name>
+type SaveProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tenant        string                 `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"` // Applications of other tenants cannot join it
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Defaults      *DeployRequest         `protobuf:"bytes,4,opt,name=defaults,proto3" json:"defaults,omitempty"` // Merged under the specs of its applications, lists of a spec replace those of the defaults
+	Quota         *ProjectQuota          `protobuf:"bytes,5,opt,name=quota,proto3" json:"quota,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveProjectRequest) Reset() {
+	*x = SaveProjectRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveProjectRequest) ProtoMessage() {}
+
+func (x *SaveProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveProjectRequest.ProtoReflect.Descriptor instead.
+func (*SaveProjectRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *SaveProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SaveProjectRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *SaveProjectRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SaveProjectRequest) GetDefaults() *DeployRequest {
+	if x != nil {
+		return x.Defaults
+	}
+	return nil
+}
+
+func (x *SaveProjectRequest) GetQuota() *ProjectQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+// Zero is no limit
+type ProjectQuota struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Cpu             float64                `protobuf:"fixed64,1,opt,name=cpu,proto3" json:"cpu,omitempty"` // Cores requested by every replica of the applications
+	MemoryMb        int64                  `protobuf:"varint,2,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	MaxApplications int32                  `protobuf:"varint,3,opt,name=max_applications,json=maxApplications,proto3" json:"max_applications,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProjectQuota) Reset() {
+	*x = ProjectQuota{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[136]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectQuota) ProtoMessage() {}
+
+func (x *ProjectQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[136]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectQuota.ProtoReflect.Descriptor instead.
+func (*ProjectQuota) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *ProjectQuota) GetCpu() float64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *ProjectQuota) GetMemoryMb() int64 {
+	if x != nil {
+		return x.MemoryMb
+	}
+	return 0
+}
+
+func (x *ProjectQuota) GetMaxApplications() int32 {
+	if x != nil {
+		return x.MaxApplications
+	}
+	return 0
+}
+
+type SaveProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveProjectResponse) Reset() {
+	*x = SaveProjectResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveProjectResponse) ProtoMessage() {}
+
+func (x *SaveProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveProjectResponse.ProtoReflect.Descriptor instead.
+func (*SaveProjectResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *SaveProjectResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SaveProjectResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Deletes a project without applications
+type DeleteProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *DeleteProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectResponse) Reset() {
+	*x = DeleteProjectResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectResponse) ProtoMessage() {}
+
+func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{139}
+}
+
+func (x *DeleteProjectResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteProjectResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"` // Defaults to the projects of every tenant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[140]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *ListProjectsRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type ProjectSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tenant        string                 `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                                                                // Worst health of its applications: degraded, progressing, unknown, healthy or suspended, empty without applications
+	Statuses      map[string]int32       `protobuf:"bytes,5,rep,name=statuses,proto3" json:"statuses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Applications per health
+	Applications  int32                  `protobuf:"varint,6,opt,name=applications,proto3" json:"applications,omitempty"`
+	Quota         *ProjectQuota          `protobuf:"bytes,7,opt,name=quota,proto3" json:"quota,omitempty"`
+	Usage         *ProjectQuota          `protobuf:"bytes,8,opt,name=usage,proto3" json:"usage,omitempty"` // What its applications request, max_applications is their number
+	CreatedAt     int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectSummary) Reset() {
+	*x = ProjectSummary{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[141]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectSummary) ProtoMessage() {}
+
+func (x *ProjectSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[141]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectSummary.ProtoReflect.Descriptor instead.
+func (*ProjectSummary) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{141}
+}
+
+func (x *ProjectSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProjectSummary) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *ProjectSummary) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProjectSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProjectSummary) GetStatuses() map[string]int32 {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ProjectSummary) GetApplications() int32 {
+	if x != nil {
+		return x.Applications
+	}
+	return 0
+}
+
+func (x *ProjectSummary) GetQuota() *ProjectQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *ProjectSummary) GetUsage() *ProjectQuota {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *ProjectSummary) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ListProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Projects      []*ProjectSummary      `protobuf:"bytes,3,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[142]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[142]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *ListProjectsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListProjectsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListProjectsResponse) GetProjects() []*ProjectSummary {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[143]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[143]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *GetProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Project       *ProjectSummary        `protobuf:"bytes,3,opt,name=project,proto3" json:"project,omitempty"`
+	Defaults      *DeployRequest         `protobuf:"bytes,4,opt,name=defaults,proto3" json:"defaults,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectResponse) Reset() {
+	*x = GetProjectResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[144]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectResponse) ProtoMessage() {}
+
+func (x *GetProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[144]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectResponse.ProtoReflect.Descriptor instead.
+func (*GetProjectResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{144}
+}
+
+func (x *GetProjectResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetProjectResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetProjectResponse) GetProject() *ProjectSummary {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *GetProjectResponse) GetDefaults() *DeployRequest {
+	if x != nil {
+		return x.Defaults
+	}
+	return nil
+}
+
+// Creates a namespace and the Nomad namespace of the same name, applications and volumes
+// deployed to it are only seen by the tokens scoped to it
+type CreateNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Lowercase DNS label, required
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Tenant        string                 `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"` // Only the applications of this tenant may be deployed to it, any when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceRequest) Reset() {
+	*x = CreateNamespaceRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[145]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceRequest) ProtoMessage() {}
+
+func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[145]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{145}
+}
+
+func (x *CreateNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateNamespaceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateNamespaceRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type NamespaceInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Tenant        string                 `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Applications  int32                  `protobuf:"varint,4,opt,name=applications,proto3" json:"applications,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamespaceInfo) Reset() {
+	*x = NamespaceInfo{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[146]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceInfo) ProtoMessage() {}
+
+func (x *NamespaceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[146]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceInfo.ProtoReflect.Descriptor instead.
+func (*NamespaceInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{146}
+}
+
+func (x *NamespaceInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NamespaceInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *NamespaceInfo) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *NamespaceInfo) GetApplications() int32 {
+	if x != nil {
+		return x.Applications
+	}
+	return 0
+}
+
+func (x *NamespaceInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type CreateNamespaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Namespace     *NamespaceInfo         `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceResponse) Reset() {
+	*x = CreateNamespaceResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[147]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceResponse) ProtoMessage() {}
+
+func (x *CreateNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[147]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{147}
+}
+
+func (x *CreateNamespaceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateNamespaceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateNamespaceResponse) GetNamespace() *NamespaceInfo {
+	if x != nil {
+		return x.Namespace
+	}
+	return nil
+}
+
+type ListNamespacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesRequest) Reset() {
+	*x = ListNamespacesRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[148]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesRequest) ProtoMessage() {}
+
+func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[148]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
+func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{148}
+}
+
+type ListNamespacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Namespaces    []*NamespaceInfo       `protobuf:"bytes,3,rep,name=namespaces,proto3" json:"namespaces,omitempty"` // The ones the token is scoped to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesResponse) Reset() {
+	*x = ListNamespacesResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[149]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesResponse) ProtoMessage() {}
+
+func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[149]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
+func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{149}
+}
+
+func (x *ListNamespacesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListNamespacesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListNamespacesResponse) GetNamespaces() []*NamespaceInfo {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+// Deletes an empty namespace and its Nomad namespace
+type DeleteNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNamespaceRequest) Reset() {
+	*x = DeleteNamespaceRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[150]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNamespaceRequest) ProtoMessage() {}
+
+func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[150]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{150}
+}
+
+func (x *DeleteNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteNamespaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNamespaceResponse) Reset() {
+	*x = DeleteNamespaceResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[151]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNamespaceResponse) ProtoMessage() {}
+
+func (x *DeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[151]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{151}
+}
+
+func (x *DeleteNamespaceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteNamespaceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Attaches an SBOM or provenance attestation of an image to an application, e.g. from CI
+// before deploying it
+type AttachArtifactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Application   string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Image         string                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"` // The image described, its tag is resolved to a digest
+	Kind          ArtifactKind           `protobuf:"varint,3,opt,name=kind,proto3,enum=controlplane.ArtifactKind" json:"kind,omitempty"`
+	MediaType     string                 `protobuf:"bytes,4,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"` // e.g. application/spdx+json, defaults to application/vnd.dsse.envelope.v1+json for attestations
+	Content       []byte                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`                      // Attestations are DSSE envelopes, verified when the controller has a verifier
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachArtifactRequest) Reset() {
+	*x = AttachArtifactRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[152]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachArtifactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachArtifactRequest) ProtoMessage() {}
+
+func (x *AttachArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[152]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachArtifactRequest.ProtoReflect.Descriptor instead.
+func (*AttachArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{152}
+}
+
+func (x *AttachArtifactRequest) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *AttachArtifactRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *AttachArtifactRequest) GetKind() ArtifactKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ArtifactKind_ARTIFACT_KIND_UNSPECIFIED
+}
+
+func (x *AttachArtifactRequest) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *AttachArtifactRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type Artifact struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Application       string                 `protobuf:"bytes,2,opt,name=application,proto3" json:"application,omitempty"`
+	Image             string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Digest            string                 `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+	Kind              ArtifactKind           `protobuf:"varint,5,opt,name=kind,proto3,enum=controlplane.ArtifactKind" json:"kind,omitempty"`
+	MediaType         string                 `protobuf:"bytes,6,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	Size              int64                  `protobuf:"varint,7,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256            string                 `protobuf:"bytes,8,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Verified          bool                   `protobuf:"varint,9,opt,name=verified,proto3" json:"verified,omitempty"`
+	Signer            string                 `protobuf:"bytes,10,opt,name=signer,proto3" json:"signer,omitempty"`
+	VerificationError string                 `protobuf:"bytes,11,opt,name=verification_error,json=verificationError,proto3" json:"verification_error,omitempty"`
+	CreatedAt         int64                  `protobuf:"varint,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[153]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artifact) ProtoMessage() {}
+
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[153]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{153}
+}
+
+func (x *Artifact) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Artifact) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *Artifact) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Artifact) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *Artifact) GetKind() ArtifactKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ArtifactKind_ARTIFACT_KIND_UNSPECIFIED
+}
+
+func (x *Artifact) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *Artifact) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Artifact) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Artifact) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *Artifact) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+	return ""
+}
+
+func (x *Artifact) GetVerificationError() string {
+	if x != nil {
+		return x.VerificationError
+	}
+	return ""
+}
+
+func (x *Artifact) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type AttachArtifactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Artifact      *Artifact              `protobuf:"bytes,3,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachArtifactResponse) Reset() {
+	*x = AttachArtifactResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[154]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachArtifactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachArtifactResponse) ProtoMessage() {}
+
+func (x *AttachArtifactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[154]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachArtifactResponse.ProtoReflect.Descriptor instead.
+func (*AttachArtifactResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{154}
+}
+
+func (x *AttachArtifactResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AttachArtifactResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AttachArtifactResponse) GetArtifact() *Artifact {
+	if x != nil {
+		return x.Artifact
+	}
+	return nil
+}
+
+type ListArtifactsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Application   string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Digest        string                 `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"` // Only the artifacts of this image
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArtifactsRequest) Reset() {
+	*x = ListArtifactsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[155]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArtifactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArtifactsRequest) ProtoMessage() {}
+
+func (x *ListArtifactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[155]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArtifactsRequest.ProtoReflect.Descriptor instead.
+func (*ListArtifactsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{155}
+}
+
+func (x *ListArtifactsRequest) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *ListArtifactsRequest) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+type ListArtifactsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Artifacts     []*Artifact            `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArtifactsResponse) Reset() {
+	*x = ListArtifactsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[156]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArtifactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArtifactsResponse) ProtoMessage() {}
+
+func (x *ListArtifactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[156]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArtifactsResponse.ProtoReflect.Descriptor instead.
+func (*ListArtifactsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{156}
+}
+
+func (x *ListArtifactsResponse) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *ListArtifactsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetArtifactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Application   string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArtifactRequest) Reset() {
+	*x = GetArtifactRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[157]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArtifactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArtifactRequest) ProtoMessage() {}
+
+func (x *GetArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[157]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArtifactRequest.ProtoReflect.Descriptor instead.
+func (*GetArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{157}
+}
+
+func (x *GetArtifactRequest) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *GetArtifactRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetArtifactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Artifact      *Artifact              `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArtifactResponse) Reset() {
+	*x = GetArtifactResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[158]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArtifactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArtifactResponse) ProtoMessage() {}
+
+func (x *GetArtifactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[158]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArtifactResponse.ProtoReflect.Descriptor instead.
+func (*GetArtifactResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{158}
+}
+
+func (x *GetArtifactResponse) GetArtifact() *Artifact {
+	if x != nil {
+		return x.Artifact
+	}
+	return nil
+}
+
+func (x *GetArtifactResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *GetArtifactResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Deploys or updates Traefik as a system job, its entrypoints and cert resolvers come from
+// the controller's config
+type BootstrapEdgeProxyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Image         string                 `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`                          // Defaults to the controller's -edge-image
+	Datacenters   []string               `protobuf:"bytes,2,rep,name=datacenters,proto3" json:"datacenters,omitempty"`              // Defaults to dc1
+	NodeClass     string                 `protobuf:"bytes,3,opt,name=node_class,json=nodeClass,proto3" json:"node_class,omitempty"` // Only run on the clients of this class, e.g. edge
+	Region        string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	RedirectHttps bool                   `protobuf:"varint,5,opt,name=redirect_https,json=redirectHttps,proto3" json:"redirect_https,omitempty"`
+	Dashboard     bool                   `protobuf:"varint,6,opt,name=dashboard,proto3" json:"dashboard,omitempty"`              // Unauthenticated, on the metrics port
+	WakeUrl       string                 `protobuf:"bytes,7,opt,name=wake_url,json=wakeUrl,proto3" json:"wake_url,omitempty"`    // Wake proxy of applications scaled to zero, e.g. http://controller.service.consul:8081
+	DryRun        bool                   `protobuf:"varint,8,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`      // Only render the configuration
+	NodePool      string                 `protobuf:"bytes,9,opt,name=node_pool,json=nodePool,proto3" json:"node_pool,omitempty"` // Only run on the clients of this node pool, defaults to the default pool
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootstrapEdgeProxyRequest) Reset() {
+	*x = BootstrapEdgeProxyRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[159]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapEdgeProxyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapEdgeProxyRequest) ProtoMessage() {}
+
+func (x *BootstrapEdgeProxyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[159]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapEdgeProxyRequest.ProtoReflect.Descriptor instead.
+func (*BootstrapEdgeProxyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{159}
+}
+
+func (x *BootstrapEdgeProxyRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *BootstrapEdgeProxyRequest) GetDatacenters() []string {
+	if x != nil {
+		return x.Datacenters
+	}
+	return nil
+}
+
+func (x *BootstrapEdgeProxyRequest) GetNodeClass() string {
+	if x != nil {
+		return x.NodeClass
+	}
+	return ""
+}
+
+func (x *BootstrapEdgeProxyRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *BootstrapEdgeProxyRequest) GetRedirectHttps() bool {
+	if x != nil {
+		return x.RedirectHttps
+	}
+	return false
+}
+
+func (x *BootstrapEdgeProxyRequest) GetDashboard() bool {
+	if x != nil {
+		return x.Dashboard
+	}
+	return false
+}
+
+func (x *BootstrapEdgeProxyRequest) GetWakeUrl() string {
+	if x != nil {
+		return x.WakeUrl
+	}
+	return ""
+}
+
+func (x *BootstrapEdgeProxyRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *BootstrapEdgeProxyRequest) GetNodePool() string {
+	if x != nil {
+		return x.NodePool
+	}
+	return ""
+}
+
+type BootstrapEdgeProxyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	EvalId        string                 `protobuf:"bytes,3,opt,name=eval_id,json=evalId,proto3" json:"eval_id,omitempty"`
+	StaticConfig  string                 `protobuf:"bytes,4,opt,name=static_config,json=staticConfig,proto3" json:"static_config,omitempty"`
+	DynamicConfig string                 `protobuf:"bytes,5,opt,name=dynamic_config,json=dynamicConfig,proto3" json:"dynamic_config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootstrapEdgeProxyResponse) Reset() {
+	*x = BootstrapEdgeProxyResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[160]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapEdgeProxyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapEdgeProxyResponse) ProtoMessage() {}
+
+func (x *BootstrapEdgeProxyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[160]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapEdgeProxyResponse.ProtoReflect.Descriptor instead.
+func (*BootstrapEdgeProxyResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{160}
+}
+
+func (x *BootstrapEdgeProxyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BootstrapEdgeProxyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BootstrapEdgeProxyResponse) GetEvalId() string {
+	if x != nil {
+		return x.EvalId
+	}
+	return ""
+}
+
+func (x *BootstrapEdgeProxyResponse) GetStaticConfig() string {
+	if x != nil {
+		return x.StaticConfig
+	}
+	return ""
+}
+
+func (x *BootstrapEdgeProxyResponse) GetDynamicConfig() string {
+	if x != nil {
+		return x.DynamicConfig
+	}
+	return ""
+}
+
+// Provisions what the control plane expects in a fresh cluster, existing resources are kept
+type BootstrapPlatformRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Namespaces    []string                   `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`                               // Nomad namespaces to create
+	NodePools     []string                   `protobuf:"bytes,2,rep,name=node_pools,json=nodePools,proto3" json:"node_pools,omitempty"`                // Node pools to create besides those of the conventions
+	EdgeProxy     *BootstrapEdgeProxyRequest `protobuf:"bytes,3,opt,name=edge_proxy,json=edgeProxy,proto3" json:"edge_proxy,omitempty"`                // dry_run is ignored
+	SkipEdgeProxy bool                       `protobuf:"varint,4,opt,name=skip_edge_proxy,json=skipEdgeProxy,proto3" json:"skip_edge_proxy,omitempty"` // The cluster already runs an edge proxy
+	DefaultDeny   bool                       `protobuf:"varint,5,opt,name=default_deny,json=defaultDeny,proto3" json:"default_deny,omitempty"`         // Consul intention denying mesh traffic not explicitly allowed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootstrapPlatformRequest) Reset() {
+	*x = BootstrapPlatformRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[161]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapPlatformRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapPlatformRequest) ProtoMessage() {}
+
+func (x *BootstrapPlatformRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[161]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapPlatformRequest.ProtoReflect.Descriptor instead.
+func (*BootstrapPlatformRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{161}
+}
+
+func (x *BootstrapPlatformRequest) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *BootstrapPlatformRequest) GetNodePools() []string {
+	if x != nil {
+		return x.NodePools
+	}
+	return nil
+}
+
+func (x *BootstrapPlatformRequest) GetEdgeProxy() *BootstrapEdgeProxyRequest {
+	if x != nil {
+		return x.EdgeProxy
+	}
+	return nil
+}
+
+func (x *BootstrapPlatformRequest) GetSkipEdgeProxy() bool {
+	if x != nil {
+		return x.SkipEdgeProxy
+	}
+	return false
+}
+
+func (x *BootstrapPlatformRequest) GetDefaultDeny() bool {
+	if x != nil {
+		return x.DefaultDeny
+	}
+	return false
+}
+
+// Deploys the controllers as a Nomad job, settings the request leaves empty keep their
+// deployed value. New controllers surge next to the running ones, which are stopped once
+// every new one is healthy, and Nomad reverts to the previous version otherwise.
+type DeployControllerRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Image                string                 `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`  // Required for the first deployment
+	Count                int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"` // Defaults to 2
+	Args                 []string               `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`    // Controller flags, e.g. -nomad=http://nomad.service.consul:4646
+	Region               string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	Datacenters          []string               `protobuf:"bytes,5,rep,name=datacenters,proto3" json:"datacenters,omitempty"` // Defaults to dc1
+	NodePool             string                 `protobuf:"bytes,6,opt,name=node_pool,json=nodePool,proto3" json:"node_pool,omitempty"`
+	HealthTimeoutSeconds int32                  `protobuf:"varint,7,opt,name=health_timeout_seconds,json=healthTimeoutSeconds,proto3" json:"health_timeout_seconds,omitempty"` // How long a new controller has to become healthy, defaults to 180
+	ReplaceArgs          bool                   `protobuf:"varint,8,opt,name=replace_args,json=replaceArgs,proto3" json:"replace_args,omitempty"`                              // Replace the deployed args with args, even when empty
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *DeployControllerRequest) Reset() {
+	*x = DeployControllerRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[162]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployControllerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployControllerRequest) ProtoMessage() {}
+
+func (x *DeployControllerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[162]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeployControllerRequest.ProtoReflect.Descriptor instead.
+func (*DeployControllerRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{162}
+}
+
+func (x *DeployControllerRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *DeployControllerRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DeployControllerRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *DeployControllerRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *DeployControllerRequest) GetDatacenters() []string {
+	if x != nil {
+		return x.Datacenters
+	}
+	return nil
+}
+
+func (x *DeployControllerRequest) GetNodePool() string {
+	if x != nil {
+		return x.NodePool
+	}
+	return ""
+}
+
+func (x *DeployControllerRequest) GetHealthTimeoutSeconds() int32 {
+	if x != nil {
+		return x.HealthTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *DeployControllerRequest) GetReplaceArgs() bool {
+	if x != nil {
+		return x.ReplaceArgs
+	}
+	return false
+}
+
+type DeployControllerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	EvalId        string                 `protobuf:"bytes,3,opt,name=eval_id,json=evalId,proto3" json:"eval_id,omitempty"`
+	Image         string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	PreviousImage string                 `protobuf:"bytes,5,opt,name=previous_image,json=previousImage,proto3" json:"previous_image,omitempty"` // Empty for the first deployment
+	JobVersion    uint64                 `protobuf:"varint,6,opt,name=job_version,json=jobVersion,proto3" json:"job_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeployControllerResponse) Reset() {
+	*x = DeployControllerResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[163]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployControllerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployControllerResponse) ProtoMessage() {}
+
+func (x *DeployControllerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[163]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeployControllerResponse.ProtoReflect.Descriptor instead.
+func (*DeployControllerResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{163}
+}
+
+func (x *DeployControllerResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeployControllerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeployControllerResponse) GetEvalId() string {
+	if x != nil {
+		return x.EvalId
+	}
+	return ""
+}
+
+func (x *DeployControllerResponse) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *DeployControllerResponse) GetPreviousImage() string {
+	if x != nil {
+		return x.PreviousImage
+	}
+	return ""
+}
+
+func (x *DeployControllerResponse) GetJobVersion() uint64 {
+	if x != nil {
+		return x.JobVersion
+	}
+	return 0
+}
+
+type BootstrapStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resource      string                 `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"` // namespace, node pool, job, intention or application
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // created, exists, updated, skipped or failed
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootstrapStep) Reset() {
+	*x = BootstrapStep{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[164]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapStep) ProtoMessage() {}
+
+func (x *BootstrapStep) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[164]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapStep.ProtoReflect.Descriptor instead.
+func (*BootstrapStep) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{164}
+}
+
+func (x *BootstrapStep) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *BootstrapStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BootstrapStep) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BootstrapStep) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BootstrapPlatformResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // false when a step failed
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Steps         []*BootstrapStep       `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootstrapPlatformResponse) Reset() {
+	*x = BootstrapPlatformResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[165]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapPlatformResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapPlatformResponse) ProtoMessage() {}
+
+func (x *BootstrapPlatformResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[165]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapPlatformResponse.ProtoReflect.Descriptor instead.
+func (*BootstrapPlatformResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{165}
+}
+
+func (x *BootstrapPlatformResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BootstrapPlatformResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BootstrapPlatformResponse) GetSteps() []*BootstrapStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type PromoteStandbyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteStandbyRequest) Reset() {
+	*x = PromoteStandbyRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[166]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteStandbyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteStandbyRequest) ProtoMessage() {}
+
+func (x *PromoteStandbyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[166]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteStandbyRequest.ProtoReflect.Descriptor instead.
+func (*PromoteStandbyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{166}
+}
+
+type PromoteStandbyResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ReplicatedIndex uint64                 `protobuf:"varint,3,opt,name=replicated_index,json=replicatedIndex,proto3" json:"replicated_index,omitempty"` // Last index of the primary's log the standby applied
+	ReplicatedAt    int64                  `protobuf:"varint,4,opt,name=replicated_at,json=replicatedAt,proto3" json:"replicated_at,omitempty"`          // Unix time the primary sent it
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PromoteStandbyResponse) Reset() {
+	*x = PromoteStandbyResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[167]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteStandbyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteStandbyResponse) ProtoMessage() {}
+
+func (x *PromoteStandbyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[167]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteStandbyResponse.ProtoReflect.Descriptor instead.
+func (*PromoteStandbyResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{167}
+}
+
+func (x *PromoteStandbyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PromoteStandbyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PromoteStandbyResponse) GetReplicatedIndex() uint64 {
+	if x != nil {
+		return x.ReplicatedIndex
+	}
+	return 0
+}
+
+func (x *PromoteStandbyResponse) GetReplicatedAt() int64 {
+	if x != nil {
+		return x.ReplicatedAt
+	}
+	return 0
+}
+
+type GetReplicationStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReplicationStatusRequest) Reset() {
+	*x = GetReplicationStatusRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[168]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReplicationStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReplicationStatusRequest) ProtoMessage() {}
+
+func (x *GetReplicationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[168]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReplicationStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetReplicationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{168}
+}
+
+type GetReplicationStatusResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Role            string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`                                               // primary, standby, promoted or disabled
+	Peer            string                 `protobuf:"bytes,4,opt,name=peer,proto3" json:"peer,omitempty"`                                               // Standby site the primary replicates to
+	Index           uint64                 `protobuf:"varint,5,opt,name=index,proto3" json:"index,omitempty"`                                            // Applied index of the local Raft log
+	ReplicatedIndex uint64                 `protobuf:"varint,6,opt,name=replicated_index,json=replicatedIndex,proto3" json:"replicated_index,omitempty"` // Last index of the primary's log the standby applied
+	ReplicatedAt    int64                  `protobuf:"varint,7,opt,name=replicated_at,json=replicatedAt,proto3" json:"replicated_at,omitempty"`          // Unix time the primary sent it
+	PromotedAt      int64                  `protobuf:"varint,8,opt,name=promoted_at,json=promotedAt,proto3" json:"promoted_at,omitempty"`
+	LastError       string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"` // Last failure of the primary to replicate
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetReplicationStatusResponse) Reset() {
+	*x = GetReplicationStatusResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[169]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReplicationStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReplicationStatusResponse) ProtoMessage() {}
+
+func (x *GetReplicationStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[169]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReplicationStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetReplicationStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{169}
+}
+
+func (x *GetReplicationStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetReplicationStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetReplicationStatusResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GetReplicationStatusResponse) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *GetReplicationStatusResponse) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *GetReplicationStatusResponse) GetReplicatedIndex() uint64 {
+	if x != nil {
+		return x.ReplicatedIndex
+	}
+	return 0
+}
+
+func (x *GetReplicationStatusResponse) GetReplicatedAt() int64 {
+	if x != nil {
+		return x.ReplicatedAt
+	}
+	return 0
+}
+
+func (x *GetReplicationStatusResponse) GetPromotedAt() int64 {
+	if x != nil {
+		return x.PromotedAt
+	}
+	return 0
+}
+
+func (x *GetReplicationStatusResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type HealthCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheckRequest) Reset() {
+	*x = HealthCheckRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[170]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckRequest) ProtoMessage() {}
+
+func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[170]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
+func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{170}
+}
+
+func (x *HealthCheckRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+type HealthCheckResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Status         HealthStatus           `protobuf:"varint,1,opt,name=status,proto3,enum=controlplane.HealthStatus" json:"status,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Timestamp      int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Mode           string                 `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`                                              // normal, degraded or shedding under Nomad API pressure
+	NomadLatencyMs int64                  `protobuf:"varint,5,opt,name=nomad_latency_ms,json=nomadLatencyMs,proto3" json:"nomad_latency_ms,omitempty"` // Average latency of the Nomad API probes
+	ModeSince      int64                  `protobuf:"varint,6,opt,name=mode_since,json=modeSince,proto3" json:"mode_since,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *HealthCheckResponse) Reset() {
+	*x = HealthCheckResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[171]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckResponse) ProtoMessage() {}
+
+func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[171]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
+func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{171}
+}
+
+func (x *HealthCheckResponse) GetStatus() HealthStatus {
+	if x != nil {
+		return x.Status
+	}
+	return HealthStatus_UNKNOWN
+}
+
+func (x *HealthCheckResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *HealthCheckResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *HealthCheckResponse) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *HealthCheckResponse) GetNomadLatencyMs() int64 {
+	if x != nil {
+		return x.NomadLatencyMs
+	}
+	return 0
+}
+
+func (x *HealthCheckResponse) GetModeSince() int64 {
+	if x != nil {
+		return x.ModeSince
+	}
+	return 0
+}
+
+type TenantQuota struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Cpu             float64                `protobuf:"fixed64,1,opt,name=cpu,proto3" json:"cpu,omitempty"`                                               // Cores, defaults to 4
+	MemoryMb        int64                  `protobuf:"varint,2,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`                      // Defaults to 8192
+	MaxApplications int32                  `protobuf:"varint,3,opt,name=max_applications,json=maxApplications,proto3" json:"max_applications,omitempty"` // Defaults to 20
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TenantQuota) Reset() {
+	*x = TenantQuota{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[172]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantQuota) ProtoMessage() {}
+
+func (x *TenantQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[172]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantQuota.ProtoReflect.Descriptor instead.
+func (*TenantQuota) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{172}
+}
+
+func (x *TenantQuota) GetCpu() float64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *TenantQuota) GetMemoryMb() int64 {
+	if x != nil {
+		return x.MemoryMb
+	}
+	return 0
+}
+
+func (x *TenantQuota) GetMaxApplications() int32 {
+	if x != nil {
+		return x.MaxApplications
+	}
+	return 0
+}
+
+type Tenant struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespaces          []string               `protobuf:"bytes,2,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	Quota               *TenantQuota           `protobuf:"bytes,3,opt,name=quota,proto3" json:"quota,omitempty"`
+	DomainTemplate      string                 `protobuf:"bytes,4,opt,name=domain_template,json=domainTemplate,proto3" json:"domain_template,omitempty"`
+	CreatedAt           int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	KeyId               string                 `protobuf:"bytes,6,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"` // KMS key the tenant's data key is wrapped with
+	SecurityDefaults    *SecurityContext       `protobuf:"bytes,7,opt,name=security_defaults,json=securityDefaults,proto3" json:"security_defaults,omitempty"`
+	AllowedImages       []string               `protobuf:"bytes,8,rep,name=allowed_images,json=allowedImages,proto3" json:"allowed_images,omitempty"`
+	RequireAttestation  bool                   `protobuf:"varint,9,opt,name=require_attestation,json=requireAttestation,proto3" json:"require_attestation,omitempty"`
+	NomadTokenAccessor  string                 `protobuf:"bytes,10,opt,name=nomad_token_accessor,json=nomadTokenAccessor,proto3" json:"nomad_token_accessor,omitempty"` // Read-only Nomad token of the tenant, empty without Nomad access
+	NomadTokenExpiresAt int64                  `protobuf:"varint,11,opt,name=nomad_token_expires_at,json=nomadTokenExpiresAt,proto3" json:"nomad_token_expires_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Tenant) Reset() {
+	*x = Tenant{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[173]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tenant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tenant) ProtoMessage() {}
+
+func (x *Tenant) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[173]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tenant.ProtoReflect.Descriptor instead.
+func (*Tenant) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{173}
+}
+
+func (x *Tenant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tenant) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *Tenant) GetQuota() *TenantQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *Tenant) GetDomainTemplate() string {
+	if x != nil {
+		return x.DomainTemplate
+	}
+	return ""
+}
+
+func (x *Tenant) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Tenant) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *Tenant) GetSecurityDefaults() *SecurityContext {
+	if x != nil {
+		return x.SecurityDefaults
+	}
+	return nil
+}
+
+func (x *Tenant) GetAllowedImages() []string {
+	if x != nil {
+		return x.AllowedImages
+	}
+	return nil
+}
+
+func (x *Tenant) GetRequireAttestation() bool {
+	if x != nil {
+		return x.RequireAttestation
+	}
+	return false
+}
+
+func (x *Tenant) GetNomadTokenAccessor() string {
+	if x != nil {
+		return x.NomadTokenAccessor
+	}
+	return ""
+}
+
+func (x *Tenant) GetNomadTokenExpiresAt() int64 {
+	if x != nil {
+		return x.NomadTokenExpiresAt
+	}
+	return 0
+}
+
+type CreateTenantRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Name               string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                        // Lowercase DNS label
+	Namespaces         []string               `protobuf:"bytes,2,rep,name=namespaces,proto3" json:"namespaces,omitempty"`                                            // Nomad namespaces to create, defaults to the tenant name
+	Quota              *TenantQuota           `protobuf:"bytes,3,opt,name=quota,proto3" json:"quota,omitempty"`                                                      // Unset limits take the defaults
+	DomainTemplate     string                 `protobuf:"bytes,4,opt,name=domain_template,json=domainTemplate,proto3" json:"domain_template,omitempty"`              // {app} and {tenant} are replaced, defaults to {app}.{tenant}.<controller -tenant-domain>
+	ServiceAccount     string                 `protobuf:"bytes,5,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`              // Defaults to deployer
+	SecurityDefaults   *SecurityContext       `protobuf:"bytes,6,opt,name=security_defaults,json=securityDefaults,proto3" json:"security_defaults,omitempty"`        // Applied to the tenant's applications which leave them unset
+	AllowedImages      []string               `protobuf:"bytes,7,rep,name=allowed_images,json=allowedImages,proto3" json:"allowed_images,omitempty"`                 // e.g. registry.example.com/payments/*, defaults to the controller's -allowed-images
+	RequireAttestation bool                   `protobuf:"varint,8,opt,name=require_attestation,json=requireAttestation,proto3" json:"require_attestation,omitempty"` // Deployments need a verified provenance attestation of their image
+	NomadAccess        bool                   `protobuf:"varint,9,opt,name=nomad_access,json=nomadAccess,proto3" json:"nomad_access,omitempty"`                      // Mints a read-only Nomad token of the namespaces, requires the controller's -tenant-nomad-acl
+	NomadReadLogs      bool                   `protobuf:"varint,10,opt,name=nomad_read_logs,json=nomadReadLogs,proto3" json:"nomad_read_logs,omitempty"`             // The Nomad token can read the logs and files of allocations
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateTenantRequest) Reset() {
+	*x = CreateTenantRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[174]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTenantRequest) ProtoMessage() {}
+
+func (x *CreateTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[174]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTenantRequest.ProtoReflect.Descriptor instead.
+func (*CreateTenantRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{174}
+}
+
+func (x *CreateTenantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTenantRequest) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *CreateTenantRequest) GetQuota() *TenantQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *CreateTenantRequest) GetDomainTemplate() string {
+	if x != nil {
+		return x.DomainTemplate
+	}
+	return ""
+}
+
+func (x *CreateTenantRequest) GetServiceAccount() string {
+	if x != nil {
+		return x.ServiceAccount
+	}
+	return ""
+}
+
+func (x *CreateTenantRequest) GetSecurityDefaults() *SecurityContext {
+	if x != nil {
+		return x.SecurityDefaults
+	}
+	return nil
+}
+
+func (x *CreateTenantRequest) GetAllowedImages() []string {
+	if x != nil {
+		return x.AllowedImages
+	}
+	return nil
+}
+
+func (x *CreateTenantRequest) GetRequireAttestation() bool {
+	if x != nil {
+		return x.RequireAttestation
+	}
+	return false
+}
+
+func (x *CreateTenantRequest) GetNomadAccess() bool {
+	if x != nil {
+		return x.NomadAccess
+	}
+	return false
+}
+
+func (x *CreateTenantRequest) GetNomadReadLogs() bool {
+	if x != nil {
+		return x.NomadReadLogs
+	}
+	return false
+}
+
+type CreateTenantResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Tenant         *Tenant                `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	ServiceAccount string                 `protobuf:"bytes,4,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
+	Token          string                 `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`                             // Only returned once, the controller stores a hash
+	NomadToken     string                 `protobuf:"bytes,6,opt,name=nomad_token,json=nomadToken,proto3" json:"nomad_token,omitempty"` // Secret of the Nomad token, its successors are kept in the variable controlplane/nomad-token
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateTenantResponse) Reset() {
+	*x = CreateTenantResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[175]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTenantResponse) ProtoMessage() {}
+
+func (x *CreateTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[175]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTenantResponse.ProtoReflect.Descriptor instead.
+func (*CreateTenantResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{175}
+}
+
+func (x *CreateTenantResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateTenantResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateTenantResponse) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+func (x *CreateTenantResponse) GetServiceAccount() string {
+	if x != nil {
+		return x.ServiceAccount
+	}
+	return ""
+}
+
+func (x *CreateTenantResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateTenantResponse) GetNomadToken() string {
+	if x != nil {
+		return x.NomadToken
+	}
+	return ""
+}
+
+type ListTenantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsRequest) Reset() {
+	*x = ListTenantsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[176]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsRequest) ProtoMessage() {}
+
+func (x *ListTenantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[176]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsRequest.ProtoReflect.Descriptor instead.
+func (*ListTenantsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{176}
+}
+
+type ListTenantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenants       []*Tenant              `protobuf:"bytes,1,rep,name=tenants,proto3" json:"tenants,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsResponse) Reset() {
+	*x = ListTenantsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[177]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsResponse) ProtoMessage() {}
+
+func (x *ListTenantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[177]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsResponse.ProtoReflect.Descriptor instead.
+func (*ListTenantsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{177}
+}
+
+func (x *ListTenantsResponse) GetTenants() []*Tenant {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+func (x *ListTenantsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Rewraps the data keys of tenants with the current KMS key, the encrypted data is unchanged
+type RotateTenantKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // All tenants when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateTenantKeysRequest) Reset() {
+	*x = RotateTenantKeysRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[178]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateTenantKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateTenantKeysRequest) ProtoMessage() {}
+
+func (x *RotateTenantKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[178]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateTenantKeysRequest.ProtoReflect.Descriptor instead.
+func (*RotateTenantKeysRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{178}
+}
+
+func (x *RotateTenantKeysRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RotateTenantKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	KeyId         string                 `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"` // Current KMS key
+	Rotated       []string               `protobuf:"bytes,4,rep,name=rotated,proto3" json:"rotated,omitempty"`          // Tenants whose data key was rewrapped
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateTenantKeysResponse) Reset() {
+	*x = RotateTenantKeysResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[179]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateTenantKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateTenantKeysResponse) ProtoMessage() {}
+
+func (x *RotateTenantKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[179]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateTenantKeysResponse.ProtoReflect.Descriptor instead.
+func (*RotateTenantKeysResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{179}
+}
+
+func (x *RotateTenantKeysResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RotateTenantKeysResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RotateTenantKeysResponse) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *RotateTenantKeysResponse) GetRotated() []string {
+	if x != nil {
+		return x.Rotated
+	}
+	return nil
+}
+
+// Mints a new read-only Nomad token of a tenant and revokes the previous one, e.g. when it leaked
+type IssueTenantNomadTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ReadLogs      bool                   `protobuf:"varint,2,opt,name=read_logs,json=readLogs,proto3" json:"read_logs,omitempty"` // The token can read the logs and files of allocations
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueTenantNomadTokenRequest) Reset() {
+	*x = IssueTenantNomadTokenRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[180]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueTenantNomadTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueTenantNomadTokenRequest) ProtoMessage() {}
+
+func (x *IssueTenantNomadTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[180]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueTenantNomadTokenRequest.ProtoReflect.Descriptor instead.
+func (*IssueTenantNomadTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{180}
+}
+
+func (x *IssueTenantNomadTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IssueTenantNomadTokenRequest) GetReadLogs() bool {
+	if x != nil {
+		return x.ReadLogs
+	}
+	return false
+}
+
+type IssueTenantNomadTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	AccessorId    string                 `protobuf:"bytes,3,opt,name=accessor_id,json=accessorId,proto3" json:"accessor_id,omitempty"`
+	SecretId      string                 `protobuf:"bytes,4,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"` // Only returned once, later tokens are kept in the variable controlplane/nomad-token
+	ExpiresAt     int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueTenantNomadTokenResponse) Reset() {
+	*x = IssueTenantNomadTokenResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[181]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueTenantNomadTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueTenantNomadTokenResponse) ProtoMessage() {}
+
+func (x *IssueTenantNomadTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[181]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueTenantNomadTokenResponse.ProtoReflect.Descriptor instead.
+func (*IssueTenantNomadTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{181}
+}
+
+func (x *IssueTenantNomadTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *IssueTenantNomadTokenResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *IssueTenantNomadTokenResponse) GetAccessorId() string {
+	if x != nil {
+		return x.AccessorId
+	}
+	return ""
+}
+
+func (x *IssueTenantNomadTokenResponse) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *IssueTenantNomadTokenResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Called before the spec is validated, e.g. to inject labels or reject non-compliant specs
+type PreValidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spec          *DeployRequest         `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreValidateRequest) Reset() {
+	*x = PreValidateRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[182]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreValidateRequest) ProtoMessage() {}
+
+func (x *PreValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[182]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreValidateRequest.ProtoReflect.Descriptor instead.
+func (*PreValidateRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{182}
+}
+
+func (x *PreValidateRequest) GetSpec() *DeployRequest {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+type PreValidateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // Why the spec was rejected
+	Spec          *DeployRequest         `protobuf:"bytes,3,opt,name=spec,proto3" json:"spec,omitempty"`       // Replaces the spec when set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreValidateResponse) Reset() {
+	*x = PreValidateResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[183]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreValidateResponse) ProtoMessage() {}
+
+func (x *PreValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[183]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreValidateResponse.ProtoReflect.Descriptor instead.
+func (*PreValidateResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{183}
+}
+
+func (x *PreValidateResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *PreValidateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PreValidateResponse) GetSpec() *DeployRequest {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+// Called with the generated Nomad job before it is registered, e.g. to add sidecars
+type MutateJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spec          *DeployRequest         `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	Job           []byte                 `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"` // Nomad job in the JSON format of the Nomad API
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MutateJobRequest) Reset() {
+	*x = MutateJobRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[184]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MutateJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MutateJobRequest) ProtoMessage() {}
+
+func (x *MutateJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[184]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MutateJobRequest.ProtoReflect.Descriptor instead.
+func (*MutateJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{184}
+}
+
+func (x *MutateJobRequest) GetSpec() *DeployRequest {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *MutateJobRequest) GetJob() []byte {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type MutateJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Job           []byte                 `protobuf:"bytes,3,opt,name=job,proto3" json:"job,omitempty"` // Replaces the job when set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MutateJobResponse) Reset() {
+	*x = MutateJobResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[185]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MutateJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MutateJobResponse) ProtoMessage() {}
+
+func (x *MutateJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[185]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MutateJobResponse.ProtoReflect.Descriptor instead.
+func (*MutateJobResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{185}
+}
+
+func (x *MutateJobResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *MutateJobResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MutateJobResponse) GetJob() []byte {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// Called after the job was registered, failures are only logged
+type PostDeployRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spec          *DeployRequest         `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	DeploymentId  string                 `protobuf:"bytes,2,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // Nomad evaluation ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostDeployRequest) Reset() {
+	*x = PostDeployRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[186]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostDeployRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostDeployRequest) ProtoMessage() {}
+
+func (x *PostDeployRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[186]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostDeployRequest.ProtoReflect.Descriptor instead.
+func (*PostDeployRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{186}
+}
+
+func (x *PostDeployRequest) GetSpec() *DeployRequest {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *PostDeployRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type PostDeployResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostDeployResponse) Reset() {
+	*x = PostDeployResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[187]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostDeployResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostDeployResponse) ProtoMessage() {}
+
+func (x *PostDeployResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[187]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostDeployResponse.ProtoReflect.Descriptor instead.
+func (*PostDeployResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{187}
+}
+
+// A command consumed from the message bus, in the JSON format of protobuf
+type Command struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Idempotency key, a redelivered command returns the result of the first one
+	// Types that are valid to be assigned to Command:
+	//
+	//	*Command_Deploy
+	//	*Command_Scale
+	//	*Command_Delete
+	Command       isCommand_Command `protobuf_oneof:"command"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Command) Reset() {
+	*x = Command{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[188]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Command) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Command) ProtoMessage() {}
+
+func (x *Command) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[188]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Command.ProtoReflect.Descriptor instead.
+func (*Command) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{188}
+}
+
+func (x *Command) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Command) GetCommand() isCommand_Command {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Command) GetDeploy() *DeployRequest {
+	if x != nil {
+		if x, ok := x.Command.(*Command_Deploy); ok {
+			return x.Deploy
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetScale() *ScaleRequest {
+	if x != nil {
+		if x, ok := x.Command.(*Command_Scale); ok {
+			return x.Scale
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetDelete() *DeleteRequest {
+	if x != nil {
+		if x, ok := x.Command.(*Command_Delete); ok {
+			return x.Delete
+		}
+	}
+	return nil
+}
+
+type isCommand_Command interface {
+	isCommand_Command()
+}
+
+type Command_Deploy struct {
+	Deploy *DeployRequest `protobuf:"bytes,2,opt,name=deploy,proto3,oneof"`
+}
+
+type Command_Scale struct {
+	Scale *ScaleRequest `protobuf:"bytes,3,opt,name=scale,proto3,oneof"`
+}
+
+type Command_Delete struct {
+	Delete *DeleteRequest `protobuf:"bytes,4,opt,name=delete,proto3,oneof"`
+}
+
+func (*Command_Deploy) isCommand_Command() {}
+
+func (*Command_Scale) isCommand_Command() {}
+
+func (*Command_Delete) isCommand_Command() {}
+
+// Replied to commands carrying a NATS reply subject
+type CommandResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Duplicate     bool                   `protobuf:"varint,4,opt,name=duplicate,proto3" json:"duplicate,omitempty"` // The result of an earlier delivery of the command
+	Deploy        *DeployResponse        `protobuf:"bytes,5,opt,name=deploy,proto3" json:"deploy,omitempty"`
+	Scale         *ScaleResponse         `protobuf:"bytes,6,opt,name=scale,proto3" json:"scale,omitempty"`
+	Delete        *DeleteResponse        `protobuf:"bytes,7,opt,name=delete,proto3" json:"delete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandResult) Reset() {
+	*x = CommandResult{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[189]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandResult) ProtoMessage() {}
+
+func (x *CommandResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[189]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandResult.ProtoReflect.Descriptor instead.
+func (*CommandResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{189}
+}
+
+func (x *CommandResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CommandResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CommandResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CommandResult) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *CommandResult) GetDeploy() *DeployResponse {
+	if x != nil {
+		return x.Deploy
+	}
+	return nil
+}
+
+func (x *CommandResult) GetScale() *ScaleResponse {
+	if x != nil {
+		return x.Scale
+	}
+	return nil
+}
+
+func (x *CommandResult) GetDelete() *DeleteResponse {
+	if x != nil {
+		return x.Delete
+	}
+	return nil
+}
+
+type ExplainPlacementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Explains the recorded decision of the application
+	Spec          *DeployRequest         `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"` // Or decides for the spec without deploying it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainPlacementRequest) Reset() {
+	*x = ExplainPlacementRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[190]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainPlacementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainPlacementRequest) ProtoMessage() {}
+
+func (x *ExplainPlacementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[190]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainPlacementRequest.ProtoReflect.Descriptor instead.
+func (*ExplainPlacementRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{190}
+}
+
+func (x *ExplainPlacementRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExplainPlacementRequest) GetSpec() *DeployRequest {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+// A region considered by the placement engine, its score is the weighted sum of its partial scores
+type PlacementCandidate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Region        string                 `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Eligible      bool                   `protobuf:"varint,2,opt,name=eligible,proto3" json:"eligible,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Why the region was excluded
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	CapacityScore float64                `protobuf:"fixed64,5,opt,name=capacity_score,json=capacityScore,proto3" json:"capacity_score,omitempty"`
+	LatencyScore  float64                `protobuf:"fixed64,6,opt,name=latency_score,json=latencyScore,proto3" json:"latency_score,omitempty"`
+	CostScore     float64                `protobuf:"fixed64,7,opt,name=cost_score,json=costScore,proto3" json:"cost_score,omitempty"`
+	CpuFreeMhz    int64                  `protobuf:"varint,8,opt,name=cpu_free_mhz,json=cpuFreeMhz,proto3" json:"cpu_free_mhz,omitempty"`
+	MemoryFreeMb  int64                  `protobuf:"varint,9,opt,name=memory_free_mb,json=memoryFreeMb,proto3" json:"memory_free_mb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlacementCandidate) Reset() {
+	*x = PlacementCandidate{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[191]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlacementCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlacementCandidate) ProtoMessage() {}
+
+func (x *PlacementCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[191]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlacementCandidate.ProtoReflect.Descriptor instead.
+func (*PlacementCandidate) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{191}
+}
+
+func (x *PlacementCandidate) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *PlacementCandidate) GetEligible() bool {
+	if x != nil {
+		return x.Eligible
+	}
+	return false
+}
+
+func (x *PlacementCandidate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PlacementCandidate) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PlacementCandidate) GetCapacityScore() float64 {
+	if x != nil {
+		return x.CapacityScore
+	}
+	return 0
+}
+
+func (x *PlacementCandidate) GetLatencyScore() float64 {
+	if x != nil {
+		return x.LatencyScore
+	}
+	return 0
+}
+
+func (x *PlacementCandidate) GetCostScore() float64 {
+	if x != nil {
+		return x.CostScore
+	}
+	return 0
+}
+
+func (x *PlacementCandidate) GetCpuFreeMhz() int64 {
+	if x != nil {
+		return x.CpuFreeMhz
+	}
+	return 0
+}
+
+func (x *PlacementCandidate) GetMemoryFreeMb() int64 {
+	if x != nil {
+		return x.MemoryFreeMb
+	}
+	return 0
+}
+
+type ExplainPlacementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Region        string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	Candidates    []*PlacementCandidate  `protobuf:"bytes,4,rep,name=candidates,proto3" json:"candidates,omitempty"` // Best first
+	DecidedAt     int64                  `protobuf:"varint,5,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainPlacementResponse) Reset() {
+	*x = ExplainPlacementResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[192]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainPlacementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainPlacementResponse) ProtoMessage() {}
+
+func (x *ExplainPlacementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[192]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainPlacementResponse.ProtoReflect.Descriptor instead.
+func (*ExplainPlacementResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{192}
+}
+
+func (x *ExplainPlacementResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ExplainPlacementResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExplainPlacementResponse) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ExplainPlacementResponse) GetCandidates() []*PlacementCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *ExplainPlacementResponse) GetDecidedAt() int64 {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return 0
+}
+
+type ResourceRecommendationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`        // Every application when empty
+	Propose       bool                   `protobuf:"varint,2,opt,name=propose,proto3" json:"propose,omitempty"` // Record recommendations differing from the current resources as updates awaiting approval
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceRecommendationsRequest) Reset() {
+	*x = ResourceRecommendationsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[193]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceRecommendationsRequest) ProtoMessage() {}
+
+func (x *ResourceRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[193]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*ResourceRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{193}
+}
+
+func (x *ResourceRecommendationsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResourceRecommendationsRequest) GetPropose() bool {
+	if x != nil {
+		return x.Propose
+	}
+	return false
+}
+
+// ResourceRecommendation sizes an application from the p95 of its sampled usage plus headroom,
+// CPU in cores and memory in MB like specs
+type ResourceRecommendation struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Application       string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	JobId             string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	CurrentCpu        float64                `protobuf:"fixed64,3,opt,name=current_cpu,json=currentCpu,proto3" json:"current_cpu,omitempty"`
+	CurrentMemory     int64                  `protobuf:"varint,4,opt,name=current_memory,json=currentMemory,proto3" json:"current_memory,omitempty"`
+	P95Cpu            float64                `protobuf:"fixed64,5,opt,name=p95_cpu,json=p95Cpu,proto3" json:"p95_cpu,omitempty"`
+	P95Memory         int64                  `protobuf:"varint,6,opt,name=p95_memory,json=p95Memory,proto3" json:"p95_memory,omitempty"`
+	RecommendedCpu    float64                `protobuf:"fixed64,7,opt,name=recommended_cpu,json=recommendedCpu,proto3" json:"recommended_cpu,omitempty"` // 0 without enough samples
+	RecommendedMemory int64                  `protobuf:"varint,8,opt,name=recommended_memory,json=recommendedMemory,proto3" json:"recommended_memory,omitempty"`
+	Samples           int32                  `protobuf:"varint,9,opt,name=samples,proto3" json:"samples,omitempty"`
+	Reason            string                 `protobuf:"bytes,10,opt,name=reason,proto3" json:"reason,omitempty"`      // over-provisioned, under-provisioned, or why nothing is recommended
+	Proposed          bool                   `protobuf:"varint,11,opt,name=proposed,proto3" json:"proposed,omitempty"` // An update awaits ApplyResourceRecommendation
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ResourceRecommendation) Reset() {
+	*x = ResourceRecommendation{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[194]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceRecommendation) ProtoMessage() {}
+
+func (x *ResourceRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[194]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceRecommendation.ProtoReflect.Descriptor instead.
+func (*ResourceRecommendation) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{194}
+}
+
+func (x *ResourceRecommendation) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *ResourceRecommendation) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ResourceRecommendation) GetCurrentCpu() float64 {
+	if x != nil {
+		return x.CurrentCpu
 	}
 	return 0
 }
 
-func (x *DeployRequest) GetRegion() string {
+func (x *ResourceRecommendation) GetCurrentMemory() int64 {
 	if x != nil {
-		return x.Region
+		return x.CurrentMemory
+	}
+	return 0
+}
+
+func (x *ResourceRecommendation) GetP95Cpu() float64 {
+	if x != nil {
+		return x.P95Cpu
+	}
+	return 0
+}
+
+func (x *ResourceRecommendation) GetP95Memory() int64 {
+	if x != nil {
+		return x.P95Memory
+	}
+	return 0
+}
+
+func (x *ResourceRecommendation) GetRecommendedCpu() float64 {
+	if x != nil {
+		return x.RecommendedCpu
+	}
+	return 0
+}
+
+func (x *ResourceRecommendation) GetRecommendedMemory() int64 {
+	if x != nil {
+		return x.RecommendedMemory
+	}
+	return 0
+}
+
+func (x *ResourceRecommendation) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *ResourceRecommendation) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *DeployRequest) GetLabels() map[string]string {
+func (x *ResourceRecommendation) GetProposed() bool {
 	if x != nil {
-		return x.Labels
+		return x.Proposed
 	}
-	return nil
+	return false
 }
 
-func (x *DeployRequest) GetTraefik() *TraefikConfig {
+type ResourceRecommendationsResponse struct {
+	state           protoimpl.MessageState    `protogen:"open.v1"`
+	Success         bool                      `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message         string                    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Recommendations []*ResourceRecommendation `protobuf:"bytes,3,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ResourceRecommendationsResponse) Reset() {
+	*x = ResourceRecommendationsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[195]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceRecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceRecommendationsResponse) ProtoMessage() {}
+
+func (x *ResourceRecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[195]
 	if x != nil {
-		return x.Traefik
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceRecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*ResourceRecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{195}
+}
+
+func (x *ResourceRecommendationsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResourceRecommendationsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResourceRecommendationsResponse) GetRecommendations() []*ResourceRecommendation {
+	if x != nil {
+		return x.Recommendations
 	}
 	return nil
 }
 
-func (x *DeployRequest) GetNetworkMode() NetworkMode {
+type ApplyResourceRecommendationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Dismiss       bool                   `protobuf:"varint,2,opt,name=dismiss,proto3" json:"dismiss,omitempty"`    // Drop the proposed update instead of rolling it out
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace of the application, default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyResourceRecommendationRequest) Reset() {
+	*x = ApplyResourceRecommendationRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[196]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyResourceRecommendationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyResourceRecommendationRequest) ProtoMessage() {}
+
+func (x *ApplyResourceRecommendationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[196]
 	if x != nil {
-		return x.NetworkMode
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return NetworkMode_NETWORK_MODE_UNSPECIFIED
+	return mi.MessageOf(x)
 }
 
-type DeployResponse struct {
+// Deprecated: Use ApplyResourceRecommendationRequest.ProtoReflect.Descriptor instead.
+func (*ApplyResourceRecommendationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{196}
+}
+
+func (x *ApplyResourceRecommendationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApplyResourceRecommendationRequest) GetDismiss() bool {
+	if x != nil {
+		return x.Dismiss
+	}
+	return false
+}
+
+func (x *ApplyResourceRecommendationRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ApplyResourceRecommendationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	DeploymentId  string                 `protobuf:"bytes,3,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeployResponse) Reset() {
-	*x = DeployResponse{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[2]
+func (x *ApplyResourceRecommendationResponse) Reset() {
+	*x = ApplyResourceRecommendationResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[197]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeployResponse) String() string {
+func (x *ApplyResourceRecommendationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeployResponse) ProtoMessage() {}
+func (*ApplyResourceRecommendationResponse) ProtoMessage() {}
 
-func (x *DeployResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[2]
+func (x *ApplyResourceRecommendationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[197]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -388,55 +14723,234 @@ func (x *DeployResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeployResponse.ProtoReflect.Descriptor instead.
-func (*DeployResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ApplyResourceRecommendationResponse.ProtoReflect.Descriptor instead.
+func (*ApplyResourceRecommendationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{197}
 }
 
-func (x *DeployResponse) GetDeploymentId() string {
+func (x *ApplyResourceRecommendationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ApplyResourceRecommendationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ApplyResourceRecommendationResponse) GetDeploymentId() string {
 	if x != nil {
 		return x.DeploymentId
 	}
 	return ""
 }
 
-func (x *DeployResponse) GetStatus() string {
+type DeploymentAnalyticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Application   string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`      // Only this application
+	Team          string                 `protobuf:"bytes,2,opt,name=team,proto3" json:"team,omitempty"`                    // Only the applications of this tenant
+	ByTeam        bool                   `protobuf:"varint,3,opt,name=by_team,json=byTeam,proto3" json:"by_team,omitempty"` // Aggregate the applications of each tenant
+	Since         int64                  `protobuf:"varint,4,opt,name=since,proto3" json:"since,omitempty"`                 // Unix seconds, defaults to 30 days ago
+	Bucket        string                 `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`                // Periods of the time series: day or week (default)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentAnalyticsRequest) Reset() {
+	*x = DeploymentAnalyticsRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[198]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentAnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentAnalyticsRequest) ProtoMessage() {}
+
+func (x *DeploymentAnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[198]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentAnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*DeploymentAnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{198}
+}
+
+func (x *DeploymentAnalyticsRequest) GetApplication() string {
+	if x != nil {
+		return x.Application
 	}
 	return ""
 }
 
-func (x *DeployResponse) GetMessage() string {
+func (x *DeploymentAnalyticsRequest) GetTeam() string {
 	if x != nil {
-		return x.Message
+		return x.Team
 	}
 	return ""
 }
 
-type DeleteRequest struct {
+func (x *DeploymentAnalyticsRequest) GetByTeam() bool {
+	if x != nil {
+		return x.ByTeam
+	}
+	return false
+}
+
+func (x *DeploymentAnalyticsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *DeploymentAnalyticsRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+// DeliveryMetrics are the DORA metrics of the rollouts finished in a period, durations are medians
+type DeliveryMetrics struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	PeriodStart            int64                  `protobuf:"varint,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	Deployments            int32                  `protobuf:"varint,2,opt,name=deployments,proto3" json:"deployments,omitempty"` // Finished rollouts
+	FailedDeployments      int32                  `protobuf:"varint,3,opt,name=failed_deployments,json=failedDeployments,proto3" json:"failed_deployments,omitempty"`
+	DeploysPerDay          float64                `protobuf:"fixed64,4,opt,name=deploys_per_day,json=deploysPerDay,proto3" json:"deploys_per_day,omitempty"`
+	ChangeFailureRate      float64                `protobuf:"fixed64,5,opt,name=change_failure_rate,json=changeFailureRate,proto3" json:"change_failure_rate,omitempty"`               // Failed of the finished rollouts
+	LeadTimeSeconds        int64                  `protobuf:"varint,6,opt,name=lead_time_seconds,json=leadTimeSeconds,proto3" json:"lead_time_seconds,omitempty"`                      // Commit to finished rollout, of the deployments passing commit_time
+	RolloutDurationSeconds int64                  `protobuf:"varint,7,opt,name=rollout_duration_seconds,json=rolloutDurationSeconds,proto3" json:"rollout_duration_seconds,omitempty"` // Of the successful rollouts
+	MttrSeconds            int64                  `protobuf:"varint,8,opt,name=mttr_seconds,json=mttrSeconds,proto3" json:"mttr_seconds,omitempty"`                                    // Failed rollout to the next successful one
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DeliveryMetrics) Reset() {
+	*x = DeliveryMetrics{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[199]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryMetrics) ProtoMessage() {}
+
+func (x *DeliveryMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[199]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryMetrics.ProtoReflect.Descriptor instead.
+func (*DeliveryMetrics) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{199}
+}
+
+func (x *DeliveryMetrics) GetPeriodStart() int64 {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return 0
+}
+
+func (x *DeliveryMetrics) GetDeployments() int32 {
+	if x != nil {
+		return x.Deployments
+	}
+	return 0
+}
+
+func (x *DeliveryMetrics) GetFailedDeployments() int32 {
+	if x != nil {
+		return x.FailedDeployments
+	}
+	return 0
+}
+
+func (x *DeliveryMetrics) GetDeploysPerDay() float64 {
+	if x != nil {
+		return x.DeploysPerDay
+	}
+	return 0
+}
+
+func (x *DeliveryMetrics) GetChangeFailureRate() float64 {
+	if x != nil {
+		return x.ChangeFailureRate
+	}
+	return 0
+}
+
+func (x *DeliveryMetrics) GetLeadTimeSeconds() int64 {
+	if x != nil {
+		return x.LeadTimeSeconds
+	}
+	return 0
+}
+
+func (x *DeliveryMetrics) GetRolloutDurationSeconds() int64 {
+	if x != nil {
+		return x.RolloutDurationSeconds
+	}
+	return 0
+}
+
+func (x *DeliveryMetrics) GetMttrSeconds() int64 {
+	if x != nil {
+		return x.MttrSeconds
+	}
+	return 0
+}
+
+type DeploymentAnalytics struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
-	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Application   string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"` // Empty when aggregated by team
+	Team          string                 `protobuf:"bytes,2,opt,name=team,proto3" json:"team,omitempty"`
+	Total         *DeliveryMetrics       `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`
+	Periods       []*DeliveryMetrics     `protobuf:"bytes,4,rep,name=periods,proto3" json:"periods,omitempty"` // Oldest first
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteRequest) Reset() {
-	*x = DeleteRequest{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[3]
+func (x *DeploymentAnalytics) Reset() {
+	*x = DeploymentAnalytics{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[200]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteRequest) String() string {
+func (x *DeploymentAnalytics) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRequest) ProtoMessage() {}
+func (*DeploymentAnalytics) ProtoMessage() {}
 
-func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[3]
+func (x *DeploymentAnalytics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[200]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -447,48 +14961,63 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use DeploymentAnalytics.ProtoReflect.Descriptor instead.
+func (*DeploymentAnalytics) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{200}
 }
 
-func (x *DeleteRequest) GetDeploymentId() string {
+func (x *DeploymentAnalytics) GetApplication() string {
 	if x != nil {
-		return x.DeploymentId
+		return x.Application
 	}
 	return ""
 }
 
-func (x *DeleteRequest) GetContainerId() string {
+func (x *DeploymentAnalytics) GetTeam() string {
 	if x != nil {
-		return x.ContainerId
+		return x.Team
 	}
 	return ""
 }
 
-type DeleteResponse struct {
+func (x *DeploymentAnalytics) GetTotal() *DeliveryMetrics {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *DeploymentAnalytics) GetPeriods() []*DeliveryMetrics {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+type DeploymentAnalyticsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Analytics     []*DeploymentAnalytics `protobuf:"bytes,3,rep,name=analytics,proto3" json:"analytics,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteResponse) Reset() {
-	*x = DeleteResponse{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[4]
+func (x *DeploymentAnalyticsResponse) Reset() {
+	*x = DeploymentAnalyticsResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[201]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteResponse) String() string {
+func (x *DeploymentAnalyticsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteResponse) ProtoMessage() {}
+func (*DeploymentAnalyticsResponse) ProtoMessage() {}
 
-func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[4]
+func (x *DeploymentAnalyticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[201]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -499,47 +15028,54 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
-func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use DeploymentAnalyticsResponse.ProtoReflect.Descriptor instead.
+func (*DeploymentAnalyticsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{201}
 }
 
-func (x *DeleteResponse) GetSuccess() bool {
+func (x *DeploymentAnalyticsResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *DeleteResponse) GetMessage() string {
+func (x *DeploymentAnalyticsResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-type StatusRequest struct {
+func (x *DeploymentAnalyticsResponse) GetAnalytics() []*DeploymentAnalytics {
+	if x != nil {
+		return x.Analytics
+	}
+	return nil
+}
+
+type GetReconcilerStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Application   string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"` // Only the failures and drift of this application
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StatusRequest) Reset() {
-	*x = StatusRequest{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[5]
+func (x *GetReconcilerStatusRequest) Reset() {
+	*x = GetReconcilerStatusRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[202]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatusRequest) String() string {
+func (x *GetReconcilerStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusRequest) ProtoMessage() {}
+func (*GetReconcilerStatusRequest) ProtoMessage() {}
 
-func (x *StatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[5]
+func (x *GetReconcilerStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[202]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -550,47 +15086,53 @@ func (x *StatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
-func (*StatusRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetReconcilerStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetReconcilerStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{202}
 }
 
-func (x *StatusRequest) GetDeploymentId() string {
+func (x *GetReconcilerStatusRequest) GetApplication() string {
 	if x != nil {
-		return x.DeploymentId
+		return x.Application
 	}
 	return ""
 }
 
-type AllocationStatus struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AllocationId  string                 `protobuf:"bytes,1,opt,name=allocation_id,json=allocationId,proto3" json:"allocation_id,omitempty"`
-	NodeId        string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	NodeName      string                 `protobuf:"bytes,3,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	DesiredStatus string                 `protobuf:"bytes,5,opt,name=desired_status,json=desiredStatus,proto3" json:"desired_status,omitempty"`
-	CreateTime    int64                  `protobuf:"varint,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
-	ModifyTime    int64                  `protobuf:"varint,7,opt,name=modify_time,json=modifyTime,proto3" json:"modify_time,omitempty"`
-	TaskStates    map[string]string      `protobuf:"bytes,8,rep,name=task_states,json=taskStates,proto3" json:"task_states,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ReconcilerLoop struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // backups, domain_verification, rollout_deadlines, geo_failover, drift_detection or usage_sampling
+	State           string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"` // pending, standby, paused, ok, failing, behind or wedged
+	IntervalSeconds int64                  `protobuf:"varint,3,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	Runs            int64                  `protobuf:"varint,4,opt,name=runs,proto3" json:"runs,omitempty"`
+	Failures        int64                  `protobuf:"varint,5,opt,name=failures,proto3" json:"failures,omitempty"`                       // Runs which failed as a whole
+	QueueDepth      int32                  `protobuf:"varint,6,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"` // Items left in the current run
+	Running         bool                   `protobuf:"varint,7,opt,name=running,proto3" json:"running,omitempty"`
+	LastStartedAt   int64                  `protobuf:"varint,8,opt,name=last_started_at,json=lastStartedAt,proto3" json:"last_started_at,omitempty"`
+	LastFinishedAt  int64                  `protobuf:"varint,9,opt,name=last_finished_at,json=lastFinishedAt,proto3" json:"last_finished_at,omitempty"`
+	LastDurationMs  int64                  `protobuf:"varint,10,opt,name=last_duration_ms,json=lastDurationMs,proto3" json:"last_duration_ms,omitempty"`
+	MaxDurationMs   int64                  `protobuf:"varint,11,opt,name=max_duration_ms,json=maxDurationMs,proto3" json:"max_duration_ms,omitempty"`
+	LastError       string                 `protobuf:"bytes,12,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastErrorAt     int64                  `protobuf:"varint,13,opt,name=last_error_at,json=lastErrorAt,proto3" json:"last_error_at,omitempty"`
+	FailingItems    int32                  `protobuf:"varint,14,opt,name=failing_items,json=failingItems,proto3" json:"failing_items,omitempty"` // Applications or domains whose last reconciliation failed
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *AllocationStatus) Reset() {
-	*x = AllocationStatus{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[6]
+func (x *ReconcilerLoop) Reset() {
+	*x = ReconcilerLoop{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[203]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AllocationStatus) String() string {
+func (x *ReconcilerLoop) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AllocationStatus) ProtoMessage() {}
+func (*ReconcilerLoop) ProtoMessage() {}
 
-func (x *AllocationStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[6]
+func (x *ReconcilerLoop) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[203]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -601,95 +15143,135 @@ func (x *AllocationStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AllocationStatus.ProtoReflect.Descriptor instead.
-func (*AllocationStatus) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ReconcilerLoop.ProtoReflect.Descriptor instead.
+func (*ReconcilerLoop) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{203}
 }
 
-func (x *AllocationStatus) GetAllocationId() string {
+func (x *ReconcilerLoop) GetName() string {
 	if x != nil {
-		return x.AllocationId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *AllocationStatus) GetNodeId() string {
+func (x *ReconcilerLoop) GetState() string {
 	if x != nil {
-		return x.NodeId
+		return x.State
 	}
 	return ""
 }
 
-func (x *AllocationStatus) GetNodeName() string {
+func (x *ReconcilerLoop) GetIntervalSeconds() int64 {
 	if x != nil {
-		return x.NodeName
+		return x.IntervalSeconds
 	}
-	return ""
+	return 0
 }
 
-func (x *AllocationStatus) GetStatus() string {
+func (x *ReconcilerLoop) GetRuns() int64 {
 	if x != nil {
-		return x.Status
+		return x.Runs
 	}
-	return ""
+	return 0
 }
 
-func (x *AllocationStatus) GetDesiredStatus() string {
+func (x *ReconcilerLoop) GetFailures() int64 {
 	if x != nil {
-		return x.DesiredStatus
+		return x.Failures
 	}
-	return ""
+	return 0
 }
 
-func (x *AllocationStatus) GetCreateTime() int64 {
+func (x *ReconcilerLoop) GetQueueDepth() int32 {
 	if x != nil {
-		return x.CreateTime
+		return x.QueueDepth
 	}
 	return 0
 }
 
-func (x *AllocationStatus) GetModifyTime() int64 {
+func (x *ReconcilerLoop) GetRunning() bool {
 	if x != nil {
-		return x.ModifyTime
+		return x.Running
+	}
+	return false
+}
+
+func (x *ReconcilerLoop) GetLastStartedAt() int64 {
+	if x != nil {
+		return x.LastStartedAt
 	}
 	return 0
 }
 
-func (x *AllocationStatus) GetTaskStates() map[string]string {
+func (x *ReconcilerLoop) GetLastFinishedAt() int64 {
 	if x != nil {
-		return x.TaskStates
+		return x.LastFinishedAt
 	}
-	return nil
+	return 0
 }
 
-type StatusResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId     string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
-	JobStatus        string                 `protobuf:"bytes,2,opt,name=job_status,json=jobStatus,proto3" json:"job_status,omitempty"`
-	JobType          string                 `protobuf:"bytes,3,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
-	DesiredInstances int32                  `protobuf:"varint,4,opt,name=desired_instances,json=desiredInstances,proto3" json:"desired_instances,omitempty"`
-	RunningInstances int32                  `protobuf:"varint,5,opt,name=running_instances,json=runningInstances,proto3" json:"running_instances,omitempty"`
-	Allocations      []*AllocationStatus    `protobuf:"bytes,6,rep,name=allocations,proto3" json:"allocations,omitempty"`
-	Message          string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *ReconcilerLoop) GetLastDurationMs() int64 {
+	if x != nil {
+		return x.LastDurationMs
+	}
+	return 0
 }
 
-func (x *StatusResponse) Reset() {
-	*x = StatusResponse{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[7]
+func (x *ReconcilerLoop) GetMaxDurationMs() int64 {
+	if x != nil {
+		return x.MaxDurationMs
+	}
+	return 0
+}
+
+func (x *ReconcilerLoop) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ReconcilerLoop) GetLastErrorAt() int64 {
+	if x != nil {
+		return x.LastErrorAt
+	}
+	return 0
+}
+
+func (x *ReconcilerLoop) GetFailingItems() int32 {
+	if x != nil {
+		return x.FailingItems
+	}
+	return 0
+}
+
+type ReconcilerFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Application   string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"` // Or the domain, for domain verification
+	Loop          string                 `protobuf:"bytes,2,opt,name=loop,proto3" json:"loop,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	FailedAt      int64                  `protobuf:"varint,4,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	Attempts      int32                  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"` // Consecutive failed attempts
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcilerFailure) Reset() {
+	*x = ReconcilerFailure{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[204]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatusResponse) String() string {
+func (x *ReconcilerFailure) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse) ProtoMessage() {}
+func (*ReconcilerFailure) ProtoMessage() {}
 
-func (x *StatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[7]
+func (x *ReconcilerFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[204]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -700,87 +15282,129 @@ func (x *StatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
-func (*StatusResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ReconcilerFailure.ProtoReflect.Descriptor instead.
+func (*ReconcilerFailure) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{204}
 }
 
-func (x *StatusResponse) GetDeploymentId() string {
+func (x *ReconcilerFailure) GetApplication() string {
 	if x != nil {
-		return x.DeploymentId
+		return x.Application
 	}
 	return ""
 }
 
-func (x *StatusResponse) GetJobStatus() string {
+func (x *ReconcilerFailure) GetLoop() string {
 	if x != nil {
-		return x.JobStatus
+		return x.Loop
 	}
 	return ""
 }
 
-func (x *StatusResponse) GetJobType() string {
+func (x *ReconcilerFailure) GetError() string {
 	if x != nil {
-		return x.JobType
+		return x.Error
 	}
 	return ""
 }
 
-func (x *StatusResponse) GetDesiredInstances() int32 {
+func (x *ReconcilerFailure) GetFailedAt() int64 {
 	if x != nil {
-		return x.DesiredInstances
+		return x.FailedAt
 	}
 	return 0
 }
 
-func (x *StatusResponse) GetRunningInstances() int32 {
+func (x *ReconcilerFailure) GetAttempts() int32 {
 	if x != nil {
-		return x.RunningInstances
+		return x.Attempts
 	}
 	return 0
 }
 
-func (x *StatusResponse) GetAllocations() []*AllocationStatus {
+type ReconcilerDrift struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Application        string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Detections         int32                  `protobuf:"varint,2,opt,name=detections,proto3" json:"detections,omitempty"`                                           // Times the deployed tag moved since the controller started
+	DriftedAllocations int32                  `protobuf:"varint,3,opt,name=drifted_allocations,json=driftedAllocations,proto3" json:"drifted_allocations,omitempty"` // Allocations which may run the moved tag
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ReconcilerDrift) Reset() {
+	*x = ReconcilerDrift{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[205]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcilerDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcilerDrift) ProtoMessage() {}
+
+func (x *ReconcilerDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[205]
 	if x != nil {
-		return x.Allocations
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *StatusResponse) GetMessage() string {
+// Deprecated: Use ReconcilerDrift.ProtoReflect.Descriptor instead.
+func (*ReconcilerDrift) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{205}
+}
+
+func (x *ReconcilerDrift) GetApplication() string {
 	if x != nil {
-		return x.Message
+		return x.Application
 	}
 	return ""
 }
 
-type LogsRequest struct {
+func (x *ReconcilerDrift) GetDetections() int32 {
+	if x != nil {
+		return x.Detections
+	}
+	return 0
+}
+
+func (x *ReconcilerDrift) GetDriftedAllocations() int32 {
+	if x != nil {
+		return x.DriftedAllocations
+	}
+	return 0
+}
+
+type RolloutQueue struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
-	AllocationId  string                 `protobuf:"bytes,2,opt,name=allocation_id,json=allocationId,proto3" json:"allocation_id,omitempty"`
-	TaskName      string                 `protobuf:"bytes,3,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`
-	Follow        bool                   `protobuf:"varint,4,opt,name=follow,proto3" json:"follow,omitempty"`
-	TailLines     int32                  `protobuf:"varint,5,opt,name=tail_lines,json=tailLines,proto3" json:"tail_lines,omitempty"`
-	LogType       string                 `protobuf:"bytes,6,opt,name=log_type,json=logType,proto3" json:"log_type,omitempty"`
+	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Waiting       int32                  `protobuf:"varint,2,opt,name=waiting,proto3" json:"waiting,omitempty"` // Deployments waiting for the group
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogsRequest) Reset() {
-	*x = LogsRequest{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[8]
+func (x *RolloutQueue) Reset() {
+	*x = RolloutQueue{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[206]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogsRequest) String() string {
+func (x *RolloutQueue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogsRequest) ProtoMessage() {}
+func (*RolloutQueue) ProtoMessage() {}
 
-func (x *LogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[8]
+func (x *RolloutQueue) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[206]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -791,77 +15415,134 @@ func (x *LogsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogsRequest.ProtoReflect.Descriptor instead.
-func (*LogsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RolloutQueue.ProtoReflect.Descriptor instead.
+func (*RolloutQueue) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{206}
 }
 
-func (x *LogsRequest) GetDeploymentId() string {
+func (x *RolloutQueue) GetGroup() string {
 	if x != nil {
-		return x.DeploymentId
+		return x.Group
 	}
 	return ""
 }
 
-func (x *LogsRequest) GetAllocationId() string {
+func (x *RolloutQueue) GetWaiting() int32 {
+	if x != nil {
+		return x.Waiting
+	}
+	return 0
+}
+
+type GetReconcilerStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Loops         []*ReconcilerLoop      `protobuf:"bytes,3,rep,name=loops,proto3" json:"loops,omitempty"`
+	Failures      []*ReconcilerFailure   `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"`
+	Drift         []*ReconcilerDrift     `protobuf:"bytes,5,rep,name=drift,proto3" json:"drift,omitempty"`
+	RolloutQueues []*RolloutQueue        `protobuf:"bytes,6,rep,name=rollout_queues,json=rolloutQueues,proto3" json:"rollout_queues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReconcilerStatusResponse) Reset() {
+	*x = GetReconcilerStatusResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[207]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReconcilerStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReconcilerStatusResponse) ProtoMessage() {}
+
+func (x *GetReconcilerStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[207]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReconcilerStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetReconcilerStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{207}
+}
+
+func (x *GetReconcilerStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetReconcilerStatusResponse) GetMessage() string {
 	if x != nil {
-		return x.AllocationId
+		return x.Message
 	}
 	return ""
 }
 
-func (x *LogsRequest) GetTaskName() string {
+func (x *GetReconcilerStatusResponse) GetLoops() []*ReconcilerLoop {
 	if x != nil {
-		return x.TaskName
+		return x.Loops
 	}
-	return ""
+	return nil
 }
 
-func (x *LogsRequest) GetFollow() bool {
+func (x *GetReconcilerStatusResponse) GetFailures() []*ReconcilerFailure {
 	if x != nil {
-		return x.Follow
+		return x.Failures
 	}
-	return false
+	return nil
 }
 
-func (x *LogsRequest) GetTailLines() int32 {
+func (x *GetReconcilerStatusResponse) GetDrift() []*ReconcilerDrift {
 	if x != nil {
-		return x.TailLines
+		return x.Drift
 	}
-	return 0
+	return nil
 }
 
-func (x *LogsRequest) GetLogType() string {
+func (x *GetReconcilerStatusResponse) GetRolloutQueues() []*RolloutQueue {
 	if x != nil {
-		return x.LogType
+		return x.RolloutQueues
 	}
-	return ""
+	return nil
 }
 
-type LogsResponse struct {
+type GetCalendarRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	LogLines      []string               `protobuf:"bytes,1,rep,name=log_lines,json=logLines,proto3" json:"log_lines,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Hours         int32                  `protobuf:"varint,1,opt,name=hours,proto3" json:"hours,omitempty"`            // How far ahead, defaults to 24, at most 168
+	Tenant        string                 `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`           // Only the operations of this tenant
+	Application   string                 `protobuf:"bytes,3,opt,name=application,proto3" json:"application,omitempty"` // Only the operations of this application
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`     // Only the operations of this namespace, default when empty, * for every namespace
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogsResponse) Reset() {
-	*x = LogsResponse{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[9]
+func (x *GetCalendarRequest) Reset() {
+	*x = GetCalendarRequest{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[208]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogsResponse) String() string {
+func (x *GetCalendarRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogsResponse) ProtoMessage() {}
+func (*GetCalendarRequest) ProtoMessage() {}
 
-func (x *LogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[9]
+func (x *GetCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[208]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -872,54 +15553,66 @@ func (x *LogsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogsResponse.ProtoReflect.Descriptor instead.
-func (*LogsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetCalendarRequest.ProtoReflect.Descriptor instead.
+func (*GetCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{208}
 }
 
-func (x *LogsResponse) GetLogLines() []string {
+func (x *GetCalendarRequest) GetHours() int32 {
 	if x != nil {
-		return x.LogLines
+		return x.Hours
 	}
-	return nil
+	return 0
 }
 
-func (x *LogsResponse) GetMessage() string {
+func (x *GetCalendarRequest) GetTenant() string {
 	if x != nil {
-		return x.Message
+		return x.Tenant
 	}
 	return ""
 }
 
-func (x *LogsResponse) GetSuccess() bool {
+func (x *GetCalendarRequest) GetApplication() string {
 	if x != nil {
-		return x.Success
+		return x.Application
 	}
-	return false
+	return ""
 }
 
-type HealthCheckRequest struct {
+func (x *GetCalendarRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ScheduledOperation is something the control plane or Nomad will do without being asked
+type ScheduledOperation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Time          int64                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"` // Unix seconds, when it was proposed for the operations awaiting approval
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`  // cron_run, backup, rollout_deadline, prediction_end, token_expiration, resource_update or blueprint_update
+	Application   string                 `protobuf:"bytes,3,opt,name=application,proto3" json:"application,omitempty"`
+	Tenant        string                 `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HealthCheckRequest) Reset() {
-	*x = HealthCheckRequest{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[10]
+func (x *ScheduledOperation) Reset() {
+	*x = ScheduledOperation{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[209]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthCheckRequest) String() string {
+func (x *ScheduledOperation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheckRequest) ProtoMessage() {}
+func (*ScheduledOperation) ProtoMessage() {}
 
-func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[10]
+func (x *ScheduledOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[209]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -930,42 +15623,72 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
-func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ScheduledOperation.ProtoReflect.Descriptor instead.
+func (*ScheduledOperation) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{209}
 }
 
-func (x *HealthCheckRequest) GetService() string {
+func (x *ScheduledOperation) GetTime() int64 {
 	if x != nil {
-		return x.Service
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ScheduledOperation) GetKind() string {
+	if x != nil {
+		return x.Kind
 	}
 	return ""
 }
 
-type HealthCheckResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        HealthStatus           `protobuf:"varint,1,opt,name=status,proto3,enum=controlplane.HealthStatus" json:"status,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ScheduledOperation) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
 }
 
-func (x *HealthCheckResponse) Reset() {
-	*x = HealthCheckResponse{}
-	mi := &file_api_proto_controlplane_proto_msgTypes[11]
+func (x *ScheduledOperation) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *ScheduledOperation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetCalendarResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Until            int64                  `protobuf:"varint,3,opt,name=until,proto3" json:"until,omitempty"`                                              // Unix seconds, the end of the window
+	Operations       []*ScheduledOperation  `protobuf:"bytes,4,rep,name=operations,proto3" json:"operations,omitempty"`                                     // Ordered by time
+	PendingApprovals []*ScheduledOperation  `protobuf:"bytes,5,rep,name=pending_approvals,json=pendingApprovals,proto3" json:"pending_approvals,omitempty"` // Applied once approved, whenever that is
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetCalendarResponse) Reset() {
+	*x = GetCalendarResponse{}
+	mi := &file_api_proto_controlplane_proto_msgTypes[210]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthCheckResponse) String() string {
+func (x *GetCalendarResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheckResponse) ProtoMessage() {}
+func (*GetCalendarResponse) ProtoMessage() {}
 
-func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_controlplane_proto_msgTypes[11]
+func (x *GetCalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_controlplane_proto_msgTypes[210]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -976,37 +15699,56 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
-func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetCalendarResponse.ProtoReflect.Descriptor instead.
+func (*GetCalendarResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_controlplane_proto_rawDescGZIP(), []int{210}
 }
 
-func (x *HealthCheckResponse) GetStatus() HealthStatus {
+func (x *GetCalendarResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Status
+		return x.Success
 	}
-	return HealthStatus_UNKNOWN
+	return false
 }
 
-func (x *HealthCheckResponse) GetMessage() string {
+func (x *GetCalendarResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *HealthCheckResponse) GetTimestamp() int64 {
+func (x *GetCalendarResponse) GetUntil() int64 {
 	if x != nil {
-		return x.Timestamp
+		return x.Until
 	}
 	return 0
 }
 
+func (x *GetCalendarResponse) GetOperations() []*ScheduledOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *GetCalendarResponse) GetPendingApprovals() []*ScheduledOperation {
+	if x != nil {
+		return x.PendingApprovals
+	}
+	return nil
+}
+
 var File_api_proto_controlplane_proto protoreflect.FileDescriptor
 
 const file_api_proto_controlplane_proto_rawDesc = "" +
 	"\n" +
-	"\x1capi/proto/controlplane.proto\x12\fcontrolplane\"\xf2\x03\n" +
+	"\x1capi/proto/controlplane.proto\x12\fcontrolplane\x1a google/protobuf/field_mask.proto\"\x80\x01\n" +
+	"\bPortSpec\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12%\n" +
+	"\x0econtainer_port\x18\x02 \x01(\x05R\rcontainerPort\x12\x1b\n" +
+	"\thost_port\x18\x03 \x01(\x05R\bhostPort\x12\x1a\n" +
+	"\bprotocol\x18\x04 \x01(\tR\bprotocol\"\xd0\x04\n" +
 	"\rTraefikConfig\x12\x16\n" +
 	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x12\n" +
 	"\x04host\x18\x02 \x01(\tR\x04host\x12\x1e\n" +
@@ -1023,10 +15765,112 @@ const file_api_proto_controlplane_proto_rawDesc = "" +
 	"pathPrefix\x12 \n" +
 	"\vmiddlewares\x18\n" +
 	" \x03(\tR\vmiddlewares\x12R\n" +
-	"\rcustom_labels\x18\v \x03(\v2-.controlplane.TraefikConfig.CustomLabelsEntryR\fcustomLabels\x1a?\n" +
+	"\rcustom_labels\x18\v \x03(\v2-.controlplane.TraefikConfig.CustomLabelsEntryR\fcustomLabels\x12?\n" +
+	"\rcert_strategy\x18\f \x01(\x0e2\x1a.controlplane.CertStrategyR\fcertStrategy\x12\x1b\n" +
+	"\tcert_sans\x18\r \x03(\tR\bcertSans\x1a?\n" +
 	"\x11CustomLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x88\x03\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
+	"\n" +
+	"Constraint\x12\x1c\n" +
+	"\tattribute\x18\x01 \x01(\tR\tattribute\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"Z\n" +
+	"\rEphemeralDisk\x12\x17\n" +
+	"\asize_mb\x18\x01 \x01(\x05R\x06sizeMb\x12\x18\n" +
+	"\amigrate\x18\x02 \x01(\bR\amigrate\x12\x16\n" +
+	"\x06sticky\x18\x03 \x01(\bR\x06sticky\"\xd0\x01\n" +
+	"\vVolumeMount\x12\x1b\n" +
+	"\tvolume_id\x18\x01 \x01(\tR\bvolumeId\x12 \n" +
+	"\vdestination\x18\x02 \x01(\tR\vdestination\x12\x1b\n" +
+	"\tread_only\x18\x03 \x01(\bR\breadOnly\x12\x1f\n" +
+	"\vaccess_mode\x18\x04 \x01(\tR\n" +
+	"accessMode\x12'\n" +
+	"\x0fattachment_mode\x18\x05 \x01(\tR\x0eattachmentMode\x12\x1b\n" +
+	"\tper_alloc\x18\x06 \x01(\bR\bperAlloc\"l\n" +
+	"\n" +
+	"EgressRule\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x12\n" +
+	"\x04cidr\x18\x02 \x01(\tR\x04cidr\x12\x14\n" +
+	"\x05ports\x18\x03 \x03(\x05R\x05ports\x12\x1a\n" +
+	"\bprotocol\x18\x04 \x01(\tR\bprotocol\"\xbe\x01\n" +
+	"\x0fSecurityContext\x12*\n" +
+	"\x11no_new_privileges\x18\x01 \x01(\bR\x0fnoNewPrivileges\x12'\n" +
+	"\x0fseccomp_profile\x18\x02 \x01(\tR\x0eseccompProfile\x12)\n" +
+	"\x10apparmor_profile\x18\x03 \x01(\tR\x0fapparmorProfile\x12+\n" +
+	"\x11drop_capabilities\x18\x04 \x03(\tR\x10dropCapabilities\"\xc7\x01\n" +
+	"\tPlacement\x12T\n" +
+	"\x0fregion_selector\x18\x01 \x03(\v2+.controlplane.Placement.RegionSelectorEntryR\x0eregionSelector\x12!\n" +
+	"\flatency_hint\x18\x02 \x01(\tR\vlatencyHint\x1aA\n" +
+	"\x13RegionSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"w\n" +
+	"\n" +
+	"GeoRouting\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x121\n" +
+	"\atargets\x18\x02 \x03(\v2\x17.controlplane.GeoTargetR\atargets\x12\x1a\n" +
+	"\bfailover\x18\x03 \x01(\bR\bfailover\"Y\n" +
+	"\tGeoTarget\x12\x16\n" +
+	"\x06region\x18\x01 \x01(\tR\x06region\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x03R\x06weight\x12\x1c\n" +
+	"\tlocations\x18\x03 \x03(\tR\tlocations\"\x82\x02\n" +
+	"\x0eUpdateStrategy\x12!\n" +
+	"\fmax_parallel\x18\x01 \x01(\x05R\vmaxParallel\x12\x16\n" +
+	"\x06canary\x18\x02 \x01(\x05R\x06canary\x12\x1f\n" +
+	"\vauto_revert\x18\x03 \x01(\bR\n" +
+	"autoRevert\x12!\n" +
+	"\fauto_promote\x18\x04 \x01(\bR\vautoPromote\x127\n" +
+	"\x18min_healthy_time_seconds\x18\x05 \x01(\x05R\x15minHealthyTimeSeconds\x128\n" +
+	"\x18healthy_deadline_seconds\x18\x06 \x01(\x05R\x16healthyDeadlineSeconds\"\x87\x01\n" +
+	"\fTrafficShift\x12\x18\n" +
+	"\aweights\x18\x01 \x03(\x05R\aweights\x122\n" +
+	"\x15step_interval_seconds\x18\x02 \x01(\x05R\x13stepIntervalSeconds\x12)\n" +
+	"\x10manual_promotion\x18\x03 \x01(\bR\x0fmanualPromotion\"\xb5\x02\n" +
+	"\vAutoscaling\x12!\n" +
+	"\fmin_replicas\x18\x01 \x01(\x05R\vminReplicas\x12!\n" +
+	"\fmax_replicas\x18\x02 \x01(\x05R\vmaxReplicas\x125\n" +
+	"\ametrics\x18\x03 \x03(\v2\x1b.controlplane.ScalingMetricR\ametrics\x12)\n" +
+	"\x10cooldown_seconds\x18\x04 \x01(\x05R\x0fcooldownSeconds\x12=\n" +
+	"\x1bscale_down_cooldown_seconds\x18\x05 \x01(\x05R\x18scaleDownCooldownSeconds\x12?\n" +
+	"\n" +
+	"prediction\x18\x06 \x01(\v2\x1f.controlplane.ScalingPredictionR\n" +
+	"prediction\"u\n" +
+	"\x11ScalingPrediction\x12\x16\n" +
+	"\x06season\x18\x01 \x01(\tR\x06season\x12%\n" +
+	"\x0emin_confidence\x18\x02 \x01(\x01R\rminConfidence\x12!\n" +
+	"\flead_seconds\x18\x03 \x01(\x05R\vleadSeconds\"\x86\x01\n" +
+	"\rScalingMetric\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\x01R\x06target\x12+\n" +
+	"\x11smoothing_seconds\x18\x04 \x01(\x05R\x10smoothingSeconds\">\n" +
+	"\fEgressConfig\x12.\n" +
+	"\x05rules\x18\x01 \x03(\v2\x18.controlplane.EgressRuleR\x05rules\"\xee\x01\n" +
+	"\fBackupConfig\x12 \n" +
+	"\vdestination\x18\x01 \x01(\tR\vdestination\x12\x1a\n" +
+	"\bschedule\x18\x02 \x01(\tR\bschedule\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\x12\x14\n" +
+	"\x05image\x18\x04 \x01(\tR\x05image\x125\n" +
+	"\x03env\x18\x05 \x03(\v2#.controlplane.BackupConfig.EnvEntryR\x03env\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"~\n" +
+	"\x05AddOn\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x16\n" +
+	"\x06memory\x18\x04 \x01(\x03R\x06memory\x12\x1b\n" +
+	"\tvolume_id\x18\x05 \x01(\tR\bvolumeId\"\x9b\x01\n" +
+	"\x0eFunctionConfig\x12'\n" +
+	"\x0fmax_concurrency\x18\x01 \x01(\x05R\x0emaxConcurrency\x12'\n" +
+	"\x0ftimeout_seconds\x18\x02 \x01(\x05R\x0etimeoutSeconds\x12\x1a\n" +
+	"\bartifact\x18\x03 \x01(\tR\bartifact\x12\x1b\n" +
+	"\tmeta_keys\x18\x04 \x03(\tR\bmetaKeys\"p\n" +
+	"\n" +
+	"CronConfig\x12\x1a\n" +
+	"\bschedule\x18\x01 \x01(\tR\bschedule\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\x12)\n" +
+	"\x10prohibit_overlap\x18\x03 \x01(\bR\x0fprohibitOverlap\"\xc1\x10\n" +
 	"\rDeployRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05image\x18\x02 \x01(\tR\x05image\x12\x1a\n" +
@@ -1036,22 +15880,213 @@ const file_api_proto_controlplane_proto_rawDesc = "" +
 	"\x06region\x18\x06 \x01(\tR\x06region\x12?\n" +
 	"\x06labels\x18\a \x03(\v2'.controlplane.DeployRequest.LabelsEntryR\x06labels\x125\n" +
 	"\atraefik\x18\b \x01(\v2\x1b.controlplane.TraefikConfigR\atraefik\x12<\n" +
-	"\fnetwork_mode\x18\t \x01(\x0e2\x19.controlplane.NetworkModeR\vnetworkMode\x1a9\n" +
+	"\fnetwork_mode\x18\t \x01(\x0e2\x19.controlplane.NetworkModeR\vnetworkMode\x12:\n" +
+	"\vconstraints\x18\n" +
+	" \x03(\v2\x18.controlplane.ConstraintR\vconstraints\x12B\n" +
+	"\x0eephemeral_disk\x18\v \x01(\v2\x1b.controlplane.EphemeralDiskR\rephemeralDisk\x120\n" +
+	"\x14idle_timeout_minutes\x18\f \x01(\x05R\x12idleTimeoutMinutes\x120\n" +
+	"\x04type\x18\r \x01(\x0e2\x1c.controlplane.DeploymentTypeR\x04type\x128\n" +
+	"\bfunction\x18\x0e \x01(\v2\x1c.controlplane.FunctionConfigR\bfunction\x12,\n" +
+	"\x04cron\x18\x0f \x01(\v2\x18.controlplane.CronConfigR\x04cron\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x10 \x03(\tR\tdependsOn\x123\n" +
+	"\avolumes\x18\x11 \x03(\v2\x19.controlplane.VolumeMountR\avolumes\x122\n" +
+	"\x06backup\x18\x12 \x01(\v2\x1a.controlplane.BackupConfigR\x06backup\x12+\n" +
+	"\x06addons\x18\x13 \x03(\v2\x13.controlplane.AddOnR\x06addons\x12\x16\n" +
+	"\x06tenant\x18\x14 \x01(\tR\x06tenant\x122\n" +
+	"\x06egress\x18\x15 \x01(\v2\x1a.controlplane.EgressConfigR\x06egress\x129\n" +
+	"\bsecurity\x18\x16 \x01(\v2\x1d.controlplane.SecurityContextR\bsecurity\x12 \n" +
+	"\fpin_on_drift\x18\x17 \x01(\bR\n" +
+	"pinOnDrift\x12\x1d\n" +
+	"\n" +
+	"memory_max\x18\x18 \x01(\x03R\tmemoryMax\x12N\n" +
+	"\vannotations\x18\x19 \x03(\v2,.controlplane.DeployRequest.AnnotationsEntryR\vannotations\x12+\n" +
+	"\x11concurrency_group\x18\x1a \x01(\tR\x10concurrencyGroup\x128\n" +
+	"\x18rollout_deadline_seconds\x18\x1b \x01(\x05R\x16rolloutDeadlineSeconds\x12,\n" +
+	"\x12revert_on_deadline\x18\x1c \x01(\bR\x10revertOnDeadline\x124\n" +
+	"\x06update\x18\x1d \x01(\v2\x1c.controlplane.UpdateStrategyR\x06update\x125\n" +
+	"\tplacement\x18\x1e \x01(\v2\x17.controlplane.PlacementR\tplacement\x12*\n" +
+	"\x03geo\x18\x1f \x01(\v2\x18.controlplane.GeoRoutingR\x03geo\x12.\n" +
+	"\aactions\x18  \x03(\v2\x14.controlplane.ActionR\aactions\x123\n" +
+	"\tconsul_kv\x18! \x01(\v2\x16.controlplane.ConsulKVR\bconsulKv\x12\x1d\n" +
+	"\n" +
+	"allow_from\x18\" \x03(\tR\tallowFrom\x12\x1f\n" +
+	"\vcommit_time\x18# \x01(\x03R\n" +
+	"commitTime\x12;\n" +
+	"\vautoscaling\x18$ \x01(\v2\x19.controlplane.AutoscalingR\vautoscaling\x126\n" +
+	"\x03env\x18% \x03(\v2$.controlplane.DeployRequest.EnvEntryR\x03env\x12,\n" +
+	"\x05ports\x18& \x03(\v2\x16.controlplane.PortSpecR\x05ports\x12\x17\n" +
+	"\adry_run\x18' \x01(\bR\x06dryRun\x12\x1c\n" +
+	"\tnamespace\x18( \x01(\tR\tnamespace\x128\n" +
+	"\bstrategy\x18) \x01(\x0e2\x1c.controlplane.DeployStrategyR\bstrategy\x12?\n" +
+	"\rtraffic_shift\x18* \x01(\v2\x1a.controlplane.TrafficShiftR\ftrafficShift\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"g\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xff\x01\n" +
+	"\bConsulKV\x12:\n" +
+	"\x06values\x18\x01 \x03(\v2\".controlplane.ConsulKV.ValuesEntryR\x06values\x12\x14\n" +
+	"\x05prune\x18\x02 \x01(\bR\x05prune\x12 \n" +
+	"\vdestination\x18\x03 \x01(\tR\vdestination\x12\x1f\n" +
+	"\vchange_mode\x18\x04 \x01(\tR\n" +
+	"changeMode\x12#\n" +
+	"\rchange_signal\x18\x05 \x01(\tR\fchangeSignal\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"J\n" +
+	"\x06Action\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\x12\x12\n" +
+	"\x04args\x18\x03 \x03(\tR\x04args\">\n" +
+	"\tSpecChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x02 \x01(\x03R\ttotalSize\"\x89\x01\n" +
+	"\x13DeployRawJobRequest\x12\x10\n" +
+	"\x03job\x18\x01 \x01(\tR\x03job\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06tenant\x18\x04 \x01(\tR\x06tenant\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\"\xe0\x01\n" +
 	"\x0eDeployResponse\x12#\n" +
 	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"W\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x15\n" +
+	"\x06job_id\x18\x04 \x01(\tR\x05jobId\x125\n" +
+	"\bwarnings\x18\x05 \x03(\v2\x19.controlplane.LintWarningR\bwarnings\x12)\n" +
+	"\x04plan\x18\x06 \x01(\v2\x15.controlplane.JobPlanR\x04plan\"\x84\x02\n" +
+	"\aJobPlan\x12\x1b\n" +
+	"\tdiff_type\x18\x01 \x01(\tR\bdiffType\x122\n" +
+	"\achanges\x18\x02 \x03(\v2\x18.controlplane.PlanChangeR\achanges\x125\n" +
+	"\x06groups\x18\x03 \x03(\v2\x1d.controlplane.PlanGroupUpdateR\x06groups\x12+\n" +
+	"\x11failed_placements\x18\x04 \x03(\tR\x10failedPlacements\x12\x1a\n" +
+	"\bwarnings\x18\x05 \x01(\tR\bwarnings\x12(\n" +
+	"\x10job_modify_index\x18\x06 \x01(\x04R\x0ejobModifyIndex\"X\n" +
+	"\n" +
+	"PlanChange\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x10\n" +
+	"\x03old\x18\x03 \x01(\tR\x03old\x12\x10\n" +
+	"\x03new\x18\x04 \x01(\tR\x03new\"\x9d\x02\n" +
+	"\x0fPlanGroupUpdate\x12\x1d\n" +
+	"\n" +
+	"task_group\x18\x01 \x01(\tR\ttaskGroup\x12\x14\n" +
+	"\x05place\x18\x02 \x01(\x04R\x05place\x12\x12\n" +
+	"\x04stop\x18\x03 \x01(\x04R\x04stop\x12&\n" +
+	"\x0fin_place_update\x18\x04 \x01(\x04R\rinPlaceUpdate\x12-\n" +
+	"\x12destructive_update\x18\x05 \x01(\x04R\x11destructiveUpdate\x12\x18\n" +
+	"\amigrate\x18\x06 \x01(\x04R\amigrate\x12\x16\n" +
+	"\x06canary\x18\a \x01(\x04R\x06canary\x12\x16\n" +
+	"\x06ignore\x18\b \x01(\x04R\x06ignore\x12 \n" +
+	"\vpreemptions\x18\t \x01(\x04R\vpreemptions\"Q\n" +
+	"\vLintWarning\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"b\n" +
+	"\x10StackApplication\x12/\n" +
+	"\x04spec\x18\x01 \x01(\v2\x1b.controlplane.DeployRequestR\x04spec\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x02 \x03(\tR\tdependsOn\"\xec\x01\n" +
+	"\x12DeployStackRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12B\n" +
+	"\fapplications\x18\x02 \x03(\v2\x1e.controlplane.StackApplicationR\fapplications\x12*\n" +
+	"\x11continue_on_error\x18\x03 \x01(\bR\x0fcontinueOnError\x124\n" +
+	"\x16health_timeout_seconds\x18\x04 \x01(\x05R\x14healthTimeoutSeconds\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\"t\n" +
+	"\x16StackApplicationResult\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05stage\x18\x02 \x01(\x05R\x05stage\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\xa5\x01\n" +
+	"\x13DeployStackResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12H\n" +
+	"\fapplications\x18\x03 \x03(\v2$.controlplane.StackApplicationResultR\fapplications\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\x9a\x01\n" +
+	"\x17PublishBlueprintRequest\x12\x1c\n" +
+	"\tblueprint\x18\x01 \x01(\tR\tblueprint\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\x12/\n" +
+	"\x04spec\x18\x03 \x01(\v2\x1b.controlplane.DeployRequestR\x04spec\x12\x16\n" +
+	"\x06tenant\x18\x04 \x01(\tR\x06tenant\"\xd0\x01\n" +
+	"\x18PublishBlueprintResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x121\n" +
+	"\x14updated_applications\x18\x04 \x03(\tR\x13updatedApplications\x123\n" +
+	"\x15proposed_applications\x18\x05 \x03(\tR\x14proposedApplications\"\x9a\x02\n" +
+	"\x10SubscribeRequest\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x1c\n" +
+	"\tblueprint\x18\x02 \x01(\tR\tblueprint\x12\x18\n" +
+	"\achannel\x18\x03 \x01(\tR\achannel\x122\n" +
+	"\x06policy\x18\x04 \x01(\x0e2\x1a.controlplane.UpdatePolicyR\x06policy\x129\n" +
+	"\toverrides\x18\x05 \x01(\v2\x1b.controlplane.DeployRequestR\toverrides\x12%\n" +
+	"\x0epinned_version\x18\x06 \x01(\x05R\rpinnedVersion\x12\x16\n" +
+	"\x06tenant\x18\a \x01(\tR\x06tenant\"\x86\x01\n" +
+	"\x11SubscribeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12#\n" +
+	"\rdeployment_id\x18\x04 \x01(\tR\fdeploymentId\"\xc5\x02\n" +
+	"\fSubscription\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x1c\n" +
+	"\tblueprint\x18\x02 \x01(\tR\tblueprint\x12\x18\n" +
+	"\achannel\x18\x03 \x01(\tR\achannel\x122\n" +
+	"\x06policy\x18\x04 \x01(\x0e2\x1a.controlplane.UpdatePolicyR\x06policy\x12%\n" +
+	"\x0epinned_version\x18\x05 \x01(\x05R\rpinnedVersion\x12)\n" +
+	"\x10deployed_version\x18\x06 \x01(\x05R\x0fdeployedVersion\x12%\n" +
+	"\x0elatest_version\x18\a \x01(\x05R\rlatestVersion\x12\x16\n" +
+	"\x06behind\x18\b \x01(\bR\x06behind\x12\x16\n" +
+	"\x06tenant\x18\t \x01(\tR\x06tenant\"Y\n" +
+	"\x18ListSubscriptionsRequest\x12\x1c\n" +
+	"\tblueprint\x18\x01 \x01(\tR\tblueprint\x12\x1f\n" +
+	"\vbehind_only\x18\x02 \x01(\bR\n" +
+	"behindOnly\"w\n" +
+	"\x19ListSubscriptionsResponse\x12@\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2\x1a.controlplane.SubscriptionR\rsubscriptions\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"Y\n" +
+	"\x1bApplyBlueprintUpdateRequest\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\x91\x01\n" +
+	"\x1cApplyBlueprintUpdateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12#\n" +
+	"\rdeployment_id\x18\x04 \x01(\tR\fdeploymentId\"A\n" +
+	"\rImpactRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"Q\n" +
+	"\x13ImpactedApplication\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\x12\x10\n" +
+	"\x03via\x18\x03 \x03(\tR\x03via\"\xa3\x01\n" +
+	"\x0eImpactResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
+	"\fdependencies\x18\x02 \x03(\tR\fdependencies\x12?\n" +
+	"\tconsumers\x18\x03 \x03(\v2!.controlplane.ImpactedApplicationR\tconsumers\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\x18\n" +
+	"\x16DependencyGraphRequest\"Q\n" +
+	"\x0eDependencyEdge\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x02 \x01(\tR\tdependsOn\"g\n" +
+	"\x17DependencyGraphResponse\x122\n" +
+	"\x05edges\x18\x01 \x03(\v2\x1c.controlplane.DependencyEdgeR\x05edges\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"u\n" +
 	"\rDeleteRequest\x12#\n" +
 	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12!\n" +
-	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\"D\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"D\n" +
 	"\x0eDeleteResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"4\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"R\n" +
 	"\rStatusRequest\x12#\n" +
-	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\xfe\x02\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\x9d\x03\n" +
 	"\x10AllocationStatus\x12#\n" +
 	"\rallocation_id\x18\x01 \x01(\tR\fallocationId\x12\x17\n" +
 	"\anode_id\x18\x02 \x01(\tR\x06nodeId\x12\x1b\n" +
@@ -1063,10 +16098,78 @@ const file_api_proto_controlplane_proto_rawDesc = "" +
 	"\vmodify_time\x18\a \x01(\x03R\n" +
 	"modifyTime\x12O\n" +
 	"\vtask_states\x18\b \x03(\v2..controlplane.AllocationStatus.TaskStatesEntryR\n" +
-	"taskStates\x1a=\n" +
+	"taskStates\x12\x1d\n" +
+	"\n" +
+	"task_group\x18\t \x01(\tR\ttaskGroup\x1a=\n" +
 	"\x0fTaskStatesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa5\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd7\x01\n" +
+	"\x0fTaskGroupStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x11desired_instances\x18\x02 \x01(\x05R\x10desiredInstances\x12+\n" +
+	"\x11running_instances\x18\x03 \x01(\x05R\x10runningInstances\x12+\n" +
+	"\x11healthy_instances\x18\x04 \x01(\x05R\x10healthyInstances\x12)\n" +
+	"\x10failed_instances\x18\x05 \x01(\x05R\x0ffailedInstances\"\xa8\x03\n" +
+	"\x0fRolloutProgress\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12+\n" +
+	"\x11desired_instances\x18\x03 \x01(\x05R\x10desiredInstances\x12+\n" +
+	"\x11healthy_instances\x18\x04 \x01(\x05R\x10healthyInstances\x12\x18\n" +
+	"\apercent\x18\x05 \x01(\x01R\apercent\x12\x1f\n" +
+	"\veta_seconds\x18\x06 \x01(\x03R\n" +
+	"etaSeconds\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\a \x01(\x03R\tstartedAt\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12\x1a\n" +
+	"\breverted\x18\t \x01(\bR\breverted\x12.\n" +
+	"\x13reverted_to_version\x18\n" +
+	" \x01(\x04R\x11revertedToVersion\x12\x1f\n" +
+	"\vjob_version\x18\v \x01(\x04R\n" +
+	"jobVersion\x12\x1f\n" +
+	"\vfinished_at\x18\f \x01(\x03R\n" +
+	"finishedAt\"o\n" +
+	"\x16WatchDeploymentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\revaluation_id\x18\x02 \x01(\tR\fevaluationId\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\x89\x01\n" +
+	"\x18PromoteDeploymentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rdeployment_id\x18\x02 \x01(\tR\fdeploymentId\x12\x16\n" +
+	"\x06groups\x18\x03 \x03(\tR\x06groups\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\x8d\x01\n" +
+	"\x19PromoteDeploymentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\rdeployment_id\x18\x03 \x01(\tR\fdeploymentId\x12\x17\n" +
+	"\aeval_id\x18\x04 \x01(\tR\x06evalId\"n\n" +
+	"\x15FailDeploymentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rdeployment_id\x18\x02 \x01(\tR\fdeploymentId\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\x8a\x01\n" +
+	"\x16FailDeploymentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\rdeployment_id\x18\x03 \x01(\tR\fdeploymentId\x12\x17\n" +
+	"\aeval_id\x18\x04 \x01(\tR\x06evalId\"\x84\x04\n" +
+	"\x0fDeploymentEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
+	"\x04time\x18\x03 \x01(\x03R\x04time\x12#\n" +
+	"\revaluation_id\x18\x04 \x01(\tR\fevaluationId\x12#\n" +
+	"\rdeployment_id\x18\x05 \x01(\tR\fdeploymentId\x12#\n" +
+	"\rallocation_id\x18\x06 \x01(\tR\fallocationId\x12\x1d\n" +
+	"\n" +
+	"task_group\x18\a \x01(\tR\ttaskGroup\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12+\n" +
+	"\x11desired_instances\x18\t \x01(\x05R\x10desiredInstances\x12)\n" +
+	"\x10placed_instances\x18\n" +
+	" \x01(\x05R\x0fplacedInstances\x12+\n" +
+	"\x11healthy_instances\x18\v \x01(\x05R\x10healthyInstances\x12/\n" +
+	"\x13unhealthy_instances\x18\f \x01(\x05R\x12unhealthyInstances\x12\x18\n" +
+	"\asuccess\x18\r \x01(\bR\asuccess\x12\x18\n" +
+	"\apercent\x18\x0e \x01(\x01R\apercent\x12\x1f\n" +
+	"\veta_seconds\x18\x0f \x01(\x03R\n" +
+	"etaSeconds\"\xfe\x05\n" +
 	"\x0eStatusResponse\x12#\n" +
 	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
 	"\n" +
@@ -1075,7 +16178,199 @@ const file_api_proto_controlplane_proto_rawDesc = "" +
 	"\x11desired_instances\x18\x04 \x01(\x05R\x10desiredInstances\x12+\n" +
 	"\x11running_instances\x18\x05 \x01(\x05R\x10runningInstances\x12@\n" +
 	"\vallocations\x18\x06 \x03(\v2\x1e.controlplane.AllocationStatusR\vallocations\x12\x18\n" +
-	"\amessage\x18\a \x01(\tR\amessage\"\xc6\x01\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x12>\n" +
+	"\vtask_groups\x18\b \x03(\v2\x1d.controlplane.TaskGroupStatusR\n" +
+	"taskGroups\x12+\n" +
+	"\x11healthy_instances\x18\t \x01(\x05R\x10healthyInstances\x12)\n" +
+	"\x10failed_instances\x18\n" +
+	" \x01(\x05R\x0ffailedInstances\x127\n" +
+	"\arollout\x18\v \x01(\v2\x1d.controlplane.RolloutProgressR\arollout\x12\x15\n" +
+	"\x06job_id\x18\f \x01(\tR\x05jobId\x12 \n" +
+	"\vapplication\x18\r \x01(\tR\vapplication\x12\x16\n" +
+	"\x06tenant\x18\x0e \x01(\tR\x06tenant\x127\n" +
+	"\ahistory\x18\x0f \x03(\v2\x1d.controlplane.RolloutProgressR\ahistory\x12)\n" +
+	"\x03geo\x18\x10 \x03(\v2\x17.controlplane.GeoRegionR\x03geo\x12\x1b\n" +
+	"\tcached_at\x18\x11 \x01(\x03R\bcachedAt\x124\n" +
+	"\atraffic\x18\x12 \x01(\v2\x1a.controlplane.TrafficSplitR\atraffic\"\xcc\x01\n" +
+	"\fTrafficSplit\x12\x1a\n" +
+	"\bstrategy\x18\x01 \x01(\tR\bstrategy\x12 \n" +
+	"\fgreen_job_id\x18\x02 \x01(\tR\n" +
+	"greenJobId\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x05R\x06weight\x12\x18\n" +
+	"\aweights\x18\x05 \x03(\x05R\aweights\x12\x14\n" +
+	"\x05phase\x18\x06 \x01(\tR\x05phase\x12 \n" +
+	"\fnext_step_at\x18\a \x01(\x03R\n" +
+	"nextStepAt\"\x8c\x01\n" +
+	"\tGeoRegion\x12\x16\n" +
+	"\x06region\x18\x01 \x01(\tR\x06region\x12\x18\n" +
+	"\ahealthy\x18\x02 \x01(\bR\ahealthy\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x04 \x01(\x03R\tchangedAt\x12\x16\n" +
+	"\x06routed\x18\x05 \x01(\bR\x06routed\".\n" +
+	"\x18ApplicationHealthRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"~\n" +
+	"\x11ApplicationHealth\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12=\n" +
+	"\x06status\x18\x02 \x01(\x0e2%.controlplane.ApplicationHealthStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"z\n" +
+	"\x19ApplicationHealthResponse\x12C\n" +
+	"\fapplications\x18\x01 \x03(\v2\x1f.controlplane.ApplicationHealthR\fapplications\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xab\x02\n" +
+	"\x17ListApplicationsRequest\x12I\n" +
+	"\x06labels\x18\x01 \x03(\v21.controlplane.ListApplicationsRequest.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x18\n" +
+	"\aproject\x18\x05 \x01(\tR\aproject\x12\x1c\n" +
+	"\tnamespace\x18\x06 \x01(\tR\tnamespace\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa1\x04\n" +
+	"\x12ApplicationSummary\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06tenant\x18\x03 \x01(\tR\x06tenant\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x14\n" +
+	"\x05image\x18\x06 \x01(\tR\x05image\x12+\n" +
+	"\x11desired_instances\x18\a \x01(\x05R\x10desiredInstances\x12+\n" +
+	"\x11running_instances\x18\b \x01(\x05R\x10runningInstances\x12+\n" +
+	"\x11healthy_instances\x18\t \x01(\x05R\x10healthyInstances\x12)\n" +
+	"\x10failed_instances\x18\n" +
+	" \x01(\x05R\x0ffailedInstances\x12\x16\n" +
+	"\x06region\x18\v \x01(\tR\x06region\x12\x10\n" +
+	"\x03url\x18\f \x01(\tR\x03url\x12!\n" +
+	"\fsubmitted_at\x18\r \x01(\x03R\vsubmittedAt\x12\x14\n" +
+	"\x05owner\x18\x0e \x01(\tR\x05owner\x12\x1a\n" +
+	"\brevision\x18\x0f \x01(\x05R\brevision\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\x03R\tcreatedAt\x12\x18\n" +
+	"\aproject\x18\x11 \x01(\tR\aproject\x12\x1c\n" +
+	"\tnamespace\x18\x12 \x01(\tR\tnamespace\"\xa2\x01\n" +
+	"\x18ListApplicationsResponse\x12D\n" +
+	"\fapplications\x18\x01 \x03(\v2 .controlplane.ApplicationSummaryR\fapplications\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\x86\x01\n" +
+	"\fScaleRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1d\n" +
+	"\n" +
+	"task_group\x18\x03 \x01(\tR\ttaskGroup\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\xd3\x01\n" +
+	"\rScaleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"task_group\x18\x03 \x01(\tR\ttaskGroup\x12%\n" +
+	"\x0eprevious_count\x18\x04 \x01(\x05R\rpreviousCount\x12#\n" +
+	"\rdesired_count\x18\x05 \x01(\x05R\fdesiredCount\x12#\n" +
+	"\rrunning_count\x18\x06 \x01(\x05R\frunningCount\"\x8a\x01\n" +
+	"\x0fRollbackRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x04R\aversion\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\x05R\brevision\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\xdf\x01\n" +
+	"\x18UpdateApplicationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12/\n" +
+	"\x04spec\x18\x02 \x01(\v2\x1b.controlplane.DeployRequestR\x04spec\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12#\n" +
+	"\rbase_revision\x18\x04 \x01(\x05R\fbaseRevision\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\"\x95\x01\n" +
+	"\x10RollbackResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x04R\aversion\x12\x17\n" +
+	"\aeval_id\x18\x04 \x01(\tR\x06evalId\x12\x1a\n" +
+	"\brevision\x18\x05 \x01(\x05R\brevision\"\xcf\x01\n" +
+	"\rInvokeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\x129\n" +
+	"\x04meta\x18\x03 \x03(\v2%.controlplane.InvokeRequest.MetaEntryR\x04meta\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x1a7\n" +
+	"\tMetaEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc3\x01\n" +
+	"\n" +
+	"Invocation\x12#\n" +
+	"\rinvocation_id\x18\x01 \x01(\tR\finvocationId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1b\n" +
+	"\texit_code\x18\x03 \x01(\x05R\bexitCode\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\x12\x1b\n" +
+	"\tqueued_ms\x18\x05 \x01(\x03R\bqueuedMs\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\x03R\tstartedAt\"\x96\x01\n" +
+	"\x0eInvokeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x128\n" +
+	"\n" +
+	"invocation\x18\x03 \x01(\v2\x18.controlplane.InvocationR\n" +
+	"invocation\x12\x16\n" +
+	"\x06output\x18\x04 \x01(\tR\x06output\"J\n" +
+	"\x16FunctionMetricsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\xa4\x02\n" +
+	"\x17FunctionMetricsResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vinvocations\x18\x02 \x01(\x03R\vinvocations\x12\x1a\n" +
+	"\bfailures\x18\x03 \x01(\x03R\bfailures\x12&\n" +
+	"\x0fp50_duration_ms\x18\x04 \x01(\x03R\rp50DurationMs\x12&\n" +
+	"\x0fp95_duration_ms\x18\x05 \x01(\x03R\rp95DurationMs\x12\x1b\n" +
+	"\tin_flight\x18\x06 \x01(\x05R\binFlight\x120\n" +
+	"\x06recent\x18\a \x03(\v2\x18.controlplane.InvocationR\x06recent\x12\x18\n" +
+	"\amessage\x18\b \x01(\tR\amessage\"\xd6\x01\n" +
+	"\x0fDispatchRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\x12;\n" +
+	"\x04meta\x18\x03 \x03(\v2'.controlplane.DispatchRequest.MetaEntryR\x04meta\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x1a7\n" +
+	"\tMetaEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8b\x01\n" +
+	"\x10DispatchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
+	"\x11dispatched_job_id\x18\x03 \x01(\tR\x0fdispatchedJobId\x12\x17\n" +
+	"\aeval_id\x18\x04 \x01(\tR\x06evalId\"Y\n" +
+	"\x0fCronRunsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\x99\x01\n" +
+	"\aCronRun\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x04 \x01(\x03R\n" +
+	"finishedAt\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\"\xd7\x01\n" +
+	"\x10CronRunsResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bschedule\x18\x02 \x01(\tR\bschedule\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\x12\x16\n" +
+	"\x06paused\x18\x04 \x01(\bR\x06paused\x12\x19\n" +
+	"\bnext_run\x18\x05 \x01(\x03R\anextRun\x12)\n" +
+	"\x04runs\x18\x06 \x03(\v2\x15.controlplane.CronRunR\x04runs\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\"F\n" +
+	"\x12CronTriggerRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"b\n" +
+	"\x13CronTriggerResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\aeval_id\x18\x03 \x01(\tR\x06evalId\"\\\n" +
+	"\x10CronPauseRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06paused\x18\x02 \x01(\bR\x06paused\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"G\n" +
+	"\x11CronPauseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xe4\x01\n" +
 	"\vLogsRequest\x12#\n" +
 	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12#\n" +
 	"\rallocation_id\x18\x02 \x01(\tR\fallocationId\x12\x1b\n" +
@@ -1083,32 +16378,835 @@ const file_api_proto_controlplane_proto_rawDesc = "" +
 	"\x06follow\x18\x04 \x01(\bR\x06follow\x12\x1d\n" +
 	"\n" +
 	"tail_lines\x18\x05 \x01(\x05R\ttailLines\x12\x19\n" +
-	"\blog_type\x18\x06 \x01(\tR\alogType\"_\n" +
+	"\blog_type\x18\x06 \x01(\tR\alogType\x12\x1c\n" +
+	"\tnamespace\x18\a \x01(\tR\tnamespace\"_\n" +
 	"\fLogsResponse\x12\x1b\n" +
 	"\tlog_lines\x18\x01 \x03(\tR\blogLines\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x03 \x01(\bR\asuccess\".\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\"x\n" +
+	"\bLogChunk\x12\x14\n" +
+	"\x05lines\x18\x01 \x03(\tR\x05lines\x12#\n" +
+	"\rallocation_id\x18\x02 \x01(\tR\fallocationId\x12\x1b\n" +
+	"\ttask_name\x18\x03 \x01(\tR\btaskName\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"`\n" +
+	"\x1bGetApplicationConfigRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\x8b\x02\n" +
+	"\x1bSetApplicationConfigRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12M\n" +
+	"\x06values\x18\x02 \x03(\v25.controlplane.SetApplicationConfigRequest.ValuesEntryR\x06values\x12\x1f\n" +
+	"\vdelete_keys\x18\x03 \x03(\tR\n" +
+	"deleteKeys\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xef\x01\n" +
+	"\x19ApplicationConfigResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12K\n" +
+	"\x06values\x18\x04 \x03(\v23.controlplane.ApplicationConfigResponse.ValuesEntryR\x06values\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd8\x01\n" +
+	"\x10RunActionRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12#\n" +
+	"\rallocation_id\x18\x03 \x01(\tR\fallocationId\x12\x1b\n" +
+	"\ttask_name\x18\x04 \x01(\tR\btaskName\x12'\n" +
+	"\x0ftimeout_seconds\x18\x05 \x01(\x05R\x0etimeoutSeconds\x12\x1c\n" +
+	"\tnamespace\x18\x06 \x01(\tR\tnamespace\"\xb9\x01\n" +
+	"\x11RunActionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\texit_code\x18\x03 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06stdout\x18\x04 \x01(\tR\x06stdout\x12\x16\n" +
+	"\x06stderr\x18\x05 \x01(\tR\x06stderr\x12#\n" +
+	"\rallocation_id\x18\x06 \x01(\tR\fallocationId\"\xe0\x04\n" +
+	"\x13CreateVolumeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tplugin_id\x18\x03 \x01(\tR\bpluginId\x12\x1f\n" +
+	"\vexternal_id\x18\x04 \x01(\tR\n" +
+	"externalId\x12&\n" +
+	"\x0fcapacity_min_mb\x18\x05 \x01(\x03R\rcapacityMinMb\x12&\n" +
+	"\x0fcapacity_max_mb\x18\x06 \x01(\x03R\rcapacityMaxMb\x12\x1f\n" +
+	"\vaccess_mode\x18\a \x01(\tR\n" +
+	"accessMode\x12'\n" +
+	"\x0fattachment_mode\x18\b \x01(\tR\x0eattachmentMode\x12\x17\n" +
+	"\afs_type\x18\t \x01(\tR\x06fsType\x12Q\n" +
+	"\n" +
+	"parameters\x18\n" +
+	" \x03(\v21.controlplane.CreateVolumeRequest.ParametersEntryR\n" +
+	"parameters\x12H\n" +
+	"\asecrets\x18\v \x03(\v2..controlplane.CreateVolumeRequest.SecretsEntryR\asecrets\x12\x1c\n" +
+	"\tnamespace\x18\f \x01(\tR\tnamespace\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fSecretsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa9\x01\n" +
+	"\x14CreateVolumeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tvolume_id\x18\x03 \x01(\tR\bvolumeId\x12\x1f\n" +
+	"\vexternal_id\x18\x04 \x01(\tR\n" +
+	"externalId\x12\x1f\n" +
+	"\vcapacity_mb\x18\x05 \x01(\x03R\n" +
+	"capacityMb\"O\n" +
+	"\x12ListVolumesRequest\x12\x1b\n" +
+	"\tplugin_id\x18\x01 \x01(\tR\bpluginId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\x8a\x02\n" +
+	"\x06Volume\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tplugin_id\x18\x03 \x01(\tR\bpluginId\x12\x1f\n" +
+	"\vexternal_id\x18\x04 \x01(\tR\n" +
+	"externalId\x12\x1f\n" +
+	"\vaccess_mode\x18\x05 \x01(\tR\n" +
+	"accessMode\x12'\n" +
+	"\x0fattachment_mode\x18\x06 \x01(\tR\x0eattachmentMode\x12 \n" +
+	"\vschedulable\x18\a \x01(\bR\vschedulable\x12\x18\n" +
+	"\areaders\x18\b \x01(\x05R\areaders\x12\x18\n" +
+	"\awriters\x18\t \x01(\x05R\awriters\"_\n" +
+	"\x13ListVolumesResponse\x12.\n" +
+	"\avolumes\x18\x01 \x03(\v2\x14.controlplane.VolumeR\avolumes\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"l\n" +
+	"\x13DeleteVolumeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0fderegister_only\x18\x02 \x01(\bR\x0ederegisterOnly\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"J\n" +
+	"\x14DeleteVolumeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"A\n" +
+	"\rBackupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\xc7\x01\n" +
+	"\bSnapshot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vapplication\x18\x02 \x01(\tR\vapplication\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x15\n" +
+	"\x06job_id\x18\x05 \x01(\tR\x05jobId\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\a \x01(\x03R\n" +
+	"finishedAt\"x\n" +
+	"\x0eBackupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\bsnapshot\x18\x03 \x01(\v2\x16.controlplane.SnapshotR\bsnapshot\"H\n" +
+	"\x14ListSnapshotsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"g\n" +
+	"\x15ListSnapshotsResponse\x124\n" +
+	"\tsnapshots\x18\x01 \x03(\v2\x16.controlplane.SnapshotR\tsnapshots\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"i\n" +
+	"\x14RestoreVolumeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
+	"snapshotId\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\x83\x01\n" +
+	"\x15RestoreVolumeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\vsnapshot_id\x18\x03 \x01(\tR\n" +
+	"snapshotId\x12\x15\n" +
+	"\x06job_id\x18\x04 \x01(\tR\x05jobId\"B\n" +
+	"\x10AddDomainRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"\x92\x02\n" +
+	"\x06Domain\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06tenant\x18\x02 \x01(\tR\x06tenant\x12\x1a\n" +
+	"\bverified\x18\x03 \x01(\bR\bverified\x12\x1f\n" +
+	"\vrecord_name\x18\x04 \x01(\tR\n" +
+	"recordName\x12!\n" +
+	"\frecord_value\x18\x05 \x01(\tR\vrecordValue\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1f\n" +
+	"\vverified_at\x18\a \x01(\x03R\n" +
+	"verifiedAt\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\b \x01(\x03R\tcheckedAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\"u\n" +
+	"\x11AddDomainResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\x06domain\x18\x03 \x01(\v2\x14.controlplane.DomainR\x06domain\"E\n" +
+	"\x13VerifyDomainRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"x\n" +
+	"\x14VerifyDomainResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\x06domain\x18\x03 \x01(\v2\x14.controlplane.DomainR\x06domain\",\n" +
+	"\x12ListDomainsRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\"_\n" +
+	"\x13ListDomainsResponse\x12.\n" +
+	"\adomains\x18\x01 \x03(\v2\x14.controlplane.DomainR\adomains\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"J\n" +
+	"\x11ImageDriftRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fdrifted_only\x18\x02 \x01(\bR\vdriftedOnly\"\xba\x02\n" +
+	"\n" +
+	"ImageDrift\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x14\n" +
+	"\x05image\x18\x02 \x01(\tR\x05image\x12'\n" +
+	"\x0fdeployed_digest\x18\x03 \x01(\tR\x0edeployedDigest\x12\x1d\n" +
+	"\n" +
+	"tag_digest\x18\x04 \x01(\tR\ttagDigest\x12/\n" +
+	"\x13drifted_allocations\x18\x05 \x03(\tR\x12driftedAllocations\x12 \n" +
+	"\fpin_on_drift\x18\x06 \x01(\bR\n" +
+	"pinOnDrift\x12\x1f\n" +
+	"\vdeployed_at\x18\a \x01(\x03R\n" +
+	"deployedAt\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\b \x01(\x03R\tcheckedAt\x12\x19\n" +
+	"\bmoved_at\x18\t \x01(\x03R\amovedAt\"`\n" +
+	"\x12ImageDriftResponse\x120\n" +
+	"\x06images\x18\x01 \x03(\v2\x18.controlplane.ImageDriftR\x06images\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"-\n" +
+	"\x17RestartAnomaliesRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"r\n" +
+	"\x13RestartedAllocation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\brestarts\x18\x02 \x01(\x05R\brestarts\x12\x1d\n" +
+	"\n" +
+	"last_event\x18\x03 \x01(\tR\tlastEvent\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\"\xdd\x02\n" +
+	"\x0eRestartAnomaly\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x1a\n" +
+	"\brestarts\x18\x02 \x01(\x05R\brestarts\x12\x1a\n" +
+	"\bbaseline\x18\x03 \x01(\x01R\bbaseline\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\x03R\x05since\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\x05 \x01(\x03R\tcheckedAt\x12C\n" +
+	"\vallocations\x18\x06 \x03(\v2!.controlplane.RestartedAllocationR\vallocations\x12=\n" +
+	"\x05links\x18\a \x03(\v2'.controlplane.RestartAnomaly.LinksEntryR\x05links\x1a8\n" +
+	"\n" +
+	"LinksEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"p\n" +
+	"\x18RestartAnomaliesResponse\x12:\n" +
+	"\tanomalies\x18\x01 \x03(\v2\x1c.controlplane.RestartAnomalyR\tanomalies\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xaa\x01\n" +
+	"\x0fTimelineRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\x03R\x05since\x12#\n" +
+	"\rallocation_id\x18\x03 \x01(\tR\fallocationId\x12\x14\n" +
+	"\x05types\x18\x04 \x03(\tR\x05types\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x1c\n" +
+	"\tnamespace\x18\x06 \x01(\tR\tnamespace\"\xe4\x01\n" +
+	"\rTimelineEvent\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12#\n" +
+	"\rallocation_id\x18\x04 \x01(\tR\fallocationId\x12\x12\n" +
+	"\x04task\x18\x05 \x01(\tR\x04task\x12\x12\n" +
+	"\x04node\x18\x06 \x01(\tR\x04node\x12\x1f\n" +
+	"\vjob_version\x18\a \x01(\x04R\n" +
+	"jobVersion\x12#\n" +
+	"\rdeployment_id\x18\b \x01(\tR\fdeploymentId\"{\n" +
+	"\x10TimelineResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x123\n" +
+	"\x06events\x18\x03 \x03(\v2\x1b.controlplane.TimelineEventR\x06events\"Y\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\xc5\x02\n" +
+	"\fSearchResult\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06tenant\x18\x03 \x01(\tR\x06tenant\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\x12\x14\n" +
+	"\x05image\x18\x05 \x01(\tR\x05image\x12>\n" +
+	"\x06labels\x18\x06 \x03(\v2&.controlplane.SearchResult.LabelsEntryR\x06labels\x12\x14\n" +
+	"\x05hosts\x18\a \x03(\tR\x05hosts\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\x03R\tupdatedAt\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x90\x01\n" +
+	"\x0eSearchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\aresults\x18\x03 \x03(\v2\x1a.controlplane.SearchResultR\aresults\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"o\n" +
+	"\x14ListRevisionsRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\x98\x02\n" +
+	"\bRevision\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x05R\brevision\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\x12#\n" +
+	"\rdeployment_id\x18\x05 \x01(\tR\fdeploymentId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12/\n" +
+	"\x04spec\x18\a \x01(\v2\x1b.controlplane.DeployRequestR\x04spec\x128\n" +
+	"\venv_changes\x18\b \x03(\v2\x17.controlplane.EnvChangeR\n" +
+	"envChanges\"\x8b\x01\n" +
+	"\tEnvChange\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06change\x18\x02 \x01(\tR\x06change\x12\x1b\n" +
+	"\told_value\x18\x03 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x04 \x01(\tR\bnewValue\x12\x1a\n" +
+	"\bredacted\x18\x05 \x01(\bR\bredacted\"\x81\x01\n" +
+	"\x15ListRevisionsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\trevisions\x18\x03 \x03(\v2\x16.controlplane.RevisionR\trevisions\"\xcd\x01\n" +
+	"\x12SaveProjectRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06tenant\x18\x02 \x01(\tR\x06tenant\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x127\n" +
+	"\bdefaults\x18\x04 \x01(\v2\x1b.controlplane.DeployRequestR\bdefaults\x120\n" +
+	"\x05quota\x18\x05 \x01(\v2\x1a.controlplane.ProjectQuotaR\x05quota\"h\n" +
+	"\fProjectQuota\x12\x10\n" +
+	"\x03cpu\x18\x01 \x01(\x01R\x03cpu\x12\x1b\n" +
+	"\tmemory_mb\x18\x02 \x01(\x03R\bmemoryMb\x12)\n" +
+	"\x10max_applications\x18\x03 \x01(\x05R\x0fmaxApplications\"I\n" +
+	"\x13SaveProjectResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"*\n" +
+	"\x14DeleteProjectRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"K\n" +
+	"\x15DeleteProjectResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"-\n" +
+	"\x13ListProjectsRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\"\xa2\x03\n" +
+	"\x0eProjectSummary\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06tenant\x18\x02 \x01(\tR\x06tenant\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12F\n" +
+	"\bstatuses\x18\x05 \x03(\v2*.controlplane.ProjectSummary.StatusesEntryR\bstatuses\x12\"\n" +
+	"\fapplications\x18\x06 \x01(\x05R\fapplications\x120\n" +
+	"\x05quota\x18\a \x01(\v2\x1a.controlplane.ProjectQuotaR\x05quota\x120\n" +
+	"\x05usage\x18\b \x01(\v2\x1a.controlplane.ProjectQuotaR\x05usage\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x1a;\n" +
+	"\rStatusesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x84\x01\n" +
+	"\x14ListProjectsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x128\n" +
+	"\bprojects\x18\x03 \x03(\v2\x1c.controlplane.ProjectSummaryR\bprojects\"'\n" +
+	"\x11GetProjectRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xb9\x01\n" +
+	"\x12GetProjectResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x126\n" +
+	"\aproject\x18\x03 \x01(\v2\x1c.controlplane.ProjectSummaryR\aproject\x127\n" +
+	"\bdefaults\x18\x04 \x01(\v2\x1b.controlplane.DeployRequestR\bdefaults\"f\n" +
+	"\x16CreateNamespaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06tenant\x18\x03 \x01(\tR\x06tenant\"\xa0\x01\n" +
+	"\rNamespaceInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06tenant\x18\x03 \x01(\tR\x06tenant\x12\"\n" +
+	"\fapplications\x18\x04 \x01(\x05R\fapplications\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"\x88\x01\n" +
+	"\x17CreateNamespaceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x129\n" +
+	"\tnamespace\x18\x03 \x01(\v2\x1b.controlplane.NamespaceInfoR\tnamespace\"\x17\n" +
+	"\x15ListNamespacesRequest\"\x89\x01\n" +
+	"\x16ListNamespacesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\n" +
+	"namespaces\x18\x03 \x03(\v2\x1b.controlplane.NamespaceInfoR\n" +
+	"namespaces\",\n" +
+	"\x16DeleteNamespaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"M\n" +
+	"\x17DeleteNamespaceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xb8\x01\n" +
+	"\x15AttachArtifactRequest\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x14\n" +
+	"\x05image\x18\x02 \x01(\tR\x05image\x12.\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\x1a.controlplane.ArtifactKindR\x04kind\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x04 \x01(\tR\tmediaType\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\fR\acontent\"\xe7\x02\n" +
+	"\bArtifact\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vapplication\x18\x02 \x01(\tR\vapplication\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12\x16\n" +
+	"\x06digest\x18\x04 \x01(\tR\x06digest\x12.\n" +
+	"\x04kind\x18\x05 \x01(\x0e2\x1a.controlplane.ArtifactKindR\x04kind\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x06 \x01(\tR\tmediaType\x12\x12\n" +
+	"\x04size\x18\a \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\b \x01(\tR\x06sha256\x12\x1a\n" +
+	"\bverified\x18\t \x01(\bR\bverified\x12\x16\n" +
+	"\x06signer\x18\n" +
+	" \x01(\tR\x06signer\x12-\n" +
+	"\x12verification_error\x18\v \x01(\tR\x11verificationError\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\x03R\tcreatedAt\"\x80\x01\n" +
+	"\x16AttachArtifactResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\bartifact\x18\x03 \x01(\v2\x16.controlplane.ArtifactR\bartifact\"P\n" +
+	"\x14ListArtifactsRequest\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x16\n" +
+	"\x06digest\x18\x02 \x01(\tR\x06digest\"g\n" +
+	"\x15ListArtifactsResponse\x124\n" +
+	"\tartifacts\x18\x01 \x03(\v2\x16.controlplane.ArtifactR\tartifacts\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"F\n" +
+	"\x12GetArtifactRequest\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"}\n" +
+	"\x13GetArtifactResponse\x122\n" +
+	"\bartifact\x18\x01 \x01(\v2\x16.controlplane.ArtifactR\bartifact\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xa0\x02\n" +
+	"\x19BootstrapEdgeProxyRequest\x12\x14\n" +
+	"\x05image\x18\x01 \x01(\tR\x05image\x12 \n" +
+	"\vdatacenters\x18\x02 \x03(\tR\vdatacenters\x12\x1d\n" +
+	"\n" +
+	"node_class\x18\x03 \x01(\tR\tnodeClass\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12%\n" +
+	"\x0eredirect_https\x18\x05 \x01(\bR\rredirectHttps\x12\x1c\n" +
+	"\tdashboard\x18\x06 \x01(\bR\tdashboard\x12\x19\n" +
+	"\bwake_url\x18\a \x01(\tR\awakeUrl\x12\x17\n" +
+	"\adry_run\x18\b \x01(\bR\x06dryRun\x12\x1b\n" +
+	"\tnode_pool\x18\t \x01(\tR\bnodePool\"\xb5\x01\n" +
+	"\x1aBootstrapEdgeProxyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\aeval_id\x18\x03 \x01(\tR\x06evalId\x12#\n" +
+	"\rstatic_config\x18\x04 \x01(\tR\fstaticConfig\x12%\n" +
+	"\x0edynamic_config\x18\x05 \x01(\tR\rdynamicConfig\"\xec\x01\n" +
+	"\x18BootstrapPlatformRequest\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x01 \x03(\tR\n" +
+	"namespaces\x12\x1d\n" +
+	"\n" +
+	"node_pools\x18\x02 \x03(\tR\tnodePools\x12F\n" +
+	"\n" +
+	"edge_proxy\x18\x03 \x01(\v2'.controlplane.BootstrapEdgeProxyRequestR\tedgeProxy\x12&\n" +
+	"\x0fskip_edge_proxy\x18\x04 \x01(\bR\rskipEdgeProxy\x12!\n" +
+	"\fdefault_deny\x18\x05 \x01(\bR\vdefaultDeny\"\x89\x02\n" +
+	"\x17DeployControllerRequest\x12\x14\n" +
+	"\x05image\x18\x01 \x01(\tR\x05image\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x12\n" +
+	"\x04args\x18\x03 \x03(\tR\x04args\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12 \n" +
+	"\vdatacenters\x18\x05 \x03(\tR\vdatacenters\x12\x1b\n" +
+	"\tnode_pool\x18\x06 \x01(\tR\bnodePool\x124\n" +
+	"\x16health_timeout_seconds\x18\a \x01(\x05R\x14healthTimeoutSeconds\x12!\n" +
+	"\freplace_args\x18\b \x01(\bR\vreplaceArgs\"\xc5\x01\n" +
+	"\x18DeployControllerResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\aeval_id\x18\x03 \x01(\tR\x06evalId\x12\x14\n" +
+	"\x05image\x18\x04 \x01(\tR\x05image\x12%\n" +
+	"\x0eprevious_image\x18\x05 \x01(\tR\rpreviousImage\x12\x1f\n" +
+	"\vjob_version\x18\x06 \x01(\x04R\n" +
+	"jobVersion\"q\n" +
+	"\rBootstrapStep\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\x82\x01\n" +
+	"\x19BootstrapPlatformResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x121\n" +
+	"\x05steps\x18\x03 \x03(\v2\x1b.controlplane.BootstrapStepR\x05steps\"\x17\n" +
+	"\x15PromoteStandbyRequest\"\x9c\x01\n" +
+	"\x16PromoteStandbyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
+	"\x10replicated_index\x18\x03 \x01(\x04R\x0freplicatedIndex\x12#\n" +
+	"\rreplicated_at\x18\x04 \x01(\x03R\freplicatedAt\"\x1d\n" +
+	"\x1bGetReplicationStatusRequest\"\xa0\x02\n" +
+	"\x1cGetReplicationStatusResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x12\n" +
+	"\x04peer\x18\x04 \x01(\tR\x04peer\x12\x14\n" +
+	"\x05index\x18\x05 \x01(\x04R\x05index\x12)\n" +
+	"\x10replicated_index\x18\x06 \x01(\x04R\x0freplicatedIndex\x12#\n" +
+	"\rreplicated_at\x18\a \x01(\x03R\freplicatedAt\x12\x1f\n" +
+	"\vpromoted_at\x18\b \x01(\x03R\n" +
+	"promotedAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\".\n" +
 	"\x12HealthCheckRequest\x12\x18\n" +
-	"\aservice\x18\x01 \x01(\tR\aservice\"\x81\x01\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\"\xde\x01\n" +
 	"\x13HealthCheckResponse\x122\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x1a.controlplane.HealthStatusR\x06status\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp*[\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x12\n" +
+	"\x04mode\x18\x04 \x01(\tR\x04mode\x12(\n" +
+	"\x10nomad_latency_ms\x18\x05 \x01(\x03R\x0enomadLatencyMs\x12\x1d\n" +
+	"\n" +
+	"mode_since\x18\x06 \x01(\x03R\tmodeSince\"g\n" +
+	"\vTenantQuota\x12\x10\n" +
+	"\x03cpu\x18\x01 \x01(\x01R\x03cpu\x12\x1b\n" +
+	"\tmemory_mb\x18\x02 \x01(\x03R\bmemoryMb\x12)\n" +
+	"\x10max_applications\x18\x03 \x01(\x05R\x0fmaxApplications\"\xd7\x03\n" +
+	"\x06Tenant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x02 \x03(\tR\n" +
+	"namespaces\x12/\n" +
+	"\x05quota\x18\x03 \x01(\v2\x19.controlplane.TenantQuotaR\x05quota\x12'\n" +
+	"\x0fdomain_template\x18\x04 \x01(\tR\x0edomainTemplate\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x15\n" +
+	"\x06key_id\x18\x06 \x01(\tR\x05keyId\x12J\n" +
+	"\x11security_defaults\x18\a \x01(\v2\x1d.controlplane.SecurityContextR\x10securityDefaults\x12%\n" +
+	"\x0eallowed_images\x18\b \x03(\tR\rallowedImages\x12/\n" +
+	"\x13require_attestation\x18\t \x01(\bR\x12requireAttestation\x120\n" +
+	"\x14nomad_token_accessor\x18\n" +
+	" \x01(\tR\x12nomadTokenAccessor\x123\n" +
+	"\x16nomad_token_expires_at\x18\v \x01(\x03R\x13nomadTokenExpiresAt\"\xbb\x03\n" +
+	"\x13CreateTenantRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x02 \x03(\tR\n" +
+	"namespaces\x12/\n" +
+	"\x05quota\x18\x03 \x01(\v2\x19.controlplane.TenantQuotaR\x05quota\x12'\n" +
+	"\x0fdomain_template\x18\x04 \x01(\tR\x0edomainTemplate\x12'\n" +
+	"\x0fservice_account\x18\x05 \x01(\tR\x0eserviceAccount\x12J\n" +
+	"\x11security_defaults\x18\x06 \x01(\v2\x1d.controlplane.SecurityContextR\x10securityDefaults\x12%\n" +
+	"\x0eallowed_images\x18\a \x03(\tR\rallowedImages\x12/\n" +
+	"\x13require_attestation\x18\b \x01(\bR\x12requireAttestation\x12!\n" +
+	"\fnomad_access\x18\t \x01(\bR\vnomadAccess\x12&\n" +
+	"\x0fnomad_read_logs\x18\n" +
+	" \x01(\bR\rnomadReadLogs\"\xd8\x01\n" +
+	"\x14CreateTenantResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\x06tenant\x18\x03 \x01(\v2\x14.controlplane.TenantR\x06tenant\x12'\n" +
+	"\x0fservice_account\x18\x04 \x01(\tR\x0eserviceAccount\x12\x14\n" +
+	"\x05token\x18\x05 \x01(\tR\x05token\x12\x1f\n" +
+	"\vnomad_token\x18\x06 \x01(\tR\n" +
+	"nomadToken\"\x14\n" +
+	"\x12ListTenantsRequest\"_\n" +
+	"\x13ListTenantsResponse\x12.\n" +
+	"\atenants\x18\x01 \x03(\v2\x14.controlplane.TenantR\atenants\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"-\n" +
+	"\x17RotateTenantKeysRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x7f\n" +
+	"\x18RotateTenantKeysResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x15\n" +
+	"\x06key_id\x18\x03 \x01(\tR\x05keyId\x12\x18\n" +
+	"\arotated\x18\x04 \x03(\tR\arotated\"O\n" +
+	"\x1cIssueTenantNomadTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tread_logs\x18\x02 \x01(\bR\breadLogs\"\xb0\x01\n" +
+	"\x1dIssueTenantNomadTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\vaccessor_id\x18\x03 \x01(\tR\n" +
+	"accessorId\x12\x1b\n" +
+	"\tsecret_id\x18\x04 \x01(\tR\bsecretId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\"E\n" +
+	"\x12PreValidateRequest\x12/\n" +
+	"\x04spec\x18\x01 \x01(\v2\x1b.controlplane.DeployRequestR\x04spec\"z\n" +
+	"\x13PreValidateResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12/\n" +
+	"\x04spec\x18\x03 \x01(\v2\x1b.controlplane.DeployRequestR\x04spec\"U\n" +
+	"\x10MutateJobRequest\x12/\n" +
+	"\x04spec\x18\x01 \x01(\v2\x1b.controlplane.DeployRequestR\x04spec\x12\x10\n" +
+	"\x03job\x18\x02 \x01(\fR\x03job\"Y\n" +
+	"\x11MutateJobResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x10\n" +
+	"\x03job\x18\x03 \x01(\fR\x03job\"i\n" +
+	"\x11PostDeployRequest\x12/\n" +
+	"\x04spec\x18\x01 \x01(\v2\x1b.controlplane.DeployRequestR\x04spec\x12#\n" +
+	"\rdeployment_id\x18\x02 \x01(\tR\fdeploymentId\"\x14\n" +
+	"\x12PostDeployResponse\"\xc6\x01\n" +
+	"\aCommand\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x125\n" +
+	"\x06deploy\x18\x02 \x01(\v2\x1b.controlplane.DeployRequestH\x00R\x06deploy\x122\n" +
+	"\x05scale\x18\x03 \x01(\v2\x1a.controlplane.ScaleRequestH\x00R\x05scale\x125\n" +
+	"\x06delete\x18\x04 \x01(\v2\x1b.controlplane.DeleteRequestH\x00R\x06deleteB\t\n" +
+	"\acommand\"\x90\x02\n" +
+	"\rCommandResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1c\n" +
+	"\tduplicate\x18\x04 \x01(\bR\tduplicate\x124\n" +
+	"\x06deploy\x18\x05 \x01(\v2\x1c.controlplane.DeployResponseR\x06deploy\x121\n" +
+	"\x05scale\x18\x06 \x01(\v2\x1b.controlplane.ScaleResponseR\x05scale\x124\n" +
+	"\x06delete\x18\a \x01(\v2\x1c.controlplane.DeleteResponseR\x06delete\"^\n" +
+	"\x17ExplainPlacementRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12/\n" +
+	"\x04spec\x18\x02 \x01(\v2\x1b.controlplane.DeployRequestR\x04spec\"\xa9\x02\n" +
+	"\x12PlacementCandidate\x12\x16\n" +
+	"\x06region\x18\x01 \x01(\tR\x06region\x12\x1a\n" +
+	"\beligible\x18\x02 \x01(\bR\beligible\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\x12%\n" +
+	"\x0ecapacity_score\x18\x05 \x01(\x01R\rcapacityScore\x12#\n" +
+	"\rlatency_score\x18\x06 \x01(\x01R\flatencyScore\x12\x1d\n" +
+	"\n" +
+	"cost_score\x18\a \x01(\x01R\tcostScore\x12 \n" +
+	"\fcpu_free_mhz\x18\b \x01(\x03R\n" +
+	"cpuFreeMhz\x12$\n" +
+	"\x0ememory_free_mb\x18\t \x01(\x03R\fmemoryFreeMb\"\xc7\x01\n" +
+	"\x18ExplainPlacementResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x12@\n" +
+	"\n" +
+	"candidates\x18\x04 \x03(\v2 .controlplane.PlacementCandidateR\n" +
+	"candidates\x12\x1d\n" +
+	"\n" +
+	"decided_at\x18\x05 \x01(\x03R\tdecidedAt\"N\n" +
+	"\x1eResourceRecommendationsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\apropose\x18\x02 \x01(\bR\apropose\"\xf7\x02\n" +
+	"\x16ResourceRecommendation\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x1f\n" +
+	"\vcurrent_cpu\x18\x03 \x01(\x01R\n" +
+	"currentCpu\x12%\n" +
+	"\x0ecurrent_memory\x18\x04 \x01(\x03R\rcurrentMemory\x12\x17\n" +
+	"\ap95_cpu\x18\x05 \x01(\x01R\x06p95Cpu\x12\x1d\n" +
+	"\n" +
+	"p95_memory\x18\x06 \x01(\x03R\tp95Memory\x12'\n" +
+	"\x0frecommended_cpu\x18\a \x01(\x01R\x0erecommendedCpu\x12-\n" +
+	"\x12recommended_memory\x18\b \x01(\x03R\x11recommendedMemory\x12\x18\n" +
+	"\asamples\x18\t \x01(\x05R\asamples\x12\x16\n" +
+	"\x06reason\x18\n" +
+	" \x01(\tR\x06reason\x12\x1a\n" +
+	"\bproposed\x18\v \x01(\bR\bproposed\"\xa5\x01\n" +
+	"\x1fResourceRecommendationsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12N\n" +
+	"\x0frecommendations\x18\x03 \x03(\v2$.controlplane.ResourceRecommendationR\x0frecommendations\"p\n" +
+	"\"ApplyResourceRecommendationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\adismiss\x18\x02 \x01(\bR\adismiss\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"~\n" +
+	"#ApplyResourceRecommendationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\rdeployment_id\x18\x03 \x01(\tR\fdeploymentId\"\x99\x01\n" +
+	"\x1aDeploymentAnalyticsRequest\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x12\n" +
+	"\x04team\x18\x02 \x01(\tR\x04team\x12\x17\n" +
+	"\aby_team\x18\x03 \x01(\bR\x06byTeam\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\x03R\x05since\x12\x16\n" +
+	"\x06bucket\x18\x05 \x01(\tR\x06bucket\"\xe6\x02\n" +
+	"\x0fDeliveryMetrics\x12!\n" +
+	"\fperiod_start\x18\x01 \x01(\x03R\vperiodStart\x12 \n" +
+	"\vdeployments\x18\x02 \x01(\x05R\vdeployments\x12-\n" +
+	"\x12failed_deployments\x18\x03 \x01(\x05R\x11failedDeployments\x12&\n" +
+	"\x0fdeploys_per_day\x18\x04 \x01(\x01R\rdeploysPerDay\x12.\n" +
+	"\x13change_failure_rate\x18\x05 \x01(\x01R\x11changeFailureRate\x12*\n" +
+	"\x11lead_time_seconds\x18\x06 \x01(\x03R\x0fleadTimeSeconds\x128\n" +
+	"\x18rollout_duration_seconds\x18\a \x01(\x03R\x16rolloutDurationSeconds\x12!\n" +
+	"\fmttr_seconds\x18\b \x01(\x03R\vmttrSeconds\"\xb9\x01\n" +
+	"\x13DeploymentAnalytics\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x12\n" +
+	"\x04team\x18\x02 \x01(\tR\x04team\x123\n" +
+	"\x05total\x18\x03 \x01(\v2\x1d.controlplane.DeliveryMetricsR\x05total\x127\n" +
+	"\aperiods\x18\x04 \x03(\v2\x1d.controlplane.DeliveryMetricsR\aperiods\"\x92\x01\n" +
+	"\x1bDeploymentAnalyticsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12?\n" +
+	"\tanalytics\x18\x03 \x03(\v2!.controlplane.DeploymentAnalyticsR\tanalytics\">\n" +
+	"\x1aGetReconcilerStatusRequest\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\"\xdc\x03\n" +
+	"\x0eReconcilerLoop\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12)\n" +
+	"\x10interval_seconds\x18\x03 \x01(\x03R\x0fintervalSeconds\x12\x12\n" +
+	"\x04runs\x18\x04 \x01(\x03R\x04runs\x12\x1a\n" +
+	"\bfailures\x18\x05 \x01(\x03R\bfailures\x12\x1f\n" +
+	"\vqueue_depth\x18\x06 \x01(\x05R\n" +
+	"queueDepth\x12\x18\n" +
+	"\arunning\x18\a \x01(\bR\arunning\x12&\n" +
+	"\x0flast_started_at\x18\b \x01(\x03R\rlastStartedAt\x12(\n" +
+	"\x10last_finished_at\x18\t \x01(\x03R\x0elastFinishedAt\x12(\n" +
+	"\x10last_duration_ms\x18\n" +
+	" \x01(\x03R\x0elastDurationMs\x12&\n" +
+	"\x0fmax_duration_ms\x18\v \x01(\x03R\rmaxDurationMs\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\f \x01(\tR\tlastError\x12\"\n" +
+	"\rlast_error_at\x18\r \x01(\x03R\vlastErrorAt\x12#\n" +
+	"\rfailing_items\x18\x0e \x01(\x05R\ffailingItems\"\x98\x01\n" +
+	"\x11ReconcilerFailure\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x12\n" +
+	"\x04loop\x18\x02 \x01(\tR\x04loop\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1b\n" +
+	"\tfailed_at\x18\x04 \x01(\x03R\bfailedAt\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\"\x84\x01\n" +
+	"\x0fReconcilerDrift\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x1e\n" +
+	"\n" +
+	"detections\x18\x02 \x01(\x05R\n" +
+	"detections\x12/\n" +
+	"\x13drifted_allocations\x18\x03 \x01(\x05R\x12driftedAllocations\">\n" +
+	"\fRolloutQueue\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x18\n" +
+	"\awaiting\x18\x02 \x01(\x05R\awaiting\"\xba\x02\n" +
+	"\x1bGetReconcilerStatusResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\x05loops\x18\x03 \x03(\v2\x1c.controlplane.ReconcilerLoopR\x05loops\x12;\n" +
+	"\bfailures\x18\x04 \x03(\v2\x1f.controlplane.ReconcilerFailureR\bfailures\x123\n" +
+	"\x05drift\x18\x05 \x03(\v2\x1d.controlplane.ReconcilerDriftR\x05drift\x12A\n" +
+	"\x0erollout_queues\x18\x06 \x03(\v2\x1a.controlplane.RolloutQueueR\rrolloutQueues\"\x82\x01\n" +
+	"\x12GetCalendarRequest\x12\x14\n" +
+	"\x05hours\x18\x01 \x01(\x05R\x05hours\x12\x16\n" +
+	"\x06tenant\x18\x02 \x01(\tR\x06tenant\x12 \n" +
+	"\vapplication\x18\x03 \x01(\tR\vapplication\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\x98\x01\n" +
+	"\x12ScheduledOperation\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12 \n" +
+	"\vapplication\x18\x03 \x01(\tR\vapplication\x12\x16\n" +
+	"\x06tenant\x18\x04 \x01(\tR\x06tenant\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\xf0\x01\n" +
+	"\x13GetCalendarResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05until\x18\x03 \x01(\x03R\x05until\x12@\n" +
+	"\n" +
+	"operations\x18\x04 \x03(\v2 .controlplane.ScheduledOperationR\n" +
+	"operations\x12M\n" +
+	"\x11pending_approvals\x18\x05 \x03(\v2 .controlplane.ScheduledOperationR\x10pendingApprovals*[\n" +
 	"\vNetworkMode\x12\x1c\n" +
 	"\x18NETWORK_MODE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11NETWORK_MODE_HOST\x10\x01\x12\x17\n" +
-	"\x13NETWORK_MODE_BRIDGE\x10\x02*N\n" +
+	"\x13NETWORK_MODE_BRIDGE\x10\x02*\x86\x01\n" +
+	"\x0eDeploymentType\x12\x1f\n" +
+	"\x1bDEPLOYMENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17DEPLOYMENT_TYPE_SERVICE\x10\x01\x12\x1c\n" +
+	"\x18DEPLOYMENT_TYPE_FUNCTION\x10\x02\x12\x18\n" +
+	"\x14DEPLOYMENT_TYPE_CRON\x10\x03*`\n" +
+	"\fUpdatePolicy\x12\x1d\n" +
+	"\x19UPDATE_POLICY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15UPDATE_POLICY_PROPOSE\x10\x01\x12\x16\n" +
+	"\x12UPDATE_POLICY_AUTO\x10\x02*a\n" +
+	"\fCertStrategy\x12\x1d\n" +
+	"\x19CERT_STRATEGY_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12CERT_STRATEGY_HOST\x10\x01\x12\x1a\n" +
+	"\x16CERT_STRATEGY_WILDCARD\x10\x02*\x92\x01\n" +
+	"\x0eDeployStrategy\x12\x1f\n" +
+	"\x1bDEPLOY_STRATEGY_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17DEPLOY_STRATEGY_ROLLING\x10\x01\x12\x1e\n" +
+	"\x1aDEPLOY_STRATEGY_BLUE_GREEN\x10\x02\x12\"\n" +
+	"\x1eDEPLOY_STRATEGY_CANARY_TRAFFIC\x10\x03*\xc0\x01\n" +
+	"\x17ApplicationHealthStatus\x12\x1e\n" +
+	"\x1aAPPLICATION_HEALTH_UNKNOWN\x10\x00\x12\x1e\n" +
+	"\x1aAPPLICATION_HEALTH_HEALTHY\x10\x01\x12\"\n" +
+	"\x1eAPPLICATION_HEALTH_PROGRESSING\x10\x02\x12\x1f\n" +
+	"\x1bAPPLICATION_HEALTH_DEGRADED\x10\x03\x12 \n" +
+	"\x1cAPPLICATION_HEALTH_SUSPENDED\x10\x04*c\n" +
+	"\fArtifactKind\x12\x1d\n" +
+	"\x19ARTIFACT_KIND_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12ARTIFACT_KIND_SBOM\x10\x01\x12\x1c\n" +
+	"\x18ARTIFACT_KIND_PROVENANCE\x10\x02*N\n" +
 	"\fHealthStatus\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\v\n" +
 	"\aSERVING\x10\x01\x12\x0f\n" +
 	"\vNOT_SERVING\x10\x02\x12\x13\n" +
-	"\x0fSERVICE_UNKNOWN\x10\x032\xa2\x03\n" +
+	"\x0fSERVICE_UNKNOWN\x10\x032\xc4+\n" +
 	"\fControlPlane\x12N\n" +
-	"\x11DeployApplication\x12\x1b.controlplane.DeployRequest\x1a\x1c.controlplane.DeployResponse\x12N\n" +
+	"\x11DeployApplication\x12\x1b.controlplane.DeployRequest\x1a\x1c.controlplane.DeployResponse\x12O\n" +
+	"\fDeployRawJob\x12!.controlplane.DeployRawJobRequest\x1a\x1c.controlplane.DeployResponse\x12D\n" +
+	"\tApplySpec\x12\x17.controlplane.SpecChunk\x1a\x1c.controlplane.DeployResponse(\x01\x12N\n" +
 	"\x11DeleteApplication\x12\x1b.controlplane.DeleteRequest\x1a\x1c.controlplane.DeleteResponse\x12Q\n" +
-	"\x14GetApplicationStatus\x12\x1b.controlplane.StatusRequest\x1a\x1c.controlplane.StatusResponse\x12K\n" +
-	"\x12GetApplicationLogs\x12\x19.controlplane.LogsRequest\x1a\x1a.controlplane.LogsResponse\x12R\n" +
-	"\vHealthCheck\x12 .controlplane.HealthCheckRequest\x1a!.controlplane.HealthCheckResponseB0Z.github.com/iuliansafta/control-plane/api/protob\x06proto3"
+	"\x14GetApplicationStatus\x12\x1b.controlplane.StatusRequest\x1a\x1c.controlplane.StatusResponse\x12X\n" +
+	"\x0fWatchDeployment\x12$.controlplane.WatchDeploymentRequest\x1a\x1d.controlplane.DeploymentEvent0\x01\x12d\n" +
+	"\x11PromoteDeployment\x12&.controlplane.PromoteDeploymentRequest\x1a'.controlplane.PromoteDeploymentResponse\x12[\n" +
+	"\x0eFailDeployment\x12#.controlplane.FailDeploymentRequest\x1a$.controlplane.FailDeploymentResponse\x12g\n" +
+	"\x14GetApplicationHealth\x12&.controlplane.ApplicationHealthRequest\x1a'.controlplane.ApplicationHealthResponse\x12a\n" +
+	"\x10ListApplications\x12%.controlplane.ListApplicationsRequest\x1a&.controlplane.ListApplicationsResponse\x12K\n" +
+	"\x10ScaleApplication\x12\x1a.controlplane.ScaleRequest\x1a\x1b.controlplane.ScaleResponse\x12T\n" +
+	"\x13RollbackApplication\x12\x1d.controlplane.RollbackRequest\x1a\x1e.controlplane.RollbackResponse\x12Y\n" +
+	"\x11UpdateApplication\x12&.controlplane.UpdateApplicationRequest\x1a\x1c.controlplane.DeployResponse\x12K\n" +
+	"\x0eInvokeFunction\x12\x1b.controlplane.InvokeRequest\x1a\x1c.controlplane.InvokeResponse\x12a\n" +
+	"\x12GetFunctionMetrics\x12$.controlplane.FunctionMetricsRequest\x1a%.controlplane.FunctionMetricsResponse\x12L\n" +
+	"\vDispatchJob\x12\x1d.controlplane.DispatchRequest\x1a\x1e.controlplane.DispatchResponse\x12M\n" +
+	"\fListCronRuns\x12\x1d.controlplane.CronRunsRequest\x1a\x1e.controlplane.CronRunsResponse\x12U\n" +
+	"\x0eTriggerCronJob\x12 .controlplane.CronTriggerRequest\x1a!.controlplane.CronTriggerResponse\x12P\n" +
+	"\rSetCronPaused\x12\x1e.controlplane.CronPauseRequest\x1a\x1f.controlplane.CronPauseResponse\x12R\n" +
+	"\vDeployStack\x12 .controlplane.DeployStackRequest\x1a!.controlplane.DeployStackResponse\x12a\n" +
+	"\x10PublishBlueprint\x12%.controlplane.PublishBlueprintRequest\x1a&.controlplane.PublishBlueprintResponse\x12W\n" +
+	"\x14SubscribeApplication\x12\x1e.controlplane.SubscribeRequest\x1a\x1f.controlplane.SubscribeResponse\x12d\n" +
+	"\x11ListSubscriptions\x12&.controlplane.ListSubscriptionsRequest\x1a'.controlplane.ListSubscriptionsResponse\x12m\n" +
+	"\x14ApplyBlueprintUpdate\x12).controlplane.ApplyBlueprintUpdateRequest\x1a*.controlplane.ApplyBlueprintUpdateResponse\x12F\n" +
+	"\tGetImpact\x12\x1b.controlplane.ImpactRequest\x1a\x1c.controlplane.ImpactResponse\x12a\n" +
+	"\x12GetDependencyGraph\x12$.controlplane.DependencyGraphRequest\x1a%.controlplane.DependencyGraphResponse\x12K\n" +
+	"\x12GetApplicationLogs\x12\x19.controlplane.LogsRequest\x1a\x1a.controlplane.LogsResponse\x12>\n" +
+	"\aGetLogs\x12\x19.controlplane.LogsRequest\x1a\x16.controlplane.LogChunk0\x01\x12L\n" +
+	"\tRunAction\x12\x1e.controlplane.RunActionRequest\x1a\x1f.controlplane.RunActionResponse\x12j\n" +
+	"\x14GetApplicationConfig\x12).controlplane.GetApplicationConfigRequest\x1a'.controlplane.ApplicationConfigResponse\x12j\n" +
+	"\x14SetApplicationConfig\x12).controlplane.SetApplicationConfigRequest\x1a'.controlplane.ApplicationConfigResponse\x12U\n" +
+	"\fCreateVolume\x12!.controlplane.CreateVolumeRequest\x1a\".controlplane.CreateVolumeResponse\x12R\n" +
+	"\vListVolumes\x12 .controlplane.ListVolumesRequest\x1a!.controlplane.ListVolumesResponse\x12U\n" +
+	"\fDeleteVolume\x12!.controlplane.DeleteVolumeRequest\x1a\".controlplane.DeleteVolumeResponse\x12N\n" +
+	"\x11BackupApplication\x12\x1b.controlplane.BackupRequest\x1a\x1c.controlplane.BackupResponse\x12X\n" +
+	"\rListSnapshots\x12\".controlplane.ListSnapshotsRequest\x1a#.controlplane.ListSnapshotsResponse\x12X\n" +
+	"\rRestoreVolume\x12\".controlplane.RestoreVolumeRequest\x1a#.controlplane.RestoreVolumeResponse\x12L\n" +
+	"\tAddDomain\x12\x1e.controlplane.AddDomainRequest\x1a\x1f.controlplane.AddDomainResponse\x12U\n" +
+	"\fVerifyDomain\x12!.controlplane.VerifyDomainRequest\x1a\".controlplane.VerifyDomainResponse\x12R\n" +
+	"\vListDomains\x12 .controlplane.ListDomainsRequest\x1a!.controlplane.ListDomainsResponse\x12S\n" +
+	"\x0eListImageDrift\x12\x1f.controlplane.ImageDriftRequest\x1a .controlplane.ImageDriftResponse\x12e\n" +
+	"\x14ListRestartAnomalies\x12%.controlplane.RestartAnomaliesRequest\x1a&.controlplane.RestartAnomaliesResponse\x12L\n" +
+	"\vGetTimeline\x12\x1d.controlplane.TimelineRequest\x1a\x1e.controlplane.TimelineResponse\x12C\n" +
+	"\x06Search\x12\x1b.controlplane.SearchRequest\x1a\x1c.controlplane.SearchResponse\x12X\n" +
+	"\rListRevisions\x12\".controlplane.ListRevisionsRequest\x1a#.controlplane.ListRevisionsResponse\x12R\n" +
+	"\vSaveProject\x12 .controlplane.SaveProjectRequest\x1a!.controlplane.SaveProjectResponse\x12X\n" +
+	"\rDeleteProject\x12\".controlplane.DeleteProjectRequest\x1a#.controlplane.DeleteProjectResponse\x12U\n" +
+	"\fListProjects\x12!.controlplane.ListProjectsRequest\x1a\".controlplane.ListProjectsResponse\x12O\n" +
+	"\n" +
+	"GetProject\x12\x1f.controlplane.GetProjectRequest\x1a .controlplane.GetProjectResponse\x12^\n" +
+	"\x0fCreateNamespace\x12$.controlplane.CreateNamespaceRequest\x1a%.controlplane.CreateNamespaceResponse\x12[\n" +
+	"\x0eListNamespaces\x12#.controlplane.ListNamespacesRequest\x1a$.controlplane.ListNamespacesResponse\x12^\n" +
+	"\x0fDeleteNamespace\x12$.controlplane.DeleteNamespaceRequest\x1a%.controlplane.DeleteNamespaceResponse\x12[\n" +
+	"\x0eAttachArtifact\x12#.controlplane.AttachArtifactRequest\x1a$.controlplane.AttachArtifactResponse\x12X\n" +
+	"\rListArtifacts\x12\".controlplane.ListArtifactsRequest\x1a#.controlplane.ListArtifactsResponse\x12R\n" +
+	"\vGetArtifact\x12 .controlplane.GetArtifactRequest\x1a!.controlplane.GetArtifactResponse\x12a\n" +
+	"\x10ExplainPlacement\x12%.controlplane.ExplainPlacementRequest\x1a&.controlplane.ExplainPlacementResponse\x12y\n" +
+	"\x1aGetResourceRecommendations\x12,.controlplane.ResourceRecommendationsRequest\x1a-.controlplane.ResourceRecommendationsResponse\x12m\n" +
+	"\x16GetDeploymentAnalytics\x12(.controlplane.DeploymentAnalyticsRequest\x1a).controlplane.DeploymentAnalyticsResponse\x12\x82\x01\n" +
+	"\x1bApplyResourceRecommendation\x120.controlplane.ApplyResourceRecommendationRequest\x1a1.controlplane.ApplyResourceRecommendationResponse\x12j\n" +
+	"\x13GetReconcilerStatus\x12(.controlplane.GetReconcilerStatusRequest\x1a).controlplane.GetReconcilerStatusResponse\x12R\n" +
+	"\vGetCalendar\x12 .controlplane.GetCalendarRequest\x1a!.controlplane.GetCalendarResponse\x12R\n" +
+	"\vHealthCheck\x12 .controlplane.HealthCheckRequest\x1a!.controlplane.HealthCheckResponse2\x85\a\n" +
+	"\x05Admin\x12U\n" +
+	"\fCreateTenant\x12!.controlplane.CreateTenantRequest\x1a\".controlplane.CreateTenantResponse\x12R\n" +
+	"\vListTenants\x12 .controlplane.ListTenantsRequest\x1a!.controlplane.ListTenantsResponse\x12a\n" +
+	"\x10RotateTenantKeys\x12%.controlplane.RotateTenantKeysRequest\x1a&.controlplane.RotateTenantKeysResponse\x12g\n" +
+	"\x12BootstrapEdgeProxy\x12'.controlplane.BootstrapEdgeProxyRequest\x1a(.controlplane.BootstrapEdgeProxyResponse\x12d\n" +
+	"\x11BootstrapPlatform\x12&.controlplane.BootstrapPlatformRequest\x1a'.controlplane.BootstrapPlatformResponse\x12[\n" +
+	"\x0ePromoteStandby\x12#.controlplane.PromoteStandbyRequest\x1a$.controlplane.PromoteStandbyResponse\x12m\n" +
+	"\x14GetReplicationStatus\x12).controlplane.GetReplicationStatusRequest\x1a*.controlplane.GetReplicationStatusResponse\x12a\n" +
+	"\x10DeployController\x12%.controlplane.DeployControllerRequest\x1a&.controlplane.DeployControllerResponse\x12p\n" +
+	"\x15IssueTenantNomadToken\x12*.controlplane.IssueTenantNomadTokenRequest\x1a+.controlplane.IssueTenantNomadTokenResponse2\xff\x01\n" +
+	"\n" +
+	"DeployHook\x12R\n" +
+	"\vPreValidate\x12 .controlplane.PreValidateRequest\x1a!.controlplane.PreValidateResponse\x12L\n" +
+	"\tMutateJob\x12\x1e.controlplane.MutateJobRequest\x1a\x1f.controlplane.MutateJobResponse\x12O\n" +
+	"\n" +
+	"PostDeploy\x12\x1f.controlplane.PostDeployRequest\x1a .controlplane.PostDeployResponseB0Z.github.com/iuliansafta/control-plane/api/protob\x06proto3"
 
 var (
 	file_api_proto_controlplane_proto_rawDescOnce sync.Once
@@ -1122,50 +17220,533 @@ func file_api_proto_controlplane_proto_rawDescGZIP() []byte {
 	return file_api_proto_controlplane_proto_rawDescData
 }
 
-var file_api_proto_controlplane_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_proto_controlplane_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_api_proto_controlplane_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_api_proto_controlplane_proto_msgTypes = make([]protoimpl.MessageInfo, 229)
 var file_api_proto_controlplane_proto_goTypes = []any{
-	(NetworkMode)(0),            // 0: controlplane.NetworkMode
-	(HealthStatus)(0),           // 1: controlplane.HealthStatus
-	(*TraefikConfig)(nil),       // 2: controlplane.TraefikConfig
-	(*DeployRequest)(nil),       // 3: controlplane.DeployRequest
-	(*DeployResponse)(nil),      // 4: controlplane.DeployResponse
-	(*DeleteRequest)(nil),       // 5: controlplane.DeleteRequest
-	(*DeleteResponse)(nil),      // 6: controlplane.DeleteResponse
-	(*StatusRequest)(nil),       // 7: controlplane.StatusRequest
-	(*AllocationStatus)(nil),    // 8: controlplane.AllocationStatus
-	(*StatusResponse)(nil),      // 9: controlplane.StatusResponse
-	(*LogsRequest)(nil),         // 10: controlplane.LogsRequest
-	(*LogsResponse)(nil),        // 11: controlplane.LogsResponse
-	(*HealthCheckRequest)(nil),  // 12: controlplane.HealthCheckRequest
-	(*HealthCheckResponse)(nil), // 13: controlplane.HealthCheckResponse
-	nil,                         // 14: controlplane.TraefikConfig.CustomLabelsEntry
-	nil,                         // 15: controlplane.DeployRequest.LabelsEntry
-	nil,                         // 16: controlplane.AllocationStatus.TaskStatesEntry
+	(NetworkMode)(0),                            // 0: controlplane.NetworkMode
+	(DeploymentType)(0),                         // 1: controlplane.DeploymentType
+	(UpdatePolicy)(0),                           // 2: controlplane.UpdatePolicy
+	(CertStrategy)(0),                           // 3: controlplane.CertStrategy
+	(DeployStrategy)(0),                         // 4: controlplane.DeployStrategy
+	(ApplicationHealthStatus)(0),                // 5: controlplane.ApplicationHealthStatus
+	(ArtifactKind)(0),                           // 6: controlplane.ArtifactKind
+	(HealthStatus)(0),                           // 7: controlplane.HealthStatus
+	(*PortSpec)(nil),                            // 8: controlplane.PortSpec
+	(*TraefikConfig)(nil),                       // 9: controlplane.TraefikConfig
+	(*Constraint)(nil),                          // 10: controlplane.Constraint
+	(*EphemeralDisk)(nil),                       // 11: controlplane.EphemeralDisk
+	(*VolumeMount)(nil),                         // 12: controlplane.VolumeMount
+	(*EgressRule)(nil),                          // 13: controlplane.EgressRule
+	(*SecurityContext)(nil),                     // 14: controlplane.SecurityContext
+	(*Placement)(nil),                           // 15: controlplane.Placement
+	(*GeoRouting)(nil),                          // 16: controlplane.GeoRouting
+	(*GeoTarget)(nil),                           // 17: controlplane.GeoTarget
+	(*UpdateStrategy)(nil),                      // 18: controlplane.UpdateStrategy
+	(*TrafficShift)(nil),                        // 19: controlplane.TrafficShift
+	(*Autoscaling)(nil),                         // 20: controlplane.Autoscaling
+	(*ScalingPrediction)(nil),                   // 21: controlplane.ScalingPrediction
+	(*ScalingMetric)(nil),                       // 22: controlplane.ScalingMetric
+	(*EgressConfig)(nil),                        // 23: controlplane.EgressConfig
+	(*BackupConfig)(nil),                        // 24: controlplane.BackupConfig
+	(*AddOn)(nil),                               // 25: controlplane.AddOn
+	(*FunctionConfig)(nil),                      // 26: controlplane.FunctionConfig
+	(*CronConfig)(nil),                          // 27: controlplane.CronConfig
+	(*DeployRequest)(nil),                       // 28: controlplane.DeployRequest
+	(*ConsulKV)(nil),                            // 29: controlplane.ConsulKV
+	(*Action)(nil),                              // 30: controlplane.Action
+	(*SpecChunk)(nil),                           // 31: controlplane.SpecChunk
+	(*DeployRawJobRequest)(nil),                 // 32: controlplane.DeployRawJobRequest
+	(*DeployResponse)(nil),                      // 33: controlplane.DeployResponse
+	(*JobPlan)(nil),                             // 34: controlplane.JobPlan
+	(*PlanChange)(nil),                          // 35: controlplane.PlanChange
+	(*PlanGroupUpdate)(nil),                     // 36: controlplane.PlanGroupUpdate
+	(*LintWarning)(nil),                         // 37: controlplane.LintWarning
+	(*StackApplication)(nil),                    // 38: controlplane.StackApplication
+	(*DeployStackRequest)(nil),                  // 39: controlplane.DeployStackRequest
+	(*StackApplicationResult)(nil),              // 40: controlplane.StackApplicationResult
+	(*DeployStackResponse)(nil),                 // 41: controlplane.DeployStackResponse
+	(*PublishBlueprintRequest)(nil),             // 42: controlplane.PublishBlueprintRequest
+	(*PublishBlueprintResponse)(nil),            // 43: controlplane.PublishBlueprintResponse
+	(*SubscribeRequest)(nil),                    // 44: controlplane.SubscribeRequest
+	(*SubscribeResponse)(nil),                   // 45: controlplane.SubscribeResponse
+	(*Subscription)(nil),                        // 46: controlplane.Subscription
+	(*ListSubscriptionsRequest)(nil),            // 47: controlplane.ListSubscriptionsRequest
+	(*ListSubscriptionsResponse)(nil),           // 48: controlplane.ListSubscriptionsResponse
+	(*ApplyBlueprintUpdateRequest)(nil),         // 49: controlplane.ApplyBlueprintUpdateRequest
+	(*ApplyBlueprintUpdateResponse)(nil),        // 50: controlplane.ApplyBlueprintUpdateResponse
+	(*ImpactRequest)(nil),                       // 51: controlplane.ImpactRequest
+	(*ImpactedApplication)(nil),                 // 52: controlplane.ImpactedApplication
+	(*ImpactResponse)(nil),                      // 53: controlplane.ImpactResponse
+	(*DependencyGraphRequest)(nil),              // 54: controlplane.DependencyGraphRequest
+	(*DependencyEdge)(nil),                      // 55: controlplane.DependencyEdge
+	(*DependencyGraphResponse)(nil),             // 56: controlplane.DependencyGraphResponse
+	(*DeleteRequest)(nil),                       // 57: controlplane.DeleteRequest
+	(*DeleteResponse)(nil),                      // 58: controlplane.DeleteResponse
+	(*StatusRequest)(nil),                       // 59: controlplane.StatusRequest
+	(*AllocationStatus)(nil),                    // 60: controlplane.AllocationStatus
+	(*TaskGroupStatus)(nil),                     // 61: controlplane.TaskGroupStatus
+	(*RolloutProgress)(nil),                     // 62: controlplane.RolloutProgress
+	(*WatchDeploymentRequest)(nil),              // 63: controlplane.WatchDeploymentRequest
+	(*PromoteDeploymentRequest)(nil),            // 64: controlplane.PromoteDeploymentRequest
+	(*PromoteDeploymentResponse)(nil),           // 65: controlplane.PromoteDeploymentResponse
+	(*FailDeploymentRequest)(nil),               // 66: controlplane.FailDeploymentRequest
+	(*FailDeploymentResponse)(nil),              // 67: controlplane.FailDeploymentResponse
+	(*DeploymentEvent)(nil),                     // 68: controlplane.DeploymentEvent
+	(*StatusResponse)(nil),                      // 69: controlplane.StatusResponse
+	(*TrafficSplit)(nil),                        // 70: controlplane.TrafficSplit
+	(*GeoRegion)(nil),                           // 71: controlplane.GeoRegion
+	(*ApplicationHealthRequest)(nil),            // 72: controlplane.ApplicationHealthRequest
+	(*ApplicationHealth)(nil),                   // 73: controlplane.ApplicationHealth
+	(*ApplicationHealthResponse)(nil),           // 74: controlplane.ApplicationHealthResponse
+	(*ListApplicationsRequest)(nil),             // 75: controlplane.ListApplicationsRequest
+	(*ApplicationSummary)(nil),                  // 76: controlplane.ApplicationSummary
+	(*ListApplicationsResponse)(nil),            // 77: controlplane.ListApplicationsResponse
+	(*ScaleRequest)(nil),                        // 78: controlplane.ScaleRequest
+	(*ScaleResponse)(nil),                       // 79: controlplane.ScaleResponse
+	(*RollbackRequest)(nil),                     // 80: controlplane.RollbackRequest
+	(*UpdateApplicationRequest)(nil),            // 81: controlplane.UpdateApplicationRequest
+	(*RollbackResponse)(nil),                    // 82: controlplane.RollbackResponse
+	(*InvokeRequest)(nil),                       // 83: controlplane.InvokeRequest
+	(*Invocation)(nil),                          // 84: controlplane.Invocation
+	(*InvokeResponse)(nil),                      // 85: controlplane.InvokeResponse
+	(*FunctionMetricsRequest)(nil),              // 86: controlplane.FunctionMetricsRequest
+	(*FunctionMetricsResponse)(nil),             // 87: controlplane.FunctionMetricsResponse
+	(*DispatchRequest)(nil),                     // 88: controlplane.DispatchRequest
+	(*DispatchResponse)(nil),                    // 89: controlplane.DispatchResponse
+	(*CronRunsRequest)(nil),                     // 90: controlplane.CronRunsRequest
+	(*CronRun)(nil),                             // 91: controlplane.CronRun
+	(*CronRunsResponse)(nil),                    // 92: controlplane.CronRunsResponse
+	(*CronTriggerRequest)(nil),                  // 93: controlplane.CronTriggerRequest
+	(*CronTriggerResponse)(nil),                 // 94: controlplane.CronTriggerResponse
+	(*CronPauseRequest)(nil),                    // 95: controlplane.CronPauseRequest
+	(*CronPauseResponse)(nil),                   // 96: controlplane.CronPauseResponse
+	(*LogsRequest)(nil),                         // 97: controlplane.LogsRequest
+	(*LogsResponse)(nil),                        // 98: controlplane.LogsResponse
+	(*LogChunk)(nil),                            // 99: controlplane.LogChunk
+	(*GetApplicationConfigRequest)(nil),         // 100: controlplane.GetApplicationConfigRequest
+	(*SetApplicationConfigRequest)(nil),         // 101: controlplane.SetApplicationConfigRequest
+	(*ApplicationConfigResponse)(nil),           // 102: controlplane.ApplicationConfigResponse
+	(*RunActionRequest)(nil),                    // 103: controlplane.RunActionRequest
+	(*RunActionResponse)(nil),                   // 104: controlplane.RunActionResponse
+	(*CreateVolumeRequest)(nil),                 // 105: controlplane.CreateVolumeRequest
+	(*CreateVolumeResponse)(nil),                // 106: controlplane.CreateVolumeResponse
+	(*ListVolumesRequest)(nil),                  // 107: controlplane.ListVolumesRequest
+	(*Volume)(nil),                              // 108: controlplane.Volume
+	(*ListVolumesResponse)(nil),                 // 109: controlplane.ListVolumesResponse
+	(*DeleteVolumeRequest)(nil),                 // 110: controlplane.DeleteVolumeRequest
+	(*DeleteVolumeResponse)(nil),                // 111: controlplane.DeleteVolumeResponse
+	(*BackupRequest)(nil),                       // 112: controlplane.BackupRequest
+	(*Snapshot)(nil),                            // 113: controlplane.Snapshot
+	(*BackupResponse)(nil),                      // 114: controlplane.BackupResponse
+	(*ListSnapshotsRequest)(nil),                // 115: controlplane.ListSnapshotsRequest
+	(*ListSnapshotsResponse)(nil),               // 116: controlplane.ListSnapshotsResponse
+	(*RestoreVolumeRequest)(nil),                // 117: controlplane.RestoreVolumeRequest
+	(*RestoreVolumeResponse)(nil),               // 118: controlplane.RestoreVolumeResponse
+	(*AddDomainRequest)(nil),                    // 119: controlplane.AddDomainRequest
+	(*Domain)(nil),                              // 120: controlplane.Domain
+	(*AddDomainResponse)(nil),                   // 121: controlplane.AddDomainResponse
+	(*VerifyDomainRequest)(nil),                 // 122: controlplane.VerifyDomainRequest
+	(*VerifyDomainResponse)(nil),                // 123: controlplane.VerifyDomainResponse
+	(*ListDomainsRequest)(nil),                  // 124: controlplane.ListDomainsRequest
+	(*ListDomainsResponse)(nil),                 // 125: controlplane.ListDomainsResponse
+	(*ImageDriftRequest)(nil),                   // 126: controlplane.ImageDriftRequest
+	(*ImageDrift)(nil),                          // 127: controlplane.ImageDrift
+	(*ImageDriftResponse)(nil),                  // 128: controlplane.ImageDriftResponse
+	(*RestartAnomaliesRequest)(nil),             // 129: controlplane.RestartAnomaliesRequest
+	(*RestartedAllocation)(nil),                 // 130: controlplane.RestartedAllocation
+	(*RestartAnomaly)(nil),                      // 131: controlplane.RestartAnomaly
+	(*RestartAnomaliesResponse)(nil),            // 132: controlplane.RestartAnomaliesResponse
+	(*TimelineRequest)(nil),                     // 133: controlplane.TimelineRequest
+	(*TimelineEvent)(nil),                       // 134: controlplane.TimelineEvent
+	(*TimelineResponse)(nil),                    // 135: controlplane.TimelineResponse
+	(*SearchRequest)(nil),                       // 136: controlplane.SearchRequest
+	(*SearchResult)(nil),                        // 137: controlplane.SearchResult
+	(*SearchResponse)(nil),                      // 138: controlplane.SearchResponse
+	(*ListRevisionsRequest)(nil),                // 139: controlplane.ListRevisionsRequest
+	(*Revision)(nil),                            // 140: controlplane.Revision
+	(*EnvChange)(nil),                           // 141: controlplane.EnvChange
+	(*ListRevisionsResponse)(nil),               // 142: controlplane.ListRevisionsResponse
+	(*SaveProjectRequest)(nil),                  // 143: controlplane.SaveProjectRequest
+	(*ProjectQuota)(nil),                        // 144: controlplane.ProjectQuota
+	(*SaveProjectResponse)(nil),                 // 145: controlplane.SaveProjectResponse
+	(*DeleteProjectRequest)(nil),                // 146: controlplane.DeleteProjectRequest
+	(*DeleteProjectResponse)(nil),               // 147: controlplane.DeleteProjectResponse
+	(*ListProjectsRequest)(nil),                 // 148: controlplane.ListProjectsRequest
+	(*ProjectSummary)(nil),                      // 149: controlplane.ProjectSummary
+	(*ListProjectsResponse)(nil),                // 150: controlplane.ListProjectsResponse
+	(*GetProjectRequest)(nil),                   // 151: controlplane.GetProjectRequest
+	(*GetProjectResponse)(nil),                  // 152: controlplane.GetProjectResponse
+	(*CreateNamespaceRequest)(nil),              // 153: controlplane.CreateNamespaceRequest
+	(*NamespaceInfo)(nil),                       // 154: controlplane.NamespaceInfo
+	(*CreateNamespaceResponse)(nil),             // 155: controlplane.CreateNamespaceResponse
+	(*ListNamespacesRequest)(nil),               // 156: controlplane.ListNamespacesRequest
+	(*ListNamespacesResponse)(nil),              // 157: controlplane.ListNamespacesResponse
+	(*DeleteNamespaceRequest)(nil),              // 158: controlplane.DeleteNamespaceRequest
+	(*DeleteNamespaceResponse)(nil),             // 159: controlplane.DeleteNamespaceResponse
+	(*AttachArtifactRequest)(nil),               // 160: controlplane.AttachArtifactRequest
+	(*Artifact)(nil),                            // 161: controlplane.Artifact
+	(*AttachArtifactResponse)(nil),              // 162: controlplane.AttachArtifactResponse
+	(*ListArtifactsRequest)(nil),                // 163: controlplane.ListArtifactsRequest
+	(*ListArtifactsResponse)(nil),               // 164: controlplane.ListArtifactsResponse
+	(*GetArtifactRequest)(nil),                  // 165: controlplane.GetArtifactRequest
+	(*GetArtifactResponse)(nil),                 // 166: controlplane.GetArtifactResponse
+	(*BootstrapEdgeProxyRequest)(nil),           // 167: controlplane.BootstrapEdgeProxyRequest
+	(*BootstrapEdgeProxyResponse)(nil),          // 168: controlplane.BootstrapEdgeProxyResponse
+	(*BootstrapPlatformRequest)(nil),            // 169: controlplane.BootstrapPlatformRequest
+	(*DeployControllerRequest)(nil),             // 170: controlplane.DeployControllerRequest
+	(*DeployControllerResponse)(nil),            // 171: controlplane.DeployControllerResponse
+	(*BootstrapStep)(nil),                       // 172: controlplane.BootstrapStep
+	(*BootstrapPlatformResponse)(nil),           // 173: controlplane.BootstrapPlatformResponse
+	(*PromoteStandbyRequest)(nil),               // 174: controlplane.PromoteStandbyRequest
+	(*PromoteStandbyResponse)(nil),              // 175: controlplane.PromoteStandbyResponse
+	(*GetReplicationStatusRequest)(nil),         // 176: controlplane.GetReplicationStatusRequest
+	(*GetReplicationStatusResponse)(nil),        // 177: controlplane.GetReplicationStatusResponse
+	(*HealthCheckRequest)(nil),                  // 178: controlplane.HealthCheckRequest
+	(*HealthCheckResponse)(nil),                 // 179: controlplane.HealthCheckResponse
+	(*TenantQuota)(nil),                         // 180: controlplane.TenantQuota
+	(*Tenant)(nil),                              // 181: controlplane.Tenant
+	(*CreateTenantRequest)(nil),                 // 182: controlplane.CreateTenantRequest
+	(*CreateTenantResponse)(nil),                // 183: controlplane.CreateTenantResponse
+	(*ListTenantsRequest)(nil),                  // 184: controlplane.ListTenantsRequest
+	(*ListTenantsResponse)(nil),                 // 185: controlplane.ListTenantsResponse
+	(*RotateTenantKeysRequest)(nil),             // 186: controlplane.RotateTenantKeysRequest
+	(*RotateTenantKeysResponse)(nil),            // 187: controlplane.RotateTenantKeysResponse
+	(*IssueTenantNomadTokenRequest)(nil),        // 188: controlplane.IssueTenantNomadTokenRequest
+	(*IssueTenantNomadTokenResponse)(nil),       // 189: controlplane.IssueTenantNomadTokenResponse
+	(*PreValidateRequest)(nil),                  // 190: controlplane.PreValidateRequest
+	(*PreValidateResponse)(nil),                 // 191: controlplane.PreValidateResponse
+	(*MutateJobRequest)(nil),                    // 192: controlplane.MutateJobRequest
+	(*MutateJobResponse)(nil),                   // 193: controlplane.MutateJobResponse
+	(*PostDeployRequest)(nil),                   // 194: controlplane.PostDeployRequest
+	(*PostDeployResponse)(nil),                  // 195: controlplane.PostDeployResponse
+	(*Command)(nil),                             // 196: controlplane.Command
+	(*CommandResult)(nil),                       // 197: controlplane.CommandResult
+	(*ExplainPlacementRequest)(nil),             // 198: controlplane.ExplainPlacementRequest
+	(*PlacementCandidate)(nil),                  // 199: controlplane.PlacementCandidate
+	(*ExplainPlacementResponse)(nil),            // 200: controlplane.ExplainPlacementResponse
+	(*ResourceRecommendationsRequest)(nil),      // 201: controlplane.ResourceRecommendationsRequest
+	(*ResourceRecommendation)(nil),              // 202: controlplane.ResourceRecommendation
+	(*ResourceRecommendationsResponse)(nil),     // 203: controlplane.ResourceRecommendationsResponse
+	(*ApplyResourceRecommendationRequest)(nil),  // 204: controlplane.ApplyResourceRecommendationRequest
+	(*ApplyResourceRecommendationResponse)(nil), // 205: controlplane.ApplyResourceRecommendationResponse
+	(*DeploymentAnalyticsRequest)(nil),          // 206: controlplane.DeploymentAnalyticsRequest
+	(*DeliveryMetrics)(nil),                     // 207: controlplane.DeliveryMetrics
+	(*DeploymentAnalytics)(nil),                 // 208: controlplane.DeploymentAnalytics
+	(*DeploymentAnalyticsResponse)(nil),         // 209: controlplane.DeploymentAnalyticsResponse
+	(*GetReconcilerStatusRequest)(nil),          // 210: controlplane.GetReconcilerStatusRequest
+	(*ReconcilerLoop)(nil),                      // 211: controlplane.ReconcilerLoop
+	(*ReconcilerFailure)(nil),                   // 212: controlplane.ReconcilerFailure
+	(*ReconcilerDrift)(nil),                     // 213: controlplane.ReconcilerDrift
+	(*RolloutQueue)(nil),                        // 214: controlplane.RolloutQueue
+	(*GetReconcilerStatusResponse)(nil),         // 215: controlplane.GetReconcilerStatusResponse
+	(*GetCalendarRequest)(nil),                  // 216: controlplane.GetCalendarRequest
+	(*ScheduledOperation)(nil),                  // 217: controlplane.ScheduledOperation
+	(*GetCalendarResponse)(nil),                 // 218: controlplane.GetCalendarResponse
+	nil,                                         // 219: controlplane.TraefikConfig.CustomLabelsEntry
+	nil,                                         // 220: controlplane.Placement.RegionSelectorEntry
+	nil,                                         // 221: controlplane.BackupConfig.EnvEntry
+	nil,                                         // 222: controlplane.DeployRequest.LabelsEntry
+	nil,                                         // 223: controlplane.DeployRequest.AnnotationsEntry
+	nil,                                         // 224: controlplane.DeployRequest.EnvEntry
+	nil,                                         // 225: controlplane.ConsulKV.ValuesEntry
+	nil,                                         // 226: controlplane.AllocationStatus.TaskStatesEntry
+	nil,                                         // 227: controlplane.ListApplicationsRequest.LabelsEntry
+	nil,                                         // 228: controlplane.InvokeRequest.MetaEntry
+	nil,                                         // 229: controlplane.DispatchRequest.MetaEntry
+	nil,                                         // 230: controlplane.SetApplicationConfigRequest.ValuesEntry
+	nil,                                         // 231: controlplane.ApplicationConfigResponse.ValuesEntry
+	nil,                                         // 232: controlplane.CreateVolumeRequest.ParametersEntry
+	nil,                                         // 233: controlplane.CreateVolumeRequest.SecretsEntry
+	nil,                                         // 234: controlplane.RestartAnomaly.LinksEntry
+	nil,                                         // 235: controlplane.SearchResult.LabelsEntry
+	nil,                                         // 236: controlplane.ProjectSummary.StatusesEntry
+	(*fieldmaskpb.FieldMask)(nil),               // 237: google.protobuf.FieldMask
 }
 var file_api_proto_controlplane_proto_depIdxs = []int32{
-	14, // 0: controlplane.TraefikConfig.custom_labels:type_name -> controlplane.TraefikConfig.CustomLabelsEntry
-	15, // 1: controlplane.DeployRequest.labels:type_name -> controlplane.DeployRequest.LabelsEntry
-	2,  // 2: controlplane.DeployRequest.traefik:type_name -> controlplane.TraefikConfig
-	0,  // 3: controlplane.DeployRequest.network_mode:type_name -> controlplane.NetworkMode
-	16, // 4: controlplane.AllocationStatus.task_states:type_name -> controlplane.AllocationStatus.TaskStatesEntry
-	8,  // 5: controlplane.StatusResponse.allocations:type_name -> controlplane.AllocationStatus
-	1,  // 6: controlplane.HealthCheckResponse.status:type_name -> controlplane.HealthStatus
-	3,  // 7: controlplane.ControlPlane.DeployApplication:input_type -> controlplane.DeployRequest
-	5,  // 8: controlplane.ControlPlane.DeleteApplication:input_type -> controlplane.DeleteRequest
-	7,  // 9: controlplane.ControlPlane.GetApplicationStatus:input_type -> controlplane.StatusRequest
-	10, // 10: controlplane.ControlPlane.GetApplicationLogs:input_type -> controlplane.LogsRequest
-	12, // 11: controlplane.ControlPlane.HealthCheck:input_type -> controlplane.HealthCheckRequest
-	4,  // 12: controlplane.ControlPlane.DeployApplication:output_type -> controlplane.DeployResponse
-	6,  // 13: controlplane.ControlPlane.DeleteApplication:output_type -> controlplane.DeleteResponse
-	9,  // 14: controlplane.ControlPlane.GetApplicationStatus:output_type -> controlplane.StatusResponse
-	11, // 15: controlplane.ControlPlane.GetApplicationLogs:output_type -> controlplane.LogsResponse
-	13, // 16: controlplane.ControlPlane.HealthCheck:output_type -> controlplane.HealthCheckResponse
-	12, // [12:17] is the sub-list for method output_type
-	7,  // [7:12] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	219, // 0: controlplane.TraefikConfig.custom_labels:type_name -> controlplane.TraefikConfig.CustomLabelsEntry
+	3,   // 1: controlplane.TraefikConfig.cert_strategy:type_name -> controlplane.CertStrategy
+	220, // 2: controlplane.Placement.region_selector:type_name -> controlplane.Placement.RegionSelectorEntry
+	17,  // 3: controlplane.GeoRouting.targets:type_name -> controlplane.GeoTarget
+	22,  // 4: controlplane.Autoscaling.metrics:type_name -> controlplane.ScalingMetric
+	21,  // 5: controlplane.Autoscaling.prediction:type_name -> controlplane.ScalingPrediction
+	13,  // 6: controlplane.EgressConfig.rules:type_name -> controlplane.EgressRule
+	221, // 7: controlplane.BackupConfig.env:type_name -> controlplane.BackupConfig.EnvEntry
+	222, // 8: controlplane.DeployRequest.labels:type_name -> controlplane.DeployRequest.LabelsEntry
+	9,   // 9: controlplane.DeployRequest.traefik:type_name -> controlplane.TraefikConfig
+	0,   // 10: controlplane.DeployRequest.network_mode:type_name -> controlplane.NetworkMode
+	10,  // 11: controlplane.DeployRequest.constraints:type_name -> controlplane.Constraint
+	11,  // 12: controlplane.DeployRequest.ephemeral_disk:type_name -> controlplane.EphemeralDisk
+	1,   // 13: controlplane.DeployRequest.type:type_name -> controlplane.DeploymentType
+	26,  // 14: controlplane.DeployRequest.function:type_name -> controlplane.FunctionConfig
+	27,  // 15: controlplane.DeployRequest.cron:type_name -> controlplane.CronConfig
+	12,  // 16: controlplane.DeployRequest.volumes:type_name -> controlplane.VolumeMount
+	24,  // 17: controlplane.DeployRequest.backup:type_name -> controlplane.BackupConfig
+	25,  // 18: controlplane.DeployRequest.addons:type_name -> controlplane.AddOn
+	23,  // 19: controlplane.DeployRequest.egress:type_name -> controlplane.EgressConfig
+	14,  // 20: controlplane.DeployRequest.security:type_name -> controlplane.SecurityContext
+	223, // 21: controlplane.DeployRequest.annotations:type_name -> controlplane.DeployRequest.AnnotationsEntry
+	18,  // 22: controlplane.DeployRequest.update:type_name -> controlplane.UpdateStrategy
+	15,  // 23: controlplane.DeployRequest.placement:type_name -> controlplane.Placement
+	16,  // 24: controlplane.DeployRequest.geo:type_name -> controlplane.GeoRouting
+	30,  // 25: controlplane.DeployRequest.actions:type_name -> controlplane.Action
+	29,  // 26: controlplane.DeployRequest.consul_kv:type_name -> controlplane.ConsulKV
+	20,  // 27: controlplane.DeployRequest.autoscaling:type_name -> controlplane.Autoscaling
+	224, // 28: controlplane.DeployRequest.env:type_name -> controlplane.DeployRequest.EnvEntry
+	8,   // 29: controlplane.DeployRequest.ports:type_name -> controlplane.PortSpec
+	4,   // 30: controlplane.DeployRequest.strategy:type_name -> controlplane.DeployStrategy
+	19,  // 31: controlplane.DeployRequest.traffic_shift:type_name -> controlplane.TrafficShift
+	225, // 32: controlplane.ConsulKV.values:type_name -> controlplane.ConsulKV.ValuesEntry
+	37,  // 33: controlplane.DeployResponse.warnings:type_name -> controlplane.LintWarning
+	34,  // 34: controlplane.DeployResponse.plan:type_name -> controlplane.JobPlan
+	35,  // 35: controlplane.JobPlan.changes:type_name -> controlplane.PlanChange
+	36,  // 36: controlplane.JobPlan.groups:type_name -> controlplane.PlanGroupUpdate
+	28,  // 37: controlplane.StackApplication.spec:type_name -> controlplane.DeployRequest
+	38,  // 38: controlplane.DeployStackRequest.applications:type_name -> controlplane.StackApplication
+	40,  // 39: controlplane.DeployStackResponse.applications:type_name -> controlplane.StackApplicationResult
+	28,  // 40: controlplane.PublishBlueprintRequest.spec:type_name -> controlplane.DeployRequest
+	2,   // 41: controlplane.SubscribeRequest.policy:type_name -> controlplane.UpdatePolicy
+	28,  // 42: controlplane.SubscribeRequest.overrides:type_name -> controlplane.DeployRequest
+	2,   // 43: controlplane.Subscription.policy:type_name -> controlplane.UpdatePolicy
+	46,  // 44: controlplane.ListSubscriptionsResponse.subscriptions:type_name -> controlplane.Subscription
+	52,  // 45: controlplane.ImpactResponse.consumers:type_name -> controlplane.ImpactedApplication
+	55,  // 46: controlplane.DependencyGraphResponse.edges:type_name -> controlplane.DependencyEdge
+	226, // 47: controlplane.AllocationStatus.task_states:type_name -> controlplane.AllocationStatus.TaskStatesEntry
+	60,  // 48: controlplane.StatusResponse.allocations:type_name -> controlplane.AllocationStatus
+	61,  // 49: controlplane.StatusResponse.task_groups:type_name -> controlplane.TaskGroupStatus
+	62,  // 50: controlplane.StatusResponse.rollout:type_name -> controlplane.RolloutProgress
+	62,  // 51: controlplane.StatusResponse.history:type_name -> controlplane.RolloutProgress
+	71,  // 52: controlplane.StatusResponse.geo:type_name -> controlplane.GeoRegion
+	70,  // 53: controlplane.StatusResponse.traffic:type_name -> controlplane.TrafficSplit
+	5,   // 54: controlplane.ApplicationHealth.status:type_name -> controlplane.ApplicationHealthStatus
+	73,  // 55: controlplane.ApplicationHealthResponse.applications:type_name -> controlplane.ApplicationHealth
+	227, // 56: controlplane.ListApplicationsRequest.labels:type_name -> controlplane.ListApplicationsRequest.LabelsEntry
+	76,  // 57: controlplane.ListApplicationsResponse.applications:type_name -> controlplane.ApplicationSummary
+	28,  // 58: controlplane.UpdateApplicationRequest.spec:type_name -> controlplane.DeployRequest
+	237, // 59: controlplane.UpdateApplicationRequest.update_mask:type_name -> google.protobuf.FieldMask
+	228, // 60: controlplane.InvokeRequest.meta:type_name -> controlplane.InvokeRequest.MetaEntry
+	84,  // 61: controlplane.InvokeResponse.invocation:type_name -> controlplane.Invocation
+	84,  // 62: controlplane.FunctionMetricsResponse.recent:type_name -> controlplane.Invocation
+	229, // 63: controlplane.DispatchRequest.meta:type_name -> controlplane.DispatchRequest.MetaEntry
+	91,  // 64: controlplane.CronRunsResponse.runs:type_name -> controlplane.CronRun
+	230, // 65: controlplane.SetApplicationConfigRequest.values:type_name -> controlplane.SetApplicationConfigRequest.ValuesEntry
+	231, // 66: controlplane.ApplicationConfigResponse.values:type_name -> controlplane.ApplicationConfigResponse.ValuesEntry
+	232, // 67: controlplane.CreateVolumeRequest.parameters:type_name -> controlplane.CreateVolumeRequest.ParametersEntry
+	233, // 68: controlplane.CreateVolumeRequest.secrets:type_name -> controlplane.CreateVolumeRequest.SecretsEntry
+	108, // 69: controlplane.ListVolumesResponse.volumes:type_name -> controlplane.Volume
+	113, // 70: controlplane.BackupResponse.snapshot:type_name -> controlplane.Snapshot
+	113, // 71: controlplane.ListSnapshotsResponse.snapshots:type_name -> controlplane.Snapshot
+	120, // 72: controlplane.AddDomainResponse.domain:type_name -> controlplane.Domain
+	120, // 73: controlplane.VerifyDomainResponse.domain:type_name -> controlplane.Domain
+	120, // 74: controlplane.ListDomainsResponse.domains:type_name -> controlplane.Domain
+	127, // 75: controlplane.ImageDriftResponse.images:type_name -> controlplane.ImageDrift
+	130, // 76: controlplane.RestartAnomaly.allocations:type_name -> controlplane.RestartedAllocation
+	234, // 77: controlplane.RestartAnomaly.links:type_name -> controlplane.RestartAnomaly.LinksEntry
+	131, // 78: controlplane.RestartAnomaliesResponse.anomalies:type_name -> controlplane.RestartAnomaly
+	134, // 79: controlplane.TimelineResponse.events:type_name -> controlplane.TimelineEvent
+	235, // 80: controlplane.SearchResult.labels:type_name -> controlplane.SearchResult.LabelsEntry
+	137, // 81: controlplane.SearchResponse.results:type_name -> controlplane.SearchResult
+	28,  // 82: controlplane.Revision.spec:type_name -> controlplane.DeployRequest
+	141, // 83: controlplane.Revision.env_changes:type_name -> controlplane.EnvChange
+	140, // 84: controlplane.ListRevisionsResponse.revisions:type_name -> controlplane.Revision
+	28,  // 85: controlplane.SaveProjectRequest.defaults:type_name -> controlplane.DeployRequest
+	144, // 86: controlplane.SaveProjectRequest.quota:type_name -> controlplane.ProjectQuota
+	236, // 87: controlplane.ProjectSummary.statuses:type_name -> controlplane.ProjectSummary.StatusesEntry
+	144, // 88: controlplane.ProjectSummary.quota:type_name -> controlplane.ProjectQuota
+	144, // 89: controlplane.ProjectSummary.usage:type_name -> controlplane.ProjectQuota
+	149, // 90: controlplane.ListProjectsResponse.projects:type_name -> controlplane.ProjectSummary
+	149, // 91: controlplane.GetProjectResponse.project:type_name -> controlplane.ProjectSummary
+	28,  // 92: controlplane.GetProjectResponse.defaults:type_name -> controlplane.DeployRequest
+	154, // 93: controlplane.CreateNamespaceResponse.namespace:type_name -> controlplane.NamespaceInfo
+	154, // 94: controlplane.ListNamespacesResponse.namespaces:type_name -> controlplane.NamespaceInfo
+	6,   // 95: controlplane.AttachArtifactRequest.kind:type_name -> controlplane.ArtifactKind
+	6,   // 96: controlplane.Artifact.kind:type_name -> controlplane.ArtifactKind
+	161, // 97: controlplane.AttachArtifactResponse.artifact:type_name -> controlplane.Artifact
+	161, // 98: controlplane.ListArtifactsResponse.artifacts:type_name -> controlplane.Artifact
+	161, // 99: controlplane.GetArtifactResponse.artifact:type_name -> controlplane.Artifact
+	167, // 100: controlplane.BootstrapPlatformRequest.edge_proxy:type_name -> controlplane.BootstrapEdgeProxyRequest
+	172, // 101: controlplane.BootstrapPlatformResponse.steps:type_name -> controlplane.BootstrapStep
+	7,   // 102: controlplane.HealthCheckResponse.status:type_name -> controlplane.HealthStatus
+	180, // 103: controlplane.Tenant.quota:type_name -> controlplane.TenantQuota
+	14,  // 104: controlplane.Tenant.security_defaults:type_name -> controlplane.SecurityContext
+	180, // 105: controlplane.CreateTenantRequest.quota:type_name -> controlplane.TenantQuota
+	14,  // 106: controlplane.CreateTenantRequest.security_defaults:type_name -> controlplane.SecurityContext
+	181, // 107: controlplane.CreateTenantResponse.tenant:type_name -> controlplane.Tenant
+	181, // 108: controlplane.ListTenantsResponse.tenants:type_name -> controlplane.Tenant
+	28,  // 109: controlplane.PreValidateRequest.spec:type_name -> controlplane.DeployRequest
+	28,  // 110: controlplane.PreValidateResponse.spec:type_name -> controlplane.DeployRequest
+	28,  // 111: controlplane.MutateJobRequest.spec:type_name -> controlplane.DeployRequest
+	28,  // 112: controlplane.PostDeployRequest.spec:type_name -> controlplane.DeployRequest
+	28,  // 113: controlplane.Command.deploy:type_name -> controlplane.DeployRequest
+	78,  // 114: controlplane.Command.scale:type_name -> controlplane.ScaleRequest
+	57,  // 115: controlplane.Command.delete:type_name -> controlplane.DeleteRequest
+	33,  // 116: controlplane.CommandResult.deploy:type_name -> controlplane.DeployResponse
+	79,  // 117: controlplane.CommandResult.scale:type_name -> controlplane.ScaleResponse
+	58,  // 118: controlplane.CommandResult.delete:type_name -> controlplane.DeleteResponse
+	28,  // 119: controlplane.ExplainPlacementRequest.spec:type_name -> controlplane.DeployRequest
+	199, // 120: controlplane.ExplainPlacementResponse.candidates:type_name -> controlplane.PlacementCandidate
+	202, // 121: controlplane.ResourceRecommendationsResponse.recommendations:type_name -> controlplane.ResourceRecommendation
+	207, // 122: controlplane.DeploymentAnalytics.total:type_name -> controlplane.DeliveryMetrics
+	207, // 123: controlplane.DeploymentAnalytics.periods:type_name -> controlplane.DeliveryMetrics
+	208, // 124: controlplane.DeploymentAnalyticsResponse.analytics:type_name -> controlplane.DeploymentAnalytics
+	211, // 125: controlplane.GetReconcilerStatusResponse.loops:type_name -> controlplane.ReconcilerLoop
+	212, // 126: controlplane.GetReconcilerStatusResponse.failures:type_name -> controlplane.ReconcilerFailure
+	213, // 127: controlplane.GetReconcilerStatusResponse.drift:type_name -> controlplane.ReconcilerDrift
+	214, // 128: controlplane.GetReconcilerStatusResponse.rollout_queues:type_name -> controlplane.RolloutQueue
+	217, // 129: controlplane.GetCalendarResponse.operations:type_name -> controlplane.ScheduledOperation
+	217, // 130: controlplane.GetCalendarResponse.pending_approvals:type_name -> controlplane.ScheduledOperation
+	28,  // 131: controlplane.ControlPlane.DeployApplication:input_type -> controlplane.DeployRequest
+	32,  // 132: controlplane.ControlPlane.DeployRawJob:input_type -> controlplane.DeployRawJobRequest
+	31,  // 133: controlplane.ControlPlane.ApplySpec:input_type -> controlplane.SpecChunk
+	57,  // 134: controlplane.ControlPlane.DeleteApplication:input_type -> controlplane.DeleteRequest
+	59,  // 135: controlplane.ControlPlane.GetApplicationStatus:input_type -> controlplane.StatusRequest
+	63,  // 136: controlplane.ControlPlane.WatchDeployment:input_type -> controlplane.WatchDeploymentRequest
+	64,  // 137: controlplane.ControlPlane.PromoteDeployment:input_type -> controlplane.PromoteDeploymentRequest
+	66,  // 138: controlplane.ControlPlane.FailDeployment:input_type -> controlplane.FailDeploymentRequest
+	72,  // 139: controlplane.ControlPlane.GetApplicationHealth:input_type -> controlplane.ApplicationHealthRequest
+	75,  // 140: controlplane.ControlPlane.ListApplications:input_type -> controlplane.ListApplicationsRequest
+	78,  // 141: controlplane.ControlPlane.ScaleApplication:input_type -> controlplane.ScaleRequest
+	80,  // 142: controlplane.ControlPlane.RollbackApplication:input_type -> controlplane.RollbackRequest
+	81,  // 143: controlplane.ControlPlane.UpdateApplication:input_type -> controlplane.UpdateApplicationRequest
+	83,  // 144: controlplane.ControlPlane.InvokeFunction:input_type -> controlplane.InvokeRequest
+	86,  // 145: controlplane.ControlPlane.GetFunctionMetrics:input_type -> controlplane.FunctionMetricsRequest
+	88,  // 146: controlplane.ControlPlane.DispatchJob:input_type -> controlplane.DispatchRequest
+	90,  // 147: controlplane.ControlPlane.ListCronRuns:input_type -> controlplane.CronRunsRequest
+	93,  // 148: controlplane.ControlPlane.TriggerCronJob:input_type -> controlplane.CronTriggerRequest
+	95,  // 149: controlplane.ControlPlane.SetCronPaused:input_type -> controlplane.CronPauseRequest
+	39,  // 150: controlplane.ControlPlane.DeployStack:input_type -> controlplane.DeployStackRequest
+	42,  // 151: controlplane.ControlPlane.PublishBlueprint:input_type -> controlplane.PublishBlueprintRequest
+	44,  // 152: controlplane.ControlPlane.SubscribeApplication:input_type -> controlplane.SubscribeRequest
+	47,  // 153: controlplane.ControlPlane.ListSubscriptions:input_type -> controlplane.ListSubscriptionsRequest
+	49,  // 154: controlplane.ControlPlane.ApplyBlueprintUpdate:input_type -> controlplane.ApplyBlueprintUpdateRequest
+	51,  // 155: controlplane.ControlPlane.GetImpact:input_type -> controlplane.ImpactRequest
+	54,  // 156: controlplane.ControlPlane.GetDependencyGraph:input_type -> controlplane.DependencyGraphRequest
+	97,  // 157: controlplane.ControlPlane.GetApplicationLogs:input_type -> controlplane.LogsRequest
+	97,  // 158: controlplane.ControlPlane.GetLogs:input_type -> controlplane.LogsRequest
+	103, // 159: controlplane.ControlPlane.RunAction:input_type -> controlplane.RunActionRequest
+	100, // 160: controlplane.ControlPlane.GetApplicationConfig:input_type -> controlplane.GetApplicationConfigRequest
+	101, // 161: controlplane.ControlPlane.SetApplicationConfig:input_type -> controlplane.SetApplicationConfigRequest
+	105, // 162: controlplane.ControlPlane.CreateVolume:input_type -> controlplane.CreateVolumeRequest
+	107, // 163: controlplane.ControlPlane.ListVolumes:input_type -> controlplane.ListVolumesRequest
+	110, // 164: controlplane.ControlPlane.DeleteVolume:input_type -> controlplane.DeleteVolumeRequest
+	112, // 165: controlplane.ControlPlane.BackupApplication:input_type -> controlplane.BackupRequest
+	115, // 166: controlplane.ControlPlane.ListSnapshots:input_type -> controlplane.ListSnapshotsRequest
+	117, // 167: controlplane.ControlPlane.RestoreVolume:input_type -> controlplane.RestoreVolumeRequest
+	119, // 168: controlplane.ControlPlane.AddDomain:input_type -> controlplane.AddDomainRequest
+	122, // 169: controlplane.ControlPlane.VerifyDomain:input_type -> controlplane.VerifyDomainRequest
+	124, // 170: controlplane.ControlPlane.ListDomains:input_type -> controlplane.ListDomainsRequest
+	126, // 171: controlplane.ControlPlane.ListImageDrift:input_type -> controlplane.ImageDriftRequest
+	129, // 172: controlplane.ControlPlane.ListRestartAnomalies:input_type -> controlplane.RestartAnomaliesRequest
+	133, // 173: controlplane.ControlPlane.GetTimeline:input_type -> controlplane.TimelineRequest
+	136, // 174: controlplane.ControlPlane.Search:input_type -> controlplane.SearchRequest
+	139, // 175: controlplane.ControlPlane.ListRevisions:input_type -> controlplane.ListRevisionsRequest
+	143, // 176: controlplane.ControlPlane.SaveProject:input_type -> controlplane.SaveProjectRequest
+	146, // 177: controlplane.ControlPlane.DeleteProject:input_type -> controlplane.DeleteProjectRequest
+	148, // 178: controlplane.ControlPlane.ListProjects:input_type -> controlplane.ListProjectsRequest
+	151, // 179: controlplane.ControlPlane.GetProject:input_type -> controlplane.GetProjectRequest
+	153, // 180: controlplane.ControlPlane.CreateNamespace:input_type -> controlplane.CreateNamespaceRequest
+	156, // 181: controlplane.ControlPlane.ListNamespaces:input_type -> controlplane.ListNamespacesRequest
+	158, // 182: controlplane.ControlPlane.DeleteNamespace:input_type -> controlplane.DeleteNamespaceRequest
+	160, // 183: controlplane.ControlPlane.AttachArtifact:input_type -> controlplane.AttachArtifactRequest
+	163, // 184: controlplane.ControlPlane.ListArtifacts:input_type -> controlplane.ListArtifactsRequest
+	165, // 185: controlplane.ControlPlane.GetArtifact:input_type -> controlplane.GetArtifactRequest
+	198, // 186: controlplane.ControlPlane.ExplainPlacement:input_type -> controlplane.ExplainPlacementRequest
+	201, // 187: controlplane.ControlPlane.GetResourceRecommendations:input_type -> controlplane.ResourceRecommendationsRequest
+	206, // 188: controlplane.ControlPlane.GetDeploymentAnalytics:input_type -> controlplane.DeploymentAnalyticsRequest
+	204, // 189: controlplane.ControlPlane.ApplyResourceRecommendation:input_type -> controlplane.ApplyResourceRecommendationRequest
+	210, // 190: controlplane.ControlPlane.GetReconcilerStatus:input_type -> controlplane.GetReconcilerStatusRequest
+	216, // 191: controlplane.ControlPlane.GetCalendar:input_type -> controlplane.GetCalendarRequest
+	178, // 192: controlplane.ControlPlane.HealthCheck:input_type -> controlplane.HealthCheckRequest
+	182, // 193: controlplane.Admin.CreateTenant:input_type -> controlplane.CreateTenantRequest
+	184, // 194: controlplane.Admin.ListTenants:input_type -> controlplane.ListTenantsRequest
+	186, // 195: controlplane.Admin.RotateTenantKeys:input_type -> controlplane.RotateTenantKeysRequest
+	167, // 196: controlplane.Admin.BootstrapEdgeProxy:input_type -> controlplane.BootstrapEdgeProxyRequest
+	169, // 197: controlplane.Admin.BootstrapPlatform:input_type -> controlplane.BootstrapPlatformRequest
+	174, // 198: controlplane.Admin.PromoteStandby:input_type -> controlplane.PromoteStandbyRequest
+	176, // 199: controlplane.Admin.GetReplicationStatus:input_type -> controlplane.GetReplicationStatusRequest
+	170, // 200: controlplane.Admin.DeployController:input_type -> controlplane.DeployControllerRequest
+	188, // 201: controlplane.Admin.IssueTenantNomadToken:input_type -> controlplane.IssueTenantNomadTokenRequest
+	190, // 202: controlplane.DeployHook.PreValidate:input_type -> controlplane.PreValidateRequest
+	192, // 203: controlplane.DeployHook.MutateJob:input_type -> controlplane.MutateJobRequest
+	194, // 204: controlplane.DeployHook.PostDeploy:input_type -> controlplane.PostDeployRequest
+	33,  // 205: controlplane.ControlPlane.DeployApplication:output_type -> controlplane.DeployResponse
+	33,  // 206: controlplane.ControlPlane.DeployRawJob:output_type -> controlplane.DeployResponse
+	33,  // 207: controlplane.ControlPlane.ApplySpec:output_type -> controlplane.DeployResponse
+	58,  // 208: controlplane.ControlPlane.DeleteApplication:output_type -> controlplane.DeleteResponse
+	69,  // 209: controlplane.ControlPlane.GetApplicationStatus:output_type -> controlplane.StatusResponse
+	68,  // 210: controlplane.ControlPlane.WatchDeployment:output_type -> controlplane.DeploymentEvent
+	65,  // 211: controlplane.ControlPlane.PromoteDeployment:output_type -> controlplane.PromoteDeploymentResponse
+	67,  // 212: controlplane.ControlPlane.FailDeployment:output_type -> controlplane.FailDeploymentResponse
+	74,  // 213: controlplane.ControlPlane.GetApplicationHealth:output_type -> controlplane.ApplicationHealthResponse
+	77,  // 214: controlplane.ControlPlane.ListApplications:output_type -> controlplane.ListApplicationsResponse
+	79,  // 215: controlplane.ControlPlane.ScaleApplication:output_type -> controlplane.ScaleResponse
+	82,  // 216: controlplane.ControlPlane.RollbackApplication:output_type -> controlplane.RollbackResponse
+	33,  // 217: controlplane.ControlPlane.UpdateApplication:output_type -> controlplane.DeployResponse
+	85,  // 218: controlplane.ControlPlane.InvokeFunction:output_type -> controlplane.InvokeResponse
+	87,  // 219: controlplane.ControlPlane.GetFunctionMetrics:output_type -> controlplane.FunctionMetricsResponse
+	89,  // 220: controlplane.ControlPlane.DispatchJob:output_type -> controlplane.DispatchResponse
+	92,  // 221: controlplane.ControlPlane.ListCronRuns:output_type -> controlplane.CronRunsResponse
+	94,  // 222: controlplane.ControlPlane.TriggerCronJob:output_type -> controlplane.CronTriggerResponse
+	96,  // 223: controlplane.ControlPlane.SetCronPaused:output_type -> controlplane.CronPauseResponse
+	41,  // 224: controlplane.ControlPlane.DeployStack:output_type -> controlplane.DeployStackResponse
+	43,  // 225: controlplane.ControlPlane.PublishBlueprint:output_type -> controlplane.PublishBlueprintResponse
+	45,  // 226: controlplane.ControlPlane.SubscribeApplication:output_type -> controlplane.SubscribeResponse
+	48,  // 227: controlplane.ControlPlane.ListSubscriptions:output_type -> controlplane.ListSubscriptionsResponse
+	50,  // 228: controlplane.ControlPlane.ApplyBlueprintUpdate:output_type -> controlplane.ApplyBlueprintUpdateResponse
+	53,  // 229: controlplane.ControlPlane.GetImpact:output_type -> controlplane.ImpactResponse
+	56,  // 230: controlplane.ControlPlane.GetDependencyGraph:output_type -> controlplane.DependencyGraphResponse
+	98,  // 231: controlplane.ControlPlane.GetApplicationLogs:output_type -> controlplane.LogsResponse
+	99,  // 232: controlplane.ControlPlane.GetLogs:output_type -> controlplane.LogChunk
+	104, // 233: controlplane.ControlPlane.RunAction:output_type -> controlplane.RunActionResponse
+	102, // 234: controlplane.ControlPlane.GetApplicationConfig:output_type -> controlplane.ApplicationConfigResponse
+	102, // 235: controlplane.ControlPlane.SetApplicationConfig:output_type -> controlplane.ApplicationConfigResponse
+	106, // 236: controlplane.ControlPlane.CreateVolume:output_type -> controlplane.CreateVolumeResponse
+	109, // 237: controlplane.ControlPlane.ListVolumes:output_type -> controlplane.ListVolumesResponse
+	111, // 238: controlplane.ControlPlane.DeleteVolume:output_type -> controlplane.DeleteVolumeResponse
+	114, // 239: controlplane.ControlPlane.BackupApplication:output_type -> controlplane.BackupResponse
+	116, // 240: controlplane.ControlPlane.ListSnapshots:output_type -> controlplane.ListSnapshotsResponse
+	118, // 241: controlplane.ControlPlane.RestoreVolume:output_type -> controlplane.RestoreVolumeResponse
+	121, // 242: controlplane.ControlPlane.AddDomain:output_type -> controlplane.AddDomainResponse
+	123, // 243: controlplane.ControlPlane.VerifyDomain:output_type -> controlplane.VerifyDomainResponse
+	125, // 244: controlplane.ControlPlane.ListDomains:output_type -> controlplane.ListDomainsResponse
+	128, // 245: controlplane.ControlPlane.ListImageDrift:output_type -> controlplane.ImageDriftResponse
+	132, // 246: controlplane.ControlPlane.ListRestartAnomalies:output_type -> controlplane.RestartAnomaliesResponse
+	135, // 247: controlplane.ControlPlane.GetTimeline:output_type -> controlplane.TimelineResponse
+	138, // 248: controlplane.ControlPlane.Search:output_type -> controlplane.SearchResponse
+	142, // 249: controlplane.ControlPlane.ListRevisions:output_type -> controlplane.ListRevisionsResponse
+	145, // 250: controlplane.ControlPlane.SaveProject:output_type -> controlplane.SaveProjectResponse
+	147, // 251: controlplane.ControlPlane.DeleteProject:output_type -> controlplane.DeleteProjectResponse
+	150, // 252: controlplane.ControlPlane.ListProjects:output_type -> controlplane.ListProjectsResponse
+	152, // 253: controlplane.ControlPlane.GetProject:output_type -> controlplane.GetProjectResponse
+	155, // 254: controlplane.ControlPlane.CreateNamespace:output_type -> controlplane.CreateNamespaceResponse
+	157, // 255: controlplane.ControlPlane.ListNamespaces:output_type -> controlplane.ListNamespacesResponse
+	159, // 256: controlplane.ControlPlane.DeleteNamespace:output_type -> controlplane.DeleteNamespaceResponse
+	162, // 257: controlplane.ControlPlane.AttachArtifact:output_type -> controlplane.AttachArtifactResponse
+	164, // 258: controlplane.ControlPlane.ListArtifacts:output_type -> controlplane.ListArtifactsResponse
+	166, // 259: controlplane.ControlPlane.GetArtifact:output_type -> controlplane.GetArtifactResponse
+	200, // 260: controlplane.ControlPlane.ExplainPlacement:output_type -> controlplane.ExplainPlacementResponse
+	203, // 261: controlplane.ControlPlane.GetResourceRecommendations:output_type -> controlplane.ResourceRecommendationsResponse
+	209, // 262: controlplane.ControlPlane.GetDeploymentAnalytics:output_type -> controlplane.DeploymentAnalyticsResponse
+	205, // 263: controlplane.ControlPlane.ApplyResourceRecommendation:output_type -> controlplane.ApplyResourceRecommendationResponse
+	215, // 264: controlplane.ControlPlane.GetReconcilerStatus:output_type -> controlplane.GetReconcilerStatusResponse
+	218, // 265: controlplane.ControlPlane.GetCalendar:output_type -> controlplane.GetCalendarResponse
+	179, // 266: controlplane.ControlPlane.HealthCheck:output_type -> controlplane.HealthCheckResponse
+	183, // 267: controlplane.Admin.CreateTenant:output_type -> controlplane.CreateTenantResponse
+	185, // 268: controlplane.Admin.ListTenants:output_type -> controlplane.ListTenantsResponse
+	187, // 269: controlplane.Admin.RotateTenantKeys:output_type -> controlplane.RotateTenantKeysResponse
+	168, // 270: controlplane.Admin.BootstrapEdgeProxy:output_type -> controlplane.BootstrapEdgeProxyResponse
+	173, // 271: controlplane.Admin.BootstrapPlatform:output_type -> controlplane.BootstrapPlatformResponse
+	175, // 272: controlplane.Admin.PromoteStandby:output_type -> controlplane.PromoteStandbyResponse
+	177, // 273: controlplane.Admin.GetReplicationStatus:output_type -> controlplane.GetReplicationStatusResponse
+	171, // 274: controlplane.Admin.DeployController:output_type -> controlplane.DeployControllerResponse
+	189, // 275: controlplane.Admin.IssueTenantNomadToken:output_type -> controlplane.IssueTenantNomadTokenResponse
+	191, // 276: controlplane.DeployHook.PreValidate:output_type -> controlplane.PreValidateResponse
+	193, // 277: controlplane.DeployHook.MutateJob:output_type -> controlplane.MutateJobResponse
+	195, // 278: controlplane.DeployHook.PostDeploy:output_type -> controlplane.PostDeployResponse
+	205, // [205:279] is the sub-list for method output_type
+	131, // [131:205] is the sub-list for method input_type
+	131, // [131:131] is the sub-list for extension type_name
+	131, // [131:131] is the sub-list for extension extendee
+	0,   // [0:131] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_controlplane_proto_init() }
@@ -1173,15 +17754,20 @@ func file_api_proto_controlplane_proto_init() {
 	if File_api_proto_controlplane_proto != nil {
 		return
 	}
+	file_api_proto_controlplane_proto_msgTypes[188].OneofWrappers = []any{
+		(*Command_Deploy)(nil),
+		(*Command_Scale)(nil),
+		(*Command_Delete)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_controlplane_proto_rawDesc), len(file_api_proto_controlplane_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   15,
+			NumEnums:      8,
+			NumMessages:   229,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   3,
 		},
 		GoTypes:           file_api_proto_controlplane_proto_goTypes,
 		DependencyIndexes: file_api_proto_controlplane_proto_depIdxs,
@@ -19,11 +19,68 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ControlPlane_DeployApplication_FullMethodName    = "/controlplane.ControlPlane/DeployApplication"
-	ControlPlane_DeleteApplication_FullMethodName    = "/controlplane.ControlPlane/DeleteApplication"
-	ControlPlane_GetApplicationStatus_FullMethodName = "/controlplane.ControlPlane/GetApplicationStatus"
-	ControlPlane_GetApplicationLogs_FullMethodName   = "/controlplane.ControlPlane/GetApplicationLogs"
-	ControlPlane_HealthCheck_FullMethodName          = "/controlplane.ControlPlane/HealthCheck"
+	ControlPlane_DeployApplication_FullMethodName           = "/controlplane.ControlPlane/DeployApplication"
+	ControlPlane_DeployRawJob_FullMethodName                = "/controlplane.ControlPlane/DeployRawJob"
+	ControlPlane_ApplySpec_FullMethodName                   = "/controlplane.ControlPlane/ApplySpec"
+	ControlPlane_DeleteApplication_FullMethodName           = "/controlplane.ControlPlane/DeleteApplication"
+	ControlPlane_GetApplicationStatus_FullMethodName        = "/controlplane.ControlPlane/GetApplicationStatus"
+	ControlPlane_WatchDeployment_FullMethodName             = "/controlplane.ControlPlane/WatchDeployment"
+	ControlPlane_PromoteDeployment_FullMethodName           = "/controlplane.ControlPlane/PromoteDeployment"
+	ControlPlane_FailDeployment_FullMethodName              = "/controlplane.ControlPlane/FailDeployment"
+	ControlPlane_GetApplicationHealth_FullMethodName        = "/controlplane.ControlPlane/GetApplicationHealth"
+	ControlPlane_ListApplications_FullMethodName            = "/controlplane.ControlPlane/ListApplications"
+	ControlPlane_ScaleApplication_FullMethodName            = "/controlplane.ControlPlane/ScaleApplication"
+	ControlPlane_RollbackApplication_FullMethodName         = "/controlplane.ControlPlane/RollbackApplication"
+	ControlPlane_UpdateApplication_FullMethodName           = "/controlplane.ControlPlane/UpdateApplication"
+	ControlPlane_InvokeFunction_FullMethodName              = "/controlplane.ControlPlane/InvokeFunction"
+	ControlPlane_GetFunctionMetrics_FullMethodName          = "/controlplane.ControlPlane/GetFunctionMetrics"
+	ControlPlane_DispatchJob_FullMethodName                 = "/controlplane.ControlPlane/DispatchJob"
+	ControlPlane_ListCronRuns_FullMethodName                = "/controlplane.ControlPlane/ListCronRuns"
+	ControlPlane_TriggerCronJob_FullMethodName              = "/controlplane.ControlPlane/TriggerCronJob"
+	ControlPlane_SetCronPaused_FullMethodName               = "/controlplane.ControlPlane/SetCronPaused"
+	ControlPlane_DeployStack_FullMethodName                 = "/controlplane.ControlPlane/DeployStack"
+	ControlPlane_PublishBlueprint_FullMethodName            = "/controlplane.ControlPlane/PublishBlueprint"
+	ControlPlane_SubscribeApplication_FullMethodName        = "/controlplane.ControlPlane/SubscribeApplication"
+	ControlPlane_ListSubscriptions_FullMethodName           = "/controlplane.ControlPlane/ListSubscriptions"
+	ControlPlane_ApplyBlueprintUpdate_FullMethodName        = "/controlplane.ControlPlane/ApplyBlueprintUpdate"
+	ControlPlane_GetImpact_FullMethodName                   = "/controlplane.ControlPlane/GetImpact"
+	ControlPlane_GetDependencyGraph_FullMethodName          = "/controlplane.ControlPlane/GetDependencyGraph"
+	ControlPlane_GetApplicationLogs_FullMethodName          = "/controlplane.ControlPlane/GetApplicationLogs"
+	ControlPlane_GetLogs_FullMethodName                     = "/controlplane.ControlPlane/GetLogs"
+	ControlPlane_RunAction_FullMethodName                   = "/controlplane.ControlPlane/RunAction"
+	ControlPlane_GetApplicationConfig_FullMethodName        = "/controlplane.ControlPlane/GetApplicationConfig"
+	ControlPlane_SetApplicationConfig_FullMethodName        = "/controlplane.ControlPlane/SetApplicationConfig"
+	ControlPlane_CreateVolume_FullMethodName                = "/controlplane.ControlPlane/CreateVolume"
+	ControlPlane_ListVolumes_FullMethodName                 = "/controlplane.ControlPlane/ListVolumes"
+	ControlPlane_DeleteVolume_FullMethodName                = "/controlplane.ControlPlane/DeleteVolume"
+	ControlPlane_BackupApplication_FullMethodName           = "/controlplane.ControlPlane/BackupApplication"
+	ControlPlane_ListSnapshots_FullMethodName               = "/controlplane.ControlPlane/ListSnapshots"
+	ControlPlane_RestoreVolume_FullMethodName               = "/controlplane.ControlPlane/RestoreVolume"
+	ControlPlane_AddDomain_FullMethodName                   = "/controlplane.ControlPlane/AddDomain"
+	ControlPlane_VerifyDomain_FullMethodName                = "/controlplane.ControlPlane/VerifyDomain"
+	ControlPlane_ListDomains_FullMethodName                 = "/controlplane.ControlPlane/ListDomains"
+	ControlPlane_ListImageDrift_FullMethodName              = "/controlplane.ControlPlane/ListImageDrift"
+	ControlPlane_ListRestartAnomalies_FullMethodName        = "/controlplane.ControlPlane/ListRestartAnomalies"
+	ControlPlane_GetTimeline_FullMethodName                 = "/controlplane.ControlPlane/GetTimeline"
+	ControlPlane_Search_FullMethodName                      = "/controlplane.ControlPlane/Search"
+	ControlPlane_ListRevisions_FullMethodName               = "/controlplane.ControlPlane/ListRevisions"
+	ControlPlane_SaveProject_FullMethodName                 = "/controlplane.ControlPlane/SaveProject"
+	ControlPlane_DeleteProject_FullMethodName               = "/controlplane.ControlPlane/DeleteProject"
+	ControlPlane_ListProjects_FullMethodName                = "/controlplane.ControlPlane/ListProjects"
+	ControlPlane_GetProject_FullMethodName                  = "/controlplane.ControlPlane/GetProject"
+	ControlPlane_CreateNamespace_FullMethodName             = "/controlplane.ControlPlane/CreateNamespace"
+	ControlPlane_ListNamespaces_FullMethodName              = "/controlplane.ControlPlane/ListNamespaces"
+	ControlPlane_DeleteNamespace_FullMethodName             = "/controlplane.ControlPlane/DeleteNamespace"
+	ControlPlane_AttachArtifact_FullMethodName              = "/controlplane.ControlPlane/AttachArtifact"
+	ControlPlane_ListArtifacts_FullMethodName               = "/controlplane.ControlPlane/ListArtifacts"
+	ControlPlane_GetArtifact_FullMethodName                 = "/controlplane.ControlPlane/GetArtifact"
+	ControlPlane_ExplainPlacement_FullMethodName            = "/controlplane.ControlPlane/ExplainPlacement"
+	ControlPlane_GetResourceRecommendations_FullMethodName  = "/controlplane.ControlPlane/GetResourceRecommendations"
+	ControlPlane_GetDeploymentAnalytics_FullMethodName      = "/controlplane.ControlPlane/GetDeploymentAnalytics"
+	ControlPlane_ApplyResourceRecommendation_FullMethodName = "/controlplane.ControlPlane/ApplyResourceRecommendation"
+	ControlPlane_GetReconcilerStatus_FullMethodName         = "/controlplane.ControlPlane/GetReconcilerStatus"
+	ControlPlane_GetCalendar_FullMethodName                 = "/controlplane.ControlPlane/GetCalendar"
+	ControlPlane_HealthCheck_FullMethodName                 = "/controlplane.ControlPlane/HealthCheck"
 )
 
 // ControlPlaneClient is the client API for ControlPlane service.
@@ -31,9 +88,66 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ControlPlaneClient interface {
 	DeployApplication(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployResponse, error)
+	DeployRawJob(ctx context.Context, in *DeployRawJobRequest, opts ...grpc.CallOption) (*DeployResponse, error)
+	ApplySpec(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SpecChunk, DeployResponse], error)
 	DeleteApplication(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 	GetApplicationStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	WatchDeployment(ctx context.Context, in *WatchDeploymentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeploymentEvent], error)
+	PromoteDeployment(ctx context.Context, in *PromoteDeploymentRequest, opts ...grpc.CallOption) (*PromoteDeploymentResponse, error)
+	FailDeployment(ctx context.Context, in *FailDeploymentRequest, opts ...grpc.CallOption) (*FailDeploymentResponse, error)
+	GetApplicationHealth(ctx context.Context, in *ApplicationHealthRequest, opts ...grpc.CallOption) (*ApplicationHealthResponse, error)
+	ListApplications(ctx context.Context, in *ListApplicationsRequest, opts ...grpc.CallOption) (*ListApplicationsResponse, error)
+	ScaleApplication(ctx context.Context, in *ScaleRequest, opts ...grpc.CallOption) (*ScaleResponse, error)
+	RollbackApplication(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error)
+	UpdateApplication(ctx context.Context, in *UpdateApplicationRequest, opts ...grpc.CallOption) (*DeployResponse, error)
+	InvokeFunction(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error)
+	GetFunctionMetrics(ctx context.Context, in *FunctionMetricsRequest, opts ...grpc.CallOption) (*FunctionMetricsResponse, error)
+	DispatchJob(ctx context.Context, in *DispatchRequest, opts ...grpc.CallOption) (*DispatchResponse, error)
+	ListCronRuns(ctx context.Context, in *CronRunsRequest, opts ...grpc.CallOption) (*CronRunsResponse, error)
+	TriggerCronJob(ctx context.Context, in *CronTriggerRequest, opts ...grpc.CallOption) (*CronTriggerResponse, error)
+	SetCronPaused(ctx context.Context, in *CronPauseRequest, opts ...grpc.CallOption) (*CronPauseResponse, error)
+	DeployStack(ctx context.Context, in *DeployStackRequest, opts ...grpc.CallOption) (*DeployStackResponse, error)
+	PublishBlueprint(ctx context.Context, in *PublishBlueprintRequest, opts ...grpc.CallOption) (*PublishBlueprintResponse, error)
+	SubscribeApplication(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*SubscribeResponse, error)
+	ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error)
+	ApplyBlueprintUpdate(ctx context.Context, in *ApplyBlueprintUpdateRequest, opts ...grpc.CallOption) (*ApplyBlueprintUpdateResponse, error)
+	GetImpact(ctx context.Context, in *ImpactRequest, opts ...grpc.CallOption) (*ImpactResponse, error)
+	GetDependencyGraph(ctx context.Context, in *DependencyGraphRequest, opts ...grpc.CallOption) (*DependencyGraphResponse, error)
 	GetApplicationLogs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (*LogsResponse, error)
+	GetLogs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogChunk], error)
+	RunAction(ctx context.Context, in *RunActionRequest, opts ...grpc.CallOption) (*RunActionResponse, error)
+	GetApplicationConfig(ctx context.Context, in *GetApplicationConfigRequest, opts ...grpc.CallOption) (*ApplicationConfigResponse, error)
+	SetApplicationConfig(ctx context.Context, in *SetApplicationConfigRequest, opts ...grpc.CallOption) (*ApplicationConfigResponse, error)
+	CreateVolume(ctx context.Context, in *CreateVolumeRequest, opts ...grpc.CallOption) (*CreateVolumeResponse, error)
+	ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error)
+	DeleteVolume(ctx context.Context, in *DeleteVolumeRequest, opts ...grpc.CallOption) (*DeleteVolumeResponse, error)
+	BackupApplication(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error)
+	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
+	RestoreVolume(ctx context.Context, in *RestoreVolumeRequest, opts ...grpc.CallOption) (*RestoreVolumeResponse, error)
+	AddDomain(ctx context.Context, in *AddDomainRequest, opts ...grpc.CallOption) (*AddDomainResponse, error)
+	VerifyDomain(ctx context.Context, in *VerifyDomainRequest, opts ...grpc.CallOption) (*VerifyDomainResponse, error)
+	ListDomains(ctx context.Context, in *ListDomainsRequest, opts ...grpc.CallOption) (*ListDomainsResponse, error)
+	ListImageDrift(ctx context.Context, in *ImageDriftRequest, opts ...grpc.CallOption) (*ImageDriftResponse, error)
+	ListRestartAnomalies(ctx context.Context, in *RestartAnomaliesRequest, opts ...grpc.CallOption) (*RestartAnomaliesResponse, error)
+	GetTimeline(ctx context.Context, in *TimelineRequest, opts ...grpc.CallOption) (*TimelineResponse, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
+	SaveProject(ctx context.Context, in *SaveProjectRequest, opts ...grpc.CallOption) (*SaveProjectResponse, error)
+	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error)
+	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
+	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*GetProjectResponse, error)
+	CreateNamespace(ctx context.Context, in *CreateNamespaceRequest, opts ...grpc.CallOption) (*CreateNamespaceResponse, error)
+	ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error)
+	DeleteNamespace(ctx context.Context, in *DeleteNamespaceRequest, opts ...grpc.CallOption) (*DeleteNamespaceResponse, error)
+	AttachArtifact(ctx context.Context, in *AttachArtifactRequest, opts ...grpc.CallOption) (*AttachArtifactResponse, error)
+	ListArtifacts(ctx context.Context, in *ListArtifactsRequest, opts ...grpc.CallOption) (*ListArtifactsResponse, error)
+	GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (*GetArtifactResponse, error)
+	ExplainPlacement(ctx context.Context, in *ExplainPlacementRequest, opts ...grpc.CallOption) (*ExplainPlacementResponse, error)
+	GetResourceRecommendations(ctx context.Context, in *ResourceRecommendationsRequest, opts ...grpc.CallOption) (*ResourceRecommendationsResponse, error)
+	GetDeploymentAnalytics(ctx context.Context, in *DeploymentAnalyticsRequest, opts ...grpc.CallOption) (*DeploymentAnalyticsResponse, error)
+	ApplyResourceRecommendation(ctx context.Context, in *ApplyResourceRecommendationRequest, opts ...grpc.CallOption) (*ApplyResourceRecommendationResponse, error)
+	GetReconcilerStatus(ctx context.Context, in *GetReconcilerStatusRequest, opts ...grpc.CallOption) (*GetReconcilerStatusResponse, error)
+	GetCalendar(ctx context.Context, in *GetCalendarRequest, opts ...grpc.CallOption) (*GetCalendarResponse, error)
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 }
 
@@ -55,217 +169,2861 @@ func (c *controlPlaneClient) DeployApplication(ctx context.Context, in *DeployRe
 	return out, nil
 }
 
+func (c *controlPlaneClient) DeployRawJob(ctx context.Context, in *DeployRawJobRequest, opts ...grpc.CallOption) (*DeployResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_DeployRawJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ApplySpec(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SpecChunk, DeployResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ControlPlane_ServiceDesc.Streams[0], ControlPlane_ApplySpec_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SpecChunk, DeployResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControlPlane_ApplySpecClient = grpc.ClientStreamingClient[SpecChunk, DeployResponse]
+
 func (c *controlPlaneClient) DeleteApplication(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, ControlPlane_DeleteApplication_FullMethodName, in, out, cOpts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_DeleteApplication_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetApplicationStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetApplicationStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) WatchDeployment(ctx context.Context, in *WatchDeploymentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeploymentEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ControlPlane_ServiceDesc.Streams[1], ControlPlane_WatchDeployment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchDeploymentRequest, DeploymentEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControlPlane_WatchDeploymentClient = grpc.ServerStreamingClient[DeploymentEvent]
+
+func (c *controlPlaneClient) PromoteDeployment(ctx context.Context, in *PromoteDeploymentRequest, opts ...grpc.CallOption) (*PromoteDeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoteDeploymentResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_PromoteDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) FailDeployment(ctx context.Context, in *FailDeploymentRequest, opts ...grpc.CallOption) (*FailDeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FailDeploymentResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_FailDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetApplicationHealth(ctx context.Context, in *ApplicationHealthRequest, opts ...grpc.CallOption) (*ApplicationHealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplicationHealthResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetApplicationHealth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListApplications(ctx context.Context, in *ListApplicationsRequest, opts ...grpc.CallOption) (*ListApplicationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListApplicationsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListApplications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ScaleApplication(ctx context.Context, in *ScaleRequest, opts ...grpc.CallOption) (*ScaleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScaleResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ScaleApplication_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) RollbackApplication(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_RollbackApplication_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) UpdateApplication(ctx context.Context, in *UpdateApplicationRequest, opts ...grpc.CallOption) (*DeployResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_UpdateApplication_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) InvokeFunction(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvokeResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_InvokeFunction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetFunctionMetrics(ctx context.Context, in *FunctionMetricsRequest, opts ...grpc.CallOption) (*FunctionMetricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FunctionMetricsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetFunctionMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) DispatchJob(ctx context.Context, in *DispatchRequest, opts ...grpc.CallOption) (*DispatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DispatchResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_DispatchJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListCronRuns(ctx context.Context, in *CronRunsRequest, opts ...grpc.CallOption) (*CronRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CronRunsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListCronRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) TriggerCronJob(ctx context.Context, in *CronTriggerRequest, opts ...grpc.CallOption) (*CronTriggerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CronTriggerResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_TriggerCronJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) SetCronPaused(ctx context.Context, in *CronPauseRequest, opts ...grpc.CallOption) (*CronPauseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CronPauseResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_SetCronPaused_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) DeployStack(ctx context.Context, in *DeployStackRequest, opts ...grpc.CallOption) (*DeployStackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployStackResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_DeployStack_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) PublishBlueprint(ctx context.Context, in *PublishBlueprintRequest, opts ...grpc.CallOption) (*PublishBlueprintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishBlueprintResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_PublishBlueprint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) SubscribeApplication(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*SubscribeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubscribeResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_SubscribeApplication_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListSubscriptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ApplyBlueprintUpdate(ctx context.Context, in *ApplyBlueprintUpdateRequest, opts ...grpc.CallOption) (*ApplyBlueprintUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyBlueprintUpdateResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ApplyBlueprintUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetImpact(ctx context.Context, in *ImpactRequest, opts ...grpc.CallOption) (*ImpactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImpactResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetImpact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetDependencyGraph(ctx context.Context, in *DependencyGraphRequest, opts ...grpc.CallOption) (*DependencyGraphResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DependencyGraphResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetDependencyGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetApplicationLogs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (*LogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetApplicationLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetLogs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ControlPlane_ServiceDesc.Streams[2], ControlPlane_GetLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LogsRequest, LogChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControlPlane_GetLogsClient = grpc.ServerStreamingClient[LogChunk]
+
+func (c *controlPlaneClient) RunAction(ctx context.Context, in *RunActionRequest, opts ...grpc.CallOption) (*RunActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunActionResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_RunAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetApplicationConfig(ctx context.Context, in *GetApplicationConfigRequest, opts ...grpc.CallOption) (*ApplicationConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplicationConfigResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetApplicationConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) SetApplicationConfig(ctx context.Context, in *SetApplicationConfigRequest, opts ...grpc.CallOption) (*ApplicationConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplicationConfigResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_SetApplicationConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) CreateVolume(ctx context.Context, in *CreateVolumeRequest, opts ...grpc.CallOption) (*CreateVolumeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVolumeResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_CreateVolume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVolumesResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListVolumes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) DeleteVolume(ctx context.Context, in *DeleteVolumeRequest, opts ...grpc.CallOption) (*DeleteVolumeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteVolumeResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_DeleteVolume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) BackupApplication(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackupResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_BackupApplication_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSnapshotsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) RestoreVolume(ctx context.Context, in *RestoreVolumeRequest, opts ...grpc.CallOption) (*RestoreVolumeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreVolumeResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_RestoreVolume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) AddDomain(ctx context.Context, in *AddDomainRequest, opts ...grpc.CallOption) (*AddDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddDomainResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_AddDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) VerifyDomain(ctx context.Context, in *VerifyDomainRequest, opts ...grpc.CallOption) (*VerifyDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyDomainResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_VerifyDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListDomains(ctx context.Context, in *ListDomainsRequest, opts ...grpc.CallOption) (*ListDomainsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDomainsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListDomains_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListImageDrift(ctx context.Context, in *ImageDriftRequest, opts ...grpc.CallOption) (*ImageDriftResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImageDriftResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListImageDrift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListRestartAnomalies(ctx context.Context, in *RestartAnomaliesRequest, opts ...grpc.CallOption) (*RestartAnomaliesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestartAnomaliesResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListRestartAnomalies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetTimeline(ctx context.Context, in *TimelineRequest, opts ...grpc.CallOption) (*TimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimelineResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRevisionsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) SaveProject(ctx context.Context, in *SaveProjectRequest, opts ...grpc.CallOption) (*SaveProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveProjectResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_SaveProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteProjectResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_DeleteProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProjectsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*GetProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProjectResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) CreateNamespace(ctx context.Context, in *CreateNamespaceRequest, opts ...grpc.CallOption) (*CreateNamespaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateNamespaceResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_CreateNamespace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNamespacesResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListNamespaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) DeleteNamespace(ctx context.Context, in *DeleteNamespaceRequest, opts ...grpc.CallOption) (*DeleteNamespaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteNamespaceResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_DeleteNamespace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) AttachArtifact(ctx context.Context, in *AttachArtifactRequest, opts ...grpc.CallOption) (*AttachArtifactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachArtifactResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_AttachArtifact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListArtifacts(ctx context.Context, in *ListArtifactsRequest, opts ...grpc.CallOption) (*ListArtifactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArtifactsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ListArtifacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (*GetArtifactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetArtifactResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetArtifact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ExplainPlacement(ctx context.Context, in *ExplainPlacementRequest, opts ...grpc.CallOption) (*ExplainPlacementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainPlacementResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ExplainPlacement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetResourceRecommendations(ctx context.Context, in *ResourceRecommendationsRequest, opts ...grpc.CallOption) (*ResourceRecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResourceRecommendationsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetResourceRecommendations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetDeploymentAnalytics(ctx context.Context, in *DeploymentAnalyticsRequest, opts ...grpc.CallOption) (*DeploymentAnalyticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeploymentAnalyticsResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetDeploymentAnalytics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ApplyResourceRecommendation(ctx context.Context, in *ApplyResourceRecommendationRequest, opts ...grpc.CallOption) (*ApplyResourceRecommendationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyResourceRecommendationResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_ApplyResourceRecommendation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetReconcilerStatus(ctx context.Context, in *GetReconcilerStatusRequest, opts ...grpc.CallOption) (*GetReconcilerStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReconcilerStatusResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetReconcilerStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) GetCalendar(ctx context.Context, in *GetCalendarRequest, opts ...grpc.CallOption) (*GetCalendarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCalendarResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_GetCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthCheckResponse)
+	err := c.cc.Invoke(ctx, ControlPlane_HealthCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ControlPlaneServer is the server API for ControlPlane service.
+// All implementations must embed UnimplementedControlPlaneServer
+// for forward compatibility.
+type ControlPlaneServer interface {
+	DeployApplication(context.Context, *DeployRequest) (*DeployResponse, error)
+	DeployRawJob(context.Context, *DeployRawJobRequest) (*DeployResponse, error)
+	ApplySpec(grpc.ClientStreamingServer[SpecChunk, DeployResponse]) error
+	DeleteApplication(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	GetApplicationStatus(context.Context, *StatusRequest) (*StatusResponse, error)
+	WatchDeployment(*WatchDeploymentRequest, grpc.ServerStreamingServer[DeploymentEvent]) error
+	PromoteDeployment(context.Context, *PromoteDeploymentRequest) (*PromoteDeploymentResponse, error)
+	FailDeployment(context.Context, *FailDeploymentRequest) (*FailDeploymentResponse, error)
+	GetApplicationHealth(context.Context, *ApplicationHealthRequest) (*ApplicationHealthResponse, error)
+	ListApplications(context.Context, *ListApplicationsRequest) (*ListApplicationsResponse, error)
+	ScaleApplication(context.Context, *ScaleRequest) (*ScaleResponse, error)
+	RollbackApplication(context.Context, *RollbackRequest) (*RollbackResponse, error)
+	UpdateApplication(context.Context, *UpdateApplicationRequest) (*DeployResponse, error)
+	InvokeFunction(context.Context, *InvokeRequest) (*InvokeResponse, error)
+	GetFunctionMetrics(context.Context, *FunctionMetricsRequest) (*FunctionMetricsResponse, error)
+	DispatchJob(context.Context, *DispatchRequest) (*DispatchResponse, error)
+	ListCronRuns(context.Context, *CronRunsRequest) (*CronRunsResponse, error)
+	TriggerCronJob(context.Context, *CronTriggerRequest) (*CronTriggerResponse, error)
+	SetCronPaused(context.Context, *CronPauseRequest) (*CronPauseResponse, error)
+	DeployStack(context.Context, *DeployStackRequest) (*DeployStackResponse, error)
+	PublishBlueprint(context.Context, *PublishBlueprintRequest) (*PublishBlueprintResponse, error)
+	SubscribeApplication(context.Context, *SubscribeRequest) (*SubscribeResponse, error)
+	ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error)
+	ApplyBlueprintUpdate(context.Context, *ApplyBlueprintUpdateRequest) (*ApplyBlueprintUpdateResponse, error)
+	GetImpact(context.Context, *ImpactRequest) (*ImpactResponse, error)
+	GetDependencyGraph(context.Context, *DependencyGraphRequest) (*DependencyGraphResponse, error)
+	GetApplicationLogs(context.Context, *LogsRequest) (*LogsResponse, error)
+	GetLogs(*LogsRequest, grpc.ServerStreamingServer[LogChunk]) error
+	RunAction(context.Context, *RunActionRequest) (*RunActionResponse, error)
+	GetApplicationConfig(context.Context, *GetApplicationConfigRequest) (*ApplicationConfigResponse, error)
+	SetApplicationConfig(context.Context, *SetApplicationConfigRequest) (*ApplicationConfigResponse, error)
+	CreateVolume(context.Context, *CreateVolumeRequest) (*CreateVolumeResponse, error)
+	ListVolumes(context.Context, *ListVolumesRequest) (*ListVolumesResponse, error)
+	DeleteVolume(context.Context, *DeleteVolumeRequest) (*DeleteVolumeResponse, error)
+	BackupApplication(context.Context, *BackupRequest) (*BackupResponse, error)
+	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error)
+	RestoreVolume(context.Context, *RestoreVolumeRequest) (*RestoreVolumeResponse, error)
+	AddDomain(context.Context, *AddDomainRequest) (*AddDomainResponse, error)
+	VerifyDomain(context.Context, *VerifyDomainRequest) (*VerifyDomainResponse, error)
+	ListDomains(context.Context, *ListDomainsRequest) (*ListDomainsResponse, error)
+	ListImageDrift(context.Context, *ImageDriftRequest) (*ImageDriftResponse, error)
+	ListRestartAnomalies(context.Context, *RestartAnomaliesRequest) (*RestartAnomaliesResponse, error)
+	GetTimeline(context.Context, *TimelineRequest) (*TimelineResponse, error)
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error)
+	SaveProject(context.Context, *SaveProjectRequest) (*SaveProjectResponse, error)
+	DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error)
+	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
+	GetProject(context.Context, *GetProjectRequest) (*GetProjectResponse, error)
+	CreateNamespace(context.Context, *CreateNamespaceRequest) (*CreateNamespaceResponse, error)
+	ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error)
+	DeleteNamespace(context.Context, *DeleteNamespaceRequest) (*DeleteNamespaceResponse, error)
+	AttachArtifact(context.Context, *AttachArtifactRequest) (*AttachArtifactResponse, error)
+	ListArtifacts(context.Context, *ListArtifactsRequest) (*ListArtifactsResponse, error)
+	GetArtifact(context.Context, *GetArtifactRequest) (*GetArtifactResponse, error)
+	ExplainPlacement(context.Context, *ExplainPlacementRequest) (*ExplainPlacementResponse, error)
+	GetResourceRecommendations(context.Context, *ResourceRecommendationsRequest) (*ResourceRecommendationsResponse, error)
+	GetDeploymentAnalytics(context.Context, *DeploymentAnalyticsRequest) (*DeploymentAnalyticsResponse, error)
+	ApplyResourceRecommendation(context.Context, *ApplyResourceRecommendationRequest) (*ApplyResourceRecommendationResponse, error)
+	GetReconcilerStatus(context.Context, *GetReconcilerStatusRequest) (*GetReconcilerStatusResponse, error)
+	GetCalendar(context.Context, *GetCalendarRequest) (*GetCalendarResponse, error)
+	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
+	mustEmbedUnimplementedControlPlaneServer()
+}
+
+// UnimplementedControlPlaneServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedControlPlaneServer struct{}
+
+func (UnimplementedControlPlaneServer) DeployApplication(context.Context, *DeployRequest) (*DeployResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployApplication not implemented")
+}
+func (UnimplementedControlPlaneServer) DeployRawJob(context.Context, *DeployRawJobRequest) (*DeployResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployRawJob not implemented")
+}
+func (UnimplementedControlPlaneServer) ApplySpec(grpc.ClientStreamingServer[SpecChunk, DeployResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ApplySpec not implemented")
+}
+func (UnimplementedControlPlaneServer) DeleteApplication(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteApplication not implemented")
+}
+func (UnimplementedControlPlaneServer) GetApplicationStatus(context.Context, *StatusRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApplicationStatus not implemented")
+}
+func (UnimplementedControlPlaneServer) WatchDeployment(*WatchDeploymentRequest, grpc.ServerStreamingServer[DeploymentEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDeployment not implemented")
+}
+func (UnimplementedControlPlaneServer) PromoteDeployment(context.Context, *PromoteDeploymentRequest) (*PromoteDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteDeployment not implemented")
+}
+func (UnimplementedControlPlaneServer) FailDeployment(context.Context, *FailDeploymentRequest) (*FailDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FailDeployment not implemented")
+}
+func (UnimplementedControlPlaneServer) GetApplicationHealth(context.Context, *ApplicationHealthRequest) (*ApplicationHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApplicationHealth not implemented")
+}
+func (UnimplementedControlPlaneServer) ListApplications(context.Context, *ListApplicationsRequest) (*ListApplicationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApplications not implemented")
+}
+func (UnimplementedControlPlaneServer) ScaleApplication(context.Context, *ScaleRequest) (*ScaleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScaleApplication not implemented")
+}
+func (UnimplementedControlPlaneServer) RollbackApplication(context.Context, *RollbackRequest) (*RollbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackApplication not implemented")
+}
+func (UnimplementedControlPlaneServer) UpdateApplication(context.Context, *UpdateApplicationRequest) (*DeployResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateApplication not implemented")
+}
+func (UnimplementedControlPlaneServer) InvokeFunction(context.Context, *InvokeRequest) (*InvokeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvokeFunction not implemented")
+}
+func (UnimplementedControlPlaneServer) GetFunctionMetrics(context.Context, *FunctionMetricsRequest) (*FunctionMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFunctionMetrics not implemented")
+}
+func (UnimplementedControlPlaneServer) DispatchJob(context.Context, *DispatchRequest) (*DispatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DispatchJob not implemented")
+}
+func (UnimplementedControlPlaneServer) ListCronRuns(context.Context, *CronRunsRequest) (*CronRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCronRuns not implemented")
+}
+func (UnimplementedControlPlaneServer) TriggerCronJob(context.Context, *CronTriggerRequest) (*CronTriggerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerCronJob not implemented")
+}
+func (UnimplementedControlPlaneServer) SetCronPaused(context.Context, *CronPauseRequest) (*CronPauseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCronPaused not implemented")
+}
+func (UnimplementedControlPlaneServer) DeployStack(context.Context, *DeployStackRequest) (*DeployStackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployStack not implemented")
+}
+func (UnimplementedControlPlaneServer) PublishBlueprint(context.Context, *PublishBlueprintRequest) (*PublishBlueprintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishBlueprint not implemented")
+}
+func (UnimplementedControlPlaneServer) SubscribeApplication(context.Context, *SubscribeRequest) (*SubscribeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubscribeApplication not implemented")
+}
+func (UnimplementedControlPlaneServer) ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubscriptions not implemented")
+}
+func (UnimplementedControlPlaneServer) ApplyBlueprintUpdate(context.Context, *ApplyBlueprintUpdateRequest) (*ApplyBlueprintUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyBlueprintUpdate not implemented")
+}
+func (UnimplementedControlPlaneServer) GetImpact(context.Context, *ImpactRequest) (*ImpactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImpact not implemented")
+}
+func (UnimplementedControlPlaneServer) GetDependencyGraph(context.Context, *DependencyGraphRequest) (*DependencyGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDependencyGraph not implemented")
+}
+func (UnimplementedControlPlaneServer) GetApplicationLogs(context.Context, *LogsRequest) (*LogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApplicationLogs not implemented")
+}
+func (UnimplementedControlPlaneServer) GetLogs(*LogsRequest, grpc.ServerStreamingServer[LogChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+}
+func (UnimplementedControlPlaneServer) RunAction(context.Context, *RunActionRequest) (*RunActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunAction not implemented")
+}
+func (UnimplementedControlPlaneServer) GetApplicationConfig(context.Context, *GetApplicationConfigRequest) (*ApplicationConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApplicationConfig not implemented")
+}
+func (UnimplementedControlPlaneServer) SetApplicationConfig(context.Context, *SetApplicationConfigRequest) (*ApplicationConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetApplicationConfig not implemented")
+}
+func (UnimplementedControlPlaneServer) CreateVolume(context.Context, *CreateVolumeRequest) (*CreateVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVolume not implemented")
+}
+func (UnimplementedControlPlaneServer) ListVolumes(context.Context, *ListVolumesRequest) (*ListVolumesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVolumes not implemented")
+}
+func (UnimplementedControlPlaneServer) DeleteVolume(context.Context, *DeleteVolumeRequest) (*DeleteVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVolume not implemented")
+}
+func (UnimplementedControlPlaneServer) BackupApplication(context.Context, *BackupRequest) (*BackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupApplication not implemented")
+}
+func (UnimplementedControlPlaneServer) ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshots not implemented")
+}
+func (UnimplementedControlPlaneServer) RestoreVolume(context.Context, *RestoreVolumeRequest) (*RestoreVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreVolume not implemented")
+}
+func (UnimplementedControlPlaneServer) AddDomain(context.Context, *AddDomainRequest) (*AddDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDomain not implemented")
+}
+func (UnimplementedControlPlaneServer) VerifyDomain(context.Context, *VerifyDomainRequest) (*VerifyDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyDomain not implemented")
+}
+func (UnimplementedControlPlaneServer) ListDomains(context.Context, *ListDomainsRequest) (*ListDomainsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDomains not implemented")
+}
+func (UnimplementedControlPlaneServer) ListImageDrift(context.Context, *ImageDriftRequest) (*ImageDriftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListImageDrift not implemented")
+}
+func (UnimplementedControlPlaneServer) ListRestartAnomalies(context.Context, *RestartAnomaliesRequest) (*RestartAnomaliesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRestartAnomalies not implemented")
+}
+func (UnimplementedControlPlaneServer) GetTimeline(context.Context, *TimelineRequest) (*TimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeline not implemented")
+}
+func (UnimplementedControlPlaneServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedControlPlaneServer) ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedControlPlaneServer) SaveProject(context.Context, *SaveProjectRequest) (*SaveProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveProject not implemented")
+}
+func (UnimplementedControlPlaneServer) DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
+}
+func (UnimplementedControlPlaneServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (UnimplementedControlPlaneServer) GetProject(context.Context, *GetProjectRequest) (*GetProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
+}
+func (UnimplementedControlPlaneServer) CreateNamespace(context.Context, *CreateNamespaceRequest) (*CreateNamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNamespace not implemented")
+}
+func (UnimplementedControlPlaneServer) ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNamespaces not implemented")
+}
+func (UnimplementedControlPlaneServer) DeleteNamespace(context.Context, *DeleteNamespaceRequest) (*DeleteNamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNamespace not implemented")
+}
+func (UnimplementedControlPlaneServer) AttachArtifact(context.Context, *AttachArtifactRequest) (*AttachArtifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttachArtifact not implemented")
+}
+func (UnimplementedControlPlaneServer) ListArtifacts(context.Context, *ListArtifactsRequest) (*ListArtifactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArtifacts not implemented")
+}
+func (UnimplementedControlPlaneServer) GetArtifact(context.Context, *GetArtifactRequest) (*GetArtifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArtifact not implemented")
+}
+func (UnimplementedControlPlaneServer) ExplainPlacement(context.Context, *ExplainPlacementRequest) (*ExplainPlacementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainPlacement not implemented")
+}
+func (UnimplementedControlPlaneServer) GetResourceRecommendations(context.Context, *ResourceRecommendationsRequest) (*ResourceRecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResourceRecommendations not implemented")
+}
+func (UnimplementedControlPlaneServer) GetDeploymentAnalytics(context.Context, *DeploymentAnalyticsRequest) (*DeploymentAnalyticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeploymentAnalytics not implemented")
+}
+func (UnimplementedControlPlaneServer) ApplyResourceRecommendation(context.Context, *ApplyResourceRecommendationRequest) (*ApplyResourceRecommendationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyResourceRecommendation not implemented")
+}
+func (UnimplementedControlPlaneServer) GetReconcilerStatus(context.Context, *GetReconcilerStatusRequest) (*GetReconcilerStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReconcilerStatus not implemented")
+}
+func (UnimplementedControlPlaneServer) GetCalendar(context.Context, *GetCalendarRequest) (*GetCalendarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalendar not implemented")
+}
+func (UnimplementedControlPlaneServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
+}
+func (UnimplementedControlPlaneServer) mustEmbedUnimplementedControlPlaneServer() {}
+func (UnimplementedControlPlaneServer) testEmbeddedByValue()                      {}
+
+// UnsafeControlPlaneServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ControlPlaneServer will
+// result in compilation errors.
+type UnsafeControlPlaneServer interface {
+	mustEmbedUnimplementedControlPlaneServer()
+}
+
+func RegisterControlPlaneServer(s grpc.ServiceRegistrar, srv ControlPlaneServer) {
+	// If the following call pancis, it indicates UnimplementedControlPlaneServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ControlPlane_ServiceDesc, srv)
+}
+
+func _ControlPlane_DeployApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).DeployApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_DeployApplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).DeployApplication(ctx, req.(*DeployRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_DeployRawJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployRawJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).DeployRawJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_DeployRawJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).DeployRawJob(ctx, req.(*DeployRawJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ApplySpec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ControlPlaneServer).ApplySpec(&grpc.GenericServerStream[SpecChunk, DeployResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControlPlane_ApplySpecServer = grpc.ClientStreamingServer[SpecChunk, DeployResponse]
+
+func _ControlPlane_DeleteApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).DeleteApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_DeleteApplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).DeleteApplication(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetApplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetApplicationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetApplicationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetApplicationStatus(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_WatchDeployment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDeploymentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlPlaneServer).WatchDeployment(m, &grpc.GenericServerStream[WatchDeploymentRequest, DeploymentEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControlPlane_WatchDeploymentServer = grpc.ServerStreamingServer[DeploymentEvent]
+
+func _ControlPlane_PromoteDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).PromoteDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_PromoteDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).PromoteDeployment(ctx, req.(*PromoteDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_FailDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FailDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).FailDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_FailDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).FailDeployment(ctx, req.(*FailDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetApplicationHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetApplicationHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetApplicationHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetApplicationHealth(ctx, req.(*ApplicationHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApplicationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListApplications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListApplications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListApplications(ctx, req.(*ListApplicationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ScaleApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ScaleApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ScaleApplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ScaleApplication(ctx, req.(*ScaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_RollbackApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).RollbackApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_RollbackApplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).RollbackApplication(ctx, req.(*RollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_UpdateApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateApplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).UpdateApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_UpdateApplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).UpdateApplication(ctx, req.(*UpdateApplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_InvokeFunction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).InvokeFunction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_InvokeFunction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).InvokeFunction(ctx, req.(*InvokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetFunctionMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FunctionMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetFunctionMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetFunctionMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetFunctionMetrics(ctx, req.(*FunctionMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_DispatchJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DispatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).DispatchJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_DispatchJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).DispatchJob(ctx, req.(*DispatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListCronRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CronRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListCronRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListCronRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListCronRuns(ctx, req.(*CronRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_TriggerCronJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CronTriggerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).TriggerCronJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_TriggerCronJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).TriggerCronJob(ctx, req.(*CronTriggerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_SetCronPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CronPauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).SetCronPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_SetCronPaused_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).SetCronPaused(ctx, req.(*CronPauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_DeployStack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployStackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).DeployStack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_DeployStack_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).DeployStack(ctx, req.(*DeployStackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_PublishBlueprint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishBlueprintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).PublishBlueprint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_PublishBlueprint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).PublishBlueprint(ctx, req.(*PublishBlueprintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_SubscribeApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).SubscribeApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_SubscribeApplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).SubscribeApplication(ctx, req.(*SubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListSubscriptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListSubscriptions(ctx, req.(*ListSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ApplyBlueprintUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyBlueprintUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ApplyBlueprintUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ApplyBlueprintUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ApplyBlueprintUpdate(ctx, req.(*ApplyBlueprintUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetImpact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImpactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetImpact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetImpact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetImpact(ctx, req.(*ImpactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetDependencyGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DependencyGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetDependencyGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetDependencyGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetDependencyGraph(ctx, req.(*DependencyGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetApplicationLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetApplicationLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetApplicationLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetApplicationLogs(ctx, req.(*LogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlPlaneServer).GetLogs(m, &grpc.GenericServerStream[LogsRequest, LogChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControlPlane_GetLogsServer = grpc.ServerStreamingServer[LogChunk]
+
+func _ControlPlane_RunAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).RunAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_RunAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).RunAction(ctx, req.(*RunActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetApplicationConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApplicationConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetApplicationConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetApplicationConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetApplicationConfig(ctx, req.(*GetApplicationConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_SetApplicationConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetApplicationConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).SetApplicationConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_SetApplicationConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).SetApplicationConfig(ctx, req.(*SetApplicationConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_CreateVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).CreateVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_CreateVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).CreateVolume(ctx, req.(*CreateVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVolumesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListVolumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListVolumes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListVolumes(ctx, req.(*ListVolumesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_DeleteVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).DeleteVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_DeleteVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).DeleteVolume(ctx, req.(*DeleteVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_BackupApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).BackupApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_BackupApplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).BackupApplication(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListSnapshots(ctx, req.(*ListSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_RestoreVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).RestoreVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_RestoreVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).RestoreVolume(ctx, req.(*RestoreVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_AddDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).AddDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_AddDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).AddDomain(ctx, req.(*AddDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_VerifyDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).VerifyDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_VerifyDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).VerifyDomain(ctx, req.(*VerifyDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListDomains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListDomains(ctx, req.(*ListDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListImageDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImageDriftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListImageDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListImageDrift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListImageDrift(ctx, req.(*ImageDriftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListRestartAnomalies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestartAnomaliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListRestartAnomalies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListRestartAnomalies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListRestartAnomalies(ctx, req.(*RestartAnomaliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetTimeline(ctx, req.(*TimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListRevisions(ctx, req.(*ListRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_SaveProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).SaveProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_SaveProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).SaveProject(ctx, req.(*SaveProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).DeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_DeleteProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).DeleteProject(ctx, req.(*DeleteProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListProjects(ctx, req.(*ListProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetProject(ctx, req.(*GetProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_CreateNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).CreateNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_CreateNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).CreateNamespace(ctx, req.(*CreateNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNamespacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListNamespaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListNamespaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListNamespaces(ctx, req.(*ListNamespacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_DeleteNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).DeleteNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_DeleteNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).DeleteNamespace(ctx, req.(*DeleteNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_AttachArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).AttachArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_AttachArtifact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).AttachArtifact(ctx, req.(*AttachArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ListArtifacts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListArtifacts(ctx, req.(*ListArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetArtifact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetArtifact(ctx, req.(*GetArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ExplainPlacement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainPlacementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ExplainPlacement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ExplainPlacement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ExplainPlacement(ctx, req.(*ExplainPlacementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetResourceRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResourceRecommendationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetResourceRecommendations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetResourceRecommendations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetResourceRecommendations(ctx, req.(*ResourceRecommendationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetDeploymentAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeploymentAnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetDeploymentAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetDeploymentAnalytics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetDeploymentAnalytics(ctx, req.(*DeploymentAnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ApplyResourceRecommendation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyResourceRecommendationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ApplyResourceRecommendation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_ApplyResourceRecommendation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ApplyResourceRecommendation(ctx, req.(*ApplyResourceRecommendationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetReconcilerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReconcilerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetReconcilerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetReconcilerStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetReconcilerStatus(ctx, req.(*GetReconcilerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_GetCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).GetCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_GetCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).GetCalendar(ctx, req.(*GetCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).HealthCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlPlane_HealthCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).HealthCheck(ctx, req.(*HealthCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ControlPlane_ServiceDesc is the grpc.ServiceDesc for ControlPlane service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ControlPlane_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "controlplane.ControlPlane",
+	HandlerType: (*ControlPlaneServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeployApplication",
+			Handler:    _ControlPlane_DeployApplication_Handler,
+		},
+		{
+			MethodName: "DeployRawJob",
+			Handler:    _ControlPlane_DeployRawJob_Handler,
+		},
+		{
+			MethodName: "DeleteApplication",
+			Handler:    _ControlPlane_DeleteApplication_Handler,
+		},
+		{
+			MethodName: "GetApplicationStatus",
+			Handler:    _ControlPlane_GetApplicationStatus_Handler,
+		},
+		{
+			MethodName: "PromoteDeployment",
+			Handler:    _ControlPlane_PromoteDeployment_Handler,
+		},
+		{
+			MethodName: "FailDeployment",
+			Handler:    _ControlPlane_FailDeployment_Handler,
+		},
+		{
+			MethodName: "GetApplicationHealth",
+			Handler:    _ControlPlane_GetApplicationHealth_Handler,
+		},
+		{
+			MethodName: "ListApplications",
+			Handler:    _ControlPlane_ListApplications_Handler,
+		},
+		{
+			MethodName: "ScaleApplication",
+			Handler:    _ControlPlane_ScaleApplication_Handler,
+		},
+		{
+			MethodName: "RollbackApplication",
+			Handler:    _ControlPlane_RollbackApplication_Handler,
+		},
+		{
+			MethodName: "UpdateApplication",
+			Handler:    _ControlPlane_UpdateApplication_Handler,
+		},
+		{
+			MethodName: "InvokeFunction",
+			Handler:    _ControlPlane_InvokeFunction_Handler,
+		},
+		{
+			MethodName: "GetFunctionMetrics",
+			Handler:    _ControlPlane_GetFunctionMetrics_Handler,
+		},
+		{
+			MethodName: "DispatchJob",
+			Handler:    _ControlPlane_DispatchJob_Handler,
+		},
+		{
+			MethodName: "ListCronRuns",
+			Handler:    _ControlPlane_ListCronRuns_Handler,
+		},
+		{
+			MethodName: "TriggerCronJob",
+			Handler:    _ControlPlane_TriggerCronJob_Handler,
+		},
+		{
+			MethodName: "SetCronPaused",
+			Handler:    _ControlPlane_SetCronPaused_Handler,
+		},
+		{
+			MethodName: "DeployStack",
+			Handler:    _ControlPlane_DeployStack_Handler,
+		},
+		{
+			MethodName: "PublishBlueprint",
+			Handler:    _ControlPlane_PublishBlueprint_Handler,
+		},
+		{
+			MethodName: "SubscribeApplication",
+			Handler:    _ControlPlane_SubscribeApplication_Handler,
+		},
+		{
+			MethodName: "ListSubscriptions",
+			Handler:    _ControlPlane_ListSubscriptions_Handler,
+		},
+		{
+			MethodName: "ApplyBlueprintUpdate",
+			Handler:    _ControlPlane_ApplyBlueprintUpdate_Handler,
+		},
+		{
+			MethodName: "GetImpact",
+			Handler:    _ControlPlane_GetImpact_Handler,
+		},
+		{
+			MethodName: "GetDependencyGraph",
+			Handler:    _ControlPlane_GetDependencyGraph_Handler,
+		},
+		{
+			MethodName: "GetApplicationLogs",
+			Handler:    _ControlPlane_GetApplicationLogs_Handler,
+		},
+		{
+			MethodName: "RunAction",
+			Handler:    _ControlPlane_RunAction_Handler,
+		},
+		{
+			MethodName: "GetApplicationConfig",
+			Handler:    _ControlPlane_GetApplicationConfig_Handler,
+		},
+		{
+			MethodName: "SetApplicationConfig",
+			Handler:    _ControlPlane_SetApplicationConfig_Handler,
+		},
+		{
+			MethodName: "CreateVolume",
+			Handler:    _ControlPlane_CreateVolume_Handler,
+		},
+		{
+			MethodName: "ListVolumes",
+			Handler:    _ControlPlane_ListVolumes_Handler,
+		},
+		{
+			MethodName: "DeleteVolume",
+			Handler:    _ControlPlane_DeleteVolume_Handler,
+		},
+		{
+			MethodName: "BackupApplication",
+			Handler:    _ControlPlane_BackupApplication_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _ControlPlane_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "RestoreVolume",
+			Handler:    _ControlPlane_RestoreVolume_Handler,
+		},
+		{
+			MethodName: "AddDomain",
+			Handler:    _ControlPlane_AddDomain_Handler,
+		},
+		{
+			MethodName: "VerifyDomain",
+			Handler:    _ControlPlane_VerifyDomain_Handler,
+		},
+		{
+			MethodName: "ListDomains",
+			Handler:    _ControlPlane_ListDomains_Handler,
+		},
+		{
+			MethodName: "ListImageDrift",
+			Handler:    _ControlPlane_ListImageDrift_Handler,
+		},
+		{
+			MethodName: "ListRestartAnomalies",
+			Handler:    _ControlPlane_ListRestartAnomalies_Handler,
+		},
+		{
+			MethodName: "GetTimeline",
+			Handler:    _ControlPlane_GetTimeline_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _ControlPlane_Search_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _ControlPlane_ListRevisions_Handler,
+		},
+		{
+			MethodName: "SaveProject",
+			Handler:    _ControlPlane_SaveProject_Handler,
+		},
+		{
+			MethodName: "DeleteProject",
+			Handler:    _ControlPlane_DeleteProject_Handler,
+		},
+		{
+			MethodName: "ListProjects",
+			Handler:    _ControlPlane_ListProjects_Handler,
+		},
+		{
+			MethodName: "GetProject",
+			Handler:    _ControlPlane_GetProject_Handler,
+		},
+		{
+			MethodName: "CreateNamespace",
+			Handler:    _ControlPlane_CreateNamespace_Handler,
+		},
+		{
+			MethodName: "ListNamespaces",
+			Handler:    _ControlPlane_ListNamespaces_Handler,
+		},
+		{
+			MethodName: "DeleteNamespace",
+			Handler:    _ControlPlane_DeleteNamespace_Handler,
+		},
+		{
+			MethodName: "AttachArtifact",
+			Handler:    _ControlPlane_AttachArtifact_Handler,
+		},
+		{
+			MethodName: "ListArtifacts",
+			Handler:    _ControlPlane_ListArtifacts_Handler,
+		},
+		{
+			MethodName: "GetArtifact",
+			Handler:    _ControlPlane_GetArtifact_Handler,
+		},
+		{
+			MethodName: "ExplainPlacement",
+			Handler:    _ControlPlane_ExplainPlacement_Handler,
+		},
+		{
+			MethodName: "GetResourceRecommendations",
+			Handler:    _ControlPlane_GetResourceRecommendations_Handler,
+		},
+		{
+			MethodName: "GetDeploymentAnalytics",
+			Handler:    _ControlPlane_GetDeploymentAnalytics_Handler,
+		},
+		{
+			MethodName: "ApplyResourceRecommendation",
+			Handler:    _ControlPlane_ApplyResourceRecommendation_Handler,
+		},
+		{
+			MethodName: "GetReconcilerStatus",
+			Handler:    _ControlPlane_GetReconcilerStatus_Handler,
+		},
+		{
+			MethodName: "GetCalendar",
+			Handler:    _ControlPlane_GetCalendar_Handler,
+		},
+		{
+			MethodName: "HealthCheck",
+			Handler:    _ControlPlane_HealthCheck_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ApplySpec",
+			Handler:       _ControlPlane_ApplySpec_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchDeployment",
+			Handler:       _ControlPlane_WatchDeployment_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetLogs",
+			Handler:       _ControlPlane_GetLogs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/proto/controlplane.proto",
+}
+
+const (
+	Admin_CreateTenant_FullMethodName          = "/controlplane.Admin/CreateTenant"
+	Admin_ListTenants_FullMethodName           = "/controlplane.Admin/ListTenants"
+	Admin_RotateTenantKeys_FullMethodName      = "/controlplane.Admin/RotateTenantKeys"
+	Admin_BootstrapEdgeProxy_FullMethodName    = "/controlplane.Admin/BootstrapEdgeProxy"
+	Admin_BootstrapPlatform_FullMethodName     = "/controlplane.Admin/BootstrapPlatform"
+	Admin_PromoteStandby_FullMethodName        = "/controlplane.Admin/PromoteStandby"
+	Admin_GetReplicationStatus_FullMethodName  = "/controlplane.Admin/GetReplicationStatus"
+	Admin_DeployController_FullMethodName      = "/controlplane.Admin/DeployController"
+	Admin_IssueTenantNomadToken_FullMethodName = "/controlplane.Admin/IssueTenantNomadToken"
+)
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Admin is the platform team's API, it is not meant for tenants
+type AdminClient interface {
+	CreateTenant(ctx context.Context, in *CreateTenantRequest, opts ...grpc.CallOption) (*CreateTenantResponse, error)
+	ListTenants(ctx context.Context, in *ListTenantsRequest, opts ...grpc.CallOption) (*ListTenantsResponse, error)
+	RotateTenantKeys(ctx context.Context, in *RotateTenantKeysRequest, opts ...grpc.CallOption) (*RotateTenantKeysResponse, error)
+	BootstrapEdgeProxy(ctx context.Context, in *BootstrapEdgeProxyRequest, opts ...grpc.CallOption) (*BootstrapEdgeProxyResponse, error)
+	BootstrapPlatform(ctx context.Context, in *BootstrapPlatformRequest, opts ...grpc.CallOption) (*BootstrapPlatformResponse, error)
+	PromoteStandby(ctx context.Context, in *PromoteStandbyRequest, opts ...grpc.CallOption) (*PromoteStandbyResponse, error)
+	GetReplicationStatus(ctx context.Context, in *GetReplicationStatusRequest, opts ...grpc.CallOption) (*GetReplicationStatusResponse, error)
+	DeployController(ctx context.Context, in *DeployControllerRequest, opts ...grpc.CallOption) (*DeployControllerResponse, error)
+	IssueTenantNomadToken(ctx context.Context, in *IssueTenantNomadTokenRequest, opts ...grpc.CallOption) (*IssueTenantNomadTokenResponse, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) CreateTenant(ctx context.Context, in *CreateTenantRequest, opts ...grpc.CallOption) (*CreateTenantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTenantResponse)
+	err := c.cc.Invoke(ctx, Admin_CreateTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListTenants(ctx context.Context, in *ListTenantsRequest, opts ...grpc.CallOption) (*ListTenantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTenantsResponse)
+	err := c.cc.Invoke(ctx, Admin_ListTenants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RotateTenantKeys(ctx context.Context, in *RotateTenantKeysRequest, opts ...grpc.CallOption) (*RotateTenantKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateTenantKeysResponse)
+	err := c.cc.Invoke(ctx, Admin_RotateTenantKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) BootstrapEdgeProxy(ctx context.Context, in *BootstrapEdgeProxyRequest, opts ...grpc.CallOption) (*BootstrapEdgeProxyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BootstrapEdgeProxyResponse)
+	err := c.cc.Invoke(ctx, Admin_BootstrapEdgeProxy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) BootstrapPlatform(ctx context.Context, in *BootstrapPlatformRequest, opts ...grpc.CallOption) (*BootstrapPlatformResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BootstrapPlatformResponse)
+	err := c.cc.Invoke(ctx, Admin_BootstrapPlatform_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) PromoteStandby(ctx context.Context, in *PromoteStandbyRequest, opts ...grpc.CallOption) (*PromoteStandbyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoteStandbyResponse)
+	err := c.cc.Invoke(ctx, Admin_PromoteStandby_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *controlPlaneClient) GetApplicationStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+func (c *adminClient) GetReplicationStatus(ctx context.Context, in *GetReplicationStatusRequest, opts ...grpc.CallOption) (*GetReplicationStatusResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(StatusResponse)
-	err := c.cc.Invoke(ctx, ControlPlane_GetApplicationStatus_FullMethodName, in, out, cOpts...)
+	out := new(GetReplicationStatusResponse)
+	err := c.cc.Invoke(ctx, Admin_GetReplicationStatus_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *controlPlaneClient) GetApplicationLogs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (*LogsResponse, error) {
+func (c *adminClient) DeployController(ctx context.Context, in *DeployControllerRequest, opts ...grpc.CallOption) (*DeployControllerResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(LogsResponse)
-	err := c.cc.Invoke(ctx, ControlPlane_GetApplicationLogs_FullMethodName, in, out, cOpts...)
+	out := new(DeployControllerResponse)
+	err := c.cc.Invoke(ctx, Admin_DeployController_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *controlPlaneClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
+func (c *adminClient) IssueTenantNomadToken(ctx context.Context, in *IssueTenantNomadTokenRequest, opts ...grpc.CallOption) (*IssueTenantNomadTokenResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(HealthCheckResponse)
-	err := c.cc.Invoke(ctx, ControlPlane_HealthCheck_FullMethodName, in, out, cOpts...)
+	out := new(IssueTenantNomadTokenResponse)
+	err := c.cc.Invoke(ctx, Admin_IssueTenantNomadToken_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// ControlPlaneServer is the server API for ControlPlane service.
-// All implementations must embed UnimplementedControlPlaneServer
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
 // for forward compatibility.
-type ControlPlaneServer interface {
-	DeployApplication(context.Context, *DeployRequest) (*DeployResponse, error)
-	DeleteApplication(context.Context, *DeleteRequest) (*DeleteResponse, error)
-	GetApplicationStatus(context.Context, *StatusRequest) (*StatusResponse, error)
-	GetApplicationLogs(context.Context, *LogsRequest) (*LogsResponse, error)
-	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
-	mustEmbedUnimplementedControlPlaneServer()
+//
+// Admin is the platform team's API, it is not meant for tenants
+type AdminServer interface {
+	CreateTenant(context.Context, *CreateTenantRequest) (*CreateTenantResponse, error)
+	ListTenants(context.Context, *ListTenantsRequest) (*ListTenantsResponse, error)
+	RotateTenantKeys(context.Context, *RotateTenantKeysRequest) (*RotateTenantKeysResponse, error)
+	BootstrapEdgeProxy(context.Context, *BootstrapEdgeProxyRequest) (*BootstrapEdgeProxyResponse, error)
+	BootstrapPlatform(context.Context, *BootstrapPlatformRequest) (*BootstrapPlatformResponse, error)
+	PromoteStandby(context.Context, *PromoteStandbyRequest) (*PromoteStandbyResponse, error)
+	GetReplicationStatus(context.Context, *GetReplicationStatusRequest) (*GetReplicationStatusResponse, error)
+	DeployController(context.Context, *DeployControllerRequest) (*DeployControllerResponse, error)
+	IssueTenantNomadToken(context.Context, *IssueTenantNomadTokenRequest) (*IssueTenantNomadTokenResponse, error)
+	mustEmbedUnimplementedAdminServer()
 }
 
-// UnimplementedControlPlaneServer must be embedded to have
+// UnimplementedAdminServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedControlPlaneServer struct{}
+type UnimplementedAdminServer struct{}
 
-func (UnimplementedControlPlaneServer) DeployApplication(context.Context, *DeployRequest) (*DeployResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeployApplication not implemented")
+func (UnimplementedAdminServer) CreateTenant(context.Context, *CreateTenantRequest) (*CreateTenantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTenant not implemented")
 }
-func (UnimplementedControlPlaneServer) DeleteApplication(context.Context, *DeleteRequest) (*DeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteApplication not implemented")
+func (UnimplementedAdminServer) ListTenants(context.Context, *ListTenantsRequest) (*ListTenantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTenants not implemented")
 }
-func (UnimplementedControlPlaneServer) GetApplicationStatus(context.Context, *StatusRequest) (*StatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetApplicationStatus not implemented")
+func (UnimplementedAdminServer) RotateTenantKeys(context.Context, *RotateTenantKeysRequest) (*RotateTenantKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateTenantKeys not implemented")
 }
-func (UnimplementedControlPlaneServer) GetApplicationLogs(context.Context, *LogsRequest) (*LogsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetApplicationLogs not implemented")
+func (UnimplementedAdminServer) BootstrapEdgeProxy(context.Context, *BootstrapEdgeProxyRequest) (*BootstrapEdgeProxyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BootstrapEdgeProxy not implemented")
 }
-func (UnimplementedControlPlaneServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
+func (UnimplementedAdminServer) BootstrapPlatform(context.Context, *BootstrapPlatformRequest) (*BootstrapPlatformResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BootstrapPlatform not implemented")
 }
-func (UnimplementedControlPlaneServer) mustEmbedUnimplementedControlPlaneServer() {}
-func (UnimplementedControlPlaneServer) testEmbeddedByValue()                      {}
+func (UnimplementedAdminServer) PromoteStandby(context.Context, *PromoteStandbyRequest) (*PromoteStandbyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteStandby not implemented")
+}
+func (UnimplementedAdminServer) GetReplicationStatus(context.Context, *GetReplicationStatusRequest) (*GetReplicationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplicationStatus not implemented")
+}
+func (UnimplementedAdminServer) DeployController(context.Context, *DeployControllerRequest) (*DeployControllerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployController not implemented")
+}
+func (UnimplementedAdminServer) IssueTenantNomadToken(context.Context, *IssueTenantNomadTokenRequest) (*IssueTenantNomadTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueTenantNomadToken not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+func (UnimplementedAdminServer) testEmbeddedByValue()               {}
 
-// UnsafeControlPlaneServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ControlPlaneServer will
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
 // result in compilation errors.
-type UnsafeControlPlaneServer interface {
-	mustEmbedUnimplementedControlPlaneServer()
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
 }
 
-func RegisterControlPlaneServer(s grpc.ServiceRegistrar, srv ControlPlaneServer) {
-	// If the following call pancis, it indicates UnimplementedControlPlaneServer was
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&ControlPlane_ServiceDesc, srv)
+	s.RegisterService(&Admin_ServiceDesc, srv)
 }
 
-func _ControlPlane_DeployApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeployRequest)
+func _Admin_CreateTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTenantRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlPlaneServer).DeployApplication(ctx, in)
+		return srv.(AdminServer).CreateTenant(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControlPlane_DeployApplication_FullMethodName,
+		FullMethod: Admin_CreateTenant_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlPlaneServer).DeployApplication(ctx, req.(*DeployRequest))
+		return srv.(AdminServer).CreateTenant(ctx, req.(*CreateTenantRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControlPlane_DeleteApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRequest)
+func _Admin_ListTenants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTenantsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlPlaneServer).DeleteApplication(ctx, in)
+		return srv.(AdminServer).ListTenants(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControlPlane_DeleteApplication_FullMethodName,
+		FullMethod: Admin_ListTenants_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlPlaneServer).DeleteApplication(ctx, req.(*DeleteRequest))
+		return srv.(AdminServer).ListTenants(ctx, req.(*ListTenantsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControlPlane_GetApplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StatusRequest)
+func _Admin_RotateTenantKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateTenantKeysRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlPlaneServer).GetApplicationStatus(ctx, in)
+		return srv.(AdminServer).RotateTenantKeys(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControlPlane_GetApplicationStatus_FullMethodName,
+		FullMethod: Admin_RotateTenantKeys_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlPlaneServer).GetApplicationStatus(ctx, req.(*StatusRequest))
+		return srv.(AdminServer).RotateTenantKeys(ctx, req.(*RotateTenantKeysRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControlPlane_GetApplicationLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LogsRequest)
+func _Admin_BootstrapEdgeProxy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BootstrapEdgeProxyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlPlaneServer).GetApplicationLogs(ctx, in)
+		return srv.(AdminServer).BootstrapEdgeProxy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControlPlane_GetApplicationLogs_FullMethodName,
+		FullMethod: Admin_BootstrapEdgeProxy_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlPlaneServer).GetApplicationLogs(ctx, req.(*LogsRequest))
+		return srv.(AdminServer).BootstrapEdgeProxy(ctx, req.(*BootstrapEdgeProxyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControlPlane_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HealthCheckRequest)
+func _Admin_BootstrapPlatform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BootstrapPlatformRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlPlaneServer).HealthCheck(ctx, in)
+		return srv.(AdminServer).BootstrapPlatform(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControlPlane_HealthCheck_FullMethodName,
+		FullMethod: Admin_BootstrapPlatform_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlPlaneServer).HealthCheck(ctx, req.(*HealthCheckRequest))
+		return srv.(AdminServer).BootstrapPlatform(ctx, req.(*BootstrapPlatformRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// ControlPlane_ServiceDesc is the grpc.ServiceDesc for ControlPlane service.
+func _Admin_PromoteStandby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteStandbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).PromoteStandby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_PromoteStandby_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).PromoteStandby(ctx, req.(*PromoteStandbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetReplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReplicationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetReplicationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetReplicationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetReplicationStatus(ctx, req.(*GetReplicationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DeployController_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployControllerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DeployController(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_DeployController_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DeployController(ctx, req.(*DeployControllerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_IssueTenantNomadToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueTenantNomadTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).IssueTenantNomadToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_IssueTenantNomadToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).IssueTenantNomadToken(ctx, req.(*IssueTenantNomadTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var ControlPlane_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "controlplane.ControlPlane",
-	HandlerType: (*ControlPlaneServer)(nil),
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "controlplane.Admin",
+	HandlerType: (*AdminServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "DeployApplication",
-			Handler:    _ControlPlane_DeployApplication_Handler,
+			MethodName: "CreateTenant",
+			Handler:    _Admin_CreateTenant_Handler,
 		},
 		{
-			MethodName: "DeleteApplication",
-			Handler:    _ControlPlane_DeleteApplication_Handler,
+			MethodName: "ListTenants",
+			Handler:    _Admin_ListTenants_Handler,
 		},
 		{
-			MethodName: "GetApplicationStatus",
-			Handler:    _ControlPlane_GetApplicationStatus_Handler,
+			MethodName: "RotateTenantKeys",
+			Handler:    _Admin_RotateTenantKeys_Handler,
 		},
 		{
-			MethodName: "GetApplicationLogs",
-			Handler:    _ControlPlane_GetApplicationLogs_Handler,
+			MethodName: "BootstrapEdgeProxy",
+			Handler:    _Admin_BootstrapEdgeProxy_Handler,
 		},
 		{
-			MethodName: "HealthCheck",
-			Handler:    _ControlPlane_HealthCheck_Handler,
+			MethodName: "BootstrapPlatform",
+			Handler:    _Admin_BootstrapPlatform_Handler,
+		},
+		{
+			MethodName: "PromoteStandby",
+			Handler:    _Admin_PromoteStandby_Handler,
+		},
+		{
+			MethodName: "GetReplicationStatus",
+			Handler:    _Admin_GetReplicationStatus_Handler,
+		},
+		{
+			MethodName: "DeployController",
+			Handler:    _Admin_DeployController_Handler,
+		},
+		{
+			MethodName: "IssueTenantNomadToken",
+			Handler:    _Admin_IssueTenantNomadToken_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/controlplane.proto",
+}
+
+const (
+	DeployHook_PreValidate_FullMethodName = "/controlplane.DeployHook/PreValidate"
+	DeployHook_MutateJob_FullMethodName   = "/controlplane.DeployHook/MutateJob"
+	DeployHook_PostDeploy_FullMethodName  = "/controlplane.DeployHook/PostDeploy"
+)
+
+// DeployHookClient is the client API for DeployHook service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DeployHook is implemented by plugins, the controller calls the hooks a plugin is configured for
+type DeployHookClient interface {
+	PreValidate(ctx context.Context, in *PreValidateRequest, opts ...grpc.CallOption) (*PreValidateResponse, error)
+	MutateJob(ctx context.Context, in *MutateJobRequest, opts ...grpc.CallOption) (*MutateJobResponse, error)
+	PostDeploy(ctx context.Context, in *PostDeployRequest, opts ...grpc.CallOption) (*PostDeployResponse, error)
+}
+
+type deployHookClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDeployHookClient(cc grpc.ClientConnInterface) DeployHookClient {
+	return &deployHookClient{cc}
+}
+
+func (c *deployHookClient) PreValidate(ctx context.Context, in *PreValidateRequest, opts ...grpc.CallOption) (*PreValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreValidateResponse)
+	err := c.cc.Invoke(ctx, DeployHook_PreValidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deployHookClient) MutateJob(ctx context.Context, in *MutateJobRequest, opts ...grpc.CallOption) (*MutateJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MutateJobResponse)
+	err := c.cc.Invoke(ctx, DeployHook_MutateJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deployHookClient) PostDeploy(ctx context.Context, in *PostDeployRequest, opts ...grpc.CallOption) (*PostDeployResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostDeployResponse)
+	err := c.cc.Invoke(ctx, DeployHook_PostDeploy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeployHookServer is the server API for DeployHook service.
+// All implementations must embed UnimplementedDeployHookServer
+// for forward compatibility.
+//
+// DeployHook is implemented by plugins, the controller calls the hooks a plugin is configured for
+type DeployHookServer interface {
+	PreValidate(context.Context, *PreValidateRequest) (*PreValidateResponse, error)
+	MutateJob(context.Context, *MutateJobRequest) (*MutateJobResponse, error)
+	PostDeploy(context.Context, *PostDeployRequest) (*PostDeployResponse, error)
+	mustEmbedUnimplementedDeployHookServer()
+}
+
+// UnimplementedDeployHookServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDeployHookServer struct{}
+
+func (UnimplementedDeployHookServer) PreValidate(context.Context, *PreValidateRequest) (*PreValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreValidate not implemented")
+}
+func (UnimplementedDeployHookServer) MutateJob(context.Context, *MutateJobRequest) (*MutateJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MutateJob not implemented")
+}
+func (UnimplementedDeployHookServer) PostDeploy(context.Context, *PostDeployRequest) (*PostDeployResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostDeploy not implemented")
+}
+func (UnimplementedDeployHookServer) mustEmbedUnimplementedDeployHookServer() {}
+func (UnimplementedDeployHookServer) testEmbeddedByValue()                    {}
+
+// UnsafeDeployHookServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DeployHookServer will
+// result in compilation errors.
+type UnsafeDeployHookServer interface {
+	mustEmbedUnimplementedDeployHookServer()
+}
+
+func RegisterDeployHookServer(s grpc.ServiceRegistrar, srv DeployHookServer) {
+	// If the following call pancis, it indicates UnimplementedDeployHookServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DeployHook_ServiceDesc, srv)
+}
+
+func _DeployHook_PreValidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeployHookServer).PreValidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeployHook_PreValidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeployHookServer).PreValidate(ctx, req.(*PreValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeployHook_MutateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MutateJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeployHookServer).MutateJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeployHook_MutateJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeployHookServer).MutateJob(ctx, req.(*MutateJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeployHook_PostDeploy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostDeployRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeployHookServer).PostDeploy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeployHook_PostDeploy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeployHookServer).PostDeploy(ctx, req.(*PostDeployRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DeployHook_ServiceDesc is the grpc.ServiceDesc for DeployHook service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DeployHook_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "controlplane.DeployHook",
+	HandlerType: (*DeployHookServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PreValidate",
+			Handler:    _DeployHook_PreValidate_Handler,
+		},
+		{
+			MethodName: "MutateJob",
+			Handler:    _DeployHook_MutateJob_Handler,
+		},
+		{
+			MethodName: "PostDeploy",
+			Handler:    _DeployHook_PostDeploy_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -0,0 +1,8 @@
+package proto
+
+import _ "embed"
+
+// Source is controlplane.proto, its comments document the spec for `cli explain`
+//
+//go:embed controlplane.proto
+var Source string
@@ -0,0 +1,12 @@
+"""Python client of the control-plane gRPC API.
+
+    from controlplane_client import Client, Spec
+
+    with Client("cp.example.com:50051", token=os.environ["CP_TOKEN"]) as client:
+        client.deploy(Spec("web", "nginx:1.27").replicas(2).route("web.example.com", ssl=True))
+"""
+
+from controlplane_client.client import Client, ControlPlaneError
+from controlplane_client.spec import Spec
+
+__all__ = ["Client", "ControlPlaneError", "Spec"]
@@ -0,0 +1,172 @@
+"""Connection handling of the client: authentication, retries and deadlines."""
+
+import collections
+import json
+
+import grpc
+
+import controlplane_pb2 as pb
+import controlplane_pb2_grpc as pb_grpc
+
+# chunks of 1MB stay well below the default 4MB gRPC message limit
+SPEC_CHUNK_SIZE = 1 << 20
+
+
+class ControlPlaneError(Exception):
+    """A request the control plane processed and rejected, the response is kept for details."""
+
+    def __init__(self, message, response=None):
+        super().__init__(message)
+        self.response = response
+
+
+class _CallDetails(
+    collections.namedtuple(
+        "_CallDetails", ("method", "timeout", "metadata", "credentials", "wait_for_ready", "compression")
+    ),
+    grpc.ClientCallDetails,
+):
+    pass
+
+
+class _TokenInterceptor(
+    grpc.UnaryUnaryClientInterceptor, grpc.UnaryStreamClientInterceptor, grpc.StreamUnaryClientInterceptor
+):
+    """Sends the service account token with every call."""
+
+    def __init__(self, token):
+        self._metadata = ("authorization", f"Bearer {token}")
+
+    def _details(self, details):
+        return _CallDetails(
+            details.method,
+            details.timeout,
+            list(details.metadata or []) + [self._metadata],
+            details.credentials,
+            details.wait_for_ready,
+            details.compression,
+        )
+
+    def intercept_unary_unary(self, continuation, details, request):
+        return continuation(self._details(details), request)
+
+    def intercept_unary_stream(self, continuation, details, request):
+        return continuation(self._details(details), request)
+
+    def intercept_stream_unary(self, continuation, details, request_iterator):
+        return continuation(self._details(details), request_iterator)
+
+
+def _service_config(max_attempts):
+    # UNAVAILABLE means the controller did not take the request, e.g. during a failover
+    return json.dumps(
+        {
+            "methodConfig": [
+                {
+                    "name": [{"service": "controlplane.ControlPlane"}, {"service": "controlplane.Admin"}],
+                    "retryPolicy": {
+                        "maxAttempts": max_attempts,
+                        "initialBackoff": "0.2s",
+                        "maxBackoff": "5s",
+                        "backoffMultiplier": 2,
+                        "retryableStatusCodes": ["UNAVAILABLE"],
+                    },
+                }
+            ]
+        }
+    )
+
+
+class Client:
+    """Client of the ControlPlane and Admin services.
+
+    The helpers raise ControlPlaneError when the control plane rejects a request,
+    every other RPC is available on the `control_plane` and `admin` stubs.
+    """
+
+    def __init__(
+        self,
+        address="localhost:50051",
+        token=None,
+        tls=False,
+        root_certificates=None,
+        max_attempts=4,
+        timeout=30.0,
+    ):
+        # gRPC caps the attempts at 5
+        options = [
+            ("grpc.enable_retries", 1),
+            ("grpc.service_config", _service_config(min(max(max_attempts, 2), 5))),
+        ]
+        if tls:
+            channel = grpc.secure_channel(address, grpc.ssl_channel_credentials(root_certificates), options)
+        else:
+            channel = grpc.insecure_channel(address, options)
+
+        self._channel = channel
+        if token:
+            channel = grpc.intercept_channel(channel, _TokenInterceptor(token))
+
+        self.timeout = timeout
+        self.control_plane = pb_grpc.ControlPlaneStub(channel)
+        self.admin = pb_grpc.AdminStub(channel)
+
+    def close(self):
+        self._channel.close()
+
+    def __enter__(self):
+        return self
+
+    def __exit__(self, *exc):
+        self.close()
+
+    def deploy(self, spec):
+        """Deploys a Spec or DeployRequest, specs above 4MB are uploaded in chunks."""
+        request = _request(spec)
+        if request.ByteSize() > SPEC_CHUNK_SIZE * 4:
+            return self.apply_spec(request)
+
+        resp = self.control_plane.DeployApplication(request, timeout=self.timeout)
+        if resp.status == "FAILED":
+            raise ControlPlaneError(resp.message, resp)
+        return resp
+
+    def apply_spec(self, spec):
+        """Deploys a spec through ApplySpec, for specs exceeding the gRPC message limit."""
+        data = _request(spec).SerializeToString()
+
+        def chunks():
+            yield pb.SpecChunk(data=data[:SPEC_CHUNK_SIZE], total_size=len(data))
+            for offset in range(SPEC_CHUNK_SIZE, len(data), SPEC_CHUNK_SIZE):
+                yield pb.SpecChunk(data=data[offset : offset + SPEC_CHUNK_SIZE])
+
+        resp = self.control_plane.ApplySpec(chunks(), timeout=self.timeout)
+        if resp.status == "FAILED":
+            raise ControlPlaneError(resp.message, resp)
+        return resp
+
+    def delete(self, name):
+        resp = self.control_plane.DeleteApplication(pb.DeleteRequest(deployment_id=name), timeout=self.timeout)
+        if not resp.success:
+            raise ControlPlaneError(resp.message, resp)
+        return resp
+
+    def status(self, name):
+        return self.control_plane.GetApplicationStatus(pb.StatusRequest(deployment_id=name), timeout=self.timeout)
+
+    def scale(self, name, count, task_group=""):
+        resp = self.control_plane.ScaleApplication(
+            pb.ScaleRequest(deployment_id=name, count=count, task_group=task_group), timeout=self.timeout
+        )
+        if not resp.success:
+            raise ControlPlaneError(resp.message, resp)
+        return resp
+
+    def health(self):
+        return self.control_plane.HealthCheck(pb.HealthCheckRequest(service="control-plane"), timeout=self.timeout)
+
+
+def _request(spec):
+    if isinstance(spec, pb.DeployRequest):
+        return spec
+    return spec.build()
@@ -0,0 +1,94 @@
+"""Typed builder of deployment specs."""
+
+import controlplane_pb2 as pb
+
+
+class Spec:
+    """Builds a DeployRequest with the defaults of the CLI.
+
+    Spec("web", "nginx:1.27").replicas(2).resources(cpu=0.5, memory=256).route("web.example.com", ssl=True)
+    """
+
+    def __init__(self, name, image):
+        self._request = pb.DeployRequest(name=name, image=image, replicas=1, cpu=0.1, memory=128)
+
+    def replicas(self, count):
+        self._request.replicas = count
+        return self
+
+    def resources(self, cpu=None, memory=None):
+        """CPU in cores, memory in MB."""
+        if cpu is not None:
+            self._request.cpu = cpu
+        if memory is not None:
+            self._request.memory = memory
+        return self
+
+    def region(self, region):
+        self._request.region = region
+        return self
+
+    def bridge(self):
+        self._request.network_mode = pb.NETWORK_MODE_BRIDGE
+        return self
+
+    def env(self, variables=None, **kwargs):
+        self._request.labels.update(variables or {}, **kwargs)
+        return self
+
+    def route(self, host, ssl=False, path_prefix="", entrypoint="websecure", middlewares=(), health_check_path="/"):
+        """Routes the host through Traefik."""
+        self._request.traefik.CopyFrom(
+            pb.TraefikConfig(
+                enable=True,
+                host=host,
+                entrypoint=entrypoint,
+                enable_ssl=ssl,
+                path_prefix=path_prefix,
+                middlewares=list(middlewares),
+                health_check_path=health_check_path,
+                health_check_interval="30s",
+            )
+        )
+        return self
+
+    def constraint(self, attribute, value="", operator="="):
+        self._request.constraints.append(pb.Constraint(attribute=attribute, operator=operator, value=value))
+        return self
+
+    def disk(self, size_mb=0, sticky=False, migrate=False):
+        self._request.ephemeral_disk.CopyFrom(pb.EphemeralDisk(size_mb=size_mb, sticky=sticky, migrate=migrate))
+        return self
+
+    def idle_timeout(self, minutes):
+        """Scales to zero without traffic, requires a route."""
+        self._request.idle_timeout_minutes = minutes
+        return self
+
+    def function(self, max_concurrency=0, timeout_seconds=0, artifact="", meta_keys=()):
+        self._request.type = pb.DEPLOYMENT_TYPE_FUNCTION
+        self._request.function.CopyFrom(
+            pb.FunctionConfig(
+                max_concurrency=max_concurrency,
+                timeout_seconds=timeout_seconds,
+                artifact=artifact,
+                meta_keys=list(meta_keys),
+            )
+        )
+        return self
+
+    def cron(self, schedule, time_zone="", prohibit_overlap=False):
+        self._request.type = pb.DEPLOYMENT_TYPE_CRON
+        self._request.cron.CopyFrom(
+            pb.CronConfig(schedule=schedule, time_zone=time_zone, prohibit_overlap=prohibit_overlap)
+        )
+        return self
+
+    def depends_on(self, *applications):
+        self._request.depends_on.extend(applications)
+        return self
+
+    def build(self):
+        request = pb.DeployRequest()
+        request.CopyFrom(self._request)
+        return request
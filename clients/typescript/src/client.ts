@@ -0,0 +1,146 @@
+// Connection handling of the client: authentication, retries and deadlines.
+
+import { toBinary, type MessageInitShape } from "@bufbuild/protobuf";
+import { Code, ConnectError, createClient, type Client, type Interceptor } from "@connectrpc/connect";
+import { createGrpcTransport } from "@connectrpc/connect-node";
+
+import {
+  Admin,
+  ControlPlane,
+  DeployRequestSchema,
+  SpecChunkSchema,
+  type DeleteResponse,
+  type DeployRequest,
+  type DeployResponse,
+  type HealthCheckResponse,
+  type ScaleResponse,
+  type StatusResponse,
+} from "./gen/controlplane_pb.js";
+import { SpecBuilder } from "./spec.js";
+
+// chunks of 1MB stay well below the default 4MB gRPC message limit
+const specChunkSize = 1 << 20;
+
+export interface ClientOptions {
+  address?: string; // defaults to http://localhost:50051, https:// enables TLS
+  token?: string; // service account token
+  maxAttempts?: number; // of unary calls the controller did not take, defaults to 4
+  timeoutMs?: number; // deadline of every call, defaults to 30s
+}
+
+// A request the control plane processed and rejected, the response is kept for details.
+export class ControlPlaneError extends Error {
+  constructor(
+    message: string,
+    readonly response?: unknown,
+  ) {
+    super(message);
+    this.name = "ControlPlaneError";
+  }
+}
+
+function tokenInterceptor(token: string): Interceptor {
+  return (next) => async (req) => {
+    req.header.set("authorization", `Bearer ${token}`);
+    return next(req);
+  };
+}
+
+// UNAVAILABLE means the controller did not take the request, e.g. during a failover.
+// Streams are not retried, their messages cannot be sent again.
+function retryInterceptor(maxAttempts: number): Interceptor {
+  return (next) => async (req) => {
+    for (let attempt = 1; ; attempt++) {
+      try {
+        return await next(req);
+      } catch (err) {
+        if (req.stream || attempt >= maxAttempts || ConnectError.from(err).code !== Code.Unavailable) {
+          throw err;
+        }
+        await new Promise((resolve) => setTimeout(resolve, Math.min(200 * 2 ** (attempt - 1), 5000)));
+      }
+    }
+  };
+}
+
+// Client of the ControlPlane and Admin services. The helpers throw ControlPlaneError
+// when the control plane rejects a request, every other RPC is available on
+// `controlPlane` and `admin`.
+export class ControlPlaneClient {
+  readonly controlPlane: Client<typeof ControlPlane>;
+  readonly admin: Client<typeof Admin>;
+
+  constructor(options: ClientOptions = {}) {
+    const interceptors = [retryInterceptor(options.maxAttempts ?? 4)];
+    if (options.token) {
+      interceptors.push(tokenInterceptor(options.token));
+    }
+
+    const transport = createGrpcTransport({
+      baseUrl: options.address ?? "http://localhost:50051",
+      defaultTimeoutMs: options.timeoutMs ?? 30_000,
+      interceptors,
+    });
+
+    this.controlPlane = createClient(ControlPlane, transport);
+    this.admin = createClient(Admin, transport);
+  }
+
+  // Deploys a spec, specs above 4MB are uploaded in chunks.
+  async deploy(spec: DeployRequest | SpecBuilder): Promise<DeployResponse> {
+    const request = spec instanceof SpecBuilder ? spec.build() : spec;
+    const data = toBinary(DeployRequestSchema, request);
+    if (data.length > specChunkSize * 4) {
+      return this.applySpec(request);
+    }
+
+    const resp = await this.controlPlane.deployApplication(request);
+    if (resp.status === "FAILED") {
+      throw new ControlPlaneError(resp.message, resp);
+    }
+    return resp;
+  }
+
+  // Deploys a spec through ApplySpec, for specs exceeding the gRPC message limit.
+  async applySpec(spec: DeployRequest | SpecBuilder): Promise<DeployResponse> {
+    const request = spec instanceof SpecBuilder ? spec.build() : spec;
+    const data = toBinary(DeployRequestSchema, request);
+
+    async function* chunks(): AsyncIterable<MessageInitShape<typeof SpecChunkSchema>> {
+      yield { data: data.subarray(0, specChunkSize), totalSize: BigInt(data.length) };
+      for (let offset = specChunkSize; offset < data.length; offset += specChunkSize) {
+        yield { data: data.subarray(offset, offset + specChunkSize) };
+      }
+    }
+
+    const resp = await this.controlPlane.applySpec(chunks());
+    if (resp.status === "FAILED") {
+      throw new ControlPlaneError(resp.message, resp);
+    }
+    return resp;
+  }
+
+  async delete(name: string): Promise<DeleteResponse> {
+    const resp = await this.controlPlane.deleteApplication({ deploymentId: name });
+    if (!resp.success) {
+      throw new ControlPlaneError(resp.message, resp);
+    }
+    return resp;
+  }
+
+  async status(name: string): Promise<StatusResponse> {
+    return this.controlPlane.getApplicationStatus({ deploymentId: name });
+  }
+
+  async scale(name: string, count: number, taskGroup = ""): Promise<ScaleResponse> {
+    const resp = await this.controlPlane.scaleApplication({ deploymentId: name, count, taskGroup });
+    if (!resp.success) {
+      throw new ControlPlaneError(resp.message, resp);
+    }
+    return resp;
+  }
+
+  async health(): Promise<HealthCheckResponse> {
+    return this.controlPlane.healthCheck({ service: "control-plane" });
+  }
+}
@@ -0,0 +1,8 @@
+// TypeScript client of the control-plane gRPC API.
+//
+//   const client = new ControlPlaneClient({ address: "http://cp.example.com:50051", token: process.env.CP_TOKEN });
+//   await client.deploy(new SpecBuilder("web", "nginx:1.27").replicas(2).route("web.example.com", { ssl: true }));
+
+export { ControlPlaneClient, ControlPlaneError, type ClientOptions } from "./client.js";
+export { SpecBuilder, type RouteOptions } from "./spec.js";
+export * from "./gen/controlplane_pb.js";
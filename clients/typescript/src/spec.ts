@@ -0,0 +1,119 @@
+// Typed builder of deployment specs.
+
+import { clone, create } from "@bufbuild/protobuf";
+
+import {
+  ConstraintSchema,
+  CronConfigSchema,
+  DeployRequestSchema,
+  DeploymentType,
+  EphemeralDiskSchema,
+  FunctionConfigSchema,
+  NetworkMode,
+  TraefikConfigSchema,
+  type DeployRequest,
+} from "./gen/controlplane_pb.js";
+
+export interface RouteOptions {
+  ssl?: boolean;
+  pathPrefix?: string;
+  entrypoint?: string; // defaults to websecure
+  middlewares?: string[];
+  healthCheckPath?: string; // defaults to /
+}
+
+// Builds a DeployRequest with the defaults of the CLI.
+//
+//   new SpecBuilder("web", "nginx:1.27").replicas(2).resources({ cpu: 0.5, memory: 256 }).route("web.example.com")
+export class SpecBuilder {
+  private readonly spec: DeployRequest;
+
+  constructor(name: string, image: string) {
+    this.spec = create(DeployRequestSchema, { name, image, replicas: 1, cpu: 0.1, memory: 128n });
+  }
+
+  replicas(count: number): this {
+    this.spec.replicas = count;
+    return this;
+  }
+
+  // CPU in cores, memory in MB.
+  resources({ cpu, memory }: { cpu?: number; memory?: number }): this {
+    if (cpu !== undefined) {
+      this.spec.cpu = cpu;
+    }
+    if (memory !== undefined) {
+      this.spec.memory = BigInt(memory);
+    }
+    return this;
+  }
+
+  region(region: string): this {
+    this.spec.region = region;
+    return this;
+  }
+
+  bridge(): this {
+    this.spec.networkMode = NetworkMode.BRIDGE;
+    return this;
+  }
+
+  env(variables: Record<string, string>): this {
+    Object.assign(this.spec.labels, variables);
+    return this;
+  }
+
+  // Routes the host through Traefik.
+  route(host: string, options: RouteOptions = {}): this {
+    this.spec.traefik = create(TraefikConfigSchema, {
+      enable: true,
+      host,
+      entrypoint: options.entrypoint ?? "websecure",
+      enableSsl: options.ssl ?? false,
+      pathPrefix: options.pathPrefix ?? "",
+      middlewares: options.middlewares ?? [],
+      healthCheckPath: options.healthCheckPath ?? "/",
+      healthCheckInterval: "30s",
+    });
+    return this;
+  }
+
+  constraint(attribute: string, value = "", operator = "="): this {
+    this.spec.constraints.push(create(ConstraintSchema, { attribute, operator, value }));
+    return this;
+  }
+
+  disk({ sizeMb = 0, sticky = false, migrate = false }: { sizeMb?: number; sticky?: boolean; migrate?: boolean }): this {
+    this.spec.ephemeralDisk = create(EphemeralDiskSchema, { sizeMb, sticky, migrate });
+    return this;
+  }
+
+  // Scales to zero without traffic, requires a route.
+  idleTimeout(minutes: number): this {
+    this.spec.idleTimeoutMinutes = minutes;
+    return this;
+  }
+
+  function(
+    options: { maxConcurrency?: number; timeoutSeconds?: number; artifact?: string; metaKeys?: string[] } = {},
+  ): this {
+    this.spec.type = DeploymentType.FUNCTION;
+    this.spec.function = create(FunctionConfigSchema, options);
+    return this;
+  }
+
+  cron(schedule: string, { timeZone = "", prohibitOverlap = false }: { timeZone?: string; prohibitOverlap?: boolean } = {}): this {
+    this.spec.type = DeploymentType.CRON;
+    this.spec.cron = create(CronConfigSchema, { schedule, timeZone, prohibitOverlap });
+    return this;
+  }
+
+  dependsOn(...applications: string[]): this {
+    this.spec.dependsOn.push(...applications);
+    return this;
+  }
+
+  build(): DeployRequest {
+    return clone(DeployRequestSchema, this.spec);
+  }
+}
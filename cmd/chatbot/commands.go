@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// command runs a chat command against the control plane and returns the reply
+type command struct {
+	usage string
+	run   func(ctx context.Context, client pb.ControlPlaneClient, args []string) (string, error)
+}
+
+const deployUsage = "deploy <application> <image> [replicas=N] [cpu=cores] [memory=MB] [host=name] [ssl=true] [network=bridge]"
+
+var commands = map[string]command{
+	"status":   {usage: "status <application>", run: statusCommand},
+	"deploy":   {usage: deployUsage, run: deployCommand},
+	"rollback": {usage: "rollback <application> [version]", run: rollbackCommand},
+}
+
+// Bot dispatches the commands of every chat platform
+type Bot struct {
+	client pb.ControlPlaneClient
+	rbac   *RBAC
+}
+
+// Handle runs the text of a slash command, e.g. "deploy web nginx:1.27", sent in a channel
+func (b *Bot) Handle(ctx context.Context, channel, user, text string) string {
+	args := strings.Fields(text)
+	if len(args) == 0 || args[0] == "help" {
+		return usage()
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Sprintf("Unknown command `%s`\n%s", args[0], usage())
+	}
+	if len(args) < 2 {
+		return fmt.Sprintf("Usage: `%s`", cmd.usage)
+	}
+
+	if err := b.rbac.Allow(channel, args[0], args[1]); err != nil {
+		log.Printf("Chatbot: denied %q of %s in %s: %v", text, user, channel, err)
+		return err.Error()
+	}
+
+	log.Printf("Chatbot: %s runs %q in %s", user, text, channel)
+	reply, err := cmd.run(ctx, b.client, args[1:])
+	if err != nil {
+		return fmt.Sprintf(":x: %v", err)
+	}
+
+	return reply
+}
+
+func usage() string {
+	return "Commands:\n" +
+		"• `" + commands["status"].usage + "`\n" +
+		"• `" + commands["deploy"].usage + "`\n" +
+		"• `" + commands["rollback"].usage + "`"
+}
+
+func statusCommand(ctx context.Context, client pb.ControlPlaneClient, args []string) (string, error) {
+	resp, err := client.GetApplicationStatus(ctx, &pb.StatusRequest{DeploymentId: args[0]})
+	if err != nil {
+		return "", fmt.Errorf("failed to get application status: %v", err)
+	}
+	if resp.JobStatus == "" {
+		return "", fmt.Errorf("%s", resp.Message)
+	}
+
+	var reply strings.Builder
+	fmt.Fprintf(&reply, "*%s* is %s: %d/%d running, %d healthy, %d failed",
+		resp.DeploymentId, resp.JobStatus, resp.RunningInstances, resp.DesiredInstances, resp.HealthyInstances, resp.FailedInstances)
+	if rollout := resp.Rollout; rollout != nil {
+		fmt.Fprintf(&reply, "\nRollout: %s %.0f%% (%d/%d healthy)", rollout.Status, rollout.Percent, rollout.HealthyInstances, rollout.DesiredInstances)
+	}
+
+	return reply.String(), nil
+}
+
+// deployCommand deploys with the defaults of the CLI, options override them
+func deployCommand(ctx context.Context, client pb.ControlPlaneClient, args []string) (string, error) {
+	if len(args) < 2 {
+		return "", fmt.Errorf("usage: `%s`", deployUsage)
+	}
+
+	req := &pb.DeployRequest{
+		Name:     args[0],
+		Image:    args[1],
+		Replicas: 1,
+		Cpu:      0.1,
+		Memory:   128,
+		Region:   "global",
+	}
+
+	for _, option := range args[2:] {
+		key, value, _ := strings.Cut(option, "=")
+		var err error
+		switch key {
+		case "replicas":
+			var replicas int
+			replicas, err = strconv.Atoi(value)
+			req.Replicas = int32(replicas)
+		case "cpu":
+			req.Cpu, err = strconv.ParseFloat(value, 64)
+		case "memory":
+			req.Memory, err = strconv.ParseInt(value, 10, 64)
+		case "host":
+			req.Traefik = &pb.TraefikConfig{
+				Enable:              true,
+				Host:                value,
+				Entrypoint:          "websecure",
+				HealthCheckPath:     "/",
+				HealthCheckInterval: "30s",
+			}
+		case "ssl":
+			if req.Traefik == nil {
+				return "", fmt.Errorf("ssl requires host")
+			}
+			req.Traefik.EnableSsl, err = strconv.ParseBool(value)
+		case "network":
+			switch value {
+			case "host":
+				req.NetworkMode = pb.NetworkMode_NETWORK_MODE_HOST
+			case "bridge":
+				req.NetworkMode = pb.NetworkMode_NETWORK_MODE_BRIDGE
+			default:
+				err = fmt.Errorf("must be host or bridge")
+			}
+		default:
+			return "", fmt.Errorf("unknown option %q", key)
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid %s: %v", key, err)
+		}
+	}
+
+	resp, err := client.DeployApplication(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("deployment failed: %v", err)
+	}
+	if resp.Status == "FAILED" {
+		return "", fmt.Errorf("deployment failed: %s", resp.Message)
+	}
+
+	return fmt.Sprintf(":rocket: Deploying *%s* with `%s` (evaluation %s)", req.Name, req.Image, resp.DeploymentId), nil
+}
+
+func rollbackCommand(ctx context.Context, client pb.ControlPlaneClient, args []string) (string, error) {
+	req := &pb.RollbackRequest{DeploymentId: args[0]}
+	if len(args) > 1 {
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid version %q", args[1])
+		}
+		req.Version = version
+	}
+
+	resp, err := client.RollbackApplication(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("rollback failed: %v", err)
+	}
+	if !resp.Success {
+		return "", fmt.Errorf("%s", resp.Message)
+	}
+
+	return fmt.Sprintf(":rewind: Rolled back *%s* to version %d (evaluation %s)", args[0], resp.Version, resp.EvalId), nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const discordAPI = "https://discord.com/api/v10"
+
+// Discord interaction and response types
+const (
+	discordPing                    = 1
+	discordApplicationCommand      = 2
+	discordPong                    = 1
+	discordDeferredChannelResponse = 5
+)
+
+type discordInteraction struct {
+	Type          int    `json:"type"`
+	ApplicationID string `json:"application_id"`
+	Token         string `json:"token"`
+	ChannelID     string `json:"channel_id"`
+	Member        *struct {
+		User discordUser `json:"user"`
+	} `json:"member"`
+	User *discordUser `json:"user"`
+	Data struct {
+		Options []discordOption `json:"options"`
+	} `json:"data"`
+}
+
+type discordUser struct {
+	Username string `json:"username"`
+}
+
+type discordOption struct {
+	Name    string          `json:"name"`
+	Value   any             `json:"value"`
+	Options []discordOption `json:"options"`
+}
+
+// discordHandler serves the interactions of the /cp command, whose subcommands are the
+// chat commands. The result is sent as a follow-up of a deferred response.
+func discordHandler(bot *Bot, publicKey ed25519.PublicKey, timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, 64<<10))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		signature, err := hex.DecodeString(r.Header.Get("X-Signature-Ed25519"))
+		message := append([]byte(r.Header.Get("X-Signature-Timestamp")), body...)
+		if err != nil || !ed25519.Verify(publicKey, message, signature) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		var interaction discordInteraction
+		if err := json.Unmarshal(body, &interaction); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		switch interaction.Type {
+		case discordPing:
+			writeJSON(w, map[string]int{"type": discordPong})
+		case discordApplicationCommand:
+			text := discordCommandText(interaction.Data.Options)
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), timeout)
+				defer cancel()
+
+				reply := bot.Handle(ctx, interaction.ChannelID, interaction.username(), text)
+				editDiscordResponse(interaction.ApplicationID, interaction.Token, reply)
+			}()
+
+			writeJSON(w, map[string]int{"type": discordDeferredChannelResponse})
+		default:
+			http.Error(w, "unsupported interaction", http.StatusBadRequest)
+		}
+	}
+}
+
+func (i *discordInteraction) username() string {
+	if i.Member != nil {
+		return i.Member.User.Username
+	}
+	if i.User != nil {
+		return i.User.Username
+	}
+	return ""
+}
+
+// discordCommandText turns a subcommand and its options into the text of a command,
+// the application, image and version options come first
+func discordCommandText(options []discordOption) string {
+	if len(options) == 0 {
+		return "help"
+	}
+
+	subcommand := options[0]
+	args := []string{subcommand.Name}
+	positional := []string{"application", "image", "version"}
+	for _, name := range positional {
+		for _, option := range subcommand.Options {
+			if option.Name == name {
+				args = append(args, fmt.Sprint(option.Value))
+			}
+		}
+	}
+	for _, option := range subcommand.Options {
+		if !slices.Contains(positional, option.Name) {
+			args = append(args, fmt.Sprintf("%s=%v", option.Name, option.Value))
+		}
+	}
+
+	return strings.Join(args, " ")
+}
+
+func editDiscordResponse(applicationID, token, content string) {
+	body, _ := json.Marshal(map[string]string{"content": content})
+
+	endpoint := fmt.Sprintf("%s/webhooks/%s/%s/messages/@original", discordAPI, applicationID, token)
+	req, err := http.NewRequest(http.MethodPatch, endpoint, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("Chatbot: failed to reply on Discord: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Printf("Chatbot: failed to reply on Discord: %v", err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("Chatbot: failed to reply on Discord: %s", resp.Status)
+	}
+}
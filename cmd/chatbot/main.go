@@ -0,0 +1,101 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/api"
+)
+
+var (
+	server           = flag.String("server", "localhost:50051", "gRPC server address")
+	useTLS           = flag.Bool("tls", false, "Connect to the controller over TLS, implied by -ca and -cert")
+	caFile           = flag.String("ca", "", "PEM CA bundle verifying the controller's certificate (default: the system roots)")
+	certFile         = flag.String("cert", "", "PEM client certificate for controllers requiring mutual TLS")
+	keyFile          = flag.String("key", "", "PEM key of the client certificate")
+	apiToken         = flag.String("token", os.Getenv("CONTROLPLANE_TOKEN"), "API token of the controller (env: CONTROLPLANE_TOKEN)")
+	listenAddress    = flag.String("listen", ":8090", "Listen address of the Slack and Discord endpoints")
+	rbacFile         = flag.String("rbac", "", "JSON file mapping channel IDs to the commands and applications allowed in them")
+	slackSecret      = flag.String("slack-signing-secret", os.Getenv("SLACK_SIGNING_SECRET"), "Signing secret of the Slack app, enables /slack/commands")
+	discordPublicKey = flag.String("discord-public-key", os.Getenv("DISCORD_PUBLIC_KEY"), "Public key of the Discord application, enables /discord/interactions")
+	commandTimeout   = flag.Duration("timeout", 30*time.Second, "How long a command may take")
+)
+
+func main() {
+	flag.Parse()
+
+	if *rbacFile == "" {
+		log.Fatalf("-rbac must be provided, channels without a policy cannot run commands")
+	}
+	if *slackSecret == "" && *discordPublicKey == "" {
+		log.Fatalf("-slack-signing-secret or -discord-public-key must be provided")
+	}
+
+	rbac, err := loadRBAC(*rbacFile)
+	if err != nil {
+		log.Fatalf("Failed to load RBAC: %v", err)
+	}
+
+	creds := insecure.NewCredentials()
+	if *useTLS || *caFile != "" || *certFile != "" || *keyFile != "" {
+		if creds, err = api.ClientCredentials(*caFile, *certFile, *keyFile); err != nil {
+			log.Fatalf("Invalid TLS flags: %v", err)
+		}
+	}
+	options := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if *apiToken != "" {
+		options = append(options, grpc.WithPerRPCCredentials(api.TokenCredentials(*apiToken)))
+	}
+	conn, err := grpc.NewClient(*server, options...)
+	if err != nil {
+		log.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	bot := &Bot{
+		client: pb.NewControlPlaneClient(conn),
+		rbac:   rbac,
+	}
+
+	mux := http.NewServeMux()
+	if *slackSecret != "" {
+		mux.Handle("POST /slack/commands", slackHandler(bot, *slackSecret, *commandTimeout))
+	}
+	if *discordPublicKey != "" {
+		publicKey, err := hex.DecodeString(*discordPublicKey)
+		if err != nil || len(publicKey) != ed25519.PublicKeySize {
+			log.Fatalf("Invalid Discord public key")
+		}
+		mux.Handle("POST /discord/interactions", discordHandler(bot, ed25519.PublicKey(publicKey), *commandTimeout))
+	}
+
+	httpServer := &http.Server{
+		Addr:              *listenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		log.Printf("Starting chatbot on %s", *listenAddress)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Chatbot error: %v", err)
+		}
+	}()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+
+	log.Println("Shutting down...")
+	_ = httpServer.Close()
+}
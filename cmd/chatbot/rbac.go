@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+)
+
+// ChannelPolicy is what the members of a chat channel may run
+type ChannelPolicy struct {
+	Commands     []string `json:"commands"`     // status, deploy, rollback
+	Applications []string `json:"applications"` // name patterns, e.g. "payments-*", default: all
+}
+
+// RBAC maps Slack and Discord channel IDs to their policy, channels without a policy
+// cannot run any command
+type RBAC struct {
+	Channels map[string]ChannelPolicy `json:"channels"`
+}
+
+func loadRBAC(file string) (*RBAC, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	rbac := &RBAC{}
+	if err := json.Unmarshal(data, rbac); err != nil {
+		return nil, fmt.Errorf("invalid RBAC file %s: %w", file, err)
+	}
+
+	for channel, policy := range rbac.Channels {
+		for _, command := range policy.Commands {
+			if _, ok := commands[command]; !ok {
+				return nil, fmt.Errorf("channel %s: unknown command %q", channel, command)
+			}
+		}
+		for _, pattern := range policy.Applications {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("channel %s: invalid application pattern %q", channel, pattern)
+			}
+		}
+	}
+
+	return rbac, nil
+}
+
+// Allow checks whether a channel may run the command against the application
+func (r *RBAC) Allow(channel, command, application string) error {
+	policy, ok := r.Channels[channel]
+	if !ok || !slices.Contains(policy.Commands, command) {
+		return fmt.Errorf("`%s` is not allowed in this channel", command)
+	}
+
+	if len(policy.Applications) == 0 {
+		return nil
+	}
+	for _, pattern := range policy.Applications {
+		if matched, _ := path.Match(pattern, application); matched {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("`%s` of %s is not allowed in this channel", command, application)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Slack rejects requests older than this to prevent replays
+const slackMaxAge = 5 * time.Minute
+
+// slackHandler serves Slack slash commands. Slack waits 3 seconds for the reply, so the
+// command is acknowledged right away and its result is posted to the response URL.
+func slackHandler(bot *Bot, signingSecret string, timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, 64<<10))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !verifySlack(signingSecret, r.Header, body) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		text := form.Get("text")
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			reply := bot.Handle(ctx, form.Get("channel_id"), form.Get("user_name"), text)
+			postSlack(form.Get("response_url"), reply)
+		}()
+
+		writeJSON(w, map[string]string{
+			"response_type": "ephemeral",
+			"text":          "Running `" + form.Get("command") + " " + text + "`...",
+		})
+	}
+}
+
+// verifySlack checks the signature Slack computes with the app's signing secret
+func verifySlack(signingSecret string, header http.Header, body []byte) bool {
+	timestamp, err := strconv.ParseInt(header.Get("X-Slack-Request-Timestamp"), 10, 64)
+	if err != nil || time.Since(time.Unix(timestamp, 0)).Abs() > slackMaxAge {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + strconv.FormatInt(timestamp, 10) + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature")))
+}
+
+func postSlack(responseURL, text string) {
+	body, _ := json.Marshal(map[string]string{
+		"response_type": "in_channel",
+		"text":          text,
+	})
+
+	resp, err := http.Post(responseURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("Chatbot: failed to reply on Slack: %v", err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("Chatbot: failed to reply on Slack: %s", resp.Status)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
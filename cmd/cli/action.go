@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// parseTaskAction parses an action as <name>=<command> [args...], e.g.
+// 'flush-cache=/app/bin/cache flush --all'
+func parseTaskAction(expr string) (*pb.Action, error) {
+	name, command, _ := strings.Cut(expr, "=")
+	fields := strings.Fields(command)
+	if name == "" || len(fields) == 0 {
+		return nil, fmt.Errorf("invalid action %q: expected <name>=<command> [args...]", expr)
+	}
+	return &pb.Action{Name: name, Command: fields[0], Args: fields[1:]}, nil
+}
+
+func runAction(client pb.ControlPlaneClient, name, action, allocID, task string, timeout int) {
+	if name == "" || action == "" {
+		log.Fatalf("-name and -run must be provided for run action")
+	}
+
+	wait := 5 * time.Minute
+	if timeout > 0 {
+		wait = time.Duration(timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), wait+30*time.Second)
+	defer cancel()
+
+	resp, err := client.RunAction(ctx, &pb.RunActionRequest{
+		DeploymentId:   name,
+		Action:         action,
+		AllocationId:   allocID,
+		TaskName:       task,
+		TimeoutSeconds: int32(timeout),
+	})
+	if err != nil {
+		log.Fatalf("Failed to run action: %v", err)
+	}
+
+	fmt.Print(resp.Stdout)
+	fmt.Fprint(os.Stderr, resp.Stderr)
+	if resp.AllocationId == "" {
+		log.Fatalf("%s", resp.Message)
+	}
+	fmt.Fprintln(os.Stderr, resp.Message)
+	if !resp.Success {
+		os.Exit(max(int(resp.ExitCode), 1))
+	}
+}
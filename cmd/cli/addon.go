@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// parseAddOn parses an -addon flag, "<name>=<type>[:<version>][@<volume id>]", e.g.
+// "db=postgres:16@pg-data"
+func parseAddOn(expr string) (*pb.AddOn, error) {
+	name, spec, ok := strings.Cut(expr, "=")
+	if !ok || name == "" || spec == "" {
+		return nil, fmt.Errorf("invalid add-on %q, expected <name>=<type>[:<version>][@<volume id>]", expr)
+	}
+
+	addOn := &pb.AddOn{Name: name}
+	spec, addOn.VolumeId, _ = strings.Cut(spec, "@")
+	addOn.Type, addOn.Version, _ = strings.Cut(spec, ":")
+	if addOn.Type == "" {
+		return nil, fmt.Errorf("invalid add-on %q: type cannot be empty", expr)
+	}
+	return addOn, nil
+}
@@ -0,0 +1,515 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/api"
+)
+
+// runAdmin handles `cli admin <resource> <command> [flags]`
+func runAdmin(args []string) {
+	if len(args) >= 2 && args[0] == "key" && args[1] == "generate" {
+		generateKey(args[2:])
+		return
+	}
+	if len(args) >= 2 && args[0] == "token" && args[1] == "generate" {
+		generateAPIToken(args[2:])
+		return
+	}
+	if len(args) >= 2 && args[0] == "edge" && args[1] == "bootstrap" {
+		bootstrapEdgeProxy(args[2:])
+		return
+	}
+	if len(args) >= 1 && args[0] == "dr" {
+		runDR(args[1:])
+		return
+	}
+	if len(args) >= 1 && args[0] == "bootstrap" {
+		bootstrapPlatform(args[1:])
+		return
+	}
+	if len(args) >= 1 && args[0] == "controller" {
+		runController(args[1:])
+		return
+	}
+	if len(args) < 2 || args[0] != "tenant" {
+		printAdminUsage()
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("admin tenant "+args[1], flag.ExitOnError)
+	var (
+		server         = fs.String("server", "localhost:50051", "gRPC server address")
+		name           = fs.String("name", "", "Tenant name")
+		cpu            = fs.Float64("cpu", 0, "CPU quota in cores (default: 4)")
+		memory         = fs.Int64("memory", 0, "Memory quota in MB (default: 8192)")
+		maxApps        = fs.Int("max-apps", 0, "Maximum number of applications (default: 20)")
+		domainTemplate = fs.String("domain-template", "", "Hostname template, e.g. '{app}.{tenant}.example.com'")
+		serviceAccount = fs.String("service-account", "", "Name of the initial service account (default: deployer)")
+		noNewPrivs     = fs.Bool("no-new-privileges", false, "Keep the processes of the tenant's applications from gaining privileges")
+		seccomp        = fs.String("seccomp", "", "Default seccomp profile: default, unconfined or a profile name")
+		apparmor       = fs.String("apparmor", "", "Default AppArmor profile")
+		namespaces     stringList
+		capDrop        stringList
+		allowedImages  stringList
+		requireAttest  = fs.Bool("require-attestation", false, "Require a verified provenance attestation of the image for every deployment")
+		nomadAccess    = fs.Bool("nomad-access", false, "Mint a read-only Nomad token of the tenant's namespaces")
+		nomadReadLogs  = fs.Bool("nomad-read-logs", false, "The Nomad token can read the logs and files of allocations")
+	)
+	fs.Var(&namespaces, "namespace", "Nomad namespace of the tenant (repeatable, default: the tenant name)")
+	fs.Var(&capDrop, "cap-drop", "Capability dropped from every application, e.g. NET_RAW (repeatable)")
+	fs.Var(&allowedImages, "allowed-image", "Repository or prefix ending in * images may come from, e.g. registry.example.com/payments/* (repeatable)")
+	var connFlags connection
+	connFlags.register(fs, "")
+	_ = fs.Parse(args[2:])
+
+	conn, err := grpc.NewClient(*server, connFlags.dialOptions()...)
+	if err != nil {
+		log.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewAdminClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	switch args[1] {
+	case "create":
+		createTenant(ctx, client, &pb.CreateTenantRequest{
+			Name:       *name,
+			Namespaces: namespaces,
+			Quota: &pb.TenantQuota{
+				Cpu:             *cpu,
+				MemoryMb:        *memory,
+				MaxApplications: int32(*maxApps),
+			},
+			DomainTemplate: *domainTemplate,
+			ServiceAccount: *serviceAccount,
+			SecurityDefaults: &pb.SecurityContext{
+				NoNewPrivileges:  *noNewPrivs,
+				SeccompProfile:   *seccomp,
+				ApparmorProfile:  *apparmor,
+				DropCapabilities: capDrop,
+			},
+			AllowedImages:      allowedImages,
+			RequireAttestation: *requireAttest,
+			NomadAccess:        *nomadAccess,
+			NomadReadLogs:      *nomadReadLogs,
+		})
+	case "list":
+		listTenants(ctx, client)
+	case "rotate-key":
+		rotateTenantKeys(ctx, client, *name)
+	case "nomad-token":
+		issueTenantNomadToken(ctx, client, *name, *nomadReadLogs)
+	default:
+		fmt.Printf("Unknown tenant command: %s\n", args[1])
+		printAdminUsage()
+		os.Exit(1)
+	}
+}
+
+func createTenant(ctx context.Context, client pb.AdminClient, req *pb.CreateTenantRequest) {
+	if req.Name == "" {
+		log.Fatalf("-name must be provided for tenant create")
+	}
+
+	resp, err := client.CreateTenant(ctx, req)
+	if err != nil {
+		log.Fatalf("Tenant creation failed: %v", err)
+	}
+
+	if !resp.Success {
+		log.Fatalf("Tenant creation failed: %s", resp.Message)
+	}
+
+	tenant := resp.Tenant
+	fmt.Printf("Tenant created successfully!\n")
+	fmt.Printf("Name: %s\n", tenant.Name)
+	fmt.Printf("Namespaces: %s\n", strings.Join(tenant.Namespaces, ", "))
+	fmt.Printf("Quota: %.1f cores, %d MB memory, %d applications\n", tenant.Quota.Cpu, tenant.Quota.MemoryMb, tenant.Quota.MaxApplications)
+	fmt.Printf("Domain template: %s\n", tenant.DomainTemplate)
+	fmt.Printf("\nService account: %s\n", resp.ServiceAccount)
+	fmt.Printf("Token: %s\n", resp.Token)
+	if resp.NomadToken != "" {
+		fmt.Printf("\nNomad token: %s (accessor %s, expires %s)\n", resp.NomadToken, tenant.NomadTokenAccessor,
+			time.Unix(tenant.NomadTokenExpiresAt, 0).Format(time.RFC3339))
+	}
+	fmt.Printf("Store the tokens now, they cannot be retrieved again.\n")
+}
+
+func issueTenantNomadToken(ctx context.Context, client pb.AdminClient, name string, readLogs bool) {
+	if name == "" {
+		log.Fatalf("-name must be provided for tenant nomad-token")
+	}
+
+	resp, err := client.IssueTenantNomadToken(ctx, &pb.IssueTenantNomadTokenRequest{Name: name, ReadLogs: readLogs})
+	if err != nil {
+		log.Fatalf("Failed to issue Nomad token: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to issue Nomad token: %s", resp.Message)
+	}
+
+	fmt.Println(resp.Message)
+	fmt.Printf("Accessor: %s\n", resp.AccessorId)
+	fmt.Printf("Secret: %s\n", resp.SecretId)
+	fmt.Printf("Expires: %s\n", time.Unix(resp.ExpiresAt, 0).Format(time.RFC3339))
+}
+
+func listTenants(ctx context.Context, client pb.AdminClient) {
+	resp, err := client.ListTenants(ctx, &pb.ListTenantsRequest{})
+	if err != nil {
+		log.Fatalf("Failed to list tenants: %v", err)
+	}
+
+	fmt.Printf("\nTenants:\n")
+	for _, tenant := range resp.Tenants {
+		fmt.Printf("  - %s: namespaces %s, %.1f cores, %d MB, %d applications, %s, key %s\n",
+			tenant.Name, strings.Join(tenant.Namespaces, ","), tenant.Quota.Cpu, tenant.Quota.MemoryMb,
+			tenant.Quota.MaxApplications, tenant.DomainTemplate, tenant.KeyId)
+		if tenant.NomadTokenAccessor != "" {
+			fmt.Printf("    Nomad token %s, expires %s\n", tenant.NomadTokenAccessor, time.Unix(tenant.NomadTokenExpiresAt, 0).Format(time.RFC3339))
+		}
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
+
+func rotateTenantKeys(ctx context.Context, client pb.AdminClient, name string) {
+	resp, err := client.RotateTenantKeys(ctx, &pb.RotateTenantKeysRequest{Name: name})
+	if err != nil {
+		log.Fatalf("Key rotation failed: %v", err)
+	}
+
+	for _, tenant := range resp.Rotated {
+		fmt.Printf("  - %s: rewrapped with key %s\n", tenant, resp.KeyId)
+	}
+
+	if !resp.Success {
+		log.Fatalf("Key rotation failed: %s", resp.Message)
+	}
+	fmt.Printf("Message: %s\n", resp.Message)
+}
+
+// bootstrapEdgeProxy deploys Traefik through the controller, or prints its config with -dry-run
+func bootstrapEdgeProxy(args []string) {
+	fs := flag.NewFlagSet("admin edge bootstrap", flag.ExitOnError)
+	var (
+		server        = fs.String("server", "localhost:50051", "gRPC server address")
+		image         = fs.String("image", "", "Traefik image (default: the controller's -edge-image)")
+		nodeClass     = fs.String("node-class", "", "Only run on the clients of this node class, e.g. edge")
+		region        = fs.String("region", "", "Nomad region")
+		redirectHTTPS = fs.Bool("redirect-https", false, "Redirect HTTP requests to HTTPS")
+		dashboard     = fs.Bool("dashboard", false, "Serve the unauthenticated dashboard on the metrics port")
+		wakeURL       = fs.String("wake-url", "", "Wake proxy of applications scaled to zero, e.g. http://controller.service.consul:8081")
+		dryRun        = fs.Bool("dry-run", false, "Only print the configuration")
+		datacenters   stringList
+	)
+	fs.Var(&datacenters, "datacenter", "Datacenter to run in (repeatable, default: dc1)")
+	var connFlags connection
+	connFlags.register(fs, "")
+	_ = fs.Parse(args)
+
+	conn, err := grpc.NewClient(*server, connFlags.dialOptions()...)
+	if err != nil {
+		log.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := pb.NewAdminClient(conn).BootstrapEdgeProxy(ctx, &pb.BootstrapEdgeProxyRequest{
+		Image:         *image,
+		Datacenters:   datacenters,
+		NodeClass:     *nodeClass,
+		Region:        *region,
+		RedirectHttps: *redirectHTTPS,
+		Dashboard:     *dashboard,
+		WakeUrl:       *wakeURL,
+		DryRun:        *dryRun,
+	})
+	if err != nil {
+		log.Fatalf("Edge proxy bootstrap failed: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Edge proxy bootstrap failed: %s", resp.Message)
+	}
+
+	if *dryRun {
+		fmt.Printf("# traefik.yml\n%s\n# dynamic.yml\n%s", resp.StaticConfig, resp.DynamicConfig)
+		return
+	}
+	fmt.Printf("Evaluation: %s\n", resp.EvalId)
+	fmt.Printf("Message: %s\n", resp.Message)
+}
+
+// demoApplication is deployed by `cli admin bootstrap` to check the platform end to end
+const demoApplication = "whoami"
+
+// bootstrapPlatform provisions a fresh cluster through the controller, deploys the demo
+// application unless it exists and prints what was created
+func bootstrapPlatform(args []string) {
+	fs := flag.NewFlagSet("admin bootstrap", flag.ExitOnError)
+	var (
+		server        = fs.String("server", "localhost:50051", "gRPC server address")
+		image         = fs.String("edge-image", "", "Traefik image (default: the controller's -edge-image)")
+		edgePool      = fs.String("edge-pool", "", "Only run the edge proxy on the clients of this node pool, e.g. edge")
+		nodeClass     = fs.String("node-class", "", "Only run the edge proxy on the clients of this node class")
+		region        = fs.String("region", "", "Nomad region")
+		redirectHTTPS = fs.Bool("redirect-https", false, "Redirect HTTP requests to HTTPS")
+		wakeURL       = fs.String("wake-url", "", "Wake proxy of applications scaled to zero")
+		skipEdge      = fs.Bool("skip-edge-proxy", false, "Keep the edge proxy the cluster already runs")
+		defaultDeny   = fs.Bool("default-deny", false, "Create the Consul intention denying mesh traffic not explicitly allowed")
+		demo          = fs.Bool("demo", true, "Deploy the demo application "+demoApplication)
+		demoHost      = fs.String("demo-host", "whoami.localhost", "Hostname of the demo application")
+		namespaces    stringList
+		nodePools     stringList
+		datacenters   stringList
+	)
+	fs.Var(&namespaces, "namespace", "Nomad namespace to create (repeatable)")
+	fs.Var(&nodePools, "node-pool", "Node pool to create besides edge (repeatable)")
+	fs.Var(&datacenters, "datacenter", "Datacenter to run the edge proxy in (repeatable, default: dc1)")
+	var connFlags connection
+	connFlags.register(fs, "")
+	_ = fs.Parse(args)
+
+	conn, err := grpc.NewClient(*server, connFlags.dialOptions()...)
+	if err != nil {
+		log.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	resp, err := pb.NewAdminClient(conn).BootstrapPlatform(ctx, &pb.BootstrapPlatformRequest{
+		Namespaces: namespaces,
+		NodePools:  nodePools,
+		EdgeProxy: &pb.BootstrapEdgeProxyRequest{
+			Image:         *image,
+			Datacenters:   datacenters,
+			NodeClass:     *nodeClass,
+			NodePool:      *edgePool,
+			Region:        *region,
+			RedirectHttps: *redirectHTTPS,
+			WakeUrl:       *wakeURL,
+		},
+		SkipEdgeProxy: *skipEdge,
+		DefaultDeny:   *defaultDeny,
+	})
+	if err != nil {
+		log.Fatalf("Bootstrap failed: %v", err)
+	}
+
+	steps := resp.Steps
+	if *demo && resp.Success {
+		steps = append(steps, deployDemo(ctx, pb.NewControlPlaneClient(conn), *demoHost, *region))
+	}
+
+	fmt.Printf("\nBootstrap:\n")
+	failed := !resp.Success
+	for _, step := range steps {
+		fmt.Printf("  %-8s %-12s %s", step.Status, step.Resource, step.Name)
+		if step.Message != "" {
+			fmt.Printf(": %s", step.Message)
+		}
+		fmt.Println()
+		failed = failed || step.Status == "failed"
+	}
+
+	if failed {
+		log.Fatalf("Bootstrap failed: %s", resp.Message)
+	}
+	fmt.Printf("\nMessage: %s\n", resp.Message)
+}
+
+// deployDemo deploys the demo application unless it exists already
+func deployDemo(ctx context.Context, client pb.ControlPlaneClient, host, region string) *pb.BootstrapStep {
+	step := &pb.BootstrapStep{Resource: "application", Name: demoApplication}
+
+	status, err := client.GetApplicationStatus(ctx, &pb.StatusRequest{DeploymentId: demoApplication})
+	switch {
+	case err != nil:
+		step.Status = "failed"
+		step.Message = err.Error()
+		return step
+	case status.JobStatus != "":
+		step.Status = "exists"
+		return step
+	case !strings.Contains(status.Message, "not found"):
+		step.Status = "failed"
+		step.Message = status.Message
+		return step
+	}
+
+	resp, err := client.DeployApplication(ctx, &pb.DeployRequest{
+		Name:     demoApplication,
+		Image:    "traefik/whoami:latest",
+		Replicas: 1,
+		Cpu:      0.1,
+		Memory:   64,
+		Region:   region,
+		Traefik: &pb.TraefikConfig{
+			Enable:              true,
+			Host:                host,
+			Entrypoint:          "web",
+			HealthCheckPath:     "/",
+			HealthCheckInterval: "30s",
+		},
+	})
+	switch {
+	case err != nil:
+		step.Status = "failed"
+		step.Message = err.Error()
+	case resp.Status == "FAILED":
+		step.Status = "failed"
+		step.Message = resp.Message
+	default:
+		step.Status = "created"
+		step.Message = "http://" + host
+	}
+	return step
+}
+
+// generateKey prints a keyring line with a random key, appended to the controller's
+// -kms-keyring file it becomes the current key after a restart
+func generateKey(args []string) {
+	fs := flag.NewFlagSet("admin key generate", flag.ExitOnError)
+	id := fs.String("id", "", "Key ID, e.g. 2025-10")
+	_ = fs.Parse(args)
+
+	if *id == "" || strings.ContainsAny(*id, "=\n") {
+		log.Fatalf("-id must be provided and cannot contain '='")
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		log.Fatalf("Failed to generate key: %v", err)
+	}
+
+	fmt.Printf("%s=%s\n", *id, base64.StdEncoding.EncodeToString(key))
+}
+
+// generateAPIToken prints a random API token and its entry of the controller's -api-tokens
+// file, which keeps only its hash
+func generateAPIToken(args []string) {
+	fs := flag.NewFlagSet("admin token generate", flag.ExitOnError)
+	name := fs.String("name", "", "Name of the token, e.g. ci")
+	var scopes stringList
+	fs.Var(&scopes, "scope", "Scope of the token: read, deploy, delete or admin (repeatable)")
+	_ = fs.Parse(args)
+
+	if *name == "" || len(scopes) == 0 {
+		log.Fatalf("-name and -scope must be provided")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Failed to generate token: %v", err)
+	}
+	token := "cp_" + hex.EncodeToString(secret)
+	entry, err := json.Marshal(api.APIToken{Name: *name, SHA256: api.TokenHash(token), Scopes: scopes})
+	if err != nil {
+		log.Fatalf("Failed to encode token: %v", err)
+	}
+
+	fmt.Printf("Token: %s\n", token)
+	fmt.Printf("Entry of -api-tokens: %s\n", entry)
+}
+
+func printAdminUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  cli admin tenant create -name=<tenant> [flags]")
+	fmt.Println("  cli admin tenant list")
+	fmt.Println("  cli admin tenant rotate-key [-name=<tenant>]   Rewrap data keys with the current KMS key")
+	fmt.Println("  cli admin tenant nomad-token -name=<tenant> [-nomad-read-logs]")
+	fmt.Println("                                                 Mint a read-only Nomad token, revoking the previous one")
+	fmt.Println("  cli admin key generate -id=<key id>            Print a keyring line for -kms-keyring")
+	fmt.Println("  cli admin token generate -name=<name> -scope=<scope>")
+	fmt.Println("                                                 Print an API token and its entry of -api-tokens")
+	fmt.Println("  cli admin edge bootstrap [flags]               Deploy the Traefik edge proxy")
+	fmt.Println("  cli admin bootstrap [flags]                    Provision a fresh cluster and deploy a demo application")
+	fmt.Println("  cli admin dr status [-server=<address>]        Show the disaster recovery replication of the site")
+	fmt.Println("  cli admin dr promote [-server=<address>]       Promote the standby site when the primary is lost")
+	fmt.Println("  cli admin controller deploy [flags]            Deploy or upgrade the controllers as a Nomad job")
+	fmt.Println("  cli admin controller status [-server=<address>] Show the controllers and their rollout")
+	fmt.Println()
+	fmt.Println("Connection flags of every command talking to a controller:")
+	fmt.Println("  -tls                     Connect over TLS, implied by -ca and -cert")
+	fmt.Println("  -ca string               PEM CA bundle verifying the controller's certificate (default: the system roots)")
+	fmt.Println("  -cert string             PEM client certificate for controllers requiring mutual TLS")
+	fmt.Println("  -key string              PEM key of the client certificate")
+	fmt.Println("  -token string            API token of the controller (env: CONTROLPLANE_TOKEN)")
+	fmt.Println()
+	fmt.Println("Tenant create flags:")
+	fmt.Println("  -server string           gRPC server address (default: localhost:50051)")
+	fmt.Println("  -name string             Tenant name")
+	fmt.Println("  -namespace string        Nomad namespace of the tenant (repeatable, default: the tenant name)")
+	fmt.Println("  -cpu float               CPU quota in cores (default: 4)")
+	fmt.Println("  -memory int              Memory quota in MB (default: 8192)")
+	fmt.Println("  -max-apps int            Maximum number of applications (default: 20)")
+	fmt.Println("  -domain-template string  Hostname template, e.g. '{app}.{tenant}.example.com'")
+	fmt.Println("  -service-account string  Name of the initial service account (default: deployer)")
+	fmt.Println("  -no-new-privileges       Keep the processes of the tenant's applications from gaining privileges")
+	fmt.Println("  -seccomp string          Default seccomp profile: default, unconfined or a profile name")
+	fmt.Println("  -apparmor string         Default AppArmor profile")
+	fmt.Println("  -cap-drop string         Capability dropped from every application, e.g. NET_RAW (repeatable)")
+	fmt.Println("  -allowed-image string    Repository or prefix ending in * images may come from, e.g. registry.example.com/payments/* (repeatable)")
+	fmt.Println("  -require-attestation     Require a verified provenance attestation of the image for every deployment")
+	fmt.Println("  -nomad-access            Mint a read-only Nomad token of the tenant's namespaces")
+	fmt.Println("  -nomad-read-logs         The Nomad token can read the logs and files of allocations")
+	fmt.Println()
+	fmt.Println("Edge bootstrap flags:")
+	fmt.Println("  -server string           gRPC server address (default: localhost:50051)")
+	fmt.Println("  -image string            Traefik image (default: the controller's -edge-image)")
+	fmt.Println("  -datacenter string       Datacenter to run in (repeatable, default: dc1)")
+	fmt.Println("  -node-class string       Only run on the clients of this node class, e.g. edge")
+	fmt.Println("  -region string           Nomad region")
+	fmt.Println("  -redirect-https          Redirect HTTP requests to HTTPS")
+	fmt.Println("  -dashboard               Serve the unauthenticated dashboard on the metrics port")
+	fmt.Println("  -wake-url string         Wake proxy of applications scaled to zero")
+	fmt.Println("  -dry-run                 Only print the configuration")
+	fmt.Println()
+	fmt.Println("Bootstrap flags:")
+	fmt.Println("  -server string           gRPC server address (default: localhost:50051)")
+	fmt.Println("  -namespace string        Nomad namespace to create (repeatable)")
+	fmt.Println("  -node-pool string        Node pool to create besides edge (repeatable)")
+	fmt.Println("  -edge-image string       Traefik image (default: the controller's -edge-image)")
+	fmt.Println("  -edge-pool string        Only run the edge proxy on the clients of this node pool, e.g. edge")
+	fmt.Println("  -node-class string       Only run the edge proxy on the clients of this node class")
+	fmt.Println("  -datacenter string       Datacenter to run the edge proxy in (repeatable, default: dc1)")
+	fmt.Println("  -region string           Nomad region")
+	fmt.Println("  -redirect-https          Redirect HTTP requests to HTTPS")
+	fmt.Println("  -wake-url string         Wake proxy of applications scaled to zero")
+	fmt.Println("  -skip-edge-proxy         Keep the edge proxy the cluster already runs")
+	fmt.Println("  -default-deny            Create the Consul intention denying mesh traffic not explicitly allowed")
+	fmt.Println("  -demo                    Deploy the demo application whoami (default: true)")
+	fmt.Println("  -demo-host string        Hostname of the demo application (default: whoami.localhost)")
+	fmt.Println()
+	fmt.Println("Controller deploy flags:")
+	fmt.Println("  -server string           gRPC server address of a controller (default: localhost:50051)")
+	fmt.Println("  -image string            Controller image, required for the first deployment")
+	fmt.Println("  -count int               Number of controllers (default: 2, or the deployed count)")
+	fmt.Println("  -arg string              Controller flag, e.g. -arg=-nomad=http://nomad.service.consul:4646 (repeatable, default: the deployed flags)")
+	fmt.Println("  -replace-args            Replace the deployed controller flags with -arg, even when none is given")
+	fmt.Println("  -datacenter string       Datacenter to run in (repeatable, default: dc1)")
+	fmt.Println("  -region string           Nomad region")
+	fmt.Println("  -node-pool string        Only run on the clients of this node pool")
+	fmt.Println("  -health-timeout duration How long a new controller has to become healthy (default: 3m)")
+	fmt.Println("  -wait                    Wait for the rollout and report whether it was reverted (default: true)")
+	fmt.Println("  -timeout duration        How long to wait for the rollout (default: 10m)")
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// deploymentAnalytics prints the DORA metrics of every application or team, with the time
+// series of each with -periods
+func deploymentAnalytics(ctx context.Context, client pb.ControlPlaneClient, req *pb.DeploymentAnalyticsRequest, periods bool) {
+	resp, err := client.GetDeploymentAnalytics(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to get deployment analytics: %v", err)
+	}
+
+	if len(resp.Analytics) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tTEAM\tPERIOD\tDEPLOYS\tPER DAY\tFAILURE RATE\tLEAD TIME\tROLLOUT\tMTTR")
+		for _, analytics := range resp.Analytics {
+			rows := []*pb.DeliveryMetrics{analytics.Total}
+			if periods {
+				rows = append(rows, analytics.Periods...)
+			}
+			for i, metrics := range rows {
+				period := "total"
+				if i > 0 {
+					period = time.Unix(metrics.PeriodStart, 0).UTC().Format(time.DateOnly)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%.2f\t%.0f%%\t%s\t%s\t%s\n",
+					orDash(analytics.Application), orDash(analytics.Team), period,
+					metrics.Deployments, metrics.DeploysPerDay, metrics.ChangeFailureRate*100,
+					formatSeconds(metrics.LeadTimeSeconds), formatSeconds(metrics.RolloutDurationSeconds), formatSeconds(metrics.MttrSeconds))
+			}
+		}
+		w.Flush()
+	}
+	fmt.Printf("\nMessage: %s\n", resp.Message)
+}
+
+// formatSeconds formats a duration of the analytics, a dash when there was none to measure
+func formatSeconds(seconds int64) string {
+	if seconds == 0 {
+		return "-"
+	}
+	return (time.Duration(seconds) * time.Second).String()
+}
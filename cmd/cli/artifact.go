@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+var artifactKinds = map[string]pb.ArtifactKind{
+	"sbom":       pb.ArtifactKind_ARTIFACT_KIND_SBOM,
+	"provenance": pb.ArtifactKind_ARTIFACT_KIND_PROVENANCE,
+}
+
+// readArtifact reads an artifact file into an AttachArtifact request
+func readArtifact(name, image, kind, file string) (*pb.AttachArtifactRequest, error) {
+	artifactKind, ok := artifactKinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("artifact kind must be sbom or provenance, got %q", kind)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AttachArtifactRequest{
+		Application: name,
+		Image:       image,
+		Kind:        artifactKind,
+		Content:     content,
+	}, nil
+}
+
+func attachArtifact(ctx context.Context, client pb.ControlPlaneClient, name, image, kind, file, mediaType string) {
+	if name == "" || image == "" || file == "" {
+		log.Fatalf("-name, -image and -f must be provided for attach action")
+	}
+
+	req, err := readArtifact(name, image, kind, file)
+	if err != nil {
+		log.Fatalf("Failed to read artifact: %v", err)
+	}
+	req.MediaType = mediaType
+
+	resp, err := client.AttachArtifact(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to attach artifact: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to attach artifact: %s", resp.Message)
+	}
+
+	printArtifact(resp.Artifact)
+	fmt.Printf("Message: %s\n", resp.Message)
+}
+
+func listArtifacts(ctx context.Context, client pb.ControlPlaneClient, name string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for artifacts action")
+	}
+
+	resp, err := client.ListArtifacts(ctx, &pb.ListArtifactsRequest{Application: name})
+	if err != nil {
+		log.Fatalf("Failed to list artifacts: %v", err)
+	}
+
+	fmt.Printf("\nArtifacts of %s:\n", name)
+	for _, artifact := range resp.Artifacts {
+		verification := "unverified"
+		switch {
+		case artifact.Verified:
+			verification = "verified, signed by " + artifact.Signer
+		case artifact.VerificationError != "":
+			verification = "verification failed: " + artifact.VerificationError
+		}
+		fmt.Printf("  - %s %s of %s (%s)\n", artifact.Id, kindName(artifact.Kind), artifact.Digest, time.Unix(artifact.CreatedAt, 0).Format(time.RFC3339))
+		fmt.Printf("    %s\n", verification)
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
+
+// getArtifact writes the artifact's content to stdout, e.g. for piping into an SBOM scanner
+func getArtifact(ctx context.Context, client pb.ControlPlaneClient, name, id string) {
+	if name == "" || id == "" {
+		log.Fatalf("-name and -artifact-id must be provided for get-artifact action")
+	}
+
+	resp, err := client.GetArtifact(ctx, &pb.GetArtifactRequest{Application: name, Id: id})
+	if err != nil {
+		log.Fatalf("Failed to get artifact: %v", err)
+	}
+	if resp.Artifact == nil {
+		log.Fatalf("%s", resp.Message)
+	}
+
+	_, _ = os.Stdout.Write(resp.Content)
+}
+
+func printArtifact(artifact *pb.Artifact) {
+	fmt.Printf("Artifact: %s\n", artifact.Id)
+	fmt.Printf("Kind: %s\n", kindName(artifact.Kind))
+	fmt.Printf("Image: %s@%s\n", artifact.Image, artifact.Digest)
+	fmt.Printf("Verified: %t\n", artifact.Verified)
+	if artifact.Signer != "" {
+		fmt.Printf("Signer: %s\n", artifact.Signer)
+	}
+}
+
+func kindName(kind pb.ArtifactKind) string {
+	for name, k := range artifactKinds {
+		if k == kind {
+			return name
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// parseScaleMetric parses <provider>:<target>[:<query>], e.g. cpu:60 or
+// prom:50:sum(rate(http_requests_total{job="{app}"}[1m])), the query may contain ':'
+func parseScaleMetric(expr string) (*pb.ScalingMetric, error) {
+	parts := strings.SplitN(expr, ":", 3)
+	if len(parts) < 2 || parts[0] == "" {
+		return nil, fmt.Errorf("invalid scale metric %q, expected <provider>:<target>[:<query>]", expr)
+	}
+
+	target, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || target <= 0 {
+		return nil, fmt.Errorf("invalid scale metric %q: invalid target %q", expr, parts[1])
+	}
+	metric := &pb.ScalingMetric{Provider: parts[0], Target: target}
+	if len(parts) == 3 {
+		metric.Query = parts[2]
+	}
+	return metric, nil
+}
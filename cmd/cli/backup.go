@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+func backupApp(ctx context.Context, client pb.ControlPlaneClient, name string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for backup action")
+	}
+
+	resp, err := client.BackupApplication(ctx, &pb.BackupRequest{Name: name})
+	if err != nil {
+		log.Fatalf("Backup failed: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Backup failed: %s", resp.Message)
+	}
+
+	fmt.Printf("Snapshot: %s\n", resp.Snapshot.Id)
+	fmt.Printf("Location: %s\n", resp.Snapshot.Location)
+	fmt.Printf("Job: %s\n", resp.Snapshot.JobId)
+	fmt.Printf("Message: %s\n", resp.Message)
+}
+
+func listSnapshots(ctx context.Context, client pb.ControlPlaneClient, name string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for snapshots action")
+	}
+
+	resp, err := client.ListSnapshots(ctx, &pb.ListSnapshotsRequest{Name: name})
+	if err != nil {
+		log.Fatalf("Failed to list snapshots: %v", err)
+	}
+
+	fmt.Printf("\nSnapshots of %s:\n", name)
+	for _, snapshot := range resp.Snapshots {
+		fmt.Printf("  - %s: %s, started %s", snapshot.Id, snapshot.Status, time.Unix(snapshot.StartedAt, 0).Format(time.RFC3339))
+		if snapshot.FinishedAt > 0 {
+			fmt.Printf(", took %s", time.Duration(snapshot.FinishedAt-snapshot.StartedAt)*time.Second)
+		}
+		fmt.Printf("\n    %s\n", snapshot.Location)
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
+
+func restoreVolume(ctx context.Context, client pb.ControlPlaneClient, name, snapshotID string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for restore action")
+	}
+
+	fmt.Printf("Restoring the volumes of '%s'...\n", name)
+	resp, err := client.RestoreVolume(ctx, &pb.RestoreVolumeRequest{Name: name, SnapshotId: snapshotID})
+	if err != nil {
+		log.Fatalf("Restore failed: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Restore failed: %s", resp.Message)
+	}
+
+	fmt.Printf("Snapshot: %s\n", resp.SnapshotId)
+	fmt.Printf("Job: %s\n", resp.JobId)
+	fmt.Printf("Message: %s\n", resp.Message)
+}
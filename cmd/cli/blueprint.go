@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// readSpec loads a JSON or YAML file in the DeployRequest or manifest format
+func readSpec(file string) *pb.DeployRequest {
+	spec, err := parseSpec(file)
+	if err != nil {
+		errs := specErrors(err)
+		if len(errs) == 1 {
+			log.Fatalf("Invalid spec file %s: %v", file, err)
+		}
+		fmt.Fprintf(os.Stderr, "Invalid spec file %s:\n", file)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "  - %v\n", err)
+		}
+		os.Exit(1)
+	}
+
+	return spec
+}
+
+// specErrors splits the errors of a manifest, it reports all it found at once
+func specErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+func publishBlueprint(ctx context.Context, client pb.ControlPlaneClient, tenant, blueprint, channel, file string) {
+	if blueprint == "" || file == "" {
+		log.Fatalf("-blueprint and -f must be provided for publish-blueprint action")
+	}
+
+	resp, err := client.PublishBlueprint(ctx, &pb.PublishBlueprintRequest{
+		Tenant:    tenant,
+		Blueprint: blueprint,
+		Channel:   channel,
+		Spec:      readSpec(file),
+	})
+	if err != nil {
+		log.Fatalf("Publish failed: %v", err)
+	}
+
+	if !resp.Success {
+		log.Fatalf("Publish failed: %s", resp.Message)
+	}
+
+	fmt.Printf("Blueprint published successfully!\n")
+	fmt.Printf("Version: %d\n", resp.Version)
+	for _, app := range resp.UpdatedApplications {
+		fmt.Printf("  - %s: updated\n", app)
+	}
+	for _, app := range resp.ProposedApplications {
+		fmt.Printf("  - %s: update proposed\n", app)
+	}
+	fmt.Printf("Message: %s\n", resp.Message)
+}
+
+func subscribe(ctx context.Context, client pb.ControlPlaneClient, tenant, name, blueprint, channel, policy string, pin int, file string) {
+	if name == "" || blueprint == "" {
+		log.Fatalf("-name and -blueprint must be provided for subscribe action")
+	}
+
+	req := &pb.SubscribeRequest{
+		Tenant:        tenant,
+		Application:   name,
+		Blueprint:     blueprint,
+		Channel:       channel,
+		PinnedVersion: int32(pin),
+	}
+
+	switch policy {
+	case "propose":
+		req.Policy = pb.UpdatePolicy_UPDATE_POLICY_PROPOSE
+	case "auto":
+		req.Policy = pb.UpdatePolicy_UPDATE_POLICY_AUTO
+	default:
+		log.Fatalf("Invalid update policy %q: must be propose or auto", policy)
+	}
+
+	if file != "" {
+		req.Overrides = readSpec(file)
+	}
+
+	resp, err := client.SubscribeApplication(ctx, req)
+	if err != nil {
+		log.Fatalf("Subscribe failed: %v", err)
+	}
+
+	if !resp.Success {
+		log.Fatalf("Subscribe failed: %s", resp.Message)
+	}
+
+	fmt.Printf("Application subscribed successfully!\n")
+	fmt.Printf("Version: %d\n", resp.Version)
+	fmt.Printf("Deployment ID: %s\n", resp.DeploymentId)
+	fmt.Printf("Message: %s\n", resp.Message)
+}
+
+func listSubscriptions(ctx context.Context, client pb.ControlPlaneClient, blueprint string, behind bool) {
+	resp, err := client.ListSubscriptions(ctx, &pb.ListSubscriptionsRequest{
+		Blueprint:  blueprint,
+		BehindOnly: behind,
+	})
+	if err != nil {
+		log.Fatalf("Failed to list subscriptions: %v", err)
+	}
+
+	fmt.Printf("\nSubscriptions:\n")
+	for _, sub := range resp.Subscriptions {
+		line := fmt.Sprintf("  - %s: %s@%s v%d (latest v%d, %s)", sub.Application, sub.Blueprint, sub.Channel,
+			sub.DeployedVersion, sub.LatestVersion, sub.Policy)
+		if sub.PinnedVersion != 0 {
+			line += fmt.Sprintf(" pinned to v%d", sub.PinnedVersion)
+		}
+		if sub.Behind {
+			line += " BEHIND"
+		}
+		fmt.Println(line)
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
+
+func applyBlueprintUpdate(ctx context.Context, client pb.ControlPlaneClient, name string, version int) {
+	if name == "" {
+		log.Fatalf("-name must be provided for apply-update action")
+	}
+
+	resp, err := client.ApplyBlueprintUpdate(ctx, &pb.ApplyBlueprintUpdateRequest{
+		Application: name,
+		Version:     int32(version),
+	})
+	if err != nil {
+		log.Fatalf("Update failed: %v", err)
+	}
+
+	if !resp.Success {
+		log.Fatalf("Update failed: %s", resp.Message)
+	}
+
+	fmt.Printf("Update applied successfully!\n")
+	fmt.Printf("Version: %d\n", resp.Version)
+	fmt.Printf("Deployment ID: %s\n", resp.DeploymentId)
+	fmt.Printf("Message: %s\n", resp.Message)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// calendar prints what the control plane and Nomad will do in the coming hours, and the
+// updates awaiting approval
+func calendar(ctx context.Context, client pb.ControlPlaneClient, req *pb.GetCalendarRequest) {
+	if req.Hours < 1 {
+		log.Fatalf("-within must be at least 1h")
+	}
+	resp, err := client.GetCalendar(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to get calendar: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to get calendar: %s", resp.Message)
+	}
+
+	fmt.Printf("\nScheduled until %s:\n", time.Unix(resp.Until, 0).Format(time.RFC3339))
+	if len(resp.Operations) == 0 {
+		fmt.Printf("  Nothing scheduled\n")
+	}
+	for _, operation := range resp.Operations {
+		fmt.Printf("  - %s  %-16s %s: %s\n", time.Unix(operation.Time, 0).Format("Jan 2 15:04"), operation.Kind, operationSubject(operation), operation.Description)
+	}
+
+	if len(resp.PendingApprovals) > 0 {
+		fmt.Printf("\nAwaiting approval:\n")
+	}
+	for _, operation := range resp.PendingApprovals {
+		fmt.Printf("  - %-16s %s: %s", operation.Kind, operationSubject(operation), operation.Description)
+		if operation.Time > 0 {
+			fmt.Printf(" (proposed %s)", time.Unix(operation.Time, 0).Format(time.RFC3339))
+		}
+		fmt.Println()
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
+
+// operationSubject names the application of an operation, or its tenant
+func operationSubject(operation *pb.ScheduledOperation) string {
+	switch {
+	case operation.Application == "":
+		return "tenant " + operation.Tenant
+	case operation.Tenant != "":
+		return operation.Tenant + "/" + operation.Application
+	default:
+		return operation.Application
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// promoteDeployment promotes the canaries of the application's rollout, of one task group
+// or of every group
+func promoteDeployment(ctx context.Context, client pb.ControlPlaneClient, name, deploymentID, taskGroup string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for promote action")
+	}
+	req := &pb.PromoteDeploymentRequest{Name: name, DeploymentId: deploymentID}
+	if taskGroup != "" {
+		req.Groups = []string{taskGroup}
+	}
+
+	resp, err := client.PromoteDeployment(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to promote rollout: %v", err)
+	}
+	printRolloutChange(resp.Success, resp.DeploymentId, resp.EvalId, resp.Message)
+}
+
+// failDeployment fails the application's rollout, Nomad reverts the job when its update
+// strategy has auto_revert
+func failDeployment(ctx context.Context, client pb.ControlPlaneClient, name, deploymentID string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for fail action")
+	}
+
+	resp, err := client.FailDeployment(ctx, &pb.FailDeploymentRequest{Name: name, DeploymentId: deploymentID})
+	if err != nil {
+		log.Fatalf("Failed to fail rollout: %v", err)
+	}
+	printRolloutChange(resp.Success, resp.DeploymentId, resp.EvalId, resp.Message)
+}
+
+func printRolloutChange(success bool, deploymentID, evalID, message string) {
+	fmt.Printf("Success: %t\n", success)
+	if deploymentID != "" {
+		fmt.Printf("Deployment: %s\n", deploymentID)
+	}
+	if evalID != "" {
+		fmt.Printf("Evaluation: %s\n", evalID)
+	}
+	fmt.Printf("Message: %s\n", message)
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/api"
+)
+
+// an unchanged spec creates no Nomad deployment, a healthy application without a new
+// rollout after this long counts as deployed
+const ciUnchangedAfter = 30 * time.Second
+
+// ciDeploy is the outcome of `cli ci deploy` reported to the pipeline
+type ciDeploy struct {
+	spec         *pb.DeployRequest
+	file         string
+	deploymentID string
+	rollout      *pb.RolloutProgress
+	status       string
+	url          string
+	duration     time.Duration
+}
+
+// runCI handles `cli ci deploy`, made for pipelines: the flags default to CP_* environment
+// variables and results are reported as GitHub Actions annotations, job summary and outputs
+func runCI(args []string) {
+	if len(args) < 1 || args[0] != "deploy" {
+		fmt.Println("Usage: cli ci deploy [flags]")
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("ci deploy", flag.ExitOnError)
+	var (
+		server   = fs.String("server", envOr("CP_SERVER", "localhost:50051"), "gRPC server address (env: CP_SERVER)")
+		file     = fs.String("f", os.Getenv("CP_SPEC"), "JSON or YAML spec file (env: CP_SPEC)")
+		image    = fs.String("image", os.Getenv("CP_IMAGE"), "Image replacing the one of the spec (env: CP_IMAGE)")
+		tag      = fs.String("tag", os.Getenv("CP_IMAGE_TAG"), "Tag replacing the one of the spec's image (env: CP_IMAGE_TAG)")
+		wait     = fs.Bool("wait", envOr("CP_WAIT", "true") == "true", "Wait for the rollout to finish (env: CP_WAIT)")
+		timeout  = fs.Duration("timeout", 10*time.Minute, "How long to wait for the rollout (env: CP_TIMEOUT)")
+		interval = fs.Duration("interval", 5*time.Second, "How often to check the rollout")
+		sbom     = fs.String("sbom", os.Getenv("CP_SBOM"), "SBOM of the image attached before deploying (env: CP_SBOM)")
+		attest   = fs.String("provenance", os.Getenv("CP_PROVENANCE"), "Provenance attestation of the image attached before deploying (env: CP_PROVENANCE)")
+		idemKey  = fs.String("idempotency-key", os.Getenv("CP_IDEMPOTENCY_KEY"), "Key of the deployment, a retried job returns the original result (env: CP_IDEMPOTENCY_KEY)")
+		commit   = fs.String("commit-time", os.Getenv("CP_COMMIT_TIME"), "Time of the commit the image was built from as unix seconds or RFC 3339, for the lead time of changes (env: CP_COMMIT_TIME)")
+	)
+	if value := os.Getenv("CP_TIMEOUT"); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			*timeout = d
+		}
+	}
+	connFlags := connection{
+		tls:  os.Getenv("CP_TLS") == "true",
+		ca:   os.Getenv("CP_TLS_CA"),
+		cert: os.Getenv("CP_TLS_CERT"),
+		key:  os.Getenv("CP_TLS_KEY"),
+	}
+	connFlags.register(fs, "")
+	_ = fs.Parse(args[1:])
+
+	if *file == "" {
+		ciFail("", "Missing spec", "-f or CP_SPEC must be provided")
+	}
+
+	spec, err := parseSpec(*file)
+	if err != nil {
+		ciFail(*file, "Invalid spec", err.Error())
+	}
+	switch {
+	case *image != "":
+		spec.Image = *image
+	case *tag != "":
+		spec.Image = withTag(spec.Image, *tag)
+	}
+	if *commit != "" {
+		if spec.CommitTime, err = strconv.ParseInt(*commit, 10, 64); err != nil {
+			committed, err := time.Parse(time.RFC3339, *commit)
+			if err != nil {
+				ciFail(*file, "Invalid commit time", "-commit-time must be unix seconds or RFC 3339")
+			}
+			spec.CommitTime = committed.Unix()
+		}
+	}
+
+	if errs := api.ValidateSpec(spec); len(errs) > 0 {
+		for _, err := range errs {
+			githubCommand("error", *file, "Invalid spec", err.Error())
+		}
+		ciFail(*file, "Invalid spec", fmt.Sprintf("%d problems found", len(errs)))
+	}
+
+	conn, err := grpc.NewClient(*server, connFlags.dialOptions()...)
+	if err != nil {
+		ciFail(*file, "Deployment failed", fmt.Sprintf("failed to connect to server: %v", err))
+	}
+	defer conn.Close()
+	client := pb.NewControlPlaneClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout+30*time.Second)
+	defer cancel()
+
+	result := &ciDeploy{spec: spec, file: *file, url: applicationURL(spec)}
+	started := time.Now()
+
+	for _, artifact := range [][2]string{{"sbom", *sbom}, {"provenance", *attest}} {
+		kind, file := artifact[0], artifact[1]
+		if file == "" {
+			continue
+		}
+		req, err := readArtifact(spec.Name, spec.Image, kind, file)
+		if err == nil {
+			var resp *pb.AttachArtifactResponse
+			if resp, err = client.AttachArtifact(ctx, req); err == nil && !resp.Success {
+				err = fmt.Errorf("%s", resp.Message)
+			}
+		}
+		if err != nil {
+			ciFail(file, "Failed to attach "+kind, err.Error())
+		}
+		fmt.Printf("Attached %s %s\n", kind, file)
+	}
+
+	// the rollout running before the deploy, the new one has another ID
+	var previous string
+	if status, err := client.GetApplicationStatus(ctx, &pb.StatusRequest{DeploymentId: spec.Name}); err == nil && status.Rollout != nil {
+		previous = status.Rollout.DeploymentId
+	}
+
+	fmt.Printf("Deploying %s with image %s...\n", spec.Name, spec.Image)
+	deployCtx := ctx
+	if *idemKey != "" {
+		deployCtx = metadata.AppendToOutgoingContext(ctx, api.IdempotencyKeyHeader, *idemKey)
+	}
+	resp, err := client.DeployApplication(deployCtx, spec)
+	if err == nil && resp.Status == "FAILED" {
+		err = fmt.Errorf("%s", resp.Message)
+	}
+	if err != nil {
+		result.status = "failed"
+		ciReport(result)
+		ciFail(*file, "Deployment failed", err.Error())
+	}
+	result.deploymentID = resp.DeploymentId
+	result.status = strings.ToLower(resp.Status)
+
+	if *wait && spec.Type != pb.DeploymentType_DEPLOYMENT_TYPE_FUNCTION && spec.Type != pb.DeploymentType_DEPLOYMENT_TYPE_CRON {
+		result.rollout, result.status = waitForRollout(ctx, client, resp.JobId, previous, started, *timeout, *interval)
+	}
+	result.duration = time.Since(started)
+	ciReport(result)
+
+	switch result.status {
+	case "successful", "unchanged", "submitted":
+		fmt.Printf("Deployment %s: %s\n", result.status, spec.Name)
+	case "timeout":
+		ciFail(*file, "Rollout timed out", fmt.Sprintf("rollout of %s did not finish within %s", spec.Name, *timeout))
+	default:
+		ciFail(*file, "Rollout failed", fmt.Sprintf("rollout of %s is %s", spec.Name, result.status))
+	}
+}
+
+// waitForRollout polls the application until the rollout started by the deploy finished
+func waitForRollout(ctx context.Context, client pb.ControlPlaneClient, name, previous string, started time.Time, timeout, interval time.Duration) (*pb.RolloutProgress, string) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		status, err := client.GetApplicationStatus(ctx, &pb.StatusRequest{DeploymentId: name})
+		if err != nil {
+			fmt.Printf("Failed to get application status: %v\n", err)
+			continue
+		}
+
+		rollout := status.Rollout
+		if rollout == nil || rollout.DeploymentId == previous {
+			if time.Since(started) > ciUnchangedAfter && status.DesiredInstances > 0 && status.HealthyInstances == status.DesiredInstances {
+				return rollout, "unchanged"
+			}
+			continue
+		}
+
+		fmt.Printf("Rollout: %s %.0f%% (%d/%d healthy)\n", rollout.Status, rollout.Percent, rollout.HealthyInstances, rollout.DesiredInstances)
+		switch rollout.Status {
+		case "successful", "failed", "cancelled":
+			return rollout, rollout.Status
+		}
+	}
+
+	return nil, "timeout"
+}
+
+// ciReport writes the job summary and the step outputs when running in GitHub Actions
+func ciReport(result *ciDeploy) {
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "### Deployment of %s\n\n", result.spec.Name)
+	fmt.Fprintf(&summary, "| | |\n|---|---|\n")
+	fmt.Fprintf(&summary, "| Status | %s |\n", result.status)
+	fmt.Fprintf(&summary, "| Image | `%s` |\n", result.spec.Image)
+	if result.deploymentID != "" {
+		fmt.Fprintf(&summary, "| Evaluation | `%s` |\n", result.deploymentID)
+	}
+	if rollout := result.rollout; rollout != nil {
+		fmt.Fprintf(&summary, "| Rollout | `%s` %.0f%% (%d/%d healthy) |\n", rollout.DeploymentId, rollout.Percent, rollout.HealthyInstances, rollout.DesiredInstances)
+	}
+	if result.duration > 0 {
+		fmt.Fprintf(&summary, "| Duration | %s |\n", result.duration.Round(time.Second))
+	}
+	if result.url != "" {
+		fmt.Fprintf(&summary, "| URL | %s |\n", result.url)
+	}
+	appendFile(os.Getenv("GITHUB_STEP_SUMMARY"), summary.String()+"\n")
+
+	outputs := fmt.Sprintf("deployment_id=%s\nstatus=%s\nurl=%s\n", result.deploymentID, result.status, result.url)
+	appendFile(os.Getenv("GITHUB_OUTPUT"), outputs)
+}
+
+// ciFail annotates the spec file with the error and exits
+func ciFail(file, title, message string) {
+	githubCommand("error", file, title, message)
+	os.Exit(1)
+}
+
+// githubCommand prints a workflow command, GitHub shows it as annotation of the file
+func githubCommand(command, file, title, message string) {
+	escape := strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	properties := "title=" + strings.NewReplacer("%", "%25", ",", "%2C", ":", "%3A").Replace(title)
+	if file != "" {
+		properties = "file=" + file + "," + properties
+	}
+	fmt.Printf("::%s %s::%s\n", command, properties, escape.Replace(message))
+}
+
+func appendFile(path, content string) {
+	if path == "" {
+		return
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		fmt.Printf("Failed to write %s: %v\n", path, err)
+		return
+	}
+	defer f.Close()
+
+	_, _ = f.WriteString(content)
+}
+
+// withTag replaces the tag or digest of an image, keeping registry ports intact
+func withTag(image, tag string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image + ":" + tag
+}
+
+func applicationURL(spec *pb.DeployRequest) string {
+	if spec.Traefik == nil || spec.Traefik.Host == "" {
+		return ""
+	}
+
+	scheme := "http"
+	host := spec.Traefik.Host
+	if spec.Traefik.EnableSsl {
+		scheme = "https"
+		if spec.Traefik.SslHost != "" {
+			host = spec.Traefik.SslHost
+		}
+	}
+	return scheme + "://" + host + spec.Traefik.PathPrefix
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,595 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/api"
+)
+
+// the controller routes container port 80 of an application, other ports are not exposed
+const composeRoutedPort = 80
+
+// composeFile is the part of a docker-compose file the conversion understands
+type composeFile struct {
+	Name     string                    `yaml:"name"`
+	Services map[string]composeService `yaml:"services"`
+}
+
+type composeService struct {
+	Image       string              `yaml:"image"`
+	Build       any                 `yaml:"build"`
+	Environment composeMapping      `yaml:"environment"`
+	EnvFile     composeList         `yaml:"env_file"`
+	Ports       []yaml.Node         `yaml:"ports"`
+	Healthcheck *composeHealthcheck `yaml:"healthcheck"`
+	DependsOn   composeList         `yaml:"depends_on"`
+	NetworkMode string              `yaml:"network_mode"`
+	CPUs        string              `yaml:"cpus"`
+	MemLimit    string              `yaml:"mem_limit"`
+	Deploy      struct {
+		Replicas  *int32 `yaml:"replicas"`
+		Resources struct {
+			Limits struct {
+				CPUs   string `yaml:"cpus"`
+				Memory string `yaml:"memory"`
+			} `yaml:"limits"`
+		} `yaml:"resources"`
+	} `yaml:"deploy"`
+
+	// not converted, reported when set
+	Command    any `yaml:"command"`
+	Entrypoint any `yaml:"entrypoint"`
+	Volumes    any `yaml:"volumes"`
+	Secrets    any `yaml:"secrets"`
+	Configs    any `yaml:"configs"`
+	Privileged any `yaml:"privileged"`
+}
+
+type composeHealthcheck struct {
+	Test     composeList `yaml:"test"`
+	Interval string      `yaml:"interval"`
+	Disable  bool        `yaml:"disable"`
+}
+
+// composeMapping is a map written as a mapping or as a list of KEY=VALUE, keys without a
+// value take it from the environment like docker compose does
+type composeMapping map[string]string
+
+func (m *composeMapping) UnmarshalYAML(node *yaml.Node) error {
+	*m = make(composeMapping)
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var entries []string
+		if err := node.Decode(&entries); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			key, value, ok := strings.Cut(entry, "=")
+			if !ok {
+				value = os.Getenv(key)
+			}
+			(*m)[key] = value
+		}
+	case yaml.MappingNode:
+		var entries map[string]*string
+		if err := node.Decode(&entries); err != nil {
+			return err
+		}
+		for key, value := range entries {
+			if value == nil {
+				(*m)[key] = os.Getenv(key)
+				continue
+			}
+			(*m)[key] = *value
+		}
+	default:
+		return fmt.Errorf("line %d: expected a mapping or a list of KEY=VALUE", node.Line)
+	}
+	return nil
+}
+
+// composeList is a list which may be written as a single string, or as the keys of a
+// mapping like the long syntax of depends_on
+type composeList []string
+
+func (l *composeList) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*l = composeList{node.Value}
+	case yaml.SequenceNode:
+		var entries []string
+		if err := node.Decode(&entries); err != nil {
+			return err
+		}
+		*l = entries
+	case yaml.MappingNode:
+		*l = nil
+		for i := 0; i < len(node.Content); i += 2 {
+			*l = append(*l, node.Content[i].Value)
+		}
+	default:
+		return fmt.Errorf("line %d: expected a string or a list", node.Line)
+	}
+	return nil
+}
+
+// convertedService is the spec of a compose service or Kubernetes Deployment, and what
+// could not be converted
+type convertedService struct {
+	spec     *pb.DeployRequest
+	warnings []string
+	err      error // the service cannot be converted
+}
+
+// convertedProject are the converted services of a file, by name
+type convertedProject struct {
+	name     string // compose project, empty when the file does not name one
+	services map[string]*convertedService
+	warnings []string // about the file rather than one of its services
+}
+
+func (p *convertedProject) warn(format string, args ...any) {
+	p.warnings = append(p.warnings, fmt.Sprintf(format, args...))
+}
+
+// runConvert handles `cli convert -f docker-compose.yml` and `cli convert -f manifests.yaml`,
+// it prints or writes the specs of the converted services, or deploys them as a stack
+func runConvert(args []string) {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	var (
+		file   = fs.String("f", "docker-compose.yml", "Compose file, or Kubernetes manifests")
+		from   = fs.String("from", "", "Format of the file: compose, kubernetes (default: detected)")
+		outDir = fs.String("o", "", "Directory the specs are written to as <service>.yaml (default: print them)")
+		domain = fs.String("domain", "", "Domain the compose services publishing port 80 are routed under as <service>.<domain>")
+		tenant = fs.String("tenant", "", "Tenant owning the applications")
+		deploy = fs.Bool("deploy", false, "Deploy the services as a stack instead of printing their specs")
+		name   = fs.String("name", "", "Name of the stack (default: the compose project name or directory)")
+		server = fs.String("server", "localhost:50051", "gRPC server address")
+	)
+	var connFlags connection
+	connFlags.register(fs, "")
+	_ = fs.Parse(args)
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *file, err)
+	}
+	format := *from
+	if format == "" {
+		format = detectFormat(data)
+	}
+
+	var project *convertedProject
+	switch format {
+	case "compose":
+		project, err = convertCompose(data, filepath.Dir(*file), *domain, *tenant)
+	case "kubernetes":
+		project, err = convertKubernetes(data, *tenant)
+	default:
+		log.Fatalf("-from must be compose or kubernetes")
+	}
+	if err != nil {
+		log.Fatalf("Failed to convert %s: %v", *file, err)
+	}
+	if len(project.services) == 0 {
+		log.Fatalf("%s has no services to convert", *file)
+	}
+
+	services := make([]string, 0, len(project.services))
+	for service := range project.services {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	for _, warning := range project.warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+	failed := false
+	for _, service := range services {
+		result := project.services[service]
+		for _, warning := range result.warnings {
+			fmt.Fprintf(os.Stderr, "%s: warning: %s\n", service, warning)
+		}
+		if result.err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", service, result.err)
+			failed = true
+			continue
+		}
+		for _, err := range api.ValidateSpec(result.spec) {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", service, err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+
+	if *deploy {
+		stack := *name
+		if stack == "" {
+			stack = project.name
+		}
+		if stack == "" {
+			absolute, _ := filepath.Abs(*file)
+			stack = filepath.Base(filepath.Dir(absolute))
+		}
+		deployConverted(*server, connFlags.dialOptions(), stack, services, project.services)
+		return
+	}
+
+	for i, service := range services {
+		data, err := specYAML(project.services[service].spec)
+		if err != nil {
+			log.Fatalf("Failed to encode the spec of %s: %v", service, err)
+		}
+
+		if *outDir == "" {
+			if i > 0 {
+				fmt.Println("---")
+			}
+			fmt.Print(string(data))
+			continue
+		}
+		path := filepath.Join(*outDir, service+".yaml")
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		fmt.Printf("✓ %s\n", path)
+	}
+}
+
+// detectFormat tells Kubernetes manifests, which declare their kind, from compose files
+func detectFormat(data []byte) string {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+		}
+		if err := decoder.Decode(&doc); err != nil {
+			return "compose"
+		}
+		if doc.APIVersion != "" && doc.Kind != "" {
+			return "kubernetes"
+		}
+	}
+}
+
+// convertCompose converts every service of a compose file after interpolating its variables
+func convertCompose(data []byte, dir, domain, tenant string) (*convertedProject, error) {
+	compose := &composeFile{}
+	if err := yaml.Unmarshal([]byte(interpolate(string(data))), compose); err != nil {
+		return nil, err
+	}
+
+	project := &convertedProject{name: compose.Name, services: make(map[string]*convertedService)}
+	for name, service := range compose.Services {
+		result, err := convertService(name, service, dir, domain, tenant)
+		if err != nil {
+			result = &convertedService{err: err}
+		}
+		project.services[name] = result
+	}
+	return project, nil
+}
+
+// composeVariable matches $$, $VAR, ${VAR}, ${VAR:-default} and ${VAR-default}
+var composeVariable = regexp.MustCompile(`\$\$|\$([A-Za-z_][A-Za-z0-9_]*)|\$\{([A-Za-z_][A-Za-z0-9_]*)(?:(:?-)([^}]*))?\}`)
+
+// interpolate substitutes the variables of a compose file from the environment
+func interpolate(text string) string {
+	return composeVariable.ReplaceAllStringFunc(text, func(match string) string {
+		if match == "$$" {
+			return "$"
+		}
+		groups := composeVariable.FindStringSubmatch(match)
+		name := groups[1] + groups[2]
+		value, set := os.LookupEnv(name)
+		switch groups[3] {
+		case ":-":
+			if value == "" {
+				return groups[4]
+			}
+		case "-":
+			if !set {
+				return groups[4]
+			}
+		}
+		return value
+	})
+}
+
+// convertService maps a compose service onto a DeployRequest: image, replicas, resources,
+// environment, the published port 80, the HTTP healthcheck and depends_on
+func convertService(name string, service composeService, dir, domain, tenant string) (*convertedService, error) {
+	if service.Image == "" {
+		if service.Build != nil {
+			return nil, fmt.Errorf("the service is built by compose, push the image and set image")
+		}
+		return nil, fmt.Errorf("image must be set")
+	}
+
+	result := &convertedService{spec: &pb.DeployRequest{
+		Name:        name,
+		Image:       service.Image,
+		Replicas:    1,
+		Cpu:         0.1,
+		Memory:      128,
+		NetworkMode: pb.NetworkMode_NETWORK_MODE_BRIDGE,
+		Tenant:      tenant,
+		DependsOn:   service.DependsOn,
+	}}
+	spec := result.spec
+	warn := func(format string, args ...any) {
+		result.warnings = append(result.warnings, fmt.Sprintf(format, args...))
+	}
+
+	if service.Deploy.Replicas != nil {
+		spec.Replicas = *service.Deploy.Replicas
+	}
+	if cpus := firstOf(service.Deploy.Resources.Limits.CPUs, service.CPUs); cpus != "" {
+		cpu, err := strconv.ParseFloat(cpus, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpus %q", cpus)
+		}
+		spec.Cpu = cpu
+	}
+	if memory := firstOf(service.Deploy.Resources.Limits.Memory, service.MemLimit); memory != "" {
+		mb, err := parseComposeMemory(memory)
+		if err != nil {
+			return nil, err
+		}
+		spec.Memory = mb
+	}
+	if service.NetworkMode == "host" {
+		spec.NetworkMode = pb.NetworkMode_NETWORK_MODE_HOST
+	} else if service.NetworkMode != "" {
+		warn("network_mode %s is not converted, the application runs in bridge mode", service.NetworkMode)
+	}
+
+	environment := make(map[string]string)
+	for _, envFile := range service.EnvFile {
+		values, err := readEnvFile(filepath.Join(dir, envFile))
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range values {
+			environment[key] = value
+		}
+	}
+	for key, value := range service.Environment {
+		environment[key] = value
+	}
+	if len(environment) > 0 {
+		spec.Env = environment
+	}
+
+	routed := false
+	for _, port := range service.Ports {
+		target, protocol, err := composePort(&port)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case protocol != "tcp":
+			warn("port %d/%s is not exposed, only HTTP on port %d is routed", target, protocol, composeRoutedPort)
+		case target != composeRoutedPort:
+			warn("port %d is not exposed, only HTTP on port %d is routed", target, composeRoutedPort)
+		case domain == "":
+			warn("port %d is not routed, pass -domain to route it", target)
+		default:
+			routed = true
+		}
+	}
+	if routed {
+		spec.Traefik = &pb.TraefikConfig{Enable: true, Host: name + "." + domain}
+	}
+
+	if check := service.Healthcheck; check != nil && !check.Disable && (len(check.Test) == 0 || check.Test[0] != "NONE") {
+		path, ok := healthcheckPath(check.Test)
+		switch {
+		case !ok:
+			warn("the healthcheck is not converted, only HTTP checks of port %d are", composeRoutedPort)
+		case !routed:
+			warn("the healthcheck is not converted, it is run by Traefik which does not route the service")
+		default:
+			spec.Traefik.HealthCheckPath = path
+			spec.Traefik.HealthCheckInterval = check.Interval
+		}
+	}
+
+	unsupported := map[string]any{
+		"command":    service.Command,
+		"entrypoint": service.Entrypoint,
+		"volumes":    service.Volumes,
+		"secrets":    service.Secrets,
+		"configs":    service.Configs,
+		"privileged": service.Privileged,
+	}
+	keys := make([]string, 0, len(unsupported))
+	for key, value := range unsupported {
+		if value != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		warn("%s is not converted", key)
+	}
+
+	return result, nil
+}
+
+// firstOf returns the first non-empty value
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// composePort returns the container port and protocol of a ports entry in the short
+// syntax, e.g. 8080:80/tcp, or in the long syntax with target and protocol
+func composePort(node *yaml.Node) (int, string, error) {
+	if node.Kind == yaml.MappingNode {
+		var long struct {
+			Target   int    `yaml:"target"`
+			Protocol string `yaml:"protocol"`
+		}
+		if err := node.Decode(&long); err != nil {
+			return 0, "", err
+		}
+		return long.Target, firstOf(long.Protocol, "tcp"), nil
+	}
+
+	spec, protocol, _ := strings.Cut(node.Value, "/")
+	target := spec[strings.LastIndex(spec, ":")+1:]
+	port, err := strconv.Atoi(target)
+	if err != nil {
+		return 0, "", fmt.Errorf("line %d: invalid port %q, port ranges are not supported", node.Line, node.Value)
+	}
+	return port, firstOf(protocol, "tcp"), nil
+}
+
+// healthcheckURL matches the request of a curl or wget healthcheck to the routed port
+var healthcheckURL = regexp.MustCompile(`https?://(?:localhost|127\.0\.0\.1)(?::(\d+))?(/[^\s'"|;&]*)?`)
+
+// healthcheckPath returns the path of an HTTP healthcheck of port 80
+func healthcheckPath(test []string) (string, bool) {
+	match := healthcheckURL.FindStringSubmatch(strings.Join(test, " "))
+	if match == nil || (match[1] != "" && match[1] != strconv.Itoa(composeRoutedPort)) {
+		return "", false
+	}
+	return firstOf(match[2], "/"), true
+}
+
+// parseComposeMemory parses a compose byte value, e.g. 512m or 1g, into MB
+func parseComposeMemory(value string) (int64, error) {
+	units := map[string]int64{"b": 1, "k": 1 << 10, "kb": 1 << 10, "m": 1 << 20, "mb": 1 << 20, "g": 1 << 30, "gb": 1 << 30}
+
+	lower := strings.ToLower(value)
+	number := strings.TrimRight(lower, "bkmg")
+	unit := int64(1)
+	if suffix := lower[len(number):]; suffix != "" {
+		var ok bool
+		if unit, ok = units[suffix]; !ok {
+			return 0, fmt.Errorf("invalid memory %q", value)
+		}
+	}
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil || amount <= 0 {
+		return 0, fmt.Errorf("invalid memory %q", value)
+	}
+	return max(int64(amount*float64(unit))>>20, 1), nil
+}
+
+// readEnvFile reads the KEY=VALUE lines of an env_file, skipping comments and blank lines
+func readEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, _ := strings.Cut(line, "=")
+		values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return values, scanner.Err()
+}
+
+// specYAML encodes a spec in the YAML format of the spec files, fields in their proto order
+func specYAML(spec *pb.DeployRequest) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is YAML, its flow style is reset to the block style of spec files
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var blockStyle func(node *yaml.Node)
+	blockStyle = func(node *yaml.Node) {
+		node.Style = 0
+		for _, child := range node.Content {
+			blockStyle(child)
+		}
+	}
+	blockStyle(&doc)
+
+	// protojson quotes 64-bit integers, spec files write them as numbers
+	fields := spec.ProtoReflect().Descriptor().Fields()
+	if root := doc.Content[0]; root.Kind == yaml.MappingNode {
+		for i := 0; i < len(root.Content); i += 2 {
+			field := fields.ByName(protoreflect.Name(root.Content[i].Value))
+			if field != nil && field.Kind() == protoreflect.Int64Kind && !field.IsList() {
+				root.Content[i+1].Tag = "!!int"
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// deployConverted deploys the converted services as a stack, depends_on orders its stages
+func deployConverted(server string, options []grpc.DialOption, stack string, services []string, converted map[string]*convertedService) {
+	req := &pb.DeployStackRequest{Name: stack}
+	for _, service := range services {
+		spec := converted[service].spec
+		req.Applications = append(req.Applications, &pb.StackApplication{Spec: spec, DependsOn: spec.DependsOn})
+	}
+
+	conn, err := grpc.NewClient(server, options...)
+	if err != nil {
+		log.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	// every stage waits for its applications to become healthy
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	fmt.Printf("Deploying stack '%s' with %d applications...\n", req.Name, len(req.Applications))
+	resp, err := pb.NewControlPlaneClient(conn).DeployStack(ctx, req)
+	if err != nil {
+		log.Fatalf("Stack deployment failed: %v", err)
+	}
+
+	printStack(resp)
+	if resp.Status != "SUCCEEDED" {
+		os.Exit(1)
+	}
+}
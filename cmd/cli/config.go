@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+func getConfig(ctx context.Context, client pb.ControlPlaneClient, name string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for config action")
+	}
+
+	resp, err := client.GetApplicationConfig(ctx, &pb.GetApplicationConfigRequest{DeploymentId: name})
+	if err != nil {
+		log.Fatalf("Failed to get config: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("%s", resp.Message)
+	}
+	printConfig(resp)
+}
+
+func setConfig(ctx context.Context, client pb.ControlPlaneClient, name string, set, unset []string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for set-config action")
+	}
+	if len(set) == 0 && len(unset) == 0 {
+		log.Fatalf("-set or -unset must be provided for set-config action")
+	}
+
+	resp, err := client.SetApplicationConfig(ctx, &pb.SetApplicationConfigRequest{
+		DeploymentId: name,
+		Values:       parseMeta(set),
+		DeleteKeys:   unset,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set config: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("%s", resp.Message)
+	}
+	fmt.Println(resp.Message)
+	printConfig(resp)
+}
+
+func printConfig(resp *pb.ApplicationConfigResponse) {
+	keys := make([]string, 0, len(resp.Values))
+	for key := range resp.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("Prefix: %s\n", resp.Prefix)
+	for _, key := range keys {
+		fmt.Printf("  %s=%s\n", key, resp.Values[key])
+	}
+}
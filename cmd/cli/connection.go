@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/iuliansafta/control-plane/pkg/api"
+)
+
+// connection are the flags of the connection to the controller, its transport security,
+// API token and namespace, shared by every command connecting to one
+type connection struct {
+	tls       bool
+	ca        string
+	cert      string
+	key       string
+	token     string
+	namespace string
+}
+
+// register adds -tls, -ca, -cert, -key, -token and -namespace to the flag set, their
+// current values are the defaults. The certificate flags are prefixed where the names are
+// taken, e.g. -tls-cert, -namespace is left to the commands having one.
+func (c *connection) register(fs *flag.FlagSet, prefix string) {
+	if c.token == "" {
+		c.token = os.Getenv("CONTROLPLANE_TOKEN")
+	}
+	if c.namespace == "" {
+		c.namespace = os.Getenv("CONTROLPLANE_NAMESPACE")
+	}
+	fs.BoolVar(&c.tls, "tls", c.tls, "Connect to the controller over TLS, implied by -ca and -"+prefix+"cert")
+	fs.StringVar(&c.ca, "ca", c.ca, "PEM CA bundle verifying the controller's certificate (default: the system roots)")
+	fs.StringVar(&c.cert, prefix+"cert", c.cert, "PEM client certificate for controllers requiring mutual TLS")
+	fs.StringVar(&c.key, prefix+"key", c.key, "PEM key of the client certificate")
+	fs.StringVar(&c.token, "token", c.token, "API token of the controller (env: CONTROLPLANE_TOKEN)")
+	if fs.Lookup("namespace") == nil {
+		fs.StringVar(&c.namespace, "namespace", c.namespace, "Namespace of the applications, * lists every namespace (env: CONTROLPLANE_NAMESPACE, default: default)")
+	}
+}
+
+// dialOptions returns the transport credentials of the flags, plaintext without them, and
+// sends the API token and namespace with every RPC
+func (c *connection) dialOptions() []grpc.DialOption {
+	creds := insecure.NewCredentials()
+	if c.tls || c.ca != "" || c.cert != "" || c.key != "" {
+		var err error
+		if creds, err = api.ClientCredentials(c.ca, c.cert, c.key); err != nil {
+			log.Fatalf("Invalid TLS flags: %v", err)
+		}
+	}
+	options := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if c.token != "" {
+		options = append(options, grpc.WithPerRPCCredentials(api.TokenCredentials(c.token)))
+	}
+	if c.namespace != "" {
+		options = append(options,
+			grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				setNamespace(req, c.namespace)
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}),
+			grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				stream, err := streamer(ctx, desc, cc, method, opts...)
+				if err != nil {
+					return nil, err
+				}
+				return &namespaceStream{ClientStream: stream, namespace: c.namespace}, nil
+			}),
+		)
+	}
+	return options
+}
+
+// setNamespace sets the namespace of a request having one unless it was set already
+func setNamespace(req any, namespace string) {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return
+	}
+	request := message.ProtoReflect()
+	field := request.Descriptor().Fields().ByName("namespace")
+	if field != nil && !request.Has(field) {
+		request.Set(field, protoreflect.ValueOfString(namespace))
+	}
+}
+
+// namespaceStream sets the namespace of the requests of a streaming RPC
+type namespaceStream struct {
+	grpc.ClientStream
+	namespace string
+}
+
+func (s *namespaceStream) SendMsg(m any) error {
+	setNamespace(m, s.namespace)
+	return s.ClientStream.SendMsg(m)
+}
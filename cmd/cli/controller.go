@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// runController deploys or upgrades the controllers running as a Nomad job, or shows them
+func runController(args []string) {
+	if len(args) < 1 || (args[0] != "deploy" && args[0] != "status") {
+		printAdminUsage()
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("admin controller "+args[0], flag.ExitOnError)
+	var (
+		server        = fs.String("server", "localhost:50051", "gRPC server address of a controller")
+		image         = fs.String("image", "", "Controller image, required for the first deployment")
+		count         = fs.Int("count", 0, "Number of controllers (default: 2, or the deployed count)")
+		replaceArgs   = fs.Bool("replace-args", false, "Replace the deployed controller flags with -arg, even when none is given")
+		region        = fs.String("region", "", "Nomad region")
+		nodePool      = fs.String("node-pool", "", "Only run on the clients of this node pool")
+		healthTimeout = fs.Duration("health-timeout", 0, "How long a new controller has to become healthy (default: 3m)")
+		wait          = fs.Bool("wait", true, "Wait for the rollout and report whether it was reverted")
+		timeout       = fs.Duration("timeout", 10*time.Minute, "How long to wait for the rollout")
+		controllerArg stringList
+		datacenters   stringList
+	)
+	fs.Var(&controllerArg, "arg", "Controller flag, e.g. -arg=-nomad=http://nomad.service.consul:4646 (repeatable, default: the deployed flags)")
+	fs.Var(&datacenters, "datacenter", "Datacenter to run in (repeatable, default: dc1)")
+	var connFlags connection
+	connFlags.register(fs, "")
+	_ = fs.Parse(args[1:])
+
+	conn, err := grpc.NewClient(*server, connFlags.dialOptions()...)
+	if err != nil {
+		log.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewControlPlaneClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout+30*time.Second)
+	defer cancel()
+
+	if args[0] == "status" {
+		resp, err := client.GetApplicationStatus(ctx, &pb.StatusRequest{DeploymentId: nomad.ControllerJobID})
+		if err != nil {
+			log.Fatalf("Failed to get the status of the controllers: %v", err)
+		}
+		printStatus(os.Stdout, resp)
+		return
+	}
+
+	// the rollout running before the deploy, the new one has another ID
+	previous := ""
+	if status, err := client.GetApplicationStatus(ctx, &pb.StatusRequest{DeploymentId: nomad.ControllerJobID}); err == nil && status.Rollout != nil {
+		previous = status.Rollout.DeploymentId
+	}
+
+	started := time.Now()
+	resp, err := pb.NewAdminClient(conn).DeployController(ctx, &pb.DeployControllerRequest{
+		Image:                *image,
+		Count:                int32(*count),
+		Args:                 controllerArg,
+		ReplaceArgs:          *replaceArgs,
+		Region:               *region,
+		Datacenters:          datacenters,
+		NodePool:             *nodePool,
+		HealthTimeoutSeconds: int32(healthTimeout.Seconds()),
+	})
+	if err != nil {
+		log.Fatalf("Controller deployment failed: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Controller deployment failed: %s", resp.Message)
+	}
+
+	fmt.Printf("%s\n", resp.Message)
+	fmt.Printf("Evaluation: %s\n", resp.EvalId)
+	fmt.Printf("Job Version: %d\n", resp.JobVersion)
+	if !*wait {
+		return
+	}
+
+	// the controllers serving the CLI may be replaced during the rollout, requests are
+	// retried on the next poll
+	rollout, status := waitForRollout(ctx, client, nomad.ControllerJobID, previous, started, *timeout, 5*time.Second)
+	switch status {
+	case "successful", "unchanged":
+		fmt.Printf("✓ Controllers run %s\n", resp.Image)
+	case "timeout":
+		log.Fatalf("The rollout of the controllers did not finish within %s", *timeout)
+	default:
+		fmt.Printf("✗ The rollout of the controllers is %s\n", status)
+		if rollout != nil {
+			printRolloutOutcome(os.Stdout, rollout)
+		}
+		if resp.PreviousImage != "" {
+			fmt.Printf("  The controllers with %s keep serving\n", resp.PreviousImage)
+		}
+		os.Exit(1)
+	}
+}
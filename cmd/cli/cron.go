@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+func cronRuns(ctx context.Context, client pb.ControlPlaneClient, name string, limit int) {
+	if name == "" {
+		log.Fatalf("-name must be provided for cron-runs action")
+	}
+
+	resp, err := client.ListCronRuns(ctx, &pb.CronRunsRequest{Name: name, Limit: int32(limit)})
+	if err != nil {
+		log.Fatalf("Failed to list cron runs: %v", err)
+	}
+
+	fmt.Printf("\nCron job: %s\n", resp.Name)
+	fmt.Printf("Schedule: %s (%s)\n", resp.Schedule, resp.TimeZone)
+	if resp.Paused {
+		fmt.Printf("Paused: yes\n")
+	} else if resp.NextRun > 0 {
+		fmt.Printf("Next run: %s\n", time.Unix(resp.NextRun, 0).Format(time.RFC3339))
+	}
+
+	if len(resp.Runs) > 0 {
+		fmt.Printf("\nRuns:\n")
+		for _, run := range resp.Runs {
+			duration := "-"
+			if run.FinishedAt > 0 {
+				duration = (time.Duration(run.DurationMs) * time.Millisecond).String()
+			}
+			fmt.Printf("  - %s %s: %s in %s\n",
+				time.Unix(run.StartedAt, 0).Format(time.RFC3339), run.JobId, run.Status, duration)
+		}
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
+
+func cronTrigger(ctx context.Context, client pb.ControlPlaneClient, name string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for cron-trigger action")
+	}
+
+	resp, err := client.TriggerCronJob(ctx, &pb.CronTriggerRequest{Name: name})
+	if err != nil {
+		log.Fatalf("Failed to trigger cron job: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("%s", resp.Message)
+	}
+
+	fmt.Printf("Evaluation: %s\n", resp.EvalId)
+	fmt.Printf("Message: %s\n", resp.Message)
+}
+
+func cronSetPaused(ctx context.Context, client pb.ControlPlaneClient, name string, paused bool) {
+	if name == "" {
+		log.Fatalf("-name must be provided to pause or resume a cron job")
+	}
+
+	resp, err := client.SetCronPaused(ctx, &pb.CronPauseRequest{Name: name, Paused: paused})
+	if err != nil {
+		log.Fatalf("Failed to update cron schedule: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("%s", resp.Message)
+	}
+
+	fmt.Printf("%s\n", resp.Message)
+}
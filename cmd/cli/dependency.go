@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+func getImpact(ctx context.Context, client pb.ControlPlaneClient, name string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for impact action")
+	}
+
+	resp, err := client.GetImpact(ctx, &pb.ImpactRequest{Name: name})
+	if err != nil {
+		log.Fatalf("Failed to get impact: %v", err)
+	}
+
+	fmt.Printf("\nApplication: %s\n", resp.Name)
+	if len(resp.Dependencies) > 0 {
+		fmt.Printf("Depends on: %s\n", strings.Join(resp.Dependencies, ", "))
+	}
+	if len(resp.Consumers) > 0 {
+		fmt.Printf("\nConsumers:\n")
+		for _, consumer := range resp.Consumers {
+			kind := "direct"
+			if consumer.Depth > 1 {
+				kind = fmt.Sprintf("transitive, depth %d", consumer.Depth)
+			}
+			fmt.Printf("  - %s (%s): %s\n", consumer.Name, kind, strings.Join(consumer.Via, " -> "))
+		}
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
+
+// dependencyGraph prints the graph in Graphviz DOT format
+func dependencyGraph(ctx context.Context, client pb.ControlPlaneClient) {
+	resp, err := client.GetDependencyGraph(ctx, &pb.DependencyGraphRequest{})
+	if err != nil {
+		log.Fatalf("Failed to get dependency graph: %v", err)
+	}
+
+	fmt.Println("digraph dependencies {")
+	for _, edge := range resp.Edges {
+		fmt.Printf("  %q -> %q;\n", edge.Application, edge.DependsOn)
+	}
+	fmt.Println("}")
+}
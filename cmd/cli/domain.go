@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+func addDomain(ctx context.Context, client pb.ControlPlaneClient, tenant, domain string) {
+	if tenant == "" || domain == "" {
+		log.Fatalf("-tenant and -domain must be provided for add-domain action")
+	}
+
+	resp, err := client.AddDomain(ctx, &pb.AddDomainRequest{Tenant: tenant, Domain: domain})
+	if err != nil {
+		log.Fatalf("Failed to add domain: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to add domain: %s", resp.Message)
+	}
+
+	printDomain(resp.Domain)
+	fmt.Printf("Message: %s\n", resp.Message)
+}
+
+func verifyDomain(ctx context.Context, client pb.ControlPlaneClient, tenant, domain string) {
+	if tenant == "" || domain == "" {
+		log.Fatalf("-tenant and -domain must be provided for verify-domain action")
+	}
+
+	resp, err := client.VerifyDomain(ctx, &pb.VerifyDomainRequest{Tenant: tenant, Domain: domain})
+	if err != nil {
+		log.Fatalf("Verification failed: %v", err)
+	}
+	if resp.Domain != nil {
+		printDomain(resp.Domain)
+	}
+	if !resp.Success {
+		log.Fatalf("Verification failed: %s", resp.Message)
+	}
+
+	fmt.Printf("Message: %s\n", resp.Message)
+}
+
+func listDomains(ctx context.Context, client pb.ControlPlaneClient, tenant string) {
+	resp, err := client.ListDomains(ctx, &pb.ListDomainsRequest{Tenant: tenant})
+	if err != nil {
+		log.Fatalf("Failed to list domains: %v", err)
+	}
+
+	fmt.Printf("\nDomains:\n")
+	for _, domain := range resp.Domains {
+		status := "pending"
+		if domain.Verified {
+			status = "verified " + time.Unix(domain.VerifiedAt, 0).Format(time.RFC3339)
+		}
+		fmt.Printf("  - %s (tenant %s): %s\n", domain.Name, domain.Tenant, status)
+		if !domain.Verified && domain.LastError != "" {
+			fmt.Printf("    %s\n", domain.LastError)
+		}
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
+
+func printDomain(domain *pb.Domain) {
+	fmt.Printf("Domain: %s\n", domain.Name)
+	fmt.Printf("Tenant: %s\n", domain.Tenant)
+	fmt.Printf("Verified: %t\n", domain.Verified)
+	if !domain.Verified {
+		fmt.Printf("TXT record: %s\n", domain.RecordName)
+		fmt.Printf("TXT value: %s\n", domain.RecordValue)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// runDR promotes the disaster recovery standby or shows how far replication went
+func runDR(args []string) {
+	if len(args) < 1 || (args[0] != "promote" && args[0] != "status") {
+		printAdminUsage()
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("admin dr "+args[0], flag.ExitOnError)
+	server := fs.String("server", "localhost:50051", "gRPC server address of a controller of the site")
+	var connFlags connection
+	connFlags.register(fs, "")
+	_ = fs.Parse(args[1:])
+
+	conn, err := grpc.NewClient(*server, connFlags.dialOptions()...)
+	if err != nil {
+		log.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewAdminClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if args[0] == "promote" {
+		resp, err := client.PromoteStandby(ctx, &pb.PromoteStandbyRequest{})
+		if err != nil {
+			log.Fatalf("Promotion failed: %v", err)
+		}
+		if !resp.Success {
+			log.Fatalf("Promotion failed: %s", resp.Message)
+		}
+
+		fmt.Printf("✓ %s\n", resp.Message)
+		fmt.Printf("  Replicated Index: %d\n", resp.ReplicatedIndex)
+		if resp.ReplicatedAt > 0 {
+			fmt.Printf("  Replicated At: %s\n", time.Unix(resp.ReplicatedAt, 0).Format(time.RFC3339))
+		}
+		return
+	}
+
+	resp, err := client.GetReplicationStatus(ctx, &pb.GetReplicationStatusRequest{})
+	if err != nil {
+		log.Fatalf("Failed to get replication status: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to get replication status: %s", resp.Message)
+	}
+
+	fmt.Printf("Role: %s\n", resp.Role)
+	if resp.Role == "disabled" {
+		return
+	}
+	if resp.Peer != "" {
+		fmt.Printf("Standby: %s\n", resp.Peer)
+	}
+	fmt.Printf("Index: %d\n", resp.Index)
+	fmt.Printf("Replicated Index: %d\n", resp.ReplicatedIndex)
+	if resp.ReplicatedAt > 0 {
+		fmt.Printf("Replicated At: %s (%s ago)\n", time.Unix(resp.ReplicatedAt, 0).Format(time.RFC3339),
+			time.Since(time.Unix(resp.ReplicatedAt, 0)).Round(time.Second))
+	}
+	if resp.PromotedAt > 0 {
+		fmt.Printf("Promoted At: %s\n", time.Unix(resp.PromotedAt, 0).Format(time.RFC3339))
+	}
+	if resp.LastError != "" {
+		fmt.Printf("Last Error: %s\n", resp.LastError)
+	}
+}
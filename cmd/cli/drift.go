@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+func listImageDrift(ctx context.Context, client pb.ControlPlaneClient, name string, driftedOnly bool) {
+	resp, err := client.ListImageDrift(ctx, &pb.ImageDriftRequest{Name: name, DriftedOnly: driftedOnly})
+	if err != nil {
+		log.Fatalf("Failed to list image drift: %v", err)
+	}
+
+	fmt.Printf("\nDeployed images:\n")
+	for _, image := range resp.Images {
+		fmt.Printf("  - %s: %s\n", image.Application, image.Image)
+		fmt.Printf("    Deployed: %s (%s)\n", image.DeployedDigest, time.Unix(image.DeployedAt, 0).Format(time.RFC3339))
+		if image.TagDigest == "" {
+			continue
+		}
+		fmt.Printf("    Tag moved: %s (since %s)\n", image.TagDigest, time.Unix(image.MovedAt, 0).Format(time.RFC3339))
+		for _, alloc := range image.DriftedAllocations {
+			fmt.Printf("    Drifted allocation: %s\n", alloc)
+		}
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
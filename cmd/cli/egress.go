@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// parseEgressRule parses an -egress flag, "service:<name>" for a Consul service or
+// "[udp:]<cidr>[@<port>,...]" for an address range, e.g. "10.0.0.0/8@443,8443"
+func parseEgressRule(expr string) (*pb.EgressRule, error) {
+	if service, ok := strings.CutPrefix(expr, "service:"); ok {
+		if service == "" {
+			return nil, fmt.Errorf("invalid egress %q: service cannot be empty", expr)
+		}
+		return &pb.EgressRule{Service: service}, nil
+	}
+
+	rule := &pb.EgressRule{}
+	for _, protocol := range []string{"tcp", "udp"} {
+		if cidr, ok := strings.CutPrefix(expr, protocol+":"); ok {
+			rule.Protocol, expr = protocol, cidr
+		}
+	}
+
+	cidr, ports, hasPorts := strings.Cut(expr, "@")
+	if !strings.Contains(cidr, "/") {
+		return nil, fmt.Errorf("invalid egress %q, expected service:<name> or [udp:]<cidr>[@<port>,...]", expr)
+	}
+	rule.Cidr = cidr
+
+	if hasPorts {
+		for _, value := range strings.Split(ports, ",") {
+			port, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid egress %q: invalid port %q", expr, value)
+			}
+			rule.Ports = append(rule.Ports, int32(port))
+		}
+	}
+	return rule, nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iuliansafta/control-plane/pkg/manifest"
+)
+
+// runExplain handles `cli explain [-recursive] deployspec[.field...]`, it documents the fields
+// of the spec from the comments of the proto without contacting the server
+func runExplain(args []string) {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	recursive := fs.Bool("recursive", false, "List the fields of the fields, without their descriptions")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: cli explain [-recursive] deployspec[.<field>...], e.g. deployspec.traefik.host")
+		os.Exit(2)
+	}
+	doc, err := manifest.Explain(fs.Arg(0), *recursive)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(doc)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// readPayload returns the payload from -payload-file when set, -payload otherwise
+func readPayload(payload, payloadFile string) []byte {
+	if payloadFile == "" {
+		return []byte(payload)
+	}
+
+	data, err := os.ReadFile(payloadFile)
+	if err != nil {
+		log.Fatalf("Failed to read payload: %v", err)
+	}
+	return data
+}
+
+func parseMeta(meta []string) map[string]string {
+	values := make(map[string]string)
+	for _, pair := range meta {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			log.Fatalf("Invalid meta %q: expected key=value", pair)
+		}
+		values[key] = value
+	}
+	return values
+}
+
+func invokeFunction(ctx context.Context, client pb.ControlPlaneClient, name, payload, payloadFile string, meta []string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for invoke action")
+	}
+
+	req := &pb.InvokeRequest{
+		Name:    name,
+		Payload: readPayload(payload, payloadFile),
+		Meta:    parseMeta(meta),
+	}
+
+	fmt.Printf("Invoking function '%s'...\n", name)
+	resp, err := client.InvokeFunction(ctx, req)
+	if err != nil {
+		log.Fatalf("Invocation failed: %v", err)
+	}
+
+	if inv := resp.Invocation; inv != nil {
+		fmt.Printf("Invocation: %s\n", inv.InvocationId)
+		fmt.Printf("Status: %s (exit code %d)\n", inv.Status, inv.ExitCode)
+		fmt.Printf("Duration: %s (queued %s)\n",
+			time.Duration(inv.DurationMs)*time.Millisecond, time.Duration(inv.QueuedMs)*time.Millisecond)
+	}
+	if resp.Output != "" {
+		fmt.Printf("\nOutput:\n%s\n", resp.Output)
+	}
+	fmt.Printf("Message: %s\n", resp.Message)
+
+	if !resp.Success {
+		os.Exit(1)
+	}
+}
+
+func functionMetrics(ctx context.Context, client pb.ControlPlaneClient, name string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for function-metrics action")
+	}
+
+	resp, err := client.GetFunctionMetrics(ctx, &pb.FunctionMetricsRequest{Name: name})
+	if err != nil {
+		log.Fatalf("Failed to get function metrics: %v", err)
+	}
+
+	fmt.Printf("\nFunction: %s\n", resp.Name)
+	fmt.Printf("Invocations: %d (%d failed, %d in flight)\n", resp.Invocations, resp.Failures, resp.InFlight)
+	fmt.Printf("Duration: p50 %s, p95 %s\n",
+		time.Duration(resp.P50DurationMs)*time.Millisecond, time.Duration(resp.P95DurationMs)*time.Millisecond)
+
+	if len(resp.Recent) > 0 {
+		fmt.Printf("\nRecent invocations:\n")
+		for _, inv := range resp.Recent {
+			fmt.Printf("  - %s %s: %s (exit code %d) in %s\n",
+				time.Unix(inv.StartedAt, 0).Format(time.RFC3339), inv.InvocationId, inv.Status, inv.ExitCode,
+				time.Duration(inv.DurationMs)*time.Millisecond)
+		}
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
+
+func dispatchJob(ctx context.Context, client pb.ControlPlaneClient, name, payload, payloadFile string, meta []string) {
+	if name == "" {
+		log.Fatalf("-name must be provided for dispatch action")
+	}
+
+	req := &pb.DispatchRequest{
+		JobId:   name,
+		Payload: readPayload(payload, payloadFile),
+		Meta:    parseMeta(meta),
+	}
+
+	fmt.Printf("Dispatching job '%s'...\n", name)
+	resp, err := client.DispatchJob(ctx, req)
+	if err != nil {
+		log.Fatalf("Dispatch failed: %v", err)
+	}
+
+	if !resp.Success {
+		log.Fatalf("%s", resp.Message)
+	}
+
+	fmt.Printf("Dispatched job: %s\n", resp.DispatchedJobId)
+	fmt.Printf("Evaluation: %s\n", resp.EvalId)
+	fmt.Printf("Message: %s\n", resp.Message)
+}
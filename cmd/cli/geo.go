@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// parseGeoTarget parses <region>[=<weight>][:<location>,...], e.g. eu-west=2 or eu-west:EU,AF
+func parseGeoTarget(expr string) (*pb.GeoTarget, error) {
+	spec, locations, hasLocations := strings.Cut(expr, ":")
+	region, weight, hasWeight := strings.Cut(spec, "=")
+	if region == "" {
+		return nil, fmt.Errorf("invalid geo target %q, expected <region>[=<weight>][:<location>,...]", expr)
+	}
+
+	target := &pb.GeoTarget{Region: region}
+	if hasWeight {
+		value, err := strconv.ParseInt(weight, 10, 64)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("invalid geo target %q: invalid weight %q", expr, weight)
+		}
+		target.Weight = value
+	}
+	if hasLocations {
+		for _, location := range strings.Split(locations, ",") {
+			target.Locations = append(target.Locations, strings.ToUpper(location))
+		}
+	}
+	return target, nil
+}
+
+func printGeoRegions(w io.Writer, regions []*pb.GeoRegion) {
+	if len(regions) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nGeo Regions:\n")
+	for _, region := range regions {
+		health := "healthy"
+		if !region.Healthy {
+			health = "unhealthy"
+		}
+		routing := "routed"
+		if !region.Routed {
+			routing = "failed over"
+		}
+		fmt.Fprintf(w, "  - %s: %s, %s since %s\n", region.Region, health, routing, time.Unix(region.ChangedAt, 0).Format(time.RFC3339))
+		if region.Reason != "" {
+			fmt.Fprintf(w, "    Reason: %s\n", region.Reason)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// appHealth prints the normalized health of an application, or of all when name is empty
+func appHealth(ctx context.Context, client pb.ControlPlaneClient, name string) {
+	resp, err := client.GetApplicationHealth(ctx, &pb.ApplicationHealthRequest{Name: name})
+	if err != nil {
+		log.Fatalf("Failed to get application health: %v", err)
+	}
+
+	fmt.Printf("\nApplications:\n")
+	for _, health := range resp.Applications {
+		status := strings.TrimPrefix(health.Status.String(), "APPLICATION_HEALTH_")
+		fmt.Printf("  - %s: %s (%s)\n", health.Name, status, health.Reason)
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
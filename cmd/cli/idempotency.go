@@ -0,0 +1,19 @@
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/iuliansafta/control-plane/pkg/api"
+)
+
+// withIdempotencyKey sends the key with every RPC, a retried action returns the result
+// of the first attempt instead of running again. Reads ignore it.
+func withIdempotencyKey(key string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, api.IdempotencyKeyHeader, key)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,435 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// k8sObject is the part of a Deployment, Service or Ingress manifest the conversion
+// understands
+type k8sObject struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec yaml.Node `yaml:"spec"`
+}
+
+type k8sDeployment struct {
+	Replicas *int32 `yaml:"replicas"`
+	Template struct {
+		Metadata struct {
+			Labels map[string]string `yaml:"labels"`
+		} `yaml:"metadata"`
+		Spec struct {
+			Containers     []k8sContainer    `yaml:"containers"`
+			InitContainers []any             `yaml:"initContainers"`
+			NodeSelector   map[string]string `yaml:"nodeSelector"`
+			Volumes        []any             `yaml:"volumes"`
+		} `yaml:"spec"`
+	} `yaml:"template"`
+}
+
+type k8sContainer struct {
+	Name  string `yaml:"name"`
+	Image string `yaml:"image"`
+	Ports []struct {
+		Name          string `yaml:"name"`
+		ContainerPort int    `yaml:"containerPort"`
+	} `yaml:"ports"`
+	Env []struct {
+		Name      string  `yaml:"name"`
+		Value     *string `yaml:"value"`
+		ValueFrom any     `yaml:"valueFrom"`
+	} `yaml:"env"`
+	EnvFrom   []any `yaml:"envFrom"`
+	Resources struct {
+		Limits   map[string]string `yaml:"limits"`
+		Requests map[string]string `yaml:"requests"`
+	} `yaml:"resources"`
+	ReadinessProbe *k8sProbe `yaml:"readinessProbe"`
+	LivenessProbe  *k8sProbe `yaml:"livenessProbe"`
+	Command        []string  `yaml:"command"`
+	Args           []string  `yaml:"args"`
+	VolumeMounts   []any     `yaml:"volumeMounts"`
+}
+
+type k8sProbe struct {
+	HTTPGet *struct {
+		Path string    `yaml:"path"`
+		Port yaml.Node `yaml:"port"`
+	} `yaml:"httpGet"`
+	PeriodSeconds int `yaml:"periodSeconds"`
+}
+
+type k8sService struct {
+	Selector map[string]string `yaml:"selector"`
+	Ports    []struct {
+		Name       string    `yaml:"name"`
+		Port       int       `yaml:"port"`
+		TargetPort yaml.Node `yaml:"targetPort"`
+	} `yaml:"ports"`
+}
+
+type k8sIngress struct {
+	TLS []struct {
+		Hosts []string `yaml:"hosts"`
+	} `yaml:"tls"`
+	Rules []struct {
+		Host string `yaml:"host"`
+		HTTP struct {
+			Paths []struct {
+				Path    string `yaml:"path"`
+				Backend struct {
+					Service struct {
+						Name string `yaml:"name"`
+						Port struct {
+							Number int    `yaml:"number"`
+							Name   string `yaml:"name"`
+						} `yaml:"port"`
+					} `yaml:"service"`
+				} `yaml:"backend"`
+			} `yaml:"paths"`
+		} `yaml:"http"`
+	} `yaml:"rules"`
+}
+
+// k8sRoute is where an Ingress sends a host to
+type k8sRoute struct {
+	host       string
+	path       string
+	ssl        bool
+	service    string
+	port       int    // of the Service
+	portName   string // of the Service, instead of port
+	ingress    string
+	deployment string
+}
+
+// convertKubernetes converts the Deployments of the manifests, e.g. rendered by helm
+// template, into specs. Services and Ingresses route container port 80 through Traefik,
+// every other kind is reported as not converted.
+func convertKubernetes(data []byte, tenant string) (*convertedProject, error) {
+	var objects []k8sObject
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var object k8sObject
+		err := decoder.Decode(&object)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if object.Kind != "" {
+			objects = append(objects, object)
+		}
+	}
+
+	project := &convertedProject{services: make(map[string]*convertedService)}
+	deployments := make(map[string]*k8sDeployment)
+	services := make(map[string]*k8sService)
+	var routes []*k8sRoute
+	var ignored []string
+	for _, object := range objects {
+		name := object.Metadata.Name
+		switch object.Kind {
+		case "Deployment":
+			deployment := &k8sDeployment{}
+			if err := object.Spec.Decode(deployment); err != nil {
+				return nil, fmt.Errorf("deployment %s: %v", name, err)
+			}
+			deployments[name] = deployment
+		case "Service":
+			service := &k8sService{}
+			if err := object.Spec.Decode(service); err != nil {
+				return nil, fmt.Errorf("service %s: %v", name, err)
+			}
+			services[name] = service
+		case "Ingress":
+			ingress := &k8sIngress{}
+			if err := object.Spec.Decode(ingress); err != nil {
+				return nil, fmt.Errorf("ingress %s: %v", name, err)
+			}
+			routes = append(routes, ingressRoutes(name, ingress)...)
+		default:
+			ignored = append(ignored, fmt.Sprintf("%s %s", object.Kind, name))
+		}
+	}
+
+	for name, deployment := range deployments {
+		project.services[name] = convertDeployment(name, deployment, tenant)
+	}
+
+	// an Ingress routes to a Deployment through the Service selecting its pods
+	for _, route := range routes {
+		service, ok := services[route.service]
+		if !ok {
+			project.warn("ingress %s routes %s to service %s, which is not in the manifests", route.ingress, route.host, route.service)
+			continue
+		}
+		for name, deployment := range deployments {
+			if len(service.Selector) > 0 && selects(service.Selector, deployment.Template.Metadata.Labels) {
+				route.deployment = name
+				routeDeployment(project.services[name], deployment, service, route)
+			}
+		}
+		if route.deployment == "" {
+			project.warn("service %s selects no Deployment, ingress %s is not converted", route.service, route.ingress)
+		}
+	}
+
+	sort.Strings(ignored)
+	for _, object := range ignored {
+		project.warn("%s is not converted", object)
+	}
+
+	return project, nil
+}
+
+func ingressRoutes(name string, ingress *k8sIngress) []*k8sRoute {
+	secure := make(map[string]bool)
+	for _, tls := range ingress.TLS {
+		for _, host := range tls.Hosts {
+			secure[host] = true
+		}
+	}
+
+	var routes []*k8sRoute
+	for _, rule := range ingress.Rules {
+		for _, path := range rule.HTTP.Paths {
+			routes = append(routes, &k8sRoute{
+				host:     rule.Host,
+				path:     path.Path,
+				ssl:      secure[rule.Host],
+				service:  path.Backend.Service.Name,
+				port:     path.Backend.Service.Port.Number,
+				portName: path.Backend.Service.Port.Name,
+				ingress:  name,
+			})
+		}
+	}
+	return routes
+}
+
+func selects(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// convertDeployment maps the first container of a Deployment onto a DeployRequest: image,
+// replicas, resources, environment and node selector
+func convertDeployment(name string, deployment *k8sDeployment, tenant string) *convertedService {
+	result := &convertedService{}
+	warn := func(format string, args ...any) {
+		result.warnings = append(result.warnings, fmt.Sprintf(format, args...))
+	}
+
+	pod := deployment.Template.Spec
+	if len(pod.Containers) == 0 {
+		result.err = fmt.Errorf("the Deployment has no containers")
+		return result
+	}
+	container := pod.Containers[0]
+	for _, sidecar := range pod.Containers[1:] {
+		warn("container %s is not converted, only the first container of a pod is", sidecar.Name)
+	}
+
+	spec := &pb.DeployRequest{
+		Name:        name,
+		Image:       container.Image,
+		Replicas:    1,
+		Cpu:         0.1,
+		Memory:      128,
+		NetworkMode: pb.NetworkMode_NETWORK_MODE_BRIDGE,
+		Tenant:      tenant,
+	}
+	result.spec = spec
+
+	if deployment.Replicas != nil {
+		spec.Replicas = *deployment.Replicas
+	}
+	if cpu := firstOf(container.Resources.Limits["cpu"], container.Resources.Requests["cpu"]); cpu != "" {
+		cores, err := parseK8sCPU(cpu)
+		if err != nil {
+			result.err = err
+			return result
+		}
+		spec.Cpu = cores
+	}
+	if memory := firstOf(container.Resources.Limits["memory"], container.Resources.Requests["memory"]); memory != "" {
+		mb, err := parseK8sMemory(memory)
+		if err != nil {
+			result.err = err
+			return result
+		}
+		spec.Memory = mb
+	}
+
+	for _, env := range container.Env {
+		if env.ValueFrom != nil {
+			warn("env %s is not converted, valueFrom is not supported", env.Name)
+			continue
+		}
+		if spec.Env == nil {
+			spec.Env = make(map[string]string)
+		}
+		if env.Value != nil {
+			spec.Env[env.Name] = *env.Value
+		} else {
+			spec.Env[env.Name] = ""
+		}
+	}
+
+	selectors := make([]string, 0, len(pod.NodeSelector))
+	for key := range pod.NodeSelector {
+		selectors = append(selectors, key)
+	}
+	sort.Strings(selectors)
+	for _, key := range selectors {
+		spec.Constraints = append(spec.Constraints, &pb.Constraint{Attribute: "meta." + key, Value: pod.NodeSelector[key]})
+	}
+	if len(selectors) > 0 {
+		warn("the nodeSelector became constraints on the Nomad node meta %s, check the clients set it", strings.Join(selectors, ", "))
+	}
+
+	unsupported := []struct {
+		field string
+		set   bool
+	}{
+		{"command", len(container.Command) > 0},
+		{"args", len(container.Args) > 0},
+		{"envFrom", len(container.EnvFrom) > 0},
+		{"volumeMounts", len(container.VolumeMounts) > 0},
+		{"volumes", len(pod.Volumes) > 0},
+		{"initContainers", len(pod.InitContainers) > 0},
+	}
+	for _, field := range unsupported {
+		if field.set {
+			warn("%s is not converted", field.field)
+		}
+	}
+
+	return result
+}
+
+// routeDeployment routes the Ingress host to the Deployment when the Service sends it to
+// container port 80, the readiness probe of that port becomes the Traefik health check
+func routeDeployment(result *convertedService, deployment *k8sDeployment, service *k8sService, route *k8sRoute) {
+	warn := func(format string, args ...any) {
+		result.warnings = append(result.warnings, fmt.Sprintf(format, args...))
+	}
+	if result.spec == nil {
+		return
+	}
+	container := deployment.Template.Spec.Containers[0]
+
+	target := 0
+	for _, port := range service.Ports {
+		if (route.portName != "" && port.Name == route.portName) || port.Port == route.port || len(service.Ports) == 1 {
+			target = containerPort(container, &port.TargetPort, port.Port)
+			break
+		}
+	}
+	if target != composeRoutedPort {
+		warn("ingress %s is not converted, service %s sends %s to container port %d and only port %d is routed",
+			route.ingress, route.service, route.host, target, composeRoutedPort)
+		return
+	}
+	if result.spec.Traefik != nil {
+		warn("ingress %s is not converted, %s is already routed as %s", route.ingress, route.host, result.spec.Traefik.Host)
+		return
+	}
+
+	traefik := &pb.TraefikConfig{Enable: true, Host: route.host, EnableSsl: route.ssl}
+	if route.path != "" && route.path != "/" {
+		traefik.PathPrefix = route.path
+	}
+	result.spec.Traefik = traefik
+
+	probe := container.ReadinessProbe
+	if probe == nil {
+		probe = container.LivenessProbe
+	}
+	if probe == nil {
+		return
+	}
+	if probe.HTTPGet == nil || containerPort(container, &probe.HTTPGet.Port, 0) != composeRoutedPort {
+		warn("the probe is not converted, only HTTP probes of port %d are", composeRoutedPort)
+		return
+	}
+	traefik.HealthCheckPath = firstOf(probe.HTTPGet.Path, "/")
+	if probe.PeriodSeconds > 0 {
+		traefik.HealthCheckInterval = fmt.Sprintf("%ds", probe.PeriodSeconds)
+	}
+}
+
+// containerPort resolves a port number or the name of a port of the container, an unset
+// port defaults to fallback
+func containerPort(container k8sContainer, port *yaml.Node, fallback int) int {
+	if port.Kind == 0 || port.Value == "" {
+		return fallback
+	}
+	if number, err := strconv.Atoi(port.Value); err == nil {
+		return number
+	}
+	for _, named := range container.Ports {
+		if named.Name == port.Value {
+			return named.ContainerPort
+		}
+	}
+	return 0
+}
+
+// parseK8sCPU parses a CPU quantity, e.g. 500m or 2, into cores
+func parseK8sCPU(value string) (float64, error) {
+	if millis, ok := strings.CutSuffix(value, "m"); ok {
+		cores, err := strconv.ParseFloat(millis, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid cpu %q", value)
+		}
+		return cores / 1000, nil
+	}
+	cores, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cpu %q", value)
+	}
+	return cores, nil
+}
+
+// parseK8sMemory parses a memory quantity, e.g. 512Mi or 1G, into MB
+func parseK8sMemory(value string) (int64, error) {
+	units := []struct {
+		suffix string
+		bytes  float64
+	}{
+		{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40},
+		{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12},
+	}
+
+	number, unit := value, 1.0
+	for _, u := range units {
+		if trimmed, ok := strings.CutSuffix(value, u.suffix); ok {
+			number, unit = trimmed, u.bytes
+			break
+		}
+	}
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil || amount <= 0 {
+		return 0, fmt.Errorf("invalid memory %q", value)
+	}
+	return max(int64(amount*unit)>>20, 1), nil
+}
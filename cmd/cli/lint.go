@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/api"
+)
+
+// runLint handles `cli lint -f app.yaml [more files]`, it flags the best practices the specs
+// do not follow like the server does on deploy; with -strict warnings fail it
+func runLint(args []string) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	var files stringList
+	fs.Var(&files, "f", "JSON or YAML spec file (repeatable)")
+	environment := fs.String("environment", "", "Environment the specs are deployed to, e.g. prod flags single replicas")
+	strict := fs.Bool("strict", false, "Exit with 1 when a spec has warnings")
+	_ = fs.Parse(args)
+
+	files = append(files, fs.Args()...)
+	if len(files) == 0 {
+		fmt.Println("Usage: cli lint [-environment=<env>] [-strict] -f <spec file> [spec files...]")
+		os.Exit(2)
+	}
+
+	warned, invalid := 0, 0
+	for _, file := range files {
+		spec, err := parseSpec(file)
+		if err != nil {
+			fmt.Printf("%s: invalid\n  - %v\n", file, err)
+			invalid++
+			continue
+		}
+
+		warnings := api.LintSpec(spec, *environment)
+		if len(warnings) == 0 {
+			fmt.Printf("%s: no warnings\n", file)
+			continue
+		}
+		fmt.Printf("%s: %d warnings\n", file, len(warnings))
+		printWarnings(warnings)
+		warned++
+	}
+
+	if invalid > 0 || *strict && warned > 0 {
+		fmt.Printf("\n%d of %d specs with warnings, %d invalid\n", warned, len(files), invalid)
+		os.Exit(1)
+	}
+}
+
+func printWarnings(warnings []*pb.LintWarning) {
+	for _, warning := range warnings {
+		fmt.Printf("  - [%s] %s: %s\n", warning.Rule, warning.Field, warning.Message)
+	}
+}
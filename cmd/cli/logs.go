@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// getLogs prints the last lines of the task's log, and with follow the lines it writes
+// afterwards until interrupted
+func getLogs(ctx context.Context, client pb.ControlPlaneClient, name, allocID, task, logType string, tail int, follow bool) {
+	if name == "" {
+		log.Fatalf("-name must be provided for logs action")
+	}
+
+	if follow {
+		// a followed log streams until interrupted instead of the default timeout
+		signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		ctx = signalCtx
+	}
+
+	req := &pb.LogsRequest{
+		DeploymentId: name,
+		AllocationId: allocID,
+		TaskName:     task,
+		Follow:       follow,
+		TailLines:    int32(tail),
+		LogType:      logType,
+	}
+
+	stream, err := client.GetLogs(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to get logs: %v", err)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return
+			}
+			log.Fatalf("Failed to get logs: %v", err)
+		}
+		for _, line := range chunk.Lines {
+			fmt.Println(line)
+		}
+		if chunk.Error != "" {
+			log.Fatalf("%s", chunk.Error)
+		}
+	}
+}
@@ -4,25 +4,107 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
 )
 
+// stringList collects a flag which can be repeated on the command line
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
 type DeployConfig struct {
-	Name        string
-	Image       string
-	Replicas    int
-	CPU         float64
-	Memory      int64
-	Region      string
-	NetworkMode string
-	TraefikHost string
-	TraefikSSL  bool
+	Name         string
+	Image        string
+	Replicas     int
+	CPU          float64
+	Memory       int64
+	MemoryMax    int64
+	Region       string
+	NetworkMode  string
+	TraefikHost  string
+	TraefikSSL   bool
+	CertMode     string
+	CertSANs     []string
+	Constraints  []string
+	DiskMB       int
+	DiskSticky   bool
+	DiskMigrate  bool
+	IdleTimeout  int
+	Type         string
+	Concurrency  int
+	Timeout      int
+	Artifact     string
+	MetaKeys     []string
+	Schedule     string
+	TimeZone     string
+	NoOverlap    bool
+	DependsOn    []string
+	AllowFrom    []string
+	Tenant       string
+	Volumes      []string
+	AddOns       []string
+	Egress       []string
+	NoNewPrivs   bool
+	Seccomp      string
+	AppArmor     string
+	CapDrop      []string
+	BackupDest   string
+	BackupCron   string
+	PinOnDrift   bool
+	Annotations  []string
+	Group        string
+	Deadline     time.Duration
+	Revert       bool
+	MaxParallel  int
+	Canary       int
+	AutoRevert   bool
+	AutoPromote  bool
+	MinHealthy   time.Duration
+	HealthyLimit time.Duration // the healthy deadline of the update strategy
+	Strategy     string
+	Weights      string // steps of the traffic split, e.g. 10,25,50
+	StepInterval time.Duration
+	Manual       bool // promote the traffic split with the promote action
+	Selector     []string
+	LatencyHint  string
+	GeoHost      string
+	GeoTargets   []string
+	Failover     bool
+	Actions      []string
+	ConsulKV     bool
+	Config       []string
+	Wait         bool // stream the rollout after the deploy
+	DryRun       bool // plan the deploy only
+	MinReplicas  int
+	MaxReplicas  int
+	Metrics      []string
+	Cooldown     time.Duration
+	DownCooldown time.Duration // before scaling in
+	Smoothing    time.Duration
+	Env          []string
+	EnvFile      string
+	Predict      string // season of predictive scaling
+	Confidence   float64
+	PredictLead  time.Duration
+	Ports        []string
 }
 
 func (c *DeployConfig) Validate() error {
@@ -44,28 +126,336 @@ func (c *DeployConfig) Validate() error {
 	if c.NetworkMode != "host" && c.NetworkMode != "bridge" {
 		return fmt.Errorf("network mode must be 'host' or 'bridge'")
 	}
+	if c.Type != "service" && c.Type != "function" && c.Type != "cron" {
+		return fmt.Errorf("type must be 'service', 'function' or 'cron'")
+	}
+	if c.Type == "cron" && c.Schedule == "" {
+		return fmt.Errorf("-schedule must be provided for cron deployments")
+	}
+	if c.Concurrency < 0 || c.Timeout < 0 {
+		return fmt.Errorf("max concurrency and timeout cannot be negative")
+	}
+	if c.CertMode != "" && c.CertMode != "host" && c.CertMode != "wildcard" {
+		return fmt.Errorf("cert must be 'host' or 'wildcard'")
+	}
+	if (c.CertMode != "" || len(c.CertSANs) > 0) && !c.TraefikSSL {
+		return fmt.Errorf("-cert and -san require -ssl")
+	}
+	if c.Type != "service" && c.TraefikHost != "" {
+		return fmt.Errorf("%s deployments cannot be routed by Traefik, remove -host", c.Type)
+	}
+	if c.IdleTimeout < 0 {
+		return fmt.Errorf("idle timeout cannot be negative")
+	}
+	if c.IdleTimeout > 0 && c.TraefikHost == "" {
+		return fmt.Errorf("-idle-timeout requires -host to wake the application on")
+	}
+	if c.Region != "" && (len(c.Selector) > 0 || c.LatencyHint != "") {
+		return fmt.Errorf("-region-selector and -latency-hint let the controller choose the region, remove -region")
+	}
+	if c.DiskMB < 0 {
+		return fmt.Errorf("disk must be greater than 0")
+	}
+	if c.DiskMigrate && !c.DiskSticky {
+		return fmt.Errorf("-disk-migrate requires -disk-sticky")
+	}
+	if slices.Contains(c.DependsOn, c.Name) {
+		return fmt.Errorf("an application cannot depend on itself")
+	}
+	if slices.Contains(c.AllowFrom, c.Name) {
+		return fmt.Errorf("an application always reaches itself, remove it from -allow-from")
+	}
+	if c.BackupDest == "" && c.BackupCron != "" {
+		return fmt.Errorf("-backup-schedule requires -backup-to")
+	}
+	if c.BackupDest != "" && len(c.Volumes) == 0 {
+		return fmt.Errorf("-backup-to requires -volume")
+	}
+	for _, expr := range c.Volumes {
+		if _, err := parseVolumeMount(expr); err != nil {
+			return err
+		}
+	}
+	for _, expr := range c.AddOns {
+		if _, err := parseAddOn(expr); err != nil {
+			return err
+		}
+	}
+	if c.Region != "" && len(c.GeoTargets) > 0 {
+		return fmt.Errorf("-geo-target deploys to the target regions, remove -region")
+	}
+	if (c.GeoHost == "") != (len(c.GeoTargets) == 0) {
+		return fmt.Errorf("-geo-host and -geo-target must be provided together")
+	}
+	if c.Strategy != "" && c.Strategy != "rolling" && c.Strategy != "blue-green" && c.Strategy != "canary-traffic" {
+		return fmt.Errorf("strategy must be 'rolling', 'blue-green' or 'canary-traffic'")
+	}
+	if (c.Weights != "" || c.StepInterval != 0 || c.Manual) && (c.Strategy == "" || c.Strategy == "rolling") {
+		return fmt.Errorf("-traffic-weights, -step-interval and -manual-promotion require -strategy=blue-green or canary-traffic")
+	}
+	if _, err := parseWeights(c.Weights); err != nil {
+		return err
+	}
+	if len(c.Config) > 0 && !c.ConsulKV {
+		return fmt.Errorf("-set requires -consul-kv")
+	}
+	for _, expr := range c.Env {
+		if key, _, found := strings.Cut(expr, "="); !found || key == "" {
+			return fmt.Errorf("invalid -env %q, expected KEY=VALUE", expr)
+		}
+	}
+	if c.EnvFile != "" {
+		if _, err := readEnvFile(c.EnvFile); err != nil {
+			return fmt.Errorf("failed to read -env-file: %w", err)
+		}
+	}
+	for _, expr := range c.Ports {
+		if _, err := parsePort(expr); err != nil {
+			return err
+		}
+	}
+	for _, expr := range c.Actions {
+		if _, err := parseTaskAction(expr); err != nil {
+			return err
+		}
+	}
+	for _, expr := range c.GeoTargets {
+		if _, err := parseGeoTarget(expr); err != nil {
+			return err
+		}
+	}
+	if (c.MaxReplicas == 0) != (len(c.Metrics) == 0) {
+		return fmt.Errorf("-max-replicas and -scale-metric must be provided together")
+	}
+	if c.Predict != "" && c.MaxReplicas == 0 {
+		return fmt.Errorf("-scale-predict requires -max-replicas and -scale-metric")
+	}
+	for _, expr := range c.Metrics {
+		if _, err := parseScaleMetric(expr); err != nil {
+			return err
+		}
+	}
+	for _, expr := range c.Egress {
+		if _, err := parseEgressRule(expr); err != nil {
+			return err
+		}
+	}
+	for _, expr := range c.Constraints {
+		constraint, err := nomad.ParseConstraint(expr)
+		if err != nil {
+			return err
+		}
+		if err := constraint.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "admin" {
+		runAdmin(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		runValidate(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		runLint(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		runExplain(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "ci" {
+		runCI(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "convert" {
+		runConvert(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "ps" {
+		runPS(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "search" {
+		runSearch(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "projects" {
+		runProjects(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "namespaces" {
+		runNamespaces(os.Args[2:])
+		return
+	}
+
 	var (
-		server      = flag.String("server", "localhost:50051", "gRPC server address")
-		action      = flag.String("action", "", "Action: deploy, delete, status, health")
-		name        = flag.String("name", "", "Application name")
-		image       = flag.String("image", "", "Container image")
-		replicas    = flag.Int("replicas", 1, "Number of replicas")
-		cpu         = flag.Float64("cpu", 0.1, "CPU cores")
-		memory      = flag.Int64("memory", 128, "Memory in MB")
-		region      = flag.String("region", "global", "Target region")
-		networkMode = flag.String("network", "host", "Network mode: host, bridge")
-		traefikHost = flag.String("host", "", "Enable Traefik with hostname")
-		traefikSSL  = flag.Bool("ssl", false, "Enable SSL for Traefik")
-		deleteId    = flag.String("delete-id", "", "Deployment ID to delete (for delete action)")
+		server       = flag.String("server", "localhost:50051", "gRPC server address")
+		idemKey      = flag.String("idempotency-key", "", "Key of the request, retrying the action with it returns the original result")
+		action       = flag.String("action", "", "Action: deploy, delete, scale, status, health, invoke, function-metrics, dispatch, logs, run, config, set-config, cron-runs, cron-trigger, cron-pause, cron-resume, deploy-stack, publish-blueprint, subscribe, subscriptions, apply-update, impact, graph, apply, apply-spec, app-health, create-volume, volumes, delete-volume, backup, snapshots, restore, add-domain, verify-domain, domains, drift, attach, artifacts, get-artifact, explain-placement, reconciler, calendar, deploy-raw, recommend, apply-recommendation, analytics, restarts, timeline, history, rollback, update, promote, fail")
+		name         = flag.String("name", "", "Application name")
+		image        = flag.String("image", "", "Container image")
+		replicas     = flag.Int("replicas", 1, "Number of replicas")
+		cpu          = flag.Float64("cpu", 0.1, "CPU cores")
+		memory       = flag.Int64("memory", 128, "Memory in MB")
+		memoryMax    = flag.Int64("memory-max", 0, "Memory in MB the application may burst to, requires memory oversubscription")
+		region       = flag.String("region", "", "Target region (default: chosen by the controller's placement engine, or global)")
+		networkMode  = flag.String("network", "host", "Network mode: host, bridge")
+		traefikHost  = flag.String("host", "", "Enable Traefik with hostname")
+		traefikSSL   = flag.Bool("ssl", false, "Enable SSL for Traefik")
+		certMode     = flag.String("cert", "", "Certificate: host, wildcard (default: the controller's policy decides)")
+		deleteId     = flag.String("delete-id", "", "Deployment ID to delete (for delete action)")
+		diskMB       = flag.Int("disk", 0, "Ephemeral disk size in MB (default: Nomad's 300)")
+		diskSticky   = flag.Bool("disk-sticky", false, "Keep the ephemeral disk on the same node when rescheduling")
+		diskMigrate  = flag.Bool("disk-migrate", false, "Migrate the ephemeral disk data when rescheduling (requires -disk-sticky)")
+		idleTimeout  = flag.Int("idle-timeout", 0, "Scale to zero after this many minutes without traffic (requires -host)")
+		deployType   = flag.String("type", "service", "Deployment type: service, function, cron")
+		concurrency  = flag.Int("max-concurrency", 0, "Concurrent invocations of a function (default: 10)")
+		fnTimeout    = flag.Int("timeout", 0, "Seconds a function invocation or action may run (default: 60 for invocations, 300 for actions)")
+		artifact     = flag.String("artifact", "", "Code artifact unpacked into the function's local/ dir")
+		payload      = flag.String("payload", "", "Payload passed to a function invocation")
+		payloadFile  = flag.String("payload-file", "", "File with the payload passed to a function invocation")
+		allocID      = flag.String("alloc", "", "Allocation ID for logs and run (default: most recent)")
+		runName      = flag.String("run", "", "Action of the application to run (for run action)")
+		consulKV     = flag.Bool("consul-kv", false, "Keep the configuration of the application under a Consul KV prefix, rendered as environment variables")
+		taskName     = flag.String("task", "", "Task name for logs (default: the allocation's only task)")
+		logType      = flag.String("log-type", "stdout", "Log type: stdout, stderr")
+		tail         = flag.Int("tail", 100, "Number of log lines to show")
+		follow       = flag.Bool("follow", false, "Keep printing the lines the task writes until interrupted (for logs action)")
+		schedule     = flag.String("schedule", "", "Cron schedule of a cron deployment, e.g. '0 3 * * *'")
+		timeZone     = flag.String("time-zone", "", "Time zone of the cron schedule (default: UTC)")
+		noOverlap    = flag.Bool("prohibit-overlap", false, "Skip a cron run while the previous one is still running")
+		limit        = flag.Int("limit", 10, "Number of cron runs, most recent timeline events or revisions to list")
+		file         = flag.String("f", "", "JSON file: stack for deploy-stack; JSON or YAML file: spec or manifest for apply, apply-spec, publish-blueprint and explain-placement, overrides for subscribe; artifact for attach; Nomad job (JSON or HCL) for deploy-raw")
+		continueErr  = flag.Bool("continue-on-error", false, "Keep deploying later stack stages when an application fails")
+		blueprint    = flag.String("blueprint", "", "Blueprint name")
+		channel      = flag.String("channel", "stable", "Blueprint release channel")
+		policy       = flag.String("policy", "propose", "Blueprint update policy: propose, auto")
+		pin          = flag.Int("pin", 0, "Pin the subscription to a blueprint version (default: follow the channel)")
+		version      = flag.Int("version", 0, "Blueprint version to apply (default: pinned version or channel head)")
+		revision     = flag.Int("revision", 0, "Revision whose spec is deployed again (for rollback action, default: Nomad reverts to the last stable job version), or the update is made against (for update action)")
+		behind       = flag.Bool("behind", false, "Only list subscriptions running an outdated blueprint version")
+		tenant       = flag.String("tenant", "", "Tenant owning the application, blueprint, subscription or domain, its data is encrypted with the tenant's key")
+		pluginID     = flag.String("plugin", "", "CSI plugin of the volume")
+		externalID   = flag.String("external-id", "", "Register an existing volume of the storage provider instead of creating one")
+		capacity     = flag.Int64("capacity", 0, "Minimum capacity of the volume in MB")
+		accessMode   = flag.String("access-mode", "", "Access mode of the volume (default: single-node-writer)")
+		fsType       = flag.String("fs-type", "", "File system of the volume, e.g. ext4")
+		deregister   = flag.Bool("deregister", false, "Only deregister the volume from Nomad, keep it at the storage provider")
+		backupDest   = flag.String("backup-to", "", "Back up the volumes to s3://bucket/prefix")
+		backupCron   = flag.String("backup-schedule", "", "Cron schedule of the backups, e.g. '0 2 * * *' (default: only on request)")
+		snapshotID   = flag.String("snapshot", "", "Snapshot to restore (default: latest complete snapshot)")
+		noNewPrivs   = flag.Bool("no-new-privileges", false, "Keep the application's processes from gaining privileges")
+		seccomp      = flag.String("seccomp", "", "Seccomp profile: default, unconfined or a profile name (default: the tenant's)")
+		apparmor     = flag.String("apparmor", "", "AppArmor profile (default: the tenant's)")
+		domain       = flag.String("domain", "", "Custom domain of the tenant, e.g. shop.example.com")
+		group        = flag.String("concurrency-group", "", "Concurrency group whose rollouts run one at a time, e.g. db-migrations")
+		deadline     = flag.Duration("rollout-deadline", 0, "Fail the rollout when it is still running after this long, e.g. 10m")
+		revert       = flag.Bool("revert-on-deadline", false, "Revert to the last stable version when the rollout deadline passes")
+		maxParallel  = flag.Int("max-parallel", 0, "Allocations replaced at a time during a rollout (default: Nomad's 1)")
+		canary       = flag.Int("canary", 0, "Allocations of the new version placed next to the old ones first")
+		autoRevert   = flag.Bool("auto-revert", false, "Let Nomad revert to the last stable version when the rollout fails")
+		autoPromote  = flag.Bool("auto-promote", false, "Let Nomad promote the canaries once all of them are healthy")
+		minHealthy   = flag.Duration("min-healthy-time", 0, "How long an allocation must be healthy to count as healthy (default: Nomad's 10s)")
+		healthyLimit = flag.Duration("healthy-deadline", 0, "An allocation not healthy after this long is unhealthy (default: Nomad's 5m)")
+		deploymentID = flag.String("deployment", "", "Rollout to promote or fail (for promote and fail actions, default: the traffic split or the latest rollout)")
+		strategy     = flag.String("strategy", "", "Deploy strategy: rolling, blue-green, canary-traffic (default: rolling)")
+		weights      = flag.String("traffic-weights", "", "Percent of the traffic shifted to the new version per step, e.g. 10,25,50")
+		stepInterval = flag.Duration("step-interval", 0, "How long a traffic step holds before the next one (default: 1m)")
+		manualPromo  = flag.Bool("manual-promotion", false, "Wait for the promote action after the last traffic step")
+		pinOnDrift   = flag.Bool("pin-on-drift", false, "Redeploy pinned to the deployed digest when the image's tag moves")
+		drifted      = flag.Bool("drifted", false, "Only list applications whose image tag moved")
+		kind         = flag.String("kind", "provenance", "Kind of the attached artifact: sbom, provenance")
+		mediaType    = flag.String("media-type", "", "Media type of the attached artifact, e.g. application/spdx+json")
+		artifactID   = flag.String("artifact-id", "", "Artifact to retrieve")
+		latencyHint  = flag.String("latency-hint", "", "Where the users are, regions close to it are preferred, e.g. eu")
+		geoHost      = flag.String("geo-host", "", "Hostname of the DNS records routing users to the -geo-target regions")
+		failover     = flag.Bool("failover", false, "Withdraw the records of a geo target region while the application is unhealthy there")
+		wait         = flag.Bool("wait", false, "Stream the rollout until it finished, exit with an error when it failed (for deploy, apply, apply-spec, deploy-raw and update actions)")
+		dryRun       = flag.Bool("dry-run", false, "Show what the scheduler would change without deploying (for deploy, apply, apply-spec and update actions)")
+		watch        = flag.Bool("watch", false, "Refresh the status until interrupted and highlight what changed (for status action)")
+		interval     = flag.Duration("interval", 2*time.Second, "Refresh interval of -watch, slowed down while nothing changes")
+		propose      = flag.Bool("propose", false, "Record the recommendations as resource updates awaiting approval (for recommend action)")
+		count        = flag.Int("count", -1, "Instance count to scale the task group to (for scale action)")
+		taskGroup    = flag.String("task-group", "", "Task group to scale, required for jobs with more than one group, or whose canaries are promoted (for promote action, default: every group)")
+		byTeam       = flag.Bool("by-team", false, "Aggregate the analytics of the applications of each tenant (for analytics action)")
+		since        = flag.Duration("since", 30*24*time.Hour, "Period the analytics or the timeline cover (for analytics and timeline actions)")
+		bucket       = flag.String("bucket", "week", "Periods of the analytics time series: day, week")
+		periods      = flag.Bool("periods", false, "Show the analytics of every period besides the total (for analytics action)")
+		within       = flag.Duration("within", 24*time.Hour, "How far ahead the calendar shows scheduled operations, whole hours (for calendar action)")
+		dismiss      = flag.Bool("dismiss", false, "Dismiss the proposed resource update instead of applying it (for apply-recommendation action)")
+		all          = flag.Bool("all", false, "Show a one-line summary of every application (for status action)")
+		sortBy       = flag.String("sort", "name", "Sort the applications of -all by: name, age, health, region, status")
+		minReplicas  = flag.Int("min-replicas", 0, "Fewest instances the autoscaler keeps (default: -replicas)")
+		maxReplicas  = flag.Int("max-replicas", 0, "Most instances the autoscaler scales to, enables autoscaling with -scale-metric")
+		cooldown     = flag.Duration("scale-cooldown", 0, "Time between two scalings (default: the controller's 5m)")
+		downCooldown = flag.Duration("scale-down-cooldown", 0, "Time between a scaling and scaling in (default: -scale-cooldown)")
+		envFile      = flag.String("env-file", "", "File of KEY=VALUE lines set as environment variables, -env takes precedence")
+		predict      = flag.String("scale-predict", "", "Scale out ahead of the recurring peaks of the CPU usage: daily, weekly (requires -scale-metric=cpu:<target>)")
+		confidence   = flag.Float64("scale-predict-confidence", 0, "Confidence between 0 and 1 a prediction needs to scale out (default: the controller's 0.8)")
+		predictLead  = flag.Duration("scale-predict-lead", 0, "How far ahead of a predicted peak to scale out (default: the controller's 15m)")
+		smoothing    = flag.Duration("scale-smoothing", 0, "Average the -scale-metric values over about this long to damp bursts")
+		constraints  stringList
+		metaKeys     stringList
+		meta         stringList
+		dependsOn    stringList
+		allowFrom    stringList
+		volumes      stringList
+		addOns       stringList
+		params       stringList
+		certSANs     stringList
+		egress       stringList
+		capDrop      stringList
+		annotations  stringList
+		selector     stringList
+		geoTargets   stringList
+		filters      stringList
+		taskActions  stringList
+		configSet    stringList
+		configUnset  stringList
+		scaleMetric  stringList
+		env          stringList
+		ports        stringList
+		eventTypes   stringList
 	)
+	flag.Var(&constraints, "constraint", "Placement constraint, e.g. 'meta.storage=ssd' (repeatable)")
+	flag.Var(&metaKeys, "meta-key", "Meta key function invocations may pass (repeatable)")
+	flag.Var(&meta, "meta", "Meta passed to a function invocation as key=value (repeatable)")
+	flag.Var(&dependsOn, "depends-on", "Application the deployed one consumes (repeatable)")
+	flag.Var(&allowFrom, "allow-from", "Application allowed to call the deployed one through the Connect mesh (repeatable)")
+	flag.Var(&volumes, "volume", "CSI volume to mount as <volume id>:<path>[:ro,per-alloc] (repeatable)")
+	flag.Var(&addOns, "addon", "Managed dependency as <name>=<type>[:<version>][@<volume id>], e.g. db=postgres:16 (repeatable)")
+	flag.Var(&certSANs, "san", "Extra host of the application's certificate (repeatable)")
+	flag.Var(&egress, "egress", "Allowed outbound traffic as service:<name> or [udp:]<cidr>[@<port>,...] (repeatable)")
+	flag.Var(&ports, "port", "Port of the task as <label>:<container port>[:<host port>][/<protocol>], e.g. dns:53:53/udp (repeatable, default: http:80)")
+	flag.Var(&env, "env", "Environment variable of the application as KEY=VALUE, may hold environment expressions (repeatable)")
+	flag.Var(&annotations, "annotation", "Annotation shown in the Nomad UI as key=value, e.g. runbook=https://wiki.example.com/shop (repeatable)")
+	flag.Var(&selector, "region-selector", "Label the placed region must have as key=value, e.g. provider=aws (repeatable)")
+	flag.Var(&geoTargets, "geo-target", "Region to deploy to and route users to as <region>[=<weight>][:<location>,...], e.g. eu-west:EU (repeatable)")
+	flag.Var(&capDrop, "cap-drop", "Capability dropped from the application, e.g. NET_RAW or ALL (repeatable)")
+	flag.Var(&taskActions, "task-action", "Action operators run in the application as <name>=<command> [args...], e.g. 'flush-cache=/app/bin/cache flush' (repeatable)")
+	flag.Var(&configSet, "set", "Config value of the application's Consul KV prefix as KEY=VALUE (for deploy with -consul-kv and set-config, repeatable)")
+	flag.Var(&configUnset, "unset", "Config key deleted from the application's Consul KV prefix (for set-config, repeatable)")
+	flag.Var(&filters, "filter", "Only list applications matching field=pattern, e.g. region=eu-*, a bare pattern matches the name (for status -all, repeatable)")
+	flag.Var(&scaleMetric, "scale-metric", "Metric the autoscaler keeps at its target as <provider>:<target>[:<query>], e.g. cpu:60 (repeatable)")
+	flag.Var(&eventTypes, "event-type", "Only show timeline events of this type, e.g. restart (for timeline action, repeatable)")
+	flag.Var(&params, "param", "Parameter passed to the CSI plugin as key=value (repeatable)")
+	// -cert is the certificate mode of the application
+	var connFlags connection
+	connFlags.register(flag.CommandLine, "tls-")
 	flag.Parse()
 
 	// Connect to gRPC server
-	conn, err := grpc.NewClient(*server, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	dialOptions := connFlags.dialOptions()
+	if *idemKey != "" {
+		dialOptions = append(dialOptions, grpc.WithUnaryInterceptor(withIdempotencyKey(*idemKey)))
+	}
+	conn, err := grpc.NewClient(*server, dialOptions...)
 	if err != nil {
 		log.Fatalf("Failed to connect to server: %v", err)
 	}
@@ -78,23 +468,243 @@ func main() {
 	switch *action {
 	case "deploy":
 		config := &DeployConfig{
-			Name:        *name,
-			Image:       *image,
-			Replicas:    *replicas,
-			CPU:         *cpu,
-			Memory:      *memory,
-			Region:      *region,
-			NetworkMode: *networkMode,
-			TraefikHost: *traefikHost,
-			TraefikSSL:  *traefikSSL,
+			Name:         *name,
+			Image:        *image,
+			Replicas:     *replicas,
+			CPU:          *cpu,
+			Memory:       *memory,
+			MemoryMax:    *memoryMax,
+			Region:       *region,
+			NetworkMode:  *networkMode,
+			TraefikHost:  *traefikHost,
+			TraefikSSL:   *traefikSSL,
+			CertMode:     *certMode,
+			CertSANs:     certSANs,
+			Constraints:  constraints,
+			DiskMB:       *diskMB,
+			DiskSticky:   *diskSticky,
+			DiskMigrate:  *diskMigrate,
+			IdleTimeout:  *idleTimeout,
+			Type:         *deployType,
+			Concurrency:  *concurrency,
+			Timeout:      *fnTimeout,
+			Artifact:     *artifact,
+			MetaKeys:     metaKeys,
+			Schedule:     *schedule,
+			TimeZone:     *timeZone,
+			NoOverlap:    *noOverlap,
+			DependsOn:    dependsOn,
+			AllowFrom:    allowFrom,
+			Tenant:       *tenant,
+			Volumes:      volumes,
+			AddOns:       addOns,
+			Egress:       egress,
+			NoNewPrivs:   *noNewPrivs,
+			Seccomp:      *seccomp,
+			AppArmor:     *apparmor,
+			CapDrop:      capDrop,
+			BackupDest:   *backupDest,
+			BackupCron:   *backupCron,
+			PinOnDrift:   *pinOnDrift,
+			Annotations:  annotations,
+			Group:        *group,
+			Deadline:     *deadline,
+			Revert:       *revert,
+			MaxParallel:  *maxParallel,
+			Canary:       *canary,
+			AutoRevert:   *autoRevert,
+			AutoPromote:  *autoPromote,
+			MinHealthy:   *minHealthy,
+			HealthyLimit: *healthyLimit,
+			Strategy:     *strategy,
+			Weights:      *weights,
+			StepInterval: *stepInterval,
+			Manual:       *manualPromo,
+			Selector:     selector,
+			LatencyHint:  *latencyHint,
+			GeoHost:      *geoHost,
+			GeoTargets:   geoTargets,
+			Failover:     *failover,
+			Actions:      taskActions,
+			ConsulKV:     *consulKV,
+			Config:       configSet,
+			Wait:         *wait,
+			DryRun:       *dryRun,
+			MinReplicas:  *minReplicas,
+			MaxReplicas:  *maxReplicas,
+			Metrics:      scaleMetric,
+			Cooldown:     *cooldown,
+			DownCooldown: *downCooldown,
+			Smoothing:    *smoothing,
+			Env:          env,
+			EnvFile:      *envFile,
+			Predict:      *predict,
+			Confidence:   *confidence,
+			PredictLead:  *predictLead,
+			Ports:        ports,
+		}
+		if config.Group != "" {
+			// the deployment waits for the rollouts of its group
+			groupCtx, groupCancel := context.WithTimeout(context.Background(), 30*time.Minute)
+			defer groupCancel()
+			ctx = groupCtx
 		}
 		deployApp(ctx, client, config)
 	case "delete":
 		deleteApp(ctx, client, *deleteId, *name)
+	case "scale":
+		scaleApp(ctx, client, *name, *taskGroup, *count)
 	case "status":
+		if *all {
+			listApplications(ctx, client, &pb.ListApplicationsRequest{PageSize: 100}, *sortBy, filters)
+			return
+		}
+		if *watch {
+			watchStatus(client, *name, *interval)
+			return
+		}
 		getStatus(ctx, client, *name)
 	case "health":
 		healthCheck(ctx, client)
+	case "app-health":
+		appHealth(ctx, client, *name)
+	case "invoke":
+		// invocations can run much longer than the other actions
+		invokeCtx, invokeCancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer invokeCancel()
+		invokeFunction(invokeCtx, client, *name, *payload, *payloadFile, meta)
+	case "function-metrics":
+		functionMetrics(ctx, client, *name)
+	case "dispatch":
+		dispatchJob(ctx, client, *name, *payload, *payloadFile, meta)
+	case "logs":
+		getLogs(ctx, client, *name, *allocID, *taskName, *logType, *tail, *follow)
+	case "run":
+		runAction(client, *name, *runName, *allocID, *taskName, *fnTimeout)
+	case "config":
+		getConfig(ctx, client, *name)
+	case "set-config":
+		setConfig(ctx, client, *name, configSet, configUnset)
+	case "cron-runs":
+		cronRuns(ctx, client, *name, *limit)
+	case "cron-trigger":
+		cronTrigger(ctx, client, *name)
+	case "cron-pause":
+		cronSetPaused(ctx, client, *name, true)
+	case "cron-resume":
+		cronSetPaused(ctx, client, *name, false)
+	case "deploy-stack":
+		// stages wait for health, which takes longer than the other actions
+		stackCtx, stackCancel := context.WithTimeout(context.Background(), time.Hour)
+		defer stackCancel()
+		deployStack(stackCtx, client, *file, *continueErr)
+	case "publish-blueprint":
+		publishBlueprint(ctx, client, *tenant, *blueprint, *channel, *file)
+	case "subscribe":
+		subscribe(ctx, client, *tenant, *name, *blueprint, *channel, *policy, *pin, *file)
+	case "subscriptions":
+		listSubscriptions(ctx, client, *blueprint, *behind)
+	case "apply-update":
+		applyBlueprintUpdate(ctx, client, *name, *version)
+	case "apply", "apply-spec":
+		applySpec(ctx, client, *file, *wait, *dryRun, connFlags.namespace)
+	case "deploy-raw":
+		deployRawJob(ctx, client, *file, *name, *tenant, *wait)
+	case "impact":
+		getImpact(ctx, client, *name)
+	case "graph":
+		dependencyGraph(ctx, client)
+	case "create-volume":
+		// the plugin provisions the volume at the storage provider
+		volumeCtx, volumeCancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer volumeCancel()
+		createVolume(volumeCtx, client, &pb.CreateVolumeRequest{
+			Id:            *name,
+			PluginId:      *pluginID,
+			ExternalId:    *externalID,
+			CapacityMinMb: *capacity,
+			AccessMode:    *accessMode,
+			FsType:        *fsType,
+		}, params)
+	case "volumes":
+		listVolumes(ctx, client, *pluginID)
+	case "delete-volume":
+		deleteVolume(ctx, client, *name, *deregister)
+	case "backup":
+		backupApp(ctx, client, *name)
+	case "snapshots":
+		listSnapshots(ctx, client, *name)
+	case "restore":
+		// waits for the snapshot to be downloaded into the volumes
+		restoreCtx, restoreCancel := context.WithTimeout(context.Background(), time.Hour)
+		defer restoreCancel()
+		restoreVolume(restoreCtx, client, *name, *snapshotID)
+	case "add-domain":
+		addDomain(ctx, client, *tenant, *domain)
+	case "verify-domain":
+		verifyDomain(ctx, client, *tenant, *domain)
+	case "domains":
+		listDomains(ctx, client, *tenant)
+	case "drift":
+		listImageDrift(ctx, client, *name, *drifted)
+	case "restarts":
+		listRestartAnomalies(ctx, client, *name)
+	case "history":
+		listRevisions(ctx, client, *name, *limit)
+	case "rollback":
+		rollbackApp(ctx, client, *name, *revision)
+	case "promote":
+		promoteDeployment(ctx, client, *name, *deploymentID, *taskGroup)
+	case "fail":
+		failDeployment(ctx, client, *name, *deploymentID)
+	case "update":
+		set := make(map[string]bool)
+		flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		updateApp(ctx, client, *name, *revision, set, &pb.DeployRequest{
+			Image:     *image,
+			Replicas:  int32(*replicas),
+			Cpu:       *cpu,
+			Memory:    *memory,
+			MemoryMax: *memoryMax,
+			Traefik:   &pb.TraefikConfig{Enable: *traefikHost != "", Host: *traefikHost, EnableSsl: *traefikSSL},
+			DryRun:    *dryRun,
+		}, *wait)
+	case "timeline":
+		getTimeline(ctx, client, &pb.TimelineRequest{
+			Name:         *name,
+			Since:        time.Now().Add(-*since).Unix(),
+			AllocationId: *allocID,
+			Types:        eventTypes,
+			Limit:        int32(*limit),
+		})
+	case "attach":
+		attachArtifact(ctx, client, *name, *image, *kind, *file, *mediaType)
+	case "artifacts":
+		listArtifacts(ctx, client, *name)
+	case "get-artifact":
+		getArtifact(ctx, client, *name, *artifactID)
+	case "explain-placement":
+		explainPlacement(ctx, client, *name, *file)
+	case "reconciler":
+		reconcilerStatus(ctx, client, *name)
+	case "calendar":
+		calendar(ctx, client, &pb.GetCalendarRequest{
+			Hours:       int32(within.Hours()),
+			Tenant:      *tenant,
+			Application: *name,
+		})
+	case "recommend":
+		recommendResources(ctx, client, *name, *propose)
+	case "apply-recommendation":
+		applyRecommendation(ctx, client, *name, *dismiss)
+	case "analytics":
+		deploymentAnalytics(ctx, client, &pb.DeploymentAnalyticsRequest{
+			Application: *name,
+			Team:        *tenant,
+			ByTeam:      *byTeam,
+			Since:       time.Now().Add(-*since).Unix(),
+			Bucket:      *bucket,
+		}, *periods)
 	default:
 		fmt.Printf("Unknown action: %s\n", *action)
 		printUsage()
@@ -126,30 +736,267 @@ func deployApp(ctx context.Context, client pb.ControlPlaneClient, config *Deploy
 			EnableSsl:           config.TraefikSSL,
 			HealthCheckPath:     "/",
 			HealthCheckInterval: "30s",
+			CertSans:            config.CertSANs,
+		}
+		switch config.CertMode {
+		case "host":
+			traefikConfig.CertStrategy = pb.CertStrategy_CERT_STRATEGY_HOST
+		case "wildcard":
+			traefikConfig.CertStrategy = pb.CertStrategy_CERT_STRATEGY_WILDCARD
 		}
 	}
 
+	var constraints []*pb.Constraint
+	for _, expr := range config.Constraints {
+		constraint, _ := nomad.ParseConstraint(expr) // already checked by Validate
+		constraints = append(constraints, &pb.Constraint{
+			Attribute: constraint.Attribute,
+			Operator:  constraint.Operator,
+			Value:     constraint.Value,
+		})
+	}
+
+	var volumes []*pb.VolumeMount
+	for _, expr := range config.Volumes {
+		volume, _ := parseVolumeMount(expr) // already checked by Validate
+		volumes = append(volumes, volume)
+	}
+
+	var egress *pb.EgressConfig
+	for _, expr := range config.Egress {
+		rule, _ := parseEgressRule(expr) // already checked by Validate
+		if egress == nil {
+			egress = &pb.EgressConfig{}
+		}
+		egress.Rules = append(egress.Rules, rule)
+	}
+
+	var security *pb.SecurityContext
+	if config.NoNewPrivs || config.Seccomp != "" || config.AppArmor != "" || len(config.CapDrop) > 0 {
+		security = &pb.SecurityContext{
+			NoNewPrivileges:  config.NoNewPrivs,
+			SeccompProfile:   config.Seccomp,
+			ApparmorProfile:  config.AppArmor,
+			DropCapabilities: config.CapDrop,
+		}
+	}
+
+	var ports []*pb.PortSpec
+	for _, expr := range config.Ports {
+		port, _ := parsePort(expr) // already checked by Validate
+		ports = append(ports, port)
+	}
+
+	var addOns []*pb.AddOn
+	for _, expr := range config.AddOns {
+		addOn, _ := parseAddOn(expr) // already checked by Validate
+		addOns = append(addOns, addOn)
+	}
+
+	var backup *pb.BackupConfig
+	if config.BackupDest != "" {
+		backup = &pb.BackupConfig{
+			Destination: config.BackupDest,
+			Schedule:    config.BackupCron,
+		}
+	}
+
+	var ephemeralDisk *pb.EphemeralDisk
+	if config.DiskMB > 0 || config.DiskSticky || config.DiskMigrate {
+		ephemeralDisk = &pb.EphemeralDisk{
+			SizeMb:  int32(config.DiskMB),
+			Sticky:  config.DiskSticky,
+			Migrate: config.DiskMigrate,
+		}
+	}
+
+	deploymentType := pb.DeploymentType_DEPLOYMENT_TYPE_SERVICE
+	var functionConfig *pb.FunctionConfig
+	var cronConfig *pb.CronConfig
+	switch config.Type {
+	case "cron":
+		deploymentType = pb.DeploymentType_DEPLOYMENT_TYPE_CRON
+		cronConfig = &pb.CronConfig{
+			Schedule:        config.Schedule,
+			TimeZone:        config.TimeZone,
+			ProhibitOverlap: config.NoOverlap,
+		}
+	case "function":
+		deploymentType = pb.DeploymentType_DEPLOYMENT_TYPE_FUNCTION
+		functionConfig = &pb.FunctionConfig{
+			MaxConcurrency: int32(config.Concurrency),
+			TimeoutSeconds: int32(config.Timeout),
+			Artifact:       config.Artifact,
+			MetaKeys:       config.MetaKeys,
+		}
+	}
+
+	var update *pb.UpdateStrategy
+	if config.MaxParallel != 0 || config.Canary != 0 || config.AutoRevert || config.AutoPromote || config.MinHealthy != 0 || config.HealthyLimit != 0 {
+		update = &pb.UpdateStrategy{
+			MaxParallel:            int32(config.MaxParallel),
+			Canary:                 int32(config.Canary),
+			AutoRevert:             config.AutoRevert,
+			AutoPromote:            config.AutoPromote,
+			MinHealthyTimeSeconds:  int32(config.MinHealthy.Seconds()),
+			HealthyDeadlineSeconds: int32(config.HealthyLimit.Seconds()),
+		}
+	}
+
+	var strategy pb.DeployStrategy
+	switch config.Strategy {
+	case "rolling":
+		strategy = pb.DeployStrategy_DEPLOY_STRATEGY_ROLLING
+	case "blue-green":
+		strategy = pb.DeployStrategy_DEPLOY_STRATEGY_BLUE_GREEN
+	case "canary-traffic":
+		strategy = pb.DeployStrategy_DEPLOY_STRATEGY_CANARY_TRAFFIC
+	}
+	var trafficShift *pb.TrafficShift
+	if config.Weights != "" || config.StepInterval != 0 || config.Manual {
+		weights, _ := parseWeights(config.Weights) // already checked by Validate
+		trafficShift = &pb.TrafficShift{
+			Weights:             weights,
+			StepIntervalSeconds: int32(config.StepInterval.Seconds()),
+			ManualPromotion:     config.Manual,
+		}
+	}
+
+	var placement *pb.Placement
+	if len(config.Selector) > 0 || config.LatencyHint != "" {
+		placement = &pb.Placement{RegionSelector: parseMeta(config.Selector), LatencyHint: config.LatencyHint}
+	}
+
+	var geo *pb.GeoRouting
+	if config.GeoHost != "" {
+		geo = &pb.GeoRouting{Hostname: config.GeoHost, Failover: config.Failover}
+		for _, expr := range config.GeoTargets {
+			target, _ := parseGeoTarget(expr) // already checked by Validate
+			geo.Targets = append(geo.Targets, target)
+		}
+	}
+
+	var actions []*pb.Action
+	for _, expr := range config.Actions {
+		action, _ := parseTaskAction(expr) // already checked by Validate
+		actions = append(actions, action)
+	}
+
+	var autoscaling *pb.Autoscaling
+	if len(config.Metrics) > 0 {
+		autoscaling = &pb.Autoscaling{
+			MinReplicas:              int32(config.MinReplicas),
+			MaxReplicas:              int32(config.MaxReplicas),
+			CooldownSeconds:          int32(config.Cooldown.Seconds()),
+			ScaleDownCooldownSeconds: int32(config.DownCooldown.Seconds()),
+		}
+		if autoscaling.MinReplicas == 0 {
+			autoscaling.MinReplicas = int32(config.Replicas)
+		}
+		if config.Predict != "" {
+			autoscaling.Prediction = &pb.ScalingPrediction{
+				Season:        config.Predict,
+				MinConfidence: config.Confidence,
+				LeadSeconds:   int32(config.PredictLead.Seconds()),
+			}
+		}
+		for _, expr := range config.Metrics {
+			metric, _ := parseScaleMetric(expr) // already checked by Validate
+			metric.SmoothingSeconds = int32(config.Smoothing.Seconds())
+			autoscaling.Metrics = append(autoscaling.Metrics, metric)
+		}
+	}
+
+	environment := make(map[string]string)
+	if config.EnvFile != "" {
+		values, _ := readEnvFile(config.EnvFile) // already checked by Validate
+		maps.Copy(environment, values)
+	}
+	maps.Copy(environment, parseMeta(config.Env))
+
+	var consulKV *pb.ConsulKV
+	if config.ConsulKV {
+		consulKV = &pb.ConsulKV{Values: parseMeta(config.Config)}
+	}
+
 	req := &pb.DeployRequest{
-		Name:        config.Name,
-		Image:       config.Image,
-		Replicas:    int32(config.Replicas),
-		Cpu:         config.CPU,
-		Memory:      config.Memory,
-		Region:      config.Region,
-		NetworkMode: networkMode,
-		Traefik:     traefikConfig,
+		Name:                   config.Name,
+		Image:                  config.Image,
+		Replicas:               int32(config.Replicas),
+		Cpu:                    config.CPU,
+		Memory:                 config.Memory,
+		MemoryMax:              config.MemoryMax,
+		Region:                 config.Region,
+		NetworkMode:            networkMode,
+		Traefik:                traefikConfig,
+		Constraints:            constraints,
+		EphemeralDisk:          ephemeralDisk,
+		IdleTimeoutMinutes:     int32(config.IdleTimeout),
+		Type:                   deploymentType,
+		Function:               functionConfig,
+		Cron:                   cronConfig,
+		DependsOn:              config.DependsOn,
+		AllowFrom:              config.AllowFrom,
+		Volumes:                volumes,
+		Addons:                 addOns,
+		Tenant:                 config.Tenant,
+		Egress:                 egress,
+		Security:               security,
+		Backup:                 backup,
+		PinOnDrift:             config.PinOnDrift,
+		Env:                    environment,
+		Annotations:            parseMeta(config.Annotations),
+		ConcurrencyGroup:       config.Group,
+		RolloutDeadlineSeconds: int32(config.Deadline.Seconds()),
+		RevertOnDeadline:       config.Revert,
+		Update:                 update,
+		Strategy:               strategy,
+		TrafficShift:           trafficShift,
+		Placement:              placement,
+		Ports:                  ports,
+		Geo:                    geo,
+		Actions:                actions,
+		ConsulKv:               consulKV,
+		Autoscaling:            autoscaling,
+		DryRun:                 config.DryRun,
 	}
 
-	fmt.Printf("Deploying application '%s' with image '%s'...\n", config.Name, config.Image)
+	if config.DryRun {
+		fmt.Printf("Planning application '%s' with image '%s'...\n", config.Name, config.Image)
+	} else {
+		fmt.Printf("Deploying application '%s' with image '%s'...\n", config.Name, config.Image)
+	}
 	resp, err := client.DeployApplication(ctx, req)
 	if err != nil {
 		log.Fatalf("Deployment failed: %v", err)
 	}
+	if resp.Plan != nil {
+		printPlan(resp)
+		return
+	}
 
 	fmt.Printf("Deployment successful!\n")
 	fmt.Printf("ID: %s\n", resp.DeploymentId)
+	fmt.Printf("Job: %s\n", resp.JobId)
 	fmt.Printf("Status: %s\n", resp.Status)
 	fmt.Printf("Message: %s\n", resp.Message)
+	if len(resp.Warnings) > 0 {
+		fmt.Printf("Warnings:\n")
+		printWarnings(resp.Warnings)
+	}
+	if config.Wait && resp.Status != "FAILED" {
+		followRollout(client, resp.JobId, resp.DeploymentId)
+	}
+}
+
+// printRolloutOutcome explains why a rollout failed and whether its version never took
+func printRolloutOutcome(w io.Writer, rollout *pb.RolloutProgress) {
+	if rollout.Reverted {
+		fmt.Fprintf(w, "    Reverted: version %d never took, the job runs version %d again\n", rollout.JobVersion, rollout.RevertedToVersion)
+	}
+	if rollout.Reason != "" {
+		fmt.Fprintf(w, "    Reason: %s\n", rollout.Reason)
+	}
 }
 
 func deleteApp(ctx context.Context, client pb.ControlPlaneClient, deleteId, name string) {
@@ -175,6 +1022,29 @@ func deleteApp(ctx context.Context, client pb.ControlPlaneClient, deleteId, name
 	fmt.Printf("%s\n", resp.Message)
 }
 
+// scaleApp changes the instance count of a task group without redeploying the spec
+func scaleApp(ctx context.Context, client pb.ControlPlaneClient, name, taskGroup string, count int) {
+	if name == "" || count < 0 {
+		log.Fatalf("-name and -count must be provided for scale action")
+	}
+
+	resp, err := client.ScaleApplication(ctx, &pb.ScaleRequest{
+		DeploymentId: name,
+		Count:        int32(count),
+		TaskGroup:    taskGroup,
+	})
+	if err != nil {
+		log.Fatalf("Failed to scale application: %v", err)
+	}
+
+	fmt.Printf("Success: %t\n", resp.Success)
+	if resp.Success {
+		fmt.Printf("Task group: %s\n", resp.TaskGroup)
+		fmt.Printf("Instances: %d desired (was %d), %d running\n", resp.DesiredCount, resp.PreviousCount, resp.RunningCount)
+	}
+	fmt.Printf("Message: %s\n", resp.Message)
+}
+
 func getStatus(ctx context.Context, client pb.ControlPlaneClient, name string) {
 	if name == "" {
 		log.Fatalf("-name must be provided for get deployment status")
@@ -189,22 +1059,71 @@ func getStatus(ctx context.Context, client pb.ControlPlaneClient, name string) {
 		log.Fatalf("Failed to get application status: %v", err)
 	}
 
-	fmt.Printf("\nApplication: %s\n", resp.DeploymentId)
-	fmt.Printf("Status: %s\n", resp.JobStatus)
-	fmt.Printf("Type: %s\n", resp.JobType)
-	fmt.Printf("Instances: %d/%d running\n", resp.RunningInstances, resp.DesiredInstances)
+	printStatus(os.Stdout, resp)
+}
+
+// printStatus writes the status of an application to w, the watch action compares
+// what it wrote between refreshes
+func printStatus(w io.Writer, resp *pb.StatusResponse) {
+	application := resp.Application
+	if application == "" {
+		application = resp.DeploymentId
+	}
+	fmt.Fprintf(w, "\nApplication: %s\n", application)
+	if resp.Tenant != "" {
+		fmt.Fprintf(w, "Tenant: %s\n", resp.Tenant)
+	}
+	if resp.JobId != "" && resp.JobId != application {
+		fmt.Fprintf(w, "Job: %s\n", resp.JobId)
+	}
+	fmt.Fprintf(w, "Status: %s\n", resp.JobStatus)
+	fmt.Fprintf(w, "Type: %s\n", resp.JobType)
+	fmt.Fprintf(w, "Instances: %d/%d running, %d healthy, %d failed\n",
+		resp.RunningInstances, resp.DesiredInstances, resp.HealthyInstances, resp.FailedInstances)
+	if resp.CachedAt > 0 {
+		fmt.Fprintf(w, "Cached: %s\n", time.Unix(resp.CachedAt, 0).Format(time.RFC3339))
+	}
+
+	if rollout := resp.Rollout; rollout != nil {
+		fmt.Fprintf(w, "Rollout: %s %.0f%% (%d/%d healthy)", rollout.Status, rollout.Percent, rollout.HealthyInstances, rollout.DesiredInstances)
+		if rollout.EtaSeconds > 0 {
+			fmt.Fprintf(w, ", ETA %s", time.Duration(rollout.EtaSeconds)*time.Second)
+		}
+		fmt.Fprintln(w)
+		printRolloutOutcome(w, rollout)
+	}
+
+	if len(resp.History) > 0 {
+		fmt.Fprintf(w, "\nRollout History:\n")
+		for _, rollout := range resp.History {
+			fmt.Fprintf(w, "  - version %d %s, %s\n", rollout.JobVersion, rollout.Status,
+				time.Unix(rollout.FinishedAt, 0).Sub(time.Unix(rollout.StartedAt, 0)))
+			printRolloutOutcome(w, rollout)
+		}
+	}
+
+	printGeoRegions(w, resp.Geo)
+	printTrafficSplit(w, resp.Traffic)
+
+	if len(resp.TaskGroups) > 1 {
+		fmt.Fprintf(w, "\nTask Groups:\n")
+		for _, group := range resp.TaskGroups {
+			fmt.Fprintf(w, "  - %s: %d/%d running, %d healthy, %d failed\n",
+				group.Name, group.RunningInstances, group.DesiredInstances, group.HealthyInstances, group.FailedInstances)
+		}
+	}
 
 	if len(resp.Allocations) > 0 {
-		fmt.Printf("\nAllocations:\n")
+		fmt.Fprintf(w, "\nAllocations:\n")
 		for _, alloc := range resp.Allocations {
 			allocID := alloc.AllocationId
 			if len(allocID) > 8 {
 				allocID = allocID[:8]
 			}
-			fmt.Printf("  - %s on %s: %s\n", allocID, alloc.NodeName, alloc.Status)
+			fmt.Fprintf(w, "  - %s on %s: %s\n", allocID, alloc.NodeName, alloc.Status)
 		}
 	}
-	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+	fmt.Fprintf(w, "\nMessage: %s\n\n", resp.Message)
 }
 
 func healthCheck(ctx context.Context, client pb.ControlPlaneClient) {
@@ -230,6 +1149,9 @@ func healthCheck(ctx context.Context, client pb.ControlPlaneClient) {
 
 	fmt.Printf("Health Status: %s\n", statusText)
 	fmt.Printf("Message: %s\n", resp.Message)
+	if resp.Mode != "" {
+		fmt.Printf("Mode: %s (Nomad latency %dms)\n", resp.Mode, resp.NomadLatencyMs)
+	}
 	fmt.Printf("Timestamp: %d\n", resp.Timestamp)
 }
 
@@ -238,32 +1160,276 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  cli [flags]")
+	fmt.Println("  cli admin tenant create|list|rotate-key [flags]")
+	fmt.Println("  cli admin key generate -id=<key id>")
+	fmt.Println("  cli admin dr status|promote [-server=<address>]")
+	fmt.Println("  cli admin controller deploy|status [flags]")
+	fmt.Println("  cli validate -f <spec file> [spec files...]")
+	fmt.Println("  cli lint [-environment=<env>] [-strict] -f <spec file> [spec files...]")
+	fmt.Println("  cli explain [-recursive] deployspec[.<field>...]")
+	fmt.Println("  cli ci deploy [-f <spec file>] [-tag <image tag>]")
+	fmt.Println("  cli ps [-sort=<field>] [-filter=<field>=<pattern>] [-label=<key>=<value>] [-region=<region>] [-project=<project>]")
+	fmt.Println("  cli search [-limit=<n>] <query>, e.g. 'image~nginx AND status=degraded'")
+	fmt.Println("  cli projects [-tenant=<tenant>] [<name>], or -save/-delete <name>")
+	fmt.Println("  cli namespaces, or -create [-tenant=<tenant>] / -delete <name>")
+	fmt.Println("  cli convert -f <compose file or Kubernetes manifests> [-o <dir>] [-domain=<domain>] [-deploy]")
 	fmt.Println()
 	fmt.Println("Flags:")
 	fmt.Println("  -server string         gRPC server address (default: localhost:50051)")
-	fmt.Println("  -action string         Action: deploy, delete, status, health")
-	fmt.Println("  -name string           Application name")
+	fmt.Println("  -tls                   Connect to the controller over TLS, implied by -ca and -tls-cert")
+	fmt.Println("  -ca string             PEM CA bundle verifying the controller's certificate (default: the system roots)")
+	fmt.Println("  -tls-cert string       PEM client certificate for controllers requiring mutual TLS, the subcommands")
+	fmt.Println("                         name it -cert")
+	fmt.Println("  -tls-key string        PEM key of the client certificate, -key for the subcommands")
+	fmt.Println("  -token string          API token of the controller (env: CONTROLPLANE_TOKEN)")
+	fmt.Println("  -namespace string      Namespace of the applications, * lists every namespace (env: CONTROLPLANE_NAMESPACE,")
+	fmt.Println("                         default: default)")
+	fmt.Println("  -idempotency-key string")
+	fmt.Println("                         Key of the request, retrying the action with it returns the original result")
+	fmt.Println("  -action string         Action: deploy, delete, scale, status, health, invoke, function-metrics, dispatch,")
+	fmt.Println("                         logs, run, config, set-config, cron-runs, cron-trigger, cron-pause, cron-resume,")
+	fmt.Println("                         deploy-stack, publish-blueprint, subscribe, subscriptions, apply-update, impact, graph,")
+	fmt.Println("                         apply, apply-spec, app-health, create-volume, volumes, delete-volume, backup,")
+	fmt.Println("                         snapshots, restore, add-domain, verify-domain, domains, drift, attach,")
+	fmt.Println("                         artifacts, get-artifact, explain-placement, reconciler, calendar, deploy-raw,")
+	fmt.Println("                         recommend, apply-recommendation, analytics, restarts, timeline, history, rollback,")
+	fmt.Println("                         update, promote, fail")
+	fmt.Println("  -name string           Application name, or volume ID for the volume actions")
 	fmt.Println("  -image string          Container image")
 	fmt.Println("  -replicas int          Number of replicas (default: 1)")
 	fmt.Println("  -cpu float             CPU cores (default: 0.1)")
 	fmt.Println("  -memory int            Memory in MB (default: 128)")
-	fmt.Println("  -region string         Target region (default: global)")
+	fmt.Println("  -memory-max int        Memory in MB the application may burst to, requires memory oversubscription")
+	fmt.Println("  -region string         Target region (default: chosen by the controller's placement engine, or global)")
+	fmt.Println("  -region-selector string")
+	fmt.Println("                         Label the placed region must have as key=value, e.g. provider=aws (repeatable)")
+	fmt.Println("  -latency-hint string   Where the users are, regions close to it are preferred, e.g. eu")
+	fmt.Println("  -geo-host string       Hostname of the DNS records routing users to the -geo-target regions")
+	fmt.Println("  -geo-target string     Region to deploy to and route users to as <region>[=<weight>][:<location>,...],")
+	fmt.Println("                         e.g. eu-west:EU (repeatable)")
+	fmt.Println("  -failover              Withdraw the records of a geo target region while the application is unhealthy there")
+	fmt.Println("  -min-replicas int      Fewest instances the autoscaler keeps (default: -replicas)")
+	fmt.Println("  -max-replicas int      Most instances the autoscaler scales to, enables autoscaling with -scale-metric")
+	fmt.Println("  -scale-metric string   Metric the autoscaler keeps at its target as <provider>:<target>[:<query>],")
+	fmt.Println("                         e.g. cpu:60, {app} in the query is the job ID (repeatable)")
+	fmt.Println("  -scale-cooldown duration")
+	fmt.Println("                         Time between two scalings (default: the controller's 5m)")
+	fmt.Println("  -scale-down-cooldown duration")
+	fmt.Println("                         Time between a scaling and scaling in (default: -scale-cooldown)")
+	fmt.Println("  -scale-predict string  Scale out ahead of the recurring peaks of the CPU usage: daily, weekly")
+	fmt.Println("                         (requires -scale-metric=cpu:<target>)")
+	fmt.Println("  -scale-predict-confidence float")
+	fmt.Println("                         Confidence between 0 and 1 a prediction needs to scale out (default: the controller's 0.8)")
+	fmt.Println("  -scale-predict-lead duration")
+	fmt.Println("                         How far ahead of a predicted peak to scale out (default: the controller's 15m)")
+	fmt.Println("  -scale-smoothing duration")
+	fmt.Println("                         Average the -scale-metric values over about this long to damp bursts")
 	fmt.Println("  -network string        Network mode: host, bridge (default: host)")
 	fmt.Println("  -host string   		  Enable Traefik with hostname")
 	fmt.Println("  -ssl           		  Enable SSL for Traefik")
+	fmt.Println("  -cert string           Certificate: host, wildcard (default: the controller's policy decides)")
+	fmt.Println("  -san string            Extra host of the application's certificate (repeatable)")
 	fmt.Println("  -delete-id string      Deployment ID to delete (for delete action)")
+	fmt.Println("  -count int             Instance count to scale the task group to (for scale action)")
+	fmt.Println("  -task-group string     Task group to scale, required for jobs with more than one group, or whose")
+	fmt.Println("                         canaries are promoted (for promote action, default: every group)")
+	fmt.Println("  -constraint string     Placement constraint, e.g. 'meta.storage=ssd' (repeatable)")
+	fmt.Println("  -disk int              Ephemeral disk size in MB (default: 300)")
+	fmt.Println("  -disk-sticky           Keep the ephemeral disk on the same node when rescheduling")
+	fmt.Println("  -disk-migrate          Migrate the ephemeral disk data when rescheduling")
+	fmt.Println("  -idle-timeout int      Scale to zero after this many minutes without traffic (requires -host)")
+	fmt.Println("  -type string           Deployment type: service, function, cron (default: service)")
+	fmt.Println("  -max-concurrency int   Concurrent invocations of a function (default: 10)")
+	fmt.Println("  -timeout int           Seconds a function invocation or action may run (default: 60 for invocations,")
+	fmt.Println("                         300 for actions)")
+	fmt.Println("  -artifact string       Code artifact unpacked into the function's local/ dir")
+	fmt.Println("  -meta-key string       Meta key function invocations may pass (repeatable)")
+	fmt.Println("  -depends-on string     Application the deployed one consumes (repeatable)")
+	fmt.Println("  -allow-from string     Application allowed to call the deployed one through the Connect mesh")
+	fmt.Println("                         (repeatable)")
+	fmt.Println("  -volume string         CSI volume to mount as <volume id>:<path>[:ro,per-alloc] (repeatable)")
+	fmt.Println("  -addon string          Managed dependency as <name>=<type>[:<version>][@<volume id>], types: postgres,")
+	fmt.Println("                         redis (repeatable)")
+	fmt.Println("  -egress string         Allowed outbound traffic as service:<name> or [udp:]<cidr>[@<port>,...]")
+	fmt.Println("                         (repeatable)")
+	fmt.Println("  -no-new-privileges     Keep the application's processes from gaining privileges")
+	fmt.Println("  -seccomp string        Seccomp profile: default, unconfined or a profile name (default: the tenant's)")
+	fmt.Println("  -apparmor string       AppArmor profile (default: the tenant's)")
+	fmt.Println("  -cap-drop string       Capability dropped from the application, e.g. NET_RAW or ALL (repeatable)")
+	fmt.Println("  -backup-to string      Back up the volumes to s3://bucket/prefix")
+	fmt.Println("  -backup-schedule string")
+	fmt.Println("                         Cron schedule of the backups (default: only on request)")
+	fmt.Println("  -pin-on-drift          Redeploy pinned to the deployed digest when the image's tag moves")
+	fmt.Println("  -port string           Port of the task as <label>:<container port>[:<host port>][/<protocol>],")
+	fmt.Println("                         e.g. dns:53:53/udp (repeatable, default: http:80)")
+	fmt.Println("  -env string            Environment variable of the application as KEY=VALUE, may hold environment")
+	fmt.Println("                         expressions (repeatable)")
+	fmt.Println("  -env-file string       File of KEY=VALUE lines set as environment variables, -env takes precedence")
+	fmt.Println("  -annotation string     Annotation shown in the Nomad UI as key=value, e.g. runbook=https://wiki.example.com/shop (repeatable)")
+	fmt.Println("  -concurrency-group string")
+	fmt.Println("                         Concurrency group whose rollouts run one at a time, e.g. db-migrations")
+	fmt.Println("  -rollout-deadline duration")
+	fmt.Println("                         Fail the rollout when it is still running after this long, e.g. 10m")
+	fmt.Println("  -revert-on-deadline    Revert to the last stable version when the rollout deadline passes")
+	fmt.Println("  -max-parallel int      Allocations replaced at a time during a rollout (default: Nomad's 1)")
+	fmt.Println("  -canary int            Allocations of the new version placed next to the old ones first")
+	fmt.Println("  -auto-revert           Let Nomad revert to the last stable version when the rollout fails")
+	fmt.Println("  -auto-promote          Let Nomad promote the canaries once all of them are healthy")
+	fmt.Println("  -min-healthy-time duration")
+	fmt.Println("                         How long an allocation must be healthy to count as healthy (default: Nomad's 10s)")
+	fmt.Println("  -healthy-deadline duration")
+	fmt.Println("                         An allocation not healthy after this long is unhealthy (default: Nomad's 5m)")
+	fmt.Println("  -deployment string     Rollout to promote or fail (for promote and fail actions, default: the traffic")
+	fmt.Println("                         split or the latest rollout)")
+	fmt.Println("  -strategy string       Deploy strategy: rolling, blue-green, canary-traffic (default: rolling)")
+	fmt.Println("  -traffic-weights string")
+	fmt.Println("                         Percent of the traffic shifted to the new version per step, e.g. 10,25,50")
+	fmt.Println("  -step-interval duration")
+	fmt.Println("                         How long a traffic step holds before the next one (default: 1m)")
+	fmt.Println("  -manual-promotion      Wait for the promote action after the last traffic step")
+	fmt.Println("  -wait                  Stream the rollout until it finished, exit with an error when it failed")
+	fmt.Println("                         (for deploy, apply, apply-spec, deploy-raw and update actions)")
+	fmt.Println("  -dry-run               Show what the scheduler would change without deploying (for deploy, apply,")
+	fmt.Println("                         apply-spec and update actions)")
+	fmt.Println("  -watch                 Refresh the status until interrupted and highlight what changed (for status action)")
+	fmt.Println("  -interval duration     Refresh interval of -watch, slowed down while nothing changes (default: 2s)")
+	fmt.Println("  -propose               Record the recommendations as resource updates awaiting approval (for recommend action)")
+	fmt.Println("  -by-team               Aggregate the analytics of the applications of each tenant (for analytics action)")
+	fmt.Println("  -since duration        Period the analytics or the timeline cover (default: 720h)")
+	fmt.Println("  -event-type string     Only show timeline events of this type, e.g. restart (repeatable)")
+	fmt.Println("  -bucket string         Periods of the analytics time series: day, week (default: week)")
+	fmt.Println("  -periods               Show the analytics of every period besides the total (for analytics action)")
+	fmt.Println("  -within duration       How far ahead the calendar shows scheduled operations (default: 24h)")
+	fmt.Println("  -dismiss               Dismiss the proposed resource update instead of applying it")
+	fmt.Println("                         (for apply-recommendation action)")
+	fmt.Println("  -all                   Show a one-line summary of every application (for status action)")
+	fmt.Println("  -sort string           Sort the applications of -all by: name, age, health, region, status (default: name)")
+	fmt.Println("  -filter string         Only list applications matching field=pattern, e.g. region=eu-*, a bare pattern")
+	fmt.Println("                         matches the name (for status -all, repeatable)")
+	fmt.Println("  -drifted               Only list applications whose image tag moved (for drift action)")
+	fmt.Println("  -kind string           Kind of the attached artifact: sbom, provenance (default: provenance)")
+	fmt.Println("  -media-type string     Media type of the attached artifact, e.g. application/spdx+json")
+	fmt.Println("  -artifact-id string    Artifact to retrieve (for get-artifact action)")
+	fmt.Println("  -payload string        Payload passed to a function invocation")
+	fmt.Println("  -payload-file string   File with the payload passed to a function invocation")
+	fmt.Println("  -meta string           Meta passed to a function invocation as key=value (repeatable)")
+	fmt.Println("  -task-action string    Action operators run in the application as <name>=<command> [args...],")
+	fmt.Println("                         e.g. 'flush-cache=/app/bin/cache flush' (repeatable)")
+	fmt.Println("  -run string            Action of the application to run (for run action)")
+	fmt.Println("  -consul-kv             Keep the configuration of the application under a Consul KV prefix, rendered as")
+	fmt.Println("                         environment variables")
+	fmt.Println("  -set string            Config value as KEY=VALUE (for deploy with -consul-kv and set-config, repeatable)")
+	fmt.Println("  -unset string          Config key to delete (for set-config, repeatable)")
+	fmt.Println("  -alloc string          Allocation ID for logs and run (default: most recent), filters the timeline")
+	fmt.Println("  -task string           Task name for logs (default: the allocation's only task)")
+	fmt.Println("  -log-type string       Log type: stdout, stderr (default: stdout)")
+	fmt.Println("  -tail int              Number of log lines to show (default: 100)")
+	fmt.Println("  -follow                Keep printing the lines the task writes until interrupted (for logs action)")
+	fmt.Println("  -schedule string       Cron schedule of a cron deployment, e.g. '0 3 * * *'")
+	fmt.Println("  -time-zone string      Time zone of the cron schedule (default: UTC)")
+	fmt.Println("  -prohibit-overlap      Skip a cron run while the previous one is still running")
+	fmt.Println("  -limit int             Number of cron runs, most recent timeline events or revisions to list (default: 10)")
+	fmt.Println("  -f string              JSON file: stack for deploy-stack; JSON or YAML file: spec or manifest for apply,")
+	fmt.Println("                         apply-spec, publish-blueprint and explain-placement, overrides for subscribe;")
+	fmt.Println("                         artifact for attach; Nomad job (JSON or HCL) for deploy-raw")
+	fmt.Println("  -continue-on-error     Keep deploying later stack stages when an application fails")
+	fmt.Println("  -blueprint string      Blueprint name")
+	fmt.Println("  -channel string        Blueprint release channel (default: stable)")
+	fmt.Println("  -policy string         Blueprint update policy: propose, auto (default: propose)")
+	fmt.Println("  -pin int               Pin the subscription to a blueprint version (default: follow the channel)")
+	fmt.Println("  -version int           Blueprint version to apply (default: pinned version or channel head)")
+	fmt.Println("  -revision int          Revision whose spec is deployed again (for rollback action, default: Nomad reverts")
+	fmt.Println("                         to the last stable job version), or the update is made against (for update action)")
+	fmt.Println("  -behind                Only list subscriptions running an outdated blueprint version")
+	fmt.Println("  -tenant string         Tenant owning the application, blueprint, subscription or domain")
+	fmt.Println("  -domain string         Custom domain of the tenant, e.g. shop.example.com")
+	fmt.Println("  -plugin string         CSI plugin of the volume")
+	fmt.Println("  -external-id string    Register an existing volume of the storage provider instead of creating one")
+	fmt.Println("  -capacity int          Minimum capacity of the volume in MB")
+	fmt.Println("  -access-mode string    Access mode of the volume (default: single-node-writer)")
+	fmt.Println("  -fs-type string        File system of the volume, e.g. ext4")
+	fmt.Println("  -param string          Parameter passed to the CSI plugin as key=value (repeatable)")
+	fmt.Println("  -deregister            Only deregister the volume from Nomad, keep it at the storage provider")
+	fmt.Println("  -snapshot string       Snapshot to restore (default: latest complete snapshot)")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println()
 	fmt.Println("  # Deploy application")
 	fmt.Println("  cli -action=deploy -name=webapp -image=nginx:latest -replicas=2")
 	fmt.Println()
+	fmt.Println("  # Deploy only on nodes with SSD storage")
+	fmt.Println("  cli -action=deploy -name=db -image=postgres:16 -constraint='meta.storage=ssd'")
+	fmt.Println()
+	fmt.Println("  # Provision a volume and run a database on it")
+	fmt.Println("  cli -action=create-volume -name=pg-data -plugin=aws-ebs -capacity=10240 -param=type=gp3")
+	fmt.Println("  cli -action=deploy -name=db -image=postgres:16 -volume=pg-data:/var/lib/postgresql/data \\")
+	fmt.Println("    -backup-to=s3://acme-backups/prod -backup-schedule='0 2 * * *'")
+	fmt.Println()
+	fmt.Println("  # Deploy an application with a managed Postgres, it reads DB_URL from its environment")
+	fmt.Println("  cli -action=deploy -name=shop -image=acme/shop:1.0 -addon=db=postgres:16@pg-data")
+	fmt.Println()
+	fmt.Println("  # Route a tenant's application on its own domain, after creating the printed TXT record")
+	fmt.Println("  cli -action=add-domain -tenant=payments -domain=pay.example.com")
+	fmt.Println("  cli -action=verify-domain -tenant=payments -domain=pay.example.com")
+	fmt.Println("  cli -action=deploy -tenant=payments -name=checkout -image=acme/checkout:1.0 -host=checkout.pay.example.com")
+	fmt.Println()
+	fmt.Println("  # Restore the database from its latest backup, once it is scaled to zero")
+	fmt.Println("  cli -action=snapshots -name=db")
+	fmt.Println("  cli -action=restore -name=db")
+	fmt.Println()
+	fmt.Println("  # Deploy and invoke a function")
+	fmt.Println("  cli -action=deploy -type=function -name=resize -image=acme/resize:1.0 -max-concurrency=5")
+	fmt.Println("  cli -action=invoke -name=resize -payload-file=image.json")
+	fmt.Println()
+	fmt.Println("  # Dispatch a parameterized job and read its logs")
+	fmt.Println("  cli -action=dispatch -name=report -meta=month=2025-09")
+	fmt.Println("  cli -action=logs -name=report/dispatch-1757000000-3f2a1b4c")
+	fmt.Println()
+	fmt.Println("  # Schedule a nightly job, list its runs and run it now")
+	fmt.Println("  cli -action=deploy -type=cron -name=backup -image=acme/backup:1.0 -schedule='0 3 * * *'")
+	fmt.Println("  cli -action=cron-runs -name=backup")
+	fmt.Println("  cli -action=cron-trigger -name=backup")
+	fmt.Println()
+	fmt.Println("  # Deploy a stack in dependency order")
+	fmt.Println("  cli -action=deploy-stack -f shop.json")
+	fmt.Println()
+	fmt.Println("  # Publish a base spec and deploy applications following its stable channel")
+	fmt.Println("  cli -action=publish-blueprint -blueprint=node-api -channel=stable -f node-api.json")
+	fmt.Println("  cli -action=subscribe -name=orders -blueprint=node-api -policy=auto -f orders-overrides.json")
+	fmt.Println("  cli -action=subscriptions -behind")
+	fmt.Println("  cli -action=apply-update -name=payments")
+	fmt.Println()
+	fmt.Println("  # Check specs offline, e.g. in a pre-commit hook or CI")
+	fmt.Println("  cli validate -f app.yaml")
+	fmt.Println("  cli lint -environment=prod -strict -f app.yaml")
+	fmt.Println()
+	fmt.Println("  # Deploy from a pipeline, reporting to GitHub Actions")
+	fmt.Println("  CP_SPEC=deploy/web.yaml CP_IMAGE_TAG=$GITHUB_SHA cli ci deploy")
+	fmt.Println()
+	fmt.Println("  # Deploy the manifest of an application, see what would change first")
+	fmt.Println("  cli -action=apply -f app.yaml -dry-run")
+	fmt.Println("  cli -action=apply -f app.yaml -wait")
+	fmt.Println()
+	fmt.Println("  # Deploy a spec too large for a single request")
+	fmt.Println("  cli -action=apply-spec -f big-spec.json")
+	fmt.Println()
+	fmt.Println("  # Show what consumes an application before deleting it")
+	fmt.Println("  cli -action=deploy -name=api -image=acme/api:1.4 -depends-on=postgres")
+	fmt.Println("  cli -action=impact -name=postgres")
+	fmt.Println("  cli -action=graph | dot -Tsvg > graph.svg")
+	fmt.Println()
+	fmt.Println("  # Onboard a team (admin)")
+	fmt.Println("  cli admin tenant create -name=payments -cpu=8 -memory=16384")
+	fmt.Println()
 	fmt.Println("  # Get application status")
 	fmt.Println("  cli -action=status -name=webapp")
 	fmt.Println()
 	fmt.Println("  # Check service health")
 	fmt.Println("  cli -action=health")
 	fmt.Println()
+	fmt.Println("  # Show the health of every application (Healthy, Progressing, Degraded, Suspended)")
+	fmt.Println("  cli -action=app-health")
+	fmt.Println()
 	fmt.Println("  # Delete application")
 	fmt.Println("  cli -action=delete -name=webapp")
 }
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// runNamespaces lists the namespaces, or creates or deletes one
+func runNamespaces(args []string) {
+	fs := flag.NewFlagSet("namespaces", flag.ExitOnError)
+	var (
+		server      = fs.String("server", "localhost:50051", "gRPC server address")
+		create      = fs.Bool("create", false, "Create the namespace and its Nomad namespace")
+		remove      = fs.Bool("delete", false, "Delete the namespace, it must have no applications")
+		description = fs.String("description", "", "Description of the namespace (with -create)")
+		tenant      = fs.String("tenant", "", "Only admit the applications of this tenant (with -create)")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: cli namespaces [flags] [name]\n\n")
+		fmt.Fprintf(fs.Output(), "Without -create or -delete the namespaces the token sees are listed. Applications are\n")
+		fmt.Fprintf(fs.Output(), "deployed to one with -namespace or metadata.namespace of their manifest.\n\n")
+		fs.PrintDefaults()
+	}
+	var connFlags connection
+	connFlags.register(fs, "")
+	_ = fs.Parse(args)
+	name := fs.Arg(0)
+	if (*create || *remove) && name == "" {
+		log.Fatalf("A namespace name must be provided with -create and -delete")
+	}
+
+	conn, err := grpc.NewClient(*server, connFlags.dialOptions()...)
+	if err != nil {
+		log.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client := pb.NewControlPlaneClient(conn)
+
+	switch {
+	case *create:
+		resp, err := client.CreateNamespace(ctx, &pb.CreateNamespaceRequest{
+			Name:        name,
+			Description: *description,
+			Tenant:      *tenant,
+		})
+		if err != nil {
+			log.Fatalf("Failed to create namespace: %v", err)
+		}
+		if !resp.Success {
+			log.Fatalf("Failed to create namespace: %s", resp.Message)
+		}
+		fmt.Println(resp.Message)
+	case *remove:
+		resp, err := client.DeleteNamespace(ctx, &pb.DeleteNamespaceRequest{Name: name})
+		if err != nil {
+			log.Fatalf("Failed to delete namespace: %v", err)
+		}
+		if !resp.Success {
+			log.Fatalf("Failed to delete namespace: %s", resp.Message)
+		}
+		fmt.Println(resp.Message)
+	default:
+		listNamespaces(ctx, client)
+	}
+}
+
+func listNamespaces(ctx context.Context, client pb.ControlPlaneClient) {
+	resp, err := client.ListNamespaces(ctx, &pb.ListNamespacesRequest{})
+	if err != nil {
+		log.Fatalf("Failed to list namespaces: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to list namespaces: %s", resp.Message)
+	}
+	if len(resp.Namespaces) == 0 {
+		fmt.Printf("No namespaces found\n")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tAPPS\tTENANT\tDESCRIPTION")
+	for _, namespace := range resp.Namespaces {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n",
+			namespace.Name,
+			namespace.Applications,
+			orDash(namespace.Tenant),
+			orDash(namespace.Description))
+	}
+	w.Flush()
+}
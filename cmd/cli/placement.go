@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// explainPlacement prints the recorded placement decision of an application, or the
+// decision the controller would make for a spec file
+func explainPlacement(ctx context.Context, client pb.ControlPlaneClient, name, file string) {
+	if name == "" && file == "" {
+		log.Fatalf("-name or -f must be provided for explain-placement action")
+	}
+
+	req := &pb.ExplainPlacementRequest{Name: name}
+	if file != "" {
+		req = &pb.ExplainPlacementRequest{Spec: readSpec(file)}
+	}
+	resp, err := client.ExplainPlacement(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to explain placement: %v", err)
+	}
+
+	if len(resp.Candidates) > 0 {
+		fmt.Printf("\nRegions:\n")
+	}
+	for _, candidate := range resp.Candidates {
+		if !candidate.Eligible {
+			fmt.Printf("  - %s: not eligible, %s\n", candidate.Region, candidate.Reason)
+			continue
+		}
+		fmt.Printf("  - %s: score %.2f (capacity %.2f, latency %.2f, cost %.2f)\n",
+			candidate.Region, candidate.Score, candidate.CapacityScore, candidate.LatencyScore, candidate.CostScore)
+		fmt.Printf("    Free: %d MHz, %d MB\n", candidate.CpuFreeMhz, candidate.MemoryFreeMb)
+	}
+	if resp.Region != "" {
+		fmt.Printf("\nRegion: %s (decided %s)\n", resp.Region, time.Unix(resp.DecidedAt, 0).Format(time.RFC3339))
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
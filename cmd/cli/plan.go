@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// planMarkers prefix the changes of a plan like nomad job plan does
+var planMarkers = map[string]string{"Added": "+", "Deleted": "-", "Edited": "~"}
+
+// printPlan prints what the scheduler would change for a dry run
+func printPlan(resp *pb.DeployResponse) {
+	plan := resp.Plan
+	fmt.Printf("Job: %s\n", resp.JobId)
+	fmt.Printf("Diff: %s\n", plan.DiffType)
+
+	if len(plan.Changes) > 0 {
+		fmt.Printf("Changes:\n")
+		for _, change := range plan.Changes {
+			marker := planMarkers[change.Type]
+			switch change.Type {
+			case "Added":
+				fmt.Printf("  %s %s: %q\n", marker, change.Path, change.New)
+			case "Deleted":
+				fmt.Printf("  %s %s: %q\n", marker, change.Path, change.Old)
+			default:
+				fmt.Printf("  %s %s: %q => %q\n", marker, change.Path, change.Old, change.New)
+			}
+		}
+	}
+
+	if len(plan.Groups) > 0 {
+		fmt.Printf("Scheduler:\n")
+		for _, group := range plan.Groups {
+			var updates []string
+			for _, update := range []struct {
+				count uint64
+				name  string
+			}{
+				{group.Place, "create"},
+				{group.DestructiveUpdate, "destroy and replace"},
+				{group.InPlaceUpdate, "update in place"},
+				{group.Stop, "stop"},
+				{group.Migrate, "migrate"},
+				{group.Canary, "canary"},
+				{group.Preemptions, "preempt"},
+				{group.Ignore, "ignore"},
+			} {
+				if update.count > 0 {
+					updates = append(updates, fmt.Sprintf("%d %s", update.count, update.name))
+				}
+			}
+			if len(updates) == 0 {
+				updates = append(updates, "no changes")
+			}
+			fmt.Printf("  %s: %s\n", group.TaskGroup, strings.Join(updates, ", "))
+		}
+	}
+
+	if len(plan.FailedPlacements) > 0 {
+		fmt.Printf("Failed placements:\n")
+		for _, failure := range plan.FailedPlacements {
+			fmt.Printf("  - %s\n", failure)
+		}
+	}
+	if plan.Warnings != "" {
+		fmt.Printf("Warnings: %s\n", strings.TrimSpace(plan.Warnings))
+	}
+	if len(resp.Warnings) > 0 {
+		fmt.Printf("Lint warnings:\n")
+		printWarnings(resp.Warnings)
+	}
+	fmt.Printf("Message: %s\n", resp.Message)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// parsePort parses <label>:<container port>[:<host port>][/<protocol>], e.g. http:8080
+// or dns:53:53/udp
+func parsePort(expr string) (*pb.PortSpec, error) {
+	spec, protocol, _ := strings.Cut(expr, "/")
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return nil, fmt.Errorf("invalid port %q, expected <label>:<container port>[:<host port>][/<protocol>]", expr)
+	}
+
+	port := &pb.PortSpec{Label: parts[0], Protocol: protocol}
+	containerPort, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q: invalid container port %q", expr, parts[1])
+	}
+	port.ContainerPort = int32(containerPort)
+	if len(parts) == 3 {
+		hostPort, err := strconv.ParseUint(parts[2], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q: invalid host port %q", expr, parts[2])
+		}
+		port.HostPort = int32(hostPort)
+	}
+	return port, nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// runProjects lists the projects, shows one with its applications, or saves or deletes one
+func runProjects(args []string) {
+	fs := flag.NewFlagSet("projects", flag.ExitOnError)
+	var (
+		server          = fs.String("server", "localhost:50051", "gRPC server address")
+		tenant          = fs.String("tenant", "", "Tenant of the project, or only list the projects of this tenant")
+		save            = fs.Bool("save", false, "Create or update the project")
+		remove          = fs.Bool("delete", false, "Delete the project, it must have no applications")
+		description     = fs.String("description", "", "Description of the project (with -save)")
+		defaults        = fs.String("defaults", "", "JSON or YAML spec the applications of the project are merged over (with -save)")
+		cpu             = fs.Float64("cpu", 0, "Cores the applications of the project may request in total, 0 is no limit (with -save)")
+		memory          = fs.Int64("memory", 0, "Memory in MB the applications of the project may request in total, 0 is no limit (with -save)")
+		maxApplications = fs.Int("max-applications", 0, "Most applications of the project, 0 is no limit (with -save)")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: cli projects [flags] [name]\n\n")
+		fmt.Fprintf(fs.Output(), "Without a name the projects are listed, with one the project and its applications are shown.\n")
+		fmt.Fprintf(fs.Output(), "Applications join a project with the label project=<name>.\n\n")
+		fs.PrintDefaults()
+	}
+	var connFlags connection
+	connFlags.register(fs, "")
+	_ = fs.Parse(args)
+	name := fs.Arg(0)
+	if (*save || *remove) && name == "" {
+		log.Fatalf("A project name must be provided with -save and -delete")
+	}
+
+	conn, err := grpc.NewClient(*server, connFlags.dialOptions()...)
+	if err != nil {
+		log.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client := pb.NewControlPlaneClient(conn)
+
+	switch {
+	case *save:
+		req := &pb.SaveProjectRequest{
+			Name:        name,
+			Tenant:      *tenant,
+			Description: *description,
+			Quota:       &pb.ProjectQuota{Cpu: *cpu, MemoryMb: *memory, MaxApplications: int32(*maxApplications)},
+		}
+		if *defaults != "" {
+			req.Defaults = readSpec(*defaults)
+		}
+		resp, err := client.SaveProject(ctx, req)
+		if err != nil {
+			log.Fatalf("Failed to save project: %v", err)
+		}
+		if !resp.Success {
+			log.Fatalf("Failed to save project: %s", resp.Message)
+		}
+		fmt.Println(resp.Message)
+	case *remove:
+		resp, err := client.DeleteProject(ctx, &pb.DeleteProjectRequest{Name: name})
+		if err != nil {
+			log.Fatalf("Failed to delete project: %v", err)
+		}
+		if !resp.Success {
+			log.Fatalf("Failed to delete project: %s", resp.Message)
+		}
+		fmt.Println(resp.Message)
+	case name != "":
+		showProject(ctx, client, name)
+	default:
+		listProjects(ctx, client, *tenant)
+	}
+}
+
+func listProjects(ctx context.Context, client pb.ControlPlaneClient, tenant string) {
+	resp, err := client.ListProjects(ctx, &pb.ListProjectsRequest{Tenant: tenant})
+	if err != nil {
+		log.Fatalf("Failed to list projects: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to list projects: %s", resp.Message)
+	}
+	if len(resp.Projects) == 0 {
+		fmt.Printf("No projects found\n")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTATUS\tAPPS\tCPU\tMEMORY\tTENANT")
+	for _, project := range resp.Projects {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			project.Name,
+			orDash(project.Status),
+			usageOf(float64(project.Usage.MaxApplications), float64(project.Quota.MaxApplications), "%.0f"),
+			usageOf(project.Usage.Cpu, project.Quota.Cpu, "%.1f"),
+			usageOf(float64(project.Usage.MemoryMb), float64(project.Quota.MemoryMb), "%.0f MB"),
+			orDash(project.Tenant))
+	}
+	w.Flush()
+}
+
+func showProject(ctx context.Context, client pb.ControlPlaneClient, name string) {
+	resp, err := client.GetProject(ctx, &pb.GetProjectRequest{Name: name})
+	if err != nil {
+		log.Fatalf("Failed to get project: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to get project: %s", resp.Message)
+	}
+
+	project := resp.Project
+	fmt.Printf("Project: %s\n", project.Name)
+	if project.Tenant != "" {
+		fmt.Printf("Tenant: %s\n", project.Tenant)
+	}
+	if project.Description != "" {
+		fmt.Printf("Description: %s\n", project.Description)
+	}
+	fmt.Printf("Status: %s\n", orDash(project.Status))
+	var statuses []string
+	for _, status := range []string{"healthy", "progressing", "degraded", "suspended", "unknown"} {
+		if count := project.Statuses[status]; count > 0 {
+			statuses = append(statuses, fmt.Sprintf("%d %s", count, status))
+		}
+	}
+	fmt.Printf("Applications: %s\n", usageOf(float64(project.Usage.MaxApplications), float64(project.Quota.MaxApplications), "%.0f"))
+	if len(statuses) > 0 {
+		fmt.Printf("  %s\n", strings.Join(statuses, ", "))
+	}
+	fmt.Printf("CPU: %s cores\n", usageOf(project.Usage.Cpu, project.Quota.Cpu, "%.1f"))
+	fmt.Printf("Memory: %s\n", usageOf(float64(project.Usage.MemoryMb), float64(project.Quota.MemoryMb), "%.0f MB"))
+	if resp.Defaults != nil {
+		if data, err := specYAML(resp.Defaults); err == nil {
+			fmt.Printf("Defaults:\n")
+			for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+				fmt.Printf("  %s\n", line)
+			}
+		}
+	}
+
+	if project.Applications > 0 {
+		fmt.Println()
+		listApplications(ctx, client, &pb.ListApplicationsRequest{Project: name, PageSize: 100}, "name", nil)
+	}
+}
+
+// usageOf formats what is used of a limit, 0 is no limit
+func usageOf(used, limit float64, format string) string {
+	if limit == 0 {
+		return fmt.Sprintf(format, used)
+	}
+	return fmt.Sprintf(format+" / "+format, used, limit)
+}
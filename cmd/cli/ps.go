@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// runPS lists every application like `-action=status -all`
+func runPS(args []string) {
+	fs := flag.NewFlagSet("ps", flag.ExitOnError)
+	var (
+		server   = fs.String("server", "localhost:50051", "gRPC server address")
+		sortBy   = fs.String("sort", "name", "Sort by: name, age, health, region, status")
+		region   = fs.String("region", "", "Only list the applications running in this region")
+		project  = fs.String("project", "", "Only list the applications of this project")
+		pageSize = fs.Int("page-size", 100, "Number of applications fetched per request")
+		filters  stringList
+		labels   stringList
+	)
+	fs.Var(&filters, "filter", "Only list applications matching field=pattern, e.g. region=eu-* or status=pending, a bare pattern matches the name (repeatable)")
+	fs.Var(&labels, "label", "Only list applications with the label key=value (repeatable)")
+	var connFlags connection
+	connFlags.register(fs, "")
+	_ = fs.Parse(args)
+
+	req := &pb.ListApplicationsRequest{Region: *region, Project: *project, PageSize: int32(*pageSize), Labels: make(map[string]string)}
+	for _, label := range labels {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || key == "" {
+			log.Fatalf("Invalid label %q, expected key=value", label)
+		}
+		req.Labels[key] = value
+	}
+
+	conn, err := grpc.NewClient(*server, connFlags.dialOptions()...)
+	if err != nil {
+		log.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	listApplications(ctx, pb.NewControlPlaneClient(conn), req, *sortBy, filters)
+}
+
+// listApplications prints one line per application: name, image, healthy/desired
+// instances, region, URL and the age of the running job version. It fetches every page
+// of the request, sorting and filters apply to all of them.
+func listApplications(ctx context.Context, client pb.ControlPlaneClient, req *pb.ListApplicationsRequest, sortBy string, filters []string) {
+	less, ok := applicationOrders[sortBy]
+	if !ok {
+		log.Fatalf("-sort must be one of name, age, health, region, status")
+	}
+	for _, filter := range filters {
+		if _, err := matchApplication(&pb.ApplicationSummary{}, filter); err != nil {
+			log.Fatalf("Invalid filter: %v", err)
+		}
+	}
+
+	var applications []*pb.ApplicationSummary
+	var resp *pb.ListApplicationsResponse
+	for {
+		var err error
+		resp, err = client.ListApplications(ctx, req)
+		if err != nil {
+			log.Fatalf("Failed to list applications: %v", err)
+		}
+
+		for _, application := range resp.Applications {
+			matches := true
+			for _, filter := range filters {
+				if matched, _ := matchApplication(application, filter); !matched {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				applications = append(applications, application)
+			}
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	sort.SliceStable(applications, func(i, j int) bool {
+		return less(applications[i], applications[j])
+	})
+
+	if len(applications) == 0 {
+		fmt.Printf("No applications found\n")
+		fmt.Printf("\nMessage: %s\n", resp.Message)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTATUS\tIMAGE\tHEALTHY\tREGION\tURL\tAGE")
+	for _, application := range applications {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%s\t%s\t%s\n",
+			application.Name,
+			application.Status,
+			orDash(application.Image),
+			application.HealthyInstances, application.DesiredInstances,
+			orDash(application.Region),
+			orDash(application.Url),
+			formatAge(application.SubmittedAt))
+	}
+	w.Flush()
+}
+
+// applicationOrders are the orders of -sort, unhealthy and recently changed applications first
+var applicationOrders = map[string]func(a, b *pb.ApplicationSummary) bool{
+	"name": func(a, b *pb.ApplicationSummary) bool {
+		return a.Name < b.Name
+	},
+	"age": func(a, b *pb.ApplicationSummary) bool {
+		return a.SubmittedAt > b.SubmittedAt
+	},
+	"health": func(a, b *pb.ApplicationSummary) bool {
+		return healthRatio(a) < healthRatio(b)
+	},
+	"region": func(a, b *pb.ApplicationSummary) bool {
+		return a.Region < b.Region
+	},
+	"status": func(a, b *pb.ApplicationSummary) bool {
+		return a.Status < b.Status
+	},
+}
+
+func healthRatio(application *pb.ApplicationSummary) float64 {
+	if application.DesiredInstances == 0 {
+		return 1
+	}
+	return float64(application.HealthyInstances) / float64(application.DesiredInstances)
+}
+
+// matchApplication matches a field=pattern filter, patterns are shell globs
+func matchApplication(application *pb.ApplicationSummary, filter string) (bool, error) {
+	field, pattern, ok := strings.Cut(filter, "=")
+	if !ok {
+		field, pattern = "name", filter
+	}
+
+	var value string
+	switch field {
+	case "name":
+		value = application.Name
+	case "tenant":
+		value = application.Tenant
+	case "status":
+		value = application.Status
+	case "type":
+		value = application.Type
+	case "image":
+		value = application.Image
+	case "region":
+		value = application.Region
+	case "namespace":
+		value = application.Namespace
+	default:
+		return false, fmt.Errorf("unknown field %q, expected name, tenant, namespace, status, type, image or region", field)
+	}
+
+	matched, err := path.Match(pattern, value)
+	if err != nil {
+		return false, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+	}
+	return matched, nil
+}
+
+// formatAge formats the time since a unix timestamp in its largest unit, e.g. 3d or 5m
+func formatAge(unix int64) string {
+	if unix == 0 {
+		return "-"
+	}
+	age := time.Since(time.Unix(unix, 0))
+	switch {
+	case age >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(age/(24*time.Hour)))
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age/time.Hour))
+	case age >= time.Minute:
+		return fmt.Sprintf("%dm", int(age/time.Minute))
+	default:
+		return fmt.Sprintf("%ds", int(age/time.Second))
+	}
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// deployRawJob deploys a Nomad job file, its format follows from the extension
+func deployRawJob(ctx context.Context, client pb.ControlPlaneClient, file, name, tenant string, wait bool) {
+	if file == "" {
+		log.Fatalf("-f must be provided for deploy-raw action")
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		log.Fatalf("Failed to read job: %v", err)
+	}
+
+	format := ""
+	switch filepath.Ext(file) {
+	case ".json":
+		format = "json"
+	case ".hcl", ".nomad":
+		format = "hcl"
+	}
+
+	resp, err := client.DeployRawJob(ctx, &pb.DeployRawJobRequest{
+		Job:    string(data),
+		Format: format,
+		Name:   name,
+		Tenant: tenant,
+	})
+	if err != nil {
+		log.Fatalf("Deployment failed: %v", err)
+	}
+	if resp.Status == "FAILED" {
+		log.Fatalf("Deployment failed: %s", resp.Message)
+	}
+
+	fmt.Printf("Job: %s\n", resp.JobId)
+	fmt.Printf("Evaluation: %s\n", resp.DeploymentId)
+	fmt.Printf("Message: %s\n", resp.Message)
+	if wait {
+		followRollout(client, resp.JobId, resp.DeploymentId)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// recommendResources prints the resources recommended for an application, or every one,
+// from their sampled usage
+func recommendResources(ctx context.Context, client pb.ControlPlaneClient, name string, propose bool) {
+	resp, err := client.GetResourceRecommendations(ctx, &pb.ResourceRecommendationsRequest{Name: name, Propose: propose})
+	if err != nil {
+		log.Fatalf("Failed to get resource recommendations: %v", err)
+	}
+
+	if len(resp.Recommendations) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tCPU\tP95 CPU\tRECOMMENDED CPU\tMEMORY\tP95 MEMORY\tRECOMMENDED MEMORY\tSAMPLES\tPROPOSED\tREASON")
+		for _, r := range resp.Recommendations {
+			recommendedCPU, recommendedMemory := "-", "-"
+			if r.RecommendedCpu > 0 {
+				recommendedCPU = fmt.Sprintf("%.1f", r.RecommendedCpu)
+				recommendedMemory = fmt.Sprintf("%d MB", r.RecommendedMemory)
+			}
+			fmt.Fprintf(w, "%s\t%.1f\t%.2f\t%s\t%d MB\t%d MB\t%s\t%d\t%t\t%s\n",
+				r.Application, r.CurrentCpu, r.P95Cpu, recommendedCPU,
+				r.CurrentMemory, r.P95Memory, recommendedMemory, r.Samples, r.Proposed, r.Reason)
+		}
+		w.Flush()
+	}
+	fmt.Printf("\nMessage: %s\n", resp.Message)
+}
+
+// applyRecommendation rolls out the resource update proposed for an application, or dismisses it
+func applyRecommendation(ctx context.Context, client pb.ControlPlaneClient, name string, dismiss bool) {
+	if name == "" {
+		log.Fatalf("-name must be provided for apply-recommendation action")
+	}
+
+	resp, err := client.ApplyResourceRecommendation(ctx, &pb.ApplyResourceRecommendationRequest{Name: name, Dismiss: dismiss})
+	if err != nil {
+		log.Fatalf("Failed to apply resource recommendation: %v", err)
+	}
+
+	fmt.Printf("Success: %t\n", resp.Success)
+	if resp.DeploymentId != "" {
+		fmt.Printf("Deployment ID: %s\n", resp.DeploymentId)
+	}
+	fmt.Printf("Message: %s\n", resp.Message)
+}
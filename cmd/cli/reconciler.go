@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// reconcilerStatus prints the reconciliation loops of the controller, and the failures
+// and drift of every application or the named one
+func reconcilerStatus(ctx context.Context, client pb.ControlPlaneClient, name string) {
+	resp, err := client.GetReconcilerStatus(ctx, &pb.GetReconcilerStatusRequest{Application: name})
+	if err != nil {
+		log.Fatalf("Failed to get reconciler status: %v", err)
+	}
+
+	fmt.Printf("\nLoops:\n")
+	for _, loop := range resp.Loops {
+		fmt.Printf("  - %s: %s, every %s\n", loop.Name, loop.State, time.Duration(loop.IntervalSeconds)*time.Second)
+		fmt.Printf("    Runs: %d, failed: %d, queue depth: %d, failing items: %d\n", loop.Runs, loop.Failures, loop.QueueDepth, loop.FailingItems)
+		if loop.LastFinishedAt > 0 {
+			fmt.Printf("    Last run: %s, took %dms (max %dms)\n",
+				time.Unix(loop.LastFinishedAt, 0).Format(time.RFC3339), loop.LastDurationMs, loop.MaxDurationMs)
+		}
+		if loop.Running {
+			fmt.Printf("    Running since: %s\n", time.Unix(loop.LastStartedAt, 0).Format(time.RFC3339))
+		}
+		if loop.LastError != "" {
+			fmt.Printf("    Last error: %s (%s)\n", loop.LastError, time.Unix(loop.LastErrorAt, 0).Format(time.RFC3339))
+		}
+	}
+
+	if len(resp.Failures) > 0 {
+		fmt.Printf("\nFailures:\n")
+	}
+	for _, failure := range resp.Failures {
+		fmt.Printf("  - %s (%s): %s\n", failure.Application, failure.Loop, failure.Error)
+		fmt.Printf("    Attempts: %d, last at %s\n", failure.Attempts, time.Unix(failure.FailedAt, 0).Format(time.RFC3339))
+	}
+
+	if len(resp.Drift) > 0 {
+		fmt.Printf("\nDrift:\n")
+	}
+	for _, drift := range resp.Drift {
+		fmt.Printf("  - %s: tag moved %d times, %d drifted allocations\n", drift.Application, drift.Detections, drift.DriftedAllocations)
+	}
+
+	if len(resp.RolloutQueues) > 0 {
+		fmt.Printf("\nRollout queues:\n")
+	}
+	for _, queue := range resp.RolloutQueues {
+		fmt.Printf("  - %s: %d waiting\n", queue.Group, queue.Waiting)
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// listRestartAnomalies prints the applications restarting far more often than their baseline,
+// with the allocations which restarted and where to diagnose them
+func listRestartAnomalies(ctx context.Context, client pb.ControlPlaneClient, name string) {
+	resp, err := client.ListRestartAnomalies(ctx, &pb.RestartAnomaliesRequest{Name: name})
+	if err != nil {
+		log.Fatalf("Failed to list restart anomalies: %v", err)
+	}
+
+	for _, anomaly := range resp.Anomalies {
+		fmt.Printf("\n%s: %d restarts in the last check, %.1f on average before (since %s)\n",
+			anomaly.Application, anomaly.Restarts, anomaly.Baseline, time.Unix(anomaly.Since, 0).Format(time.RFC3339))
+		for _, alloc := range anomaly.Allocations {
+			fmt.Printf("  - %s: %d restarts\n", alloc.Id, alloc.Restarts)
+			if alloc.LastEvent != "" {
+				fmt.Printf("    Last event: %s\n", alloc.LastEvent)
+			}
+			fmt.Printf("    Nomad: %s\n", alloc.Url)
+			fmt.Printf("    Logs: cli -action=logs -name=%s -alloc=%s\n", anomaly.Application, alloc.Id)
+		}
+		for _, label := range slices.Sorted(maps.Keys(anomaly.Links)) {
+			fmt.Printf("  %s: %s\n", label, anomaly.Links[label])
+		}
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
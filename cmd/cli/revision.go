@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// listRevisions prints the specs an application was deployed with, most recent first, and
+// the changes of their environment
+func listRevisions(ctx context.Context, client pb.ControlPlaneClient, name string, limit int) {
+	if name == "" {
+		log.Fatalf("-name must be provided for history action")
+	}
+
+	resp, err := client.ListRevisions(ctx, &pb.ListRevisionsRequest{DeploymentId: name, Limit: int32(limit)})
+	if err != nil {
+		log.Fatalf("Failed to list revisions: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to list revisions: %s", resp.Message)
+	}
+	if len(resp.Revisions) == 0 {
+		fmt.Printf("No revisions of %s found\n", name)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REVISION\tIMAGE\tREPLICAS\tCPU\tMEMORY\tOWNER\tDEPLOYMENT\tAGE")
+	for i, revision := range resp.Revisions {
+		current := ""
+		if i == 0 {
+			current = " (current)"
+		}
+		spec := revision.Spec
+		fmt.Fprintf(w, "%d%s\t%s\t%d\t%.1f\t%d MB\t%s\t%s\t%s\n",
+			revision.Revision, current,
+			revision.Image,
+			max(spec.Replicas, 1),
+			spec.Cpu,
+			spec.Memory,
+			orDash(revision.Owner),
+			orDash(revision.DeploymentId),
+			formatAge(revision.CreatedAt))
+	}
+	w.Flush()
+
+	printEnvChanges(resp.Revisions)
+}
+
+// printEnvChanges prints what each revision changed in the environment, the controller
+// redacted the sensitive values
+func printEnvChanges(revisions []*pb.Revision) {
+	printed := false
+	for _, revision := range revisions {
+		if len(revision.EnvChanges) == 0 {
+			continue
+		}
+		if !printed {
+			fmt.Printf("\nEnvironment Changes:\n")
+			printed = true
+		}
+		fmt.Printf("  Revision %d:\n", revision.Revision)
+		for _, change := range revision.EnvChanges {
+			switch change.Change {
+			case "added":
+				fmt.Printf("    + %s=%s\n", change.Key, change.NewValue)
+			case "removed":
+				fmt.Printf("    - %s=%s\n", change.Key, change.OldValue)
+			default:
+				fmt.Printf("    ~ %s: %s -> %s\n", change.Key, change.OldValue, change.NewValue)
+			}
+		}
+	}
+}
+
+// rollbackApp deploys the spec of an earlier revision again, without one Nomad reverts the
+// job to its last stable version
+func rollbackApp(ctx context.Context, client pb.ControlPlaneClient, name string, revision int) {
+	if name == "" {
+		log.Fatalf("-name must be provided for rollback action")
+	}
+
+	resp, err := client.RollbackApplication(ctx, &pb.RollbackRequest{
+		DeploymentId: name,
+		Revision:     int32(revision),
+	})
+	if err != nil {
+		log.Fatalf("Failed to roll back application: %v", err)
+	}
+
+	fmt.Printf("Success: %t\n", resp.Success)
+	if resp.Success {
+		if resp.Revision > 0 {
+			fmt.Printf("Revision: %d\n", resp.Revision)
+		} else {
+			fmt.Printf("Job version: %d\n", resp.Version)
+		}
+		fmt.Printf("Evaluation: %s\n", resp.EvalId)
+	}
+	fmt.Printf("Message: %s\n", resp.Message)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// runSearch finds applications with a query, e.g. `cli search 'image~nginx AND status=degraded'`
+func runSearch(args []string) {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	var (
+		server = fs.String("server", "localhost:50051", "gRPC server address")
+		limit  = fs.Int("limit", 100, "Most applications to show")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: cli search [flags] <query>\n\n")
+		fmt.Fprintf(fs.Output(), "Terms joined by AND: field=value, field!=value or field~substring over\n")
+		fmt.Fprintf(fs.Output(), "name, image, owner, tenant, host, status, project or label.<key>\n\n")
+		fs.PrintDefaults()
+	}
+	var connFlags connection
+	connFlags.register(fs, "")
+	_ = fs.Parse(args)
+
+	conn, err := grpc.NewClient(*server, connFlags.dialOptions()...)
+	if err != nil {
+		log.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := pb.NewControlPlaneClient(conn).Search(ctx, &pb.SearchRequest{
+		Query: strings.Join(fs.Args(), " "),
+		Limit: int32(*limit),
+	})
+	if err != nil {
+		log.Fatalf("Failed to search applications: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to search applications: %s", resp.Message)
+	}
+	if len(resp.Results) == 0 {
+		fmt.Printf("No applications found\n")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTATUS\tIMAGE\tOWNER\tHOSTS")
+	for _, result := range resp.Results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			result.Name,
+			result.Status,
+			orDash(result.Image),
+			orDash(result.Owner),
+			orDash(strings.Join(result.Hosts, ",")))
+	}
+	w.Flush()
+	fmt.Printf("\n%s\n", resp.Message)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// chunks of 1MB stay well below the default 4MB gRPC message limit
+const specChunkSize = 1 << 20
+
+// applySpec uploads a DeployRequest or manifest in chunks, for specs too large for the deploy
+// action or kept in files. The spec is deployed to the namespace unless it names one.
+func applySpec(ctx context.Context, client pb.ControlPlaneClient, file string, wait, dryRun bool, namespace string) {
+	if file == "" {
+		log.Fatalf("-f must be provided for apply and apply-spec actions")
+	}
+
+	spec := readSpec(file)
+	spec.DryRun = dryRun
+	if spec.Namespace == "" {
+		spec.Namespace = namespace
+	}
+	data, err := proto.Marshal(spec)
+	if err != nil {
+		log.Fatalf("Failed to encode spec: %v", err)
+	}
+
+	fmt.Printf("Uploading spec of %d bytes...\n", len(data))
+	stream, err := client.ApplySpec(ctx)
+	if err != nil {
+		log.Fatalf("Failed to upload spec: %v", err)
+	}
+
+	for offset := 0; offset < len(data) || offset == 0; offset += specChunkSize {
+		end := min(offset+specChunkSize, len(data))
+		chunk := &pb.SpecChunk{Data: data[offset:end]}
+		if offset == 0 {
+			chunk.TotalSize = int64(len(data))
+		}
+
+		if err := stream.Send(chunk); err != nil {
+			// the server closed the stream early, its response explains why
+			break
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Fatalf("Deployment failed: %v", err)
+	}
+
+	if resp.Status == "FAILED" {
+		log.Fatalf("Deployment failed: %s", resp.Message)
+	}
+	if resp.Plan != nil {
+		printPlan(resp)
+		return
+	}
+
+	fmt.Printf("Deployment successful!\n")
+	fmt.Printf("ID: %s\n", resp.DeploymentId)
+	fmt.Printf("Status: %s\n", resp.Status)
+	fmt.Printf("Message: %s\n", resp.Message)
+	if len(resp.Warnings) > 0 {
+		fmt.Printf("Warnings:\n")
+		printWarnings(resp.Warnings)
+	}
+	if wait {
+		followRollout(client, resp.JobId, resp.DeploymentId)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// deployStack deploys a stack described by a JSON file in the DeployStackRequest format
+func deployStack(ctx context.Context, client pb.ControlPlaneClient, file string, continueOnError bool) {
+	if file == "" {
+		log.Fatalf("-f must be provided for deploy-stack action")
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		log.Fatalf("Failed to read stack: %v", err)
+	}
+
+	req := &pb.DeployStackRequest{}
+	if err := protojson.Unmarshal(data, req); err != nil {
+		log.Fatalf("Invalid stack file %s: %v", file, err)
+	}
+	if continueOnError {
+		req.ContinueOnError = true
+	}
+
+	fmt.Printf("Deploying stack '%s' with %d applications...\n", req.Name, len(req.Applications))
+	resp, err := client.DeployStack(ctx, req)
+	if err != nil {
+		log.Fatalf("Stack deployment failed: %v", err)
+	}
+
+	printStack(resp)
+	if resp.Status != "SUCCEEDED" {
+		os.Exit(1)
+	}
+}
+
+func printStack(resp *pb.DeployStackResponse) {
+	fmt.Printf("\nStack: %s\n", resp.Name)
+	fmt.Printf("Status: %s\n", resp.Status)
+	if len(resp.Applications) > 0 {
+		fmt.Printf("\nApplications:\n")
+		for _, app := range resp.Applications {
+			fmt.Printf("  - [stage %d] %s: %s - %s\n", app.Stage, app.Name, app.Status, app.Message)
+		}
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// parseWeights parses the steps of a traffic split, e.g. 10,25,50
+func parseWeights(expr string) ([]int32, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	var weights []int32
+	for _, step := range strings.Split(expr, ",") {
+		weight, err := strconv.ParseInt(strings.TrimSpace(step), 10, 32)
+		if err != nil || weight < 1 || weight > 100 {
+			return nil, fmt.Errorf("invalid -traffic-weights %q, expected percents between 1 and 100, e.g. 10,25,50", expr)
+		}
+		weights = append(weights, int32(weight))
+	}
+	return weights, nil
+}
+
+func printTrafficSplit(w io.Writer, split *pb.TrafficSplit) {
+	if split == nil {
+		return
+	}
+
+	steps := make([]string, 0, len(split.Weights))
+	for _, weight := range split.Weights {
+		steps = append(steps, fmt.Sprintf("%d%%", weight))
+	}
+	fmt.Fprintf(w, "\nTraffic Split (%s):\n", split.Strategy)
+	fmt.Fprintf(w, "  Green: %s, %s\n", split.GreenJobId, split.Image)
+	fmt.Fprintf(w, "  Weight: %d%% of %s\n", split.Weight, strings.Join(steps, ", "))
+	fmt.Fprintf(w, "  Phase: %s\n", split.Phase)
+	if split.NextStepAt > 0 {
+		fmt.Fprintf(w, "  Next Step: %s\n", time.Unix(split.NextStepAt, 0).Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// getTimeline prints the history of an application oldest first, its rollouts, scalings and
+// what happened to its allocations
+func getTimeline(ctx context.Context, client pb.ControlPlaneClient, req *pb.TimelineRequest) {
+	resp, err := client.GetTimeline(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to get timeline: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to get timeline: %s", resp.Message)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tTYPE\tNODE\tEVENT")
+	for _, event := range resp.Events {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", time.Unix(event.Time, 0).Format(time.DateTime), event.Type, event.Node, event.Message)
+	}
+	w.Flush()
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// updateFlags are the flags of the update action and the fields of the spec they replace
+var updateFlags = map[string][]string{
+	"image":      {"image"},
+	"replicas":   {"replicas"},
+	"cpu":        {"cpu"},
+	"memory":     {"memory"},
+	"memory-max": {"memory_max"},
+	"host":       {"traefik.enable", "traefik.host"},
+	"ssl":        {"traefik.enable_ssl"},
+	"dry-run":    {"dry_run"},
+}
+
+// updateApp replaces the fields of the flags set on the command line in the latest
+// revision of the application and deploys it
+func updateApp(ctx context.Context, client pb.ControlPlaneClient, name string, baseRevision int, set map[string]bool, spec *pb.DeployRequest, wait bool) {
+	if name == "" {
+		log.Fatalf("-name must be provided for update action")
+	}
+	var paths []string
+	for flagName, fields := range updateFlags {
+		if set[flagName] {
+			paths = append(paths, fields...)
+		}
+	}
+	if len(paths) == 0 {
+		names := make([]string, 0, len(updateFlags))
+		for flagName := range updateFlags {
+			names = append(names, "-"+flagName)
+		}
+		slices.Sort(names)
+		log.Fatalf("update needs at least one of %s", strings.Join(names, ", "))
+	}
+	slices.Sort(paths)
+
+	resp, err := client.UpdateApplication(ctx, &pb.UpdateApplicationRequest{
+		Name:         name,
+		Spec:         spec,
+		UpdateMask:   &fieldmaskpb.FieldMask{Paths: paths},
+		BaseRevision: int32(baseRevision),
+	})
+	if err != nil {
+		log.Fatalf("Update failed: %v", err)
+	}
+	if resp.Plan != nil {
+		printPlan(resp)
+		return
+	}
+
+	fmt.Printf("Updated: %s\n", strings.Join(paths, ", "))
+	fmt.Printf("ID: %s\n", resp.DeploymentId)
+	fmt.Printf("Job: %s\n", resp.JobId)
+	fmt.Printf("Status: %s\n", resp.Status)
+	fmt.Printf("Message: %s\n", resp.Message)
+	if len(resp.Warnings) > 0 {
+		fmt.Printf("Warnings:\n")
+		printWarnings(resp.Warnings)
+	}
+	if wait && resp.Status != "FAILED" {
+		followRollout(client, resp.JobId, resp.DeploymentId)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/api"
+	"github.com/iuliansafta/control-plane/pkg/manifest"
+)
+
+// parseSpec loads a JSON or YAML file in the DeployRequest or manifest format, unknown fields
+// are errors
+func parseSpec(file string) (*pb.DeployRequest, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Is(data) {
+		return manifest.Parse(data)
+	}
+
+	switch filepath.Ext(file) {
+	case ".yaml", ".yml":
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	spec := &pb.DeployRequest{}
+	if err := protojson.Unmarshal(data, spec); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// runValidate handles `cli validate -f app.yaml [more files]`, it checks the specs like
+// the server would without contacting it and exits with 1 when one of them is invalid
+func runValidate(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	var files stringList
+	fs.Var(&files, "f", "JSON or YAML spec file (repeatable)")
+	quiet := fs.Bool("q", false, "Only print invalid specs")
+	_ = fs.Parse(args)
+
+	// pre-commit hooks pass the staged files as arguments
+	files = append(files, fs.Args()...)
+	if len(files) == 0 {
+		fmt.Println("Usage: cli validate -f <spec file> [spec files...]")
+		os.Exit(2)
+	}
+
+	invalid := 0
+	for _, file := range files {
+		spec, err := parseSpec(file)
+		if err != nil {
+			fmt.Printf("%s: invalid\n", file)
+			for _, err := range specErrors(err) {
+				fmt.Printf("  - %v\n", err)
+			}
+			invalid++
+			continue
+		}
+
+		errs := api.ValidateSpec(spec)
+		if len(errs) == 0 {
+			if !*quiet {
+				fmt.Printf("%s: valid\n", file)
+			}
+			continue
+		}
+
+		fmt.Printf("%s: invalid\n", file)
+		for _, err := range errs {
+			fmt.Printf("  - %v\n", err)
+		}
+		invalid++
+	}
+
+	if invalid > 0 {
+		fmt.Printf("\n%d of %d specs invalid\n", invalid, len(files))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// parseVolumeMount parses a -volume flag, "<volume id>:<destination>[:<options>]" where the
+// options are a comma separated list of "ro" and "per-alloc"
+func parseVolumeMount(expr string) (*pb.VolumeMount, error) {
+	parts := strings.SplitN(expr, ":", 3)
+	if len(parts) < 2 || parts[0] == "" || !strings.HasPrefix(parts[1], "/") {
+		return nil, fmt.Errorf("invalid volume %q, expected <volume id>:<absolute path>[:ro,per-alloc]", expr)
+	}
+
+	mount := &pb.VolumeMount{
+		VolumeId:    parts[0],
+		Destination: parts[1],
+	}
+	if len(parts) == 3 {
+		for _, option := range strings.Split(parts[2], ",") {
+			switch option {
+			case "ro":
+				mount.ReadOnly = true
+			case "per-alloc":
+				mount.PerAlloc = true
+			default:
+				return nil, fmt.Errorf("invalid volume %q: unknown option %q", expr, option)
+			}
+		}
+	}
+	return mount, nil
+}
+
+func createVolume(ctx context.Context, client pb.ControlPlaneClient, req *pb.CreateVolumeRequest, params []string) {
+	if req.Id == "" || req.PluginId == "" {
+		log.Fatalf("-name and -plugin must be provided for create-volume action")
+	}
+
+	req.Parameters = make(map[string]string)
+	for _, param := range params {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			log.Fatalf("Invalid -param %q, expected key=value", param)
+		}
+		req.Parameters[key] = value
+	}
+
+	resp, err := client.CreateVolume(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to create volume: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("%s", resp.Message)
+	}
+
+	fmt.Printf("Volume: %s\n", resp.VolumeId)
+	fmt.Printf("External ID: %s\n", resp.ExternalId)
+	if resp.CapacityMb > 0 {
+		fmt.Printf("Capacity: %d MB\n", resp.CapacityMb)
+	}
+	fmt.Printf("Message: %s\n", resp.Message)
+}
+
+func listVolumes(ctx context.Context, client pb.ControlPlaneClient, pluginID string) {
+	resp, err := client.ListVolumes(ctx, &pb.ListVolumesRequest{PluginId: pluginID})
+	if err != nil {
+		log.Fatalf("Failed to list volumes: %v", err)
+	}
+
+	fmt.Printf("\nVolumes:\n")
+	for _, volume := range resp.Volumes {
+		schedulable := "schedulable"
+		if !volume.Schedulable {
+			schedulable = "not schedulable"
+		}
+		fmt.Printf("  - %s (%s, %s): %s, %d readers, %d writers\n",
+			volume.Id, volume.PluginId, volume.AccessMode, schedulable, volume.Readers, volume.Writers)
+	}
+	fmt.Printf("\nMessage: %s\n\n", resp.Message)
+}
+
+func deleteVolume(ctx context.Context, client pb.ControlPlaneClient, id string, deregisterOnly bool) {
+	if id == "" {
+		log.Fatalf("-name must be provided for delete-volume action")
+	}
+
+	resp, err := client.DeleteVolume(ctx, &pb.DeleteVolumeRequest{Id: id, DeregisterOnly: deregisterOnly})
+	if err != nil {
+		log.Fatalf("Failed to delete volume: %v", err)
+	}
+	if !resp.Success {
+		log.Fatalf("%s", resp.Message)
+	}
+
+	fmt.Printf("%s\n", resp.Message)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// polling slows down to maxWatchInterval while the status does not change or the
+// controller cannot be reached, and is back to the -interval once it changes
+const maxWatchInterval = 30 * time.Second
+
+const (
+	clearScreen    = "\033[H\033[2J"
+	highlightStart = "\033[1;33m"
+	highlightEnd   = "\033[0m"
+)
+
+// watchStatus refreshes the status of the application until interrupted and highlights
+// the lines which changed since the previous refresh. On a terminal the screen is
+// redrawn, otherwise a status is printed each time it changes with its changed lines
+// marked by a '*'.
+func watchStatus(client pb.ControlPlaneClient, name string, interval time.Duration) {
+	if name == "" {
+		log.Fatalf("-name must be provided for get deployment status")
+	}
+	if interval <= 0 {
+		log.Fatalf("-interval must be greater than 0")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	terminal := isTerminal(os.Stdout)
+	delay := interval
+	var previous []string
+	for {
+		callCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		resp, err := client.GetApplicationStatus(callCtx, &pb.StatusRequest{DeploymentId: name})
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		var lines []string
+		if err != nil {
+			delay = min(2*delay, maxWatchInterval)
+			lines = []string{"", fmt.Sprintf("Failed to get application status: %v", err), ""}
+		} else {
+			var out bytes.Buffer
+			printStatus(&out, resp)
+			lines = strings.Split(out.String(), "\n")
+		}
+
+		changed := changedLines(previous, lines)
+		switch {
+		case err != nil:
+		case previous == nil || len(changed) > 0:
+			delay = interval
+		default:
+			delay = min(delay*3/2, maxWatchInterval)
+		}
+
+		if terminal {
+			fmt.Print(clearScreen)
+			fmt.Printf("Every %s: status of %s at %s (Ctrl-C to stop)\n", delay, name, time.Now().Format(time.RFC3339))
+			for i, line := range lines {
+				if changed[i] && line != "" {
+					line = highlightStart + line + highlightEnd
+				}
+				fmt.Println(line)
+			}
+		} else if previous == nil || len(changed) > 0 {
+			fmt.Printf("Status of %s at %s:\n", name, time.Now().Format(time.RFC3339))
+			for i, line := range lines {
+				if line != "" {
+					if changed[i] {
+						line = "* " + line
+					} else {
+						line = "  " + line
+					}
+				}
+				fmt.Println(line)
+			}
+		}
+		if err == nil {
+			previous = lines
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// changedLines marks the lines which were not printed by the previous refresh, nothing
+// is marked on the first one
+func changedLines(previous, lines []string) map[int]bool {
+	changed := make(map[int]bool)
+	if previous == nil {
+		return changed
+	}
+
+	seen := make(map[string]int, len(previous))
+	for _, line := range previous {
+		seen[line]++
+	}
+	for i, line := range lines {
+		if seen[line] > 0 {
+			seen[line]--
+			continue
+		}
+		changed[i] = true
+	}
+	return changed
+}
+
+// isTerminal reports whether f is a terminal rather than a file or a pipe
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// rolloutWaitTimeout bounds how long -wait follows a rollout
+const rolloutWaitTimeout = 30 * time.Minute
+
+// followRollout streams the progress of the rollout the evaluation of a deploy started
+// until it finished, and exits with an error when it failed
+func followRollout(client pb.ControlPlaneClient, name, evalID string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, rolloutWaitTimeout)
+	defer cancel()
+
+	stream, err := client.WatchDeployment(ctx, &pb.WatchDeploymentRequest{Name: name, EvaluationId: evalID})
+	if err != nil {
+		log.Fatalf("Failed to watch the rollout: %v", err)
+	}
+
+	fmt.Printf("\nWaiting for the rollout of %s...\n", name)
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			log.Fatalf("Failed to watch the rollout: %v", err)
+		}
+		if event.Type == "done" {
+			if !event.Success {
+				log.Fatalf("Rollout failed: %s", event.Message)
+			}
+			fmt.Printf("Rollout finished: %s\n", event.Message)
+			return
+		}
+		message := event.Message
+		if event.EtaSeconds > 0 {
+			message = fmt.Sprintf("%s, ETA %s", message, time.Duration(event.EtaSeconds)*time.Second)
+		}
+		fmt.Printf("  %s  %s\n", time.Unix(event.Time, 0).Format(time.TimeOnly), message)
+	}
+}
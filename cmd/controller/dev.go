@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	devNomadAddress = "http://127.0.0.1:4646"
+	// how long the Nomad dev agent has to elect itself leader
+	devNomadTimeout = 30 * time.Second
+)
+
+// devNomad is the Nomad agent in dev mode started by -dev
+type devNomad struct {
+	cmd  *exec.Cmd
+	dir  string
+	done chan struct{}
+}
+
+// startDevNomad starts `nomad agent -dev` unless a Nomad agent answers at the address
+// already, it returns nil then
+func startDevNomad(address string) (*devNomad, error) {
+	if nomadLeader(address) {
+		log.Printf("Dev mode: using the Nomad agent at %s", address)
+		return nil, nil
+	}
+	if address != devNomadAddress {
+		return nil, fmt.Errorf("no Nomad agent answers at %s", address)
+	}
+	binary, err := exec.LookPath("nomad")
+	if err != nil {
+		return nil, fmt.Errorf("no Nomad agent answers at %s and no nomad binary is on the PATH, install Nomad or pass -nomad", address)
+	}
+
+	dir, err := os.MkdirTemp("", "controlplane-dev-")
+	if err != nil {
+		return nil, err
+	}
+	logFile, err := os.Create(filepath.Join(dir, "nomad.log"))
+	if err != nil {
+		return nil, err
+	}
+	agent := &devNomad{
+		cmd:  exec.Command(binary, "agent", "-dev", "-bind=127.0.0.1", "-data-dir="+filepath.Join(dir, "data")),
+		dir:  dir,
+		done: make(chan struct{}),
+	}
+	agent.cmd.Stdout, agent.cmd.Stderr = logFile, logFile
+	if err := agent.cmd.Start(); err != nil {
+		logFile.Close()
+		return nil, fmt.Errorf("failed to start %s: %w", binary, err)
+	}
+	go func() {
+		_ = agent.cmd.Wait()
+		logFile.Close()
+		close(agent.done)
+	}()
+	log.Printf("Dev mode: started nomad agent -dev, its log is %s", logFile.Name())
+
+	deadline := time.Now().Add(devNomadTimeout)
+	for !nomadLeader(address) {
+		select {
+		case <-agent.done:
+			return nil, fmt.Errorf("nomad agent -dev exited, see %s", logFile.Name())
+		case <-time.After(500 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			agent.Stop()
+			return nil, fmt.Errorf("nomad agent -dev did not elect a leader within %s, see %s", devNomadTimeout, logFile.Name())
+		}
+	}
+	return agent, nil
+}
+
+// Stop interrupts the agent, kills it when it did not exit within 10s and removes its data
+func (d *devNomad) Stop() {
+	_ = d.cmd.Process.Signal(os.Interrupt)
+	select {
+	case <-d.done:
+	case <-time.After(10 * time.Second):
+		_ = d.cmd.Process.Kill()
+		<-d.done
+	}
+	_ = os.RemoveAll(d.dir)
+}
+
+// nomadLeader reports whether the Nomad agent at the address has a cluster leader
+func nomadLeader(address string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(address, "/")+"/v1/status/leader", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	leader, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode == http.StatusOK && strings.Trim(strings.TrimSpace(string(leader)), `"`) != ""
+}
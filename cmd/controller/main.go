@@ -1,45 +1,757 @@
 package main
 
 import (
+	"cmp"
+	"context"
 	"flag"
 	"log"
 	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	pb "github.com/iuliansafta/control-plane/api/proto"
 	"github.com/iuliansafta/control-plane/pkg/api"
+	"github.com/iuliansafta/control-plane/pkg/attest"
+	"github.com/iuliansafta/control-plane/pkg/bus"
+	"github.com/iuliansafta/control-plane/pkg/consul"
+	"github.com/iuliansafta/control-plane/pkg/dns"
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/idle"
+	"github.com/iuliansafta/control-plane/pkg/kms"
+	"github.com/iuliansafta/control-plane/pkg/metrics"
 	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/oci"
+	"github.com/iuliansafta/control-plane/pkg/orchestrator"
+	"github.com/iuliansafta/control-plane/pkg/plugin"
+	"github.com/iuliansafta/control-plane/pkg/store"
 	"google.golang.org/grpc"
 )
 
 var (
-	grpcPort     = flag.String("port", "50051", "gRPC service port")
-	nomadAddress = flag.String("nomad", "", "Nomad server address")
+	grpcPort      = flag.String("port", "50051", "gRPC service port")
+	nomadAddress  = flag.String("nomad", "", "Nomad server address (default: NOMAD_ADDR or http://127.0.0.1:4646)")
+	nomadToken    = flag.String("nomad-token", "", "ACL token of the requests to Nomad (default: NOMAD_TOKEN, which keeps it out of the process list)")
+	nomadCACert   = flag.String("nomad-ca-cert", "", "PEM CA bundle verifying the certificate of the Nomad agent (default: NOMAD_CACERT)")
+	nomadCert     = flag.String("nomad-client-cert", "", "PEM client certificate presented to Nomad agents verifying their clients (default: NOMAD_CLIENT_CERT)")
+	nomadKey      = flag.String("nomad-client-key", "", "PEM key of the Nomad client certificate (default: NOMAD_CLIENT_KEY)")
+	nomadTLSName  = flag.String("nomad-tls-server-name", "", "Name verified in the certificate of the Nomad agent, e.g. server.global.nomad (default: NOMAD_TLS_SERVER_NAME)")
+	nomadInsecure = flag.Bool("nomad-tls-skip-verify", false, "Do not verify the certificate of the Nomad agent, for tests only (default: NOMAD_SKIP_VERIFY)")
+	dev           = flag.Bool("dev", false, "Run a local control plane to try the API: starts nomad agent -dev unless one answers at -nomad, keeps the registry in memory and serves gRPC on localhost only")
+
+	orchestratorName = flag.String("orchestrator", orchestrator.SchedulerNomad, "Scheduler running the applications: nomad, or kubernetes serving the deploy, delete, status, list, scale, logs and health RPCs only")
+	kubeconfig       = flag.String("kubeconfig", "", "Kubeconfig of the kubernetes orchestrator, its current context is used (default: the pod's service account)")
+	kubeNamespace    = flag.String("kubernetes-namespace", "", "Namespace the kubernetes orchestrator deploys applications to (default: the one of the context or service account)")
+	ingressClass     = flag.String("ingress-class", "", "Ingress class routing the hosts of applications on Kubernetes (default: the cluster's default class)")
+
+	idleMetricsURL = flag.String("idle-metrics-url", "", "Traefik Prometheus endpoint, enables scaling idle applications to zero")
+	idleInterval   = flag.Duration("idle-interval", time.Minute, "How often to check applications for traffic")
+	wakeAddress    = flag.String("wake-addr", ":8081", "Listen address of the wake proxy for applications scaled to zero")
+	wakeUpstream   = flag.String("wake-upstream", "http://localhost:80", "Traefik entrypoint woken requests are replayed to")
+	wakeTimeout    = flag.Duration("wake-timeout", 2*time.Minute, "How long a request waits for its application to wake up")
+
+	tenantDomain = flag.String("tenant-domain", "apps.local", "Base domain of the default tenant domain template")
+	kmsKeyring   = flag.String("kms-keyring", "", "Keyring file wrapping the tenant data keys, the last key is the current one")
+
+	tenantNomadACL      = flag.Bool("tenant-nomad-acl", false, "Mint read-only Nomad tokens scoped to the namespaces of tenants asking for Nomad access, requires a management NOMAD_TOKEN")
+	tenantTokenTTL      = flag.Duration("tenant-token-ttl", 30*24*time.Hour, "Lifetime of the Nomad tokens of tenants, their successors are minted once a third is left")
+	tenantTokenInterval = flag.Duration("tenant-token-interval", time.Hour, "How often to check the Nomad tokens of tenants for rotation")
+
+	registryAddress = flag.String("registry", "sqlite://data/registry.db", "Database of the registry, sqlite://<path> or postgres://<url>, memory keeps it in process memory (ignored with -raft-addr)")
+
+	raftAddress   = flag.String("raft-addr", "", "Raft transport address, enables replicating the registry across controllers")
+	raftHTTP      = flag.String("raft-http-addr", ":8300", "Listen address for joins and writes forwarded by other replicas")
+	raftAdvertise = flag.String("raft-advertise", "", "HTTP address other replicas reach this one on (default: -raft-http-addr)")
+	raftNodeID    = flag.String("raft-id", "", "Unique ID of this replica (default: hostname)")
+	raftDir       = flag.String("raft-dir", "data/raft", "Directory of the Raft log and snapshots")
+	raftBootstrap = flag.Bool("raft-bootstrap", false, "Bootstrap a new cluster with this replica")
+	raftJoin      = flag.String("raft-join", "", "HTTP address of a replica to join")
+
+	drReplicateTo = flag.String("dr-replicate-to", "", "Raft HTTP address of a controller of the standby site to replicate the registry to")
+	drInterval    = flag.Duration("dr-interval", 5*time.Second, "How often to replicate the registry to the standby site")
+	drStandby     = flag.Bool("dr-standby", false, "Run as the disaster recovery standby of another site, only serving reads until promoted")
+
+	pressureDegraded = flag.Duration("pressure-degraded", time.Second, "Average Nomad API latency at which background reconciliation is paused (0 disables)")
+	pressureShedding = flag.Duration("pressure-shedding", 3*time.Second, "Average Nomad API latency at which application status is served from cache as well (0 disables)")
+	pressureWindow   = flag.Duration("pressure-window", 30*time.Second, "Window the Nomad API latency is averaged over")
+	pressureInterval = flag.Duration("pressure-interval", 5*time.Second, "How often to probe the latency of the Nomad API")
+
+	readOnly = flag.Bool("read-only", false, "Only serve read RPCs, joins a Raft cluster as a non-voter")
+
+	idempotencyTTL = flag.Duration("idempotency-ttl", 24*time.Hour, "How long a retried mutating RPC with the same Idempotency-Key returns the original result (0 disables)")
+
+	maxMessageSize = flag.Int("max-message-size", 4<<20, "Largest gRPC request in bytes, larger specs are uploaded with ApplySpec")
+
+	tlsCert     = flag.String("tls-cert", "", "PEM certificate the gRPC API is served over TLS with (requires -tls-key)")
+	tlsKey      = flag.String("tls-key", "", "PEM key of the -tls-cert certificate")
+	tlsClientCA = flag.String("tls-client-ca", "", "PEM CA bundle clients must present a certificate of, mutual TLS (requires -tls-cert)")
+
+	apiTokensFile       = flag.String("api-tokens", "", "JSON file of the API tokens and their scopes, RPCs without a token granting their scope are denied")
+	authServiceAccounts = flag.Bool("auth-service-accounts", false, "Accept the tokens of the tenants' service accounts with the read, deploy and delete scopes, RPCs without a token are denied")
+
+	httpAddress  = flag.String("http-addr", ":8082", "Listen address of the REST endpoints, e.g. application health for GitOps tools")
+	wsOrigins    = flag.String("ws-allowed-origins", "", "Comma separated origins of the dashboards allowed to open the event websockets besides the controller's own, e.g. https://dash.example.com, * for any")
+	httpCacheTTL = flag.Duration("http-cache-ttl", 5*time.Second, "How long the REST endpoints answer polls from the last response instead of Nomad, 0 to always ask Nomad (ETags are still checked)")
+
+	pluginConfig = flag.String("plugins", "", "JSON file of the deploy hook plugins to call")
+
+	backupInterval = flag.Duration("backup-interval", time.Minute, "How often to check the backup schedules of applications")
+
+	wildcardDomains  = flag.String("wildcard-domains", "", "Comma separated domains whose hosts share a wildcard certificate, e.g. preview.example.com")
+	wildcardResolver = flag.String("wildcard-resolver", "", "Traefik cert resolver with a DNS-01 challenge, requesting the wildcard certificates")
+
+	edgeImage           = flag.String("edge-image", nomad.DefaultEdgeProxyImage, "Traefik image of the edge proxy deployed by BootstrapEdgeProxy")
+	acmeEmail           = flag.String("acme-email", "", "ACME account email, enables the cert resolvers of the edge proxy")
+	acmeResolver        = flag.String("acme-resolver", "letsencrypt", "Cert resolver of the edge proxy issuing per host certificates with the TLS-ALPN-01 challenge")
+	acmeCAServer        = flag.String("acme-ca-server", "", "ACME directory of the cert resolvers (default: Let's Encrypt)")
+	wildcardDNSProvider = flag.String("wildcard-dns-provider", "", "DNS provider of the -wildcard-resolver's DNS-01 challenge, e.g. cloudflare")
+
+	egressMode    = flag.String("egress-mode", nomad.EgressModeHints, "How the cluster enforces the egress rules of applications: hints, consul, iptables")
+	egressImage   = flag.String("egress-image", nomad.DefaultFirewallImage, "Image of the egress firewall task in the iptables mode")
+	consulAddress = flag.String("consul-addr", "http://localhost:8500", "Consul HTTP API address, manages the intentions of the consul egress mode and the Consul KV configuration of applications")
+
+	allowedImages = flag.String("allowed-images", "", "Comma separated repositories or prefixes ending in * images may come from, e.g. registry.example.com/*; any when empty")
+	sensitiveEnv  = flag.String("sensitive-env", strings.Join(api.DefaultSensitiveEnv, ","), "Comma separated patterns of environment keys, * matching any characters, whose values the revision history redacts")
+
+	driftDetection = flag.Bool("drift-detection", false, "Record the digests of deployed images and alert when their tags move at the registry")
+	driftInterval  = flag.Duration("drift-interval", 5*time.Minute, "How often to resolve the tags of deployed images")
+	driftWebhook   = flag.String("drift-webhook", "", "URL receiving image drift events as JSON")
+
+	usageSampling   = flag.Bool("usage-sampling", false, "Sample the CPU and memory applications use and recommend their resources")
+	usageInterval   = flag.Duration("usage-interval", 5*time.Minute, "How often to sample the usage of running allocations")
+	usageWindow     = flag.Duration("usage-window", 7*24*time.Hour, "Usage recommendations are based on")
+	usageHeadroom   = flag.Float64("usage-headroom", 0.2, "Fraction added to the p95 usage, e.g. 0.2 recommends 20% above it")
+	usageMinSamples = flag.Int("usage-min-samples", 288, "Samples needed before resources are recommended")
+
+	restartDetection   = flag.Bool("restart-detection", false, "Alert when applications restart far more often than their baseline, e.g. crash loops")
+	restartInterval    = flag.Duration("restart-interval", time.Minute, "How often to count the restarts of allocations")
+	restartBaseline    = flag.Duration("restart-baseline", 24*time.Hour, "Period whose restart rate is the baseline of an application")
+	restartFactor      = flag.Float64("restart-factor", 5, "Times the baseline's restarts per check that are a spike")
+	restartMinRestarts = flag.Int("restart-min-restarts", 3, "Restarts per check below which there is never a spike")
+	restartWebhook     = flag.String("restart-webhook", "", "URL receiving restart spike events as JSON")
+
+	autoscaling       = flag.Bool("autoscaling", false, "Scale services deployed with an autoscaling spec on their metrics")
+	autoscaleInterval = flag.Duration("autoscale-interval", 30*time.Second, "How often to evaluate the metrics of autoscaled services")
+	traefikMetrics    = flag.String("traefik-metrics-url", "", "Traefik Prometheus endpoint with router labels the rps autoscaling metric reads (default: -idle-metrics-url)")
+	metricProviders   = flag.String("metric-providers", "", "Comma separated name=URL providers of external metrics: prometheus+http://host:9090, traefik+http://<prometheus>, nats+http://host:8222 or sqs://<region>")
+
+	requireAttestation = flag.Bool("require-attestation", false, "Require a verified provenance attestation of the image for every deployment")
+	cosignKey          = flag.String("cosign-key", "", "Public key or KMS URI verifying attestations with cosign")
+	cosignIdentity     = flag.String("cosign-identity", "", "Certificate identity of keyless attestations, e.g. the CI workflow")
+	cosignIssuer       = flag.String("cosign-issuer", "https://token.actions.githubusercontent.com", "OIDC issuer of keyless attestations")
+
+	uiDescription = flag.String("ui-description", "", "Description of every job in the Nomad UI, e.g. 'Owned by {annotation.team}'")
+	uiLinks       = flag.String("ui-links", "", "Comma separated Label=URL links of every job in the Nomad UI, e.g. Logs=https://grafana.example.com/explore?app={app}")
+
+	jobNaming      = flag.String("job-naming", "name", "Comma separated strategies naming the Nomad jobs of applications: name, tenant-prefix, environment-suffix or hash")
+	jobEnvironment = flag.String("job-environment", "", "Environment appended to job IDs by the environment-suffix strategy, e.g. staging")
+	jobCollision   = flag.String("job-collision", "reject", "When a job ID belongs to another application: reject, or hash to append the hash of tenant and name")
+
+	reservedNames = flag.String("reserved-names", strings.Join(api.DefaultReservedNames, ","), "Comma separated application names tenants cannot deploy, * matches any characters")
+	reservedHosts = flag.String("reserved-hosts", "", "Comma separated hostnames tenants cannot route, e.g. *.internal.example.com; the apex of -tenant-domain is always reserved")
+
+	eventSinks  = flag.String("event-sinks", "", "Comma separated sinks of the lifecycle CloudEvents: http(s)://..., nats://host:4222/<subject> or kafka+http://<rest proxy>/<topic>")
+	eventSource = flag.String("event-source", "/control-plane", "Source attribute of the published CloudEvents, e.g. //control-plane.example.com")
+
+	notificationRoutes = flag.String("notification-routes", "", "JSON file routing lifecycle events and alerts to the Slack, webhook and email receivers of the team owning the application, and the daily email digests of tenants")
+	incidentConfig     = flag.String("incidents", "", "JSON file of the alerting rules opening PagerDuty or Opsgenie incidents for the applications of each criticality tier")
+	incidentInterval   = flag.Duration("incident-interval", time.Minute, "How often to evaluate the alerting rules of -incidents")
+
+	statusPageAddress  = flag.String("status-page-addr", "", "Listen address of the public status page, e.g. :8090, disabled when empty")
+	statusPageTitle    = flag.String("status-page-title", "Status", "Title of the status page")
+	statusPageSelector = flag.String("status-page-selector", "status-page=public", "Comma separated key=value labels of the applications the status page shows")
+	uptimeInterval     = flag.Duration("uptime-interval", time.Minute, "How often to check the availability of the applications of the status page")
+
+	commandSources = flag.String("command-sources", "", "Comma separated message bus topics deploy, scale and delete commands are consumed from: nats://host:4222/<subject> or kafka+http://<rest proxy>/<topic>")
+
+	placementConfig = flag.String("placement-config", "", "JSON file of the regions, their labels, latencies, costs and ingress addresses the placement engine and geo routing use")
+
+	dnsProvider = flag.String("dns-provider", "", "DNS provider managing the records of geo routed applications: route53://<hosted zone id>")
+	geoInterval = flag.Duration("geo-interval", 30*time.Second, "How often to check geo routed applications in their regions and fail over unhealthy ones")
+
+	deadlineInterval = flag.Duration("rollout-deadline-interval", 30*time.Second, "How often to fail rollouts running past the deadline of their spec")
+	rolloutInterval  = flag.Duration("rollout-interval", 30*time.Second, "How often to record the finished rollouts of applications in their history")
+	shiftInterval    = flag.Duration("traffic-shift-interval", 10*time.Second, "How often to advance the traffic splits of blue-green and canary-traffic rollouts")
+
+	domainInterval = flag.Duration("domain-interval", time.Minute, "How often to look up the TXT records of pending custom domains")
+
+	searchInterval = flag.Duration("search-interval", time.Minute, "How often to refresh the search index of applications from Nomad, e.g. their health")
 )
 
 func main() {
 	flag.Parse()
 
-	// Initialize Nomad client
-	nomadClient, err := nomad.NewNomadClient(*nomadAddress)
+	if *readOnly && (*idleMetricsURL != "" || *raftBootstrap) {
+		log.Fatalf("A read-only replica cannot scale idle applications or bootstrap a Raft cluster")
+	}
+	if *readOnly && *raftAddress == "" {
+		log.Fatalf("-read-only requires -raft-addr, read-only replicas share the registry of the leader through Raft")
+	}
+	if (*drReplicateTo != "" || *drStandby) && *raftAddress == "" {
+		log.Fatalf("-dr-replicate-to and -dr-standby require -raft-addr, disaster recovery replicates the Raft registry")
+	}
+	if *egressMode != nomad.EgressModeHints && *egressMode != nomad.EgressModeConsul && *egressMode != nomad.EgressModeIptables {
+		log.Fatalf("-egress-mode must be hints, consul or iptables")
+	}
+	if *requireAttestation && *cosignKey == "" && *cosignIdentity == "" {
+		log.Fatalf("-require-attestation requires -cosign-key or -cosign-identity to verify attestations")
+	}
+	if *acmeEmail != "" && *wildcardResolver != "" && *wildcardDNSProvider == "" {
+		log.Fatalf("-wildcard-resolver requires -wildcard-dns-provider for the edge proxy, wildcard certificates need a DNS-01 challenge")
+	}
+	if *wildcardDomains != "" && *wildcardResolver == "" {
+		log.Fatalf("-wildcard-domains requires -wildcard-resolver, wildcard certificates need a DNS-01 challenge")
+	}
+	if *orchestratorName != orchestrator.SchedulerNomad && *orchestratorName != orchestrator.SchedulerKubernetes {
+		log.Fatalf("-orchestrator must be nomad or kubernetes")
+	}
+	if *dev && (*raftAddress != "" || *readOnly || *drStandby || *drReplicateTo != "" || *orchestratorName != orchestrator.SchedulerNomad) {
+		log.Fatalf("-dev runs a single controller on a local Nomad agent, it cannot replicate the registry, run on Kubernetes or be a replica")
+	}
+	if *tlsClientCA != "" && *tlsCert == "" {
+		log.Fatalf("-tls-client-ca requires -tls-cert and -tls-key, clients are verified over TLS")
+	}
+	if *orchestratorName == orchestrator.SchedulerKubernetes && (*idleMetricsURL != "" || *tenantNomadACL || *driftDetection || *usageSampling ||
+		*restartDetection || *autoscaling || *placementConfig != "" || *dnsProvider != "" || *egressMode != nomad.EgressModeHints || *incidentConfig != "" || *statusPageAddress != "") {
+		log.Fatalf("-orchestrator=kubernetes cannot scale idle applications, mint tenant tokens, detect drift, sample usage, detect restarts, autoscale, place, geo route, enforce egress, open incidents or serve a status page, these require Nomad")
+	}
+
+	// Initialize the orchestrator, Nomad unless the applications run on Kubernetes
+	var orch orchestrator.Orchestrator
+	var nomadClient *nomad.NomadClient
+	var err error
+	if *dev {
+		*nomadAddress = cmp.Or(*nomadAddress, os.Getenv("NOMAD_ADDR"), devNomadAddress)
+		devAgent, err := startDevNomad(*nomadAddress)
+		if err != nil {
+			log.Fatalf("Dev mode: %v", err)
+		}
+		if devAgent != nil {
+			defer devAgent.Stop()
+		}
+		// nothing outlives a dev control plane
+		*registryAddress = "memory"
+	}
+	if *orchestratorName == orchestrator.SchedulerKubernetes {
+		kube, err := orchestrator.NewKubernetes(*kubeconfig, *kubeNamespace)
+		if err != nil {
+			log.Fatalf("Failed to create Kubernetes client: %v", err)
+		}
+		kube.IngressClass = *ingressClass
+		if err := kube.HealthCheck(); err != nil {
+			log.Printf("Failed to reach the Kubernetes API server: %v", err)
+		}
+		log.Printf("Running applications on Kubernetes in namespace %s, only the portable RPCs are served", kube.Namespace)
+		orch = kube
+	} else {
+		nomadClient, err = nomad.NewNomadClient(nomad.Config{
+			Address:       *nomadAddress,
+			Token:         *nomadToken,
+			CACert:        *nomadCACert,
+			ClientCert:    *nomadCert,
+			ClientKey:     *nomadKey,
+			TLSServerName: *nomadTLSName,
+			TLSSkipVerify: *nomadInsecure,
+		})
+		if err != nil {
+			log.Fatalf("Failed to create Nomad client: %v", err)
+		}
+		if caps, err := nomadClient.DetectCapabilities(); err != nil {
+			log.Printf("Failed to detect the Nomad version, features are not gated: %v", err)
+		} else {
+			log.Printf("Connected to Nomad %s: variables %t, node pools %t, actions %t, memory oversubscription %t",
+				caps.Version, caps.Variables, caps.NodePools, caps.Actions, caps.MemoryOversubscription)
+		}
+		orch = nomadClient
+	}
+
+	// Registry of what the controller deployed, replicated when running a Raft cluster and
+	// otherwise kept in a database
+	var registry store.Store = store.NewMemoryStore()
+	var raftServer *http.Server
+	var raftStore *store.RaftStore
+	if *raftAddress == "" && *registryAddress != "memory" {
+		sqlStore, err := store.OpenSQLStore(*registryAddress)
+		if err != nil {
+			log.Fatalf("Failed to open the registry database: %v", err)
+		}
+		defer sqlStore.Close()
+		registry = sqlStore
+		log.Printf("Registry stored in %s", strings.SplitN(*registryAddress, "://", 2)[0])
+	}
+	if *raftAddress != "" {
+		nodeID := *raftNodeID
+		if nodeID == "" {
+			if nodeID, err = os.Hostname(); err != nil {
+				log.Fatalf("Failed to determine raft node ID: %v", err)
+			}
+		}
+		advertise := *raftAdvertise
+		if advertise == "" {
+			advertise = *raftHTTP
+		}
+
+		raftStore, err = store.NewRaftStore(store.RaftConfig{
+			NodeID:    nodeID,
+			BindAddr:  *raftAddress,
+			HTTPAddr:  advertise,
+			DataDir:   *raftDir,
+			Bootstrap: *raftBootstrap,
+			Join:      *raftJoin,
+			NonVoter:  *readOnly,
+			Standby:   *drStandby,
+		})
+		if err != nil {
+			log.Fatalf("Failed to start raft: %v", err)
+		}
+		defer raftStore.Close()
+		registry = raftStore
+
+		raftServer = &http.Server{
+			Addr:    *raftHTTP,
+			Handler: raftStore.Handler(),
+		}
+		go func() {
+			log.Printf("Starting raft endpoint on %s", *raftHTTP)
+			if err := raftServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Raft endpoint error: %v", err)
+			}
+		}()
+	}
+
+	// Key encryption keys of the tenant data keys
+	var keys kms.KMS
+	if *kmsKeyring != "" {
+		keys, err = kms.LoadKeyring(*kmsKeyring)
+	} else {
+		log.Printf("No -kms-keyring given, tenant data keys are wrapped with an ephemeral key")
+		keys, err = kms.NewEphemeralKMS()
+	}
 	if err != nil {
-		log.Fatalf("Failed to create Nomad client: %v", err)
+		log.Fatalf("Failed to load KMS keys: %v", err)
+	}
+	sealer := kms.NewSealer(keys)
+
+	// Deploy hooks of external plugins
+	var plugins *plugin.Chain
+	if *pluginConfig != "" {
+		if plugins, err = plugin.Load(*pluginConfig); err != nil {
+			log.Fatalf("Failed to load plugins: %v", err)
+		}
+		defer plugins.Close()
+	}
+
+	// Wildcard certificates shared by the hosts below the wildcard domains
+	certPolicy := &nomad.CertPolicy{WildcardResolver: *wildcardResolver}
+	if *wildcardDomains != "" {
+		certPolicy.WildcardDomains = strings.Split(*wildcardDomains, ",")
 	}
 
-	// Init gRPC service with Nomad client
-	apiServer := api.NewApplicationService(nomadClient)
+	// Registries and namespaces images may come from, tenants may have their own
+	var imagePatterns []string
+	if *allowedImages != "" {
+		imagePatterns = strings.Split(*allowedImages, ",")
+	}
+
+	// Environment keys whose values the revision history redacts
+	var sensitiveEnvPatterns []string
+	if *sensitiveEnv != "" {
+		sensitiveEnvPatterns = strings.Split(*sensitiveEnv, ",")
+	}
+
+	// Description and links of the jobs' pages in the Nomad UI
+	uiConfig := &nomad.UIConfig{Description: *uiDescription}
+	if *uiLinks != "" {
+		if uiConfig.Links, err = nomad.ParseUILinks(strings.Split(*uiLinks, ",")); err != nil {
+			log.Fatalf("Invalid -ui-links: %v", err)
+		}
+	}
+
+	// Nomad job IDs of the applications, unique across tenants
+	naming, err := api.ParseJobNaming(*jobNaming, *jobEnvironment, *jobCollision)
+	if err != nil {
+		log.Fatalf("Invalid job naming: %v", err)
+	}
+
+	// Names and hosts of the infrastructure the applications cannot shadow
+	reserved := &api.Reserved{Hosts: []string{*tenantDomain}}
+	if *reservedNames != "" {
+		reserved.Names = strings.Split(*reservedNames, ",")
+	}
+	if *reservedHosts != "" {
+		reserved.Hosts = append(reserved.Hosts, strings.Split(*reservedHosts, ",")...)
+	}
+
+	// Lifecycle events for event-driven platforms
+	// the websockets of the REST endpoints receive every published event
+	eventHub := events.NewHub()
+	publisher := &events.Publisher{Source: *eventSource, Sinks: []events.Sink{eventHub}}
+	if *eventSinks != "" {
+		for _, sink := range strings.Split(*eventSinks, ",") {
+			parsed, err := events.ParseSink(sink)
+			if err != nil {
+				log.Fatalf("Invalid -event-sinks: %v", err)
+			}
+			publisher.Sinks = append(publisher.Sinks, parsed)
+		}
+	}
+	var router *events.Router
+	if *notificationRoutes != "" {
+		if router, err = events.LoadRouter(*notificationRoutes); err != nil {
+			log.Fatalf("Invalid -notification-routes: %v", err)
+		}
+		publisher.Sinks = append(publisher.Sinks, router)
+	}
+	statusPage := api.StatusPage{Title: *statusPageTitle, Selector: make(map[string]string)}
+	if *statusPageAddress != "" {
+		for _, label := range strings.Split(*statusPageSelector, ",") {
+			key, value, ok := strings.Cut(label, "=")
+			if !ok || key == "" {
+				log.Fatalf("Invalid -status-page-selector: %q is not key=value", label)
+			}
+			statusPage.Selector[key] = value
+		}
+	}
+	var incidents *events.IncidentConfig
+	if *incidentConfig != "" {
+		if incidents, err = events.LoadIncidents(*incidentConfig); err != nil {
+			log.Fatalf("Invalid -incidents: %v", err)
+		}
+	}
+
+	// Commands of automation pipelines that cannot call gRPC
+	var consumers []bus.Consumer
+	if *commandSources != "" {
+		for _, source := range strings.Split(*commandSources, ",") {
+			consumer, err := bus.ParseConsumer(source)
+			if err != nil {
+				log.Fatalf("Invalid -command-sources: %v", err)
+			}
+			consumers = append(consumers, consumer)
+		}
+	}
+
+	// Regions of deployments naming none
+	var placement *api.PlacementPolicy
+	if *placementConfig != "" {
+		if placement, err = api.LoadPlacementPolicy(*placementConfig); err != nil {
+			log.Fatalf("Failed to load placement config: %v", err)
+		}
+	}
+
+	// Records routing the users of applications deployed to several regions
+	var geoDNS dns.Provider
+	if *dnsProvider != "" {
+		if geoDNS, err = dns.ParseProvider(*dnsProvider); err != nil {
+			log.Fatalf("Invalid -dns-provider: %v", err)
+		}
+	}
+
+	// Digests of deployed images compared with their registries
+	var driftPolicy *api.DriftPolicy
+	if *driftDetection {
+		driftPolicy = &api.DriftPolicy{Resolver: &oci.Resolver{}, Webhook: *driftWebhook}
+	}
+
+	// Traefik deployed by BootstrapEdgeProxy, with the resolvers the applications' routers use
+	edgeProxy := nomad.EdgeProxy{Image: *edgeImage, ConsulAddress: *consulAddress}
+	if *acmeEmail != "" {
+		if *acmeResolver != "" {
+			edgeProxy.Resolvers = append(edgeProxy.Resolvers, nomad.CertResolver{Name: *acmeResolver, Email: *acmeEmail, CAServer: *acmeCAServer})
+		}
+		if *wildcardResolver != "" {
+			edgeProxy.Resolvers = append(edgeProxy.Resolvers, nomad.CertResolver{Name: *wildcardResolver, Email: *acmeEmail, CAServer: *acmeCAServer, DNSProvider: *wildcardDNSProvider})
+		}
+	}
+
+	// Attestations attached by CI, verified with cosign
+	attestationPolicy := &api.AttestationPolicy{Required: *requireAttestation, Resolver: &oci.Resolver{}}
+	if *cosignKey != "" || *cosignIdentity != "" {
+		attestationPolicy.Verifier = &attest.Cosign{Key: *cosignKey, Identity: *cosignIdentity, Issuer: *cosignIssuer}
+	}
+
+	consulClient := consul.NewClient(*consulAddress)
+
+	// Usage of allocations sizing the resources of applications
+	var recommendations *api.RecommendationPolicy
+	if *usageSampling {
+		recommendations = &api.RecommendationPolicy{Headroom: *usageHeadroom, Window: *usageWindow, MinSamples: *usageMinSamples}
+	}
+
+	// Restart spikes of applications
+	var restartPolicy *api.RestartPolicy
+	if *restartDetection {
+		restartPolicy = &api.RestartPolicy{Baseline: *restartBaseline, Factor: *restartFactor, MinRestarts: *restartMinRestarts, Webhook: *restartWebhook}
+	}
+
+	// Metrics services scale on
+	var autoscalePolicy *api.AutoscalePolicy
+	if *autoscaling {
+		autoscalePolicy = &api.AutoscalePolicy{Providers: make(map[string]metrics.Provider)}
+		if *metricProviders != "" {
+			for _, spec := range strings.Split(*metricProviders, ",") {
+				name, provider, err := metrics.ParseProvider(spec)
+				if err != nil {
+					log.Fatalf("Invalid -metric-providers: %v", err)
+				}
+				autoscalePolicy.Providers[name] = provider
+			}
+		}
+		if *traefikMetrics == "" {
+			*traefikMetrics = *idleMetricsURL
+		}
+		if *traefikMetrics != "" {
+			autoscalePolicy.Traefik = metrics.NewTraefikRouters(*traefikMetrics)
+		}
+	}
+
+	// Init gRPC service with the orchestrator
+	apiServer := api.NewApplicationService(orch, registry, sealer, plugins, certPolicy, &api.EgressPolicy{
+		Mode:          *egressMode,
+		FirewallImage: *egressImage,
+		Consul:        consulClient,
+	}, imagePatterns, driftPolicy, attestationPolicy, uiConfig, naming, reserved, publisher, placement, geoDNS, consulClient, recommendations, restartPolicy, autoscalePolicy, sensitiveEnvPatterns)
+	if router != nil {
+		router.Owner = apiServer.ApplicationOwner
+	}
+	var tenantACL *api.TenantACL
+	if *tenantNomadACL {
+		tenantACL = &api.TenantACL{TokenTTL: *tenantTokenTTL}
+	}
+	// tenants, volumes and the edge proxy are managed in Nomad
+	var adminServer *api.AdminService
+	if nomadClient != nil {
+		adminServer = api.NewAdminService(nomadClient, registry, sealer, *tenantDomain, edgeProxy, consulClient, tenantACL)
+	}
 
 	// Create listener
-	listener, err := net.Listen("tcp", ":"+*grpcPort)
+	listenAddress := ":" + *grpcPort
+	if *dev {
+		listenAddress = "127.0.0.1:" + *grpcPort // RPCs are not authenticated unless -api-tokens is given
+	}
+	listener, err := net.Listen("tcp", listenAddress)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Load shedding when the Nomad API slows down
+	if nomadClient != nil && (*pressureDegraded > 0 || *pressureShedding > 0) {
+		go nomadClient.MonitorPressure(ctx, nomad.PressureThresholds{
+			Degraded: *pressureDegraded,
+			Shedding: *pressureShedding,
+			Window:   *pressureWindow,
+		}, *pressureInterval)
+	}
+
+	// Scale idle applications to zero and wake them up on request
+	var wakeServer *http.Server
+	if *idleMetricsURL != "" {
+		upstream, err := url.Parse(*wakeUpstream)
+		if err != nil {
+			log.Fatalf("Invalid wake upstream: %v", err)
+		}
+
+		idleController := idle.NewController(nomadClient, *idleMetricsURL, *idleInterval)
+		go idleController.Run(ctx)
+
+		wakeServer = &http.Server{
+			Addr:    *wakeAddress,
+			Handler: idle.NewWakeProxy(idleController, upstream, *wakeTimeout),
+		}
+		go func() {
+			log.Printf("Starting wake proxy on %s", *wakeAddress)
+			if err := wakeServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Wake proxy error: %v", err)
+			}
+		}()
+	}
+
+	// Scheduled backups of application volumes
+	if !*readOnly && nomadClient != nil {
+		go apiServer.RunBackups(ctx, *backupInterval)
+	}
+
+	// Verification of custom domains claimed by tenants
+	if !*readOnly && nomadClient != nil {
+		go apiServer.RunDomainVerification(ctx, *domainInterval)
+	}
+
+	// Commands consumed from the message bus
+	if !*readOnly {
+		for _, consumer := range consumers {
+			go apiServer.RunCommandConsumer(ctx, consumer)
+		}
+	}
+
+	// Search index of the applications
+	if !*readOnly && nomadClient != nil {
+		go apiServer.RunSearchIndexing(ctx, *searchInterval)
+	}
+
+	// Rollouts stuck past their deadline
+	if !*readOnly && nomadClient != nil {
+		go apiServer.RunRolloutDeadlines(ctx, *deadlineInterval)
+	}
+
+	// History of the finished rollouts
+	if !*readOnly && nomadClient != nil {
+		go apiServer.RunRolloutRecording(ctx, *rolloutInterval)
+	}
+
+	// Traffic shifted to the green jobs of blue-green and canary-traffic rollouts
+	if !*readOnly && nomadClient != nil {
+		go apiServer.RunTrafficShifts(ctx, *shiftInterval)
+	}
+
+	// Failover of geo routed applications unhealthy in a region
+	if !*readOnly && geoDNS != nil {
+		go apiServer.RunGeoFailover(ctx, *geoInterval)
+	}
+
+	// Disaster recovery replication of the registry to the standby site
+	if !*readOnly && *drReplicateTo != "" {
+		go raftStore.ReplicateTo(ctx, *drReplicateTo, *drInterval)
+	}
+
+	// Drift of deployed images whose tags moved
+	if !*readOnly && driftPolicy != nil {
+		go apiServer.RunDriftDetection(ctx, *driftInterval)
+	}
+
+	// Usage samples of running allocations
+	if !*readOnly && recommendations != nil {
+		go apiServer.RunUsageSampling(ctx, *usageInterval)
+	}
+
+	// Restarts of allocations compared with their baseline
+	if !*readOnly && restartPolicy != nil {
+		go apiServer.RunRestartDetection(ctx, *restartInterval)
+	}
+
+	// Incidents of the applications matching the alerting rules of their tier
+	if !*readOnly && incidents != nil {
+		go apiServer.RunIncidents(ctx, incidents, *incidentInterval)
+	}
+
+	// Availability of the applications of the status page
+	if !*readOnly && *statusPageAddress != "" {
+		go apiServer.RunUptimeChecks(ctx, statusPage, *uptimeInterval)
+	}
+
+	// Counts of autoscaled services
+	if !*readOnly && autoscalePolicy != nil {
+		go apiServer.RunAutoscaling(ctx, *autoscaleInterval)
+	}
+
+	// Rotation of the Nomad tokens of tenants
+	if !*readOnly && tenantACL != nil {
+		go adminServer.RunTenantTokenRotation(ctx, *tenantTokenInterval)
+	}
+
+	// Daily email digests of the notification routes
+	if !*readOnly && router != nil {
+		go apiServer.RunDigests(ctx, router)
+	}
+
 	// Create the gRPC service
-	grpcServer := grpc.NewServer()
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	var auth *api.Authenticator
+	if *apiTokensFile != "" || *authServiceAccounts {
+		var tokens []api.APIToken
+		if *apiTokensFile != "" {
+			if tokens, err = api.LoadAPITokens(*apiTokensFile); err != nil {
+				log.Fatalf("Failed to load API tokens: %v", err)
+			}
+		}
+		var accounts store.Store
+		if *authServiceAccounts {
+			accounts = registry
+		}
+		auth = api.NewAuthenticator(tokens, accounts)
+		unary = append(unary, api.AuthInterceptor(auth))
+		stream = append(stream, api.AuthStreamInterceptor(auth))
+		log.Printf("Authenticating RPCs with %d API tokens, service accounts: %t", len(tokens), *authServiceAccounts)
+	}
+	unary = append(unary, api.NamespaceInterceptor(registry))
+	stream = append(stream, api.NamespaceStreamInterceptor(registry))
+	if *readOnly {
+		log.Printf("Running as a read-only replica")
+		unary = append(unary, api.ReadOnlyInterceptor())
+		stream = append(stream, api.ReadOnlyStreamInterceptor())
+	} else if *drStandby {
+		log.Printf("Running as a disaster recovery standby, writes are refused until it is promoted")
+		unary = append(unary, api.StandbyInterceptor(raftStore.Standby))
+		stream = append(stream, api.StandbyStreamInterceptor(raftStore.Standby))
+	}
+	if nomadClient == nil {
+		unary = append(unary, api.OrchestratorInterceptor(orch))
+		stream = append(stream, api.OrchestratorStreamInterceptor(orch))
+	}
+	if !*readOnly && *idempotencyTTL > 0 {
+		unary = append(unary, api.IdempotencyInterceptor(registry, *idempotencyTTL))
+	}
+	serverOptions := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(*maxMessageSize),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+	if *tlsCert != "" || *tlsKey != "" {
+		creds, err := api.ServerCredentials(*tlsCert, *tlsKey, *tlsClientCA)
+		if err != nil {
+			log.Fatalf("Failed to set up TLS: %v", err)
+		}
+		serverOptions = append(serverOptions, grpc.Creds(creds))
+	}
+	grpcServer := grpc.NewServer(serverOptions...)
 	pb.RegisterControlPlaneServer(grpcServer, apiServer)
+	if adminServer != nil {
+		pb.RegisterAdminServer(grpcServer, adminServer)
+	}
+
+	// Application health for GitOps tools, read from Nomad
+	var restServer *http.Server
+	if *httpAddress != "" && nomadClient != nil {
+		bridge := &api.WebsocketBridge{Service: apiServer, Hub: eventHub, Auth: auth}
+		if *wsOrigins != "" {
+			bridge.Origins = strings.Split(*wsOrigins, ",")
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/v1/ws/", bridge.Handler())
+		mux.Handle("/", apiServer.HealthHandler(*httpCacheTTL, auth))
+		restServer = &http.Server{
+			Addr:    *httpAddress,
+			Handler: mux,
+		}
+		go func() {
+			log.Printf("Starting REST endpoint on %s", *httpAddress)
+			if err := restServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("REST endpoint error: %v", err)
+			}
+		}()
+	}
+
+	// Public status page, served from the registry by every replica
+	var statusServer *http.Server
+	if *statusPageAddress != "" {
+		statusServer = &http.Server{
+			Addr:    *statusPageAddress,
+			Handler: apiServer.StatusPageHandler(statusPage),
+		}
+		go func() {
+			log.Printf("Starting status page on %s", *statusPageAddress)
+			if err := statusServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Status page error: %v", err)
+			}
+		}()
+	}
 
 	// go func() {
 	// 	log.Printf("Starting metrics server on :%s", *metricsPort)
@@ -50,17 +762,43 @@ func main() {
 
 	// Start gRPC server
 	go func() {
-		log.Printf("Starting gRPC server on :%s", *grpcPort)
+		switch {
+		case *tlsClientCA != "":
+			log.Printf("Starting gRPC server on %s with mutual TLS", listenAddress)
+		case *tlsCert != "":
+			log.Printf("Starting gRPC server on %s with TLS", listenAddress)
+		default:
+			log.Printf("Starting gRPC server on %s", listenAddress)
+		}
 		if err := grpcServer.Serve(listener); err != nil {
 			log.Fatalf("Failed to serve: %v", err)
 		}
 	}()
 
+	if *dev {
+		log.Printf("Dev control plane ready, Nomad UI at %s/ui, try:", strings.TrimSuffix(*nomadAddress, "/"))
+		log.Printf("  cli -server=localhost:%s -action=deploy -name=whoami -image=traefik/whoami:latest", *grpcPort)
+		log.Printf("  cli ps -server=localhost:%s", *grpcPort)
+	}
+
 	// Wait for interrupt
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	<-sigChan
 
 	log.Println("Shutting down...")
+	cancel()
+	if wakeServer != nil {
+		_ = wakeServer.Close()
+	}
+	if restServer != nil {
+		_ = restServer.Close()
+	}
+	if statusServer != nil {
+		_ = statusServer.Close()
+	}
 	grpcServer.GracefulStop()
+	if raftServer != nil {
+		_ = raftServer.Close()
+	}
 }
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/peer"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/events"
+)
+
+const (
+	defaultActionTimeout = 5 * time.Minute
+	maxActionOutput      = 64 << 10
+)
+
+// RunAction runs an action the application defines in one of its running allocations
+// and returns its output. Every run is logged and published as an event, with the caller.
+func (s *ApplicationService) RunAction(ctx context.Context, req *pb.RunActionRequest) (*pb.RunActionResponse, error) {
+	if req.Action == "" {
+		return &pb.RunActionResponse{Message: "action must be provided"}, nil
+	}
+
+	client, job, alloc, task, err := s.resolveTask(req.DeploymentId, req.AllocationId, req.TaskName, true)
+	if err == nil && !hasAction(job, task, req.Action) {
+		err = fmt.Errorf("task %s defines no action %s", task, req.Action)
+	}
+	if err != nil {
+		return &pb.RunActionResponse{
+			Message: fmt.Sprintf("Failed to run action: %v", err),
+		}, nil
+	}
+
+	timeout := defaultActionTimeout
+	if req.TimeoutSeconds > 0 {
+		timeout = time.Duration(req.TimeoutSeconds) * time.Second
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	caller := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		caller = p.Addr.String()
+	}
+
+	started := time.Now()
+	result, err := client.RunAction(runCtx, alloc, *job.ID, task, req.Action, maxActionOutput)
+	if err != nil {
+		log.Printf("Action %s of %s in allocation %s requested by %s failed: %v", req.Action, *job.ID, alloc, caller, err)
+		s.publish(events.ApplicationActionRun, *job.ID, lifecycleEvent{
+			Action:  req.Action,
+			AllocID: alloc,
+			Caller:  caller,
+			Message: err.Error(),
+		})
+		return &pb.RunActionResponse{
+			AllocationId: alloc,
+			Message:      fmt.Sprintf("Failed to run action: %v", err),
+		}, nil
+	}
+
+	exitCode := int32(result.ExitCode)
+	log.Printf("Action %s of %s in allocation %s requested by %s exited with %d after %s",
+		req.Action, *job.ID, alloc, caller, exitCode, time.Since(started).Round(time.Millisecond))
+	s.publish(events.ApplicationActionRun, *job.ID, lifecycleEvent{
+		Action:   req.Action,
+		AllocID:  alloc,
+		ExitCode: &exitCode,
+		Caller:   caller,
+	})
+
+	return &pb.RunActionResponse{
+		Success:      exitCode == 0,
+		Message:      fmt.Sprintf("Action %s in allocation %s exited with %d", req.Action, alloc, exitCode),
+		ExitCode:     exitCode,
+		Stdout:       result.Stdout,
+		Stderr:       result.Stderr,
+		AllocationId: alloc,
+	}, nil
+}
+
+func hasAction(job *nmd.Job, task, action string) bool {
+	for _, group := range job.TaskGroups {
+		for _, t := range group.Tasks {
+			if t.Name != task {
+				continue
+			}
+			for _, a := range t.Actions {
+				if a.Name == action {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
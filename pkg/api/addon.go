@@ -0,0 +1,143 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+func addOnsFromSpec(req *pb.DeployRequest) []*nomad.AddOn {
+	var addOns []*nomad.AddOn
+	for _, addOn := range req.Addons {
+		addOns = append(addOns, &nomad.AddOn{
+			Application: req.Name,
+			Name:        addOn.Name,
+			Type:        addOn.Type,
+			Version:     addOn.Version,
+			MemoryMB:    int(addOn.Memory),
+			Region:      req.Region,
+			VolumeID:    addOn.VolumeId,
+		})
+	}
+	return addOns
+}
+
+// deployAddOns registers the add-on jobs before the application, whose templates wait for
+// their services. Each add-on gets a password on its first deploy which is kept afterwards,
+// the add-on reads it from its own variable and the application from the application's.
+func (s *ApplicationService) deployAddOns(req *pb.DeployRequest) error {
+	addOns := addOnsFromSpec(req)
+	if len(addOns) == 0 {
+		return nil
+	}
+
+	// the jobs and variables of the add-ons are in the namespace of the application
+	client, err := s.orhClient.InNamespace(req.Namespace)
+	if err != nil {
+		return err
+	}
+	appPath := nomad.JobVariablePath(req.Name)
+	appItems, err := client.VariableItems(appPath)
+	if err != nil {
+		return err
+	}
+	if appItems == nil {
+		appItems = make(map[string]string)
+	}
+
+	for _, addOn := range addOns {
+		path := nomad.JobVariablePath(addOn.JobID())
+		items, err := client.VariableItems(path)
+		if err != nil {
+			return err
+		}
+
+		password := items[nomad.AddOnPasswordKey]
+		if password == "" {
+			if password, err = newPassword(); err != nil {
+				return err
+			}
+			if err := client.PutVariable(path, map[string]string{nomad.AddOnPasswordKey: password}); err != nil {
+				return fmt.Errorf("add-on %s: %w", addOn.Name, err)
+			}
+		}
+		appItems[addOn.PasswordKey()] = password
+
+		if _, err := client.DeployJob(addOn.Job()); err != nil {
+			return fmt.Errorf("add-on %s: %w", addOn.Name, err)
+		}
+	}
+
+	return client.PutVariable(appPath, appItems)
+}
+
+// removeAddOns tears down the add-ons of an application which are not in keep, with their
+// passwords. Data on their volumes is kept.
+func (s *ApplicationService) removeAddOns(application string, keep []*nomad.AddOn) {
+	client, err := s.orhClient.InNamespace(jobNamespace(s.jobName(application)))
+	if err != nil {
+		log.Printf("Failed to list add-ons of %s: %v", application, err)
+		return
+	}
+	jobs, err := client.ListJobs()
+	if err != nil {
+		log.Printf("Failed to list add-ons of %s: %v", application, err)
+		return
+	}
+
+	kept := make(map[string]bool)
+	for _, addOn := range keep {
+		kept[addOn.JobID()] = true
+	}
+
+	var removed []*nomad.AddOn
+	for _, job := range jobs {
+		if job.Meta[nomad.MetaAddOnOf] != application || kept[job.ID] {
+			continue
+		}
+		addOn := &nomad.AddOn{Application: application, Name: job.Meta[nomad.MetaAddOnName]}
+
+		if err := client.DeleteJob(job.ID); err != nil {
+			log.Printf("Failed to delete add-on %s: %v", job.ID, err)
+			continue
+		}
+		if err := client.DeleteVariable(nomad.JobVariablePath(job.ID)); err != nil {
+			log.Printf("Failed to delete the password of add-on %s: %v", job.ID, err)
+		}
+		removed = append(removed, addOn)
+	}
+	if len(removed) == 0 {
+		return
+	}
+
+	appPath := nomad.JobVariablePath(application)
+	appItems, err := client.VariableItems(appPath)
+	if err != nil || appItems == nil {
+		return
+	}
+	for _, addOn := range removed {
+		delete(appItems, addOn.PasswordKey())
+	}
+
+	if len(appItems) == 0 {
+		err = client.DeleteVariable(appPath)
+	} else {
+		err = client.PutVariable(appPath, appItems)
+	}
+	if err != nil {
+		log.Printf("Failed to remove add-on passwords of %s: %v", application, err)
+	}
+}
+
+// newPassword returns a random password safe to use in connection URLs
+func newPassword() (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,340 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/consul"
+	"github.com/iuliansafta/control-plane/pkg/kms"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// Quotas of tenants created without explicit limits
+const (
+	defaultTenantCPU          = 4
+	defaultTenantMemoryMB     = 8192
+	defaultTenantApplications = 20
+	defaultServiceAccount     = "deployer"
+)
+
+// tenant names end up in namespaces and hostnames
+var tenantName = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// AdminService implements the platform team's API
+type AdminService struct {
+	pb.UnimplementedAdminServer
+	orhClient    *nomad.NomadClient
+	registry     store.Store
+	sealer       *kms.Sealer
+	tenantDomain string
+	edgeProxy    nomad.EdgeProxy
+	consul       *consul.Client
+	tenantACL    *TenantACL
+}
+
+// NewAdminService creates the admin API, tenants get hostnames below tenantDomain by default
+func NewAdminService(orchClient *nomad.NomadClient, registry store.Store, sealer *kms.Sealer, tenantDomain string, edgeProxy nomad.EdgeProxy, consulClient *consul.Client, tenantACL *TenantACL) *AdminService {
+	return &AdminService{
+		orhClient:    orchClient,
+		registry:     registry,
+		sealer:       sealer,
+		tenantDomain: tenantDomain,
+		edgeProxy:    edgeProxy,
+		consul:       consulClient,
+		tenantACL:    tenantACL,
+	}
+}
+
+// CreateTenant onboards a team: it creates its namespaces, records its quota and domain
+// template and issues the token of its first service account, and a read-only Nomad
+// token when the team needs one.
+func (s *AdminService) CreateTenant(ctx context.Context, req *pb.CreateTenantRequest) (*pb.CreateTenantResponse, error) {
+	tenant, err := s.tenantFromRequest(req)
+	if err != nil {
+		return &pb.CreateTenantResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid tenant: %v", err),
+		}, nil
+	}
+
+	if _, err := s.registry.Tenant(tenant.Name); err == nil {
+		return &pb.CreateTenantResponse{
+			Success: false,
+			Message: fmt.Sprintf("Tenant %s already exists", tenant.Name),
+		}, nil
+	} else if !errors.Is(err, store.ErrNotFound) {
+		return &pb.CreateTenantResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create tenant: %v", err),
+		}, nil
+	}
+
+	for _, namespace := range tenant.Namespaces {
+		err := s.orhClient.CreateNamespace(namespace, fmt.Sprintf("Namespace of tenant %s", tenant.Name), map[string]string{
+			nomad.MetaTenant: tenant.Name,
+		})
+		if err != nil {
+			return &pb.CreateTenantResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to create namespace %s: %v", namespace, err),
+			}, nil
+		}
+	}
+
+	var nomadToken string
+	if req.NomadAccess {
+		if nomadToken, err = s.mintTenantToken(&tenant, req.NomadReadLogs); err != nil {
+			return &pb.CreateTenantResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to issue Nomad token: %v", err),
+			}, nil
+		}
+	}
+
+	token, tokenHash, err := newToken()
+	if err != nil {
+		return &pb.CreateTenantResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to issue token: %v", err),
+		}, nil
+	}
+
+	if tenant.DataKey, tenant.KeyID, err = s.sealer.NewDataKey(ctx); err != nil {
+		return &pb.CreateTenantResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create data key: %v", err),
+		}, nil
+	}
+
+	if err := s.registry.CreateTenant(tenant); err != nil {
+		return &pb.CreateTenantResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create tenant: %v", err),
+		}, nil
+	}
+	// its applications are deployed to its namespaces with the namespace of their requests
+	for _, namespace := range tenant.Namespaces {
+		err := s.registry.CreateNamespace(store.Namespace{Name: namespace, Tenant: tenant.Name, CreatedAt: tenant.CreatedAt})
+		if err != nil && !errors.Is(err, store.ErrAlreadyExists) {
+			return &pb.CreateTenantResponse{
+				Success: false,
+				Message: fmt.Sprintf("Tenant created but failed to record namespace %s: %v", namespace, err),
+				Tenant:  toTenant(tenant),
+			}, nil
+		}
+	}
+
+	account := store.ServiceAccount{
+		Tenant:    tenant.Name,
+		Name:      req.ServiceAccount,
+		TokenHash: tokenHash,
+		CreatedAt: tenant.CreatedAt,
+	}
+	if account.Name == "" {
+		account.Name = defaultServiceAccount
+	}
+	if err := s.registry.SaveServiceAccount(account); err != nil {
+		return &pb.CreateTenantResponse{
+			Success: false,
+			Message: fmt.Sprintf("Tenant created but failed to save service account: %v", err),
+			Tenant:  toTenant(tenant),
+		}, nil
+	}
+
+	return &pb.CreateTenantResponse{
+		Success:        true,
+		Message:        fmt.Sprintf("Tenant %s created with %d namespaces", tenant.Name, len(tenant.Namespaces)),
+		Tenant:         toTenant(tenant),
+		ServiceAccount: account.Name,
+		Token:          token,
+		NomadToken:     nomadToken,
+	}, nil
+}
+
+// ListTenants lists the onboarded tenants.
+func (s *AdminService) ListTenants(ctx context.Context, req *pb.ListTenantsRequest) (*pb.ListTenantsResponse, error) {
+	tenants, err := s.registry.Tenants()
+	if err != nil {
+		return &pb.ListTenantsResponse{
+			Message: fmt.Sprintf("Failed to list tenants: %v", err),
+		}, nil
+	}
+
+	resp := &pb.ListTenantsResponse{
+		Message: "Tenants retrieved successfully",
+	}
+	for _, tenant := range tenants {
+		resp.Tenants = append(resp.Tenants, toTenant(tenant))
+	}
+
+	return resp, nil
+}
+
+// RotateTenantKeys rewraps the data keys of tenants with the current KMS key. Run it
+// after adding a key to the keyring, the previous key can be retired once every
+// tenant reports the new key ID.
+func (s *AdminService) RotateTenantKeys(ctx context.Context, req *pb.RotateTenantKeysRequest) (*pb.RotateTenantKeysResponse, error) {
+	var tenants []store.Tenant
+	if req.Name != "" {
+		tenant, err := s.registry.Tenant(req.Name)
+		if err != nil {
+			return &pb.RotateTenantKeysResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to find tenant: %v", err),
+			}, nil
+		}
+		tenants = append(tenants, tenant)
+	} else {
+		var err error
+		if tenants, err = s.registry.Tenants(); err != nil {
+			return &pb.RotateTenantKeysResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to list tenants: %v", err),
+			}, nil
+		}
+	}
+
+	resp := &pb.RotateTenantKeysResponse{
+		Success: true,
+		KeyId:   s.sealer.KeyID(),
+	}
+
+	for _, tenant := range tenants {
+		if tenant.KeyID == resp.KeyId {
+			continue
+		}
+
+		wrapped, keyID, err := s.sealer.Rewrap(ctx, tenant.KeyID, tenant.DataKey)
+		if err == nil {
+			tenant.DataKey, tenant.KeyID = wrapped, keyID
+			err = s.registry.UpdateTenant(tenant)
+		}
+		if err != nil {
+			resp.Success = false
+			resp.Message = fmt.Sprintf("Failed to rotate the key of tenant %s: %v", tenant.Name, err)
+			return resp, nil
+		}
+
+		resp.Rotated = append(resp.Rotated, tenant.Name)
+	}
+
+	resp.Message = fmt.Sprintf("%d tenants rewrapped with key %s", len(resp.Rotated), resp.KeyId)
+	return resp, nil
+}
+
+// tenantFromRequest validates the request and fills in the defaults
+func (s *AdminService) tenantFromRequest(req *pb.CreateTenantRequest) (store.Tenant, error) {
+	if !tenantName.MatchString(req.Name) {
+		return store.Tenant{}, fmt.Errorf("name %q must be a lowercase DNS label", req.Name)
+	}
+
+	tenant := store.Tenant{
+		Name:       req.Name,
+		Namespaces: req.Namespaces,
+		Quota: store.TenantQuota{
+			CPU:             defaultTenantCPU,
+			MemoryMB:        defaultTenantMemoryMB,
+			MaxApplications: defaultTenantApplications,
+		},
+		DomainTemplate:     req.DomainTemplate,
+		Security:           securityDefaultsFromProto(req.SecurityDefaults),
+		AllowedImages:      req.AllowedImages,
+		RequireAttestation: req.RequireAttestation,
+		CreatedAt:          time.Now(),
+	}
+
+	if req.NomadAccess && s.tenantACL == nil {
+		return store.Tenant{}, fmt.Errorf("the controller does not manage Nomad ACLs, start it with -tenant-nomad-acl")
+	}
+	if req.NomadReadLogs && !req.NomadAccess {
+		return store.Tenant{}, fmt.Errorf("nomad_read_logs requires nomad_access")
+	}
+
+	if security := securityFromProto(req.SecurityDefaults); security != nil {
+		if err := security.Validate(); err != nil {
+			return store.Tenant{}, fmt.Errorf("security defaults: %w", err)
+		}
+	}
+
+	if len(tenant.Namespaces) == 0 {
+		tenant.Namespaces = []string{req.Name}
+	}
+	for _, namespace := range tenant.Namespaces {
+		if !tenantName.MatchString(namespace) {
+			return store.Tenant{}, fmt.Errorf("namespace %q must be a lowercase DNS label", namespace)
+		}
+	}
+
+	if quota := req.Quota; quota != nil {
+		if quota.Cpu < 0 || quota.MemoryMb < 0 || quota.MaxApplications < 0 {
+			return store.Tenant{}, fmt.Errorf("quota limits cannot be negative")
+		}
+		if quota.Cpu > 0 {
+			tenant.Quota.CPU = quota.Cpu
+		}
+		if quota.MemoryMb > 0 {
+			tenant.Quota.MemoryMB = quota.MemoryMb
+		}
+		if quota.MaxApplications > 0 {
+			tenant.Quota.MaxApplications = int(quota.MaxApplications)
+		}
+	}
+
+	for _, pattern := range tenant.AllowedImages {
+		if pattern == "" || strings.ContainsAny(pattern, " @") || strings.Contains(strings.TrimSuffix(pattern, "*"), "*") {
+			return store.Tenant{}, fmt.Errorf("allowed image %q must be a repository or a prefix ending in *", pattern)
+		}
+	}
+
+	if tenant.DomainTemplate == "" {
+		tenant.DomainTemplate = "{app}.{tenant}." + s.tenantDomain
+	}
+	if !strings.Contains(tenant.DomainTemplate, "{app}") {
+		return store.Tenant{}, fmt.Errorf("domain template %q must contain {app}", tenant.DomainTemplate)
+	}
+
+	return tenant, nil
+}
+
+// newToken returns a random token and the hash stored in its place
+func newToken() (string, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	token := "cp_" + hex.EncodeToString(secret)
+
+	return token, TokenHash(token), nil
+}
+
+func toTenant(tenant store.Tenant) *pb.Tenant {
+	resp := &pb.Tenant{
+		Name:       tenant.Name,
+		Namespaces: tenant.Namespaces,
+		Quota: &pb.TenantQuota{
+			Cpu:             tenant.Quota.CPU,
+			MemoryMb:        tenant.Quota.MemoryMB,
+			MaxApplications: int32(tenant.Quota.MaxApplications),
+		},
+		DomainTemplate:     tenant.DomainTemplate,
+		CreatedAt:          tenant.CreatedAt.Unix(),
+		KeyId:              tenant.KeyID,
+		SecurityDefaults:   toSecurityContext(tenant.Security),
+		AllowedImages:      tenant.AllowedImages,
+		RequireAttestation: tenant.RequireAttestation,
+	}
+	if tenant.NomadAccess != nil {
+		resp.NomadTokenAccessor = tenant.NomadAccess.AccessorID
+		resp.NomadTokenExpiresAt = tenant.NomadAccess.ExpiresAt.Unix()
+	}
+	return resp
+}
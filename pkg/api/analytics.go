@@ -0,0 +1,217 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// the period analytics cover when the request names none
+const defaultAnalyticsPeriod = 30 * 24 * time.Hour
+
+// recovery is the time it took an application to roll out successfully after a failed rollout
+type recovery struct {
+	failedAt time.Time
+	duration time.Duration
+}
+
+// analyticsGroup collects the rollouts of an application, or of the applications of a team
+type analyticsGroup struct {
+	application string
+	team        string
+	rollouts    []store.Rollout
+	recoveries  []recovery
+}
+
+// GetDeploymentAnalytics aggregates the rollouts recorded in the registry into the DORA metrics
+// of every application or team: deploy frequency, lead time, rollout duration, change failure
+// rate and time to recover, in total and per day or week.
+func (s *ApplicationService) GetDeploymentAnalytics(ctx context.Context, req *pb.DeploymentAnalyticsRequest) (*pb.DeploymentAnalyticsResponse, error) {
+	bucket := 7 * 24 * time.Hour
+	switch req.Bucket {
+	case "", "week":
+	case "day":
+		bucket = 24 * time.Hour
+	default:
+		return &pb.DeploymentAnalyticsResponse{
+			Message: "bucket must be day or week",
+		}, nil
+	}
+
+	now := time.Now().UTC()
+	since := now.Add(-defaultAnalyticsPeriod)
+	if req.Since > 0 {
+		since = time.Unix(req.Since, 0).UTC()
+	}
+	since = since.Truncate(bucket)
+
+	jobIDs, err := s.applicationJobs()
+	if err == nil && req.Application != "" {
+		var jobID string
+		jobID, err = s.resolveJobID(req.Application)
+		jobIDs = []string{jobID}
+	}
+	if err != nil {
+		return &pb.DeploymentAnalyticsResponse{
+			Message: fmt.Sprintf("Failed to list applications: %v", err),
+		}, nil
+	}
+
+	groups := make(map[string]*analyticsGroup)
+	for _, jobID := range jobIDs {
+		name := s.jobName(jobID)
+		if req.Team != "" && name.Tenant != req.Team || !tokenSees(ctx, name) {
+			continue
+		}
+
+		rollouts, err := s.registry.Rollouts(jobID)
+		if err != nil {
+			log.Printf("Failed to load rollout history of %s: %v", jobID, err)
+			continue
+		}
+
+		key := jobID
+		group := &analyticsGroup{application: name.Application, team: name.Tenant}
+		if req.ByTeam {
+			key, group.application = name.Tenant, ""
+		}
+		if existing, ok := groups[key]; ok {
+			group = existing
+		}
+		groups[key] = group
+
+		// oldest first to pair failures with the recoveries after them
+		slices.Reverse(rollouts)
+		var failedAt time.Time
+		for _, rollout := range rollouts {
+			switch {
+			case rollout.Status == nmd.DeploymentStatusFailed && failedAt.IsZero():
+				failedAt = rollout.FinishedAt
+			case rollout.Status == nmd.DeploymentStatusSuccessful && !failedAt.IsZero():
+				group.recoveries = append(group.recoveries, recovery{failedAt: failedAt, duration: rollout.FinishedAt.Sub(failedAt)})
+				failedAt = time.Time{}
+			}
+			if !rollout.FinishedAt.Before(since) {
+				group.rollouts = append(group.rollouts, rollout)
+			}
+		}
+	}
+
+	resp := &pb.DeploymentAnalyticsResponse{Success: true}
+	for _, group := range groups {
+		analytics := &pb.DeploymentAnalytics{
+			Application: group.application,
+			Team:        group.team,
+			Total:       deliveryMetrics(group, since, now),
+		}
+		for start := since; start.Before(now); start = start.Add(bucket) {
+			end := start.Add(bucket)
+			if end.After(now) {
+				end = now
+			}
+			analytics.Periods = append(analytics.Periods, deliveryMetrics(group, start, end))
+		}
+		resp.Analytics = append(resp.Analytics, analytics)
+	}
+	sort.Slice(resp.Analytics, func(i, j int) bool {
+		a, b := resp.Analytics[i], resp.Analytics[j]
+		if a.Team != b.Team {
+			return a.Team < b.Team
+		}
+		return a.Application < b.Application
+	})
+
+	resp.Message = fmt.Sprintf("Analytics of %d applications since %s", len(jobIDs), since.Format(time.DateOnly))
+	if req.ByTeam {
+		resp.Message = fmt.Sprintf("Analytics of %d teams since %s", len(resp.Analytics), since.Format(time.DateOnly))
+	}
+	return resp, nil
+}
+
+// deliveryMetrics computes the metrics of the rollouts of the group which finished in a
+// period, and of the recoveries from failures in it
+func deliveryMetrics(group *analyticsGroup, start, end time.Time) *pb.DeliveryMetrics {
+	metrics := &pb.DeliveryMetrics{PeriodStart: start.Unix()}
+
+	var leadTimes, durations, recoveries []time.Duration
+	for _, rollout := range group.rollouts {
+		if rollout.FinishedAt.Before(start) || !rollout.FinishedAt.Before(end) {
+			continue
+		}
+		metrics.Deployments++
+		switch rollout.Status {
+		case nmd.DeploymentStatusFailed:
+			metrics.FailedDeployments++
+		case nmd.DeploymentStatusSuccessful:
+			durations = append(durations, rollout.Duration())
+			if !rollout.CommittedAt.IsZero() {
+				leadTimes = append(leadTimes, rollout.FinishedAt.Sub(rollout.CommittedAt))
+			}
+		}
+	}
+	for _, recovery := range group.recoveries {
+		if !recovery.failedAt.Before(start) && recovery.failedAt.Before(end) {
+			recoveries = append(recoveries, recovery.duration)
+		}
+	}
+
+	if days := end.Sub(start).Hours() / 24; days > 0 {
+		metrics.DeploysPerDay = float64(metrics.Deployments) / days
+	}
+	if metrics.Deployments > 0 {
+		metrics.ChangeFailureRate = float64(metrics.FailedDeployments) / float64(metrics.Deployments)
+	}
+	metrics.LeadTimeSeconds = int64(median(leadTimes).Seconds())
+	metrics.RolloutDurationSeconds = int64(median(durations).Seconds())
+	metrics.MttrSeconds = int64(median(recoveries).Seconds())
+	return metrics
+}
+
+// analyticsHandler serves GetDeploymentAnalytics as JSON, the query parameters are the
+// fields of the request
+func (s *ApplicationService) analyticsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &pb.DeploymentAnalyticsRequest{
+		Application: query.Get("application"),
+		Team:        query.Get("team"),
+		ByTeam:      query.Get("by_team") == "true",
+		Bucket:      query.Get("bucket"),
+	}
+	if since := query.Get("since"); since != "" {
+		unix, err := strconv.ParseInt(since, 10, 64)
+		if err != nil {
+			http.Error(w, "since must be unix seconds", http.StatusBadRequest)
+			return
+		}
+		req.Since = unix
+	}
+
+	resp, _ := s.GetDeploymentAnalytics(r.Context(), req)
+	if !resp.Success {
+		http.Error(w, resp.Message, http.StatusBadRequest)
+		return
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func median(durations []time.Duration) time.Duration {
+	slices.Sort(durations)
+	return percentile(durations, 50)
+}
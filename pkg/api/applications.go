@@ -0,0 +1,318 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// ListApplications summarizes the applications of the controller in one line each, for an
+// overview of what is deployed. The applications are ordered by name and paged, only the
+// applications of a page are looked up in Nomad. Those the registry recorded are listed
+// when Nomad lost their job or is unreachable, with the image of their latest revision.
+func (s *ApplicationService) ListApplications(ctx context.Context, req *pb.ListApplicationsRequest) (*pb.ListApplicationsResponse, error) {
+	if s.orhClient == nil {
+		return s.listOrchestrator(ctx, req)
+	}
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return &pb.ListApplicationsResponse{
+			Message: fmt.Sprintf("Invalid page token: %v", err),
+		}, nil
+	}
+
+	jobIDs, err := s.applicationJobs()
+	if err != nil {
+		// the registry still knows what was deployed, the applications are listed from it
+		log.Printf("Failed to list the jobs of the applications, listing the registry: %v", err)
+	}
+	names, registryErr := s.registry.JobNames()
+	if err != nil && registryErr != nil {
+		return &pb.ListApplicationsResponse{
+			Message: fmt.Sprintf("Failed to list applications: %v", err),
+		}, nil
+	}
+	for _, name := range names {
+		if !slices.Contains(jobIDs, name.JobID) {
+			jobIDs = append(jobIDs, name.JobID)
+		}
+	}
+
+	keys := make([]pageKey, 0, len(jobIDs))
+	for _, jobID := range jobIDs {
+		keys = append(keys, pageKey{Name: s.jobName(jobID).Application, JobID: jobID})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].less(keys[j])
+	})
+
+	filtered := len(req.Labels) > 0 || req.Region != ""
+	var applications []*pb.ApplicationSummary
+	var nextPageToken string
+	for _, key := range keys {
+		if req.PageToken != "" && !after.less(key) {
+			continue
+		}
+		if req.Project != "" && s.jobName(key.JobID).Project != req.Project {
+			continue
+		}
+		if !inNamespace(s.jobName(key.JobID), req.Namespace) {
+			continue
+		}
+		if req.PageSize > 0 && len(applications) == int(req.PageSize) {
+			nextPageToken = encodePageToken(pageKey{Name: applications[len(applications)-1].Name, JobID: applications[len(applications)-1].JobId})
+			break
+		}
+
+		summary, job, err := s.applicationSummary(key.JobID)
+		if err != nil {
+			// deleted since it was listed, or its region is unreachable
+			log.Printf("Failed to summarize application %s: %v", key.JobID, err)
+			if filtered {
+				continue
+			}
+			name := s.jobName(key.JobID)
+			summary = &pb.ApplicationSummary{
+				Name:      name.Application,
+				JobId:     key.JobID,
+				Tenant:    name.Tenant,
+				Owner:     name.Tenant,
+				Status:    "unknown",
+				Namespace: jobNamespace(name),
+			}
+		}
+		s.describeRevision(summary)
+		if req.Region != "" && summary.Region != req.Region {
+			continue
+		}
+		if len(req.Labels) > 0 && !hasLabels(job, req.Labels) {
+			continue
+		}
+		applications = append(applications, summary)
+	}
+
+	message := fmt.Sprintf("Listed %d applications", len(applications))
+	if nextPageToken != "" {
+		message += ", more on the next page"
+	}
+	return &pb.ListApplicationsResponse{
+		Applications:  applications,
+		Message:       message,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// listHandler serves ListApplications as JSON, the query parameters are the fields of the
+// request, each label as label=key=value
+func (s *ApplicationService) listHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &pb.ListApplicationsRequest{
+		Region:    query.Get("region"),
+		PageToken: query.Get("page_token"),
+		Project:   query.Get("project"),
+		Namespace: query.Get("namespace"),
+	}
+	for _, label := range query["label"] {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok {
+			http.Error(w, "label must be key=value", http.StatusBadRequest)
+			return
+		}
+		if req.Labels == nil {
+			req.Labels = make(map[string]string)
+		}
+		req.Labels[key] = value
+	}
+	if size := query.Get("page_size"); size != "" {
+		n, err := strconv.ParseInt(size, 10, 32)
+		if err != nil {
+			http.Error(w, "page_size must be a number", http.StatusBadRequest)
+			return
+		}
+		req.PageSize = int32(n)
+	}
+	if _, err := decodePageToken(req.PageToken); err != nil {
+		http.Error(w, fmt.Sprintf("invalid page token: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := checkRequestNamespace(r.Context(), s.registry, pb.ControlPlane_ListApplications_FullMethodName, req); err != nil {
+		writeStatusError(w, err)
+		return
+	}
+
+	resp, _ := s.ListApplications(r.Context(), req)
+	// an empty page is listed too, only the failures to list leave no applications
+	if len(resp.Applications) == 0 && strings.HasPrefix(resp.Message, "Failed") {
+		http.Error(w, resp.Message, http.StatusBadGateway)
+		return
+	}
+	writeProtoJSON(w, resp)
+}
+
+// pageKey is the position of an application in the order of ListApplications, the job ID
+// tells apart applications of the same name in different tenants
+type pageKey struct {
+	Name  string `json:"name"`
+	JobID string `json:"job_id"`
+}
+
+func (k pageKey) less(other pageKey) bool {
+	if k.Name != other.Name {
+		return k.Name < other.Name
+	}
+	return k.JobID < other.JobID
+}
+
+// encodePageToken encodes the last application of a page, the next page starts after it
+// even when applications are deployed or deleted in between
+func encodePageToken(key pageKey) string {
+	data, _ := json.Marshal(key)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(token string) (pageKey, error) {
+	var key pageKey
+	if token == "" {
+		return key, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &key)
+	}
+	return key, err
+}
+
+// hasLabels tells whether the application has all the labels, which the job keeps in its meta
+func hasLabels(job *nmd.Job, labels map[string]string) bool {
+	for key, value := range labels {
+		if label, ok := job.Meta[nomad.MetaLabelPrefix+key]; !ok || label != value {
+			return false
+		}
+	}
+	return true
+}
+
+// jobLabels returns the labels of the application of a job, which keeps them in its meta
+func jobLabels(job *nmd.Job) map[string]string {
+	labels := make(map[string]string)
+	for key, value := range job.Meta {
+		if label, ok := strings.CutPrefix(key, nomad.MetaLabelPrefix); ok {
+			labels[label] = value
+		}
+	}
+	return labels
+}
+
+// applicationOwner is the owner label of an application, or else its tenant
+func applicationOwner(labels map[string]string, tenant string) string {
+	if owner := labels["owner"]; owner != "" {
+		return owner
+	}
+	return tenant
+}
+
+// isPlatformJob tells the jobs the control plane runs itself, the edge proxy and the
+// controllers, from those of applications
+func isPlatformJob(job *nmd.JobListStub) bool {
+	return job.Meta[nomad.MetaEdgeProxy] != "" || job.Meta[nomad.MetaController] != ""
+}
+
+// applicationJobs lists the jobs of the applications in the default region and those
+// placed in other regions
+func (s *ApplicationService) applicationJobs() ([]string, error) {
+	jobs, err := s.orhClient.ListJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make(map[string]bool)
+	var jobIDs []string
+	for _, job := range jobs {
+		// dispatched and periodic runs belong to their parent, backup and add-on jobs to their application
+		if job.ParentID != "" || job.Meta[nomad.MetaBackupOf] != "" || job.Meta[nomad.MetaAddOnOf] != "" || job.Meta[nomad.MetaGreenOf] != "" || isPlatformJob(job) {
+			continue
+		}
+		listed[job.ID] = true
+		jobIDs = append(jobIDs, job.ID)
+	}
+
+	names, err := s.registry.JobNames()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if listed[name.JobID] {
+			continue
+		}
+		_, placed := s.registry.Placement(name.JobID)
+		_, routed := s.registry.GeoRoute(name.JobID)
+		if placed == nil || routed == nil {
+			jobIDs = append(jobIDs, name.JobID)
+		}
+	}
+
+	return jobIDs, nil
+}
+
+func (s *ApplicationService) applicationSummary(jobID string) (*pb.ApplicationSummary, *nmd.Job, error) {
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+	job, allocations, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := s.jobName(jobID)
+	summary := &pb.ApplicationSummary{
+		Name:   name.Application,
+		JobId:  jobID,
+		Tenant: name.Tenant,
+		Type:   *job.Type,
+		Status: *job.Status,
+		Image:  taskImage(job, jobID),
+		Url:    nomad.TraefikURL(job),
+		Owner:  applicationOwner(jobLabels(job), name.Tenant),
+	}
+	if job.Namespace != nil {
+		summary.Namespace = *job.Namespace
+	}
+	if job.SubmitTime != nil {
+		summary.SubmittedAt = *job.SubmitTime / 1e9
+	}
+	if job.Region != nil {
+		summary.Region = *job.Region
+	}
+	if summary.Image == "" && len(job.TaskGroups) > 0 && len(job.TaskGroups[0].Tasks) > 0 {
+		summary.Image, _ = job.TaskGroups[0].Tasks[0].Config["image"].(string)
+	}
+
+	for _, group := range job.TaskGroups {
+		summary.DesiredInstances += int32(*group.Count)
+	}
+	for _, alloc := range allocations {
+		if alloc.ClientStatus == "running" {
+			summary.RunningInstances++
+		}
+		if isHealthy(alloc) {
+			summary.HealthyInstances++
+		}
+		if isFailed(alloc) {
+			summary.FailedInstances++
+		}
+	}
+
+	return summary, job, nil
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+	"github.com/iuliansafta/control-plane/pkg/utils"
+)
+
+// fakeNomad serves the jobs of the Nomad API ListApplications reads
+func fakeNomad(t *testing.T, jobs ...*nmd.Job) *nomad.NomadClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/jobs" {
+			stubs := make([]*nmd.JobListStub, 0, len(jobs))
+			for _, job := range jobs {
+				stubs = append(stubs, &nmd.JobListStub{ID: *job.ID, Name: *job.Name, Namespace: *job.Namespace, Meta: job.Meta, Type: *job.Type, Status: *job.Status})
+			}
+			json.NewEncoder(w).Encode(stubs)
+			return
+		}
+		id, allocations := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/v1/job/"), "/allocations")
+		for _, job := range jobs {
+			if *job.ID != id {
+				continue
+			}
+			if allocations {
+				json.NewEncoder(w).Encode([]*nmd.AllocationListStub{})
+			} else {
+				json.NewEncoder(w).Encode(job)
+			}
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := nomad.NewNomadClient(nomad.Config{Address: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func fakeJob(id string, labels map[string]string) *nmd.Job {
+	job := nmd.NewServiceJob(id, id, "global", 50)
+	job.Namespace = utils.StringPtr(nomad.DefaultNamespace)
+	job.Status = utils.StringPtr("running")
+	job.Meta = make(map[string]string)
+	for key, value := range labels {
+		job.Meta[nomad.MetaLabelPrefix+key] = value
+	}
+	job.AddTaskGroup(nmd.NewTaskGroup(id, 1).AddTask(&nmd.Task{Name: id, Driver: "docker", Config: map[string]any{"image": id + ":1"}}))
+	return job
+}
+
+func TestListApplicationsPages(t *testing.T) {
+	service := &ApplicationService{
+		registry: store.NewMemoryStore(),
+		orhClient: fakeNomad(t,
+			fakeJob("delta", nil),
+			fakeJob("alpha", map[string]string{"team": "web"}),
+			fakeJob("charlie", nil),
+			fakeJob("bravo", map[string]string{"team": "web"}),
+		),
+	}
+
+	tests := []struct {
+		name        string
+		req         *pb.ListApplicationsRequest
+		want        []string
+		wantNext    *pageKey // the key of the next page token, none when nil
+		wantMessage string
+	}{
+		{
+			name:     "first page",
+			req:      &pb.ListApplicationsRequest{PageSize: 2},
+			want:     []string{"alpha", "bravo"},
+			wantNext: &pageKey{Name: "bravo", JobID: "bravo"},
+		},
+		{
+			name: "last page",
+			req:  &pb.ListApplicationsRequest{PageSize: 2, PageToken: encodePageToken(pageKey{Name: "bravo", JobID: "bravo"})},
+			want: []string{"charlie", "delta"},
+		},
+		{
+			name: "page as large as the list",
+			req:  &pb.ListApplicationsRequest{PageSize: 4},
+			want: []string{"alpha", "bravo", "charlie", "delta"},
+		},
+		{
+			name:     "page of one",
+			req:      &pb.ListApplicationsRequest{PageSize: 1, PageToken: encodePageToken(pageKey{Name: "alpha", JobID: "alpha"})},
+			want:     []string{"bravo"},
+			wantNext: &pageKey{Name: "bravo", JobID: "bravo"},
+		},
+		{
+			name: "token of a deleted application",
+			req:  &pb.ListApplicationsRequest{PageSize: 2, PageToken: encodePageToken(pageKey{Name: "bravo-old", JobID: "bravo-old"})},
+			want: []string{"charlie", "delta"},
+		},
+		{
+			name: "token after the last application",
+			req:  &pb.ListApplicationsRequest{PageSize: 2, PageToken: encodePageToken(pageKey{Name: "echo", JobID: "echo"})},
+		},
+		{
+			name:        "token not in base64",
+			req:         &pb.ListApplicationsRequest{PageToken: "not a token!"},
+			wantMessage: "Invalid page token",
+		},
+		{
+			name:        "token not of a page",
+			req:         &pb.ListApplicationsRequest{PageToken: "bm90IGpzb24"},
+			wantMessage: "Invalid page token",
+		},
+		{
+			name: "labels",
+			req:  &pb.ListApplicationsRequest{Labels: map[string]string{"team": "web"}},
+			want: []string{"alpha", "bravo"},
+		},
+		{
+			name: "labels matching nothing",
+			req:  &pb.ListApplicationsRequest{Labels: map[string]string{"team": "data"}},
+		},
+		{
+			name: "region matching nothing",
+			req:  &pb.ListApplicationsRequest{Region: "eu-west"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.ListApplications(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantMessage != "" && !strings.HasPrefix(resp.Message, tt.wantMessage) {
+				t.Fatalf("got message %q, want %q", resp.Message, tt.wantMessage)
+			}
+
+			var got []string
+			for _, application := range resp.Applications {
+				got = append(got, application.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			if tt.wantNext == nil {
+				if resp.NextPageToken != "" {
+					t.Fatalf("got next page token %q, want none", resp.NextPageToken)
+				}
+				return
+			}
+			next, err := decodePageToken(resp.NextPageToken)
+			if err != nil || next != *tt.wantNext {
+				t.Fatalf("got next page %+v (%v), want %+v", next, err, *tt.wantNext)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/attest"
+	"github.com/iuliansafta/control-plane/pkg/oci"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// artifacts are replicated with the registry, larger ones belong in the image's registry
+const maxArtifactSize = 2 << 20
+
+const dsseMediaType = "application/vnd.dsse.envelope.v1+json"
+
+var artifactKinds = map[pb.ArtifactKind]string{
+	pb.ArtifactKind_ARTIFACT_KIND_SBOM:       "sbom",
+	pb.ArtifactKind_ARTIFACT_KIND_PROVENANCE: "provenance",
+}
+
+// AttestationPolicy verifies the attestations attached to applications and decides which
+// deployments need one
+type AttestationPolicy struct {
+	Verifier attest.Verifier // attestations stay unverified without one
+	Required bool            // every deployment needs one, otherwise only those of tenants requiring it
+	Resolver *oci.Resolver
+}
+
+// AttachArtifact records an SBOM or provenance attestation of an image for audits, attestations
+// are verified right away
+func (s *ApplicationService) AttachArtifact(ctx context.Context, req *pb.AttachArtifactRequest) (*pb.AttachArtifactResponse, error) {
+	kind, ok := artifactKinds[req.Kind]
+	switch {
+	case req.Application == "" || req.Image == "":
+		return &pb.AttachArtifactResponse{Message: "Application and image are required"}, nil
+	case !ok:
+		return &pb.AttachArtifactResponse{Message: "Artifact kind must be SBOM or provenance"}, nil
+	case len(req.Content) == 0:
+		return &pb.AttachArtifactResponse{Message: "Artifact content is empty"}, nil
+	case len(req.Content) > maxArtifactSize:
+		return &pb.AttachArtifactResponse{
+			Message: fmt.Sprintf("Artifact is %d bytes, the limit is %d bytes", len(req.Content), maxArtifactSize),
+		}, nil
+	}
+
+	_, envelopeErr := attest.ParseEnvelope(req.Content)
+	if kind == "provenance" && envelopeErr != nil {
+		return &pb.AttachArtifactResponse{
+			Message: fmt.Sprintf("Invalid provenance attestation: %v", envelopeErr),
+		}, nil
+	}
+
+	resolveCtx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	digest, err := s.attestation.Resolver.Digest(resolveCtx, req.Image)
+	cancel()
+	if err != nil {
+		return &pb.AttachArtifactResponse{
+			Message: fmt.Sprintf("Failed to resolve the image digest: %v", err),
+		}, nil
+	}
+
+	sum := sha256.Sum256(req.Content)
+	artifact := store.Artifact{
+		ID:          hex.EncodeToString(sum[:6]),
+		Application: req.Application,
+		Image:       req.Image,
+		Digest:      digest,
+		Kind:        kind,
+		MediaType:   req.MediaType,
+		SHA256:      hex.EncodeToString(sum[:]),
+		Content:     req.Content,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if artifact.MediaType == "" && envelopeErr == nil {
+		artifact.MediaType = dsseMediaType
+	}
+
+	if envelopeErr == nil && s.attestation.Verifier != nil {
+		signer, err := s.attestation.Verifier.Verify(ctx, oci.WithDigest(req.Image, digest), req.Content)
+		if err != nil {
+			artifact.VerificationError = err.Error()
+		} else {
+			artifact.Verified, artifact.Signer = true, signer
+		}
+	}
+
+	if err := s.registry.SaveArtifact(artifact); err != nil {
+		return &pb.AttachArtifactResponse{
+			Message: fmt.Sprintf("Failed to record artifact: %v", err),
+		}, nil
+	}
+
+	message := "Artifact attached"
+	switch {
+	case artifact.Verified:
+		message = "Attestation verified and attached"
+	case artifact.VerificationError != "":
+		message = fmt.Sprintf("Artifact attached, but its verification failed: %s", artifact.VerificationError)
+	}
+	return &pb.AttachArtifactResponse{
+		Success:  true,
+		Message:  message,
+		Artifact: toArtifact(artifact),
+	}, nil
+}
+
+// ListArtifacts lists the artifacts attached to an application without their content
+func (s *ApplicationService) ListArtifacts(ctx context.Context, req *pb.ListArtifactsRequest) (*pb.ListArtifactsResponse, error) {
+	artifacts, err := s.registry.Artifacts(req.Application)
+	if err != nil {
+		return &pb.ListArtifactsResponse{
+			Message: fmt.Sprintf("Failed to list artifacts: %v", err),
+		}, nil
+	}
+
+	resp := &pb.ListArtifactsResponse{}
+	for _, artifact := range artifacts {
+		if req.Digest == "" || artifact.Digest == req.Digest {
+			resp.Artifacts = append(resp.Artifacts, toArtifact(artifact))
+		}
+	}
+	resp.Message = fmt.Sprintf("%d artifacts", len(resp.Artifacts))
+
+	return resp, nil
+}
+
+// GetArtifact returns an artifact with its content
+func (s *ApplicationService) GetArtifact(ctx context.Context, req *pb.GetArtifactRequest) (*pb.GetArtifactResponse, error) {
+	artifacts, err := s.registry.Artifacts(req.Application)
+	if err != nil {
+		return &pb.GetArtifactResponse{
+			Message: fmt.Sprintf("Failed to get artifact: %v", err),
+		}, nil
+	}
+
+	for _, artifact := range artifacts {
+		if artifact.ID == req.Id {
+			return &pb.GetArtifactResponse{
+				Artifact: toArtifact(artifact),
+				Content:  artifact.Content,
+				Message:  "Artifact retrieved successfully",
+			}, nil
+		}
+	}
+	return &pb.GetArtifactResponse{
+		Message: fmt.Sprintf("Failed to get artifact: %s of %s not found", req.Id, req.Application),
+	}, nil
+}
+
+// checkAttestation requires a verified provenance attestation of the deployed image when
+// the controller or the tenant demand one
+func (s *ApplicationService) checkAttestation(ctx context.Context, req *pb.DeployRequest) error {
+	required := s.attestation.Required
+	if !required && req.Tenant != "" {
+		tenant, err := s.registry.Tenant(req.Tenant)
+		if err != nil {
+			return err
+		}
+		required = tenant.RequireAttestation
+	}
+	if !required {
+		return nil
+	}
+
+	resolveCtx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	digest, err := s.attestation.Resolver.Digest(resolveCtx, req.Image)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to resolve the digest of %s: %w", req.Image, err)
+	}
+
+	artifacts, err := s.registry.Artifacts(req.Name)
+	if err != nil {
+		return err
+	}
+	for _, artifact := range artifacts {
+		if artifact.Kind == "provenance" && artifact.Digest == digest && artifact.Verified {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s needs a verified provenance attestation of %s (%s), attach one with AttachArtifact", req.Name, req.Image, digest)
+}
+
+func toArtifact(artifact store.Artifact) *pb.Artifact {
+	kind := pb.ArtifactKind_ARTIFACT_KIND_UNSPECIFIED
+	for k, name := range artifactKinds {
+		if name == artifact.Kind {
+			kind = k
+		}
+	}
+
+	return &pb.Artifact{
+		Id:                artifact.ID,
+		Application:       artifact.Application,
+		Image:             artifact.Image,
+		Digest:            artifact.Digest,
+		Kind:              kind,
+		MediaType:         artifact.MediaType,
+		Size:              int64(len(artifact.Content)),
+		Sha256:            artifact.SHA256,
+		Verified:          artifact.Verified,
+		Signer:            artifact.Signer,
+		VerificationError: artifact.VerificationError,
+		CreatedAt:         artifact.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,361 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// Scopes of API tokens, every RPC requires one of them
+const (
+	ScopeRead   = "read"   // the RPCs a read-only replica serves
+	ScopeDeploy = "deploy" // the other RPCs of the ControlPlane service
+	ScopeDelete = "delete" // deleting applications, volumes and projects
+	ScopeAdmin  = "admin"  // the Admin service
+)
+
+var scopes = []string{ScopeRead, ScopeDeploy, ScopeDelete, ScopeAdmin}
+
+// serviceAccountScopes are granted to the tokens of the tenants' service accounts
+var serviceAccountScopes = []string{ScopeRead, ScopeDeploy, ScopeDelete}
+
+// AuthorizationHeader is the gRPC metadata key of the API token, as "Bearer <token>"
+const AuthorizationHeader = "authorization"
+
+// deleteMethods are the RPCs requiring the delete scope
+var deleteMethods = map[string]bool{
+	pb.ControlPlane_DeleteApplication_FullMethodName: true,
+	pb.ControlPlane_DeleteVolume_FullMethodName:      true,
+	pb.ControlPlane_DeleteProject_FullMethodName:     true,
+	pb.ControlPlane_DeleteNamespace_FullMethodName:   true,
+}
+
+// publicMethods are served without a token, for load balancers and probes
+var publicMethods = map[string]bool{
+	pb.ControlPlane_HealthCheck_FullMethodName: true,
+}
+
+// APIToken is a token accepted by the controller with the scopes it grants
+type APIToken struct {
+	Name       string   `json:"name"`
+	Token      string   `json:"token,omitempty"`  // the token, or
+	SHA256     string   `json:"sha256,omitempty"` // its SHA-256 in hex, keeping the token out of the file
+	Scopes     []string `json:"scopes"`
+	Namespaces []string `json:"namespaces,omitempty"` // the only namespaces it sees, every one when empty
+	Tenant     string   `json:"-"`                    // the only tenant it sees, set for the tokens of service accounts
+}
+
+// LoadAPITokens reads the API token file, a JSON list of tokens. $VAR and ${VAR} are
+// expanded from the environment so the tokens can stay out of it.
+func LoadAPITokens(file string) ([]APIToken, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []APIToken
+	if err := json.Unmarshal([]byte(os.ExpandEnv(string(data))), &tokens); err != nil {
+		return nil, fmt.Errorf("invalid API tokens %s: %w", file, err)
+	}
+	for i := range tokens {
+		token := &tokens[i]
+		if token.Name == "" {
+			return nil, fmt.Errorf("token %d needs a name", i+1)
+		}
+		if (token.Token == "") == (token.SHA256 == "") {
+			return nil, fmt.Errorf("token %s needs exactly one of token or sha256", token.Name)
+		}
+		if token.Token != "" {
+			token.SHA256, token.Token = TokenHash(token.Token), ""
+		}
+		token.SHA256 = strings.ToLower(token.SHA256)
+		if sum, err := hex.DecodeString(token.SHA256); err != nil || len(sum) != sha256.Size {
+			return nil, fmt.Errorf("token %s: sha256 must be 64 hex digits", token.Name)
+		}
+		if len(token.Scopes) == 0 {
+			return nil, fmt.Errorf("token %s needs at least one scope of %v", token.Name, scopes)
+		}
+		for _, scope := range token.Scopes {
+			if !slices.Contains(scopes, scope) {
+				return nil, fmt.Errorf("token %s: unknown scope %q, expected one of %v", token.Name, scope, scopes)
+			}
+		}
+		for _, namespace := range token.Namespaces {
+			if !tenantName.MatchString(namespace) {
+				return nil, fmt.Errorf("token %s: namespace %q must be a lowercase DNS label", token.Name, namespace)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// TokenHash is the SHA-256 of a token in hex, as kept by the token file and the registry
+func TokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Authenticator resolves the API token of a request to the scopes it grants
+type Authenticator struct {
+	tokens   map[string]APIToken // SHA-256
+	registry store.Store         // accepts the tokens of the service accounts when set
+}
+
+// NewAuthenticator accepts the tokens of the file, and the tokens of the tenants' service
+// accounts with a registry
+func NewAuthenticator(tokens []APIToken, registry store.Store) *Authenticator {
+	a := &Authenticator{tokens: make(map[string]APIToken), registry: registry}
+	for _, token := range tokens {
+		a.tokens[token.SHA256] = token
+	}
+	return a
+}
+
+// authenticate returns the known token, its name and scopes
+func (a *Authenticator) authenticate(token string) (APIToken, error) {
+	hash := TokenHash(token)
+	if known, ok := a.tokens[hash]; ok {
+		return known, nil
+	}
+	if a.registry == nil {
+		return APIToken{}, errors.New("unknown token")
+	}
+
+	tenants, err := a.registry.Tenants()
+	if err != nil {
+		return APIToken{}, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	for _, tenant := range tenants {
+		accounts, err := a.registry.ServiceAccounts(tenant.Name)
+		if err != nil {
+			return APIToken{}, fmt.Errorf("failed to list service accounts: %w", err)
+		}
+		for _, account := range accounts {
+			if account.TokenHash != hash {
+				continue
+			}
+			// the token is scoped to the tenant's namespaces, a tenant without any would
+			// otherwise see every namespace
+			if len(tenant.Namespaces) == 0 {
+				return APIToken{}, fmt.Errorf("tenant %s owns no namespaces", tenant.Name)
+			}
+			return APIToken{
+				Name:       tenant.Name + "/" + account.Name,
+				SHA256:     hash,
+				Scopes:     serviceAccountScopes,
+				Namespaces: tenant.Namespaces,
+				Tenant:     tenant.Name,
+			}, nil
+		}
+	}
+	return APIToken{}, errors.New("unknown token")
+}
+
+// authorize checks the token of the request grants the scope of the RPC, the context
+// returned keeps the namespaces the token is scoped to
+func (a *Authenticator) authorize(ctx context.Context, method string) (context.Context, error) {
+	if publicMethods[method] {
+		return ctx, nil
+	}
+
+	values := metadata.ValueFromIncomingContext(ctx, AuthorizationHeader)
+	if len(values) == 0 {
+		return nil, status.Errorf(codes.PermissionDenied, "%s requires an API token", method)
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || token == "" {
+		return nil, status.Error(codes.PermissionDenied, "the authorization must be Bearer <token>")
+	}
+
+	known, err := a.check(token, method)
+	if err != nil {
+		return nil, err
+	}
+	return withTokenNamespaces(ctx, known), nil
+}
+
+// authorized requires the token of an endpoint of -http-addr to grant the scope of the RPC
+// it serves, sent as Authorization: Bearer <token>. The context of the request keeps the
+// namespaces the token is scoped to. Without an authenticator every request is served.
+func (a *Authenticator) authorized(method string, handler http.HandlerFunc) http.HandlerFunc {
+	if a == nil {
+		return handler
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			http.Error(w, "an API token is required", http.StatusUnauthorized)
+			return
+		}
+		known, err := a.check(token, method)
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+			return
+		}
+		handler(w, r.WithContext(withTokenNamespaces(r.Context(), known)))
+	}
+}
+
+// check authenticates the token and checks it grants the scope of the RPC
+func (a *Authenticator) check(token, method string) (APIToken, error) {
+	known, err := a.authenticate(token)
+	if err != nil {
+		return APIToken{}, status.Errorf(codes.PermissionDenied, "invalid API token: %v", err)
+	}
+	scope := methodScope(method)
+	if !slices.Contains(known.Scopes, scope) {
+		log.Printf("Denied %s to token %s, it lacks the %s scope", method, known.Name, scope)
+		return APIToken{}, status.Errorf(codes.PermissionDenied, "token %s lacks the %s scope of %s", known.Name, scope, method)
+	}
+	return known, nil
+}
+
+type (
+	tokenNamespacesKey struct{}
+	tokenTenantKey     struct{}
+)
+
+// withTokenNamespaces keeps the namespaces and the tenant the token is scoped to in the context
+func withTokenNamespaces(ctx context.Context, token APIToken) context.Context {
+	if token.Tenant != "" {
+		ctx = context.WithValue(ctx, tokenTenantKey{}, token.Tenant)
+	}
+	if len(token.Namespaces) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, tokenNamespacesKey{}, token.Namespaces)
+}
+
+// tokenTenant returns the tenant the token of the request is scoped to, empty when it acts
+// for every tenant
+func tokenTenant(ctx context.Context) string {
+	tenant, _ := ctx.Value(tokenTenantKey{}).(string)
+	return tenant
+}
+
+// checkRequestTenant scopes a request to the tenant of its token: the tenant fields of the
+// request and of the messages it holds, like the spec of an update, default to it and may
+// not name another tenant, whose image policy, security defaults and domains would apply
+func checkRequestTenant(ctx context.Context, req any) error {
+	tenant := tokenTenant(ctx)
+	message, ok := req.(proto.Message)
+	if tenant == "" || !ok {
+		return nil
+	}
+	return scopeTenant(message.ProtoReflect(), tenant)
+}
+
+func scopeTenant(message protoreflect.Message, tenant string) error {
+	fields := message.Descriptor().Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		switch {
+		case field.IsMap():
+		case field.Name() == "tenant" && field.Kind() == protoreflect.StringKind && !field.IsList():
+			switch value := message.Get(field).String(); value {
+			case tenant:
+			case "":
+				message.Set(field, protoreflect.ValueOfString(tenant))
+			default:
+				return status.Errorf(codes.PermissionDenied, "the token of tenant %s cannot act for tenant %s", tenant, value)
+			}
+		case field.Kind() != protoreflect.MessageKind || !message.Has(field):
+		case field.IsList():
+			list := message.Get(field).List()
+			for j := range list.Len() {
+				if err := scopeTenant(list.Get(j).Message(), tenant); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := scopeTenant(message.Mutable(field).Message(), tenant); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// tokenNamespaces returns the namespaces the token of the request is scoped to, nil when
+// it sees every namespace
+func tokenNamespaces(ctx context.Context) []string {
+	namespaces, _ := ctx.Value(tokenNamespacesKey{}).([]string)
+	return namespaces
+}
+
+// methodScope returns the scope an RPC requires
+func methodScope(method string) string {
+	switch {
+	case strings.HasPrefix(method, "/"+pb.Admin_ServiceDesc.ServiceName+"/"):
+		return ScopeAdmin
+	case readMethods[method]:
+		return ScopeRead
+	case deleteMethods[method]:
+		return ScopeDelete
+	default:
+		return ScopeDeploy
+	}
+}
+
+// AuthInterceptor rejects the RPCs without an API token granting their scope with
+// PermissionDenied.
+func AuthInterceptor(auth *Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := auth.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor is AuthInterceptor for streaming RPCs
+func AuthStreamInterceptor(auth *Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := auth.authorize(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// contextStream is a server stream with the context of its interceptor
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// TokenCredentials sends an API token with every RPC of a client, also in plaintext to
+// controllers without TLS
+type TokenCredentials string
+
+func (t TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{AuthorizationHeader: "Bearer " + string(t)}, nil
+}
+
+func (t TokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
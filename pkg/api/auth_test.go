@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestServiceAccountTenantIsolation(t *testing.T) {
+	registry := store.NewMemoryStore()
+	for _, tenant := range []store.Tenant{
+		{Name: "tenant-a", Namespaces: []string{"tenant-a"}},
+		{Name: "tenant-b", Namespaces: []string{"tenant-b"}},
+	} {
+		if err := registry.CreateTenant(tenant); err != nil {
+			t.Fatal(err)
+		}
+		if err := registry.CreateNamespace(store.Namespace{Name: tenant.Name, Tenant: tenant.Name}); err != nil {
+			t.Fatal(err)
+		}
+		if err := registry.SaveServiceAccount(store.ServiceAccount{Tenant: tenant.Name, Name: "ci", TokenHash: TokenHash(tenant.Name + "-token")}); err != nil {
+			t.Fatal(err)
+		}
+		if err := registry.SaveJobName(store.JobName{JobID: tenant.Name + "-web", Application: "web", Tenant: tenant.Name, Namespace: tenant.Name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	auth := NewAuthenticator(nil, registry)
+
+	tests := []struct {
+		name     string
+		method   string
+		req      any
+		wantCode codes.Code
+	}{
+		{
+			name:   "own application",
+			method: pb.ControlPlane_DeleteApplication_FullMethodName,
+			req:    &pb.DeleteRequest{DeploymentId: "tenant-a-web", Namespace: "tenant-a"},
+		},
+		{
+			name:     "other tenant's application",
+			method:   pb.ControlPlane_DeleteApplication_FullMethodName,
+			req:      &pb.DeleteRequest{DeploymentId: "tenant-b-web", Namespace: "tenant-b"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "other tenant's application by its default namespace",
+			method:   pb.ControlPlane_ScaleApplication_FullMethodName,
+			req:      &pb.ScaleRequest{DeploymentId: "tenant-b-web"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "other tenant's application from its own namespace",
+			method:   pb.ControlPlane_DeleteApplication_FullMethodName,
+			req:      &pb.DeleteRequest{DeploymentId: "tenant-b-web", Namespace: "tenant-a"},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthorizationHeader, "Bearer tenant-a-token"))
+			ctx, err := auth.authorize(ctx, tt.method)
+			if err != nil {
+				t.Fatalf("authorize: %v", err)
+			}
+			err = checkRequestNamespace(ctx, registry, tt.method, tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("got %v (%v), want %v", got, err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestServiceAccountOfTenantWithoutNamespaces(t *testing.T) {
+	registry := store.NewMemoryStore()
+	if err := registry.CreateTenant(store.Tenant{Name: "tenant-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.SaveServiceAccount(store.ServiceAccount{Tenant: "tenant-a", Name: "ci", TokenHash: TokenHash("token")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewAuthenticator(nil, registry).check("token", pb.ControlPlane_GetApplicationStatus_FullMethodName); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("got %v, want PermissionDenied", err)
+	}
+}
+
+func TestServiceAccountTenantScope(t *testing.T) {
+	ctx := withTokenNamespaces(context.Background(), APIToken{Name: "tenant-a/ci", Tenant: "tenant-a"})
+
+	tests := []struct {
+		name       string
+		req        proto.Message
+		wantTenant func(proto.Message) string
+		wantCode   codes.Code
+	}{
+		{
+			name:       "own tenant",
+			req:        &pb.DeployRequest{Name: "web", Tenant: "tenant-a"},
+			wantTenant: func(m proto.Message) string { return m.(*pb.DeployRequest).Tenant },
+		},
+		{
+			name:       "no tenant",
+			req:        &pb.DeployRequest{Name: "web"},
+			wantTenant: func(m proto.Message) string { return m.(*pb.DeployRequest).Tenant },
+		},
+		{
+			name:     "other tenant",
+			req:      &pb.DeployRequest{Name: "web", Tenant: "tenant-b"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:       "spec of an update",
+			req:        &pb.UpdateApplicationRequest{Name: "web", Spec: &pb.DeployRequest{Image: "web:2"}},
+			wantTenant: func(m proto.Message) string { return m.(*pb.UpdateApplicationRequest).Spec.Tenant },
+		},
+		{
+			name:     "other tenant in the spec of an update",
+			req:      &pb.UpdateApplicationRequest{Name: "web", Spec: &pb.DeployRequest{Tenant: "tenant-b"}},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRequestTenant(ctx, tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("got %v (%v), want %v", got, err, tt.wantCode)
+			}
+			if tt.wantTenant != nil {
+				if got := tt.wantTenant(tt.req); got != "tenant-a" {
+					t.Fatalf("got tenant %q, want tenant-a", got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/metrics"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// AutoscalePolicy scales the services deployed with an autoscaling spec on their metrics,
+// a nil policy rejects such specs
+type AutoscalePolicy struct {
+	Providers map[string]metrics.Provider // the external metrics by name, besides cpu and memory
+	Traefik   *metrics.TraefikRouters     // the rps metric, nil rejects specs scaling on it
+}
+
+// time between two scalings of an application when its spec sets none
+const defaultScaleCooldown = 5 * time.Minute
+
+// metrics within this fraction of their target do not scale the application
+const scalingTolerance = 0.1
+
+// the request rates of the rps metric are known from the second scrape of Traefik on
+var errNoRates = errors.New("no request rates yet")
+
+// autoscaleTracker remembers when the applications were last scaled, for their cooldown,
+// and the smoothed values of their metrics. It starts over when another replica leads.
+type autoscaleTracker struct {
+	mu          sync.Mutex
+	scaledAt    map[string]time.Time                      // job
+	smoothed    map[string]map[string]smoothedMetricValue // job to metric
+	predictions map[string]scalingPrediction              // job
+}
+
+type smoothedMetricValue struct {
+	value float64
+	at    time.Time
+}
+
+func (t *autoscaleTracker) lastScaled(jobID string) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.scaledAt[jobID]
+}
+
+func (t *autoscaleTracker) scaled(jobID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.scaledAt == nil {
+		t.scaledAt = make(map[string]time.Time)
+	}
+	t.scaledAt[jobID] = time.Now()
+}
+
+// smooth returns the exponential moving average of the metric over about the window, the
+// weight of the value grows with the time since the previous one
+func (t *autoscaleTracker) smooth(jobID string, metric *pb.ScalingMetric, value float64) float64 {
+	if metric.SmoothingSeconds <= 0 {
+		return value
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.smoothed == nil {
+		t.smoothed = make(map[string]map[string]smoothedMetricValue)
+	}
+	if t.smoothed[jobID] == nil {
+		t.smoothed[jobID] = make(map[string]smoothedMetricValue)
+	}
+
+	key := metric.Provider + " " + metric.Query
+	now := time.Now()
+	if previous, ok := t.smoothed[jobID][key]; ok {
+		weight := 1 - math.Exp(-now.Sub(previous.at).Seconds()/float64(metric.SmoothingSeconds))
+		value = previous.value + weight*(value-previous.value)
+	}
+	t.smoothed[jobID][key] = smoothedMetricValue{value: value, at: now}
+	return value
+}
+
+func (t *autoscaleTracker) forget(jobID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.scaledAt, jobID)
+	delete(t.smoothed, jobID)
+	delete(t.predictions, jobID)
+}
+
+// validateAutoscaling checks the autoscaling spec without knowing the providers of the
+// controller
+func validateAutoscaling(req *pb.DeployRequest) []error {
+	var errs []error
+	autoscaling := req.Autoscaling
+
+	if req.Type != pb.DeploymentType_DEPLOYMENT_TYPE_UNSPECIFIED && req.Type != pb.DeploymentType_DEPLOYMENT_TYPE_SERVICE {
+		errs = append(errs, fmt.Errorf("only services autoscale"))
+	}
+	if autoscaling.MinReplicas < 1 {
+		errs = append(errs, fmt.Errorf("autoscaling min replicas must be at least 1, idle timeout scales to zero"))
+	}
+	if autoscaling.MaxReplicas < autoscaling.MinReplicas {
+		errs = append(errs, fmt.Errorf("autoscaling max replicas cannot be below min replicas"))
+	}
+	if autoscaling.CooldownSeconds < 0 || autoscaling.ScaleDownCooldownSeconds < 0 {
+		errs = append(errs, fmt.Errorf("autoscaling cooldown cannot be negative"))
+	}
+	if len(autoscaling.Metrics) == 0 {
+		errs = append(errs, fmt.Errorf("autoscaling needs at least one metric"))
+	}
+
+	if autoscaling.Prediction != nil {
+		errs = append(errs, validatePrediction(autoscaling)...)
+	}
+
+	for _, metric := range autoscaling.Metrics {
+		switch {
+		case metric.Provider == "":
+			errs = append(errs, fmt.Errorf("autoscaling metric provider cannot be empty"))
+		case metric.Target <= 0:
+			errs = append(errs, fmt.Errorf("target of autoscaling metric %s must be positive", metric.Provider))
+		case metric.SmoothingSeconds < 0:
+			errs = append(errs, fmt.Errorf("smoothing of autoscaling metric %s cannot be negative", metric.Provider))
+		case metric.Provider == metrics.RPS:
+			if req.Traefik == nil || !req.Traefik.Enable || req.Traefik.Host == "" {
+				errs = append(errs, fmt.Errorf("autoscaling metric %s counts the requests of the Traefik host, enable Traefik with a host", metric.Provider))
+			}
+			if metric.Query != "" {
+				errs = append(errs, fmt.Errorf("autoscaling metric %s takes no query", metric.Provider))
+			}
+		case metric.Provider == metrics.CPU || metric.Provider == metrics.Memory:
+			if metric.Target > 100 {
+				errs = append(errs, fmt.Errorf("target of autoscaling metric %s is a percent of the reservation, at most 100", metric.Provider))
+			}
+			if metric.Query != "" {
+				errs = append(errs, fmt.Errorf("autoscaling metric %s takes no query", metric.Provider))
+			}
+		case metric.Query == "":
+			errs = append(errs, fmt.Errorf("autoscaling metric %s needs a query", metric.Provider))
+		}
+	}
+	return errs
+}
+
+// applyAutoscaling checks the metrics of the spec name providers of the controller. A
+// service deployed again keeps the count the autoscaler reached, within the new bounds.
+func (s *ApplicationService) applyAutoscaling(jobID string, req *pb.DeployRequest, jobTemplate *nomad.JobTemplate) error {
+	autoscaling := req.Autoscaling
+	if autoscaling == nil {
+		return nil
+	}
+	if s.autoscale == nil {
+		return fmt.Errorf("autoscaling is disabled, start the controller with -autoscaling")
+	}
+	if autoscaling.Prediction != nil && s.recommendations == nil {
+		return fmt.Errorf("prediction forecasts from the usage history, start the controller with -usage-sampling")
+	}
+	for _, metric := range autoscaling.Metrics {
+		switch {
+		case metric.Provider == metrics.RPS && s.autoscale.Traefik == nil:
+			return fmt.Errorf("the rps metric is disabled, start the controller with -traefik-metrics-url")
+		case metric.Provider != metrics.CPU && metric.Provider != metrics.Memory && metric.Provider != metrics.RPS && s.autoscale.Providers[metric.Provider] == nil:
+			return fmt.Errorf("unknown metric provider %s, the controller has cpu, memory, rps and %v", metric.Provider, slices.Sorted(maps.Keys(s.autoscale.Providers)))
+		}
+	}
+
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return err
+	}
+	job, _, err := client.GetJobStatus(jobID)
+	if err != nil || job.Meta[nomad.MetaAutoscaling] == "" || len(job.TaskGroups) != 1 || job.TaskGroups[0].Count == nil {
+		return nil
+	}
+	jobTemplate.Instances = clampCount(*job.TaskGroups[0].Count, autoscaling)
+	return nil
+}
+
+// RunAutoscaling scales the services with an autoscaling spec to the count their metrics
+// need every interval until the context is cancelled. With a replicated registry only the
+// leader scales them.
+func (s *ApplicationService) RunAutoscaling(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopAutoscaling, interval, func() error { return s.autoscaleApplications(ctx) })
+		if err != nil {
+			log.Printf("Autoscaling: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) autoscaleApplications(ctx context.Context) error {
+	jobIDs, err := s.applicationJobs()
+	if err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	// one scrape of Traefik serves every application scaling on rps
+	var rates map[string]float64
+	ratesErr := errNoRates
+	if s.autoscale.Traefik != nil {
+		rates, ratesErr = s.autoscale.Traefik.Rates(ctx)
+		if ratesErr == nil && rates == nil {
+			ratesErr = errNoRates
+		}
+	}
+
+	s.reconciler.queue(loopAutoscaling, len(jobIDs))
+	for _, jobID := range jobIDs {
+		s.reconciler.next(loopAutoscaling)
+		err := s.autoscaleApplication(ctx, jobID, rates, ratesErr)
+		if err != nil {
+			log.Printf("Autoscaling: %s: %v", jobID, err)
+		}
+		s.reconciler.result(loopAutoscaling, jobID, err)
+	}
+	return nil
+}
+
+// autoscaleApplication scales the service to the highest count one of its metrics needs.
+// A metric failing to be read leaves the count as it is. The metrics are read during the
+// cooldown too, so their smoothed values are current once it ended.
+func (s *ApplicationService) autoscaleApplication(ctx context.Context, jobID string, rates map[string]float64, ratesErr error) error {
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return err
+	}
+	job, allocations, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return err
+	}
+	if job.Meta[nomad.MetaAutoscaling] == "" || len(job.TaskGroups) != 1 {
+		return nil
+	}
+	autoscaling := &pb.Autoscaling{}
+	if err := protojson.Unmarshal([]byte(job.Meta[nomad.MetaAutoscaling]), autoscaling); err != nil {
+		return fmt.Errorf("invalid autoscaling meta: %w", err)
+	}
+
+	group := job.TaskGroups[0]
+	current := 0
+	if group.Count != nil {
+		current = *group.Count
+	}
+	// scaled to zero while idle, the first request wakes it
+	if current == 0 {
+		return nil
+	}
+
+	desired, usage := 0.0, -1.0
+	var readings []string
+	for _, metric := range autoscaling.Metrics {
+		value, err := s.metricValue(ctx, client, job, jobID, allocations, metric, rates, ratesErr)
+		if errors.Is(err, errNoRates) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("metric %s: %w", metric.Provider, err)
+		}
+		if metric.Provider == metrics.CPU {
+			usage = value
+		}
+		value = s.autoscaler.smooth(jobID, metric, value)
+
+		// the usage of cpu and memory falls as instances are added, an external metric
+		// needs one instance per target
+		needed := value / metric.Target
+		if metric.Provider == metrics.CPU || metric.Provider == metrics.Memory {
+			needed *= float64(current)
+		}
+		desired = max(desired, needed)
+		readings = append(readings, fmt.Sprintf("%s %.1f of %.1f", metric.Provider, value, metric.Target))
+	}
+
+	count := current
+	if math.Abs(desired/float64(current)-1) > scalingTolerance {
+		count = clampCount(int(math.Ceil(desired)), autoscaling)
+	}
+	// a confident prediction keeps the instances of the coming peak
+	if autoscaling.Prediction != nil && usage >= 0 {
+		if predicted := s.predictedCount(jobID, job, autoscaling, usage, current); predicted > count {
+			count = clampCount(predicted, autoscaling)
+			readings = append(readings, fmt.Sprintf("%s prediction %d", autoscaling.Prediction.Season, predicted))
+		}
+	}
+	if count == current {
+		return nil
+	}
+	if time.Since(s.autoscaler.lastScaled(jobID)) < scaleCooldown(autoscaling, count < current) {
+		return nil
+	}
+
+	if _, err := client.ScaleJob(jobID, *group.Name, count); err != nil {
+		return fmt.Errorf("failed to scale from %d to %d: %w", current, count, err)
+	}
+	s.autoscaler.scaled(jobID)
+
+	message := fmt.Sprintf("autoscaled from %d to %d: %s", current, count, strings.Join(readings, ", "))
+	log.Printf("Autoscaling: %s %s", jobID, message)
+	instances := int32(count)
+	s.publish(events.ApplicationScaled, jobID, lifecycleEvent{
+		TaskGroup: *group.Name,
+		Count:     &instances,
+		Message:   message,
+	})
+	return nil
+}
+
+// metricValue reads the metric. The usage of cpu and memory is the mean percent of the
+// reservation over the running allocations, rps the requests per second of the job's
+// Traefik routers since the previous scrape.
+func (s *ApplicationService) metricValue(ctx context.Context, client *nomad.NomadClient, job *nmd.Job, jobID string, allocations []*nmd.AllocationListStub, metric *pb.ScalingMetric, rates map[string]float64, ratesErr error) (float64, error) {
+	switch metric.Provider {
+	case metrics.CPU, metrics.Memory:
+	case metrics.RPS:
+		if s.autoscale.Traefik == nil {
+			return 0, fmt.Errorf("the controller reads no Traefik metrics")
+		}
+		if ratesErr != nil {
+			return 0, ratesErr
+		}
+		return metrics.RouterRate(jobID, rates), nil
+	default:
+		provider := s.autoscale.Providers[metric.Provider]
+		if provider == nil {
+			return 0, fmt.Errorf("the controller has no metric provider %s", metric.Provider)
+		}
+		queryCtx, cancel := context.WithTimeout(ctx, resolveTimeout)
+		defer cancel()
+		return provider.Value(queryCtx, strings.ReplaceAll(metric.Query, "{app}", jobID))
+	}
+
+	task := applicationTask(job, jobID)
+	if task == nil || task.Resources == nil || task.Resources.CPU == nil || task.Resources.MemoryMB == nil {
+		return 0, fmt.Errorf("the job has no task %s with reserved resources", jobID)
+	}
+
+	total, measured := 0.0, 0
+	for _, alloc := range allocations {
+		if alloc.ClientStatus != "running" || alloc.TaskStates[jobID] == nil {
+			continue
+		}
+		cpu, memoryMB, err := client.TaskUsage(alloc.ID, jobID)
+		if err != nil {
+			// the allocation stopped since it was listed
+			continue
+		}
+		if metric.Provider == metrics.CPU {
+			total += cpu * 100 / float64(*task.Resources.CPU)
+		} else {
+			total += float64(memoryMB) * 100 / float64(*task.Resources.MemoryMB)
+		}
+		measured++
+	}
+	if measured == 0 {
+		return 0, fmt.Errorf("no running allocation to measure")
+	}
+	return total / float64(measured), nil
+}
+
+// scaleCooldown is the time since the last scaling the application waits before scaling
+// out, or in
+func scaleCooldown(autoscaling *pb.Autoscaling, scaleIn bool) time.Duration {
+	cooldown := defaultScaleCooldown
+	if autoscaling.CooldownSeconds > 0 {
+		cooldown = time.Duration(autoscaling.CooldownSeconds) * time.Second
+	}
+	if scaleIn && autoscaling.ScaleDownCooldownSeconds > 0 {
+		cooldown = time.Duration(autoscaling.ScaleDownCooldownSeconds) * time.Second
+	}
+	return cooldown
+}
+
+// clampCount keeps the count between the min and max replicas of the spec
+func clampCount(count int, autoscaling *pb.Autoscaling) int {
+	return min(max(count, int(autoscaling.MinReplicas)), int(autoscaling.MaxReplicas))
+}
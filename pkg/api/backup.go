@@ -0,0 +1,312 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// Snapshot states recorded in the registry
+const (
+	snapshotRunning  = "running"
+	snapshotComplete = "complete"
+	snapshotFailed   = "failed"
+)
+
+// backups running longer are recorded as failed
+const backupTimeout = 6 * time.Hour
+
+// backupFromSpec returns the backup of the application's volumes, nil without a backup config
+func backupFromSpec(req *pb.DeployRequest) *nomad.Backup {
+	if req.Backup == nil {
+		return nil
+	}
+
+	return &nomad.Backup{
+		Application: req.Name,
+		Region:      req.Region,
+		Volumes:     volumeMountsFromSpec(req),
+		Destination: req.Backup.Destination,
+		Schedule:    req.Backup.Schedule,
+		TimeZone:    req.Backup.TimeZone,
+		Image:       req.Backup.Image,
+		Environment: req.Backup.Env,
+	}
+}
+
+// syncBackupJobs registers the backup and restore jobs of the application, or removes
+// them once the backup config is dropped from the spec
+func (s *ApplicationService) syncBackupJobs(req *pb.DeployRequest) error {
+	backup := backupFromSpec(req)
+	if backup == nil {
+		if _, _, err := s.orhClient.GetJobStatus(nomad.BackupJobID(req.Name)); err == nil {
+			s.removeBackupJobs(req.Name)
+		}
+		return nil
+	}
+
+	client, err := s.orhClient.InNamespace(req.Namespace)
+	if err != nil {
+		return err
+	}
+	for _, job := range []*nomad.JobTemplate{backup.BackupJob(), backup.RestoreJob()} {
+		if _, err := client.DeployJob(job); err != nil {
+			return fmt.Errorf("%s: %w", job.Name, err)
+		}
+	}
+	return nil
+}
+
+// removeBackupJobs stops the backup schedule, the snapshots are kept
+func (s *ApplicationService) removeBackupJobs(application string) {
+	for _, jobID := range []string{nomad.BackupJobID(application), nomad.RestoreJobID(application)} {
+		if err := s.orhClient.DeleteJob(jobID); err != nil {
+			log.Printf("Failed to delete %s: %v", jobID, err)
+		}
+	}
+}
+
+// RunBackups dispatches the scheduled backups until the context is cancelled. With a
+// replicated registry only the leader dispatches them.
+func (s *ApplicationService) RunBackups(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	started := time.Now()
+	for {
+		err := s.reconcile(loopBackups, interval, func() error { return s.dispatchDueBackups(started) })
+		if err != nil {
+			log.Printf("Backup scheduler: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) dispatchDueBackups(started time.Time) error {
+	jobs, err := s.orhClient.ListJobs()
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	now := time.Now()
+	s.reconciler.queue(loopBackups, len(jobs))
+	for _, job := range jobs {
+		s.reconciler.next(loopBackups)
+		schedule := job.Meta[nomad.MetaBackupSchedule]
+		if schedule == "" || job.ParentID != "" || job.Stop {
+			continue
+		}
+		application := job.Meta[nomad.MetaBackupOf]
+
+		// the registry is shared by the replicas, a new leader continues the schedule
+		last := started
+		if snapshots, err := s.registry.Snapshots(application); err == nil && len(snapshots) > 0 && snapshots[0].StartedAt.After(last) {
+			last = snapshots[0].StartedAt
+		}
+
+		periodic := &nomad.Periodic{Schedule: schedule, TimeZone: job.Meta[nomad.MetaBackupTimeZone]}
+		next, err := periodic.Next(last)
+		if err != nil || next.IsZero() || next.After(now) {
+			continue
+		}
+
+		_, err = s.startBackup(application, job.Meta[nomad.MetaBackupDestination])
+		if err != nil {
+			log.Printf("Backup scheduler: failed to back up %s: %v", application, err)
+		}
+		s.reconciler.result(loopBackups, application, err)
+	}
+
+	return nil
+}
+
+// startBackup dispatches the backup job and records the snapshot, its outcome is
+// recorded once the job finished
+func (s *ApplicationService) startBackup(application, destination string) (store.Snapshot, error) {
+	now := time.Now().UTC()
+	id := now.Format("20060102-150405")
+
+	dispatch, err := s.orhClient.DispatchJob(nomad.BackupJobID(application), nil, map[string]string{nomad.MetaSnapshot: id})
+	if err != nil {
+		return store.Snapshot{}, err
+	}
+
+	snapshot := store.Snapshot{
+		ID:          id,
+		Application: application,
+		Location:    nomad.SnapshotLocation(destination, application, id),
+		Status:      snapshotRunning,
+		JobID:       dispatch.DispatchedJobID,
+		StartedAt:   now,
+	}
+	if err := s.registry.SaveSnapshot(snapshot); err != nil {
+		return snapshot, err
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), backupTimeout)
+		defer cancel()
+
+		snapshot.Status = snapshotFailed
+		alloc, err := s.orhClient.WaitForCompletion(ctx, snapshot.JobID)
+		if err != nil {
+			log.Printf("Backup %s of %s did not finish: %v", snapshot.ID, application, err)
+		} else if alloc.ClientStatus == nmd.AllocClientStatusComplete && exitCode(alloc, nomad.BackupJobID(application)) == 0 {
+			snapshot.Status = snapshotComplete
+		}
+		snapshot.FinishedAt = time.Now().UTC()
+
+		if err := s.registry.SaveSnapshot(snapshot); err != nil {
+			log.Printf("Failed to record backup %s of %s: %v", snapshot.ID, application, err)
+		}
+	}()
+
+	return snapshot, nil
+}
+
+// BackupApplication backs up the volumes of an application outside of its schedule
+func (s *ApplicationService) BackupApplication(ctx context.Context, req *pb.BackupRequest) (*pb.BackupResponse, error) {
+	job, _, err := s.orhClient.GetJobStatus(nomad.BackupJobID(req.Name))
+	if err != nil {
+		return &pb.BackupResponse{
+			Success: false,
+			Message: fmt.Sprintf("%s has no backup configured: %v", req.Name, err),
+		}, nil
+	}
+
+	snapshot, err := s.startBackup(req.Name, job.Meta[nomad.MetaBackupDestination])
+	if err != nil {
+		return &pb.BackupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to back up application: %v", err),
+		}, nil
+	}
+
+	return &pb.BackupResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("Backup %s started", snapshot.ID),
+		Snapshot: toSnapshot(snapshot),
+	}, nil
+}
+
+// ListSnapshots lists the backups of an application, most recent first
+func (s *ApplicationService) ListSnapshots(ctx context.Context, req *pb.ListSnapshotsRequest) (*pb.ListSnapshotsResponse, error) {
+	snapshots, err := s.registry.Snapshots(req.Name)
+	if err != nil {
+		return &pb.ListSnapshotsResponse{
+			Message: fmt.Sprintf("Failed to list snapshots: %v", err),
+		}, nil
+	}
+
+	resp := &pb.ListSnapshotsResponse{
+		Message: fmt.Sprintf("Found %d snapshots", len(snapshots)),
+	}
+	for _, snapshot := range snapshots {
+		resp.Snapshots = append(resp.Snapshots, toSnapshot(snapshot))
+	}
+	return resp, nil
+}
+
+// RestoreVolume overwrites the volumes of a stopped application with a snapshot and
+// waits for the restore to finish
+func (s *ApplicationService) RestoreVolume(ctx context.Context, req *pb.RestoreVolumeRequest) (*pb.RestoreVolumeResponse, error) {
+	_, allocations, err := s.orhClient.GetJobStatus(req.Name)
+	if err != nil {
+		return &pb.RestoreVolumeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get application: %v", err),
+		}, nil
+	}
+	for _, alloc := range allocations {
+		if alloc.DesiredStatus == "run" && (alloc.ClientStatus == "pending" || alloc.ClientStatus == "running") {
+			return &pb.RestoreVolumeResponse{
+				Success: false,
+				Message: fmt.Sprintf("%s is running, stop it or scale it to zero before restoring its volumes", req.Name),
+			}, nil
+		}
+	}
+
+	snapshot, err := s.findSnapshot(req.Name, req.SnapshotId)
+	if err != nil {
+		return &pb.RestoreVolumeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to restore volumes: %v", err),
+		}, nil
+	}
+
+	dispatch, err := s.orhClient.DispatchJob(nomad.RestoreJobID(req.Name), nil, map[string]string{nomad.MetaSnapshot: snapshot.ID})
+	if err != nil {
+		return &pb.RestoreVolumeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to restore volumes: %v", err),
+		}, nil
+	}
+
+	resp := &pb.RestoreVolumeResponse{
+		SnapshotId: snapshot.ID,
+		JobId:      dispatch.DispatchedJobID,
+	}
+
+	alloc, err := s.orhClient.WaitForCompletion(ctx, dispatch.DispatchedJobID)
+	switch {
+	case err != nil:
+		resp.Message = fmt.Sprintf("Restore of snapshot %s is still running: %v", snapshot.ID, err)
+	case alloc.ClientStatus != nmd.AllocClientStatusComplete || exitCode(alloc, nomad.RestoreJobID(req.Name)) != 0:
+		resp.Message = fmt.Sprintf("Restore of snapshot %s %s, see the logs of %s", snapshot.ID, alloc.ClientStatus, dispatch.DispatchedJobID)
+	default:
+		resp.Success = true
+		resp.Message = fmt.Sprintf("Volumes of %s restored from snapshot %s", req.Name, snapshot.ID)
+	}
+	return resp, nil
+}
+
+// findSnapshot returns the snapshot with the ID, or the latest complete one for an empty ID
+func (s *ApplicationService) findSnapshot(application, id string) (store.Snapshot, error) {
+	snapshots, err := s.registry.Snapshots(application)
+	if err != nil {
+		return store.Snapshot{}, err
+	}
+
+	for _, snapshot := range snapshots {
+		if id == "" && snapshot.Status == snapshotComplete {
+			return snapshot, nil
+		}
+		if id != "" && snapshot.ID == id {
+			if snapshot.Status != snapshotComplete {
+				return store.Snapshot{}, fmt.Errorf("snapshot %s is %s", id, snapshot.Status)
+			}
+			return snapshot, nil
+		}
+	}
+
+	if id == "" {
+		return store.Snapshot{}, fmt.Errorf("%s has no complete snapshot", application)
+	}
+	return store.Snapshot{}, fmt.Errorf("snapshot %s: %w", id, store.ErrNotFound)
+}
+
+func toSnapshot(snapshot store.Snapshot) *pb.Snapshot {
+	result := &pb.Snapshot{
+		Id:          snapshot.ID,
+		Application: snapshot.Application,
+		Location:    snapshot.Location,
+		Status:      snapshot.Status,
+		JobId:       snapshot.JobID,
+		StartedAt:   snapshot.StartedAt.Unix(),
+	}
+	if !snapshot.FinishedAt.IsZero() {
+		result.FinishedAt = snapshot.FinishedAt.Unix()
+	}
+	return result
+}
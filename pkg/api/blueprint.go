@@ -0,0 +1,300 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+const (
+	policyPropose = "propose"
+	policyAuto    = "auto"
+)
+
+// PublishBlueprint publishes a new version of a blueprint on a channel and rolls it
+// out to the applications following that channel according to their update policy.
+func (s *ApplicationService) PublishBlueprint(ctx context.Context, req *pb.PublishBlueprintRequest) (*pb.PublishBlueprintResponse, error) {
+	if req.Blueprint == "" || req.Channel == "" || req.Spec == nil {
+		return &pb.PublishBlueprintResponse{
+			Success: false,
+			Message: "blueprint, channel and spec are required",
+		}, nil
+	}
+
+	spec, err := proto.Marshal(req.Spec)
+	if err != nil {
+		return &pb.PublishBlueprintResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid blueprint spec: %v", err),
+		}, nil
+	}
+
+	spec, err = sealForTenant(ctx, s.registry, s.sealer, req.Tenant, spec)
+	if err != nil {
+		return &pb.PublishBlueprintResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to publish blueprint: %v", err),
+		}, nil
+	}
+
+	version, err := s.registry.PublishBlueprint(req.Tenant, req.Blueprint, req.Channel, spec)
+	if err != nil {
+		return &pb.PublishBlueprintResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to publish blueprint: %v", err),
+		}, nil
+	}
+
+	resp := &pb.PublishBlueprintResponse{
+		Success: true,
+		Version: int32(version.Version),
+	}
+
+	subscriptions, err := s.registry.Subscriptions(req.Blueprint)
+	if err != nil {
+		log.Printf("Failed to load subscriptions of %s: %v", req.Blueprint, err)
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription.Channel != req.Channel || subscription.PinnedVersion != 0 {
+			continue
+		}
+
+		if subscription.Policy != policyAuto {
+			resp.ProposedApplications = append(resp.ProposedApplications, subscription.Application)
+			continue
+		}
+
+		if _, err := s.applyBlueprint(ctx, subscription, version); err != nil {
+			log.Printf("Failed to update %s to %s v%d: %v", subscription.Application, req.Blueprint, version.Version, err)
+			resp.ProposedApplications = append(resp.ProposedApplications, subscription.Application)
+			continue
+		}
+		resp.UpdatedApplications = append(resp.UpdatedApplications, subscription.Application)
+	}
+
+	resp.Message = fmt.Sprintf("Published %s v%d on %s: %d applications updated, %d updates proposed",
+		req.Blueprint, version.Version, req.Channel, len(resp.UpdatedApplications), len(resp.ProposedApplications))
+
+	return resp, nil
+}
+
+// SubscribeApplication deploys an application from a blueprint channel and keeps
+// following that channel.
+func (s *ApplicationService) SubscribeApplication(ctx context.Context, req *pb.SubscribeRequest) (*pb.SubscribeResponse, error) {
+	if req.Application == "" || req.Blueprint == "" || req.Channel == "" {
+		return &pb.SubscribeResponse{
+			Success: false,
+			Message: "application, blueprint and channel are required",
+		}, nil
+	}
+
+	if req.Tenant != "" {
+		if _, err := s.registry.Tenant(req.Tenant); err != nil {
+			return &pb.SubscribeResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to find tenant: %v", err),
+			}, nil
+		}
+	}
+
+	version, err := s.registry.BlueprintVersion(req.Blueprint, req.Channel, int(req.PinnedVersion))
+	if err != nil {
+		return &pb.SubscribeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to find blueprint: %v", err),
+		}, nil
+	}
+
+	if version.Tenant != "" && version.Tenant != req.Tenant {
+		return &pb.SubscribeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Blueprint %s belongs to another tenant", req.Blueprint),
+		}, nil
+	}
+
+	subscription := store.Subscription{
+		Tenant:        req.Tenant,
+		Application:   req.Application,
+		Blueprint:     req.Blueprint,
+		Channel:       req.Channel,
+		Policy:        policyPropose,
+		PinnedVersion: int(req.PinnedVersion),
+	}
+	if req.Policy == pb.UpdatePolicy_UPDATE_POLICY_AUTO {
+		subscription.Policy = policyAuto
+	}
+	if req.Overrides != nil {
+		overrides, err := proto.Marshal(req.Overrides)
+		if err != nil {
+			return &pb.SubscribeResponse{
+				Success: false,
+				Message: fmt.Sprintf("Invalid overrides: %v", err),
+			}, nil
+		}
+		if subscription.Overrides, err = sealForTenant(ctx, s.registry, s.sealer, req.Tenant, overrides); err != nil {
+			return &pb.SubscribeResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to subscribe application: %v", err),
+			}, nil
+		}
+	}
+
+	deployment, err := s.applyBlueprint(ctx, subscription, version)
+	if err != nil {
+		return &pb.SubscribeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to deploy application: %v", err),
+		}, nil
+	}
+
+	return &pb.SubscribeResponse{
+		Success:      true,
+		Message:      fmt.Sprintf("%s deployed from %s v%d and following %s", req.Application, req.Blueprint, version.Version, req.Channel),
+		Version:      int32(version.Version),
+		DeploymentId: deployment.DeploymentId,
+	}, nil
+}
+
+// ListSubscriptions reports which applications run an older version than their channel offers.
+func (s *ApplicationService) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	subscriptions, err := s.registry.Subscriptions(req.Blueprint)
+	if err != nil {
+		return &pb.ListSubscriptionsResponse{
+			Message: fmt.Sprintf("Failed to list subscriptions: %v", err),
+		}, nil
+	}
+
+	resp := &pb.ListSubscriptionsResponse{
+		Message: "Subscriptions retrieved successfully",
+	}
+
+	for _, subscription := range subscriptions {
+		latest := 0
+		if head, err := s.registry.BlueprintVersion(subscription.Blueprint, subscription.Channel, 0); err == nil {
+			latest = head.Version
+		}
+
+		target := latest
+		if subscription.PinnedVersion != 0 {
+			target = subscription.PinnedVersion
+		}
+
+		behind := subscription.DeployedVersion != target
+		if req.BehindOnly && !behind {
+			continue
+		}
+
+		policy := pb.UpdatePolicy_UPDATE_POLICY_PROPOSE
+		if subscription.Policy == policyAuto {
+			policy = pb.UpdatePolicy_UPDATE_POLICY_AUTO
+		}
+
+		resp.Subscriptions = append(resp.Subscriptions, &pb.Subscription{
+			Tenant:          subscription.Tenant,
+			Application:     subscription.Application,
+			Blueprint:       subscription.Blueprint,
+			Channel:         subscription.Channel,
+			Policy:          policy,
+			PinnedVersion:   int32(subscription.PinnedVersion),
+			DeployedVersion: int32(subscription.DeployedVersion),
+			LatestVersion:   int32(latest),
+			Behind:          behind,
+		})
+	}
+
+	return resp, nil
+}
+
+// ApplyBlueprintUpdate deploys a proposed blueprint update to an application.
+func (s *ApplicationService) ApplyBlueprintUpdate(ctx context.Context, req *pb.ApplyBlueprintUpdateRequest) (*pb.ApplyBlueprintUpdateResponse, error) {
+	subscription, err := s.registry.Subscription(req.Application)
+	if err != nil {
+		message := fmt.Sprintf("Failed to find subscription: %v", err)
+		if errors.Is(err, store.ErrNotFound) {
+			message = fmt.Sprintf("%s is not subscribed to a blueprint", req.Application)
+		}
+		return &pb.ApplyBlueprintUpdateResponse{
+			Success: false,
+			Message: message,
+		}, nil
+	}
+
+	versionNumber := int(req.Version)
+	if versionNumber == 0 {
+		versionNumber = subscription.PinnedVersion
+	}
+
+	version, err := s.registry.BlueprintVersion(subscription.Blueprint, subscription.Channel, versionNumber)
+	if err != nil {
+		return &pb.ApplyBlueprintUpdateResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to find blueprint version: %v", err),
+		}, nil
+	}
+
+	deployment, err := s.applyBlueprint(ctx, subscription, version)
+	if err != nil {
+		return &pb.ApplyBlueprintUpdateResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to deploy application: %v", err),
+		}, nil
+	}
+
+	return &pb.ApplyBlueprintUpdateResponse{
+		Success:      true,
+		Message:      fmt.Sprintf("%s updated to %s v%d", req.Application, subscription.Blueprint, version.Version),
+		Version:      int32(version.Version),
+		DeploymentId: deployment.DeploymentId,
+	}, nil
+}
+
+// applyBlueprint deploys the blueprint version with the application's overrides
+// merged over it and records the deployed version on the subscription.
+func (s *ApplicationService) applyBlueprint(ctx context.Context, subscription store.Subscription, version store.BlueprintVersion) (*pb.DeployResponse, error) {
+	data, err := openForTenant(ctx, s.registry, s.sealer, version.Tenant, version.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &pb.DeployRequest{}
+	if err := proto.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("invalid blueprint spec: %w", err)
+	}
+
+	if len(subscription.Overrides) > 0 {
+		data, err := openForTenant(ctx, s.registry, s.sealer, subscription.Tenant, subscription.Overrides)
+		if err != nil {
+			return nil, err
+		}
+
+		overrides := &pb.DeployRequest{}
+		if err := proto.Unmarshal(data, overrides); err != nil {
+			return nil, fmt.Errorf("invalid overrides: %w", err)
+		}
+		proto.Merge(spec, overrides)
+	}
+	spec.Name = subscription.Application
+	spec.Tenant = subscription.Tenant
+
+	resp, err := s.DeployApplication(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status == "FAILED" {
+		return nil, errors.New(resp.Message)
+	}
+
+	subscription.DeployedVersion = version.Version
+	if err := s.registry.SaveSubscription(subscription); err != nil {
+		return nil, fmt.Errorf("deployed but failed to record the subscription: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// Statuses of a bootstrap step
+const (
+	bootstrapCreated = "created"
+	bootstrapExists  = "exists"
+	bootstrapUpdated = "updated"
+	bootstrapSkipped = "skipped"
+	bootstrapFailed  = "failed"
+)
+
+// BootstrapPlatform provisions what the control plane expects in a fresh cluster: the
+// namespaces, the node pools of its conventions, the edge proxy and the default deny
+// intention. Existing resources are kept, so it can be called again after a partial run.
+func (s *AdminService) BootstrapPlatform(ctx context.Context, req *pb.BootstrapPlatformRequest) (*pb.BootstrapPlatformResponse, error) {
+	for _, namespace := range req.Namespaces {
+		if !tenantName.MatchString(namespace) {
+			return &pb.BootstrapPlatformResponse{
+				Message: fmt.Sprintf("Invalid bootstrap request: namespace %q must be a lowercase DNS label", namespace),
+			}, nil
+		}
+	}
+
+	var steps []*pb.BootstrapStep
+
+	for _, namespace := range req.Namespaces {
+		// like the namespaces of CreateNamespace, the registry records them along with Nomad
+		steps = append(steps, ensure("namespace", namespace,
+			func() (bool, error) {
+				if namespace == nomad.DefaultNamespace {
+					return true, nil
+				}
+				if _, err := s.registry.Namespace(namespace); errors.Is(err, store.ErrNotFound) {
+					return false, nil
+				} else if err != nil {
+					return false, err
+				}
+				return s.orhClient.NamespaceExists(namespace)
+			},
+			func() error {
+				exists, err := s.orhClient.NamespaceExists(namespace)
+				if err == nil && !exists {
+					err = s.orhClient.CreateNamespace(namespace, "Created by the control plane bootstrap", nil)
+				}
+				if err != nil {
+					return err
+				}
+				err = s.registry.CreateNamespace(store.Namespace{Name: namespace, Description: "Created by the control plane bootstrap", CreatedAt: time.Now()})
+				if errors.Is(err, store.ErrAlreadyExists) {
+					return nil
+				}
+				return err
+			},
+		))
+	}
+
+	pools := maps.Clone(nomad.NodePools)
+	for _, pool := range req.NodePools {
+		if _, ok := pools[pool]; !ok {
+			pools[pool] = "Created by the control plane bootstrap"
+		}
+	}
+	for _, pool := range slices.Sorted(maps.Keys(pools)) {
+		if err := s.orhClient.Capabilities().CheckNodePool(pool); err != nil {
+			steps = append(steps, &pb.BootstrapStep{Resource: "node pool", Name: pool, Status: bootstrapSkipped, Message: err.Error()})
+			continue
+		}
+		steps = append(steps, ensure("node pool", pool,
+			func() (bool, error) { return s.orhClient.NodePoolExists(pool) },
+			func() error { return s.orhClient.CreateNodePool(pool, pools[pool], nil) },
+		))
+	}
+
+	steps = append(steps, s.bootstrapEdgeProxy(req))
+	steps = append(steps, s.bootstrapDefaultDeny(req.DefaultDeny))
+
+	failed := 0
+	for _, step := range steps {
+		if step.Status == bootstrapFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return &pb.BootstrapPlatformResponse{
+			Steps:   steps,
+			Message: fmt.Sprintf("Bootstrap failed: %d of %d steps failed", failed, len(steps)),
+		}, nil
+	}
+
+	return &pb.BootstrapPlatformResponse{
+		Success: true,
+		Steps:   steps,
+		Message: fmt.Sprintf("Platform bootstrapped in %d steps", len(steps)),
+	}, nil
+}
+
+// ensure creates a resource unless it exists
+func ensure(resource, name string, exists func() (bool, error), create func() error) *pb.BootstrapStep {
+	step := &pb.BootstrapStep{Resource: resource, Name: name}
+
+	found, err := exists()
+	switch {
+	case err != nil:
+		step.Status = bootstrapFailed
+		step.Message = fmt.Sprintf("failed to look up %s: %v", resource, err)
+	case found:
+		step.Status = bootstrapExists
+	default:
+		if err := create(); err != nil {
+			step.Status = bootstrapFailed
+			step.Message = fmt.Sprintf("failed to create %s: %v", resource, err)
+		} else {
+			step.Status = bootstrapCreated
+		}
+	}
+	return step
+}
+
+// bootstrapEdgeProxy registers the edge proxy, Nomad only creates a new version of the job
+// when its config changed
+func (s *AdminService) bootstrapEdgeProxy(req *pb.BootstrapPlatformRequest) *pb.BootstrapStep {
+	step := &pb.BootstrapStep{Resource: "job", Name: nomad.EdgeProxyJobID}
+	if req.SkipEdgeProxy {
+		step.Status = bootstrapSkipped
+		step.Message = "the cluster runs its own edge proxy"
+		return step
+	}
+
+	edgeReq := req.EdgeProxy
+	if edgeReq == nil {
+		edgeReq = &pb.BootstrapEdgeProxyRequest{}
+	}
+	proxy := s.edgeProxyFor(edgeReq)
+	job, err := proxy.Job()
+	if err == nil {
+		err = s.orhClient.Capabilities().CheckNodePool(proxy.NodePool)
+	}
+	if err != nil {
+		step.Status = bootstrapFailed
+		step.Message = fmt.Sprintf("invalid edge proxy config: %v", err)
+		return step
+	}
+
+	before, found, err := s.orhClient.JobVersion(nomad.EdgeProxyJobID)
+	if err != nil {
+		step.Status = bootstrapFailed
+		step.Message = fmt.Sprintf("failed to look up job: %v", err)
+		return step
+	}
+	registered, err := s.orhClient.RegisterJob(job)
+	if err != nil {
+		step.Status = bootstrapFailed
+		step.Message = fmt.Sprintf("failed to deploy edge proxy: %v", err)
+		return step
+	}
+
+	step.Message = "evaluation " + registered.EvalID
+	if !found {
+		step.Status = bootstrapCreated
+		return step
+	}
+
+	step.Status = bootstrapExists
+	if after, _, err := s.orhClient.JobVersion(nomad.EdgeProxyJobID); err == nil && after != before {
+		step.Status = bootstrapUpdated
+	}
+	return step
+}
+
+// bootstrapDefaultDeny creates the intention denying mesh traffic the applications' egress
+// rules do not allow
+func (s *AdminService) bootstrapDefaultDeny(enabled bool) *pb.BootstrapStep {
+	if !enabled {
+		return &pb.BootstrapStep{
+			Resource: "intention",
+			Name:     "* => *",
+			Status:   bootstrapSkipped,
+			Message:  "mesh traffic is allowed unless denied, request the default deny to enforce egress rules",
+		}
+	}
+
+	return ensure("intention", "* => *",
+		func() (bool, error) { return s.consul.IntentionExists("*", "*") },
+		func() error {
+			return s.consul.DenyIntention("*", "*", "Default deny, created by the control plane bootstrap")
+		},
+	)
+}
@@ -0,0 +1,320 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// Kinds of the operations on the calendar
+const (
+	operationCronRun         = "cron_run"
+	operationBackup          = "backup"
+	operationRolloutDeadline = "rollout_deadline"
+	operationPredictionEnd   = "prediction_end"
+	operationTokenExpiration = "token_expiration"
+	operationResourceUpdate  = "resource_update"
+	operationBlueprintUpdate = "blueprint_update"
+)
+
+const (
+	defaultCalendarHours = 24
+	maxCalendarHours     = 7 * 24
+	// runs of a schedule listed, a cron job running every minute would fill the calendar
+	maxCalendarRuns = 10
+)
+
+// calendar collects the operations of the window matching the filters of a request
+type calendar struct {
+	tenant      string
+	application string
+	namespace   string
+	scoped      bool // the token is scoped to namespaces, the operations of no job are left out
+	from, until time.Time
+	operations  []*pb.ScheduledOperation
+	pending     []*pb.ScheduledOperation
+}
+
+// includes reports whether the operations of the application are on the calendar, an empty
+// application names the tenant itself
+func (c *calendar) includes(name store.JobName) bool {
+	if c.tenant != "" && name.Tenant != c.tenant {
+		return false
+	}
+	if name.JobID == "" && c.scoped || name.JobID != "" && !inNamespace(name, c.namespace) {
+		return false
+	}
+	return c.application == "" || name.Application == c.application || name.JobID == c.application
+}
+
+// add puts an operation on the calendar when it falls in the window
+func (c *calendar) add(at time.Time, kind string, name store.JobName, description string) {
+	if !c.includes(name) || at.After(c.until) {
+		return
+	}
+	c.operations = append(c.operations, &pb.ScheduledOperation{
+		Time:        at.Unix(),
+		Kind:        kind,
+		Application: name.Application,
+		Tenant:      name.Tenant,
+		Description: description,
+	})
+}
+
+// propose puts an operation awaiting approval on the calendar, proposed at when known
+func (c *calendar) propose(at time.Time, kind string, name store.JobName, description string) {
+	if !c.includes(name) {
+		return
+	}
+	operation := &pb.ScheduledOperation{
+		Kind:        kind,
+		Application: name.Application,
+		Tenant:      name.Tenant,
+		Description: description,
+	}
+	if !at.IsZero() {
+		operation.Time = at.Unix()
+	}
+	c.pending = append(c.pending, operation)
+}
+
+// GetCalendar lists what the control plane and Nomad will do in the coming hours without
+// being asked: cron and backup runs, rollout deadlines, the end of predictive scaling and
+// the expiration of tenant tokens, and the updates awaiting approval.
+func (s *ApplicationService) GetCalendar(ctx context.Context, req *pb.GetCalendarRequest) (*pb.GetCalendarResponse, error) {
+	hours := req.Hours
+	if hours == 0 {
+		hours = defaultCalendarHours
+	}
+	if hours < 0 || hours > maxCalendarHours {
+		return &pb.GetCalendarResponse{
+			Message: fmt.Sprintf("hours must be between 1 and %d", maxCalendarHours),
+		}, nil
+	}
+
+	now := time.Now()
+	cal := &calendar{
+		tenant:      req.Tenant,
+		application: req.Application,
+		namespace:   req.Namespace,
+		scoped:      tokenNamespaces(ctx) != nil,
+		from:        now,
+		until:       now.Add(time.Duration(hours) * time.Hour),
+	}
+
+	jobs, err := s.orhClient.ListJobs()
+	if err != nil {
+		return &pb.GetCalendarResponse{
+			Message: fmt.Sprintf("Failed to list jobs: %v", err),
+		}, nil
+	}
+	for _, job := range jobs {
+		if job.Stop || job.ParentID != "" || job.Meta[nomad.MetaAddOnOf] != "" || job.Meta[nomad.MetaGreenOf] != "" {
+			continue
+		}
+		if application := job.Meta[nomad.MetaBackupOf]; application != "" {
+			if name := s.jobName(application); cal.includes(name) {
+				s.backupOperations(cal, job, name)
+			}
+			continue
+		}
+		if name := s.jobName(job.ID); cal.includes(name) {
+			if err := s.jobOperations(cal, job, name); err != nil {
+				log.Printf("Calendar: %s: %v", job.ID, err)
+			}
+		}
+	}
+
+	if err := s.approvalOperations(cal); err != nil {
+		return &pb.GetCalendarResponse{
+			Message: fmt.Sprintf("Failed to list the updates awaiting approval: %v", err),
+		}, nil
+	}
+
+	if cal.application == "" {
+		tenants, err := s.registry.Tenants()
+		if err != nil {
+			return &pb.GetCalendarResponse{
+				Message: fmt.Sprintf("Failed to list tenants: %v", err),
+			}, nil
+		}
+		for _, tenant := range tenants {
+			if access := tenant.NomadAccess; access != nil && access.ExpiresAt.After(now) {
+				cal.add(access.ExpiresAt, operationTokenExpiration, store.JobName{Tenant: tenant.Name},
+					fmt.Sprintf("Nomad token %s expires", access.AccessorID))
+			}
+		}
+	}
+
+	slices.SortStableFunc(cal.operations, func(a, b *pb.ScheduledOperation) int { return cmp.Compare(a.Time, b.Time) })
+	slices.SortStableFunc(cal.pending, func(a, b *pb.ScheduledOperation) int { return cmp.Compare(a.Time, b.Time) })
+
+	return &pb.GetCalendarResponse{
+		Success:          true,
+		Message:          fmt.Sprintf("%d operations in the next %d hours, %d awaiting approval", len(cal.operations), hours, len(cal.pending)),
+		Until:            cal.until.Unix(),
+		Operations:       cal.operations,
+		PendingApprovals: cal.pending,
+	}, nil
+}
+
+// jobOperations puts the cron runs, the rollout deadline and the end of the scaling
+// prediction of an application on the calendar
+func (s *ApplicationService) jobOperations(cal *calendar, stub *nmd.JobListStub, name store.JobName) error {
+	if stub.Periodic {
+		job, _, err := s.orhClient.GetJobStatus(stub.ID)
+		if err != nil {
+			return err
+		}
+		if periodic := job.Periodic; periodic != nil && periodic.Spec != nil && (periodic.Enabled == nil || *periodic.Enabled) {
+			schedule := &nomad.Periodic{Schedule: *periodic.Spec}
+			if periodic.TimeZone != nil {
+				schedule.TimeZone = *periodic.TimeZone
+			}
+			for _, at := range scheduledRuns(schedule, cal.from, cal.until) {
+				cal.add(at, operationCronRun, name, fmt.Sprintf("Nomad runs the job on its schedule %q", schedule.Schedule))
+			}
+		}
+	}
+
+	if deadline, err := time.ParseDuration(stub.Meta[nomad.MetaRolloutDeadline]); err == nil {
+		deployment, err := s.orhClient.LatestDeployment(stub.ID)
+		if err != nil {
+			return err
+		}
+		// a paused rollout waits for a promotion, it is not failed
+		if deployment != nil && (deployment.Status == nmd.DeploymentStatusPending || deployment.Status == nmd.DeploymentStatusRunning) {
+			description := fmt.Sprintf("the rollout of version %d fails unless it finished, its deadline is %s", deployment.JobVersion, deadline)
+			if revert, _ := strconv.ParseBool(stub.Meta[nomad.MetaRolloutRevert]); revert {
+				description += ", the job is then reverted"
+			}
+			at := time.Unix(0, deployment.CreateTime).Add(deadline)
+			if at.Before(cal.from) {
+				at = cal.from // failed by the next run of the deadline loop
+			}
+			cal.add(at, operationRolloutDeadline, name, description)
+		}
+	}
+
+	if prediction, ok := s.autoscaler.prediction(stub.ID); ok && prediction.Applied && prediction.Until.After(cal.from) {
+		cal.add(prediction.Until, operationPredictionEnd, name,
+			fmt.Sprintf("the %s prediction keeping %d instances ends, the metrics scale the application again", prediction.Season, prediction.Instances))
+	}
+
+	if proposal, err := s.registry.ResourceProposal(stub.ID); err == nil {
+		cal.propose(proposal.ProposedAt, operationResourceUpdate, name,
+			fmt.Sprintf("resources updated to %g CPU cores and %d MB of memory once the recommendation is applied", proposal.CPU, proposal.MemoryMB))
+	}
+	return nil
+}
+
+// backupOperations puts the scheduled backups of an application on the calendar, a backup
+// due already is dispatched by the next run of the backup loop
+func (s *ApplicationService) backupOperations(cal *calendar, job *nmd.JobListStub, name store.JobName) {
+	last := cal.from
+	if snapshots, err := s.registry.Snapshots(job.Meta[nomad.MetaBackupOf]); err == nil && len(snapshots) > 0 {
+		last = snapshots[0].StartedAt
+	}
+	schedule := &nomad.Periodic{Schedule: job.Meta[nomad.MetaBackupSchedule], TimeZone: job.Meta[nomad.MetaBackupTimeZone]}
+	runs := scheduledRuns(schedule, last, cal.until)
+	if len(runs) > 0 && runs[0].Before(cal.from) {
+		// the schedule continues from the overdue backup
+		runs = append([]time.Time{cal.from}, scheduledRuns(schedule, cal.from, cal.until)...)
+	}
+	for _, at := range runs {
+		cal.add(at, operationBackup, name,
+			fmt.Sprintf("backup to %s on its schedule %q", job.Meta[nomad.MetaBackupDestination], schedule.Schedule))
+	}
+}
+
+// approvalOperations puts the blueprint updates proposed to the subscribed applications on
+// the calendar, the resource updates are put by jobOperations
+func (s *ApplicationService) approvalOperations(cal *calendar) error {
+	subscriptions, err := s.registry.Subscriptions("")
+	if err != nil {
+		return err
+	}
+	for _, subscription := range subscriptions {
+		if subscription.Policy == policyAuto {
+			continue // deployed when the blueprint is published
+		}
+		target := subscription.PinnedVersion
+		if target == 0 {
+			head, err := s.registry.BlueprintVersion(subscription.Blueprint, subscription.Channel, 0)
+			if err != nil {
+				continue
+			}
+			target = head.Version
+		}
+		if subscription.DeployedVersion == target {
+			continue
+		}
+		name := store.JobName{Application: subscription.Application, Tenant: subscription.Tenant}
+		cal.propose(time.Time{}, operationBlueprintUpdate, name,
+			fmt.Sprintf("update from version %d to %d of blueprint %s once applied", subscription.DeployedVersion, target, subscription.Blueprint))
+	}
+	return nil
+}
+
+// scheduledRuns returns the runs of the schedule after from until the end of the window,
+// at most maxCalendarRuns of them
+func scheduledRuns(schedule *nomad.Periodic, from, until time.Time) []time.Time {
+	var runs []time.Time
+	for len(runs) < maxCalendarRuns {
+		next, err := schedule.Next(from)
+		if err != nil || next.IsZero() || next.After(until) {
+			break
+		}
+		runs = append(runs, next)
+		from = next
+	}
+	return runs
+}
+
+// calendarHandler serves GetCalendar as JSON, the query parameters are the fields of the
+// request
+func (s *ApplicationService) calendarHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &pb.GetCalendarRequest{
+		Tenant:      query.Get("tenant"),
+		Application: query.Get("application"),
+		Namespace:   query.Get("namespace"),
+	}
+	if hours := query.Get("hours"); hours != "" {
+		n, err := strconv.ParseInt(hours, 10, 32)
+		if err != nil {
+			http.Error(w, "hours must be a number", http.StatusBadRequest)
+			return
+		}
+		req.Hours = int32(n)
+	}
+
+	if err := checkRequestNamespace(r.Context(), s.registry, pb.ControlPlane_GetCalendar_FullMethodName, req); err != nil {
+		writeStatusError(w, err)
+		return
+	}
+	resp, _ := s.GetCalendar(r.Context(), req)
+	if !resp.Success {
+		http.Error(w, resp.Message, http.StatusBadRequest)
+		return
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	nmd "github.com/hashicorp/nomad/api"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// PromoteDeployment promotes the canaries of a rollout waiting for a manual promotion,
+// Nomad then replaces the remaining allocations of the old version. A blue-green or
+// canary-traffic rollout is promoted without a deployment ID, the job of the application is
+// updated to the version of its green job.
+func (s *ApplicationService) PromoteDeployment(ctx context.Context, req *pb.PromoteDeploymentRequest) (*pb.PromoteDeploymentResponse, error) {
+	if jobID, client, green := s.splitOf(req.Name); green != nil && req.DeploymentId == "" {
+		evalID, err := s.promoteSplit(client, jobID, green)
+		if err != nil {
+			return &pb.PromoteDeploymentResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to promote rollout: %v", err),
+			}, nil
+		}
+		return &pb.PromoteDeploymentResponse{
+			Success: true,
+			Message: fmt.Sprintf("%s promoted, %s is torn down once %s rolled out its version", *green.ID, *green.ID, jobID),
+			EvalId:  evalID,
+		}, nil
+	}
+
+	jobID, client, deployment, err := s.rolloutOf(req.Name, req.DeploymentId)
+	if err != nil {
+		return &pb.PromoteDeploymentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to find the rollout: %v", err),
+		}, nil
+	}
+	if deployment.Status != nmd.DeploymentStatusRunning && deployment.Status != nmd.DeploymentStatusPaused {
+		return &pb.PromoteDeploymentResponse{
+			Success:      false,
+			Message:      fmt.Sprintf("Rollout %s is %s, only running rollouts can be promoted", deployment.ID, deployment.Status),
+			DeploymentId: deployment.ID,
+		}, nil
+	}
+	if !hasCanaries(deployment, req.Groups) {
+		return &pb.PromoteDeploymentResponse{
+			Success:      false,
+			Message:      fmt.Sprintf("Rollout %s has no canaries to promote", deployment.ID),
+			DeploymentId: deployment.ID,
+		}, nil
+	}
+
+	evalID, err := client.PromoteDeployment(jobID, deployment.ID, req.Groups)
+	if err != nil {
+		return &pb.PromoteDeploymentResponse{
+			Success:      false,
+			Message:      fmt.Sprintf("Failed to promote rollout: %v", err),
+			DeploymentId: deployment.ID,
+		}, nil
+	}
+
+	promoted := "every task group"
+	if len(req.Groups) > 0 {
+		promoted = "task groups " + strings.Join(req.Groups, ", ")
+	}
+	message := fmt.Sprintf("Canaries of %s of version %d promoted", promoted, deployment.JobVersion)
+	s.publish(events.ApplicationPromoted, jobID, lifecycleEvent{
+		Version: &deployment.JobVersion,
+		EvalID:  evalID,
+		Message: message,
+	})
+	return &pb.PromoteDeploymentResponse{
+		Success:      true,
+		Message:      message,
+		DeploymentId: deployment.ID,
+		EvalId:       evalID,
+	}, nil
+}
+
+// FailDeployment fails a running rollout, Nomad stops placing the new version and reverts
+// the job to its last stable version when the update strategy has auto_revert. A blue-green
+// or canary-traffic rollout is failed without a deployment ID, its green job is torn down.
+func (s *ApplicationService) FailDeployment(ctx context.Context, req *pb.FailDeploymentRequest) (*pb.FailDeploymentResponse, error) {
+	if jobID, client, green := s.splitOf(req.Name); green != nil && req.DeploymentId == "" {
+		if err := s.abortSplit(client, jobID, "the traffic split failed on request"); err != nil {
+			return &pb.FailDeploymentResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to fail rollout: %v", err),
+			}, nil
+		}
+		return &pb.FailDeploymentResponse{
+			Success: true,
+			Message: fmt.Sprintf("%s torn down, %s takes all of its traffic again", *green.ID, jobID),
+		}, nil
+	}
+
+	jobID, client, deployment, err := s.rolloutOf(req.Name, req.DeploymentId)
+	if err != nil {
+		return &pb.FailDeploymentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to find the rollout: %v", err),
+		}, nil
+	}
+	if deployment.Status != nmd.DeploymentStatusRunning && deployment.Status != nmd.DeploymentStatusPaused && deployment.Status != nmd.DeploymentStatusPending {
+		return &pb.FailDeploymentResponse{
+			Success:      false,
+			Message:      fmt.Sprintf("Rollout %s is %s already", deployment.ID, deployment.Status),
+			DeploymentId: deployment.ID,
+		}, nil
+	}
+
+	evalID, revertedTo, err := client.FailDeployment(jobID, deployment.ID)
+	if err != nil {
+		return &pb.FailDeploymentResponse{
+			Success:      false,
+			Message:      fmt.Sprintf("Failed to fail rollout: %v", err),
+			DeploymentId: deployment.ID,
+		}, nil
+	}
+
+	message := fmt.Sprintf("Rollout of version %d failed on request", deployment.JobVersion)
+	if revertedTo != nil {
+		message = fmt.Sprintf("%s, the job runs version %d again", message, *revertedTo)
+	}
+	s.publish(events.RolloutFailed, jobID, lifecycleEvent{
+		Version:  &deployment.JobVersion,
+		EvalID:   evalID,
+		Reverted: revertedTo != nil,
+		Message:  message,
+	})
+	return &pb.FailDeploymentResponse{
+		Success:      true,
+		Message:      message,
+		DeploymentId: deployment.ID,
+		EvalId:       evalID,
+	}, nil
+}
+
+// rolloutOf resolves an application and its deployment, the latest one without an ID
+func (s *ApplicationService) rolloutOf(name, deploymentID string) (string, *nomad.NomadClient, *nmd.Deployment, error) {
+	if name == "" {
+		return "", nil, nil, fmt.Errorf("name is required")
+	}
+	jobID, err := s.resolveJobID(name)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	deployment, err := client.JobDeployment(jobID, deploymentID)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return jobID, client, deployment, nil
+}
+
+// splitOf resolves an application and the green job of its traffic split, nil without one
+// or once the promotion updates the application's job, whose own rollout is then promoted
+// or failed
+func (s *ApplicationService) splitOf(name string) (string, *nomad.NomadClient, *nmd.Job) {
+	if name == "" {
+		return "", nil, nil
+	}
+	jobID, err := s.resolveJobID(name)
+	if err != nil {
+		return "", nil, nil
+	}
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return "", nil, nil
+	}
+	green, _, err := client.GetJobStatus(nomad.GreenJobID(jobID))
+	if err != nil || green.Meta[nomad.MetaGreenOf] != jobID {
+		return "", nil, nil
+	}
+	if primary, _, err := client.GetJobStatus(jobID); err == nil && primary.Meta[nomad.MetaPromotedFrom] == nomad.PromotionOf(green) {
+		return "", nil, nil
+	}
+	return jobID, client, green
+}
+
+// hasCanaries reports whether the task groups of the rollout, every group when none is
+// given, placed canaries waiting for a promotion
+func hasCanaries(deployment *nmd.Deployment, groups []string) bool {
+	for name, group := range deployment.TaskGroups {
+		if len(groups) > 0 && !slices.Contains(groups, name) {
+			continue
+		}
+		if group.DesiredCanaries > 0 && !group.Promoted {
+			return true
+		}
+	}
+	return false
+}
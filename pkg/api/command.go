@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/bus"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+const (
+	// how long a redelivered command returns the result of the first delivery
+	commandKeyTTL = 24 * time.Hour
+	// wait before consuming again after the connection to the bus failed
+	consumerBackoff = 5 * time.Second
+)
+
+// RunCommandConsumer handles the deploy, scale and delete commands of the message bus until
+// the context is cancelled. With a replicated registry only the leader consumes them.
+func (s *ApplicationService) RunCommandConsumer(ctx context.Context, consumer bus.Consumer) {
+	for ctx.Err() == nil {
+		if leader, ok := s.registry.(interface{ IsLeader() bool }); ok && !leader.IsLeader() {
+			select {
+			case <-ctx.Done():
+			case <-time.After(consumerBackoff):
+			}
+			continue
+		}
+
+		consumeCtx, cancel := context.WithCancel(ctx)
+		go s.cancelOnLostLeadership(consumeCtx, cancel)
+
+		log.Printf("Consuming commands from %s", consumer)
+		err := consumer.Consume(consumeCtx, s.HandleCommand)
+		cancel()
+		if err != nil {
+			log.Printf("Commands: %s: %v", consumer, err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(consumerBackoff):
+		}
+	}
+}
+
+// cancelOnLostLeadership stops consuming when another replica became the leader
+func (s *ApplicationService) cancelOnLostLeadership(ctx context.Context, cancel context.CancelFunc) {
+	leader, ok := s.registry.(interface{ IsLeader() bool })
+	if !ok {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(consumerBackoff):
+			if !leader.IsLeader() {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// HandleCommand runs a command of the message bus and returns its result as JSON
+func (s *ApplicationService) HandleCommand(ctx context.Context, data []byte) []byte {
+	result := s.handleCommand(ctx, data)
+	if !result.Success {
+		log.Printf("Command %s failed: %s", result.Id, result.Message)
+	}
+
+	reply, err := protojson.Marshal(result)
+	if err != nil {
+		log.Printf("Failed to encode the result of command %s: %v", result.Id, err)
+	}
+	return reply
+}
+
+func (s *ApplicationService) handleCommand(ctx context.Context, data []byte) *pb.CommandResult {
+	// unknown fields and mistyped values are rejected
+	command := &pb.Command{}
+	if err := protojson.Unmarshal(data, command); err != nil {
+		return &pb.CommandResult{Message: fmt.Sprintf("Invalid command: %v", err)}
+	}
+	if command.Id == "" {
+		return &pb.CommandResult{Message: "Invalid command: id cannot be empty"}
+	}
+	operation := commandOperation(command)
+	if operation == "" {
+		return &pb.CommandResult{Id: command.Id, Message: "Invalid command: one of deploy, scale or delete is required"}
+	}
+
+	now := time.Now()
+	key := store.IdempotencyKey{
+		Key:       "command/" + command.Id,
+		Operation: operation,
+		CreatedAt: now,
+		ExpiresAt: now.Add(commandKeyTTL),
+	}
+	err := s.registry.CreateIdempotencyKey(key)
+	if errors.Is(err, store.ErrAlreadyExists) {
+		return s.duplicateCommand(command.Id, key.Key, operation)
+	}
+	if err != nil {
+		return &pb.CommandResult{Id: command.Id, Message: fmt.Sprintf("Failed to record command: %v", err)}
+	}
+
+	result := s.runCommand(ctx, command)
+
+	key.Done = true
+	if key.Result, err = protojson.Marshal(result); err == nil {
+		err = s.registry.UpdateIdempotencyKey(key)
+	}
+	if err != nil {
+		log.Printf("Failed to record the result of command %s: %v", command.Id, err)
+	}
+	return result
+}
+
+// duplicateCommand returns the result of the first delivery of a command
+func (s *ApplicationService) duplicateCommand(id, key, operation string) *pb.CommandResult {
+	existing, err := s.registry.IdempotencyKey(key)
+	if err != nil {
+		return &pb.CommandResult{Id: id, Message: fmt.Sprintf("Failed to look up command: %v", err)}
+	}
+	if existing.Operation != operation {
+		return &pb.CommandResult{Id: id, Message: fmt.Sprintf("Invalid command: id %s was used by a %s command", id, existing.Operation)}
+	}
+	if !existing.Done {
+		return &pb.CommandResult{Id: id, Duplicate: true, Message: "Command is still running"}
+	}
+
+	result := &pb.CommandResult{}
+	if err := protojson.Unmarshal(existing.Result, result); err != nil {
+		return &pb.CommandResult{Id: id, Message: fmt.Sprintf("Failed to decode the result of command: %v", err)}
+	}
+	result.Duplicate = true
+	return result
+}
+
+func commandOperation(command *pb.Command) string {
+	switch command.Command.(type) {
+	case *pb.Command_Deploy:
+		return "deploy"
+	case *pb.Command_Scale:
+		return "scale"
+	case *pb.Command_Delete:
+		return "delete"
+	default:
+		return ""
+	}
+}
+
+// runCommand calls the RPC of the command, which validates it like a gRPC request
+func (s *ApplicationService) runCommand(ctx context.Context, command *pb.Command) *pb.CommandResult {
+	result := &pb.CommandResult{Id: command.Id}
+
+	switch c := command.Command.(type) {
+	case *pb.Command_Deploy:
+		resp, _ := s.DeployApplication(ctx, c.Deploy)
+		result.Deploy, result.Success, result.Message = resp, resp.Status != "FAILED", resp.Message
+	case *pb.Command_Scale:
+		resp, _ := s.ScaleApplication(ctx, c.Scale)
+		result.Scale, result.Success, result.Message = resp, resp.Success, resp.Message
+	case *pb.Command_Delete:
+		resp, _ := s.DeleteApplication(ctx, c.Delete)
+		result.Delete, result.Success, result.Message = resp, resp.Success, resp.Message
+	}
+
+	return result
+}
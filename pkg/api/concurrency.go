@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+const (
+	groupPollInterval = 2 * time.Second
+	// how long a released group waits for Nomad to start the rollout of the registered job
+	rolloutStartTimeout = 15 * time.Second
+)
+
+// activeRollout are the statuses of a deployment that has not finished
+var activeRollout = []string{
+	nmd.DeploymentStatusPending,
+	nmd.DeploymentStatusRunning,
+	nmd.DeploymentStatusPaused,
+	nmd.DeploymentStatusBlocked,
+	nmd.DeploymentStatusUnblocking,
+}
+
+// rolloutGroups serializes the deployments of a concurrency group within the controller,
+// rollouts of other controllers are seen through the group meta of their jobs
+type rolloutGroups struct {
+	mu      sync.Mutex
+	slots   map[string]chan struct{}
+	waiting map[string]int
+}
+
+func (r *rolloutGroups) get(group string) chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.slots == nil {
+		r.slots = make(map[string]chan struct{})
+	}
+	slot, ok := r.slots[group]
+	if !ok {
+		slot = make(chan struct{}, 1)
+		r.slots[group] = slot
+	}
+	return slot
+}
+
+// wait counts the deployments waiting for the group
+func (r *rolloutGroups) wait(group string, delta int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.waiting == nil {
+		r.waiting = make(map[string]int)
+	}
+	r.waiting[group] += delta
+	if r.waiting[group] <= 0 {
+		delete(r.waiting, group)
+	}
+}
+
+// queues returns how many deployments wait for each group
+func (r *rolloutGroups) queues() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return maps.Clone(r.waiting)
+}
+
+// acquireGroup waits until no other application of the group rolls out. The returned
+// release must be called once the job is registered, it holds the group until Nomad
+// started the job's rollout. Deployments without a group return immediately.
+func (s *ApplicationService) acquireGroup(ctx context.Context, group, jobID string) (func(registered bool), error) {
+	if group == "" {
+		return func(bool) {}, nil
+	}
+
+	slot := s.rollouts.get(group)
+	s.rollouts.wait(group, 1)
+	defer s.rollouts.wait(group, -1)
+	select {
+	case slot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("another deployment of concurrency group %s is in progress: %w", group, ctx.Err())
+	}
+
+	for {
+		busy, err := s.groupRollout(group, jobID)
+		if err != nil {
+			<-slot
+			return nil, err
+		}
+		if busy == "" {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			<-slot
+			return nil, fmt.Errorf("rollout of %s in concurrency group %s did not finish in time: %w", busy, group, ctx.Err())
+		case <-time.After(groupPollInterval):
+		}
+	}
+
+	return func(registered bool) {
+		if registered {
+			s.waitRolloutStart(jobID)
+		}
+		<-slot
+	}, nil
+}
+
+// groupRollout returns a job of the group other than jobID whose rollout has not finished
+func (s *ApplicationService) groupRollout(group, jobID string) (string, error) {
+	jobs, err := s.orhClient.ListJobs()
+	if err != nil {
+		return "", err
+	}
+
+	for _, job := range jobs {
+		if job.ID == jobID || job.Stop || job.Meta[nomad.MetaConcurrencyGroup] != group {
+			continue
+		}
+		deployment, err := s.orhClient.LatestDeployment(job.ID)
+		if err != nil {
+			return "", err
+		}
+		if deployment != nil && slices.Contains(activeRollout, deployment.Status) {
+			return job.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// waitRolloutStart waits until Nomad created the deployment of the job's current version,
+// the next deployment of the group would not see the rollout before
+func (s *ApplicationService) waitRolloutStart(jobID string) {
+	version, found, err := s.orhClient.JobVersion(jobID)
+	if err != nil || !found {
+		return
+	}
+
+	deadline := time.Now().Add(rolloutStartTimeout)
+	for time.Now().Before(deadline) {
+		deployment, err := s.orhClient.LatestDeployment(jobID)
+		if err != nil || deployment != nil && deployment.JobVersion >= version {
+			return
+		}
+		time.Sleep(groupPollInterval / 4)
+	}
+}
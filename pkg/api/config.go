@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+func consulKVFromSpec(req *pb.DeployRequest) *nomad.ConsulKV {
+	return &nomad.ConsulKV{
+		Prefix:       nomad.ConsulKVPrefix(req.Name),
+		Destination:  req.ConsulKv.Destination,
+		ChangeMode:   req.ConsulKv.ChangeMode,
+		ChangeSignal: req.ConsulKv.ChangeSignal,
+	}
+}
+
+// syncConsulKV writes the configuration values of the spec to the application's prefix,
+// and deletes the other keys when the spec prunes them
+func (s *ApplicationService) syncConsulKV(req *pb.DeployRequest) error {
+	if req.ConsulKv == nil {
+		return nil
+	}
+	if s.consul == nil {
+		return fmt.Errorf("the controller has no Consul client")
+	}
+
+	prefix := nomad.ConsulKVPrefix(req.Name)
+	for key, value := range req.ConsulKv.Values {
+		if err := s.consul.PutKey(prefix+key, value); err != nil {
+			return err
+		}
+	}
+	if !req.ConsulKv.Prune {
+		return nil
+	}
+
+	existing, err := s.consul.ListKeys(prefix)
+	if err != nil {
+		return err
+	}
+	for key := range existing {
+		if _, ok := req.ConsulKv.Values[key]; ok {
+			continue
+		}
+		if err := s.consul.DeleteKey(prefix + key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeConsulKV deletes the configuration of a deleted application
+func (s *ApplicationService) removeConsulKV(prefix string) {
+	if prefix == "" || s.consul == nil {
+		return
+	}
+	if err := s.consul.DeletePrefix(prefix); err != nil {
+		log.Printf("Failed to delete the Consul KV configuration %s: %v", prefix, err)
+	}
+}
+
+// GetApplicationConfig returns the keys of the application's Consul KV prefix
+func (s *ApplicationService) GetApplicationConfig(ctx context.Context, req *pb.GetApplicationConfigRequest) (*pb.ApplicationConfigResponse, error) {
+	prefix, err := s.consulKVPrefix(req.DeploymentId)
+	if err != nil {
+		return &pb.ApplicationConfigResponse{
+			Message: fmt.Sprintf("Failed to get config: %v", err),
+		}, nil
+	}
+
+	values, err := s.consul.ListKeys(prefix)
+	if err != nil {
+		return &pb.ApplicationConfigResponse{
+			Prefix:  prefix,
+			Message: fmt.Sprintf("Failed to get config: %v", err),
+		}, nil
+	}
+	return &pb.ApplicationConfigResponse{
+		Success: true,
+		Prefix:  prefix,
+		Values:  values,
+		Message: fmt.Sprintf("%d keys under %s", len(values), prefix),
+	}, nil
+}
+
+// SetApplicationConfig writes and deletes keys of the application's Consul KV prefix,
+// Nomad re-renders them into the running allocations
+func (s *ApplicationService) SetApplicationConfig(ctx context.Context, req *pb.SetApplicationConfigRequest) (*pb.ApplicationConfigResponse, error) {
+	if len(req.Values) == 0 && len(req.DeleteKeys) == 0 {
+		return &pb.ApplicationConfigResponse{Message: "values or delete_keys must be provided"}, nil
+	}
+
+	prefix, err := s.consulKVPrefix(req.DeploymentId)
+	if err == nil {
+		for key, value := range req.Values {
+			if err = nomad.ValidateConsulKVEntry(key, value); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return &pb.ApplicationConfigResponse{
+			Message: fmt.Sprintf("Failed to set config: %v", err),
+		}, nil
+	}
+
+	for key, value := range req.Values {
+		if err = s.consul.PutKey(prefix+key, value); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		for _, key := range req.DeleteKeys {
+			if err = s.consul.DeleteKey(prefix + key); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return &pb.ApplicationConfigResponse{
+			Prefix:  prefix,
+			Message: fmt.Sprintf("Failed to set config: %v", err),
+		}, nil
+	}
+
+	values, err := s.consul.ListKeys(prefix)
+	if err != nil {
+		return &pb.ApplicationConfigResponse{
+			Success: true,
+			Prefix:  prefix,
+			Message: fmt.Sprintf("Config updated, but failed to read it back: %v", err),
+		}, nil
+	}
+	return &pb.ApplicationConfigResponse{
+		Success: true,
+		Prefix:  prefix,
+		Values:  values,
+		Message: fmt.Sprintf("Config of %s updated, %d keys set and %d deleted", req.DeploymentId, len(req.Values), len(req.DeleteKeys)),
+	}, nil
+}
+
+// consulKVPrefix is the Consul KV prefix of an application deployed with consul_kv
+func (s *ApplicationService) consulKVPrefix(name string) (string, error) {
+	if s.consul == nil {
+		return "", fmt.Errorf("the controller has no Consul client")
+	}
+	jobID, err := s.resolveJobID(name)
+	if err != nil {
+		return "", err
+	}
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return "", err
+	}
+	job, _, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return "", err
+	}
+	prefix := job.Meta[nomad.MetaConsulKV]
+	if prefix == "" {
+		return "", fmt.Errorf("%s was not deployed with consul_kv", jobID)
+	}
+	return prefix, nil
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// DeployController deploys the controllers as a Nomad job, or upgrades the deployed ones.
+// The running controllers keep serving during the rollout, including the one handling
+// this request, and stay when the new ones fail their health check.
+func (s *AdminService) DeployController(ctx context.Context, req *pb.DeployControllerRequest) (*pb.DeployControllerResponse, error) {
+	existing, _, err := s.orhClient.GetJobStatus(nomad.ControllerJobID)
+	if err != nil && !nomad.IsNotFound(err) {
+		return &pb.DeployControllerResponse{
+			Message: fmt.Sprintf("Failed to look up the controllers: %v", err),
+		}, nil
+	}
+
+	controller := nomad.Controller{}
+	previous := ""
+	if err == nil {
+		controller = nomad.ControllerFromJob(existing)
+		previous = controller.Image
+	}
+	applyControllerRequest(&controller, req)
+
+	job, err := controller.Job()
+	if err == nil && controller.NodePool != "" {
+		err = s.orhClient.Capabilities().CheckNodePool(controller.NodePool)
+	}
+	if err != nil {
+		return &pb.DeployControllerResponse{
+			Message: fmt.Sprintf("Invalid controller config: %v", err),
+		}, nil
+	}
+
+	registered, err := s.orhClient.RegisterJob(job)
+	if err != nil {
+		return &pb.DeployControllerResponse{
+			Message: fmt.Sprintf("Failed to deploy the controllers: %v", err),
+		}, nil
+	}
+
+	resp := &pb.DeployControllerResponse{
+		Success:       true,
+		EvalId:        registered.EvalID,
+		Image:         controller.Image,
+		PreviousImage: previous,
+		Message:       fmt.Sprintf("Controllers %s submitted with %s", nomad.ControllerJobID, controller.Image),
+	}
+	if version, found, err := s.orhClient.JobVersion(nomad.ControllerJobID); err == nil && found {
+		resp.JobVersion = version
+	}
+	if previous != "" && previous != controller.Image {
+		resp.Message = fmt.Sprintf("Controllers %s upgrading from %s to %s", nomad.ControllerJobID, previous, controller.Image)
+	}
+	return resp, nil
+}
+
+// applyControllerRequest overrides the deployed settings with those of the request
+func applyControllerRequest(controller *nomad.Controller, req *pb.DeployControllerRequest) {
+	if req.Image != "" {
+		controller.Image = req.Image
+	}
+	if req.Count > 0 {
+		controller.Count = int(req.Count)
+	}
+	if len(req.Args) > 0 || req.ReplaceArgs {
+		controller.Args = req.Args
+	}
+	if req.Region != "" {
+		controller.Region = req.Region
+	}
+	if len(req.Datacenters) > 0 {
+		controller.Datacenters = req.Datacenters
+	}
+	if req.NodePool != "" {
+		controller.NodePool = req.NodePool
+	}
+	if req.HealthTimeoutSeconds > 0 {
+		controller.HealthTimeout = time.Duration(req.HealthTimeoutSeconds) * time.Second
+	}
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+const defaultCronRuns = 10
+
+// applyCronSpec turns the job into a periodic batch job
+func applyCronSpec(jobTemplate *nomad.JobTemplate, req *pb.DeployRequest) error {
+	if req.Cron == nil {
+		return fmt.Errorf("cron deployments require a schedule")
+	}
+	if req.Traefik != nil && req.Traefik.Enable {
+		return fmt.Errorf("cron jobs cannot be routed by Traefik")
+	}
+
+	jobTemplate.Type = "batch"
+	jobTemplate.Periodic = &nomad.Periodic{
+		Schedule:        req.Cron.Schedule,
+		TimeZone:        req.Cron.TimeZone,
+		ProhibitOverlap: req.Cron.ProhibitOverlap,
+	}
+
+	return nil
+}
+
+// ListCronRuns lists the past runs of a cron job with their outcome and duration.
+func (s *ApplicationService) ListCronRuns(ctx context.Context, req *pb.CronRunsRequest) (*pb.CronRunsResponse, error) {
+	job, _, err := s.orhClient.GetJobStatus(req.Name)
+	if err != nil {
+		return &pb.CronRunsResponse{
+			Name:    req.Name,
+			Message: fmt.Sprintf("Failed to get cron job: %v", err),
+		}, nil
+	}
+	if job.Periodic == nil {
+		return &pb.CronRunsResponse{
+			Name:    req.Name,
+			Message: fmt.Sprintf("%s is not a cron job", req.Name),
+		}, nil
+	}
+
+	resp := &pb.CronRunsResponse{
+		Name:    req.Name,
+		Paused:  job.Periodic.Enabled != nil && !*job.Periodic.Enabled,
+		Message: "Cron runs retrieved successfully",
+	}
+	if job.Periodic.Spec != nil {
+		resp.Schedule = *job.Periodic.Spec
+	}
+	if job.Periodic.TimeZone != nil {
+		resp.TimeZone = *job.Periodic.TimeZone
+	}
+	if !resp.Paused {
+		if location, err := job.Periodic.GetLocation(); err == nil {
+			if next, err := job.Periodic.Next(time.Now().In(location)); err == nil && !next.IsZero() {
+				resp.NextRun = next.Unix()
+			}
+		}
+	}
+
+	runs, err := s.orhClient.PeriodicRuns(req.Name)
+	if err != nil {
+		resp.Message = fmt.Sprintf("Failed to list cron runs: %v", err)
+		return resp, nil
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultCronRuns
+	}
+	if len(runs) > limit {
+		runs = runs[:limit]
+	}
+
+	for _, run := range runs {
+		_, allocations, err := s.orhClient.GetJobStatus(run.ID)
+		if err != nil {
+			resp.Runs = append(resp.Runs, &pb.CronRun{
+				JobId:     run.ID,
+				Status:    "unknown",
+				StartedAt: time.Unix(0, run.SubmitTime).Unix(),
+			})
+			continue
+		}
+		resp.Runs = append(resp.Runs, cronRun(run, allocations))
+	}
+
+	return resp, nil
+}
+
+// cronRun summarizes the allocations of a single run
+func cronRun(run *nmd.JobListStub, allocations []*nmd.AllocationListStub) *pb.CronRun {
+	result := &pb.CronRun{
+		JobId:     run.ID,
+		Status:    "pending",
+		StartedAt: time.Unix(0, run.SubmitTime).Unix(),
+	}
+
+	var started, finished time.Time
+	running, failed, complete := false, false, false
+
+	for _, alloc := range allocations {
+		switch alloc.ClientStatus {
+		case nmd.AllocClientStatusRunning:
+			running = true
+		case nmd.AllocClientStatusFailed, nmd.AllocClientStatusLost:
+			failed = true
+		case nmd.AllocClientStatusComplete:
+			complete = true
+		}
+
+		for _, state := range alloc.TaskStates {
+			if !state.StartedAt.IsZero() && (started.IsZero() || state.StartedAt.Before(started)) {
+				started = state.StartedAt
+			}
+			if state.FinishedAt.After(finished) {
+				finished = state.FinishedAt
+			}
+		}
+	}
+
+	switch {
+	case running:
+		result.Status = "running"
+	case failed:
+		result.Status = "failed"
+	case complete:
+		result.Status = "complete"
+	}
+
+	if !started.IsZero() {
+		result.StartedAt = started.Unix()
+		if !running && !finished.IsZero() {
+			result.FinishedAt = finished.Unix()
+			result.DurationMs = finished.Sub(started).Milliseconds()
+		}
+	}
+
+	return result
+}
+
+// TriggerCronJob runs a cron job immediately without waiting for its schedule.
+func (s *ApplicationService) TriggerCronJob(ctx context.Context, req *pb.CronTriggerRequest) (*pb.CronTriggerResponse, error) {
+	evalID, err := s.orhClient.ForcePeriodicRun(req.Name)
+	if err != nil {
+		return &pb.CronTriggerResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to trigger cron job: %v", err),
+		}, nil
+	}
+
+	return &pb.CronTriggerResponse{
+		Success: true,
+		Message: "Cron job triggered successfully",
+		EvalId:  evalID,
+	}, nil
+}
+
+// SetCronPaused pauses or resumes the schedule of a cron job.
+func (s *ApplicationService) SetCronPaused(ctx context.Context, req *pb.CronPauseRequest) (*pb.CronPauseResponse, error) {
+	if err := s.orhClient.SetPeriodicEnabled(req.Name, !req.Paused); err != nil {
+		return &pb.CronPauseResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to update cron schedule: %v", err),
+		}, nil
+	}
+
+	message := "Cron schedule resumed"
+	if req.Paused {
+		message = "Cron schedule paused"
+	}
+
+	return &pb.CronPauseResponse{
+		Success: true,
+		Message: message,
+	}, nil
+}
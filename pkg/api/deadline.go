@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// RunRolloutDeadlines fails the rollouts running longer than the deadline of their spec
+// every interval until the context is cancelled. With a replicated registry only the
+// leader checks them.
+func (s *ApplicationService) RunRolloutDeadlines(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopDeadlines, interval, s.checkRolloutDeadlines)
+		if err != nil {
+			log.Printf("Rollout deadlines: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) checkRolloutDeadlines() error {
+	jobs, err := s.orhClient.ListJobs()
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	s.reconciler.queue(loopDeadlines, len(jobs))
+	for _, job := range jobs {
+		s.reconciler.next(loopDeadlines)
+		deadline, err := time.ParseDuration(job.Meta[nomad.MetaRolloutDeadline])
+		if err != nil || job.Stop {
+			continue
+		}
+
+		deployment, err := s.orhClient.LatestDeployment(job.ID)
+		s.reconciler.result(loopDeadlines, job.ID, err)
+		if err != nil {
+			log.Printf("Rollout deadlines: %s: %v", job.ID, err)
+			continue
+		}
+		// a paused rollout waits for a promotion, not on its allocations
+		if deployment == nil || deployment.Status != nmd.DeploymentStatusPending && deployment.Status != nmd.DeploymentStatusRunning {
+			continue
+		}
+		if time.Since(time.Unix(0, deployment.CreateTime)) < deadline {
+			continue
+		}
+
+		if err := s.failRollout(job, deployment, deadline); err != nil {
+			log.Printf("Rollout deadlines: %s: %v", job.ID, err)
+			s.reconciler.result(loopDeadlines, job.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// failRollout fails a rollout that exceeded its deadline, reverts the job when its spec
+// asks to and records why in the rollout history
+func (s *ApplicationService) failRollout(job *nmd.JobListStub, deployment *nmd.Deployment, deadline time.Duration) error {
+	reason := fmt.Sprintf("deadline of %s exceeded", deadline)
+	if stuck := s.stuckReason(deployment.ID); stuck != "" {
+		reason += ": " + stuck
+	}
+
+	if _, _, err := s.orhClient.FailDeployment(job.ID, deployment.ID); err != nil {
+		return fmt.Errorf("failed to fail deployment %s: %w", deployment.ID, err)
+	}
+
+	rollout := store.Rollout{
+		ID:          deployment.ID,
+		Application: job.ID,
+		JobVersion:  deployment.JobVersion,
+		Status:      nmd.DeploymentStatusFailed,
+		StartedAt:   time.Unix(0, deployment.CreateTime),
+		FinishedAt:  time.Now(),
+	}
+
+	if revert, _ := strconv.ParseBool(job.Meta[nomad.MetaRolloutRevert]); revert {
+		if version, _, err := s.orhClient.RevertJob(job.ID, 0); err != nil {
+			reason += fmt.Sprintf(", revert failed: %v", err)
+		} else {
+			reason += fmt.Sprintf(", reverted to version %d", version)
+			rollout.Reverted, rollout.RevertedTo = true, version
+		}
+	}
+	log.Printf("Rollout of %s version %d failed: %s", job.ID, deployment.JobVersion, reason)
+
+	rollout.Reason = reason
+	s.publish(events.RolloutFailed, job.ID, lifecycleEvent{
+		Version:  &deployment.JobVersion,
+		Reverted: rollout.Reverted,
+		Message:  reason,
+	})
+	return s.registry.SaveRollout(rollout)
+}
+
+// stuckReason returns the most recent task event of the rollout's unhealthy allocations,
+// e.g. a failing image pull
+func (s *ApplicationService) stuckReason(deploymentID string) string {
+	allocations, err := s.orhClient.DeploymentAllocations(deploymentID)
+	if err != nil {
+		return ""
+	}
+
+	var latest *nmd.TaskEvent
+	for _, alloc := range allocations {
+		if isHealthy(alloc) {
+			continue
+		}
+		for _, state := range alloc.TaskStates {
+			for _, event := range state.Events {
+				if event.DisplayMessage != "" && (latest == nil || event.Time > latest.Time) {
+					latest = event
+				}
+			}
+		}
+	}
+
+	if latest == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s", latest.Type, latest.DisplayMessage)
+}
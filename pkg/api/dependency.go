@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// GetImpact answers which applications consume an application, directly or through
+// other applications, to assess the blast radius of deleting or changing it.
+func (s *ApplicationService) GetImpact(ctx context.Context, req *pb.ImpactRequest) (*pb.ImpactResponse, error) {
+	graph, err := s.registry.Dependencies()
+	if err != nil {
+		return &pb.ImpactResponse{
+			Name:    req.Name,
+			Message: fmt.Sprintf("Failed to load dependencies: %v", err),
+		}, nil
+	}
+
+	consumers := impactedApplications(graph, req.Name)
+
+	return &pb.ImpactResponse{
+		Name:         req.Name,
+		Dependencies: graph[req.Name],
+		Consumers:    consumers,
+		Message:      fmt.Sprintf("%d applications depend on %s", len(consumers), req.Name),
+	}, nil
+}
+
+// GetDependencyGraph returns every declared dependency between applications.
+func (s *ApplicationService) GetDependencyGraph(ctx context.Context, req *pb.DependencyGraphRequest) (*pb.DependencyGraphResponse, error) {
+	graph, err := s.registry.Dependencies()
+	if err != nil {
+		return &pb.DependencyGraphResponse{
+			Message: fmt.Sprintf("Failed to load dependencies: %v", err),
+		}, nil
+	}
+
+	applications := make([]string, 0, len(graph))
+	for application := range graph {
+		applications = append(applications, application)
+	}
+	sort.Strings(applications)
+
+	resp := &pb.DependencyGraphResponse{
+		Message: "Dependency graph retrieved successfully",
+	}
+	for _, application := range applications {
+		for _, dependency := range graph[application] {
+			resp.Edges = append(resp.Edges, &pb.DependencyEdge{
+				Application: application,
+				DependsOn:   dependency,
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+// impactedApplications walks the reversed dependency graph breadth first, so every
+// consumer is reported with its shortest path to the application.
+func impactedApplications(graph map[string][]string, application string) []*pb.ImpactedApplication {
+	consumersOf := make(map[string][]string)
+	for consumer, dependencies := range graph {
+		for _, dependency := range dependencies {
+			consumersOf[dependency] = append(consumersOf[dependency], consumer)
+		}
+	}
+
+	paths := map[string][]string{application: nil}
+	queue := []string{application}
+	var impacted []*pb.ImpactedApplication
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		consumers := consumersOf[current]
+		sort.Strings(consumers)
+		for _, consumer := range consumers {
+			if _, seen := paths[consumer]; seen {
+				continue
+			}
+
+			path := append(slices.Clone(paths[current]), current)
+			paths[consumer] = path
+			queue = append(queue, consumer)
+
+			via := make([]string, 0, len(path)+1)
+			via = append(via, consumer)
+			for i := len(path) - 1; i >= 0; i-- {
+				via = append(via, path[i])
+			}
+
+			impacted = append(impacted, &pb.ImpactedApplication{
+				Name:  consumer,
+				Depth: int32(len(path)),
+				Via:   via,
+			})
+		}
+	}
+
+	return impacted
+}
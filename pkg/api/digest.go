@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+const (
+	digestPeriod  = 24 * time.Hour
+	digestHorizon = 7 * 24 * time.Hour // how far ahead the digest warns of expirations
+	digestTimeout = time.Minute
+)
+
+// digestReport is what happened to the applications of a tenant during a digest period
+type digestReport struct {
+	deployments []string
+	failures    []string
+	drift       []string
+	expirations []string
+}
+
+func (r digestReport) empty() bool {
+	return len(r.deployments)+len(r.failures)+len(r.drift)+len(r.expirations) == 0
+}
+
+// RunDigests emails every digest of the notification routes at its hour of the day until
+// the context is cancelled. With a replicated registry only the leader sends them.
+func (s *ApplicationService) RunDigests(ctx context.Context, router *events.Router) {
+	var wg sync.WaitGroup
+	for _, digest := range router.Digests {
+		wg.Go(func() { s.runDigest(ctx, router, digest) })
+	}
+	wg.Wait()
+}
+
+func (s *ApplicationService) runDigest(ctx context.Context, router *events.Router, digest events.Digest) {
+	location, _ := digest.Location() // checked when the routes were loaded
+	since := time.Now().Add(-digestPeriod)
+	for {
+		now := time.Now().In(location)
+		next := time.Date(now.Year(), now.Month(), now.Day(), digest.Hour, 0, 0, 0, location)
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 1)
+		}
+		if err := sleep(ctx, time.Until(next)); err != nil {
+			return
+		}
+
+		if leader, ok := s.registry.(interface{ IsLeader() bool }); !ok || leader.IsLeader() {
+			if err := s.sendDigest(ctx, router, digest, since, next); err != nil {
+				log.Printf("Digest: failed to send the digest of %s: %v", digestScope(digest.Tenant), err)
+			}
+		}
+		since = next
+	}
+}
+
+// sendDigest emails what happened between since and until, digests without anything to
+// report are not sent
+func (s *ApplicationService) sendDigest(ctx context.Context, router *events.Router, digest events.Digest, since, until time.Time) error {
+	report, err := s.digestReport(digest.Tenant, since, until)
+	if err != nil {
+		return err
+	}
+	if report.empty() {
+		log.Printf("Digest: nothing to report for %s", digestScope(digest.Tenant))
+		return nil
+	}
+
+	location, _ := digest.Location()
+	var body strings.Builder
+	fmt.Fprintf(&body, "What happened to the applications of %s from %s to %s.\n",
+		digestScope(digest.Tenant), since.In(location).Format("Jan 2 15:04"), until.In(location).Format("Jan 2 15:04 MST"))
+	for _, section := range []struct {
+		title string
+		lines []string
+	}{
+		{"Deployments", report.deployments},
+		{"Failures", report.failures},
+		{"Image drift", report.drift},
+		{"Upcoming expirations", report.expirations},
+	} {
+		if len(section.lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&body, "\n%s (%d)\n", section.title, len(section.lines))
+		for _, line := range section.lines {
+			fmt.Fprintf(&body, "  %s\n", line)
+		}
+	}
+
+	subject := fmt.Sprintf("Daily digest of %s: %d applications deployed, %d failures", digestScope(digest.Tenant), len(report.deployments), len(report.failures))
+	sendCtx, cancel := context.WithTimeout(ctx, digestTimeout)
+	defer cancel()
+	return router.SendEmail(sendCtx, digest.To, subject, body.String())
+}
+
+// digestReport collects the deployments, failed rollouts and drifted images of the
+// applications of a tenant, all tenants when empty, and what expires soon after until
+func (s *ApplicationService) digestReport(tenant string, since, until time.Time) (digestReport, error) {
+	var report digestReport
+	names, err := s.registry.JobNames()
+	if err != nil {
+		return report, err
+	}
+	images, err := s.registry.DeployedImages()
+	if err != nil {
+		return report, err
+	}
+
+	for _, name := range names {
+		if tenant != "" && name.Tenant != tenant {
+			continue
+		}
+		application := name.Application
+		if tenant == "" && name.Tenant != "" {
+			application = name.Tenant + "/" + name.Application
+		}
+
+		if revisions, err := s.registry.Revisions(name.JobID); err == nil {
+			deployed := 0
+			for _, revision := range revisions {
+				if !revision.CreatedAt.Before(since) && revision.CreatedAt.Before(until) {
+					deployed++
+				}
+			}
+			if deployed > 0 {
+				report.deployments = append(report.deployments, fmt.Sprintf("%s: %d deployments, now revision %d with %s",
+					application, deployed, revisions[0].Revision, revisions[0].Image))
+			}
+		}
+
+		// rollouts nobody asked the status of are recorded now, before Nomad collects them
+		if s.orhClient != nil {
+			if client, err := s.nomadFor(name.JobID); err == nil {
+				if deployments, err := client.Deployments(name.JobID); err == nil {
+					for _, deployment := range deployments {
+						s.recordRollout(name.JobID, deployment)
+					}
+				}
+			}
+		}
+		if rollouts, err := s.registry.Rollouts(name.JobID); err == nil {
+			for _, rollout := range rollouts {
+				if rollout.Status != nmd.DeploymentStatusFailed || rollout.FinishedAt.Before(since) || !rollout.FinishedAt.Before(until) {
+					continue
+				}
+				line := fmt.Sprintf("%s: rollout of version %d failed at %s", application, rollout.JobVersion, rollout.FinishedAt.UTC().Format("Jan 2 15:04 MST"))
+				if rollout.Reason != "" {
+					line += ", " + rollout.Reason
+				}
+				if rollout.Reverted {
+					line += fmt.Sprintf(", reverted to version %d", rollout.RevertedTo)
+				}
+				report.failures = append(report.failures, line)
+			}
+		}
+
+		if i := slices.IndexFunc(images, func(image store.DeployedImage) bool { return image.Application == name.JobID }); i >= 0 {
+			if image := images[i]; image.TagDigest != "" {
+				report.drift = append(report.drift, fmt.Sprintf("%s: %s now resolves to %s, %d allocations may run it",
+					application, image.Image, image.TagDigest, len(image.Drifted)))
+			}
+		}
+	}
+
+	tenants, err := s.registry.Tenants()
+	if err != nil {
+		return report, err
+	}
+	for _, t := range tenants {
+		if tenant != "" && t.Name != tenant {
+			continue
+		}
+		if access := t.NomadAccess; access != nil && access.ExpiresAt.Before(until.Add(digestHorizon)) {
+			report.expirations = append(report.expirations, fmt.Sprintf("Nomad token %s of tenant %s expires %s",
+				access.AccessorID, t.Name, access.ExpiresAt.UTC().Format("Jan 2 15:04 MST")))
+		}
+	}
+	return report, nil
+}
+
+func digestScope(tenant string) string {
+	if tenant == "" {
+		return "all tenants"
+	}
+	return "tenant " + tenant
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// DispatchJob dispatches an instance of a parameterized job. The dispatched job
+// can be followed through GetApplicationStatus and GetApplicationLogs.
+func (s *ApplicationService) DispatchJob(ctx context.Context, req *pb.DispatchRequest) (*pb.DispatchResponse, error) {
+	if req.JobId == "" {
+		return &pb.DispatchResponse{
+			Success: false,
+			Message: "job id cannot be empty",
+		}, nil
+	}
+
+	resp, err := s.orhClient.DispatchJob(req.JobId, req.Payload, req.Meta)
+	if err != nil {
+		return &pb.DispatchResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to dispatch job: %v", err),
+		}, nil
+	}
+
+	return &pb.DispatchResponse{
+		Success:         true,
+		Message:         "Job dispatched successfully",
+		DispatchedJobId: resp.DispatchedJobID,
+		EvalId:          resp.EvalID,
+	}, nil
+}
@@ -0,0 +1,320 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// The TXT record proving a tenant controls a domain
+const (
+	domainChallengePrefix = "_controlplane-challenge."
+	domainTokenPrefix     = "controlplane-verification="
+)
+
+// pending domains are checked by the controller for a week, VerifyDomain checks them at any time
+const domainVerificationWindow = 7 * 24 * time.Hour
+
+var domainName = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// AddDomain claims a custom domain for a tenant. The tenant can route applications on the
+// domain and its subdomains once the TXT record of the response is found.
+func (s *ApplicationService) AddDomain(ctx context.Context, req *pb.AddDomainRequest) (*pb.AddDomainResponse, error) {
+	name := normalizeHost(req.Domain)
+	if !domainName.MatchString(name) {
+		return &pb.AddDomainResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid domain %q", req.Domain),
+		}, nil
+	}
+
+	if _, err := s.registry.Tenant(req.Tenant); err != nil {
+		return &pb.AddDomainResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to find tenant: %v", err),
+		}, nil
+	}
+
+	domains, err := s.registry.Domains("")
+	if err != nil {
+		return &pb.AddDomainResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to add domain: %v", err),
+		}, nil
+	}
+	for _, domain := range domains {
+		if domain.Name != name {
+			continue
+		}
+		if domain.Tenant == req.Tenant {
+			return &pb.AddDomainResponse{
+				Success: true,
+				Message: fmt.Sprintf("Domain %s is already claimed", name),
+				Domain:  toDomain(domain),
+			}, nil
+		}
+		if domain.Verified {
+			return &pb.AddDomainResponse{
+				Success: false,
+				Message: fmt.Sprintf("Domain %s is verified by another tenant", name),
+			}, nil
+		}
+	}
+
+	domain := store.Domain{
+		Name:      name,
+		Tenant:    req.Tenant,
+		Token:     rand.Text(),
+		CreatedAt: time.Now(),
+	}
+	if err := s.registry.SaveDomain(domain); err != nil {
+		return &pb.AddDomainResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to add domain: %v", err),
+		}, nil
+	}
+
+	resp := toDomain(domain)
+	return &pb.AddDomainResponse{
+		Success: true,
+		Message: fmt.Sprintf("Create the TXT record %s with the value %q to verify %s", resp.RecordName, resp.RecordValue, name),
+		Domain:  resp,
+	}, nil
+}
+
+// VerifyDomain looks up the TXT record of a claimed domain.
+func (s *ApplicationService) VerifyDomain(ctx context.Context, req *pb.VerifyDomainRequest) (*pb.VerifyDomainResponse, error) {
+	domain, err := s.findDomain(req.Tenant, normalizeHost(req.Domain))
+	if err != nil {
+		return &pb.VerifyDomainResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to find domain: %v", err),
+		}, nil
+	}
+
+	if !domain.Verified {
+		if domain, err = s.checkDomain(ctx, domain); err != nil {
+			return &pb.VerifyDomainResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to verify domain: %v", err),
+				Domain:  toDomain(domain),
+			}, nil
+		}
+	}
+
+	if !domain.Verified {
+		return &pb.VerifyDomainResponse{
+			Success: false,
+			Message: fmt.Sprintf("Domain %s is not verified: %s", domain.Name, domain.LastError),
+			Domain:  toDomain(domain),
+		}, nil
+	}
+
+	return &pb.VerifyDomainResponse{
+		Success: true,
+		Message: fmt.Sprintf("Domain %s is verified", domain.Name),
+		Domain:  toDomain(domain),
+	}, nil
+}
+
+// ListDomains lists the claimed domains of a tenant.
+func (s *ApplicationService) ListDomains(ctx context.Context, req *pb.ListDomainsRequest) (*pb.ListDomainsResponse, error) {
+	domains, err := s.registry.Domains(req.Tenant)
+	if err != nil {
+		return &pb.ListDomainsResponse{
+			Message: fmt.Sprintf("Failed to list domains: %v", err),
+		}, nil
+	}
+
+	resp := &pb.ListDomainsResponse{
+		Message: "Domains retrieved successfully",
+	}
+	for _, domain := range domains {
+		resp.Domains = append(resp.Domains, toDomain(domain))
+	}
+
+	return resp, nil
+}
+
+// RunDomainVerification checks the TXT records of pending domains every interval until
+// the context is cancelled.
+func (s *ApplicationService) RunDomainVerification(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopDomains, interval, func() error { return s.verifyPendingDomains(ctx) })
+		if err != nil {
+			log.Printf("Domain verification: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) verifyPendingDomains(ctx context.Context) error {
+	domains, err := s.registry.Domains("")
+	if err != nil {
+		return fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	s.reconciler.queue(loopDomains, len(domains))
+	for _, domain := range domains {
+		s.reconciler.next(loopDomains)
+		if domain.Verified || time.Since(domain.CreatedAt) > domainVerificationWindow {
+			continue
+		}
+		if domain, err = s.checkDomain(ctx, domain); err != nil {
+			log.Printf("Failed to verify domain %s of %s: %v", domain.Name, domain.Tenant, err)
+		} else if domain.Verified {
+			log.Printf("Domain %s of %s verified", domain.Name, domain.Tenant)
+		}
+		s.reconciler.result(loopDomains, domain.Name, err)
+	}
+
+	return nil
+}
+
+// checkDomain looks up the TXT record of a domain and records the outcome. A domain
+// verified by another tenant in the meantime stays with that tenant.
+func (s *ApplicationService) checkDomain(ctx context.Context, domain store.Domain) (store.Domain, error) {
+	if owner, err := s.domainOwner(domain.Name); err != nil {
+		return domain, err
+	} else if owner != "" && owner != domain.Tenant {
+		domain.LastError = "the domain is verified by another tenant"
+	} else {
+		domain.LastError = lookupChallenge(ctx, domain)
+	}
+
+	domain.CheckedAt = time.Now()
+	if domain.LastError == "" {
+		domain.Verified = true
+		domain.VerifiedAt = domain.CheckedAt
+	}
+
+	return domain, s.registry.SaveDomain(domain)
+}
+
+// lookupChallenge returns why the TXT record of the domain does not prove its claim
+func lookupChallenge(ctx context.Context, domain store.Domain) string {
+	lookupCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	records, err := net.DefaultResolver.LookupTXT(lookupCtx, domainChallengePrefix+domain.Name)
+	if err != nil {
+		return fmt.Sprintf("TXT lookup failed: %v", err)
+	}
+	for _, record := range records {
+		if record == domainTokenPrefix+domain.Token {
+			return ""
+		}
+	}
+	return fmt.Sprintf("TXT record %s%s does not contain the verification token", domainChallengePrefix, domain.Name)
+}
+
+func (s *ApplicationService) findDomain(tenant, name string) (store.Domain, error) {
+	domains, err := s.registry.Domains(tenant)
+	if err != nil {
+		return store.Domain{}, err
+	}
+	for _, domain := range domains {
+		if domain.Name == name {
+			return domain, nil
+		}
+	}
+	return store.Domain{}, fmt.Errorf("domain %s of tenant %s: %w", name, tenant, store.ErrNotFound)
+}
+
+// domainOwner returns the tenant which verified the most specific domain covering host
+func (s *ApplicationService) domainOwner(host string) (string, error) {
+	domains, err := s.registry.Domains("")
+	if err != nil {
+		return "", err
+	}
+
+	owner, matched := "", ""
+	for _, domain := range domains {
+		if !domain.Verified || len(domain.Name) <= len(matched) {
+			continue
+		}
+		if host == domain.Name || strings.HasSuffix(host, "."+domain.Name) {
+			owner, matched = domain.Tenant, domain.Name
+		}
+	}
+	return owner, nil
+}
+
+// checkHosts prevents hostname hijacking between tenants: a tenant routes on the host of
+// its domain template or on domains it verified, nobody else routes on a verified domain
+// or requests a certificate for it.
+func (s *ApplicationService) checkHosts(req *pb.DeployRequest) error {
+	if req.Traefik == nil {
+		return nil
+	}
+
+	templateHost := ""
+	if req.Tenant != "" {
+		tenant, err := s.registry.Tenant(req.Tenant)
+		if err != nil {
+			return err
+		}
+		templateHost = strings.NewReplacer("{app}", req.Name, "{tenant}", tenant.Name).Replace(tenant.DomainTemplate)
+	}
+
+	hosts := append([]string{req.Traefik.Host, req.Traefik.SslHost}, req.Traefik.CertSans...)
+	for _, host := range hosts {
+		host = normalizeHost(strings.TrimPrefix(host, "*."))
+		if host == "" || host == templateHost {
+			continue
+		}
+
+		owner, err := s.domainOwner(host)
+		if err != nil {
+			return err
+		}
+		switch {
+		case owner == req.Tenant:
+		case owner != "":
+			return fmt.Errorf("host %s belongs to a domain verified by another tenant", host)
+		default:
+			return fmt.Errorf("host %s is outside the domain template of tenant %s, verify its domain with AddDomain first", host, req.Tenant)
+		}
+	}
+
+	return nil
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+func toDomain(domain store.Domain) *pb.Domain {
+	resp := &pb.Domain{
+		Name:        domain.Name,
+		Tenant:      domain.Tenant,
+		Verified:    domain.Verified,
+		RecordName:  domainChallengePrefix + domain.Name,
+		RecordValue: domainTokenPrefix + domain.Token,
+		CreatedAt:   domain.CreatedAt.Unix(),
+		LastError:   domain.LastError,
+	}
+	if !domain.VerifiedAt.IsZero() {
+		resp.VerifiedAt = domain.VerifiedAt.Unix()
+	}
+	if !domain.CheckedAt.IsZero() {
+		resp.CheckedAt = domain.CheckedAt.Unix()
+	}
+	return resp
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// disasterRecovery is a registry replicated to a standby site, only the Raft store is
+type disasterRecovery interface {
+	Promote() error
+	ReplicationStatus() store.ReplicationStatus
+}
+
+// standbyMethods are the RPCs a standby serves besides the reads, the platform team
+// must be able to see the replication and promote the standby
+var standbyMethods = map[string]bool{
+	pb.Admin_PromoteStandby_FullMethodName: true,
+}
+
+// StandbyInterceptor rejects every mutating RPC while the controller is a disaster
+// recovery standby, its registry is written by the primary site until it is promoted
+func StandbyInterceptor(standby func() bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if standby() && !readMethods[info.FullMethod] && !standbyMethods[info.FullMethod] {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s is not available on a standby, promote it with PromoteStandby", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StandbyStreamInterceptor is StandbyInterceptor for streaming RPCs
+func StandbyStreamInterceptor(standby func() bool) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if standby() && !readMethods[info.FullMethod] && !standbyMethods[info.FullMethod] {
+			return status.Errorf(codes.FailedPrecondition, "%s is not available on a standby, promote it with PromoteStandby", info.FullMethod)
+		}
+		return handler(srv, stream)
+	}
+}
+
+// PromoteStandby makes the standby site take over when the primary site is lost. The
+// primary's writes are refused from then on, point it at a new standby to fail back.
+func (s *AdminService) PromoteStandby(ctx context.Context, req *pb.PromoteStandbyRequest) (*pb.PromoteStandbyResponse, error) {
+	dr, ok := s.registry.(disasterRecovery)
+	if !ok {
+		return &pb.PromoteStandbyResponse{
+			Success: false,
+			Message: "Disaster recovery needs a Raft registry",
+		}, nil
+	}
+
+	if err := dr.Promote(); err != nil {
+		return &pb.PromoteStandbyResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to promote the standby: %v", err),
+		}, nil
+	}
+
+	replication := dr.ReplicationStatus()
+	log.Printf("Promoted the standby, replicated up to index %d of the primary", replication.ReplicatedIndex)
+
+	resp := &pb.PromoteStandbyResponse{
+		Success:         true,
+		Message:         "Standby promoted, it now serves writes",
+		ReplicatedIndex: replication.ReplicatedIndex,
+	}
+	if !replication.ReplicatedAt.IsZero() {
+		resp.ReplicatedAt = replication.ReplicatedAt.Unix()
+	}
+	return resp, nil
+}
+
+// GetReplicationStatus reports the role of the controller's site and how far the
+// standby is behind the primary
+func (s *AdminService) GetReplicationStatus(ctx context.Context, req *pb.GetReplicationStatusRequest) (*pb.GetReplicationStatusResponse, error) {
+	dr, ok := s.registry.(disasterRecovery)
+	if !ok {
+		return &pb.GetReplicationStatusResponse{
+			Success: true,
+			Message: "Disaster recovery needs a Raft registry",
+			Role:    "disabled",
+		}, nil
+	}
+
+	replication := dr.ReplicationStatus()
+	resp := &pb.GetReplicationStatusResponse{
+		Success:         true,
+		Message:         "Replication status retrieved successfully",
+		Role:            replication.Role,
+		Peer:            replication.Peer,
+		Index:           replication.Index,
+		ReplicatedIndex: replication.ReplicatedIndex,
+		LastError:       replication.LastError,
+	}
+	if resp.Role == "" {
+		resp.Role = "disabled"
+	}
+	if !replication.ReplicatedAt.IsZero() {
+		resp.ReplicatedAt = replication.ReplicatedAt.Unix()
+	}
+	if !replication.PromotedAt.IsZero() {
+		resp.PromotedAt = replication.PromotedAt.Unix()
+	}
+	return resp, nil
+}
@@ -0,0 +1,312 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/oci"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// registries slower than this are retried on the next check
+const resolveTimeout = 10 * time.Second
+
+// Types of the drift events posted to the webhook and published as CloudEvents
+const (
+	eventImageDrift  = "image.drift"
+	eventImagePinned = "image.pinned"
+)
+
+// DriftPolicy detects applications which may run another image than deployed because the
+// tag moved at the registry, e.g. a mutable tag was pushed again. A nil policy disables it.
+type DriftPolicy struct {
+	Resolver *oci.Resolver
+	Webhook  string // receives the drift events as JSON, they are only logged without it
+}
+
+// driftEvent is posted to the webhook when allocations drifted or an application was pinned
+type driftEvent struct {
+	Type           string    `json:"type"`
+	Application    string    `json:"application"`
+	Image          string    `json:"image"`
+	DeployedDigest string    `json:"deployed_digest"`
+	TagDigest      string    `json:"tag_digest"`
+	Allocations    []string  `json:"allocations,omitempty"`
+	Time           time.Time `json:"time"`
+}
+
+// recordImage records the digest the application's tag resolves to at deploy time
+func (s *ApplicationService) recordImage(ctx context.Context, req *pb.DeployRequest) {
+	if s.drift == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+
+	digest, err := s.drift.Resolver.Digest(ctx, req.Image)
+	if err != nil {
+		// an outdated record would report drift for the previous image
+		log.Printf("Drift detection: failed to resolve %s of %s, not checking it: %v", req.Image, req.Name, err)
+		if err := s.registry.DeleteDeployedImage(req.Name); err != nil {
+			log.Printf("Failed to remove the deployed image of %s: %v", req.Name, err)
+		}
+		return
+	}
+
+	image := store.DeployedImage{
+		Application: req.Name,
+		Image:       req.Image,
+		Digest:      digest,
+		DeployedAt:  time.Now().UTC(),
+		Redeploy:    req.PinOnDrift,
+	}
+	if err := s.registry.SaveDeployedImage(image); err != nil {
+		log.Printf("Failed to record the deployed image of %s: %v", req.Name, err)
+	}
+}
+
+// ListImageDrift compares the deployed images of applications with their registries as of
+// the last check
+func (s *ApplicationService) ListImageDrift(ctx context.Context, req *pb.ImageDriftRequest) (*pb.ImageDriftResponse, error) {
+	images, err := s.registry.DeployedImages()
+	if err != nil {
+		return &pb.ImageDriftResponse{
+			Message: fmt.Sprintf("Failed to list deployed images: %v", err),
+		}, nil
+	}
+
+	resp := &pb.ImageDriftResponse{}
+	for _, image := range images {
+		if (req.Name != "" && image.Application != req.Name) || (req.DriftedOnly && image.TagDigest == "") {
+			continue
+		}
+		resp.Images = append(resp.Images, toImageDrift(image))
+	}
+	if s.drift == nil {
+		resp.Message = "Drift detection is disabled"
+	} else {
+		resp.Message = fmt.Sprintf("%d deployed images", len(resp.Images))
+	}
+
+	return resp, nil
+}
+
+// RunDriftDetection resolves the tags of deployed images every interval until the context
+// is cancelled. With a replicated registry only the leader checks them.
+func (s *ApplicationService) RunDriftDetection(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopDrift, interval, func() error { return s.checkDrift(ctx) })
+		if err != nil {
+			log.Printf("Drift detection: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) checkDrift(ctx context.Context) error {
+	images, err := s.registry.DeployedImages()
+	if err != nil {
+		return fmt.Errorf("failed to list deployed images: %w", err)
+	}
+
+	s.reconciler.queue(loopDrift, len(images))
+	for _, image := range images {
+		s.reconciler.next(loopDrift)
+		// a pinned image cannot move
+		if oci.ParseReference(image.Image).Digest != "" {
+			continue
+		}
+		err := s.checkImage(ctx, image)
+		if err != nil {
+			log.Printf("Drift detection: %s: %v", image.Application, err)
+		}
+		s.reconciler.result(loopDrift, image.Application, err)
+	}
+
+	return nil
+}
+
+// checkImage resolves the tag of the deployed image. Once it moved, the allocations which
+// (re)started since the last check still resolving to the deployed digest have pulled
+// the image again and may run the moved tag.
+func (s *ApplicationService) checkImage(ctx context.Context, image store.DeployedImage) error {
+	job, allocations, err := s.orhClient.GetJobStatus(image.Application)
+	if err != nil {
+		return err
+	}
+	// rolled back or changed outside of the controller, the record is outdated
+	if taskImage(job, image.Application) != image.Image {
+		return nil
+	}
+
+	resolveCtx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	digest, err := s.drift.Resolver.Digest(resolveCtx, image.Image)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if digest == image.Digest {
+		if image.TagDigest != "" {
+			log.Printf("Drift detection: %s of %s resolves to the deployed digest again", image.Image, image.Application)
+		}
+		image.TagDigest, image.MovedAt, image.Drifted = "", time.Time{}, nil
+		image.CheckedAt = now
+		return s.registry.SaveDeployedImage(image)
+	}
+
+	if image.TagDigest != digest {
+		image.TagDigest = digest
+		image.MovedAt = image.CheckedAt
+		if image.MovedAt.IsZero() {
+			image.MovedAt = image.DeployedAt
+		}
+		image.AlertedAt = time.Time{}
+		s.reconciler.drifted(image.Application)
+		log.Printf("Drift detection: %s of %s moved from %s to %s", image.Image, image.Application, image.Digest, digest)
+	}
+	image.CheckedAt = now
+	image.Drifted = startedSince(allocations, image.Application, image.MovedAt)
+
+	switch {
+	case image.Redeploy:
+		pinned := oci.WithDigest(image.Image, image.Digest)
+		if err := s.pinImage(job, image.Application, image.Image, pinned); err != nil {
+			_ = s.registry.SaveDeployedImage(image)
+			return fmt.Errorf("failed to pin %s: %w", pinned, err)
+		}
+		s.raiseDrift(eventImagePinned, image)
+		image.Image, image.AlertedAt = pinned, now
+	case len(image.Drifted) > 0 && image.AlertedAt.IsZero():
+		s.raiseDrift(eventImageDrift, image)
+		image.AlertedAt = now
+	}
+
+	return s.registry.SaveDeployedImage(image)
+}
+
+// pinImage redeploys the job with the application's task pinned to the digest
+func (s *ApplicationService) pinImage(job *nmd.Job, application, image, pinned string) error {
+	for _, group := range job.TaskGroups {
+		for _, task := range group.Tasks {
+			if task.Name == application && task.Config["image"] == image {
+				task.Config["image"] = pinned
+			}
+		}
+	}
+
+	_, err := s.orhClient.RegisterJob(job)
+	return err
+}
+
+// raiseDrift logs the event and posts it to the webhook
+func (s *ApplicationService) raiseDrift(eventType string, image store.DeployedImage) {
+	event := driftEvent{
+		Type:           eventType,
+		Application:    image.Application,
+		Image:          image.Image,
+		DeployedDigest: image.Digest,
+		TagDigest:      image.TagDigest,
+		Allocations:    image.Drifted,
+		Time:           time.Now().UTC(),
+	}
+
+	if eventType == eventImagePinned {
+		log.Printf("Drift detection: %s redeployed pinned to %s", image.Application, image.Digest)
+	} else {
+		log.Printf("Drift detection: %d allocations of %s may run %s instead of %s", len(image.Drifted), image.Application, image.TagDigest, image.Digest)
+	}
+
+	// the CloudEvents are typed io.controlplane.image.drift and io.controlplane.image.pinned
+	s.events.Publish(eventType, image.Application, event)
+
+	postWebhook(s.drift.Webhook, "Drift detection", eventType, image.Application, event)
+}
+
+// postWebhook posts the event as JSON in the background, failures are logged prefixed with
+// the loop raising it. Nothing is posted without a webhook.
+func postWebhook(webhook, loop, eventType, application string, event any) {
+	if webhook == "" {
+		return
+	}
+	go func() {
+		data, _ := json.Marshal(event)
+		client := &http.Client{Timeout: resolveTimeout}
+		resp, err := client.Post(webhook, "application/json", bytes.NewReader(data))
+		if err != nil {
+			log.Printf("%s: failed to post %s of %s: %v", loop, eventType, application, err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			log.Printf("%s: webhook rejected %s of %s: %s", loop, eventType, application, resp.Status)
+		}
+	}()
+}
+
+// taskImage is the image of the application's task in the job
+func taskImage(job *nmd.Job, application string) string {
+	for _, group := range job.TaskGroups {
+		for _, task := range group.Tasks {
+			if task.Name == application {
+				image, _ := task.Config["image"].(string)
+				return image
+			}
+		}
+	}
+	return ""
+}
+
+// startedSince lists the running allocations whose task started or restarted after since
+func startedSince(allocations []*nmd.AllocationListStub, task string, since time.Time) []string {
+	var drifted []string
+	for _, alloc := range allocations {
+		if alloc.DesiredStatus != "run" || alloc.ClientStatus != "running" {
+			continue
+		}
+		state := alloc.TaskStates[task]
+		if state == nil {
+			continue
+		}
+		if state.StartedAt.After(since) || state.LastRestart.After(since) {
+			drifted = append(drifted, alloc.ID)
+		}
+	}
+	return drifted
+}
+
+func toImageDrift(image store.DeployedImage) *pb.ImageDrift {
+	drift := &pb.ImageDrift{
+		Application:        image.Application,
+		Image:              image.Image,
+		DeployedDigest:     image.Digest,
+		TagDigest:          image.TagDigest,
+		DriftedAllocations: image.Drifted,
+		PinOnDrift:         image.Redeploy,
+		DeployedAt:         image.DeployedAt.Unix(),
+	}
+	if !image.CheckedAt.IsZero() {
+		drift.CheckedAt = image.CheckedAt.Unix()
+	}
+	if !image.MovedAt.IsZero() {
+		drift.MovedAt = image.MovedAt.Unix()
+	}
+	return drift
+}
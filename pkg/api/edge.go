@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// BootstrapEdgeProxy deploys Traefik with the controller's entrypoints and cert resolvers,
+// which makes a fresh cluster ingress-ready. Calling it again updates the proxy.
+func (s *AdminService) BootstrapEdgeProxy(ctx context.Context, req *pb.BootstrapEdgeProxyRequest) (*pb.BootstrapEdgeProxyResponse, error) {
+	proxy := s.edgeProxyFor(req)
+	job, err := proxy.Job()
+	if err == nil {
+		err = s.orhClient.Capabilities().CheckNodePool(proxy.NodePool)
+	}
+	if err != nil {
+		return &pb.BootstrapEdgeProxyResponse{
+			Message: fmt.Sprintf("Invalid edge proxy config: %v", err),
+		}, nil
+	}
+	// rendered by Job already, it cannot fail anymore
+	static, _ := proxy.StaticConfig()
+	dynamic, _ := proxy.DynamicConfig()
+
+	resp := &pb.BootstrapEdgeProxyResponse{
+		StaticConfig:  static,
+		DynamicConfig: dynamic,
+	}
+	if req.DryRun {
+		resp.Success = true
+		resp.Message = "Edge proxy config rendered, nothing deployed"
+		return resp, nil
+	}
+
+	registered, err := s.orhClient.RegisterJob(job)
+	if err != nil {
+		resp.Message = fmt.Sprintf("Failed to deploy edge proxy: %v", err)
+		return resp, nil
+	}
+
+	resp.Success = true
+	resp.EvalId = registered.EvalID
+	resp.Message = fmt.Sprintf("Edge proxy %s submitted to %s", *job.ID, proxy.Image)
+	return resp, nil
+}
+
+// edgeProxyFor applies the overrides of the request to the controller's edge proxy
+func (s *AdminService) edgeProxyFor(req *pb.BootstrapEdgeProxyRequest) nomad.EdgeProxy {
+	proxy := s.edgeProxy
+	if req.Image != "" {
+		proxy.Image = req.Image
+	}
+	if len(req.Datacenters) > 0 {
+		proxy.Datacenters = req.Datacenters
+	}
+	proxy.NodeClass = req.NodeClass
+	proxy.NodePool = req.NodePool
+	proxy.Region = req.Region
+	proxy.RedirectHTTPS = req.RedirectHttps
+	proxy.Dashboard = req.Dashboard
+	proxy.WakeURL = req.WakeUrl
+	return proxy
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"log"
+	"slices"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/consul"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// EgressPolicy is how the cluster enforces the egress rules of applications, a nil
+// policy only records them
+type EgressPolicy struct {
+	Mode          string // nomad.EgressModeHints, nomad.EgressModeConsul or nomad.EgressModeIptables
+	FirewallImage string
+	Consul        *consul.Client // manages the intentions of the Consul mode
+}
+
+func egressFromSpec(req *pb.DeployRequest) *nomad.Egress {
+	if req.Egress == nil || len(req.Egress.Rules) == 0 {
+		return nil
+	}
+
+	egress := &nomad.Egress{}
+	for _, rule := range req.Egress.Rules {
+		ports := make([]int, len(rule.Ports))
+		for i, port := range rule.Ports {
+			ports[i] = int(port)
+		}
+		egress.Rules = append(egress.Rules, nomad.EgressRule{
+			Service:  rule.Service,
+			CIDR:     rule.Cidr,
+			Ports:    ports,
+			Protocol: rule.Protocol,
+		})
+	}
+	return egress
+}
+
+// apply renders the egress rules of the job in the cluster's mode
+func (p *EgressPolicy) apply(jobTemplate *nomad.JobTemplate) error {
+	if p == nil {
+		return nil
+	}
+	// an application others are allowed to call joins the mesh without egress rules
+	if jobTemplate.Egress == nil && len(jobTemplate.AllowFrom) > 0 && p.Mode == nomad.EgressModeConsul {
+		jobTemplate.Egress = &nomad.Egress{}
+	}
+	if jobTemplate.Egress == nil {
+		return nil
+	}
+
+	jobTemplate.Egress.Mode = p.Mode
+	jobTemplate.Egress.FirewallImage = p.FirewallImage
+	if err := jobTemplate.Egress.Validate(jobTemplate); err != nil {
+		return err
+	}
+	if service := jobTemplate.ConnectService(); service != "" {
+		jobTemplate.Meta[nomad.MetaConnectService] = service
+	}
+	return nil
+}
+
+// syncIntentions allows the application's service to reach the services of its egress
+// rules and revokes the intentions of removed rules
+func (p *EgressPolicy) syncIntentions(jobTemplate *nomad.JobTemplate) error {
+	if p == nil || p.Mode != nomad.EgressModeConsul {
+		return nil
+	}
+
+	application := jobTemplate.Name
+	existing, err := p.Consul.Intentions(application)
+	if err != nil {
+		return err
+	}
+
+	var services []string
+	if jobTemplate.Egress != nil {
+		services = jobTemplate.Egress.Services()
+		source := jobTemplate.ConnectService()
+		for _, service := range services {
+			if err := p.Consul.AllowIntention(application, source, service); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, intention := range existing {
+		if !slices.Contains(services, intention.DestinationName) {
+			if err := p.Consul.ReleaseIntention(intention, consul.MetaApplication); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *EgressPolicy) removeIntentions(application string) {
+	if p == nil || p.Mode != nomad.EgressModeConsul {
+		return
+	}
+
+	intentions, err := p.Consul.Intentions(application)
+	if err != nil {
+		log.Printf("Failed to list the intentions of %s: %v", application, err)
+		return
+	}
+	for _, intention := range intentions {
+		if err := p.Consul.ReleaseIntention(intention, consul.MetaApplication); err != nil {
+			log.Printf("Failed to delete intention %s => %s: %v", intention.SourceName, intention.DestinationName, err)
+		}
+	}
+}
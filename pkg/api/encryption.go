@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iuliansafta/control-plane/pkg/kms"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// sealForTenant encrypts a record with the tenant's data key, platform records
+// without a tenant are stored as is
+func sealForTenant(ctx context.Context, registry store.Store, sealer *kms.Sealer, tenantName string, data []byte) ([]byte, error) {
+	if tenantName == "" || len(data) == 0 {
+		return data, nil
+	}
+
+	tenant, err := registry.Tenant(tenantName)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := sealer.Seal(ctx, tenant.KeyID, tenant.DataKey, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt data of tenant %s: %w", tenantName, err)
+	}
+
+	return sealed, nil
+}
+
+// openForTenant decrypts a record sealed by sealForTenant
+func openForTenant(ctx context.Context, registry store.Store, sealer *kms.Sealer, tenantName string, data []byte) ([]byte, error) {
+	if tenantName == "" || len(data) == 0 {
+		return data, nil
+	}
+
+	tenant, err := registry.Tenant(tenantName)
+	if err != nil {
+		return nil, err
+	}
+
+	opened, err := sealer.Open(ctx, tenant.KeyID, tenant.DataKey, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data of tenant %s: %w", tenantName, err)
+	}
+
+	return opened, nil
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// DefaultSensitiveEnv are the patterns of the environment keys whose values the revision
+// history redacts when the controller is given none
+var DefaultSensitiveEnv = []string{"*PASSWORD*", "*PASSWD*", "*SECRET*", "*TOKEN*", "*CREDENTIAL*", "*PRIVATE*", "*_KEY", "*_KEY_*", "*_DSN"}
+
+// redacted replaces the sensitive values of the revision history
+const redacted = "<redacted>"
+
+// minSecretLength keeps short secrets, e.g. a port kept in a variable, from redacting every
+// value containing them
+const minSecretLength = 6
+
+// Changes of an environment variable between revisions
+const (
+	envAdded   = "added"
+	envChanged = "changed"
+	envRemoved = "removed"
+)
+
+// sensitiveKey matches an environment key against the patterns, case insensitive, where *
+// matches any characters
+func sensitiveKey(key string, patterns []string) bool {
+	key = strings.ToUpper(key)
+	for _, pattern := range patterns {
+		parts := strings.Split(strings.ToUpper(pattern), "*")
+		rest, ok := strings.CutPrefix(key, parts[0])
+		if !ok {
+			continue
+		}
+		if len(parts) == 1 {
+			if rest == "" {
+				return true
+			}
+			continue
+		}
+		for _, part := range parts[1 : len(parts)-1] {
+			index := strings.Index(rest, part)
+			if index < 0 {
+				ok = false
+				break
+			}
+			rest = rest[index+len(part):]
+		}
+		if ok && strings.HasSuffix(rest, parts[len(parts)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// envRedactor tells which values of an application's environment the history redacts:
+// those of keys matching the sensitive patterns and those containing one of its secrets
+type envRedactor struct {
+	patterns []string
+	secrets  []string
+}
+
+func (r *envRedactor) redacts(key, value string) bool {
+	if sensitiveKey(key, r.patterns) {
+		return true
+	}
+	for _, secret := range r.secrets {
+		if strings.Contains(value, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// envRedactor reads the secrets of the application from its Nomad variable, the passwords
+// of its add-ons among them
+func (s *ApplicationService) envRedactor(jobID, namespace string) *envRedactor {
+	redactor := &envRedactor{patterns: s.sensitiveEnv}
+	if s.orhClient == nil {
+		return redactor
+	}
+	client, err := s.orhClient.InNamespace(namespace)
+	if err != nil {
+		return redactor
+	}
+	items, err := client.VariableItems(nomad.JobVariablePath(jobID))
+	if err != nil {
+		return redactor
+	}
+	for _, secret := range items {
+		if len(secret) >= minSecretLength {
+			redactor.secrets = append(redactor.secrets, secret)
+		}
+	}
+	return redactor
+}
+
+// diffEnv lists the changes of the environment from one spec to the next by key, with the
+// values the redactor redacts replaced. A redacted value still shows whether it changed.
+func diffEnv(previous, next map[string]string, redactor *envRedactor) []store.EnvChange {
+	keys := slices.Collect(maps.Keys(previous))
+	for key := range next {
+		if _, ok := previous[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var changes []store.EnvChange
+	for _, key := range keys {
+		old, hadOld := previous[key]
+		value, hasNew := next[key]
+		change := store.EnvChange{Key: key, Old: old, New: value}
+		switch {
+		case !hadOld:
+			change.Change = envAdded
+		case !hasNew:
+			change.Change = envRemoved
+		case old != value:
+			change.Change = envChanged
+		default:
+			continue
+		}
+		if (hadOld && redactor.redacts(key, old)) || (hasNew && redactor.redacts(key, value)) {
+			change.Redacted = true
+			if hadOld {
+				change.Old = redacted
+			}
+			if hasNew {
+				change.New = redacted
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// envChanges diffs the environment of a spec with the one of the latest revision of its
+// job, the changes are sealed with the tenant's data key like the spec. The first revision
+// adds every variable.
+func (s *ApplicationService) envChanges(ctx context.Context, jobID string, req *pb.DeployRequest) ([]byte, error) {
+	var previous map[string]string
+	if revision, err := s.registry.Revision(jobID, 0); err == nil {
+		spec, err := s.revisionSpec(ctx, revision)
+		if err != nil {
+			return nil, err
+		}
+		previous = spec.Env
+	}
+
+	changes := diffEnv(previous, req.Env, s.envRedactor(jobID, req.Namespace))
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	return sealForTenant(ctx, s.registry, s.sealer, req.Tenant, data)
+}
+
+// revisionEnvChanges opens the environment changes of a revision
+func (s *ApplicationService) revisionEnvChanges(ctx context.Context, revision store.Revision) ([]*pb.EnvChange, error) {
+	if len(revision.EnvChanges) == 0 {
+		return nil, nil
+	}
+	data, err := openForTenant(ctx, s.registry, s.sealer, revision.Tenant, revision.EnvChanges)
+	if err != nil {
+		return nil, err
+	}
+	var changes []store.EnvChange
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return nil, fmt.Errorf("invalid environment changes: %w", err)
+	}
+
+	envChanges := make([]*pb.EnvChange, 0, len(changes))
+	for _, change := range changes {
+		envChanges = append(envChanges, &pb.EnvChange{
+			Key:      change.Key,
+			Change:   change.Change,
+			OldValue: change.Old,
+			NewValue: change.New,
+			Redacted: change.Redacted,
+		})
+	}
+	return envChanges, nil
+}
+
+// redactEnv replaces the values of the spec's environment the redactor redacts, the spec
+// is a copy
+func redactEnv(spec *pb.DeployRequest, redactor *envRedactor) {
+	for key, value := range spec.Env {
+		if redactor.redacts(key, value) {
+			spec.Env[key] = redacted
+		}
+	}
+}
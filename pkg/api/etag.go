@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCache keeps the last response of the REST endpoints by URL and the namespaces of
+// the token. Requests within the TTL are answered from it without reaching Nomad, with 304
+// Not Modified when their If-None-Match has its ETag, so polling dashboards skip unchanged
+// payloads.
+type responseCache struct {
+	ttl       time.Duration // 0 only revalidates the ETag of fresh responses
+	mu        sync.Mutex
+	responses map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	etag        string
+	contentType string
+	body        []byte
+	at          time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{ttl: ttl, responses: make(map[string]cachedResponse)}
+}
+
+func (c *responseCache) get(key string) (cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.responses[key]
+	if !ok || time.Since(cached.at) >= c.ttl {
+		return cachedResponse{}, false
+	}
+	return cached, true
+}
+
+func (c *responseCache) put(key string, response cachedResponse) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the URLs vary with their query, drop the expired responses instead of growing
+	for url, cached := range c.responses {
+		if time.Since(cached.at) >= c.ttl {
+			delete(c.responses, url)
+		}
+	}
+	c.responses[key] = response
+}
+
+// cached serves the handler's successful responses with an ETag and Cache-Control, and
+// from the cache while they are fresh
+func (c *responseCache) cached(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// the tokens scoped to namespaces see other responses for the same URL
+		key := r.URL.RequestURI()
+		if scoped := tokenNamespaces(r.Context()); scoped != nil {
+			key += " " + strings.Join(scoped, ",")
+		}
+		if cached, ok := c.get(key); ok {
+			c.write(w, r, cached)
+			return
+		}
+
+		recorder := &responseRecorder{header: make(http.Header), code: http.StatusOK}
+		handler(recorder, r)
+		if recorder.code != http.StatusOK {
+			for name, values := range recorder.header {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(recorder.code)
+			_, _ = w.Write(recorder.body.Bytes())
+			return
+		}
+
+		sum := sha256.Sum256(recorder.body.Bytes())
+		response := cachedResponse{
+			etag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+			contentType: recorder.header.Get("Content-Type"),
+			body:        recorder.body.Bytes(),
+			at:          time.Now(),
+		}
+		c.put(key, response)
+		c.write(w, r, response)
+	}
+}
+
+func (c *responseCache) write(w http.ResponseWriter, r *http.Request, response cachedResponse) {
+	w.Header().Set("ETag", response.etag)
+	if maxAge := c.ttl - time.Since(response.at); maxAge >= time.Second {
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds())))
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	if etagMatch(r.Header.Get("If-None-Match"), response.etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", response.contentType)
+	_, _ = w.Write(response.body)
+}
+
+// etagMatch reports whether an If-None-Match header lists the ETag, compared weakly as
+// RFC 9110 requires
+func etagMatch(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// responseRecorder keeps a response to cache it before it is written
+type responseRecorder struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	r.code = code
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	return r.body.Write(data)
+}
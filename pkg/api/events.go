@@ -0,0 +1,70 @@
+package api
+
+import (
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/events"
+)
+
+// lifecycleEvent is the data of the CloudEvents about an application
+type lifecycleEvent struct {
+	Application string  `json:"application"`
+	JobID       string  `json:"job_id,omitempty"`
+	Tenant      string  `json:"tenant,omitempty"`
+	Image       string  `json:"image,omitempty"`
+	EvalID      string  `json:"eval_id,omitempty"`
+	TaskGroup   string  `json:"task_group,omitempty"`
+	Count       *int32  `json:"count,omitempty"`
+	Version     *uint64 `json:"version,omitempty"`
+	Revision    int     `json:"revision,omitempty"`
+	Reverted    bool    `json:"reverted,omitempty"`
+	Region      string  `json:"region,omitempty"`
+	Action      string  `json:"action,omitempty"`
+	AllocID     string  `json:"alloc_id,omitempty"`
+	ExitCode    *int32  `json:"exit_code,omitempty"`
+	Caller      string  `json:"caller,omitempty"` // address of the client calling the RPC
+	Message     string  `json:"message,omitempty"`
+}
+
+// publish sends an event about the application of a job
+func (s *ApplicationService) publish(eventType, jobID string, event lifecycleEvent) {
+	if s.events == nil {
+		return
+	}
+	name := s.jobName(jobID)
+	event.Application, event.JobID, event.Tenant = name.Application, jobID, name.Tenant
+	s.events.Publish(eventType, name.Application, event)
+}
+
+// publishDeploy sends the outcome of a deployment, rejected specs are deploy failures
+func (s *ApplicationService) publishDeploy(req *pb.DeployRequest, resp *pb.DeployResponse) {
+	if s.events == nil || resp == nil {
+		return
+	}
+
+	event := lifecycleEvent{
+		Application: req.Name,
+		JobID:       resp.JobId,
+		Tenant:      req.Tenant,
+		Image:       req.Image,
+		EvalID:      resp.DeploymentId,
+		Message:     resp.Message,
+	}
+	eventType := events.ApplicationDeployed
+	if resp.Status == "FAILED" {
+		eventType = events.ApplicationDeployFailed
+	}
+	s.events.Publish(eventType, req.Name, event)
+}
+
+// ApplicationOwner returns the team owning an application and the labels of its spec as
+// the search index recorded them, the notification routes match on them
+func (s *ApplicationService) ApplicationOwner(application string) (string, map[string]string) {
+	jobID, err := s.resolveJobID(application)
+	if err != nil {
+		return "", nil
+	}
+	if entry, err := s.registry.SearchEntry(jobID); err == nil {
+		return entry.Owner, entry.Labels
+	}
+	return s.jobName(jobID).Tenant, nil
+}
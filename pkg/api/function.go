@@ -0,0 +1,275 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+const (
+	defaultMaxConcurrency  = 10
+	defaultFunctionTimeout = time.Minute
+	maxFunctionOutput      = 64 << 10
+	recentInvocations      = 20
+)
+
+// functionSlots limits how many invocations of each function run at the same time
+type functionSlots struct {
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+func (f *functionSlots) get(function string, capacity int) chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.slots == nil {
+		f.slots = make(map[string]chan struct{})
+	}
+
+	// a redeploy may change the concurrency, running invocations release their old slot
+	slot, ok := f.slots[function]
+	if !ok || cap(slot) != capacity {
+		slot = make(chan struct{}, capacity)
+		f.slots[function] = slot
+	}
+
+	return slot
+}
+
+func (f *functionSlots) inFlight(function string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.slots[function])
+}
+
+// applyFunctionSpec turns the job into a parameterized batch job, every invocation
+// dispatches a short-lived instance of it so an idle function runs no allocations.
+func applyFunctionSpec(jobTemplate *nomad.JobTemplate, req *pb.DeployRequest) error {
+	if req.Traefik != nil && req.Traefik.Enable {
+		return fmt.Errorf("functions are invoked through InvokeFunction and cannot be routed by Traefik")
+	}
+
+	concurrency := defaultMaxConcurrency
+	timeout := defaultFunctionTimeout
+	var metaKeys []string
+
+	if fn := req.Function; fn != nil {
+		if fn.MaxConcurrency < 0 || fn.TimeoutSeconds < 0 {
+			return fmt.Errorf("function concurrency and timeout cannot be negative")
+		}
+		if fn.MaxConcurrency > 0 {
+			concurrency = int(fn.MaxConcurrency)
+		}
+		if fn.TimeoutSeconds > 0 {
+			timeout = time.Duration(fn.TimeoutSeconds) * time.Second
+		}
+		if fn.Artifact != "" {
+			jobTemplate.Artifacts = append(jobTemplate.Artifacts, fn.Artifact)
+		}
+		metaKeys = fn.MetaKeys
+	}
+
+	jobTemplate.Type = "batch"
+	jobTemplate.Instances = 1
+	jobTemplate.Parameterized = &nomad.Parameterized{
+		Payload:      "optional",
+		MetaOptional: metaKeys,
+	}
+	jobTemplate.Meta[nomad.MetaMaxConcurrency] = strconv.Itoa(concurrency)
+	jobTemplate.Meta[nomad.MetaTimeout] = timeout.String()
+
+	return nil
+}
+
+// InvokeFunction dispatches a function and waits for it to finish.
+func (s *ApplicationService) InvokeFunction(ctx context.Context, req *pb.InvokeRequest) (*pb.InvokeResponse, error) {
+	job, _, err := s.orhClient.GetJobStatus(req.Name)
+	if err != nil {
+		return &pb.InvokeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to find function: %v", err),
+		}, nil
+	}
+	if job.ParameterizedJob == nil {
+		return &pb.InvokeResponse{
+			Success: false,
+			Message: fmt.Sprintf("%s is not a function deployment", req.Name),
+		}, nil
+	}
+
+	concurrency, err := strconv.Atoi(job.Meta[nomad.MetaMaxConcurrency])
+	if err != nil || concurrency <= 0 {
+		concurrency = defaultMaxConcurrency
+	}
+	timeout, err := time.ParseDuration(job.Meta[nomad.MetaTimeout])
+	if err != nil || timeout <= 0 {
+		timeout = defaultFunctionTimeout
+	}
+
+	// an invocation queues for a slot as long as it may run, the caller's deadline or
+	// cancellation is reported as such
+	queuedAt := time.Now()
+	queue := time.NewTimer(timeout)
+	defer queue.Stop()
+	slot := s.functions.get(req.Name, concurrency)
+	select {
+	case slot <- struct{}{}:
+	case <-queue.C:
+		return &pb.InvokeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Function %s is at its concurrency limit of %d, no slot freed up within %s", req.Name, concurrency, timeout),
+		}, nil
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	defer func() { <-slot }()
+
+	invocation := store.Invocation{
+		Function:  req.Name,
+		StartedAt: time.Now(),
+		Queued:    time.Since(queuedAt),
+	}
+
+	dispatch, err := s.orhClient.DispatchJob(req.Name, req.Payload, req.Meta)
+	if err != nil {
+		return &pb.InvokeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to invoke function: %v", err),
+		}, nil
+	}
+	invocation.ID = dispatch.DispatchedJobID
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	alloc, err := s.orhClient.WaitForCompletion(runCtx, dispatch.DispatchedJobID)
+	if err != nil {
+		// stop the invocation instead of letting it run unobserved
+		if stopErr := s.orhClient.DeleteJob(dispatch.DispatchedJobID); stopErr != nil {
+			log.Printf("Failed to stop invocation %s: %v", dispatch.DispatchedJobID, stopErr)
+		}
+		invocation.Status = "timeout"
+		invocation.ExitCode = -1
+	} else {
+		invocation.Status = alloc.ClientStatus
+		invocation.ExitCode = exitCode(alloc, req.Name)
+	}
+	invocation.Duration = time.Since(invocation.StartedAt)
+
+	if err := s.registry.SaveInvocation(invocation); err != nil {
+		log.Printf("Failed to record invocation %s: %v", invocation.ID, err)
+	}
+
+	resp := &pb.InvokeResponse{
+		Success:    invocation.Status == nmd.AllocClientStatusComplete && invocation.ExitCode == 0,
+		Invocation: toInvocationStatus(invocation),
+	}
+
+	if alloc != nil {
+		output, err := s.orhClient.ReadTaskLog(alloc.ID, req.Name, "stdout", maxFunctionOutput)
+		if err != nil {
+			log.Printf("Failed to read output of invocation %s: %v", invocation.ID, err)
+		}
+		resp.Output = output
+	}
+
+	if resp.Success {
+		resp.Message = "Function invoked successfully"
+	} else {
+		resp.Message = fmt.Sprintf("Function invocation %s (exit code %d)", invocation.Status, invocation.ExitCode)
+	}
+
+	return resp, nil
+}
+
+// GetFunctionMetrics aggregates the recorded invocations of a function.
+func (s *ApplicationService) GetFunctionMetrics(ctx context.Context, req *pb.FunctionMetricsRequest) (*pb.FunctionMetricsResponse, error) {
+	invocations, err := s.registry.Invocations(req.Name)
+	if err != nil {
+		return &pb.FunctionMetricsResponse{
+			Name:    req.Name,
+			Message: fmt.Sprintf("Failed to load invocations: %v", err),
+		}, nil
+	}
+
+	resp := &pb.FunctionMetricsResponse{
+		Name:        req.Name,
+		Invocations: int64(len(invocations)),
+		InFlight:    int32(s.functions.inFlight(req.Name)),
+		Message:     "Function metrics retrieved successfully",
+	}
+
+	durations := make([]time.Duration, 0, len(invocations))
+	for i, invocation := range invocations {
+		if invocation.Status != nmd.AllocClientStatusComplete || invocation.ExitCode != 0 {
+			resp.Failures++
+		}
+		durations = append(durations, invocation.Duration)
+
+		if i < recentInvocations {
+			resp.Recent = append(resp.Recent, toInvocationStatus(invocation))
+		}
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	resp.P50DurationMs = percentile(durations, 50).Milliseconds()
+	resp.P95DurationMs = percentile(durations, 95).Milliseconds()
+
+	return resp, nil
+}
+
+func toInvocationStatus(invocation store.Invocation) *pb.Invocation {
+	return &pb.Invocation{
+		InvocationId: invocation.ID,
+		Status:       invocation.Status,
+		ExitCode:     int32(invocation.ExitCode),
+		DurationMs:   invocation.Duration.Milliseconds(),
+		QueuedMs:     invocation.Queued.Milliseconds(),
+		StartedAt:    invocation.StartedAt.Unix(),
+	}
+}
+
+// exitCode finds the exit code of the task from its termination event
+func exitCode(alloc *nmd.AllocationListStub, task string) int {
+	state, ok := alloc.TaskStates[task]
+	if !ok {
+		return -1
+	}
+
+	for i := len(state.Events) - 1; i >= 0; i-- {
+		event := state.Events[i]
+		if event.Type != nmd.TaskTerminated {
+			continue
+		}
+		if code, err := strconv.Atoi(event.Details["exit_code"]); err == nil {
+			return code
+		}
+		return event.ExitCode
+	}
+
+	if state.Failed {
+		return -1
+	}
+	return 0
+}
+
+// percentile expects sorted durations
+func percentile(durations []time.Duration, p int) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	return durations[(len(durations)-1)*p/100]
+}
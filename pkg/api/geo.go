@@ -0,0 +1,415 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/dns"
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+const (
+	geoTTL         = 60 // seconds, resolvers pick up a failover within a minute
+	geoSyncTimeout = 30 * time.Second
+)
+
+// continents are the continent codes of geo routing, other locations are country codes
+var continents = map[string]bool{"AF": true, "AN": true, "AS": true, "EU": true, "NA": true, "OC": true, "SA": true}
+
+var geoLocation = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// validateGeo checks the geo routing of a spec without contacting the regions
+func validateGeo(geo *pb.GeoRouting) []error {
+	var errs []error
+	if normalizeHost(geo.Hostname) == "" {
+		errs = append(errs, fmt.Errorf("geo routing needs a hostname"))
+	}
+	if len(geo.Targets) < 2 {
+		errs = append(errs, fmt.Errorf("geo routing needs at least two target regions"))
+	}
+
+	regions := make(map[string]bool)
+	locations := make(map[string]bool)
+	located := 0
+	for _, target := range geo.Targets {
+		if target.Region == "" {
+			errs = append(errs, fmt.Errorf("geo target needs a region"))
+		} else if regions[target.Region] {
+			errs = append(errs, fmt.Errorf("geo target region %s is listed twice", target.Region))
+		}
+		regions[target.Region] = true
+		if target.Weight < 0 {
+			errs = append(errs, fmt.Errorf("geo target %s: weight cannot be negative", target.Region))
+		}
+		if len(target.Locations) > 0 {
+			located++
+		}
+		for _, location := range target.Locations {
+			if !geoLocation.MatchString(location) {
+				errs = append(errs, fmt.Errorf("geo target %s: location %q must be a continent or country code, e.g. EU or DE", target.Region, location))
+			} else if locations[location] {
+				errs = append(errs, fmt.Errorf("geo location %s is routed to several regions", location))
+			}
+			locations[location] = true
+		}
+	}
+	if located > 0 && located < len(geo.Targets) {
+		errs = append(errs, fmt.Errorf("either every geo target has locations or none, routing is geographic or weighted"))
+	}
+	return errs
+}
+
+// checkGeo checks that the controller can route the regions of a spec
+func (s *ApplicationService) checkGeo(geo *pb.GeoRouting) error {
+	if s.dns == nil {
+		return fmt.Errorf("geo routing needs the controller's -dns-provider")
+	}
+	recordType := ""
+	for _, target := range geo.Targets {
+		ingress, err := s.ingressOf(target.Region)
+		if err != nil {
+			return err
+		}
+		if recordType != "" && ingressType(ingress) != recordType {
+			return fmt.Errorf("the ingress of region %s is not of the same record type as the other regions", target.Region)
+		}
+		recordType = ingressType(ingress)
+	}
+	return nil
+}
+
+// ingressOf returns the addresses of a region's edge proxy from the regions config
+func (s *ApplicationService) ingressOf(region string) ([]string, error) {
+	if s.placement != nil {
+		for _, r := range s.placement.Regions {
+			if r.Name == region && len(r.Ingress) > 0 {
+				return r.Ingress, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("region %s has no ingress in the controller's -placement-config", region)
+}
+
+// ingressType is the record type pointing at the ingress, IP addresses or a single hostname
+func ingressType(ingress []string) string {
+	ip := net.ParseIP(ingress[0])
+	switch {
+	case ip == nil:
+		return "CNAME"
+	case ip.To4() == nil:
+		return "AAAA"
+	default:
+		return "A"
+	}
+}
+
+// deployGeo registers the job in the other target regions, withdraws it from the regions
+// no longer targeted and publishes the records of the route
+func (s *ApplicationService) deployGeo(req *pb.DeployRequest, jobID string, job *nmd.Job) error {
+	previous, err := s.registry.GeoRoute(jobID)
+	if err != nil {
+		previous = store.GeoRoute{}
+	}
+	if req.Geo == nil {
+		if previous.Application == "" {
+			return nil
+		}
+		return s.removeGeo(previous, req.Region)
+	}
+
+	route := store.GeoRoute{
+		Application: jobID,
+		Hostname:    normalizeHost(req.Geo.Hostname),
+		Failover:    req.Geo.Failover,
+		Health:      make(map[string]store.GeoHealth),
+		Records:     previous.Records,
+		UpdatedAt:   time.Now(),
+	}
+	for i, target := range req.Geo.Targets {
+		route.Targets = append(route.Targets, store.GeoTarget{
+			Region:    target.Region,
+			Weight:    max(target.Weight, 1),
+			Locations: target.Locations,
+		})
+		health, ok := previous.Health[target.Region]
+		if !ok {
+			health = store.GeoHealth{Healthy: true, ChangedAt: route.UpdatedAt}
+		}
+		route.Health[target.Region] = health
+
+		// the first region was deployed to by the request
+		if i == 0 {
+			continue
+		}
+		regional := *job
+		regional.Region = &target.Region
+		if _, err := s.orhClient.RegisterJob(&regional); err != nil {
+			return fmt.Errorf("failed to deploy to region %s: %w", target.Region, err)
+		}
+	}
+
+	for _, target := range previous.Targets {
+		if !slices.ContainsFunc(route.Targets, func(t store.GeoTarget) bool { return t.Region == target.Region }) {
+			s.deleteRegionalJob(jobID, target.Region)
+		}
+	}
+
+	err = s.syncGeo(&route)
+	if saveErr := s.registry.SaveGeoRoute(route); saveErr != nil {
+		log.Printf("Failed to record the geo route of %s: %v", jobID, saveErr)
+	}
+	return err
+}
+
+// removeGeo withdraws an application from the regions of its route except the one it
+// keeps running in, and deletes its records
+func (s *ApplicationService) removeGeo(route store.GeoRoute, keep string) error {
+	for _, target := range route.Targets {
+		if target.Region != keep {
+			s.deleteRegionalJob(route.Application, target.Region)
+		}
+	}
+
+	if s.dns == nil {
+		return fmt.Errorf("deleting the records of %s needs the controller's -dns-provider", route.Hostname)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), geoSyncTimeout)
+	defer cancel()
+	if err := s.dns.Apply(ctx, nil, route.Records); err != nil {
+		return fmt.Errorf("failed to delete the records of %s: %w", route.Hostname, err)
+	}
+	return s.registry.DeleteGeoRoute(route.Application)
+}
+
+func (s *ApplicationService) deleteRegionalJob(jobID, region string) {
+	client, err := s.orhClient.InRegion(region)
+	if err == nil {
+		err = client.DeleteJob(jobID)
+	}
+	if err != nil {
+		log.Printf("Failed to delete %s in region %s: %v", jobID, region, err)
+	}
+}
+
+// routedTargets are the regions receiving traffic: the healthy ones with failover, all of
+// them without failover or when none is healthy
+func routedTargets(route store.GeoRoute) []store.GeoTarget {
+	if !route.Failover {
+		return route.Targets
+	}
+	var routed []store.GeoTarget
+	for _, target := range route.Targets {
+		if health, ok := route.Health[target.Region]; !ok || health.Healthy {
+			routed = append(routed, target)
+		}
+	}
+	if len(routed) == 0 {
+		return route.Targets
+	}
+	return routed
+}
+
+// geoRecords are the records of a route. Weighted routing has a record per routed region,
+// geographic routing one per location, served by the first routed region while the
+// location's region is not routed, and a default one for the users matching no location.
+func (s *ApplicationService) geoRecords(route store.GeoRoute) ([]dns.Record, error) {
+	routed := routedTargets(route)
+	record := func(setID, region string) (dns.Record, error) {
+		ingress, err := s.ingressOf(region)
+		if err != nil {
+			return dns.Record{}, err
+		}
+		return dns.Record{
+			Name:   route.Hostname,
+			Type:   ingressType(ingress),
+			SetID:  setID,
+			Values: ingress,
+			TTL:    geoTTL,
+		}, nil
+	}
+
+	var records []dns.Record
+	if len(route.Targets[0].Locations) == 0 {
+		for _, target := range routed {
+			r, err := record("weighted-"+target.Region, target.Region)
+			if err != nil {
+				return nil, err
+			}
+			r.Weight = &target.Weight
+			records = append(records, r)
+		}
+		return records, nil
+	}
+
+	for _, target := range route.Targets {
+		serving := target.Region
+		if !slices.ContainsFunc(routed, func(t store.GeoTarget) bool { return t.Region == target.Region }) {
+			serving = routed[0].Region
+		}
+		for _, location := range target.Locations {
+			r, err := record("geo-"+location, serving)
+			if err != nil {
+				return nil, err
+			}
+			if continents[location] {
+				r.Continent = location
+			} else {
+				r.Country = location
+			}
+			records = append(records, r)
+		}
+	}
+	r, err := record("geo-default", routed[0].Region)
+	if err != nil {
+		return nil, err
+	}
+	r.Country = "*"
+	return append(records, r), nil
+}
+
+// syncGeo publishes the records of a route and deletes the ones it no longer has
+func (s *ApplicationService) syncGeo(route *store.GeoRoute) error {
+	records, err := s.geoRecords(*route)
+	if err != nil {
+		return err
+	}
+
+	var stale []dns.Record
+	for _, applied := range route.Records {
+		if !slices.ContainsFunc(records, func(r dns.Record) bool { return r.Key() == applied.Key() }) {
+			stale = append(stale, applied)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), geoSyncTimeout)
+	defer cancel()
+	if err := s.dns.Apply(ctx, records, stale); err != nil {
+		return fmt.Errorf("failed to update the records of %s at %s: %w", route.Hostname, s.dns, err)
+	}
+	route.Records = records
+	return nil
+}
+
+// RunGeoFailover checks the health of geo routed applications in their regions every
+// interval until the context is cancelled, and moves the traffic of regions where they
+// became unhealthy to the healthy ones. With a replicated registry only the leader checks.
+func (s *ApplicationService) RunGeoFailover(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopGeoFailover, interval, s.checkGeoRoutes)
+		if err != nil {
+			log.Printf("Geo failover: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) checkGeoRoutes() error {
+	routes, err := s.registry.GeoRoutes()
+	if err != nil {
+		return fmt.Errorf("failed to list geo routes: %w", err)
+	}
+
+	s.reconciler.queue(loopGeoFailover, len(routes))
+	for _, route := range routes {
+		s.reconciler.next(loopGeoFailover)
+		// the registry's copy only changes through SaveGeoRoute
+		route.Health = maps.Clone(route.Health)
+		if route.Health == nil {
+			route.Health = make(map[string]store.GeoHealth)
+		}
+		changed := false
+		for _, target := range route.Targets {
+			healthy, reason := s.regionHealth(route.Application, target.Region)
+			if previous, ok := route.Health[target.Region]; ok && previous.Healthy == healthy {
+				continue
+			}
+			route.Health[target.Region] = store.GeoHealth{Healthy: healthy, Reason: reason, ChangedAt: time.Now()}
+			changed = true
+
+			eventType := events.RegionRecovered
+			if !healthy {
+				eventType = events.RegionFailedOver
+			}
+			if route.Failover {
+				s.publish(eventType, route.Application, lifecycleEvent{Region: target.Region, Message: reason})
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		route.UpdatedAt = time.Now()
+		err := s.syncGeo(&route)
+		if err != nil {
+			log.Printf("Geo failover: %s: %v", route.Application, err)
+		}
+		if saveErr := s.registry.SaveGeoRoute(route); saveErr != nil {
+			log.Printf("Geo failover: failed to record the health of %s: %v", route.Application, saveErr)
+			err = cmp.Or(err, saveErr)
+		}
+		s.reconciler.result(loopGeoFailover, route.Application, err)
+	}
+
+	return nil
+}
+
+// regionHealth assesses an application in a region, rollouts in progress are healthy
+func (s *ApplicationService) regionHealth(jobID, region string) (bool, string) {
+	client, err := s.orhClient.InRegion(region)
+	if err != nil {
+		return false, err.Error()
+	}
+	job, allocations, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return false, err.Error()
+	}
+	deployment, err := client.LatestDeployment(jobID)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	status, reason := assessHealth(job, allocations, deployment)
+	healthy := status == pb.ApplicationHealthStatus_APPLICATION_HEALTH_HEALTHY ||
+		status == pb.ApplicationHealthStatus_APPLICATION_HEALTH_PROGRESSING
+	return healthy, reason
+}
+
+// geoStatus reports the regions of a geo routed application
+func (s *ApplicationService) geoStatus(jobID string) []*pb.GeoRegion {
+	route, err := s.registry.GeoRoute(jobID)
+	if err != nil {
+		return nil
+	}
+
+	routed := routedTargets(route)
+	var regions []*pb.GeoRegion
+	for _, target := range route.Targets {
+		health, ok := route.Health[target.Region]
+		regions = append(regions, &pb.GeoRegion{
+			Region:    target.Region,
+			Healthy:   !ok || health.Healthy,
+			Reason:    health.Reason,
+			ChangedAt: health.ChangedAt.Unix(),
+			Routed:    slices.ContainsFunc(routed, func(t store.GeoTarget) bool { return t.Region == target.Region }),
+		})
+	}
+	return regions
+}
@@ -0,0 +1,308 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// GetApplicationHealth reports the normalized health of an application, or of every
+// application when no name is given.
+func (s *ApplicationService) GetApplicationHealth(ctx context.Context, req *pb.ApplicationHealthRequest) (*pb.ApplicationHealthResponse, error) {
+	if req.Name != "" {
+		jobID, err := s.resolveJobID(req.Name)
+		var health *pb.ApplicationHealth
+		if err == nil {
+			health, err = s.applicationHealth(jobID)
+		}
+		if err != nil {
+			return &pb.ApplicationHealthResponse{
+				Message: fmt.Sprintf("Failed to assess application health: %v", err),
+			}, nil
+		}
+
+		return &pb.ApplicationHealthResponse{
+			Applications: []*pb.ApplicationHealth{health},
+			Message:      "Application health assessed successfully",
+		}, nil
+	}
+
+	applications, err := s.applicationsHealth(ctx)
+	if err != nil {
+		return &pb.ApplicationHealthResponse{
+			Message: fmt.Sprintf("Failed to list applications: %v", err),
+		}, nil
+	}
+
+	return &pb.ApplicationHealthResponse{
+		Applications: applications,
+		Message:      fmt.Sprintf("Health of %d applications assessed", len(applications)),
+	}, nil
+}
+
+// applicationsHealth assesses the applications of the namespaces the token of the request
+// is scoped to
+func (s *ApplicationService) applicationsHealth(ctx context.Context) ([]*pb.ApplicationHealth, error) {
+	jobs, err := s.orhClient.ListJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	var applications []*pb.ApplicationHealth
+	for _, job := range jobs {
+		// dispatched and periodic runs belong to their parent, backup and add-on jobs to their application
+		if job.ParentID != "" || job.Meta[nomad.MetaBackupOf] != "" || job.Meta[nomad.MetaAddOnOf] != "" || job.Meta[nomad.MetaGreenOf] != "" || isPlatformJob(job) {
+			continue
+		}
+		if !tokenSees(ctx, s.jobName(job.ID)) {
+			continue
+		}
+
+		health, err := s.applicationHealth(job.ID)
+		if err != nil {
+			health = &pb.ApplicationHealth{
+				Name:   job.ID,
+				Status: pb.ApplicationHealthStatus_APPLICATION_HEALTH_UNKNOWN,
+				Reason: err.Error(),
+			}
+		}
+		applications = append(applications, health)
+	}
+
+	sort.Slice(applications, func(i, j int) bool {
+		return applications[i].Name < applications[j].Name
+	})
+
+	return applications, nil
+}
+
+func (s *ApplicationService) applicationHealth(name string) (*pb.ApplicationHealth, error) {
+	client, err := s.nomadFor(name)
+	if err != nil {
+		return nil, err
+	}
+	job, allocations, err := client.GetJobStatus(name)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := client.LatestDeployment(name)
+	if err != nil {
+		return nil, err
+	}
+
+	status, reason := assessHealth(job, allocations, deployment)
+	return &pb.ApplicationHealth{
+		Name:   name,
+		Status: status,
+		Reason: reason,
+	}, nil
+}
+
+// assessHealth derives the health of an application from its Nomad state, the first
+// matching rule wins:
+//
+//  1. Suspended: the job is stopped or its cron schedule paused
+//  2. Healthy: functions and cron jobs, their runs are reported by ListCronRuns and GetFunctionMetrics
+//  3. Suspended: the service is scaled to zero
+//  4. Suspended, Progressing or Degraded: the rollout of the current job version is paused
+//     or waits for promotion, is running, or failed
+//  5. Degraded: instances failed and were not replaced
+//  6. Healthy: every desired instance is healthy
+//  7. Progressing: instances are still starting
+//  8. Degraded: otherwise, fewer instances are healthy than desired
+func assessHealth(job *nmd.Job, allocations []*nmd.AllocationListStub, deployment *nmd.Deployment) (pb.ApplicationHealthStatus, string) {
+	if job.Stop != nil && *job.Stop {
+		return pb.ApplicationHealthStatus_APPLICATION_HEALTH_SUSPENDED, "job is stopped"
+	}
+	if job.Periodic != nil && job.Periodic.Enabled != nil && !*job.Periodic.Enabled {
+		return pb.ApplicationHealthStatus_APPLICATION_HEALTH_SUSPENDED, "cron schedule is paused"
+	}
+
+	if job.Type != nil && *job.Type != "service" {
+		return pb.ApplicationHealthStatus_APPLICATION_HEALTH_HEALTHY, fmt.Sprintf("%s job, runs are reported separately", *job.Type)
+	}
+
+	desired := 0
+	for _, group := range job.TaskGroups {
+		if group.Count != nil {
+			desired += *group.Count
+		}
+	}
+	if desired == 0 {
+		return pb.ApplicationHealthStatus_APPLICATION_HEALTH_SUSPENDED, "scaled to zero"
+	}
+
+	if deployment != nil && job.Version != nil && deployment.JobVersion == *job.Version {
+		switch deployment.Status {
+		case nmd.DeploymentStatusPaused, nmd.DeploymentStatusBlocked:
+			return pb.ApplicationHealthStatus_APPLICATION_HEALTH_SUSPENDED, fmt.Sprintf("rollout is %s: %s", deployment.Status, deployment.StatusDescription)
+		case nmd.DeploymentStatusPending, nmd.DeploymentStatusRunning, nmd.DeploymentStatusUnblocking:
+			return pb.ApplicationHealthStatus_APPLICATION_HEALTH_PROGRESSING, fmt.Sprintf("rollout of version %d is %s", deployment.JobVersion, deployment.Status)
+		case nmd.DeploymentStatusFailed, nmd.DeploymentStatusCancelled:
+			return pb.ApplicationHealthStatus_APPLICATION_HEALTH_DEGRADED, fmt.Sprintf("rollout %s: %s", deployment.Status, deployment.StatusDescription)
+		}
+	}
+
+	healthy, failed, starting := 0, 0, 0
+	for _, alloc := range allocations {
+		if isFailed(alloc) {
+			failed++
+		}
+		if alloc.DesiredStatus != "run" {
+			continue
+		}
+		if isHealthy(alloc) {
+			healthy++
+		} else if alloc.ClientStatus == "pending" || alloc.ClientStatus == "running" {
+			starting++
+		}
+	}
+
+	switch {
+	case failed > 0:
+		return pb.ApplicationHealthStatus_APPLICATION_HEALTH_DEGRADED, fmt.Sprintf("%d instances failed", failed)
+	case healthy >= desired:
+		return pb.ApplicationHealthStatus_APPLICATION_HEALTH_HEALTHY, fmt.Sprintf("%d/%d instances healthy", healthy, desired)
+	case starting > 0:
+		return pb.ApplicationHealthStatus_APPLICATION_HEALTH_PROGRESSING, fmt.Sprintf("%d/%d instances healthy, %d starting", healthy, desired, starting)
+	default:
+		return pb.ApplicationHealthStatus_APPLICATION_HEALTH_DEGRADED, fmt.Sprintf("%d/%d instances healthy", healthy, desired)
+	}
+}
+
+// healthJSON is an application's health in the REST API, the status is named like in Argo CD
+type healthJSON struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+func toHealthJSON(health *pb.ApplicationHealth) healthJSON {
+	name := strings.TrimPrefix(health.Status.String(), "APPLICATION_HEALTH_")
+	return healthJSON{
+		Name:   health.Name,
+		Status: name[:1] + strings.ToLower(name[1:]),
+		Reason: health.Reason,
+	}
+}
+
+// HealthHandler serves the health of applications over REST for GitOps tools, the deployment
+// analytics and the calendar of scheduled operations for dashboards, the metrics of the
+// reconciliation loops in the Prometheus text format and the controller's health. The
+// applications, their status, health and timeline, the analytics and calendar responses
+// carry an ETag and are cached for cacheTTL:
+//
+//	GET /v1/applications?label=&region=&project=&page_size=&page_token=&namespace=
+//	GET /v1/applications/{name}/status?namespace=
+//	GET /v1/applications/health
+//	GET /v1/applications/{name}/health
+//	GET /v1/applications/{name}/timeline?since=&allocation_id=&type=&limit=&namespace=
+//	GET /v1/analytics?application=&team=&by_team=&since=&bucket=
+//	GET /v1/calendar?hours=&tenant=&application=&namespace=
+//	GET /metrics
+//	GET /v1/health
+//
+// With an authenticator every endpoint but the controller's health requires a token granting
+// the scope of the RPC it serves, the tokens scoped to namespaces only see their applications.
+func (s *ApplicationService) HealthHandler(cacheTTL time.Duration, auth *Authenticator) http.Handler {
+	mux := http.NewServeMux()
+	cache := newResponseCache(cacheTTL)
+
+	mux.HandleFunc("GET /v1/applications", auth.authorized(pb.ControlPlane_ListApplications_FullMethodName, cache.cached(s.listHandler)))
+	mux.HandleFunc("GET /v1/applications/{name}/status", auth.authorized(pb.ControlPlane_GetApplicationStatus_FullMethodName, cache.cached(s.statusHandler)))
+	mux.HandleFunc("GET /v1/applications/health", auth.authorized(pb.ControlPlane_GetApplicationHealth_FullMethodName, cache.cached(func(w http.ResponseWriter, r *http.Request) {
+		applications, err := s.applicationsHealth(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		result := make([]healthJSON, len(applications))
+		for i, health := range applications {
+			result[i] = toHealthJSON(health)
+		}
+		writeJSON(w, map[string][]healthJSON{"applications": result})
+	})))
+
+	mux.HandleFunc("GET /v1/applications/{name}/health", auth.authorized(pb.ControlPlane_GetApplicationHealth_FullMethodName, cache.cached(func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		if !tokenSees(r.Context(), s.jobName(name)) {
+			http.Error(w, fmt.Sprintf("application %s not found", name), http.StatusNotFound)
+			return
+		}
+		health, err := s.applicationHealth(name)
+		if err != nil {
+			code := http.StatusBadGateway
+			if nomad.IsNotFound(err) {
+				code = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+		writeJSON(w, toHealthJSON(health))
+	})))
+
+	mux.HandleFunc("GET /v1/applications/{name}/timeline", auth.authorized(pb.ControlPlane_GetTimeline_FullMethodName, cache.cached(s.timelineHandler)))
+	mux.HandleFunc("GET /v1/analytics", auth.authorized(pb.ControlPlane_GetDeploymentAnalytics_FullMethodName, cache.cached(s.analyticsHandler)))
+	mux.HandleFunc("GET /v1/calendar", auth.authorized(pb.ControlPlane_GetCalendar_FullMethodName, cache.cached(s.calendarHandler)))
+	mux.HandleFunc("GET /metrics", auth.authorized(pb.ControlPlane_GetReconcilerStatus_FullMethodName, s.metricsHandler))
+
+	// the health check of the controllers deployed by DeployController
+	mux.HandleFunc("GET "+nomad.ControllerHealthPath, func(w http.ResponseWriter, r *http.Request) {
+		health, _ := s.HealthCheck(r.Context(), &pb.HealthCheckRequest{})
+		code := http.StatusOK
+		if health.Status != pb.HealthStatus_SERVING {
+			code = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": health.Status.String(), "mode": health.Mode, "message": health.Message})
+	})
+
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeProtoJSON writes a response of the RPCs as JSON with the field names of the proto
+func writeProtoJSON(w http.ResponseWriter, m proto.Message) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// writeStatusError answers a REST request with the HTTP status of a gRPC error, e.g. the
+// error of checkRequestNamespace
+func writeStatusError(w http.ResponseWriter, err error) {
+	code := http.StatusBadRequest
+	switch status.Code(err) {
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.Internal:
+		code = http.StatusInternalServerError
+	}
+	http.Error(w, status.Convert(err).Message(), code)
+}
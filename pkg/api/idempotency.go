@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// IdempotencyKeyHeader is the gRPC metadata key of a mutating request's idempotency key
+const IdempotencyKeyHeader = "idempotency-key"
+
+// IdempotencyInterceptor deduplicates the mutating RPCs sent with an Idempotency-Key: a
+// retry gets the result of the first request instead of running it again, and fails
+// while the first one still runs. Keys are kept in the registry for ttl, per RPC.
+func IdempotencyInterceptor(registry store.Store, ttl time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		keys := metadata.ValueFromIncomingContext(ctx, IdempotencyKeyHeader)
+		if len(keys) == 0 || keys[0] == "" || readMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		request, err := requestHash(req)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash the request: %v", err)
+		}
+
+		now := time.Now()
+		key := store.IdempotencyKey{
+			Key:       "rpc/" + info.FullMethod + "/" + keys[0],
+			Operation: info.FullMethod,
+			Request:   request,
+			CreatedAt: now,
+			ExpiresAt: now.Add(ttl),
+		}
+		err = registry.CreateIdempotencyKey(key)
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return duplicateRequest(registry, key)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to record the idempotency key: %v", err)
+		}
+
+		resp, handlerErr := handler(ctx, req)
+
+		// a failed RPC is replayed as it failed, like a response reporting the failure
+		var result proto.Message = status.Convert(handlerErr).Proto()
+		if handlerErr == nil {
+			result = resp.(proto.Message)
+		}
+		key.Done = true
+		if key.Result, err = marshalResult(result); err == nil {
+			err = registry.UpdateIdempotencyKey(key)
+		}
+		if err != nil {
+			log.Printf("Failed to record the result of %s with idempotency key %s: %v", info.FullMethod, keys[0], err)
+		}
+
+		return resp, handlerErr
+	}
+}
+
+// duplicateRequest returns the result of the first request with the key
+func duplicateRequest(registry store.Store, key store.IdempotencyKey) (any, error) {
+	existing, err := registry.IdempotencyKey(key.Key)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to look up the idempotency key: %v", err)
+	}
+	if existing.Request != key.Request {
+		return nil, status.Errorf(codes.InvalidArgument, "the idempotency key was used for another request")
+	}
+	if !existing.Done {
+		return nil, status.Errorf(codes.Aborted, "a request with the idempotency key is still running, retry later")
+	}
+
+	result, err := unmarshalResult(existing.Result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode the result of the idempotency key: %v", err)
+	}
+	if failure, ok := result.(*spb.Status); ok {
+		return nil, status.ErrorProto(failure)
+	}
+	return result, nil
+}
+
+// requestHash identifies a request, a key cannot be reused for another one
+func requestHash(req any) (string, error) {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return "", errors.New("not a protobuf message")
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// marshalResult encodes a response as JSON, with its type so that it can be decoded
+// without knowing the RPC
+func marshalResult(result proto.Message) ([]byte, error) {
+	typed, err := anypb.New(result)
+	if err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(typed)
+}
+
+func unmarshalResult(data []byte) (proto.Message, error) {
+	typed := &anypb.Any{}
+	if err := protojson.Unmarshal(data, typed); err != nil {
+		return nil, err
+	}
+	return typed.UnmarshalNew()
+}
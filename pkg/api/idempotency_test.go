@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestIdempotencyInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: pb.ControlPlane_ScaleApplication_FullMethodName}
+
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		first     *pb.ScaleRequest
+		retry     *pb.ScaleRequest
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{
+			name:      "retry with the same key",
+			ttl:       time.Hour,
+			first:     &pb.ScaleRequest{DeploymentId: "web", Count: 3},
+			retry:     &pb.ScaleRequest{DeploymentId: "web", Count: 3},
+			wantCalls: 1,
+		},
+		{
+			name:      "same key for another request",
+			ttl:       time.Hour,
+			first:     &pb.ScaleRequest{DeploymentId: "web", Count: 3},
+			retry:     &pb.ScaleRequest{DeploymentId: "web", Count: 5},
+			wantCalls: 1,
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "expired key",
+			ttl:       0,
+			first:     &pb.ScaleRequest{DeploymentId: "web", Count: 3},
+			retry:     &pb.ScaleRequest{DeploymentId: "web", Count: 3},
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := IdempotencyInterceptor(store.NewMemoryStore(), tt.ttl)
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, "key-1"))
+
+			calls := 0
+			handler := func(ctx context.Context, req any) (any, error) {
+				calls++
+				return &pb.ScaleResponse{Success: true, Message: fmt.Sprintf("call %d", calls)}, nil
+			}
+
+			first, err := interceptor(ctx, tt.first, info, handler)
+			if err != nil {
+				t.Fatalf("first request: %v", err)
+			}
+			retry, err := interceptor(ctx, tt.retry, info, handler)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("got %v (%v), want %v", got, err, tt.wantCode)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("handler called %d times, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantCode == codes.OK && tt.wantCalls == 1 && !proto.Equal(retry.(proto.Message), first.(proto.Message)) {
+				t.Fatalf("got %v, want the stored response %v", retry, first)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/oci"
+)
+
+// imageAllowed matches the image against the patterns, a pattern is a repository such as
+// docker.io/library/nginx or a prefix ending in /* such as registry.example.com/payments/*
+func imageAllowed(image string, patterns []string) bool {
+	repository := strings.ToLower(oci.ParseReference(image).Name())
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(repository, prefix) {
+				return true
+			}
+		} else if repository == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// admitImage rejects images from registries and namespaces the tenant, or the controller for
+// deployments without a tenant policy, does not allow
+func (s *ApplicationService) admitImage(req *pb.DeployRequest) error {
+	patterns, owner := s.allowedImages, "the controller"
+	if req.Tenant != "" {
+		tenant, err := s.registry.Tenant(req.Tenant)
+		if err != nil {
+			return err
+		}
+		if len(tenant.AllowedImages) > 0 {
+			patterns, owner = tenant.AllowedImages, "tenant "+tenant.Name
+		}
+	}
+
+	if len(patterns) == 0 || imageAllowed(req.Image, patterns) {
+		return nil
+	}
+	return fmt.Errorf("image %s (%s) is not allowed by %s, allowed: %s", req.Image, oci.ParseReference(req.Image).Name(), owner, strings.Join(patterns, ", "))
+}
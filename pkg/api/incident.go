@@ -0,0 +1,221 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/events"
+)
+
+const incidentTimeout = 30 * time.Second
+
+// openIncident is an alerting rule matching an application, pending until the rule's
+// condition held for its duration, then triggered at the provider of the application's tier
+type openIncident struct {
+	incident  events.Incident
+	jobID     string
+	tier      events.Tier
+	wait      time.Duration
+	since     time.Time
+	triggered bool
+}
+
+// RunIncidents evaluates the alerting rules against every application every interval until
+// the context is cancelled, opening incidents at the provider of the application's tier and
+// resolving them once it recovers. With a replicated registry only the leader evaluates them.
+func (s *ApplicationService) RunIncidents(ctx context.Context, config *events.IncidentConfig, interval time.Duration) {
+	open := make(map[string]*openIncident) // incident key
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopIncidents, interval, func() error { return s.checkIncidents(ctx, config, open) })
+		if err != nil {
+			log.Printf("Incidents: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) checkIncidents(ctx context.Context, config *events.IncidentConfig, open map[string]*openIncident) error {
+	jobIDs, err := s.applicationJobs()
+	if err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	now := time.Now()
+	held := make(map[string]bool)    // incident keys whose condition holds
+	checked := make(map[string]bool) // jobs evaluated, the others keep their incidents
+	s.reconciler.queue(loopIncidents, len(jobIDs))
+	for _, jobID := range jobIDs {
+		s.reconciler.next(loopIncidents)
+		incidents, err := s.applicationIncidents(config, jobID)
+		if err != nil {
+			log.Printf("Incidents: %s: %v", jobID, err)
+		} else {
+			checked[jobID] = true
+		}
+		for _, incident := range incidents {
+			held[incident.incident.Key] = true
+			if current, ok := open[incident.incident.Key]; ok {
+				current.incident = incident.incident
+				continue
+			}
+			incident.since = now
+			open[incident.incident.Key] = incident
+		}
+		s.reconciler.result(loopIncidents, jobID, err)
+	}
+
+	for key, incident := range open {
+		switch {
+		case held[key] && !incident.triggered && now.Sub(incident.since) >= incident.wait:
+			if err := s.sendIncident(ctx, incident, true); err != nil {
+				log.Printf("Incidents: failed to open %s: %v", key, err)
+				continue
+			}
+			incident.triggered = true
+			log.Printf("Incidents: opened %s: %s", key, incident.incident.Summary)
+		case held[key]:
+		case !checked[incident.jobID] && slices.Contains(jobIDs, incident.jobID):
+			// the application could not be evaluated, it is not known to have recovered
+		case !incident.triggered:
+			delete(open, key)
+		default:
+			if err := s.sendIncident(ctx, incident, false); err != nil {
+				log.Printf("Incidents: failed to resolve %s: %v", key, err)
+				continue
+			}
+			delete(open, key)
+			log.Printf("Incidents: resolved %s after %s", key, now.Sub(incident.since).Round(time.Second))
+		}
+	}
+	return nil
+}
+
+// applicationIncidents evaluates the rules of the application's tier, it returns the
+// incidents of the rules whose condition holds
+func (s *ApplicationService) applicationIncidents(config *events.IncidentConfig, jobID string) ([]*openIncident, error) {
+	name := s.jobName(jobID)
+	team, labels := name.Tenant, map[string]string(nil)
+	if entry, err := s.registry.SearchEntry(jobID); err == nil {
+		team, labels = entry.Owner, entry.Labels
+	}
+	tierName := labels[config.TierLabel]
+	if tierName == "" {
+		tierName = config.DefaultTier
+	}
+	tier, ok := config.Tiers[tierName]
+	if !ok {
+		return nil, nil
+	}
+
+	var rules []events.AlertRule
+	for _, rule := range config.Rules {
+		if tier.Applies(rule.Name) && matchLabels(labels, rule.Labels) {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return nil, err
+	}
+	job, allocations, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := client.LatestDeployment(jobID)
+	if err != nil {
+		return nil, err
+	}
+	status, reason := assessHealth(job, allocations, deployment)
+	healthy, desired := instanceCounts(job, allocations)
+
+	links := map[string]string{"Nomad": client.JobURL(jobID)}
+	for label, link := range s.applicationLinks(job, jobID) {
+		links[label] = link
+	}
+
+	var incidents []*openIncident
+	for _, rule := range rules {
+		summary := ""
+		switch rule.Condition {
+		case events.ConditionNoHealthyInstances:
+			if status != pb.ApplicationHealthStatus_APPLICATION_HEALTH_HEALTHY && status != pb.ApplicationHealthStatus_APPLICATION_HEALTH_SUSPENDED && desired > 0 && healthy == 0 {
+				summary = fmt.Sprintf("%s has no healthy instances: %s", name.Application, reason)
+			}
+		case events.ConditionDegraded:
+			if status == pb.ApplicationHealthStatus_APPLICATION_HEALTH_DEGRADED {
+				summary = fmt.Sprintf("%s is degraded: %s", name.Application, reason)
+			}
+		case events.ConditionRestartSpike:
+			if anomaly := s.restarts.anomaly(jobID); anomaly != nil {
+				summary = fmt.Sprintf("%s is restarting abnormally: %d restarts since the last check, %.1f on average before",
+					name.Application, anomaly.Restarts, anomaly.Baseline)
+			}
+		}
+		if summary == "" {
+			continue
+		}
+
+		incidents = append(incidents, &openIncident{
+			incident: events.Incident{
+				Key:         "controlplane/" + rule.Name + "/" + jobID,
+				Summary:     summary,
+				Application: name.Application,
+				Rule:        rule.Name,
+				Details: map[string]string{
+					"job_id": jobID,
+					"team":   team,
+					"tier":   tierName,
+					"health": reason,
+				},
+				Links: links,
+			},
+			jobID: jobID,
+			tier:  tier,
+			wait:  rule.Duration(),
+		})
+	}
+	return incidents, nil
+}
+
+func (s *ApplicationService) sendIncident(ctx context.Context, incident *openIncident, trigger bool) error {
+	ctx, cancel := context.WithTimeout(ctx, incidentTimeout)
+	defer cancel()
+
+	if trigger {
+		return incident.tier.Provider().Trigger(ctx, incident.incident)
+	}
+	return incident.tier.Provider().Resolve(ctx, incident.incident)
+}
+
+// instanceCounts counts the healthy instances of a job and the instances it wants
+func instanceCounts(job *nmd.Job, allocations []*nmd.AllocationListStub) (int, int) {
+	healthy, desired := 0, 0
+	for _, group := range job.TaskGroups {
+		if group.Count != nil {
+			desired += *group.Count
+		}
+	}
+	for _, alloc := range allocations {
+		if alloc.DesiredStatus == "run" && isHealthy(alloc) {
+			healthy++
+		}
+	}
+	return healthy, desired
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/oci"
+)
+
+// Lint rules, a spec breaking one deploys but is likely to hurt in operation
+const (
+	LintHealthCheck   = "health-check"
+	LintSingleReplica = "single-replica"
+	LintLatestTag     = "latest-tag"
+	LintResources     = "resources"
+	LintHostNetwork   = "host-network"
+)
+
+// HostNetworkReason is the annotation explaining why an application needs host networking,
+// e.g. static ports a load balancer outside the cluster forwards to
+const HostNetworkReason = "host-network-reason"
+
+// productionEnvironments are the environments single replicas are flagged in
+var productionEnvironments = []string{"prod", "production"}
+
+// LintSpec flags the best practices a valid spec does not follow. The environment is the
+// controller's -job-environment, or the one `cli lint` is given; the spec's environment
+// label takes precedence.
+func LintSpec(req *pb.DeployRequest, environment string) []*pb.LintWarning {
+	var warnings []*pb.LintWarning
+	warn := func(rule, field, format string, args ...any) {
+		warnings = append(warnings, &pb.LintWarning{Rule: rule, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if label := req.Labels["environment"]; label != "" {
+		environment = label
+	}
+	service := req.Type == pb.DeploymentType_DEPLOYMENT_TYPE_UNSPECIFIED || req.Type == pb.DeploymentType_DEPLOYMENT_TYPE_SERVICE
+
+	if service && (req.Traefik == nil || req.Traefik.HealthCheckPath == "") {
+		warn(LintHealthCheck, "traefik.health_check_path", "no health check is defined, Traefik routes to instances as soon as they start and failed ones are not detected")
+	}
+	if service && req.Replicas <= 1 && slices.Contains(productionEnvironments, strings.ToLower(environment)) {
+		warn(LintSingleReplica, "replicas", "a single replica in %s is unavailable during rollouts and node failures", environment)
+	}
+	if req.Image != "" {
+		if ref := oci.ParseReference(req.Image); ref.Digest == "" && ref.Tag == "latest" {
+			warn(LintLatestTag, "image", "image %s uses the latest tag, redeploys and reschedules may run another version", req.Image)
+		}
+	}
+	if req.Cpu == 0 {
+		warn(LintResources, "cpu", "no CPU is reserved, the application competes with every other task of its node")
+	}
+	if req.Memory == 0 {
+		warn(LintResources, "memory", "no memory limit is set, Nomad's default of 300 MB may kill the application or waste its node")
+	}
+	if service && req.NetworkMode != pb.NetworkMode_NETWORK_MODE_BRIDGE && req.Annotations[HostNetworkReason] == "" {
+		warn(LintHostNetwork, "network_mode", "host networking shares the node's network with every task, use bridge networking or record why in the %s annotation", HostNetworkReason)
+	}
+
+	return warnings
+}
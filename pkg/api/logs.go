@@ -0,0 +1,242 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"google.golang.org/grpc"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+const (
+	defaultTailLines = 100
+	maxLogBytes      = 1 << 20
+	// GetLogs reads this much of the end of the log per tail line, longer lines shorten the tail
+	tailLineBytes = 512
+	// a followed log is caught up once Nomad sent nothing for this long
+	logBacklogWait = 500 * time.Millisecond
+)
+
+// GetApplicationLogs returns the last lines of a task's log.
+func (s *ApplicationService) GetApplicationLogs(ctx context.Context, req *pb.LogsRequest) (*pb.LogsResponse, error) {
+	if s.orhClient == nil {
+		return s.orchestratorLogs(ctx, req)
+	}
+	client, alloc, task, err := s.resolveLogTarget(req.DeploymentId, req.AllocationId, req.TaskName)
+	if err != nil {
+		return &pb.LogsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get logs: %v", err),
+		}, nil
+	}
+
+	logType, tailLines, err := logOptions(req)
+	if err != nil {
+		return &pb.LogsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	output, err := client.ReadTaskLog(alloc, task, logType, maxLogBytes)
+	if err != nil {
+		return &pb.LogsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get logs: %v", err),
+		}, nil
+	}
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > tailLines {
+		lines = lines[len(lines)-tailLines:]
+	}
+
+	return &pb.LogsResponse{
+		LogLines: lines,
+		Success:  true,
+		Message:  fmt.Sprintf("Logs of task %s in allocation %s", task, alloc),
+	}, nil
+}
+
+// GetLogs streams the last lines of a task's log and, with follow, the lines the task
+// writes afterwards until the caller cancels or the task stops. A failure ends the stream
+// with a chunk telling what went wrong.
+func (s *ApplicationService) GetLogs(req *pb.LogsRequest, stream grpc.ServerStreamingServer[pb.LogChunk]) error {
+	if s.orhClient == nil {
+		return s.streamOrchestratorLogs(req, stream)
+	}
+	client, alloc, task, err := s.resolveLogTarget(req.DeploymentId, req.AllocationId, req.TaskName)
+	if err != nil {
+		return stream.Send(&pb.LogChunk{Error: fmt.Sprintf("Failed to get logs: %v", err)})
+	}
+	logType, tailLines, err := logOptions(req)
+	if err != nil {
+		return stream.Send(&pb.LogChunk{Error: err.Error()})
+	}
+
+	ctx := stream.Context()
+	frames, errs, err := client.StreamTaskLog(ctx, alloc, task, logType, min(int64(tailLines)*tailLineBytes, maxLogBytes), req.Follow)
+	if err != nil {
+		return stream.Send(&pb.LogChunk{AllocationId: alloc, TaskName: task, Error: fmt.Sprintf("Failed to get logs: %v", err)})
+	}
+	send := func(lines []string) error {
+		if len(lines) == 0 {
+			return nil
+		}
+		return stream.Send(&pb.LogChunk{Lines: lines, AllocationId: alloc, TaskName: task})
+	}
+
+	// the lines read back from the end of the log are held until it is caught up, then
+	// only the tail is sent
+	var tail []string
+	tailing := true
+	var caughtUp *time.Timer
+	var caughtUpC <-chan time.Time
+	if req.Follow {
+		caughtUp = time.NewTimer(logBacklogWait)
+		defer caughtUp.Stop()
+		caughtUpC = caughtUp.C
+	}
+
+	var partial []byte
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errs:
+			if tailing {
+				send(lastLines(tail, tailLines))
+			}
+			return stream.Send(&pb.LogChunk{AllocationId: alloc, TaskName: task, Error: fmt.Sprintf("Failed to read logs: %v", err)})
+		case <-caughtUpC:
+			tailing, caughtUpC = false, nil
+			if err := send(lastLines(tail, tailLines)); err != nil {
+				return err
+			}
+			tail = nil
+		case frame, ok := <-frames:
+			if !ok {
+				lines := tail
+				if len(partial) > 0 {
+					lines = append(lines, string(partial))
+				}
+				if tailing {
+					lines = lastLines(lines, tailLines)
+				}
+				return send(lines)
+			}
+
+			data := append(partial, frame.Data...)
+			end := bytes.LastIndexByte(data, '\n')
+			if end < 0 {
+				partial = data
+				continue
+			}
+			lines := strings.Split(string(data[:end]), "\n")
+			partial = bytes.Clone(data[end+1:])
+
+			if !tailing {
+				if err := send(lines); err != nil {
+					return err
+				}
+				continue
+			}
+			tail = lastLines(append(tail, lines...), tailLines)
+			if caughtUp != nil {
+				caughtUp.Reset(logBacklogWait)
+			}
+		}
+	}
+}
+
+// logOptions validates the log type and tail lines of the request and applies their defaults
+func logOptions(req *pb.LogsRequest) (string, int, error) {
+	logType := req.LogType
+	if logType == "" {
+		logType = "stdout"
+	}
+	if logType != "stdout" && logType != "stderr" {
+		return "", 0, fmt.Errorf("log type must be 'stdout' or 'stderr'")
+	}
+
+	tailLines := int(req.TailLines)
+	if tailLines <= 0 {
+		tailLines = defaultTailLines
+	}
+	return logType, tailLines, nil
+}
+
+func lastLines(lines []string, n int) []string {
+	if len(lines) > n {
+		return lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// resolveLogTarget picks the most recent allocation of the job and its only task
+// when the caller did not choose them, in the region the application runs in.
+func (s *ApplicationService) resolveLogTarget(name, allocID, task string) (*nomad.NomadClient, string, string, error) {
+	client, _, alloc, task, err := s.resolveTask(name, allocID, task, false)
+	return client, alloc, task, err
+}
+
+// resolveTask is resolveLogTarget also returning the job, only picking a running
+// allocation when running is set
+func (s *ApplicationService) resolveTask(name, allocID, task string, running bool) (*nomad.NomadClient, *nmd.Job, string, string, error) {
+	jobID, err := s.resolveJobID(name)
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+	job, allocations, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+
+	var target *nmd.AllocationListStub
+	for _, alloc := range allocations {
+		if allocID != "" {
+			if alloc.ID == allocID || strings.HasPrefix(alloc.ID, allocID) {
+				target = alloc
+				break
+			}
+			continue
+		}
+		if running && alloc.ClientStatus != nmd.AllocClientStatusRunning {
+			continue
+		}
+		if target == nil || alloc.CreateTime > target.CreateTime {
+			target = alloc
+		}
+	}
+
+	if target == nil {
+		if allocID != "" {
+			return nil, nil, "", "", fmt.Errorf("allocation %s not found in %s", allocID, jobID)
+		}
+		if running {
+			return nil, nil, "", "", fmt.Errorf("%s has no running allocations", jobID)
+		}
+		return nil, nil, "", "", fmt.Errorf("%s has no allocations", jobID)
+	}
+
+	if task == "" {
+		if len(target.TaskStates) != 1 {
+			return nil, nil, "", "", fmt.Errorf("allocation %s runs %d tasks, a task name must be provided", target.ID, len(target.TaskStates))
+		}
+		for name := range target.TaskStates {
+			task = name
+		}
+	}
+
+	return client, job, target.ID, task, nil
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/iuliansafta/control-plane/pkg/consul"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// syncMeshIntentions reconciles the intentions allowing applications to call the application,
+// and those of the applications its intentions involve: the ones it depends or depended on
+// and the ones allowing it
+func (s *ApplicationService) syncMeshIntentions(application string, dependencies []string) error {
+	if s.egress == nil || s.egress.Mode != nomad.EgressModeConsul {
+		return nil
+	}
+
+	graph, err := s.registry.Dependencies()
+	if err != nil {
+		return err
+	}
+	allowing, err := s.egress.Consul.IntentionsBy(consul.MetaSource, application)
+	if err != nil {
+		return err
+	}
+
+	destinations := append([]string{application}, dependencies...)
+	for _, intention := range allowing {
+		destinations = append(destinations, intention.Meta[consul.MetaAllowedBy])
+	}
+	slices.Sort(destinations)
+	for _, destination := range slices.Compact(destinations) {
+		if err := s.reconcileMeshIntentions(destination, graph); err != nil {
+			return fmt.Errorf("intentions of %s: %w", destination, err)
+		}
+	}
+	return nil
+}
+
+// reconcileMeshIntentions allows the applications of the destination's allow list and those
+// depending on it to call its service, and revokes the intentions of any other application
+func (s *ApplicationService) reconcileMeshIntentions(destination string, graph map[string][]string) error {
+	service, allowFrom, err := s.meshIdentity(destination)
+	if err != nil {
+		return err
+	}
+
+	desired := make(map[string]string) // source service to its application
+	if service != "" {
+		sources := slices.Clone(allowFrom)
+		for application, dependsOn := range graph {
+			if slices.Contains(dependsOn, destination) {
+				sources = append(sources, application)
+			}
+		}
+		for _, source := range sources {
+			sourceService, _, err := s.meshIdentity(source)
+			if err != nil {
+				log.Printf("Failed to resolve the Connect service of %s: %v", source, err)
+				continue
+			}
+			if sourceService != "" {
+				desired[sourceService] = source
+			}
+		}
+	}
+
+	for sourceService, source := range desired {
+		if err := s.egress.Consul.AllowMeshIntention(source, sourceService, destination, service); err != nil {
+			return err
+		}
+	}
+
+	existing, err := s.egress.Consul.IntentionsBy(consul.MetaAllowedBy, destination)
+	if err != nil {
+		return err
+	}
+	for _, intention := range existing {
+		if intention.DestinationName == service && desired[intention.SourceName] != "" {
+			continue
+		}
+		if err := s.egress.Consul.ReleaseIntention(intention, consul.MetaAllowedBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// meshIdentity returns the Connect service and allow list of a deployed application, the
+// service is empty when the application is not deployed or not in the mesh
+func (s *ApplicationService) meshIdentity(application string) (string, []string, error) {
+	jobID, err := s.resolveJobID(application)
+	if err != nil {
+		return "", nil, err
+	}
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return "", nil, err
+	}
+	job, _, err := client.GetJobStatus(jobID)
+	if nomad.IsNotFound(err) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	var allowFrom []string
+	if job.Meta[nomad.MetaAllowFrom] != "" {
+		allowFrom = strings.Split(job.Meta[nomad.MetaAllowFrom], ",")
+	}
+	return job.Meta[nomad.MetaConnectService], allowFrom, nil
+}
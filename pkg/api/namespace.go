@@ -0,0 +1,358 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// allNamespaces is the namespace of the list RPCs listing every namespace
+const allNamespaces = "*"
+
+// applicationFields are the fields naming the application of the RPCs addressing one, it
+// must run in the namespace of the request
+var applicationFields = map[string]protoreflect.Name{
+	pb.ControlPlane_DeleteApplication_FullMethodName:           "deployment_id",
+	pb.ControlPlane_GetApplicationStatus_FullMethodName:        "deployment_id",
+	pb.ControlPlane_WatchDeployment_FullMethodName:             "name",
+	pb.ControlPlane_PromoteDeployment_FullMethodName:           "name",
+	pb.ControlPlane_FailDeployment_FullMethodName:              "name",
+	pb.ControlPlane_ScaleApplication_FullMethodName:            "deployment_id",
+	pb.ControlPlane_RollbackApplication_FullMethodName:         "deployment_id",
+	pb.ControlPlane_UpdateApplication_FullMethodName:           "name",
+	pb.ControlPlane_InvokeFunction_FullMethodName:              "name",
+	pb.ControlPlane_GetFunctionMetrics_FullMethodName:          "name",
+	pb.ControlPlane_DispatchJob_FullMethodName:                 "job_id",
+	pb.ControlPlane_ListCronRuns_FullMethodName:                "name",
+	pb.ControlPlane_TriggerCronJob_FullMethodName:              "name",
+	pb.ControlPlane_SetCronPaused_FullMethodName:               "name",
+	pb.ControlPlane_GetApplicationLogs_FullMethodName:          "deployment_id",
+	pb.ControlPlane_GetLogs_FullMethodName:                     "deployment_id",
+	pb.ControlPlane_RunAction_FullMethodName:                   "deployment_id",
+	pb.ControlPlane_GetApplicationConfig_FullMethodName:        "deployment_id",
+	pb.ControlPlane_SetApplicationConfig_FullMethodName:        "deployment_id",
+	pb.ControlPlane_BackupApplication_FullMethodName:           "name",
+	pb.ControlPlane_ListSnapshots_FullMethodName:               "name",
+	pb.ControlPlane_RestoreVolume_FullMethodName:               "name",
+	pb.ControlPlane_GetImpact_FullMethodName:                   "name",
+	pb.ControlPlane_GetTimeline_FullMethodName:                 "name",
+	pb.ControlPlane_ListRevisions_FullMethodName:               "deployment_id",
+	pb.ControlPlane_ApplyResourceRecommendation_FullMethodName: "name",
+}
+
+// listMethods list the applications of the namespace of the request, or of every
+// namespace
+var listMethods = map[string]bool{
+	pb.ControlPlane_ListApplications_FullMethodName: true,
+	pb.ControlPlane_Search_FullMethodName:           true,
+	pb.ControlPlane_GetCalendar_FullMethodName:      true,
+}
+
+// unnamespacedMethods are served to the tokens scoped to namespaces although their
+// requests have no namespace: the namespace of a spec is checked when it is deployed and
+// only the namespaces of the token are listed
+var unnamespacedMethods = map[string]bool{
+	pb.ControlPlane_ApplySpec_FullMethodName:      true,
+	pb.ControlPlane_ListNamespaces_FullMethodName: true,
+	pb.ControlPlane_HealthCheck_FullMethodName:    true,
+}
+
+// CreateNamespace creates a namespace of the control plane and the Nomad namespace of the
+// same name its jobs run in
+func (s *ApplicationService) CreateNamespace(ctx context.Context, req *pb.CreateNamespaceRequest) (*pb.CreateNamespaceResponse, error) {
+	switch {
+	case !tenantName.MatchString(req.Name):
+		return &pb.CreateNamespaceResponse{Message: fmt.Sprintf("Invalid namespace name %q, it must be a lowercase DNS label", req.Name)}, nil
+	case req.Name == nomad.DefaultNamespace:
+		return &pb.CreateNamespaceResponse{Message: "The default namespace always exists"}, nil
+	}
+	if req.Tenant != "" {
+		if _, err := s.registry.Tenant(req.Tenant); err != nil {
+			return &pb.CreateNamespaceResponse{Message: fmt.Sprintf("Failed to find tenant: %v", err)}, nil
+		}
+	}
+	if _, err := s.registry.Namespace(req.Name); err == nil {
+		return &pb.CreateNamespaceResponse{Message: fmt.Sprintf("Namespace %s already exists", req.Name)}, nil
+	}
+
+	var meta map[string]string
+	if req.Tenant != "" {
+		meta = map[string]string{nomad.MetaTenant: req.Tenant}
+	}
+	description := cmp.Or(req.Description, "Namespace of the control plane")
+	if err := s.orhClient.CreateNamespace(req.Name, description, meta); err != nil {
+		return &pb.CreateNamespaceResponse{
+			Message: fmt.Sprintf("Failed to create the Nomad namespace: %v", err),
+		}, nil
+	}
+
+	namespace := store.Namespace{
+		Name:        req.Name,
+		Description: req.Description,
+		Tenant:      req.Tenant,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.registry.CreateNamespace(namespace); err != nil {
+		return &pb.CreateNamespaceResponse{
+			Message: fmt.Sprintf("Failed to create namespace: %v", err),
+		}, nil
+	}
+
+	return &pb.CreateNamespaceResponse{
+		Success:   true,
+		Message:   fmt.Sprintf("Namespace %s created", req.Name),
+		Namespace: toNamespaceInfo(namespace, 0),
+	}, nil
+}
+
+// ListNamespaces lists the namespaces the token of the request sees, with their number of
+// applications
+func (s *ApplicationService) ListNamespaces(ctx context.Context, req *pb.ListNamespacesRequest) (*pb.ListNamespacesResponse, error) {
+	namespaces, err := s.registry.Namespaces()
+	var names []store.JobName
+	if err == nil {
+		names, err = s.registry.JobNames()
+	}
+	if err != nil {
+		return &pb.ListNamespacesResponse{
+			Message: fmt.Sprintf("Failed to list namespaces: %v", err),
+		}, nil
+	}
+
+	counts := make(map[string]int)
+	for _, name := range names {
+		counts[jobNamespace(name)]++
+	}
+	namespaces = append([]store.Namespace{{Name: nomad.DefaultNamespace, Description: "Applications deployed without a namespace"}}, namespaces...)
+
+	resp := &pb.ListNamespacesResponse{Success: true}
+	scoped := tokenNamespaces(ctx)
+	for _, namespace := range namespaces {
+		if scoped != nil && !slices.Contains(scoped, namespace.Name) {
+			continue
+		}
+		resp.Namespaces = append(resp.Namespaces, toNamespaceInfo(namespace, counts[namespace.Name]))
+	}
+	resp.Message = fmt.Sprintf("Found %d namespaces", len(resp.Namespaces))
+	return resp, nil
+}
+
+// DeleteNamespace deletes a namespace and its Nomad namespace once it has no applications
+func (s *ApplicationService) DeleteNamespace(ctx context.Context, req *pb.DeleteNamespaceRequest) (*pb.DeleteNamespaceResponse, error) {
+	_, err := s.registry.Namespace(req.Name)
+	var names []store.JobName
+	if err == nil {
+		names, err = s.registry.JobNames()
+	}
+	if err == nil {
+		names = slices.DeleteFunc(names, func(name store.JobName) bool { return jobNamespace(name) != req.Name })
+		if len(names) > 0 {
+			err = fmt.Errorf("%d applications still run in it, e.g. %s", len(names), names[0].Application)
+		}
+	}
+	if err == nil {
+		err = s.orhClient.DeleteNamespace(req.Name)
+	}
+	if err == nil {
+		err = s.registry.DeleteNamespace(req.Name)
+	}
+	if err != nil {
+		return &pb.DeleteNamespaceResponse{
+			Message: fmt.Sprintf("Failed to delete namespace: %v", err),
+		}, nil
+	}
+
+	return &pb.DeleteNamespaceResponse{
+		Success: true,
+		Message: fmt.Sprintf("Namespace %s deleted", req.Name),
+	}, nil
+}
+
+func toNamespaceInfo(namespace store.Namespace, applications int) *pb.NamespaceInfo {
+	return &pb.NamespaceInfo{
+		Name:         namespace.Name,
+		Description:  namespace.Description,
+		Tenant:       namespace.Tenant,
+		Applications: int32(applications),
+		CreatedAt:    unixOrZero(namespace.CreatedAt),
+	}
+}
+
+// jobNamespace is the namespace of a recorded application
+func jobNamespace(name store.JobName) string {
+	return cmp.Or(name.Namespace, nomad.DefaultNamespace)
+}
+
+// inNamespace reports whether an application is listed for the namespace of a list request
+func inNamespace(name store.JobName, namespace string) bool {
+	return namespace == allNamespaces || jobNamespace(name) == cmp.Or(namespace, nomad.DefaultNamespace)
+}
+
+// tokenSees reports whether the token of the request is scoped to the namespace of the
+// application
+func tokenSees(ctx context.Context, name store.JobName) bool {
+	scoped := tokenNamespaces(ctx)
+	return scoped == nil || slices.Contains(scoped, jobNamespace(name))
+}
+
+// checkNamespace checks the namespace exists and the token of the request is scoped to it,
+// it returns the namespace record, empty for the default namespace
+func checkNamespace(ctx context.Context, registry store.Store, namespace string) (store.Namespace, error) {
+	namespace = cmp.Or(namespace, nomad.DefaultNamespace)
+	if scoped := tokenNamespaces(ctx); scoped != nil && !slices.Contains(scoped, namespace) {
+		return store.Namespace{}, status.Errorf(codes.PermissionDenied, "the token is not scoped to namespace %s", namespace)
+	}
+	if namespace == nomad.DefaultNamespace {
+		return store.Namespace{}, nil
+	}
+	record, err := registry.Namespace(namespace)
+	if errors.Is(err, store.ErrNotFound) {
+		return store.Namespace{}, status.Errorf(codes.NotFound, "namespace %s does not exist", namespace)
+	} else if err != nil {
+		return store.Namespace{}, status.Errorf(codes.Internal, "failed to read namespace %s: %v", namespace, err)
+	}
+	return record, nil
+}
+
+// applicationInNamespace reports whether the application, by its job ID or name, runs in
+// the namespace. Applications the registry has not recorded run in the default namespace.
+func applicationInNamespace(registry store.Store, application, namespace string) (bool, error) {
+	names, err := registry.JobNames()
+	if err != nil {
+		return false, err
+	}
+	// the jobs of dispatched and periodic runs are named after their parent
+	application, _, _ = strings.Cut(application, "/")
+	recorded := false
+	for _, name := range names {
+		if name.JobID != application && name.Application != application {
+			continue
+		}
+		if jobNamespace(name) == namespace {
+			return true, nil
+		}
+		recorded = true
+	}
+	return !recorded && namespace == nomad.DefaultNamespace, nil
+}
+
+// checkRequestNamespace checks the namespace of a request: the token must be scoped to it,
+// it must exist and the application the RPC addresses must run in it
+func checkRequestNamespace(ctx context.Context, registry store.Store, method string, req any) error {
+	message, ok := req.(proto.Message)
+	if !ok || unnamespacedMethods[method] {
+		return nil
+	}
+	request := message.ProtoReflect()
+	field := request.Descriptor().Fields().ByName("namespace")
+	if field == nil {
+		if scoped := tokenNamespaces(ctx); scoped != nil {
+			return status.Errorf(codes.PermissionDenied, "%s is not namespaced, the token is scoped to the namespaces %s", method, strings.Join(scoped, ", "))
+		}
+		return nil
+	}
+
+	namespace := request.Get(field).String()
+	if namespace == allNamespaces {
+		if !listMethods[method] {
+			return status.Errorf(codes.InvalidArgument, "%s does not serve every namespace at once", method)
+		}
+		if tokenNamespaces(ctx) != nil {
+			return status.Error(codes.PermissionDenied, "the token is not scoped to every namespace")
+		}
+		return nil
+	}
+	if _, err := checkNamespace(ctx, registry, namespace); err != nil {
+		return err
+	}
+
+	name, ok := applicationFields[method]
+	if !ok {
+		return nil
+	}
+	application := request.Get(request.Descriptor().Fields().ByName(name)).String()
+	if application == "" {
+		return nil
+	}
+	namespace = cmp.Or(namespace, nomad.DefaultNamespace)
+	found, err := applicationInNamespace(registry, application, namespace)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to read the job names: %v", err)
+	}
+	if !found {
+		return status.Errorf(codes.NotFound, "application %s not found in namespace %s", application, namespace)
+	}
+	return nil
+}
+
+// NamespaceInterceptor keeps the namespaces apart: the RPCs may only address the
+// applications of the namespace of their request, which must exist and the token be scoped
+// to. The tokens scoped to namespaces cannot call the RPCs without one, those of a tenant
+// only act for it.
+func NamespaceInterceptor(registry store.Store) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkRequestTenant(ctx, req); err != nil {
+			return nil, err
+		}
+		if err := checkRequestNamespace(ctx, registry, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NamespaceStreamInterceptor is NamespaceInterceptor for streaming RPCs, it checks every
+// message the client sends
+func NamespaceStreamInterceptor(registry store.Store) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &namespaceStream{ServerStream: stream, registry: registry, method: info.FullMethod})
+	}
+}
+
+type namespaceStream struct {
+	grpc.ServerStream
+	registry store.Store
+	method   string
+}
+
+func (s *namespaceStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := checkRequestTenant(s.Context(), m); err != nil {
+		return err
+	}
+	return checkRequestNamespace(s.Context(), s.registry, s.method, m)
+}
+
+// checkDeployNamespace checks an application may be deployed to the namespace of its spec:
+// the token is scoped to it, the namespace admits the tenant of the spec and the job does
+// not run in another namespace already
+func (s *ApplicationService) checkDeployNamespace(ctx context.Context, req *pb.DeployRequest, jobID string) error {
+	record, err := checkNamespace(ctx, s.registry, req.Namespace)
+	if err != nil {
+		return errors.New(status.Convert(err).Message())
+	}
+	if record.Tenant != "" && req.Tenant != record.Tenant {
+		return fmt.Errorf("namespace %s only admits the applications of tenant %s", record.Name, record.Tenant)
+	}
+	namespace := cmp.Or(req.Namespace, nomad.DefaultNamespace)
+	if existing := s.jobName(jobID); !existing.CreatedAt.IsZero() && jobNamespace(existing) != namespace {
+		return fmt.Errorf("job %s runs in another namespace, delete it there before deploying it to %s", jobID, namespace)
+	}
+	return nil
+}
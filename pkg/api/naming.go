@@ -0,0 +1,185 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// Job naming strategies, combined into <tenant>-<name>-<environment>-<hash>
+const (
+	NamingName              = "name" // the job ID is the application name
+	NamingTenantPrefix      = "tenant-prefix"
+	NamingEnvironmentSuffix = "environment-suffix"
+	NamingHash              = "hash" // of tenant, name and environment
+)
+
+// Collision strategies, applied when the job ID belongs to another application
+const (
+	CollisionReject = "reject"
+	CollisionHash   = "hash" // append the hash to the job ID
+)
+
+// JobNaming derives the Nomad job IDs of applications, which would otherwise collide when
+// two tenants deploy the same name. A nil JobNaming uses the application name.
+type JobNaming struct {
+	Strategies  []string
+	Environment string // suffix of environment-suffix, e.g. staging
+	Collision   string
+}
+
+// ParseJobNaming parses the comma separated strategies of the controller
+func ParseJobNaming(strategies, environment, collision string) (*JobNaming, error) {
+	naming := &JobNaming{Environment: environment, Collision: collision}
+	for _, strategy := range strings.Split(strategies, ",") {
+		switch strategy {
+		case NamingName:
+		case NamingTenantPrefix, NamingHash:
+			naming.Strategies = append(naming.Strategies, strategy)
+		case NamingEnvironmentSuffix:
+			if environment == "" {
+				return nil, fmt.Errorf("the environment-suffix strategy needs an environment")
+			}
+			naming.Strategies = append(naming.Strategies, strategy)
+		default:
+			return nil, fmt.Errorf("unknown job naming strategy %q", strategy)
+		}
+	}
+	if collision != CollisionReject && collision != CollisionHash {
+		return nil, fmt.Errorf("unknown collision strategy %q", collision)
+	}
+	return naming, nil
+}
+
+func (n *JobNaming) jobID(tenant, name string) string {
+	if n == nil {
+		return name
+	}
+
+	id := name
+	if slices.Contains(n.Strategies, NamingTenantPrefix) && tenant != "" {
+		id = tenant + "-" + id
+	}
+	if slices.Contains(n.Strategies, NamingEnvironmentSuffix) {
+		id += "-" + n.Environment
+	}
+	if slices.Contains(n.Strategies, NamingHash) {
+		id += "-" + n.hash(tenant, name)
+	}
+	return id
+}
+
+func (n *JobNaming) hash(tenant, name string) string {
+	sum := sha256.Sum256([]byte(tenant + "/" + name + "/" + n.Environment))
+	return hex.EncodeToString(sum[:4])
+}
+
+// jobIDFor derives the job ID of the application to deploy. A job ID of another
+// application is rejected, or suffixed with the hash by the hash collision strategy.
+func (s *ApplicationService) jobIDFor(req *pb.DeployRequest) (string, error) {
+	names, err := s.registry.JobNames()
+	if err != nil {
+		return "", err
+	}
+	taken := func(id string) error {
+		for _, name := range names {
+			if name.JobID == id && (name.Application != req.Name || name.Tenant != req.Tenant) {
+				return fmt.Errorf("job %s belongs to application %s of tenant %q, choose another name", id, name.Application, name.Tenant)
+			}
+		}
+		return nil
+	}
+
+	id := s.naming.jobID(req.Tenant, req.Name)
+	if err := taken(id); err != nil {
+		if s.naming == nil || s.naming.Collision != CollisionHash {
+			return "", err
+		}
+		id += "-" + s.naming.hash(req.Tenant, req.Name)
+		if err := taken(id); err != nil {
+			return "", err
+		}
+	}
+	return id, nil
+}
+
+// recordJobID records the job of a deployed application and its project, status output
+// and the RPCs addressing it by its name resolve the job from it
+func (s *ApplicationService) recordJobID(jobID, application, tenant, project, namespace string) {
+	environment := ""
+	if s.naming != nil {
+		environment = s.naming.Environment
+	}
+	existing := s.jobName(jobID)
+	if existing.Application == application && existing.Tenant == tenant && existing.Project == project && existing.Namespace == namespace && !existing.CreatedAt.IsZero() {
+		return
+	}
+	createdAt := time.Now()
+	if existing.Application == application && !existing.CreatedAt.IsZero() {
+		// the application moved to another project
+		createdAt = existing.CreatedAt
+	}
+
+	err := s.registry.SaveJobName(store.JobName{
+		JobID:       jobID,
+		Application: application,
+		Tenant:      tenant,
+		Environment: environment,
+		Project:     project,
+		Namespace:   namespace,
+		CreatedAt:   createdAt,
+	})
+	if err != nil {
+		log.Printf("Failed to record the job of %s: %v", application, err)
+	}
+}
+
+// resolveJobID maps the name an RPC was given to the job of the application: job IDs
+// are taken as is, application names must be unique across tenants
+func (s *ApplicationService) resolveJobID(name string) (string, error) {
+	names, err := s.registry.JobNames()
+	if err != nil {
+		return "", err
+	}
+
+	var matches []string
+	for _, jobName := range names {
+		if jobName.JobID == name {
+			return name, nil
+		}
+		if jobName.Application == name {
+			matches = append(matches, jobName.JobID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		// deployed before the naming was recorded
+		return name, nil
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("application %s is deployed by several tenants, use one of the job IDs %s", name, strings.Join(matches, ", "))
+	}
+}
+
+// jobName returns the recorded application of a job, for status output
+func (s *ApplicationService) jobName(jobID string) store.JobName {
+	names, err := s.registry.JobNames()
+	if err != nil {
+		log.Printf("Failed to read the job names: %v", err)
+	}
+	for _, name := range names {
+		if name.JobID == jobID {
+			return name
+		}
+	}
+	return store.JobName{JobID: jobID, Application: jobID}
+}
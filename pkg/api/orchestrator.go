@@ -0,0 +1,415 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/orchestrator"
+)
+
+// portableMethods are the RPCs served on every orchestrator, the others need Nomad
+var portableMethods = map[string]bool{
+	pb.ControlPlane_DeployApplication_FullMethodName:    true,
+	pb.ControlPlane_DeleteApplication_FullMethodName:    true,
+	pb.ControlPlane_GetApplicationStatus_FullMethodName: true,
+	pb.ControlPlane_ListApplications_FullMethodName:     true,
+	pb.ControlPlane_ScaleApplication_FullMethodName:     true,
+	pb.ControlPlane_RollbackApplication_FullMethodName:  true,
+	pb.ControlPlane_UpdateApplication_FullMethodName:    true,
+	pb.ControlPlane_GetApplicationLogs_FullMethodName:   true,
+	pb.ControlPlane_GetLogs_FullMethodName:              true,
+	pb.ControlPlane_ListRevisions_FullMethodName:        true,
+	pb.ControlPlane_SaveProject_FullMethodName:          true,
+	pb.ControlPlane_DeleteProject_FullMethodName:        true,
+	pb.ControlPlane_ListProjects_FullMethodName:         true,
+	pb.ControlPlane_GetProject_FullMethodName:           true,
+	pb.ControlPlane_HealthCheck_FullMethodName:          true,
+}
+
+// kubernetesName is a DNS label, the names of Kubernetes objects are more restricted than
+// the names of Nomad jobs
+var kubernetesName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// OrchestratorInterceptor rejects the RPCs the orchestrator cannot serve when the
+// controller does not run applications on Nomad.
+func OrchestratorInterceptor(orch orchestrator.Orchestrator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !portableMethods[info.FullMethod] {
+			return nil, status.Errorf(codes.Unimplemented, "%s requires the Nomad orchestrator, the controller runs on %s", info.FullMethod, orch)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// OrchestratorStreamInterceptor is OrchestratorInterceptor for streaming RPCs
+func OrchestratorStreamInterceptor(orch orchestrator.Orchestrator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !portableMethods[info.FullMethod] {
+			return status.Errorf(codes.Unimplemented, "%s requires the Nomad orchestrator, the controller runs on %s", info.FullMethod, orch)
+		}
+		return handler(srv, stream)
+	}
+}
+
+// deployToOrchestrator deploys a validated spec to an orchestrator other than Nomad, specs
+// using what only Nomad supports are rejected rather than deployed without it
+func (s *ApplicationService) deployToOrchestrator(ctx context.Context, req *pb.DeployRequest, application string) (*pb.DeployResponse, error) {
+	if !kubernetesName.MatchString(req.Name) || len(req.Name) > 63 {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Invalid deployment spec: name %s must be a lowercase DNS label of at most 63 characters on %s", req.Name, s.orchestrator),
+		}, nil
+	}
+	if fields := unportableFields(req); len(fields) > 0 {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Invalid deployment spec: %s require the Nomad orchestrator, the controller runs on %s", strings.Join(fields, ", "), s.orchestrator),
+		}, nil
+	}
+
+	app := &orchestrator.Application{
+		ID:          req.Name,
+		Image:       req.Image,
+		Instances:   int(req.Replicas),
+		CPU:         req.Cpu,
+		MemoryMB:    req.Memory,
+		Env:         req.Env,
+		Labels:      req.Labels,
+		Annotations: req.Annotations,
+	}
+	for _, port := range req.Ports {
+		app.Ports = append(app.Ports, orchestrator.Port{Label: port.Label, ContainerPort: int(port.ContainerPort), Protocol: port.Protocol})
+	}
+	if traefik := req.Traefik; traefik != nil && traefik.Enable {
+		app.Host, app.SSL, app.HealthPath = traefik.Host, traefik.EnableSsl, traefik.HealthCheckPath
+		if len(app.Ports) == 0 {
+			app.Ports = []orchestrator.Port{{Label: "http", ContainerPort: 80, Protocol: "http"}}
+		}
+		// the routed port comes first, like the primary port of a Nomad job
+		sort.SliceStable(app.Ports, func(i, j int) bool {
+			return app.Ports[i].Protocol == "http" && app.Ports[j].Protocol != "http"
+		})
+	}
+
+	ref, err := s.orchestrator.Deploy(ctx, app)
+	if err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Failed to deploy application: %v", err),
+		}, nil
+	}
+	s.recordJobID(req.Name, application, req.Tenant, req.Labels[projectLabel], "")
+	s.recordRevision(ctx, req.Name, application, req, ref)
+
+	return &pb.DeployResponse{
+		DeploymentId: ref,
+		Status:       "SUBMITTED",
+		Message:      "Application deployment submitted successfully",
+		JobId:        req.Name,
+	}, nil
+}
+
+// unportableFields names the fields of a spec only the Nomad orchestrator implements
+func unportableFields(req *pb.DeployRequest) []string {
+	var fields []string
+	add := func(set bool, field string) {
+		if set {
+			fields = append(fields, field)
+		}
+	}
+
+	add(req.Type != pb.DeploymentType_DEPLOYMENT_TYPE_UNSPECIFIED && req.Type != pb.DeploymentType_DEPLOYMENT_TYPE_SERVICE, "functions and cron jobs")
+	add(req.Tenant != "", "tenant")
+	add(req.Namespace != "" && req.Namespace != nomad.DefaultNamespace, "namespaces")
+	add(req.Region != "", "region")
+	add(req.NetworkMode == pb.NetworkMode_NETWORK_MODE_HOST, "host network mode")
+	add(len(req.Constraints) > 0, "constraints")
+	add(req.EphemeralDisk != nil, "ephemeral_disk")
+	add(req.IdleTimeoutMinutes > 0, "idle_timeout_minutes")
+	add(len(req.DependsOn) > 0 || len(req.AllowFrom) > 0, "depends_on and allow_from")
+	add(len(req.Volumes) > 0 || req.Backup != nil, "volumes and backups")
+	add(len(req.Addons) > 0, "addons")
+	add(req.Egress != nil, "egress")
+	add(req.Security != nil, "security")
+	add(req.PinOnDrift, "pin_on_drift")
+	add(req.MemoryMax > 0, "memory_max")
+	add(req.ConcurrencyGroup != "" || req.RolloutDeadlineSeconds > 0 || req.Update != nil, "rollout settings")
+	add(req.Strategy != pb.DeployStrategy_DEPLOY_STRATEGY_UNSPECIFIED && req.Strategy != pb.DeployStrategy_DEPLOY_STRATEGY_ROLLING || req.TrafficShift != nil, "deploy strategies")
+	add(req.Placement != nil || req.Geo != nil, "placement and geo routing")
+	add(len(req.Actions) > 0, "actions")
+	add(req.ConsulKv != nil, "consul_kv")
+	add(req.Autoscaling != nil, "autoscaling")
+	add(req.DryRun, "dry_run")
+
+	expressions := false
+	for _, value := range req.Env {
+		expressions = expressions || strings.Contains(value, "${")
+	}
+	add(expressions, "environment expressions")
+
+	hostPorts, allocatedPorts := false, false
+	for _, port := range req.Ports {
+		hostPorts = hostPorts || port.HostPort > 0
+		allocatedPorts = allocatedPorts || port.ContainerPort == 0
+	}
+	add(hostPorts, "host ports")
+	add(allocatedPorts, "allocated container ports")
+
+	if traefik := req.Traefik; traefik != nil {
+		add(traefik.Entrypoint != "" || traefik.CertResolver != "" || traefik.CertStrategy != pb.CertStrategy_CERT_STRATEGY_UNSPECIFIED ||
+			len(traefik.CertSans) > 0 || traefik.SslHost != "" || traefik.HealthCheckInterval != "" ||
+			traefik.PathPrefix != "" || len(traefik.Middlewares) > 0 || len(traefik.CustomLabels) > 0, "traefik settings besides enable, host, enable_ssl and health_check_path")
+	}
+	return fields
+}
+
+// deleteFromOrchestrator deletes an application deployed by deployToOrchestrator
+func (s *ApplicationService) deleteFromOrchestrator(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	jobID, err := s.resolveJobID(req.DeploymentId)
+	if err == nil {
+		err = s.orchestrator.Delete(ctx, jobID)
+	}
+	if err != nil {
+		return &pb.DeleteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to delete application: %v", err),
+		}, nil
+	}
+
+	message := "Application deleted successfully"
+	s.publish(events.ApplicationDeleted, jobID, lifecycleEvent{Message: message})
+	if err := s.registry.DeleteJobName(jobID); err != nil {
+		log.Printf("Failed to remove the job name of %s: %v", jobID, err)
+	}
+	return &pb.DeleteResponse{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+// orchestratorStatus reports an application like GetApplicationStatus reports a job with
+// one task group, its instances are the allocations
+func (s *ApplicationService) orchestratorStatus(ctx context.Context, req *pb.StatusRequest) (*pb.StatusResponse, error) {
+	jobID, err := s.resolveJobID(req.DeploymentId)
+	var app *orchestrator.Status
+	if err == nil {
+		app, err = s.orchestrator.Status(ctx, jobID)
+	}
+	if err != nil {
+		return &pb.StatusResponse{
+			DeploymentId: req.DeploymentId,
+			Message:      fmt.Sprintf("Failed to get application status: %v", err),
+		}, nil
+	}
+
+	name := s.jobName(jobID)
+	resp := &pb.StatusResponse{
+		DeploymentId:     req.DeploymentId,
+		JobId:            jobID,
+		Application:      name.Application,
+		Tenant:           name.Tenant,
+		JobStatus:        app.Status,
+		JobType:          "service",
+		DesiredInstances: int32(app.Desired),
+		RunningInstances: int32(app.Running),
+		HealthyInstances: int32(app.Healthy),
+		FailedInstances:  int32(app.Failed),
+		TaskGroups: []*pb.TaskGroupStatus{{
+			Name:             jobID,
+			DesiredInstances: int32(app.Desired),
+			RunningInstances: int32(app.Running),
+			HealthyInstances: int32(app.Healthy),
+			FailedInstances:  int32(app.Failed),
+		}},
+		Message: "Application status retrieved successfully",
+	}
+	for _, instance := range app.Instances {
+		resp.Allocations = append(resp.Allocations, &pb.AllocationStatus{
+			AllocationId:  instance.ID,
+			NodeName:      instance.Node,
+			Status:        instance.Status,
+			DesiredStatus: "run",
+			CreateTime:    instance.StartedAt.UnixNano(),
+			TaskGroup:     jobID,
+		})
+	}
+	return resp, nil
+}
+
+// listOrchestrator is ListApplications for the applications of another orchestrator,
+// which has no regions
+func (s *ApplicationService) listOrchestrator(ctx context.Context, req *pb.ListApplicationsRequest) (*pb.ListApplicationsResponse, error) {
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return &pb.ListApplicationsResponse{
+			Message: fmt.Sprintf("Invalid page token: %v", err),
+		}, nil
+	}
+	apps, err := s.orchestrator.List(ctx)
+	if err != nil {
+		return &pb.ListApplicationsResponse{
+			Message: fmt.Sprintf("Failed to list applications: %v", err),
+		}, nil
+	}
+
+	var applications []*pb.ApplicationSummary
+	for _, app := range apps {
+		name := s.jobName(app.ID)
+		if req.Region != "" || !matchLabels(app.Labels, req.Labels) || (req.Project != "" && name.Project != req.Project) {
+			continue
+		}
+		applications = append(applications, &pb.ApplicationSummary{
+			Name:             name.Application,
+			JobId:            app.ID,
+			Tenant:           name.Tenant,
+			Type:             "service",
+			Status:           app.Status,
+			Image:            app.Image,
+			DesiredInstances: int32(app.Desired),
+			RunningInstances: int32(app.Running),
+			HealthyInstances: int32(app.Healthy),
+			FailedInstances:  int32(app.Failed),
+			SubmittedAt:      unixOrZero(app.UpdatedAt),
+			Owner:            applicationOwner(app.Labels, name.Tenant),
+		})
+		s.describeRevision(applications[len(applications)-1])
+	}
+	key := func(summary *pb.ApplicationSummary) pageKey {
+		return pageKey{Name: summary.Name, JobID: summary.JobId}
+	}
+	sort.Slice(applications, func(i, j int) bool {
+		return key(applications[i]).less(key(applications[j]))
+	})
+	if req.PageToken != "" {
+		start := sort.Search(len(applications), func(i int) bool { return after.less(key(applications[i])) })
+		applications = applications[start:]
+	}
+
+	var nextPageToken string
+	if req.PageSize > 0 && len(applications) > int(req.PageSize) {
+		applications = applications[:req.PageSize]
+		nextPageToken = encodePageToken(key(applications[len(applications)-1]))
+	}
+	message := fmt.Sprintf("Listed %d applications", len(applications))
+	if nextPageToken != "" {
+		message += ", more on the next page"
+	}
+	return &pb.ListApplicationsResponse{
+		Applications:  applications,
+		Message:       message,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// matchLabels tells whether labels hold every label of the selector
+func matchLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if label, ok := labels[key]; !ok || label != value {
+			return false
+		}
+	}
+	return true
+}
+
+// scaleOrchestrator scales an application of another orchestrator, which has no task
+// groups besides the application
+func (s *ApplicationService) scaleOrchestrator(ctx context.Context, req *pb.ScaleRequest) (*pb.ScaleResponse, error) {
+	jobID, err := s.resolveJobID(req.DeploymentId)
+	var app *orchestrator.Status
+	if err == nil {
+		app, err = s.orchestrator.Status(ctx, jobID)
+	}
+	previous := 0
+	if err == nil {
+		previous, err = s.orchestrator.Scale(ctx, jobID, int(req.Count))
+	}
+	if err != nil {
+		return &pb.ScaleResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to scale application: %v", err),
+		}, nil
+	}
+
+	resp := &pb.ScaleResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("Application %s scaled to %d", jobID, req.Count),
+		TaskGroup:     jobID,
+		PreviousCount: int32(previous),
+		DesiredCount:  req.Count,
+		RunningCount:  int32(app.Running),
+	}
+	if resp.PreviousCount != req.Count {
+		resp.Message = fmt.Sprintf("Application %s scaled from %d to %d, %d instances running", jobID, resp.PreviousCount, req.Count, resp.RunningCount)
+	}
+	s.publish(events.ApplicationScaled, jobID, lifecycleEvent{TaskGroup: jobID, Count: &req.Count})
+	return resp, nil
+}
+
+// orchestratorLogs returns the last lines of an instance of another orchestrator, the
+// allocation of the request is the instance
+func (s *ApplicationService) orchestratorLogs(ctx context.Context, req *pb.LogsRequest) (*pb.LogsResponse, error) {
+	logType, tailLines, err := logOptions(req)
+	if err != nil {
+		return &pb.LogsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	jobID, err := s.resolveJobID(req.DeploymentId)
+	var lines []string
+	if err == nil {
+		lines, err = s.orchestrator.Logs(ctx, jobID, req.AllocationId, tailLines, logType == "stderr")
+	}
+	if err != nil {
+		return &pb.LogsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get logs: %v", err),
+		}, nil
+	}
+
+	return &pb.LogsResponse{
+		LogLines: lines,
+		Success:  true,
+		Message:  fmt.Sprintf("Logs of application %s", jobID),
+	}, nil
+}
+
+// streamOrchestratorLogs sends the last lines of an instance in one chunk, following the
+// log needs Nomad
+func (s *ApplicationService) streamOrchestratorLogs(req *pb.LogsRequest, stream grpc.ServerStreamingServer[pb.LogChunk]) error {
+	if req.Follow {
+		return stream.Send(&pb.LogChunk{Error: fmt.Sprintf("Following logs requires the Nomad orchestrator, the controller runs on %s", s.orchestrator)})
+	}
+	resp, _ := s.orchestratorLogs(stream.Context(), req)
+	if !resp.Success {
+		return stream.Send(&pb.LogChunk{AllocationId: req.AllocationId, Error: resp.Message})
+	}
+	return stream.Send(&pb.LogChunk{Lines: resp.LogLines, AllocationId: req.AllocationId})
+}
+
+// orchestratorHealth reports the health of another orchestrator, which has no load
+// shedding
+func (s *ApplicationService) orchestratorHealth() *pb.HealthCheckResponse {
+	resp := &pb.HealthCheckResponse{
+		Status:    pb.HealthStatus_SERVING,
+		Message:   "Service is healthy",
+		Timestamp: time.Now().Unix(),
+	}
+	if err := s.orchestrator.HealthCheck(); err != nil {
+		resp.Status = pb.HealthStatus_NOT_SERVING
+		resp.Message = fmt.Sprintf("%s orchestrator unhealthy: %v", s.orchestrator, err)
+	}
+	return resp
+}
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// PlacementRegion is a region of the Nomad federation applications may be placed in
+type PlacementRegion struct {
+	Name    string            `json:"name"`
+	Labels  map[string]string `json:"labels"`     // matched by the region selector of deployments, e.g. provider=aws
+	Latency map[string]int    `json:"latency_ms"` // to the locations of latency hints, e.g. {"eu": 20, "us": 90}
+	Cost    float64           `json:"cost"`       // relative price of the region's capacity
+	Ingress []string          `json:"ingress"`    // IP addresses or hostname of the edge proxy, the records of geo routing point at
+}
+
+// PlacementWeights weigh the partial scores of a region, each between 0 and 1
+type PlacementWeights struct {
+	Capacity float64 `json:"capacity"`
+	Latency  float64 `json:"latency"`
+	Cost     float64 `json:"cost"`
+}
+
+// PlacementPolicy chooses the region of deployments naming none. A nil policy, or one of
+// a single region, leaves the region to Nomad.
+type PlacementPolicy struct {
+	Regions []PlacementRegion `json:"regions"`
+	Weights PlacementWeights  `json:"weights"`
+}
+
+// LoadPlacementPolicy reads the regions and weights of the placement engine from a JSON file
+func LoadPlacementPolicy(file string) (*PlacementPolicy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &PlacementPolicy{Weights: PlacementWeights{Capacity: 1, Latency: 1, Cost: 1}}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("invalid placement config %s: %w", file, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, region := range policy.Regions {
+		if region.Name == "" {
+			return nil, fmt.Errorf("placement region needs a name")
+		}
+		if seen[region.Name] {
+			return nil, fmt.Errorf("placement region %s is listed twice", region.Name)
+		}
+		seen[region.Name] = true
+		if region.Cost < 0 {
+			return nil, fmt.Errorf("placement region %s: cost cannot be negative", region.Name)
+		}
+		if len(region.Ingress) > 1 && ingressType(region.Ingress) == "CNAME" {
+			return nil, fmt.Errorf("placement region %s: the ingress is either IP addresses or a single hostname", region.Name)
+		}
+		for _, address := range region.Ingress {
+			if len(region.Ingress) > 1 && ingressType([]string{address}) != ingressType(region.Ingress) {
+				return nil, fmt.Errorf("placement region %s: the ingress addresses must all be IPv4 or IPv6", region.Name)
+			}
+		}
+	}
+	if policy.Weights.Capacity < 0 || policy.Weights.Latency < 0 || policy.Weights.Cost < 0 {
+		return nil, fmt.Errorf("placement weights cannot be negative")
+	}
+
+	return policy, nil
+}
+
+// enabled reports whether the policy has regions to choose from
+func (p *PlacementPolicy) enabled() bool {
+	return p != nil && len(p.Regions) > 1
+}
+
+// place chooses the region of a deployment naming none. An application keeps the region
+// it was placed in, moving it would leave its job running in the previous one.
+func (s *ApplicationService) place(application string, req *pb.DeployRequest) (store.PlacementDecision, error) {
+	if previous, err := s.registry.Placement(application); err == nil {
+		return previous, nil
+	}
+	return s.decidePlacement(application, req)
+}
+
+// decidePlacement scores the regions of the policy for a deployment, regions lacking the
+// selector's labels or the free capacity for its replicas are not eligible
+func (s *ApplicationService) decidePlacement(application string, req *pb.DeployRequest) (store.PlacementDecision, error) {
+	selector := req.GetPlacement().GetRegionSelector()
+	hint := req.GetPlacement().GetLatencyHint()
+	replicas := max(int64(req.Replicas), 1)
+	cpu, memory := int64(req.Cpu*10)*replicas, req.Memory*replicas
+
+	maxLatency, maxCost := 0, 0.0
+	for _, region := range s.placement.Regions {
+		if latency, ok := region.Latency[hint]; ok {
+			maxLatency = max(maxLatency, latency)
+		}
+		maxCost = max(maxCost, region.Cost)
+	}
+
+	decision := store.PlacementDecision{Application: application, DecidedAt: time.Now()}
+	for _, region := range s.placement.Regions {
+		decision.Candidates = append(decision.Candidates, store.PlacementCandidate{Region: region.Name})
+		candidate := &decision.Candidates[len(decision.Candidates)-1]
+
+		if key, ok := missingLabel(region.Labels, selector); !ok {
+			candidate.Reason = fmt.Sprintf("label %s=%s not set", key, selector[key])
+			continue
+		}
+		if _, ok := region.Latency[hint]; hint != "" && !ok {
+			candidate.Reason = fmt.Sprintf("no latency to %s", hint)
+			continue
+		}
+
+		capacity, err := s.orhClient.RegionCapacity(region.Name)
+		if err != nil {
+			candidate.Reason = fmt.Sprintf("capacity unknown: %v", err)
+			continue
+		}
+		candidate.CPUFreeMHz, candidate.MemoryFreeMB = capacity.CPUFreeMHz, capacity.MemoryFreeMB
+		if capacity.CPUFreeMHz < cpu || capacity.MemoryFreeMB < memory {
+			candidate.Reason = fmt.Sprintf("%d MHz and %d MB free, %d MHz and %d MB needed", capacity.CPUFreeMHz, capacity.MemoryFreeMB, cpu, memory)
+			continue
+		}
+
+		candidate.Eligible = true
+		if capacity.MemoryTotalMB > 0 {
+			candidate.CapacityScore = float64(capacity.MemoryFreeMB-memory) / float64(capacity.MemoryTotalMB)
+		}
+		candidate.LatencyScore = 1
+		if hint != "" && maxLatency > 0 {
+			candidate.LatencyScore = 1 - float64(region.Latency[hint])/float64(maxLatency)
+		}
+		candidate.CostScore = 1
+		if maxCost > 0 {
+			candidate.CostScore = 1 - region.Cost/maxCost
+		}
+		weights := s.placement.Weights
+		candidate.Score = weights.Capacity*candidate.CapacityScore + weights.Latency*candidate.LatencyScore + weights.Cost*candidate.CostScore
+	}
+
+	slices.SortStableFunc(decision.Candidates, func(a, b store.PlacementCandidate) int {
+		switch {
+		case a.Eligible != b.Eligible && a.Eligible:
+			return -1
+		case a.Eligible != b.Eligible:
+			return 1
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+
+	if len(decision.Candidates) == 0 || !decision.Candidates[0].Eligible {
+		var reasons []string
+		for _, candidate := range decision.Candidates {
+			reasons = append(reasons, candidate.Region+": "+candidate.Reason)
+		}
+		return decision, fmt.Errorf("no region is eligible (%s)", strings.Join(reasons, "; "))
+	}
+	decision.Region = decision.Candidates[0].Region
+	return decision, nil
+}
+
+// missingLabel returns the first key of the selector the labels do not match
+func missingLabel(labels, selector map[string]string) (string, bool) {
+	for _, key := range slices.Sorted(maps.Keys(selector)) {
+		if labels[key] != selector[key] {
+			return key, false
+		}
+	}
+	return "", true
+}
+
+// nomadFor returns the client of the region an application was placed in, or the first
+// region of its geo route. Applications naming their region are addressed in the
+// controller's region.
+func (s *ApplicationService) nomadFor(jobID string) (*nomad.NomadClient, error) {
+	if decision, err := s.registry.Placement(jobID); err == nil {
+		return s.orhClient.InRegion(decision.Region)
+	}
+	if route, err := s.registry.GeoRoute(jobID); err == nil {
+		return s.orhClient.InRegion(route.Targets[0].Region)
+	}
+	return s.orhClient, nil
+}
+
+// recordPlacement records the region a deployed application was placed in
+func (s *ApplicationService) recordPlacement(decision store.PlacementDecision) {
+	if decision.Region == "" {
+		return
+	}
+	if previous, err := s.registry.Placement(decision.Application); err == nil && previous.DecidedAt.Equal(decision.DecidedAt) {
+		return
+	}
+	if err := s.registry.SavePlacement(decision); err != nil {
+		log.Printf("Failed to record the placement of %s: %v", decision.Application, err)
+	}
+}
+
+// ExplainPlacement returns the recorded placement decision of an application, or decides
+// for a spec without deploying it
+func (s *ApplicationService) ExplainPlacement(ctx context.Context, req *pb.ExplainPlacementRequest) (*pb.ExplainPlacementResponse, error) {
+	if !s.placement.enabled() {
+		return &pb.ExplainPlacementResponse{
+			Message: "Placement is disabled, the controller has no -placement-config of several regions",
+		}, nil
+	}
+
+	var decision store.PlacementDecision
+	switch {
+	case req.Spec != nil:
+		if errs := ValidateSpec(req.Spec); len(errs) > 0 {
+			return &pb.ExplainPlacementResponse{
+				Message: fmt.Sprintf("Invalid deployment spec: %v", joinErrors(errs)),
+			}, nil
+		}
+		var err error
+		if decision, err = s.decidePlacement(req.Spec.Name, req.Spec); err != nil {
+			return placementResponse(decision, fmt.Sprintf("Placement failed: %v", err)), nil
+		}
+	case req.Name != "":
+		jobID, err := s.resolveJobID(req.Name)
+		if err == nil {
+			decision, err = s.registry.Placement(jobID)
+		}
+		if err != nil {
+			return &pb.ExplainPlacementResponse{
+				Message: fmt.Sprintf("No placement recorded for %s: %v", req.Name, err),
+			}, nil
+		}
+	default:
+		return &pb.ExplainPlacementResponse{Message: "Either a name or a spec is required"}, nil
+	}
+
+	resp := placementResponse(decision, fmt.Sprintf("%s is placed in %s", decision.Application, decision.Region))
+	resp.Success = true
+	return resp, nil
+}
+
+func placementResponse(decision store.PlacementDecision, message string) *pb.ExplainPlacementResponse {
+	resp := &pb.ExplainPlacementResponse{
+		Message:   message,
+		Region:    decision.Region,
+		DecidedAt: decision.DecidedAt.Unix(),
+	}
+	for _, candidate := range decision.Candidates {
+		resp.Candidates = append(resp.Candidates, &pb.PlacementCandidate{
+			Region:        candidate.Region,
+			Eligible:      candidate.Eligible,
+			Reason:        candidate.Reason,
+			Score:         candidate.Score,
+			CapacityScore: candidate.CapacityScore,
+			LatencyScore:  candidate.LatencyScore,
+			CostScore:     candidate.CostScore,
+			CpuFreeMhz:    candidate.CPUFreeMHz,
+			MemoryFreeMb:  candidate.MemoryFreeMB,
+		})
+	}
+	return resp
+}
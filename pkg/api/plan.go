@@ -0,0 +1,109 @@
+package api
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	nmd "github.com/hashicorp/nomad/api"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// planJob has the scheduler plan the job of a dry run, nothing is registered and none of
+// the side effects of a deployment happen
+func (s *ApplicationService) planJob(job *nmd.Job, jobID string) *pb.DeployResponse {
+	plan, err := s.orhClient.PlanJob(job)
+	if err != nil {
+		return &pb.DeployResponse{
+			JobId:   jobID,
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Failed to plan application: %v", err),
+		}
+	}
+
+	resp := &pb.DeployResponse{
+		JobId:  jobID,
+		Status: "PLANNED",
+		Plan:   jobPlan(plan),
+	}
+	resp.Message = fmt.Sprintf("Dry run: job %s would be %s, %d fields changed", jobID, strings.ToLower(resp.Plan.DiffType), len(resp.Plan.Changes))
+	if len(resp.Plan.FailedPlacements) > 0 {
+		resp.Message += fmt.Sprintf(", %d task groups could not be placed", len(resp.Plan.FailedPlacements))
+	}
+	return resp
+}
+
+// jobPlan flattens the diff of a plan into the paths of the changed fields
+func jobPlan(plan *nmd.JobPlanResponse) *pb.JobPlan {
+	result := &pb.JobPlan{
+		DiffType:       "None",
+		Warnings:       plan.Warnings,
+		JobModifyIndex: plan.JobModifyIndex,
+	}
+	if diff := plan.Diff; diff != nil {
+		result.DiffType = diff.Type
+		result.Changes = diffChanges(nil, diff.Fields, diff.Objects)
+		for _, group := range diff.TaskGroups {
+			path := []string{"Task Group " + group.Name}
+			if group.Type == "Added" || group.Type == "Deleted" {
+				result.Changes = append(result.Changes, &pb.PlanChange{Type: group.Type, Path: path[0]})
+				continue
+			}
+			result.Changes = append(result.Changes, diffChanges(path, group.Fields, group.Objects)...)
+			for _, task := range group.Tasks {
+				taskPath := append(slices.Clone(path), "Task "+task.Name)
+				if task.Type == "Added" || task.Type == "Deleted" {
+					result.Changes = append(result.Changes, &pb.PlanChange{Type: task.Type, Path: strings.Join(taskPath, " > ")})
+					continue
+				}
+				result.Changes = append(result.Changes, diffChanges(taskPath, task.Fields, task.Objects)...)
+			}
+		}
+	}
+
+	if plan.Annotations != nil {
+		for _, group := range slices.Sorted(maps.Keys(plan.Annotations.DesiredTGUpdates)) {
+			updates := plan.Annotations.DesiredTGUpdates[group]
+			result.Groups = append(result.Groups, &pb.PlanGroupUpdate{
+				TaskGroup:         group,
+				Place:             updates.Place,
+				Stop:              updates.Stop,
+				InPlaceUpdate:     updates.InPlaceUpdate,
+				DestructiveUpdate: updates.DestructiveUpdate,
+				Migrate:           updates.Migrate,
+				Canary:            updates.Canary,
+				Ignore:            updates.Ignore,
+				Preemptions:       updates.Preemptions,
+			})
+		}
+	}
+	for _, group := range slices.Sorted(maps.Keys(plan.FailedTGAllocs)) {
+		result.FailedPlacements = append(result.FailedPlacements, fmt.Sprintf("%s: %s", group, placementFailure(plan.FailedTGAllocs[group])))
+	}
+	return result
+}
+
+// diffChanges lists the changed fields of a diff and of its nested objects
+func diffChanges(path []string, fields []*nmd.FieldDiff, objects []*nmd.ObjectDiff) []*pb.PlanChange {
+	var changes []*pb.PlanChange
+	for _, field := range fields {
+		if field.Type == "None" {
+			continue
+		}
+		changes = append(changes, &pb.PlanChange{
+			Type: field.Type,
+			Path: strings.Join(append(slices.Clone(path), field.Name), " > "),
+			Old:  field.Old,
+			New:  field.New,
+		})
+	}
+	for _, object := range objects {
+		if object.Type == "None" {
+			continue
+		}
+		changes = append(changes, diffChanges(append(slices.Clone(path), object.Name), object.Fields, object.Objects)...)
+	}
+	return changes
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/metrics"
+)
+
+// Seasons of predictive scaling, the periods the usage of applications repeats with
+var predictionSeasons = map[string]time.Duration{
+	"daily":  24 * time.Hour,
+	"weekly": 7 * 24 * time.Hour,
+}
+
+const (
+	defaultPredictionConfidence = 0.8
+	defaultPredictionLead       = 15 * time.Minute
+	// a forecast averages the peaks of at least this many past seasons
+	minPredictionSeasons = 2
+	// the usage history forecasts read, two weekly seasons and a day
+	predictionHistory = 15 * 24 * time.Hour
+)
+
+// Events of predictive scaling, typed io.controlplane.application.scaling_predicted and
+// io.controlplane.application.prediction_outcome
+const (
+	eventScalingPredicted  = "application.scaling_predicted"
+	eventPredictionOutcome = "application.prediction_outcome"
+)
+
+// scalingPrediction is the forecast of the instances an application needs from when it
+// was made until the end of its lead, and once that passed the instances it needed
+type scalingPrediction struct {
+	Type        string    `json:"type"`
+	Application string    `json:"application"`
+	JobID       string    `json:"job_id"`
+	Tenant      string    `json:"tenant,omitempty"`
+	Season      string    `json:"season"`
+	From        time.Time `json:"from"`
+	Until       time.Time `json:"until"`
+	Seasons     int       `json:"seasons"`        // the past seasons averaged
+	CPU         float64   `json:"cpu_mhz"`        // the peak CPU of all instances
+	Instances   int       `json:"instances"`      // the instances the peak needs
+	Confidence  float64   `json:"confidence"`     // 1 when every season peaked alike
+	Applied     bool      `json:"applied"`        // confident enough to scale out ahead
+	ActualCPU   float64   `json:"actual_cpu_mhz"` // outcome: the peak CPU measured
+	Actual      int       `json:"actual_instances"`
+}
+
+func (t *autoscaleTracker) prediction(jobID string) (scalingPrediction, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prediction, ok := t.predictions[jobID]
+	return prediction, ok
+}
+
+func (t *autoscaleTracker) predict(prediction scalingPrediction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.predictions == nil {
+		t.predictions = make(map[string]scalingPrediction)
+	}
+	t.predictions[prediction.JobID] = prediction
+}
+
+// measured records the CPU the application uses and the instances it needs for it, the
+// highest ones are the outcome of the prediction
+func (t *autoscaleTracker) measured(jobID string, cpu float64, instances int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prediction, ok := t.predictions[jobID]
+	if !ok {
+		return
+	}
+	prediction.ActualCPU = max(prediction.ActualCPU, cpu)
+	prediction.Actual = max(prediction.Actual, instances)
+	t.predictions[jobID] = prediction
+}
+
+// validatePrediction checks the prediction of the autoscaling spec
+func validatePrediction(autoscaling *pb.Autoscaling) []error {
+	var errs []error
+	prediction := autoscaling.Prediction
+	if _, ok := predictionSeasons[prediction.Season]; !ok {
+		errs = append(errs, fmt.Errorf("prediction season must be daily or weekly"))
+	}
+	if prediction.MinConfidence < 0 || prediction.MinConfidence > 1 {
+		errs = append(errs, fmt.Errorf("prediction min confidence must be between 0 and 1"))
+	}
+	if prediction.LeadSeconds < 0 {
+		errs = append(errs, fmt.Errorf("prediction lead cannot be negative"))
+	}
+	if cpuMetric(autoscaling) == nil {
+		errs = append(errs, fmt.Errorf("prediction sizes the instances with the target of a cpu metric, add one"))
+	}
+	return errs
+}
+
+// cpuMetric returns the cpu metric of the autoscaling spec, or nil
+func cpuMetric(autoscaling *pb.Autoscaling) *pb.ScalingMetric {
+	for _, metric := range autoscaling.Metrics {
+		if metric.Provider == metrics.CPU {
+			return metric
+		}
+	}
+	return nil
+}
+
+// predictedCount returns the instances the prediction of the application needs until the
+// end of its lead, 0 when it is not confident. The usage, the mean percent of the CPU
+// reservation in use, is the outcome of the running prediction; once it ended it is
+// published and the next one is made.
+func (s *ApplicationService) predictedCount(jobID string, job *nmd.Job, autoscaling *pb.Autoscaling, usage float64, current int) int {
+	task := applicationTask(job, jobID)
+	if task == nil || task.Resources == nil || task.Resources.CPU == nil {
+		return 0
+	}
+	reserved := float64(*task.Resources.CPU)
+	target := cpuMetric(autoscaling).Target
+
+	now := time.Now()
+	prediction, ok := s.autoscaler.prediction(jobID)
+	if ok && now.Before(prediction.Until) {
+		s.autoscaler.measured(jobID, usage/100*reserved*float64(current), int(math.Ceil(float64(current)*usage/target)))
+		if prediction.Applied {
+			return prediction.Instances
+		}
+		return 0
+	}
+	if ok {
+		prediction.Type = eventPredictionOutcome
+		log.Printf("Predictive scaling: %s needed %d instances for %.0f MHz, %d were predicted for %.0f MHz", jobID, prediction.Actual, prediction.ActualCPU, prediction.Instances, prediction.CPU)
+		s.events.Publish(eventPredictionOutcome, prediction.Application, prediction)
+	}
+
+	prediction, err := s.forecast(jobID, autoscaling.Prediction, reserved*target/100, now)
+	if err != nil {
+		log.Printf("Predictive scaling: %s: %v", jobID, err)
+		return 0
+	}
+	if prediction.Seasons < minPredictionSeasons {
+		return 0
+	}
+	minConfidence := autoscaling.Prediction.MinConfidence
+	if minConfidence == 0 {
+		minConfidence = defaultPredictionConfidence
+	}
+	prediction.Applied = prediction.Confidence >= minConfidence
+	s.autoscaler.predict(prediction)
+
+	log.Printf("Predictive scaling: %s needs %d instances for %.0f MHz until %s, confidence %.2f over %d %s seasons, applied: %t",
+		jobID, prediction.Instances, prediction.CPU, prediction.Until.Format(time.TimeOnly), prediction.Confidence, prediction.Seasons, prediction.Season, prediction.Applied)
+	s.events.Publish(eventScalingPredicted, prediction.Application, prediction)
+	if prediction.Applied {
+		return prediction.Instances
+	}
+	return 0
+}
+
+// forecast averages the peaks of the CPU all instances used during the same window of the
+// past seasons, the window from now until the end of the lead. The confidence falls with
+// the spread of the peaks. Each instance handles the CPU of its reservation at the target.
+func (s *ApplicationService) forecast(jobID string, spec *pb.ScalingPrediction, perInstance float64, now time.Time) (scalingPrediction, error) {
+	name := s.jobName(jobID)
+	prediction := scalingPrediction{
+		Type:        eventScalingPredicted,
+		Application: name.Application,
+		JobID:       jobID,
+		Tenant:      name.Tenant,
+		Season:      spec.Season,
+		From:        now,
+		Until:       now.Add(defaultPredictionLead),
+		Confidence:  1,
+	}
+	if spec.LeadSeconds > 0 {
+		prediction.Until = now.Add(time.Duration(spec.LeadSeconds) * time.Second)
+	}
+
+	samples, err := s.registry.Usage(jobID, now.Add(-predictionHistory))
+	if err != nil || len(samples) == 0 {
+		return prediction, err
+	}
+	// the samples of one sampling share its time, their sum is the CPU of all instances
+	usage := make(map[time.Time]float64)
+	for _, sample := range samples {
+		usage[sample.At] += sample.CPU
+	}
+
+	period := predictionSeasons[spec.Season]
+	var peaks []float64
+	for past := period; !now.Add(-past).Before(samples[0].At); past += period {
+		from, until := prediction.From.Add(-past), prediction.Until.Add(-past)
+		peak, sampled := 0.0, false
+		for at, cpu := range usage {
+			if !at.Before(from) && at.Before(until) {
+				peak, sampled = max(peak, cpu), true
+			}
+		}
+		if sampled {
+			peaks = append(peaks, peak)
+		}
+	}
+	prediction.Seasons = len(peaks)
+	if len(peaks) == 0 {
+		return prediction, nil
+	}
+
+	mean := 0.0
+	for _, peak := range peaks {
+		mean += peak
+	}
+	mean /= float64(len(peaks))
+	variance := 0.0
+	for _, peak := range peaks {
+		variance += (peak - mean) * (peak - mean)
+	}
+	deviation := math.Sqrt(variance / float64(len(peaks)))
+
+	prediction.CPU = mean
+	prediction.Instances = int(math.Ceil(mean / perInstance))
+	if mean > 0 {
+		prediction.Confidence = max(0, 1-deviation/mean)
+	}
+	return prediction, nil
+}
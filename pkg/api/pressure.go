@@ -0,0 +1,69 @@
+package api
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// statusCache keeps the last status of every application, it is served instead of
+// asking Nomad while the controller sheds load
+type statusCache struct {
+	mu       sync.Mutex
+	statuses map[string]cachedStatus
+}
+
+type cachedStatus struct {
+	resp *pb.StatusResponse
+	at   time.Time
+}
+
+func (c *statusCache) put(jobID string, resp *pb.StatusResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.statuses == nil {
+		c.statuses = make(map[string]cachedStatus)
+	}
+	c.statuses[jobID] = cachedStatus{resp: proto.Clone(resp).(*pb.StatusResponse), at: time.Now()}
+}
+
+func (c *statusCache) get(jobID string) (cachedStatus, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.statuses[jobID]
+	return cached, ok
+}
+
+func (c *statusCache) forget(jobID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.statuses, jobID)
+}
+
+// cachedStatus returns the last status of the application while Nomad is under too much
+// pressure to be asked, nil when it is not or the status was never retrieved
+func (s *ApplicationService) cachedStatus(jobID, deploymentID string) *pb.StatusResponse {
+	pressure := s.orhClient.Pressure()
+	if pressure.Mode != nomad.ModeShedding {
+		return nil
+	}
+
+	cached, ok := s.statuses.get(jobID)
+	if !ok {
+		return nil
+	}
+
+	resp := proto.Clone(cached.resp).(*pb.StatusResponse)
+	resp.DeploymentId = deploymentID
+	resp.CachedAt = cached.at.Unix()
+	resp.Message = fmt.Sprintf("Application status from %s ago, Nomad is under pressure (%s latency)",
+		time.Since(cached.at).Round(time.Second), pressure.Latency.Round(time.Millisecond))
+	return resp
+}
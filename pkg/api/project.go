@@ -0,0 +1,329 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// projectLabel is the label applications join a project with
+const projectLabel = "project"
+
+// the status of a project is the worst health of its applications, in this order
+var projectStatuses = []string{"degraded", "progressing", "unknown", "healthy", "suspended"}
+
+// SaveProject creates or updates a project: the defaults its applications are deployed
+// with and the quota they share.
+func (s *ApplicationService) SaveProject(ctx context.Context, req *pb.SaveProjectRequest) (*pb.SaveProjectResponse, error) {
+	switch {
+	case !applicationName.MatchString(req.Name):
+		return &pb.SaveProjectResponse{Message: fmt.Sprintf("Invalid project name %q", req.Name)}, nil
+	case req.Defaults != nil && (req.Defaults.Name != "" || req.Defaults.Tenant != ""):
+		return &pb.SaveProjectResponse{Message: "The defaults of a project cannot set a name or tenant"}, nil
+	case req.Quota != nil && (req.Quota.Cpu < 0 || req.Quota.MemoryMb < 0 || req.Quota.MaxApplications < 0):
+		return &pb.SaveProjectResponse{Message: "The quota of a project cannot be negative"}, nil
+	}
+	if req.Tenant != "" {
+		if _, err := s.registry.Tenant(req.Tenant); err != nil {
+			return &pb.SaveProjectResponse{Message: fmt.Sprintf("Failed to find tenant: %v", err)}, nil
+		}
+	}
+
+	now := time.Now()
+	project := store.Project{
+		Name:        req.Name,
+		Tenant:      req.Tenant,
+		Description: req.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if quota := req.Quota; quota != nil {
+		project.Quota = store.ProjectQuota{
+			CPU:             quota.Cpu,
+			MemoryMB:        quota.MemoryMb,
+			MaxApplications: int(quota.MaxApplications),
+		}
+	}
+
+	var err error
+	if req.Defaults != nil {
+		project.Defaults, err = proto.Marshal(req.Defaults)
+		if err == nil {
+			project.Defaults, err = sealForTenant(ctx, s.registry, s.sealer, req.Tenant, project.Defaults)
+		}
+	}
+	if err == nil {
+		err = s.registry.SaveProject(project)
+	}
+	if err != nil {
+		return &pb.SaveProjectResponse{
+			Message: fmt.Sprintf("Failed to save project: %v", err),
+		}, nil
+	}
+
+	return &pb.SaveProjectResponse{
+		Success: true,
+		Message: fmt.Sprintf("Project %s saved, applications join it with the label %s=%s", req.Name, projectLabel, req.Name),
+	}, nil
+}
+
+// DeleteProject deletes a project once it has no applications
+func (s *ApplicationService) DeleteProject(ctx context.Context, req *pb.DeleteProjectRequest) (*pb.DeleteProjectResponse, error) {
+	_, err := s.registry.Project(req.Name)
+	var names []store.JobName
+	if err == nil {
+		names, err = s.projectApplications(req.Name)
+	}
+	if err == nil && len(names) > 0 {
+		err = fmt.Errorf("%d applications still belong to it, e.g. %s", len(names), names[0].Application)
+	}
+	if err == nil {
+		err = s.registry.DeleteProject(req.Name)
+	}
+	if err != nil {
+		return &pb.DeleteProjectResponse{
+			Message: fmt.Sprintf("Failed to delete project: %v", err),
+		}, nil
+	}
+
+	return &pb.DeleteProjectResponse{
+		Success: true,
+		Message: fmt.Sprintf("Project %s deleted", req.Name),
+	}, nil
+}
+
+// ListProjects rolls up the health and requested resources of the applications of
+// every project
+func (s *ApplicationService) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+	projects, err := s.registry.Projects(req.Tenant)
+	if err != nil {
+		return &pb.ListProjectsResponse{
+			Message: fmt.Sprintf("Failed to list projects: %v", err),
+		}, nil
+	}
+
+	resp := &pb.ListProjectsResponse{Success: true}
+	for _, project := range projects {
+		summary, err := s.projectSummary(ctx, project)
+		if err != nil {
+			return &pb.ListProjectsResponse{
+				Message: fmt.Sprintf("Failed to summarize project %s: %v", project.Name, err),
+			}, nil
+		}
+		resp.Projects = append(resp.Projects, summary)
+	}
+	resp.Message = fmt.Sprintf("Found %d projects", len(resp.Projects))
+	return resp, nil
+}
+
+// GetProject returns the roll-up of a project and its defaults, ListApplications lists
+// its applications
+func (s *ApplicationService) GetProject(ctx context.Context, req *pb.GetProjectRequest) (*pb.GetProjectResponse, error) {
+	project, err := s.registry.Project(req.Name)
+	var summary *pb.ProjectSummary
+	if err == nil {
+		summary, err = s.projectSummary(ctx, project)
+	}
+	var defaults *pb.DeployRequest
+	if err == nil {
+		defaults, err = s.projectDefaults(ctx, project)
+	}
+	if err != nil {
+		return &pb.GetProjectResponse{
+			Message: fmt.Sprintf("Failed to get project: %v", err),
+		}, nil
+	}
+
+	return &pb.GetProjectResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("Project %s has %d applications", project.Name, summary.Applications),
+		Project:  summary,
+		Defaults: defaults,
+	}, nil
+}
+
+// projectApplications returns the job names of the applications of a project
+func (s *ApplicationService) projectApplications(project string) ([]store.JobName, error) {
+	names, err := s.registry.JobNames()
+	if err != nil {
+		return nil, err
+	}
+	var applications []store.JobName
+	for _, name := range names {
+		if name.Project == project {
+			applications = append(applications, name)
+		}
+	}
+	return applications, nil
+}
+
+// projectSummary rolls up the health the search index keeps of the applications of a
+// project, applications it has not indexed yet are unknown
+func (s *ApplicationService) projectSummary(ctx context.Context, project store.Project) (*pb.ProjectSummary, error) {
+	names, err := s.projectApplications(project.Name)
+	if err != nil {
+		return nil, err
+	}
+	cpu, memoryMB, err := s.projectUsage(ctx, names, "")
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &pb.ProjectSummary{
+		Name:         project.Name,
+		Tenant:       project.Tenant,
+		Description:  project.Description,
+		Statuses:     make(map[string]int32),
+		Applications: int32(len(names)),
+		Quota: &pb.ProjectQuota{
+			Cpu:             project.Quota.CPU,
+			MemoryMb:        project.Quota.MemoryMB,
+			MaxApplications: int32(project.Quota.MaxApplications),
+		},
+		Usage: &pb.ProjectQuota{
+			Cpu:             cpu,
+			MemoryMb:        memoryMB,
+			MaxApplications: int32(len(names)),
+		},
+		CreatedAt: unixOrZero(project.CreatedAt),
+	}
+	for _, name := range names {
+		status := "unknown"
+		if entry, err := s.registry.SearchEntry(name.JobID); err == nil {
+			status = entry.Status
+		}
+		summary.Statuses[status]++
+	}
+	for _, status := range projectStatuses {
+		if summary.Statuses[status] > 0 {
+			summary.Status = status
+			break
+		}
+	}
+	return summary, nil
+}
+
+// projectUsage adds up the resources the latest revisions of applications request with
+// all their replicas, but those of one job
+func (s *ApplicationService) projectUsage(ctx context.Context, names []store.JobName, except string) (float64, int64, error) {
+	var cpu float64
+	var memoryMB int64
+	for _, name := range names {
+		if name.JobID == except {
+			continue
+		}
+		revision, err := s.registry.Revision(name.JobID, 0)
+		if errors.Is(err, store.ErrNotFound) {
+			// deployed before the registry recorded revisions
+			continue
+		}
+		var spec *pb.DeployRequest
+		if err == nil {
+			spec, err = s.revisionSpec(ctx, revision)
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("revision of %s: %w", name.JobID, err)
+		}
+		replicas := max(spec.Replicas, 1)
+		cpu += spec.Cpu * float64(replicas)
+		memoryMB += spec.Memory * int64(replicas)
+	}
+	return cpu, memoryMB, nil
+}
+
+// projectDefaults opens and parses the defaults of a project, nil when it has none
+func (s *ApplicationService) projectDefaults(ctx context.Context, project store.Project) (*pb.DeployRequest, error) {
+	if len(project.Defaults) == 0 {
+		return nil, nil
+	}
+	data, err := openForTenant(ctx, s.registry, s.sealer, project.Tenant, project.Defaults)
+	if err != nil {
+		return nil, err
+	}
+	defaults := &pb.DeployRequest{}
+	if err := proto.Unmarshal(data, defaults); err != nil {
+		return nil, fmt.Errorf("invalid defaults: %w", err)
+	}
+	return defaults, nil
+}
+
+// applyProject merges the spec of an application of a project over the defaults of the
+// project. Fields set by the spec win, its lists replace those of the defaults.
+func (s *ApplicationService) applyProject(ctx context.Context, req *pb.DeployRequest) (*pb.DeployRequest, error) {
+	name := req.Labels[projectLabel]
+	if name == "" {
+		return req, nil
+	}
+	project, err := s.registry.Project(name)
+	if err != nil {
+		return nil, err
+	}
+	if project.Tenant != req.Tenant {
+		if project.Tenant == "" {
+			return nil, fmt.Errorf("project %s is a platform project, tenant %s cannot join it", name, req.Tenant)
+		}
+		return nil, fmt.Errorf("project %s belongs to tenant %s", name, project.Tenant)
+	}
+
+	defaults, err := s.projectDefaults(ctx, project)
+	if err != nil || defaults == nil {
+		return req, err
+	}
+	proto.Merge(defaults, req)
+	spec := req.ProtoReflect()
+	spec.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if field.IsList() {
+			defaults.ProtoReflect().Set(field, value)
+		}
+		return true
+	})
+	return defaults, nil
+}
+
+// checkProjectQuota checks that the applications of the project of a spec stay within its
+// quota once the spec is deployed
+func (s *ApplicationService) checkProjectQuota(ctx context.Context, req *pb.DeployRequest, jobID string) error {
+	name := req.Labels[projectLabel]
+	if name == "" {
+		return nil
+	}
+	project, err := s.registry.Project(name)
+	if err != nil {
+		return err
+	}
+	quota := project.Quota
+
+	names, err := s.projectApplications(name)
+	if err != nil {
+		return err
+	}
+	applications := 1
+	for _, existing := range names {
+		if existing.JobID != jobID {
+			applications++
+		}
+	}
+	if quota.MaxApplications > 0 && applications > quota.MaxApplications {
+		return fmt.Errorf("project %s may have %d applications", name, quota.MaxApplications)
+	}
+
+	cpu, memoryMB, err := s.projectUsage(ctx, names, jobID)
+	if err != nil {
+		return err
+	}
+	replicas := max(req.Replicas, 1)
+	cpu += req.Cpu * float64(replicas)
+	memoryMB += req.Memory * int64(replicas)
+	if (quota.CPU > 0 && cpu > quota.CPU) || (quota.MemoryMB > 0 && memoryMB > quota.MemoryMB) {
+		return fmt.Errorf("the applications of project %s would request %.1f cores and %d MB, its quota is %.1f cores and %d MB",
+			name, cpu, memoryMB, quota.CPU, quota.MemoryMB)
+	}
+	return nil
+}
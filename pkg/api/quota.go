@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// checkTenantQuota checks that the applications of a tenant stay within its quota once the
+// job is deployed with the resources, the version of the job it replaces no longer counted
+func (s *ApplicationService) checkTenantQuota(ctx context.Context, tenantName, jobID string, cpu float64, memoryMB int64) error {
+	if tenantName == "" {
+		return nil
+	}
+	tenant, err := s.registry.Tenant(tenantName)
+	if err != nil {
+		return err
+	}
+	quota := tenant.Quota
+
+	all, err := s.registry.JobNames()
+	if err != nil {
+		return err
+	}
+	var names []store.JobName
+	for _, name := range all {
+		if name.Tenant == tenant.Name && name.JobID != jobID {
+			names = append(names, name)
+		}
+	}
+	if applications := len(names) + 1; applications > quota.MaxApplications {
+		return fmt.Errorf("tenant %s may deploy %d applications", tenant.Name, quota.MaxApplications)
+	}
+
+	usedCPU, usedMemoryMB, err := s.tenantUsage(ctx, names)
+	if err != nil {
+		return err
+	}
+	cpu += usedCPU
+	memoryMB += usedMemoryMB
+	if cpu > quota.CPU || memoryMB > quota.MemoryMB {
+		return fmt.Errorf("the applications of tenant %s would request %.1f cores and %d MB, its quota is %.1f cores and %d MB",
+			tenant.Name, cpu, memoryMB, quota.CPU, quota.MemoryMB)
+	}
+	return nil
+}
+
+// tenantUsage adds up what the applications request: the spec of their latest revision, or
+// the job in Nomad for raw jobs, which have no revisions. Jobs Nomad no longer has do not count.
+func (s *ApplicationService) tenantUsage(ctx context.Context, names []store.JobName) (float64, int64, error) {
+	var specs, jobs []store.JobName
+	for _, name := range names {
+		if _, err := s.registry.Revision(name.JobID, 0); err == nil {
+			specs = append(specs, name)
+		} else {
+			jobs = append(jobs, name)
+		}
+	}
+
+	cpu, memoryMB, err := s.projectUsage(ctx, specs, "")
+	if err != nil {
+		return 0, 0, err
+	}
+	if s.orhClient == nil {
+		return cpu, memoryMB, nil
+	}
+	for _, name := range jobs {
+		job, _, err := s.orhClient.GetJobStatus(name.JobID)
+		if err != nil {
+			continue
+		}
+		jobCPU, jobMemoryMB := jobResources(job)
+		cpu += jobCPU
+		memoryMB += jobMemoryMB
+	}
+	return cpu, memoryMB, nil
+}
+
+// jobResources adds up the CPU and memory of all the allocations of a job, CPU counted in
+// cores of 10 MHz like specs
+func jobResources(job *nmd.Job) (float64, int64) {
+	var cpu float64
+	var memoryMB int64
+	for _, group := range job.TaskGroups {
+		count := 1
+		if group.Count != nil {
+			count = *group.Count
+		}
+		for _, task := range group.Tasks {
+			if task.Resources == nil {
+				continue
+			}
+			if task.Resources.Cores != nil {
+				cpu += float64(count * *task.Resources.Cores)
+			} else if task.Resources.CPU != nil {
+				cpu += float64(*task.Resources.CPU*count) / 10
+			}
+			if task.Resources.MemoryMB != nil {
+				memoryMB += int64(count * *task.Resources.MemoryMB)
+			}
+		}
+	}
+	return cpu, memoryMB
+}
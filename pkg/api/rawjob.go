@@ -0,0 +1,177 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// DeployRawJob deploys a Nomad job as written, for what the templater cannot express. The
+// job passes the admission of specs: plugins, image policy, attestation, reserved names and
+// the tenant's quota, and is recorded like a templated application.
+func (s *ApplicationService) DeployRawJob(ctx context.Context, req *pb.DeployRawJobRequest) (*pb.DeployResponse, error) {
+	job, spec, err := s.parseRawJob(req)
+	if err == nil {
+		_, err = s.plugins.PreValidate(ctx, spec)
+	}
+	if err != nil {
+		resp := &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Invalid job: %v", err),
+		}
+		if spec != nil {
+			s.publishDeploy(spec, resp)
+		}
+		return resp, nil
+	}
+
+	resp := s.deployRawJob(ctx, job, spec)
+	s.publishDeploy(spec, resp)
+	return resp, nil
+}
+
+func (s *ApplicationService) deployRawJob(ctx context.Context, job *nmd.Job, spec *pb.DeployRequest) *pb.DeployResponse {
+	jobID := *job.ID
+	for _, image := range rawJobImages(job) {
+		imageSpec := &pb.DeployRequest{Name: spec.Name, Tenant: spec.Tenant, Image: image}
+		if err := s.admitImage(imageSpec); err != nil {
+			return &pb.DeployResponse{
+				Status:  "FAILED",
+				Message: fmt.Sprintf("Image rejected: %v", err),
+			}
+		}
+		if err := s.checkAttestation(ctx, imageSpec); err != nil {
+			return &pb.DeployResponse{
+				Status:  "FAILED",
+				Message: fmt.Sprintf("Attestation required: %v", err),
+			}
+		}
+	}
+
+	err := s.reserved.check(spec, jobID)
+	if err == nil {
+		if owner := s.jobName(jobID); !owner.CreatedAt.IsZero() && (owner.Application != spec.Name || owner.Tenant != spec.Tenant) {
+			err = fmt.Errorf("job %s belongs to application %s of tenant %q, choose another ID", jobID, owner.Application, owner.Tenant)
+		}
+	}
+	if err == nil {
+		cpu, memoryMB := jobResources(job)
+		err = s.checkTenantQuota(ctx, spec.Tenant, jobID, cpu, memoryMB)
+	}
+	if err == nil {
+		err = s.checkDeployNamespace(ctx, spec, jobID)
+	}
+	if err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Job rejected: %v", err),
+		}
+	}
+
+	if job.Meta == nil {
+		job.Meta = make(map[string]string)
+	}
+	job.Meta[nomad.MetaRawJob] = "true"
+
+	job, err = s.plugins.MutateJob(ctx, spec, job)
+	if err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Failed to deploy job: %v", err),
+		}
+	}
+
+	resp, err := s.orhClient.RegisterJob(job)
+	if err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Failed to deploy job: %v", err),
+		}
+	}
+	s.recordJobID(jobID, spec.Name, spec.Tenant, "", spec.Namespace)
+	s.plugins.PostDeploy(spec, resp.EvalID)
+
+	return &pb.DeployResponse{
+		DeploymentId: resp.EvalID,
+		JobId:        jobID,
+		Status:       "SUBMITTED",
+		Message:      "Raw job submitted successfully",
+	}
+}
+
+// parseRawJob decodes the job and describes it as the spec plugins and events receive,
+// with the application's name, tenant and first image
+func (s *ApplicationService) parseRawJob(req *pb.DeployRawJobRequest) (*nmd.Job, *pb.DeployRequest, error) {
+	format := req.Format
+	if format == "" {
+		format = "hcl"
+		if strings.HasPrefix(strings.TrimSpace(req.Job), "{") {
+			format = "json"
+		}
+	}
+
+	var job *nmd.Job
+	switch format {
+	case "json":
+		var wrapped struct{ Job *nmd.Job }
+		if err := json.Unmarshal([]byte(req.Job), &wrapped); err != nil {
+			return nil, nil, err
+		}
+		if job = wrapped.Job; job == nil {
+			if err := json.Unmarshal([]byte(req.Job), &job); err != nil {
+				return nil, nil, err
+			}
+		}
+	case "hcl":
+		var err error
+		if job, err = s.orhClient.ParseHCLJob(req.Job); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("format must be json or hcl")
+	}
+
+	if job == nil || job.ID == nil || *job.ID == "" {
+		return nil, nil, fmt.Errorf("the job has no ID")
+	}
+	// the job runs in the namespace of the request, the control plane records it there
+	namespace := cmp.Or(req.Namespace, nomad.DefaultNamespace)
+	if job.Namespace != nil && *job.Namespace != "" && *job.Namespace != namespace {
+		return nil, nil, fmt.Errorf("the job runs in namespace %s, not in the namespace %s of the request", *job.Namespace, namespace)
+	}
+	job.Namespace = &namespace
+	if job.ParentID != nil && *job.ParentID != "" {
+		return nil, nil, fmt.Errorf("the job is a child of %s, deploy its parent", *job.ParentID)
+	}
+
+	spec := &pb.DeployRequest{Name: req.Name, Tenant: req.Tenant, Namespace: req.Namespace}
+	if spec.Name == "" {
+		spec.Name = *job.ID
+	}
+	if !applicationName.MatchString(spec.Name) {
+		return nil, nil, fmt.Errorf("name %q may only contain letters, digits, '-' and '_'", spec.Name)
+	}
+	if images := rawJobImages(job); len(images) > 0 {
+		spec.Image = images[0]
+	}
+	return job, spec, nil
+}
+
+// rawJobImages are the images of the job's docker and containerd tasks
+func rawJobImages(job *nmd.Job) []string {
+	var images []string
+	for _, group := range job.TaskGroups {
+		for _, task := range group.Tasks {
+			if image, ok := task.Config["image"].(string); ok && image != "" {
+				images = append(images, image)
+			}
+		}
+	}
+	return images
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// readMethods are the RPCs a read-only replica serves, they only read Nomad and the registry
+var readMethods = map[string]bool{
+	pb.ControlPlane_GetApplicationStatus_FullMethodName:   true,
+	pb.ControlPlane_WatchDeployment_FullMethodName:        true,
+	pb.ControlPlane_GetApplicationHealth_FullMethodName:   true,
+	pb.ControlPlane_ListApplications_FullMethodName:       true,
+	pb.ControlPlane_GetApplicationLogs_FullMethodName:     true,
+	pb.ControlPlane_GetLogs_FullMethodName:                true,
+	pb.ControlPlane_GetApplicationConfig_FullMethodName:   true,
+	pb.ControlPlane_GetFunctionMetrics_FullMethodName:     true,
+	pb.ControlPlane_ListCronRuns_FullMethodName:           true,
+	pb.ControlPlane_ListSubscriptions_FullMethodName:      true,
+	pb.ControlPlane_GetImpact_FullMethodName:              true,
+	pb.ControlPlane_GetDependencyGraph_FullMethodName:     true,
+	pb.ControlPlane_ListVolumes_FullMethodName:            true,
+	pb.ControlPlane_ListSnapshots_FullMethodName:          true,
+	pb.ControlPlane_ListDomains_FullMethodName:            true,
+	pb.ControlPlane_ListImageDrift_FullMethodName:         true,
+	pb.ControlPlane_ListArtifacts_FullMethodName:          true,
+	pb.ControlPlane_GetArtifact_FullMethodName:            true,
+	pb.ControlPlane_ExplainPlacement_FullMethodName:       true,
+	pb.ControlPlane_GetReconcilerStatus_FullMethodName:    true,
+	pb.ControlPlane_GetCalendar_FullMethodName:            true,
+	pb.ControlPlane_GetDeploymentAnalytics_FullMethodName: true,
+	pb.ControlPlane_GetTimeline_FullMethodName:            true,
+	pb.ControlPlane_Search_FullMethodName:                 true,
+	pb.ControlPlane_ListRevisions_FullMethodName:          true,
+	pb.ControlPlane_ListProjects_FullMethodName:           true,
+	pb.ControlPlane_GetProject_FullMethodName:             true,
+	pb.ControlPlane_ListNamespaces_FullMethodName:         true,
+	pb.ControlPlane_ListRestartAnomalies_FullMethodName:   true,
+	pb.ControlPlane_HealthCheck_FullMethodName:            true,
+	pb.Admin_ListTenants_FullMethodName:                   true,
+	pb.Admin_GetReplicationStatus_FullMethodName:          true,
+}
+
+// ReadOnlyInterceptor rejects every mutating RPC, dashboards and heavy pollers can be
+// pointed at read-only replicas to isolate them from the controllers making changes.
+func ReadOnlyInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !readMethods[info.FullMethod] {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s is not available on a read-only replica", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ReadOnlyStreamInterceptor is ReadOnlyInterceptor for streaming RPCs
+func ReadOnlyStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !readMethods[info.FullMethod] {
+			return status.Errorf(codes.FailedPrecondition, "%s is not available on a read-only replica", info.FullMethod)
+		}
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,325 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// RecommendationPolicy samples the usage of applications and recommends their CPU and
+// memory, a nil policy disables both
+type RecommendationPolicy struct {
+	Headroom   float64       // added to the p95 usage, 0.2 recommends 20% above it
+	Window     time.Duration // the samples recommendations are based on
+	MinSamples int           // fewer samples in the window recommend nothing
+}
+
+// resources within this fraction of the recommendation are not worth a rollout
+const recommendationTolerance = 0.1
+
+// RunUsageSampling records the CPU and memory the running allocations of every application
+// use every interval until the context is cancelled. With a replicated registry only the
+// leader samples them.
+func (s *ApplicationService) RunUsageSampling(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopUsage, interval, s.sampleUsage)
+		if err != nil {
+			log.Printf("Usage sampling: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) sampleUsage() error {
+	jobIDs, err := s.applicationJobs()
+	if err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	var samples []store.UsageSample
+	s.reconciler.queue(loopUsage, len(jobIDs))
+	for _, jobID := range jobIDs {
+		s.reconciler.next(loopUsage)
+		sampled, err := s.sampleApplication(jobID)
+		if err != nil {
+			log.Printf("Usage sampling: %s: %v", jobID, err)
+		}
+		s.reconciler.result(loopUsage, jobID, err)
+		samples = append(samples, sampled...)
+	}
+
+	if len(samples) == 0 {
+		return nil
+	}
+	return s.registry.SaveUsage(samples)
+}
+
+// sampleApplication measures the application's task in each of its running allocations
+func (s *ApplicationService) sampleApplication(jobID string) ([]store.UsageSample, error) {
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return nil, err
+	}
+	_, allocations, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []store.UsageSample
+	now := time.Now().UTC()
+	for _, alloc := range allocations {
+		if alloc.ClientStatus != "running" || alloc.TaskStates[jobID] == nil {
+			continue
+		}
+		cpu, memoryMB, err := client.TaskUsage(alloc.ID, jobID)
+		if err != nil {
+			// the allocation stopped since it was listed
+			continue
+		}
+		samples = append(samples, store.UsageSample{
+			Application:  jobID,
+			AllocationID: alloc.ID,
+			CPU:          cpu,
+			MemoryMB:     memoryMB,
+			At:           now,
+		})
+	}
+	return samples, nil
+}
+
+// GetResourceRecommendations recommends the CPU and memory of applications from the p95 of
+// their sampled usage plus headroom. With propose, recommendations differing from the
+// current resources are recorded as updates awaiting ApplyResourceRecommendation.
+func (s *ApplicationService) GetResourceRecommendations(ctx context.Context, req *pb.ResourceRecommendationsRequest) (*pb.ResourceRecommendationsResponse, error) {
+	if s.recommendations == nil {
+		return &pb.ResourceRecommendationsResponse{
+			Message: "Resource recommendations are disabled, start the controller with -usage-sampling",
+		}, nil
+	}
+
+	var jobIDs []string
+	var err error
+	if req.Name != "" {
+		var jobID string
+		jobID, err = s.resolveJobID(req.Name)
+		jobIDs = []string{jobID}
+	} else {
+		jobIDs, err = s.applicationJobs()
+	}
+	if err != nil {
+		return &pb.ResourceRecommendationsResponse{
+			Message: fmt.Sprintf("Failed to list applications: %v", err),
+		}, nil
+	}
+
+	resp := &pb.ResourceRecommendationsResponse{}
+	proposed := 0
+	for _, jobID := range jobIDs {
+		recommendation, err := s.recommendResources(jobID, req.Propose)
+		if err != nil {
+			if req.Name != "" {
+				resp.Message = fmt.Sprintf("Failed to recommend resources: %v", err)
+				return resp, nil
+			}
+			log.Printf("Failed to recommend the resources of %s: %v", jobID, err)
+			continue
+		}
+		if recommendation.Proposed {
+			proposed++
+		}
+		resp.Recommendations = append(resp.Recommendations, recommendation)
+	}
+
+	resp.Success = true
+	resp.Message = fmt.Sprintf("%d recommendations, %d awaiting approval", len(resp.Recommendations), proposed)
+	return resp, nil
+}
+
+func (s *ApplicationService) recommendResources(jobID string, propose bool) (*pb.ResourceRecommendation, error) {
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return nil, err
+	}
+	job, _, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return nil, err
+	}
+	task := applicationTask(job, jobID)
+	if task == nil || task.Resources == nil {
+		return nil, fmt.Errorf("job %s has no task %s", jobID, jobID)
+	}
+
+	recommendation := &pb.ResourceRecommendation{
+		Application: s.jobName(jobID).Application,
+		JobId:       jobID,
+	}
+	if task.Resources.CPU != nil {
+		recommendation.CurrentCpu = float64(*task.Resources.CPU) / 10
+	}
+	if task.Resources.MemoryMB != nil {
+		recommendation.CurrentMemory = int64(*task.Resources.MemoryMB)
+	}
+
+	policy := s.recommendations
+	samples, err := s.registry.Usage(jobID, time.Now().Add(-policy.Window))
+	if err != nil {
+		return nil, err
+	}
+	recommendation.Samples = int32(len(samples))
+	if len(samples) < policy.MinSamples {
+		recommendation.Reason = fmt.Sprintf("%d of the %d samples needed were taken", len(samples), policy.MinSamples)
+		return recommendation, nil
+	}
+
+	cpu := make([]float64, len(samples))
+	memory := make([]float64, len(samples))
+	for i, sample := range samples {
+		cpu[i], memory[i] = sample.CPU/10, float64(sample.MemoryMB)
+	}
+	slices.Sort(cpu)
+	slices.Sort(memory)
+	recommendation.P95Cpu = math.Round(cpu[(len(cpu)-1)*95/100]*100) / 100
+	recommendation.P95Memory = int64(math.Ceil(memory[(len(memory)-1)*95/100]))
+
+	// CPU in tenths of cores like the templater's 10 MHz steps
+	recommendation.RecommendedCpu = max(minCPU, math.Ceil(recommendation.P95Cpu*(1+policy.Headroom)*10)/10)
+	recommendation.RecommendedMemory = max(minMemoryMB, int64(math.Ceil(float64(recommendation.P95Memory)*(1+policy.Headroom))))
+
+	cpuOff := math.Abs(recommendation.RecommendedCpu-recommendation.CurrentCpu) > recommendation.CurrentCpu*recommendationTolerance
+	memoryOff := math.Abs(float64(recommendation.RecommendedMemory-recommendation.CurrentMemory)) > float64(recommendation.CurrentMemory)*recommendationTolerance
+	switch {
+	case !cpuOff && !memoryOff:
+		recommendation.Reason = "the resources match the usage"
+	case recommendation.RecommendedCpu <= recommendation.CurrentCpu && recommendation.RecommendedMemory <= recommendation.CurrentMemory:
+		recommendation.Reason = "over-provisioned"
+	case recommendation.RecommendedCpu >= recommendation.CurrentCpu && recommendation.RecommendedMemory >= recommendation.CurrentMemory:
+		recommendation.Reason = "under-provisioned"
+	default:
+		recommendation.Reason = "the usage shifted between CPU and memory"
+	}
+
+	if propose && (cpuOff || memoryOff) {
+		err := s.registry.SaveResourceProposal(store.ResourceProposal{
+			Application: jobID,
+			CPU:         recommendation.RecommendedCpu,
+			MemoryMB:    recommendation.RecommendedMemory,
+			ProposedAt:  time.Now().UTC(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to propose the update: %w", err)
+		}
+	}
+	if _, err := s.registry.ResourceProposal(jobID); err == nil {
+		recommendation.Proposed = true
+	}
+
+	return recommendation, nil
+}
+
+// ApplyResourceRecommendation approves the resource update proposed for an application and
+// rolls it out, or dismisses it. A later deploy of the application's spec overrides the
+// update, the spec should be changed alike.
+func (s *ApplicationService) ApplyResourceRecommendation(ctx context.Context, req *pb.ApplyResourceRecommendationRequest) (*pb.ApplyResourceRecommendationResponse, error) {
+	jobID, err := s.resolveJobID(req.Name)
+	if err != nil {
+		return &pb.ApplyResourceRecommendationResponse{
+			Message: fmt.Sprintf("Failed to resolve application: %v", err),
+		}, nil
+	}
+
+	proposal, err := s.registry.ResourceProposal(jobID)
+	if err != nil {
+		message := fmt.Sprintf("Failed to find the proposed update: %v", err)
+		if errors.Is(err, store.ErrNotFound) {
+			message = fmt.Sprintf("No resource update is proposed for %s, see GetResourceRecommendations", req.Name)
+		}
+		return &pb.ApplyResourceRecommendationResponse{
+			Message: message,
+		}, nil
+	}
+
+	if req.Dismiss {
+		if err := s.registry.DeleteResourceProposal(jobID); err != nil {
+			return &pb.ApplyResourceRecommendationResponse{
+				Message: fmt.Sprintf("Failed to dismiss the proposed update: %v", err),
+			}, nil
+		}
+		return &pb.ApplyResourceRecommendationResponse{
+			Success: true,
+			Message: fmt.Sprintf("Resource update of %s dismissed", req.Name),
+		}, nil
+	}
+
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return &pb.ApplyResourceRecommendationResponse{
+			Message: fmt.Sprintf("Failed to find application: %v", err),
+		}, nil
+	}
+	job, _, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return &pb.ApplyResourceRecommendationResponse{
+			Message: fmt.Sprintf("Failed to find application: %v", err),
+		}, nil
+	}
+	task := applicationTask(job, jobID)
+	if task == nil {
+		return &pb.ApplyResourceRecommendationResponse{
+			Message: fmt.Sprintf("Job %s has no task %s", jobID, jobID),
+		}, nil
+	}
+
+	cpu, memoryMB := int(proposal.CPU*10), int(proposal.MemoryMB)
+	if task.Resources == nil {
+		task.Resources = &nmd.Resources{}
+	}
+	task.Resources.CPU = &cpu
+	task.Resources.MemoryMB = &memoryMB
+	if task.Resources.MemoryMaxMB != nil && *task.Resources.MemoryMaxMB < memoryMB {
+		task.Resources.MemoryMaxMB = &memoryMB
+	}
+
+	registered, err := client.RegisterJob(job)
+	if err != nil {
+		return &pb.ApplyResourceRecommendationResponse{
+			Message: fmt.Sprintf("Failed to update application: %v", err),
+		}, nil
+	}
+	if err := s.registry.DeleteResourceProposal(jobID); err != nil {
+		log.Printf("Failed to remove the applied resource proposal of %s: %v", jobID, err)
+	}
+
+	return &pb.ApplyResourceRecommendationResponse{
+		Success:      true,
+		Message:      fmt.Sprintf("%s updated to %.1f cores and %d MB, change its spec alike", req.Name, proposal.CPU, proposal.MemoryMB),
+		DeploymentId: registered.EvalID,
+	}, nil
+}
+
+// applicationTask is the task of a templated job running the application's image
+func applicationTask(job *nmd.Job, jobID string) *nmd.Task {
+	for _, group := range job.TaskGroups {
+		for _, task := range group.Tasks {
+			if task.Name == jobID {
+				return task
+			}
+		}
+	}
+	return nil
+}
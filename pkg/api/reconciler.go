@@ -0,0 +1,419 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// Reconciliation loops of the controller
+const (
+	loopBackups       = "backups"
+	loopDomains       = "domain_verification"
+	loopDeadlines     = "rollout_deadlines"
+	loopTrafficShifts = "traffic_shifts"
+	loopGeoFailover   = "geo_failover"
+	loopDrift         = "drift_detection"
+	loopUsage         = "usage_sampling"
+	loopRestarts      = "restart_detection"
+	loopAutoscaling   = "autoscaling"
+	loopSearch        = "search_index"
+	loopIncidents     = "incidents"
+	loopUptime        = "uptime_checks"
+	loopRollouts      = "rollout_recording"
+)
+
+// a loop still running after this many intervals is wedged, one that has not finished a
+// run for as long is falling behind
+const reconcilerStallIntervals = 3
+
+// reconcilerStats tracks the reconciliation loops, so operators can tell when one is
+// falling behind or wedged
+type reconcilerStats struct {
+	mu       sync.Mutex
+	loops    map[string]*loopStats
+	failures map[string]map[string]*itemFailure // application, then loop
+	drift    map[string]int                     // detections of moved tags per application
+}
+
+type loopStats struct {
+	interval     time.Duration
+	leading      bool
+	paused       bool
+	runs         int64
+	failures     int64
+	running      bool
+	startedAt    time.Time
+	finishedAt   time.Time
+	lastDuration time.Duration
+	maxDuration  time.Duration
+	totalSeconds float64
+	queued       int
+	lastError    string
+	lastErrorAt  time.Time
+}
+
+type itemFailure struct {
+	err      string
+	at       time.Time
+	attempts int
+}
+
+func (r *reconcilerStats) loop(name string, interval time.Duration) *loopStats {
+	if r.loops == nil {
+		r.loops = make(map[string]*loopStats)
+	}
+	loop, ok := r.loops[name]
+	if !ok {
+		loop = &loopStats{}
+		r.loops[name] = loop
+	}
+	loop.interval = interval
+	return loop
+}
+
+// standby records that the loop skipped its run, another replica leads
+func (r *reconcilerStats) standby(name string, interval time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loop := r.loop(name, interval)
+	loop.leading, loop.paused = false, false
+}
+
+// pause records that the loop skipped its run, Nomad is under pressure
+func (r *reconcilerStats) pause(name string, interval time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loop := r.loop(name, interval)
+	loop.leading, loop.paused = true, true
+}
+
+func (r *reconcilerStats) start(name string, interval time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loop := r.loop(name, interval)
+	loop.leading, loop.paused, loop.running = true, false, true
+	loop.startedAt = time.Now()
+	loop.queued = 0
+}
+
+func (r *reconcilerStats) finish(name string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loop := r.loops[name]
+	loop.running = false
+	loop.finishedAt = time.Now()
+	loop.lastDuration = loop.finishedAt.Sub(loop.startedAt)
+	loop.maxDuration = max(loop.maxDuration, loop.lastDuration)
+	loop.totalSeconds += loop.lastDuration.Seconds()
+	loop.queued = 0
+	loop.runs++
+	if err != nil {
+		loop.failures++
+		loop.lastError, loop.lastErrorAt = err.Error(), loop.finishedAt
+	} else {
+		loop.lastError = ""
+	}
+}
+
+// queue records how many items the current run of the loop goes through
+func (r *reconcilerStats) queue(name string, items int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if loop, ok := r.loops[name]; ok {
+		loop.queued = items
+	}
+}
+
+// next takes an item of the current run off the queue
+func (r *reconcilerStats) next(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if loop, ok := r.loops[name]; ok && loop.queued > 0 {
+		loop.queued--
+	}
+}
+
+// result records the outcome of reconciling an application, a success clears its failure
+func (r *reconcilerStats) result(name, application string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err == nil {
+		delete(r.failures[application], name)
+		if len(r.failures[application]) == 0 {
+			delete(r.failures, application)
+		}
+		return
+	}
+
+	if r.failures == nil {
+		r.failures = make(map[string]map[string]*itemFailure)
+	}
+	if r.failures[application] == nil {
+		r.failures[application] = make(map[string]*itemFailure)
+	}
+	failure, ok := r.failures[application][name]
+	if !ok {
+		failure = &itemFailure{}
+		r.failures[application][name] = failure
+	}
+	failure.err, failure.at = err.Error(), time.Now()
+	failure.attempts++
+}
+
+// drifted counts a move of the deployed tag of an application
+func (r *reconcilerStats) drifted(application string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.drift == nil {
+		r.drift = make(map[string]int)
+	}
+	r.drift[application]++
+}
+
+// forget drops what was recorded about a deleted application
+func (r *reconcilerStats) forget(application string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.failures, application)
+	delete(r.drift, application)
+}
+
+// state sums up the health of a loop
+func (l *loopStats) state(now time.Time) string {
+	stall := reconcilerStallIntervals * l.interval
+	switch {
+	case !l.leading && !l.running:
+		if l.runs == 0 {
+			return "pending"
+		}
+		return "standby"
+	case l.paused:
+		return "paused"
+	case l.running && now.Sub(l.startedAt) > stall:
+		return "wedged"
+	case !l.running && now.Sub(l.finishedAt) > stall, l.lastDuration > l.interval:
+		return "behind"
+	case l.lastError != "":
+		return "failing"
+	default:
+		return "ok"
+	}
+}
+
+// reconcile runs a loop once on the leader and records how it went. The loop is paused
+// while Nomad is under pressure, deployments go first.
+func (s *ApplicationService) reconcile(name string, interval time.Duration, run func() error) error {
+	if leader, ok := s.registry.(interface{ IsLeader() bool }); ok && !leader.IsLeader() {
+		s.reconciler.standby(name, interval)
+		return nil
+	}
+	if s.orhClient.Pressure().Mode != nomad.ModeNormal {
+		s.reconciler.pause(name, interval)
+		return nil
+	}
+
+	s.reconciler.start(name, interval)
+	err := run()
+	s.reconciler.finish(name, err)
+	return err
+}
+
+// GetReconcilerStatus details the reconciliation loops of the controller: how long their
+// runs take, how much is left to go through, and what failed for which application
+func (s *ApplicationService) GetReconcilerStatus(ctx context.Context, req *pb.GetReconcilerStatusRequest) (*pb.GetReconcilerStatusResponse, error) {
+	resp := &pb.GetReconcilerStatusResponse{
+		Success: true,
+		Message: "Reconciler status retrieved successfully",
+	}
+
+	drifted := make(map[string]int)
+	if images, err := s.registry.DeployedImages(); err == nil {
+		for _, image := range images {
+			if len(image.Drifted) > 0 {
+				drifted[image.Application] = len(image.Drifted)
+			}
+		}
+	}
+
+	now := time.Now()
+	r := &s.reconciler
+	r.mu.Lock()
+	for _, name := range slices.Sorted(maps.Keys(r.loops)) {
+		loop := r.loops[name]
+		resp.Loops = append(resp.Loops, &pb.ReconcilerLoop{
+			Name:            name,
+			State:           loop.state(now),
+			IntervalSeconds: int64(loop.interval.Seconds()),
+			Runs:            loop.runs,
+			Failures:        loop.failures,
+			QueueDepth:      int32(loop.queued),
+			Running:         loop.running,
+			LastStartedAt:   unixOrZero(loop.startedAt),
+			LastFinishedAt:  unixOrZero(loop.finishedAt),
+			LastDurationMs:  loop.lastDuration.Milliseconds(),
+			MaxDurationMs:   loop.maxDuration.Milliseconds(),
+			LastError:       loop.lastError,
+			LastErrorAt:     unixOrZero(loop.lastErrorAt),
+			FailingItems:    int32(r.failingItems(name)),
+		})
+	}
+	for _, application := range slices.Sorted(maps.Keys(r.failures)) {
+		if req.Application != "" && application != req.Application {
+			continue
+		}
+		for _, name := range slices.Sorted(maps.Keys(r.failures[application])) {
+			failure := r.failures[application][name]
+			resp.Failures = append(resp.Failures, &pb.ReconcilerFailure{
+				Application: application,
+				Loop:        name,
+				Error:       failure.err,
+				FailedAt:    failure.at.Unix(),
+				Attempts:    int32(failure.attempts),
+			})
+		}
+	}
+	detections := maps.Clone(r.drift)
+	if detections == nil {
+		detections = make(map[string]int)
+	}
+	r.mu.Unlock()
+
+	for application := range drifted {
+		if _, ok := detections[application]; !ok {
+			detections[application] = 0
+		}
+	}
+	for _, application := range slices.Sorted(maps.Keys(detections)) {
+		if req.Application != "" && application != req.Application {
+			continue
+		}
+		resp.Drift = append(resp.Drift, &pb.ReconcilerDrift{
+			Application:        application,
+			Detections:         int32(detections[application]),
+			DriftedAllocations: int32(drifted[application]),
+		})
+	}
+
+	for group, waiting := range s.rollouts.queues() {
+		resp.RolloutQueues = append(resp.RolloutQueues, &pb.RolloutQueue{Group: group, Waiting: int32(waiting)})
+	}
+	sort.Slice(resp.RolloutQueues, func(i, j int) bool { return resp.RolloutQueues[i].Group < resp.RolloutQueues[j].Group })
+
+	return resp, nil
+}
+
+// failingItems counts the applications whose last reconciliation by the loop failed
+func (r *reconcilerStats) failingItems(name string) int {
+	count := 0
+	for _, loops := range r.failures {
+		if _, ok := loops[name]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+// metricsHandler exposes the reconciler in the Prometheus text format
+func (s *ApplicationService) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	// the metrics cover every application, the tokens scoped to namespaces are denied
+	req := &pb.GetReconcilerStatusRequest{}
+	if err := checkRequestNamespace(r.Context(), s.registry, pb.ControlPlane_GetReconcilerStatus_FullMethodName, req); err != nil {
+		writeStatusError(w, err)
+		return
+	}
+	resp, _ := s.GetReconcilerStatus(r.Context(), req)
+
+	var b strings.Builder
+	metric := func(name, kind, help string, samples func(add func(labels string, value float64))) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+		samples(func(labels string, value float64) {
+			fmt.Fprintf(&b, "%s{%s} %g\n", name, labels, value)
+		})
+	}
+	loops := func(value func(loop *pb.ReconcilerLoop) float64) func(add func(string, float64)) {
+		return func(add func(string, float64)) {
+			for _, loop := range resp.Loops {
+				add(fmt.Sprintf("loop=%q", loop.Name), value(loop))
+			}
+		}
+	}
+
+	metric("control_plane_reconciler_runs_total", "counter", "Runs of the reconciliation loop.",
+		loops(func(l *pb.ReconcilerLoop) float64 { return float64(l.Runs) }))
+	metric("control_plane_reconciler_failures_total", "counter", "Runs of the reconciliation loop which failed as a whole.",
+		loops(func(l *pb.ReconcilerLoop) float64 { return float64(l.Failures) }))
+	metric("control_plane_reconciler_duration_seconds_total", "counter", "Time spent in runs of the reconciliation loop.",
+		func(add func(string, float64)) {
+			s.reconciler.mu.Lock()
+			defer s.reconciler.mu.Unlock()
+			for _, name := range slices.Sorted(maps.Keys(s.reconciler.loops)) {
+				add(fmt.Sprintf("loop=%q", name), s.reconciler.loops[name].totalSeconds)
+			}
+		})
+	metric("control_plane_reconciler_last_duration_seconds", "gauge", "Duration of the last run of the reconciliation loop.",
+		loops(func(l *pb.ReconcilerLoop) float64 { return float64(l.LastDurationMs) / 1000 }))
+	metric("control_plane_reconciler_last_run_timestamp_seconds", "gauge", "When the last run of the reconciliation loop finished.",
+		loops(func(l *pb.ReconcilerLoop) float64 { return float64(l.LastFinishedAt) }))
+	metric("control_plane_reconciler_queue_depth", "gauge", "Items left in the current run of the reconciliation loop.",
+		loops(func(l *pb.ReconcilerLoop) float64 { return float64(l.QueueDepth) }))
+	metric("control_plane_reconciler_failing_items", "gauge", "Applications whose last reconciliation by the loop failed.",
+		loops(func(l *pb.ReconcilerLoop) float64 { return float64(l.FailingItems) }))
+	metric("control_plane_reconciler_state", "gauge", "State of the reconciliation loop, 1 for its current state.",
+		func(add func(string, float64)) {
+			for _, loop := range resp.Loops {
+				add(fmt.Sprintf("loop=%q,state=%q", loop.Name, loop.State), 1)
+			}
+		})
+	metric("control_plane_drift_detections_total", "counter", "Moves of the deployed image tag of the application.",
+		func(add func(string, float64)) {
+			for _, drift := range resp.Drift {
+				add(fmt.Sprintf("application=%q", drift.Application), float64(drift.Detections))
+			}
+		})
+	metric("control_plane_drifted_allocations", "gauge", "Allocations of the application which may run a moved image tag.",
+		func(add func(string, float64)) {
+			for _, drift := range resp.Drift {
+				add(fmt.Sprintf("application=%q", drift.Application), float64(drift.DriftedAllocations))
+			}
+		})
+	metric("control_plane_rollout_queue_depth", "gauge", "Deployments waiting for their concurrency group.",
+		func(add func(string, float64)) {
+			for _, queue := range resp.RolloutQueues {
+				add(fmt.Sprintf("group=%q", queue.Group), float64(queue.Waiting))
+			}
+		})
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	_, _ = w.Write([]byte(b.String()))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// DefaultReservedNames are the jobs of the infrastructure next to the applications
+var DefaultReservedNames = []string{nomad.EdgeProxyJobID, "consul", "nomad", "vault", "controller", "control-plane"}
+
+// Reserved are the application names and hostnames tenants cannot deploy, so they cannot
+// shadow infrastructure components. Patterns may contain *, which also matches dots:
+// *.example.com reserves every subdomain, example.com only the apex.
+type Reserved struct {
+	Names []string
+	Hosts []string
+}
+
+func (r *Reserved) check(req *pb.DeployRequest, jobID string) error {
+	if r == nil {
+		return nil
+	}
+
+	for _, name := range []string{req.Name, jobID} {
+		if pattern, ok := reservedMatch(r.Names, name); ok {
+			return fmt.Errorf("name %s is reserved by %s", name, pattern)
+		}
+	}
+
+	if req.Traefik == nil {
+		return nil
+	}
+	hosts := append([]string{req.Traefik.Host, req.Traefik.SslHost}, req.Traefik.CertSans...)
+	for _, host := range hosts {
+		host = normalizeHost(host)
+		if host == "" {
+			continue
+		}
+		if pattern, ok := reservedMatch(r.Hosts, host); ok {
+			return fmt.Errorf("host %s is reserved by %s", host, pattern)
+		}
+	}
+
+	return nil
+}
+
+func reservedMatch(patterns []string, value string) (string, bool) {
+	value = strings.ToLower(value)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), value); ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
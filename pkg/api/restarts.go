@@ -0,0 +1,412 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// Types of the restart events posted to the webhook and published as CloudEvents
+const (
+	eventRestartSpike    = "application.restart_spike"
+	eventRestartsSettled = "application.restarts_settled"
+)
+
+// RestartPolicy detects applications restarting far more often than their baseline, e.g. a
+// crash loop after a deploy or a failing dependency. A nil policy disables it.
+type RestartPolicy struct {
+	Baseline    time.Duration // the restart rate of this period before a check is its baseline
+	Factor      float64       // a check counting this many times the baseline's restarts is a spike
+	MinRestarts int           // fewer restarts during a check are never a spike
+	Webhook     string        // receives the restart events as JSON, they are only logged without it
+}
+
+// restartEvent is posted to the webhook when a restart spike starts or settles
+type restartEvent struct {
+	Type        string                `json:"type"`
+	Application string                `json:"application"`
+	JobID       string                `json:"job_id"`
+	Restarts    int                   `json:"restarts"`
+	Baseline    float64               `json:"baseline"`
+	Allocations []restartedAllocation `json:"allocations,omitempty"`
+	Links       map[string]string     `json:"links,omitempty"` // diagnostics, label to URL
+	Since       time.Time             `json:"since"`
+	Time        time.Time             `json:"time"`
+}
+
+type restartedAllocation struct {
+	ID        string `json:"id"`
+	Restarts  int    `json:"restarts"`
+	LastEvent string `json:"last_event,omitempty"`
+	URL       string `json:"url"`
+}
+
+// restartTracker keeps the restart counts of the allocations of every application and the
+// restarts of the last checks. The baseline starts over when another replica leads.
+type restartTracker struct {
+	mu        sync.Mutex
+	counts    map[string]map[string]int // job, then allocation, restarts as of the last check
+	history   map[string][]int          // job, restarts per check oldest first, spikes left out
+	anomalies map[string]*restartEvent  // job
+}
+
+// observe records the restart counts of a job's allocations and returns the restarts since
+// the last check, by allocation. The first check of a job only records them.
+func (t *restartTracker) observe(jobID string, counts map[string]int) (map[string]int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.counts == nil {
+		t.counts = make(map[string]map[string]int)
+	}
+	previous, seen := t.counts[jobID]
+	t.counts[jobID] = counts
+	if !seen {
+		return nil, false
+	}
+
+	restarted := make(map[string]int)
+	for allocID, count := range counts {
+		if delta := count - previous[allocID]; delta > 0 {
+			restarted[allocID] = delta
+		}
+	}
+	return restarted, true
+}
+
+// baseline is the mean restarts per check of the job
+func (t *restartTracker) baseline(jobID string) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	history := t.history[jobID]
+	if len(history) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, restarts := range history {
+		sum += restarts
+	}
+	return float64(sum) / float64(len(history))
+}
+
+// record adds the restarts of a check without a spike to the job's baseline
+func (t *restartTracker) record(jobID string, restarts, checks int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.history == nil {
+		t.history = make(map[string][]int)
+	}
+	history := append(t.history[jobID], restarts)
+	if len(history) > checks {
+		history = history[len(history)-checks:]
+	}
+	t.history[jobID] = history
+}
+
+func (t *restartTracker) anomaly(jobID string) *restartEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.anomalies[jobID]
+}
+
+func (t *restartTracker) setAnomaly(jobID string, anomaly *restartEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if anomaly == nil {
+		delete(t.anomalies, jobID)
+		return
+	}
+	if t.anomalies == nil {
+		t.anomalies = make(map[string]*restartEvent)
+	}
+	t.anomalies[jobID] = anomaly
+}
+
+// list returns the current anomalies sorted by job
+func (t *restartTracker) list() []restartEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var anomalies []restartEvent
+	for _, jobID := range slices.Sorted(maps.Keys(t.anomalies)) {
+		anomalies = append(anomalies, *t.anomalies[jobID])
+	}
+	return anomalies
+}
+
+// forget drops what was tracked of a deleted application
+func (t *restartTracker) forget(jobID string) {
+	t.retain(nil, jobID)
+}
+
+// retain drops what was tracked of the jobs not listed, and of the jobs dropped
+func (t *restartTracker) retain(jobIDs []string, dropped ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for jobID := range t.counts {
+		if !slices.Contains(jobIDs, jobID) || slices.Contains(dropped, jobID) {
+			delete(t.counts, jobID)
+			delete(t.history, jobID)
+			delete(t.anomalies, jobID)
+		}
+	}
+}
+
+// ListRestartAnomalies lists the applications whose restarts spiked as of the last check.
+// The restarts are only counted by the leader, the other replicas and the read-only ones
+// refer the caller to it.
+func (s *ApplicationService) ListRestartAnomalies(ctx context.Context, req *pb.RestartAnomaliesRequest) (*pb.RestartAnomaliesResponse, error) {
+	if s.restartPolicy == nil {
+		return &pb.RestartAnomaliesResponse{
+			Message: "Restart anomaly detection is disabled, start the controller with -restart-detection",
+		}, nil
+	}
+	if leader, ok := s.registry.(interface{ IsLeader() bool }); ok && !leader.IsLeader() {
+		return &pb.RestartAnomaliesResponse{
+			Message: "Restart anomalies are only tracked by the leading controller, ask it instead of this replica",
+		}, nil
+	}
+
+	resp := &pb.RestartAnomaliesResponse{}
+	for _, anomaly := range s.restarts.list() {
+		if req.Name != "" && anomaly.Application != req.Name && anomaly.JobID != req.Name {
+			continue
+		}
+		resp.Anomalies = append(resp.Anomalies, toRestartAnomaly(anomaly))
+	}
+	resp.Message = fmt.Sprintf("%d applications restarting abnormally", len(resp.Anomalies))
+	return resp, nil
+}
+
+// RunRestartDetection compares the restarts of every application's allocations with its
+// baseline every interval until the context is cancelled. With a replicated registry only
+// the leader checks them.
+func (s *ApplicationService) RunRestartDetection(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopRestarts, interval, func() error { return s.checkRestarts(interval) })
+		if err != nil {
+			log.Printf("Restart detection: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) checkRestarts(interval time.Duration) error {
+	jobIDs, err := s.applicationJobs()
+	if err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	checks := max(int(s.restartPolicy.Baseline/interval), 1)
+	s.reconciler.queue(loopRestarts, len(jobIDs))
+	for _, jobID := range jobIDs {
+		s.reconciler.next(loopRestarts)
+		err := s.checkApplicationRestarts(jobID, checks)
+		if err != nil {
+			log.Printf("Restart detection: %s: %v", jobID, err)
+		}
+		s.reconciler.result(loopRestarts, jobID, err)
+	}
+
+	s.restarts.retain(jobIDs)
+	return nil
+}
+
+// checkApplicationRestarts counts the restarts of the application since the last check. A
+// spike is raised once and settles when a check counts restarts within the baseline again.
+func (s *ApplicationService) checkApplicationRestarts(jobID string, checks int) error {
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return err
+	}
+	job, allocations, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int)
+	for _, alloc := range allocations {
+		counts[alloc.ID] = allocationRestarts(alloc)
+	}
+	restarted, ok := s.restarts.observe(jobID, counts)
+	if !ok {
+		return nil
+	}
+	restarts := 0
+	for _, delta := range restarted {
+		restarts += delta
+	}
+
+	baseline := s.restarts.baseline(jobID)
+	spike := restarts >= s.restartPolicy.MinRestarts && float64(restarts) > s.restartPolicy.Factor*baseline
+	current := s.restarts.anomaly(jobID)
+	now := time.Now().UTC()
+
+	switch {
+	case spike && current == nil:
+		anomaly := s.restartAnomaly(client, job, jobID, allocations, restarted, restarts, baseline)
+		anomaly.Since, anomaly.Time = now, now
+		s.restarts.setAnomaly(jobID, anomaly)
+		s.raiseRestarts(eventRestartSpike, *anomaly)
+	case spike:
+		// the spike goes on, the baseline stays the one before it
+		anomaly := s.restartAnomaly(client, job, jobID, allocations, restarted, restarts, current.Baseline)
+		anomaly.Since, anomaly.Time = current.Since, now
+		s.restarts.setAnomaly(jobID, anomaly)
+	case current != nil:
+		settled := *current
+		settled.Type, settled.Restarts, settled.Allocations, settled.Time = eventRestartsSettled, restarts, nil, now
+		s.restarts.setAnomaly(jobID, nil)
+		s.raiseRestarts(eventRestartsSettled, settled)
+		s.restarts.record(jobID, restarts, checks)
+	default:
+		s.restarts.record(jobID, restarts, checks)
+	}
+	return nil
+}
+
+// restartAnomaly describes a spike with the diagnostics of the restarted allocations: their
+// last task event and Nomad UI page, and the links of the application's page
+func (s *ApplicationService) restartAnomaly(client *nomad.NomadClient, job *nmd.Job, jobID string, allocations []*nmd.AllocationListStub, restarted map[string]int, restarts int, baseline float64) *restartEvent {
+	name := s.jobName(jobID)
+	anomaly := &restartEvent{
+		Type:        eventRestartSpike,
+		Application: name.Application,
+		JobID:       jobID,
+		Restarts:    restarts,
+		Baseline:    baseline,
+	}
+
+	for _, alloc := range allocations {
+		if restarted[alloc.ID] == 0 {
+			continue
+		}
+		anomaly.Allocations = append(anomaly.Allocations, restartedAllocation{
+			ID:        alloc.ID,
+			Restarts:  restarted[alloc.ID],
+			LastEvent: lastTaskEvent(alloc),
+			URL:       client.AllocationURL(alloc.ID),
+		})
+	}
+	anomaly.Links = s.applicationLinks(job, jobID)
+	return anomaly
+}
+
+// applicationLinks are the links of the application's Nomad UI page, label to URL
+func (s *ApplicationService) applicationLinks(job *nmd.Job, jobID string) map[string]string {
+	name := s.jobName(jobID)
+	region := ""
+	if job.Region != nil {
+		region = *job.Region
+	}
+	ui := s.ui.Render(map[string]string{
+		"app":    name.Application,
+		"tenant": name.Tenant,
+		"image":  taskImage(job, jobID),
+		"region": region,
+	}, nil)
+	if ui == nil {
+		return nil
+	}
+	links := make(map[string]string)
+	for _, link := range ui.Links {
+		links[link.Label] = link.URL
+	}
+	return links
+}
+
+// raiseRestarts logs the event, publishes it and posts it to the webhook
+func (s *ApplicationService) raiseRestarts(eventType string, event restartEvent) {
+	if eventType == eventRestartSpike {
+		log.Printf("Restart detection: %s restarted %d times since the last check, %.1f on average before, e.g. %s", event.Application, event.Restarts, event.Baseline, lastEventOf(event.Allocations))
+	} else {
+		log.Printf("Restart detection: restarts of %s are back within the baseline after %s", event.Application, event.Time.Sub(event.Since).Round(time.Second))
+	}
+
+	// the CloudEvents are typed io.controlplane.application.restart_spike and
+	// io.controlplane.application.restarts_settled
+	s.events.Publish(eventType, event.Application, event)
+	postWebhook(s.restartPolicy.Webhook, "Restart detection", eventType, event.Application, event)
+}
+
+// allocationRestarts counts the restarts of an allocation's tasks, a failed allocation
+// counts once more as Nomad replaces it instead of restarting it
+func allocationRestarts(alloc *nmd.AllocationListStub) int {
+	restarts := 0
+	for _, state := range alloc.TaskStates {
+		restarts += int(state.Restarts)
+	}
+	if alloc.ClientStatus == "failed" {
+		restarts++
+	}
+	return restarts
+}
+
+// lastTaskEvent is the message of the most recent event of the allocation's tasks
+func lastTaskEvent(alloc *nmd.AllocationListStub) string {
+	var last *nmd.TaskEvent
+	for _, state := range alloc.TaskStates {
+		if len(state.Events) == 0 {
+			continue
+		}
+		if event := state.Events[len(state.Events)-1]; last == nil || event.Time > last.Time {
+			last = event
+		}
+	}
+	if last == nil {
+		return ""
+	}
+	if last.DisplayMessage != "" {
+		return fmt.Sprintf("%s: %s", last.Type, last.DisplayMessage)
+	}
+	return last.Type
+}
+
+func lastEventOf(allocations []restartedAllocation) string {
+	for _, alloc := range allocations {
+		if alloc.LastEvent != "" {
+			return fmt.Sprintf("%s of %s", alloc.LastEvent, alloc.ID)
+		}
+	}
+	return "no task event"
+}
+
+func toRestartAnomaly(anomaly restartEvent) *pb.RestartAnomaly {
+	resp := &pb.RestartAnomaly{
+		Application: anomaly.Application,
+		Restarts:    int32(anomaly.Restarts),
+		Baseline:    anomaly.Baseline,
+		Since:       anomaly.Since.Unix(),
+		CheckedAt:   anomaly.Time.Unix(),
+		Links:       anomaly.Links,
+	}
+	for _, alloc := range anomaly.Allocations {
+		resp.Allocations = append(resp.Allocations, &pb.RestartedAllocation{
+			Id:        alloc.ID,
+			Restarts:  int32(alloc.Restarts),
+			LastEvent: alloc.LastEvent,
+			Url:       alloc.URL,
+		})
+	}
+	return resp
+}
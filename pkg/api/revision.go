@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// recordRevision records the spec of a submitted deployment as the next revision of the
+// job, the spec is sealed with the tenant's data key like the blueprints. The changes of its
+// environment from the previous revision are recorded with their sensitive values redacted.
+func (s *ApplicationService) recordRevision(ctx context.Context, jobID, application string, req *pb.DeployRequest, deploymentID string) {
+	spec, err := proto.Marshal(req)
+	if err == nil {
+		spec, err = sealForTenant(ctx, s.registry, s.sealer, req.Tenant, spec)
+	}
+	var envChanges []byte
+	if err == nil {
+		envChanges, err = s.envChanges(ctx, jobID, req)
+	}
+	if err == nil {
+		_, err = s.registry.SaveRevision(store.Revision{
+			JobID:        jobID,
+			Application:  application,
+			Tenant:       req.Tenant,
+			Owner:        applicationOwner(req.Labels, req.Tenant),
+			Image:        req.Image,
+			Spec:         spec,
+			EnvChanges:   envChanges,
+			DeploymentID: deploymentID,
+		})
+	}
+	if err != nil {
+		log.Printf("Failed to record the revision of %s: %v", jobID, err)
+	}
+}
+
+// ListRevisions returns the specs an application was deployed with, most recent first, and
+// the changes of their environment. The registry keeps them after the application is
+// deleted. Sensitive values of the environment are redacted.
+func (s *ApplicationService) ListRevisions(ctx context.Context, req *pb.ListRevisionsRequest) (*pb.ListRevisionsResponse, error) {
+	jobID, err := s.resolveJobID(req.DeploymentId)
+	var revisions []store.Revision
+	if err == nil {
+		revisions, err = s.registry.Revisions(jobID)
+	}
+	if err != nil {
+		return &pb.ListRevisionsResponse{
+			Message: fmt.Sprintf("Failed to list revisions: %v", err),
+		}, nil
+	}
+	if req.Limit > 0 && len(revisions) > int(req.Limit) {
+		revisions = revisions[:req.Limit]
+	}
+
+	resp := &pb.ListRevisionsResponse{Success: true}
+	var redactor *envRedactor
+	for _, revision := range revisions {
+		spec, err := s.revisionSpec(ctx, revision)
+		var envChanges []*pb.EnvChange
+		if err == nil {
+			envChanges, err = s.revisionEnvChanges(ctx, revision)
+		}
+		if err != nil {
+			return &pb.ListRevisionsResponse{
+				Message: fmt.Sprintf("Failed to read revision %d of %s: %v", revision.Revision, jobID, err),
+			}, nil
+		}
+		if redactor == nil {
+			redactor = s.envRedactor(jobID, spec.Namespace)
+		}
+		redactEnv(spec, redactor)
+		resp.Revisions = append(resp.Revisions, &pb.Revision{
+			Revision:     int32(revision.Revision),
+			JobId:        revision.JobID,
+			Image:        revision.Image,
+			Owner:        revision.Owner,
+			DeploymentId: revision.DeploymentID,
+			CreatedAt:    unixOrZero(revision.CreatedAt),
+			Spec:         spec,
+			EnvChanges:   envChanges,
+		})
+	}
+	resp.Message = fmt.Sprintf("Found %d revisions of %s", len(resp.Revisions), jobID)
+	return resp, nil
+}
+
+// rollbackToRevision deploys the spec of an earlier revision of an application again, it
+// goes through the checks of any deployment and is recorded as the next revision
+func (s *ApplicationService) rollbackToRevision(ctx context.Context, req *pb.RollbackRequest) *pb.RollbackResponse {
+	jobID, err := s.resolveJobID(req.DeploymentId)
+	var revision store.Revision
+	if err == nil {
+		revision, err = s.registry.Revision(jobID, int(req.Revision))
+	}
+	var spec *pb.DeployRequest
+	if err == nil {
+		spec, err = s.revisionSpec(ctx, revision)
+	}
+	var deployed *pb.DeployResponse
+	if err == nil {
+		deployed, err = s.DeployApplication(ctx, spec)
+	}
+	if err == nil && deployed.Status != "SUBMITTED" {
+		err = errors.New(deployed.Message)
+	}
+	if err != nil {
+		return &pb.RollbackResponse{
+			Message: fmt.Sprintf("Failed to roll back application: %v", err),
+		}
+	}
+
+	resp := &pb.RollbackResponse{
+		Success: true,
+		Message: fmt.Sprintf("Application rolled back to the spec of revision %d", revision.Revision),
+		EvalId:  deployed.DeploymentId,
+	}
+	if latest, err := s.registry.Revision(deployed.JobId, 0); err == nil {
+		resp.Revision = int32(latest.Revision)
+		resp.Message = fmt.Sprintf("Application rolled back to the spec of revision %d as revision %d", revision.Revision, latest.Revision)
+	}
+	s.publish(events.ApplicationRolledBack, deployed.JobId, lifecycleEvent{Revision: revision.Revision, EvalID: deployed.DeploymentId})
+	return resp
+}
+
+// revisionSpec opens and parses the deployment spec of a revision
+func (s *ApplicationService) revisionSpec(ctx context.Context, revision store.Revision) (*pb.DeployRequest, error) {
+	data, err := openForTenant(ctx, s.registry, s.sealer, revision.Tenant, revision.Spec)
+	if err != nil {
+		return nil, err
+	}
+	spec := &pb.DeployRequest{}
+	if err := proto.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	return spec, nil
+}
+
+// describeRevision completes the summary of an application with what the registry
+// recorded of it, Nomad may have lost the job or be unreachable
+func (s *ApplicationService) describeRevision(summary *pb.ApplicationSummary) {
+	name := s.jobName(summary.JobId)
+	summary.CreatedAt = unixOrZero(name.CreatedAt)
+	summary.Project = name.Project
+	revision, err := s.registry.Revision(summary.JobId, 0)
+	if err != nil {
+		return
+	}
+	summary.Revision = int32(revision.Revision)
+	summary.Owner = revision.Owner
+	if summary.Image == "" {
+		summary.Image = revision.Image
+	}
+	if summary.SubmittedAt == 0 {
+		summary.SubmittedAt = unixOrZero(revision.CreatedAt)
+	}
+}
@@ -0,0 +1,264 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// number of past rollouts used to estimate how long the next one takes
+const rolloutHistorySize = 10
+
+// rolloutProgress reports how far the latest rollout of an application got, it only reads
+// Nomad and the registry
+func (s *ApplicationService) rolloutProgress(application string) *pb.RolloutProgress {
+	client, err := s.nomadFor(application)
+	if err != nil {
+		return nil
+	}
+	deployment, err := client.LatestDeployment(application)
+	if err != nil || deployment == nil {
+		return nil
+	}
+
+	desired, healthy := 0, 0
+	for _, state := range deployment.TaskGroups {
+		desired += state.DesiredTotal
+		healthy += state.HealthyAllocs
+	}
+
+	percent := 100.0
+	if desired > 0 {
+		percent = float64(healthy) * 100 / float64(desired)
+	}
+
+	startedAt := time.Unix(0, deployment.CreateTime)
+	progress := &pb.RolloutProgress{
+		DeploymentId:     deployment.ID,
+		Status:           deployment.Status,
+		DesiredInstances: int32(desired),
+		HealthyInstances: int32(healthy),
+		Percent:          percent,
+		StartedAt:        startedAt.Unix(),
+		JobVersion:       deployment.JobVersion,
+	}
+
+	if rollout, ok := s.finishedRollout(application, deployment); ok {
+		progress.Reason = rollout.Reason
+		progress.Reverted = rollout.Reverted
+		progress.RevertedToVersion = rollout.RevertedTo
+		progress.FinishedAt = rollout.FinishedAt.Unix()
+	} else {
+		progress.EtaSeconds = int64(s.estimateRemaining(application, time.Since(startedAt), percent).Seconds())
+	}
+
+	return progress
+}
+
+// RunRolloutRecording records the rollouts of every application in the registry as they
+// finish, every interval until the context is cancelled, to estimate the future ones and
+// before Nomad collects them. With a replicated registry only the leader records them.
+func (s *ApplicationService) RunRolloutRecording(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopRollouts, interval, s.recordRollouts)
+		if err != nil {
+			log.Printf("Rollout recording: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) recordRollouts() error {
+	jobIDs, err := s.applicationJobs()
+	if err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	s.reconciler.queue(loopRollouts, len(jobIDs))
+	for _, jobID := range jobIDs {
+		s.reconciler.next(loopRollouts)
+		client, err := s.nomadFor(jobID)
+		var deployments []*nmd.Deployment
+		if err == nil {
+			deployments, err = client.Deployments(jobID)
+		}
+		s.reconciler.result(loopRollouts, jobID, err)
+		if err != nil {
+			log.Printf("Rollout recording: %s: %v", jobID, err)
+			continue
+		}
+		for _, deployment := range deployments {
+			s.recordRollout(jobID, deployment)
+		}
+	}
+	return nil
+}
+
+// finishedRollout returns the rollout of a finished deployment without recording it, ok
+// is false while it runs. A recorded rollout keeps the reason the controller failed it.
+func (s *ApplicationService) finishedRollout(application string, deployment *nmd.Deployment) (store.Rollout, bool) {
+	if !rolloutFinished(deployment) {
+		return store.Rollout{}, false
+	}
+	if rollout, ok := s.recordedRollout(application, deployment.ID); ok {
+		return rollout, true
+	}
+	return s.newRollout(application, deployment), true
+}
+
+// recordRollout records a finished rollout, ok is false while it runs. Only the background
+// loops of the leader record them, the RPCs read them with finishedRollout.
+func (s *ApplicationService) recordRollout(application string, deployment *nmd.Deployment) (store.Rollout, bool) {
+	if !rolloutFinished(deployment) {
+		return store.Rollout{}, false
+	}
+	if rollout, ok := s.recordedRollout(application, deployment.ID); ok {
+		return rollout, true
+	}
+
+	rollout := s.newRollout(application, deployment)
+	if err := s.registry.SaveRollout(rollout); err != nil {
+		log.Printf("Failed to record rollout %s: %v", deployment.ID, err)
+	}
+	if rollout.Status == nmd.DeploymentStatusFailed {
+		s.publish(events.RolloutFailed, application, lifecycleEvent{
+			Version:  &rollout.JobVersion,
+			Reverted: rollout.Reverted,
+			Message:  rollout.Reason,
+		})
+	}
+	return rollout, true
+}
+
+// rolloutFinished reports whether the deployment succeeded, failed or was cancelled
+func rolloutFinished(deployment *nmd.Deployment) bool {
+	switch deployment.Status {
+	case nmd.DeploymentStatusSuccessful, nmd.DeploymentStatusFailed, nmd.DeploymentStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// recordedRollout returns the rollout of the deployment the registry recorded
+func (s *ApplicationService) recordedRollout(application, deploymentID string) (store.Rollout, bool) {
+	rollouts, err := s.registry.Rollouts(application)
+	if err != nil {
+		log.Printf("Failed to load rollout history of %s: %v", application, err)
+	}
+	for _, rollout := range rollouts {
+		if rollout.ID == deploymentID {
+			return rollout, true
+		}
+	}
+	return store.Rollout{}, false
+}
+
+// newRollout describes a finished deployment as a rollout of the history
+func (s *ApplicationService) newRollout(application string, deployment *nmd.Deployment) store.Rollout {
+	rollout := store.Rollout{
+		ID:          deployment.ID,
+		Application: application,
+		JobVersion:  deployment.JobVersion,
+		Status:      deployment.Status,
+		StartedAt:   time.Unix(0, deployment.CreateTime),
+		FinishedAt:  time.Unix(0, deployment.ModifyTime),
+	}
+	if deployment.Status == nmd.DeploymentStatusFailed {
+		rollout.Reason = deployment.StatusDescription
+		rollout.RevertedTo, rollout.Reverted = nomad.RevertedTo(deployment)
+	}
+	rollout.CommittedAt = s.commitTime(application, deployment.JobVersion)
+	return rollout
+}
+
+// commitTime is the time of the commit a job version was built from, zero when the
+// deployment did not pass it
+func (s *ApplicationService) commitTime(application string, version uint64) time.Time {
+	client, err := s.nomadFor(application)
+	if err != nil {
+		return time.Time{}
+	}
+	job, err := client.JobAtVersion(application, version)
+	if err != nil {
+		return time.Time{}
+	}
+	unix, err := strconv.ParseInt(job.Meta[nomad.MetaCommitTime], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0).UTC()
+}
+
+// rolloutHistory returns the recorded rollouts of an application, most recent first
+func (s *ApplicationService) rolloutHistory(application string) []*pb.RolloutProgress {
+	rollouts, err := s.registry.Rollouts(application)
+	if err != nil {
+		log.Printf("Failed to load rollout history of %s: %v", application, err)
+		return nil
+	}
+
+	history := make([]*pb.RolloutProgress, 0, min(len(rollouts), rolloutHistorySize))
+	for _, rollout := range rollouts[:min(len(rollouts), rolloutHistorySize)] {
+		history = append(history, &pb.RolloutProgress{
+			DeploymentId:      rollout.ID,
+			Status:            rollout.Status,
+			StartedAt:         rollout.StartedAt.Unix(),
+			FinishedAt:        rollout.FinishedAt.Unix(),
+			JobVersion:        rollout.JobVersion,
+			Reason:            rollout.Reason,
+			Reverted:          rollout.Reverted,
+			RevertedToVersion: rollout.RevertedTo,
+		})
+	}
+	return history
+}
+
+// estimateRemaining uses the average of the previous successful rollouts and falls
+// back to extrapolating the current rate. A negative duration means unknown.
+func (s *ApplicationService) estimateRemaining(application string, elapsed time.Duration, percent float64) time.Duration {
+	rollouts, err := s.registry.Rollouts(application)
+	if err != nil {
+		log.Printf("Failed to load rollout history of %s: %v", application, err)
+	}
+
+	var total time.Duration
+	count := 0
+	for _, rollout := range rollouts {
+		if rollout.Status != nmd.DeploymentStatusSuccessful {
+			continue
+		}
+		total += rollout.Duration()
+		count++
+		if count == rolloutHistorySize {
+			break
+		}
+	}
+
+	if count > 0 {
+		if remaining := total/time.Duration(count) - elapsed; remaining > 0 {
+			return remaining
+		}
+	}
+
+	if percent > 0 && percent < 100 {
+		return time.Duration(float64(elapsed) * (100 - percent) / percent)
+	}
+
+	return -1
+}
@@ -0,0 +1,273 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+const defaultSearchLimit = 100
+
+// the operators of search terms, != before = so it is not cut short
+var searchOperators = []string{"!=", "=", "~"}
+
+var searchAnd = regexp.MustCompile(`(?i)\s+and\s+`)
+
+var searchStatuses = []string{"healthy", "progressing", "degraded", "suspended", "unknown"}
+
+// searchTerm is one field, operator and value of a query
+type searchTerm struct {
+	field string
+	op    string
+	value string
+}
+
+// parseSearchQuery parses terms joined by AND, values may be quoted
+func parseSearchQuery(query string) ([]searchTerm, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+
+	var terms []searchTerm
+	for _, expr := range searchAnd.Split(query, -1) {
+		index, op := -1, ""
+		for _, candidate := range searchOperators {
+			if i := strings.Index(expr, candidate); i >= 0 && (index < 0 || i < index) {
+				index, op = i, candidate
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("term %q has no operator, expected field=value, field!=value or field~value", expr)
+		}
+
+		term := searchTerm{
+			field: strings.ToLower(strings.TrimSpace(expr[:index])),
+			op:    op,
+			value: strings.Trim(strings.TrimSpace(expr[index+len(op):]), `"'`),
+		}
+		switch term.field {
+		case "hostname":
+			term.field = store.SearchHost
+		case projectLabel:
+			term.field = store.SearchLabelPrefix + projectLabel
+		}
+		switch {
+		case term.field == store.SearchName, term.field == store.SearchImage, term.field == store.SearchOwner,
+			term.field == store.SearchTenant, term.field == store.SearchHost, term.field == store.SearchStatus:
+		case strings.HasPrefix(term.field, store.SearchLabelPrefix) && len(term.field) > len(store.SearchLabelPrefix):
+		default:
+			return nil, fmt.Errorf("unknown field %q, expected name, image, owner, tenant, host, status, project or label.<key>", term.field)
+		}
+		if term.value == "" && term.op != "!=" {
+			return nil, fmt.Errorf("term %q has no value", expr)
+		}
+		if term.field == store.SearchStatus && term.op != "~" && !slices.Contains(searchStatuses, strings.ToLower(term.value)) {
+			return nil, fmt.Errorf("unknown status %q, expected one of %s", term.value, strings.Join(searchStatuses, ", "))
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+// matches tells whether a value of the field satisfies the term, case-insensitively
+func (t searchTerm) matches(entry store.SearchEntry) bool {
+	values := entry.Values(t.field)
+	switch t.op {
+	case "=":
+		return slices.ContainsFunc(values, func(value string) bool { return strings.EqualFold(value, t.value) })
+	case "~":
+		return slices.ContainsFunc(values, func(value string) bool {
+			return strings.Contains(strings.ToLower(value), strings.ToLower(t.value))
+		})
+	default:
+		return !slices.ContainsFunc(values, func(value string) bool { return strings.EqualFold(value, t.value) })
+	}
+}
+
+// Search finds applications by their name, image, owner, tenant, hosts, health and labels
+// in the registry's search index instead of Nomad, so queries stay cheap across hundreds of
+// applications. The equality terms are looked up in the index, the others filter what they
+// matched.
+func (s *ApplicationService) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	terms, err := parseSearchQuery(req.Query)
+	if err != nil {
+		return &pb.SearchResponse{
+			Message: fmt.Sprintf("Invalid query: %v", err),
+		}, nil
+	}
+
+	entries, err := s.searchCandidates(terms)
+	if err != nil {
+		return &pb.SearchResponse{
+			Message: fmt.Sprintf("Failed to search applications: %v", err),
+		}, nil
+	}
+	entries = slices.DeleteFunc(entries, func(entry store.SearchEntry) bool {
+		return !inNamespace(s.jobName(entry.JobID), req.Namespace) ||
+			slices.ContainsFunc(terms, func(term searchTerm) bool { return !term.matches(entry) })
+	})
+
+	limit := defaultSearchLimit
+	if req.Limit > 0 {
+		limit = int(req.Limit)
+	}
+	resp := &pb.SearchResponse{Success: true, Total: int32(len(entries))}
+	for _, entry := range entries[:min(limit, len(entries))] {
+		resp.Results = append(resp.Results, &pb.SearchResult{
+			Name:      entry.Application,
+			JobId:     entry.JobID,
+			Tenant:    entry.Tenant,
+			Owner:     entry.Owner,
+			Image:     entry.Image,
+			Labels:    entry.Labels,
+			Hosts:     entry.Hosts,
+			Status:    entry.Status,
+			UpdatedAt: unixOrZero(entry.UpdatedAt),
+		})
+	}
+	resp.Message = fmt.Sprintf("%d applications matched", resp.Total)
+	if len(resp.Results) < len(entries) {
+		resp.Message += fmt.Sprintf(", showing the first %d", len(resp.Results))
+	}
+	return resp, nil
+}
+
+// searchCandidates looks up the entries of every equality term in the index and keeps
+// those all of them matched, every entry when the query has none
+func (s *ApplicationService) searchCandidates(terms []searchTerm) ([]store.SearchEntry, error) {
+	var candidates []store.SearchEntry
+	looked := false
+	for _, term := range terms {
+		if term.op != "=" {
+			continue
+		}
+		entries, err := s.registry.LookupSearchEntries(term.field, term.value)
+		if err != nil {
+			return nil, err
+		}
+		if !looked {
+			candidates, looked = entries, true
+			continue
+		}
+		candidates = slices.DeleteFunc(candidates, func(candidate store.SearchEntry) bool {
+			return !slices.ContainsFunc(entries, func(entry store.SearchEntry) bool { return entry.JobID == candidate.JobID })
+		})
+	}
+	if looked {
+		return candidates, nil
+	}
+	return s.registry.SearchEntries()
+}
+
+// RunSearchIndexing refreshes the search entries of the applications from Nomad, their
+// health changes without a deployment
+func (s *ApplicationService) RunSearchIndexing(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopSearch, interval, s.indexApplications)
+		if err != nil {
+			log.Printf("Search indexing: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) indexApplications() error {
+	jobIDs, err := s.applicationJobs()
+	if err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	s.reconciler.queue(loopSearch, len(jobIDs))
+	for _, jobID := range jobIDs {
+		s.reconciler.next(loopSearch)
+		err := s.indexApplication(jobID)
+		if err != nil {
+			log.Printf("Search indexing: %s: %v", jobID, err)
+		}
+		s.reconciler.result(loopSearch, jobID, err)
+	}
+
+	// applications deleted outside of the controller leave the index
+	entries, err := s.registry.SearchEntries()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !slices.Contains(jobIDs, entry.JobID) {
+			if err := s.registry.DeleteSearchEntry(entry.JobID); err != nil {
+				log.Printf("Failed to remove the search entry of %s: %v", entry.JobID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *ApplicationService) indexApplication(jobID string) error {
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return err
+	}
+	job, allocations, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return err
+	}
+	deployment, err := client.LatestDeployment(jobID)
+	if err != nil {
+		return err
+	}
+	health, _ := assessHealth(job, allocations, deployment)
+	s.saveSearchEntry(jobID, job, health)
+	return nil
+}
+
+// saveSearchEntry indexes a job with its health, the entry is only written when it changed
+func (s *ApplicationService) saveSearchEntry(jobID string, job *nmd.Job, health pb.ApplicationHealthStatus) {
+	name := s.jobName(jobID)
+	entry := store.SearchEntry{
+		JobID:       jobID,
+		Application: name.Application,
+		Tenant:      name.Tenant,
+		Image:       taskImage(job, name.Application),
+		Labels:      jobLabels(job),
+		Hosts:       nomad.TraefikHosts(job),
+		Status:      strings.ToLower(strings.TrimPrefix(health.String(), "APPLICATION_HEALTH_")),
+		UpdatedAt:   time.Now(),
+	}
+	if entry.Image == "" && len(job.TaskGroups) > 0 && len(job.TaskGroups[0].Tasks) > 0 {
+		entry.Image, _ = job.TaskGroups[0].Tasks[0].Config["image"].(string)
+	}
+	entry.Owner = applicationOwner(entry.Labels, name.Tenant)
+
+	if previous, err := s.registry.SearchEntry(jobID); err == nil && sameSearchEntry(previous, entry) {
+		return
+	}
+	if err := s.registry.SaveSearchEntry(entry); err != nil {
+		log.Printf("Failed to index %s for search: %v", jobID, err)
+	}
+}
+
+// sameSearchEntry compares two entries regardless of when they were indexed
+func sameSearchEntry(a, b store.SearchEntry) bool {
+	return a.JobID == b.JobID && a.Application == b.Application && a.Tenant == b.Tenant && a.Owner == b.Owner &&
+		a.Image == b.Image && a.Status == b.Status && maps.Equal(a.Labels, b.Labels) && slices.Equal(a.Hosts, b.Hosts)
+}
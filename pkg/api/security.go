@@ -0,0 +1,65 @@
+package api
+
+import (
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+func securityFromProto(security *pb.SecurityContext) *nomad.SecurityContext {
+	if security == nil {
+		return nil
+	}
+	return &nomad.SecurityContext{
+		NoNewPrivileges:  security.NoNewPrivileges,
+		SeccompProfile:   security.SeccompProfile,
+		AppArmorProfile:  security.ApparmorProfile,
+		DropCapabilities: security.DropCapabilities,
+	}
+}
+
+func securityDefaultsFromProto(security *pb.SecurityContext) store.SecurityDefaults {
+	if security == nil {
+		return store.SecurityDefaults{}
+	}
+	return store.SecurityDefaults{
+		NoNewPrivileges:  security.NoNewPrivileges,
+		SeccompProfile:   security.SeccompProfile,
+		AppArmorProfile:  security.ApparmorProfile,
+		DropCapabilities: security.DropCapabilities,
+	}
+}
+
+func toSecurityContext(defaults store.SecurityDefaults) *pb.SecurityContext {
+	return &pb.SecurityContext{
+		NoNewPrivileges:  defaults.NoNewPrivileges,
+		SeccompProfile:   defaults.SeccompProfile,
+		ApparmorProfile:  defaults.AppArmorProfile,
+		DropCapabilities: defaults.DropCapabilities,
+	}
+}
+
+// applyTenantSecurity fills the security settings the spec leaves unset from the defaults
+// of its tenant
+func (s *ApplicationService) applyTenantSecurity(req *pb.DeployRequest, jobTemplate *nomad.JobTemplate) error {
+	if req.Tenant == "" {
+		return nil
+	}
+
+	tenant, err := s.registry.Tenant(req.Tenant)
+	if err != nil {
+		return err
+	}
+
+	defaults := tenant.Security
+	if !defaults.NoNewPrivileges && defaults.SeccompProfile == "" && defaults.AppArmorProfile == "" && len(defaults.DropCapabilities) == 0 {
+		return nil
+	}
+	jobTemplate.Security = jobTemplate.Security.WithDefaults(&nomad.SecurityContext{
+		NoNewPrivileges:  defaults.NoNewPrivileges,
+		SeccompProfile:   defaults.SeccompProfile,
+		AppArmorProfile:  defaults.AppArmorProfile,
+		DropCapabilities: defaults.DropCapabilities,
+	})
+	return nil
+}
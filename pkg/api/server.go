@@ -3,27 +3,390 @@ package api
 import (
 	"context"
 	"fmt"
+	"log"
 	"maps"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	nmd "github.com/hashicorp/nomad/api"
 	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/consul"
+	"github.com/iuliansafta/control-plane/pkg/dns"
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/kms"
 	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/orchestrator"
+	"github.com/iuliansafta/control-plane/pkg/plugin"
+	"github.com/iuliansafta/control-plane/pkg/store"
 	"github.com/iuliansafta/control-plane/pkg/utils"
 )
 
 type ApplicationService struct {
 	pb.UnimplementedControlPlaneServer
-	orhClient *nomad.NomadClient //INFO: this could be extended to handle multiple orchestrators
+	orhClient *nomad.NomadClient // nil when the applications run on another orchestrator
+	// runs the applications, only the portable RPCs are served when it is not Nomad
+	orchestrator orchestrator.Orchestrator
+	registry     store.Store
+	sealer       *kms.Sealer
+	plugins      *plugin.Chain
+	certPolicy   *nomad.CertPolicy
+	egress       *EgressPolicy
+	// repositories images may come from when the tenant has no policy, any when empty
+	allowedImages []string
+	drift         *DriftPolicy
+	attestation   *AttestationPolicy
+	ui            *nomad.UIConfig
+	naming        *JobNaming
+	reserved      *Reserved
+	events        *events.Publisher
+	placement     *PlacementPolicy
+	dns           dns.Provider
+	consul        *consul.Client // keeps the Consul KV configuration of applications
+	// samples the usage of applications to recommend their resources
+	recommendations *RecommendationPolicy
+	// detects applications restarting far more often than their baseline
+	restartPolicy *RestartPolicy
+	// scales services on their metrics
+	autoscale  *AutoscalePolicy
+	functions  functionSlots
+	rollouts   rolloutGroups
+	reconciler reconcilerStats
+	statuses   statusCache
+	restarts   restartTracker
+	autoscaler autoscaleTracker
+
+	// patterns of the environment keys whose values the revision history redacts
+	sensitiveEnv []string
 }
 
-func NewApplicationService(orchClient *nomad.NomadClient) *ApplicationService {
+func NewApplicationService(orch orchestrator.Orchestrator, registry store.Store, sealer *kms.Sealer, plugins *plugin.Chain, certPolicy *nomad.CertPolicy, egress *EgressPolicy, allowedImages []string, drift *DriftPolicy, attestation *AttestationPolicy, ui *nomad.UIConfig, naming *JobNaming, reserved *Reserved, publisher *events.Publisher, placement *PlacementPolicy, dnsProvider dns.Provider, consulClient *consul.Client, recommendations *RecommendationPolicy, restartPolicy *RestartPolicy, autoscale *AutoscalePolicy, sensitiveEnv []string) *ApplicationService {
+	// every feature is built on Nomad, other orchestrators only serve the portable RPCs
+	orhClient, _ := orch.(*nomad.NomadClient)
 	return &ApplicationService{
-		orhClient: orchClient,
+		orhClient:       orhClient,
+		orchestrator:    orch,
+		registry:        registry,
+		sealer:          sealer,
+		plugins:         plugins,
+		certPolicy:      certPolicy,
+		egress:          egress,
+		allowedImages:   allowedImages,
+		drift:           drift,
+		attestation:     attestation,
+		ui:              ui,
+		naming:          naming,
+		reserved:        reserved,
+		events:          publisher,
+		placement:       placement,
+		dns:             dnsProvider,
+		consul:          consulClient,
+		recommendations: recommendations,
+		restartPolicy:   restartPolicy,
+		autoscale:       autoscale,
+		sensitiveEnv:    sensitiveEnv,
 	}
 }
 
 // DeployApplication deploys an application to the orchestrator
 func (s *ApplicationService) DeployApplication(ctx context.Context, req *pb.DeployRequest) (*pb.DeployResponse, error) {
+	resp, err := s.deployApplication(ctx, req)
+	if resp != nil {
+		environment := ""
+		if s.naming != nil {
+			environment = s.naming.Environment
+		}
+		resp.Warnings = LintSpec(req, environment)
+	}
+	if !req.DryRun {
+		s.publishDeploy(req, resp)
+	}
+	return resp, err
+}
+
+func (s *ApplicationService) deployApplication(ctx context.Context, req *pb.DeployRequest) (*pb.DeployResponse, error) {
+	req, err := s.plugins.PreValidate(ctx, req)
+	if err == nil {
+		req, err = s.applyProject(ctx, req)
+	}
+	if err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Invalid deployment spec: %v", err),
+		}, nil
+	}
+
+	if errs := ValidateSpec(req); len(errs) > 0 {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Invalid deployment spec: %v", joinErrors(errs)),
+		}, nil
+	}
+
+	if err := s.admitImage(req); err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Image rejected: %v", err),
+		}, nil
+	}
+
+	if err := s.checkAttestation(ctx, req); err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Attestation required: %v", err),
+		}, nil
+	}
+
+	if err := s.checkHosts(req); err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Invalid deployment spec: %v", err),
+		}, nil
+	}
+
+	// from here on the application is named by its job
+	application := req.Name
+	jobID, err := s.jobIDFor(req)
+	if err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Job name rejected: %v", err),
+		}, nil
+	}
+	if err := s.reserved.check(req, jobID); err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Invalid deployment spec: %v", err),
+		}, nil
+	}
+	if err := s.checkProjectQuota(ctx, req, jobID); err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Project quota exceeded: %v", err),
+		}, nil
+	}
+	replicas := max(req.Replicas, 1)
+	if err := s.checkTenantQuota(ctx, req.Tenant, jobID, req.Cpu*float64(replicas), req.Memory*int64(replicas)); err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Tenant quota exceeded: %v", err),
+		}, nil
+	}
+	if err := s.checkDeployNamespace(ctx, req, jobID); err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Namespace rejected: %v", err),
+		}, nil
+	}
+	if jobID != application {
+		req = proto.Clone(req).(*pb.DeployRequest)
+		req.Name = jobID
+	}
+	if s.orhClient == nil {
+		return s.deployToOrchestrator(ctx, req, application)
+	}
+
+	if req.Geo != nil {
+		if err := s.checkGeo(req.Geo); err != nil {
+			return &pb.DeployResponse{
+				Status:  "FAILED",
+				Message: fmt.Sprintf("Invalid deployment spec: %v", err),
+			}, nil
+		}
+		req = proto.Clone(req).(*pb.DeployRequest)
+		req.Region = req.Geo.Targets[0].Region
+	} else if route, err := s.registry.GeoRoute(jobID); err == nil && req.Region == "" {
+		// the application leaves the other regions of its route
+		req = proto.Clone(req).(*pb.DeployRequest)
+		req.Region = route.Targets[0].Region
+	}
+
+	var placement store.PlacementDecision
+	if s.placement.enabled() && req.Region == "" {
+		if placement, err = s.place(jobID, req); err != nil {
+			return &pb.DeployResponse{
+				Status:  "FAILED",
+				Message: fmt.Sprintf("Placement failed: %v", err),
+			}, nil
+		}
+		req = proto.Clone(req).(*pb.DeployRequest)
+		req.Region = placement.Region
+	}
+
+	jobTemplate, err := jobTemplateFromSpec(req)
+	if err == nil {
+		err = s.certPolicy.Apply(&jobTemplate.Traefik)
+	}
+	if err == nil {
+		err = s.egress.apply(jobTemplate)
+	}
+	if err == nil {
+		err = s.applyTenantSecurity(req, jobTemplate)
+	}
+	if err == nil {
+		err = s.applyAutoscaling(jobID, req, jobTemplate)
+	}
+	if err == nil {
+		err = s.orhClient.Capabilities().Check(jobTemplate)
+	}
+	if err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Invalid deployment spec: %v", err),
+		}, nil
+	}
+
+	jobTemplate.UI = s.ui.Render(map[string]string{
+		"app":    application,
+		"tenant": req.Tenant,
+		"image":  req.Image,
+		"region": req.Region,
+	}, req.Annotations)
+
+	job, err := s.plugins.MutateJob(ctx, req, jobTemplate.ToNomadJob())
+	if err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Failed to deploy application: %v", err),
+		}, nil
+	}
+	// blue-green and canary-traffic rollouts deploy the green job next to the running one
+	deployed := job
+	if split := splitFromSpec(req); split != nil {
+		if deployed, err = s.splitJob(req, jobID, job, split); err != nil {
+			return &pb.DeployResponse{
+				Status:  "FAILED",
+				Message: fmt.Sprintf("Failed to start the traffic split: %v", err),
+			}, nil
+		}
+	}
+	if req.DryRun {
+		return s.planJob(deployed, jobID), nil
+	}
+
+	if err := s.deployAddOns(req); err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Failed to deploy add-ons: %v", err),
+		}, nil
+	}
+
+	// the keys exist before the template first renders them
+	if err := s.syncConsulKV(req); err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Failed to write the Consul KV configuration: %v", err),
+		}, nil
+	}
+
+	if deployed != job {
+		if err := s.shareVariable(req, jobID, *deployed.ID); err != nil {
+			return &pb.DeployResponse{
+				Status:  "FAILED",
+				Message: fmt.Sprintf("Failed to start the traffic split: %v", err),
+			}, nil
+		}
+	}
+
+	release, err := s.acquireGroup(ctx, req.ConcurrencyGroup, jobID)
+	if err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Deployment not started: %v", err),
+		}, nil
+	}
+	resp, err := s.orhClient.RegisterJob(deployed)
+	release(err == nil)
+	if err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Failed to deploy application: %v", err),
+		}, nil
+	}
+	if deployed == job {
+		// a rolling update takes the traffic of an unfinished split back
+		if client, err := s.nomadFor(jobID); err == nil {
+			if err := s.removeGreen(client, jobID); err != nil {
+				log.Printf("Failed to remove the green job of %s: %v", jobID, err)
+			}
+		}
+	}
+	s.recordJobID(jobID, application, req.Tenant, req.Labels[projectLabel], req.Namespace)
+	s.recordPlacement(placement)
+	s.plugins.PostDeploy(req, resp.EvalID)
+	s.recordImage(ctx, req)
+	s.recordRevision(ctx, jobID, application, req, resp.EvalID)
+	// found by search right away, its health is indexed by the next run of the loop
+	s.saveSearchEntry(jobID, job, pb.ApplicationHealthStatus_APPLICATION_HEALTH_PROGRESSING)
+
+	// the applications it no longer depends on revoke its intentions
+	var previousDependencies []string
+	if graph, err := s.registry.Dependencies(); err == nil {
+		previousDependencies = graph[req.Name]
+	}
+	if err := s.registry.SetDependencies(req.Name, req.DependsOn); err != nil {
+		log.Printf("Failed to record dependencies of %s: %v", req.Name, err)
+	}
+
+	s.removeAddOns(req.Name, addOnsFromSpec(req))
+
+	if err := s.deployGeo(req, jobID, job); err != nil {
+		return &pb.DeployResponse{
+			DeploymentId: resp.EvalID,
+			JobId:        jobID,
+			Status:       "FAILED",
+			Message:      fmt.Sprintf("Application deployment submitted, but failed to route it across regions: %v", err),
+		}, nil
+	}
+
+	if err := s.egress.syncIntentions(jobTemplate); err != nil {
+		return &pb.DeployResponse{
+			DeploymentId: resp.EvalID,
+			JobId:        jobID,
+			Status:       "FAILED",
+			Message:      fmt.Sprintf("Application deployment submitted, but failed to allow its egress: %v", err),
+		}, nil
+	}
+
+	if err := s.syncMeshIntentions(req.Name, append(previousDependencies, req.DependsOn...)); err != nil {
+		return &pb.DeployResponse{
+			DeploymentId: resp.EvalID,
+			JobId:        jobID,
+			Status:       "FAILED",
+			Message:      fmt.Sprintf("Application deployment submitted, but failed to reconcile its intentions: %v", err),
+		}, nil
+	}
+
+	if err := s.syncBackupJobs(req); err != nil {
+		return &pb.DeployResponse{
+			DeploymentId: resp.EvalID,
+			JobId:        jobID,
+			Status:       "FAILED",
+			Message:      fmt.Sprintf("Application deployment submitted, but failed to register its backup jobs: %v", err),
+		}, nil
+	}
+
+	return &pb.DeployResponse{
+		DeploymentId: resp.EvalID,
+		JobId:        jobID,
+		Status:       "SUBMITTED",
+		Message:      "Application deployment submitted successfully",
+	}, nil
+}
+
+var certStrategies = map[pb.CertStrategy]string{
+	pb.CertStrategy_CERT_STRATEGY_UNSPECIFIED: nomad.CertStrategyAuto,
+	pb.CertStrategy_CERT_STRATEGY_HOST:        nomad.CertStrategyHost,
+	pb.CertStrategy_CERT_STRATEGY_WILDCARD:    nomad.CertStrategyWildcard,
+}
+
+// jobTemplateFromSpec translates a deployment spec into the Nomad job template
+func jobTemplateFromSpec(req *pb.DeployRequest) (*nomad.JobTemplate, error) {
 	networkMode := "host"
 	switch req.NetworkMode {
 	case pb.NetworkMode_NETWORK_MODE_BRIDGE:
@@ -39,6 +402,7 @@ func (s *ApplicationService) DeployApplication(ctx context.Context, req *pb.Depl
 		Image:         req.Image,
 		Instances:     int(req.Replicas),
 		Region:        req.Region,
+		Namespace:     req.Namespace,
 		DisableConsul: false,
 		NetworkMode:   networkMode,
 		ResourcesSpec: nomad.Resources{
@@ -46,6 +410,10 @@ func (s *ApplicationService) DeployApplication(ctx context.Context, req *pb.Depl
 			MemoryMB: utils.IntPtr(int(req.Memory)),
 		},
 		Environment: make(map[string]string),
+		Meta:        make(map[string]string),
+	}
+	if req.MemoryMax > 0 {
+		jobTemplate.ResourcesSpec.MemoryMaxMB = utils.IntPtr(int(req.MemoryMax))
 	}
 
 	if req.Traefik != nil {
@@ -56,6 +424,8 @@ func (s *ApplicationService) DeployApplication(ctx context.Context, req *pb.Depl
 			EnableSSL:           req.Traefik.EnableSsl,
 			SSLHost:             req.Traefik.SslHost,
 			CertResolver:        req.Traefik.CertResolver,
+			CertStrategy:        certStrategies[req.Traefik.CertStrategy],
+			CertSANs:            req.Traefik.CertSans,
 			HealthCheckPath:     req.Traefik.HealthCheckPath,
 			HealthCheckInterval: req.Traefik.HealthCheckInterval,
 			PathPrefix:          req.Traefik.PathPrefix,
@@ -64,9 +434,141 @@ func (s *ApplicationService) DeployApplication(ctx context.Context, req *pb.Depl
 		}
 	}
 
-	maps.Copy(jobTemplate.Environment, req.Labels)
+	for _, constraint := range req.Constraints {
+		jobTemplate.Constraints = append(jobTemplate.Constraints, nomad.Constraint{
+			Attribute: constraint.Attribute,
+			Operator:  constraint.Operator,
+			Value:     constraint.Value,
+		})
+	}
 
-	if jobTemplate.Ports.Label == "" {
+	if req.EphemeralDisk != nil {
+		jobTemplate.EphemeralDisk = &nomad.EphemeralDisk{
+			Migrate: req.EphemeralDisk.Migrate,
+			Sticky:  req.EphemeralDisk.Sticky,
+		}
+		if req.EphemeralDisk.SizeMb != 0 {
+			jobTemplate.EphemeralDisk.SizeMB = utils.IntPtr(int(req.EphemeralDisk.SizeMb))
+		}
+	}
+
+	jobTemplate.Volumes = volumeMountsFromSpec(req)
+
+	jobTemplate.Security = securityFromProto(req.Security)
+
+	for _, action := range req.Actions {
+		jobTemplate.Actions = append(jobTemplate.Actions, nomad.Action{
+			Name:    action.Name,
+			Command: action.Command,
+			Args:    action.Args,
+		})
+	}
+
+	if req.Update != nil {
+		jobTemplate.Update = &nomad.UpdateStrategy{
+			MaxParallel:     int(req.Update.MaxParallel),
+			Canary:          int(req.Update.Canary),
+			AutoRevert:      req.Update.AutoRevert,
+			AutoPromote:     req.Update.AutoPromote,
+			MinHealthyTime:  time.Duration(req.Update.MinHealthyTimeSeconds) * time.Second,
+			HealthyDeadline: time.Duration(req.Update.HealthyDeadlineSeconds) * time.Second,
+		}
+	}
+
+	if jobTemplate.Egress = egressFromSpec(req); jobTemplate.Egress != nil {
+		jobTemplate.Meta[nomad.MetaEgress] = jobTemplate.Egress.String()
+	}
+	if jobTemplate.AllowFrom = req.AllowFrom; len(req.AllowFrom) > 0 {
+		jobTemplate.Meta[nomad.MetaAllowFrom] = strings.Join(req.AllowFrom, ",")
+	}
+
+	addOnNames := make(map[string]bool)
+	for _, addOn := range addOnsFromSpec(req) {
+		if err := addOn.Validate(); err != nil {
+			return nil, err
+		}
+		if addOnNames[addOn.Name] {
+			return nil, fmt.Errorf("add-on %s is requested twice", addOn.Name)
+		}
+		addOnNames[addOn.Name] = true
+		jobTemplate.Templates = append(jobTemplate.Templates, addOn.EnvTemplate())
+	}
+
+	if req.IdleTimeoutMinutes > 0 {
+		if req.Traefik == nil || req.Traefik.Host == "" {
+			return nil, fmt.Errorf("idle timeout requires a Traefik host to wake the application on")
+		}
+		jobTemplate.Meta[nomad.MetaIdleTimeout] = fmt.Sprintf("%dm", req.IdleTimeoutMinutes)
+		jobTemplate.Meta[nomad.MetaHost] = req.Traefik.Host
+	}
+
+	if req.ConcurrencyGroup != "" {
+		jobTemplate.Meta[nomad.MetaConcurrencyGroup] = req.ConcurrencyGroup
+	}
+	if req.CommitTime > 0 {
+		jobTemplate.Meta[nomad.MetaCommitTime] = strconv.FormatInt(req.CommitTime, 10)
+	}
+	if req.Autoscaling != nil {
+		autoscaling, err := protojson.Marshal(req.Autoscaling)
+		if err != nil {
+			return nil, err
+		}
+		jobTemplate.Meta[nomad.MetaAutoscaling] = string(autoscaling)
+		jobTemplate.Instances = clampCount(jobTemplate.Instances, req.Autoscaling)
+	}
+	if req.RolloutDeadlineSeconds > 0 {
+		jobTemplate.Meta[nomad.MetaRolloutDeadline] = (time.Duration(req.RolloutDeadlineSeconds) * time.Second).String()
+		jobTemplate.Meta[nomad.MetaRolloutRevert] = strconv.FormatBool(req.RevertOnDeadline)
+	}
+
+	maps.Copy(jobTemplate.Environment, req.Env)
+	for key, value := range req.Labels {
+		jobTemplate.Meta[nomad.MetaLabelPrefix+key] = value
+	}
+
+	if req.ConsulKv != nil {
+		kv := consulKVFromSpec(req)
+		if err := kv.Validate(); err != nil {
+			return nil, err
+		}
+		for key, value := range req.ConsulKv.Values {
+			if err := nomad.ValidateConsulKVEntry(key, value); err != nil {
+				return nil, err
+			}
+		}
+		jobTemplate.Templates = append(jobTemplate.Templates, kv.Template())
+		jobTemplate.Meta[nomad.MetaConsulKV] = kv.Prefix
+	}
+
+	var err error
+	switch req.Type {
+	case pb.DeploymentType_DEPLOYMENT_TYPE_FUNCTION:
+		err = applyFunctionSpec(jobTemplate, req)
+	case pb.DeploymentType_DEPLOYMENT_TYPE_CRON:
+		err = applyCronSpec(jobTemplate, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the first http port is the application's service, without one the first port is
+	for _, spec := range req.Ports {
+		port := nomad.Ports{Label: spec.Label, Value: int(spec.HostPort), To: int(spec.ContainerPort), Protocol: spec.Protocol}
+		if port.Protocol == "" {
+			port.Protocol = "http"
+		}
+		if jobTemplate.Ports.Label == "" && port.Protocol == "http" {
+			jobTemplate.Ports = port
+		} else {
+			jobTemplate.ExtraPorts = append(jobTemplate.ExtraPorts, port)
+		}
+	}
+	if jobTemplate.Ports.Label == "" && len(jobTemplate.ExtraPorts) > 0 {
+		jobTemplate.Ports, jobTemplate.ExtraPorts = jobTemplate.ExtraPorts[0], jobTemplate.ExtraPorts[1:]
+	}
+
+	// batch jobs run to completion and are not reachable over the network
+	if jobTemplate.Ports.Label == "" && jobTemplate.Type != "batch" {
 		jobTemplate.Ports = nomad.Ports{
 			Label: "http",
 			Value: 0, // dynamic port from nomad
@@ -74,24 +576,37 @@ func (s *ApplicationService) DeployApplication(ctx context.Context, req *pb.Depl
 		}
 	}
 
-	resp, err := s.orhClient.DeployJob(jobTemplate)
-	if err != nil {
-		return &pb.DeployResponse{
-			Status:  "FAILED",
-			Message: fmt.Sprintf("Failed to deploy application: %v", err),
-		}, nil
+	// after the ports are known, ${port.<label>} must name one of them
+	if err := jobTemplate.ResolveEnvironment(map[string]string{"name": req.Name, "tenant": req.Tenant}); err != nil {
+		return nil, err
 	}
 
-	return &pb.DeployResponse{
-		DeploymentId: resp.EvalID,
-		Status:       "SUBMITTED",
-		Message:      "Application deployment submitted successfully",
-	}, nil
+	if err := jobTemplate.Validate(); err != nil {
+		return nil, err
+	}
+
+	return jobTemplate, nil
 }
 
 // DeleteApplication deletes an application.
 func (s *ApplicationService) DeleteApplication(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
-	err := s.orhClient.DeleteJob(req.DeploymentId)
+	if s.orhClient == nil {
+		return s.deleteFromOrchestrator(ctx, req)
+	}
+	jobID, err := s.resolveJobID(req.DeploymentId)
+	var client *nomad.NomadClient
+	if err == nil {
+		client, err = s.nomadFor(jobID)
+	}
+	// the job is purged, what it kept outside of Nomad is read first
+	kvPrefix := ""
+	if err == nil {
+		if job, _, err := client.GetJobStatus(jobID); err == nil {
+			kvPrefix = job.Meta[nomad.MetaConsulKV]
+		}
+		err = client.DeleteJob(jobID)
+	}
+
 	if err != nil {
 		return &pb.DeleteResponse{
 			Success: false,
@@ -99,16 +614,88 @@ func (s *ApplicationService) DeleteApplication(ctx context.Context, req *pb.Dele
 		}, nil
 	}
 
+	message := "Application deleted successfully"
+	var dependencies []string
+	if graph, err := s.registry.Dependencies(); err == nil {
+		if consumers := impactedApplications(graph, jobID); len(consumers) > 0 {
+			message = fmt.Sprintf("%s, %d applications depend on it", message, len(consumers))
+		}
+		dependencies = graph[jobID]
+	}
+
+	if err := s.registry.SetDependencies(jobID, nil); err != nil {
+		log.Printf("Failed to remove dependencies of %s: %v", jobID, err)
+	}
+
+	if _, _, err := s.orhClient.GetJobStatus(nomad.BackupJobID(jobID)); err == nil {
+		s.removeBackupJobs(jobID)
+	}
+	s.removeAddOns(jobID, nil)
+	if err := s.removeGreen(client, jobID); err != nil {
+		log.Printf("Failed to remove the green job of %s: %v", jobID, err)
+	}
+	s.egress.removeIntentions(jobID)
+	if err := s.syncMeshIntentions(jobID, dependencies); err != nil {
+		log.Printf("Failed to revoke the intentions of %s: %v", jobID, err)
+	}
+	s.removeConsulKV(kvPrefix)
+	if err := s.registry.DeleteDeployedImage(jobID); err != nil {
+		log.Printf("Failed to remove the deployed image of %s: %v", jobID, err)
+	}
+
+	s.publish(events.ApplicationDeleted, jobID, lifecycleEvent{Message: message})
+	if route, err := s.registry.GeoRoute(jobID); err == nil {
+		if err := s.removeGeo(route, route.Targets[0].Region); err != nil {
+			log.Printf("Failed to remove the geo route of %s: %v", jobID, err)
+		}
+	}
+	if err := s.registry.DeletePlacement(jobID); err != nil {
+		log.Printf("Failed to remove the placement of %s: %v", jobID, err)
+	}
+	if err := s.registry.DeleteUsage(jobID); err != nil {
+		log.Printf("Failed to remove the usage of %s: %v", jobID, err)
+	}
+	if err := s.registry.DeleteUptime(jobID); err != nil {
+		log.Printf("Failed to remove the uptime of %s: %v", jobID, err)
+	}
+	if err := s.registry.DeleteJobName(jobID); err != nil {
+		log.Printf("Failed to remove the job name of %s: %v", jobID, err)
+	}
+	if err := s.registry.DeleteSearchEntry(jobID); err != nil {
+		log.Printf("Failed to remove the search entry of %s: %v", jobID, err)
+	}
+	s.reconciler.forget(jobID)
+	s.statuses.forget(jobID)
+	s.restarts.forget(jobID)
+	s.autoscaler.forget(jobID)
+
 	return &pb.DeleteResponse{
 		Success: true,
-		Message: "Application deleted successfully",
+		Message: message,
 	}, nil
 }
 
 // GetApplicationStatus retrieves the status of an application.
 func (s *ApplicationService) GetApplicationStatus(ctx context.Context, req *pb.StatusRequest) (*pb.StatusResponse, error) {
-	job, allocations, err := s.orhClient.GetJobStatus(req.DeploymentId)
+	if s.orhClient == nil {
+		return s.orchestratorStatus(ctx, req)
+	}
+	jobID, err := s.resolveJobID(req.DeploymentId)
+	if err == nil {
+		if cached := s.cachedStatus(jobID, req.DeploymentId); cached != nil {
+			return cached, nil
+		}
+	}
 
+	var job *nmd.Job
+	var allocations []*nmd.AllocationListStub
+	var client *nomad.NomadClient
+	if err == nil {
+		client, err = s.nomadFor(jobID)
+	}
+	if err == nil {
+		job, allocations, err = client.GetJobStatus(jobID)
+	}
 	if err != nil {
 		return &pb.StatusResponse{
 			DeploymentId: req.DeploymentId,
@@ -117,7 +704,16 @@ func (s *ApplicationService) GetApplicationStatus(ctx context.Context, req *pb.S
 	}
 
 	var allocationStatuses []*pb.AllocationStatus
-	runningInstances := int32(0)
+	groups := make(map[string]*pb.TaskGroupStatus)
+	totals := &pb.TaskGroupStatus{}
+
+	for _, group := range job.TaskGroups {
+		groups[*group.Name] = &pb.TaskGroupStatus{
+			Name:             *group.Name,
+			DesiredInstances: int32(*group.Count),
+		}
+		totals.DesiredInstances += int32(*group.Count)
+	}
 
 	for _, alloc := range allocations {
 		taskStates := make(map[string]string)
@@ -127,8 +723,24 @@ func (s *ApplicationService) GetApplicationStatus(ctx context.Context, req *pb.S
 			}
 		}
 
+		// allocations of groups removed from the job still count towards the totals
+		group, ok := groups[alloc.TaskGroup]
+		if !ok {
+			group = &pb.TaskGroupStatus{Name: alloc.TaskGroup}
+			groups[alloc.TaskGroup] = group
+		}
+
 		if alloc.ClientStatus == "running" {
-			runningInstances++
+			group.RunningInstances++
+			totals.RunningInstances++
+		}
+		if isHealthy(alloc) {
+			group.HealthyInstances++
+			totals.HealthyInstances++
+		}
+		if isFailed(alloc) {
+			group.FailedInstances++
+			totals.FailedInstances++
 		}
 
 		allocationStatus := &pb.AllocationStatus{
@@ -140,29 +752,179 @@ func (s *ApplicationService) GetApplicationStatus(ctx context.Context, req *pb.S
 			CreateTime:    alloc.CreateTime,
 			ModifyTime:    alloc.ModifyTime,
 			TaskStates:    taskStates,
+			TaskGroup:     alloc.TaskGroup,
 		}
 		allocationStatuses = append(allocationStatuses, allocationStatus)
 	}
 
-	desiredInstances := int32(0)
-
-	if len(job.TaskGroups) > 0 {
-		desiredInstances = int32(*job.TaskGroups[0].Count)
+	taskGroups := make([]*pb.TaskGroupStatus, 0, len(groups))
+	for _, group := range groups {
+		taskGroups = append(taskGroups, group)
 	}
+	sort.Slice(taskGroups, func(i, j int) bool {
+		return taskGroups[i].Name < taskGroups[j].Name
+	})
 
-	return &pb.StatusResponse{
+	name := s.jobName(jobID)
+	resp := &pb.StatusResponse{
 		DeploymentId:     req.DeploymentId,
+		JobId:            jobID,
+		Application:      name.Application,
+		Tenant:           name.Tenant,
 		JobStatus:        *job.Status,
 		JobType:          *job.Type,
-		DesiredInstances: desiredInstances,
-		RunningInstances: runningInstances,
+		DesiredInstances: totals.DesiredInstances,
+		RunningInstances: totals.RunningInstances,
+		HealthyInstances: totals.HealthyInstances,
+		FailedInstances:  totals.FailedInstances,
 		Allocations:      allocationStatuses,
+		TaskGroups:       taskGroups,
+		Rollout:          s.rolloutProgress(jobID),
+		History:          s.rolloutHistory(jobID),
+		Geo:              s.geoStatus(jobID),
+		Traffic:          s.trafficSplit(client, jobID),
 		Message:          "Application status retrieved successfully",
+	}
+	s.statuses.put(jobID, resp)
+	return resp, nil
+}
+
+// statusHandler serves GetApplicationStatus as JSON, the namespace is a query parameter
+func (s *ApplicationService) statusHandler(w http.ResponseWriter, r *http.Request) {
+	req := &pb.StatusRequest{
+		DeploymentId: r.PathValue("name"),
+		Namespace:    r.URL.Query().Get("namespace"),
+	}
+	if err := checkRequestNamespace(r.Context(), s.registry, pb.ControlPlane_GetApplicationStatus_FullMethodName, req); err != nil {
+		writeStatusError(w, err)
+		return
+	}
+	resp, _ := s.GetApplicationStatus(r.Context(), req)
+	if resp.JobId == "" {
+		http.Error(w, resp.Message, http.StatusBadGateway)
+		return
+	}
+	writeProtoJSON(w, resp)
+}
+
+// isHealthy reports whether an allocation passed its deployment health checks.
+// Allocations placed outside of a deployment are healthy as long as they run.
+func isHealthy(alloc *nmd.AllocationListStub) bool {
+	if alloc.DeploymentStatus != nil && alloc.DeploymentStatus.Healthy != nil {
+		return *alloc.DeploymentStatus.Healthy && alloc.ClientStatus == "running"
+	}
+	return alloc.ClientStatus == "running"
+}
+
+// isFailed reports whether an allocation failed and has not been replaced yet
+func isFailed(alloc *nmd.AllocationListStub) bool {
+	if alloc.NextAllocation != "" {
+		return false
+	}
+	return alloc.ClientStatus == "failed" || alloc.ClientStatus == "lost"
+}
+
+// ScaleApplication changes the instance count of an application's task group in place.
+func (s *ApplicationService) ScaleApplication(ctx context.Context, req *pb.ScaleRequest) (*pb.ScaleResponse, error) {
+	if req.Count < 0 {
+		return &pb.ScaleResponse{
+			Success: false,
+			Message: "count cannot be negative",
+		}, nil
+	}
+	if s.orhClient == nil {
+		return s.scaleOrchestrator(ctx, req)
+	}
+
+	jobID, err := s.resolveJobID(req.DeploymentId)
+	group := req.TaskGroup
+	var client *nomad.NomadClient
+	var job *nmd.Job
+	var allocations []*nmd.AllocationListStub
+	if err == nil {
+		client, err = s.nomadFor(jobID)
+	}
+	if err == nil {
+		job, allocations, err = client.GetJobStatus(jobID)
+	}
+	if err == nil {
+		group, err = client.ScaleJob(jobID, req.TaskGroup, int(req.Count))
+	}
+	if err != nil {
+		return &pb.ScaleResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to scale application: %v", err),
+			TaskGroup: group,
+		}, nil
+	}
+
+	resp := &pb.ScaleResponse{
+		Success:      true,
+		Message:      fmt.Sprintf("Task group %s scaled to %d", group, req.Count),
+		TaskGroup:    group,
+		DesiredCount: req.Count,
+	}
+	for _, taskGroup := range job.TaskGroups {
+		if *taskGroup.Name == group && taskGroup.Count != nil {
+			resp.PreviousCount = int32(*taskGroup.Count)
+		}
+	}
+	for _, alloc := range allocations {
+		if alloc.TaskGroup == group && alloc.ClientStatus == "running" {
+			resp.RunningCount++
+		}
+	}
+	if resp.PreviousCount != req.Count {
+		resp.Message = fmt.Sprintf("Task group %s scaled from %d to %d, %d instances running", group, resp.PreviousCount, req.Count, resp.RunningCount)
+	}
+
+	s.publish(events.ApplicationScaled, jobID, lifecycleEvent{TaskGroup: group, Count: &req.Count})
+	return resp, nil
+}
+
+// RollbackApplication reverts an application to an earlier version of its job, or deploys
+// the spec of an earlier revision again.
+func (s *ApplicationService) RollbackApplication(ctx context.Context, req *pb.RollbackRequest) (*pb.RollbackResponse, error) {
+	if req.Revision > 0 {
+		return s.rollbackToRevision(ctx, req), nil
+	}
+	if s.orhClient == nil && s.orchestrator != nil {
+		return &pb.RollbackResponse{
+			Message: fmt.Sprintf("Rolling back to a job version requires the Nomad orchestrator, the controller runs on %s, roll back to a revision instead", s.orchestrator),
+		}, nil
+	}
+
+	jobID, err := s.resolveJobID(req.DeploymentId)
+	var version uint64
+	var evalID string
+	var client *nomad.NomadClient
+	if err == nil {
+		client, err = s.nomadFor(jobID)
+	}
+	if err == nil {
+		version, evalID, err = client.RevertJob(jobID, req.Version)
+	}
+	if err != nil {
+		return &pb.RollbackResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to roll back application: %v", err),
+		}, nil
+	}
+
+	s.publish(events.ApplicationRolledBack, jobID, lifecycleEvent{Version: &version, EvalID: evalID})
+	return &pb.RollbackResponse{
+		Success: true,
+		Message: fmt.Sprintf("Application rolled back to version %d", version),
+		Version: version,
+		EvalId:  evalID,
 	}, nil
 }
 
 // HealthCheck performs a health check on the service
 func (s *ApplicationService) HealthCheck(ctx context.Context, req *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
+	if s.orhClient == nil && s.orchestrator != nil {
+		return s.orchestratorHealth(), nil
+	}
 	status := pb.HealthStatus_SERVING
 	message := "Service is healthy"
 
@@ -177,9 +939,21 @@ func (s *ApplicationService) HealthCheck(ctx context.Context, req *pb.HealthChec
 		message = "Nomad client not initialized"
 	}
 
-	return &pb.HealthCheckResponse{
+	resp := &pb.HealthCheckResponse{
 		Status:    status,
 		Message:   message,
 		Timestamp: time.Now().Unix(),
-	}, nil
+		Mode:      nomad.ModeNormal,
+	}
+	// under pressure the controller keeps serving, with less
+	if s.orhClient != nil {
+		pressure := s.orhClient.Pressure()
+		resp.Mode = pressure.Mode
+		resp.NomadLatencyMs = pressure.Latency.Milliseconds()
+		resp.ModeSince = unixOrZero(pressure.Since)
+		if pressure.Mode != nomad.ModeNormal && status == pb.HealthStatus_SERVING {
+			resp.Message = fmt.Sprintf("Service is %s, Nomad API latency averages %s", pressure.Mode, pressure.Latency.Round(time.Millisecond))
+		}
+	}
+	return resp, nil
 }
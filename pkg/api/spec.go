@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// Limits of a deployment spec, larger specs are rejected with a clear error instead
+// of failing somewhere between the controller, Nomad and the container runtime.
+const (
+	// MaxSpecSize is the largest serialized spec accepted, also through ApplySpec
+	MaxSpecSize = 16 << 20
+	// a single environment variable, Linux refuses to exec with longer strings (MAX_ARG_STRLEN)
+	maxEnvValueSize = 128 << 10
+	// all environment variables, they share ARG_MAX with the command line
+	maxEnvSize = 1 << 20
+)
+
+// validateSpecSize checks the spec against the size limits
+func validateSpecSize(req *pb.DeployRequest) error {
+	if size := proto.Size(req); size > MaxSpecSize {
+		return fmt.Errorf("spec is %d bytes, the limit is %d bytes", size, MaxSpecSize)
+	}
+
+	total := 0
+	for key, value := range req.Env {
+		if len(key)+len(value)+1 > maxEnvValueSize {
+			return fmt.Errorf("environment variable %s is %d bytes, the limit is %d bytes", key, len(key)+len(value)+1, maxEnvValueSize)
+		}
+		total += len(key) + len(value) + 1
+	}
+	if total > maxEnvSize {
+		return fmt.Errorf("environment variables take %d bytes, the limit is %d bytes", total, maxEnvSize)
+	}
+
+	return nil
+}
+
+// ApplySpec deploys a spec uploaded in chunks, for specs exceeding the gRPC message limit.
+func (s *ApplicationService) ApplySpec(stream grpc.ClientStreamingServer[pb.SpecChunk, pb.DeployResponse]) error {
+	var spec bytes.Buffer
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if chunk.TotalSize > MaxSpecSize || spec.Len()+len(chunk.Data) > MaxSpecSize {
+			size := max(chunk.TotalSize, int64(spec.Len()+len(chunk.Data)))
+			return stream.SendAndClose(&pb.DeployResponse{
+				Status:  "FAILED",
+				Message: fmt.Sprintf("Invalid deployment spec: spec is at least %d bytes, the limit is %d bytes", size, MaxSpecSize),
+			})
+		}
+		spec.Write(chunk.Data)
+	}
+
+	req := &pb.DeployRequest{}
+	if err := proto.Unmarshal(spec.Bytes(), req); err != nil {
+		return stream.SendAndClose(&pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Invalid deployment spec: %v", err),
+		})
+	}
+
+	resp, err := s.DeployApplication(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(resp)
+}
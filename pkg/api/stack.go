@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+const defaultStackHealthTimeout = 5 * time.Minute
+
+// Stack application statuses
+const (
+	stackHealthy   = "HEALTHY"
+	stackSubmitted = "SUBMITTED"
+	stackFailed    = "FAILED"
+	stackSkipped   = "SKIPPED"
+)
+
+// stackStages orders the applications of a stack into stages, applications only
+// depend on applications of earlier stages.
+func stackStages(apps []*pb.StackApplication) ([][]*pb.StackApplication, error) {
+	byName := make(map[string]*pb.StackApplication)
+	for _, app := range apps {
+		if app.Spec == nil || app.Spec.Name == "" {
+			return nil, fmt.Errorf("every stack application needs a spec with a name")
+		}
+		if _, ok := byName[app.Spec.Name]; ok {
+			return nil, fmt.Errorf("application %s is declared twice", app.Spec.Name)
+		}
+		byName[app.Spec.Name] = app
+	}
+
+	pending := make(map[string]int)
+	dependents := make(map[string][]string)
+	for _, app := range apps {
+		for _, dep := range app.DependsOn {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("application %s depends on %s which is not part of the stack", app.Spec.Name, dep)
+			}
+			dependents[dep] = append(dependents[dep], app.Spec.Name)
+		}
+		pending[app.Spec.Name] = len(app.DependsOn)
+	}
+
+	var stages [][]*pb.StackApplication
+	placed := 0
+	for placed < len(apps) {
+		var ready []string
+		for name, count := range pending {
+			if count == 0 {
+				ready = append(ready, name)
+			}
+		}
+		if len(ready) == 0 {
+			return nil, fmt.Errorf("stack dependencies contain a cycle")
+		}
+		sort.Strings(ready)
+
+		stage := make([]*pb.StackApplication, 0, len(ready))
+		for _, name := range ready {
+			delete(pending, name)
+			for _, dependent := range dependents[name] {
+				pending[dependent]--
+			}
+			stage = append(stage, byName[name])
+		}
+
+		stages = append(stages, stage)
+		placed += len(stage)
+	}
+
+	return stages, nil
+}
+
+// DeployStack deploys the applications of a stack stage by stage, waiting for each
+// stage to be healthy before starting the applications depending on it.
+func (s *ApplicationService) DeployStack(ctx context.Context, req *pb.DeployStackRequest) (*pb.DeployStackResponse, error) {
+	stages, err := stackStages(req.Applications)
+	if err != nil {
+		return &pb.DeployStackResponse{
+			Name:    req.Name,
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Invalid stack: %v", err),
+		}, nil
+	}
+
+	healthTimeout := defaultStackHealthTimeout
+	if req.HealthTimeoutSeconds > 0 {
+		healthTimeout = time.Duration(req.HealthTimeoutSeconds) * time.Second
+	}
+
+	var results []*pb.StackApplicationResult
+	stopped := false
+
+	for i, stage := range stages {
+		stageResults := make([]*pb.StackApplicationResult, len(stage))
+
+		if stopped {
+			for j, app := range stage {
+				stageResults[j] = &pb.StackApplicationResult{
+					Name:    app.Spec.Name,
+					Stage:   int32(i + 1),
+					Status:  stackSkipped,
+					Message: "Skipped because an earlier stage failed",
+				}
+			}
+			results = append(results, stageResults...)
+			continue
+		}
+
+		stageCtx, cancel := context.WithTimeout(ctx, healthTimeout)
+		var wg sync.WaitGroup
+		for j, app := range stage {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// the stack's dependencies are recorded like declared ones
+				spec := proto.Clone(app.Spec).(*pb.DeployRequest)
+				spec.DependsOn = append(spec.DependsOn, app.DependsOn...)
+
+				stageResults[j] = s.deployStackApplication(stageCtx, spec)
+				stageResults[j].Stage = int32(i + 1)
+			}()
+		}
+		wg.Wait()
+		cancel()
+
+		for _, result := range stageResults {
+			if result.Status == stackFailed && !req.ContinueOnError {
+				stopped = true
+			}
+		}
+		results = append(results, stageResults...)
+	}
+
+	succeeded, failed := 0, 0
+	for _, result := range results {
+		switch result.Status {
+		case stackHealthy, stackSubmitted:
+			succeeded++
+		case stackFailed:
+			failed++
+		}
+	}
+
+	resp := &pb.DeployStackResponse{
+		Name:         req.Name,
+		Applications: results,
+	}
+
+	switch {
+	case succeeded == len(results):
+		resp.Status = "SUCCEEDED"
+		resp.Message = fmt.Sprintf("Stack deployed in %d stages", len(stages))
+	case succeeded == 0:
+		resp.Status = "FAILED"
+		resp.Message = "No application of the stack was deployed"
+	default:
+		resp.Status = "PARTIAL"
+		resp.Message = fmt.Sprintf("%d of %d applications deployed, %d failed", succeeded, len(results), failed)
+	}
+
+	return resp, nil
+}
+
+func (s *ApplicationService) deployStackApplication(ctx context.Context, spec *pb.DeployRequest) *pb.StackApplicationResult {
+	result := &pb.StackApplicationResult{Name: spec.Name}
+
+	resp, err := s.DeployApplication(ctx, spec)
+	if err != nil || resp.Status == "FAILED" {
+		result.Status = stackFailed
+		result.Message = resp.GetMessage()
+		if err != nil {
+			result.Message = err.Error()
+		}
+		return result
+	}
+
+	// only services have deployments with health checks to wait for
+	if spec.Type != pb.DeploymentType_DEPLOYMENT_TYPE_UNSPECIFIED && spec.Type != pb.DeploymentType_DEPLOYMENT_TYPE_SERVICE {
+		result.Status = stackSubmitted
+		result.Message = resp.Message
+		return result
+	}
+
+	if err := s.orhClient.WaitForDeployment(ctx, resp.JobId); err != nil {
+		result.Status = stackFailed
+		result.Message = fmt.Sprintf("Application did not become healthy: %v", err)
+		return result
+	}
+
+	result.Status = stackHealthy
+	result.Message = "Application deployed and healthy"
+	return result
+}
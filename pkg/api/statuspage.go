@@ -0,0 +1,287 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// Statuses of the applications on the status page
+const (
+	statusOperational = "operational"
+	statusDegraded    = "degraded"
+	statusOutage      = "outage"
+	statusPaused      = "paused"
+)
+
+// days the status page shows the uptime of
+const statusPageDays = 90
+
+// StatusPage is the public page showing the health and uptime of the applications with all
+// the labels of the selector
+type StatusPage struct {
+	Title    string
+	Selector map[string]string
+}
+
+// RunUptimeChecks checks the availability of the applications of the status page every
+// interval until the context is cancelled. With a replicated registry only the leader checks
+// them, every replica serves the page.
+func (s *ApplicationService) RunUptimeChecks(ctx context.Context, page StatusPage, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopUptime, interval, func() error { return s.checkUptime(page) })
+		if err != nil {
+			log.Printf("Uptime checks: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) checkUptime(page StatusPage) error {
+	entries, err := s.statusPageEntries(page)
+	if err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	var checks []store.UptimeCheck
+	s.reconciler.queue(loopUptime, len(entries))
+	for _, entry := range entries {
+		s.reconciler.next(loopUptime)
+		check, err := s.uptimeCheck(entry.JobID)
+		if err == nil {
+			checks = append(checks, check)
+		}
+		s.reconciler.result(loopUptime, entry.JobID, err)
+	}
+	if len(checks) == 0 {
+		return nil
+	}
+	return s.registry.RecordUptime(checks)
+}
+
+// uptimeCheck derives the status of an application from its health, it is up while an
+// instance is healthy
+func (s *ApplicationService) uptimeCheck(jobID string) (store.UptimeCheck, error) {
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return store.UptimeCheck{}, err
+	}
+	job, allocations, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return store.UptimeCheck{}, err
+	}
+	deployment, err := client.LatestDeployment(jobID)
+	if err != nil {
+		return store.UptimeCheck{}, err
+	}
+	health, reason := assessHealth(job, allocations, deployment)
+	healthy, _ := instanceCounts(job, allocations)
+
+	check := store.UptimeCheck{Application: jobID, Reason: reason, At: time.Now().UTC()}
+	switch {
+	case health == pb.ApplicationHealthStatus_APPLICATION_HEALTH_SUSPENDED:
+		check.Status = statusPaused
+	case health == pb.ApplicationHealthStatus_APPLICATION_HEALTH_HEALTHY:
+		check.Status, check.Up = statusOperational, true
+	case healthy > 0:
+		check.Status, check.Up = statusDegraded, true
+	default:
+		check.Status = statusOutage
+	}
+	return check, nil
+}
+
+// statusPageEntries are the search entries of the applications the status page shows
+func (s *ApplicationService) statusPageEntries(page StatusPage) ([]store.SearchEntry, error) {
+	entries, err := s.registry.SearchEntries()
+	if err != nil {
+		return nil, err
+	}
+	var selected []store.SearchEntry
+	for _, entry := range entries {
+		if matchLabels(entry.Labels, page.Selector) {
+			selected = append(selected, entry)
+		}
+	}
+	return selected, nil
+}
+
+// statusPageJSON is the status page as served at /status.json
+type statusPageJSON struct {
+	Title        string                `json:"title"`
+	Status       string                `json:"status"`
+	Applications []statusPageComponent `json:"applications"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+}
+
+type statusPageComponent struct {
+	Name      string          `json:"name"`
+	Status    string          `json:"status"`
+	Uptime    float64         `json:"uptime"` // percent of the checks of the last 90 days
+	CheckedAt time.Time       `json:"checked_at"`
+	Days      []statusPageDay `json:"days"`
+}
+
+type statusPageDay struct {
+	Date   string  `json:"date"`
+	Uptime float64 `json:"uptime"` // percent, -1 without checks
+}
+
+// statusPage reads the status of the applications from the registry, requests never reach
+// Nomad however often the public page is loaded
+func (s *ApplicationService) statusPage(page StatusPage) (statusPageJSON, error) {
+	result := statusPageJSON{Title: page.Title, Status: statusOperational}
+	entries, err := s.statusPageEntries(page)
+	if err != nil {
+		return result, err
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for _, entry := range entries {
+		uptime, err := s.registry.Uptime(entry.JobID)
+		if err != nil {
+			continue // not checked yet
+		}
+		component := statusPageComponent{
+			Name:      entry.Application,
+			Status:    uptime.Status,
+			Uptime:    -1,
+			CheckedAt: uptime.CheckedAt,
+		}
+
+		days := make(map[time.Time]store.UptimeDay, len(uptime.Days))
+		checks, up := 0, 0
+		for _, day := range uptime.Days {
+			days[day.Day] = day
+			checks += day.Checks
+			up += day.Up
+		}
+		if checks > 0 {
+			component.Uptime = 100 * float64(up) / float64(checks)
+		}
+		for i := statusPageDays - 1; i >= 0; i-- {
+			date := today.AddDate(0, 0, -i)
+			day := statusPageDay{Date: date.Format(time.DateOnly), Uptime: -1}
+			if recorded, ok := days[date]; ok && recorded.Checks > 0 {
+				day.Uptime = 100 * float64(recorded.Up) / float64(recorded.Checks)
+			}
+			component.Days = append(component.Days, day)
+		}
+
+		switch {
+		case uptime.Status == statusOutage:
+			result.Status = statusOutage
+		case uptime.Status == statusDegraded && result.Status == statusOperational:
+			result.Status = statusDegraded
+		}
+		if uptime.CheckedAt.After(result.UpdatedAt) {
+			result.UpdatedAt = uptime.CheckedAt
+		}
+		result.Applications = append(result.Applications, component)
+	}
+	return result, nil
+}
+
+// StatusPageHandler serves the status page, it is public and meant for a listener of its own:
+//
+//	GET /
+//	GET /status.json
+func (s *ApplicationService) StatusPageHandler(page StatusPage) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status.json", func(w http.ResponseWriter, r *http.Request) {
+		status, err := s.statusPage(page)
+		if err != nil {
+			http.Error(w, "status unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=30")
+		writeJSON(w, status)
+	})
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		status, err := s.statusPage(page)
+		if err != nil {
+			http.Error(w, "status unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "public, max-age=30")
+		if err := statusPageTemplate.Execute(w, status); err != nil {
+			log.Printf("Status page: %v", err)
+		}
+	})
+	return mux
+}
+
+var statusPageTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"headline": func(status string) string {
+		switch status {
+		case statusOutage:
+			return "Some systems are down"
+		case statusDegraded:
+			return "Some systems are degraded"
+		default:
+			return "All systems operational"
+		}
+	},
+	"dayClass": func(uptime float64) string {
+		switch {
+		case uptime < 0:
+			return "none"
+		case uptime >= 99.9:
+			return statusOperational
+		case uptime >= 95:
+			return statusDegraded
+		default:
+			return statusOutage
+		}
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="60">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 52rem; margin: 2rem auto; padding: 0 1rem; color: #1f2328; }
+.banner { padding: 1rem; border-radius: 6px; color: #fff; font-weight: 600; margin-bottom: 1.5rem; }
+.component { border: 1px solid #d0d7de; border-radius: 6px; padding: 1rem; margin-bottom: 1rem; }
+.header { display: flex; justify-content: space-between; margin-bottom: .5rem; }
+.bars { display: flex; gap: 2px; height: 2rem; }
+.bars span { flex: 1; border-radius: 2px; }
+.footer { display: flex; justify-content: space-between; font-size: .8rem; color: #656d76; margin-top: .25rem; }
+.operational { background: #2da44e; } .degraded { background: #d4a72c; } .outage { background: #cf222e; }
+.paused, .none { background: #d0d7de; }
+.text-operational { color: #2da44e; } .text-degraded { color: #9a6700; } .text-outage { color: #cf222e; } .text-paused { color: #656d76; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="banner {{.Status}}">{{headline .Status}}</div>
+{{range .Applications}}
+<div class="component">
+  <div class="header"><strong>{{.Name}}</strong><span class="text-{{.Status}}">{{.Status}}</span></div>
+  <div class="bars">{{range .Days}}<span class="{{dayClass .Uptime}}" title="{{.Date}}{{if ge .Uptime 0.0}}: {{printf "%.2f" .Uptime}}%{{else}}: no data{{end}}"></span>{{end}}</div>
+  <div class="footer"><span>90 days ago</span><span>{{if ge .Uptime 0.0}}{{printf "%.2f" .Uptime}}% uptime{{end}}</span><span>Today</span></div>
+</div>
+{{else}}
+<p>No applications are monitored yet.</p>
+{{end}}
+{{if not .UpdatedAt.IsZero}}<p class="footer">Updated {{.UpdatedAt.Format "Jan 2 15:04 MST"}}</p>{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,375 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// Phases of a traffic split, see pb.TrafficSplit
+const (
+	splitStarting          = "starting"           // the green job is rolled out, without traffic
+	splitShifting          = "shifting"           // the green job gets a growing share of the traffic
+	splitAwaitingPromotion = "awaiting_promotion" // the last step holds until PromoteDeployment
+	splitPromoting         = "promoting"          // the application's job is updated to the green version
+)
+
+var deployStrategies = map[pb.DeployStrategy]string{
+	pb.DeployStrategy_DEPLOY_STRATEGY_BLUE_GREEN:     nomad.StrategyBlueGreen,
+	pb.DeployStrategy_DEPLOY_STRATEGY_CANARY_TRAFFIC: nomad.StrategyCanaryTraffic,
+}
+
+// defaultWeights are the steps of the strategies whose spec has none: blue-green switches
+// the traffic at once, canary-traffic sends half of it at most before the promotion
+var defaultWeights = map[string][]int{
+	nomad.StrategyBlueGreen:     {100},
+	nomad.StrategyCanaryTraffic: {10, 25, 50},
+}
+
+// splitFromSpec returns the traffic split of a blue-green or canary-traffic spec, nil for a
+// rolling one
+func splitFromSpec(req *pb.DeployRequest) *nomad.TrafficSplit {
+	strategy, ok := deployStrategies[req.Strategy]
+	if !ok {
+		return nil
+	}
+	split := &nomad.TrafficSplit{
+		Strategy: strategy,
+		Weights:  defaultWeights[strategy],
+		Interval: nomad.DefaultSplitInterval,
+	}
+	if shift := req.TrafficShift; shift != nil {
+		if len(shift.Weights) > 0 {
+			split.Weights = nil
+			for _, weight := range shift.Weights {
+				split.Weights = append(split.Weights, int(weight))
+			}
+		}
+		if shift.StepIntervalSeconds != 0 {
+			split.Interval = time.Duration(shift.StepIntervalSeconds) * time.Second
+		}
+		split.Manual = shift.ManualPromotion
+	}
+	return split
+}
+
+// validateStrategy checks the deploy strategy of a spec, the traffic of the strategies
+// other than rolling is shifted by the routers of its Traefik host
+func validateStrategy(req *pb.DeployRequest) []error {
+	split := splitFromSpec(req)
+	if split == nil {
+		if req.TrafficShift != nil {
+			return []error{fmt.Errorf("traffic shift requires the blue-green or canary-traffic strategy")}
+		}
+		return nil
+	}
+
+	var errs []error
+	if err := split.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if req.Type != pb.DeploymentType_DEPLOYMENT_TYPE_UNSPECIFIED && req.Type != pb.DeploymentType_DEPLOYMENT_TYPE_SERVICE {
+		errs = append(errs, fmt.Errorf("the %s strategy requires a service", split.Strategy))
+	}
+	if req.Traefik == nil || !req.Traefik.Enable || req.Traefik.Host == "" {
+		errs = append(errs, fmt.Errorf("the %s strategy shifts the traffic of a Traefik host, traefik needs a host", split.Strategy))
+	}
+	if req.Geo != nil {
+		errs = append(errs, fmt.Errorf("the %s strategy cannot be combined with geo routing", split.Strategy))
+	}
+	for _, addOn := range req.Addons {
+		if nomad.GreenJobID(req.Name) == req.Name+"-"+addOn.Name {
+			errs = append(errs, fmt.Errorf("add-on name %s is the green job of the %s strategy", addOn.Name, split.Strategy))
+		}
+	}
+	return errs
+}
+
+// splitJob returns the green job of a blue-green or canary-traffic rollout of the job. On
+// the first deployment there is no traffic to shift and the job is deployed as it is.
+func (s *ApplicationService) splitJob(req *pb.DeployRequest, jobID string, job *nmd.Job, split *nomad.TrafficSplit) (*nmd.Job, error) {
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return nil, err
+	}
+	running, _, err := client.GetJobStatus(jobID)
+	if nomad.IsNotFound(err) || err == nil && running.Stop != nil && *running.Stop {
+		return job, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	split.Count = int(req.Replicas)
+	if len(job.TaskGroups) > 0 && job.TaskGroups[0].Count != nil {
+		split.Count = *job.TaskGroups[0].Count
+	}
+	count := split.Count
+	if split.Strategy == nomad.StrategyCanaryTraffic {
+		count = 1
+		if req.Update != nil && req.Update.Canary > 0 {
+			count = int(req.Update.Canary)
+		}
+	}
+	return split.GreenJob(job, running, count)
+}
+
+// shareVariable copies the variable of the application to the path of its green job, which
+// the workload identity of the green job reads
+func (s *ApplicationService) shareVariable(req *pb.DeployRequest, jobID, greenID string) error {
+	client, err := s.orhClient.InNamespace(req.Namespace)
+	if err != nil {
+		return err
+	}
+	items, err := client.VariableItems(nomad.JobVariablePath(jobID))
+	if err == nil && len(items) > 0 {
+		err = client.PutVariable(nomad.JobVariablePath(greenID), items)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy the variable of %s: %w", jobID, err)
+	}
+	return nil
+}
+
+// RunTrafficShifts advances the blue-green and canary-traffic rollouts every interval
+// until the context is cancelled: the green job gets the next share of the traffic once
+// healthy and the step held, and is promoted after the last step. With a replicated
+// registry only the leader advances them.
+func (s *ApplicationService) RunTrafficShifts(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.reconcile(loopTrafficShifts, interval, s.checkTrafficShifts)
+		if err != nil {
+			log.Printf("Traffic shifts: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApplicationService) checkTrafficShifts() error {
+	jobs, err := s.orhClient.ListJobs()
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	s.reconciler.queue(loopTrafficShifts, len(jobs))
+	for _, stub := range jobs {
+		s.reconciler.next(loopTrafficShifts)
+		jobID := stub.Meta[nomad.MetaGreenOf]
+		if jobID == "" || stub.Stop {
+			continue
+		}
+		err := s.advanceSplit(jobID)
+		s.reconciler.result(loopTrafficShifts, jobID, err)
+		if err != nil {
+			log.Printf("Traffic shifts: %s: %v", jobID, err)
+		}
+	}
+	return nil
+}
+
+// advanceSplit takes the traffic split of the application's green job one step further
+func (s *ApplicationService) advanceSplit(jobID string) error {
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return err
+	}
+	green, _, err := client.GetJobStatus(nomad.GreenJobID(jobID))
+	if err != nil {
+		return fmt.Errorf("failed to get the green job: %w", err)
+	}
+	split, ok := nomad.SplitOf(green)
+	if !ok {
+		return nil
+	}
+
+	if primary, _, err := client.GetJobStatus(jobID); err == nil && primary.Meta[nomad.MetaPromotedFrom] == nomad.PromotionOf(green) {
+		return s.finishPromotion(client, jobID, green, primary)
+	}
+
+	deployment, err := client.LatestDeployment(*green.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get the rollout of the green job: %w", err)
+	}
+	if deployment != nil {
+		switch deployment.Status {
+		case nmd.DeploymentStatusFailed, nmd.DeploymentStatusCancelled:
+			return s.abortSplit(client, jobID, fmt.Sprintf("the green job failed to roll out: %s", deployment.StatusDescription))
+		case nmd.DeploymentStatusSuccessful:
+		default:
+			return nil // the green job or the tags of the last step are rolled out
+		}
+	}
+
+	weight := nomad.GreenWeight(green)
+	if weight > 0 && time.Since(submittedAt(green)) < split.Interval {
+		return nil
+	}
+	if next, ok := split.Next(weight); ok {
+		nomad.SetGreenWeight(green, next)
+		if _, err := client.RegisterJob(green); err != nil {
+			return fmt.Errorf("failed to route %d%% of the traffic to the green job: %w", next, err)
+		}
+		log.Printf("Traffic shifts: %s routes %d%% of its traffic to %s", jobID, next, *green.ID)
+		return nil
+	}
+	if split.Manual {
+		return nil
+	}
+	_, err = s.promoteSplit(client, jobID, green)
+	return err
+}
+
+// promoteSplit updates the application's job to the version of its green job, the green
+// job keeps its share of the traffic until the job rolled out. It returns the evaluation.
+func (s *ApplicationService) promoteSplit(client *nomad.NomadClient, jobID string, green *nmd.Job) (string, error) {
+	job, err := nomad.PrimaryJob(green)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.RegisterJob(job)
+	if err != nil {
+		return "", fmt.Errorf("failed to promote the green job: %w", err)
+	}
+	log.Printf("Traffic shifts: %s promoted from %s", jobID, *green.ID)
+	return resp.EvalID, nil
+}
+
+// finishPromotion tears the green job down once the application's job rolled out its
+// version, or gives up the split when the rollout failed
+func (s *ApplicationService) finishPromotion(client *nomad.NomadClient, jobID string, green, primary *nmd.Job) error {
+	deployment, err := client.LatestDeployment(jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get the rollout of the promotion: %w", err)
+	}
+	if deployment == nil || primary.Version == nil || deployment.JobVersion != *primary.Version {
+		return nil
+	}
+	switch deployment.Status {
+	case nmd.DeploymentStatusSuccessful:
+	case nmd.DeploymentStatusFailed, nmd.DeploymentStatusCancelled:
+		return s.abortSplit(client, jobID, fmt.Sprintf("the promotion failed to roll out: %s", deployment.StatusDescription))
+	default:
+		return nil
+	}
+
+	if err := s.removeGreen(client, jobID); err != nil {
+		return err
+	}
+	image := ""
+	if groups := primary.TaskGroups; len(groups) > 0 && len(groups[0].Tasks) > 0 {
+		image, _ = groups[0].Tasks[0].Config["image"].(string)
+	}
+	s.publish(events.ApplicationPromoted, jobID, lifecycleEvent{
+		Version: primary.Version,
+		Image:   image,
+		Message: fmt.Sprintf("%s promoted, the green job was torn down", green.Meta[nomad.MetaSplitStrategy]),
+	})
+	return nil
+}
+
+// abortSplit tears the green job down, the application's job takes all of its traffic
+// again
+func (s *ApplicationService) abortSplit(client *nomad.NomadClient, jobID, reason string) error {
+	if err := s.removeGreen(client, jobID); err != nil {
+		return err
+	}
+	log.Printf("Traffic shifts: %s aborted: %s", jobID, reason)
+	s.publish(events.RolloutFailed, jobID, lifecycleEvent{Reverted: true, Message: reason})
+	return nil
+}
+
+// removeGreen deletes the green job of the application and its copy of the variable, if
+// any
+func (s *ApplicationService) removeGreen(client *nomad.NomadClient, jobID string) error {
+	greenID := nomad.GreenJobID(jobID)
+	green, _, err := client.GetJobStatus(greenID)
+	if nomad.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if green.Meta[nomad.MetaGreenOf] != jobID {
+		return nil
+	}
+	if err := client.DeleteJob(greenID); err != nil {
+		return fmt.Errorf("failed to delete the green job: %w", err)
+	}
+
+	namespace := ""
+	if green.Namespace != nil {
+		namespace = *green.Namespace
+	}
+	if variables, err := s.orhClient.InNamespace(namespace); err == nil {
+		if err := variables.DeleteVariable(nomad.JobVariablePath(greenID)); err != nil && !nomad.IsNotFound(err) {
+			log.Printf("Failed to delete the variable of %s: %v", greenID, err)
+		}
+	}
+	return nil
+}
+
+// trafficSplit reports where the blue-green or canary-traffic rollout of an application
+// stands, nil without one
+func (s *ApplicationService) trafficSplit(client *nomad.NomadClient, jobID string) *pb.TrafficSplit {
+	green, _, err := client.GetJobStatus(nomad.GreenJobID(jobID))
+	if err != nil {
+		return nil
+	}
+	split, ok := nomad.SplitOf(green)
+	if !ok || green.Meta[nomad.MetaGreenOf] != jobID {
+		return nil
+	}
+
+	status := &pb.TrafficSplit{
+		Strategy:   split.Strategy,
+		GreenJobId: *green.ID,
+		Weight:     int32(nomad.GreenWeight(green)),
+		Phase:      splitStarting,
+	}
+	for _, weight := range split.Weights {
+		status.Weights = append(status.Weights, int32(weight))
+	}
+	if groups := green.TaskGroups; len(groups) > 0 && len(groups[0].Tasks) > 0 {
+		status.Image, _ = groups[0].Tasks[0].Config["image"].(string)
+	}
+
+	_, more := split.Next(int(status.Weight))
+	switch {
+	case status.Weight == 0:
+	case more:
+		status.Phase = splitShifting
+		status.NextStepAt = submittedAt(green).Add(split.Interval).Unix()
+	case split.Manual:
+		status.Phase = splitAwaitingPromotion
+	default:
+		status.Phase = splitPromoting
+	}
+	if primary, _, err := client.GetJobStatus(jobID); err == nil && primary.Meta[nomad.MetaPromotedFrom] == nomad.PromotionOf(green) {
+		status.Phase, status.NextStepAt = splitPromoting, 0
+	}
+	return status
+}
+
+// submittedAt is when the version of a job was submitted, when its last step started for
+// a green job
+func submittedAt(job *nmd.Job) time.Time {
+	if job.SubmitTime == nil {
+		return time.Time{}
+	}
+	return time.Unix(0, *job.SubmitTime)
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+// TenantACL mints read-only Nomad tokens scoped to the namespaces of tenants, a nil policy
+// leaves the Nomad ACLs to the platform team
+type TenantACL struct {
+	TokenTTL time.Duration // the successor of a token is minted once a third of it is left
+}
+
+// IssueTenantNomadToken mints a read-only Nomad token of a tenant and revokes the
+// previous one.
+func (s *AdminService) IssueTenantNomadToken(ctx context.Context, req *pb.IssueTenantNomadTokenRequest) (*pb.IssueTenantNomadTokenResponse, error) {
+	if s.tenantACL == nil {
+		return &pb.IssueTenantNomadTokenResponse{
+			Message: "The controller does not manage Nomad ACLs, start it with -tenant-nomad-acl",
+		}, nil
+	}
+
+	tenant, err := s.registry.Tenant(req.Name)
+	if err != nil {
+		return &pb.IssueTenantNomadTokenResponse{
+			Message: fmt.Sprintf("Failed to find tenant: %v", err),
+		}, nil
+	}
+
+	previous := tenant.NomadAccess
+	secret, err := s.mintTenantToken(&tenant, req.ReadLogs)
+	if err == nil {
+		err = s.registry.UpdateTenant(tenant)
+	}
+	if err != nil {
+		return &pb.IssueTenantNomadTokenResponse{
+			Message: fmt.Sprintf("Failed to issue Nomad token: %v", err),
+		}, nil
+	}
+
+	message := fmt.Sprintf("Nomad token of %s issued", tenant.Name)
+	if previous != nil {
+		if err := s.orhClient.DeleteACLToken(previous.AccessorID); err != nil {
+			message = fmt.Sprintf("%s, but failed to revoke the previous token %s: %v", message, previous.AccessorID, err)
+		} else {
+			message = fmt.Sprintf("%s, previous token %s revoked", message, previous.AccessorID)
+		}
+	}
+
+	return &pb.IssueTenantNomadTokenResponse{
+		Success:    true,
+		Message:    message,
+		AccessorId: tenant.NomadAccess.AccessorID,
+		SecretId:   secret,
+		ExpiresAt:  tenant.NomadAccess.ExpiresAt.Unix(),
+	}, nil
+}
+
+// RunTenantTokenRotation mints the successors of tenant Nomad tokens about to expire every
+// interval until the context is cancelled. The previous token stays valid until it expires,
+// tenants read its successor from the token variable in the meantime. With a replicated
+// registry only the leader rotates them.
+func (s *AdminService) RunTenantTokenRotation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if leader, ok := s.registry.(interface{ IsLeader() bool }); !ok || leader.IsLeader() {
+			s.rotateTenantTokens()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *AdminService) rotateTenantTokens() {
+	tenants, err := s.registry.Tenants()
+	if err != nil {
+		log.Printf("Tenant token rotation: failed to list tenants: %v", err)
+		return
+	}
+
+	for _, tenant := range tenants {
+		access := tenant.NomadAccess
+		if access == nil || time.Until(access.ExpiresAt) > s.tenantACL.TokenTTL/3 {
+			continue
+		}
+
+		_, err := s.mintTenantToken(&tenant, access.ReadLogs)
+		if err == nil {
+			err = s.registry.UpdateTenant(tenant)
+		}
+		if err != nil {
+			log.Printf("Tenant token rotation: %s: %v", tenant.Name, err)
+			continue
+		}
+		log.Printf("Rotated the Nomad token of tenant %s, %s expires at %s", tenant.Name, tenant.NomadAccess.AccessorID, tenant.NomadAccess.ExpiresAt.Format(time.RFC3339))
+	}
+}
+
+// mintTenantToken updates the tenant's policy, mints a token of it and keeps the token in
+// the variable of the tenant's first namespace. It returns the secret of the token.
+func (s *AdminService) mintTenantToken(tenant *store.Tenant, readLogs bool) (string, error) {
+	policy := nomad.TenantPolicyName(tenant.Name)
+	rules := nomad.TenantPolicyRules(tenant.Namespaces, readLogs)
+	if err := s.orhClient.UpsertACLPolicy(policy, fmt.Sprintf("Read access of tenant %s", tenant.Name), rules); err != nil {
+		return "", fmt.Errorf("failed to write ACL policy %s: %w", policy, err)
+	}
+
+	token, err := s.orhClient.CreateACLToken("controlplane-tenant-"+tenant.Name, []string{policy}, s.tenantACL.TokenTTL)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token: %w", err)
+	}
+
+	expiresAt := time.Now().Add(s.tenantACL.TokenTTL)
+	if token.ExpirationTime != nil {
+		expiresAt = *token.ExpirationTime
+	}
+	err = s.orhClient.PutNamespaceVariable(tenant.Namespaces[0], nomad.TenantTokenVariable, map[string]string{
+		"accessor_id": token.AccessorID,
+		"secret_id":   token.SecretID,
+		"expires_at":  expiresAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		if err := s.orhClient.DeleteACLToken(token.AccessorID); err != nil {
+			log.Printf("Failed to revoke the unused token %s: %v", token.AccessorID, err)
+		}
+		return "", fmt.Errorf("failed to write variable %s: %w", nomad.TenantTokenVariable, err)
+	}
+
+	tenant.NomadAccess = &store.NomadAccess{
+		AccessorID: token.AccessorID,
+		ExpiresAt:  expiresAt,
+		ReadLogs:   readLogs,
+	}
+	return token.SecretID, nil
+}
@@ -0,0 +1,279 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// Types of the events of an application's timeline
+const (
+	timelineDeploy     = "deploy"
+	timelineRollout    = "rollout"
+	timelineScaling    = "scaling"
+	timelinePlacement  = "placement"
+	timelineHealth     = "health"
+	timelineRestart    = "restart"
+	timelineFailure    = "failure"
+	timelineReschedule = "reschedule"
+	timelineStop       = "stop"
+)
+
+var timelineTypes = []string{
+	timelineDeploy, timelineRollout, timelineScaling, timelinePlacement, timelineHealth,
+	timelineRestart, timelineFailure, timelineReschedule, timelineStop,
+}
+
+const (
+	defaultTimelinePeriod = 7 * 24 * time.Hour
+	defaultTimelineLimit  = 200
+)
+
+// failedTaskEvents are the task events telling why a task stopped working, a task which
+// terminated counts when it exited with an error
+var failedTaskEvents = []string{
+	nmd.TaskDriverFailure,
+	nmd.TaskSetupFailure,
+	nmd.TaskFailedValidation,
+	nmd.TaskArtifactDownloadFailed,
+	nmd.TaskNotRestarting,
+}
+
+// timelineEvent keeps the exact time of an event, events of the same second stay in order
+type timelineEvent struct {
+	at    time.Time
+	event *pb.TimelineEvent
+}
+
+// GetTimeline merges the rollouts, scalings and the lifecycle of the allocations of an
+// application into one history, oldest first. It reaches back as far as Nomad kept the
+// deployments and allocations, garbage collected ones are gone.
+func (s *ApplicationService) GetTimeline(ctx context.Context, req *pb.TimelineRequest) (*pb.TimelineResponse, error) {
+	for _, eventType := range req.Types {
+		if !slices.Contains(timelineTypes, eventType) {
+			return &pb.TimelineResponse{
+				Message: fmt.Sprintf("Unknown event type %q, expected one of %s", eventType, strings.Join(timelineTypes, ", ")),
+			}, nil
+		}
+	}
+
+	jobID, err := s.resolveJobID(req.Name)
+	var events []timelineEvent
+	if err == nil {
+		events, err = s.timeline(jobID)
+	}
+	if err != nil {
+		return &pb.TimelineResponse{
+			Message: fmt.Sprintf("Failed to build the timeline: %v", err),
+		}, nil
+	}
+
+	since := time.Now().Add(-defaultTimelinePeriod)
+	if req.Since > 0 {
+		since = time.Unix(req.Since, 0)
+	}
+	events = slices.DeleteFunc(events, func(e timelineEvent) bool {
+		switch {
+		case e.at.Before(since):
+			return true
+		case req.AllocationId != "" && (e.event.AllocationId == "" || !strings.HasPrefix(e.event.AllocationId, req.AllocationId)):
+			return true
+		case len(req.Types) > 0 && !slices.Contains(req.Types, e.event.Type):
+			return true
+		}
+		return false
+	})
+	slices.SortStableFunc(events, func(a, b timelineEvent) int { return a.at.Compare(b.at) })
+
+	limit := defaultTimelineLimit
+	if req.Limit > 0 {
+		limit = int(req.Limit)
+	}
+	resp := &pb.TimelineResponse{Success: true}
+	for _, e := range events[max(0, len(events)-limit):] {
+		resp.Events = append(resp.Events, e.event)
+	}
+	resp.Message = fmt.Sprintf("%d events since %s", len(resp.Events), since.UTC().Format(time.RFC3339))
+	return resp, nil
+}
+
+// timeline collects the events of a job from its deployments, scaling events and
+// allocations in no particular order
+func (s *ApplicationService) timeline(jobID string) ([]timelineEvent, error) {
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return nil, err
+	}
+	_, allocations, err := client.GetJobStatus(jobID)
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := client.Deployments(jobID)
+	if err != nil {
+		return nil, err
+	}
+	scalings, err := client.ScalingEvents(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []timelineEvent
+	add := func(at time.Time, event *pb.TimelineEvent) {
+		event.Time = at.Unix()
+		events = append(events, timelineEvent{at: at, event: event})
+	}
+
+	for _, deployment := range deployments {
+		add(time.Unix(0, deployment.CreateTime), &pb.TimelineEvent{
+			Type:         timelineDeploy,
+			Message:      fmt.Sprintf("Rollout of version %d started", deployment.JobVersion),
+			JobVersion:   deployment.JobVersion,
+			DeploymentId: deployment.ID,
+		})
+		rollout, finished := s.finishedRollout(jobID, deployment)
+		if !finished {
+			continue
+		}
+		message := fmt.Sprintf("Rollout of version %d %s", rollout.JobVersion, rollout.Status)
+		if rollout.Reason != "" {
+			message = fmt.Sprintf("%s: %s", message, rollout.Reason)
+		}
+		if rollout.Reverted {
+			message = fmt.Sprintf("%s, the job runs version %d again", message, rollout.RevertedTo)
+		}
+		add(rollout.FinishedAt, &pb.TimelineEvent{
+			Type:         timelineRollout,
+			Message:      message,
+			JobVersion:   rollout.JobVersion,
+			DeploymentId: rollout.ID,
+		})
+	}
+
+	for group, scalingEvents := range scalings {
+		for _, scaling := range scalingEvents {
+			message := fmt.Sprintf("Task group %s", group)
+			switch {
+			case scaling.Error:
+				message = fmt.Sprintf("%s failed to scale", message)
+			case scaling.Count != nil:
+				message = fmt.Sprintf("%s scaled from %d to %d", message, scaling.PreviousCount, *scaling.Count)
+			}
+			if scaling.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, scaling.Message)
+			}
+			add(time.Unix(0, int64(scaling.Time)), &pb.TimelineEvent{Type: timelineScaling, Message: message})
+		}
+	}
+
+	for _, alloc := range allocations {
+		allocEvent := func(eventType, format string, args ...any) *pb.TimelineEvent {
+			return &pb.TimelineEvent{
+				Type:         eventType,
+				Message:      fmt.Sprintf("Allocation %s ", shortID(alloc.ID)) + fmt.Sprintf(format, args...),
+				AllocationId: alloc.ID,
+				Node:         alloc.NodeName,
+				JobVersion:   alloc.JobVersion,
+			}
+		}
+
+		add(time.Unix(0, alloc.CreateTime), allocEvent(timelinePlacement, "placed on %s, version %d", alloc.NodeName, alloc.JobVersion))
+		// the tracker lists every reschedule of the chain, the last one replaced this allocation's predecessor
+		if tracker := alloc.RescheduleTracker; tracker != nil && len(tracker.Events) > 0 {
+			reschedule := tracker.Events[len(tracker.Events)-1]
+			add(time.Unix(0, reschedule.RescheduleTime), allocEvent(timelineReschedule, "replaces %s, rescheduled %d times", shortID(reschedule.PrevAllocID), len(tracker.Events)))
+		}
+		if status := alloc.DeploymentStatus; status != nil && status.Healthy != nil && !status.Timestamp.IsZero() {
+			health := "unhealthy"
+			if *status.Healthy {
+				health = "healthy"
+			}
+			add(status.Timestamp, allocEvent(timelineHealth, "is %s", health))
+		}
+
+		for task, state := range alloc.TaskStates {
+			for _, taskEvent := range state.Events {
+				var eventType string
+				switch {
+				case taskEvent.Type == nmd.TaskRestarting:
+					eventType = timelineRestart
+				case slices.Contains(failedTaskEvents, taskEvent.Type),
+					taskEvent.Type == nmd.TaskTerminated && cmp.Or(taskEvent.Details["exit_code"], "0") != "0":
+					eventType = timelineFailure
+				default:
+					continue
+				}
+				event := allocEvent(eventType, "task %s: %s", task, taskEvent.Type)
+				if taskEvent.DisplayMessage != "" {
+					event.Message = fmt.Sprintf("%s: %s", event.Message, taskEvent.DisplayMessage)
+				}
+				event.Task = task
+				add(time.Unix(0, taskEvent.Time), event)
+			}
+		}
+
+		switch alloc.ClientStatus {
+		case nmd.AllocClientStatusComplete, nmd.AllocClientStatusFailed, nmd.AllocClientStatusLost:
+			event := allocEvent(timelineStop, "%s", alloc.ClientStatus)
+			if description := cmp.Or(alloc.DesiredDescription, alloc.ClientDescription); description != "" {
+				event.Message = fmt.Sprintf("%s: %s", event.Message, description)
+			}
+			add(time.Unix(0, alloc.ModifyTime), event)
+		}
+	}
+	return events, nil
+}
+
+// timelineHandler serves GetTimeline as JSON, the query parameters are the fields of the
+// request, type may repeat
+func (s *ApplicationService) timelineHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &pb.TimelineRequest{
+		Name:         r.PathValue("name"),
+		AllocationId: query.Get("allocation_id"),
+		Types:        query["type"],
+		Namespace:    query.Get("namespace"),
+	}
+	if since := query.Get("since"); since != "" {
+		unix, err := strconv.ParseInt(since, 10, 64)
+		if err != nil {
+			http.Error(w, "since must be unix seconds", http.StatusBadRequest)
+			return
+		}
+		req.Since = unix
+	}
+	if limit := query.Get("limit"); limit != "" {
+		count, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			http.Error(w, "limit must be a number", http.StatusBadRequest)
+			return
+		}
+		req.Limit = int32(count)
+	}
+
+	if err := checkRequestNamespace(r.Context(), s.registry, pb.ControlPlane_GetTimeline_FullMethodName, req); err != nil {
+		writeStatusError(w, err)
+		return
+	}
+	resp, _ := s.GetTimeline(r.Context(), req)
+	if !resp.Success {
+		http.Error(w, resp.Message, http.StatusBadRequest)
+		return
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// immutableFields identify the application, they cannot be updated
+var immutableFields = []string{"name", "tenant", "namespace"}
+
+// UpdateApplication deploys the latest revision of an application with the fields of the
+// mask replaced by those of the spec, so callers change e.g. only the image without
+// submitting the full spec. The update goes through the checks of any deployment and is
+// recorded as the next revision.
+func (s *ApplicationService) UpdateApplication(ctx context.Context, req *pb.UpdateApplicationRequest) (*pb.DeployResponse, error) {
+	spec, err := s.updatedSpec(ctx, req)
+	if err != nil {
+		return &pb.DeployResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("Failed to update application: %v", err),
+		}, nil
+	}
+	return s.DeployApplication(ctx, spec)
+}
+
+// updatedSpec merges the update into the spec of the latest revision of the application
+func (s *ApplicationService) updatedSpec(ctx context.Context, req *pb.UpdateApplicationRequest) (*pb.DeployRequest, error) {
+	if req.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	paths := req.UpdateMask.GetPaths()
+	if len(paths) == 0 {
+		return nil, errors.New("update_mask names no fields, deploy the full spec with DeployApplication instead")
+	}
+	for _, path := range paths {
+		if slices.Contains(immutableFields, path) {
+			return nil, fmt.Errorf("%s cannot be updated, deploy a new application instead", path)
+		}
+	}
+
+	jobID, err := s.resolveJobID(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.registry.Revision(jobID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("no revision of %s to update, deploy its full spec: %w", jobID, err)
+	}
+	if req.BaseRevision > 0 && revision.Revision != int(req.BaseRevision) {
+		return nil, fmt.Errorf("%s is at revision %d, the update was made against revision %d", jobID, revision.Revision, req.BaseRevision)
+	}
+	spec, err := s.revisionSpec(ctx, revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revision %d of %s: %w", revision.Revision, jobID, err)
+	}
+
+	// plan the update only when the caller asks for it
+	spec.DryRun = false
+	update := req.Spec
+	if update == nil {
+		update = &pb.DeployRequest{}
+	}
+	for _, path := range paths {
+		if err := mergePath(spec.ProtoReflect(), update.ProtoReflect(), path); err != nil {
+			return nil, err
+		}
+	}
+	return spec, nil
+}
+
+// mergePath replaces the field of the path in dst by the one of src, clearing it when src
+// does not set it. The path names a field, or a field of a message field like
+// traefik.host, by their proto names.
+func mergePath(dst, src protoreflect.Message, path string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		field := dst.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return fmt.Errorf("invalid update_mask path %q: %s has no field %s", path, dst.Descriptor().Name(), name)
+		}
+		if i == len(names)-1 {
+			if src.Has(field) {
+				dst.Set(field, src.Get(field))
+			} else {
+				dst.Clear(field)
+			}
+			return nil
+		}
+		if field.Message() == nil || field.IsList() || field.IsMap() {
+			return fmt.Errorf("invalid update_mask path %q: %s is not a message", path, name)
+		}
+		dst, src = dst.Mutable(field).Message(), src.Get(field).Message()
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// Nomad's smallest task resources
+const (
+	minCPU      = 0.1 // cores, the job gets 10 MHz per 1 core requested
+	minMemoryMB = 10
+)
+
+// applicationName is usable as Nomad job ID, Consul service and Traefik router name
+var applicationName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// portLabel is usable in the NOMAD_PORT_<label> variable
+var portLabel = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// ValidateSpec checks a deployment spec without contacting the orchestrator and returns
+// every problem found. The server runs it before each deploy, `cli validate` runs it offline.
+func ValidateSpec(req *pb.DeployRequest) []error {
+	var errs []error
+
+	if err := validateSpecSize(req); err != nil {
+		errs = append(errs, err)
+	}
+
+	if req.Name == "" {
+		errs = append(errs, fmt.Errorf("name cannot be empty"))
+	} else if !applicationName.MatchString(req.Name) {
+		errs = append(errs, fmt.Errorf("name %q may only contain letters, digits, '-' and '_'", req.Name))
+	}
+	if req.Image == "" {
+		errs = append(errs, fmt.Errorf("image cannot be empty"))
+	}
+
+	if req.Replicas < 0 {
+		errs = append(errs, fmt.Errorf("replicas cannot be negative"))
+	}
+	if req.Cpu < minCPU {
+		errs = append(errs, fmt.Errorf("cpu must be at least %.1f cores", minCPU))
+	}
+	if req.Memory < minMemoryMB {
+		errs = append(errs, fmt.Errorf("memory must be at least %d MB", minMemoryMB))
+	}
+	if req.MemoryMax != 0 && req.MemoryMax < req.Memory {
+		errs = append(errs, fmt.Errorf("memory max cannot be below memory"))
+	}
+	if req.IdleTimeoutMinutes < 0 {
+		errs = append(errs, fmt.Errorf("idle timeout cannot be negative"))
+	}
+
+	if req.RolloutDeadlineSeconds < 0 {
+		errs = append(errs, fmt.Errorf("rollout deadline cannot be negative"))
+	}
+	if req.RevertOnDeadline && req.RolloutDeadlineSeconds == 0 {
+		errs = append(errs, fmt.Errorf("revert on deadline requires a rollout deadline"))
+	}
+
+	if req.ConcurrencyGroup != "" && !tenantName.MatchString(req.ConcurrencyGroup) {
+		errs = append(errs, fmt.Errorf("concurrency group %q must be a lowercase DNS label", req.ConcurrencyGroup))
+	}
+	if req.Placement != nil && req.Region != "" {
+		errs = append(errs, fmt.Errorf("placement chooses the region, it cannot be combined with a region"))
+	}
+	if req.Geo != nil {
+		if req.Region != "" || req.Placement != nil {
+			errs = append(errs, fmt.Errorf("geo routing deploys to its target regions, it cannot be combined with a region or placement"))
+		}
+		errs = append(errs, validateGeo(req.Geo)...)
+	}
+
+	if err := nomad.ValidateAnnotations(req.Annotations); err != nil {
+		errs = append(errs, err)
+	}
+
+	if slices.Contains(req.DependsOn, req.Name) {
+		errs = append(errs, fmt.Errorf("an application cannot depend on itself"))
+	}
+	if slices.Contains(req.DependsOn, "") {
+		errs = append(errs, fmt.Errorf("dependency names cannot be empty"))
+	}
+
+	if req.Autoscaling != nil {
+		errs = append(errs, validateAutoscaling(req)...)
+	}
+	errs = append(errs, validatePorts(req)...)
+	errs = append(errs, validateStrategy(req)...)
+
+	if backup := backupFromSpec(req); backup != nil {
+		if err := backup.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// covers the deployment type, Traefik rules, constraints, disk, volumes, add-ons and cron schedule
+	if _, err := jobTemplateFromSpec(req); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// validatePorts checks the ports of the spec, Traefik routes one speaking http
+func validatePorts(req *pb.DeployRequest) []error {
+	var errs []error
+	labels := make(map[string]bool)
+	hostPorts := make(map[int32]bool)
+	routable := false
+	for _, port := range req.Ports {
+		if !portLabel.MatchString(port.Label) {
+			errs = append(errs, fmt.Errorf("port label %q may only contain letters, digits and '_'", port.Label))
+		} else if labels[port.Label] {
+			errs = append(errs, fmt.Errorf("port %s is defined twice", port.Label))
+		}
+		labels[port.Label] = true
+
+		if port.ContainerPort < 0 || port.ContainerPort > 65535 || port.HostPort < 0 || port.HostPort > 65535 {
+			errs = append(errs, fmt.Errorf("port %s must be between 1 and 65535", port.Label))
+		}
+		if port.HostPort > 0 {
+			if hostPorts[port.HostPort] {
+				errs = append(errs, fmt.Errorf("host port %d is used by two ports", port.HostPort))
+			}
+			hostPorts[port.HostPort] = true
+		}
+
+		switch port.Protocol {
+		case "", "http":
+			routable = true
+		case "tcp", "udp":
+		default:
+			errs = append(errs, fmt.Errorf("protocol of port %s must be http, tcp or udp", port.Label))
+		}
+	}
+
+	if len(req.Ports) > 0 && !routable && req.Traefik != nil && req.Traefik.Enable {
+		errs = append(errs, fmt.Errorf("traefik routes an http port, the spec has only tcp and udp ports"))
+	}
+	return errs
+}
+
+// joinErrors formats the problems of a spec for a single response message
+func joinErrors(errs []error) string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	nmd "github.com/hashicorp/nomad/api"
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// CreateVolume provisions a CSI volume, the applications of its namespace claim it with the
+// volumes of their spec
+func (s *ApplicationService) CreateVolume(ctx context.Context, req *pb.CreateVolumeRequest) (*pb.CreateVolumeResponse, error) {
+	client, err := s.orhClient.InNamespace(req.Namespace)
+	if err != nil {
+		return &pb.CreateVolumeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create volume: %v", err),
+		}, nil
+	}
+	volume, err := client.CreateVolume(&nomad.Volume{
+		ID:             req.Id,
+		Name:           req.Name,
+		PluginID:       req.PluginId,
+		ExternalID:     req.ExternalId,
+		CapacityMinMB:  req.CapacityMinMb,
+		CapacityMaxMB:  req.CapacityMaxMb,
+		AccessMode:     req.AccessMode,
+		AttachmentMode: req.AttachmentMode,
+		FSType:         req.FsType,
+		Parameters:     req.Parameters,
+		Secrets:        req.Secrets,
+	})
+	if err != nil {
+		return &pb.CreateVolumeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create volume: %v", err),
+		}, nil
+	}
+
+	message := fmt.Sprintf("Volume %s created", volume.ID)
+	if req.ExternalId != "" {
+		message = fmt.Sprintf("Volume %s registered", volume.ID)
+	}
+
+	return &pb.CreateVolumeResponse{
+		Success:    true,
+		Message:    message,
+		VolumeId:   volume.ID,
+		ExternalId: volume.ExternalID,
+		CapacityMb: volume.Capacity >> 20,
+	}, nil
+}
+
+// ListVolumes lists the CSI volumes of a namespace with their claims
+func (s *ApplicationService) ListVolumes(ctx context.Context, req *pb.ListVolumesRequest) (*pb.ListVolumesResponse, error) {
+	client, err := s.orhClient.InNamespace(req.Namespace)
+	var stubs []*nmd.CSIVolumeListStub
+	if err == nil {
+		stubs, err = client.ListVolumes(req.PluginId)
+	}
+	if err != nil {
+		return &pb.ListVolumesResponse{
+			Message: fmt.Sprintf("Failed to list volumes: %v", err),
+		}, nil
+	}
+
+	volumes := make([]*pb.Volume, len(stubs))
+	for i, stub := range stubs {
+		volumes[i] = &pb.Volume{
+			Id:             stub.ID,
+			Name:           stub.Name,
+			PluginId:       stub.PluginID,
+			ExternalId:     stub.ExternalID,
+			AccessMode:     string(stub.AccessMode),
+			AttachmentMode: string(stub.AttachmentMode),
+			Schedulable:    stub.Schedulable,
+			Readers:        int32(stub.CurrentReaders),
+			Writers:        int32(stub.CurrentWriters),
+		}
+	}
+
+	return &pb.ListVolumesResponse{
+		Volumes: volumes,
+		Message: fmt.Sprintf("Found %d volumes", len(volumes)),
+	}, nil
+}
+
+// DeleteVolume removes a CSI volume which is not claimed by any application
+func (s *ApplicationService) DeleteVolume(ctx context.Context, req *pb.DeleteVolumeRequest) (*pb.DeleteVolumeResponse, error) {
+	client, err := s.orhClient.InNamespace(req.Namespace)
+	if err == nil {
+		err = client.DeleteVolume(req.Id, req.DeregisterOnly)
+	}
+	if err != nil {
+		return &pb.DeleteVolumeResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to delete volume: %v", err),
+		}, nil
+	}
+
+	message := fmt.Sprintf("Volume %s deleted", req.Id)
+	if req.DeregisterOnly {
+		message = fmt.Sprintf("Volume %s deregistered", req.Id)
+	}
+
+	return &pb.DeleteVolumeResponse{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+func volumeMountsFromSpec(req *pb.DeployRequest) []nomad.VolumeMount {
+	var volumes []nomad.VolumeMount
+	for _, volume := range req.Volumes {
+		volumes = append(volumes, nomad.VolumeMount{
+			VolumeID:       volume.VolumeId,
+			Destination:    volume.Destination,
+			ReadOnly:       volume.ReadOnly,
+			AccessMode:     volume.AccessMode,
+			AttachmentMode: volume.AttachmentMode,
+			PerAlloc:       volume.PerAlloc,
+		})
+	}
+	return volumes
+}
@@ -0,0 +1,319 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"google.golang.org/grpc"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+// how often WatchDeployment asks Nomad for the progress of the rollout
+const watchInterval = 2 * time.Second
+
+// Types of the events WatchDeployment streams
+const (
+	watchEvaluation      = "evaluation"
+	watchPlacementFailed = "placement_failed"
+	watchAllocation      = "allocation"
+	watchDeployment      = "deployment"
+	watchDone            = "done"
+)
+
+// WatchDeployment follows the evaluation a deploy returned and the Nomad deployment it
+// created, streaming placements, allocation health and the progress of the deployment
+// until the rollout succeeds or fails. The stream always ends with a done event telling
+// whether it succeeded, unless the caller cancels it.
+func (s *ApplicationService) WatchDeployment(req *pb.WatchDeploymentRequest, stream grpc.ServerStreamingServer[pb.DeploymentEvent]) error {
+	jobID, err := s.resolveJobID(req.Name)
+	if err != nil {
+		return stream.Send(doneEvent(false, "Failed to find application: %v", err))
+	}
+	client, err := s.nomadFor(jobID)
+	if err != nil {
+		return stream.Send(doneEvent(false, "Failed to find application: %v", err))
+	}
+
+	w := &deploymentWatch{stream: stream, client: client, allocations: make(map[string]string)}
+	w.estimate = func(elapsed time.Duration, percent float64) time.Duration {
+		return s.estimateRemaining(jobID, elapsed, percent)
+	}
+	deploymentID := ""
+	if req.EvaluationId != "" {
+		var done *pb.DeploymentEvent
+		deploymentID, done, err = w.followEvaluation(stream.Context(), req.EvaluationId)
+		if err != nil || done != nil {
+			return w.finish(done, err)
+		}
+	} else {
+		deployment, err := client.LatestDeployment(jobID)
+		if err != nil {
+			return stream.Send(doneEvent(false, "Failed to find the rollout: %v", err))
+		}
+		if deployment == nil {
+			return stream.Send(doneEvent(false, "%s has no rollout to watch", req.Name))
+		}
+		deploymentID = deployment.ID
+	}
+
+	deployment, err := w.followDeployment(stream.Context(), deploymentID)
+	if err != nil {
+		return w.finish(nil, err)
+	}
+
+	done := doneEvent(deployment.Status == nmd.DeploymentStatusSuccessful, "Rollout of version %d %s", deployment.JobVersion, deployment.Status)
+	if deployment.StatusDescription != "" {
+		done.Message = fmt.Sprintf("%s: %s", done.Message, deployment.StatusDescription)
+	}
+	if version, reverted := nomad.RevertedTo(deployment); reverted {
+		done.Message = fmt.Sprintf("%s, the job runs version %d again", done.Message, version)
+	}
+	done.DeploymentId, done.Status = deployment.ID, deployment.Status
+	return stream.Send(done)
+}
+
+// deploymentWatch streams what changed since the last poll of a rollout
+type deploymentWatch struct {
+	stream      grpc.ServerStreamingServer[pb.DeploymentEvent]
+	client      *nomad.NomadClient
+	allocations map[string]string // allocation to its last streamed state
+	progress    string            // the last streamed progress of the deployment
+
+	// estimate is the time the rollout has left, negative when unknown
+	estimate func(elapsed time.Duration, percent float64) time.Duration
+}
+
+// followEvaluation waits for the scheduler to process the evaluation. It returns the
+// deployment the evaluation created, or the done event when it has none to follow.
+func (w *deploymentWatch) followEvaluation(ctx context.Context, evalID string) (string, *pb.DeploymentEvent, error) {
+	status := ""
+	for {
+		eval, err := w.client.Evaluation(evalID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get evaluation %s: %w", evalID, err)
+		}
+
+		if eval.Status != status {
+			status = eval.Status
+			message := fmt.Sprintf("Evaluation %s", eval.Status)
+			if eval.StatusDescription != "" {
+				message = fmt.Sprintf("%s: %s", message, eval.StatusDescription)
+			}
+			err := w.send(&pb.DeploymentEvent{
+				Type:         watchEvaluation,
+				Message:      message,
+				EvaluationId: eval.ID,
+				DeploymentId: eval.DeploymentID,
+				Status:       eval.Status,
+			})
+			if err != nil {
+				return "", nil, err
+			}
+		}
+
+		switch eval.Status {
+		case nmd.EvalStatusComplete:
+			for _, group := range slices.Sorted(maps.Keys(eval.FailedTGAllocs)) {
+				err := w.send(&pb.DeploymentEvent{
+					Type:         watchPlacementFailed,
+					Message:      placementFailure(eval.FailedTGAllocs[group]),
+					EvaluationId: eval.ID,
+					TaskGroup:    group,
+				})
+				if err != nil {
+					return "", nil, err
+				}
+			}
+			switch {
+			case eval.DeploymentID != "":
+				return eval.DeploymentID, nil, nil
+			case len(eval.FailedTGAllocs) > 0:
+				return "", doneEvent(false, "The job could not be placed"), nil
+			default:
+				// batch jobs and updates Nomad places in place have no deployment
+				return "", doneEvent(true, "The job was placed without a rollout"), nil
+			}
+		case nmd.EvalStatusFailed, nmd.EvalStatusCancelled:
+			return "", doneEvent(false, "Evaluation %s: %s", eval.Status, eval.StatusDescription), nil
+		}
+
+		if err := sleep(ctx, watchInterval); err != nil {
+			return "", nil, err
+		}
+	}
+}
+
+// followDeployment streams the allocations of the deployment and its progress until it
+// finishes, and returns it as it finished
+func (w *deploymentWatch) followDeployment(ctx context.Context, deploymentID string) (*nmd.Deployment, error) {
+	for {
+		deployment, err := w.client.Deployment(deploymentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment %s: %w", deploymentID, err)
+		}
+		allocations, err := w.client.DeploymentAllocations(deploymentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the allocations of deployment %s: %w", deploymentID, err)
+		}
+
+		slices.SortFunc(allocations, func(a, b *nmd.AllocationListStub) int { return cmp.Compare(a.CreateIndex, b.CreateIndex) })
+		for _, alloc := range allocations {
+			if err := w.sendAllocation(deploymentID, alloc); err != nil {
+				return nil, err
+			}
+		}
+		if err := w.sendProgress(deployment); err != nil {
+			return nil, err
+		}
+
+		switch deployment.Status {
+		case nmd.DeploymentStatusSuccessful, nmd.DeploymentStatusFailed, nmd.DeploymentStatusCancelled:
+			return deployment, nil
+		}
+
+		if err := sleep(ctx, watchInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sendAllocation streams the allocation when it was placed, its client status changed or
+// the deployment decided its health
+func (w *deploymentWatch) sendAllocation(deploymentID string, alloc *nmd.AllocationListStub) error {
+	health := "pending"
+	if alloc.DeploymentStatus != nil && alloc.DeploymentStatus.Healthy != nil {
+		health = "unhealthy"
+		if *alloc.DeploymentStatus.Healthy {
+			health = "healthy"
+		}
+	}
+	state := alloc.ClientStatus + "/" + health
+	previous, seen := w.allocations[alloc.ID]
+	if previous == state {
+		return nil
+	}
+	w.allocations[alloc.ID] = state
+
+	var message string
+	switch {
+	case !seen:
+		message = fmt.Sprintf("Allocation %s placed on %s, %s", shortID(alloc.ID), alloc.NodeName, alloc.ClientStatus)
+	case health != "pending" && !strings.HasSuffix(previous, "/"+health):
+		message = fmt.Sprintf("Allocation %s is %s", shortID(alloc.ID), health)
+	default:
+		message = fmt.Sprintf("Allocation %s is %s", shortID(alloc.ID), alloc.ClientStatus)
+	}
+	if alloc.ClientStatus == "failed" || health == "unhealthy" {
+		if event := lastTaskEvent(alloc); event != "" {
+			message = fmt.Sprintf("%s: %s", message, event)
+		}
+	}
+
+	return w.send(&pb.DeploymentEvent{
+		Type:         watchAllocation,
+		Message:      message,
+		DeploymentId: deploymentID,
+		AllocationId: alloc.ID,
+		TaskGroup:    alloc.TaskGroup,
+		Status:       alloc.ClientStatus,
+	})
+}
+
+// sendProgress streams the deployment when its status or instance counts changed
+func (w *deploymentWatch) sendProgress(deployment *nmd.Deployment) error {
+	event := &pb.DeploymentEvent{
+		Type:         watchDeployment,
+		DeploymentId: deployment.ID,
+		Status:       deployment.Status,
+	}
+	for _, state := range deployment.TaskGroups {
+		event.DesiredInstances += int32(state.DesiredTotal)
+		event.PlacedInstances += int32(state.PlacedAllocs)
+		event.HealthyInstances += int32(state.HealthyAllocs)
+		event.UnhealthyInstances += int32(state.UnhealthyAllocs)
+	}
+	event.Message = fmt.Sprintf("Deployment %s %s: %d/%d healthy, %d placed, %d unhealthy", shortID(deployment.ID), deployment.Status,
+		event.HealthyInstances, event.DesiredInstances, event.PlacedInstances, event.UnhealthyInstances)
+
+	if event.Message == w.progress {
+		return nil
+	}
+	w.progress = event.Message
+
+	event.Percent = 100
+	if event.DesiredInstances > 0 {
+		event.Percent = float64(event.HealthyInstances) * 100 / float64(event.DesiredInstances)
+	}
+	switch deployment.Status {
+	case nmd.DeploymentStatusSuccessful, nmd.DeploymentStatusFailed, nmd.DeploymentStatusCancelled:
+	default:
+		event.EtaSeconds = -1
+		if remaining := w.estimate(time.Since(time.Unix(0, deployment.CreateTime)), event.Percent); remaining >= 0 {
+			event.EtaSeconds = int64(remaining.Seconds())
+		}
+	}
+	return w.send(event)
+}
+
+func (w *deploymentWatch) send(event *pb.DeploymentEvent) error {
+	event.Time = time.Now().Unix()
+	return w.stream.Send(event)
+}
+
+// finish ends the stream with the done event, or with a failed one telling what went
+// wrong. A cancelled watch ends without it.
+func (w *deploymentWatch) finish(done *pb.DeploymentEvent, err error) error {
+	if ctxErr := w.stream.Context().Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if err != nil {
+		done = doneEvent(false, "Failed to watch the rollout: %v", err)
+	}
+	return w.send(done)
+}
+
+func doneEvent(success bool, format string, args ...any) *pb.DeploymentEvent {
+	return &pb.DeploymentEvent{
+		Type:    watchDone,
+		Message: fmt.Sprintf(format, args...),
+		Time:    time.Now().Unix(),
+		Success: success,
+	}
+}
+
+// placementFailure explains why the scheduler found no node for allocations of a task group
+func placementFailure(metric *nmd.AllocationMetric) string {
+	reasons := []string{fmt.Sprintf("%d nodes evaluated, %d filtered, %d exhausted", metric.NodesEvaluated, metric.NodesFiltered, metric.NodesExhausted)}
+	for _, dimension := range slices.Sorted(maps.Keys(metric.DimensionExhausted)) {
+		reasons = append(reasons, fmt.Sprintf("%s exhausted on %d nodes", dimension, metric.DimensionExhausted[dimension]))
+	}
+	for _, constraint := range slices.Sorted(maps.Keys(metric.ConstraintFiltered)) {
+		reasons = append(reasons, fmt.Sprintf("constraint %s filtered %d nodes", constraint, metric.ConstraintFiltered[constraint]))
+	}
+	return fmt.Sprintf("%d allocations could not be placed: %s", metric.CoalescedFailures+1, strings.Join(reasons, ", "))
+}
+
+// sleep waits for the interval unless the context is cancelled first
+func sleep(ctx context.Context, interval time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(interval):
+		return nil
+	}
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,266 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+	"github.com/iuliansafta/control-plane/pkg/events"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+	"github.com/iuliansafta/control-plane/pkg/store"
+)
+
+const (
+	// WebsocketProtocol is the subprotocol of the websockets, browsers offer the token
+	// next to it as bearer.<base64url token> since they cannot set headers
+	WebsocketProtocol = "controlplane.v1"
+	tokenProtocol     = "bearer."
+
+	websocketPing  = 30 * time.Second
+	websocketWrite = 10 * time.Second
+)
+
+// WebsocketBridge serves the lifecycle events and WatchDeployment to browsers over
+// websockets, with the API tokens and scopes of the gRPC API:
+//
+//	GET /v1/ws/events?application=&tenant=&namespace=&type=
+//	GET /v1/ws/deployments/{name}?evaluation_id=&namespace=
+type WebsocketBridge struct {
+	Service *ApplicationService
+	Hub     *events.Hub    // receives the events the controller publishes
+	Auth    *Authenticator // every client is served without one
+	Origins []string       // origins of the pages allowed to connect besides the controller's own
+}
+
+// Handler serves the websockets
+func (b *WebsocketBridge) Handler() http.Handler {
+	upgrader := &websocket.Upgrader{
+		Subprotocols:     []string{WebsocketProtocol},
+		HandshakeTimeout: websocketWrite,
+		CheckOrigin:      b.checkOrigin,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/ws/events", func(w http.ResponseWriter, r *http.Request) {
+		viewer, ok := b.authorize(w, r)
+		if !ok {
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		b.streamEvents(conn, viewer, r.URL.Query())
+	})
+	mux.HandleFunc("GET /v1/ws/deployments/{name}", func(w http.ResponseWriter, r *http.Request) {
+		viewer, ok := b.authorize(w, r)
+		if !ok {
+			return
+		}
+		name, namespace := r.PathValue("name"), r.URL.Query().Get("namespace")
+		if err := b.checkApplication(viewer, name, namespace); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		b.streamDeployment(conn, &pb.WatchDeploymentRequest{
+			Name:         name,
+			EvaluationId: r.URL.Query().Get("evaluation_id"),
+			Namespace:    namespace,
+		})
+	})
+	return mux
+}
+
+// checkOrigin admits the pages of the controller's own origin, of the allowed origins and
+// clients which are no browsers
+func (b *WebsocketBridge) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || slices.Contains(b.Origins, "*") || slices.Contains(b.Origins, origin) {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// authorize checks the token of the request grants the read scope, like WatchDeployment.
+// The token is the Authorization header, or offered as a subprotocol by browsers.
+func (b *WebsocketBridge) authorize(w http.ResponseWriter, r *http.Request) (APIToken, bool) {
+	if b.Auth == nil {
+		return APIToken{}, true
+	}
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	for _, protocol := range websocket.Subprotocols(r) {
+		if encoded, ok := strings.CutPrefix(protocol, tokenProtocol); ok {
+			decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+			if err != nil {
+				http.Error(w, "the token subprotocol must be bearer.<base64url token>", http.StatusUnauthorized)
+				return APIToken{}, false
+			}
+			token = string(decoded)
+		}
+	}
+	if token == "" {
+		http.Error(w, "an API token is required", http.StatusUnauthorized)
+		return APIToken{}, false
+	}
+	viewer, err := b.Auth.check(token, pb.ControlPlane_WatchDeployment_FullMethodName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return APIToken{}, false
+	}
+	return viewer, true
+}
+
+// visible reports whether the token may see the application: the tokens of service
+// accounts only see their tenant's, the tokens scoped to namespaces only theirs
+func visible(viewer APIToken, name store.JobName) bool {
+	if viewer.Tenant != "" && name.Tenant != viewer.Tenant {
+		return false
+	}
+	return len(viewer.Namespaces) == 0 || slices.Contains(viewer.Namespaces, jobNamespace(name))
+}
+
+// checkApplication checks the application runs in the namespace and the token sees it
+func (b *WebsocketBridge) checkApplication(viewer APIToken, application, namespace string) error {
+	namespace = cmp.Or(namespace, nomad.DefaultNamespace)
+	found, err := applicationInNamespace(b.Service.registry, application, namespace)
+	if err != nil {
+		return err
+	}
+	if found {
+		jobID, err := b.Service.resolveJobID(application)
+		if err != nil {
+			return err
+		}
+		found = visible(viewer, b.Service.jobName(jobID))
+	}
+	if !found {
+		return fmt.Errorf("application %s not found in namespace %s", application, namespace)
+	}
+	return nil
+}
+
+// streamEvents writes the published events the token sees and the query selects, as
+// CloudEvents in JSON, until the client closes the websocket
+func (b *WebsocketBridge) streamEvents(conn *websocket.Conn, viewer APIToken, query url.Values) {
+	defer conn.Close()
+	subscription, unsubscribe := b.Hub.Subscribe()
+	defer unsubscribe()
+	closed := readUntilClosed(conn)
+
+	types := query["type"]
+	ping := time.NewTicker(websocketPing)
+	defer ping.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(websocketWrite)); err != nil {
+				return
+			}
+		case event, ok := <-subscription:
+			if !ok {
+				return
+			}
+			if len(types) > 0 && !slices.Contains(types, strings.TrimPrefix(event.Type, events.TypePrefix)) {
+				continue
+			}
+			name := b.Service.jobName(event.Subject)
+			if !visible(viewer, name) || !matchesQuery(name, query) {
+				continue
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(websocketWrite))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// matchesQuery reports whether the application matches the application, tenant and
+// namespace parameters of the query
+func matchesQuery(name store.JobName, query url.Values) bool {
+	if application := query.Get("application"); application != "" && application != name.Application && application != name.JobID {
+		return false
+	}
+	if tenant := query.Get("tenant"); tenant != "" && tenant != name.Tenant {
+		return false
+	}
+	if namespace := query.Get("namespace"); namespace != "" && namespace != allNamespaces && namespace != jobNamespace(name) {
+		return false
+	}
+	return true
+}
+
+// streamDeployment runs WatchDeployment with the websocket as its stream, the events are
+// written in the JSON mapping of DeploymentEvent
+func (b *WebsocketBridge) streamDeployment(conn *websocket.Conn, req *pb.WatchDeploymentRequest) {
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-readUntilClosed(conn)
+		cancel()
+	}()
+
+	err := b.Service.WatchDeployment(req, &websocketStream{ctx: ctx, conn: conn})
+	message := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.Printf("Websocket: watching %s failed: %v", req.Name, err)
+		message = websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error())
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(websocketWrite))
+}
+
+// readUntilClosed reads the websocket, which answers the pings and close frames of the
+// client, the channel is closed once the connection is
+func readUntilClosed(conn *websocket.Conn) <-chan struct{} {
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+	return closed
+}
+
+// websocketStream is the server stream of WatchDeployment on a websocket, which only sends
+// and reads its context
+type websocketStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	conn *websocket.Conn
+}
+
+func (s *websocketStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *websocketStream) Send(event *pb.DeploymentEvent) error {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_ = s.conn.SetWriteDeadline(time.Now().Add(websocketWrite))
+	return s.conn.WriteMessage(websocket.TextMessage, data)
+}
@@ -0,0 +1,112 @@
+package attest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Verifier checks the signature of an attestation of an image
+type Verifier interface {
+	// Verify checks that the attestation is signed for the image and returns who signed it
+	Verify(ctx context.Context, image string, attestation []byte) (string, error)
+}
+
+// Envelope is a DSSE envelope as produced by cosign attest and the SLSA generators
+type Envelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"` // base64 of an in-toto statement
+}
+
+// statement is the part of an in-toto statement naming what it attests
+type statement struct {
+	PredicateType string `json:"predicateType"`
+}
+
+// ParseEnvelope reads a DSSE envelope, the first line of JSON lines files like .intoto.jsonl
+func ParseEnvelope(attestation []byte) (Envelope, error) {
+	line, _, _ := bytes.Cut(bytes.TrimSpace(attestation), []byte("\n"))
+
+	var envelope Envelope
+	if err := json.Unmarshal(line, &envelope); err != nil || envelope.Payload == "" {
+		return Envelope{}, fmt.Errorf("not a DSSE envelope")
+	}
+	return envelope, nil
+}
+
+// PredicateType is the type of the attested statement, e.g. https://slsa.dev/provenance/v1
+func (e Envelope) PredicateType() (string, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return "", fmt.Errorf("invalid payload: %w", err)
+	}
+
+	var s statement
+	if err := json.Unmarshal(payload, &s); err != nil {
+		return "", fmt.Errorf("payload is not an in-toto statement: %w", err)
+	}
+	return s.PredicateType, nil
+}
+
+// Cosign verifies attestations with the cosign CLI. The attestation must be one of those
+// cosign verifies for the image at its registry, signed with the key or, keyless, by the
+// identity of the issuer.
+type Cosign struct {
+	Binary   string // defaults to cosign on the PATH
+	Key      string // public key file or KMS URI
+	Identity string // certificate identity of keyless signatures, e.g. the CI workflow
+	Issuer   string // OIDC issuer of keyless signatures
+}
+
+func (c *Cosign) Verify(ctx context.Context, image string, attestation []byte) (string, error) {
+	envelope, err := ParseEnvelope(attestation)
+	if err != nil {
+		return "", err
+	}
+	predicateType, err := envelope.PredicateType()
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"verify-attestation", "--output", "json", "--type", predicateType}
+	signer := c.Key
+	if c.Key != "" {
+		args = append(args, "--key", c.Key)
+	} else {
+		args = append(args, "--certificate-identity", c.Identity, "--certificate-oidc-issuer", c.Issuer)
+		signer = c.Identity
+	}
+	args = append(args, image)
+
+	binary := c.Binary
+	if binary == "" {
+		binary = "cosign"
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("cosign: %v: %s", err, lastLine(stderr.String()))
+	}
+
+	// cosign prints every verified envelope of the image on its own line
+	scanner := bufio.NewScanner(&stdout)
+	scanner.Buffer(nil, 64<<20)
+	for scanner.Scan() {
+		var verified Envelope
+		if json.Unmarshal(scanner.Bytes(), &verified) == nil && verified.Payload == envelope.Payload {
+			return signer, nil
+		}
+	}
+	return "", fmt.Errorf("the attestation is not among the signed attestations of %s", image)
+}
+
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return lines[len(lines)-1]
+}
@@ -0,0 +1,49 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Group is the NATS queue group and Kafka consumer group of the controllers, each command
+// is delivered to one of them
+const Group = "control-plane"
+
+// Handler processes a command and returns the reply to it
+type Handler func(ctx context.Context, data []byte) []byte
+
+// Consumer receives commands from a message bus until the context is cancelled or the
+// connection fails
+type Consumer interface {
+	Consume(ctx context.Context, handle Handler) error
+	String() string
+}
+
+// ParseConsumer parses a command source URL:
+//
+//	nats://host:4222/<subject>       subscribes to the subject, replies go to the reply subject
+//	kafka+http(s)://host/<topic>     consumes the topic through a Kafka REST Proxy
+func ParseConsumer(source string) (Consumer, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid command source %q: %w", source, err)
+	}
+
+	name := strings.Trim(u.Path, "/")
+	switch u.Scheme {
+	case "nats":
+		if u.Host == "" || name == "" {
+			return nil, fmt.Errorf("NATS source %q must be nats://host:port/<subject>", source)
+		}
+		return &NATSConsumer{Address: u.Host, Subject: name}, nil
+	case "kafka+http", "kafka+https":
+		if u.Host == "" || name == "" {
+			return nil, fmt.Errorf("Kafka source %q must be kafka+http://<rest proxy>/<topic>", source)
+		}
+		return &KafkaConsumer{Proxy: strings.TrimPrefix(u.Scheme, "kafka+") + "://" + u.Host, Topic: name}, nil
+	default:
+		return nil, fmt.Errorf("command source %q must be a nats or kafka+http(s) URL", source)
+	}
+}
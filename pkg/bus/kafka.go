@@ -0,0 +1,134 @@
+package bus
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	kafkaContentType = "application/vnd.kafka.v2+json"
+	kafkaRecordsType = "application/vnd.kafka.json.v2+json"
+	kafkaPollTimeout = time.Second
+)
+
+// KafkaConsumer consumes a topic in the consumer group of the controllers through the v2
+// API of a Kafka REST Proxy. Offsets are committed after a command was handled, so a
+// command interrupted by a crash is delivered again.
+type KafkaConsumer struct {
+	Proxy string
+	Topic string
+}
+
+type kafkaRecord struct {
+	Topic     string          `json:"topic"`
+	Value     json.RawMessage `json:"value"`
+	Partition int             `json:"partition"`
+	Offset    int64           `json:"offset"`
+}
+
+func (k *KafkaConsumer) Consume(ctx context.Context, handle Handler) error {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+
+	var instance struct {
+		BaseURI string `json:"base_uri"`
+	}
+	err := k.do(ctx, http.MethodPost, k.Proxy+"/consumers/"+Group, map[string]string{
+		"name":               Group + "-" + hex.EncodeToString(suffix),
+		"format":             "json",
+		"auto.offset.reset":  "earliest",
+		"auto.commit.enable": "false",
+	}, &instance)
+	if err != nil {
+		return fmt.Errorf("failed to create consumer: %w", err)
+	}
+	defer func() {
+		// the context may be cancelled already
+		cleanup, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		k.do(cleanup, http.MethodDelete, instance.BaseURI, nil, nil)
+	}()
+
+	if err := k.do(ctx, http.MethodPost, instance.BaseURI+"/subscription", map[string][]string{"topics": {k.Topic}}, nil); err != nil {
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	for ctx.Err() == nil {
+		var records []kafkaRecord
+		if err := k.do(ctx, http.MethodGet, fmt.Sprintf("%s/records?timeout=%d", instance.BaseURI, kafkaPollTimeout.Milliseconds()), nil, &records); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to fetch records: %w", err)
+		}
+
+		for _, record := range records {
+			handle(ctx, record.Value)
+
+			offsets := map[string]any{"offsets": []map[string]any{{
+				"topic":     record.Topic,
+				"partition": record.Partition,
+				"offset":    record.Offset,
+			}}}
+			if err := k.do(ctx, http.MethodPost, instance.BaseURI+"/offsets", offsets, nil); err != nil {
+				return fmt.Errorf("failed to commit offset %d: %w", record.Offset, err)
+			}
+		}
+
+		if len(records) == 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(kafkaPollTimeout):
+			}
+		}
+	}
+	return nil
+}
+
+func (k *KafkaConsumer) do(ctx context.Context, method, url string, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", kafkaContentType)
+	if method == http.MethodGet {
+		req.Header.Set("Accept", kafkaRecordsType)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (k *KafkaConsumer) String() string {
+	return "kafka topic " + k.Topic
+}
@@ -0,0 +1,81 @@
+package bus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// NATSConsumer subscribes to a subject in the queue group of the controllers with the NATS
+// client protocol. Servers requiring authentication or TLS are not supported.
+type NATSConsumer struct {
+	Address string
+	Subject string
+}
+
+func (n *NATSConsumer) Consume(ctx context.Context, handle Handler) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.Address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// unblocks the read when the context is cancelled
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	reader := bufio.NewReader(conn)
+	if line, err := reader.ReadString('\n'); err != nil {
+		return err
+	} else if !strings.HasPrefix(line, "INFO") {
+		return fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line))
+	}
+
+	fmt.Fprintf(conn, "CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":\"control-plane\"}\r\nSUB %s %s 1\r\n", n.Subject, Group)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS: %s", line)
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <size>
+			fields := strings.Fields(line)
+			if len(fields) < 4 {
+				return fmt.Errorf("NATS: malformed %q", line)
+			}
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return fmt.Errorf("NATS: malformed %q", line)
+			}
+			payload := make([]byte, size+2) // with the trailing CRLF
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return err
+			}
+
+			reply := handle(ctx, payload[:size])
+			if len(fields) == 5 {
+				fmt.Fprintf(conn, "PUB %s %d\r\n%s\r\n", fields[3], len(reply), reply)
+			}
+		}
+	}
+}
+
+func (n *NATSConsumer) String() string {
+	return "nats subject " + n.Subject
+}
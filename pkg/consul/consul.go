@@ -0,0 +1,173 @@
+package consul
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Meta of the intentions the control plane manages, an intention is deleted once none of
+// the reasons it exists for remain
+const (
+	MetaApplication = "controlplane_application" // application whose egress rules allow the destination
+	MetaAllowedBy   = "controlplane_allowed_by"  // application whose service the source may call
+	MetaSource      = "controlplane_source"      // application of the source service, set with MetaAllowedBy
+)
+
+// Client talks to the Consul HTTP API, it reads the ACL token from CONSUL_HTTP_TOKEN
+type Client struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+// Intention allows or denies a Connect service to reach another
+type Intention struct {
+	SourceName      string
+	DestinationName string
+	Action          string
+	Description     string            `json:",omitempty"`
+	Meta            map[string]string `json:",omitempty"`
+}
+
+func NewClient(address string) *Client {
+	return &Client{
+		address: strings.TrimSuffix(address, "/"),
+		token:   os.Getenv("CONSUL_HTTP_TOKEN"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// AllowIntention lets the application's source service reach destination
+func (c *Client) AllowIntention(application, source, destination string) error {
+	description := fmt.Sprintf("Egress of %s, managed by the control plane", application)
+	return c.allow(source, destination, description, map[string]string{MetaApplication: application})
+}
+
+// AllowMeshIntention lets the service of sourceApp reach the service of destinationApp on
+// behalf of destinationApp
+func (c *Client) AllowMeshIntention(sourceApp, source, destinationApp, destination string) error {
+	description := fmt.Sprintf("%s may call %s, managed by the control plane", sourceApp, destinationApp)
+	return c.allow(source, destination, description, map[string]string{MetaAllowedBy: destinationApp, MetaSource: sourceApp})
+}
+
+// allow creates or updates an allow intention, keeping the meta of an existing one
+func (c *Client) allow(source, destination, description string, meta map[string]string) error {
+	var existing Intention
+	err := c.do(http.MethodGet, "/v1/connect/intentions/exact?"+exact(source, destination), nil, &existing)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return err
+	}
+	for key, value := range existing.Meta {
+		if _, ok := meta[key]; !ok {
+			meta[key] = value
+		}
+	}
+
+	return c.putIntention(source, destination, Intention{Action: "allow", Description: description, Meta: meta})
+}
+
+// ReleaseIntention drops the reasons of keys from a managed intention, and deletes it
+// when no other reason remains
+func (c *Client) ReleaseIntention(intention Intention, keys ...string) error {
+	meta := make(map[string]string, len(intention.Meta))
+	for key, value := range intention.Meta {
+		if !slices.Contains(keys, key) {
+			meta[key] = value
+		}
+	}
+	if meta[MetaAllowedBy] == "" {
+		delete(meta, MetaSource)
+	}
+	if meta[MetaApplication] == "" && meta[MetaAllowedBy] == "" {
+		return c.DeleteIntention(intention.SourceName, intention.DestinationName)
+	}
+
+	intention.Meta = meta
+	return c.putIntention(intention.SourceName, intention.DestinationName, intention)
+}
+
+func (c *Client) putIntention(source, destination string, intention Intention) error {
+	intention.SourceName, intention.DestinationName = "", ""
+	body, err := json.Marshal(intention)
+	if err != nil {
+		return err
+	}
+	return c.do(http.MethodPut, "/v1/connect/intentions/exact?"+exact(source, destination), body, nil)
+}
+
+// DenyIntention keeps source from reaching destination, "*" matches every service
+func (c *Client) DenyIntention(source, destination, description string) error {
+	return c.putIntention(source, destination, Intention{Action: "deny", Description: description})
+}
+
+// IntentionExists reports whether an intention from source to destination exists
+func (c *Client) IntentionExists(source, destination string) (bool, error) {
+	var intention Intention
+	err := c.do(http.MethodGet, "/v1/connect/intentions/exact?"+exact(source, destination), nil, &intention)
+	if err != nil && strings.Contains(err.Error(), "404") {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (c *Client) DeleteIntention(source, destination string) error {
+	return c.do(http.MethodDelete, "/v1/connect/intentions/exact?"+exact(source, destination), nil, nil)
+}
+
+// Intentions lists the intentions managed for the egress of an application
+func (c *Client) Intentions(application string) ([]Intention, error) {
+	return c.IntentionsBy(MetaApplication, application)
+}
+
+// IntentionsBy lists the managed intentions whose meta key has the value
+func (c *Client) IntentionsBy(key, value string) ([]Intention, error) {
+	var intentions []Intention
+	if err := c.do(http.MethodGet, "/v1/connect/intentions", nil, &intentions); err != nil {
+		return nil, err
+	}
+
+	var managed []Intention
+	for _, intention := range intentions {
+		if intention.Meta[key] == value {
+			managed = append(managed, intention)
+		}
+	}
+	return managed, nil
+}
+
+func exact(source, destination string) string {
+	return url.Values{"source": {source}, "destination": {destination}}.Encode()
+}
+
+func (c *Client) do(method, path string, body []byte, result any) error {
+	req, err := http.NewRequest(method, c.address+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("consul %s %s returned %s: %s", method, path, resp.Status, strings.TrimSpace(string(message)))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,63 @@
+package consul
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// kvPair is an entry of the KV store as listed by the HTTP API, its value base64 encoded
+type kvPair struct {
+	Key   string
+	Value string
+}
+
+// PutKey writes a value to the KV store
+func (c *Client) PutKey(key, value string) error {
+	return c.do(http.MethodPut, "/v1/kv/"+escapeKey(key), []byte(value), nil)
+}
+
+func (c *Client) DeleteKey(key string) error {
+	return c.do(http.MethodDelete, "/v1/kv/"+escapeKey(key), nil, nil)
+}
+
+// DeletePrefix deletes every key under the prefix
+func (c *Client) DeletePrefix(prefix string) error {
+	return c.do(http.MethodDelete, "/v1/kv/"+escapeKey(prefix)+"?recurse=true", nil, nil)
+}
+
+// ListKeys returns the values of every key under the prefix by their key relative to it,
+// keys of nested folders are skipped
+func (c *Client) ListKeys(prefix string) (map[string]string, error) {
+	var pairs []kvPair
+	err := c.do(http.MethodGet, "/v1/kv/"+escapeKey(prefix)+"?recurse=true", nil, &pairs)
+	if err != nil && strings.Contains(err.Error(), "404") {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key := strings.TrimPrefix(pair.Key, prefix)
+		if key == "" || strings.Contains(key, "/") {
+			continue
+		}
+		value, err := base64.StdEncoding.DecodeString(pair.Value)
+		if err != nil {
+			return nil, err
+		}
+		values[key] = string(value)
+	}
+	return values, nil
+}
+
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,64 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Record is a record set of a routing policy, the records of a name are told apart by their
+// set ID. Weighted records carry a weight, geo records a continent or country, "*" for the
+// users matching no location.
+type Record struct {
+	Name      string
+	Type      string // A, AAAA or CNAME
+	SetID     string
+	Values    []string
+	TTL       int64
+	Weight    *int64
+	Continent string
+	Country   string
+}
+
+// Key identifies the record set at the provider
+func (r Record) Key() string {
+	return r.Name + "/" + r.Type + "/" + r.SetID
+}
+
+// Provider changes the record sets of a zone
+type Provider interface {
+	// Apply deletes the records and upserts the others in one change
+	Apply(ctx context.Context, upsert, remove []Record) error
+	String() string
+}
+
+// ParseProvider parses a provider URL:
+//
+//	route53://<hosted zone id>    credentials are read from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+func ParseProvider(provider string) (Provider, error) {
+	u, err := url.Parse(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "route53":
+		if u.Host == "" {
+			return nil, fmt.Errorf("DNS provider %s needs the hosted zone ID, e.g. route53://Z0123456789", provider)
+		}
+		route53 := &Route53{
+			HostedZone:   strings.TrimPrefix(u.Host, "hostedzone/"),
+			AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		}
+		if route53.AccessKey == "" || route53.SecretKey == "" {
+			return nil, fmt.Errorf("DNS provider %s needs AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY", provider)
+		}
+		return route53, nil
+	default:
+		return nil, fmt.Errorf("unknown DNS provider %q, use route53://<hosted zone id>", provider)
+	}
+}
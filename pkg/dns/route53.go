@@ -0,0 +1,190 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	route53Endpoint = "https://route53.amazonaws.com"
+	route53Version  = "2013-04-01"
+	// Route53 is a global service signed for us-east-1
+	route53SigningRegion = "us-east-1"
+)
+
+// Route53 changes the record sets of a hosted zone through the Route 53 API, signed with
+// AWS Signature Version 4
+type Route53 struct {
+	HostedZone   string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+}
+
+type route53Request struct {
+	XMLName xml.Name        `xml:"ChangeResourceRecordSetsRequest"`
+	XMLNS   string          `xml:"xmlns,attr"`
+	Comment string          `xml:"ChangeBatch>Comment"`
+	Changes []route53Change `xml:"ChangeBatch>Changes>Change"`
+}
+
+type route53Change struct {
+	Action string           `xml:"Action"`
+	Set    route53RecordSet `xml:"ResourceRecordSet"`
+}
+
+// route53RecordSet lists its elements in the order of the API's schema
+type route53RecordSet struct {
+	Name        string               `xml:"Name"`
+	Type        string               `xml:"Type"`
+	SetID       string               `xml:"SetIdentifier,omitempty"`
+	Weight      *int64               `xml:"Weight,omitempty"`
+	GeoLocation *route53GeoLocation  `xml:"GeoLocation,omitempty"`
+	TTL         int64                `xml:"TTL"`
+	Records     []route53RecordValue `xml:"ResourceRecords>ResourceRecord"`
+}
+
+type route53GeoLocation struct {
+	Continent string `xml:"ContinentCode,omitempty"`
+	Country   string `xml:"CountryCode,omitempty"`
+}
+
+type route53RecordValue struct {
+	Value string `xml:"Value"`
+}
+
+type route53Error struct {
+	Code    string `xml:"Error>Code"`
+	Message string `xml:"Error>Message"`
+	// InvalidChangeBatch reports its messages without the Error element
+	Messages []string `xml:"Messages>Message"`
+}
+
+func (r *Route53) Apply(ctx context.Context, upsert, remove []Record) error {
+	if len(upsert) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	request := route53Request{
+		XMLNS:   "https://route53.amazonaws.com/doc/" + route53Version + "/",
+		Comment: "Managed by the control plane",
+	}
+	// deletes come first, a set ID may move to another routing policy
+	for _, record := range remove {
+		request.Changes = append(request.Changes, route53Change{Action: "DELETE", Set: toRoute53(record)})
+	}
+	for _, record := range upsert {
+		request.Changes = append(request.Changes, route53Change{Action: "UPSERT", Set: toRoute53(record)})
+	}
+
+	body, err := xml.Marshal(request)
+	if err != nil {
+		return err
+	}
+	body = append([]byte(xml.Header), body...)
+
+	path := "/" + route53Version + "/hostedzone/" + r.HostedZone + "/rrset/"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, route53Endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	r.sign(req, body, time.Now().UTC())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		var apiErr route53Error
+		if xml.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("route53: %s: %s", apiErr.Code, apiErr.Message)
+		}
+		if len(apiErr.Messages) > 0 {
+			return fmt.Errorf("route53: invalid change: %v", apiErr.Messages)
+		}
+		return fmt.Errorf("route53: %s", resp.Status)
+	}
+	return nil
+}
+
+func (r *Route53) String() string {
+	return "route53 hosted zone " + r.HostedZone
+}
+
+func toRoute53(record Record) route53RecordSet {
+	set := route53RecordSet{
+		Name:   record.Name,
+		Type:   record.Type,
+		SetID:  record.SetID,
+		Weight: record.Weight,
+		TTL:    record.TTL,
+	}
+	if record.Continent != "" || record.Country != "" {
+		set.GeoLocation = &route53GeoLocation{Continent: record.Continent, Country: record.Country}
+	}
+	for _, value := range record.Values {
+		set.Records = append(set.Records, route53RecordValue{Value: value})
+	}
+	return set
+}
+
+// sign adds the Signature Version 4 headers of the request
+func (r *Route53) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if r.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", r.SessionToken)
+	}
+
+	signedHeaders := "content-type;host;x-amz-date"
+	canonicalHeaders := "content-type:" + req.Header.Get("Content-Type") + "\n" +
+		"host:" + req.URL.Host + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	if r.SessionToken != "" {
+		signedHeaders += ";x-amz-security-token"
+		canonicalHeaders += "x-amz-security-token:" + r.SessionToken + "\n"
+	}
+
+	canonicalRequest := req.Method + "\n" +
+		req.URL.EscapedPath() + "\n" +
+		req.URL.RawQuery + "\n" +
+		canonicalHeaders + "\n" +
+		signedHeaders + "\n" +
+		hashHex(body)
+
+	scope := date + "/" + route53SigningRegion + "/route53/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hashHex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+r.SecretKey), date)
+	key = hmacSHA256(key, route53SigningRegion)
+	key = hmacSHA256(key, "route53")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+r.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
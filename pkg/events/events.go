@@ -0,0 +1,120 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Types of the lifecycle events, prefixed with TypePrefix
+const (
+	TypePrefix = "io.controlplane."
+
+	ApplicationDeployed     = "application.deployed"
+	ApplicationDeployFailed = "application.deploy_failed"
+	ApplicationScaled       = "application.scaled"
+	ApplicationDeleted      = "application.deleted"
+	ApplicationRolledBack   = "application.rolled_back"
+	ApplicationActionRun    = "application.action_run"
+	ApplicationPromoted     = "application.promoted"
+	RolloutFailed           = "rollout.failed"
+	RegionFailedOver        = "region.failed_over"
+	RegionRecovered         = "region.recovered"
+)
+
+const sendTimeout = 10 * time.Second
+
+// Event is a CloudEvent 1.0 in its structured JSON format
+type Event struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"` // the application
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            any       `json:"data"`
+}
+
+// Sink delivers events to a consumer
+type Sink interface {
+	Send(ctx context.Context, event Event) error
+	String() string
+}
+
+// Publisher sends lifecycle events to its sinks in the background, failures are only
+// logged. A nil Publisher drops the events.
+type Publisher struct {
+	Source string // e.g. //control-plane.example.com
+	Sinks  []Sink
+}
+
+// ParseSink parses a sink URL:
+//
+//	http(s)://host/path              posts every event to the URL
+//	nats://host:4222/<subject>       publishes to the subject
+//	kafka+http(s)://host/<topic>     produces to the topic through a Kafka REST Proxy
+func ParseSink(sink string) (Sink, error) {
+	u, err := url.Parse(sink)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event sink %q: %w", sink, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		return &HTTPSink{URL: sink}, nil
+	case "nats":
+		subject := strings.Trim(u.Path, "/")
+		if u.Host == "" || subject == "" {
+			return nil, fmt.Errorf("NATS sink %q must be nats://host:port/<subject>", sink)
+		}
+		return &NATSSink{Address: u.Host, Subject: subject}, nil
+	case "kafka+http", "kafka+https":
+		topic := strings.Trim(u.Path, "/")
+		if u.Host == "" || topic == "" {
+			return nil, fmt.Errorf("Kafka sink %q must be kafka+http://<rest proxy>/<topic>", sink)
+		}
+		return &KafkaSink{Proxy: strings.TrimPrefix(u.Scheme, "kafka+") + "://" + u.Host, Topic: topic}, nil
+	default:
+		return nil, fmt.Errorf("event sink %q must be an http(s), nats or kafka+http(s) URL", sink)
+	}
+}
+
+// Publish sends an event of the type about the subject to every sink
+func (p *Publisher) Publish(eventType, subject string, data any) {
+	if p == nil || len(p.Sinks) == 0 {
+		return
+	}
+
+	event := Event{
+		SpecVersion:     "1.0",
+		ID:              newID(),
+		Source:          p.Source,
+		Type:            TypePrefix + eventType,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+
+	for _, sink := range p.Sinks {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+			defer cancel()
+			if err := sink.Send(ctx, event); err != nil {
+				log.Printf("Events: failed to send %s of %s to %s: %v", event.Type, subject, sink, err)
+			}
+		}()
+	}
+}
+
+func newID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,56 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// hubBuffer is how many events a subscriber may lag behind before it misses events
+const hubBuffer = 64
+
+// Hub is a sink fanning the events out to in-process subscribers, e.g. the websockets of
+// browser dashboards. Subscribers too slow to keep up miss events instead of holding up
+// the others.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewHub creates a hub without subscribers
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[chan Event]struct{})}
+}
+
+// Subscribe returns the events sent from now on, until unsubscribe is called
+func (h *Hub) Subscribe() (events <-chan Event, unsubscribe func()) {
+	ch := make(chan Event, hubBuffer)
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[ch]; ok {
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (h *Hub) Send(ctx context.Context, event Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+func (h *Hub) String() string {
+	return "websocket subscribers"
+}
@@ -0,0 +1,269 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+)
+
+// Conditions of the alerting rules, evaluated against every application
+const (
+	ConditionNoHealthyInstances = "no_healthy_instances" // a service wanting instances has none healthy
+	ConditionDegraded           = "degraded"             // the application health is Degraded
+	ConditionRestartSpike       = "restart_spike"        // its restarts spiked, requires restart detection
+)
+
+var conditions = []string{ConditionNoHealthyInstances, ConditionDegraded, ConditionRestartSpike}
+
+const (
+	pagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+	opsgenieURL  = "https://api.opsgenie.com"
+)
+
+// IncidentConfig opens incidents at PagerDuty or Opsgenie while the applications of a
+// criticality tier match an alerting rule, and resolves them once they recover
+type IncidentConfig struct {
+	Rules       []AlertRule     `json:"rules"`
+	Tiers       map[string]Tier `json:"tiers"`
+	TierLabel   string          `json:"tier_label"`   // label of the spec naming the tier, tier by default
+	DefaultTier string          `json:"default_tier"` // of applications without the label, they page nobody when empty
+}
+
+// AlertRule matches the applications with all the labels, e.g. env: prod, once its condition
+// held for the duration
+type AlertRule struct {
+	Name      string            `json:"name"`
+	Condition string            `json:"condition"`
+	Labels    map[string]string `json:"labels"`
+	For       string            `json:"for"` // e.g. 2m, the first check it holds by default
+
+	duration time.Duration
+}
+
+// Duration is how long the condition must hold before an incident is opened
+func (r AlertRule) Duration() time.Duration {
+	return r.duration
+}
+
+// Tier is the provider paged for the applications of a criticality tier
+type Tier struct {
+	PagerDuty *PagerDuty `json:"pagerduty"`
+	Opsgenie  *Opsgenie  `json:"opsgenie"`
+	Rules     []string   `json:"rules"` // names of the rules opening incidents, every rule when empty
+}
+
+// Applies reports whether the rule opens incidents for the tier
+func (t Tier) Applies(rule string) bool {
+	return len(t.Rules) == 0 || slices.Contains(t.Rules, rule)
+}
+
+// Provider opens the incident of a tier, or resolves it
+func (t Tier) Provider() IncidentProvider {
+	if t.PagerDuty != nil {
+		return t.PagerDuty
+	}
+	return t.Opsgenie
+}
+
+// Incident of an application matching an alerting rule, its key stays the same until it
+// is resolved so providers deduplicate repeated triggers
+type Incident struct {
+	Key         string
+	Summary     string
+	Application string
+	Rule        string
+	Details     map[string]string
+	Links       map[string]string // label to URL
+}
+
+// IncidentProvider is an incident management service
+type IncidentProvider interface {
+	Trigger(ctx context.Context, incident Incident) error
+	Resolve(ctx context.Context, incident Incident) error
+}
+
+// LoadIncidents reads the incident config file, $VAR and ${VAR} are expanded from the
+// environment so the keys can stay out of it
+func LoadIncidents(file string) (*IncidentConfig, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &IncidentConfig{}
+	if err := json.Unmarshal([]byte(os.ExpandEnv(string(data))), config); err != nil {
+		return nil, fmt.Errorf("invalid incident config %s: %w", file, err)
+	}
+	if config.TierLabel == "" {
+		config.TierLabel = "tier"
+	}
+
+	var names []string
+	for i := range config.Rules {
+		rule := &config.Rules[i]
+		if rule.Name == "" || slices.Contains(names, rule.Name) {
+			return nil, fmt.Errorf("rule %d needs a unique name", i+1)
+		}
+		names = append(names, rule.Name)
+		if !slices.Contains(conditions, rule.Condition) {
+			return nil, fmt.Errorf("rule %s: unknown condition %q, expected one of %v", rule.Name, rule.Condition, conditions)
+		}
+		if rule.For != "" {
+			if rule.duration, err = time.ParseDuration(rule.For); err != nil {
+				return nil, fmt.Errorf("rule %s: invalid for: %w", rule.Name, err)
+			}
+		}
+	}
+	for name, tier := range config.Tiers {
+		if (tier.PagerDuty == nil) == (tier.Opsgenie == nil) {
+			return nil, fmt.Errorf("tier %s needs exactly one of pagerduty or opsgenie", name)
+		}
+		if tier.PagerDuty != nil && tier.PagerDuty.RoutingKey == "" {
+			return nil, fmt.Errorf("tier %s: pagerduty needs a routing_key", name)
+		}
+		if tier.PagerDuty != nil && !slices.Contains([]string{"", "critical", "error", "warning", "info"}, tier.PagerDuty.Severity) {
+			return nil, fmt.Errorf("tier %s: pagerduty severity %q must be critical, error, warning or info", name, tier.PagerDuty.Severity)
+		}
+		if tier.Opsgenie != nil && tier.Opsgenie.APIKey == "" {
+			return nil, fmt.Errorf("tier %s: opsgenie needs an api_key", name)
+		}
+		if tier.Opsgenie != nil && !slices.Contains([]string{"", "P1", "P2", "P3", "P4", "P5"}, tier.Opsgenie.Priority) {
+			return nil, fmt.Errorf("tier %s: opsgenie priority %q must be P1 to P5", name, tier.Opsgenie.Priority)
+		}
+		for _, rule := range tier.Rules {
+			if !slices.Contains(names, rule) {
+				return nil, fmt.Errorf("tier %s: unknown rule %s", name, rule)
+			}
+		}
+	}
+	if _, ok := config.Tiers[config.DefaultTier]; config.DefaultTier != "" && !ok {
+		return nil, fmt.Errorf("default tier %s is not defined", config.DefaultTier)
+	}
+	if len(config.Rules) == 0 || len(config.Tiers) == 0 {
+		return nil, errors.New("the incident config needs at least one rule and one tier")
+	}
+	return config, nil
+}
+
+// PagerDuty opens incidents through the Events API v2 of a service's integration
+type PagerDuty struct {
+	RoutingKey string `json:"routing_key"`
+	Severity   string `json:"severity"` // critical (default), error, warning or info
+	URL        string `json:"url"`      // of the Events API, for proxies
+}
+
+func (p *PagerDuty) Trigger(ctx context.Context, incident Incident) error {
+	severity := p.Severity
+	if severity == "" {
+		severity = "critical"
+	}
+	var links []map[string]string
+	for label, href := range incident.Links {
+		links = append(links, map[string]string{"href": href, "text": label})
+	}
+	return p.send(ctx, map[string]any{
+		"routing_key":  p.RoutingKey,
+		"event_action": "trigger",
+		"dedup_key":    incident.Key,
+		"payload": map[string]any{
+			"summary":        incident.Summary,
+			"source":         incident.Application,
+			"severity":       severity,
+			"component":      incident.Application,
+			"class":          incident.Rule,
+			"custom_details": incident.Details,
+		},
+		"links": links,
+	})
+}
+
+func (p *PagerDuty) Resolve(ctx context.Context, incident Incident) error {
+	return p.send(ctx, map[string]any{
+		"routing_key":  p.RoutingKey,
+		"event_action": "resolve",
+		"dedup_key":    incident.Key,
+	})
+}
+
+func (p *PagerDuty) send(ctx context.Context, event map[string]any) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	endpoint := p.URL
+	if endpoint == "" {
+		endpoint = pagerDutyURL
+	}
+	return post(ctx, endpoint, "application/json", data)
+}
+
+// Opsgenie opens alerts through the Alert API, aliased by the incident key
+type Opsgenie struct {
+	APIKey   string   `json:"api_key"`
+	Priority string   `json:"priority"` // P1 to P5, Opsgenie's default P3 when empty
+	Teams    []string `json:"teams"`    // responders of the alerts
+	URL      string   `json:"url"`      // e.g. https://api.eu.opsgenie.com, the US API by default
+}
+
+func (o *Opsgenie) Trigger(ctx context.Context, incident Incident) error {
+	message := incident.Summary
+	if len(message) > 130 {
+		message = message[:127] + "..."
+	}
+	description := incident.Summary
+	for label, link := range incident.Links {
+		description += fmt.Sprintf("\n%s: %s", label, link)
+	}
+	alert := map[string]any{
+		"message":     message,
+		"alias":       incident.Key,
+		"description": description,
+		"entity":      incident.Application,
+		"source":      "control-plane",
+		"tags":        []string{incident.Rule},
+		"details":     incident.Details,
+	}
+	if o.Priority != "" {
+		alert["priority"] = o.Priority
+	}
+	var responders []map[string]string
+	for _, team := range o.Teams {
+		responders = append(responders, map[string]string{"name": team, "type": "team"})
+	}
+	if len(responders) > 0 {
+		alert["responders"] = responders
+	}
+	return o.send(ctx, "/v2/alerts", alert)
+}
+
+func (o *Opsgenie) Resolve(ctx context.Context, incident Incident) error {
+	return o.send(ctx, "/v2/alerts/"+url.PathEscape(incident.Key)+"/close?identifierType=alias", map[string]any{
+		"source": "control-plane",
+		"note":   "The application recovered",
+	})
+}
+
+func (o *Opsgenie) send(ctx context.Context, path string, body map[string]any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	endpoint := o.URL
+	if endpoint == "" {
+		endpoint = opsgenieURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+o.APIKey)
+	return do(req)
+}
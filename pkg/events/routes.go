@@ -0,0 +1,297 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+)
+
+// Severities of the events, receivers only get the events of their minimum severity or above
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+var severityRank = map[string]int{SeverityInfo: 0, SeverityWarning: 1, SeverityCritical: 2}
+
+// severities of the event types above info, image.drift and application.restart_spike are
+// raised by the drift and restart detection
+var severities = map[string]string{
+	ApplicationDeployFailed:     SeverityCritical,
+	RolloutFailed:               SeverityCritical,
+	RegionFailedOver:            SeverityCritical,
+	"application.restart_spike": SeverityCritical,
+	ApplicationRolledBack:       SeverityWarning,
+	"image.drift":               SeverityWarning,
+}
+
+// Severity returns the severity of an event type, with or without TypePrefix
+func Severity(eventType string) string {
+	if severity, ok := severities[strings.TrimPrefix(eventType, TypePrefix)]; ok {
+		return severity
+	}
+	return SeverityInfo
+}
+
+// Router is a sink delivering the events of an application to the receivers of the team
+// owning it. Events no route matches go to the default receivers.
+type Router struct {
+	Routes  []Route     `json:"routes"`
+	Default []Receiver  `json:"default"`
+	SMTP    *SMTPConfig `json:"smtp"` // server sending the emails of the email receivers and digests
+	Digests []Digest    `json:"digests"`
+
+	// Owner returns the team owning an application and the labels of its spec, the
+	// controller sets it once the registry is open
+	Owner func(application string) (string, map[string]string) `json:"-"`
+}
+
+// Route matches the applications of a team with all the labels, an empty team matches any
+type Route struct {
+	Team      string            `json:"team"`
+	Labels    map[string]string `json:"labels"`
+	Receivers []Receiver        `json:"receivers"`
+}
+
+// Receiver is one of a Slack incoming webhook, a webhook receiving the CloudEvents or an
+// email address
+type Receiver struct {
+	Slack       string `json:"slack"`
+	Webhook     string `json:"webhook"`
+	Email       string `json:"email"`
+	MinSeverity string `json:"min_severity"` // info (default), warning or critical
+}
+
+// Digest emails the recipients a summary of the applications of a tenant once a day
+type Digest struct {
+	Tenant   string   `json:"tenant"` // every tenant when empty
+	To       []string `json:"to"`
+	Hour     int      `json:"hour"`      // of the day it is sent at, 0-23
+	TimeZone string   `json:"time_zone"` // of the hour, UTC when empty
+}
+
+// Location returns the time zone of the digest's hour
+func (d Digest) Location() (*time.Location, error) {
+	if d.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(d.TimeZone)
+}
+
+// SMTPConfig of the server sending emails, authenticating with PLAIN when a username is set
+type SMTPConfig struct {
+	Address  string `json:"address"` // host:port
+	From     string `json:"from"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoadRouter reads the routing config file, $VAR and ${VAR} are expanded from the
+// environment so secrets can stay out of it
+func LoadRouter(file string) (*Router, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	router := &Router{}
+	if err := json.Unmarshal([]byte(os.ExpandEnv(string(data))), router); err != nil {
+		return nil, fmt.Errorf("invalid notification routes %s: %w", file, err)
+	}
+
+	receivers := router.Default
+	for i, route := range router.Routes {
+		if len(route.Receivers) == 0 {
+			return nil, fmt.Errorf("route %d has no receivers", i+1)
+		}
+		receivers = append(receivers, route.Receivers...)
+	}
+	for _, receiver := range receivers {
+		set := 0
+		for _, target := range []string{receiver.Slack, receiver.Webhook, receiver.Email} {
+			if target != "" {
+				set++
+			}
+		}
+		if set != 1 {
+			return nil, errors.New("a receiver needs exactly one of slack, webhook or email")
+		}
+		if _, ok := severityRank[receiver.MinSeverity]; !ok && receiver.MinSeverity != "" {
+			return nil, fmt.Errorf("receiver %s: min_severity %q must be info, warning or critical", receiver, receiver.MinSeverity)
+		}
+		if receiver.Email != "" && (router.SMTP == nil || router.SMTP.Address == "" || router.SMTP.From == "") {
+			return nil, fmt.Errorf("receiver %s needs an smtp address and from", receiver)
+		}
+	}
+	for _, digest := range router.Digests {
+		if len(digest.To) == 0 {
+			return nil, fmt.Errorf("digest of tenant %q has no recipients", digest.Tenant)
+		}
+		if digest.Hour < 0 || digest.Hour > 23 {
+			return nil, fmt.Errorf("digest of tenant %q: hour %d must be 0-23", digest.Tenant, digest.Hour)
+		}
+		if _, err := digest.Location(); err != nil {
+			return nil, fmt.Errorf("digest of tenant %q: %w", digest.Tenant, err)
+		}
+		if router.SMTP == nil || router.SMTP.Address == "" || router.SMTP.From == "" {
+			return nil, fmt.Errorf("digest of tenant %q needs an smtp address and from", digest.Tenant)
+		}
+	}
+	return router, nil
+}
+
+// Send delivers the event to the receivers of the routes matching its application, above
+// their minimum severity
+func (r *Router) Send(ctx context.Context, event Event) error {
+	team, labels := "", map[string]string(nil)
+	if r.Owner != nil {
+		team, labels = r.Owner(event.Subject)
+	}
+
+	var receivers []Receiver
+	for _, route := range r.Routes {
+		if route.matches(team, labels) {
+			receivers = append(receivers, route.Receivers...)
+		}
+	}
+	if len(receivers) == 0 {
+		receivers = r.Default
+	}
+
+	severity := Severity(event.Type)
+	var errs []error
+	for _, receiver := range receivers {
+		if severityRank[severity] < severityRank[receiver.MinSeverity] {
+			continue
+		}
+		if err := r.deliver(ctx, receiver, event, team, severity); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", receiver, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Router) String() string {
+	return "notification routes"
+}
+
+func (route Route) matches(team string, labels map[string]string) bool {
+	if route.Team != "" && route.Team != team {
+		return false
+	}
+	for key, value := range route.Labels {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Router) deliver(ctx context.Context, receiver Receiver, event Event, team, severity string) error {
+	switch {
+	case receiver.Slack != "":
+		data, err := json.Marshal(map[string]string{"text": notificationText(event, team, severity)})
+		if err != nil {
+			return err
+		}
+		return post(ctx, receiver.Slack, "application/json", data)
+	case receiver.Webhook != "":
+		return (&HTTPSink{URL: receiver.Webhook}).Send(ctx, event)
+	default:
+		subject := fmt.Sprintf("[%s] %s: %s", severity, event.Subject, strings.TrimPrefix(event.Type, TypePrefix))
+		return r.SendEmail(ctx, []string{receiver.Email}, subject, notificationText(event, team, severity))
+	}
+}
+
+// SendEmail sends a plain text email through the smtp server, net/smtp does not take a
+// context so only its deadline is honored
+func (r *Router) SendEmail(ctx context.Context, to []string, subject, body string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", r.SMTP.Address)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	host, _, _ := net.SplitHostPort(r.SMTP.Address)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(nil); err != nil {
+			return err
+		}
+	}
+	if r.SMTP.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", r.SMTP.Username, r.SMTP.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(r.SMTP.From); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n",
+		r.SMTP.From, strings.Join(to, ", "), subject, body)
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// notificationText describes the event in a line, with the message or image of its data
+func notificationText(event Event, team, severity string) string {
+	text := fmt.Sprintf("[%s] %s: %s", severity, event.Subject, strings.TrimPrefix(event.Type, TypePrefix))
+	var data struct {
+		Image   string `json:"image"`
+		Region  string `json:"region"`
+		Message string `json:"message"`
+	}
+	if raw, err := json.Marshal(event.Data); err == nil {
+		json.Unmarshal(raw, &data)
+	}
+	if data.Region != "" {
+		text += " in " + data.Region
+	}
+	switch {
+	case data.Message != "":
+		text += " - " + data.Message
+	case data.Image != "":
+		text += " - " + data.Image
+	}
+	if team != "" {
+		text += fmt.Sprintf(" (team %s)", team)
+	}
+	return text
+}
+
+func (r Receiver) String() string {
+	switch {
+	case r.Slack != "":
+		return "slack webhook"
+	case r.Webhook != "":
+		return r.Webhook
+	default:
+		return r.Email
+	}
+}
@@ -0,0 +1,122 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// HTTPSink posts events in the structured content mode of the CloudEvents HTTP binding
+type HTTPSink struct {
+	URL string
+}
+
+func (h *HTTPSink) Send(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return post(ctx, h.URL, "application/cloudevents+json", data)
+}
+
+func (h *HTTPSink) String() string {
+	return h.URL
+}
+
+// KafkaSink produces events to a topic through the v2 API of a Kafka REST Proxy, keyed by
+// their subject so the events of an application stay in order
+type KafkaSink struct {
+	Proxy string
+	Topic string
+}
+
+func (k *KafkaSink) Send(ctx context.Context, event Event) error {
+	data, err := json.Marshal(map[string]any{
+		"records": []map[string]any{{"key": event.Subject, "value": event}},
+	})
+	if err != nil {
+		return err
+	}
+	return post(ctx, k.Proxy+"/topics/"+k.Topic, "application/vnd.kafka.json.v2+json", data)
+}
+
+func (k *KafkaSink) String() string {
+	return "kafka topic " + k.Topic
+}
+
+func post(ctx context.Context, url, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return do(req)
+}
+
+// do sends a request, a status of 300 or above is an error with the start of the body
+func do(req *http.Request) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// NATSSink publishes events to a subject with the NATS client protocol, connecting for
+// every event. Servers requiring authentication or TLS are not supported.
+type NATSSink struct {
+	Address string
+	Subject string
+}
+
+func (n *NATSSink) Send(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.Address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	reader := bufio.NewReader(conn)
+	// the server greets with INFO {...}
+	if line, err := reader.ReadString('\n'); err != nil {
+		return err
+	} else if !strings.HasPrefix(line, "INFO") {
+		return fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line))
+	}
+
+	// PING waits for the server to process the publish, it answers -ERR or PONG
+	fmt.Fprintf(conn, "CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":\"control-plane\"}\r\nPUB %s %d\r\n%s\r\nPING\r\n", n.Subject, len(data), data)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if line = strings.TrimSpace(line); line != "PONG" {
+		return fmt.Errorf("NATS: %s", line)
+	}
+	return nil
+}
+
+func (n *NATSSink) String() string {
+	return "nats subject " + n.Subject
+}
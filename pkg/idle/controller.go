@@ -0,0 +1,293 @@
+package idle
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"github.com/iuliansafta/control-plane/pkg/nomad"
+)
+
+type appState struct {
+	timeout     time.Duration
+	host        string
+	services    []string // Traefik services of the job, read from its spec
+	modifyIndex uint64   // of the job the services were read from
+	requests    float64
+	lastActive  time.Time
+	sleeping    bool
+	groups      map[string]int // task group to the count restored when the application wakes up
+	waking      chan struct{}  // closed once a wake up in progress finishes
+}
+
+// Controller scales HTTP applications to zero once Traefik saw no traffic for
+// them during their idle timeout, and scales them back up on request.
+type Controller struct {
+	nomadClient *nomad.NomadClient
+	metricsURL  string
+	interval    time.Duration
+	httpClient  *http.Client
+
+	mu   sync.Mutex
+	apps map[string]*appState // keyed by job ID
+}
+
+// NewController creates an idle controller reading traffic from Traefik's Prometheus endpoint
+func NewController(nomadClient *nomad.NomadClient, metricsURL string, interval time.Duration) *Controller {
+	return &Controller{
+		nomadClient: nomadClient,
+		metricsURL:  metricsURL,
+		interval:    interval,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		apps:        make(map[string]*appState),
+	}
+}
+
+// Run checks for idle applications until the context is cancelled
+func (c *Controller) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.reconcile(ctx); err != nil {
+			log.Printf("Idle controller: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Controller) reconcile(ctx context.Context) error {
+	jobs, err := c.nomadClient.ListJobs()
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	counts, err := scrapeRequestCounts(ctx, c.httpClient, c.metricsURL)
+	if err != nil {
+		return fmt.Errorf("failed to read traefik metrics: %w", err)
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool)
+
+	for _, job := range jobs {
+		timeout, err := time.ParseDuration(job.Meta[nomad.MetaIdleTimeout])
+		if err != nil || timeout <= 0 || job.Stop {
+			continue
+		}
+		seen[job.ID] = true
+
+		c.mu.Lock()
+		state, ok := c.apps[job.ID]
+		if !ok {
+			state = &appState{lastActive: now, groups: make(map[string]int)}
+			c.apps[job.ID] = state
+		}
+		state.timeout = timeout
+		state.host = job.Meta[nomad.MetaHost]
+		refresh := !ok || state.modifyIndex != job.JobModifyIndex
+		c.mu.Unlock()
+
+		// the services only change with the spec of the job
+		var services []string
+		if refresh {
+			spec, _, err := c.nomadClient.GetJobStatus(job.ID)
+			if err != nil {
+				log.Printf("Idle controller: failed to read the services of %s: %v", job.ID, err)
+				continue
+			}
+			services = jobServices(spec)
+		}
+
+		c.mu.Lock()
+		if refresh {
+			state.services, state.modifyIndex = services, job.JobModifyIndex
+		}
+		if requests := requestsFor(state.services, counts); requests != state.requests {
+			state.requests = requests
+			state.lastActive = now
+		}
+
+		idle := !state.sleeping && state.waking == nil && now.Sub(state.lastActive) >= state.timeout
+		c.mu.Unlock()
+
+		if idle {
+			c.sleep(job.ID, state)
+		}
+	}
+
+	c.mu.Lock()
+	for id := range c.apps {
+		if !seen[id] {
+			delete(c.apps, id)
+		}
+	}
+	c.mu.Unlock()
+
+	return nil
+}
+
+// sleep scales every task group of the job to zero, keeping the counts to restore
+func (c *Controller) sleep(jobID string, state *appState) {
+	job, _, err := c.nomadClient.GetJobStatus(jobID)
+	if err != nil || len(job.TaskGroups) == 0 {
+		log.Printf("Idle controller: failed to read %s before scaling to zero: %v", jobID, err)
+		return
+	}
+
+	groups := make(map[string]int, len(job.TaskGroups))
+	for _, group := range job.TaskGroups {
+		name := *group.Name
+		instances := *group.Count
+		if instances == 0 {
+			continue
+		}
+		if _, err := c.nomadClient.ScaleJob(jobID, name, 0); err != nil {
+			log.Printf("Idle controller: failed to scale %s of %s to zero: %v", name, jobID, err)
+			continue
+		}
+		groups[name] = instances
+	}
+	if len(groups) > 0 {
+		log.Printf("Idle controller: scaled %s to zero after %s without traffic", jobID, state.timeout)
+	}
+
+	c.mu.Lock()
+	state.sleeping = true
+	// a group already at zero, e.g. when the controller restarted, wakes up with one instance
+	for _, group := range job.TaskGroups {
+		name := *group.Name
+		if instances, ok := groups[name]; ok {
+			state.groups[name] = instances
+		} else if _, ok := state.groups[name]; !ok {
+			state.groups[name] = 1
+		}
+	}
+	c.mu.Unlock()
+}
+
+// Wake scales the application routed on host back up and waits until one of its
+// allocations is running. Concurrent calls for the same application share one wake up.
+func (c *Controller) Wake(ctx context.Context, host string) error {
+	c.mu.Lock()
+	jobID, state := c.lookup(host)
+	if state == nil {
+		c.mu.Unlock()
+		return fmt.Errorf("no idle application is routed on %s", host)
+	}
+	state.lastActive = time.Now()
+
+	if waking := state.waking; waking != nil {
+		c.mu.Unlock()
+		select {
+		case <-waking:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if !state.sleeping {
+		c.mu.Unlock()
+		return nil
+	}
+
+	waking := make(chan struct{})
+	state.waking = waking
+	groups := maps.Clone(state.groups)
+	c.mu.Unlock()
+
+	err := c.wake(ctx, jobID, groups)
+
+	c.mu.Lock()
+	state.waking = nil
+	if err == nil {
+		state.sleeping = false
+		state.lastActive = time.Now()
+	}
+	c.mu.Unlock()
+	close(waking)
+
+	return err
+}
+
+// wake scales every task group of the job back to its count and waits until one of its
+// allocations runs
+func (c *Controller) wake(ctx context.Context, jobID string, groups map[string]int) error {
+	for _, name := range slices.Sorted(maps.Keys(groups)) {
+		log.Printf("Idle controller: waking %s of %s up with %d instances", name, jobID, groups[name])
+		if _, err := c.nomadClient.ScaleJob(jobID, name, groups[name]); err != nil {
+			return fmt.Errorf("failed to scale %s of %s up: %w", name, jobID, err)
+		}
+	}
+
+	for {
+		_, allocations, err := c.nomadClient.GetJobStatus(jobID)
+		if err == nil {
+			for _, alloc := range allocations {
+				if alloc.ClientStatus == "running" && alloc.DesiredStatus == "run" {
+					return nil
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s did not start in time: %w", jobID, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (c *Controller) lookup(host string) (string, *appState) {
+	for id, state := range c.apps {
+		if strings.EqualFold(state.host, host) {
+			return id, state
+		}
+	}
+	return "", nil
+}
+
+// jobServices lists the Traefik services generated for a job: the Consul services of its
+// task groups, "<job>-<port label>", and the job itself, which names the service its
+// health check is set on
+func jobServices(job *nmd.Job) []string {
+	services := []string{*job.ID}
+	add := func(service *nmd.Service) {
+		if !slices.Contains(services, service.Name) {
+			services = append(services, service.Name)
+		}
+	}
+	for _, group := range job.TaskGroups {
+		for _, service := range group.Services {
+			add(service)
+		}
+		for _, task := range group.Tasks {
+			for _, service := range task.Services {
+				add(service)
+			}
+		}
+	}
+	return services
+}
+
+// requestsFor adds up the requests of the services, matched by their exact names
+func requestsFor(services []string, counts map[string]float64) float64 {
+	total := 0.0
+	for _, service := range services {
+		total += counts[service]
+	}
+	return total
+}
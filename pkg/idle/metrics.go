@@ -0,0 +1,74 @@
+package idle
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const requestsMetric = "traefik_service_requests_total"
+
+// scrapeRequestCounts reads Traefik's Prometheus endpoint and returns the total
+// number of requests served per Traefik service, without the @provider suffix.
+func scrapeRequestCounts(ctx context.Context, client *http.Client, metricsURL string) (map[string]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("traefik metrics returned %s", resp.Status)
+	}
+
+	counts := make(map[string]float64)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, requestsMetric+"{") {
+			continue
+		}
+
+		end := strings.LastIndex(line, "}")
+		if end < 0 {
+			continue
+		}
+
+		service := labelValue(line[len(requestsMetric)+1:end], "service")
+		if service == "" {
+			continue
+		}
+		service, _, _ = strings.Cut(service, "@")
+
+		fields := strings.Fields(line[end+1:])
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+
+		counts[service] += value
+	}
+
+	return counts, scanner.Err()
+}
+
+func labelValue(labels, name string) string {
+	for _, pair := range strings.Split(labels, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		if found && strings.TrimSpace(key) == name {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
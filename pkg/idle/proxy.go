@@ -0,0 +1,115 @@
+package idle
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// wakeHeader marks requests replayed by the proxy, Traefik routing them back to the
+// proxy means the woken application is not registered in Traefik yet.
+const wakeHeader = "X-Controlplane-Wake"
+
+// largest request body the proxy buffers while an application wakes up
+const maxBufferedBody = 10 << 20
+
+// WakeProxy receives requests for applications scaled to zero, through a low
+// priority catch-all Traefik router, wakes them up and replays the request.
+type WakeProxy struct {
+	controller *Controller
+	upstream   *url.URL
+	timeout    time.Duration
+	client     *http.Client
+}
+
+// NewWakeProxy creates a proxy replaying woken requests to the Traefik entrypoint at upstream
+func NewWakeProxy(controller *Controller, upstream *url.URL, timeout time.Duration) *WakeProxy {
+	return &WakeProxy{
+		controller: controller,
+		upstream:   upstream,
+		timeout:    timeout,
+		client: &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (p *WakeProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(wakeHeader) != "" {
+		w.Header().Set(wakeHeader, "waking")
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "application is starting", http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBufferedBody))
+	if err != nil {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), p.timeout)
+	defer cancel()
+
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	if err := p.controller.Wake(ctx, host); err != nil {
+		log.Printf("Wake proxy: %v", err)
+		w.Header().Set("Retry-After", "5")
+		http.Error(w, "application is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	for {
+		resp, err := p.replay(ctx, r, body)
+		if err == nil && resp.Header.Get(wakeHeader) == "" {
+			defer resp.Body.Close()
+			for key, values := range resp.Header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(resp.StatusCode)
+			_, _ = io.Copy(w, resp.Body)
+			return
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			w.Header().Set("Retry-After", "5")
+			http.Error(w, "application is starting", http.StatusServiceUnavailable)
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// replay sends the buffered request through Traefik again now the application runs
+func (p *WakeProxy) replay(ctx context.Context, r *http.Request, body []byte) (*http.Response, error) {
+	target := *p.upstream
+	target.Path = r.URL.Path
+	target.RawPath = r.URL.RawPath
+	target.RawQuery = r.URL.RawQuery
+
+	req, err := http.NewRequestWithContext(ctx, r.Method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = r.Header.Clone()
+	req.Header.Set(wakeHeader, "replay")
+	req.Host = r.Host
+
+	return p.client.Do(req)
+}
@@ -0,0 +1,104 @@
+package kms
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+)
+
+// Sealer encrypts records with data keys wrapped by a KMS, unwrapped data keys are
+// cached so the KMS is only called once per data key.
+type Sealer struct {
+	kms KMS
+
+	mu   sync.Mutex
+	keys map[string][]byte // wrapped data key to data key
+}
+
+// NewSealer creates a sealer on top of a KMS
+func NewSealer(k KMS) *Sealer {
+	return &Sealer{
+		kms:  k,
+		keys: make(map[string][]byte),
+	}
+}
+
+// KeyID returns the key new data keys are wrapped with
+func (s *Sealer) KeyID() string {
+	return s.kms.KeyID()
+}
+
+// NewDataKey generates a data key and returns it wrapped, with the ID of the wrapping key
+func (s *Sealer) NewDataKey(ctx context.Context) ([]byte, string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, "", err
+	}
+
+	return s.kms.Encrypt(ctx, key)
+}
+
+// Rewrap wraps a data key again with the current key, the data it encrypts is unchanged
+func (s *Sealer) Rewrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, string, error) {
+	key, err := s.dataKey(ctx, keyID, wrapped)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.kms.Encrypt(ctx, key)
+}
+
+// Seal encrypts plaintext with the data key
+func (s *Sealer) Seal(ctx context.Context, keyID string, wrapped, plaintext []byte) ([]byte, error) {
+	key, err := s.dataKey(ctx, keyID, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return seal(aead, plaintext)
+}
+
+// Open decrypts ciphertext sealed with the data key
+func (s *Sealer) Open(ctx context.Context, keyID string, wrapped, ciphertext []byte) ([]byte, error) {
+	key, err := s.dataKey(ctx, keyID, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(aead, ciphertext)
+}
+
+func (s *Sealer) dataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	if len(wrapped) == 0 {
+		return nil, fmt.Errorf("no data key")
+	}
+
+	s.mu.Lock()
+	key, ok := s.keys[string(wrapped)]
+	s.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	key, err := s.kms.Decrypt(ctx, keyID, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	s.mu.Lock()
+	s.keys[string(wrapped)] = key
+	s.mu.Unlock()
+
+	return key, nil
+}
@@ -0,0 +1,161 @@
+// Package kms encrypts tenant data with envelope encryption: every tenant has a data
+// key which encrypts its records, and only the data key wrapped by the KMS is stored.
+package kms
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// KMS wraps and unwraps data keys with key encryption keys it never hands out
+type KMS interface {
+	// KeyID returns the key new data keys are wrapped with
+	KeyID() string
+	// Encrypt wraps a data key with the current key and returns the ID of that key
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, string, error)
+	// Decrypt unwraps a data key wrapped by the given key
+	Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+}
+
+// LocalKMS keeps the key encryption keys in process, read from a keyring file
+type LocalKMS struct {
+	mu      sync.RWMutex
+	keys    map[string]cipher.AEAD
+	current string
+}
+
+// NewLocalKMS creates a KMS from 32 byte AES keys, current is the ID of the key used for new data keys
+func NewLocalKMS(keys map[string][]byte, current string) (*LocalKMS, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("current key %q is not in the keyring", current)
+	}
+
+	k := &LocalKMS{keys: make(map[string]cipher.AEAD), current: current}
+	for id, key := range keys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		k.keys[id] = aead
+	}
+
+	return k, nil
+}
+
+// LoadKeyring reads a keyring file with one `<key id>=<base64 key>` per line, the last
+// key is the current one so rotating means appending a key and keeping the old ones.
+func LoadKeyring(path string) (*LocalKMS, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	keys := make(map[string][]byte)
+	current := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid keyring line %q: expected <key id>=<base64 key>", line)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+
+		id = strings.TrimSpace(id)
+		keys[id] = key
+		current = id
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current == "" {
+		return nil, fmt.Errorf("keyring %s contains no keys", path)
+	}
+
+	return NewLocalKMS(keys, current)
+}
+
+// NewEphemeralKMS creates a KMS with a random key which is lost on restart
+func NewEphemeralKMS() (*LocalKMS, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	return NewLocalKMS(map[string][]byte{"ephemeral": key}, "ephemeral")
+}
+
+func (k *LocalKMS) KeyID() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.current
+}
+
+func (k *LocalKMS) Encrypt(ctx context.Context, plaintext []byte) ([]byte, string, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	ciphertext, err := seal(k.keys[k.current], plaintext)
+	return ciphertext, k.current, err
+}
+
+func (k *LocalKMS) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	k.mu.RLock()
+	aead, ok := k.keys[keyID]
+	k.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", keyID)
+	}
+
+	return open(aead, ciphertext)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal prepends the random nonce to the ciphertext
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, nil)
+}
@@ -0,0 +1,265 @@
+package manifest
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// resources are the messages Explain documents, by the name given to `cli explain`
+var resources = map[string]protoreflect.MessageDescriptor{
+	"deployspec":    (&pb.DeployRequest{}).ProtoReflect().Descriptor(),
+	"deployrequest": (&pb.DeployRequest{}).ProtoReflect().Descriptor(),
+}
+
+// width the descriptions are wrapped at
+const explainWidth = 80
+
+// Explain documents a resource or one of its fields the way kubectl explain does: its type,
+// description and the fields it holds, e.g. deployspec.traefik.host. With recursive the
+// fields of the fields are listed too, without their descriptions.
+func Explain(path string, recursive bool) (string, error) {
+	parts := strings.Split(path, ".")
+	message, ok := resources[strings.ToLower(parts[0])]
+	if !ok {
+		return "", fmt.Errorf("unknown resource %q, expected deployspec", parts[0])
+	}
+	kind := message
+
+	var field protoreflect.FieldDescriptor
+	for i, part := range parts[1:] {
+		if message == nil {
+			return "", fmt.Errorf("%s is a <%s>, it has no fields", strings.Join(parts[:i+1], "."), typeName(field))
+		}
+		field = fieldOf(message.Fields(), part)
+		if field == nil {
+			return "", fmt.Errorf("%s: %s", strings.Join(parts[:i+2], "."), unknownField(part, fieldNames(message.Fields())))
+		}
+		message = fieldMessage(field)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "KIND:     %s\n", kind.Name())
+	var description []string
+	if field != nil {
+		fmt.Fprintf(&b, "FIELD:    %s <%s>\n", field.Name(), typeName(field))
+		description = fieldDoc(field, kind)
+	}
+	if message != nil {
+		if doc := docs()[message.FullName()]; doc != "" {
+			description = append(description, doc)
+		}
+	}
+	if len(description) == 0 {
+		description = []string{"Undocumented."}
+	}
+	b.WriteString("\nDESCRIPTION:\n")
+	for _, paragraph := range description {
+		writeWrapped(&b, paragraph, "    ")
+	}
+
+	if message != nil && message.Fields().Len() > 0 {
+		b.WriteString("\nFIELDS:\n")
+		writeFields(&b, message, kind, "  ", recursive, []protoreflect.FullName{message.FullName()})
+	}
+	return b.String(), nil
+}
+
+// writeFields lists the fields of a message with their descriptions, or with the fields of
+// their messages when recursive. seen holds the messages being listed, a message holding
+// itself is listed once.
+func writeFields(b *strings.Builder, message, kind protoreflect.MessageDescriptor, indent string, recursive bool, seen []protoreflect.FullName) {
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fmt.Fprintf(b, "%s%s\t<%s>\n", indent, field.Name(), typeName(field))
+		if !recursive {
+			for _, paragraph := range fieldDoc(field, kind) {
+				writeWrapped(b, paragraph, indent+"  ")
+			}
+			b.WriteString("\n")
+			continue
+		}
+		if nested := fieldMessage(field); nested != nil && !slices.Contains(seen, nested.FullName()) {
+			writeFields(b, nested, kind, indent+"   ", recursive, append(seen, nested.FullName()))
+		}
+	}
+}
+
+// fieldDoc is the description of a field: its comment, the values of its enum and where a
+// manifest sets it
+func fieldDoc(field protoreflect.FieldDescriptor, kind protoreflect.MessageDescriptor) []string {
+	var doc []string
+	if comment := docs()[field.FullName()]; comment != "" {
+		doc = append(doc, comment)
+	}
+	if enum := field.Enum(); enum != nil {
+		prefix := enumPrefix(enum)
+		values := enum.Values()
+		for i := 0; i < values.Len(); i++ {
+			value := values.Get(i)
+			name := strings.ToLower(strings.TrimPrefix(string(value.Name()), prefix))
+			if name == "unspecified" {
+				name = "unset"
+			}
+			line := "- " + name
+			if comment := docs()[enum.FullName().Append(value.Name())]; comment != "" {
+				line += ": " + comment
+			}
+			doc = append(doc, line)
+		}
+	}
+	if field.ContainingMessage() == kind {
+		name := string(field.Name())
+		switch {
+		case slices.Contains(metadataFields, name):
+			doc = append(doc, fmt.Sprintf("Set in metadata.%s of a manifest.", name))
+		case specOnlyFields[name] != "":
+			doc = append(doc, fmt.Sprintf("Not part of a manifest, %s.", specOnlyFields[name]))
+		}
+	}
+	return doc
+}
+
+// fieldMessage is the message of a field, or of the values of a map, nil for scalars
+func fieldMessage(field protoreflect.FieldDescriptor) protoreflect.MessageDescriptor {
+	if field.IsMap() {
+		return field.MapValue().Message()
+	}
+	return field.Message()
+}
+
+// typeName is the type of a field as written in YAML or JSON, messages by their name
+func typeName(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.IsMap():
+		return "map[string]" + kindName(field.MapValue())
+	case field.IsList():
+		return "[]" + kindName(field)
+	default:
+		return kindName(field)
+	}
+}
+
+func kindName(field protoreflect.FieldDescriptor) string {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(field.Message().Name())
+	case protoreflect.BoolKind:
+		return "boolean"
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.EnumKind:
+		return "string"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return "number"
+	default:
+		return "integer"
+	}
+}
+
+// writeWrapped writes a paragraph wrapped at explainWidth, lines after the first of a list
+// item are aligned with its text
+func writeWrapped(b *strings.Builder, paragraph, indent string) {
+	words := strings.Fields(paragraph)
+	line, continuation := indent, indent
+	if len(words) > 0 && words[0] == "-" {
+		continuation += "  "
+	}
+	for i, word := range words {
+		if i > 0 && len(line)+1+len(word) > explainWidth {
+			b.WriteString(line + "\n")
+			line = continuation + word
+			continue
+		}
+		if i > 0 {
+			line += " "
+		}
+		line += word
+	}
+	b.WriteString(line + "\n")
+}
+
+var (
+	declaration = regexp.MustCompile(`^(message|enum|oneof|service)\s+(\w+)\s*\{`)
+	fieldLine   = regexp.MustCompile(`^(?:repeated\s+|optional\s+)?(?:map\s*<[^>]*>|[\w.]+)\s+(\w+)\s*=\s*\d+`)
+	enumValue   = regexp.MustCompile(`^(\w+)\s*=\s*-?\d+`)
+)
+
+// docs are the comments of controlplane.proto, the lines above a declaration followed by
+// the comment after it, by full name of message, field and enum value. Enum values are
+// named after their enum, e.g. controlplane.NetworkMode.NETWORK_MODE_HOST.
+var docs = sync.OnceValue(func() map[protoreflect.FullName]string {
+	return parseDocs(pb.Source)
+})
+
+func parseDocs(source string) map[protoreflect.FullName]string {
+	type scope struct{ kind, name string }
+	docs := map[protoreflect.FullName]string{}
+	var pkg string
+	var scopes []scope
+	var leading []string
+
+	// fullName names a declaration of the innermost message or enum, oneofs are skipped
+	fullName := func(name string) protoreflect.FullName {
+		parts := []string{pkg}
+		for _, s := range scopes {
+			if s.kind == "message" || s.kind == "enum" {
+				parts = append(parts, s.name)
+			}
+		}
+		return protoreflect.FullName(strings.Join(append(parts, name), "."))
+	}
+	innermost := func() string {
+		for i := len(scopes) - 1; i >= 0; i-- {
+			if scopes[i].kind != "oneof" {
+				return scopes[i].kind
+			}
+		}
+		return ""
+	}
+
+	for _, line := range strings.Split(source, "\n") {
+		line = strings.TrimSpace(line)
+		if comment, ok := strings.CutPrefix(line, "//"); ok {
+			leading = append(leading, strings.TrimSpace(comment))
+			continue
+		}
+		code, trailing, _ := strings.Cut(line, "//")
+		code = strings.TrimSpace(code)
+		doc := strings.TrimSpace(strings.Join(append(leading, strings.TrimSpace(trailing)), " "))
+		leading = nil
+
+		opened := strings.Count(code, "{")
+		switch {
+		case strings.HasPrefix(code, "package "):
+			pkg = strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(code, "package ")), ";")
+		case declaration.MatchString(code):
+			match := declaration.FindStringSubmatch(code)
+			if doc != "" && (match[1] == "message" || match[1] == "enum") {
+				docs[fullName(match[2])] = doc
+			}
+			scopes = append(scopes, scope{kind: match[1], name: match[2]})
+			opened--
+		case innermost() == "message" && fieldLine.MatchString(code):
+			if doc != "" {
+				docs[fullName(fieldLine.FindStringSubmatch(code)[1])] = doc
+			}
+		case innermost() == "enum" && enumValue.MatchString(code):
+			if doc != "" {
+				docs[fullName(enumValue.FindStringSubmatch(code)[1])] = doc
+			}
+		}
+		for ; opened > 0; opened-- {
+			scopes = append(scopes, scope{})
+		}
+		for closed := strings.Count(code, "}"); closed > 0 && len(scopes) > 0; closed-- {
+			scopes = scopes[:len(scopes)-1]
+		}
+	}
+	return docs
+}
@@ -0,0 +1,408 @@
+// Package manifest parses application manifests, declarative YAML or JSON files holding the
+// spec of an application the way `cli -action=apply -f app.yaml` deploys it:
+//
+//	apiVersion: controlplane/v1
+//	kind: Application
+//	metadata:
+//	  name: web
+//	  labels: {team: storefront}
+//	spec:
+//	  image: nginx:1.27
+//	  replicas: 2
+//	  env: {PORT: 8080}
+//	  healthCheck: {path: /healthz, interval: 10s}
+//	  traefik: {enable: true, host: web.example.com}
+//
+// The spec holds the fields of a DeployRequest, in their proto or JSON names, and is checked
+// against its schema so typos and mistyped values are reported with their line.
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+const (
+	APIVersion      = "controlplane/v1"
+	KindApplication = "Application"
+)
+
+// fields of the spec set in the metadata instead, and set by the CLI
+var (
+	metadataFields = []string{"name", "namespace", "tenant", "labels", "annotations"}
+	specOnlyFields = map[string]string{"dry_run": "pass -dry-run to the CLI instead"}
+)
+
+// Error is a problem with a field of a manifest
+type Error struct {
+	Line    int
+	Path    string // e.g. spec.traefik.host
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", e.Line, e.Path, e.Message)
+}
+
+// Is reports whether a YAML or JSON document is a manifest rather than a bare spec
+func Is(data []byte) bool {
+	var header struct {
+		APIVersion string `yaml:"apiVersion"`
+	}
+	return yaml.Unmarshal(data, &header) == nil && header.APIVersion != ""
+}
+
+// Parse decodes a YAML or JSON manifest into the DeployRequest deploying it, every problem
+// found is returned as an *Error joined with the others
+func Parse(data []byte) (*pb.DeployRequest, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, errors.New("manifest is empty")
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, &Error{Line: root.Line, Message: "a manifest is a mapping of apiVersion, kind, metadata and spec"}
+	}
+
+	d := &decoder{}
+	var apiVersion, kind string
+	var metadata, spec *yaml.Node
+	for i := 0; i < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "apiVersion":
+			apiVersion = value.Value
+		case "kind":
+			kind = value.Value
+		case "metadata":
+			metadata = value
+		case "spec":
+			spec = value
+		default:
+			d.fail(key, key.Value, "%s", unknownField(key.Value, []string{"apiVersion", "kind", "metadata", "spec"}))
+		}
+	}
+
+	switch {
+	case apiVersion == "":
+		d.fail(root, "apiVersion", "missing, set it to %s", APIVersion)
+	case apiVersion != APIVersion:
+		d.fail(root, "apiVersion", "%q is not supported, set it to %s", apiVersion, APIVersion)
+	}
+	switch {
+	case kind == "":
+		d.fail(root, "kind", "missing, set it to %s", KindApplication)
+	case kind != KindApplication:
+		d.fail(root, "kind", "%q is not supported, set it to %s", kind, KindApplication)
+	}
+
+	fields := (&pb.DeployRequest{}).ProtoReflect().Descriptor().Fields()
+	values := map[string]any{}
+	if metadata == nil || metadata.Kind != yaml.MappingNode {
+		d.fail(root, "metadata", "missing, a mapping holding at least the name of the application")
+	} else {
+		for i := 0; i < len(metadata.Content); i += 2 {
+			key, value := metadata.Content[i], metadata.Content[i+1]
+			if !slices.Contains(metadataFields, key.Value) {
+				d.fail(key, "metadata."+key.Value, "%s", unknownField(key.Value, metadataFields))
+				continue
+			}
+			values[key.Value] = d.value(value, fields.ByName(protoreflect.Name(key.Value)), "metadata."+key.Value)
+		}
+	}
+
+	if spec == nil || spec.Kind != yaml.MappingNode {
+		d.fail(root, "spec", "missing, a mapping holding at least the image of the application")
+	} else {
+		for i := 0; i < len(spec.Content); i += 2 {
+			key, value := spec.Content[i], spec.Content[i+1]
+			path := "spec." + key.Value
+			if key.Value == "healthCheck" || key.Value == "health_check" {
+				d.healthCheck(value, path, values)
+				continue
+			}
+			field := fieldOf(fields, key.Value)
+			switch {
+			case field == nil:
+				d.fail(key, path, "%s", unknownField(key.Value, fieldNames(fields)))
+				continue
+			case slices.Contains(metadataFields, string(field.Name())):
+				d.fail(key, path, "set it in metadata.%s", field.Name())
+				continue
+			case specOnlyFields[string(field.Name())] != "":
+				d.fail(key, path, "not part of a manifest, %s", specOnlyFields[string(field.Name())])
+				continue
+			}
+			merge(values, string(field.Name()), d.value(value, field, path))
+		}
+	}
+
+	if len(d.errs) > 0 {
+		slices.SortStableFunc(d.errs, func(a, b *Error) int { return a.Line - b.Line })
+		errs := make([]error, len(d.errs))
+		for i, err := range d.errs {
+			errs[i] = err
+		}
+		return nil, errors.Join(errs...)
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.DeployRequest{}
+	if err := protojson.Unmarshal(data, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// decoder checks the nodes of a manifest against the fields of the spec and converts them
+// to the JSON protojson reads, collecting every error on the way
+type decoder struct {
+	errs []*Error
+}
+
+func (d *decoder) fail(node *yaml.Node, path, format string, args ...any) {
+	d.errs = append(d.errs, &Error{Line: node.Line, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// healthCheck reads the healthCheck shorthand, the path and interval of the Traefik health check
+func (d *decoder) healthCheck(node *yaml.Node, path string, values map[string]any) {
+	if node.Kind != yaml.MappingNode {
+		d.fail(node, path, "expected a mapping of path and interval")
+		return
+	}
+	traefik := map[string]any{}
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "path":
+			traefik["health_check_path"] = d.scalar(value, nil, protoreflect.StringKind, path+".path")
+		case "interval":
+			if _, err := strconv.Atoi(value.Value); err == nil {
+				d.fail(value, path+".interval", "%s has no unit, e.g. %ss", value.Value, value.Value)
+				continue
+			}
+			traefik["health_check_interval"] = d.scalar(value, nil, protoreflect.StringKind, path+".interval")
+		default:
+			d.fail(key, path+"."+key.Value, "%s", unknownField(key.Value, []string{"path", "interval"}))
+		}
+	}
+	merge(values, "traefik", traefik)
+}
+
+// value converts the node of a field, a list, map, message or scalar
+func (d *decoder) value(node *yaml.Node, field protoreflect.FieldDescriptor, path string) any {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch {
+	case node.Tag == "!!null":
+		return nil
+	case field.IsList():
+		if node.Kind != yaml.SequenceNode {
+			d.fail(node, path, "expected a list")
+			return nil
+		}
+		list := make([]any, 0, len(node.Content))
+		for i, item := range node.Content {
+			list = append(list, d.single(item, field, fmt.Sprintf("%s[%d]", path, i)))
+		}
+		return list
+	case field.IsMap():
+		if node.Kind != yaml.MappingNode {
+			d.fail(node, path, "expected a mapping")
+			return nil
+		}
+		entries := map[string]any{}
+		for i := 0; i < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			entries[key.Value] = d.single(value, field.MapValue(), path+"."+key.Value)
+		}
+		return entries
+	default:
+		return d.single(node, field, path)
+	}
+}
+
+// single converts one message or scalar of a field
+func (d *decoder) single(node *yaml.Node, field protoreflect.FieldDescriptor, path string) any {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if field.Kind() != protoreflect.MessageKind {
+		return d.scalar(node, field.Enum(), field.Kind(), path)
+	}
+
+	if node.Kind != yaml.MappingNode {
+		d.fail(node, path, "expected a mapping of the fields of %s", field.Message().Name())
+		return nil
+	}
+	fields := field.Message().Fields()
+	message := map[string]any{}
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		nested := fieldOf(fields, key.Value)
+		if nested == nil {
+			d.fail(key, path+"."+key.Value, "%s", unknownField(key.Value, fieldNames(fields)))
+			continue
+		}
+		message[string(nested.Name())] = d.value(value, nested, path+"."+key.Value)
+	}
+	return message
+}
+
+// scalar converts a scalar node, numbers and booleans are taken as strings where strings
+// are expected so env: {PORT: 8080} needs no quotes
+func (d *decoder) scalar(node *yaml.Node, enum protoreflect.EnumDescriptor, kind protoreflect.Kind, path string) any {
+	if node.Kind != yaml.ScalarNode {
+		d.fail(node, path, "expected a single value")
+		return nil
+	}
+
+	switch kind {
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		if node.Tag == "!!null" {
+			return ""
+		}
+		return node.Value
+	case protoreflect.BoolKind:
+		var value bool
+		if node.Tag != "!!bool" || node.Decode(&value) != nil {
+			d.fail(node, path, "%q is not true or false", node.Value)
+			return nil
+		}
+		return value
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		var value float64
+		if (node.Tag != "!!int" && node.Tag != "!!float") || node.Decode(&value) != nil {
+			d.fail(node, path, "%q is not a number", node.Value)
+			return nil
+		}
+		return value
+	case protoreflect.EnumKind:
+		return d.enum(node, enum, path)
+	default:
+		var value int64
+		if node.Tag != "!!int" || node.Decode(&value) != nil {
+			d.fail(node, path, "%q is not a whole number", node.Value)
+			return nil
+		}
+		return value
+	}
+}
+
+// enum converts the name of an enum value, with or without the prefix of the enum and in
+// any case, e.g. bridge for NETWORK_MODE_BRIDGE
+func (d *decoder) enum(node *yaml.Node, enum protoreflect.EnumDescriptor, path string) any {
+	prefix := enumPrefix(enum)
+	name := strings.ToUpper(strings.ReplaceAll(node.Value, "-", "_"))
+	var names []string
+	values := enum.Values()
+	for i := 0; i < values.Len(); i++ {
+		value := string(values.Get(i).Name())
+		if value == name || value == prefix+name {
+			return value
+		}
+		if !strings.HasSuffix(value, "_UNSPECIFIED") {
+			names = append(names, strings.ToLower(strings.TrimPrefix(value, prefix)))
+		}
+	}
+	d.fail(node, path, "%q is not one of %s", node.Value, strings.Join(names, ", "))
+	return nil
+}
+
+// enumPrefix is the prefix the values of an enum share, the name of its unspecified value
+func enumPrefix(enum protoreflect.EnumDescriptor) string {
+	first := string(enum.Values().Get(0).Name())
+	if prefix, ok := strings.CutSuffix(first, "UNSPECIFIED"); ok {
+		return prefix
+	}
+	return ""
+}
+
+// fieldOf finds a field by its proto or JSON name
+func fieldOf(fields protoreflect.FieldDescriptors, name string) protoreflect.FieldDescriptor {
+	if field := fields.ByName(protoreflect.Name(name)); field != nil {
+		return field
+	}
+	return fields.ByJSONName(name)
+}
+
+func fieldNames(fields protoreflect.FieldDescriptors) []string {
+	names := make([]string, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		names = append(names, string(fields.Get(i).Name()))
+	}
+	return names
+}
+
+// merge sets a value, mappings are merged so healthCheck and traefik can both be set
+func merge(values map[string]any, name string, value any) {
+	existing, ok := values[name].(map[string]any)
+	update, isMap := value.(map[string]any)
+	if !ok || !isMap {
+		values[name] = value
+		return
+	}
+	for key, v := range update {
+		existing[key] = v
+	}
+}
+
+// unknownField proposes the closest of the known names to a misspelled one, or lists the
+// known names when there are few of them
+func unknownField(name string, known []string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	best, distance := "", 3
+	for _, candidate := range known {
+		if d := editDistance(normalized, strings.ToLower(strings.ReplaceAll(candidate, "_", ""))); d < distance {
+			best, distance = candidate, d
+		}
+	}
+	switch {
+	case best != "":
+		return fmt.Sprintf("unknown field, did you mean %s?", best)
+	case len(known) <= 8:
+		return "unknown field, expected one of " + strings.Join(known, ", ")
+	default:
+		return "unknown field"
+	}
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Metrics the controller reads from Nomad itself and from Traefik's routers, providers
+// cannot take their names
+const (
+	CPU    = "cpu"
+	Memory = "memory"
+	RPS    = "rps"
+)
+
+// providers slower than this fail the evaluation of the policy
+const queryTimeout = 10 * time.Second
+
+var providerName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Provider reads the current value of an external metric applications scale on
+type Provider interface {
+	// Value evaluates the query, e.g. a PromQL expression or the name of a queue
+	Value(ctx context.Context, query string) (float64, error)
+	String() string
+}
+
+// ParseProvider parses a provider given as name=URL:
+//
+//	prometheus+http(s)://host:9090    evaluates PromQL queries, a vector is summed
+//	traefik+http(s)://host:9090       requests per second of the Traefik service named by the
+//	                                  query, from the Prometheus scraping Traefik
+//	nats+http(s)://host:8222          pending messages of the JetStream consumer named
+//	                                  stream/consumer, from the NATS monitoring endpoint
+//	sqs://<region>                    visible messages of the SQS queue URL, credentials are read
+//	                                  from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+func ParseProvider(spec string) (string, Provider, error) {
+	name, provider, ok := strings.Cut(spec, "=")
+	if !ok || !providerName.MatchString(name) {
+		return "", nil, fmt.Errorf("metric provider %q must be name=URL with a lowercase name", spec)
+	}
+	if name == CPU || name == Memory || name == RPS {
+		return "", nil, fmt.Errorf("metric provider name %s is reserved for the built-in metric", name)
+	}
+
+	u, err := url.Parse(provider)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid metric provider %q: %w", provider, err)
+	}
+	kind, scheme, _ := strings.Cut(u.Scheme, "+")
+	if kind != "sqs" && (u.Host == "" || (scheme != "http" && scheme != "https")) {
+		return "", nil, fmt.Errorf("metric provider %q must be %s+http(s)://host:port", provider, kind)
+	}
+	endpoint := scheme + "://" + u.Host + strings.TrimSuffix(u.Path, "/")
+	client := &http.Client{Timeout: queryTimeout}
+
+	switch kind {
+	case "prometheus":
+		return name, &Prometheus{URL: endpoint, Client: client}, nil
+	case "traefik":
+		return name, &Traefik{Prometheus: &Prometheus{URL: endpoint, Client: client}}, nil
+	case "nats":
+		return name, &NATS{URL: endpoint, Client: client}, nil
+	case "sqs":
+		if u.Host == "" {
+			return "", nil, fmt.Errorf("metric provider %s needs the region, e.g. sqs://eu-west-1", provider)
+		}
+		sqs := &SQS{
+			Region:       u.Host,
+			AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+			Client:       client,
+		}
+		if sqs.AccessKey == "" || sqs.SecretKey == "" {
+			return "", nil, fmt.Errorf("metric provider %s needs AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY", provider)
+		}
+		return name, sqs, nil
+	default:
+		return "", nil, fmt.Errorf("unknown metric provider %q, use prometheus+http://, traefik+http://, nats+http:// or sqs://", provider)
+	}
+}
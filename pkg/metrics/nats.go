@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NATS reads the messages a JetStream consumer has yet to process from the monitoring
+// endpoint of a NATS server, the query is stream/consumer
+type NATS struct {
+	URL    string
+	Client *http.Client
+}
+
+type jszResponse struct {
+	Accounts []struct {
+		Streams []struct {
+			Name      string `json:"name"`
+			Consumers []struct {
+				Name          string `json:"name"`
+				NumPending    uint64 `json:"num_pending"`
+				NumAckPending int    `json:"num_ack_pending"`
+			} `json:"consumer_detail"`
+		} `json:"stream_detail"`
+	} `json:"account_details"`
+}
+
+// Value counts the messages not delivered to the consumer yet and those awaiting their ack
+func (n *NATS) Value(ctx context.Context, query string) (float64, error) {
+	stream, consumer, ok := strings.Cut(query, "/")
+	if !ok || stream == "" || consumer == "" {
+		return 0, fmt.Errorf("NATS query %q must be stream/consumer", query)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, n.URL+"/jsz?accounts=true&streams=true&consumers=true", nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("NATS monitoring returned %s", resp.Status)
+	}
+
+	var jsz jszResponse
+	if err := json.NewDecoder(resp.Body).Decode(&jsz); err != nil {
+		return 0, err
+	}
+	for _, account := range jsz.Accounts {
+		for _, s := range account.Streams {
+			if s.Name != stream {
+				continue
+			}
+			for _, c := range s.Consumers {
+				if c.Name == consumer {
+					return float64(c.NumPending) + float64(c.NumAckPending), nil
+				}
+			}
+		}
+	}
+	return 0, fmt.Errorf("JetStream consumer %s of stream %s not found", consumer, stream)
+}
+
+func (n *NATS) String() string {
+	return "NATS " + n.URL
+}
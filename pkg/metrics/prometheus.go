@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// Prometheus evaluates PromQL queries with the instant query API
+type Prometheus struct {
+	URL    string
+	Client *http.Client
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// Value returns the value of a scalar, or the sum of a vector. An empty vector, e.g. the
+// rate of a counter no request increased yet, is 0.
+func (p *Prometheus) Value(ctx context.Context, query string) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL+"/api/v1/query?query="+url.QueryEscape(query), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var result prometheusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("prometheus returned %s: %w", resp.Status, err)
+	}
+	if result.Status != "success" {
+		return 0, fmt.Errorf("prometheus: %s", result.Error)
+	}
+
+	switch result.Data.ResultType {
+	case "scalar":
+		var sample [2]any
+		if err := json.Unmarshal(result.Data.Result, &sample); err != nil {
+			return 0, err
+		}
+		return sampleValue(sample)
+	case "vector":
+		var series []struct {
+			Value [2]any `json:"value"`
+		}
+		if err := json.Unmarshal(result.Data.Result, &series); err != nil {
+			return 0, err
+		}
+		sum := 0.0
+		for _, s := range series {
+			value, err := sampleValue(s.Value)
+			if err != nil {
+				return 0, err
+			}
+			sum += value
+		}
+		return sum, nil
+	default:
+		return 0, fmt.Errorf("query %q returned a %s, not a scalar or an instant vector", query, result.Data.ResultType)
+	}
+}
+
+func (p *Prometheus) String() string {
+	return "Prometheus " + p.URL
+}
+
+// sampleValue reads the value of a [timestamp, "value"] sample
+func sampleValue(sample [2]any) (float64, error) {
+	value, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid sample %v", sample)
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+var traefikService = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// Traefik reads the requests per second a Traefik service served over the last minute from
+// the Prometheus scraping Traefik, the query is the service without its @provider suffix
+type Traefik struct {
+	Prometheus *Prometheus
+}
+
+func (t *Traefik) Value(ctx context.Context, service string) (float64, error) {
+	if !traefikService.MatchString(service) {
+		return 0, fmt.Errorf("invalid Traefik service %q", service)
+	}
+	return t.Prometheus.Value(ctx, fmt.Sprintf(`sum(rate(traefik_service_requests_total{service=~"%s@.*"}[1m]))`, service))
+}
+
+func (t *Traefik) String() string {
+	return "Traefik requests from " + t.Prometheus.String()
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const routerRequestsMetric = "traefik_router_requests_total"
+
+// TraefikRouters reads the request counters of the routers from Traefik's Prometheus
+// endpoint, which needs addRoutersLabels. The rates are the increase of the counters
+// between two scrapes, so they lag by no more than the scrape interval.
+type TraefikRouters struct {
+	URL    string
+	Client *http.Client
+
+	mu        sync.Mutex
+	counts    map[string]float64
+	scrapedAt time.Time
+}
+
+// NewTraefikRouters reads the routers from the metrics URL of Traefik
+func NewTraefikRouters(metricsURL string) *TraefikRouters {
+	return &TraefikRouters{URL: metricsURL, Client: &http.Client{Timeout: queryTimeout}}
+}
+
+// Rates scrapes the counters and returns the requests per second of every router since
+// the previous scrape, by router name without the @provider suffix. The first scrape has
+// nothing to compare with and returns nil.
+func (t *TraefikRouters) Rates(ctx context.Context) (map[string]float64, error) {
+	counts, err := t.scrape(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	previous, elapsed := t.counts, now.Sub(t.scrapedAt).Seconds()
+	t.counts, t.scrapedAt = counts, now
+	if previous == nil || elapsed <= 0 {
+		return nil, nil
+	}
+
+	rates := make(map[string]float64, len(counts))
+	for router, count := range counts {
+		increase := count - previous[router]
+		if increase < 0 {
+			// Traefik restarted and its counters started over
+			increase = count
+		}
+		rates[router] = increase / elapsed
+	}
+	return rates, nil
+}
+
+func (t *TraefikRouters) scrape(ctx context.Context) (map[string]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("traefik metrics returned %s", resp.Status)
+	}
+
+	counts := make(map[string]float64)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, routerRequestsMetric+"{") {
+			continue
+		}
+		end := strings.LastIndex(line, "}")
+		if end < 0 {
+			continue
+		}
+
+		router := labelValue(line[len(routerRequestsMetric)+1:end], "router")
+		if router == "" {
+			continue
+		}
+		router, _, _ = strings.Cut(router, "@")
+
+		fields := strings.Fields(line[end+1:])
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		// one series per code and method
+		counts[router] += value
+	}
+	return counts, scanner.Err()
+}
+
+func labelValue(labels, name string) string {
+	for _, pair := range strings.Split(labels, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		if found && strings.TrimSpace(key) == name {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// RouterRate adds up the rates of the routers generated for a job, named after the job
+// and "<job>-secure" for its SSL host
+func RouterRate(jobID string, rates map[string]float64) float64 {
+	return rates[jobID] + rates[jobID+"-secure"]
+}
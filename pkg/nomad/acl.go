@@ -0,0 +1,73 @@
+package nomad
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+// TenantTokenVariable is the Nomad variable of a tenant's first namespace the current token
+// of the tenant is kept in, the token can read it to pick up its successor
+const TenantTokenVariable = "controlplane/nomad-token"
+
+// TenantPolicyName is the ACL policy granting a tenant read access to its namespaces
+func TenantPolicyName(tenant string) string {
+	return "controlplane-tenant-" + tenant
+}
+
+// TenantPolicyRules grants read access to the jobs of the namespaces and to the token
+// variable, readLogs adds the logs and files of allocations
+func TenantPolicyRules(namespaces []string, readLogs bool) string {
+	var rules strings.Builder
+	for _, namespace := range namespaces {
+		fmt.Fprintf(&rules, "namespace %q {\n  policy = \"read\"\n", namespace)
+		if readLogs {
+			rules.WriteString("  capabilities = [\"read-logs\", \"read-fs\"]\n")
+		}
+		fmt.Fprintf(&rules, "  variables {\n    path %q {\n      capabilities = [\"read\"]\n    }\n  }\n}\n\n", TenantTokenVariable)
+	}
+	rules.WriteString("node {\n  policy = \"read\"\n}\n")
+	return rules.String()
+}
+
+// UpsertACLPolicy creates or replaces an ACL policy
+func (nc *NomadClient) UpsertACLPolicy(name, description, rules string) error {
+	_, err := nc.client.ACLPolicies().Upsert(&nmd.ACLPolicy{
+		Name:        name,
+		Description: description,
+		Rules:       rules,
+	}, nil)
+	return mapError(err)
+}
+
+// CreateACLToken mints a client token of the policies expiring after ttl
+func (nc *NomadClient) CreateACLToken(name string, policies []string, ttl time.Duration) (*nmd.ACLToken, error) {
+	token, _, err := nc.client.ACLTokens().Create(&nmd.ACLToken{
+		Name:          name,
+		Type:          "client",
+		Policies:      policies,
+		ExpirationTTL: ttl,
+	}, nil)
+	return token, mapError(err)
+}
+
+// DeleteACLToken revokes a token, tokens which already expired are ignored
+func (nc *NomadClient) DeleteACLToken(accessorID string) error {
+	_, err := nc.client.ACLTokens().Delete(accessorID, nil)
+	if isNotFound(err) {
+		return nil
+	}
+	return mapError(err)
+}
+
+// PutNamespaceVariable creates or replaces a Nomad variable of a namespace
+func (nc *NomadClient) PutNamespaceVariable(namespace, path string, items map[string]string) error {
+	_, _, err := nc.client.Variables().Create(&nmd.Variable{
+		Namespace: namespace,
+		Path:      path,
+		Items:     items,
+	}, &nmd.WriteOptions{Namespace: namespace})
+	return mapError(err)
+}
@@ -0,0 +1,76 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+var actionName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Action is a named command of the task operators run in a running allocation, e.g. a
+// cache flush or a reindex. Nomad runs it like exec, without a shell.
+type Action struct {
+	Name    string
+	Command string
+	Args    []string
+}
+
+func (a *Action) Validate() error {
+	if !actionName.MatchString(a.Name) {
+		return fmt.Errorf("action name %q may only contain letters, digits, '-' and '_'", a.Name)
+	}
+	if a.Command == "" {
+		return fmt.Errorf("action %s needs a command", a.Name)
+	}
+	return nil
+}
+
+func (a *Action) toNomadAction() *nmd.Action {
+	return &nmd.Action{Name: a.Name, Command: a.Command, Args: a.Args}
+}
+
+// ActionResult is the exit code and the output of an action, cut to its last bytes
+type ActionResult struct {
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+// RunAction runs an action of the task in the allocation and waits for it to exit, the
+// output beyond limit bytes per stream is dropped from the start
+func (nc *NomadClient) RunAction(ctx context.Context, allocID, jobID, task, action string, limit int) (*ActionResult, error) {
+	alloc, _, err := nc.client.Allocations().Info(allocID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, stderr := &tailWriter{limit: limit}, &tailWriter{limit: limit}
+	exitCode, err := nc.client.Jobs().ActionExec(ctx, alloc, jobID, task, false, nil, action,
+		strings.NewReader(""), stdout, stderr, nil, nc.jobQuery(jobID))
+	if err != nil {
+		return nil, err
+	}
+	return &ActionResult{ExitCode: exitCode, Stdout: stdout.String(), Stderr: stderr.String()}, nil
+}
+
+// tailWriter keeps the last limit bytes written to it
+type tailWriter struct {
+	limit int
+	data  []byte
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.data = append(w.data, p...)
+	if len(w.data) > w.limit {
+		w.data = w.data[len(w.data)-w.limit:]
+	}
+	return len(p), nil
+}
+
+func (w *tailWriter) String() string {
+	return string(w.data)
+}
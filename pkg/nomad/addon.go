@@ -0,0 +1,189 @@
+package nomad
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/iuliansafta/control-plane/pkg/utils"
+)
+
+// Job meta keys of add-on jobs, the controller tears them down with their application
+const (
+	MetaAddOnOf   = "controlplane_addon_of"
+	MetaAddOnName = "controlplane_addon_name"
+)
+
+// AddOnPasswordKey is the item of the add-on's variable holding its password
+const AddOnPasswordKey = "password"
+
+type addOnKind struct {
+	image          string
+	defaultVersion string
+	memoryMB       int
+	dataDir        string
+	command        string
+	args           []string
+	env            map[string]string
+	passwordEnv    string // set from the add-on's variable
+	url            string // connection URL of the application, %[1]s is the password and %[2]s host:port
+}
+
+// addOnKinds are the managed dependencies an application can request. They listen on the
+// dynamic port of the add-on job.
+var addOnKinds = map[string]addOnKind{
+	"postgres": {
+		image:          "postgres",
+		defaultVersion: "16",
+		memoryMB:       256,
+		dataDir:        "/var/lib/postgresql/data",
+		env: map[string]string{
+			"POSTGRES_USER": "app",
+			"POSTGRES_DB":   "app",
+			"PGDATA":        "/var/lib/postgresql/data/pgdata",
+			"PGPORT":        "${NOMAD_PORT_postgres}",
+		},
+		passwordEnv: "POSTGRES_PASSWORD",
+		url:         "postgres://app:%[1]s@%[2]s/app",
+	},
+	"redis": {
+		image:          "redis",
+		defaultVersion: "7",
+		memoryMB:       128,
+		dataDir:        "/data",
+		command:        "redis-server",
+		args:           []string{"--port", "${NOMAD_PORT_redis}", "--requirepass", "${REDIS_PASSWORD}", "--appendonly", "yes"},
+		passwordEnv:    "REDIS_PASSWORD",
+		url:            "redis://:%[1]s@%[2]s",
+	},
+}
+
+// AddOnTypes lists the supported add-on types
+func AddOnTypes() []string {
+	return slices.Sorted(maps.Keys(addOnKinds))
+}
+
+var addOnName = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// AddOn is a managed dependency, e.g. a database, the controller deploys alongside an
+// application. Its connection details reach the application as environment variables.
+type AddOn struct {
+	Application string
+	Name        string // the job is <application>-<name>, the environment variables are prefixed with NAME_
+	Type        string
+	Version     string // image tag, defaults to the type's default version
+	MemoryMB    int
+	Region      string
+	VolumeID    string // persists the data, without a volume it is lost when the add-on is rescheduled
+}
+
+// JobID is the job running the add-on
+func (a *AddOn) JobID() string {
+	return a.Application + "-" + a.Name
+}
+
+// EnvPrefix prefixes the environment variables of the application, e.g. DB for DB_URL
+func (a *AddOn) EnvPrefix() string {
+	return strings.ToUpper(a.Name)
+}
+
+// PasswordKey is the item of the application's variable holding the add-on's password
+func (a *AddOn) PasswordKey() string {
+	return a.EnvPrefix() + "_PASSWORD"
+}
+
+// serviceName matches the service the job template registers for the add-on's port
+func (a *AddOn) serviceName() string {
+	return a.JobID() + "-" + a.Type
+}
+
+func (a *AddOn) Validate() error {
+	if !addOnName.MatchString(a.Name) {
+		return fmt.Errorf("add-on name %q may only contain lowercase letters and digits", a.Name)
+	}
+	if _, ok := addOnKinds[a.Type]; !ok {
+		return fmt.Errorf("add-on %s: unknown type %q, supported: %s", a.Name, a.Type, strings.Join(AddOnTypes(), ", "))
+	}
+	if a.MemoryMB < 0 {
+		return fmt.Errorf("add-on %s: memory cannot be negative", a.Name)
+	}
+	return nil
+}
+
+// Job is the job running the add-on, it reads its password from its own variable
+func (a *AddOn) Job() *JobTemplate {
+	kind := addOnKinds[a.Type]
+
+	version := a.Version
+	if version == "" {
+		version = kind.defaultVersion
+	}
+	memory := a.MemoryMB
+	if memory == 0 {
+		memory = kind.memoryMB
+	}
+
+	job := &JobTemplate{
+		Name:      a.JobID(),
+		Image:     kind.image + ":" + version,
+		Instances: 1,
+		Region:    a.Region,
+		Ports: Ports{
+			Label: a.Type,
+		},
+		Environment: maps.Clone(kind.env),
+		ResourcesSpec: Resources{
+			CPU:      utils.IntPtr(500),
+			MemoryMB: utils.IntPtr(memory),
+		},
+		HealthCheck: ServiceCheck{
+			Type:     "tcp",
+			Interval: 10 * time.Second,
+			Timeout:  "2s",
+		},
+		Command: kind.command,
+		Args:    kind.args,
+		Templates: []Template{{
+			Data:        fmt.Sprintf(`{{ with nomadVar %q }}%s={{ .%s }}{{ end }}`, JobVariablePath(a.JobID()), kind.passwordEnv, AddOnPasswordKey),
+			Destination: "secrets/addon.env",
+			Env:         true,
+		}},
+		Meta: map[string]string{
+			MetaAddOnOf:   a.Application,
+			MetaAddOnName: a.Name,
+		},
+	}
+
+	if a.VolumeID != "" {
+		job.Volumes = []VolumeMount{{VolumeID: a.VolumeID, Destination: kind.dataDir}}
+	} else {
+		job.EphemeralDisk = &EphemeralDisk{Sticky: true, Migrate: true}
+	}
+
+	return job
+}
+
+// EnvTemplate renders the connection details of the add-on into the application's
+// environment, <NAME>_HOST, <NAME>_PORT, <NAME>_PASSWORD and <NAME>_URL
+func (a *AddOn) EnvTemplate() Template {
+	kind := addOnKinds[a.Type]
+	prefix := a.EnvPrefix()
+	address := `{{ .Address }}:{{ .Port }}`
+
+	var data strings.Builder
+	fmt.Fprintf(&data, "{{ with nomadVar %q }}{{ $password := .%s }}{{ range service %q }}\n", JobVariablePath(a.Application), a.PasswordKey(), a.serviceName())
+	fmt.Fprintf(&data, "%s_HOST={{ .Address }}\n", prefix)
+	fmt.Fprintf(&data, "%s_PORT={{ .Port }}\n", prefix)
+	fmt.Fprintf(&data, "%s_PASSWORD={{ $password }}\n", prefix)
+	fmt.Fprintf(&data, "%s_URL=%s\n", prefix, fmt.Sprintf(kind.url, "{{ $password }}", address))
+	data.WriteString("{{ end }}{{ end }}\n")
+
+	return Template{
+		Data:        data.String(),
+		Destination: "secrets/" + a.Name + ".env",
+		Env:         true,
+	}
+}
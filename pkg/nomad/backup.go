@@ -0,0 +1,157 @@
+package nomad
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"github.com/iuliansafta/control-plane/pkg/utils"
+)
+
+// Job meta keys of the backup jobs, the controller schedules backups from them
+const (
+	MetaBackupOf          = "controlplane_backup_of"
+	MetaBackupSchedule    = "controlplane_backup_schedule"
+	MetaBackupTimeZone    = "controlplane_backup_time_zone"
+	MetaBackupDestination = "controlplane_backup_destination"
+)
+
+// MetaSnapshot is the dispatch meta naming the snapshot a backup job writes or a restore job reads
+const MetaSnapshot = "snapshot"
+
+// DefaultBackupImage runs the backup and restore scripts, it needs a shell, tar and rclone
+const DefaultBackupImage = "rclone/rclone:1.68"
+
+// volumes are mounted below backupDir, one directory per volume
+const backupDir = "/backup"
+
+const (
+	backupScript = `set -eo pipefail
+tar -C ` + backupDir + ` -czf - . | rclone rcat "$BACKUP_REMOTE/$NOMAD_META_snapshot.tar.gz"`
+
+	restoreScript = `set -eo pipefail
+for volume in ` + backupDir + `/*; do find "$volume" -mindepth 1 -delete; done
+rclone cat "$BACKUP_REMOTE/$NOMAD_META_snapshot.tar.gz" | tar -C ` + backupDir + ` -xzf -`
+)
+
+// Backup archives the volumes of an application to S3 compatible storage, the controller
+// dispatches the backup job on the schedule and the restore job on request
+type Backup struct {
+	Application string
+	Region      string
+	Volumes     []VolumeMount
+	Destination string // s3://bucket/prefix, snapshots are written below <prefix>/<application>/
+	Schedule    string // cron expression, backups only run on request when empty
+	TimeZone    string
+	Image       string            // defaults to DefaultBackupImage
+	Environment map[string]string // e.g. RCLONE_S3_ENDPOINT for S3 compatible storage
+}
+
+// BackupJobID is the parameterized job archiving the volumes of an application
+func BackupJobID(application string) string {
+	return application + "-backup"
+}
+
+// RestoreJobID is the parameterized job overwriting the volumes of an application with a snapshot
+func RestoreJobID(application string) string {
+	return application + "-restore"
+}
+
+// SnapshotLocation is the object a snapshot is stored in
+func SnapshotLocation(destination, application, snapshot string) string {
+	return strings.TrimSuffix(destination, "/") + "/" + application + "/" + snapshot + ".tar.gz"
+}
+
+func (b *Backup) Validate() error {
+	if len(b.Volumes) == 0 {
+		return fmt.Errorf("backups require volumes")
+	}
+	for _, volume := range b.Volumes {
+		if volume.PerAlloc {
+			return fmt.Errorf("volume %s: per-alloc volumes cannot be backed up", volume.VolumeID)
+		}
+	}
+
+	destination, err := url.Parse(b.Destination)
+	if err != nil || destination.Scheme != "s3" || destination.Host == "" {
+		return fmt.Errorf("backup destination %q must be an s3://bucket/prefix URL", b.Destination)
+	}
+
+	if b.Schedule != "" {
+		periodic := &Periodic{Schedule: b.Schedule, TimeZone: b.TimeZone}
+		if _, err := periodic.Next(time.Now()); err != nil {
+			return fmt.Errorf("invalid backup schedule %q: %w", b.Schedule, err)
+		}
+	}
+
+	return nil
+}
+
+// BackupJob mounts the volumes read only and uploads them as a single archive
+func (b *Backup) BackupJob() *JobTemplate {
+	job := b.job(BackupJobID(b.Application), true, backupScript)
+	job.Meta[MetaBackupSchedule] = b.Schedule
+	job.Meta[MetaBackupTimeZone] = b.TimeZone
+	return job
+}
+
+// RestoreJob mounts the volumes read-write and replaces their content with a snapshot
+func (b *Backup) RestoreJob() *JobTemplate {
+	return b.job(RestoreJobID(b.Application), false, restoreScript)
+}
+
+func (b *Backup) job(name string, readOnly bool, script string) *JobTemplate {
+	image := b.Image
+	if image == "" {
+		image = DefaultBackupImage
+	}
+
+	// rclone's on the fly remote, credentials come from the environment or the node's instance role
+	destination, _ := url.Parse(b.Destination)
+	environment := map[string]string{
+		"BACKUP_REMOTE":             ":s3:" + path.Join(destination.Host, destination.Path, b.Application),
+		"RCLONE_S3_PROVIDER":        "AWS",
+		"RCLONE_S3_ENV_AUTH":        "true",
+		"RCLONE_S3_NO_CHECK_BUCKET": "true",
+	}
+	maps.Copy(environment, b.Environment)
+
+	var volumes []VolumeMount
+	for _, volume := range b.Volumes {
+		volume.Destination = path.Join(backupDir, volume.VolumeID)
+		volume.ReadOnly = readOnly
+		if volume.AccessMode == "" {
+			// the volume's access mode, a read only claim does not take the application's writer claim
+			volume.AccessMode = string(nmd.CSIVolumeAccessModeSingleNodeWriter)
+		}
+		volumes = append(volumes, volume)
+	}
+
+	return &JobTemplate{
+		Name:          name,
+		Image:         image,
+		Instances:     1,
+		Region:        b.Region,
+		DisableConsul: true,
+		Type:          "batch",
+		Entrypoint:    []string{"/bin/sh", "-c", script},
+		ResourcesSpec: Resources{
+			CPU:      utils.IntPtr(500),
+			MemoryMB: utils.IntPtr(256),
+		},
+		Environment: environment,
+		Meta: map[string]string{
+			MetaBackupOf:          b.Application,
+			MetaBackupDestination: b.Destination,
+		},
+		Parameterized: &Parameterized{
+			Payload:      "forbidden",
+			MetaRequired: []string{MetaSnapshot},
+		},
+		Volumes: volumes,
+	}
+}
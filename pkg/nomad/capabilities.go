@@ -0,0 +1,110 @@
+package nomad
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Capabilities are the features of the Nomad cluster the templates may use, detected once
+// on connect. Nil capabilities were not detected and do not restrict any feature.
+type Capabilities struct {
+	Version                string
+	Variables              bool // nomadVar in templates, Nomad 1.4
+	NodePools              bool // Nomad 1.6
+	MemoryOversubscription bool // memory_max, enabled in the scheduler configuration
+	Actions                bool // task actions, Nomad 1.7
+}
+
+// DetectCapabilities queries the version and the scheduler configuration of the cluster,
+// the result gates the features of later deployments
+func (nc *NomadClient) DetectCapabilities() (*Capabilities, error) {
+	self, err := nc.client.Agent().Self()
+	if err != nil {
+		return nil, err
+	}
+
+	version := self.Member.Tags["build"]
+	if config, ok := self.Config["Version"].(map[string]any); ok && version == "" {
+		version, _ = config["Version"].(string)
+	}
+	if version == "" {
+		return nil, fmt.Errorf("the agent did not report its version")
+	}
+
+	caps := &Capabilities{
+		Version:   version,
+		Variables: atLeast(version, 1, 4),
+		NodePools: atLeast(version, 1, 6),
+		Actions:   atLeast(version, 1, 7),
+	}
+
+	// reading the scheduler configuration needs operator:read, without it memory_max is
+	// passed on to Nomad as is
+	scheduler, _, err := nc.client.Operator().SchedulerGetConfiguration(nil)
+	if err != nil || scheduler.SchedulerConfig == nil {
+		caps.MemoryOversubscription = true
+	} else {
+		caps.MemoryOversubscription = scheduler.SchedulerConfig.MemoryOversubscriptionEnabled
+	}
+
+	nc.capabilities = caps
+	return caps, nil
+}
+
+// Capabilities returns the capabilities detected on connect, nil before
+func (nc *NomadClient) Capabilities() *Capabilities {
+	return nc.capabilities
+}
+
+// Check rejects templates using features the cluster does not support, before Nomad
+// fails the registration with a less helpful error
+func (c *Capabilities) Check(jt *JobTemplate) error {
+	if c == nil {
+		return nil
+	}
+
+	if !c.Variables {
+		for _, template := range jt.Templates {
+			if strings.Contains(template.Data, "nomadVar") {
+				return fmt.Errorf("secrets and add-ons read Nomad variables, which require Nomad 1.4 or later, the cluster runs %s", c.Version)
+			}
+		}
+	}
+
+	if len(jt.Actions) > 0 && !c.Actions {
+		return fmt.Errorf("actions require Nomad 1.7 or later, the cluster runs %s", c.Version)
+	}
+
+	if jt.ResourcesSpec.MemoryMaxMB != nil && !c.MemoryOversubscription {
+		return fmt.Errorf("memory_max requires memory oversubscription, which the scheduler configuration of the cluster disables, " +
+			"enable it with 'nomad operator scheduler set-config -memory-oversubscription=true'")
+	}
+
+	return nil
+}
+
+// CheckNodePool rejects placing a job in a node pool when the cluster has none
+func (c *Capabilities) CheckNodePool(pool string) error {
+	if c == nil || pool == "" || c.NodePools {
+		return nil
+	}
+	return fmt.Errorf("node pool %s: node pools require Nomad 1.6 or later, the cluster runs %s", pool, c.Version)
+}
+
+// atLeast compares the major and minor version of a Nomad version like 1.8.2+ent
+func atLeast(version string, major, minor int) bool {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	gotMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	gotMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return gotMajor > major || gotMajor == major && gotMinor >= minor
+}
@@ -0,0 +1,73 @@
+package nomad
+
+import (
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+// RegionCapacity is the CPU and memory of the ready nodes of a region, and how much of it
+// the running allocations leave free
+type RegionCapacity struct {
+	Region        string
+	Nodes         int
+	CPUTotalMHz   int64
+	CPUFreeMHz    int64
+	MemoryTotalMB int64
+	MemoryFreeMB  int64
+}
+
+// Regions lists the regions of the Nomad federation
+func (nc *NomadClient) Regions() ([]string, error) {
+	regions, err := nc.client.Regions().List()
+	return regions, mapError(err)
+}
+
+// RegionCapacity sums the resources of the region's ready nodes minus their reserved
+// resources and the resources of running allocations
+func (nc *NomadClient) RegionCapacity(region string) (*RegionCapacity, error) {
+	query := &nmd.QueryOptions{Region: region, Params: map[string]string{"resources": "true"}}
+
+	nodes, _, err := nc.client.Nodes().List(query)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	capacity := &RegionCapacity{Region: region}
+	ready := make(map[string]bool)
+	for _, node := range nodes {
+		if node.Status != "ready" || node.SchedulingEligibility != nmd.NodeSchedulingEligible || node.NodeResources == nil {
+			continue
+		}
+		ready[node.ID] = true
+		capacity.Nodes++
+
+		cpu, memory := node.NodeResources.Cpu.CpuShares, node.NodeResources.Memory.MemoryMB
+		if reserved := node.ReservedResources; reserved != nil {
+			cpu -= int64(reserved.Cpu.CpuShares)
+			memory -= int64(reserved.Memory.MemoryMB)
+		}
+		capacity.CPUTotalMHz += cpu
+		capacity.MemoryTotalMB += memory
+	}
+
+	capacity.CPUFreeMHz, capacity.MemoryFreeMB = capacity.CPUTotalMHz, capacity.MemoryTotalMB
+	allocations, _, err := nc.client.Allocations().List(&nmd.QueryOptions{
+		Region:    region,
+		Namespace: nmd.AllNamespacesNamespace,
+		Filter:    `ClientStatus == "running"`,
+		Params:    map[string]string{"resources": "true"},
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	for _, alloc := range allocations {
+		if !ready[alloc.NodeID] || alloc.AllocatedResources == nil {
+			continue
+		}
+		for _, task := range alloc.AllocatedResources.Tasks {
+			capacity.CPUFreeMHz -= task.Cpu.CpuShares
+			capacity.MemoryFreeMB -= task.Memory.MemoryMB
+		}
+	}
+
+	return capacity, nil
+}
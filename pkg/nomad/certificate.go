@@ -0,0 +1,78 @@
+package nomad
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Certificate strategies of a Traefik router with SSL
+const (
+	CertStrategyAuto     = ""         // the controller's CertPolicy decides
+	CertStrategyHost     = "host"     // a certificate per host, with the extra SANs of the spec
+	CertStrategyWildcard = "wildcard" // the wildcard certificate of the domain the host is directly below
+)
+
+// CertPolicy decides which certificates Traefik requests for applications. Hosts directly
+// below a wildcard domain share its wildcard certificate, so preview environments spinning
+// up do not each request a certificate and exhaust the ACME rate limits.
+type CertPolicy struct {
+	WildcardDomains  []string // e.g. preview.example.com covers pr-123.preview.example.com
+	WildcardResolver string   // cert resolver solving DNS-01 challenges, which wildcards require
+}
+
+// wildcardDomain returns the wildcard domain covering host, wildcards only cover a single label
+func (p *CertPolicy) wildcardDomain(host string) string {
+	host = strings.ToLower(host)
+	for _, domain := range p.WildcardDomains {
+		label, ok := strings.CutSuffix(host, "."+strings.ToLower(domain))
+		if ok && label != "" && !strings.Contains(label, ".") {
+			return domain
+		}
+	}
+	return ""
+}
+
+// Apply resolves the certificate strategy of the Traefik spec into the certificate domains
+// of its SSL router, a nil policy has no wildcard domains
+func (p *CertPolicy) Apply(ts *TraefikSpec) error {
+	if !ts.Enable || !ts.EnableSSL || ts.Host == "" {
+		return nil
+	}
+	if p == nil {
+		p = &CertPolicy{}
+	}
+
+	host := ts.SSLHost
+	if host == "" {
+		host = ts.Host
+	}
+	domain := p.wildcardDomain(host)
+
+	strategy := ts.CertStrategy
+	if strategy == CertStrategyAuto {
+		strategy = CertStrategyHost
+		if domain != "" && len(ts.CertSANs) == 0 {
+			strategy = CertStrategyWildcard
+		}
+	}
+
+	switch strategy {
+	case CertStrategyWildcard:
+		if domain == "" {
+			return fmt.Errorf("host %s is not directly below a wildcard domain of the controller", host)
+		}
+		if len(ts.CertSANs) > 0 {
+			return fmt.Errorf("a wildcard certificate cannot have extra SANs")
+		}
+		ts.CertMain = "*." + domain
+		ts.CertResolver = p.WildcardResolver
+	case CertStrategyHost:
+		if len(ts.CertSANs) > 0 {
+			ts.CertMain = host
+		}
+	default:
+		return fmt.Errorf("unknown certificate strategy %q", ts.CertStrategy)
+	}
+
+	return nil
+}
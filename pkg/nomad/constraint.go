@@ -0,0 +1,158 @@
+package nomad
+
+import (
+	"fmt"
+	"strings"
+
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+type Constraint struct {
+	Attribute string
+	Operator  string
+	Value     string
+}
+
+var constraintOperators = map[string]bool{
+	"=":                             true,
+	"==":                            true,
+	"is":                            true,
+	"!=":                            true,
+	"not":                           true,
+	">":                             true,
+	">=":                            true,
+	"<":                             true,
+	"<=":                            true,
+	nmd.ConstraintDistinctHosts:     true,
+	nmd.ConstraintDistinctProperty:  true,
+	nmd.ConstraintRegex:             true,
+	nmd.ConstraintVersion:           true,
+	nmd.ConstraintSemver:            true,
+	nmd.ConstraintSetContains:       true,
+	nmd.ConstraintSetContainsAll:    true,
+	nmd.ConstraintSetContainsAny:    true,
+	nmd.ConstraintAttributeIsSet:    true,
+	nmd.ConstraintAttributeIsNotSet: true,
+}
+
+// Node attributes which can be targeted exactly. The prefixes below cover the
+// fingerprinted attr.* values and operator supplied meta.* values.
+var nodeAttributes = map[string]bool{
+	"node.unique.id":   true,
+	"node.unique.name": true,
+	"node.datacenter":  true,
+	"node.class":       true,
+	"node.pool":        true,
+}
+
+var attributePrefixes = []string{
+	"attr.kernel.",
+	"attr.os.",
+	"attr.cpu.",
+	"attr.memory.",
+	"attr.unique.",
+	"attr.driver.",
+	"attr.consul.",
+	"attr.nomad.",
+	"attr.vault.",
+	"attr.platform.",
+	"meta.",
+}
+
+// ParseConstraint parses the "attribute operator value" form used by the CLI,
+// e.g. "meta.storage = ssd" or "attr.kernel.name=linux". The operators without operands
+// stand alone, "distinct_hosts", or follow the attribute, "meta.rack distinct_property".
+func ParseConstraint(expr string) (Constraint, error) {
+	fields := strings.Fields(expr)
+	if len(fields) >= 1 && fields[0] == nmd.ConstraintDistinctHosts {
+		return Constraint{
+			Operator: fields[0],
+			Value:    strings.Join(fields[1:], " "),
+		}, nil
+	}
+	if len(fields) >= 2 && constraintOperators[fields[1]] {
+		return Constraint{
+			Attribute: fields[0],
+			Operator:  fields[1],
+			Value:     strings.Join(fields[2:], " "),
+		}, nil
+	}
+
+	for _, op := range []string{"!=", ">=", "<=", "==", "=", ">", "<"} {
+		if attr, value, found := strings.Cut(expr, op); found {
+			return Constraint{
+				Attribute: strings.TrimSpace(attr),
+				Operator:  op,
+				Value:     strings.TrimSpace(value),
+			}, nil
+		}
+	}
+
+	return Constraint{}, fmt.Errorf("invalid constraint %q: expected 'attribute operator value'", expr)
+}
+
+// Validate checks the constraint against the operators and node attributes known to Nomad
+func (c Constraint) Validate() error {
+	operator := c.operator()
+	if !constraintOperators[operator] {
+		return fmt.Errorf("constraint operator %q is not supported", c.Operator)
+	}
+
+	// distinct_hosts is the only operator which does not need an attribute
+	if operator == nmd.ConstraintDistinctHosts {
+		return nil
+	}
+
+	attribute := c.bareAttribute()
+	if attribute == "" {
+		return fmt.Errorf("constraint attribute cannot be empty")
+	}
+	if !isKnownAttribute(attribute) {
+		return fmt.Errorf("constraint attribute %q is not a known Nomad attribute (expected attr.*, meta.* or node.*)", c.Attribute)
+	}
+
+	switch operator {
+	case nmd.ConstraintAttributeIsSet, nmd.ConstraintAttributeIsNotSet, nmd.ConstraintDistinctProperty:
+	default:
+		if c.Value == "" {
+			return fmt.Errorf("constraint on %q requires a value", c.Attribute)
+		}
+	}
+
+	return nil
+}
+
+func (c Constraint) toNomadConstraint() *nmd.Constraint {
+	attribute := ""
+	if c.bareAttribute() != "" {
+		attribute = "${" + c.bareAttribute() + "}"
+	}
+
+	return nmd.NewConstraint(attribute, c.operator(), c.Value)
+}
+
+func (c Constraint) operator() string {
+	if c.Operator == "" {
+		return "="
+	}
+	return c.Operator
+}
+
+func (c Constraint) bareAttribute() string {
+	attribute := strings.TrimSpace(c.Attribute)
+	attribute = strings.TrimPrefix(attribute, "${")
+	attribute = strings.TrimSuffix(attribute, "}")
+	return attribute
+}
+
+func isKnownAttribute(attribute string) bool {
+	if nodeAttributes[attribute] {
+		return true
+	}
+	for _, prefix := range attributePrefixes {
+		if strings.HasPrefix(attribute, prefix) && len(attribute) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
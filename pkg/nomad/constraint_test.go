@@ -0,0 +1,45 @@
+package nomad
+
+import (
+	"testing"
+
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+func TestParseConstraint(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    Constraint
+		wantErr bool
+	}{
+		{expr: "meta.storage = ssd", want: Constraint{Attribute: "meta.storage", Operator: "=", Value: "ssd"}},
+		{expr: "attr.kernel.name=linux", want: Constraint{Attribute: "attr.kernel.name", Operator: "=", Value: "linux"}},
+		{expr: "attr.cpu.numcores >= 4", want: Constraint{Attribute: "attr.cpu.numcores", Operator: ">=", Value: "4"}},
+		{expr: "distinct_hosts", want: Constraint{Operator: nmd.ConstraintDistinctHosts}},
+		{expr: "distinct_hosts true", want: Constraint{Operator: nmd.ConstraintDistinctHosts, Value: "true"}},
+		{expr: "meta.rack distinct_property", want: Constraint{Attribute: "meta.rack", Operator: nmd.ConstraintDistinctProperty}},
+		{expr: "meta.gpu is_set", want: Constraint{Attribute: "meta.gpu", Operator: nmd.ConstraintAttributeIsSet}},
+		{expr: "meta.gpu is_not_set", want: Constraint{Attribute: "meta.gpu", Operator: nmd.ConstraintAttributeIsNotSet}},
+		{expr: "meta.storage", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseConstraint(tt.expr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			if err := got.Validate(); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+		})
+	}
+}
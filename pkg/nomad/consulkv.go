@@ -0,0 +1,77 @@
+package nomad
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// MetaConsulKV is the Consul KV prefix the configuration of the application is kept under
+const MetaConsulKV = "controlplane_consul_kv"
+
+// ConsulKVPrefix is the Consul KV prefix of the job's configuration
+func ConsulKVPrefix(jobID string) string {
+	return "controlplane/apps/" + jobID + "/"
+}
+
+var (
+	consulKVKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	templateDir = regexp.MustCompile(`^(local|secrets)/`)
+)
+
+// ConsulKV renders the keys of the application's Consul KV prefix into the task as
+// KEY=VALUE lines, Nomad re-renders them and applies ChangeMode whenever a key changes
+type ConsulKV struct {
+	Prefix       string
+	Destination  string // file relative to the task dir, the keys become environment variables when empty
+	ChangeMode   string // restart (default), signal or noop
+	ChangeSignal string // sent with the signal change mode, e.g. SIGHUP
+}
+
+// ValidateConsulKVEntry checks a key and its value can be rendered as a KEY=VALUE line
+func ValidateConsulKVEntry(key, value string) error {
+	if !consulKVKey.MatchString(key) {
+		return fmt.Errorf("config key %q must be a letter or '_' followed by letters, digits or '_'", key)
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("config value of %s cannot span several lines", key)
+	}
+	return nil
+}
+
+func (c *ConsulKV) Validate() error {
+	if c.Destination != "" {
+		if !templateDir.MatchString(c.Destination) || path.Clean(c.Destination) != c.Destination {
+			return fmt.Errorf("consul kv destination %q must be a file in local/ or secrets/", c.Destination)
+		}
+	}
+	switch c.ChangeMode {
+	case "", "restart", "noop":
+		if c.ChangeSignal != "" {
+			return fmt.Errorf("consul kv change signal requires the signal change mode")
+		}
+	case "signal":
+		if c.ChangeSignal == "" {
+			return fmt.Errorf("consul kv signal change mode requires a change signal, e.g. SIGHUP")
+		}
+	default:
+		return fmt.Errorf("consul kv change mode must be restart, signal or noop")
+	}
+	return nil
+}
+
+// Template renders the keys of the prefix, one per line
+func (c *ConsulKV) Template() Template {
+	destination := c.Destination
+	if destination == "" {
+		destination = "local/consul-kv.env"
+	}
+	return Template{
+		Data:         fmt.Sprintf("{{ range ls %q }}{{ .Key }}={{ .Value }}\n{{ end }}", strings.TrimSuffix(c.Prefix, "/")),
+		Destination:  destination,
+		Env:          c.Destination == "",
+		ChangeMode:   c.ChangeMode,
+		ChangeSignal: c.ChangeSignal,
+	}
+}
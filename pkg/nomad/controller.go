@@ -0,0 +1,182 @@
+package nomad
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+
+	"github.com/iuliansafta/control-plane/pkg/utils"
+)
+
+// ControllerJobID is the job of the controllers the control plane deploys itself, its
+// variable holds their environment, e.g. NOMAD_TOKEN
+const ControllerJobID = "control-plane"
+
+// MetaController marks the job of the controllers
+const MetaController = "controlplane_controller"
+
+// ControllerHealthPath is the REST endpoint the health check of the controllers calls
+const ControllerHealthPath = "/v1/health"
+
+// the controllers listen on the dynamic ports of their allocation
+var controllerPortArgs = []string{
+	"-port=${NOMAD_PORT_grpc}",
+	"-http-addr=:${NOMAD_PORT_http}",
+	"-wake-addr=:${NOMAD_PORT_wake}",
+}
+
+// Controller is the control plane deployed as a service job. A new version is placed next
+// to the running controllers, which are only stopped once every new controller passed its
+// health check; Nomad reverts to the previous version otherwise.
+type Controller struct {
+	Image         string
+	Count         int // defaults to 2
+	Args          []string
+	Region        string
+	Datacenters   []string      // defaults to dc1
+	NodePool      string        // defaults to the default pool
+	HealthTimeout time.Duration // how long a new controller has to become healthy, defaults to 3m
+}
+
+func (c *Controller) withDefaults() Controller {
+	controller := *c
+	if controller.Count == 0 {
+		controller.Count = 2
+	}
+	if len(controller.Datacenters) == 0 {
+		controller.Datacenters = []string{"dc1"}
+	}
+	if controller.HealthTimeout == 0 {
+		controller.HealthTimeout = 3 * time.Minute
+	}
+	return controller
+}
+
+func (c *Controller) Validate() error {
+	if c.Image == "" {
+		return fmt.Errorf("the controller needs an image")
+	}
+	if c.Count < 0 {
+		return fmt.Errorf("count cannot be negative")
+	}
+	if c.HealthTimeout < 0 {
+		return fmt.Errorf("health timeout cannot be negative")
+	}
+	return nil
+}
+
+// ControllerFromJob reads the settings of the deployed controllers back from their job
+func ControllerFromJob(job *nmd.Job) Controller {
+	controller := Controller{Datacenters: job.Datacenters}
+	if job.Region != nil {
+		controller.Region = *job.Region
+	}
+	if job.NodePool != nil && *job.NodePool != "default" {
+		controller.NodePool = *job.NodePool
+	}
+	if job.Update != nil && job.Update.HealthyDeadline != nil {
+		controller.HealthTimeout = *job.Update.HealthyDeadline
+	}
+	if len(job.TaskGroups) == 0 || len(job.TaskGroups[0].Tasks) == 0 {
+		return controller
+	}
+
+	group := job.TaskGroups[0]
+	if group.Count != nil {
+		controller.Count = *group.Count
+	}
+	task := group.Tasks[0]
+	controller.Image, _ = task.Config["image"].(string)
+	args, _ := task.Config["args"].([]any)
+	for _, arg := range args {
+		if arg, ok := arg.(string); ok && !slices.Contains(controllerPortArgs, arg) {
+			controller.Args = append(controller.Args, arg)
+		}
+	}
+	return controller
+}
+
+// Job is the service job of the controllers, rolled out with as many canaries as
+// controllers and reverted when they do not become healthy
+func (c *Controller) Job() (*nmd.Job, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	controller := c.withDefaults()
+
+	task := &nmd.Task{
+		Name:   ControllerJobID,
+		Driver: "containerd-driver",
+		Config: map[string]any{
+			"image":        controller.Image,
+			"host_network": true,
+			"args":         append(slices.Clone(controller.Args), controllerPortArgs...),
+		},
+		Resources: &nmd.Resources{
+			CPU:      utils.IntPtr(500),
+			MemoryMB: utils.IntPtr(256),
+		},
+		Templates: []*nmd.Template{{
+			// environment of the controllers, e.g. NOMAD_TOKEN
+			EmbeddedTmpl: utils.StringPtr(fmt.Sprintf("{{ with nomadVar %q }}{{ range $key, $value := . }}{{ $key }}={{ $value }}\n{{ end }}{{ end }}", JobVariablePath(ControllerJobID))),
+			DestPath:     utils.StringPtr("secrets/controller.env"),
+			Envvars:      utils.BoolPtr(true),
+		}},
+	}
+
+	group := &nmd.TaskGroup{
+		Name:  utils.StringPtr(ControllerJobID + "-group"),
+		Count: utils.IntPtr(controller.Count),
+		Tasks: []*nmd.Task{task},
+		Networks: []*nmd.NetworkResource{{
+			DynamicPorts: []nmd.Port{{Label: "grpc"}, {Label: "http"}, {Label: "wake"}},
+		}},
+		Services: []*nmd.Service{
+			{
+				Name:      ControllerJobID,
+				PortLabel: "grpc",
+			},
+			{
+				Name:      ControllerJobID + "-http",
+				PortLabel: "http",
+				Checks: []nmd.ServiceCheck{{
+					Type:     "http",
+					Path:     ControllerHealthPath,
+					Interval: 10 * time.Second,
+					Timeout:  2 * time.Second,
+				}},
+			},
+		},
+	}
+
+	job := &nmd.Job{
+		ID:          utils.StringPtr(ControllerJobID),
+		Name:        utils.StringPtr(ControllerJobID),
+		Type:        utils.StringPtr("service"),
+		Datacenters: controller.Datacenters,
+		TaskGroups:  []*nmd.TaskGroup{group},
+		Meta:        map[string]string{MetaController: "true"},
+		// the new controllers surge next to the old ones, which keep serving until all of
+		// the new ones are healthy
+		Update: &nmd.UpdateStrategy{
+			MaxParallel:      utils.IntPtr(controller.Count),
+			Canary:           utils.IntPtr(controller.Count),
+			AutoPromote:      utils.BoolPtr(true),
+			AutoRevert:       utils.BoolPtr(true),
+			HealthCheck:      utils.StringPtr("checks"),
+			MinHealthyTime:   utils.DurationPtr(10 * time.Second),
+			HealthyDeadline:  utils.DurationPtr(controller.HealthTimeout),
+			ProgressDeadline: utils.DurationPtr(controller.HealthTimeout + 2*time.Minute),
+		},
+	}
+	if controller.Region != "" {
+		job.Region = utils.StringPtr(controller.Region)
+	}
+	if controller.NodePool != "" {
+		job.NodePool = utils.StringPtr(controller.NodePool)
+	}
+
+	return job, nil
+}
@@ -0,0 +1,284 @@
+package nomad
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"gopkg.in/yaml.v3"
+
+	"github.com/iuliansafta/control-plane/pkg/utils"
+)
+
+// EdgeProxyJobID is the job of the Traefik edge proxy deployed by the controller, its
+// variable holds the credentials of the DNS providers, e.g. CF_DNS_API_TOKEN
+const EdgeProxyJobID = "traefik"
+
+const DefaultEdgeProxyImage = "traefik:v3.1"
+
+// MetaEdgeProxy marks the job of the edge proxy
+const MetaEdgeProxy = "controlplane_edge_proxy"
+
+// CertResolver is an ACME cert resolver of the edge proxy
+type CertResolver struct {
+	Name        string
+	Email       string
+	DNSProvider string // DNS-01 challenge with this provider, required by wildcard certificates; TLS-ALPN-01 otherwise
+	CAServer    string // defaults to Let's Encrypt
+}
+
+// EdgeProxy is Traefik deployed as a system job, on every client or those of a node class.
+// It routes on the tags of the applications' Consul services.
+type EdgeProxy struct {
+	Image         string
+	Region        string
+	Datacenters   []string // defaults to dc1
+	NodeClass     string   // only run on the clients of this class, e.g. edge
+	NodePool      string   // only run on the clients of this node pool, defaults to the default pool
+	ConsulAddress string
+	HTTPPort      int // defaults to 80
+	HTTPSPort     int // defaults to 443
+	MetricsPort   int // Prometheus metrics read by the idle controller, defaults to 8082
+	RedirectHTTPS bool
+	Dashboard     bool // the API and dashboard on the metrics port, without authentication
+	Resolvers     []CertResolver
+	WakeURL       string // catch-all router to the wake proxy of applications scaled to zero
+}
+
+func (p *EdgeProxy) withDefaults() EdgeProxy {
+	proxy := *p
+	if proxy.Image == "" {
+		proxy.Image = DefaultEdgeProxyImage
+	}
+	if len(proxy.Datacenters) == 0 {
+		proxy.Datacenters = []string{"dc1"}
+	}
+	if proxy.HTTPPort == 0 {
+		proxy.HTTPPort = 80
+	}
+	if proxy.HTTPSPort == 0 {
+		proxy.HTTPSPort = 443
+	}
+	if proxy.MetricsPort == 0 {
+		proxy.MetricsPort = 8082
+	}
+	return proxy
+}
+
+func (p *EdgeProxy) Validate() error {
+	if p.ConsulAddress == "" {
+		return fmt.Errorf("the edge proxy needs the Consul address of the catalog it routes on")
+	}
+	for _, resolver := range p.Resolvers {
+		if !traefikName.MatchString(resolver.Name) {
+			return fmt.Errorf("cert resolver name %q may only contain letters, digits, '-' and '_'", resolver.Name)
+		}
+		if resolver.Email == "" {
+			return fmt.Errorf("cert resolver %s needs an ACME account email", resolver.Name)
+		}
+	}
+	if p.WakeURL != "" {
+		if u, err := url.Parse(p.WakeURL); err != nil || u.Host == "" {
+			return fmt.Errorf("wake URL %q must be an absolute URL", p.WakeURL)
+		}
+	}
+	return nil
+}
+
+// StaticConfig is Traefik's static configuration: the entrypoints, the Consul catalog
+// provider and the cert resolvers
+func (p *EdgeProxy) StaticConfig() (string, error) {
+	proxy := p.withDefaults()
+
+	web := map[string]any{"address": fmt.Sprintf(":%d", proxy.HTTPPort)}
+	if proxy.RedirectHTTPS {
+		web["http"] = map[string]any{
+			"redirections": map[string]any{
+				"entryPoint": map[string]any{"to": "websecure", "scheme": "https"},
+			},
+		}
+	}
+
+	config := map[string]any{
+		"entryPoints": map[string]any{
+			"web":       web,
+			"websecure": map[string]any{"address": fmt.Sprintf(":%d", proxy.HTTPSPort)},
+			"metrics":   map[string]any{"address": fmt.Sprintf(":%d", proxy.MetricsPort)},
+		},
+		"providers": map[string]any{
+			"consulCatalog": map[string]any{
+				"exposedByDefault": false,
+				"endpoint":         consulEndpoint(proxy.ConsulAddress),
+			},
+			"file": map[string]any{"filename": "/local/dynamic.yml", "watch": true},
+		},
+		"metrics": map[string]any{
+			"prometheus": map[string]any{"entryPoint": "metrics", "addServicesLabels": true, "addRoutersLabels": true},
+		},
+		"ping": map[string]any{"entryPoint": "metrics"},
+	}
+	if proxy.Dashboard {
+		config["api"] = map[string]any{"dashboard": true, "insecure": true}
+	}
+
+	if len(proxy.Resolvers) > 0 {
+		resolvers := map[string]any{}
+		for _, resolver := range proxy.Resolvers {
+			acme := map[string]any{
+				"email":   resolver.Email,
+				"storage": "/alloc/data/acme-" + resolver.Name + ".json",
+			}
+			if resolver.CAServer != "" {
+				acme["caServer"] = resolver.CAServer
+			}
+			if resolver.DNSProvider != "" {
+				acme["dnsChallenge"] = map[string]any{"provider": resolver.DNSProvider}
+			} else {
+				acme["tlsChallenge"] = map[string]any{}
+			}
+			resolvers[resolver.Name] = map[string]any{"acme": acme}
+		}
+		config["certificatesResolvers"] = resolvers
+	}
+
+	data, err := yaml.Marshal(config)
+	return string(data), err
+}
+
+// consulEndpoint splits a Consul URL into Traefik's address and scheme
+func consulEndpoint(address string) map[string]any {
+	u, err := url.Parse(address)
+	if err != nil || u.Host == "" {
+		return map[string]any{"address": address}
+	}
+	return map[string]any{"address": u.Host, "scheme": u.Scheme}
+}
+
+// DynamicConfig routes requests no application matches to the wake proxy, Traefik drops
+// the routers of applications scaled to zero
+func (p *EdgeProxy) DynamicConfig() (string, error) {
+	if p.WakeURL == "" {
+		return "{}\n", nil
+	}
+
+	config := map[string]any{
+		"http": map[string]any{
+			"routers": map[string]any{
+				"wake": map[string]any{
+					"rule":        "HostRegexp(`.+`)",
+					"priority":    1,
+					"service":     "wake",
+					"entryPoints": []string{"web", "websecure"},
+				},
+			},
+			"services": map[string]any{
+				"wake": map[string]any{
+					"loadBalancer": map[string]any{
+						"servers": []map[string]string{{"url": p.WakeURL}},
+					},
+				},
+			},
+		},
+	}
+
+	data, err := yaml.Marshal(config)
+	return string(data), err
+}
+
+// Job is the system job running the edge proxy on the host network
+func (p *EdgeProxy) Job() (*nmd.Job, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	proxy := p.withDefaults()
+
+	static, err := proxy.StaticConfig()
+	if err != nil {
+		return nil, err
+	}
+	dynamic, err := proxy.DynamicConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	task := &nmd.Task{
+		Name:   EdgeProxyJobID,
+		Driver: "containerd-driver",
+		Config: map[string]any{
+			"image":        proxy.Image,
+			"host_network": true,
+			"args":         []string{"--configFile=/local/traefik.yml"},
+		},
+		Resources: &nmd.Resources{
+			CPU:      utils.IntPtr(500),
+			MemoryMB: utils.IntPtr(256),
+		},
+		Templates: []*nmd.Template{
+			{
+				EmbeddedTmpl: utils.StringPtr(static),
+				DestPath:     utils.StringPtr("local/traefik.yml"),
+				ChangeMode:   utils.StringPtr("restart"),
+			},
+			{
+				EmbeddedTmpl: utils.StringPtr(dynamic),
+				DestPath:     utils.StringPtr("local/dynamic.yml"),
+				ChangeMode:   utils.StringPtr("noop"), // watched by the file provider
+			},
+			{
+				// credentials of the DNS providers, e.g. CF_DNS_API_TOKEN
+				EmbeddedTmpl: utils.StringPtr(fmt.Sprintf("{{ with nomadVar %q }}{{ range $key, $value := . }}{{ $key }}={{ $value }}\n{{ end }}{{ end }}", JobVariablePath(EdgeProxyJobID))),
+				DestPath:     utils.StringPtr("secrets/dns.env"),
+				Envvars:      utils.BoolPtr(true),
+			},
+		},
+	}
+
+	ports := []nmd.Port{
+		{Label: "http", Value: proxy.HTTPPort},
+		{Label: "https", Value: proxy.HTTPSPort},
+		{Label: "metrics", Value: proxy.MetricsPort},
+	}
+
+	group := &nmd.TaskGroup{
+		Name:     utils.StringPtr(EdgeProxyJobID + "-group"),
+		Tasks:    []*nmd.Task{task},
+		Networks: []*nmd.NetworkResource{{ReservedPorts: ports}},
+		Services: []*nmd.Service{{
+			Name:      EdgeProxyJobID,
+			PortLabel: "http",
+			Checks: []nmd.ServiceCheck{{
+				Type:      "http",
+				Path:      "/ping",
+				PortLabel: "metrics",
+				Interval:  10 * time.Second,
+				Timeout:   2 * time.Second,
+			}},
+		}},
+		// the ACME accounts and certificates survive restarts and job updates on the node
+		EphemeralDisk: &nmd.EphemeralDisk{
+			Sticky:  utils.BoolPtr(true),
+			Migrate: utils.BoolPtr(true),
+		},
+	}
+
+	job := &nmd.Job{
+		ID:          utils.StringPtr(EdgeProxyJobID),
+		Name:        utils.StringPtr(EdgeProxyJobID),
+		Type:        utils.StringPtr("system"),
+		Datacenters: proxy.Datacenters,
+		TaskGroups:  []*nmd.TaskGroup{group},
+		Meta:        map[string]string{MetaEdgeProxy: "true"},
+	}
+	if proxy.Region != "" {
+		job.Region = utils.StringPtr(proxy.Region)
+	}
+	if proxy.NodePool != "" {
+		job.NodePool = utils.StringPtr(proxy.NodePool)
+	}
+	if proxy.NodeClass != "" {
+		job.Constraints = []*nmd.Constraint{{LTarget: "${node.class}", RTarget: proxy.NodeClass, Operand: "="}}
+	}
+
+	return job, nil
+}
@@ -0,0 +1,233 @@
+package nomad
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"github.com/iuliansafta/control-plane/pkg/utils"
+)
+
+// MetaEgress records the egress rules of an application on its job, whatever the mode
+const MetaEgress = "controlplane_egress"
+
+// MetaConnectService is the service the application registers in the Connect mesh, its
+// intentions are reconciled with MetaAllowFrom and the applications depending on it
+const (
+	MetaConnectService = "controlplane_connect_service"
+	MetaAllowFrom      = "controlplane_allow_from"
+)
+
+// Egress modes, the controller picks the one its cluster supports
+const (
+	EgressModeHints    = "hints"    // the rules are only recorded on the job
+	EgressModeConsul   = "consul"   // service destinations are Connect upstreams allowed by intentions
+	EgressModeIptables = "iptables" // a prestart task firewalls the allocation's network namespace
+)
+
+// DefaultFirewallImage runs the firewall task of the iptables mode, iptables is installed
+// when the image lacks it
+const DefaultFirewallImage = "alpine:3.20"
+
+// firewallTask sets up the rules in the group's network namespace before the application starts
+const firewallTask = "egress-firewall"
+
+// upstreams of the Consul mode listen on consecutive local ports
+const firstUpstreamPort = 21000
+
+// EgressRule allows outbound traffic to a Consul service or an address range
+type EgressRule struct {
+	Service  string // Consul service, e.g. billing-http
+	CIDR     string // e.g. 10.0.0.0/8 or 203.0.113.7/32
+	Ports    []int  // every port when empty, a service is reached on its registered port
+	Protocol string // "tcp" (default) or "udp"
+}
+
+// Egress is the outbound traffic allowed from an application, everything else is denied
+// in the iptables mode
+type Egress struct {
+	Mode          string
+	Rules         []EgressRule
+	FirewallImage string // defaults to DefaultFirewallImage
+}
+
+func (r *EgressRule) protocol() string {
+	if r.Protocol == "" {
+		return "tcp"
+	}
+	return r.Protocol
+}
+
+func (r *EgressRule) Validate() error {
+	if (r.Service == "") == (r.CIDR == "") {
+		return fmt.Errorf("egress rule needs either a service or a CIDR")
+	}
+	if r.CIDR != "" {
+		if _, err := netip.ParsePrefix(r.CIDR); err != nil {
+			return fmt.Errorf("egress rule: invalid CIDR %q", r.CIDR)
+		}
+	}
+	if r.Service != "" && len(r.Ports) > 0 {
+		return fmt.Errorf("egress rule for %s: services are reached on their registered port", r.Service)
+	}
+	for _, port := range r.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("egress rule: invalid port %d", port)
+		}
+	}
+	if r.Protocol != "" && r.Protocol != "tcp" && r.Protocol != "udp" {
+		return fmt.Errorf("egress rule: protocol must be tcp or udp")
+	}
+	return nil
+}
+
+// Validate checks the rules and whether the mode can enforce them for the job
+func (e *Egress) Validate(jt *JobTemplate) error {
+	for _, rule := range e.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	switch e.Mode {
+	case "", EgressModeHints:
+	case EgressModeConsul:
+		for _, rule := range e.Rules {
+			if rule.CIDR != "" {
+				return fmt.Errorf("egress to %s: the cluster enforces egress with Consul intentions, which only cover services", rule.CIDR)
+			}
+		}
+		if jt.NetworkMode != "bridge" || jt.Ports.Label == "" || jt.DisableConsul {
+			return fmt.Errorf("egress through Consul Connect requires bridge networking and a service")
+		}
+	case EgressModeIptables:
+		if jt.NetworkMode != "bridge" {
+			return fmt.Errorf("egress firewalling requires bridge networking, it would firewall the host otherwise")
+		}
+	default:
+		return fmt.Errorf("unknown egress mode %q", e.Mode)
+	}
+
+	return nil
+}
+
+// String summarizes the rules for MetaEgress, e.g. "service:billing-http,tcp:10.0.0.0/8:443"
+func (e *Egress) String() string {
+	rules := make([]string, len(e.Rules))
+	for i, rule := range e.Rules {
+		if rule.Service != "" {
+			rules[i] = "service:" + rule.Service
+			continue
+		}
+		rules[i] = rule.protocol() + ":" + rule.CIDR
+		if len(rule.Ports) > 0 {
+			rules[i] += ":" + joinPorts(rule.Ports, ",")
+		}
+	}
+	return strings.Join(rules, ",")
+}
+
+// Services are the Consul services the application may reach
+func (e *Egress) Services() []string {
+	var services []string
+	for _, rule := range e.Rules {
+		if rule.Service != "" {
+			services = append(services, rule.Service)
+		}
+	}
+	return services
+}
+
+// ConnectService is the service the job registers in the Connect mesh, empty when the job
+// does not join it
+func (jt *JobTemplate) ConnectService() string {
+	if jt.Egress == nil || jt.Egress.Mode != EgressModeConsul || jt.Ports.Label == "" || jt.DisableConsul {
+		return ""
+	}
+	return jt.Name + "-" + jt.Ports.Label
+}
+
+// connect joins the application's service to the Connect mesh with the allowed services as
+// upstreams, the application reaches them on NOMAD_UPSTREAM_ADDR_<service>
+func (e *Egress) connect() *nmd.ConsulConnect {
+	var upstreams []*nmd.ConsulUpstream
+	for i, service := range e.Services() {
+		upstreams = append(upstreams, &nmd.ConsulUpstream{
+			DestinationName: service,
+			LocalBindPort:   firstUpstreamPort + i,
+		})
+	}
+
+	return &nmd.ConsulConnect{
+		SidecarService: &nmd.ConsulSidecarService{
+			Proxy: &nmd.ConsulProxy{Upstreams: upstreams},
+		},
+	}
+}
+
+// firewall is the prestart task denying every outbound connection but DNS and the rules.
+// Service addresses are rendered when the allocation starts.
+func (e *Egress) firewall() *nmd.Task {
+	image := e.FirewallImage
+	if image == "" {
+		image = DefaultFirewallImage
+	}
+
+	var script strings.Builder
+	script.WriteString("set -e\n")
+	script.WriteString("command -v iptables >/dev/null || apk add --no-cache iptables\n")
+	script.WriteString("iptables -A OUTPUT -o lo -j ACCEPT\n")
+	script.WriteString("iptables -A OUTPUT -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT\n")
+	script.WriteString("iptables -A OUTPUT -p udp --dport 53 -j ACCEPT\n")
+	script.WriteString("iptables -A OUTPUT -p tcp --dport 53 -j ACCEPT\n")
+
+	var templates []*nmd.Template
+	for i, rule := range e.Rules {
+		if rule.Service != "" {
+			file := fmt.Sprintf("local/egress-%d.txt", i)
+			templates = append(templates, &nmd.Template{
+				EmbeddedTmpl: utils.StringPtr(fmt.Sprintf("{{ range service %q }}{{ .Address }} {{ .Port }}\n{{ end }}", rule.Service)),
+				DestPath:     utils.StringPtr(file),
+				ChangeMode:   utils.StringPtr("noop"),
+			})
+			fmt.Fprintf(&script, "while read -r address port; do iptables -A OUTPUT -p tcp -d \"$address\" --dport \"$port\" -j ACCEPT; done < /%s\n", file)
+			continue
+		}
+
+		if len(rule.Ports) == 0 {
+			fmt.Fprintf(&script, "iptables -A OUTPUT -p %s -d %s -j ACCEPT\n", rule.protocol(), rule.CIDR)
+		} else {
+			fmt.Fprintf(&script, "iptables -A OUTPUT -p %s -d %s -m multiport --dports %s -j ACCEPT\n", rule.protocol(), rule.CIDR, joinPorts(rule.Ports, ","))
+		}
+	}
+	script.WriteString("iptables -A OUTPUT -j REJECT\n")
+
+	return &nmd.Task{
+		Name:   firewallTask,
+		Driver: "containerd-driver",
+		Config: map[string]any{
+			"image":      image,
+			"entrypoint": []string{"/bin/sh", "-c", script.String()},
+			"cap_add":    []string{"CAP_NET_ADMIN"},
+		},
+		Lifecycle: &nmd.TaskLifecycle{
+			Hook:    nmd.TaskLifecycleHookPrestart,
+			Sidecar: false,
+		},
+		Resources: &nmd.Resources{
+			CPU:      utils.IntPtr(50),
+			MemoryMB: utils.IntPtr(32),
+		},
+		Templates: templates,
+	}
+}
+
+func joinPorts(ports []int, separator string) string {
+	values := make([]string, len(ports))
+	for i, port := range ports {
+		values[i] = strconv.Itoa(port)
+	}
+	return strings.Join(values, separator)
+}
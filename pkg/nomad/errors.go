@@ -0,0 +1,133 @@
+package nomad
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+// ErrorKind is the category of an error returned by the Nomad API
+type ErrorKind string
+
+const (
+	ErrorValidation  ErrorKind = "rejected by Nomad"
+	ErrorPermission  ErrorKind = "permission denied"
+	ErrorNotFound    ErrorKind = "not found"
+	ErrorDriver      ErrorKind = "missing task driver"
+	ErrorQuota       ErrorKind = "quota exceeded"
+	ErrorUnavailable ErrorKind = "Nomad unavailable"
+)
+
+// Error is an error of the Nomad API mapped to its category, with a hint how to resolve it
+type Error struct {
+	Kind   ErrorKind
+	Detail string // what Nomad reported
+	Hint   string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	message := string(e.Kind) + ": " + e.Detail
+	if e.Hint != "" {
+		message += " (" + e.Hint + ")"
+	}
+	return message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether Nomad did not find the object of the request
+func IsNotFound(err error) bool {
+	var nomadErr *Error
+	return errors.As(err, &nomadErr) && nomadErr.Kind == ErrorNotFound || isNotFound(err)
+}
+
+// isNotFound matches the raw errors of the Nomad API for unknown objects
+func isNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "404")
+}
+
+// multierror prefixes the validation errors of Nomad with "N errors occurred:"
+var multierrorPrefix = regexp.MustCompile(`^\d+ errors? occurred:\s*`)
+
+// mapError categorizes an error of the Nomad API, errors it cannot categorize are
+// returned as is
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var nomadErr *Error
+	if errors.As(err, &nomadErr) {
+		return err
+	}
+
+	var netErr *net.OpError
+	if errors.As(err, &netErr) {
+		return &Error{
+			Kind:   ErrorUnavailable,
+			Detail: netErr.Error(),
+			Hint:   "check the controller's -nomad address and that the agent is running",
+			Err:    err,
+		}
+	}
+
+	var response nmd.UnexpectedResponseError
+	if !errors.As(err, &response) || !response.HasStatusCode() {
+		return err
+	}
+
+	detail := cleanDetail(response.Body())
+	if detail == "" {
+		detail = response.StatusText()
+	}
+	lower := strings.ToLower(detail)
+	mapped := &Error{Detail: detail, Err: err}
+
+	switch {
+	case response.StatusCode() == http.StatusForbidden || strings.Contains(lower, "permission denied"):
+		mapped.Kind = ErrorPermission
+		mapped.Hint = "the controller's NOMAD_TOKEN needs an ACL policy granting this in the job's namespace"
+		if strings.Contains(lower, "acl token not found") {
+			mapped.Hint = "NOMAD_TOKEN is not a token of this cluster or expired"
+		}
+	case response.StatusCode() == http.StatusNotFound:
+		mapped.Kind = ErrorNotFound
+	case strings.Contains(lower, "missing drivers") || strings.Contains(lower, "driver") && strings.Contains(lower, "not found"):
+		mapped.Kind = ErrorDriver
+		mapped.Hint = "no client runs the containerd-driver plugin, install it and list it in the plugin directory of the clients"
+	case strings.Contains(lower, "quota"):
+		mapped.Kind = ErrorQuota
+		mapped.Hint = "lower the resources of the spec or raise the quota of the namespace"
+	case response.StatusCode() == http.StatusBadRequest || strings.Contains(lower, "validation failed") || multierrorPrefix.MatchString(strings.TrimSpace(response.Body())):
+		mapped.Kind = ErrorValidation
+		mapped.Hint = "the generated job is invalid for this cluster, adjust the spec"
+	case strings.Contains(lower, "no cluster leader") || strings.Contains(lower, "no path to region") ||
+		response.StatusCode() == http.StatusServiceUnavailable || response.StatusCode() == http.StatusBadGateway:
+		mapped.Kind = ErrorUnavailable
+		mapped.Hint = "the Nomad servers cannot serve requests, check 'nomad server members'"
+	default:
+		return err
+	}
+
+	return mapped
+}
+
+// cleanDetail flattens the multierror of Nomad into a single line
+func cleanDetail(body string) string {
+	body = multierrorPrefix.ReplaceAllString(strings.TrimSpace(body), "")
+
+	var parts []string
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+		if line != "" {
+			parts = append(parts, line)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
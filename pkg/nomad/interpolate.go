@@ -0,0 +1,183 @@
+package nomad
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// EnvTemplateDestination is where the environment values Consul renders are written to
+const EnvTemplateDestination = "local/controlplane.env"
+
+// envExpression matches the ${...} expressions of environment values
+var envExpression = regexp.MustCompile(`\$\{([^{}]+)\}`)
+
+// runtimeVariables are the expressions Nomad sets as variables of the task
+var runtimeVariables = map[string]string{
+	"app.job":        "NOMAD_JOB_ID",
+	"app.region":     "NOMAD_REGION",
+	"app.namespace":  "NOMAD_NAMESPACE",
+	"app.datacenter": "NOMAD_DC",
+	"alloc.id":       "NOMAD_ALLOC_ID",
+	"alloc.index":    "NOMAD_ALLOC_INDEX",
+	"alloc.name":     "NOMAD_ALLOC_NAME",
+}
+
+// nodeVariables are the node the allocation is placed on, written as ${node.<name>} or
+// ${meta.node.<name>}
+var nodeVariables = map[string]string{
+	"datacenter": "node.datacenter",
+	"region":     "node.region",
+	"class":      "node.class",
+	"pool":       "node.pool",
+	"name":       "node.unique.name",
+	"id":         "node.unique.id",
+}
+
+// envValue is an environment value in the form of the task's env block, where Nomad
+// interpolates ${...}, and of a template Consul renders
+type envValue struct {
+	env      strings.Builder
+	template strings.Builder
+	rendered bool // only a template can resolve the value, e.g. the address of a service
+}
+
+func (v *envValue) literal(text string) {
+	v.env.WriteString(text)
+	v.template.WriteString(strings.ReplaceAll(text, "{{", `{{ "{{" }}`))
+}
+
+func (v *envValue) variable(name string) {
+	v.env.WriteString("${" + name + "}")
+	v.template.WriteString(fmt.Sprintf("{{ env %q }}", name))
+}
+
+// ResolveEnvironment resolves the expressions of the environment values, e.g.
+// ${app.name}, ${node.datacenter} or ${port.http}, into the variables Nomad interpolates
+// when it starts the task. Values referring to ${service.<name>} are rendered by Consul
+// into a template instead. app are the values the controller knows, e.g. name and
+// tenant; expressions outside the known namespaces, e.g. ${NOMAD_TASK_DIR}, are left to
+// Nomad.
+func (jt *JobTemplate) ResolveEnvironment(app map[string]string) error {
+	keys := slices.Sorted(maps.Keys(jt.Environment))
+
+	var rendered []string
+	for _, key := range keys {
+		value, err := jt.resolveValue(jt.Environment[key], app)
+		if err != nil {
+			return fmt.Errorf("env %s: %w", key, err)
+		}
+		if !value.rendered {
+			jt.Environment[key] = value.env.String()
+			continue
+		}
+		if strings.Contains(value.template.String(), "\n") {
+			return fmt.Errorf("env %s: values referring to a service cannot span several lines", key)
+		}
+		delete(jt.Environment, key)
+		rendered = append(rendered, key+"="+value.template.String())
+	}
+
+	if len(rendered) > 0 {
+		jt.Templates = append(jt.Templates, Template{
+			Data:        strings.Join(rendered, "\n") + "\n",
+			Destination: EnvTemplateDestination,
+			Env:         true,
+		})
+	}
+	return nil
+}
+
+func (jt *JobTemplate) resolveValue(text string, app map[string]string) (*envValue, error) {
+	value := &envValue{}
+	last := 0
+	for _, match := range envExpression.FindAllStringSubmatchIndex(text, -1) {
+		value.literal(text[last:match[0]])
+		last = match[1]
+		if err := jt.resolveExpression(value, strings.TrimSpace(text[match[2]:match[3]]), app); err != nil {
+			return nil, err
+		}
+	}
+	value.literal(text[last:])
+	return value, nil
+}
+
+func (jt *JobTemplate) resolveExpression(value *envValue, expression string, app map[string]string) error {
+	namespace, name, _ := strings.Cut(expression, ".")
+	switch namespace {
+	case "app":
+		if literal, ok := app[name]; ok {
+			value.literal(literal)
+			return nil
+		}
+		if variable, ok := runtimeVariables[expression]; ok {
+			value.variable(variable)
+			return nil
+		}
+		return unknownExpression(expression, append(slices.Collect(maps.Keys(app)), "job", "region", "namespace", "datacenter"))
+	case "alloc":
+		if variable, ok := runtimeVariables[expression]; ok {
+			value.variable(variable)
+			return nil
+		}
+		return unknownExpression(expression, []string{"id", "index", "name"})
+	case "node":
+		return resolveNode(value, expression, name)
+	case "meta":
+		// node meta, ${meta.node.<name>} is the node itself
+		if node, ok := strings.CutPrefix(name, "node."); ok {
+			return resolveNode(value, expression, node)
+		}
+		value.variable(expression)
+	case "port", "addr", "ip":
+		ports := jt.AllPorts()
+		if len(ports) == 0 {
+			return fmt.Errorf("${%s}: the application exposes no port", expression)
+		}
+		labels := make([]string, len(ports))
+		for i, port := range ports {
+			labels[i] = port.Label
+		}
+		if !slices.Contains(labels, name) {
+			return fmt.Errorf("${%s}: the application exposes no port %s, only %s", expression, name, strings.Join(labels, ", "))
+		}
+		value.variable(fmt.Sprintf("NOMAD_%s_%s", strings.ToUpper(namespace), name))
+	case "service":
+		if name == "" || strings.ContainsAny(name, `"\`) {
+			return fmt.Errorf("${%s}: invalid service name", expression)
+		}
+		if jt.DisableConsul {
+			return fmt.Errorf("${%s}: services are resolved through Consul, which the application does not use", expression)
+		}
+		// the address of a healthy instance, the task restarts when it changes
+		value.rendered = true
+		value.env.WriteString("${" + expression + "}")
+		value.template.WriteString(fmt.Sprintf(`{{ with service %q }}{{ with index . 0 }}{{ .Address }}:{{ .Port }}{{ end }}{{ end }}`, name))
+	default:
+		// attr.*, NOMAD_* and the variables of the task
+		value.variable(expression)
+	}
+	return nil
+}
+
+func resolveNode(value *envValue, expression, name string) error {
+	variable, ok := nodeVariables[name]
+	if !ok && slices.Contains(slices.Collect(maps.Values(nodeVariables)), "node."+name) {
+		// the names of Nomad, e.g. ${node.unique.name}
+		variable, ok = "node."+name, true
+	}
+	if !ok {
+		return unknownExpression(expression, slices.Collect(maps.Keys(nodeVariables)))
+	}
+	value.variable(variable)
+	return nil
+}
+
+func unknownExpression(expression string, names []string) error {
+	sort.Strings(names)
+	namespace, _, _ := strings.Cut(expression, ".")
+	return fmt.Errorf("unknown ${%s}, %s has %s", expression, namespace, strings.Join(names, ", "))
+}
@@ -2,12 +2,37 @@ package nomad
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	nmd "github.com/hashicorp/nomad/api"
 	"github.com/iuliansafta/control-plane/pkg/utils"
 )
 
+// Job meta keys the control plane uses to keep settings on the job itself
+const (
+	MetaIdleTimeout    = "controlplane_idle_timeout"
+	MetaHost           = "controlplane_host"
+	MetaMaxConcurrency = "controlplane_max_concurrency"
+	MetaTimeout        = "controlplane_timeout"
+	// deployments of the same group roll out one at a time
+	MetaConcurrencyGroup = "controlplane_concurrency_group"
+	// rollouts running longer are failed by the controller, and reverted when the revert meta is true
+	MetaRolloutDeadline = "controlplane_rollout_deadline"
+	MetaRolloutRevert   = "controlplane_rollout_revert"
+	// unix seconds of the commit the job version was built from, for the lead time of changes
+	MetaCommitTime = "controlplane_commit_time"
+	// the autoscaling spec of the service as JSON, the controller scales it on its metrics
+	MetaAutoscaling = "controlplane_autoscaling"
+	// prefix of the labels of the spec, ListApplications filters on them
+	MetaLabelPrefix = "controlplane_label_"
+)
+
+// DispatchPayloadFile is where dispatched payloads are written, relative to the task's local/ dir
+const DispatchPayloadFile = "payload"
+
 type Resources struct {
 	CPU         *int
 	Cores       *int
@@ -15,6 +40,12 @@ type Resources struct {
 	MemoryMaxMB *int
 }
 
+type EphemeralDisk struct {
+	SizeMB  *int
+	Migrate bool
+	Sticky  bool
+}
+
 type ServiceCheck struct {
 	Type     string
 	Path     string
@@ -31,6 +62,9 @@ type TraefikSpec struct {
 	EnableSSL           bool
 	SSLHost             string
 	CertResolver        string
+	CertStrategy        string   // CertStrategyAuto, CertStrategyHost or CertStrategyWildcard
+	CertSANs            []string // extra hosts of the certificate, e.g. the other hosts of a multi-domain app
+	CertMain            string   // main domain of the certificate, set by CertPolicy.Apply
 	HealthCheckPath     string
 	HealthCheckInterval string
 	PathPrefix          string
@@ -38,10 +72,55 @@ type TraefikSpec struct {
 	CustomLabels        map[string]string
 }
 
+type Parameterized struct {
+	Payload      string // "optional", "required" or "forbidden"
+	MetaRequired []string
+	MetaOptional []string
+}
+
+type Periodic struct {
+	Schedule        string // cron expression
+	TimeZone        string
+	ProhibitOverlap bool
+}
+
+func (p *Periodic) toNomadPeriodic() *nmd.PeriodicConfig {
+	config := &nmd.PeriodicConfig{
+		Enabled:         utils.BoolPtr(true),
+		Spec:            utils.StringPtr(p.Schedule),
+		SpecType:        utils.StringPtr(nmd.PeriodicSpecCron),
+		ProhibitOverlap: utils.BoolPtr(p.ProhibitOverlap),
+	}
+	if p.TimeZone != "" {
+		config.TimeZone = utils.StringPtr(p.TimeZone)
+	}
+	return config
+}
+
+// Next returns the first run of the schedule after from, in the schedule's time zone
+func (p *Periodic) Next(from time.Time) (time.Time, error) {
+	config := p.toNomadPeriodic()
+	location, err := config.GetLocation()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return config.Next(from.In(location))
+}
+
+// Template is rendered into the task's directory, as environment variables when Env is set
+type Template struct {
+	Data         string
+	Destination  string // relative to the task dir, e.g. secrets/db.env
+	Env          bool
+	ChangeMode   string // what Nomad does when the rendered data changes, restart when empty
+	ChangeSignal string
+}
+
 type Ports struct {
-	Label string
-	Value int
-	To    int
+	Label    string
+	Value    int    // static host port, 0 allocates a dynamic one
+	To       int    // container port in bridge mode
+	Protocol string // http, tcp or udp, informational for Nomad
 }
 
 type JobTemplate struct {
@@ -49,31 +128,174 @@ type JobTemplate struct {
 	Image         string
 	Instances     int
 	Region        string
-	Ports         Ports
+	Namespace     string  // Nomad namespace, the one of the client registering the job when empty
+	Ports         Ports   // the port of the application's service, routed by Traefik
+	ExtraPorts    []Ports // further ports, each registered as a service of its own
 	Environment   map[string]string
 	ResourcesSpec Resources
 	HealthCheck   ServiceCheck
 	Traefik       TraefikSpec
 	DisableConsul bool
 	NetworkMode   string // "bridge" or "host", defaults to "host" if empty
+	Constraints   []Constraint
+	EphemeralDisk *EphemeralDisk
+	Meta          map[string]string
+	Type          string         // "service" or "batch", defaults to "service" if empty
+	Parameterized *Parameterized // Makes the job a template for dispatched jobs
+	Artifacts     []string       // go-getter sources unpacked into the task's local/ dir
+	Periodic      *Periodic      // Launches the job on a cron schedule
+	Volumes       []VolumeMount  // CSI volumes claimed by the task group
+	Entrypoint    []string       // Overrides the image's entrypoint
+	Command       string         // Overrides the image's command
+	Args          []string
+	Templates     []Template
+	Egress        *Egress  // outbound traffic allowed from the task
+	AllowFrom     []string // applications allowed to call the service through the Connect mesh
+	Security      *SecurityContext
+	UI            *UI // description and links on the job's page in the Nomad UI
+	Update        *UpdateStrategy
+	Actions       []Action // commands operators run in the task through RunAction
 }
 
 func BuildJobTemplate(req *JobTemplate) *JobTemplate {
 	return req
 }
 
+// AllPorts are the port of the application's service followed by the extra ports, none
+// when the task is not reachable
+func (jt *JobTemplate) AllPorts() []Ports {
+	if jt.Ports.Label == "" {
+		return nil
+	}
+	return append([]Ports{jt.Ports}, jt.ExtraPorts...)
+}
+
+// Validate checks the parts of the template Nomad would otherwise reject at registration
+func (jt *JobTemplate) Validate() error {
+	for _, constraint := range jt.Constraints {
+		if err := constraint.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := jt.Traefik.Validate(); err != nil {
+		return err
+	}
+
+	if jt.EphemeralDisk != nil {
+		if jt.EphemeralDisk.SizeMB != nil && *jt.EphemeralDisk.SizeMB <= 0 {
+			return fmt.Errorf("ephemeral disk size must be greater than 0")
+		}
+		if jt.EphemeralDisk.Migrate && !jt.EphemeralDisk.Sticky {
+			return fmt.Errorf("ephemeral disk migrate requires sticky to be enabled")
+		}
+	}
+
+	if jt.Egress != nil {
+		if err := jt.Egress.Validate(jt); err != nil {
+			return err
+		}
+	}
+
+	for _, application := range jt.AllowFrom {
+		if application == "" {
+			return fmt.Errorf("allow_from cannot contain an empty application")
+		}
+	}
+
+	if jt.Security != nil {
+		if err := jt.Security.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if jt.Update != nil {
+		if jt.Type == "batch" {
+			return fmt.Errorf("update strategies only apply to services")
+		}
+		if err := jt.Update.Validate(); err != nil {
+			return err
+		}
+	}
+
+	mounted := make(map[string]bool)
+	for _, volume := range jt.Volumes {
+		if err := volume.Validate(); err != nil {
+			return err
+		}
+		if mounted[volume.VolumeID] {
+			return fmt.Errorf("volume %s is mounted twice", volume.VolumeID)
+		}
+		mounted[volume.VolumeID] = true
+	}
+
+	actions := make(map[string]bool)
+	for _, action := range jt.Actions {
+		if err := action.Validate(); err != nil {
+			return err
+		}
+		if actions[action.Name] {
+			return fmt.Errorf("action %s is defined twice", action.Name)
+		}
+		actions[action.Name] = true
+	}
+
+	if jt.Periodic != nil {
+		if jt.Periodic.Schedule == "" {
+			return fmt.Errorf("cron schedule cannot be empty")
+		}
+		if _, err := jt.Periodic.toNomadPeriodic().Next(time.Now()); err != nil {
+			return err
+		}
+		if _, err := time.LoadLocation(jt.Periodic.TimeZone); err != nil {
+			return fmt.Errorf("invalid time zone %q: %w", jt.Periodic.TimeZone, err)
+		}
+	}
+
+	return nil
+}
+
 func (jt *JobTemplate) ToNomadJob() *nmd.Job {
+	jobType := jt.Type
+	if jobType == "" {
+		jobType = "service"
+	}
+
 	job := &nmd.Job{
 		ID:          &jt.Name,
 		Name:        &jt.Name,
-		Type:        utils.StringPtr("service"),
+		Type:        &jobType,
 		Datacenters: []string{"dc1"},
 		TaskGroups:  jt.buildTaskGroup(),
+		Meta:        jt.Meta,
 	}
 
 	if jt.Region != "" {
 		job.Region = &jt.Region
 	}
+	if jt.Namespace != "" {
+		job.Namespace = &jt.Namespace
+	}
+
+	for _, constraint := range jt.Constraints {
+		job.Constraints = append(job.Constraints, constraint.toNomadConstraint())
+	}
+
+	if jt.Periodic != nil {
+		job.Periodic = jt.Periodic.toNomadPeriodic()
+	}
+
+	if jt.UI != nil {
+		job.UI = jt.UI.toNomadUI()
+	}
+
+	if jt.Parameterized != nil {
+		job.ParameterizedJob = &nmd.ParameterizedJobConfig{
+			Payload:      jt.Parameterized.Payload,
+			MetaRequired: jt.Parameterized.MetaRequired,
+			MetaOptional: jt.Parameterized.MetaOptional,
+		}
+	}
 
 	return job
 }
@@ -104,26 +326,39 @@ func (jt *JobTemplate) buildTaskGroup() []*nmd.TaskGroup {
 			Mode: networkMode,
 		}
 
-		var dynamicPorts []nmd.Port
-		if networkMode == "bridge" {
-			dynamicPorts = append(dynamicPorts, nmd.Port{
-				Label: jt.Ports.Label,
-				To:    jt.Ports.To, // Container port
-			})
-		} else {
-			dynamicPorts = append(dynamicPorts, nmd.Port{
-				Label: jt.Ports.Label,
-				Value: jt.Ports.Value, // Host port (0 for dynamic allocation)
-			})
+		for _, port := range jt.AllPorts() {
+			nomadPort := nmd.Port{
+				Label: port.Label,
+				Value: port.Value, // Host port (0 for dynamic allocation)
+			}
+			if networkMode == "bridge" {
+				nomadPort.To = port.To // Container port
+			}
+			if port.Value > 0 {
+				network.ReservedPorts = append(network.ReservedPorts, nomadPort)
+			} else {
+				network.DynamicPorts = append(network.DynamicPorts, nomadPort)
+			}
 		}
 
-		network.DynamicPorts = dynamicPorts
 		networks = append(networks, network)
 	}
 
 	driverConfig := map[string]any{
 		"image": jt.Image,
 	}
+	if len(jt.Entrypoint) > 0 {
+		driverConfig["entrypoint"] = jt.Entrypoint
+	}
+	if jt.Command != "" {
+		driverConfig["command"] = jt.Command
+	}
+	if len(jt.Args) > 0 {
+		driverConfig["args"] = jt.Args
+	}
+	if jt.Security != nil {
+		jt.Security.applyDriverConfig(driverConfig)
+	}
 
 	task := &nmd.Task{
 		Name:      jt.Name,
@@ -133,6 +368,38 @@ func (jt *JobTemplate) buildTaskGroup() []*nmd.TaskGroup {
 		Env:       jt.Environment,
 	}
 
+	for _, action := range jt.Actions {
+		task.Actions = append(task.Actions, action.toNomadAction())
+	}
+
+	for _, source := range jt.Artifacts {
+		task.Artifacts = append(task.Artifacts, &nmd.TaskArtifact{
+			GetterSource: utils.StringPtr(source),
+			RelativeDest: utils.StringPtr("local/"),
+		})
+	}
+
+	for _, template := range jt.Templates {
+		nomadTemplate := &nmd.Template{
+			EmbeddedTmpl: utils.StringPtr(template.Data),
+			DestPath:     utils.StringPtr(template.Destination),
+			Envvars:      utils.BoolPtr(template.Env),
+		}
+		if template.ChangeMode != "" {
+			nomadTemplate.ChangeMode = utils.StringPtr(template.ChangeMode)
+		}
+		if template.ChangeSignal != "" {
+			nomadTemplate.ChangeSignal = utils.StringPtr(template.ChangeSignal)
+		}
+		task.Templates = append(task.Templates, nomadTemplate)
+	}
+
+	if jt.Parameterized != nil && jt.Parameterized.Payload != "forbidden" {
+		task.DispatchPayload = &nmd.DispatchPayloadConfig{
+			File: DispatchPayloadFile,
+		}
+	}
+
 	var services []*nmd.Service
 	if jt.Ports.Label != "" && !jt.DisableConsul {
 		traefikTags := jt.Traefik.GenerateTraefikTags(jt.Name, jt.Ports.Label)
@@ -163,7 +430,23 @@ func (jt *JobTemplate) buildTaskGroup() []*nmd.TaskGroup {
 			service.Checks = []nmd.ServiceCheck{*check}
 		}
 
+		if jt.Egress != nil && jt.Egress.Mode == EgressModeConsul {
+			service.Connect = jt.Egress.connect()
+		}
+
 		services = append(services, service)
+
+		for _, port := range jt.ExtraPorts {
+			tags := []string{"deployment"}
+			if port.Protocol != "" {
+				tags = append(tags, port.Protocol)
+			}
+			services = append(services, &nmd.Service{
+				Name:      jt.Name + "-" + port.Label,
+				PortLabel: port.Label,
+				Tags:      tags,
+			})
+		}
 	}
 
 	taskGroup := &nmd.TaskGroup{
@@ -174,6 +457,42 @@ func (jt *JobTemplate) buildTaskGroup() []*nmd.TaskGroup {
 		Services: services,
 	}
 
+	// batch allocations run once, a failed invocation is reported instead of retried
+	if jt.Type == "batch" {
+		taskGroup.RestartPolicy = &nmd.RestartPolicy{
+			Attempts: utils.IntPtr(0),
+			Mode:     utils.StringPtr("fail"),
+		}
+		taskGroup.ReschedulePolicy = &nmd.ReschedulePolicy{
+			Attempts:  utils.IntPtr(0),
+			Unlimited: utils.BoolPtr(false),
+		}
+	}
+
+	if jt.Update != nil {
+		taskGroup.Update = jt.Update.toNomadUpdate()
+	}
+
+	if jt.Egress != nil && jt.Egress.Mode == EgressModeIptables {
+		taskGroup.Tasks = append([]*nmd.Task{jt.Egress.firewall()}, taskGroup.Tasks...)
+	}
+
+	if len(jt.Volumes) > 0 {
+		taskGroup.Volumes = make(map[string]*nmd.VolumeRequest, len(jt.Volumes))
+		for _, volume := range jt.Volumes {
+			taskGroup.Volumes[volume.VolumeID] = volume.toNomadVolumeRequest()
+			task.VolumeMounts = append(task.VolumeMounts, volume.toNomadVolumeMount())
+		}
+	}
+
+	if jt.EphemeralDisk != nil {
+		taskGroup.EphemeralDisk = &nmd.EphemeralDisk{
+			SizeMB:  jt.EphemeralDisk.SizeMB,
+			Migrate: &jt.EphemeralDisk.Migrate,
+			Sticky:  &jt.EphemeralDisk.Sticky,
+		}
+	}
+
 	return []*nmd.TaskGroup{taskGroup}
 }
 
@@ -234,6 +553,14 @@ func (ts *TraefikSpec) GenerateTraefikTags(serviceName, portLabel string) []stri
 			tags = append(tags, fmt.Sprintf("traefik.http.routers.%s.tls=true", sslRouterName))
 		}
 
+		// routers listing the same domains share a certificate
+		if ts.CertMain != "" {
+			tags = append(tags, fmt.Sprintf("traefik.http.routers.%s.tls.domains[0].main=%s", sslRouterName, ts.CertMain))
+			if len(ts.CertSANs) > 0 {
+				tags = append(tags, fmt.Sprintf("traefik.http.routers.%s.tls.domains[0].sans=%s", sslRouterName, strings.Join(ts.CertSANs, ",")))
+			}
+		}
+
 		if len(ts.Middlewares) > 0 {
 			middlewares := ts.Middlewares[0]
 			for i := 1; i < len(ts.Middlewares); i++ {
@@ -260,6 +587,117 @@ func (ts *TraefikSpec) GenerateTraefikTags(serviceName, portLabel string) []stri
 	return tags
 }
 
+var (
+	traefikHostname = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
+	traefikName     = regexp.MustCompile(`^[a-zA-Z0-9_-]+(@[a-z]+)?$`) // entrypoints, middlewares and cert resolvers
+	// the label keys naming the hosts of a router, Traefik reads them case insensitively
+	traefikRouterHosts = regexp.MustCompile(`(?i)^traefik\.(http|tcp|udp)\.routers\.[^.]+\.(rule|tls\.domains)`)
+)
+
+// Validate checks the values ending up in the router rules and labels, Traefik skips
+// a router with an invalid rule and only logs the error
+func (ts *TraefikSpec) Validate() error {
+	if !ts.Enable {
+		return nil
+	}
+
+	for _, host := range []string{ts.Host, ts.SSLHost} {
+		if host != "" && !traefikHostname.MatchString(host) {
+			return fmt.Errorf("traefik host %q is not a valid hostname", host)
+		}
+	}
+	for _, san := range ts.CertSANs {
+		if !traefikHostname.MatchString(strings.TrimPrefix(san, "*.")) {
+			return fmt.Errorf("certificate SAN %q is not a valid hostname", san)
+		}
+	}
+	if len(ts.CertSANs) > 0 && !ts.EnableSSL {
+		return fmt.Errorf("certificate SANs require SSL")
+	}
+	if ts.CertStrategy != CertStrategyAuto && ts.CertStrategy != CertStrategyHost && ts.CertStrategy != CertStrategyWildcard {
+		return fmt.Errorf("unknown certificate strategy %q", ts.CertStrategy)
+	}
+	if ts.PathPrefix != "" && (!strings.HasPrefix(ts.PathPrefix, "/") || strings.ContainsAny(ts.PathPrefix, "` ")) {
+		return fmt.Errorf("traefik path prefix %q must start with / and cannot contain spaces or backticks", ts.PathPrefix)
+	}
+	if ts.HealthCheckPath != "" && !strings.HasPrefix(ts.HealthCheckPath, "/") {
+		return fmt.Errorf("traefik health check path %q must start with /", ts.HealthCheckPath)
+	}
+	if ts.HealthCheckInterval != "" {
+		interval, err := time.ParseDuration(ts.HealthCheckInterval)
+		if err != nil {
+			return fmt.Errorf("invalid traefik health check interval: %w", err)
+		}
+		if interval <= 0 {
+			return fmt.Errorf("traefik health check interval must be greater than 0")
+		}
+	}
+
+	names := append([]string{ts.Entrypoint, ts.CertResolver}, ts.Middlewares...)
+	for i, name := range names {
+		// entrypoint and cert resolver are optional, middlewares are not
+		if name == "" && i < 2 {
+			continue
+		}
+		if !traefikName.MatchString(name) {
+			return fmt.Errorf("traefik name %q may only contain letters, digits, '-' and '_', optionally with an @provider suffix", name)
+		}
+	}
+
+	for key := range ts.CustomLabels {
+		if key == "" || strings.ContainsAny(key, " =") {
+			return fmt.Errorf("traefik label %q cannot be empty or contain spaces or '='", key)
+		}
+		// the hosts of a job are verified before they are routed, labels cannot route others
+		if traefikRouterHosts.MatchString(key) {
+			return fmt.Errorf("traefik label %q cannot set the rule or TLS domains of a router, use host, ssl_host and cert_sans", key)
+		}
+	}
+
+	return nil
+}
+
+// traefikRouterRule matches the router rule tags of GenerateTraefikTags
+var traefikRouterRule = regexp.MustCompile("^traefik\\.http\\.routers\\.([^.]+)\\.rule=Host\\(`([^`]+)`\\)")
+
+// TraefikURL is the URL Traefik routes to the job, https when it has an SSL router.
+// Empty when the job is not routed by Traefik.
+func TraefikURL(job *nmd.Job) string {
+	url := ""
+	for _, group := range job.TaskGroups {
+		for _, service := range group.Services {
+			for _, tag := range service.Tags {
+				match := traefikRouterRule.FindStringSubmatch(tag)
+				if match == nil {
+					continue
+				}
+				if strings.HasSuffix(match[1], "-secure") {
+					return "https://" + match[2]
+				}
+				if url == "" {
+					url = "http://" + match[2]
+				}
+			}
+		}
+	}
+	return url
+}
+
+// TraefikHosts lists the hosts Traefik routes to the job, in the order of its services
+func TraefikHosts(job *nmd.Job) []string {
+	var hosts []string
+	for _, group := range job.TaskGroups {
+		for _, service := range group.Services {
+			for _, tag := range service.Tags {
+				if match := traefikRouterRule.FindStringSubmatch(tag); match != nil && !slices.Contains(hosts, match[2]) {
+					hosts = append(hosts, match[2])
+				}
+			}
+		}
+	}
+	return hosts
+}
+
 type TraefikOption func(*TraefikSpec)
 
 func NewTraefikSpec(host string, options ...TraefikOption) TraefikSpec {
@@ -0,0 +1,32 @@
+package nomad
+
+import "testing"
+
+func TestTraefikSpecValidateCustomLabels(t *testing.T) {
+	tests := []struct {
+		label   string
+		wantErr bool
+	}{
+		{label: "traefik.http.routers.web.priority"},
+		{label: "traefik.http.middlewares.auth.basicauth.users"},
+		{label: "traefik.http.routers.web.rule", wantErr: true},
+		{label: "traefik.http.routers.other.rule", wantErr: true},
+		{label: "Traefik.HTTP.Routers.web.Rule", wantErr: true},
+		{label: "traefik.http.routers.web-secure.tls.domains[0].main", wantErr: true},
+		{label: "traefik.tcp.routers.db.rule", wantErr: true},
+		{label: "traefik.http.routers.web rule", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			spec := NewTraefikSpec("shop.example.com")
+			spec.CustomLabels[tt.label] = "Host(`bank.example.com`)"
+			err := spec.Validate()
+			if tt.wantErr && err == nil {
+				t.Fatal("got no error, want one")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("got %v, want no error", err)
+			}
+		})
+	}
+}
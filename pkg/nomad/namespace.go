@@ -0,0 +1,143 @@
+package nomad
+
+import (
+	"cmp"
+	"strings"
+	"sync"
+
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+// Namespace meta keys written by the controller
+const (
+	MetaTenant = "controlplane_tenant"
+)
+
+// DefaultNamespace is the Nomad namespace of the jobs without one
+const DefaultNamespace = "default"
+
+// jobNamespaces remembers the namespace of the jobs, job IDs are unique across the
+// namespaces of the controller
+type jobNamespaces struct {
+	mu    sync.Mutex
+	byJob map[string]string
+}
+
+func (j *jobNamespaces) found(jobID, namespace string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.byJob[jobID] = cmp.Or(namespace, DefaultNamespace)
+}
+
+func (j *jobNamespaces) forget(jobID string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(j.byJob, jobID)
+}
+
+func (j *jobNamespaces) lookup(jobID string) (string, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	namespace, ok := j.byJob[jobID]
+	return namespace, ok
+}
+
+// InNamespace returns a client of the namespace, of the default one when empty
+func (nc *NomadClient) InNamespace(namespace string) (*NomadClient, error) {
+	namespace = cmp.Or(namespace, DefaultNamespace)
+	if namespace == nc.namespace {
+		return nc, nil
+	}
+
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	if nc.namespaces == nil {
+		nc.namespaces = make(map[string]*NomadClient)
+	}
+	if client, ok := nc.namespaces[namespace]; ok {
+		return client, nil
+	}
+	client, err := nc.derive(nc.region, namespace)
+	if err != nil {
+		return nil, err
+	}
+	nc.namespaces[namespace] = client
+	return client, nil
+}
+
+// Namespace is the namespace of the client, empty when it finds the jobs in any namespace
+func (nc *NomadClient) Namespace() string {
+	return nc.namespace
+}
+
+// JobNamespace returns the namespace of a job, the default one when no namespace has it.
+// Dispatched and periodic children are in the namespace of their parent.
+func (nc *NomadClient) JobNamespace(jobID string) string {
+	return nc.jobNamespace(jobID)
+}
+
+func (nc *NomadClient) jobNamespace(jobID string) string {
+	if nc.namespace != "" {
+		return nc.namespace
+	}
+	jobID, _, _ = strings.Cut(jobID, "/")
+	if namespace, ok := nc.jobs.lookup(jobID); ok {
+		return namespace
+	}
+
+	stubs, _, err := nc.client.Jobs().List(&nmd.QueryOptions{Namespace: nmd.AllNamespacesNamespace, Prefix: jobID})
+	if err != nil {
+		return DefaultNamespace
+	}
+	namespace := ""
+	for _, stub := range stubs {
+		if stub.ID == jobID && (namespace == "" || stub.Namespace == DefaultNamespace) {
+			namespace = stub.Namespace
+		}
+	}
+	if namespace == "" {
+		return DefaultNamespace // not registered yet
+	}
+	nc.jobs.found(jobID, namespace)
+	return namespace
+}
+
+// jobQuery are the query options of the requests on a job
+func (nc *NomadClient) jobQuery(jobID string) *nmd.QueryOptions {
+	return &nmd.QueryOptions{Namespace: nc.jobNamespace(jobID)}
+}
+
+// writeOptions are the write options registering the job, in its namespace or the one of
+// the client
+func (nc *NomadClient) writeOptions(job *nmd.Job) *nmd.WriteOptions {
+	if job.Namespace == nil || *job.Namespace == "" {
+		namespace := cmp.Or(nc.namespace, DefaultNamespace)
+		job.Namespace = &namespace
+	}
+	return &nmd.WriteOptions{Namespace: *job.Namespace}
+}
+
+// CreateNamespace creates or updates a Nomad namespace
+func (nc *NomadClient) CreateNamespace(name, description string, meta map[string]string) error {
+	_, err := nc.client.Namespaces().Register(&nmd.Namespace{
+		Name:        name,
+		Description: description,
+		Meta:        meta,
+	}, nil)
+	return mapError(err)
+}
+
+// DeleteNamespace deletes a Nomad namespace, it must not contain jobs
+func (nc *NomadClient) DeleteNamespace(name string) error {
+	_, err := nc.client.Namespaces().Delete(name, nil)
+	return mapError(err)
+}
+
+// NamespaceExists reports whether a Nomad namespace exists
+func (nc *NomadClient) NamespaceExists(name string) (bool, error) {
+	_, _, err := nc.client.Namespaces().Info(name, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	return err == nil, mapError(err)
+}
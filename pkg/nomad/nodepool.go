@@ -0,0 +1,34 @@
+package nomad
+
+import (
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+// NodePoolEdge is the node pool of the clients reachable from the internet, the edge proxy
+// runs there when bootstrapped with it
+const NodePoolEdge = "edge"
+
+// NodePools are the node pools of the control plane's conventions with their description,
+// clients join one with node_pool in their agent config
+var NodePools = map[string]string{
+	NodePoolEdge: "Clients reachable from the internet, running the edge proxy",
+}
+
+// NodePoolExists reports whether a Nomad node pool exists
+func (nc *NomadClient) NodePoolExists(name string) (bool, error) {
+	_, _, err := nc.client.NodePools().Info(name, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	return err == nil, mapError(err)
+}
+
+// CreateNodePool creates or updates a Nomad node pool
+func (nc *NomadClient) CreateNodePool(name, description string, meta map[string]string) error {
+	_, err := nc.client.NodePools().Register(&nmd.NodePool{
+		Name:        name,
+		Description: description,
+		Meta:        meta,
+	}, nil)
+	return mapError(err)
+}
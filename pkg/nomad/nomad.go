@@ -1,69 +1,511 @@
 package nomad
 
 import (
-	"log"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
 
 	nmd "github.com/hashicorp/nomad/api"
 )
 
 type NomadClient struct {
-	client *nmd.Client
+	client       *nmd.Client
+	capabilities *Capabilities
+	config       Config
+	address      string
+	region       string
+	namespace    string // of the requests, jobs are found in any namespace when empty
+	jobs         *jobNamespaces
+
+	mu         sync.Mutex
+	regions    map[string]*NomadClient
+	namespaces map[string]*NomadClient
+	pressure   *pressure
 }
 
-// NewNomadClient creates a new Nomad client
-func NewNomadClient(address string) (*NomadClient, error) {
+// Config is the connection to the Nomad agent. The empty fields are read from the
+// environment of the Nomad CLI: NOMAD_ADDR, NOMAD_TOKEN, NOMAD_CACERT, NOMAD_CLIENT_CERT,
+// NOMAD_CLIENT_KEY, NOMAD_TLS_SERVER_NAME and NOMAD_SKIP_VERIFY.
+type Config struct {
+	Address       string
+	Token         string // ACL token of the requests
+	CACert        string // PEM CA bundle verifying the agent's certificate
+	ClientCert    string // PEM client certificate for agents verifying their clients
+	ClientKey     string
+	TLSServerName string // name verified in the agent's certificate, e.g. server.global.nomad
+	TLSSkipVerify bool   // accept any certificate, for tests only
+}
+
+// apiConfig is the configuration of the API client of the region and namespace
+func (c Config) apiConfig(region, namespace string) *nmd.Config {
 	config := nmd.DefaultConfig()
-	config.Address = address
+	config.Address = cmp.Or(c.Address, config.Address)
+	config.SecretID = cmp.Or(c.Token, config.SecretID)
+	config.TLSConfig.CACert = cmp.Or(c.CACert, config.TLSConfig.CACert)
+	config.TLSConfig.ClientCert = cmp.Or(c.ClientCert, config.TLSConfig.ClientCert)
+	config.TLSConfig.ClientKey = cmp.Or(c.ClientKey, config.TLSConfig.ClientKey)
+	config.TLSConfig.TLSServerName = cmp.Or(c.TLSServerName, config.TLSConfig.TLSServerName)
+	config.TLSConfig.Insecure = c.TLSSkipVerify || config.TLSConfig.Insecure
+	config.Region = region
+	config.Namespace = namespace
+	return config
+}
+
+// NewNomadClient creates a new Nomad client
+func NewNomadClient(c Config) (*NomadClient, error) {
+	config := c.apiConfig("", "")
+	if (config.TLSConfig.ClientCert == "") != (config.TLSConfig.ClientKey == "") {
+		return nil, fmt.Errorf("a Nomad client certificate needs both the certificate and the key")
+	}
 
 	client, err := nmd.NewClient(config)
 	if err != nil {
-		log.Fatal("connection with nomad failed")
+		return nil, fmt.Errorf("connection with nomad failed: %w", err)
+	}
+
+	return &NomadClient{
+		client:  client,
+		config:  c,
+		address: config.Address,
+		jobs:    &jobNamespaces{byJob: make(map[string]string)},
+	}, nil
+}
+
+// InRegion returns a client whose requests are served by another region of the
+// federation, the agent forwards them. An empty region is the agent's own.
+func (nc *NomadClient) InRegion(region string) (*NomadClient, error) {
+	if region == "" {
+		return nc, nil
+	}
+
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	if regional, ok := nc.regions[region]; ok {
+		return regional, nil
+	}
+	if nc.regions == nil {
+		nc.regions = make(map[string]*NomadClient)
+	}
 
+	regional, err := nc.derive(region, nc.namespace)
+	if err != nil {
 		return nil, err
 	}
+	nc.regions[region] = regional
+	return regional, nil
+}
 
+// derive creates a client of the same agent for the region and namespace
+func (nc *NomadClient) derive(region, namespace string) (*NomadClient, error) {
+	client, err := nmd.NewClient(nc.config.apiConfig(region, namespace))
+	if err != nil {
+		return nil, err
+	}
 	return &NomadClient{
-		client: client,
+		client:       client,
+		capabilities: nc.capabilities,
+		config:       nc.config,
+		address:      nc.address,
+		region:       region,
+		namespace:    namespace,
+		jobs:         nc.jobs,
+		pressure:     nc.pressure,
 	}, nil
 }
 
 // DeployJob deploys a job to the orchestrator
 func (nc *NomadClient) DeployJob(jobTemplate *JobTemplate) (*nmd.JobRegisterResponse, error) {
-	job := jobTemplate.ToNomadJob()
+	return nc.RegisterJob(jobTemplate.ToNomadJob())
+}
 
+// RegisterJob registers a Nomad job as is, for jobs changed after leaving the template
+func (nc *NomadClient) RegisterJob(job *nmd.Job) (*nmd.JobRegisterResponse, error) {
 	jobs := nc.client.Jobs()
-	resp, _, err := jobs.Register(job, nil)
+	resp, _, err := jobs.Register(job, nc.writeOptions(job))
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
+	nc.jobs.found(*job.ID, *job.Namespace)
+
+	return resp, nil
+}
 
+// PlanJob has the scheduler plan a job without registering it, with the diff against the
+// registered version
+func (nc *NomadClient) PlanJob(job *nmd.Job) (*nmd.JobPlanResponse, error) {
+	resp, _, err := nc.client.Jobs().Plan(job, true, nc.writeOptions(job))
+	if err != nil {
+		return nil, mapError(err)
+	}
 	return resp, nil
 }
 
+// MetaRawJob marks the jobs deployed with DeployRawJob, the templater did not generate them
+const MetaRawJob = "controlplane_raw_job"
+
+// ParseHCLJob has the Nomad servers convert an HCL job specification
+func (nc *NomadClient) ParseHCLJob(hcl string) (*nmd.Job, error) {
+	job, err := nc.client.Jobs().ParseHCL(hcl, true)
+	return job, mapError(err)
+}
+
 // DeleteJob deletes a job from the orchestrator
 func (nc *NomadClient) DeleteJob(jobID string) error {
 	jobs := nc.client.Jobs()
-	_, _, err := jobs.Deregister(jobID, true, nil)
-	return err
+	_, _, err := jobs.Deregister(jobID, true, &nmd.WriteOptions{Namespace: nc.jobNamespace(jobID)})
+	if err == nil {
+		nc.jobs.forget(jobID)
+	}
+	return mapError(err)
 }
 
 // GetJobStatus retrieves the status of a job and its allocations
 func (nc *NomadClient) GetJobStatus(jobID string) (*nmd.Job, []*nmd.AllocationListStub, error) {
 	jobs := nc.client.Jobs()
+	q := nc.jobQuery(jobID)
 
-	job, _, err := jobs.Info(jobID, nil)
+	job, _, err := jobs.Info(jobID, q)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, mapError(err)
 	}
 
-	allocations, _, err := jobs.Allocations(jobID, false, nil)
+	allocations, _, err := jobs.Allocations(jobID, false, q)
 	if err != nil {
-		return job, nil, err
+		return job, nil, mapError(err)
 	}
 
 	return job, allocations, nil
 }
 
+// JobVersion returns the current version of a job, found is false when it does not exist
+func (nc *NomadClient) JobVersion(jobID string) (version uint64, found bool, err error) {
+	job, _, err := nc.client.Jobs().Info(jobID, nc.jobQuery(jobID))
+	if isNotFound(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, mapError(err)
+	}
+	if job.Version != nil {
+		version = *job.Version
+	}
+	return version, true, nil
+}
+
+// JobAtVersion returns a job as it was submitted in a version
+func (nc *NomadClient) JobAtVersion(jobID string, version uint64) (*nmd.Job, error) {
+	versions, _, _, err := nc.client.Jobs().Versions(jobID, false, nc.jobQuery(jobID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	for _, job := range versions {
+		if job.Version != nil && *job.Version == version {
+			return job, nil
+		}
+	}
+	return nil, &Error{Kind: ErrorNotFound, Detail: fmt.Sprintf("version %d of job %s", version, jobID)}
+}
+
+// ListJobs lists all jobs including their meta, of every namespace unless the client is
+// of one
+func (nc *NomadClient) ListJobs() ([]*nmd.JobListStub, error) {
+	opts := &nmd.JobListOptions{
+		Fields: &nmd.JobListFields{Meta: true},
+	}
+
+	jobs, _, err := nc.client.Jobs().ListOptions(opts, &nmd.QueryOptions{Namespace: cmp.Or(nc.namespace, nmd.AllNamespacesNamespace)})
+	for _, job := range jobs {
+		nc.jobs.found(job.ID, job.Namespace)
+	}
+	return jobs, mapError(err)
+}
+
+// LatestDeployment retrieves the most recent deployment of a job, nil if the job never had one
+func (nc *NomadClient) LatestDeployment(jobID string) (*nmd.Deployment, error) {
+	deployment, _, err := nc.client.Jobs().LatestDeployment(jobID, nc.jobQuery(jobID))
+	return deployment, mapError(err)
+}
+
+// Deployments lists the deployments of a job, most recent first
+func (nc *NomadClient) Deployments(jobID string) ([]*nmd.Deployment, error) {
+	deployments, _, err := nc.client.Jobs().Deployments(jobID, false, nc.jobQuery(jobID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].CreateIndex > deployments[j].CreateIndex
+	})
+	return deployments, nil
+}
+
+// Deployment retrieves a deployment
+func (nc *NomadClient) Deployment(deploymentID string) (*nmd.Deployment, error) {
+	deployment, _, err := nc.client.Deployments().Info(deploymentID, nil)
+	return deployment, mapError(err)
+}
+
+// Evaluation retrieves an evaluation, e.g. the one registering a job
+func (nc *NomadClient) Evaluation(evalID string) (*nmd.Evaluation, error) {
+	eval, _, err := nc.client.Evaluations().Info(evalID, nil)
+	return eval, mapError(err)
+}
+
+// JobDeployment retrieves a deployment of the job, its latest one when the ID is empty
+func (nc *NomadClient) JobDeployment(jobID, deploymentID string) (*nmd.Deployment, error) {
+	if deploymentID == "" {
+		deployment, err := nc.LatestDeployment(jobID)
+		if err == nil && deployment == nil {
+			err = fmt.Errorf("job %s has no deployment", jobID)
+		}
+		return deployment, err
+	}
+	deployment, _, err := nc.client.Deployments().Info(deploymentID, nc.jobQuery(jobID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	if deployment.JobID != jobID {
+		return nil, fmt.Errorf("deployment %s is not a deployment of job %s", deploymentID, jobID)
+	}
+	return deployment, nil
+}
+
+// PromoteDeployment promotes the canaries of the task groups of a deployment of the job,
+// of every group when none is given. It returns the evaluation placing the remaining
+// allocations.
+func (nc *NomadClient) PromoteDeployment(jobID, deploymentID string, groups []string) (string, error) {
+	options := &nmd.WriteOptions{Namespace: nc.jobNamespace(jobID)}
+	var resp *nmd.DeploymentUpdateResponse
+	var err error
+	if len(groups) == 0 {
+		resp, _, err = nc.client.Deployments().PromoteAll(deploymentID, options)
+	} else {
+		resp, _, err = nc.client.Deployments().PromoteGroups(deploymentID, groups, options)
+	}
+	if err != nil {
+		return "", mapError(err)
+	}
+	return resp.EvalID, nil
+}
+
+// FailDeployment marks a running deployment of the job failed, Nomad stops placing its
+// allocations and reverts the job when its update block has auto_revert. It returns the
+// evaluation Nomad created and the version the job was reverted to, if any.
+func (nc *NomadClient) FailDeployment(jobID, deploymentID string) (string, *uint64, error) {
+	resp, _, err := nc.client.Deployments().Fail(deploymentID, &nmd.WriteOptions{Namespace: nc.jobNamespace(jobID)})
+	if err != nil {
+		return "", nil, mapError(err)
+	}
+	return resp.EvalID, resp.RevertedJobVersion, nil
+}
+
+// DeploymentAllocations lists the allocations placed by a deployment
+func (nc *NomadClient) DeploymentAllocations(deploymentID string) ([]*nmd.AllocationListStub, error) {
+	allocations, _, err := nc.client.Deployments().Allocations(deploymentID, nil)
+	return allocations, mapError(err)
+}
+
+// WaitForDeployment waits until the deployment of the job's current version finished.
+// Jobs without deployments, like batch jobs, return immediately.
+func (nc *NomadClient) WaitForDeployment(ctx context.Context, jobID string) error {
+	jobs := nc.client.Jobs()
+	q := nc.jobQuery(jobID)
+
+	job, _, err := jobs.Info(jobID, q)
+	if err != nil {
+		return err
+	}
+	if job.Type == nil || *job.Type != "service" {
+		return nil
+	}
+
+	for {
+		deployment, _, err := jobs.LatestDeployment(jobID, q)
+		if err != nil {
+			return err
+		}
+
+		if deployment != nil && deployment.JobVersion >= *job.Version {
+			switch deployment.Status {
+			case nmd.DeploymentStatusSuccessful:
+				return nil
+			case nmd.DeploymentStatusFailed, nmd.DeploymentStatusCancelled:
+				return fmt.Errorf("deployment %s: %s", deployment.Status, deployment.StatusDescription)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("deployment of %s did not finish in time: %w", jobID, ctx.Err())
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// ScaleJob sets the count of a single task group. An empty group selects the
+// job's only task group and is rejected for multi-group jobs.
+func (nc *NomadClient) ScaleJob(jobID, group string, count int) (string, error) {
+	jobs := nc.client.Jobs()
+	namespace := nc.jobNamespace(jobID)
+
+	if group == "" {
+		job, _, err := jobs.Info(jobID, &nmd.QueryOptions{Namespace: namespace})
+		if err != nil {
+			return "", mapError(err)
+		}
+		if len(job.TaskGroups) != 1 {
+			return "", fmt.Errorf("job %s has %d task groups, a task group must be selected", jobID, len(job.TaskGroups))
+		}
+		group = *job.TaskGroups[0].Name
+	}
+
+	message := fmt.Sprintf("scaled to %d by control plane", count)
+	_, _, err := jobs.Scale(jobID, group, &count, message, false, nil, &nmd.WriteOptions{Namespace: namespace})
+	if err != nil {
+		return group, mapError(err)
+	}
+
+	return group, nil
+}
+
+// ScalingEvents returns the scaling events Nomad keeps for each task group of a job,
+// the manual and autoscaler scalings with the count they set
+func (nc *NomadClient) ScalingEvents(jobID string) (map[string][]nmd.ScalingEvent, error) {
+	status, _, err := nc.client.Jobs().ScaleStatus(jobID, nc.jobQuery(jobID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	events := make(map[string][]nmd.ScalingEvent, len(status.TaskGroups))
+	for group, state := range status.TaskGroups {
+		events[group] = state.Events
+	}
+	return events, nil
+}
+
+// RevertJob reverts a job to an earlier version. Version 0 selects the most recent
+// stable version before the current one.
+func (nc *NomadClient) RevertJob(jobID string, version uint64) (uint64, string, error) {
+	jobs := nc.client.Jobs()
+	namespace := nc.jobNamespace(jobID)
+
+	if version == 0 {
+		versions, _, _, err := jobs.Versions(jobID, false, &nmd.QueryOptions{Namespace: namespace})
+		if err != nil {
+			return 0, "", mapError(err)
+		}
+
+		// versions are sorted newest first, the first one is the current version
+		found := false
+		for _, job := range versions[min(1, len(versions)):] {
+			if job.Stable != nil && *job.Stable {
+				version, found = *job.Version, true
+				break
+			}
+		}
+		if !found {
+			return 0, "", fmt.Errorf("job %s has no stable version to revert to", jobID)
+		}
+	}
+
+	resp, _, err := jobs.Revert(jobID, version, nil, &nmd.WriteOptions{Namespace: namespace}, "", "")
+	if err != nil {
+		return version, "", mapError(err)
+	}
+
+	return version, resp.EvalID, nil
+}
+
+// DispatchJob dispatches an instance of a parameterized job
+func (nc *NomadClient) DispatchJob(jobID string, payload []byte, meta map[string]string) (*nmd.JobDispatchResponse, error) {
+	resp, _, err := nc.client.Jobs().Dispatch(jobID, meta, payload, "", &nmd.WriteOptions{Namespace: nc.jobNamespace(jobID)})
+	return resp, mapError(err)
+}
+
+// WaitForCompletion waits until an allocation of a batch job reached a terminal state
+func (nc *NomadClient) WaitForCompletion(ctx context.Context, jobID string) (*nmd.AllocationListStub, error) {
+	q := nc.jobQuery(jobID)
+	for {
+		allocations, _, err := nc.client.Jobs().Allocations(jobID, false, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, alloc := range allocations {
+			switch alloc.ClientStatus {
+			case nmd.AllocClientStatusComplete, nmd.AllocClientStatusFailed, nmd.AllocClientStatusLost:
+				return alloc, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// ReadTaskLog returns up to limit bytes from the end of a task's stdout or stderr log
+func (nc *NomadClient) ReadTaskLog(allocID, task, logType string, limit int64) (string, error) {
+	alloc, _, err := nc.client.Allocations().Info(allocID, nil)
+	if err != nil {
+		return "", err
+	}
+
+	reader, err := nc.client.AllocFS().Cat(alloc, fmt.Sprintf("alloc/logs/%s.%s.0", task, logType), nil)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) > limit {
+		data = data[int64(len(data))-limit:]
+	}
+
+	return string(data), nil
+}
+
+// StreamTaskLog streams a task's stdout or stderr log from offset bytes before its end,
+// across rotated files. Without follow the frames end with the log, otherwise they end
+// when the context is cancelled or the task stops.
+func (nc *NomadClient) StreamTaskLog(ctx context.Context, allocID, task, logType string, offset int64, follow bool) (<-chan *nmd.StreamFrame, <-chan error, error) {
+	alloc, _, err := nc.client.Allocations().Info(allocID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	frames, errs := nc.client.AllocFS().Logs(alloc, follow, task, logType, nmd.OriginEnd, offset, ctx.Done(), (&nmd.QueryOptions{}).WithContext(ctx))
+	return frames, errs, nil
+}
+
+// TaskUsage returns the CPU in MHz and the resident memory in MB a task of an allocation
+// uses, as the Nomad client of the allocation's node measured them last
+func (nc *NomadClient) TaskUsage(allocID, task string) (float64, int64, error) {
+	stats, err := nc.client.Allocations().Stats(&nmd.Allocation{ID: allocID}, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	usage, ok := stats.Tasks[task]
+	if !ok || usage.ResourceUsage == nil || usage.ResourceUsage.CpuStats == nil || usage.ResourceUsage.MemoryStats == nil {
+		return 0, 0, fmt.Errorf("no usage of task %s in allocation %s", task, allocID)
+	}
+	memory := usage.ResourceUsage.MemoryStats.RSS
+	if memory == 0 {
+		// cgroups v2 do not measure the RSS
+		memory = usage.ResourceUsage.MemoryStats.Usage
+	}
+	return usage.ResourceUsage.CpuStats.TotalTicks, int64(memory / 1024 / 1024), nil
+}
+
 // HealthCheck checks the health of the Nomad connection
 func (nc *NomadClient) HealthCheck() error {
 	agent := nc.client.Agent()
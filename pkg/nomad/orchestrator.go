@@ -0,0 +1,214 @@
+package nomad
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+
+	"github.com/iuliansafta/control-plane/pkg/orchestrator"
+	"github.com/iuliansafta/control-plane/pkg/utils"
+)
+
+// the Nomad client is the orchestrator supporting every feature of the control plane
+var _ orchestrator.Orchestrator = (*NomadClient)(nil)
+
+// Deploy registers the application as a service job with one task routed by Traefik
+func (nc *NomadClient) Deploy(ctx context.Context, app *orchestrator.Application) (string, error) {
+	jobTemplate := &JobTemplate{
+		Name:        app.ID,
+		Image:       app.Image,
+		Instances:   app.Instances,
+		Environment: app.Env,
+		ResourcesSpec: Resources{
+			CPU:      utils.IntPtr(int(app.CPU * 10)),
+			MemoryMB: utils.IntPtr(int(app.MemoryMB)),
+		},
+		Traefik: TraefikSpec{
+			Enable:          app.Host != "",
+			Host:            app.Host,
+			EnableSSL:       app.SSL,
+			HealthCheckPath: app.HealthPath,
+		},
+		NetworkMode: "bridge",
+		Meta:        make(map[string]string),
+		Type:        "service",
+	}
+	for key, value := range app.Labels {
+		jobTemplate.Meta[MetaLabelPrefix+key] = value
+	}
+	for i, port := range app.Ports {
+		nomadPort := Ports{Label: port.Label, To: port.ContainerPort, Protocol: port.Protocol}
+		if i == 0 {
+			jobTemplate.Ports = nomadPort
+		} else {
+			jobTemplate.ExtraPorts = append(jobTemplate.ExtraPorts, nomadPort)
+		}
+	}
+	// a nil config only renders the annotations
+	jobTemplate.UI = (*UIConfig)(nil).Render(nil, app.Annotations)
+
+	resp, err := nc.DeployJob(jobTemplate)
+	if err != nil {
+		return "", err
+	}
+	return resp.EvalID, nil
+}
+
+// Delete purges the job of the application
+func (nc *NomadClient) Delete(ctx context.Context, id string) error {
+	return notFound(nc.DeleteJob(id))
+}
+
+// Status counts the allocations of the job by client status and deployment health
+func (nc *NomadClient) Status(ctx context.Context, id string) (*orchestrator.Status, error) {
+	job, allocations, err := nc.GetJobStatus(id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return jobStatus(job, allocations), nil
+}
+
+// List returns the status of every job the control plane deployed
+func (nc *NomadClient) List(ctx context.Context) ([]*orchestrator.Status, error) {
+	jobs, err := nc.ListJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []*orchestrator.Status
+	for _, stub := range jobs {
+		// dispatched and periodic runs belong to their parent, backup and add-on jobs to their application
+		if stub.ParentID != "" || stub.Meta[MetaBackupOf] != "" || stub.Meta[MetaAddOnOf] != "" || stub.Meta[MetaGreenOf] != "" {
+			continue
+		}
+		job, allocations, err := nc.GetJobStatus(stub.ID)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, jobStatus(job, allocations))
+	}
+	return statuses, nil
+}
+
+// Scale sets the count of the job's only task group
+func (nc *NomadClient) Scale(ctx context.Context, id string, instances int) (int, error) {
+	job, _, err := nc.GetJobStatus(id)
+	if err != nil {
+		return 0, notFound(err)
+	}
+	if len(job.TaskGroups) != 1 {
+		return 0, fmt.Errorf("job %s has %d task groups, scale them with ScaleJob", id, len(job.TaskGroups))
+	}
+	previous := 0
+	if job.TaskGroups[0].Count != nil {
+		previous = *job.TaskGroups[0].Count
+	}
+	if _, err := nc.ScaleJob(id, *job.TaskGroups[0].Name, instances); err != nil {
+		return previous, err
+	}
+	return previous, nil
+}
+
+// Logs reads the end of the log of the allocation's first task
+func (nc *NomadClient) Logs(ctx context.Context, id, instance string, lines int, stderr bool) ([]string, error) {
+	_, allocations, err := nc.GetJobStatus(id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	slices.SortFunc(allocations, func(a, b *nmd.AllocationListStub) int { return cmp.Compare(b.CreateIndex, a.CreateIndex) })
+	index := slices.IndexFunc(allocations, func(alloc *nmd.AllocationListStub) bool {
+		return instance == "" || strings.HasPrefix(alloc.ID, instance)
+	})
+	if index < 0 {
+		return nil, fmt.Errorf("allocation %s of job %s: %w", instance, id, orchestrator.ErrNotFound)
+	}
+	alloc := allocations[index]
+
+	tasks := make([]string, 0, len(alloc.TaskStates))
+	for task := range alloc.TaskStates {
+		tasks = append(tasks, task)
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("allocation %s has not started a task yet", alloc.ID)
+	}
+	slices.Sort(tasks)
+
+	logType := "stdout"
+	if stderr {
+		logType = "stderr"
+	}
+	// lines of up to 512 bytes on average
+	output, err := nc.ReadTaskLog(alloc.ID, tasks[0], logType, int64(lines)*512)
+	if err != nil {
+		return nil, err
+	}
+	logLines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	return logLines[max(0, len(logLines)-lines):], nil
+}
+
+func (nc *NomadClient) String() string {
+	return orchestrator.SchedulerNomad
+}
+
+func jobStatus(job *nmd.Job, allocations []*nmd.AllocationListStub) *orchestrator.Status {
+	status := &orchestrator.Status{ID: *job.ID, Labels: make(map[string]string)}
+	if job.Status != nil {
+		status.Status = *job.Status
+	}
+	if job.SubmitTime != nil {
+		status.UpdatedAt = time.Unix(0, *job.SubmitTime)
+	}
+	for key, value := range job.Meta {
+		if label, ok := strings.CutPrefix(key, MetaLabelPrefix); ok {
+			status.Labels[label] = value
+		}
+	}
+	for _, group := range job.TaskGroups {
+		if group.Count != nil {
+			status.Desired += *group.Count
+		}
+		for _, task := range group.Tasks {
+			if image, ok := task.Config["image"].(string); ok && status.Image == "" {
+				status.Image = image
+			}
+		}
+	}
+
+	for _, alloc := range allocations {
+		if alloc.DesiredStatus != nmd.AllocDesiredStatusRun && alloc.ClientStatus != nmd.AllocClientStatusFailed {
+			continue
+		}
+		instance := orchestrator.Instance{
+			ID:        alloc.ID,
+			Node:      alloc.NodeName,
+			Status:    alloc.ClientStatus,
+			StartedAt: time.Unix(0, alloc.CreateTime),
+		}
+		switch alloc.ClientStatus {
+		case nmd.AllocClientStatusRunning:
+			status.Running++
+			// allocations outside a deployment are healthy once running
+			instance.Healthy = alloc.DeploymentStatus == nil || alloc.DeploymentStatus.Healthy == nil || *alloc.DeploymentStatus.Healthy
+		case nmd.AllocClientStatusFailed, nmd.AllocClientStatusLost:
+			status.Failed++
+		}
+		if instance.Healthy {
+			status.Healthy++
+		}
+		status.Instances = append(status.Instances, instance)
+	}
+	return status
+}
+
+// notFound wraps orchestrator.ErrNotFound into the errors of unknown jobs
+func notFound(err error) error {
+	if err != nil && IsNotFound(err) {
+		return fmt.Errorf("%w: %w", orchestrator.ErrNotFound, err)
+	}
+	return err
+}
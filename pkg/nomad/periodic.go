@@ -0,0 +1,54 @@
+package nomad
+
+import (
+	"fmt"
+	"sort"
+
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+// ForcePeriodicRun launches a periodic job immediately, outside of its schedule
+func (nc *NomadClient) ForcePeriodicRun(jobID string) (string, error) {
+	evalID, _, err := nc.client.Jobs().PeriodicForce(jobID, &nmd.WriteOptions{Namespace: nc.jobNamespace(jobID)})
+	return evalID, mapError(err)
+}
+
+// SetPeriodicEnabled pauses or resumes the schedule of a periodic job
+func (nc *NomadClient) SetPeriodicEnabled(jobID string, enabled bool) error {
+	jobs := nc.client.Jobs()
+
+	job, _, err := jobs.Info(jobID, nc.jobQuery(jobID))
+	if err != nil {
+		return mapError(err)
+	}
+	if job.Periodic == nil {
+		return fmt.Errorf("job %s is not periodic", jobID)
+	}
+
+	job.Periodic.Enabled = &enabled
+	_, _, err = jobs.Register(job, nc.writeOptions(job))
+	return mapError(err)
+}
+
+// PeriodicRuns lists the jobs launched by a periodic job, most recent first
+func (nc *NomadClient) PeriodicRuns(jobID string) ([]*nmd.JobListStub, error) {
+	q := nc.jobQuery(jobID)
+	q.Prefix = jobID + "/periodic-"
+	children, _, err := nc.client.Jobs().List(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []*nmd.JobListStub
+	for _, child := range children {
+		if child.ParentID == jobID {
+			runs = append(runs, child)
+		}
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].SubmitTime > runs[j].SubmitTime
+	})
+
+	return runs, nil
+}
@@ -0,0 +1,163 @@
+package nomad
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+// Modes of the controller under Nomad API pressure, the work of the lowest priority is
+// shed first and mutating operations never are
+const (
+	ModeNormal   = "normal"
+	ModeDegraded = "degraded" // background reconciliation is paused
+	ModeShedding = "shedding" // status is served from cache as well
+)
+
+// a mode is left once the latency fell below this share of its threshold, so that the
+// controller does not flap around a threshold
+const pressureRecovery = 0.8
+
+// PressureThresholds are the Nomad API latencies the controller degrades at, a zero
+// threshold disables its mode
+type PressureThresholds struct {
+	Degraded time.Duration
+	Shedding time.Duration
+	Window   time.Duration // latency is averaged over the probes of the window
+}
+
+// PressureStatus is how the controller copes with the latency of the Nomad API
+type PressureStatus struct {
+	Mode    string
+	Latency time.Duration // average over the window
+	Since   time.Time     // when the controller switched to the mode
+}
+
+// pressure tracks the latency of the Nomad API from periodic probes
+type pressure struct {
+	thresholds PressureThresholds
+
+	mu      sync.Mutex
+	samples []pressureSample
+	mode    string
+	since   time.Time
+}
+
+type pressureSample struct {
+	at      time.Time
+	latency time.Duration
+}
+
+// MonitorPressure probes the latency of the Nomad API every interval until ctx is done,
+// Pressure reports the mode the controller is in from then on
+func (nc *NomadClient) MonitorPressure(ctx context.Context, thresholds PressureThresholds, interval time.Duration) {
+	p := &pressure{thresholds: thresholds, mode: ModeNormal, since: time.Now()}
+	nc.mu.Lock()
+	nc.pressure = p
+	nc.mu.Unlock()
+
+	// a probe timing out counts twice the shedding latency
+	timeout := 2 * max(thresholds.Shedding, thresholds.Degraded)
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, timeout)
+		started := time.Now()
+		_, _, err := nc.client.Jobs().List((&nmd.QueryOptions{PerPage: 1}).WithContext(probeCtx))
+		latency := time.Since(started)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			latency = max(latency, timeout)
+		}
+		p.observe(latency)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Pressure reports the mode of the controller, normal when the latency is not monitored
+func (nc *NomadClient) Pressure() PressureStatus {
+	nc.mu.Lock()
+	p := nc.pressure
+	nc.mu.Unlock()
+
+	if p == nil {
+		return PressureStatus{Mode: ModeNormal}
+	}
+	return p.status()
+}
+
+func (p *pressure) observe(latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.samples = append(p.samples, pressureSample{at: time.Now(), latency: latency})
+	p.evaluate()
+}
+
+func (p *pressure) status() PressureStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	latency := p.evaluate()
+	return PressureStatus{Mode: p.mode, Latency: latency, Since: p.since}
+}
+
+// evaluate drops the samples outside of the window and switches mode on the average
+// latency of the others, without samples the controller is back to normal
+func (p *pressure) evaluate() time.Duration {
+	cutoff := time.Now().Add(-p.thresholds.Window)
+	kept := p.samples[:0]
+	var total time.Duration
+	for _, sample := range p.samples {
+		if sample.at.After(cutoff) {
+			kept = append(kept, sample)
+			total += sample.latency
+		}
+	}
+	p.samples = kept
+
+	var latency time.Duration
+	if len(kept) > 0 {
+		latency = total / time.Duration(len(kept))
+	}
+
+	exceeds := func(threshold time.Duration, mode string) bool {
+		if threshold <= 0 {
+			return false
+		}
+		if p.mode == mode || p.mode == ModeShedding && mode == ModeDegraded {
+			return float64(latency) >= pressureRecovery*float64(threshold)
+		}
+		return latency >= threshold
+	}
+
+	mode := ModeNormal
+	switch {
+	case exceeds(p.thresholds.Shedding, ModeShedding):
+		mode = ModeShedding
+	case exceeds(p.thresholds.Degraded, ModeDegraded):
+		mode = ModeDegraded
+	}
+	if mode != p.mode {
+		log.Printf("Nomad API latency averages %s, switching from %s to %s mode", latency.Round(time.Millisecond), p.mode, mode)
+		p.mode, p.since = mode, time.Now()
+	}
+
+	return latency
+}
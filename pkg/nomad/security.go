@@ -0,0 +1,114 @@
+package nomad
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// SeccompProfileDir is where the clients keep the seccomp profiles, a profile named
+// "strict" is read from <SeccompProfileDir>/strict.json
+const SeccompProfileDir = "/etc/nomad/seccomp"
+
+// Seccomp profiles with a special meaning
+const (
+	SeccompDefault    = "default"    // the runtime's default profile
+	SeccompUnconfined = "unconfined" // no syscall filtering
+)
+
+var (
+	profileName    = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+	capabilityName = regexp.MustCompile(`^CAP_[A-Z_]+$`)
+)
+
+// SecurityContext restricts what the task's processes may do
+type SecurityContext struct {
+	NoNewPrivileges  bool
+	SeccompProfile   string   // SeccompDefault, SeccompUnconfined or a profile in SeccompProfileDir
+	AppArmorProfile  string   // loaded on the clients
+	DropCapabilities []string // e.g. NET_RAW or ALL
+}
+
+// normalizeCapability returns the capability as the driver expects it, e.g. CAP_NET_RAW
+func normalizeCapability(capability string) string {
+	capability = strings.ToUpper(capability)
+	if capability == "ALL" || strings.HasPrefix(capability, "CAP_") {
+		return capability
+	}
+	return "CAP_" + capability
+}
+
+func (sc *SecurityContext) Validate() error {
+	if sc.SeccompProfile != "" && !profileName.MatchString(sc.SeccompProfile) {
+		return fmt.Errorf("invalid seccomp profile %q", sc.SeccompProfile)
+	}
+	if sc.AppArmorProfile != "" && !profileName.MatchString(sc.AppArmorProfile) {
+		return fmt.Errorf("invalid AppArmor profile %q", sc.AppArmorProfile)
+	}
+	for _, capability := range sc.DropCapabilities {
+		if normalized := normalizeCapability(capability); normalized != "ALL" && !capabilityName.MatchString(normalized) {
+			return fmt.Errorf("invalid capability %q", capability)
+		}
+	}
+	return nil
+}
+
+// WithDefaults fills the settings the context leaves unset from defaults, e.g. a tenant's
+// policy. Dropped capabilities add up and no new privileges cannot be turned off.
+func (sc *SecurityContext) WithDefaults(defaults *SecurityContext) *SecurityContext {
+	if defaults == nil {
+		return sc
+	}
+	if sc == nil {
+		sc = &SecurityContext{}
+	}
+
+	merged := &SecurityContext{
+		NoNewPrivileges:  sc.NoNewPrivileges || defaults.NoNewPrivileges,
+		SeccompProfile:   sc.SeccompProfile,
+		AppArmorProfile:  sc.AppArmorProfile,
+		DropCapabilities: slices.Clone(sc.DropCapabilities),
+	}
+	if merged.SeccompProfile == "" {
+		merged.SeccompProfile = defaults.SeccompProfile
+	}
+	if merged.AppArmorProfile == "" {
+		merged.AppArmorProfile = defaults.AppArmorProfile
+	}
+	for _, capability := range defaults.DropCapabilities {
+		if !slices.Contains(merged.DropCapabilities, capability) {
+			merged.DropCapabilities = append(merged.DropCapabilities, capability)
+		}
+	}
+	return merged
+}
+
+// applyDriverConfig maps the context onto the containerd driver's task config
+func (sc *SecurityContext) applyDriverConfig(config map[string]any) {
+	if sc.NoNewPrivileges {
+		config["no_new_privileges"] = true
+	}
+
+	switch sc.SeccompProfile {
+	case "", SeccompUnconfined:
+	case SeccompDefault:
+		config["seccomp"] = true
+	default:
+		config["seccomp"] = true
+		config["seccomp_profile"] = path.Join(SeccompProfileDir, sc.SeccompProfile+".json")
+	}
+
+	if sc.AppArmorProfile != "" {
+		config["apparmor_profile"] = sc.AppArmorProfile
+	}
+
+	if len(sc.DropCapabilities) > 0 {
+		capabilities := make([]string, len(sc.DropCapabilities))
+		for i, capability := range sc.DropCapabilities {
+			capabilities[i] = normalizeCapability(capability)
+		}
+		config["cap_drop"] = capabilities
+	}
+}
@@ -0,0 +1,361 @@
+package nomad
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+// Job meta keys of the green job of a blue-green or canary-traffic rollout, set when it is
+// registered. Changing them would replace its allocations, the weight of a step is only
+// kept in the tags of its service.
+const (
+	MetaGreenOf       = "controlplane_green_of"
+	MetaSplitStrategy = "controlplane_split_strategy"
+	MetaSplitWeights  = "controlplane_split_weights"
+	MetaSplitInterval = "controlplane_split_interval"
+	MetaSplitManual   = "controlplane_split_manual"
+	MetaSplitCount    = "controlplane_split_count"
+)
+
+// MetaPromotedFrom marks the job of an application promoted from its green job, as
+// <green job>@<version>
+const MetaPromotedFrom = "controlplane_promoted_from"
+
+// Deploy strategies of services
+const (
+	StrategyRolling       = "rolling"
+	StrategyBlueGreen     = "blue-green"
+	StrategyCanaryTraffic = "canary-traffic"
+)
+
+// DefaultSplitInterval is how long a step of a traffic split holds by default
+const DefaultSplitInterval = time.Minute
+
+// splitRouterPriority puts the routers of the green job ahead of those of the running job,
+// Traefik ranks routers without a priority by the length of their rule
+const splitRouterPriority = 10000
+
+// GreenJobID is the job running the new version of an application next to its job
+func GreenJobID(jobID string) string {
+	return jobID + "-green"
+}
+
+// TrafficSplit shifts the traffic of an application from its running job to the green job
+// of its new version in steps. After the last step the green job is promoted: the job of
+// the application is updated to the new version and the green job is torn down.
+type TrafficSplit struct {
+	Strategy string        // StrategyBlueGreen or StrategyCanaryTraffic
+	Weights  []int         // percent of the traffic routed to the green job per step, increasing
+	Interval time.Duration // how long a step holds before the next one
+	Manual   bool          // wait for a promotion after the last step
+	Count    int           // instances of the application once promoted
+}
+
+func (t *TrafficSplit) Validate() error {
+	if t.Strategy != StrategyBlueGreen && t.Strategy != StrategyCanaryTraffic {
+		return fmt.Errorf("unknown deploy strategy %q", t.Strategy)
+	}
+	if len(t.Weights) == 0 {
+		return fmt.Errorf("traffic shift needs at least one step")
+	}
+	previous := 0
+	for _, weight := range t.Weights {
+		if weight <= previous || weight > 100 {
+			return fmt.Errorf("traffic shift weights must increase between 1 and 100, got %v", t.Weights)
+		}
+		previous = weight
+	}
+	if t.Interval < 0 {
+		return fmt.Errorf("traffic shift interval cannot be negative")
+	}
+	return nil
+}
+
+// Next returns the weight of the step after the given weight, false after the last step
+func (t *TrafficSplit) Next(weight int) (int, bool) {
+	for _, step := range t.Weights {
+		if step > weight {
+			return step, true
+		}
+	}
+	return weight, false
+}
+
+// SplitOf reads the traffic split of a green job, false for any other job
+func SplitOf(job *nmd.Job) (*TrafficSplit, bool) {
+	if job.Meta[MetaGreenOf] == "" {
+		return nil, false
+	}
+	split := &TrafficSplit{
+		Strategy: job.Meta[MetaSplitStrategy],
+		Interval: DefaultSplitInterval,
+	}
+	for _, weight := range strings.Split(job.Meta[MetaSplitWeights], ",") {
+		if value, err := strconv.Atoi(weight); err == nil {
+			split.Weights = append(split.Weights, value)
+		}
+	}
+	if interval, err := time.ParseDuration(job.Meta[MetaSplitInterval]); err == nil {
+		split.Interval = interval
+	}
+	split.Manual, _ = strconv.ParseBool(job.Meta[MetaSplitManual])
+	split.Count, _ = strconv.Atoi(job.Meta[MetaSplitCount])
+	return split, true
+}
+
+// GreenJob turns the job of an application's new version into its green job, running count
+// instances next to the running job. The jobs, services and Traefik routers are renamed,
+// the routers take precedence over those of the running job and send the traffic to a
+// weighted service splitting it between both jobs, none to the green job yet. The green job
+// reads the variable of the application from its own path.
+func (t *TrafficSplit) GreenJob(job, running *nmd.Job, count int) (*nmd.Job, error) {
+	primaryService, ok := routedService(running)
+	if !ok {
+		return nil, fmt.Errorf("job %s is not routed by Traefik", *running.ID)
+	}
+
+	green, err := copyJob(job)
+	if err != nil {
+		return nil, err
+	}
+	jobID := *job.ID
+	greenID := GreenJobID(jobID)
+	renameJob(green, jobID, greenID)
+
+	routed := false
+	for _, group := range green.TaskGroups {
+		group.Count = &count
+		// the green job is the canary, Nomad places its allocations right away
+		if group.Update != nil {
+			group.Update.Canary = nil
+			group.Update.AutoPromote = nil
+		}
+		for _, service := range group.Services {
+			if !slices.Contains(service.Tags, "traefik.enable=true") {
+				continue
+			}
+			routed = true
+			for _, router := range routers(service.Tags) {
+				service.Tags = append(service.Tags,
+					fmt.Sprintf("traefik.http.routers.%s.priority=%d", router, splitRouterPriority),
+					fmt.Sprintf("traefik.http.routers.%s.service=%s", router, splitService(greenID)),
+				)
+			}
+			service.Tags = append(service.Tags,
+				fmt.Sprintf("traefik.http.services.%s.loadbalancer.passhostheader=true", greenID),
+				fmt.Sprintf("traefik.http.services.%s.weighted.services[0].name=%s@consulcatalog", splitService(greenID), primaryService),
+				fmt.Sprintf("traefik.http.services.%s.weighted.services[1].name=%s@consulcatalog", splitService(greenID), greenID),
+			)
+			service.Tags = append(service.Tags, weightTags(greenID, 0)...)
+		}
+	}
+	if !routed {
+		return nil, fmt.Errorf("the new version of %s is not routed by Traefik", jobID)
+	}
+
+	green.Meta[MetaGreenOf] = jobID
+	green.Meta[MetaSplitStrategy] = t.Strategy
+	weights := make([]string, len(t.Weights))
+	for i, weight := range t.Weights {
+		weights[i] = strconv.Itoa(weight)
+	}
+	green.Meta[MetaSplitWeights] = strings.Join(weights, ",")
+	green.Meta[MetaSplitInterval] = t.Interval.String()
+	green.Meta[MetaSplitManual] = strconv.FormatBool(t.Manual)
+	green.Meta[MetaSplitCount] = strconv.Itoa(t.Count)
+	return green, nil
+}
+
+// GreenWeight is the percent of the traffic the routers of a green job send to it
+func GreenWeight(green *nmd.Job) int {
+	prefix := fmt.Sprintf("traefik.http.services.%s.weighted.services[1].weight=", splitService(*green.ID))
+	for _, group := range green.TaskGroups {
+		for _, service := range group.Services {
+			for _, tag := range service.Tags {
+				if weight, ok := strings.CutPrefix(tag, prefix); ok {
+					value, _ := strconv.Atoi(weight)
+					return value
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// SetGreenWeight routes the percent of the traffic to the green job, the tags of its
+// services are updated in place
+func SetGreenWeight(green *nmd.Job, weight int) {
+	prefix := fmt.Sprintf("traefik.http.services.%s.weighted.services[", splitService(*green.ID))
+	for _, group := range green.TaskGroups {
+		for _, service := range group.Services {
+			if !slices.ContainsFunc(service.Tags, func(tag string) bool { return strings.HasPrefix(tag, prefix) }) {
+				continue
+			}
+			service.Tags = slices.DeleteFunc(service.Tags, func(tag string) bool {
+				return strings.HasPrefix(tag, prefix) && strings.Contains(tag, "].weight=")
+			})
+			service.Tags = append(service.Tags, weightTags(*green.ID, weight)...)
+		}
+	}
+}
+
+// PrimaryJob turns a green job back into the job of its application, with the new version
+// and the instance count of the application. The routers of the green job keep the traffic
+// until it is torn down.
+func PrimaryJob(green *nmd.Job) (*nmd.Job, error) {
+	split, ok := SplitOf(green)
+	if !ok {
+		return nil, fmt.Errorf("job %s is not a green job", *green.ID)
+	}
+	job, err := copyJob(green)
+	if err != nil {
+		return nil, err
+	}
+	greenID, jobID := *green.ID, green.Meta[MetaGreenOf]
+	renameJob(job, greenID, jobID)
+
+	for _, group := range job.TaskGroups {
+		if split.Count > 0 {
+			group.Count = &split.Count
+		}
+		for _, service := range group.Services {
+			splitRouters := routers(service.Tags)
+			service.Tags = slices.DeleteFunc(service.Tags, func(tag string) bool {
+				if strings.HasPrefix(tag, "traefik.http.services."+splitService(greenID)+".") ||
+					tag == fmt.Sprintf("traefik.http.services.%s.loadbalancer.passhostheader=true", jobID) {
+					return true
+				}
+				for _, router := range splitRouters {
+					if tag == fmt.Sprintf("traefik.http.routers.%s.priority=%d", router, splitRouterPriority) ||
+						tag == fmt.Sprintf("traefik.http.routers.%s.service=%s", router, splitService(greenID)) {
+						return true
+					}
+				}
+				return false
+			})
+		}
+	}
+
+	for _, key := range []string{MetaGreenOf, MetaSplitStrategy, MetaSplitWeights, MetaSplitInterval, MetaSplitManual, MetaSplitCount} {
+		delete(job.Meta, key)
+	}
+	job.Meta[MetaPromotedFrom] = PromotionOf(green)
+	return job, nil
+}
+
+// PromotionOf identifies the version of a green job an application is promoted from
+func PromotionOf(green *nmd.Job) string {
+	version := uint64(0)
+	if green.Version != nil {
+		version = *green.Version
+	}
+	return fmt.Sprintf("%s@%d", *green.ID, version)
+}
+
+// renameJob renames a job and its services, routers and variable path from one job ID to
+// another. The job is registered as a new job.
+func renameJob(job *nmd.Job, from, to string) {
+	job.ID, job.Name = &to, &to
+	job.Version, job.JobModifyIndex, job.CreateIndex, job.ModifyIndex, job.SubmitTime = nil, nil, nil, nil, nil
+	job.Status, job.StatusDescription, job.Stable = nil, nil, nil
+	if job.Meta == nil {
+		job.Meta = make(map[string]string)
+	}
+
+	fromVariable, toVariable := fmt.Sprintf("%q", JobVariablePath(from)), fmt.Sprintf("%q", JobVariablePath(to))
+	for _, group := range job.TaskGroups {
+		for _, service := range group.Services {
+			if rest, ok := strings.CutPrefix(service.Name, from+"-"); ok {
+				service.Name = to + "-" + rest
+			}
+			for i, tag := range service.Tags {
+				service.Tags[i] = renameTraefikTag(tag, from, to)
+			}
+		}
+		for _, task := range group.Tasks {
+			for _, template := range task.Templates {
+				if template.EmbeddedTmpl != nil {
+					data := strings.ReplaceAll(*template.EmbeddedTmpl, fromVariable, toVariable)
+					template.EmbeddedTmpl = &data
+				}
+			}
+		}
+	}
+}
+
+// renameTraefikTag renames the routers and the service GenerateTraefikTags named after a job
+func renameTraefikTag(tag, from, to string) string {
+	for _, kind := range []string{"traefik.http.routers.", "traefik.http.services."} {
+		for _, suffix := range []string{".", "-secure."} {
+			if rest, ok := strings.CutPrefix(tag, kind+from+suffix); ok {
+				return kind + to + suffix + rest
+			}
+		}
+	}
+	return tag
+}
+
+// routers lists the Traefik routers the tags define
+func routers(tags []string) []string {
+	var names []string
+	for _, tag := range tags {
+		if rest, ok := strings.CutPrefix(tag, "traefik.http.routers."); ok {
+			if name, _, ok := strings.Cut(rest, "."); ok && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// routedService returns the Traefik service of the job's routed Consul service: the one
+// its tags define, otherwise Traefik names it after the Consul service
+func routedService(job *nmd.Job) (string, bool) {
+	for _, group := range job.TaskGroups {
+		for _, service := range group.Services {
+			if !slices.Contains(service.Tags, "traefik.enable=true") {
+				continue
+			}
+			for _, tag := range service.Tags {
+				if rest, ok := strings.CutPrefix(tag, "traefik.http.services."); ok {
+					if name, _, ok := strings.Cut(rest, "."); ok {
+						return name, true
+					}
+				}
+			}
+			return service.Name, true
+		}
+	}
+	return "", false
+}
+
+// splitService is the weighted Traefik service of a green job
+func splitService(greenID string) string {
+	return greenID + "-split"
+}
+
+func weightTags(greenID string, weight int) []string {
+	return []string{
+		fmt.Sprintf("traefik.http.services.%s.weighted.services[0].weight=%d", splitService(greenID), 100-weight),
+		fmt.Sprintf("traefik.http.services.%s.weighted.services[1].weight=%d", splitService(greenID), weight),
+	}
+}
+
+// copyJob deep copies a job through its JSON encoding
+func copyJob(job *nmd.Job) (*nmd.Job, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	copied := &nmd.Job{}
+	if err := json.Unmarshal(data, copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
@@ -0,0 +1,140 @@
+package nomad
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+// AnnotationDescription is the annotation shown as the description of the job in the Nomad UI
+const AnnotationDescription = "description"
+
+var annotationKey = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// placeholder matches {app}, {tenant}, {image}, {region} and {annotation.<key>}
+var placeholder = regexp.MustCompile(`\{([a-z]+(\.[a-z0-9._-]+)?)\}`)
+
+// UILink is a link on the job's page in the Nomad UI
+type UILink struct {
+	Label string
+	URL   string
+}
+
+// UI is the description and the links on the job's page in the Nomad UI
+type UI struct {
+	Description string // markdown
+	Links       []UILink
+}
+
+// UIConfig are the templates of the Nomad UI metadata of every application, with the
+// placeholders {app}, {tenant}, {image}, {region} and {annotation.<key>}
+type UIConfig struct {
+	Description string   // e.g. "Owned by {annotation.team}", the description annotation takes precedence
+	Links       []UILink // URL templates, e.g. https://grafana.example.com/d/apps?var-app={app}
+}
+
+// ParseUILinks parses links given as Label=URL
+func ParseUILinks(specs []string) ([]UILink, error) {
+	var links []UILink
+	for _, spec := range specs {
+		label, link, ok := strings.Cut(spec, "=")
+		if !ok || label == "" || link == "" {
+			return nil, fmt.Errorf("UI link %q must be Label=URL", spec)
+		}
+		links = append(links, UILink{Label: label, URL: link})
+	}
+	return links, nil
+}
+
+// ValidateAnnotations checks the annotations of a deployment
+func ValidateAnnotations(annotations map[string]string) error {
+	for key := range annotations {
+		if !annotationKey.MatchString(key) {
+			return fmt.Errorf("annotation key %q may only contain lowercase letters, digits, '.', '-' and '_'", key)
+		}
+	}
+	return nil
+}
+
+// Render fills the templates for an application. Links of templates referring to an
+// annotation the deployment lacks are left out, annotations holding an http(s) URL,
+// e.g. runbook or repo, become links labeled with their key. A nil config only
+// renders the annotations.
+func (c *UIConfig) Render(values map[string]string, annotations map[string]string) *UI {
+	lookup := func(name string) (string, bool) {
+		if key, ok := strings.CutPrefix(name, "annotation."); ok {
+			value, ok := annotations[key]
+			return value, ok && value != ""
+		}
+		value, ok := values[name]
+		return value, ok && value != ""
+	}
+
+	ui := &UI{Description: annotations[AnnotationDescription]}
+	if c != nil {
+		if description, ok := expand(c.Description, lookup, false); ok && ui.Description == "" {
+			ui.Description = description
+		}
+		for _, link := range c.Links {
+			if rendered, ok := expand(link.URL, lookup, true); ok {
+				ui.Links = append(ui.Links, UILink{Label: link.Label, URL: rendered})
+			}
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		if u, err := url.Parse(annotations[key]); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+			ui.Links = append(ui.Links, UILink{Label: key, URL: annotations[key]})
+		}
+	}
+
+	if ui.Description == "" && len(ui.Links) == 0 {
+		return nil
+	}
+	return ui
+}
+
+// expand replaces the placeholders of a template, ok is false when one has no value.
+// Values of URLs are escaped.
+func expand(template string, lookup func(string) (string, bool), escape bool) (string, bool) {
+	ok := true
+	expanded := placeholder.ReplaceAllStringFunc(template, func(match string) string {
+		value, found := lookup(match[1 : len(match)-1])
+		if !found {
+			ok = false
+			return ""
+		}
+		if escape {
+			return url.QueryEscape(value)
+		}
+		return value
+	})
+	return expanded, ok
+}
+
+func (ui *UI) toNomadUI() *nmd.JobUIConfig {
+	config := &nmd.JobUIConfig{Description: ui.Description}
+	for _, link := range ui.Links {
+		config.Links = append(config.Links, &nmd.JobUILink{Label: link.Label, URL: link.URL})
+	}
+	return config
+}
+
+// AllocationURL is the page of an allocation in the Nomad UI
+func (nc *NomadClient) AllocationURL(allocID string) string {
+	return strings.TrimSuffix(nc.address, "/") + "/ui/allocations/" + allocID
+}
+
+// JobURL is the page of a job in the Nomad UI
+func (nc *NomadClient) JobURL(jobID string) string {
+	link := strings.TrimSuffix(nc.address, "/") + "/ui/jobs/" + jobID
+	if namespace := nc.jobNamespace(jobID); namespace != DefaultNamespace {
+		link += "@" + namespace
+	}
+	return link
+}
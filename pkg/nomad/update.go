@@ -0,0 +1,92 @@
+package nomad
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"github.com/iuliansafta/control-plane/pkg/utils"
+)
+
+// UpdateStrategy is how Nomad rolls out a new version of a service job
+type UpdateStrategy struct {
+	MaxParallel int  // allocations replaced at a time, 0 keeps Nomad's default of 1
+	Canary      int  // allocations of the new version placed next to the old ones first
+	AutoRevert  bool // revert to the last stable version when the rollout fails
+	AutoPromote bool // promote the canaries once all of them are healthy
+	// an allocation must be healthy this long to count as healthy, 0 keeps Nomad's default
+	MinHealthyTime time.Duration
+	// an allocation not healthy after this long is unhealthy, 0 keeps Nomad's default
+	HealthyDeadline time.Duration
+}
+
+// Nomad's defaults of the update block
+const (
+	defaultMinHealthyTime   = 10 * time.Second
+	defaultHealthyDeadline  = 5 * time.Minute
+	defaultProgressDeadline = 10 * time.Minute
+)
+
+func (u *UpdateStrategy) Validate() error {
+	if u.MaxParallel < 0 {
+		return fmt.Errorf("max parallel cannot be negative")
+	}
+	if u.Canary < 0 {
+		return fmt.Errorf("canary cannot be negative")
+	}
+	if u.AutoPromote && u.Canary == 0 {
+		return fmt.Errorf("auto promote requires canaries")
+	}
+	if u.MinHealthyTime < 0 || u.HealthyDeadline < 0 {
+		return fmt.Errorf("min healthy time and healthy deadline cannot be negative")
+	}
+	minHealthy, deadline := u.MinHealthyTime, u.HealthyDeadline
+	if minHealthy == 0 {
+		minHealthy = defaultMinHealthyTime
+	}
+	if deadline == 0 {
+		deadline = defaultHealthyDeadline
+	}
+	if minHealthy >= deadline {
+		return fmt.Errorf("min healthy time %s must be shorter than the healthy deadline %s", minHealthy, deadline)
+	}
+	return nil
+}
+
+func (u *UpdateStrategy) toNomadUpdate() *nmd.UpdateStrategy {
+	update := &nmd.UpdateStrategy{
+		Canary:      utils.IntPtr(u.Canary),
+		AutoRevert:  utils.BoolPtr(u.AutoRevert),
+		AutoPromote: utils.BoolPtr(u.AutoPromote),
+	}
+	if u.MaxParallel > 0 {
+		update.MaxParallel = utils.IntPtr(u.MaxParallel)
+	}
+	if u.MinHealthyTime > 0 {
+		update.MinHealthyTime = utils.DurationPtr(u.MinHealthyTime)
+	}
+	if u.HealthyDeadline > 0 {
+		update.HealthyDeadline = utils.DurationPtr(u.HealthyDeadline)
+		// Nomad requires the progress deadline to be longer than the healthy deadline
+		if u.HealthyDeadline >= defaultProgressDeadline {
+			update.ProgressDeadline = utils.DurationPtr(u.HealthyDeadline + defaultMinHealthyTime)
+		}
+	}
+	return update
+}
+
+// Nomad describes a failed deployment it auto reverted with
+// "Failed due to unhealthy allocations - rolling back to job version 3"
+var rollbackDescription = regexp.MustCompile(`rolling back to job version (\d+)`)
+
+// RevertedTo returns the version Nomad rolled a failed deployment back to
+func RevertedTo(deployment *nmd.Deployment) (uint64, bool) {
+	match := rollbackDescription.FindStringSubmatch(deployment.StatusDescription)
+	if match == nil {
+		return 0, false
+	}
+	version, err := strconv.ParseUint(match[1], 10, 64)
+	return version, err == nil
+}
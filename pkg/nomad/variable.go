@@ -0,0 +1,31 @@
+package nomad
+
+import (
+	nmd "github.com/hashicorp/nomad/api"
+)
+
+// JobVariablePath is the Nomad variable the tasks of a job can read with their workload identity
+func JobVariablePath(jobID string) string {
+	return "nomad/jobs/" + jobID
+}
+
+// VariableItems reads the items of a Nomad variable, nil when it does not exist
+func (nc *NomadClient) VariableItems(path string) (map[string]string, error) {
+	variable, _, err := nc.client.Variables().Peek(path, nil)
+	if err != nil || variable == nil {
+		return nil, mapError(err)
+	}
+	return variable.Items, nil
+}
+
+// PutVariable creates or replaces a Nomad variable
+func (nc *NomadClient) PutVariable(path string, items map[string]string) error {
+	_, _, err := nc.client.Variables().Create(&nmd.Variable{Path: path, Items: items}, nil)
+	return mapError(err)
+}
+
+// DeleteVariable deletes a Nomad variable
+func (nc *NomadClient) DeleteVariable(path string) error {
+	_, err := nc.client.Variables().Delete(path, nil)
+	return err
+}
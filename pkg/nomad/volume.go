@@ -0,0 +1,196 @@
+package nomad
+
+import (
+	"fmt"
+	"path"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"github.com/iuliansafta/control-plane/pkg/utils"
+)
+
+var volumeAccessModes = map[string]bool{
+	string(nmd.CSIVolumeAccessModeSingleNodeReader):      true,
+	string(nmd.CSIVolumeAccessModeSingleNodeWriter):      true,
+	string(nmd.CSIVolumeAccessModeMultiNodeReader):       true,
+	string(nmd.CSIVolumeAccessModeMultiNodeSingleWriter): true,
+	string(nmd.CSIVolumeAccessModeMultiNodeMultiWriter):  true,
+}
+
+var volumeAttachmentModes = map[string]bool{
+	string(nmd.CSIVolumeAttachmentModeFilesystem):  true,
+	string(nmd.CSIVolumeAttachmentModeBlockDevice): true,
+}
+
+// Volume is a CSI volume created through the storage provider's controller plugin, or an
+// existing one of the provider registered with Nomad when ExternalID is set
+type Volume struct {
+	ID             string
+	Name           string
+	PluginID       string
+	ExternalID     string
+	CapacityMinMB  int64
+	CapacityMaxMB  int64
+	AccessMode     string // defaults to "single-node-writer"
+	AttachmentMode string // defaults to "file-system"
+	FSType         string
+	Parameters     map[string]string
+	Secrets        map[string]string
+}
+
+// VolumeMount claims a CSI volume for the task group and mounts it into the task
+type VolumeMount struct {
+	VolumeID       string
+	Destination    string
+	ReadOnly       bool
+	AccessMode     string // defaults to "single-node-writer", or "single-node-reader-only" when read only
+	AttachmentMode string // defaults to "file-system"
+	PerAlloc       bool   // instance N claims the volume "<VolumeID>[N]"
+}
+
+func (v *Volume) Validate() error {
+	if v.ID == "" {
+		return fmt.Errorf("volume ID cannot be empty")
+	}
+	if v.PluginID == "" {
+		return fmt.Errorf("volume %s: plugin ID cannot be empty", v.ID)
+	}
+	if v.CapacityMinMB < 0 || v.CapacityMaxMB < 0 {
+		return fmt.Errorf("volume %s: capacity cannot be negative", v.ID)
+	}
+	if v.CapacityMaxMB > 0 && v.CapacityMaxMB < v.CapacityMinMB {
+		return fmt.Errorf("volume %s: maximum capacity is below the minimum capacity", v.ID)
+	}
+	if v.AccessMode != "" && !volumeAccessModes[v.AccessMode] {
+		return fmt.Errorf("volume %s: unknown access mode %q", v.ID, v.AccessMode)
+	}
+	if v.AttachmentMode != "" && !volumeAttachmentModes[v.AttachmentMode] {
+		return fmt.Errorf("volume %s: unknown attachment mode %q", v.ID, v.AttachmentMode)
+	}
+	return nil
+}
+
+func (vm *VolumeMount) Validate() error {
+	if vm.VolumeID == "" {
+		return fmt.Errorf("volume ID cannot be empty")
+	}
+	if !path.IsAbs(vm.Destination) {
+		return fmt.Errorf("volume %s: destination %q must be an absolute path", vm.VolumeID, vm.Destination)
+	}
+	if vm.AccessMode != "" && !volumeAccessModes[vm.AccessMode] {
+		return fmt.Errorf("volume %s: unknown access mode %q", vm.VolumeID, vm.AccessMode)
+	}
+	if vm.AttachmentMode != "" && !volumeAttachmentModes[vm.AttachmentMode] {
+		return fmt.Errorf("volume %s: unknown attachment mode %q", vm.VolumeID, vm.AttachmentMode)
+	}
+	return nil
+}
+
+func (v *Volume) toNomadVolume() *nmd.CSIVolume {
+	name := v.Name
+	if name == "" {
+		name = v.ID
+	}
+	accessMode := v.AccessMode
+	if accessMode == "" {
+		accessMode = string(nmd.CSIVolumeAccessModeSingleNodeWriter)
+	}
+	attachmentMode := v.AttachmentMode
+	if attachmentMode == "" {
+		attachmentMode = string(nmd.CSIVolumeAttachmentModeFilesystem)
+	}
+
+	volume := &nmd.CSIVolume{
+		ID:                   v.ID,
+		Name:                 name,
+		PluginID:             v.PluginID,
+		ExternalID:           v.ExternalID,
+		RequestedCapacityMin: v.CapacityMinMB << 20,
+		RequestedCapacityMax: v.CapacityMaxMB << 20,
+		RequestedCapabilities: []*nmd.CSIVolumeCapability{{
+			AccessMode:     nmd.CSIVolumeAccessMode(accessMode),
+			AttachmentMode: nmd.CSIVolumeAttachmentMode(attachmentMode),
+		}},
+		Parameters: v.Parameters,
+		Secrets:    v.Secrets,
+	}
+	if v.FSType != "" {
+		volume.MountOptions = &nmd.CSIMountOptions{FSType: v.FSType}
+	}
+	return volume
+}
+
+func (vm *VolumeMount) toNomadVolumeRequest() *nmd.VolumeRequest {
+	accessMode := vm.AccessMode
+	if accessMode == "" {
+		accessMode = string(nmd.CSIVolumeAccessModeSingleNodeWriter)
+		if vm.ReadOnly {
+			accessMode = string(nmd.CSIVolumeAccessModeSingleNodeReader)
+		}
+	}
+	attachmentMode := vm.AttachmentMode
+	if attachmentMode == "" {
+		attachmentMode = string(nmd.CSIVolumeAttachmentModeFilesystem)
+	}
+
+	return &nmd.VolumeRequest{
+		Name:           vm.VolumeID,
+		Type:           "csi",
+		Source:         vm.VolumeID,
+		ReadOnly:       vm.ReadOnly,
+		AccessMode:     accessMode,
+		AttachmentMode: attachmentMode,
+		PerAlloc:       vm.PerAlloc,
+	}
+}
+
+func (vm *VolumeMount) toNomadVolumeMount() *nmd.VolumeMount {
+	return &nmd.VolumeMount{
+		Volume:      utils.StringPtr(vm.VolumeID),
+		Destination: utils.StringPtr(vm.Destination),
+		ReadOnly:    utils.BoolPtr(vm.ReadOnly),
+	}
+}
+
+// CreateVolume creates a CSI volume through the controller plugin and registers it with
+// Nomad. A volume with an ExternalID already exists at the storage provider and is only registered.
+func (nc *NomadClient) CreateVolume(v *Volume) (*nmd.CSIVolume, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+
+	volume := v.toNomadVolume()
+	if v.ExternalID != "" {
+		if _, err := nc.client.CSIVolumes().Register(volume, nil); err != nil {
+			return nil, mapError(err)
+		}
+		return volume, nil
+	}
+
+	created, _, err := nc.client.CSIVolumes().Create(volume, nil)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	if len(created) == 0 {
+		return nil, fmt.Errorf("plugin %s did not create volume %s", v.PluginID, v.ID)
+	}
+	return created[0], nil
+}
+
+// ListVolumes lists the CSI volumes, of every plugin when pluginID is empty
+func (nc *NomadClient) ListVolumes(pluginID string) ([]*nmd.CSIVolumeListStub, error) {
+	if pluginID != "" {
+		volumes, _, err := nc.client.CSIVolumes().PluginList(pluginID)
+		return volumes, err
+	}
+	volumes, _, err := nc.client.CSIVolumes().List(nil)
+	return volumes, err
+}
+
+// DeleteVolume deletes a CSI volume through the controller plugin, or only deregisters it
+// from Nomad and keeps the provider's volume. Claimed volumes cannot be removed.
+func (nc *NomadClient) DeleteVolume(id string, deregisterOnly bool) error {
+	if deregisterOnly {
+		return nc.client.CSIVolumes().Deregister(id, false, nil)
+	}
+	return nc.client.CSIVolumes().DeleteOpts(&nmd.CSIVolumeDeleteRequest{ExternalVolumeID: id}, nil)
+}
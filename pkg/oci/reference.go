@@ -0,0 +1,55 @@
+package oci
+
+import "strings"
+
+// DefaultRegistry is the registry of images without one, e.g. nginx or acme/shop
+const DefaultRegistry = "docker.io"
+
+// Reference is a parsed image reference
+type Reference struct {
+	Registry   string // DefaultRegistry for images without a registry
+	Repository string // official images of the default registry are in library/
+	Tag        string // latest when neither a tag nor a digest is given
+	Digest     string
+}
+
+// ParseReference splits an image reference into its parts, e.g. nginx:1.27 is the tag 1.27
+// of docker.io/library/nginx
+func ParseReference(image string) Reference {
+	var ref Reference
+
+	name, digest, _ := strings.Cut(image, "@")
+	ref.Digest = digest
+	if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") {
+		name, ref.Tag = name[:colon], name[colon+1:]
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	first, rest, found := strings.Cut(name, "/")
+	switch {
+	case found && (strings.ContainsAny(first, ".:") || first == "localhost"):
+		ref.Registry, ref.Repository = first, rest
+	case found:
+		ref.Registry, ref.Repository = DefaultRegistry, name
+	default:
+		ref.Registry, ref.Repository = DefaultRegistry, "library/"+name
+	}
+
+	return ref
+}
+
+// Name is the fully qualified repository, e.g. docker.io/library/nginx
+func (r Reference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// WithDigest pins an image to a digest, keeping its name as written
+func WithDigest(image, digest string) string {
+	name, _, _ := strings.Cut(image, "@")
+	if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") {
+		name = name[:colon]
+	}
+	return name + "@" + digest
+}
@@ -0,0 +1,147 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// manifestTypes are accepted when resolving a tag, the digest of a multi-platform image is
+// the one of its index
+var manifestTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// Resolver looks up the digests of tags with the distribution API of registries. It pulls
+// anonymously, private repositories cannot be resolved.
+type Resolver struct {
+	Client *http.Client
+}
+
+// Digest resolves the image's tag to the digest the registry serves now, images pinned to
+// a digest resolve to it without asking the registry
+func (r *Resolver) Digest(ctx context.Context, image string) (string, error) {
+	ref := ParseReference(image)
+	if ref.Digest != "" {
+		return ref.Digest, nil
+	}
+
+	manifest := fmt.Sprintf("%s/v2/%s/manifests/%s", endpoint(ref.Registry), ref.Repository, ref.Tag)
+	resp, err := r.head(ctx, manifest, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := r.token(ctx, resp.Header.Get("WWW-Authenticate"), ref)
+		if err != nil {
+			return "", fmt.Errorf("registry %s: %w", ref.Registry, err)
+		}
+		if resp, err = r.head(ctx, manifest, token); err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry %s: %s:%s: %s", ref.Registry, ref.Repository, ref.Tag, resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry %s did not return the digest of %s:%s", ref.Registry, ref.Repository, ref.Tag)
+	}
+	return digest, nil
+}
+
+func (r *Resolver) head(ctx context.Context, manifest, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifest, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// token requests an anonymous pull token from the auth server of a Bearer challenge
+func (r *Resolver) token(ctx context.Context, challenge string, ref Reference) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication %q", scheme)
+	}
+
+	values := map[string]string{}
+	for _, match := range challengeParam.FindAllStringSubmatch(params, -1) {
+		values[match[1]] = match[2]
+	}
+	if values["realm"] == "" {
+		return "", fmt.Errorf("authentication challenge without realm")
+	}
+	if values["scope"] == "" {
+		values["scope"] = "repository:" + ref.Repository + ":pull"
+	}
+
+	query := url.Values{"scope": {values["scope"]}}
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, values["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed: %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+func (r *Resolver) client() *http.Client {
+	if r.Client != nil {
+		return r.Client
+	}
+	return http.DefaultClient
+}
+
+// endpoint is the base URL of a registry's API, local registries are served over plain HTTP
+func endpoint(registry string) string {
+	switch {
+	case registry == DefaultRegistry:
+		return "https://registry-1.docker.io"
+	case registry == "localhost" || strings.HasPrefix(registry, "localhost:") || strings.HasPrefix(registry, "127.0.0.1"):
+		return "http://" + registry
+	default:
+		return "https://" + registry
+	}
+}
@@ -0,0 +1,741 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// the service account of a pod running the controller
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	// owner of the fields the controller applies
+	fieldManager = "control-plane"
+	// the container of the application in its pods
+	containerName = "app"
+
+	labelName      = "app.kubernetes.io/name"
+	labelManagedBy = "app.kubernetes.io/managed-by"
+	// prefix of the annotations keeping the labels of the spec, label values are too
+	// restricted to hold them
+	annotationLabelPrefix = "label.controlplane.io/"
+)
+
+// Kubernetes runs applications as Deployments of one container in a namespace, with a
+// Service for their ports and an Ingress routing their host. It talks to the API server
+// over REST with a bearer token or a client certificate, the credentials of kubeconfigs
+// relying on exec or auth provider plugins, basic auth, impersonation or a proxy are
+// refused rather than half supported.
+type Kubernetes struct {
+	Server       string
+	Namespace    string
+	Token        string
+	TokenFile    string // read on every request, projected service account tokens rotate
+	IngressClass string // of the Ingresses, the cluster's default when empty
+	Client       *http.Client
+}
+
+// NewKubernetes connects with the current context of a kubeconfig file, or inside the
+// cluster with the pod's service account when the path is empty. An empty namespace takes
+// the one of the context or the service account.
+func NewKubernetes(kubeconfig, namespace string) (*Kubernetes, error) {
+	var k *Kubernetes
+	var err error
+	if kubeconfig == "" {
+		k, err = inCluster()
+	} else {
+		k, err = fromKubeconfig(kubeconfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if namespace != "" {
+		k.Namespace = namespace
+	}
+	if k.Namespace == "" {
+		k.Namespace = "default"
+	}
+	return k, nil
+}
+
+func inCluster() (*Kubernetes, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running in a Kubernetes cluster, pass a kubeconfig")
+	}
+	ca, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the service account: %w", err)
+	}
+	namespace, _ := os.ReadFile(serviceAccountDir + "/namespace")
+
+	tlsConfig, err := caConfig(ca)
+	if err != nil {
+		return nil, err
+	}
+	return &Kubernetes{
+		Server:    "https://" + net.JoinHostPort(host, port),
+		Namespace: strings.TrimSpace(string(namespace)),
+		TokenFile: serviceAccountDir + "/token",
+		Client:    &http.Client{Timeout: 30 * time.Second, Transport: &http.Transport{TLSClientConfig: tlsConfig}},
+	}, nil
+}
+
+// kubeconfig holds the parts of a kubeconfig file the controller supports, and those it
+// refuses: exec and auth provider plugins, basic auth, impersonation and proxies
+type kubeconfig struct {
+	CurrentContext string        `yaml:"current-context"`
+	Contexts       []kubeContext `yaml:"contexts"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+			TLSServerName            string `yaml:"tls-server-name"`
+			ProxyURL                 string `yaml:"proxy-url"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []kubeNamedUser `yaml:"users"`
+}
+
+type kubeNamedUser struct {
+	Name string   `yaml:"name"`
+	User kubeUser `yaml:"user"`
+}
+
+type kubeUser struct {
+	Token                 string         `yaml:"token"`
+	TokenFile             string         `yaml:"tokenFile"`
+	ClientCertificate     string         `yaml:"client-certificate"`
+	ClientCertificateData string         `yaml:"client-certificate-data"`
+	ClientKey             string         `yaml:"client-key"`
+	ClientKeyData         string         `yaml:"client-key-data"`
+	Exec                  map[string]any `yaml:"exec"`
+	AuthProvider          map[string]any `yaml:"auth-provider"`
+	Username              string         `yaml:"username"`
+	Password              string         `yaml:"password"`
+	As                    string         `yaml:"as"`
+	AsGroups              []string       `yaml:"as-groups"`
+}
+
+// unsupported names the credentials of the user the controller does not implement
+func (u *kubeUser) unsupported() string {
+	switch {
+	case u.Exec != nil:
+		return "an exec plugin"
+	case u.AuthProvider != nil:
+		return "an auth provider plugin"
+	case u.Username != "" || u.Password != "":
+		return "basic auth"
+	case u.As != "" || len(u.AsGroups) > 0:
+		return "impersonation"
+	}
+	return ""
+}
+
+type kubeContext struct {
+	Name    string `yaml:"name"`
+	Context struct {
+		Cluster   string `yaml:"cluster"`
+		User      string `yaml:"user"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"context"`
+}
+
+func fromKubeconfig(path string) (*Kubernetes, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config kubeconfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig %s: %w", path, err)
+	}
+
+	i := slices.IndexFunc(config.Contexts, func(c kubeContext) bool { return c.Name == config.CurrentContext })
+	if i < 0 {
+		return nil, fmt.Errorf("kubeconfig %s has no current context %q", path, config.CurrentContext)
+	}
+	current := config.Contexts[i].Context
+
+	// like kubectl, the files of a kubeconfig are relative to it
+	dir := filepath.Dir(path)
+
+	k := &Kubernetes{Namespace: current.Namespace}
+	tlsConfig := &tls.Config{}
+	found := false
+	for _, cluster := range config.Clusters {
+		if cluster.Name != current.Cluster {
+			continue
+		}
+		found = true
+		if cluster.Cluster.ProxyURL != "" {
+			return nil, fmt.Errorf("cluster %s is reached through a proxy, which is not supported", cluster.Name)
+		}
+		k.Server = strings.TrimSuffix(cluster.Cluster.Server, "/")
+		ca, err := fileOrData(dir, cluster.Cluster.CertificateAuthority, cluster.Cluster.CertificateAuthorityData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA of cluster %s: %w", cluster.Name, err)
+		}
+		if ca != nil {
+			if tlsConfig, err = caConfig(ca); err != nil {
+				return nil, err
+			}
+		}
+		tlsConfig.InsecureSkipVerify = cluster.Cluster.InsecureSkipTLSVerify
+		tlsConfig.ServerName = cluster.Cluster.TLSServerName
+	}
+	if !found {
+		return nil, fmt.Errorf("kubeconfig %s has no cluster %q", path, current.Cluster)
+	}
+
+	i = slices.IndexFunc(config.Users, func(u kubeNamedUser) bool { return u.Name == current.User })
+	if i < 0 {
+		return nil, fmt.Errorf("kubeconfig %s has no user %q", path, current.User)
+	}
+	name, user := config.Users[i].Name, config.Users[i].User
+	if unsupported := user.unsupported(); unsupported != "" {
+		return nil, fmt.Errorf("user %s authenticates with %s, use a token or a client certificate", name, unsupported)
+	}
+	k.Token, k.TokenFile = user.Token, resolve(dir, user.TokenFile)
+	certificate, err := fileOrData(dir, user.ClientCertificate, user.ClientCertificateData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the certificate of user %s: %w", name, err)
+	}
+	key, err := fileOrData(dir, user.ClientKey, user.ClientKeyData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the key of user %s: %w", name, err)
+	}
+	if certificate != nil {
+		pair, err := tls.X509KeyPair(certificate, key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate of user %s: %w", name, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+	if k.Token == "" && k.TokenFile == "" && certificate == nil {
+		return nil, fmt.Errorf("user %s has no token or client certificate", name)
+	}
+
+	k.Client = &http.Client{Timeout: 30 * time.Second, Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	return k, nil
+}
+
+// fileOrData reads a file of a kubeconfig in dir, or decodes its base64 data
+func fileOrData(dir, path, data string) ([]byte, error) {
+	if data != "" {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	if path != "" {
+		return os.ReadFile(resolve(dir, path))
+	}
+	return nil, nil
+}
+
+// resolve returns the path of a file of a kubeconfig in dir
+func resolve(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func caConfig(ca []byte) (*tls.Config, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("invalid CA certificate of the API server")
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// Deploy applies the Deployment of the application, and its Service and Ingress when it
+// has ports and a host; the ones it no longer needs are deleted. The reference is the
+// generation of the Deployment.
+func (k *Kubernetes) Deploy(ctx context.Context, app *Application) (string, error) {
+	var deployment kubeDeployment
+	if err := k.apply(ctx, k.path("apis/apps/v1", "deployments", app.ID), k.deploymentManifest(app), &deployment); err != nil {
+		return "", fmt.Errorf("failed to apply deployment %s: %w", app.ID, err)
+	}
+
+	service := k.path("api/v1", "services", app.ID)
+	if len(app.Ports) > 0 {
+		if err := k.apply(ctx, service, k.serviceManifest(app), nil); err != nil {
+			return "", fmt.Errorf("failed to apply service %s: %w", app.ID, err)
+		}
+	} else if err := k.deleteIfExists(ctx, service); err != nil {
+		return "", err
+	}
+
+	ingress := k.path("apis/networking.k8s.io/v1", "ingresses", app.ID)
+	if app.Host != "" && len(app.Ports) > 0 {
+		if err := k.apply(ctx, ingress, k.ingressManifest(app), nil); err != nil {
+			return "", fmt.Errorf("failed to apply ingress %s: %w", app.ID, err)
+		}
+	} else if err := k.deleteIfExists(ctx, ingress); err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(deployment.Metadata.Generation, 10), nil
+}
+
+// Delete deletes the Deployment of the application with its pods, Service and Ingress
+func (k *Kubernetes) Delete(ctx context.Context, id string) error {
+	// the pods are deleted in the background, the controller does not wait for them
+	err := k.do(ctx, http.MethodDelete, k.path("apis/apps/v1", "deployments", id), "application/json", map[string]any{"propagationPolicy": "Background"}, nil)
+	if err != nil {
+		return err
+	}
+	if err := k.deleteIfExists(ctx, k.path("api/v1", "services", id)); err != nil {
+		return err
+	}
+	return k.deleteIfExists(ctx, k.path("apis/networking.k8s.io/v1", "ingresses", id))
+}
+
+// Status reads the Deployment of the application and its pods
+func (k *Kubernetes) Status(ctx context.Context, id string) (*Status, error) {
+	var deployment kubeDeployment
+	if err := k.do(ctx, http.MethodGet, k.path("apis/apps/v1", "deployments", id), "", nil, &deployment); err != nil {
+		return nil, err
+	}
+	pods, err := k.pods(ctx, labelName+"="+id)
+	if err != nil {
+		return nil, err
+	}
+	return deployment.status(pods), nil
+}
+
+// List returns the status of every Deployment managed by the control plane
+func (k *Kubernetes) List(ctx context.Context) ([]*Status, error) {
+	var deployments struct {
+		Items []kubeDeployment `json:"items"`
+	}
+	query := url.Values{"labelSelector": {labelManagedBy + "=" + fieldManager}}
+	if err := k.do(ctx, http.MethodGet, k.path("apis/apps/v1", "deployments", "")+"?"+query.Encode(), "", nil, &deployments); err != nil {
+		return nil, err
+	}
+	pods, err := k.pods(ctx, labelManagedBy+"="+fieldManager)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*Status, 0, len(deployments.Items))
+	for _, deployment := range deployments.Items {
+		owned := slices.DeleteFunc(slices.Clone(pods), func(pod kubePod) bool {
+			return pod.Metadata.Labels[labelName] != deployment.Metadata.Name
+		})
+		statuses = append(statuses, deployment.status(owned))
+	}
+	return statuses, nil
+}
+
+// Scale sets the replicas of the Deployment through its scale subresource
+func (k *Kubernetes) Scale(ctx context.Context, id string, instances int) (int, error) {
+	path := k.path("apis/apps/v1", "deployments", id) + "/scale"
+	var scale struct {
+		Spec struct {
+			Replicas int `json:"replicas"`
+		} `json:"spec"`
+	}
+	if err := k.do(ctx, http.MethodGet, path, "", nil, &scale); err != nil {
+		return 0, err
+	}
+	previous := scale.Spec.Replicas
+	patch := map[string]any{"spec": map[string]any{"replicas": instances}}
+	return previous, k.do(ctx, http.MethodPatch, path, "application/merge-patch+json", patch, nil)
+}
+
+// Logs reads the last lines of the application's container in a pod, the most recently
+// started one when none is named. Kubernetes keeps stdout and stderr in one log.
+func (k *Kubernetes) Logs(ctx context.Context, id, instance string, lines int, stderr bool) ([]string, error) {
+	if stderr {
+		return nil, fmt.Errorf("kubernetes keeps stdout and stderr in one log, read the stdout log")
+	}
+	pods, err := k.pods(ctx, labelName+"="+id)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(pods, func(a, b kubePod) int { return b.Status.StartTime.Compare(a.Status.StartTime) })
+	i := slices.IndexFunc(pods, func(pod kubePod) bool {
+		return instance == "" || strings.HasPrefix(pod.Metadata.Name, instance)
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("pod %s of %s: %w", instance, id, ErrNotFound)
+	}
+
+	query := url.Values{"container": {containerName}, "tailLines": {strconv.Itoa(lines)}}
+	req, err := k.request(ctx, http.MethodGet, k.path("api/v1", "pods", pods[i].Metadata.Name)+"/log?"+query.Encode(), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := k.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := statusError(resp); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimRight(string(data), "\n"), "\n"), nil
+}
+
+// HealthCheck asks the API server for its version
+func (k *Kubernetes) HealthCheck() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return k.do(ctx, http.MethodGet, "/version", "", nil, nil)
+}
+
+func (k *Kubernetes) String() string {
+	return SchedulerKubernetes
+}
+
+func (k *Kubernetes) deploymentManifest(app *Application) map[string]any {
+	selector := map[string]string{labelName: app.ID}
+	labels := map[string]string{labelName: app.ID, labelManagedBy: fieldManager}
+	annotations := maps.Clone(app.Annotations)
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	for key, value := range app.Labels {
+		annotations[annotationLabelPrefix+key] = value
+	}
+
+	env := make([]map[string]string, 0, len(app.Env))
+	for _, name := range slices.Sorted(maps.Keys(app.Env)) {
+		env = append(env, map[string]string{"name": name, "value": app.Env[name]})
+	}
+	container := map[string]any{
+		"name":  containerName,
+		"image": app.Image,
+		"env":   env,
+		"resources": map[string]any{
+			"requests": map[string]string{"cpu": fmt.Sprintf("%dm", int(app.CPU*1000)), "memory": fmt.Sprintf("%dMi", app.MemoryMB)},
+			"limits":   map[string]string{"memory": fmt.Sprintf("%dMi", app.MemoryMB)},
+		},
+	}
+	var ports []map[string]any
+	for _, port := range app.Ports {
+		ports = append(ports, map[string]any{"containerPort": port.ContainerPort, "protocol": kubeProtocol(port.Protocol)})
+	}
+	if len(ports) > 0 {
+		container["ports"] = ports
+		if app.HealthPath != "" {
+			container["readinessProbe"] = map[string]any{
+				"httpGet": map[string]any{"path": app.HealthPath, "port": app.Ports[0].ContainerPort},
+			}
+		}
+	}
+
+	return map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": app.ID, "namespace": k.Namespace, "labels": labels, "annotations": annotations},
+		"spec": map[string]any{
+			"replicas": app.Instances,
+			"selector": map[string]any{"matchLabels": selector},
+			"template": map[string]any{
+				"metadata": map[string]any{"labels": labels},
+				"spec":     map[string]any{"containers": []any{container}},
+			},
+		},
+	}
+}
+
+func (k *Kubernetes) serviceManifest(app *Application) map[string]any {
+	var ports []map[string]any
+	for _, port := range app.Ports {
+		ports = append(ports, map[string]any{
+			// port names are DNS labels
+			"name":       strings.Trim(strings.ReplaceAll(strings.ToLower(port.Label), "_", "-"), "-"),
+			"port":       port.ContainerPort,
+			"targetPort": port.ContainerPort,
+			"protocol":   kubeProtocol(port.Protocol),
+		})
+	}
+	return map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]any{"name": app.ID, "namespace": k.Namespace, "labels": map[string]string{labelName: app.ID, labelManagedBy: fieldManager}},
+		"spec": map[string]any{
+			"selector": map[string]string{labelName: app.ID},
+			"ports":    ports,
+		},
+	}
+}
+
+func (k *Kubernetes) ingressManifest(app *Application) map[string]any {
+	spec := map[string]any{
+		"rules": []any{map[string]any{
+			"host": app.Host,
+			"http": map[string]any{"paths": []any{map[string]any{
+				"path":     "/",
+				"pathType": "Prefix",
+				"backend": map[string]any{"service": map[string]any{
+					"name": app.ID,
+					"port": map[string]any{"number": app.Ports[0].ContainerPort},
+				}},
+			}}},
+		}},
+	}
+	if k.IngressClass != "" {
+		spec["ingressClassName"] = k.IngressClass
+	}
+	if app.SSL {
+		// the certificate is issued into the secret by the cluster, e.g. cert-manager
+		spec["tls"] = []any{map[string]any{"hosts": []string{app.Host}, "secretName": app.ID + "-tls"}}
+	}
+	return map[string]any{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata":   map[string]any{"name": app.ID, "namespace": k.Namespace, "labels": map[string]string{labelName: app.ID, labelManagedBy: fieldManager}},
+		"spec":       spec,
+	}
+}
+
+func kubeProtocol(protocol string) string {
+	if protocol == "udp" {
+		return "UDP"
+	}
+	return "TCP"
+}
+
+// kubeDeployment holds the fields of a Deployment the controller reads
+type kubeDeployment struct {
+	Metadata struct {
+		Name        string            `json:"name"`
+		Generation  int64             `json:"generation"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		Replicas int `json:"replicas"`
+		Template struct {
+			Spec struct {
+				Containers []struct {
+					Image string `json:"image"`
+				} `json:"containers"`
+			} `json:"spec"`
+		} `json:"template"`
+	} `json:"spec"`
+	Status struct {
+		AvailableReplicas int `json:"availableReplicas"`
+		Conditions        []struct {
+			Type           string    `json:"type"`
+			LastUpdateTime time.Time `json:"lastUpdateTime"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+type kubePod struct {
+	Metadata struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels"`
+	} `json:"metadata"`
+	Spec struct {
+		NodeName string `json:"nodeName"`
+	} `json:"spec"`
+	Status struct {
+		Phase      string    `json:"phase"`
+		StartTime  time.Time `json:"startTime"`
+		Conditions []struct {
+			Type   string `json:"type"`
+			Status string `json:"status"`
+		} `json:"conditions"`
+		ContainerStatuses []struct {
+			State struct {
+				Waiting *struct {
+					Reason string `json:"reason"`
+				} `json:"waiting"`
+			} `json:"state"`
+		} `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// status counts the pods like Nomad counts allocations, a pod crash looping is failed
+func (d *kubeDeployment) status(pods []kubePod) *Status {
+	status := &Status{
+		ID:      d.Metadata.Name,
+		Status:  "pending",
+		Desired: d.Spec.Replicas,
+		Labels:  make(map[string]string),
+	}
+	if d.Status.AvailableReplicas > 0 {
+		status.Status = "running"
+	}
+	if containers := d.Spec.Template.Spec.Containers; len(containers) > 0 {
+		status.Image = containers[0].Image
+	}
+	for key, value := range d.Metadata.Annotations {
+		if label, ok := strings.CutPrefix(key, annotationLabelPrefix); ok {
+			status.Labels[label] = value
+		}
+	}
+	for _, condition := range d.Status.Conditions {
+		if condition.LastUpdateTime.After(status.UpdatedAt) {
+			status.UpdatedAt = condition.LastUpdateTime
+		}
+	}
+
+	for _, pod := range pods {
+		instance := Instance{
+			ID:        pod.Metadata.Name,
+			Node:      pod.Spec.NodeName,
+			Status:    strings.ToLower(pod.Status.Phase),
+			StartedAt: pod.Status.StartTime,
+		}
+		if instance.Status == "succeeded" {
+			instance.Status = "complete"
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == "Ready" {
+				instance.Healthy = condition.Status == "True"
+			}
+		}
+		for _, container := range pod.Status.ContainerStatuses {
+			if container.State.Waiting != nil && container.State.Waiting.Reason == "CrashLoopBackOff" {
+				instance.Status = "failed"
+			}
+		}
+
+		switch instance.Status {
+		case "running":
+			status.Running++
+		case "failed":
+			status.Failed++
+		}
+		if instance.Healthy {
+			status.Healthy++
+		}
+		status.Instances = append(status.Instances, instance)
+	}
+	return status
+}
+
+func (k *Kubernetes) pods(ctx context.Context, selector string) ([]kubePod, error) {
+	var pods struct {
+		Items []kubePod `json:"items"`
+	}
+	query := url.Values{"labelSelector": {selector}}
+	if err := k.do(ctx, http.MethodGet, k.path("api/v1", "pods", "")+"?"+query.Encode(), "", nil, &pods); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// path of a namespaced object, or of its collection when the name is empty
+func (k *Kubernetes) path(group, resource, name string) string {
+	path := fmt.Sprintf("/%s/namespaces/%s/%s", group, url.PathEscape(k.Namespace), resource)
+	if name != "" {
+		path += "/" + url.PathEscape(name)
+	}
+	return path
+}
+
+// apply creates or updates an object with server-side apply, taking over fields others changed
+func (k *Kubernetes) apply(ctx context.Context, path string, manifest map[string]any, out any) error {
+	query := url.Values{"fieldManager": {fieldManager}, "force": {"true"}}
+	// JSON is YAML, the apply patch type takes both
+	return k.do(ctx, http.MethodPatch, path+"?"+query.Encode(), "application/apply-patch+yaml", manifest, out)
+}
+
+func (k *Kubernetes) deleteIfExists(ctx context.Context, path string) error {
+	err := k.do(ctx, http.MethodDelete, path, "", nil, nil)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (k *Kubernetes) request(ctx context.Context, method, path, contentType string, body any) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, k.Server+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	token := k.Token
+	if k.TokenFile != "" {
+		data, err := os.ReadFile(k.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
+
+func (k *Kubernetes) do(ctx context.Context, method, path, contentType string, body, out any) error {
+	req, err := k.request(ctx, method, path, contentType, body)
+	if err != nil {
+		return err
+	}
+	resp, err := k.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := statusError(resp); err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// statusError returns the message of the Status the API server answers a failed request
+// with, wrapping ErrNotFound when the object does not exist
+func statusError(resp *http.Response) error {
+	if resp.StatusCode < 300 {
+		return nil
+	}
+	var status struct {
+		Message string `json:"message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(data, &status) != nil || status.Message == "" {
+		status.Message = strings.TrimSpace(string(data))
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s: %w", status.Message, ErrNotFound)
+	}
+	return fmt.Errorf("kubernetes API %s: %s", resp.Status, status.Message)
+}
@@ -0,0 +1,242 @@
+package orchestrator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificate returns a self-signed certificate and its key in PEM
+func testCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestFromKubeconfig(t *testing.T) {
+	certificate, key := testCertificate(t)
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{"ca.crt": certificate, "client.crt": certificate, "client.key": key} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data := func(pem []byte) string { return base64.StdEncoding.EncodeToString(pem) }
+
+	tests := []struct {
+		name      string
+		cluster   string
+		user      string
+		wantToken string
+		wantCert  bool
+		wantErr   string
+	}{
+		{
+			name:      "token",
+			user:      "token: secret",
+			wantToken: "secret",
+		},
+		{
+			name: "token file relative to the kubeconfig",
+			user: "tokenFile: token",
+		},
+		{
+			name:     "client certificate files relative to the kubeconfig",
+			cluster:  "certificate-authority: ca.crt",
+			user:     "client-certificate: client.crt\n    client-key: client.key",
+			wantCert: true,
+		},
+		{
+			name:     "client certificate data",
+			cluster:  "certificate-authority-data: " + data(certificate),
+			user:     "client-certificate-data: " + data(certificate) + "\n    client-key-data: " + data(key),
+			wantCert: true,
+		},
+		{
+			name:    "exec plugin",
+			user:    "exec:\n      command: aws",
+			wantErr: "exec plugin",
+		},
+		{
+			name:    "auth provider",
+			user:    "auth-provider:\n      name: gcp",
+			wantErr: "auth provider plugin",
+		},
+		{
+			name:    "basic auth",
+			user:    "username: admin\n    password: admin",
+			wantErr: "basic auth",
+		},
+		{
+			name:    "impersonation",
+			user:    "token: secret\n    as: admin",
+			wantErr: "impersonation",
+		},
+		{
+			name:    "proxy",
+			cluster: "proxy-url: http://proxy:3128",
+			user:    "token: secret",
+			wantErr: "proxy",
+		},
+		{
+			name:    "no credentials",
+			user:    "{}",
+			wantErr: "no token or client certificate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := "current-context: test\n" +
+				"contexts:\n- name: test\n  context:\n    cluster: test\n    user: test\n    namespace: apps\n" +
+				"clusters:\n- name: test\n  cluster:\n    server: https://kubernetes.example.com/\n"
+			if tt.cluster != "" {
+				config += "    " + tt.cluster + "\n"
+			}
+			config += "users:\n- name: test\n  user:\n    " + tt.user + "\n"
+			path := filepath.Join(dir, "kubeconfig")
+			if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			k, err := fromKubeconfig(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got %v, want an error about %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if k.Server != "https://kubernetes.example.com" || k.Namespace != "apps" {
+				t.Fatalf("got server %s and namespace %s", k.Server, k.Namespace)
+			}
+			if k.Token != tt.wantToken {
+				t.Fatalf("got token %q, want %q", k.Token, tt.wantToken)
+			}
+			if k.TokenFile != "" && k.TokenFile != filepath.Join(dir, "token") {
+				t.Fatalf("got token file %s, want it in %s", k.TokenFile, dir)
+			}
+			certificates := k.Client.Transport.(*http.Transport).TLSClientConfig.Certificates
+			if got := len(certificates) > 0; got != tt.wantCert {
+				t.Fatalf("got a client certificate %v, want %v", got, tt.wantCert)
+			}
+		})
+	}
+}
+
+func TestKubernetesScale(t *testing.T) {
+	replicas := 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/apis/apps/v1/namespaces/apps/deployments/web/scale" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"message": `deployments.apps "missing" not found`})
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]any{"spec": map[string]any{"replicas": replicas}})
+		case http.MethodPatch:
+			var patch struct {
+				Spec struct {
+					Replicas int `json:"replicas"`
+				} `json:"spec"`
+			}
+			if r.Header.Get("Content-Type") != "application/merge-patch+json" || json.NewDecoder(r.Body).Decode(&patch) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			replicas = patch.Spec.Replicas
+		}
+	}))
+	defer server.Close()
+	k := &Kubernetes{Server: server.URL, Namespace: "apps", Token: "secret", Client: server.Client()}
+
+	tests := []struct {
+		name         string
+		id           string
+		wantPrevious int
+		wantErr      error
+	}{
+		{name: "scale", id: "web", wantPrevious: 2},
+		{name: "scale again", id: "web", wantPrevious: 5},
+		{name: "missing deployment", id: "missing", wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous, err := k.Scale(context.Background(), tt.id, 5)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (previous != tt.wantPrevious || replicas != 5) {
+				t.Fatalf("got previous %d and replicas %d, want %d and 5", previous, replicas, tt.wantPrevious)
+			}
+		})
+	}
+}
+
+func TestKubeDeploymentStatus(t *testing.T) {
+	var deployment kubeDeployment
+	var pods []kubePod
+	err := json.Unmarshal([]byte(`{
+		"metadata": {"name": "web", "annotations": {"label.controlplane.io/team": "payments", "other": "x"}},
+		"spec": {"replicas": 3},
+		"status": {"availableReplicas": 1}
+	}`), &deployment)
+	if err == nil {
+		err = json.Unmarshal([]byte(`[
+			{"status": {"phase": "Running", "conditions": [{"type": "Ready", "status": "True"}]}},
+			{"status": {"phase": "Running", "containerStatuses": [{"state": {"waiting": {"reason": "CrashLoopBackOff"}}}]}},
+			{"status": {"phase": "Pending"}}
+		]`), &pods)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := deployment.status(pods)
+	if status.Status != "running" || status.Desired != 3 || status.Running != 1 || status.Failed != 1 || status.Healthy != 1 {
+		t.Fatalf("got %+v", status)
+	}
+	if len(status.Labels) != 1 || status.Labels["team"] != "payments" {
+		t.Fatalf("got labels %v, want team=payments", status.Labels)
+	}
+}
@@ -0,0 +1,79 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Schedulers the controller runs applications on
+const (
+	SchedulerNomad      = "nomad"
+	SchedulerKubernetes = "kubernetes"
+)
+
+// ErrNotFound is wrapped by the errors of applications the scheduler does not run
+var ErrNotFound = errors.New("not found")
+
+// Orchestrator runs the applications of the control plane on a scheduler. Nomad supports
+// every feature of the API, other schedulers only these operations.
+type Orchestrator interface {
+	// Deploy creates the application or updates it in place, returning a reference of the
+	// rollout, e.g. a Nomad evaluation
+	Deploy(ctx context.Context, app *Application) (string, error)
+	Delete(ctx context.Context, id string) error
+	Status(ctx context.Context, id string) (*Status, error)
+	// List returns the status of every application the control plane deployed
+	List(ctx context.Context) ([]*Status, error)
+	// Scale sets the instances of the application and returns how many it had
+	Scale(ctx context.Context, id string, instances int) (int, error)
+	// Logs returns the last lines of an instance, the most recent one when empty
+	Logs(ctx context.Context, id, instance string, lines int, stderr bool) ([]string, error)
+	HealthCheck() error
+	String() string
+}
+
+// Application is what every scheduler can run, a container with its resources, ports and
+// an HTTP route
+type Application struct {
+	ID          string
+	Image       string
+	Instances   int
+	CPU         float64 // cores
+	MemoryMB    int64
+	Env         map[string]string
+	Labels      map[string]string
+	Annotations map[string]string
+	Ports       []Port // the first one is routed
+	Host        string // routed to the first port when set
+	SSL         bool
+	HealthPath  string
+}
+
+type Port struct {
+	Label         string
+	ContainerPort int
+	Protocol      string // http, tcp or udp
+}
+
+// Status of an application and its instances
+type Status struct {
+	ID        string
+	Image     string
+	Status    string // pending, running or dead
+	Desired   int
+	Running   int
+	Healthy   int
+	Failed    int
+	Labels    map[string]string
+	UpdatedAt time.Time
+	Instances []Instance
+}
+
+type Instance struct {
+	ID        string
+	Node      string
+	Status    string // pending, running, complete or failed
+	Healthy   bool
+	StartedAt time.Time
+}
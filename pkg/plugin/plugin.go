@@ -0,0 +1,196 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"time"
+
+	nmd "github.com/hashicorp/nomad/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/iuliansafta/control-plane/api/proto"
+)
+
+// Hook is a point of the deploy flow plugins are called at
+type Hook string
+
+const (
+	PreValidate Hook = "pre-validate" // may change or reject the spec
+	MutateJob   Hook = "mutate-job"   // may change or reject the generated Nomad job
+	PostDeploy  Hook = "post-deploy"  // is notified of the registered job
+)
+
+const defaultTimeout = 5 * time.Second
+
+// Config of a plugin, an external gRPC service implementing DeployHook
+type Config struct {
+	Name     string `json:"name"`
+	Address  string `json:"address"`
+	Hooks    []Hook `json:"hooks"`
+	Timeout  string `json:"timeout"`   // per call, defaults to 5s
+	FailOpen bool   `json:"fail_open"` // deploy anyway when the plugin cannot be reached
+}
+
+type plugin struct {
+	Config
+	timeout time.Duration
+	client  pb.DeployHookClient
+	conn    *grpc.ClientConn
+}
+
+// Chain calls the plugins in the order of their config, the output of one plugin is the
+// input of the next. A nil Chain has no plugins.
+type Chain struct {
+	plugins []*plugin
+}
+
+// Load reads the plugin config file and connects to the plugins
+func Load(file string) (*Chain, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var config struct {
+		Plugins []Config `json:"plugins"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid plugin config %s: %w", file, err)
+	}
+
+	chain := &Chain{}
+	for _, c := range config.Plugins {
+		if c.Name == "" || c.Address == "" {
+			return nil, fmt.Errorf("plugin needs a name and an address")
+		}
+		for _, hook := range c.Hooks {
+			if hook != PreValidate && hook != MutateJob && hook != PostDeploy {
+				return nil, fmt.Errorf("plugin %s: unknown hook %q", c.Name, hook)
+			}
+		}
+
+		timeout := defaultTimeout
+		if c.Timeout != "" {
+			if timeout, err = time.ParseDuration(c.Timeout); err != nil {
+				return nil, fmt.Errorf("plugin %s: invalid timeout: %w", c.Name, err)
+			}
+		}
+
+		conn, err := grpc.NewClient(c.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %w", c.Name, err)
+		}
+
+		chain.plugins = append(chain.plugins, &plugin{
+			Config:  c,
+			timeout: timeout,
+			client:  pb.NewDeployHookClient(conn),
+			conn:    conn,
+		})
+	}
+
+	return chain, nil
+}
+
+// Close disconnects from the plugins
+func (c *Chain) Close() {
+	if c == nil {
+		return
+	}
+	for _, p := range c.plugins {
+		_ = p.conn.Close()
+	}
+}
+
+func (c *Chain) with(hook Hook) []*plugin {
+	if c == nil {
+		return nil
+	}
+
+	var plugins []*plugin
+	for _, p := range c.plugins {
+		if slices.Contains(p.Hooks, hook) {
+			plugins = append(plugins, p)
+		}
+	}
+	return plugins
+}
+
+// PreValidate passes the spec through the plugins, which may replace or reject it
+func (c *Chain) PreValidate(ctx context.Context, spec *pb.DeployRequest) (*pb.DeployRequest, error) {
+	for _, p := range c.with(PreValidate) {
+		callCtx, cancel := context.WithTimeout(ctx, p.timeout)
+		resp, err := p.client.PreValidate(callCtx, &pb.PreValidateRequest{Spec: spec})
+		cancel()
+
+		if err != nil {
+			if p.FailOpen {
+				log.Printf("Plugin %s: pre-validate failed, continuing: %v", p.Name, err)
+				continue
+			}
+			return nil, fmt.Errorf("plugin %s: %w", p.Name, err)
+		}
+		if !resp.Allowed {
+			return nil, fmt.Errorf("rejected by plugin %s: %s", p.Name, resp.Message)
+		}
+		if resp.Spec != nil {
+			spec = resp.Spec
+		}
+	}
+
+	return spec, nil
+}
+
+// MutateJob passes the Nomad job through the plugins, which may replace or reject it
+func (c *Chain) MutateJob(ctx context.Context, spec *pb.DeployRequest, job *nmd.Job) (*nmd.Job, error) {
+	for _, p := range c.with(MutateJob) {
+		data, err := json.Marshal(job)
+		if err != nil {
+			return nil, err
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, p.timeout)
+		resp, err := p.client.MutateJob(callCtx, &pb.MutateJobRequest{Spec: spec, Job: data})
+		cancel()
+
+		if err != nil {
+			if p.FailOpen {
+				log.Printf("Plugin %s: mutate-job failed, continuing: %v", p.Name, err)
+				continue
+			}
+			return nil, fmt.Errorf("plugin %s: %w", p.Name, err)
+		}
+		if !resp.Allowed {
+			return nil, fmt.Errorf("rejected by plugin %s: %s", p.Name, resp.Message)
+		}
+		if len(resp.Job) > 0 {
+			mutated := &nmd.Job{}
+			if err := json.Unmarshal(resp.Job, mutated); err != nil {
+				return nil, fmt.Errorf("plugin %s returned an invalid job: %w", p.Name, err)
+			}
+			job = mutated
+		}
+	}
+
+	return job, nil
+}
+
+// PostDeploy notifies the plugins in the background, the deploy does not wait for them
+func (c *Chain) PostDeploy(spec *pb.DeployRequest, deploymentID string) {
+	for _, p := range c.with(PostDeploy) {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+			defer cancel()
+
+			_, err := p.client.PostDeploy(ctx, &pb.PostDeployRequest{Spec: spec, DeploymentId: deploymentID})
+			if err != nil {
+				log.Printf("Plugin %s: post-deploy of %s failed: %v", p.Name, spec.Name, err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,47 @@
+package store
+
+import "time"
+
+// artifacts kept per application, the oldest are dropped first
+const maxArtifacts = 50
+
+// Artifact is an SBOM or provenance attestation CI attached to an image of an application
+type Artifact struct {
+	ID                string // first 12 hex digits of SHA256, attaching the same content again replaces it
+	Application       string
+	Image             string
+	Digest            string // the image the artifact describes
+	Kind              string
+	MediaType         string
+	SHA256            string
+	Content           []byte
+	Verified          bool
+	Signer            string // who signed the attestation, set when verified
+	VerificationError string
+	CreatedAt         time.Time
+}
+
+func (m *MemoryStore) SaveArtifact(artifact Artifact) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	artifacts := []Artifact{artifact}
+	for _, existing := range m.artifacts[artifact.Application] {
+		if existing.ID != artifact.ID {
+			artifacts = append(artifacts, existing)
+		}
+	}
+	if len(artifacts) > maxArtifacts {
+		artifacts = artifacts[:maxArtifacts]
+	}
+	m.artifacts[artifact.Application] = artifacts
+
+	return nil
+}
+
+func (m *MemoryStore) Artifacts(application string) ([]Artifact, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]Artifact(nil), m.artifacts[application]...), nil
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"sort"
+	"time"
+)
+
+// Snapshot is a backup of the volumes of an application
+type Snapshot struct {
+	ID          string
+	Application string
+	Location    string // object the archive is written to
+	Status      string // running, complete or failed
+	JobID       string // dispatched backup job
+	StartedAt   time.Time
+	FinishedAt  time.Time
+}
+
+func (m *MemoryStore) SaveSnapshot(snapshot Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshots := m.snapshots[snapshot.Application]
+	for i, existing := range snapshots {
+		if existing.ID == snapshot.ID {
+			snapshots[i] = snapshot
+			return nil
+		}
+	}
+
+	snapshots = append(snapshots, snapshot)
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].StartedAt.After(snapshots[j].StartedAt)
+	})
+	m.snapshots[snapshot.Application] = snapshots
+
+	return nil
+}
+
+func (m *MemoryStore) Snapshots(application string) ([]Snapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]Snapshot(nil), m.snapshots[application]...), nil
+}
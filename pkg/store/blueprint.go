@@ -0,0 +1,127 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BlueprintVersion is a published revision of a shared base spec
+type BlueprintVersion struct {
+	Tenant      string // empty for platform blueprints
+	Blueprint   string
+	Channel     string
+	Version     int
+	Spec        []byte // serialized DeployRequest, sealed with the tenant's data key
+	PublishedAt time.Time
+}
+
+// Subscription ties an application to the channel of a blueprint it is built from
+type Subscription struct {
+	Tenant          string
+	Application     string
+	Blueprint       string
+	Channel         string
+	Policy          string // "propose" or "auto"
+	PinnedVersion   int    // 0 follows the channel
+	DeployedVersion int
+	Overrides       []byte // serialized DeployRequest merged over the blueprint spec, sealed with the tenant's data key
+}
+
+type blueprintRecord struct {
+	tenant   string
+	versions []BlueprintVersion
+	heads    map[string]int // channel to latest version
+}
+
+func (m *MemoryStore) PublishBlueprint(tenant, blueprint, channel string, spec []byte) (BlueprintVersion, error) {
+	return m.publishBlueprint(BlueprintVersion{
+		Tenant:      tenant,
+		Blueprint:   blueprint,
+		Channel:     channel,
+		Spec:        spec,
+		PublishedAt: time.Now(),
+	})
+}
+
+// publishBlueprint numbers and stores the version, replicas replay it with the leader's timestamp
+func (m *MemoryStore) publishBlueprint(version BlueprintVersion) (BlueprintVersion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.blueprints[version.Blueprint]
+	if !ok {
+		record = &blueprintRecord{tenant: version.Tenant, heads: make(map[string]int)}
+		m.blueprints[version.Blueprint] = record
+	}
+	if record.tenant != version.Tenant {
+		return BlueprintVersion{}, fmt.Errorf("blueprint %s: %w for another tenant", version.Blueprint, ErrAlreadyExists)
+	}
+
+	version.Version = len(record.versions) + 1
+	record.versions = append(record.versions, version)
+	record.heads[version.Channel] = version.Version
+
+	return version, nil
+}
+
+func (m *MemoryStore) BlueprintVersion(blueprint, channel string, version int) (BlueprintVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	record, ok := m.blueprints[blueprint]
+	if !ok {
+		return BlueprintVersion{}, fmt.Errorf("blueprint %s: %w", blueprint, ErrNotFound)
+	}
+
+	if version == 0 {
+		head, ok := record.heads[channel]
+		if !ok {
+			return BlueprintVersion{}, fmt.Errorf("channel %s of blueprint %s: %w", channel, blueprint, ErrNotFound)
+		}
+		version = head
+	}
+
+	if version < 1 || version > len(record.versions) {
+		return BlueprintVersion{}, fmt.Errorf("version %d of blueprint %s: %w", version, blueprint, ErrNotFound)
+	}
+
+	return record.versions[version-1], nil
+}
+
+func (m *MemoryStore) SaveSubscription(subscription Subscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.subscriptions[subscription.Application] = subscription
+	return nil
+}
+
+func (m *MemoryStore) Subscription(application string) (Subscription, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	subscription, ok := m.subscriptions[application]
+	if !ok {
+		return Subscription{}, fmt.Errorf("subscription of %s: %w", application, ErrNotFound)
+	}
+	return subscription, nil
+}
+
+func (m *MemoryStore) Subscriptions(blueprint string) ([]Subscription, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var subscriptions []Subscription
+	for _, subscription := range m.subscriptions {
+		if blueprint == "" || subscription.Blueprint == blueprint {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].Application < subscriptions[j].Application
+	})
+
+	return subscriptions, nil
+}
@@ -0,0 +1,31 @@
+package store
+
+import "slices"
+
+func (m *MemoryStore) SetDependencies(application string, dependsOn []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(dependsOn) == 0 {
+		delete(m.dependencies, application)
+		return nil
+	}
+
+	dependencies := slices.Clone(dependsOn)
+	slices.Sort(dependencies)
+	m.dependencies[application] = slices.Compact(dependencies)
+
+	return nil
+}
+
+func (m *MemoryStore) Dependencies() (map[string][]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	graph := make(map[string][]string, len(m.dependencies))
+	for application, dependsOn := range m.dependencies {
+		graph[application] = slices.Clone(dependsOn)
+	}
+
+	return graph, nil
+}
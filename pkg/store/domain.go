@@ -0,0 +1,47 @@
+package store
+
+import (
+	"sort"
+	"time"
+)
+
+// Domain is a custom domain a tenant routes applications on, outside of its domain template
+type Domain struct {
+	Name       string
+	Tenant     string
+	Token      string // expected in the TXT record proving the tenant controls the domain
+	Verified   bool
+	CreatedAt  time.Time
+	VerifiedAt time.Time
+	CheckedAt  time.Time
+	LastError  string // why the last check failed
+}
+
+func (m *MemoryStore) SaveDomain(domain Domain) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.domains[domain.Tenant+"/"+domain.Name] = domain
+
+	return nil
+}
+
+func (m *MemoryStore) Domains(tenant string) ([]Domain, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var domains []Domain
+	for _, domain := range m.domains {
+		if tenant == "" || domain.Tenant == tenant {
+			domains = append(domains, domain)
+		}
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		if domains[i].Name != domains[j].Name {
+			return domains[i].Name < domains[j].Name
+		}
+		return domains[i].Tenant < domains[j].Tenant
+	})
+
+	return domains, nil
+}
@@ -0,0 +1,415 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+// drBatchSize bounds the log entries sent to the standby site in one request
+const drBatchSize = 256
+
+// Roles of a controller in disaster recovery replication
+const (
+	RolePrimary  = "primary"
+	RoleStandby  = "standby"
+	RolePromoted = "promoted"
+)
+
+// ReplicationStatus is the progress of disaster recovery replication between sites
+type ReplicationStatus struct {
+	Role            string // empty without replication
+	Peer            string // standby site a primary replicates to
+	Index           uint64 // applied index of the local Raft log
+	ReplicatedIndex uint64 // index of the primary's log the standby applied
+	ReplicatedAt    time.Time
+	PromotedAt      time.Time
+	LastError       string
+}
+
+// drState is what a standby replicated from the primary site, it is part of the
+// standby's own Raft state so that every replica of the standby agrees on it
+type drState struct {
+	Index      uint64    `json:"index"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Promoted   bool      `json:"promoted"`
+	PromotedAt time.Time `json:"promoted_at"`
+}
+
+// drBatch is a range of the primary's Raft log, the commands are the log entries as
+// they are, entries which are not registry writes are left out but count in the range
+type drBatch struct {
+	FromIndex uint64            `json:"from_index"`
+	ToIndex   uint64            `json:"to_index"`
+	Entries   []json.RawMessage `json:"entries"`
+	SentAt    time.Time         `json:"sent_at"`
+}
+
+// drRestore replaces the registry of the standby with a snapshot of the primary, when
+// the log entries it misses were compacted
+type drRestore struct {
+	Index    uint64          `json:"index"`
+	Snapshot json.RawMessage `json:"snapshot"`
+	SentAt   time.Time       `json:"sent_at"`
+}
+
+func (f *raftFSM) drState() drState {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.dr
+}
+
+// drRejected refuses writes from the primary once the standby was promoted, or when
+// they do not continue what the standby replicated so far
+func (f *raftFSM) drRejected(fromIndex uint64, restore bool) *raftResult {
+	dr := f.drState()
+	switch {
+	case dr.Promoted:
+		return &raftResult{Error: "the standby was promoted", Code: "promoted", Index: dr.Index}
+	case !restore && fromIndex != dr.Index:
+		return &raftResult{
+			Error: fmt.Sprintf("the standby replicated up to index %d, not %d", dr.Index, fromIndex),
+			Code:  "out_of_sync",
+			Index: dr.Index,
+		}
+	}
+	return nil
+}
+
+func (f *raftFSM) applyBatch(batch drBatch) *raftResult {
+	if result := f.drRejected(batch.FromIndex, false); result != nil {
+		return result
+	}
+
+	// the commands failed the same way on the primary, their errors are not the batch's
+	for _, entry := range batch.Entries {
+		f.applyCommand(entry)
+	}
+
+	f.mu.Lock()
+	f.dr.Index = batch.ToIndex
+	f.dr.UpdatedAt = batch.SentAt
+	f.mu.Unlock()
+
+	return &raftResult{Index: batch.ToIndex}
+}
+
+func (f *raftFSM) applyRestore(restore drRestore) *raftResult {
+	if result := f.drRejected(restore.Index, true); result != nil {
+		return result
+	}
+
+	var snapshot fsmSnapshot
+	if err := json.Unmarshal(restore.Snapshot, &snapshot); err != nil {
+		return resultOf(fmt.Errorf("invalid snapshot: %w", err))
+	}
+
+	// members and replication state of the primary site are not the standby's
+	f.state.restore(&snapshot)
+
+	f.mu.Lock()
+	f.dr.Index = restore.Index
+	f.dr.UpdatedAt = restore.SentAt
+	f.mu.Unlock()
+
+	return &raftResult{Index: restore.Index}
+}
+
+// Standby reports whether the replica belongs to a standby site which was not promoted,
+// its registry is written by the primary site only
+func (s *RaftStore) Standby() bool {
+	return s.config.Standby && !s.fsm.drState().Promoted
+}
+
+// Promote makes the standby site take over, it stops accepting writes from the
+// primary and starts serving its own
+func (s *RaftStore) Promote() error {
+	if !s.config.Standby {
+		return fmt.Errorf("the controller is not a disaster recovery standby")
+	}
+	if !s.Standby() {
+		return fmt.Errorf("the standby was already promoted")
+	}
+
+	_, err := s.apply(opDRPromote, time.Now().UTC())
+	return err
+}
+
+// ReplicationStatus reports the role of the site and how far replication went
+func (s *RaftStore) ReplicationStatus() ReplicationStatus {
+	status := ReplicationStatus{Index: s.raft.AppliedIndex()}
+
+	if s.config.Standby {
+		dr := s.fsm.drState()
+		status.Role = RoleStandby
+		status.ReplicatedIndex = dr.Index
+		status.ReplicatedAt = dr.UpdatedAt
+		if !dr.Promoted {
+			return status
+		}
+		status.Role = RolePromoted
+		status.PromotedAt = dr.PromotedAt
+	}
+
+	// a promoted standby replicates to the old primary when it is failed back
+	s.drMu.Lock()
+	defer s.drMu.Unlock()
+	if s.drPeer != "" {
+		if status.Role == "" {
+			status.Role = RolePrimary
+		}
+		status.Peer = s.drPeer
+		status.ReplicatedIndex = s.drSent
+		status.ReplicatedAt = s.drSentAt
+		status.LastError = s.drError
+	}
+	return status
+}
+
+// ReplicateTo streams the registry to the standby site at the HTTP address of one of
+// its Raft replicas, until ctx is done. Only the leader replicates, followers of the
+// primary site take over when they are elected.
+func (s *RaftStore) ReplicateTo(ctx context.Context, standby string, interval time.Duration) {
+	s.drMu.Lock()
+	s.drPeer = standby
+	s.drMu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if s.IsLeader() {
+			err := s.replicate(standby)
+			s.drMu.Lock()
+			s.drError = ""
+			if err != nil {
+				s.drError = err.Error()
+			}
+			s.drMu.Unlock()
+			if err != nil {
+				log.Printf("DR: failed to replicate to %s: %v", standby, err)
+			}
+		} else {
+			// a leader elected later asks the standby where it stands
+			s.drMu.Lock()
+			s.drKnown = false
+			s.drMu.Unlock()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// replicate sends the standby the log entries it misses, or a snapshot when it has
+// nothing yet or they were compacted
+func (s *RaftStore) replicate(standby string) error {
+	s.drMu.Lock()
+	sent, known := s.drSent, s.drKnown
+	s.drMu.Unlock()
+
+	if !known {
+		result, err := s.sendDR(standby, http.MethodGet, "/dr/status", nil)
+		if err != nil {
+			return err
+		}
+		if result.Code == "promoted" {
+			return errors.New(result.Error)
+		}
+		sent = result.Index
+		s.drMu.Lock()
+		s.drSent, s.drKnown = sent, true
+		s.drMu.Unlock()
+	}
+
+	applied := s.raft.AppliedIndex()
+	if sent == applied {
+		return nil
+	}
+	if sent == 0 || sent > applied {
+		return s.replicateSnapshot(standby)
+	}
+
+	batch := drBatch{FromIndex: sent, ToIndex: sent, SentAt: time.Now().UTC()}
+	for index := sent + 1; index <= applied && len(batch.Entries) < drBatchSize; index++ {
+		var entry raft.Log
+		if err := s.logs.GetLog(index, &entry); err != nil {
+			if errors.Is(err, raft.ErrLogNotFound) {
+				return s.replicateSnapshot(standby)
+			}
+			return err
+		}
+		batch.ToIndex = index
+		if entry.Type != raft.LogCommand {
+			continue
+		}
+
+		var cmd raftCommand
+		if err := json.Unmarshal(entry.Data, &cmd); err != nil {
+			return fmt.Errorf("invalid command at index %d: %w", index, err)
+		}
+		switch cmd.Op {
+		case opJoin, opDRPromote:
+			continue
+		case opDRApply, opDRRestore:
+			// a promoted standby's log holds what it replicated, a snapshot has it applied
+			return s.replicateSnapshot(standby)
+		}
+		batch.Entries = append(batch.Entries, entry.Data)
+	}
+
+	return s.sendBatch(standby, opDRApply, batch, batch.ToIndex, batch.SentAt)
+}
+
+// replicateSnapshot sends the latest snapshot of the registry, taking one first so that
+// the standby does not replay what is left in the log
+func (s *RaftStore) replicateSnapshot(standby string) error {
+	if err := s.raft.Snapshot().Error(); err != nil && !errors.Is(err, raft.ErrNothingNewToSnapshot) {
+		return fmt.Errorf("failed to snapshot: %w", err)
+	}
+
+	snapshots, err := s.snapshots.List()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		return fmt.Errorf("no snapshot of the registry to send")
+	}
+
+	meta, reader, err := s.snapshots.Open(snapshots[0].ID)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	restore := drRestore{Index: meta.Index, Snapshot: data, SentAt: time.Now().UTC()}
+	if err := s.sendBatch(standby, opDRRestore, restore, restore.Index, restore.SentAt); err != nil {
+		return err
+	}
+
+	log.Printf("DR: restored snapshot at index %d on %s", meta.Index, standby)
+	return nil
+}
+
+// sendBatch applies a command on the standby and records how far it got
+func (s *RaftStore) sendBatch(standby, op string, v any, index uint64, sentAt time.Time) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	cmd, err := json.Marshal(raftCommand{Op: op, Data: data})
+	if err != nil {
+		return err
+	}
+
+	result, err := s.sendDR(standby, http.MethodPost, "/dr/apply", cmd)
+	if err != nil {
+		return err
+	}
+
+	s.drMu.Lock()
+	defer s.drMu.Unlock()
+	switch result.Code {
+	case "":
+		if result.Error != "" {
+			return errors.New(result.Error)
+		}
+		s.drSent, s.drSentAt = index, sentAt
+		return nil
+	case "out_of_sync":
+		// resume from where the standby is on the next round
+		s.drSent = result.Index
+		return nil
+	default:
+		return errors.New(result.Error)
+	}
+}
+
+func (s *RaftStore) sendDR(standby, method, path string, body []byte) (*raftResult, error) {
+	req, err := http.NewRequest(method, "http://"+standby+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("standby %s: %s", standby, bytes.TrimSpace(message))
+	}
+
+	result := &raftResult{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// handleDRStatus tells the primary site how far the standby replicated
+func (s *RaftStore) handleDRStatus(w http.ResponseWriter, r *http.Request) {
+	if !s.config.Standby {
+		http.Error(w, "not a disaster recovery standby", http.StatusForbidden)
+		return
+	}
+
+	dr := s.fsm.drState()
+	result := &raftResult{Index: dr.Index}
+	if dr.Promoted {
+		result.Error, result.Code = "the standby was promoted", "promoted"
+	}
+	writeResult(w, result)
+}
+
+// handleDRApply applies what the primary site replicates through the standby's leader
+func (s *RaftStore) handleDRApply(w http.ResponseWriter, r *http.Request) {
+	if !s.config.Standby {
+		http.Error(w, "not a disaster recovery standby", http.StatusForbidden)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var cmd raftCommand
+	if err := json.Unmarshal(body, &cmd); err != nil || (cmd.Op != opDRApply && cmd.Op != opDRRestore) {
+		http.Error(w, "invalid replication command", http.StatusBadRequest)
+		return
+	}
+
+	var result *raftResult
+	if s.raft.State() == raft.Leader {
+		result, err = s.applyLocal(body)
+	} else {
+		result, err = s.forward("/raft/apply", body)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	writeResult(w, result)
+}
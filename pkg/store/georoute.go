@@ -0,0 +1,80 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/iuliansafta/control-plane/pkg/dns"
+)
+
+// GeoRoute routes the users of an application deployed to several regions through DNS
+type GeoRoute struct {
+	Application string
+	Hostname    string
+	Targets     []GeoTarget
+	Failover    bool
+	Health      map[string]GeoHealth // by region
+	Records     []dns.Record         // applied at the DNS provider
+	UpdatedAt   time.Time
+}
+
+// GeoTarget is a region the application is deployed to
+type GeoTarget struct {
+	Region    string
+	Weight    int64
+	Locations []string // continents or countries routed to the region
+}
+
+// GeoHealth is the health of the application in a region, its records are withdrawn
+// while it is unhealthy
+type GeoHealth struct {
+	Healthy   bool
+	Reason    string
+	ChangedAt time.Time
+}
+
+func (m *MemoryStore) SaveGeoRoute(route GeoRoute) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.geoRoutes[route.Application] = route
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteGeoRoute(application string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.geoRoutes, application)
+
+	return nil
+}
+
+func (m *MemoryStore) GeoRoute(application string) (GeoRoute, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	route, ok := m.geoRoutes[application]
+	if !ok {
+		return GeoRoute{}, fmt.Errorf("geo route of %s: %w", application, ErrNotFound)
+	}
+
+	return route, nil
+}
+
+func (m *MemoryStore) GeoRoutes() ([]GeoRoute, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	routes := make([]GeoRoute, 0, len(m.geoRoutes))
+	for _, route := range m.geoRoutes {
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Application < routes[j].Application
+	})
+
+	return routes, nil
+}
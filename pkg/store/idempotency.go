@@ -0,0 +1,79 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// IdempotencyKey records a mutating request, a retry with the same key gets the original
+// result instead of running the request again
+type IdempotencyKey struct {
+	Key       string
+	Operation string // e.g. deploy, scale or delete
+	Request   string // hash of the request, a key cannot be reused for another one
+	Done      bool   // false while the request runs
+	Result    []byte // JSON of the result
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func (k IdempotencyKey) expired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
+
+func (m *MemoryStore) CreateIdempotencyKey(key IdempotencyKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the creation time of the new key keeps replicas dropping the same keys
+	for name, existing := range m.idempotencyKeys {
+		if existing.expired(key.CreatedAt) {
+			delete(m.idempotencyKeys, name)
+		}
+	}
+
+	if _, ok := m.idempotencyKeys[key.Key]; ok {
+		return fmt.Errorf("idempotency key %s: %w", key.Key, ErrAlreadyExists)
+	}
+	m.idempotencyKeys[key.Key] = key
+
+	return nil
+}
+
+// expiredIdempotencyKeys lists the keys CreateIdempotencyKey drops when it creates a key at a time
+func (m *MemoryStore) expiredIdempotencyKeys(now time.Time) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var keys []string
+	for name, existing := range m.idempotencyKeys {
+		if existing.expired(now) {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
+func (m *MemoryStore) UpdateIdempotencyKey(key IdempotencyKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.idempotencyKeys[key.Key]; !ok {
+		return fmt.Errorf("idempotency key %s: %w", key.Key, ErrNotFound)
+	}
+	m.idempotencyKeys[key.Key] = key
+
+	return nil
+}
+
+func (m *MemoryStore) IdempotencyKey(key string) (IdempotencyKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	existing, ok := m.idempotencyKeys[key]
+	if !ok || existing.expired(time.Now()) {
+		return IdempotencyKey{}, fmt.Errorf("idempotency key %s: %w", key, ErrNotFound)
+	}
+
+	return existing, nil
+}
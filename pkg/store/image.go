@@ -0,0 +1,55 @@
+package store
+
+import (
+	"sort"
+	"time"
+)
+
+// DeployedImage is the image an application was deployed with and the digest its tag
+// resolved to, the controller compares it with the registry to detect drift
+type DeployedImage struct {
+	Application string
+	Image       string
+	Digest      string
+	DeployedAt  time.Time
+	Redeploy    bool // pin the deployed digest once the tag moves
+	CheckedAt   time.Time
+	// set while the tag resolves to another digest than deployed
+	TagDigest string
+	MovedAt   time.Time // last check still resolving to the deployed digest
+	Drifted   []string  // allocations (re)started since, they may run TagDigest
+	AlertedAt time.Time
+}
+
+func (m *MemoryStore) SaveDeployedImage(image DeployedImage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deployedImages[image.Application] = image
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteDeployedImage(application string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.deployedImages, application)
+
+	return nil
+}
+
+func (m *MemoryStore) DeployedImages() ([]DeployedImage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	images := make([]DeployedImage, 0, len(m.deployedImages))
+	for _, image := range m.deployedImages {
+		images = append(images, image)
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Application < images[j].Application
+	})
+
+	return images, nil
+}
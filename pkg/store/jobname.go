@@ -0,0 +1,51 @@
+package store
+
+import (
+	"sort"
+	"time"
+)
+
+// JobName maps an application of a tenant to the Nomad job the naming strategy of the
+// controller gave it
+type JobName struct {
+	JobID       string
+	Application string // the name the application was deployed with
+	Tenant      string
+	Environment string
+	Project     string // the project label of the application
+	Namespace   string // Nomad namespace of the job, empty for the default namespace
+	CreatedAt   time.Time
+}
+
+func (m *MemoryStore) SaveJobName(name JobName) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.jobNames[name.JobID] = name
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteJobName(jobID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.jobNames, jobID)
+
+	return nil
+}
+
+func (m *MemoryStore) JobNames() ([]JobName, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make([]JobName, 0, len(m.jobNames))
+	for _, name := range m.jobNames {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].JobID < names[j].JobID
+	})
+
+	return names, nil
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Namespace separates the applications of a team, they run in the Nomad namespace of the
+// same name
+type Namespace struct {
+	Name        string
+	Description string
+	Tenant      string // owner, empty for namespaces of the platform
+	CreatedAt   time.Time
+}
+
+func (m *MemoryStore) CreateNamespace(namespace Namespace) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.namespaces[namespace.Name]; ok {
+		return fmt.Errorf("namespace %s: %w", namespace.Name, ErrAlreadyExists)
+	}
+	m.namespaces[namespace.Name] = namespace
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteNamespace(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.namespaces, name)
+
+	return nil
+}
+
+func (m *MemoryStore) Namespace(name string) (Namespace, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	namespace, ok := m.namespaces[name]
+	if !ok {
+		return Namespace{}, fmt.Errorf("namespace %s: %w", name, ErrNotFound)
+	}
+	return namespace, nil
+}
+
+func (m *MemoryStore) Namespaces() ([]Namespace, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	namespaces := make([]Namespace, 0, len(m.namespaces))
+	for _, namespace := range m.namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Name < namespaces[j].Name
+	})
+
+	return namespaces, nil
+}
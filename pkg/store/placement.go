@@ -0,0 +1,58 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// PlacementDecision is the region the placement engine chose for an application and how
+// it scored the candidates
+type PlacementDecision struct {
+	Application string
+	Region      string
+	Candidates  []PlacementCandidate // best first
+	DecidedAt   time.Time
+}
+
+// PlacementCandidate is a region considered by the placement engine
+type PlacementCandidate struct {
+	Region        string
+	Eligible      bool
+	Reason        string // why the region was excluded
+	Score         float64
+	CapacityScore float64
+	LatencyScore  float64
+	CostScore     float64
+	CPUFreeMHz    int64
+	MemoryFreeMB  int64
+}
+
+func (m *MemoryStore) SavePlacement(decision PlacementDecision) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.placements[decision.Application] = decision
+
+	return nil
+}
+
+func (m *MemoryStore) DeletePlacement(application string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.placements, application)
+
+	return nil
+}
+
+func (m *MemoryStore) Placement(application string) (PlacementDecision, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	decision, ok := m.placements[application]
+	if !ok {
+		return PlacementDecision{}, fmt.Errorf("placement of %s: %w", application, ErrNotFound)
+	}
+
+	return decision, nil
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Project groups applications of a tenant, they join it with the project label
+type Project struct {
+	Name        string
+	Tenant      string // empty for platform projects
+	Description string
+	Defaults    []byte // serialized DeployRequest the specs of the applications are merged over, sealed with the tenant's data key
+	Quota       ProjectQuota
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ProjectQuota limits what the applications of a project request in total, zero is no limit
+type ProjectQuota struct {
+	CPU             float64 // cores
+	MemoryMB        int64
+	MaxApplications int
+}
+
+func (m *MemoryStore) SaveProject(project Project) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.projects[project.Name]; ok {
+		if existing.Tenant != project.Tenant {
+			return fmt.Errorf("project %s: %w for another tenant", project.Name, ErrAlreadyExists)
+		}
+		project.CreatedAt = existing.CreatedAt
+	}
+	m.projects[project.Name] = project
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteProject(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.projects, name)
+
+	return nil
+}
+
+func (m *MemoryStore) Project(name string) (Project, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	project, ok := m.projects[name]
+	if !ok {
+		return Project{}, fmt.Errorf("project %s: %w", name, ErrNotFound)
+	}
+	return project, nil
+}
+
+func (m *MemoryStore) Projects(tenant string) ([]Project, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var projects []Project
+	for _, project := range m.projects {
+		if tenant == "" || project.Tenant == tenant {
+			projects = append(projects, project)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	return projects, nil
+}
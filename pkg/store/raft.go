@@ -0,0 +1,510 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
+)
+
+const (
+	raftApplyTimeout = 10 * time.Second
+	raftJoinRetry    = 5 * time.Second
+)
+
+// RaftConfig configures a controller replica of a Raft cluster
+type RaftConfig struct {
+	NodeID    string // unique and stable across restarts
+	BindAddr  string // Raft transport, e.g. 10.0.0.1:7000
+	HTTPAddr  string // advertised address of Handler, receives joins and forwarded writes
+	DataDir   string
+	Bootstrap bool   // start a new cluster with this replica as the only member
+	Join      string // HTTP address of a member to join
+	NonVoter  bool   // replicate without voting or becoming leader, for read-only replicas
+	Standby   bool   // disaster recovery standby, written by the primary site until promoted
+}
+
+// RaftStore replicates the registry across controller replicas with an embedded Raft
+// cluster, the control plane is highly available without an external database.
+// Reads are served from the local replica, writes are applied through the leader.
+type RaftStore struct {
+	*MemoryStore
+
+	config    RaftConfig
+	raft      *raft.Raft
+	fsm       *raftFSM
+	logs      raft.LogStore
+	snapshots raft.SnapshotStore
+	client    *http.Client
+
+	// disaster recovery replication to the standby site
+	drMu     sync.Mutex
+	drPeer   string
+	drKnown  bool
+	drSent   uint64
+	drSentAt time.Time
+	drError  string
+}
+
+// NewRaftStore starts the replica, its log, snapshots and state survive restarts in DataDir
+func NewRaftStore(config RaftConfig) (*RaftStore, error) {
+	if config.NodeID == "" || config.BindAddr == "" || config.HTTPAddr == "" || config.DataDir == "" {
+		return nil, fmt.Errorf("raft needs a node ID, bind address, HTTP address and data dir")
+	}
+
+	if err := os.MkdirAll(config.DataDir, 0o700); err != nil {
+		return nil, err
+	}
+
+	fsm := &raftFSM{
+		state:   NewMemoryStore(),
+		members: make(map[string]raftMember),
+	}
+
+	raftConfig := raft.DefaultConfig()
+	raftConfig.LocalID = raft.ServerID(config.NodeID)
+
+	advertise, err := net.ResolveTCPAddr("tcp", config.BindAddr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raft address: %w", err)
+	}
+	transport, err := raft.NewTCPTransport(config.BindAddr, advertise, 3, 10*time.Second, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := raft.NewFileSnapshotStore(config.DataDir, 2, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	boltStore, err := raftboltdb.NewBoltStore(filepath.Join(config.DataDir, "raft.db"))
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := raft.NewRaft(raftConfig, fsm, boltStore, boltStore, snapshots, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Bootstrap {
+		err := r.BootstrapCluster(raft.Configuration{
+			Servers: []raft.Server{{ID: raftConfig.LocalID, Address: transport.LocalAddr()}},
+		}).Error()
+		if err != nil && !errors.Is(err, raft.ErrCantBootstrap) {
+			return nil, fmt.Errorf("failed to bootstrap raft: %w", err)
+		}
+	}
+
+	s := &RaftStore{
+		MemoryStore: fsm.state,
+		config:      config,
+		raft:        r,
+		fsm:         fsm,
+		logs:        boltStore,
+		snapshots:   snapshots,
+		client:      &http.Client{Timeout: raftApplyTimeout},
+	}
+
+	go s.registerOnLeadership()
+	if config.Join != "" {
+		go s.join()
+	}
+
+	return s, nil
+}
+
+// Close leaves the replica's state on disk for the next start
+func (s *RaftStore) Close() error {
+	return s.raft.Shutdown().Error()
+}
+
+func (s *RaftStore) SaveRollout(rollout Rollout) error {
+	_, err := s.apply(opSaveRollout, rollout)
+	return err
+}
+
+func (s *RaftStore) SaveInvocation(invocation Invocation) error {
+	_, err := s.apply(opSaveInvocation, invocation)
+	return err
+}
+
+func (s *RaftStore) PublishBlueprint(tenant, blueprint, channel string, spec []byte) (BlueprintVersion, error) {
+	result, err := s.apply(opPublishBlueprint, BlueprintVersion{
+		Tenant:      tenant,
+		Blueprint:   blueprint,
+		Channel:     channel,
+		Spec:        spec,
+		PublishedAt: time.Now(),
+	})
+	if err != nil {
+		return BlueprintVersion{}, err
+	}
+	return *result.Blueprint, nil
+}
+
+func (s *RaftStore) SaveSubscription(subscription Subscription) error {
+	_, err := s.apply(opSaveSubscription, subscription)
+	return err
+}
+
+func (s *RaftStore) SetDependencies(application string, dependsOn []string) error {
+	_, err := s.apply(opSetDependencies, dependencies{Application: application, DependsOn: dependsOn})
+	return err
+}
+
+func (s *RaftStore) CreateTenant(tenant Tenant) error {
+	_, err := s.apply(opCreateTenant, tenant)
+	return err
+}
+
+func (s *RaftStore) UpdateTenant(tenant Tenant) error {
+	_, err := s.apply(opUpdateTenant, tenant)
+	return err
+}
+
+func (s *RaftStore) SaveServiceAccount(account ServiceAccount) error {
+	_, err := s.apply(opSaveServiceAccount, account)
+	return err
+}
+
+func (s *RaftStore) SaveSnapshot(snapshot Snapshot) error {
+	_, err := s.apply(opSaveSnapshot, snapshot)
+	return err
+}
+
+func (s *RaftStore) SaveDomain(domain Domain) error {
+	_, err := s.apply(opSaveDomain, domain)
+	return err
+}
+
+func (s *RaftStore) SaveDeployedImage(image DeployedImage) error {
+	_, err := s.apply(opSaveDeployedImage, image)
+	return err
+}
+
+func (s *RaftStore) DeleteDeployedImage(application string) error {
+	_, err := s.apply(opDeleteDeployedImage, application)
+	return err
+}
+
+func (s *RaftStore) SaveArtifact(artifact Artifact) error {
+	_, err := s.apply(opSaveArtifact, artifact)
+	return err
+}
+
+func (s *RaftStore) SaveJobName(name JobName) error {
+	_, err := s.apply(opSaveJobName, name)
+	return err
+}
+
+func (s *RaftStore) CreateIdempotencyKey(key IdempotencyKey) error {
+	_, err := s.apply(opCreateIdempotency, key)
+	return err
+}
+
+func (s *RaftStore) UpdateIdempotencyKey(key IdempotencyKey) error {
+	_, err := s.apply(opUpdateIdempotency, key)
+	return err
+}
+
+func (s *RaftStore) SavePlacement(decision PlacementDecision) error {
+	_, err := s.apply(opSavePlacement, decision)
+	return err
+}
+
+func (s *RaftStore) DeletePlacement(application string) error {
+	_, err := s.apply(opDeletePlacement, application)
+	return err
+}
+
+func (s *RaftStore) SaveGeoRoute(route GeoRoute) error {
+	_, err := s.apply(opSaveGeoRoute, route)
+	return err
+}
+
+func (s *RaftStore) DeleteGeoRoute(application string) error {
+	_, err := s.apply(opDeleteGeoRoute, application)
+	return err
+}
+
+func (s *RaftStore) SaveUsage(samples []UsageSample) error {
+	_, err := s.apply(opSaveUsage, samples)
+	return err
+}
+
+func (s *RaftStore) DeleteUsage(application string) error {
+	_, err := s.apply(opDeleteUsage, application)
+	return err
+}
+
+func (s *RaftStore) SaveResourceProposal(proposal ResourceProposal) error {
+	_, err := s.apply(opSaveProposal, proposal)
+	return err
+}
+
+func (s *RaftStore) DeleteResourceProposal(application string) error {
+	_, err := s.apply(opDeleteProposal, application)
+	return err
+}
+
+func (s *RaftStore) DeleteJobName(jobID string) error {
+	_, err := s.apply(opDeleteJobName, jobID)
+	return err
+}
+
+func (s *RaftStore) SaveSearchEntry(entry SearchEntry) error {
+	_, err := s.apply(opSaveSearchEntry, entry)
+	return err
+}
+
+func (s *RaftStore) DeleteSearchEntry(jobID string) error {
+	_, err := s.apply(opDeleteSearchEntry, jobID)
+	return err
+}
+
+func (s *RaftStore) SaveRevision(revision Revision) (Revision, error) {
+	revision.CreatedAt = time.Now()
+	result, err := s.apply(opSaveRevision, revision)
+	if err != nil {
+		return Revision{}, err
+	}
+	return *result.Revision, nil
+}
+
+func (s *RaftStore) SaveProject(project Project) error {
+	_, err := s.apply(opSaveProject, project)
+	return err
+}
+
+func (s *RaftStore) DeleteProject(name string) error {
+	_, err := s.apply(opDeleteProject, name)
+	return err
+}
+
+func (s *RaftStore) RecordUptime(checks []UptimeCheck) error {
+	_, err := s.apply(opRecordUptime, checks)
+	return err
+}
+
+func (s *RaftStore) DeleteUptime(application string) error {
+	_, err := s.apply(opDeleteUptime, application)
+	return err
+}
+
+func (s *RaftStore) CreateNamespace(namespace Namespace) error {
+	_, err := s.apply(opCreateNamespace, namespace)
+	return err
+}
+
+func (s *RaftStore) DeleteNamespace(name string) error {
+	_, err := s.apply(opDeleteNamespace, name)
+	return err
+}
+
+// IsLeader reports whether this replica leads the cluster, background work which must
+// only run once per cluster checks it. A standby site runs none until it is promoted.
+func (s *RaftStore) IsLeader() bool {
+	return s.raft.State() == raft.Leader && !s.Standby()
+}
+
+// apply replicates a write, followers forward it to the leader
+func (s *RaftStore) apply(op string, v any) (*raftResult, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := json.Marshal(raftCommand{Op: op, Data: data})
+	if err != nil {
+		return nil, err
+	}
+
+	var result *raftResult
+	if s.raft.State() == raft.Leader {
+		result, err = s.applyLocal(cmd)
+	} else {
+		result, err = s.forward("/raft/apply", cmd)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, result.err()
+}
+
+func (s *RaftStore) applyLocal(cmd []byte) (*raftResult, error) {
+	future := s.raft.Apply(cmd, raftApplyTimeout)
+	if err := future.Error(); err != nil {
+		return nil, fmt.Errorf("failed to replicate: %w", err)
+	}
+
+	return future.Response().(*raftResult), nil
+}
+
+// forward sends a request to the HTTP endpoint of the leader
+func (s *RaftStore) forward(path string, body []byte) (*raftResult, error) {
+	_, leaderID := s.raft.LeaderWithID()
+	if leaderID == "" {
+		return nil, fmt.Errorf("no raft leader elected")
+	}
+
+	leader, ok := s.fsm.member(string(leaderID))
+	if !ok {
+		return nil, fmt.Errorf("address of raft leader %s unknown", leaderID)
+	}
+
+	resp, err := s.client.Post("http://"+leader.HTTPAddr+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to forward to raft leader %s: %w", leaderID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("raft leader %s: %s", leaderID, bytes.TrimSpace(message))
+	}
+
+	result := &raftResult{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// registerOnLeadership records the HTTP address of every replica becoming leader,
+// followers need it to forward writes
+func (s *RaftStore) registerOnLeadership() {
+	for leader := range s.raft.LeaderCh() {
+		if !leader {
+			continue
+		}
+
+		member := raftMember{
+			ID:       s.config.NodeID,
+			RaftAddr: s.config.BindAddr,
+			HTTPAddr: s.config.HTTPAddr,
+		}
+		if _, err := s.apply(opJoin, member); err != nil {
+			log.Printf("Raft: failed to register leader address: %v", err)
+		}
+	}
+}
+
+// join asks a member of the cluster to add this replica, until it succeeds
+func (s *RaftStore) join() {
+	body, _ := json.Marshal(raftMember{
+		ID:       s.config.NodeID,
+		RaftAddr: s.config.BindAddr,
+		HTTPAddr: s.config.HTTPAddr,
+		NonVoter: s.config.NonVoter,
+	})
+
+	for {
+		resp, err := s.client.Post("http://"+s.config.Join+"/raft/join", "application/json", bytes.NewReader(body))
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				log.Printf("Raft: joined cluster through %s", s.config.Join)
+				return
+			}
+			err = fmt.Errorf("status %s", resp.Status)
+		}
+
+		log.Printf("Raft: failed to join through %s, retrying: %v", s.config.Join, err)
+		time.Sleep(raftJoinRetry)
+	}
+}
+
+// Handler serves the joins and forwarded writes of the other replicas, and what the
+// primary site replicates to a standby, it must only be reachable from the controller network
+func (s *RaftStore) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /raft/apply", s.handleApply)
+	mux.HandleFunc("POST /raft/join", s.handleJoin)
+	mux.HandleFunc("GET /dr/status", s.handleDRStatus)
+	mux.HandleFunc("POST /dr/apply", s.handleDRApply)
+	return mux
+}
+
+func (s *RaftStore) handleApply(w http.ResponseWriter, r *http.Request) {
+	cmd, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// only forward once, a stale leader view must not bounce requests around
+	if s.raft.State() != raft.Leader {
+		http.Error(w, "not the raft leader", http.StatusServiceUnavailable)
+		return
+	}
+
+	result, err := s.applyLocal(cmd)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	writeResult(w, result)
+}
+
+func (s *RaftStore) handleJoin(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var member raftMember
+	if err := json.Unmarshal(body, &member); err != nil || member.ID == "" || member.RaftAddr == "" {
+		http.Error(w, "invalid member", http.StatusBadRequest)
+		return
+	}
+
+	if s.raft.State() != raft.Leader {
+		result, err := s.forward("/raft/join", body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		writeResult(w, result)
+		return
+	}
+
+	add := s.raft.AddVoter
+	if member.NonVoter {
+		add = s.raft.AddNonvoter
+	}
+	if err := add(raft.ServerID(member.ID), raft.ServerAddress(member.RaftAddr), 0, raftApplyTimeout).Error(); err != nil {
+		http.Error(w, fmt.Sprintf("failed to add member: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+
+	result, err := s.apply(opJoin, member)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	log.Printf("Raft: %s joined from %s", member.ID, member.RaftAddr)
+	writeResult(w, result)
+}
+
+func writeResult(w http.ResponseWriter, result *raftResult) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,540 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+// Operations replicated through the Raft log
+const (
+	opSaveRollout         = "save_rollout"
+	opSaveInvocation      = "save_invocation"
+	opPublishBlueprint    = "publish_blueprint"
+	opSaveSubscription    = "save_subscription"
+	opSetDependencies     = "set_dependencies"
+	opCreateTenant        = "create_tenant"
+	opUpdateTenant        = "update_tenant"
+	opSaveServiceAccount  = "save_service_account"
+	opSaveSnapshot        = "save_snapshot"
+	opSaveDomain          = "save_domain"
+	opSaveDeployedImage   = "save_deployed_image"
+	opDeleteDeployedImage = "delete_deployed_image"
+	opSaveArtifact        = "save_artifact"
+	opSaveJobName         = "save_job_name"
+	opDeleteJobName       = "delete_job_name"
+	opCreateIdempotency   = "create_idempotency_key"
+	opUpdateIdempotency   = "update_idempotency_key"
+	opSavePlacement       = "save_placement"
+	opDeletePlacement     = "delete_placement"
+	opSaveGeoRoute        = "save_geo_route"
+	opDeleteGeoRoute      = "delete_geo_route"
+	opSaveUsage           = "save_usage"
+	opDeleteUsage         = "delete_usage"
+	opSaveProposal        = "save_resource_proposal"
+	opDeleteProposal      = "delete_resource_proposal"
+	opSaveSearchEntry     = "save_search_entry"
+	opDeleteSearchEntry   = "delete_search_entry"
+	opSaveRevision        = "save_revision"
+	opSaveProject         = "save_project"
+	opDeleteProject       = "delete_project"
+	opRecordUptime        = "record_uptime"
+	opDeleteUptime        = "delete_uptime"
+	opCreateNamespace     = "create_namespace"
+	opDeleteNamespace     = "delete_namespace"
+	opJoin                = "join"
+	opDRApply             = "dr_apply"
+	opDRRestore           = "dr_restore"
+	opDRPromote           = "dr_promote"
+)
+
+// raftCommand is a registry write as it is stored in the Raft log
+type raftCommand struct {
+	Op   string          `json:"op"`
+	Data json.RawMessage `json:"data"`
+}
+
+// raftResult is the outcome of applying a command, the sentinel errors survive
+// forwarding from a follower to the leader through Code
+type raftResult struct {
+	Blueprint *BlueprintVersion `json:"blueprint,omitempty"`
+	Revision  *Revision         `json:"revision,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	Code      string            `json:"code,omitempty"`
+	Index     uint64            `json:"index,omitempty"` // replicated index of a standby
+}
+
+func (r *raftResult) err() error {
+	switch {
+	case r.Error == "":
+		return nil
+	case r.Code == "not_found":
+		return &appliedError{message: r.Error, sentinel: ErrNotFound}
+	case r.Code == "already_exists":
+		return &appliedError{message: r.Error, sentinel: ErrAlreadyExists}
+	default:
+		return errors.New(r.Error)
+	}
+}
+
+// appliedError keeps the message of an error returned by the leader matching its sentinel
+type appliedError struct {
+	message  string
+	sentinel error
+}
+
+func (e *appliedError) Error() string { return e.message }
+
+func (e *appliedError) Unwrap() error { return e.sentinel }
+
+func resultOf(err error) *raftResult {
+	result := &raftResult{}
+	if err == nil {
+		return result
+	}
+
+	result.Error = err.Error()
+	switch {
+	case errors.Is(err, ErrNotFound):
+		result.Code = "not_found"
+	case errors.Is(err, ErrAlreadyExists):
+		result.Code = "already_exists"
+	}
+	return result
+}
+
+type dependencies struct {
+	Application string   `json:"application"`
+	DependsOn   []string `json:"depends_on"`
+}
+
+// raftMember is a controller replica, its HTTP address receives forwarded writes
+type raftMember struct {
+	ID       string `json:"id"`
+	RaftAddr string `json:"raft_addr"`
+	HTTPAddr string `json:"http_addr"`
+	NonVoter bool   `json:"non_voter,omitempty"`
+}
+
+// raftFSM applies the replicated commands to the in-memory registry of the replica
+type raftFSM struct {
+	state *MemoryStore
+
+	mu      sync.RWMutex
+	members map[string]raftMember
+	dr      drState
+}
+
+func (f *raftFSM) Apply(entry *raft.Log) any {
+	return f.applyCommand(entry.Data)
+}
+
+// applyCommand applies a command of the Raft log, or of a batch replicated from the
+// primary site when the replica is a disaster recovery standby
+func (f *raftFSM) applyCommand(data []byte) *raftResult {
+	var cmd raftCommand
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		return resultOf(fmt.Errorf("invalid command: %w", err))
+	}
+
+	decode := func(v any) error {
+		return json.Unmarshal(cmd.Data, v)
+	}
+
+	var err error
+	switch cmd.Op {
+	case opSaveRollout:
+		var rollout Rollout
+		if err = decode(&rollout); err == nil {
+			err = f.state.SaveRollout(rollout)
+		}
+	case opSaveInvocation:
+		var invocation Invocation
+		if err = decode(&invocation); err == nil {
+			err = f.state.SaveInvocation(invocation)
+		}
+	case opPublishBlueprint:
+		var version BlueprintVersion
+		if err = decode(&version); err == nil {
+			if version, err = f.state.publishBlueprint(version); err == nil {
+				return &raftResult{Blueprint: &version}
+			}
+		}
+	case opSaveSubscription:
+		var subscription Subscription
+		if err = decode(&subscription); err == nil {
+			err = f.state.SaveSubscription(subscription)
+		}
+	case opSetDependencies:
+		var deps dependencies
+		if err = decode(&deps); err == nil {
+			err = f.state.SetDependencies(deps.Application, deps.DependsOn)
+		}
+	case opCreateTenant:
+		var tenant Tenant
+		if err = decode(&tenant); err == nil {
+			err = f.state.CreateTenant(tenant)
+		}
+	case opUpdateTenant:
+		var tenant Tenant
+		if err = decode(&tenant); err == nil {
+			err = f.state.UpdateTenant(tenant)
+		}
+	case opSaveServiceAccount:
+		var account ServiceAccount
+		if err = decode(&account); err == nil {
+			err = f.state.SaveServiceAccount(account)
+		}
+	case opSaveSnapshot:
+		var snapshot Snapshot
+		if err = decode(&snapshot); err == nil {
+			err = f.state.SaveSnapshot(snapshot)
+		}
+	case opSaveDomain:
+		var domain Domain
+		if err = decode(&domain); err == nil {
+			err = f.state.SaveDomain(domain)
+		}
+	case opSaveDeployedImage:
+		var image DeployedImage
+		if err = decode(&image); err == nil {
+			err = f.state.SaveDeployedImage(image)
+		}
+	case opDeleteDeployedImage:
+		var application string
+		if err = decode(&application); err == nil {
+			err = f.state.DeleteDeployedImage(application)
+		}
+	case opSaveArtifact:
+		var artifact Artifact
+		if err = decode(&artifact); err == nil {
+			err = f.state.SaveArtifact(artifact)
+		}
+	case opSaveJobName:
+		var name JobName
+		if err = decode(&name); err == nil {
+			err = f.state.SaveJobName(name)
+		}
+	case opCreateIdempotency:
+		var key IdempotencyKey
+		if err = decode(&key); err == nil {
+			err = f.state.CreateIdempotencyKey(key)
+		}
+	case opUpdateIdempotency:
+		var key IdempotencyKey
+		if err = decode(&key); err == nil {
+			err = f.state.UpdateIdempotencyKey(key)
+		}
+	case opSavePlacement:
+		var decision PlacementDecision
+		if err = decode(&decision); err == nil {
+			err = f.state.SavePlacement(decision)
+		}
+	case opDeletePlacement:
+		var application string
+		if err = decode(&application); err == nil {
+			err = f.state.DeletePlacement(application)
+		}
+	case opSaveGeoRoute:
+		var route GeoRoute
+		if err = decode(&route); err == nil {
+			err = f.state.SaveGeoRoute(route)
+		}
+	case opDeleteGeoRoute:
+		var application string
+		if err = decode(&application); err == nil {
+			err = f.state.DeleteGeoRoute(application)
+		}
+	case opSaveUsage:
+		var samples []UsageSample
+		if err = decode(&samples); err == nil {
+			err = f.state.SaveUsage(samples)
+		}
+	case opDeleteUsage:
+		var application string
+		if err = decode(&application); err == nil {
+			err = f.state.DeleteUsage(application)
+		}
+	case opSaveProposal:
+		var proposal ResourceProposal
+		if err = decode(&proposal); err == nil {
+			err = f.state.SaveResourceProposal(proposal)
+		}
+	case opDeleteProposal:
+		var application string
+		if err = decode(&application); err == nil {
+			err = f.state.DeleteResourceProposal(application)
+		}
+	case opSaveSearchEntry:
+		var entry SearchEntry
+		if err = decode(&entry); err == nil {
+			err = f.state.SaveSearchEntry(entry)
+		}
+	case opDeleteSearchEntry:
+		var jobID string
+		if err = decode(&jobID); err == nil {
+			err = f.state.DeleteSearchEntry(jobID)
+		}
+	case opSaveRevision:
+		var revision Revision
+		if err = decode(&revision); err == nil {
+			if revision, err = f.state.saveRevision(revision); err == nil {
+				return &raftResult{Revision: &revision}
+			}
+		}
+	case opSaveProject:
+		var project Project
+		if err = decode(&project); err == nil {
+			err = f.state.SaveProject(project)
+		}
+	case opDeleteProject:
+		var name string
+		if err = decode(&name); err == nil {
+			err = f.state.DeleteProject(name)
+		}
+	case opRecordUptime:
+		var checks []UptimeCheck
+		if err = decode(&checks); err == nil {
+			err = f.state.RecordUptime(checks)
+		}
+	case opDeleteUptime:
+		var application string
+		if err = decode(&application); err == nil {
+			err = f.state.DeleteUptime(application)
+		}
+	case opCreateNamespace:
+		var namespace Namespace
+		if err = decode(&namespace); err == nil {
+			err = f.state.CreateNamespace(namespace)
+		}
+	case opDeleteNamespace:
+		var name string
+		if err = decode(&name); err == nil {
+			err = f.state.DeleteNamespace(name)
+		}
+	case opDeleteJobName:
+		var jobID string
+		if err = decode(&jobID); err == nil {
+			err = f.state.DeleteJobName(jobID)
+		}
+	case opJoin:
+		var member raftMember
+		if err = decode(&member); err == nil {
+			f.mu.Lock()
+			f.members[member.ID] = member
+			f.mu.Unlock()
+		}
+	case opDRApply:
+		var batch drBatch
+		if err = decode(&batch); err == nil {
+			return f.applyBatch(batch)
+		}
+	case opDRRestore:
+		var restore drRestore
+		if err = decode(&restore); err == nil {
+			return f.applyRestore(restore)
+		}
+	case opDRPromote:
+		var promotedAt time.Time
+		if err = decode(&promotedAt); err == nil {
+			f.mu.Lock()
+			f.dr.Promoted = true
+			f.dr.PromotedAt = promotedAt
+			f.mu.Unlock()
+		}
+	default:
+		err = fmt.Errorf("unknown operation %q", cmd.Op)
+	}
+
+	return resultOf(err)
+}
+
+func (f *raftFSM) member(id string) (raftMember, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	member, ok := f.members[id]
+	return member, ok
+}
+
+// fsmSnapshot is the whole registry of a replica, serialized when Raft compacts its log
+type fsmSnapshot struct {
+	Rollouts          map[string][]Rollout         `json:"rollouts"`
+	Invocations       map[string][]Invocation      `json:"invocations"`
+	Blueprints        map[string]blueprintSnapshot `json:"blueprints"`
+	Subscriptions     map[string]Subscription      `json:"subscriptions"`
+	Dependencies      map[string][]string          `json:"dependencies"`
+	Tenants           map[string]Tenant            `json:"tenants"`
+	ServiceAccounts   map[string]ServiceAccount    `json:"service_accounts"`
+	Snapshots         map[string][]Snapshot        `json:"snapshots"`
+	Domains           map[string]Domain            `json:"domains"`
+	DeployedImages    map[string]DeployedImage     `json:"deployed_images"`
+	Artifacts         map[string][]Artifact        `json:"artifacts"`
+	JobNames          map[string]JobName           `json:"job_names"`
+	IdempotencyKeys   map[string]IdempotencyKey    `json:"idempotency_keys"`
+	Placements        map[string]PlacementDecision `json:"placements"`
+	GeoRoutes         map[string]GeoRoute          `json:"geo_routes"`
+	Usage             map[string][]UsageSample     `json:"usage"`
+	ResourceProposals map[string]ResourceProposal  `json:"resource_proposals"`
+	SearchEntries     map[string]SearchEntry       `json:"search_entries"`
+	Revisions         map[string][]Revision        `json:"revisions"`
+	Projects          map[string]Project           `json:"projects"`
+	Uptime            map[string]Uptime            `json:"uptime"`
+	Namespaces        map[string]Namespace         `json:"namespaces"`
+	Members           map[string]raftMember        `json:"members"`
+	DR                drState                      `json:"dr"`
+	data              []byte
+}
+
+type blueprintSnapshot struct {
+	Tenant   string             `json:"tenant"`
+	Versions []BlueprintVersion `json:"versions"`
+	Heads    map[string]int     `json:"heads"`
+}
+
+func (f *raftFSM) Snapshot() (raft.FSMSnapshot, error) {
+	m := f.state
+	m.mu.RLock()
+	snapshot := &fsmSnapshot{
+		Rollouts:          m.rollouts,
+		Invocations:       m.invocations,
+		Blueprints:        make(map[string]blueprintSnapshot, len(m.blueprints)),
+		Subscriptions:     m.subscriptions,
+		Dependencies:      m.dependencies,
+		Tenants:           m.tenants,
+		ServiceAccounts:   m.serviceAccounts,
+		Snapshots:         m.snapshots,
+		Domains:           m.domains,
+		DeployedImages:    m.deployedImages,
+		Artifacts:         m.artifacts,
+		JobNames:          m.jobNames,
+		IdempotencyKeys:   m.idempotencyKeys,
+		Placements:        m.placements,
+		GeoRoutes:         m.geoRoutes,
+		Usage:             m.usage,
+		ResourceProposals: m.resourceProposals,
+		SearchEntries:     m.searchEntries,
+		Revisions:         m.revisions,
+		Projects:          m.projects,
+		Uptime:            m.uptime,
+		Namespaces:        m.namespaces,
+	}
+	for name, record := range m.blueprints {
+		snapshot.Blueprints[name] = blueprintSnapshot{
+			Tenant:   record.tenant,
+			Versions: record.versions,
+			Heads:    record.heads,
+		}
+	}
+
+	f.mu.RLock()
+	snapshot.Members = f.members
+	snapshot.DR = f.dr
+	data, err := json.Marshal(snapshot)
+	f.mu.RUnlock()
+	m.mu.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+	snapshot.data = data
+
+	return snapshot, nil
+}
+
+func (f *raftFSM) Restore(reader io.ReadCloser) error {
+	defer reader.Close()
+
+	var snapshot fsmSnapshot
+	if err := json.NewDecoder(reader).Decode(&snapshot); err != nil {
+		return err
+	}
+
+	f.state.restore(&snapshot)
+
+	f.mu.Lock()
+	f.members = make(map[string]raftMember)
+	maps.Copy(f.members, snapshot.Members)
+	f.dr = snapshot.DR
+	f.mu.Unlock()
+
+	return nil
+}
+
+// restore replaces the registry with the one of a snapshot
+func (m *MemoryStore) restore(snapshot *fsmSnapshot) {
+	state := NewMemoryStore()
+	maps.Copy(state.rollouts, snapshot.Rollouts)
+	maps.Copy(state.invocations, snapshot.Invocations)
+	maps.Copy(state.subscriptions, snapshot.Subscriptions)
+	maps.Copy(state.dependencies, snapshot.Dependencies)
+	maps.Copy(state.tenants, snapshot.Tenants)
+	maps.Copy(state.serviceAccounts, snapshot.ServiceAccounts)
+	maps.Copy(state.snapshots, snapshot.Snapshots)
+	maps.Copy(state.domains, snapshot.Domains)
+	maps.Copy(state.deployedImages, snapshot.DeployedImages)
+	maps.Copy(state.artifacts, snapshot.Artifacts)
+	maps.Copy(state.jobNames, snapshot.JobNames)
+	maps.Copy(state.idempotencyKeys, snapshot.IdempotencyKeys)
+	maps.Copy(state.placements, snapshot.Placements)
+	maps.Copy(state.geoRoutes, snapshot.GeoRoutes)
+	maps.Copy(state.usage, snapshot.Usage)
+	maps.Copy(state.resourceProposals, snapshot.ResourceProposals)
+	maps.Copy(state.revisions, snapshot.Revisions)
+	maps.Copy(state.projects, snapshot.Projects)
+	maps.Copy(state.uptime, snapshot.Uptime)
+	maps.Copy(state.namespaces, snapshot.Namespaces)
+	// the index is not part of the snapshot, it is rebuilt from the entries
+	for _, entry := range snapshot.SearchEntries {
+		_ = state.SaveSearchEntry(entry)
+	}
+	for name, record := range snapshot.Blueprints {
+		state.blueprints[name] = &blueprintRecord{
+			tenant:   record.Tenant,
+			versions: record.Versions,
+			heads:    record.Heads,
+		}
+	}
+
+	m.mu.Lock()
+	m.rollouts = state.rollouts
+	m.invocations = state.invocations
+	m.blueprints = state.blueprints
+	m.subscriptions = state.subscriptions
+	m.dependencies = state.dependencies
+	m.tenants = state.tenants
+	m.serviceAccounts = state.serviceAccounts
+	m.snapshots = state.snapshots
+	m.domains = state.domains
+	m.deployedImages = state.deployedImages
+	m.artifacts = state.artifacts
+	m.jobNames = state.jobNames
+	m.idempotencyKeys = state.idempotencyKeys
+	m.placements = state.placements
+	m.geoRoutes = state.geoRoutes
+	m.usage = state.usage
+	m.resourceProposals = state.resourceProposals
+	m.searchEntries = state.searchEntries
+	m.searchIndex = state.searchIndex
+	m.revisions = state.revisions
+	m.projects = state.projects
+	m.uptime = state.uptime
+	m.namespaces = state.namespaces
+	m.mu.Unlock()
+}
+
+func (s *fsmSnapshot) Persist(sink raft.SnapshotSink) error {
+	if _, err := sink.Write(s.data); err != nil {
+		_ = sink.Cancel()
+		return err
+	}
+	return sink.Close()
+}
+
+func (s *fsmSnapshot) Release() {}
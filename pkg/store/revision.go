@@ -0,0 +1,101 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// revisions kept per application, the oldest are dropped first
+const maxRevisions = 100
+
+// Revision is a deployment spec of an application as it was submitted, numbered from 1
+// per job
+type Revision struct {
+	JobID        string
+	Revision     int
+	Application  string
+	Tenant       string
+	Owner        string // the owner label, or else the tenant
+	Image        string
+	Spec         []byte // serialized DeployRequest, sealed with the tenant's data key
+	EnvChanges   []byte // JSON of the EnvChanges from the previous revision, sealed like the spec
+	DeploymentID string // Nomad evaluation of the deployment
+	CreatedAt    time.Time
+}
+
+// EnvChange is a change of an environment variable from one revision to the next, its
+// sensitive values are redacted before it is recorded
+type EnvChange struct {
+	Key      string `json:"key"`
+	Change   string `json:"change"` // added, changed or removed
+	Old      string `json:"old,omitempty"`
+	New      string `json:"new,omitempty"`
+	Redacted bool   `json:"redacted,omitempty"`
+}
+
+func (m *MemoryStore) SaveRevision(revision Revision) (Revision, error) {
+	revision.CreatedAt = time.Now()
+	return m.saveRevision(revision)
+}
+
+// saveRevision numbers and stores the revision, replicas replay it with the leader's timestamp
+func (m *MemoryStore) saveRevision(revision Revision) (Revision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revisions := m.revisions[revision.JobID]
+	revision.Revision = 1
+	if len(revisions) > 0 {
+		revision.Revision = revisions[0].Revision + 1
+	}
+
+	revisions = append([]Revision{revision}, revisions...)
+	if len(revisions) > maxRevisions {
+		revisions = revisions[:maxRevisions]
+	}
+	m.revisions[revision.JobID] = revisions
+
+	return revision, nil
+}
+
+// jobRevisions copies the revisions of a job for restoreRevisions
+func (m *MemoryStore) jobRevisions(jobID string) []Revision {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]Revision(nil), m.revisions[jobID]...)
+}
+
+// restoreRevisions puts back the revisions of a job jobRevisions copied
+func (m *MemoryStore) restoreRevisions(jobID string, revisions []Revision) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(revisions) == 0 {
+		delete(m.revisions, jobID)
+		return
+	}
+	m.revisions[jobID] = revisions
+}
+
+func (m *MemoryStore) Revisions(jobID string) ([]Revision, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]Revision(nil), m.revisions[jobID]...), nil
+}
+
+func (m *MemoryStore) Revision(jobID string, revision int) (Revision, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, existing := range m.revisions[jobID] {
+		if revision == 0 || existing.Revision == revision {
+			return existing, nil
+		}
+	}
+	if revision == 0 {
+		return Revision{}, fmt.Errorf("revisions of %s: %w", jobID, ErrNotFound)
+	}
+	return Revision{}, fmt.Errorf("revision %d of %s: %w", revision, jobID, ErrNotFound)
+}
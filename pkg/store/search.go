@@ -0,0 +1,169 @@
+package store
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Fields of the search index, labels are indexed as label.<key>
+const (
+	SearchName        = "name"
+	SearchImage       = "image"
+	SearchOwner       = "owner"
+	SearchTenant      = "tenant"
+	SearchHost        = "host"
+	SearchStatus      = "status"
+	SearchLabelPrefix = "label."
+)
+
+// SearchEntry is what the search index keeps of an application, refreshed when it is
+// deployed and by the search indexing loop
+type SearchEntry struct {
+	JobID       string
+	Application string
+	Tenant      string
+	Owner       string // the owner label, or else the tenant
+	Image       string
+	Labels      map[string]string
+	Hosts       []string
+	Status      string // health: healthy, progressing, degraded, suspended or unknown
+	UpdatedAt   time.Time
+}
+
+// Values returns the values of a field of the index, none for a label the entry lacks
+func (e SearchEntry) Values(field string) []string {
+	switch field {
+	case SearchName:
+		return []string{e.Application, e.JobID}
+	case SearchImage:
+		return []string{e.Image}
+	case SearchOwner:
+		return []string{e.Owner}
+	case SearchTenant:
+		return []string{e.Tenant}
+	case SearchHost:
+		return e.Hosts
+	case SearchStatus:
+		return []string{e.Status}
+	}
+	if key, ok := strings.CutPrefix(field, SearchLabelPrefix); ok {
+		if value, ok := e.Labels[key]; ok {
+			return []string{value}
+		}
+	}
+	return nil
+}
+
+// fields lists the fields the entry is indexed under
+func (e SearchEntry) fields() []string {
+	fields := []string{SearchName, SearchImage, SearchOwner, SearchTenant, SearchHost, SearchStatus}
+	for key := range e.Labels {
+		fields = append(fields, SearchLabelPrefix+key)
+	}
+	return fields
+}
+
+func (m *MemoryStore) SaveSearchEntry(entry SearchEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.unindex(entry.JobID)
+	m.searchEntries[entry.JobID] = entry
+	for _, field := range entry.fields() {
+		for _, value := range entry.Values(field) {
+			if value == "" {
+				continue
+			}
+			values := m.searchIndex[field]
+			if values == nil {
+				values = make(map[string]map[string]bool)
+				m.searchIndex[field] = values
+			}
+			value = strings.ToLower(value)
+			if values[value] == nil {
+				values[value] = make(map[string]bool)
+			}
+			values[value][entry.JobID] = true
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteSearchEntry(jobID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.unindex(jobID)
+	delete(m.searchEntries, jobID)
+
+	return nil
+}
+
+// unindex removes the entry of a job from the index, the caller holds the lock
+func (m *MemoryStore) unindex(jobID string) {
+	entry, ok := m.searchEntries[jobID]
+	if !ok {
+		return
+	}
+	for _, field := range entry.fields() {
+		for _, value := range entry.Values(field) {
+			value = strings.ToLower(value)
+			delete(m.searchIndex[field][value], jobID)
+			if len(m.searchIndex[field][value]) == 0 {
+				delete(m.searchIndex[field], value)
+			}
+		}
+		if len(m.searchIndex[field]) == 0 {
+			delete(m.searchIndex, field)
+		}
+	}
+}
+
+func (m *MemoryStore) SearchEntry(jobID string) (SearchEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entry, ok := m.searchEntries[jobID]
+	if !ok {
+		return SearchEntry{}, ErrNotFound
+	}
+	return entry, nil
+}
+
+func (m *MemoryStore) SearchEntries() ([]SearchEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := make([]SearchEntry, 0, len(m.searchEntries))
+	for _, entry := range m.searchEntries {
+		entries = append(entries, entry)
+	}
+	sortSearchEntries(entries)
+
+	return entries, nil
+}
+
+func (m *MemoryStore) LookupSearchEntries(field, value string) ([]SearchEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jobIDs := m.searchIndex[field][strings.ToLower(value)]
+	entries := make([]SearchEntry, 0, len(jobIDs))
+	for jobID := range jobIDs {
+		entries = append(entries, m.searchEntries[jobID])
+	}
+	sortSearchEntries(entries)
+
+	return entries, nil
+}
+
+func sortSearchEntries(entries []SearchEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Application != entries[j].Application {
+			return entries[i].Application < entries[j].Application
+		}
+		return entries[i].JobID < entries[j].JobID
+	})
+}
@@ -0,0 +1,777 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Kinds of the records table, named like the fields of a Raft snapshot so the rows load
+// into one
+const (
+	kindRollouts          = "rollouts"
+	kindInvocations       = "invocations"
+	kindBlueprints        = "blueprints"
+	kindSubscriptions     = "subscriptions"
+	kindDependencies      = "dependencies"
+	kindTenants           = "tenants"
+	kindServiceAccounts   = "service_accounts"
+	kindSnapshots         = "snapshots"
+	kindDomains           = "domains"
+	kindDeployedImages    = "deployed_images"
+	kindArtifacts         = "artifacts"
+	kindJobNames          = "job_names"
+	kindIdempotencyKeys   = "idempotency_keys"
+	kindPlacements        = "placements"
+	kindGeoRoutes         = "geo_routes"
+	kindUsage             = "usage"
+	kindResourceProposals = "resource_proposals"
+	kindSearchEntries     = "search_entries"
+	kindProjects          = "projects"
+	kindUptime            = "uptime"
+	kindNamespaces        = "namespaces"
+)
+
+// sqlDialect is what differs between the databases of the store
+type sqlDialect struct {
+	driver    string
+	blob      string
+	timestamp string
+	numbered  bool // $1 placeholders instead of ?
+}
+
+var (
+	sqliteDialect   = sqlDialect{driver: "sqlite3", blob: "BLOB", timestamp: "TIMESTAMP"}
+	postgresDialect = sqlDialect{driver: "postgres", blob: "BYTEA", timestamp: "TIMESTAMPTZ", numbered: true}
+)
+
+// sqlRecord names a record of the registry, a row of the records table
+type sqlRecord struct {
+	kind string
+	name string
+}
+
+// registryLockKey is the Postgres advisory lock held by the controller owning a database
+const registryLockKey int64 = 0x636f6e74726f6c // "control"
+
+// SQLStore keeps the registry in a SQLite or Postgres database, a controller which
+// restarts still knows what it deployed without a Raft cluster. The registry is loaded
+// into memory when the store opens, reads are served from memory and writes go through
+// to the database, so a database has a single controller: the store holds a lock on it
+// while open, replicas share a registry with Raft instead.
+type SQLStore struct {
+	*MemoryStore
+
+	db      *sql.DB
+	dialect sqlDialect
+	owner   *sql.Conn  // holds the advisory lock of a Postgres database
+	writeMu sync.Mutex // the database sees the writes in the order of the memory
+}
+
+// OpenSQLStore opens the database of an address, sqlite://<path> or postgres://<url>,
+// creating its tables on first use
+func OpenSQLStore(address string) (*SQLStore, error) {
+	scheme, path, ok := strings.Cut(address, "://")
+	if !ok || path == "" {
+		return nil, fmt.Errorf("invalid registry address %q, expected sqlite://<path> or postgres://<url>", address)
+	}
+
+	var dialect sqlDialect
+	var dsn string
+	switch scheme {
+	case "sqlite":
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return nil, err
+		}
+		dialect, dsn = sqliteDialect, "file:"+path+"?_journal_mode=WAL&_busy_timeout=5000"
+	case "postgres", "postgresql":
+		dialect, dsn = postgresDialect, address
+	default:
+		return nil, fmt.Errorf("unsupported registry database %q, expected sqlite or postgres", scheme)
+	}
+
+	db, err := sql.Open(dialect.driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if dialect == sqliteDialect {
+		// SQLite has a single writer, more connections only wait on its lock
+		db.SetMaxOpenConns(1)
+	}
+
+	s := &SQLStore{
+		MemoryStore: NewMemoryStore(),
+		db:          db,
+		dialect:     dialect,
+	}
+	if err := s.lock(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create the registry tables: %w", err)
+	}
+	if err := s.load(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to load the registry: %w", err)
+	}
+
+	return s, nil
+}
+
+// lock takes the advisory lock of a Postgres database for the lifetime of the store, a
+// second controller would neither see the writes of the first nor keep its own from being
+// overwritten. SQLite databases are local files of a single controller.
+func (s *SQLStore) lock() error {
+	if s.dialect != postgresDialect {
+		return nil
+	}
+	conn, err := s.db.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	var locked bool
+	if err := conn.QueryRowContext(context.Background(), `SELECT pg_try_advisory_lock($1)`, registryLockKey).Scan(&locked); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to lock the registry database: %w", err)
+	}
+	if !locked {
+		conn.Close()
+		return errors.New("the registry database is used by another controller, share a registry between controllers with -raft-addr")
+	}
+	s.owner = conn
+	return nil
+}
+
+// Close closes the database, the registry stays in it for the next start
+func (s *SQLStore) Close() error {
+	if s.owner != nil {
+		// closing the connection releases the lock
+		s.owner.Close()
+	}
+	return s.db.Close()
+}
+
+func (s *SQLStore) migrate() error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS records (
+			kind TEXT NOT NULL,
+			name TEXT NOT NULL,
+			data TEXT NOT NULL,
+			PRIMARY KEY (kind, name)
+		)`,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS revisions (
+			job_id TEXT NOT NULL,
+			revision INTEGER NOT NULL,
+			application TEXT NOT NULL,
+			tenant TEXT NOT NULL,
+			owner TEXT NOT NULL,
+			image TEXT NOT NULL,
+			deployment_id TEXT NOT NULL,
+			spec %s,
+			env_changes %s,
+			created_at %s NOT NULL,
+			PRIMARY KEY (job_id, revision)
+		)`, s.dialect.blob, s.dialect.blob, s.dialect.timestamp),
+	}
+	for _, statement := range statements {
+		if _, err := s.db.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	// the revisions table of older registries lacks the environment changes
+	if _, err := s.db.Exec(`SELECT env_changes FROM revisions LIMIT 0`); err != nil {
+		if _, err := s.db.Exec(fmt.Sprintf(`ALTER TABLE revisions ADD COLUMN env_changes %s`, s.dialect.blob)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load reads the records into the memory like a Raft replica restores a snapshot
+func (s *SQLStore) load() error {
+	rows, err := s.db.Query(`SELECT kind, name, data FROM records`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	records := make(map[string]map[string]json.RawMessage)
+	for rows.Next() {
+		var kind, name, data string
+		if err := rows.Scan(&kind, &name, &data); err != nil {
+			return err
+		}
+		if records[kind] == nil {
+			records[kind] = make(map[string]json.RawMessage)
+		}
+		records[kind][name] = json.RawMessage(data)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	var snapshot fsmSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+
+	snapshot.Revisions, err = s.loadRevisions()
+	if err != nil {
+		return err
+	}
+
+	s.MemoryStore.restore(&snapshot)
+	return nil
+}
+
+func (s *SQLStore) loadRevisions() (map[string][]Revision, error) {
+	rows, err := s.db.Query(`SELECT job_id, revision, application, tenant, owner, image, deployment_id, spec, env_changes, created_at
+		FROM revisions ORDER BY job_id, revision DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := make(map[string][]Revision)
+	for rows.Next() {
+		var revision Revision
+		err := rows.Scan(&revision.JobID, &revision.Revision, &revision.Application, &revision.Tenant, &revision.Owner,
+			&revision.Image, &revision.DeploymentID, &revision.Spec, &revision.EnvChanges, &revision.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if len(revisions[revision.JobID]) < maxRevisions {
+			revisions[revision.JobID] = append(revisions[revision.JobID], revision)
+		}
+	}
+	return revisions, rows.Err()
+}
+
+// rebind rewrites the ? placeholders of a statement for the dialect
+func (s *SQLStore) rebind(query string) string {
+	if !s.dialect.numbered {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// write applies a write to the memory and then saves the records it changed in one
+// transaction, also when it failed: creating an idempotency key drops the expired ones
+// before it finds a taken key. When the database refuses them the records are restored in
+// memory, the registry does not report what it did not save.
+func (s *SQLStore) write(apply func() error, records ...sqlRecord) error {
+	return s.writeRecords(apply, false, records)
+}
+
+// create is write for a record the database must not have yet, the first one
+func (s *SQLStore) create(apply func() error, records ...sqlRecord) error {
+	return s.writeRecords(apply, true, records)
+}
+
+func (s *SQLStore) writeRecords(apply func() error, created bool, records []sqlRecord) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	previous := make([]recordData, len(records))
+	for i, record := range records {
+		data, found, err := s.MemoryStore.marshalRecord(record.kind, record.name)
+		if err != nil {
+			return err
+		}
+		previous[i] = recordData{data: data, found: found}
+	}
+
+	err := apply()
+	if err != nil && created {
+		// the created record was not applied, the others are saved as they are
+		records = records[1:]
+		created = false
+	}
+	if perr := s.persist(records, created); perr != nil {
+		for i, record := range records {
+			if rerr := s.MemoryStore.restoreRecord(record.kind, record.name, previous[i].data, previous[i].found); rerr != nil {
+				log.Printf("Failed to restore %s %s of the registry: %v", record.kind, record.name, rerr)
+			}
+		}
+		return perr
+	}
+	return err
+}
+
+// recordData is a record as marshalRecord serializes it
+type recordData struct {
+	data  []byte
+	found bool
+}
+
+// persist writes the records as the memory holds them in one transaction, deleting the
+// rows of those gone. When created is set the first record is refused if the database has
+// it already.
+func (s *SQLStore) persist(records []sqlRecord, created bool) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to write the registry database: %w", err)
+	}
+	defer tx.Rollback()
+
+	if created && len(records) > 0 {
+		var exists int
+		err := tx.QueryRow(s.rebind(`SELECT COUNT(*) FROM records WHERE kind = ? AND name = ?`), records[0].kind, records[0].name).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to read the registry database: %w", err)
+		}
+		if exists > 0 {
+			return fmt.Errorf("%s %s: %w", records[0].kind, records[0].name, ErrAlreadyExists)
+		}
+	}
+	for _, record := range records {
+		data, found, err := s.MemoryStore.marshalRecord(record.kind, record.name)
+		if err == nil && !found {
+			_, err = tx.Exec(s.rebind(`DELETE FROM records WHERE kind = ? AND name = ?`), record.kind, record.name)
+		} else if err == nil {
+			_, err = tx.Exec(s.rebind(`INSERT INTO records (kind, name, data) VALUES (?, ?, ?)
+				ON CONFLICT (kind, name) DO UPDATE SET data = excluded.data`), record.kind, record.name, string(data))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save %s %s to the registry database: %w", record.kind, record.name, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save to the registry database: %w", err)
+	}
+	return nil
+}
+
+// marshalRecord serializes a record like a Raft snapshot holds it, found is false when
+// the registry no longer has it
+func (m *MemoryStore) marshalRecord(kind, name string) ([]byte, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var record any
+	var found bool
+	switch kind {
+	case kindRollouts:
+		record, found = m.rollouts[name]
+	case kindInvocations:
+		record, found = m.invocations[name]
+	case kindBlueprints:
+		if blueprint, ok := m.blueprints[name]; ok {
+			record, found = blueprintSnapshot{Tenant: blueprint.tenant, Versions: blueprint.versions, Heads: blueprint.heads}, true
+		}
+	case kindSubscriptions:
+		record, found = m.subscriptions[name]
+	case kindDependencies:
+		record, found = m.dependencies[name]
+	case kindTenants:
+		record, found = m.tenants[name]
+	case kindServiceAccounts:
+		record, found = m.serviceAccounts[name]
+	case kindSnapshots:
+		record, found = m.snapshots[name]
+	case kindDomains:
+		record, found = m.domains[name]
+	case kindDeployedImages:
+		record, found = m.deployedImages[name]
+	case kindArtifacts:
+		record, found = m.artifacts[name]
+	case kindJobNames:
+		record, found = m.jobNames[name]
+	case kindIdempotencyKeys:
+		record, found = m.idempotencyKeys[name]
+	case kindPlacements:
+		record, found = m.placements[name]
+	case kindGeoRoutes:
+		record, found = m.geoRoutes[name]
+	case kindUsage:
+		record, found = m.usage[name]
+	case kindResourceProposals:
+		record, found = m.resourceProposals[name]
+	case kindSearchEntries:
+		record, found = m.searchEntries[name]
+	case kindProjects:
+		record, found = m.projects[name]
+	case kindUptime:
+		record, found = m.uptime[name]
+	case kindNamespaces:
+		record, found = m.namespaces[name]
+	default:
+		return nil, false, fmt.Errorf("unknown record kind %q", kind)
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	data, err := json.Marshal(record)
+	return data, true, err
+}
+
+// restoreRecord puts back a record marshalRecord serialized, found is false when the
+// registry did not have it
+func (m *MemoryStore) restoreRecord(kind, name string, data []byte, found bool) error {
+	// the search index is kept along with the entries
+	if kind == kindSearchEntries {
+		if !found {
+			return m.DeleteSearchEntry(name)
+		}
+		var entry SearchEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return err
+		}
+		return m.SaveSearchEntry(entry)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch kind {
+	case kindRollouts:
+		return restoreEntry(m.rollouts, name, data, found)
+	case kindInvocations:
+		return restoreEntry(m.invocations, name, data, found)
+	case kindBlueprints:
+		if !found {
+			delete(m.blueprints, name)
+			return nil
+		}
+		var blueprint blueprintSnapshot
+		if err := json.Unmarshal(data, &blueprint); err != nil {
+			return err
+		}
+		m.blueprints[name] = &blueprintRecord{tenant: blueprint.Tenant, versions: blueprint.Versions, heads: blueprint.Heads}
+		return nil
+	case kindSubscriptions:
+		return restoreEntry(m.subscriptions, name, data, found)
+	case kindDependencies:
+		return restoreEntry(m.dependencies, name, data, found)
+	case kindTenants:
+		return restoreEntry(m.tenants, name, data, found)
+	case kindServiceAccounts:
+		return restoreEntry(m.serviceAccounts, name, data, found)
+	case kindSnapshots:
+		return restoreEntry(m.snapshots, name, data, found)
+	case kindDomains:
+		return restoreEntry(m.domains, name, data, found)
+	case kindDeployedImages:
+		return restoreEntry(m.deployedImages, name, data, found)
+	case kindArtifacts:
+		return restoreEntry(m.artifacts, name, data, found)
+	case kindJobNames:
+		return restoreEntry(m.jobNames, name, data, found)
+	case kindIdempotencyKeys:
+		return restoreEntry(m.idempotencyKeys, name, data, found)
+	case kindPlacements:
+		return restoreEntry(m.placements, name, data, found)
+	case kindGeoRoutes:
+		return restoreEntry(m.geoRoutes, name, data, found)
+	case kindUsage:
+		return restoreEntry(m.usage, name, data, found)
+	case kindResourceProposals:
+		return restoreEntry(m.resourceProposals, name, data, found)
+	case kindProjects:
+		return restoreEntry(m.projects, name, data, found)
+	case kindUptime:
+		return restoreEntry(m.uptime, name, data, found)
+	case kindNamespaces:
+		return restoreEntry(m.namespaces, name, data, found)
+	}
+	return fmt.Errorf("unknown record kind %q", kind)
+}
+
+func restoreEntry[T any](records map[string]T, name string, data []byte, found bool) error {
+	if !found {
+		delete(records, name)
+		return nil
+	}
+	var record T
+	if err := json.Unmarshal(data, &record); err != nil {
+		return err
+	}
+	records[name] = record
+	return nil
+}
+
+func (s *SQLStore) SaveRollout(rollout Rollout) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveRollout(rollout)
+	}, sqlRecord{kindRollouts, rollout.Application})
+}
+
+func (s *SQLStore) SaveInvocation(invocation Invocation) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveInvocation(invocation)
+	}, sqlRecord{kindInvocations, invocation.Function})
+}
+
+func (s *SQLStore) PublishBlueprint(tenant, blueprint, channel string, spec []byte) (BlueprintVersion, error) {
+	var version BlueprintVersion
+	err := s.write(func() (err error) {
+		version, err = s.MemoryStore.PublishBlueprint(tenant, blueprint, channel, spec)
+		return err
+	}, sqlRecord{kindBlueprints, blueprint})
+	return version, err
+}
+
+func (s *SQLStore) SaveSubscription(subscription Subscription) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveSubscription(subscription)
+	}, sqlRecord{kindSubscriptions, subscription.Application})
+}
+
+func (s *SQLStore) SetDependencies(application string, dependsOn []string) error {
+	return s.write(func() error {
+		return s.MemoryStore.SetDependencies(application, dependsOn)
+	}, sqlRecord{kindDependencies, application})
+}
+
+func (s *SQLStore) CreateTenant(tenant Tenant) error {
+	return s.write(func() error {
+		return s.MemoryStore.CreateTenant(tenant)
+	}, sqlRecord{kindTenants, tenant.Name})
+}
+
+func (s *SQLStore) UpdateTenant(tenant Tenant) error {
+	return s.write(func() error {
+		return s.MemoryStore.UpdateTenant(tenant)
+	}, sqlRecord{kindTenants, tenant.Name})
+}
+
+func (s *SQLStore) SaveServiceAccount(account ServiceAccount) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveServiceAccount(account)
+	}, sqlRecord{kindServiceAccounts, account.Tenant + "/" + account.Name})
+}
+
+func (s *SQLStore) SaveSnapshot(snapshot Snapshot) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveSnapshot(snapshot)
+	}, sqlRecord{kindSnapshots, snapshot.Application})
+}
+
+func (s *SQLStore) SaveDomain(domain Domain) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveDomain(domain)
+	}, sqlRecord{kindDomains, domain.Tenant + "/" + domain.Name})
+}
+
+func (s *SQLStore) SaveDeployedImage(image DeployedImage) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveDeployedImage(image)
+	}, sqlRecord{kindDeployedImages, image.Application})
+}
+
+func (s *SQLStore) DeleteDeployedImage(application string) error {
+	return s.write(func() error {
+		return s.MemoryStore.DeleteDeployedImage(application)
+	}, sqlRecord{kindDeployedImages, application})
+}
+
+func (s *SQLStore) SaveArtifact(artifact Artifact) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveArtifact(artifact)
+	}, sqlRecord{kindArtifacts, artifact.Application})
+}
+
+func (s *SQLStore) SaveJobName(name JobName) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveJobName(name)
+	}, sqlRecord{kindJobNames, name.JobID})
+}
+
+func (s *SQLStore) DeleteJobName(jobID string) error {
+	return s.write(func() error {
+		return s.MemoryStore.DeleteJobName(jobID)
+	}, sqlRecord{kindJobNames, jobID})
+}
+
+func (s *SQLStore) CreateIdempotencyKey(key IdempotencyKey) error {
+	// the keys expired by then are dropped along with the creation
+	records := []sqlRecord{{kindIdempotencyKeys, key.Key}}
+	for _, expired := range s.MemoryStore.expiredIdempotencyKeys(key.CreatedAt) {
+		records = append(records, sqlRecord{kindIdempotencyKeys, expired})
+	}
+	return s.create(func() error {
+		return s.MemoryStore.CreateIdempotencyKey(key)
+	}, records...)
+}
+
+func (s *SQLStore) UpdateIdempotencyKey(key IdempotencyKey) error {
+	return s.write(func() error {
+		return s.MemoryStore.UpdateIdempotencyKey(key)
+	}, sqlRecord{kindIdempotencyKeys, key.Key})
+}
+
+func (s *SQLStore) SavePlacement(decision PlacementDecision) error {
+	return s.write(func() error {
+		return s.MemoryStore.SavePlacement(decision)
+	}, sqlRecord{kindPlacements, decision.Application})
+}
+
+func (s *SQLStore) DeletePlacement(application string) error {
+	return s.write(func() error {
+		return s.MemoryStore.DeletePlacement(application)
+	}, sqlRecord{kindPlacements, application})
+}
+
+func (s *SQLStore) SaveGeoRoute(route GeoRoute) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveGeoRoute(route)
+	}, sqlRecord{kindGeoRoutes, route.Application})
+}
+
+func (s *SQLStore) DeleteGeoRoute(application string) error {
+	return s.write(func() error {
+		return s.MemoryStore.DeleteGeoRoute(application)
+	}, sqlRecord{kindGeoRoutes, application})
+}
+
+func (s *SQLStore) SaveUsage(samples []UsageSample) error {
+	var records []sqlRecord
+	for _, sample := range samples {
+		record := sqlRecord{kindUsage, sample.Application}
+		if len(records) == 0 || records[len(records)-1] != record {
+			records = append(records, record)
+		}
+	}
+	return s.write(func() error {
+		return s.MemoryStore.SaveUsage(samples)
+	}, records...)
+}
+
+func (s *SQLStore) DeleteUsage(application string) error {
+	return s.write(func() error {
+		return s.MemoryStore.DeleteUsage(application)
+	}, sqlRecord{kindUsage, application}, sqlRecord{kindResourceProposals, application})
+}
+
+func (s *SQLStore) SaveResourceProposal(proposal ResourceProposal) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveResourceProposal(proposal)
+	}, sqlRecord{kindResourceProposals, proposal.Application})
+}
+
+func (s *SQLStore) DeleteResourceProposal(application string) error {
+	return s.write(func() error {
+		return s.MemoryStore.DeleteResourceProposal(application)
+	}, sqlRecord{kindResourceProposals, application})
+}
+
+func (s *SQLStore) SaveSearchEntry(entry SearchEntry) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveSearchEntry(entry)
+	}, sqlRecord{kindSearchEntries, entry.JobID})
+}
+
+func (s *SQLStore) DeleteSearchEntry(jobID string) error {
+	return s.write(func() error {
+		return s.MemoryStore.DeleteSearchEntry(jobID)
+	}, sqlRecord{kindSearchEntries, jobID})
+}
+
+func (s *SQLStore) SaveProject(project Project) error {
+	return s.write(func() error {
+		return s.MemoryStore.SaveProject(project)
+	}, sqlRecord{kindProjects, project.Name})
+}
+
+func (s *SQLStore) DeleteProject(name string) error {
+	return s.write(func() error {
+		return s.MemoryStore.DeleteProject(name)
+	}, sqlRecord{kindProjects, name})
+}
+
+func (s *SQLStore) RecordUptime(checks []UptimeCheck) error {
+	var records []sqlRecord
+	for _, check := range checks {
+		records = append(records, sqlRecord{kindUptime, check.Application})
+	}
+	return s.write(func() error {
+		return s.MemoryStore.RecordUptime(checks)
+	}, records...)
+}
+
+func (s *SQLStore) DeleteUptime(application string) error {
+	return s.write(func() error {
+		return s.MemoryStore.DeleteUptime(application)
+	}, sqlRecord{kindUptime, application})
+}
+
+func (s *SQLStore) CreateNamespace(namespace Namespace) error {
+	return s.write(func() error {
+		return s.MemoryStore.CreateNamespace(namespace)
+	}, sqlRecord{kindNamespaces, namespace.Name})
+}
+
+func (s *SQLStore) DeleteNamespace(name string) error {
+	return s.write(func() error {
+		return s.MemoryStore.DeleteNamespace(name)
+	}, sqlRecord{kindNamespaces, name})
+}
+
+// SaveRevision inserts the revision into its own table, the history is only appended to
+func (s *SQLStore) SaveRevision(revision Revision) (Revision, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	previous := s.MemoryStore.jobRevisions(revision.JobID)
+	revision, err := s.MemoryStore.SaveRevision(revision)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	if err := s.insertRevision(revision); err != nil {
+		s.MemoryStore.restoreRevisions(revision.JobID, previous)
+		return Revision{}, fmt.Errorf("failed to save revision %d of %s to the registry database: %w", revision.Revision, revision.JobID, err)
+	}
+	return revision, nil
+}
+
+func (s *SQLStore) insertRevision(revision Revision) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(s.rebind(`INSERT INTO revisions (job_id, revision, application, tenant, owner, image, deployment_id, spec, env_changes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		revision.JobID, revision.Revision, revision.Application, revision.Tenant, revision.Owner,
+		revision.Image, revision.DeploymentID, revision.Spec, revision.EnvChanges, revision.CreatedAt.UTC())
+	if err != nil {
+		return err
+	}
+	// like the memory, the database keeps the last revisions
+	_, err = tx.Exec(s.rebind(`DELETE FROM revisions WHERE job_id = ? AND revision <= ?`), revision.JobID, revision.Revision-maxRevisions)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,278 @@
+package store
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when a record does not exist
+var ErrNotFound = errors.New("not found")
+
+// ErrAlreadyExists is returned when creating a record which already exists
+var ErrAlreadyExists = errors.New("already exists")
+
+// Rollout is a finished Nomad deployment of an application
+type Rollout struct {
+	ID          string // Nomad deployment ID
+	Application string
+	JobVersion  uint64
+	Status      string
+	Reason      string // why the rollout failed, e.g. its deadline passed
+	Reverted    bool   // the version never took, the job was reverted to RevertedTo
+	RevertedTo  uint64
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	CommittedAt time.Time // commit the version was built from, when the deployment passed it
+}
+
+func (r Rollout) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// Invocation is a single run of a function deployment
+type Invocation struct {
+	ID        string // Dispatched Nomad job ID
+	Function  string
+	Status    string
+	ExitCode  int
+	StartedAt time.Time
+	Duration  time.Duration
+	Queued    time.Duration
+}
+
+// invocations kept per function by the memory store
+const maxInvocations = 1000
+
+// Store is the controller's registry of what it deployed
+type Store interface {
+	// SaveRollout records a finished rollout, saving the same rollout twice is a no-op
+	SaveRollout(rollout Rollout) error
+	// Rollouts returns the recorded rollouts of an application, most recent first
+	Rollouts(application string) ([]Rollout, error)
+	// SaveInvocation records a finished function invocation
+	SaveInvocation(invocation Invocation) error
+	// Invocations returns the recorded invocations of a function, most recent first
+	Invocations(function string) ([]Invocation, error)
+
+	// PublishBlueprint stores a new version of a blueprint and makes it the head of the channel,
+	// a blueprint belongs to the tenant which published it first
+	PublishBlueprint(tenant, blueprint, channel string, spec []byte) (BlueprintVersion, error)
+	// BlueprintVersion returns a version of a blueprint, version 0 returns the head of the channel
+	BlueprintVersion(blueprint, channel string, version int) (BlueprintVersion, error)
+	// SaveSubscription creates or replaces the subscription of an application
+	SaveSubscription(subscription Subscription) error
+	Subscription(application string) (Subscription, error)
+	// Subscriptions lists the subscriptions to a blueprint, all of them for an empty blueprint
+	Subscriptions(blueprint string) ([]Subscription, error)
+
+	// SetDependencies replaces the applications an application depends on, none removes it from the graph
+	SetDependencies(application string, dependsOn []string) error
+	// Dependencies returns the whole dependency graph keyed by the depending application
+	Dependencies() (map[string][]string, error)
+
+	// CreateTenant records a new tenant, failing with ErrAlreadyExists for a taken name
+	CreateTenant(tenant Tenant) error
+	UpdateTenant(tenant Tenant) error
+	Tenant(name string) (Tenant, error)
+	Tenants() ([]Tenant, error)
+	// SaveServiceAccount creates or replaces a service account of a tenant
+	SaveServiceAccount(account ServiceAccount) error
+	ServiceAccounts(tenant string) ([]ServiceAccount, error)
+
+	// SaveSnapshot creates or replaces a backup snapshot of an application
+	SaveSnapshot(snapshot Snapshot) error
+	// Snapshots returns the recorded snapshots of an application, most recent first
+	Snapshots(application string) ([]Snapshot, error)
+
+	// SaveDomain creates or replaces the claim of a tenant on a custom domain
+	SaveDomain(domain Domain) error
+	// Domains lists the domains claimed by a tenant, of every tenant when empty
+	Domains(tenant string) ([]Domain, error)
+
+	// SaveDeployedImage creates or replaces the deployed image of an application
+	SaveDeployedImage(image DeployedImage) error
+	DeleteDeployedImage(application string) error
+	DeployedImages() ([]DeployedImage, error)
+
+	// SaveArtifact records an artifact of an application, replacing one with the same ID
+	SaveArtifact(artifact Artifact) error
+	// Artifacts returns the artifacts of an application, most recent first
+	Artifacts(application string) ([]Artifact, error)
+
+	// SaveJobName records the Nomad job of an application, replacing one with the same job ID
+	SaveJobName(name JobName) error
+	DeleteJobName(jobID string) error
+	JobNames() ([]JobName, error)
+
+	// CreateIdempotencyKey claims a key, failing with ErrAlreadyExists while it has not expired
+	CreateIdempotencyKey(key IdempotencyKey) error
+	// UpdateIdempotencyKey records the result of the request of a key
+	UpdateIdempotencyKey(key IdempotencyKey) error
+	// IdempotencyKey returns a key, an expired one is not found
+	IdempotencyKey(key string) (IdempotencyKey, error)
+
+	// SavePlacement records the region chosen for an application, replacing the previous decision
+	SavePlacement(decision PlacementDecision) error
+	DeletePlacement(application string) error
+	Placement(application string) (PlacementDecision, error)
+
+	// SaveGeoRoute replaces the geo route of an application
+	SaveGeoRoute(route GeoRoute) error
+	DeleteGeoRoute(application string) error
+	GeoRoute(application string) (GeoRoute, error)
+	GeoRoutes() ([]GeoRoute, error)
+
+	// SaveUsage records usage samples, the oldest samples of an application are dropped
+	// beyond a limit
+	SaveUsage(samples []UsageSample) error
+	// Usage returns the samples of an application taken since a time, oldest first
+	Usage(application string, since time.Time) ([]UsageSample, error)
+	// DeleteUsage forgets the samples and the resource proposal of an application
+	DeleteUsage(application string) error
+	// SaveResourceProposal replaces the resource update proposed for an application
+	SaveResourceProposal(proposal ResourceProposal) error
+	DeleteResourceProposal(application string) error
+	ResourceProposal(application string) (ResourceProposal, error)
+
+	// SaveSearchEntry replaces the search entry of an application and its index
+	SaveSearchEntry(entry SearchEntry) error
+	DeleteSearchEntry(jobID string) error
+	SearchEntry(jobID string) (SearchEntry, error)
+	// SearchEntries returns every search entry, ordered by application
+	SearchEntries() ([]SearchEntry, error)
+	// LookupSearchEntries returns the entries with a value of the field, from the index of
+	// the field. Values are compared case-insensitively.
+	LookupSearchEntries(field, value string) ([]SearchEntry, error)
+
+	// SaveRevision records a deployment spec of an application as its next revision,
+	// revisions are kept after the application is deleted
+	SaveRevision(revision Revision) (Revision, error)
+	// Revisions returns the recorded revisions of a job, most recent first
+	Revisions(jobID string) ([]Revision, error)
+	// Revision returns a revision of a job, revision 0 returns the latest one
+	Revision(jobID string, revision int) (Revision, error)
+
+	// SaveProject creates or replaces a project, a project belongs to the tenant which
+	// created it
+	SaveProject(project Project) error
+	DeleteProject(name string) error
+	Project(name string) (Project, error)
+	// Projects lists the projects of a tenant, of every tenant when empty
+	Projects(tenant string) ([]Project, error)
+
+	// RecordUptime records checks of the availability of applications, the days beyond
+	// the last 90 are dropped
+	RecordUptime(checks []UptimeCheck) error
+	Uptime(application string) (Uptime, error)
+	DeleteUptime(application string) error
+
+	// CreateNamespace records a namespace, ErrAlreadyExists when it exists
+	CreateNamespace(namespace Namespace) error
+	DeleteNamespace(name string) error
+	Namespace(name string) (Namespace, error)
+	Namespaces() ([]Namespace, error)
+}
+
+type MemoryStore struct {
+	mu                sync.RWMutex
+	rollouts          map[string][]Rollout
+	invocations       map[string][]Invocation
+	blueprints        map[string]*blueprintRecord
+	subscriptions     map[string]Subscription
+	dependencies      map[string][]string
+	tenants           map[string]Tenant
+	serviceAccounts   map[string]ServiceAccount // keyed by tenant/name
+	snapshots         map[string][]Snapshot
+	domains           map[string]Domain // keyed by tenant/name
+	deployedImages    map[string]DeployedImage
+	artifacts         map[string][]Artifact
+	jobNames          map[string]JobName // keyed by job ID
+	idempotencyKeys   map[string]IdempotencyKey
+	placements        map[string]PlacementDecision
+	geoRoutes         map[string]GeoRoute
+	usage             map[string][]UsageSample
+	resourceProposals map[string]ResourceProposal
+	searchEntries     map[string]SearchEntry                // keyed by job ID
+	searchIndex       map[string]map[string]map[string]bool // field, then lowercase value, then job IDs
+	revisions         map[string][]Revision                 // keyed by job ID
+	projects          map[string]Project
+	uptime            map[string]Uptime
+	namespaces        map[string]Namespace
+}
+
+// NewMemoryStore creates a store which keeps everything in process memory
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		rollouts:          make(map[string][]Rollout),
+		invocations:       make(map[string][]Invocation),
+		blueprints:        make(map[string]*blueprintRecord),
+		subscriptions:     make(map[string]Subscription),
+		dependencies:      make(map[string][]string),
+		tenants:           make(map[string]Tenant),
+		serviceAccounts:   make(map[string]ServiceAccount),
+		snapshots:         make(map[string][]Snapshot),
+		domains:           make(map[string]Domain),
+		deployedImages:    make(map[string]DeployedImage),
+		artifacts:         make(map[string][]Artifact),
+		jobNames:          make(map[string]JobName),
+		idempotencyKeys:   make(map[string]IdempotencyKey),
+		placements:        make(map[string]PlacementDecision),
+		geoRoutes:         make(map[string]GeoRoute),
+		usage:             make(map[string][]UsageSample),
+		resourceProposals: make(map[string]ResourceProposal),
+		searchEntries:     make(map[string]SearchEntry),
+		searchIndex:       make(map[string]map[string]map[string]bool),
+		revisions:         make(map[string][]Revision),
+		projects:          make(map[string]Project),
+		uptime:            make(map[string]Uptime),
+		namespaces:        make(map[string]Namespace),
+	}
+}
+
+func (m *MemoryStore) SaveRollout(rollout Rollout) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.rollouts[rollout.Application] {
+		if existing.ID == rollout.ID {
+			return nil
+		}
+	}
+
+	rollouts := append(m.rollouts[rollout.Application], rollout)
+	sort.Slice(rollouts, func(i, j int) bool {
+		return rollouts[i].StartedAt.After(rollouts[j].StartedAt)
+	})
+	m.rollouts[rollout.Application] = rollouts
+
+	return nil
+}
+
+func (m *MemoryStore) Rollouts(application string) ([]Rollout, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]Rollout(nil), m.rollouts[application]...), nil
+}
+
+func (m *MemoryStore) SaveInvocation(invocation Invocation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	invocations := append([]Invocation{invocation}, m.invocations[invocation.Function]...)
+	if len(invocations) > maxInvocations {
+		invocations = invocations[:maxInvocations]
+	}
+	m.invocations[invocation.Function] = invocations
+
+	return nil
+}
+
+func (m *MemoryStore) Invocations(function string) ([]Invocation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]Invocation(nil), m.invocations[function]...), nil
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Tenant is a team onboarded onto the platform
+type Tenant struct {
+	Name               string
+	Namespaces         []string // Nomad namespaces owned by the tenant
+	Quota              TenantQuota
+	DomainTemplate     string // e.g. {app}.{tenant}.apps.example.com
+	KeyID              string // KMS key the data key is wrapped with
+	DataKey            []byte // wrapped data key encrypting the tenant's records
+	Security           SecurityDefaults
+	AllowedImages      []string // repositories or prefixes ending in /*, the controller's policy applies when empty
+	RequireAttestation bool     // deployments need a verified provenance attestation of their image
+	NomadAccess        *NomadAccess
+	CreatedAt          time.Time
+}
+
+// NomadAccess is the read-only Nomad token of a tenant, the controller mints its successor
+// before it expires
+type NomadAccess struct {
+	AccessorID string
+	ExpiresAt  time.Time
+	ReadLogs   bool // the token can read the logs and files of allocations
+}
+
+// SecurityDefaults apply to the applications of a tenant which leave them unset
+type SecurityDefaults struct {
+	NoNewPrivileges  bool
+	SeccompProfile   string
+	AppArmorProfile  string
+	DropCapabilities []string
+}
+
+// TenantQuota limits the resources a tenant may deploy
+type TenantQuota struct {
+	CPU             float64 // cores
+	MemoryMB        int64
+	MaxApplications int
+}
+
+// ServiceAccount is a non-human identity of a tenant authenticated by a token
+type ServiceAccount struct {
+	Tenant    string
+	Name      string
+	TokenHash string // SHA-256 of the token, the token itself is never stored
+	CreatedAt time.Time
+}
+
+func (m *MemoryStore) CreateTenant(tenant Tenant) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[tenant.Name]; ok {
+		return fmt.Errorf("tenant %s: %w", tenant.Name, ErrAlreadyExists)
+	}
+	m.tenants[tenant.Name] = tenant
+
+	return nil
+}
+
+func (m *MemoryStore) UpdateTenant(tenant Tenant) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[tenant.Name]; !ok {
+		return fmt.Errorf("tenant %s: %w", tenant.Name, ErrNotFound)
+	}
+	m.tenants[tenant.Name] = tenant
+
+	return nil
+}
+
+func (m *MemoryStore) Tenant(name string) (Tenant, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tenant, ok := m.tenants[name]
+	if !ok {
+		return Tenant{}, fmt.Errorf("tenant %s: %w", name, ErrNotFound)
+	}
+
+	return tenant, nil
+}
+
+func (m *MemoryStore) Tenants() ([]Tenant, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tenants := make([]Tenant, 0, len(m.tenants))
+	for _, tenant := range m.tenants {
+		tenants = append(tenants, tenant)
+	}
+	sort.Slice(tenants, func(i, j int) bool { return tenants[i].Name < tenants[j].Name })
+
+	return tenants, nil
+}
+
+func (m *MemoryStore) SaveServiceAccount(account ServiceAccount) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.serviceAccounts[account.Tenant+"/"+account.Name] = account
+
+	return nil
+}
+
+func (m *MemoryStore) ServiceAccounts(tenant string) ([]ServiceAccount, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var accounts []ServiceAccount
+	for _, account := range m.serviceAccounts {
+		if account.Tenant == tenant {
+			accounts = append(accounts, account)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Name < accounts[j].Name })
+
+	return accounts, nil
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// Uptime is the availability of an application as checked for the status page
+type Uptime struct {
+	Application string
+	Status      string // of the last check: operational, degraded, outage or paused
+	Reason      string
+	CheckedAt   time.Time
+	Days        []UptimeDay // oldest first
+}
+
+// UptimeDay counts the checks of a day, midnight UTC, which found the application up
+type UptimeDay struct {
+	Day    time.Time
+	Checks int
+	Up     int
+}
+
+// UptimeCheck is one check of the availability of an application, paused applications
+// are not counted
+type UptimeCheck struct {
+	Application string
+	Status      string
+	Reason      string
+	Up          bool
+	At          time.Time
+}
+
+// days of uptime kept per application, what status pages show
+const maxUptimeDays = 90
+
+func (m *MemoryStore) RecordUptime(checks []UptimeCheck) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, check := range checks {
+		uptime := m.uptime[check.Application]
+		uptime.Application = check.Application
+		uptime.Status, uptime.Reason, uptime.CheckedAt = check.Status, check.Reason, check.At
+
+		if check.Status != "paused" {
+			day := check.At.UTC().Truncate(24 * time.Hour)
+			if n := len(uptime.Days); n == 0 || !uptime.Days[n-1].Day.Equal(day) {
+				uptime.Days = append(uptime.Days, UptimeDay{Day: day})
+			}
+			today := &uptime.Days[len(uptime.Days)-1]
+			today.Checks++
+			if check.Up {
+				today.Up++
+			}
+			if len(uptime.Days) > maxUptimeDays {
+				uptime.Days = uptime.Days[len(uptime.Days)-maxUptimeDays:]
+			}
+		}
+		m.uptime[check.Application] = uptime
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) Uptime(application string) (Uptime, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	uptime, ok := m.uptime[application]
+	if !ok {
+		return Uptime{}, fmt.Errorf("uptime of %s: %w", application, ErrNotFound)
+	}
+	uptime.Days = append([]UptimeDay(nil), uptime.Days...)
+	return uptime, nil
+}
+
+func (m *MemoryStore) DeleteUptime(application string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.uptime, application)
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// UsageSample is the resource usage of an application's task in one of its allocations
+type UsageSample struct {
+	Application  string
+	AllocationID string
+	CPU          float64 // MHz
+	MemoryMB     int64
+	At           time.Time
+}
+
+// ResourceProposal is a resource update recommended for an application awaiting approval
+type ResourceProposal struct {
+	Application string
+	CPU         float64 // cores like specs
+	MemoryMB    int64
+	ProposedAt  time.Time
+}
+
+// usage samples kept per application by the memory store, two weeks of one allocation
+// sampled every 5 minutes
+const maxUsageSamples = 4032
+
+func (m *MemoryStore) SaveUsage(samples []UsageSample) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, sample := range samples {
+		usage := append(m.usage[sample.Application], sample)
+		if len(usage) > maxUsageSamples {
+			usage = usage[len(usage)-maxUsageSamples:]
+		}
+		m.usage[sample.Application] = usage
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) Usage(application string, since time.Time) ([]UsageSample, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	usage := m.usage[application]
+	first := sort.Search(len(usage), func(i int) bool {
+		return !usage[i].At.Before(since)
+	})
+
+	return append([]UsageSample(nil), usage[first:]...), nil
+}
+
+func (m *MemoryStore) DeleteUsage(application string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.usage, application)
+	delete(m.resourceProposals, application)
+
+	return nil
+}
+
+func (m *MemoryStore) SaveResourceProposal(proposal ResourceProposal) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.resourceProposals[proposal.Application] = proposal
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteResourceProposal(application string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.resourceProposals, application)
+
+	return nil
+}
+
+func (m *MemoryStore) ResourceProposal(application string) (ResourceProposal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	proposal, ok := m.resourceProposals[application]
+	if !ok {
+		return ResourceProposal{}, fmt.Errorf("resource proposal of %s: %w", application, ErrNotFound)
+	}
+
+	return proposal, nil
+}
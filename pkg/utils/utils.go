@@ -1,5 +1,7 @@
 package utils
 
+import "time"
+
 func IntPtr(i int) *int {
 	return &i
 }
@@ -7,3 +9,11 @@ func IntPtr(i int) *int {
 func StringPtr(s string) *string {
 	return &s
 }
+
+func BoolPtr(b bool) *bool {
+	return &b
+}
+
+func DurationPtr(d time.Duration) *time.Duration {
+	return &d
+}